@@ -15,6 +15,12 @@
 
 set -euo pipefail
 
+# Respect per-project overrides in .claude/hooks.json
+source "$(dirname "${BASH_SOURCE[0]}")/project-overrides.sh"
+if crew_hook_disabled backup-before-change; then
+    exit 0
+fi
+
 # Configuration
 BACKUP_DIR="${SUPERCREW_BACKUP_DIR:-.claude/backups}"
 BACKUP_DAYS="${SUPERCREW_BACKUP_DAYS:-7}"
@@ -14,6 +14,12 @@
 
 set -euo pipefail
 
+# Respect per-project overrides in .claude/hooks.json
+source "$(dirname "${BASH_SOURCE[0]}")/project-overrides.sh"
+if crew_hook_disabled git-auto-commit; then
+    exit 0
+fi
+
 # Check if auto-commit is enabled
 if [[ "${SUPERCREW_GIT_AUTO_COMMIT:-true}" == "false" ]]; then
     exit 0
@@ -15,6 +15,12 @@
 
 set -euo pipefail
 
+# Respect per-project overrides in .claude/hooks.json
+source "$(dirname "${BASH_SOURCE[0]}")/project-overrides.sh"
+if crew_hook_disabled lint-on-save; then
+    exit 0
+fi
+
 # Configuration
 AUTOFIX="${SUPERCREW_LINT_AUTOFIX:-false}"
 QUIET="${SUPERCREW_LINT_QUIET:-false}"
@@ -0,0 +1,23 @@
+#!/bin/bash
+#
+# Claude Code Super Crew - Project Overrides
+#
+# Shared by the crew hooks, which source it; it is not a hook itself.
+#
+
+# crew_hook_disabled NAME succeeds when the project's .claude/hooks.json
+# disables the hook NAME. Without jq the file is matched as text, which
+# only understands the layout crew writes, so a warning is printed.
+crew_hook_disabled() {
+    local name="$1"
+    local overrides="${CLAUDE_PROJECT_DIR:-$(pwd)}/.claude/hooks.json"
+    [[ -f "$overrides" ]] || return 1
+
+    if command -v jq >/dev/null 2>&1; then
+        [[ "$(jq -r --arg name "$name" '.hooks[$name].enabled' "$overrides" 2>/dev/null)" == "false" ]]
+        return
+    fi
+
+    echo "crew: jq not found, reading $overrides without it" >&2
+    tr -d ' \t\r\n' < "$overrides" | grep -q "\"$name\":{[^{}]*\"enabled\":false"
+}
@@ -15,6 +15,12 @@
 
 set -euo pipefail
 
+# Respect per-project overrides in .claude/hooks.json
+source "$(dirname "${BASH_SOURCE[0]}")/project-overrides.sh"
+if crew_hook_disabled security-scan; then
+    exit 0
+fi
+
 # Configuration
 BLOCK="${SUPERCREW_SECURITY_BLOCK:-true}"
 LEVEL="${SUPERCREW_SECURITY_LEVEL:-medium}"
@@ -15,6 +15,12 @@
 
 set -euo pipefail
 
+# Respect per-project overrides in .claude/hooks.json
+source "$(dirname "${BASH_SOURCE[0]}")/project-overrides.sh"
+if crew_hook_disabled test-on-change; then
+    exit 0
+fi
+
 # Configuration
 TEST_PATTERN="${SUPERCREW_TEST_PATTERN:-auto}"
 COVERAGE="${SUPERCREW_TEST_COVERAGE:-false}"
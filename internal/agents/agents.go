@@ -0,0 +1,162 @@
+// Package agents reads the agent definitions crew installs globally and the
+// ones a project adds in .claude/agents, and indexes their activation
+// keywords to explain which agents a request routes to.
+package agents
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Scopes of an agent definition
+const (
+	Global  = "global"
+	Project = "project"
+)
+
+// Keywords are the activation keywords of an agent, strongest first
+type Keywords struct {
+	Primary    []string `yaml:"primary" json:"primary,omitempty"`
+	Secondary  []string `yaml:"secondary" json:"secondary,omitempty"`
+	Contextual []string `yaml:"contextual" json:"contextual,omitempty"`
+}
+
+// Empty reports whether no keywords are declared
+func (k Keywords) Empty() bool {
+	return len(k.Primary) == 0 && len(k.Secondary) == 0 && len(k.Contextual) == 0
+}
+
+// Agent is the frontmatter of an agent definition
+type Agent struct {
+	Name        string   `yaml:"name" json:"name"`
+	Description string   `yaml:"description" json:"description,omitempty"`
+	Keywords    Keywords `yaml:"activation_keywords" json:"activation_keywords"`
+	// Created is the date a generated specialist was written
+	Created string `yaml:"created" json:"created,omitempty"`
+	// SourcePatterns are the files whose presence justified generating
+	// the agent for the project
+	SourcePatterns []string `yaml:"source_patterns" json:"source_patterns,omitempty"`
+	// Tools and Model are what Claude Code grants and runs the subagent with
+	Tools ToolList `yaml:"tools" json:"tools,omitempty"`
+	Model string   `yaml:"model" json:"model,omitempty"`
+	// Path and Scope say where the definition was read from
+	Path  string `yaml:"-" json:"path"`
+	Scope string `yaml:"-" json:"scope"`
+	// Named is false when the frontmatter has no name and Name is taken
+	// from the file; Size is the file's length in bytes
+	Named bool `yaml:"-" json:"-"`
+	Size  int  `yaml:"-" json:"-"`
+}
+
+// ParseError is an agent definition that could not be read
+type ParseError struct {
+	Path string
+	Err  error
+}
+
+func (e *ParseError) Error() string { return e.Err.Error() }
+
+func (e *ParseError) Unwrap() error { return e.Err }
+
+// ParseFile reads the frontmatter of the agent definition at path. An
+// agent without a name is named after its file.
+func ParseFile(path string) (*Agent, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, &ParseError{Path: path, Err: err}
+	}
+	return parse(path, data)
+}
+
+// parse reads the agent definition data of the file at path
+func parse(path string, data []byte) (*Agent, error) {
+	front, ok := frontmatter(data)
+	if !ok {
+		return nil, &ParseError{Path: path, Err: fmt.Errorf("%s has no frontmatter", path)}
+	}
+
+	var agent Agent
+	if err := yaml.Unmarshal(front, &agent); err != nil {
+		return nil, &ParseError{Path: path, Err: fmt.Errorf("invalid frontmatter in %s: %w", path, err)}
+	}
+	agent.Named = agent.Name != ""
+	if !agent.Named {
+		agent.Name = strings.TrimSuffix(filepath.Base(path), ".md")
+	}
+	agent.Path = path
+	agent.Size = len(data)
+	return &agent, nil
+}
+
+// frontmatter returns the YAML between the leading --- lines of data
+func frontmatter(data []byte) ([]byte, bool) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	if !bytes.HasPrefix(data, []byte("---")) {
+		return nil, false
+	}
+	rest := data[3:]
+	end := bytes.Index(rest, []byte("\n---"))
+	if end < 0 {
+		return nil, false
+	}
+	return rest[:end], true
+}
+
+// LoadDir reads the agent definitions directly in dir, skipping templates
+// and files that do not parse. A missing directory has no agents.
+func LoadDir(dir, scope string) ([]*Agent, []error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, []error{err}
+	}
+
+	var agents []*Agent
+	var problems []error
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".md") {
+			continue
+		}
+		agent, err := ParseFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			problems = append(problems, err)
+			continue
+		}
+		agent.Scope = scope
+		agents = append(agents, agent)
+	}
+	return agents, problems
+}
+
+// Load reads the global agents in globalDir and the project agents in
+// projectDir. A project agent replaces the global one of the same name,
+// as it does when Claude Code resolves agents.
+func Load(globalDir, projectDir string) ([]*Agent, []error) {
+	byName := make(map[string]*Agent)
+	var problems []error
+	for _, source := range []struct{ dir, scope string }{{globalDir, Global}, {projectDir, Project}} {
+		if source.dir == "" {
+			continue
+		}
+		agents, errs := LoadDir(source.dir, source.scope)
+		problems = append(problems, errs...)
+		for _, agent := range agents {
+			byName[agent.Name] = agent
+		}
+	}
+
+	agents := make([]*Agent, 0, len(byName))
+	for _, agent := range byName {
+		agents = append(agents, agent)
+	}
+	sort.Slice(agents, func(i, j int) bool { return agents[i].Name < agents[j].Name })
+	return agents, problems
+}
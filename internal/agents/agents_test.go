@@ -0,0 +1,229 @@
+package agents
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeAgent(t *testing.T, dir, name, frontmatter string) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	content := "---\nname: " + name + "\n" + frontmatter + "---\n\n# " + name + "\n"
+	if err := os.WriteFile(filepath.Join(dir, name+".md"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTerms(t *testing.T) {
+	got := Terms("Fix the flaky integration-tests; optimizing queries & services")
+	want := []string{"fix", "the", "flaky", "integration", "test", "optimiz", "query", "servic"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Terms() = %v, want %v", got, want)
+	}
+	for _, word := range []string{"optimize", "optimized", "optimizes"} {
+		if got := Terms(word); got[0] != "optimiz" {
+			t.Errorf("Terms(%q) = %v, want [optimiz]", word, got)
+		}
+	}
+}
+
+func TestLoadPrefersProjectAgents(t *testing.T) {
+	root := t.TempDir()
+	global := filepath.Join(root, "global")
+	project := filepath.Join(root, "project")
+	writeAgent(t, global, "qa-persona", "activation_keywords:\n  primary: [test]\n")
+	writeAgent(t, global, "scribe-persona", "")
+	writeAgent(t, project, "qa-persona", "activation_keywords:\n  primary: [flaky]\n")
+	writeAgent(t, filepath.Join(global, "templates"), "generic-persona-template", "")
+	if err := os.WriteFile(filepath.Join(global, "broken.md"), []byte("no frontmatter"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	agents, problems := Load(global, project)
+	if len(problems) != 1 {
+		t.Errorf("expected one problem for broken.md, got %v", problems)
+	}
+	if len(agents) != 2 {
+		t.Fatalf("expected 2 agents, got %d", len(agents))
+	}
+	qa := agents[0]
+	if qa.Name != "qa-persona" || qa.Scope != Project || !reflect.DeepEqual(qa.Keywords.Primary, []string{"flaky"}) {
+		t.Errorf("expected the project qa-persona, got %+v", qa)
+	}
+	if agents[1].Scope != Global || !agents[1].Keywords.Empty() {
+		t.Errorf("expected the global scribe-persona without keywords, got %+v", agents[1])
+	}
+}
+
+func TestMatchRanksAgents(t *testing.T) {
+	dir := t.TempDir()
+	writeAgent(t, dir, "qa-persona", "activation_keywords:\n  primary: [test, quality]\n  secondary: [edge case, regression]\n  contextual: [flaky, integration]\n")
+	writeAgent(t, dir, "analyzer-persona", "activation_keywords:\n  primary: [investigate]\n  secondary: [debug]\n  contextual: [bug, flaky]\n")
+	writeAgent(t, dir, "scribe-persona", "activation_keywords:\n  primary: [document]\n")
+	agents, _ := LoadDir(dir, Global)
+	index := NewIndex(agents)
+
+	matches := index.Match("Fix the flaky integration tests, the tests fail on an edge case")
+	if len(matches) != 2 {
+		t.Fatalf("expected 2 matches, got %+v", matches)
+	}
+
+	qa := matches[0]
+	if qa.Agent.Name != "qa-persona" || !qa.Activates {
+		t.Errorf("expected qa-persona to rank first and activate, got %+v", qa)
+	}
+	// test counts once however often it appears
+	if want := 1.0 + 0.6 + 0.3 + 0.3; qa.Score < want-0.001 || qa.Score > want+0.001 {
+		t.Errorf("qa-persona score = %v, want %v", qa.Score, want)
+	}
+	if qa.Hits[0].Keyword != "test" || qa.Hits[1].Keyword != "edge case" {
+		t.Errorf("expected hits ordered by weight, got %+v", qa.Hits)
+	}
+
+	analyzer := matches[1]
+	if analyzer.Agent.Name != "analyzer-persona" || analyzer.Activates {
+		t.Errorf("expected analyzer-persona below the threshold, got %+v", analyzer)
+	}
+
+	// a multi-word keyword only matches as a phrase
+	for _, match := range index.Match("an edge in one case") {
+		for _, hit := range match.Hits {
+			if hit.Keyword == "edge case" {
+				t.Errorf("edge case matched words that are not adjacent")
+			}
+		}
+	}
+}
+
+func TestMatchPattern(t *testing.T) {
+	for _, tt := range []struct {
+		pattern, path string
+		want          bool
+	}{
+		{"go.mod", "go.mod", true},
+		{"**/*_test.go", "a_test.go", true},
+		{"**/*_test.go", "pkg/x/a_test.go", true},
+		{"**/migrations/**", "db/migrations/001.sql", true},
+		{"src/**/*.tsx", "src/App.tsx", true},
+		{"src/**/*.tsx", "lib/App.tsx", false},
+		{"k8s/*.yaml", "k8s/base/app.yaml", false},
+	} {
+		if got := MatchPattern(tt.pattern, tt.path); got != tt.want {
+			t.Errorf("MatchPattern(%q, %q) = %v, want %v", tt.pattern, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestTranscriptDir(t *testing.T) {
+	if got, want := TranscriptDir("/home/u/.claude", "/home/u/src/my.app"), filepath.Join("/home/u/.claude", "projects", "-home-u-src-my-app"); got != want {
+		t.Errorf("TranscriptDir() = %q, want %q", got, want)
+	}
+}
+
+func TestLifecycles(t *testing.T) {
+	root := t.TempDir()
+	projectDir := filepath.Join(root, "project")
+	agentsDir := filepath.Join(projectDir, ".claude", "agents")
+	writeAgent(t, agentsDir, "go-backend-specialist", "created: 2025-07-30\nsource_patterns: [go.mod]\n")
+	writeAgent(t, agentsDir, "database-specialist", "created: \"2025-07-30\"\n")
+	writeAgent(t, agentsDir, "docs-specialist", "")
+	writeAgent(t, agentsDir, "orchestrator-specialist", "")
+	if err := os.WriteFile(filepath.Join(projectDir, "go.mod"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	transcripts := filepath.Join(root, "transcripts")
+	if err := os.MkdirAll(transcripts, 0755); err != nil {
+		t.Fatal(err)
+	}
+	transcript := `{"timestamp":"2026-10-01T10:00:00Z","message":{"content":[{"type":"tool_use","name":"Task","input":{"subagent_type":"go-backend-specialist"}}]}}
+{"timestamp":"2026-10-10T10:00:00Z","message":{"content":[{"type":"tool_use","name":"Task","input":{"subagent_type":"go-backend-specialist"}}]}}
+{"timestamp":"2026-09-01T10:00:00Z","message":{"content":"the go-backend-specialist agent"}}
+`
+	if err := os.WriteFile(filepath.Join(transcripts, "session.jsonl"), []byte(transcript), 0644); err != nil {
+		t.Fatal(err)
+	}
+	usage, err := LoadUsage(transcripts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, _ := Load("", agentsDir)
+	sources := func(name string) []string {
+		if name == "database-specialist" {
+			return []string{"**/migrations/**", "schema.sql"}
+		}
+		return nil
+	}
+	lifecycles := Lifecycles(projectDir, loaded, usage, sources)
+	if len(lifecycles) != 3 {
+		t.Fatalf("expected 3 specialists without the orchestrator, got %+v", lifecycles)
+	}
+	byName := make(map[string]Lifecycle)
+	for _, l := range lifecycles {
+		byName[l.Name] = l
+	}
+
+	goBackend := byName["go-backend-specialist"]
+	if goBackend.Status != StatusActive || goBackend.Uses != 2 || !goBackend.LastUsed.Equal(time.Date(2026, 10, 10, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected go-backend-specialist lifecycle %+v", goBackend)
+	}
+	if !goBackend.CreatedAt.Equal(time.Date(2025, 7, 30, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected the created date of the frontmatter, got %v", goBackend.CreatedAt)
+	}
+	if db := byName["database-specialist"]; db.Status != StatusStale || db.Justified() {
+		t.Errorf("expected database-specialist to be stale, got %+v", db)
+	}
+	if docs := byName["docs-specialist"]; docs.Status != StatusUnused || !docs.Justified() {
+		t.Errorf("expected docs-specialist without patterns to be unused, got %+v", docs)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	global, project := t.TempDir(), t.TempDir()
+	writeAgent(t, global, "qa-persona", "description: Tests\ntools: Read, Grep, mcp__context7__get-library-docs\n")
+	writeAgent(t, global, "orchestrator-specialist", "description: Routes\n")
+	writeAgent(t, project, "orchestrator-specialist", "description: Routes\ntools: [Read, Task]\nmodel: inherit\n")
+	writeAgent(t, project, "qa-persona", "description: Project tests\n")
+	writeAgent(t, project, "Bad_Name", "description: Bad\ntools: [Read, Frobnicate, Read]\nmodel: gpt\n")
+	writeAgent(t, project, "api-specialist", "")
+	if err := os.WriteFile(filepath.Join(project, "api-copy.md"), []byte("---\nname: api-specialist\ndescription: Copy\n---\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(project, "broken.md"), []byte("# no frontmatter\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, issue := range Validate(global, project) {
+		got = append(got, filepath.Base(issue.Path)+" "+issue.Severity+": "+issue.Message)
+	}
+	want := []string{
+		`Bad_Name.md error: name "Bad_Name" must be lowercase letters, digits and hyphens`,
+		`Bad_Name.md error: unknown tool "Frobnicate"`,
+		`Bad_Name.md warning: tool Read is listed twice`,
+		`Bad_Name.md error: unknown model "gpt" (expected haiku, inherit, opus, sonnet)`,
+		`api-specialist.md error: description is missing`,
+		`api-specialist.md error: project agent name is also used by ` + filepath.Join(project, "api-copy.md"),
+		`broken.md error: ` + filepath.Join(project, "broken.md") + ` has no frontmatter`,
+		`qa-persona.md warning: overrides the global agent ` + filepath.Join(global, "qa-persona.md"),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("issues:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if !HasErrors(Validate(global, project)) {
+		t.Error("expected errors")
+	}
+}
+
+func TestShippedAgentsAreValid(t *testing.T) {
+	for _, issue := range Validate(filepath.Join("..", "..", "SuperCrew", "agents"), "") {
+		t.Errorf("%s: %s", issue.Path, issue.Message)
+	}
+}
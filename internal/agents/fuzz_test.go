@@ -0,0 +1,29 @@
+package agents
+
+import (
+	"errors"
+	"testing"
+)
+
+func FuzzParse(f *testing.F) {
+	f.Add("---\nname: qa-persona\ndescription: Tests\nactivation_keywords:\n  primary: [test]\ntools: Read, Grep\n---\n\n# QA\n")
+	f.Add("\xef\xbb\xbf---\nname: bom\n---\n")
+	f.Add("---\ntools: [Read, [Grep]]\nmodel: {}\n---")
+	f.Add("---\n&a [*a]\n---\n")
+	f.Add("---")
+	f.Fuzz(func(t *testing.T, content string) {
+		agent, err := parse("/agents/fuzz.md", []byte(content))
+		if err != nil {
+			var parseErr *ParseError
+			if !errors.As(err, &parseErr) || parseErr.Path != "/agents/fuzz.md" {
+				t.Fatalf("error %v is not a ParseError for the file", err)
+			}
+			return
+		}
+		if agent.Name == "" || agent.Size != len(content) {
+			t.Fatalf("parsed agent %+v", agent)
+		}
+		ValidateAgent(agent)
+		NewIndex([]*Agent{agent})
+	})
+}
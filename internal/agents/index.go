@@ -0,0 +1,183 @@
+package agents
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Keyword tiers and the weight a match in each adds to an agent's score
+const (
+	Primary    = "primary"
+	Secondary  = "secondary"
+	Contextual = "contextual"
+)
+
+var tierWeights = map[string]float64{Primary: 1.0, Secondary: 0.6, Contextual: 0.3}
+
+// ActivationThreshold is the score at which the orchestrator activates an
+// agent: one primary keyword, or two weaker ones
+const ActivationThreshold = 0.9
+
+// Hit is one keyword of an agent found in a request
+type Hit struct {
+	Keyword string  `json:"keyword"`
+	Tier    string  `json:"tier"`
+	Weight  float64 `json:"weight"`
+}
+
+// Match is an agent whose keywords a request contains
+type Match struct {
+	Agent     *Agent  `json:"agent"`
+	Score     float64 `json:"score"`
+	Activates bool    `json:"activates"`
+	Hits      []Hit   `json:"hits"`
+}
+
+// posting is a keyword of an agent, stored under the keyword's first term
+type posting struct {
+	agent   *Agent
+	keyword string
+	terms   []string
+	tier    string
+}
+
+// Index maps the terms of activation keywords to the agents declaring them
+type Index struct {
+	postings map[string][]posting
+	agents   []*Agent
+}
+
+// NewIndex builds the inverted index of the agents' activation keywords
+func NewIndex(agents []*Agent) *Index {
+	index := &Index{postings: make(map[string][]posting), agents: agents}
+	for _, agent := range agents {
+		for _, tier := range []struct {
+			name     string
+			keywords []string
+		}{{Primary, agent.Keywords.Primary}, {Secondary, agent.Keywords.Secondary}, {Contextual, agent.Keywords.Contextual}} {
+			for _, keyword := range tier.keywords {
+				terms := Terms(keyword)
+				if len(terms) == 0 {
+					continue
+				}
+				index.postings[terms[0]] = append(index.postings[terms[0]], posting{agent, keyword, terms, tier.name})
+			}
+		}
+	}
+	return index
+}
+
+// Agents returns the indexed agents
+func (ix *Index) Agents() []*Agent {
+	return ix.agents
+}
+
+// Match scores every agent with a keyword in request, best first. Each
+// keyword counts once however often it appears; a keyword declared in
+// several tiers counts in its strongest.
+func (ix *Index) Match(request string) []Match {
+	terms := Terms(request)
+	best := make(map[*Agent]map[string]Hit)
+
+	for i, term := range terms {
+		for _, p := range ix.postings[term] {
+			if !hasPhrase(terms[i:], p.terms) {
+				continue
+			}
+			hits := best[p.agent]
+			if hits == nil {
+				hits = make(map[string]Hit)
+				best[p.agent] = hits
+			}
+			key := strings.Join(p.terms, " ")
+			weight := tierWeights[p.tier]
+			if hit, ok := hits[key]; !ok || hit.Weight < weight {
+				hits[key] = Hit{Keyword: p.keyword, Tier: p.tier, Weight: weight}
+			}
+		}
+	}
+
+	matches := make([]Match, 0, len(best))
+	for agent, hits := range best {
+		match := Match{Agent: agent}
+		for _, hit := range hits {
+			match.Hits = append(match.Hits, hit)
+			match.Score += hit.Weight
+		}
+		sort.Slice(match.Hits, func(i, j int) bool {
+			if match.Hits[i].Weight != match.Hits[j].Weight {
+				return match.Hits[i].Weight > match.Hits[j].Weight
+			}
+			return match.Hits[i].Keyword < match.Hits[j].Keyword
+		})
+		match.Activates = match.Score >= ActivationThreshold
+		matches = append(matches, match)
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return matches[i].Agent.Name < matches[j].Agent.Name
+	})
+	return matches
+}
+
+func hasPhrase(terms, phrase []string) bool {
+	if len(terms) < len(phrase) {
+		return false
+	}
+	for i, term := range phrase {
+		if terms[i] != term {
+			return false
+		}
+	}
+	return true
+}
+
+// Terms splits text into lowercase words reduced to a rough stem, so that
+// "tests", "testing" and "tested" all match the keyword "test"
+func Terms(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, word := range words {
+		words[i] = stem(word)
+	}
+	return words
+}
+
+// stem strips one common English suffix from words long enough to keep a
+// meaningful root, then a final e so that "optimize" and "optimizing" meet
+func stem(word string) string {
+	word = stripSuffix(word)
+	if len(word) > 4 && strings.HasSuffix(word, "e") {
+		return strings.TrimSuffix(word, "e")
+	}
+	return word
+}
+
+func stripSuffix(word string) string {
+	for _, suffix := range []string{"ing", "ies", "ed", "es", "s"} {
+		if !strings.HasSuffix(word, suffix) || len(word)-len(suffix) < 3 {
+			continue
+		}
+		root := strings.TrimSuffix(word, suffix)
+		switch suffix {
+		case "ies":
+			return root + "y"
+		case "es":
+			// "fixes", "caches" but not "services"
+			if strings.HasSuffix(root, "x") || strings.HasSuffix(root, "ch") || strings.HasSuffix(root, "sh") || strings.HasSuffix(root, "ss") {
+				return root
+			}
+			continue
+		case "s":
+			if strings.HasSuffix(root, "s") {
+				return word
+			}
+		}
+		return root
+	}
+	return word
+}
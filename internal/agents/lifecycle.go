@@ -0,0 +1,267 @@
+package agents
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Lifecycle states of a project specialist
+const (
+	// StatusActive specialists were invoked and are still justified
+	StatusActive = "active"
+	// StatusUnused specialists are justified but were never invoked
+	StatusUnused = "unused"
+	// StatusStale specialists no longer match any of their source patterns
+	StatusStale = "stale"
+)
+
+// IsSpecialist reports whether agent is a specialist generated for the
+// project; the orchestrator specialist is part of every integration
+func IsSpecialist(agent *Agent) bool {
+	return agent.Scope == Project && strings.HasSuffix(agent.Name, "-specialist") && agent.Name != "orchestrator-specialist"
+}
+
+// Lifecycle is when a specialist was created and last used, and whether
+// the project still has the files that justified it
+type Lifecycle struct {
+	Name      string     `json:"name"`
+	Path      string     `json:"path"`
+	CreatedAt time.Time  `json:"created_at"`
+	LastUsed  *time.Time `json:"last_used,omitempty"`
+	Uses      int        `json:"uses"`
+	// SourcePatterns are the patterns that justified the specialist and
+	// Matched the ones that still match a file
+	SourcePatterns []string `json:"source_patterns,omitempty"`
+	Matched        []string `json:"matched,omitempty"`
+	Status         string   `json:"status"`
+}
+
+// Justified reports whether the specialist has no source patterns to check
+// or still matches one
+func (l *Lifecycle) Justified() bool {
+	return len(l.SourcePatterns) == 0 || len(l.Matched) > 0
+}
+
+// Usage counts the Task invocations of an agent
+type Usage struct {
+	Count int
+	Last  time.Time
+}
+
+// Lifecycles reports on the specialists among agents in the project in
+// projectDir. sources supplies patterns for specialists whose frontmatter
+// declares none, e.g. ones generated before crew recorded them.
+func Lifecycles(projectDir string, agents []*Agent, usage map[string]Usage, sources func(name string) []string) []Lifecycle {
+	var lifecycles []Lifecycle
+	for _, agent := range agents {
+		if !IsSpecialist(agent) {
+			continue
+		}
+		l := Lifecycle{Name: agent.Name, Path: agent.Path, CreatedAt: createdAt(agent)}
+		if use, ok := usage[agent.Name]; ok {
+			last := use.Last
+			l.LastUsed = &last
+			l.Uses = use.Count
+		}
+
+		l.SourcePatterns = agent.SourcePatterns
+		if len(l.SourcePatterns) == 0 && sources != nil {
+			l.SourcePatterns = sources(agent.Name)
+		}
+		l.Matched = MatchingPatterns(projectDir, l.SourcePatterns)
+
+		switch {
+		case !l.Justified():
+			l.Status = StatusStale
+		case l.Uses > 0:
+			l.Status = StatusActive
+		default:
+			l.Status = StatusUnused
+		}
+		lifecycles = append(lifecycles, l)
+	}
+	return lifecycles
+}
+
+// createdAt returns the created date of the frontmatter, or the time the
+// file was last written
+func createdAt(agent *Agent) time.Time {
+	for _, layout := range []string{"2006-01-02", time.RFC3339} {
+		if t, err := time.Parse(layout, agent.Created); err == nil {
+			return t
+		}
+	}
+	if info, err := os.Stat(agent.Path); err == nil {
+		return info.ModTime()
+	}
+	return time.Time{}
+}
+
+// TranscriptDir returns where Claude Code keeps the session transcripts of
+// the project in projectDir: every character of the path that is not a
+// letter or digit becomes a dash
+func TranscriptDir(claudeHome, projectDir string) string {
+	encoded := []rune(projectDir)
+	for i, r := range encoded {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9') {
+			encoded[i] = '-'
+		}
+	}
+	return filepath.Join(claudeHome, "projects", string(encoded))
+}
+
+var subagentPattern = regexp.MustCompile(`"subagent_type"\s*:\s*"([^"]+)"`)
+
+// LoadUsage counts the Task invocations of each agent in the session
+// transcripts in dir. A transcript entry without a timestamp is dated by
+// its file. A missing directory means no agent was used.
+func LoadUsage(dir string) (map[string]Usage, error) {
+	usage := make(map[string]Usage)
+	files, err := filepath.Glob(filepath.Join(dir, "*.jsonl"))
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range files {
+		if err := scanTranscript(path, usage); err != nil {
+			return nil, err
+		}
+	}
+	return usage, nil
+}
+
+func scanTranscript(path string, usage map[string]Usage) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	reader := bufio.NewReader(file)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if bytes.Contains(line, []byte(`"subagent_type"`)) {
+			when := info.ModTime()
+			var entry struct {
+				Timestamp string `json:"timestamp"`
+			}
+			if json.Unmarshal(line, &entry) == nil {
+				if t, err := time.Parse(time.RFC3339Nano, entry.Timestamp); err == nil {
+					when = t
+				}
+			}
+			for _, match := range subagentPattern.FindAllSubmatch(line, -1) {
+				name := string(match[1])
+				use := usage[name]
+				use.Count++
+				if when.After(use.Last) {
+					use.Last = when
+				}
+				usage[name] = use
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// skippedDirs are never searched for source patterns
+var skippedDirs = map[string]bool{".git": true, ".claude": true, "node_modules": true, "vendor": true}
+
+// MatchingPatterns returns the patterns that match a file or directory in
+// root. Patterns are slash-separated paths relative to root where * matches
+// within a path element and ** any number of elements.
+func MatchingPatterns(root string, patterns []string) []string {
+	matched := make(map[string]bool)
+	var globs []string
+	for _, pattern := range patterns {
+		if !strings.ContainsAny(pattern, "*?[") {
+			if _, err := os.Stat(filepath.Join(root, filepath.FromSlash(pattern))); err == nil {
+				matched[pattern] = true
+			}
+			continue
+		}
+		globs = append(globs, pattern)
+	}
+
+	if len(globs) > 0 {
+		filepath.WalkDir(root, func(path string, entry os.DirEntry, err error) error {
+			if err != nil || path == root {
+				return nil
+			}
+			if entry.IsDir() && skippedDirs[entry.Name()] {
+				return filepath.SkipDir
+			}
+			rel, err := filepath.Rel(root, path)
+			if err != nil {
+				return nil
+			}
+			remaining := 0
+			for _, glob := range globs {
+				if matched[glob] {
+					continue
+				}
+				if MatchPattern(glob, filepath.ToSlash(rel)) {
+					matched[glob] = true
+				} else {
+					remaining++
+				}
+			}
+			if remaining == 0 {
+				return filepath.SkipAll
+			}
+			return nil
+		})
+	}
+
+	var result []string
+	for _, pattern := range patterns {
+		if matched[pattern] {
+			result = append(result, pattern)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// MatchPattern reports whether the slash-separated path matches pattern,
+// where ** matches zero or more path elements
+func MatchPattern(pattern, path string) bool {
+	return matchElements(strings.Split(pattern, "/"), strings.Split(path, "/"))
+}
+
+func matchElements(pattern, path []string) bool {
+	if len(pattern) == 0 {
+		return len(path) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(path); i++ {
+			if matchElements(pattern[1:], path[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(path) == 0 {
+		return false
+	}
+	if ok, err := filepath.Match(pattern[0], path[0]); err != nil || !ok {
+		return false
+	}
+	return matchElements(pattern[1:], path[1:])
+}
@@ -0,0 +1,193 @@
+package agents
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Severities of a validation issue
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// Limits Claude Code places on subagent definitions
+const (
+	// MaxFileSize is the largest agent file, in bytes; the whole file
+	// becomes the subagent's system prompt
+	MaxFileSize = 64 * 1024
+	// MaxDescriptionLength is the longest description, which Claude Code
+	// reads to decide when to delegate to the agent
+	MaxDescriptionLength = 1024
+)
+
+// KnownTools are the built-in tools a subagent may be granted. MCP tools
+// are named mcp__<server>__<tool>.
+var KnownTools = []string{
+	"Bash", "Edit", "Glob", "Grep", "LS", "MultiEdit", "NotebookEdit",
+	"NotebookRead", "Read", "Task", "TodoWrite", "WebFetch", "WebSearch", "Write",
+}
+
+// KnownModels are the values the model field accepts
+var KnownModels = []string{"haiku", "inherit", "opus", "sonnet"}
+
+var (
+	agentNamePattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+	mcpToolPattern   = regexp.MustCompile(`^mcp__[A-Za-z0-9_-]+__[A-Za-z0-9_-]+$`)
+)
+
+// sharedNames are agents every integrated project overrides on purpose:
+// crew installs the orchestrator specialist globally as a template and
+// again into each project
+var sharedNames = map[string]bool{"orchestrator-specialist": true}
+
+// ToolList is the tools field, written as a YAML list or as a comma
+// separated string
+type ToolList []string
+
+// UnmarshalYAML accepts both forms of the tools field
+func (t *ToolList) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*t = nil
+		for _, tool := range strings.Split(node.Value, ",") {
+			if tool = strings.TrimSpace(tool); tool != "" {
+				*t = append(*t, tool)
+			}
+		}
+		return nil
+	}
+	var tools []string
+	if err := node.Decode(&tools); err != nil {
+		return err
+	}
+	*t = tools
+	return nil
+}
+
+// Issue is a problem with an agent definition
+type Issue struct {
+	Path     string `json:"path"`
+	Agent    string `json:"agent,omitempty"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+// ValidateAgent checks the frontmatter and size of a parsed agent against
+// what Claude Code accepts for a subagent
+func ValidateAgent(agent *Agent) []Issue {
+	var issues []Issue
+	report := func(severity, format string, args ...interface{}) {
+		issues = append(issues, Issue{Path: agent.Path, Agent: agent.Name, Severity: severity, Message: fmt.Sprintf(format, args...)})
+	}
+
+	switch {
+	case !agent.Named:
+		report(SeverityError, "name is missing")
+	case !agentNamePattern.MatchString(agent.Name):
+		report(SeverityError, "name %q must be lowercase letters, digits and hyphens", agent.Name)
+	}
+
+	description := strings.TrimSpace(agent.Description)
+	switch {
+	case description == "":
+		report(SeverityError, "description is missing")
+	case len(description) > MaxDescriptionLength:
+		report(SeverityError, "description is %d characters, more than %d", len(description), MaxDescriptionLength)
+	}
+
+	seen := make(map[string]bool)
+	for _, tool := range agent.Tools {
+		switch {
+		case seen[tool]:
+			report(SeverityWarning, "tool %s is listed twice", tool)
+		case !isKnownTool(tool):
+			report(SeverityError, "unknown tool %q", tool)
+		}
+		seen[tool] = true
+	}
+
+	if agent.Model != "" && !contains(KnownModels, agent.Model) {
+		report(SeverityError, "unknown model %q (expected %s)", agent.Model, strings.Join(KnownModels, ", "))
+	}
+
+	if agent.Size > MaxFileSize {
+		report(SeverityError, "file is %d KiB, more than %d KiB", agent.Size/1024, MaxFileSize/1024)
+	}
+	return issues
+}
+
+func isKnownTool(tool string) bool {
+	return contains(KnownTools, tool) || mcpToolPattern.MatchString(tool)
+}
+
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks every agent definition in globalDir and projectDir. Two
+// definitions of one name in a scope are an error, since Claude Code loads
+// only one of them; a project agent hiding a global one is a warning.
+func Validate(globalDir, projectDir string) []Issue {
+	var issues []Issue
+	global := validateDir(globalDir, Global, &issues)
+	project := validateDir(projectDir, Project, &issues)
+
+	for name, agent := range project {
+		if _, ok := global[name]; ok && !sharedNames[name] {
+			issues = append(issues, Issue{Path: agent.Path, Agent: name, Severity: SeverityWarning,
+				Message: fmt.Sprintf("overrides the global agent %s", global[name].Path)})
+		}
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool { return issues[i].Path < issues[j].Path })
+	return issues
+}
+
+// validateDir checks the agents in dir and returns them by name
+func validateDir(dir, scope string, issues *[]Issue) map[string]*Agent {
+	byName := make(map[string]*Agent)
+	if dir == "" {
+		return byName
+	}
+	agents, problems := LoadDir(dir, scope)
+	for _, problem := range problems {
+		*issues = append(*issues, Issue{Path: problemPath(problem, dir), Severity: SeverityError, Message: problem.Error()})
+	}
+	for _, agent := range agents {
+		*issues = append(*issues, ValidateAgent(agent)...)
+		if other, ok := byName[agent.Name]; ok {
+			*issues = append(*issues, Issue{Path: agent.Path, Agent: agent.Name, Severity: SeverityError,
+				Message: fmt.Sprintf("%s agent name is also used by %s", scope, other.Path)})
+			continue
+		}
+		byName[agent.Name] = agent
+	}
+	return byName
+}
+
+// problemPath returns the file a load error is about, or dir
+func problemPath(problem error, dir string) string {
+	if err, ok := problem.(*ParseError); ok {
+		return err.Path
+	}
+	return dir
+}
+
+// HasErrors reports whether any of issues is an error
+func HasErrors(issues []Issue) bool {
+	for _, issue := range issues {
+		if issue.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
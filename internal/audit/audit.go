@@ -0,0 +1,149 @@
+// Package audit keeps an append-only record of the crew operations that
+// changed an installation: who ran what, with which flags, and whether it
+// worked. Entries are JSON lines so the file can be read with any tool.
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"time"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/crewdirs"
+)
+
+// FileName is the audit log in the crew logs directory
+const FileName = "audit.jsonl"
+
+// Path returns the audit log of the installation in installDir
+func Path(installDir string) string {
+	return crewdirs.Path(installDir, "logs", FileName)
+}
+
+// Undo kinds, naming what reverts an operation
+const (
+	// UndoBackup restores the backup taken before the operation
+	UndoBackup = "backup"
+	// UndoTrash restores the trash entry holding what the operation removed
+	UndoTrash = "trash"
+)
+
+// Undo records how an operation can be reverted
+type Undo struct {
+	Kind string `json:"kind"`
+	// Ref is the backup file or the trash entry ID
+	Ref string `json:"ref"`
+	// Added lists the files the operation created, relative to the
+	// installation, with forward slashes. Restoring a backup leaves them,
+	// so undo moves them to the trash.
+	Added []string `json:"added,omitempty"`
+}
+
+// Entry is one audited operation
+type Entry struct {
+	ID      string    `json:"id"`
+	Time    time.Time `json:"time"`
+	User    string    `json:"user"`
+	Host    string    `json:"host,omitempty"`
+	Command string    `json:"command"`
+	Args    []string  `json:"args,omitempty"`
+	// Flags holds the flags set on the command line
+	Flags      map[string]string `json:"flags,omitempty"`
+	InstallDir string            `json:"install_dir"`
+	DurationMs int64             `json:"duration_ms"`
+	Success    bool              `json:"success"`
+	Error      string            `json:"error,omitempty"`
+	Undo       *Undo             `json:"undo,omitempty"`
+	// Reverts is the ID of the operation this one undid
+	Reverts string `json:"reverts,omitempty"`
+}
+
+// Latest returns the most recent operation in entries that was not undone
+// and is not itself an undo, or nil. Operations that failed without
+// recording how to undo them are skipped; they stopped before changing
+// anything worth reverting.
+func Latest(entries []Entry) *Entry {
+	reverted := make(map[string]bool)
+	for _, entry := range entries {
+		if entry.Reverts != "" && entry.Success {
+			reverted[entry.Reverts] = true
+		}
+	}
+	for i := len(entries) - 1; i >= 0; i-- {
+		entry := entries[i]
+		if entry.Reverts != "" || (entry.ID != "" && reverted[entry.ID]) {
+			continue
+		}
+		if !entry.Success && entry.Undo == nil {
+			continue
+		}
+		return &entries[i]
+	}
+	return nil
+}
+
+// CurrentUser returns the name of the user running crew
+func CurrentUser() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	if name := os.Getenv("USER"); name != "" {
+		return name
+	}
+	return os.Getenv("USERNAME")
+}
+
+// Log is an audit log file
+type Log struct {
+	Path string
+}
+
+// Append adds entry to the end of the log. Existing entries are never
+// rewritten.
+func (l *Log) Append(entry Entry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(l.Path), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(l.Path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer f.Close()
+	_, err = f.Write(append(data, '\n'))
+	return err
+}
+
+// Read returns the entries recorded at or after since, oldest first. A
+// zero since returns every entry; lines that do not parse are skipped.
+func (l *Log) Read(since time.Time) ([]Entry, error) {
+	f, err := os.Open(l.Path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var entry Entry
+		if json.Unmarshal(scanner.Bytes(), &entry) != nil {
+			continue
+		}
+		if !since.IsZero() && entry.Time.Before(since) {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
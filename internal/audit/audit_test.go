@@ -0,0 +1,57 @@
+package audit
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAppendAndRead(t *testing.T) {
+	l := &Log{Path: filepath.Join(t.TempDir(), "logs", FileName)}
+	if entries, err := l.Read(time.Time{}); err != nil || len(entries) != 0 {
+		t.Fatalf("Expected an empty log, got %v, %v", entries, err)
+	}
+
+	now := time.Now().UTC()
+	old := Entry{Time: now.Add(-48 * time.Hour), User: "dev", Command: "crew install", Success: true}
+	recent := Entry{Time: now, User: "dev", Command: "crew uninstall", Flags: map[string]string{"complete": "true"}, Error: "boom"}
+	for _, e := range []Entry{old, recent} {
+		if err := l.Append(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// A torn line from a crash must not hide the others
+	f, _ := os.OpenFile(l.Path, os.O_WRONLY|os.O_APPEND, 0)
+	f.WriteString("{\"time\":\n")
+	f.Close()
+
+	entries, err := l.Read(time.Time{})
+	if err != nil || len(entries) != 2 {
+		t.Fatalf("Expected two entries, got %+v, %v", entries, err)
+	}
+	entries, _ = l.Read(now.Add(-time.Hour))
+	if len(entries) != 1 || entries[0].Command != "crew uninstall" || entries[0].Flags["complete"] != "true" || entries[0].Error != "boom" {
+		t.Errorf("Unexpected entries since an hour ago: %+v", entries)
+	}
+}
+
+func TestLatest(t *testing.T) {
+	entries := []Entry{
+		{ID: "1", Command: "crew install", Success: true},
+		{ID: "2", Command: "crew update", Undo: &Undo{Kind: UndoBackup, Ref: "b.tar.gz"}},
+		{ID: "3", Command: "crew undo", Reverts: "2", Success: true},
+		{ID: "4", Command: "crew undo"},
+	}
+	if latest := Latest(entries); latest == nil || latest.ID != "1" {
+		t.Errorf("Expected the install to be next, got %+v", latest)
+	}
+	// A failed undo leaves the operation in place
+	entries[2].Success = false
+	if latest := Latest(entries); latest == nil || latest.ID != "2" {
+		t.Errorf("Expected the update to be next, got %+v", latest)
+	}
+	if latest := Latest(entries[:0]); latest != nil {
+		t.Errorf("Expected nothing for an empty log, got %+v", latest)
+	}
+}
@@ -0,0 +1,119 @@
+// Package buildinfo reports the version of the crew binary.
+//
+// Release builds set Version, Commit and Date with -ldflags, e.g.
+//
+//	go build -ldflags "-X github.com/jonwraymond/claude-code-super-crew/internal/buildinfo.Version=1.2.0"
+//
+// Builds without them fall back to what the Go toolchain records: the
+// module version for 'go install ...@v1.2.0' and the VCS revision for
+// builds from a checkout. Nothing is read from the working directory.
+package buildinfo
+
+import (
+	"regexp"
+	"runtime"
+	"runtime/debug"
+	"strings"
+)
+
+// Set with -ldflags -X
+var (
+	Version = ""
+	Commit  = ""
+	Date    = ""
+)
+
+// DefaultVersion is reported when neither -ldflags nor the module version
+// say which release a binary was built from
+const DefaultVersion = "1.0.0"
+
+// pseudoVersion matches the timestamp and revision the Go toolchain adds
+// to versions of untagged commits, e.g. 1.2.1-0.20240501100000-0123456789ab
+var pseudoVersion = regexp.MustCompile(`-(0\.)?\d{14}-[0-9a-f]{12}$`)
+
+// Info describes the running binary
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit,omitempty"`
+	Date      string `json:"date,omitempty"`
+	Modified  bool   `json:"modified,omitempty"`
+	GoVersion string `json:"go_version"`
+	Platform  string `json:"platform"`
+	// Dev is set for builds that are not a tagged release
+	Dev bool `json:"dev"`
+}
+
+// Get returns the build information of the running binary
+func Get() Info {
+	bi, _ := debug.ReadBuildInfo()
+	return resolve(Version, Commit, Date, bi)
+}
+
+func resolve(version, commit, date string, bi *debug.BuildInfo) Info {
+	info := Info{
+		Version:   strings.TrimPrefix(version, "v"),
+		Commit:    commit,
+		Date:      date,
+		GoVersion: runtime.Version(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+	}
+
+	if bi != nil {
+		if info.Version == "" && bi.Main.Version != "" && bi.Main.Version != "(devel)" {
+			info.Version = moduleVersion(bi.Main.Version, &info)
+		}
+		for _, s := range bi.Settings {
+			switch s.Key {
+			case "vcs.revision":
+				if info.Commit == "" {
+					info.Commit = s.Value
+				}
+			case "vcs.time":
+				if info.Date == "" {
+					info.Date = s.Value
+				}
+			case "vcs.modified":
+				info.Modified = info.Modified || s.Value == "true"
+			}
+		}
+		if bi.GoVersion != "" {
+			info.GoVersion = bi.GoVersion
+		}
+	}
+
+	if info.Version == "" {
+		info.Version = DefaultVersion
+		info.Dev = true
+	}
+	if info.Modified {
+		info.Dev = true
+	}
+	return info
+}
+
+// moduleVersion turns the module version the toolchain recorded into a
+// release version. Pseudo-versions mark development builds; the 0.0.0 ones
+// of repositories without tags say nothing about the release and are
+// dropped.
+func moduleVersion(version string, info *Info) string {
+	version = strings.TrimPrefix(version, "v")
+	if base, dirty := strings.CutSuffix(version, "+dirty"); dirty {
+		version = base
+		info.Modified = true
+	}
+	if pseudoVersion.MatchString(version) {
+		info.Dev = true
+		if strings.HasPrefix(version, "0.0.0-") {
+			return ""
+		}
+	}
+	return version
+}
+
+// ShortCommit returns the first 12 characters of the commit
+func (i Info) ShortCommit() string {
+	if len(i.Commit) > 12 {
+		return i.Commit[:12]
+	}
+	return i.Commit
+}
@@ -0,0 +1,72 @@
+package buildinfo
+
+import (
+	"runtime/debug"
+	"testing"
+)
+
+func TestResolve(t *testing.T) {
+	checkout := &debug.BuildInfo{
+		Main: debug.Module{Version: "(devel)"},
+		Settings: []debug.BuildSetting{
+			{Key: "vcs.revision", Value: "0123456789abcdef0123"},
+			{Key: "vcs.time", Value: "2024-05-01T10:00:00Z"},
+			{Key: "vcs.modified", Value: "false"},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		version string
+		commit  string
+		bi      *debug.BuildInfo
+		want    Info
+	}{
+		{
+			name:    "ldflags win over build info",
+			version: "v1.2.0",
+			commit:  "abc",
+			bi:      checkout,
+			want:    Info{Version: "1.2.0", Commit: "abc", Date: "2024-05-01T10:00:00Z"},
+		},
+		{
+			name: "checkout build is a dev build",
+			bi:   checkout,
+			want: Info{Version: DefaultVersion, Commit: "0123456789abcdef0123", Date: "2024-05-01T10:00:00Z", Dev: true},
+		},
+		{
+			name: "go install of a tag",
+			bi:   &debug.BuildInfo{Main: debug.Module{Version: "v1.3.0"}},
+			want: Info{Version: "1.3.0"},
+		},
+		{
+			name: "pseudo-version",
+			bi:   &debug.BuildInfo{Main: debug.Module{Version: "v1.3.1-0.20240501100000-0123456789ab"}},
+			want: Info{Version: "1.3.1-0.20240501100000-0123456789ab", Dev: true},
+		},
+		{
+			name: "untagged repository",
+			bi:   &debug.BuildInfo{Main: debug.Module{Version: "v0.0.0-20240501100000-0123456789ab+dirty"}},
+			want: Info{Version: DefaultVersion, Dev: true},
+		},
+		{
+			name: "no build info",
+			want: Info{Version: DefaultVersion, Dev: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := resolve(tt.version, tt.commit, "", tt.bi)
+			if got.Version != tt.want.Version || got.Commit != tt.want.Commit || got.Date != tt.want.Date || got.Dev != tt.want.Dev {
+				t.Errorf("resolve() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestShortCommit(t *testing.T) {
+	if got := (Info{Commit: "0123456789abcdef"}).ShortCommit(); got != "0123456789ab" {
+		t.Errorf("ShortCommit() = %q", got)
+	}
+}
@@ -0,0 +1,267 @@
+// Package cache keeps downloaded archives, rendered templates and analysis
+// results of an installation in its cache directory. Each kind of entry
+// has a namespace, a directory of its own. The cache is capped in size:
+// once it grows past the cap the least recently used files are evicted.
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/crewdirs"
+	"github.com/jonwraymond/claude-code-super-crew/internal/retry"
+)
+
+// DefaultMaxSize caps the cache unless the settings.cache_max_mb config
+// says otherwise
+const DefaultMaxSize int64 = 512 << 20
+
+// Namespaces used by crew
+const (
+	Releases  = "releases"
+	Templates = "templates"
+	Analysis  = "analysis"
+)
+
+// Dir returns the cache directory of the installation in installDir
+func Dir(installDir string) string {
+	return crewdirs.Path(installDir, "cache")
+}
+
+// Cache is a size-capped cache directory
+type Cache struct {
+	Dir string
+	// MaxSize in bytes; 0 or less disables eviction
+	MaxSize int64
+}
+
+// New returns a cache in dir capped at DefaultMaxSize
+func New(dir string) *Cache {
+	return &Cache{Dir: dir, MaxSize: DefaultMaxSize}
+}
+
+// Namespace returns the directory of a namespace
+func (c *Cache) Namespace(name string) string {
+	return filepath.Join(c.Dir, name)
+}
+
+// path returns the file of key in namespace. Keys are hashed so any string,
+// e.g. a URL or a list of inputs, can be one.
+func (c *Cache) path(namespace, key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.Namespace(namespace), hex.EncodeToString(sum[:]))
+}
+
+// Get returns the entry for key, marking it as recently used
+func (c *Cache) Get(namespace, key string) ([]byte, bool) {
+	path := c.path(namespace, key)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	Touch(path)
+	return data, true
+}
+
+// Put stores data for key and evicts old entries if the cache is over its
+// cap
+func (c *Cache) Put(namespace, key string, data []byte) error {
+	path := c.path(namespace, key)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	err := retry.Do(context.Background(), retry.Lock, func() error {
+		return os.Rename(tmp, path)
+	})
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	_, err = c.Evict(path)
+	return err
+}
+
+// Touch marks a cached file as recently used
+func Touch(path string) {
+	now := time.Now()
+	os.Chtimes(path, now, now)
+}
+
+// Removal describes files removed from the cache
+type Removal struct {
+	Files []string `json:"files"`
+	Bytes int64    `json:"bytes"`
+}
+
+type file struct {
+	path    string
+	size    int64
+	modTime time.Time
+}
+
+func (c *Cache) files() ([]file, error) {
+	var files []file
+	err := filepath.WalkDir(c.Dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == c.Dir {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		files = append(files, file{path: path, size: info.Size(), modTime: info.ModTime()})
+		return nil
+	})
+	return files, err
+}
+
+// Evict removes the least recently used files until the cache fits its
+// cap. Files in keep, e.g. the one just added, stay.
+func (c *Cache) Evict(keep ...string) (*Removal, error) {
+	removal := &Removal{}
+	if c.MaxSize <= 0 {
+		return removal, nil
+	}
+	files, err := c.files()
+	if err != nil {
+		return removal, err
+	}
+
+	var total int64
+	for _, f := range files {
+		total += f.size
+	}
+	if total <= c.MaxSize {
+		return removal, nil
+	}
+
+	kept := make(map[string]bool, len(keep))
+	for _, path := range keep {
+		kept[path] = true
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].modTime.Before(files[j].modTime) })
+	for _, f := range files {
+		if total <= c.MaxSize {
+			break
+		}
+		if kept[f.path] {
+			continue
+		}
+		if err := os.Remove(f.path); err != nil {
+			return removal, fmt.Errorf("failed to evict %s: %w", f.path, err)
+		}
+		total -= f.size
+		removal.Files = append(removal.Files, f.path)
+		removal.Bytes += f.size
+	}
+	c.pruneDirs()
+	return removal, nil
+}
+
+// Clean removes files last used before cutoff; a zero cutoff removes
+// everything. With dryRun nothing is removed.
+func (c *Cache) Clean(cutoff time.Time, dryRun bool) (*Removal, error) {
+	removal := &Removal{}
+	files, err := c.files()
+	if err != nil {
+		return removal, err
+	}
+	for _, f := range files {
+		if !cutoff.IsZero() && !f.modTime.Before(cutoff) {
+			continue
+		}
+		if !dryRun {
+			if err := os.Remove(f.path); err != nil {
+				return removal, fmt.Errorf("failed to remove %s: %w", f.path, err)
+			}
+		}
+		removal.Files = append(removal.Files, f.path)
+		removal.Bytes += f.size
+	}
+	if !dryRun {
+		c.pruneDirs()
+	}
+	return removal, nil
+}
+
+// pruneDirs removes the directories left empty below the cache directory
+func (c *Cache) pruneDirs() {
+	var dirs []string
+	filepath.WalkDir(c.Dir, func(path string, d fs.DirEntry, err error) error {
+		if err == nil && d.IsDir() && path != c.Dir {
+			dirs = append(dirs, path)
+		}
+		return nil
+	})
+	// Deepest first
+	for i := len(dirs) - 1; i >= 0; i-- {
+		os.Remove(dirs[i])
+	}
+}
+
+// Usage summarizes one namespace
+type Usage struct {
+	Namespace string    `json:"namespace"`
+	Files     int       `json:"files"`
+	Bytes     int64     `json:"bytes"`
+	Oldest    time.Time `json:"oldest,omitempty"`
+	Newest    time.Time `json:"newest,omitempty"`
+}
+
+// Info returns the usage of each namespace, sorted by name. Files directly
+// in the cache directory count toward the namespace ".".
+func (c *Cache) Info() ([]Usage, error) {
+	files, err := c.files()
+	if err != nil {
+		return nil, err
+	}
+	usage := make(map[string]*Usage)
+	for _, f := range files {
+		rel, err := filepath.Rel(c.Dir, f.path)
+		if err != nil {
+			return nil, err
+		}
+		name := "."
+		if parts := strings.SplitN(filepath.ToSlash(rel), "/", 2); len(parts) == 2 {
+			name = parts[0]
+		}
+		u, ok := usage[name]
+		if !ok {
+			u = &Usage{Namespace: name}
+			usage[name] = u
+		}
+		u.Files++
+		u.Bytes += f.size
+		if u.Oldest.IsZero() || f.modTime.Before(u.Oldest) {
+			u.Oldest = f.modTime
+		}
+		if f.modTime.After(u.Newest) {
+			u.Newest = f.modTime
+		}
+	}
+
+	result := make([]Usage, 0, len(usage))
+	for _, u := range usage {
+		result = append(result, *u)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Namespace < result[j].Namespace })
+	return result, nil
+}
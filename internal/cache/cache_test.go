@@ -0,0 +1,107 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// age sets the last use of path to d ago
+func age(t *testing.T, path string, d time.Duration) {
+	t.Helper()
+	when := time.Now().Add(-d)
+	if err := os.Chtimes(path, when, when); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGetPut(t *testing.T) {
+	c := New(t.TempDir())
+	if _, ok := c.Get(Templates, "agent.md"); ok {
+		t.Fatal("Expected a miss on an empty cache")
+	}
+	if err := c.Put(Templates, "agent.md", []byte("rendered")); err != nil {
+		t.Fatal(err)
+	}
+	data, ok := c.Get(Templates, "agent.md")
+	if !ok || string(data) != "rendered" {
+		t.Errorf("Expected the stored entry, got %q, %v", data, ok)
+	}
+}
+
+func TestPutEvictsLeastRecentlyUsed(t *testing.T) {
+	c := &Cache{Dir: t.TempDir(), MaxSize: 10}
+	for _, key := range []string{"a", "b"} {
+		if err := c.Put(Analysis, key, []byte("12345")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	age(t, c.path(Analysis, "a"), 2*time.Hour)
+	age(t, c.path(Analysis, "b"), time.Hour)
+	// Using a makes b the least recently used
+	c.Get(Analysis, "a")
+
+	if err := c.Put(Analysis, "c", []byte("12345")); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := c.Get(Analysis, "b"); ok {
+		t.Error("Expected b to be evicted")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, ok := c.Get(Analysis, key); !ok {
+			t.Errorf("Expected %s to stay", key)
+		}
+	}
+}
+
+func TestCleanAndInfo(t *testing.T) {
+	c := New(t.TempDir())
+	if usage, err := c.Info(); err != nil || len(usage) != 0 {
+		t.Fatalf("Expected an empty cache, got %v, %v", usage, err)
+	}
+
+	c.Put(Templates, "old", []byte("old"))
+	c.Put(Templates, "new", []byte("new!"))
+	release := filepath.Join(c.Namespace(Releases), "1.2.0", "crew-linux-amd64")
+	os.MkdirAll(filepath.Dir(release), 0755)
+	os.WriteFile(release, []byte("binary"), 0644)
+	age(t, c.path(Templates, "old"), 48*time.Hour)
+	age(t, release, 48*time.Hour)
+
+	usage, err := c.Info()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(usage) != 2 || usage[0].Namespace != Releases || usage[1].Namespace != Templates {
+		t.Fatalf("Unexpected usage %+v", usage)
+	}
+	if usage[1].Files != 2 || usage[1].Bytes != 7 {
+		t.Errorf("Unexpected templates usage %+v", usage[1])
+	}
+
+	removal, err := c.Clean(time.Now().Add(-24*time.Hour), true)
+	if err != nil || len(removal.Files) != 2 || removal.Bytes != 9 {
+		t.Fatalf("Unexpected dry run %+v, %v", removal, err)
+	}
+	if _, err := os.Stat(release); err != nil {
+		t.Error("Dry run removed a file")
+	}
+
+	if _, err := c.Clean(time.Now().Add(-24*time.Hour), false); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(c.Namespace(Releases)); !os.IsNotExist(err) {
+		t.Error("Expected the emptied releases namespace to be removed")
+	}
+	if _, ok := c.Get(Templates, "new"); !ok {
+		t.Error("Expected the recent entry to stay")
+	}
+
+	if _, err := c.Clean(time.Time{}, false); err != nil {
+		t.Fatal(err)
+	}
+	if usage, _ := c.Info(); len(usage) != 0 {
+		t.Errorf("Expected an empty cache, got %+v", usage)
+	}
+}
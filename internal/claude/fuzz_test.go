@@ -0,0 +1,38 @@
+package claude
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jonwraymond/claude-code-super-crew/pkg/logger"
+)
+
+func FuzzParseCommandContent(f *testing.F) {
+	f.Add(analyzeCommand)
+	f.Add("---\nallowed-tools: [Read, \"Grep\", Read]\nwave-enabled: true\nwave-strategy: systematic\n---\n# /crew:x - X\n/crew:x [--a b|c] [--d|--e] <f>\n## Arguments\n- `--a` - A\n")
+	f.Add("---\n---\n---\n:\n")
+	f.Add("\xef\xbb\xbf---\nkey\n---")
+	r := &SlashCommandRegistry{logger: logger.GetLogger()}
+	f.Fuzz(func(t *testing.T, content string) {
+		source := parseCommandContent(content)
+		for key := range source.frontmatter {
+			if key != strings.TrimSpace(key) {
+				t.Fatalf("frontmatter key %q is not trimmed", key)
+			}
+		}
+		parseWaveConfig(source.frontmatter)
+
+		tools := parseFrontmatterList(source.frontmatter["allowed-tools"])
+		merged := mergeFrontmatterLists(source.frontmatter["allowed-tools"], source.frontmatter["allowed-tools"])
+		if again := mergeFrontmatterLists(merged, merged); again != merged {
+			t.Fatalf("merging %v again gave %q, want %q", tools, again, merged)
+		}
+
+		args := mergeArgumentSection(r.parseArguments(source.usage), source.argumentLines)
+		for _, arg := range args {
+			if arg.Name == "" {
+				t.Fatalf("usage %q gave an argument without a name", source.usage)
+			}
+		}
+	})
+}
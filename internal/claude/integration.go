@@ -4,11 +4,13 @@
 package claude
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 
+	"github.com/jonwraymond/claude-code-super-crew/internal/agents"
 	"github.com/jonwraymond/claude-code-super-crew/pkg/logger"
 )
 
@@ -19,8 +21,13 @@ type ClaudeIntegration struct {
 	configFile   string
 	pathResolver *PathResolver
 	logger       logger.Logger
+	// version is recorded in the integration config; see SetVersion
+	version string
 }
 
+// DefaultVersion is recorded when the framework version is unknown
+const DefaultVersion = "1.0.0"
+
 // IntegrationConfig represents the Claude Code integration configuration
 type IntegrationConfig struct {
 	Version        string          `json:"version"`
@@ -41,6 +48,7 @@ type IntegrationMeta struct {
 // NewClaudeIntegration creates a new Claude Code integration manager
 func NewClaudeIntegration(commandsPath, claudeDir string) (*ClaudeIntegration, error) {
 	registry := NewSlashCommandRegistry(commandsPath)
+	registry.SetClaudeDir(claudeDir)
 	if err := registry.LoadCommands(); err != nil {
 		return nil, fmt.Errorf("failed to load commands: %w", err)
 	}
@@ -58,13 +66,36 @@ func NewClaudeIntegration(commandsPath, claudeDir string) (*ClaudeIntegration, e
 	return integration, nil
 }
 
+// SetVersion sets the framework version the integration records, so a
+// later install can tell whether the project is behind the framework
+func (ci *ClaudeIntegration) SetVersion(version string) {
+	ci.version = version
+}
+
 // InstallIntegration installs SuperCrew integration into Claude Code
 func (ci *ClaudeIntegration) InstallIntegration() error {
 	ci.logger.Info("Installing SuperCrew integration for Claude Code...")
 
+	if _, err := ci.SyncIntegration(true); err != nil {
+		return err
+	}
+
+	ci.logger.Successf("SuperCrew integration installed successfully")
+	ci.logger.Infof("Configuration file: %s", ci.configFile)
+	ci.logger.Infof("Commands available: %d", len(ci.registry.ListCommands()))
+
+	return nil
+}
+
+// SyncIntegration writes the integration config and completion scripts.
+// Unless force is set, files that already have the expected content are
+// left untouched, so running it on an up-to-date project changes nothing.
+// It returns the paths it wrote. Agents and other project files are never
+// touched.
+func (ci *ClaudeIntegration) SyncIntegration(force bool) ([]string, error) {
 	// Ensure Claude directory exists
 	if err := os.MkdirAll(ci.claudeDir, 0755); err != nil {
-		return fmt.Errorf("failed to create Claude directory: %w", err)
+		return nil, fmt.Errorf("failed to create Claude directory: %w", err)
 	}
 
 	// Perform directory restructuring if needed
@@ -73,37 +104,51 @@ func (ci *ClaudeIntegration) InstallIntegration() error {
 		// Continue with installation even if restructuring fails
 	}
 
-	// Generate integration configuration
-	config := ci.generateIntegrationConfig()
-
-	// Write configuration file
-	configData, err := json.MarshalIndent(config, "", "  ")
+	configData, err := json.MarshalIndent(ci.generateIntegrationConfig(), "", "  ")
 	if err != nil {
-		return fmt.Errorf("failed to marshal config: %w", err)
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
 	}
 
-	if err := os.WriteFile(ci.configFile, configData, 0644); err != nil {
-		return fmt.Errorf("failed to write config file: %w", err)
+	var changed []string
+	wrote, err := writeIfChanged(ci.configFile, configData, force)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write config file: %w", err)
 	}
-
-	// Install completion scripts
-	if err := ci.installCompletionScripts(); err != nil {
-		return fmt.Errorf("failed to install completion scripts: %w", err)
+	if wrote {
+		changed = append(changed, ci.configFile)
 	}
 
-	ci.logger.Successf("SuperCrew integration installed successfully")
-	ci.logger.Infof("Configuration file: %s", ci.configFile)
-	ci.logger.Infof("Commands available: %d", len(config.Commands))
+	scripts, err := ci.installCompletionScripts(force)
+	if err != nil {
+		return nil, fmt.Errorf("failed to install completion scripts: %w", err)
+	}
+	return append(changed, scripts...), nil
+}
 
-	return nil
+// writeIfChanged writes data to path unless the file already holds it
+func writeIfChanged(path string, data []byte, force bool) (bool, error) {
+	if !force {
+		if current, err := os.ReadFile(path); err == nil && bytes.Equal(current, data) {
+			return false, nil
+		}
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return false, err
+	}
+	return true, nil
 }
 
 // generateIntegrationConfig creates the integration configuration
 func (ci *ClaudeIntegration) generateIntegrationConfig() *IntegrationConfig {
 	commands := ci.registry.ListCommands()
 
+	version := ci.version
+	if version == "" {
+		version = DefaultVersion
+	}
+
 	return &IntegrationConfig{
-		Version:  "1.0.0",
+		Version:  version,
 		Commands: commands,
 		Metadata: IntegrationMeta{
 			Name:         "Claude Code Super Crew",
@@ -115,13 +160,15 @@ func (ci *ClaudeIntegration) generateIntegrationConfig() *IntegrationConfig {
 	}
 }
 
-// installCompletionScripts installs shell completion scripts
-func (ci *ClaudeIntegration) installCompletionScripts() error {
+// installCompletionScripts installs shell completion scripts and returns
+// the ones it wrote
+func (ci *ClaudeIntegration) installCompletionScripts(force bool) ([]string, error) {
 	completionDir := ci.pathResolver.GetCompletionsDir()
 	if err := os.MkdirAll(completionDir, 0755); err != nil {
-		return fmt.Errorf("failed to create completion directory: %w", err)
+		return nil, fmt.Errorf("failed to create completion directory: %w", err)
 	}
 
+	var written []string
 	shells := []string{"bash", "zsh", "fish"}
 	for _, shell := range shells {
 		script, err := ci.registry.GenerateCompletionScript(shell)
@@ -131,15 +178,18 @@ func (ci *ClaudeIntegration) installCompletionScripts() error {
 		}
 
 		scriptPath := filepath.Join(completionDir, fmt.Sprintf("supercrew.%s", shell))
-		if err := os.WriteFile(scriptPath, []byte(script), 0644); err != nil {
+		wrote, err := writeIfChanged(scriptPath, []byte(script), force)
+		if err != nil {
 			ci.logger.Warnf("Failed to write %s completion script: %v", shell, err)
 			continue
 		}
-
-		ci.logger.Infof("Installed %s completion: %s", shell, scriptPath)
+		if wrote {
+			written = append(written, scriptPath)
+			ci.logger.Infof("Installed %s completion: %s", shell, scriptPath)
+		}
 	}
 
-	return nil
+	return written, nil
 }
 
 // UpdateIntegration updates the Claude Code integration
@@ -243,6 +293,18 @@ func (ci *ClaudeIntegration) ExecuteSlashCommand(commandLine string) error {
 	return ci.registry.ExecuteCommand(commandLine)
 }
 
+// RenderSlashCommand validates a /crew: command line and returns the Task
+// invocation it stands for
+func (ci *ClaudeIntegration) RenderSlashCommand(commandLine string) (*RenderedPrompt, error) {
+	return ci.registry.RenderCommand(commandLine)
+}
+
+// SimulateRoute returns where a /crew: command line would be routed
+// without running it
+func (ci *ClaudeIntegration) SimulateRoute(commandLine string, index *agents.Index) (*RouteSimulation, error) {
+	return ci.registry.SimulateRoute(commandLine, index)
+}
+
 // GetCommandCompletions returns completions for partial command input
 func (ci *ClaudeIntegration) GetCommandCompletions(partial string) []string {
 	return ci.registry.GetCompletions(partial)
@@ -0,0 +1,70 @@
+package claude
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func newTestIntegration(t *testing.T) (*ClaudeIntegration, string) {
+	t.Helper()
+	commandsDir := t.TempDir()
+	command := "---\ndescription: \"Build the project\"\n---\n\n# /crew:build\n"
+	if err := os.WriteFile(filepath.Join(commandsDir, "build.md"), []byte(command), 0644); err != nil {
+		t.Fatal(err)
+	}
+	claudeDir := filepath.Join(t.TempDir(), ".claude")
+	ci, err := NewClaudeIntegration(commandsDir, claudeDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ci, claudeDir
+}
+
+func TestSyncIntegrationIsIdempotent(t *testing.T) {
+	ci, claudeDir := newTestIntegration(t)
+	ci.SetVersion("1.2.0")
+
+	changed, err := ci.SyncIntegration(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changed) != 4 {
+		t.Fatalf("Expected the config and 3 completion scripts on first install, got %v", changed)
+	}
+
+	agent := filepath.Join(claudeDir, "agents", "api-specialist.md")
+	if err := os.MkdirAll(filepath.Dir(agent), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(agent, []byte("customized"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if changed, err = ci.SyncIntegration(false); err != nil || len(changed) != 0 {
+		t.Fatalf("Expected nothing to change on a second run, got %v, %v", changed, err)
+	}
+
+	// A framework upgrade rewrites only the config
+	ci.SetVersion("1.3.0")
+	if changed, err = ci.SyncIntegration(false); err != nil {
+		t.Fatal(err)
+	}
+	if len(changed) != 1 || changed[0] != ci.configFile {
+		t.Errorf("Expected only the config to change, got %v", changed)
+	}
+	var config IntegrationConfig
+	data, _ := os.ReadFile(ci.configFile)
+	if err := json.Unmarshal(data, &config); err != nil || config.Version != "1.3.0" {
+		t.Errorf("Expected version 1.3.0 recorded, got %q (%v)", config.Version, err)
+	}
+
+	if data, _ := os.ReadFile(agent); string(data) != "customized" {
+		t.Errorf("Project agent was modified: %q", data)
+	}
+
+	if changed, _ = ci.SyncIntegration(true); len(changed) != 4 {
+		t.Errorf("Expected force to rewrite every file, got %v", changed)
+	}
+}
@@ -4,6 +4,8 @@ package claude
 import (
 	"os"
 	"path/filepath"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/crewdirs"
 )
 
 // PathResolver handles path resolution for the new .crew/ directory structure
@@ -21,7 +23,7 @@ func NewPathResolver(claudeDir string) *PathResolver {
 	
 	return &PathResolver{
 		claudeDir: claudeDir,
-		crewDir:   filepath.Join(claudeDir, ".crew"),
+		crewDir:   crewdirs.Path(claudeDir),
 	}
 }
 
@@ -49,7 +51,7 @@ func (pr *PathResolver) GetLogsDir() string {
 
 // GetConfigDir returns the config directory path
 func (pr *PathResolver) GetConfigDir() string {
-	return filepath.Join(pr.crewDir, "config")
+	return crewdirs.Path(pr.claudeDir, "config")
 }
 
 // GetCompletionsDir returns the completions directory path
@@ -74,7 +76,7 @@ func (pr *PathResolver) GetPromptsDir() string {
 
 // GetCrewPath returns the path for any directory under .crew/
 func (pr *PathResolver) GetCrewPath(dirName string) string {
-	return filepath.Join(pr.crewDir, dirName)
+	return crewdirs.Path(pr.claudeDir, dirName)
 }
 
 // Core SuperCrew directories (remain in main .claude/)
@@ -118,12 +120,12 @@ func (pr *PathResolver) GetMainConfigFile() string {
 
 // GetInstallationMetadata returns the installation metadata file path
 func (pr *PathResolver) GetInstallationMetadata() string {
-	return filepath.Join(pr.crewDir, "config", "crew-metadata.json")
+	return crewdirs.Path(pr.claudeDir, "config", crewdirs.MetadataFile)
 }
 
 // GetUserSettings returns the user settings file path
 func (pr *PathResolver) GetUserSettings() string {
-	return filepath.Join(pr.crewDir, "config", "settings.json")
+	return crewdirs.Path(pr.claudeDir, "config", "settings.json")
 }
 
 // Convenience methods for common operations
@@ -0,0 +1,287 @@
+package claude
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/pathsutil"
+	"github.com/jonwraymond/claude-code-super-crew/internal/prompts"
+)
+
+// ParsedCommand is a /crew: command line checked against the arguments the
+// command file declares
+type ParsedCommand struct {
+	Command    *SlashCommand
+	Positional []string
+	// Flags maps each flag given to its value, "" for switches
+	Flags map[string]string
+	// Args are the argument words as typed, after quote removal
+	Args []string
+}
+
+// RenderedPrompt is the Task invocation a command line asks Claude Code for
+type RenderedPrompt struct {
+	CommandLine  string `json:"command_line"`
+	Command      string `json:"command"`
+	Description  string `json:"description"`
+	SubagentType string `json:"subagent_type"`
+	Prompt       string `json:"prompt"`
+}
+
+// Task returns the invocation as it is pasted into Claude Code
+func (p *RenderedPrompt) Task() string {
+	return fmt.Sprintf("Task(description=%s, prompt=%s, subagent_type=%s)",
+		taskQuote(p.Description), taskQuote(p.Prompt), taskQuote(p.SubagentType))
+}
+
+func taskQuote(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, "'", `\'`).Replace(s) + "'"
+}
+
+// taskDescriptions describe the Task of the commands whose prompt template
+// chains specific agents
+var taskDescriptions = map[string]string{
+	"analyze":   "Analyze project code and architecture",
+	"build":     "Build and compile Go project",
+	"implement": "Implement feature or component",
+}
+
+// projectClaudeDir returns the project's .claude directory, which holds
+// its agents and the prompt templates that override the built-in ones.
+// Without one set, it is that of the repository the working directory is
+// in, so commands run from a subdirectory find the project.
+func (r *SlashCommandRegistry) projectClaudeDir() string {
+	if r.claudeDir != "" {
+		return r.claudeDir
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return pathsutil.ClaudeDirName
+	}
+	return pathsutil.ClaudeDir(pathsutil.ProjectDir(wd))
+}
+
+// taskFor renders the Task invocation of a command from its prompt
+// template, or the generic one routed through the orchestrator specialist
+func (r *SlashCommandRegistry) taskFor(command *SlashCommand, args []string) (*RenderedPrompt, error) {
+	claudeDir := r.projectClaudeDir()
+	projectDir := filepath.Dir(claudeDir)
+	if abs, err := filepath.Abs(projectDir); err == nil {
+		projectDir = abs
+	}
+
+	prompt, err := prompts.Render(command.Name, claudeDir, prompts.Data{
+		Command:      command.Name,
+		Description:  command.Description,
+		ProjectDir:   projectDir,
+		Args:         joinCommandArgs(args),
+		AllowedTools: command.AllowedTools,
+		Analysis:     prompts.LoadAnalysis(projectDir),
+		Wave:         command.Wave.promptData(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	description, ok := taskDescriptions[command.Name]
+	if !ok {
+		description = command.Description
+	}
+	return &RenderedPrompt{
+		Command:      command.Name,
+		Description:  description,
+		SubagentType: "orchestrator-specialist",
+		Prompt:       prompt,
+	}, nil
+}
+
+// RenderCommand validates commandLine and returns the Task invocation that
+// runs it, without executing anything
+func (r *SlashCommandRegistry) RenderCommand(commandLine string) (*RenderedPrompt, error) {
+	parsed, err := r.ParseCommandLine(commandLine)
+	if err != nil {
+		return nil, err
+	}
+
+	var rendered *RenderedPrompt
+	switch parsed.Command.Name {
+	case "onboard", "load":
+		targetDir := "."
+		if len(parsed.Positional) > 0 {
+			targetDir = parsed.Positional[0]
+		} else if wd, err := os.Getwd(); err == nil {
+			targetDir = wd
+		}
+		metaprompt, err := r.generateLoadCommandMetaprompt(targetDir)
+		if err != nil {
+			return nil, err
+		}
+		rendered = &RenderedPrompt{
+			Command:      parsed.Command.Name,
+			Description:  "Analyze and onboard the project",
+			SubagentType: "orchestrator-agent",
+			Prompt:       metaprompt,
+		}
+	default:
+		if rendered, err = r.taskFor(parsed.Command, parsed.Args); err != nil {
+			return nil, err
+		}
+	}
+	rendered.CommandLine = strings.TrimSpace(commandLine)
+	return rendered, nil
+}
+
+// ParseCommandLine splits commandLine, honoring quotes, and checks its
+// flags and values against the arguments of the command: flags must be
+// declared, choice flags need one of their values and required positional
+// arguments must be present
+func (r *SlashCommandRegistry) ParseCommandLine(commandLine string) (*ParsedCommand, error) {
+	words, err := splitCommandLine(commandLine)
+	if err != nil {
+		return nil, err
+	}
+	if len(words) == 0 {
+		return nil, fmt.Errorf("empty command")
+	}
+	if !strings.HasPrefix(words[0], "/crew:") {
+		return nil, fmt.Errorf("invalid command format: %s (expected /crew:command)", words[0])
+	}
+	name := strings.TrimPrefix(words[0], "/crew:")
+	command, ok := r.GetCommand(name)
+	if !ok {
+		return nil, fmt.Errorf("unknown command: /crew:%s", name)
+	}
+
+	flags := make(map[string]*CommandArgument)
+	var required []string
+	for i := range command.Arguments {
+		arg := &command.Arguments[i]
+		if strings.HasPrefix(arg.Name, "--") {
+			flags[arg.Name] = arg
+		} else if arg.Required {
+			required = append(required, arg.Name)
+		}
+	}
+
+	parsed := &ParsedCommand{Command: command, Flags: make(map[string]string), Args: words[1:]}
+	for i := 1; i < len(words); i++ {
+		word := words[i]
+		if !strings.HasPrefix(word, "--") || word == "--" {
+			parsed.Positional = append(parsed.Positional, word)
+			continue
+		}
+
+		flag, value, hasValue := strings.Cut(word, "=")
+		arg, ok := flags[flag]
+		if !ok {
+			return nil, fmt.Errorf("unknown flag %s for /crew:%s (valid: %s)", flag, name, strings.Join(sortedFlagNames(flags), ", "))
+		}
+		if len(arg.Choices) == 0 {
+			if hasValue {
+				return nil, fmt.Errorf("flag %s of /crew:%s takes no value", flag, name)
+			}
+			parsed.Flags[flag] = ""
+			continue
+		}
+
+		if !hasValue {
+			if i+1 >= len(words) || strings.HasPrefix(words[i+1], "--") {
+				return nil, fmt.Errorf("flag %s of /crew:%s needs a value (%s)", flag, name, strings.Join(arg.Choices, ", "))
+			}
+			i++
+			value = words[i]
+		}
+		if !contains(arg.Choices, value) {
+			return nil, fmt.Errorf("invalid value %q for %s of /crew:%s (valid: %s)", value, flag, name, strings.Join(arg.Choices, ", "))
+		}
+		parsed.Flags[flag] = value
+	}
+
+	if len(parsed.Positional) < len(required) {
+		return nil, fmt.Errorf("/crew:%s is missing required argument %s", name, required[len(parsed.Positional)])
+	}
+	return parsed, nil
+}
+
+func sortedFlagNames(flags map[string]*CommandArgument) []string {
+	names := make([]string, 0, len(flags))
+	for name := range flags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+// splitCommandLine splits line into words the way a shell would for single
+// and double quotes and backslash escapes, without any expansion
+func splitCommandLine(line string) ([]string, error) {
+	var words []string
+	var word strings.Builder
+	inWord := false
+	var quote rune
+	escaped := false
+
+	for _, c := range line {
+		switch {
+		case escaped:
+			word.WriteRune(c)
+			escaped = false
+		case c == '\\' && quote != '\'':
+			escaped = true
+			inWord = true
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			} else {
+				word.WriteRune(c)
+			}
+		case c == '\'' || c == '"':
+			quote = c
+			inWord = true
+		case c == ' ' || c == '\t' || c == '\n':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(c)
+			inWord = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote in %s", quote, line)
+	}
+	if escaped {
+		word.WriteRune('\\')
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words, nil
+}
+
+// joinCommandArgs joins args back into one line, quoting the ones that
+// contain spaces
+func joinCommandArgs(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		if strings.ContainsAny(arg, " \t\n") {
+			arg = fmt.Sprintf("%q", arg)
+		}
+		quoted[i] = arg
+	}
+	return strings.Join(quoted, " ")
+}
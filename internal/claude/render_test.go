@@ -0,0 +1,83 @@
+package claude
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSplitCommandLine(t *testing.T) {
+	words, err := splitCommandLine(`/crew:implement "user auth" --type='api' it\'s`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"/crew:implement", "user auth", "--type=api", "it's"}; !reflect.DeepEqual(words, want) {
+		t.Errorf("Expected %q, got %q", want, words)
+	}
+	if _, err := splitCommandLine(`/crew:analyze "src`); err == nil {
+		t.Error("Expected an unterminated quote to fail")
+	}
+}
+
+func TestParseCommandLine(t *testing.T) {
+	registry := newTestRegistry(t)
+
+	parsed, err := registry.ParseCommandLine("/crew:analyze src --focus security --safe --format=json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(parsed.Positional, []string{"src"}) {
+		t.Errorf("Unexpected positional arguments %q", parsed.Positional)
+	}
+	if want := map[string]string{"--focus": "security", "--safe": "", "--format": "json"}; !reflect.DeepEqual(parsed.Flags, want) {
+		t.Errorf("Expected flags %v, got %v", want, parsed.Flags)
+	}
+
+	for line, problem := range map[string]string{
+		"analyze":                         "invalid command format",
+		"/crew:missing":                   "unknown command",
+		"/crew:analyze --nope":            "unknown flag --nope",
+		"/crew:analyze --focus":           "needs a value",
+		"/crew:analyze --focus --safe":    "needs a value",
+		"/crew:analyze --focus speed":     `invalid value "speed"`,
+		"/crew:analyze --safe=yes":        "takes no value",
+		"/crew:analyze --format=markdown": `invalid value "markdown"`,
+	} {
+		if _, err := registry.ParseCommandLine(line); err == nil || !strings.Contains(err.Error(), problem) {
+			t.Errorf("%s: expected an error about %q, got %v", line, problem, err)
+		}
+	}
+}
+
+func TestRenderCommand(t *testing.T) {
+	dir := writeCommandFiles(t, map[string]string{
+		"analyze.md": analyzeCommand,
+		"spawn.md":   "---\nallowed-tools: [Read, Task]\ndescription: \"Coordinate subtasks\"\n---\n\n## Usage\n```\n/crew:spawn task [--parallel]\n```\n",
+	})
+	registry := NewSlashCommandRegistry(dir)
+	if err := registry.LoadCommands(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := registry.RenderCommand("/crew:spawn --parallel"); err == nil || !strings.Contains(err.Error(), "missing required argument task") {
+		t.Errorf("Expected the missing task to be reported, got %v", err)
+	}
+
+	rendered, err := registry.RenderCommand(`/crew:spawn "ship it's release" --parallel`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rendered.Command != "spawn" || rendered.SubagentType != "orchestrator-specialist" || rendered.Description != "Coordinate subtasks" {
+		t.Errorf("Unexpected rendering %+v", rendered)
+	}
+	task := rendered.Task()
+	for _, fragment := range []string{"Allowed tools: Read, Task.", `Args: "ship it\'s release" --parallel'`, "subagent_type='orchestrator-specialist')"} {
+		if !strings.Contains(task, fragment) {
+			t.Errorf("Expected %q in %s", fragment, task)
+		}
+	}
+
+	if rendered, err = registry.RenderCommand("/crew:analyze src"); err != nil || !strings.Contains(rendered.Prompt, "analyzer-persona") {
+		t.Errorf("Expected the dedicated analyze chain, got %+v, %v", rendered, err)
+	}
+}
@@ -0,0 +1,204 @@
+package claude
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/agents"
+	"github.com/jonwraymond/claude-code-super-crew/internal/pathsutil"
+)
+
+// Kinds of route a command line can take
+const (
+	// RouteTemplate renders the command's prompt template for the
+	// orchestrator specialist
+	RouteTemplate = "template"
+	// RouteOnboard hands project analysis to the global orchestrator agent
+	RouteOnboard = "onboard"
+	// RouteOrchestrate coordinates several agents through the orchestrator
+	RouteOrchestrate = "orchestrate"
+	RoutePersona     = "persona"
+	RouteSpecialist  = "specialist"
+	RouteHelp        = "help"
+	// RoutePending marks commands whose handler is not implemented yet
+	RoutePending = "pending"
+	// RouteUnrouted leaves the choice of agent to the orchestrator
+	RouteUnrouted = "unrouted"
+	// RouteKeywords is an alternative found by agent activation keywords
+	RouteKeywords = "keywords"
+)
+
+// Route is where a command line is sent
+type Route struct {
+	Kind   string   `json:"kind"`
+	Agents []string `json:"agents,omitempty"`
+	Reason string   `json:"reason"`
+}
+
+// RouteSimulation is the routing decision for a command line, with the
+// complexity analysis behind it and the routes that were not taken
+type RouteSimulation struct {
+	CommandLine  string            `json:"command_line"`
+	Command      string            `json:"command"`
+	Args         []string          `json:"args"`
+	Complexity   CommandComplexity `json:"complexity"`
+	Route        Route             `json:"route"`
+	Alternatives []Route           `json:"alternatives"`
+}
+
+// personaRule routes commands named after a domain to its persona; prompt
+// is formatted with the command name and its arguments
+type personaRule struct {
+	commands []string
+	persona  string
+	prompt   string
+}
+
+var personaRules = []personaRule{
+	{[]string{"go", "backend", "api", "endpoint", "rest"}, "backend-persona", "Handle %[1]s task"},
+	{[]string{"test", "validate", "qa"}, "qa-persona", "Test %[2]s"},
+	{[]string{"analyze", "investigate", "debug"}, "analyzer-persona", "Analyze %[2]s"},
+	{[]string{"document", "docs", "readme"}, "scribe-persona", "Document %[2]s"},
+	{[]string{"design", "architecture"}, "architect-persona", "Design %[2]s"},
+	{[]string{"optimize", "profile", "performance"}, "performance-persona", "Optimize %[2]s"},
+	{[]string{"secure", "audit", "vulnerability"}, "security-persona", "Security %[1]s for %[2]s"},
+}
+
+func personaRuleFor(name string) *personaRule {
+	for i := range personaRules {
+		if contains(personaRules[i].commands, name) {
+			return &personaRules[i]
+		}
+	}
+	return nil
+}
+
+// orchestratorCommands ask for the orchestrator specialist by name
+var orchestratorCommands = []string{"orchestrate", "chain", "workflow", "multimodal"}
+
+// pendingHandlers are the commands with a handler that is not implemented
+// yet, and the agent their error message points to
+var pendingHandlers = map[string]string{
+	"install":  "",
+	"cleanup":  "orchestrator-agent",
+	"document": "scribe-persona",
+	"improve":  "refactorer-persona",
+	"test":     "qa-persona",
+}
+
+func isPending(name string) bool {
+	_, pending := pendingHandlers[name]
+	return pending
+}
+
+// simpleRoute routes a single-domain command to a project specialist
+// for backend work, or the persona of its domain
+func simpleRoute(name string, specialists []string) Route {
+	rule := personaRuleFor(name)
+	if rule == nil {
+		return Route{Kind: RouteUnrouted, Reason: fmt.Sprintf("no routing rule for /crew:%s; the orchestrator picks an agent", name)}
+	}
+	if rule.persona == "backend-persona" {
+		for _, specialist := range specialists {
+			if strings.Contains(specialist, "backend") || strings.Contains(specialist, name) {
+				return Route{Kind: RouteSpecialist, Agents: []string{specialist}, Reason: fmt.Sprintf("local specialist %s covers /crew:%s", specialist, name)}
+			}
+		}
+	}
+	return Route{Kind: RoutePersona, Agents: []string{rule.persona}, Reason: fmt.Sprintf("/crew:%s routes to the %s persona", name, strings.TrimSuffix(rule.persona, "-persona"))}
+}
+
+// orchestrateRoute sends the command to the orchestrator specialist
+func orchestrateRoute(reason string) Route {
+	return Route{Kind: RouteOrchestrate, Agents: []string{"orchestrator-specialist"}, Reason: reason}
+}
+
+// SimulateRoute decides where commandLine would be routed, as ExecuteCommand
+// does, without printing or rendering anything. index, when not nil, adds
+// the agents the line's activation keywords select as alternatives.
+func (r *SlashCommandRegistry) SimulateRoute(commandLine string, index *agents.Index) (*RouteSimulation, error) {
+	parsed, err := r.ParseCommandLine(commandLine)
+	if err != nil {
+		return nil, err
+	}
+	name := parsed.Command.Name
+	args := parsed.Args
+	specialists := r.listProjectSpecialists(filepath.Join(r.projectClaudeDir(), pathsutil.AgentsDirName))
+
+	sim := &RouteSimulation{
+		CommandLine: strings.TrimSpace(commandLine),
+		Command:     name,
+		Args:        args,
+		Complexity:  r.analyzeCommandComplexity(name, args),
+	}
+	simple := simpleRoute(name, specialists)
+
+	switch {
+	case name == "analyze" || name == "build" || name == "implement":
+		sim.Route = Route{Kind: RouteTemplate, Agents: []string{"orchestrator-specialist"}, Reason: fmt.Sprintf("/crew:%s renders its prompt template", name)}
+		if parsed.Command.Wave != nil {
+			sim.Route.Reason += fmt.Sprintf(" with a %s wave plan", parsed.Command.Wave.Strategy)
+		}
+		sim.Alternatives = append(sim.Alternatives, simple)
+	case name == "load" || name == "onboard":
+		sim.Route = Route{Kind: RouteOnboard, Agents: []string{"orchestrator-agent"}, Reason: "project analysis runs in the global orchestrator agent"}
+	case isPending(name):
+		sim.Route = Route{Kind: RoutePending, Reason: fmt.Sprintf("the /crew:%s handler is not implemented yet", name)}
+		if agent := pendingHandlers[name]; agent != "" {
+			sim.Route.Agents = []string{agent}
+			sim.Route.Reason += "; run it with the Task tool and " + agent
+		}
+	case contains(orchestratorCommands, name):
+		sim.Route = orchestrateRoute(fmt.Sprintf("/crew:%s asks for the orchestrator", name))
+	case name == "help" || name == "agent-help":
+		sim.Route = Route{Kind: RouteHelp, Reason: "lists the available agents"}
+	case sim.Complexity.RequiresOrchestration:
+		sim.Route = orchestrateRoute(fmt.Sprintf("complexity %.1f with %d domains needs orchestration", sim.Complexity.Score, len(sim.Complexity.Domains)))
+		sim.Alternatives = append(sim.Alternatives, simple)
+	default:
+		sim.Route = simple
+	}
+
+	if sim.Route.Kind != RouteOrchestrate && sim.Route.Kind != RouteHelp && sim.Route.Kind != RouteOnboard {
+		task := strings.TrimSpace(name + " " + joinCommandArgs(args))
+		sim.Alternatives = append(sim.Alternatives, orchestrateRoute(fmt.Sprintf("/crew:orchestrate %q coordinates several agents", task)))
+	}
+
+	if index != nil {
+		for _, match := range index.Match(name + " " + strings.Join(args, " ")) {
+			if !match.Activates {
+				continue
+			}
+			var hits []string
+			for _, hit := range match.Hits {
+				hits = append(hits, fmt.Sprintf("%s (%s)", hit.Keyword, hit.Tier))
+			}
+			sim.Alternatives = append(sim.Alternatives, Route{
+				Kind:   RouteKeywords,
+				Agents: []string{match.Agent.Name},
+				Reason: fmt.Sprintf("activation keywords score %.1f: %s", match.Score, strings.Join(hits, ", ")),
+			})
+		}
+	}
+
+	sim.Alternatives = distinctAlternatives(sim.Route, sim.Alternatives)
+	return sim, nil
+}
+
+// distinctAlternatives drops alternatives without agents and those sending
+// the command to the same agents as the chosen route or an earlier
+// alternative
+func distinctAlternatives(chosen Route, alternatives []Route) []Route {
+	seen := map[string]bool{strings.Join(chosen.Agents, ","): true}
+	distinct := []Route{}
+	for _, alt := range alternatives {
+		key := strings.Join(alt.Agents, ",")
+		if len(alt.Agents) == 0 || seen[key] {
+			continue
+		}
+		seen[key] = true
+		distinct = append(distinct, alt)
+	}
+	return distinct
+}
@@ -0,0 +1,132 @@
+package claude
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/agents"
+)
+
+// newRoutingRegistry loads one command per routing rule and a project with
+// a backend specialist
+func newRoutingRegistry(t *testing.T) *SlashCommandRegistry {
+	t.Helper()
+	files := map[string]string{"analyze.md": analyzeCommand}
+	for _, name := range []string{"api", "design", "secure", "test", "workflow", "troubleshoot", "onboard", "help"} {
+		files[name+".md"] = "---\ndescription: \"" + name + "\"\n---\n"
+	}
+	files["build.md"] = "---\ndescription: \"Build\"\nwave-enabled: true\nwave-strategy: progressive\n---\n"
+	registry := NewSlashCommandRegistry(writeCommandFiles(t, files))
+	if err := registry.LoadCommands(); err != nil {
+		t.Fatal(err)
+	}
+
+	claudeDir := filepath.Join(t.TempDir(), ".claude")
+	if err := os.MkdirAll(filepath.Join(claudeDir, "agents"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(claudeDir, "agents", "api-backend-specialist.md"), []byte("---\nname: api-backend-specialist\n---\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	registry.SetClaudeDir(claudeDir)
+	return registry
+}
+
+func TestSimulateRoute(t *testing.T) {
+	registry := newRoutingRegistry(t)
+
+	tests := []struct {
+		line         string
+		kind         string
+		agents       []string
+		score        float64
+		orchestrate  bool
+		alternatives []string
+	}{
+		{"/crew:analyze src --focus security", RouteTemplate, []string{"orchestrator-specialist"}, 0, false, []string{"analyzer-persona"}},
+		{"/crew:build", RouteTemplate, []string{"orchestrator-specialist"}, 0, false, nil},
+		{"/crew:onboard", RouteOnboard, []string{"orchestrator-agent"}, 0, false, nil},
+		{"/crew:test the login flow", RoutePending, []string{"qa-persona"}, 0.2, false, []string{"orchestrator-specialist"}},
+		{"/crew:workflow release prep", RouteOrchestrate, []string{"orchestrator-specialist"}, 0, false, nil},
+		{"/crew:help", RouteHelp, nil, 0, false, nil},
+		{"/crew:design the cache layer", RoutePersona, []string{"architect-persona"}, 0, false, []string{"orchestrator-specialist"}},
+		{"/crew:secure the uploads", RoutePersona, []string{"security-persona"}, 0.2, false, []string{"orchestrator-specialist"}},
+		{"/crew:api rate limits", RouteSpecialist, []string{"api-backend-specialist"}, 0, false, []string{"orchestrator-specialist"}},
+		{"/crew:troubleshoot flaky builds", RouteUnrouted, nil, 0, false, []string{"orchestrator-specialist"}},
+		// design + api + secure: three domains, multi-step and cross-domain
+		{"/crew:design the api and secure auth", RouteOrchestrate, []string{"orchestrator-specialist"}, 0.8, true, []string{"architect-persona"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			sim, err := registry.SimulateRoute(tt.line, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if sim.Route.Kind != tt.kind || !reflect.DeepEqual(sim.Route.Agents, tt.agents) {
+				t.Errorf("route = %s %v, want %s %v (%s)", sim.Route.Kind, sim.Route.Agents, tt.kind, tt.agents, sim.Route.Reason)
+			}
+			if diff := sim.Complexity.Score - tt.score; diff > 0.001 || diff < -0.001 {
+				t.Errorf("complexity = %.2f, want %.2f (%v)", sim.Complexity.Score, tt.score, sim.Complexity.Breakdown)
+			}
+			if sim.Complexity.RequiresOrchestration != tt.orchestrate {
+				t.Errorf("requires orchestration = %v, want %v", sim.Complexity.RequiresOrchestration, tt.orchestrate)
+			}
+			var alternatives []string
+			for _, alt := range sim.Alternatives {
+				alternatives = append(alternatives, alt.Agents...)
+			}
+			if !reflect.DeepEqual(alternatives, tt.alternatives) {
+				t.Errorf("alternatives = %v, want %v", alternatives, tt.alternatives)
+			}
+		})
+	}
+}
+
+func TestSimulateRouteBreakdownAndKeywords(t *testing.T) {
+	registry := newRoutingRegistry(t)
+	index := agents.NewIndex([]*agents.Agent{
+		{Name: "qa-persona", Keywords: agents.Keywords{Primary: []string{"test"}, Contextual: []string{"flaky"}}},
+		{Name: "scribe-persona", Keywords: agents.Keywords{Primary: []string{"document"}}},
+	})
+
+	sim, err := registry.SimulateRoute("/crew:troubleshoot flaky tests and then deploy", index)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []ComplexityFactor{{"multi-step", 0.3}, {"test", 0.2}, {"deploy", 0.2}, {"cross-domain", 0.3}}
+	if !reflect.DeepEqual(sim.Complexity.Breakdown, want) {
+		t.Errorf("breakdown = %v, want %v", sim.Complexity.Breakdown, want)
+	}
+	last := sim.Alternatives[len(sim.Alternatives)-1]
+	if last.Kind != RouteKeywords || !reflect.DeepEqual(last.Agents, []string{"qa-persona"}) || !strings.Contains(last.Reason, "test (primary)") {
+		t.Errorf("expected qa-persona as a keyword alternative, got %+v", sim.Alternatives)
+	}
+
+	if _, err := registry.SimulateRoute("/crew:analyze --nope", index); err == nil {
+		t.Error("expected an unknown flag to fail the simulation")
+	}
+}
+
+func TestRouteSimpleCommandMessages(t *testing.T) {
+	registry := newRoutingRegistry(t)
+	for name, want := range map[string]string{
+		"test":   "Task(subagent_type='qa-persona', prompt='Test login flow')",
+		"secure": "Task(subagent_type='security-persona', prompt='Security secure for login flow')",
+		"rest":   "Task(subagent_type='api-backend-specialist', prompt='rest login flow')",
+	} {
+		if got := registry.routeSimpleCommand(name, []string{"login", "flow"}); !strings.HasSuffix(got, want) {
+			t.Errorf("routeSimpleCommand(%s) = %q, want suffix %q", name, got, want)
+		}
+	}
+
+	// Without the specialist, backend work falls back to the persona
+	registry.SetClaudeDir(t.TempDir())
+	want := "Task(subagent_type='backend-persona', prompt='Handle rest task')"
+	if got := registry.routeSimpleCommand("rest", []string{"login", "flow"}); !strings.HasSuffix(got, want) {
+		t.Errorf("routeSimpleCommand(rest) = %q, want suffix %q", got, want)
+	}
+}
@@ -10,6 +10,8 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/jonwraymond/claude-code-super-crew/internal/pathsutil"
+	"github.com/jonwraymond/claude-code-super-crew/internal/prompts"
 	"github.com/jonwraymond/claude-code-super-crew/pkg/logger"
 )
 
@@ -26,6 +28,7 @@ type SlashCommand struct {
 	BestFor       string            `json:"best_for,omitempty"`
 	Complexity    string            `json:"complexity,omitempty"`
 	WaveEnabled   bool              `json:"wave_enabled,omitempty"`
+	Wave          *WaveConfig       `json:"wave,omitempty"`
 }
 
 // CommandArgument represents a command argument with its properties
@@ -41,7 +44,9 @@ type CommandArgument struct {
 type SlashCommandRegistry struct {
 	commands     map[string]*SlashCommand
 	commandsPath string
-	logger       logger.Logger
+	// claudeDir holds the project's prompt template overrides; see SetClaudeDir
+	claudeDir string
+	logger    logger.Logger
 }
 
 // NewSlashCommandRegistry creates a new slash command registry
@@ -53,6 +58,13 @@ func NewSlashCommandRegistry(commandsPath string) *SlashCommandRegistry {
 	}
 }
 
+// SetClaudeDir sets the project .claude directory whose prompts/templates
+// override the built-in prompt templates. It defaults to .claude in the
+// working directory.
+func (r *SlashCommandRegistry) SetClaudeDir(claudeDir string) {
+	r.claudeDir = claudeDir
+}
+
 // LoadCommands discovers and loads all available slash commands from the SuperCrew/Commands directory
 func (r *SlashCommandRegistry) LoadCommands() error {
 	if _, err := os.Stat(r.commandsPath); os.IsNotExist(err) {
@@ -78,181 +90,408 @@ func (r *SlashCommandRegistry) LoadCommands() error {
 		}
 	}
 
-	r.logger.Infof("Loaded %d slash commands", len(r.commands))
+	r.logger.Debugf("Loaded %d slash commands", len(r.commands))
 	return nil
 }
 
-// loadCommand parses a command markdown file and extracts command metadata
+// IncludesDir is the directory, inside the commands directory, holding the
+// shared snippets command files can list under "includes:"
+const IncludesDir = "_includes"
+
+// commandSource is a command file as written, before inheritance is resolved
+type commandSource struct {
+	frontmatter   map[string]string
+	title         string
+	usage         string
+	argumentLines []string
+}
+
+// loadCommand parses a command markdown file and extracts command metadata,
+// resolving the base command it extends and the snippets it includes
 func (r *SlashCommandRegistry) loadCommand(name, filePath string) error {
-	content, err := os.ReadFile(filePath)
+	source, err := r.resolveCommandSource(filePath, nil)
 	if err != nil {
-		return fmt.Errorf("failed to read command file: %w", err)
+		return err
 	}
 
 	command := &SlashCommand{
-		Name: name,
+		Name:        name,
+		Description: source.title,
+		Usage:       source.usage,
 	}
 
-	lines := strings.Split(string(content), "\n")
-	var inFrontMatter bool
-	var frontMatterLines []string
+	// Parse frontmatter for SuperCrew metadata
+	fm := source.frontmatter
+	if tools := parseFrontmatterList(fm["allowed-tools"]); len(tools) > 0 {
+		command.AllowedTools = tools
+	}
+	// The frontmatter description wins over the title of the first header
+	if desc := fm["description"]; desc != "" {
+		command.Description = desc
+	}
+	command.Category = fm["category"]
+	command.Purpose = fm["purpose"]
+	command.AutoActivates = fm["auto-activates"]
+	command.BestFor = fm["best-for"]
+	command.Complexity = fm["complexity"]
+	command.WaveEnabled = fm["wave-enabled"] == "true"
+	wave, warnings := parseWaveConfig(fm)
+	for _, warning := range warnings {
+		r.logger.Warnf("Command %s: %s", name, warning)
+	}
+	command.Wave = wave
 
-	// Parse frontmatter for metadata
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
+	// Parse arguments from usage pattern
+	if command.Usage != "" {
+		command.Arguments = r.parseArguments(command.Usage)
+	}
+	command.Arguments = mergeArgumentSection(command.Arguments, source.argumentLines)
 
-		if line == "---" {
-			if !inFrontMatter {
-				inFrontMatter = true
-				continue
-			} else {
-				break
-			}
-		}
+	// Set default description if none found
+	if command.Description == "" {
+		command.Description = fmt.Sprintf("SuperCrew %s command", name)
+	}
 
-		if inFrontMatter {
-			frontMatterLines = append(frontMatterLines, line)
-			continue
+	r.commands[name] = command
+	return nil
+}
+
+// resolveCommandSource reads filePath and folds in, in order, the command
+// named by "extends:", the snippets listed under "includes:" and finally the
+// file itself. Later sources override earlier frontmatter keys, except that
+// allowed-tools lists are combined; the description, title and usage are
+// never inherited. chain holds the files being resolved, to report cycles.
+func (r *SlashCommandRegistry) resolveCommandSource(filePath string, chain []string) (*commandSource, error) {
+	for _, seen := range chain {
+		if seen == filePath {
+			return nil, fmt.Errorf("inheritance cycle: %s", strings.Join(append(chain, filePath), " -> "))
 		}
+	}
+	chain = append(chain, filePath)
 
-		// Extract description from first header
-		if strings.HasPrefix(line, "# /crew:") && command.Description == "" {
-			parts := strings.SplitN(line, " - ", 2)
-			if len(parts) == 2 {
-				command.Description = parts[1]
+	own, err := parseCommandSource(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved := &commandSource{frontmatter: make(map[string]string)}
+	inherit := func(parent *commandSource) {
+		for key, value := range parent.frontmatter {
+			if key == "description" {
+				continue
 			}
+			resolved.frontmatter[key] = value
 		}
+		resolved.argumentLines = append(resolved.argumentLines, parent.argumentLines...)
+	}
 
-		// Extract usage from usage section
-		if strings.HasPrefix(line, "```") && strings.Contains(strings.ToLower(line), "crew:") {
-			continue
+	var baseUsage string
+	if base := own.frontmatter["extends"]; base != "" {
+		parent, err := r.resolveCommandSource(filepath.Join(r.commandsPath, base+".md"), chain)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve extends %s: %w", base, err)
 		}
-		if strings.HasPrefix(line, "/crew:") && command.Usage == "" {
-			command.Usage = line
+		inherit(parent)
+		baseUsage = parent.usage
+	}
+	for _, include := range parseFrontmatterList(own.frontmatter["includes"]) {
+		snippet, err := r.resolveCommandSource(filepath.Join(r.commandsPath, IncludesDir, include+".md"), chain)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve include %s: %w", include, err)
 		}
+		inherit(snippet)
 	}
 
-	// Parse frontmatter for SuperCrew metadata
-	for _, fmLine := range frontMatterLines {
-		if strings.HasPrefix(fmLine, "allowed-tools:") {
-			toolsStr := strings.TrimPrefix(fmLine, "allowed-tools:")
-			toolsStr = strings.Trim(toolsStr, " []")
-			if toolsStr != "" {
-				tools := strings.Split(toolsStr, ",")
-				for i, tool := range tools {
-					tools[i] = strings.Trim(tool, " \"")
-				}
-				command.AllowedTools = tools
-			}
+	for key, value := range own.frontmatter {
+		if key == "allowed-tools" {
+			value = mergeFrontmatterLists(resolved.frontmatter[key], value)
 		}
-		if strings.HasPrefix(fmLine, "description:") {
-			desc := strings.TrimPrefix(fmLine, "description:")
-			desc = strings.Trim(desc, " \"")
-			if desc != "" && command.Description == "" {
-				command.Description = desc
-			}
+		resolved.frontmatter[key] = value
+	}
+	delete(resolved.frontmatter, "extends")
+	delete(resolved.frontmatter, "includes")
+	resolved.argumentLines = append(resolved.argumentLines, own.argumentLines...)
+	resolved.title = own.title
+	resolved.usage = own.usage
+	if resolved.usage == "" && baseUsage != "" {
+		// A variant without its own usage takes the base usage under its name
+		name := strings.TrimSuffix(filepath.Base(filePath), ".md")
+		resolved.usage = "/crew:" + name
+		if _, args, ok := strings.Cut(baseUsage, " "); ok {
+			resolved.usage += " " + args
 		}
-		if strings.HasPrefix(fmLine, "category:") {
-			category := strings.TrimPrefix(fmLine, "category:")
-			command.Category = strings.Trim(category, " \"")
+	}
+	return resolved, nil
+}
+
+// parseCommandSource reads the frontmatter, header title, usage line and
+// Arguments section of a command file
+func parseCommandSource(filePath string) (*commandSource, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read command file: %w", err)
+	}
+	return parseCommandContent(string(content)), nil
+}
+
+// parseCommandContent parses the content of a command file
+func parseCommandContent(content string) *commandSource {
+	source := &commandSource{frontmatter: make(map[string]string)}
+	lines := strings.Split(content, "\n")
+	var inFrontMatter bool
+	var section string
+
+	for i, line := range lines {
+		line = strings.TrimSpace(line)
+
+		// Frontmatter opens on the first line; later rules are body content
+		if line == "---" && (inFrontMatter || i == 0) {
+			inFrontMatter = !inFrontMatter
+			continue
 		}
-		if strings.HasPrefix(fmLine, "purpose:") {
-			purpose := strings.TrimPrefix(fmLine, "purpose:")
-			command.Purpose = strings.Trim(purpose, " \"")
+
+		if inFrontMatter {
+			if key, value, ok := strings.Cut(line, ":"); ok {
+				source.frontmatter[strings.TrimSpace(key)] = strings.Trim(value, " \"")
+			}
+			continue
 		}
-		if strings.HasPrefix(fmLine, "auto-activates:") {
-			autoActivates := strings.TrimPrefix(fmLine, "auto-activates:")
-			command.AutoActivates = strings.Trim(autoActivates, " \"")
+
+		if strings.HasPrefix(line, "## ") {
+			section = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(line, "## ")))
+			continue
 		}
-		if strings.HasPrefix(fmLine, "best-for:") {
-			bestFor := strings.TrimPrefix(fmLine, "best-for:")
-			command.BestFor = strings.Trim(bestFor, " \"")
+		if section == "arguments" && strings.HasPrefix(line, "- `") {
+			source.argumentLines = append(source.argumentLines, line)
+			continue
 		}
-		if strings.HasPrefix(fmLine, "complexity:") {
-			complexity := strings.TrimPrefix(fmLine, "complexity:")
-			command.Complexity = strings.Trim(complexity, " \"")
+
+		// Extract description from first header
+		if strings.HasPrefix(line, "# /crew:") && source.title == "" {
+			parts := strings.SplitN(line, " - ", 2)
+			if len(parts) == 2 {
+				source.title = parts[1]
+			}
 		}
-		if strings.HasPrefix(fmLine, "wave-enabled:") {
-			waveStr := strings.TrimPrefix(fmLine, "wave-enabled:")
-			waveStr = strings.Trim(waveStr, " \"")
-			command.WaveEnabled = waveStr == "true"
+
+		// Extract usage from usage section
+		if strings.HasPrefix(line, "/crew:") && source.usage == "" {
+			source.usage = line
 		}
 	}
+	return source
+}
 
-	// Parse arguments from usage pattern
-	if command.Usage != "" {
-		command.Arguments = r.parseArguments(command.Usage)
+// parseFrontmatterList splits a "[Read, Grep]" style list
+func parseFrontmatterList(value string) []string {
+	value = strings.Trim(value, " []")
+	if value == "" {
+		return nil
 	}
-
-	// Set default description if none found
-	if command.Description == "" {
-		command.Description = fmt.Sprintf("SuperCrew %s command", name)
+	items := strings.Split(value, ",")
+	for i, item := range items {
+		items[i] = strings.Trim(item, " \"")
 	}
+	return items
+}
 
-	r.commands[name] = command
-	return nil
+// mergeFrontmatterLists combines two lists, keeping the order of first
+// appearance and dropping duplicates
+func mergeFrontmatterLists(base, own string) string {
+	var merged []string
+	seen := make(map[string]bool)
+	for _, item := range append(parseFrontmatterList(base), parseFrontmatterList(own)...) {
+		if !seen[item] {
+			seen[item] = true
+			merged = append(merged, item)
+		}
+	}
+	return "[" + strings.Join(merged, ", ") + "]"
 }
 
-// parseArguments extracts argument information from usage string following SuperCrew patterns
+// parseArguments extracts argument information from usage string following SuperCrew patterns.
+// Bracketed groups are optional; "[--focus a|b]" declares a flag with choices and
+// "[--safe|--aggressive]" alternative flags.
 func (r *SlashCommandRegistry) parseArguments(usage string) []CommandArgument {
 	var args []CommandArgument
 
-	// Enhanced parsing for SuperCrew command patterns
-	parts := strings.Fields(usage)
-	for i, part := range parts {
+	for i, group := range splitUsageGroups(usage) {
 		if i == 0 { // Skip command name
 			continue
 		}
 
-		arg := CommandArgument{
-			Type: "string",
+		optional := strings.HasPrefix(group, "[") && strings.HasSuffix(group, "]")
+		fields := strings.Fields(strings.Trim(group, "[]"))
+		if len(fields) == 0 {
+			continue
 		}
+		head := fields[0]
 
-		if strings.HasPrefix(part, "[") && strings.HasSuffix(part, "]") {
-			// Optional argument like [target] or [--flag]
-			cleaned := strings.Trim(part, "[]")
-			arg.Name = cleaned
-			arg.Required = false
-
-			if strings.HasPrefix(cleaned, "--") {
-				arg.Type = "flag"
-				arg.Description = fmt.Sprintf("Optional flag: %s", cleaned)
+		if !strings.HasPrefix(head, "--") {
+			arg := CommandArgument{Name: head, Type: "string", Required: !optional}
+			if optional {
+				arg.Description = fmt.Sprintf("Optional %s parameter", head)
 			} else {
-				arg.Description = fmt.Sprintf("Optional %s parameter", cleaned)
+				arg.Description = fmt.Sprintf("Required %s parameter", head)
 			}
-		} else if strings.HasPrefix(part, "--") {
-			// Flag argument like --focus, --type
-			arg.Name = part
-			arg.Type = "flag"
-			arg.Required = false
-
-			// Add common flag choices based on SuperCrew documentation
-			switch part {
+			args = append(args, arg)
+			continue
+		}
+
+		for _, name := range strings.Split(head, "|") {
+			if !strings.HasPrefix(name, "--") {
+				continue
+			}
+			arg := CommandArgument{Name: name, Type: "flag"}
+			if len(fields) > 1 && strings.Contains(fields[1], "|") {
+				arg.Choices = completionChoices(strings.Split(fields[1], "|"))
+			}
+			if arg.Choices == nil {
+				// Add common flag choices based on SuperCrew documentation
+				switch name {
+				case "--focus":
+					arg.Choices = []string{"quality", "security", "performance", "architecture"}
+				case "--type":
+					arg.Choices = []string{"component", "api", "service", "feature", "architecture"}
+				case "--scope":
+					arg.Choices = []string{"file", "module", "project", "system"}
+				}
+			}
+			if arg.Choices != nil {
+				arg.Type = "choice"
+			}
+			switch name {
 			case "--focus":
-				arg.Choices = []string{"quality", "security", "performance", "architecture"}
 				arg.Description = "Focus area for analysis or improvement"
 			case "--type":
-				arg.Choices = []string{"component", "api", "service", "feature", "architecture"}
 				arg.Description = "Type of implementation or design"
 			case "--scope":
-				arg.Choices = []string{"file", "module", "project", "system"}
 				arg.Description = "Scope of operation"
 			default:
-				arg.Description = fmt.Sprintf("Flag: %s", part)
+				arg.Description = fmt.Sprintf("Flag: %s", name)
 			}
-		} else {
-			// Required positional argument
-			arg.Name = part
-			arg.Required = true
-			arg.Description = fmt.Sprintf("Required %s parameter", part)
+			args = append(args, arg)
 		}
+	}
 
-		args = append(args, arg)
+	return args
+}
+
+// splitUsageGroups splits a usage line into words, keeping each bracketed
+// group such as "[--depth quick|deep]" together
+func splitUsageGroups(usage string) []string {
+	var groups []string
+	var current strings.Builder
+	depth := 0
+	for _, c := range usage {
+		switch {
+		case c == '[':
+			depth++
+		case c == ']' && depth > 0:
+			depth--
+		case (c == ' ' || c == '\t') && depth == 0:
+			if current.Len() > 0 {
+				groups = append(groups, current.String())
+				current.Reset()
+			}
+			continue
+		}
+		current.WriteRune(c)
+	}
+	if current.Len() > 0 {
+		groups = append(groups, current.String())
 	}
+	return groups
+}
 
+// mergeArgumentSection folds the "## Arguments" list of a command file, lines
+// like "- `--depth` - Analysis depth (quick, deep)", into the arguments parsed
+// from its usage. Entries give descriptions, and choices to flags whose usage
+// did not list any; flags only documented there are added as optional.
+func mergeArgumentSection(args []CommandArgument, lines []string) []CommandArgument {
+	for _, line := range lines {
+		rest := strings.TrimPrefix(line, "- `")
+		end := strings.Index(rest, "`")
+		if end <= 0 {
+			continue
+		}
+		name := rest[:end]
+		description := strings.TrimSpace(strings.TrimLeft(rest[end+1:], " -"))
+
+		index := -1
+		for i := range args {
+			if args[i].Name == name {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			if !strings.HasPrefix(name, "--") || strings.ContainsAny(name, " |") {
+				continue
+			}
+			args = append(args, CommandArgument{Name: name, Type: "flag"})
+			index = len(args) - 1
+		}
+
+		arg := &args[index]
+		if description != "" {
+			arg.Description = description
+		}
+		if arg.Type != "string" && len(arg.Choices) == 0 {
+			if choices := parenthesizedChoices(description); choices != nil {
+				arg.Choices = choices
+				arg.Type = "choice"
+			}
+		}
+	}
 	return args
 }
 
+// parenthesizedChoices returns the words of a trailing "(a, b, c)" list, or
+// nil when there is none or it reads as prose rather than values
+func parenthesizedChoices(description string) []string {
+	open := strings.LastIndex(description, "(")
+	if open < 0 || !strings.HasSuffix(description, ")") {
+		return nil
+	}
+	words := strings.Split(description[open+1:len(description)-1], ",")
+	for i, word := range words {
+		words[i] = strings.TrimSpace(word)
+	}
+	choices := completionChoices(words)
+	if len(choices) < 2 || len(choices) < len(words)-1 {
+		return nil
+	}
+	return choices
+}
+
+// completionChoices keeps the words that can be offered as values, dropping
+// a trailing "etc" and anything that is not a plain word
+func completionChoices(words []string) []string {
+	var choices []string
+	for _, word := range words {
+		if word == "etc" || word == "etc." || !isCompletionWord(word) {
+			continue
+		}
+		choices = append(choices, word)
+	}
+	return choices
+}
+
+// isCompletionWord reports whether word is safe to emit unquoted into a
+// completion script
+func isCompletionWord(word string) bool {
+	if word == "" {
+		return false
+	}
+	for _, c := range word {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_' || c == '.') {
+			return false
+		}
+	}
+	return true
+}
+
 // GetCommand returns a command by name
 func (r *SlashCommandRegistry) GetCommand(name string) (*SlashCommand, bool) {
 	cmd, exists := r.commands[name]
@@ -335,7 +574,7 @@ func (r *SlashCommandRegistry) executeCommandHandler(name string, args []string,
 		return r.handleImproveCommand(args)
 	case "test":
 		return r.handleTestCommand(args)
-	case "load":
+	case "load", "onboard":
 		return r.handleLoadCommand(args)
 	case "implement":
 		return r.handleImplementCommand(args)
@@ -346,28 +585,29 @@ func (r *SlashCommandRegistry) executeCommandHandler(name string, args []string,
 
 // Command handlers - these can be expanded with full implementations
 
+// printTask prints the Task tool invocation of a command for Claude Code
+func (r *SlashCommandRegistry) printTask(name string, args []string) error {
+	command, ok := r.GetCommand(name)
+	if !ok {
+		command = &SlashCommand{Name: name}
+	}
+	task, err := r.taskFor(command, args)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("\nExecuting /crew:%s...\n", name)
+	fmt.Println(task.Task())
+	return nil
+}
+
 func (r *SlashCommandRegistry) handleAnalyzeCommand(args []string) error {
 	r.logger.Info("Executing analyze command via slash interface")
-	
-	// Generate the Task tool invocation for Claude Code
-	prompt := fmt.Sprintf("You are the orchestrator-specialist for Claude Code Super Crew. Execute /crew:analyze by chaining agents: 1) analyzer-persona for comprehensive analysis, 2) go-backend-specialist for Go-specific insights, 3) scribe-persona for clear documentation. Follow the mandatory sub-agent chaining framework. Args: %s", strings.Join(args, " "))
-	
-	fmt.Printf("\nExecuting /crew:analyze...\n")
-	fmt.Printf("Task(description='Analyze project code and architecture', prompt='%s', subagent_type='orchestrator-specialist')\n", prompt)
-	
-	return nil
+	return r.printTask("analyze", args)
 }
 
 func (r *SlashCommandRegistry) handleBuildCommand(args []string) error {
 	r.logger.Info("Executing build command via slash interface")
-	
-	// Generate the Task tool invocation for Claude Code
-	prompt := fmt.Sprintf("You are the orchestrator-specialist for Claude Code Super Crew. Execute /crew:build by chaining agents: 1) analyzer-persona for build requirements analysis, 2) go-backend-specialist for Go build implementation, 3) qa-persona for build validation. Use the project's Makefile and Go module system. Args: %s", strings.Join(args, " "))
-	
-	fmt.Printf("\nExecuting /crew:build...\n")
-	fmt.Printf("Task(description='Build and compile Go project', prompt='%s', subagent_type='orchestrator-specialist')\n", prompt)
-	
-	return nil
+	return r.printTask("build", args)
 }
 
 func (r *SlashCommandRegistry) handleInstallCommand(args []string) error {
@@ -420,7 +660,10 @@ func (r *SlashCommandRegistry) handleLoadCommand(args []string) error {
 	}
 
 	// Generate orchestrator metaprompt for project analysis and setup
-	metaprompt := r.generateLoadCommandMetaprompt(targetDir)
+	metaprompt, err := r.generateLoadCommandMetaprompt(targetDir)
+	if err != nil {
+		return err
+	}
 
 	// Route to the global orchestrator via Task tool
 	r.logger.Info("🎯 Delegating to global orchestrator agent...")
@@ -437,58 +680,26 @@ func (r *SlashCommandRegistry) handleLoadCommand(args []string) error {
 
 func (r *SlashCommandRegistry) handleImplementCommand(args []string) error {
 	r.logger.Info("Executing implement command via slash interface")
-	
-	// Generate the Task tool invocation for Claude Code
-	prompt := fmt.Sprintf("You are the orchestrator-specialist for Claude Code Super Crew. Execute /crew:implement by chaining agents based on the feature type: 1) analyzer-persona for requirements analysis, 2) appropriate specialist (go-backend-specialist for Go code, frontend-persona for UI), 3) qa-persona for testing. Args: %s", strings.Join(args, " "))
-	
-	fmt.Printf("\nExecuting /crew:implement...\n")
-	fmt.Printf("Task(description='Implement feature or component', prompt='%s', subagent_type='orchestrator-specialist')\n", prompt)
-	
-	return nil
+	return r.printTask("implement", args)
 }
 
-// generateLoadCommandMetaprompt creates the metaprompt for /crew:onboard orchestrator delegation
-func (r *SlashCommandRegistry) generateLoadCommandMetaprompt(targetDir string) string {
-	return fmt.Sprintf(`You are the global orchestrator agent from ~/.claude/agents/orchestrator.agent.md. 
-
-TASK: Execute the /crew:onboard command for project analysis and setup.
-
-PROJECT DIRECTORY: %s
-
-INSTRUCTIONS:
-1. **Project Analysis Phase**:
-   - Use Glob to discover all source files and project structure
-   - Count files by extension to determine primary languages
-   - Use Read to examine key configuration files (go.mod, package.json, etc.)
-   - Use Grep to identify architectural patterns and frameworks
-   - Analyze project complexity and development patterns
-
-2. **Local Orchestrator Creation**:
-   - Create/update .claude/agents/orchestrator-specialist.md based on the global template
-   - Customize it with project-specific routing rules and context
-   - Include project analysis findings and recommended workflows
-
-3. **Specialist Generation** (only if patterns justify it):
-   - Generate project-specific specialists for REPEATED complex patterns
-   - Examples: go-specialist (if 20+ Go files), api-specialist (if 10+ API endpoints)
-   - Each specialist should be in .claude/agents/[name]-specialist.md
-
-4. **Shadow Command Creation** (if beneficial):
-   - Create enhanced versions of global commands in .claude/commands/shadows/
-   - Only for commands that benefit from project-specific customization
-
-5. **Project Configuration**:
-   - Update .claude/project-config.json with analysis results
-   - Include detected languages, frameworks, and architectural patterns
-
-6. **Completion Report**:
-   - Summarize what was created/updated
-   - List available agents and their specialties
-   - Recommend next steps for the user
-
-CONTEXT: This is the initial project setup. Be intelligent about what specialists and shadow commands are actually needed based on real patterns in the codebase, not just assumptions.
-
-Execute this comprehensive project analysis and setup now.`, targetDir)
+// generateLoadCommandMetaprompt renders the onboard prompt template for
+// /crew:onboard orchestrator delegation, including the analysis the project
+// in targetDir already recorded
+func (r *SlashCommandRegistry) generateLoadCommandMetaprompt(targetDir string) (string, error) {
+	claudeDir := r.claudeDir
+	if claudeDir == "" {
+		claudeDir = pathsutil.ClaudeDir(targetDir)
+	}
+	data := prompts.Data{
+		Command:    "onboard",
+		ProjectDir: targetDir,
+		Analysis:   prompts.LoadAnalysis(targetDir),
+	}
+	if command, ok := r.GetCommand("onboard"); ok {
+		data.Wave = command.Wave.promptData()
+	}
+	return prompts.Render("onboard", claudeDir, data)
 }
 
 func (r *SlashCommandRegistry) handleGenericCommand(name string, args []string, command *SlashCommand) error {
@@ -510,10 +721,10 @@ func (r *SlashCommandRegistry) handleGenericCommand(name string, args []string,
 // suggestAgentForCommand analyzes command complexity and routes appropriately
 func (r *SlashCommandRegistry) suggestAgentForCommand(name string, args []string) string {
 	// Check for explicit orchestration commands
-	switch name {
-	case "orchestrate", "chain", "workflow", "multimodal":
+	switch {
+	case contains(orchestratorCommands, name):
 		return r.handleOrchestratorCommand(name, args)
-	case "agent-help", "help":
+	case name == "agent-help" || name == "help":
 		return r.showAvailableAgents()
 	}
 
@@ -535,12 +746,20 @@ Or continue with single agent routing if you prefer.`,
 	return r.routeSimpleCommand(name, args)
 }
 
+// A command needs orchestration from a complexity score of
+// OrchestrationThreshold, or once it touches OrchestrationDomains domains
+const (
+	OrchestrationThreshold = 0.7
+	OrchestrationDomains   = 3
+)
+
 // analyzeCommandComplexity determines if orchestration is beneficial
 func (r *SlashCommandRegistry) analyzeCommandComplexity(name string, args []string) CommandComplexity {
 	complexity := CommandComplexity{
 		Score:      0.0,
 		Indicators: []string{},
 		Domains:    []string{},
+		Breakdown:  []ComplexityFactor{},
 	}
 
 	// Check for multi-domain indicators
@@ -548,16 +767,14 @@ func (r *SlashCommandRegistry) analyzeCommandComplexity(name string, args []stri
 
 	// Multi-step indicators
 	if strings.Contains(fullCommand, " and ") || strings.Contains(fullCommand, " then ") {
-		complexity.Score += 0.3
-		complexity.Indicators = append(complexity.Indicators, "multi-step")
+		complexity.add("multi-step", 0.3)
 	}
 
 	// Cross-domain keywords
 	crossDomainKeywords := []string{"full", "complete", "entire", "comprehensive", "integrate", "secure", "test", "deploy"}
 	for _, keyword := range crossDomainKeywords {
 		if strings.Contains(strings.ToLower(fullCommand), keyword) {
-			complexity.Score += 0.2
-			complexity.Indicators = append(complexity.Indicators, keyword)
+			complexity.add(keyword, 0.2)
 		}
 	}
 
@@ -580,11 +797,10 @@ func (r *SlashCommandRegistry) analyzeCommandComplexity(name string, args []stri
 
 	// Multiple domains = higher complexity
 	if len(complexity.Domains) >= 2 {
-		complexity.Score += 0.3
-		complexity.Indicators = append(complexity.Indicators, "cross-domain")
+		complexity.add("cross-domain", 0.3)
 	}
 
-	complexity.RequiresOrchestration = complexity.Score >= 0.7 || len(complexity.Domains) >= 3
+	complexity.RequiresOrchestration = complexity.Score >= OrchestrationThreshold || len(complexity.Domains) >= OrchestrationDomains
 
 	return complexity
 }
@@ -592,39 +808,20 @@ func (r *SlashCommandRegistry) analyzeCommandComplexity(name string, args []stri
 // routeSimpleCommand handles single-domain routing
 func (r *SlashCommandRegistry) routeSimpleCommand(name string, args []string) string {
 	// Check if project has specialists (only check, don't hardcode)
-	agentsDir := ".claude/agents"
-	specialists := r.listProjectSpecialists(agentsDir)
+	specialists := r.listProjectSpecialists(filepath.Join(r.projectClaudeDir(), pathsutil.AgentsDirName))
 
 	// Route based on command to personas (no hardcoded specialists)
-	switch name {
-	case "go", "backend", "api", "endpoint", "rest":
-		// Check if a relevant specialist exists
-		for _, specialist := range specialists {
-			if strings.Contains(specialist, "backend") || strings.Contains(specialist, name) {
-				return fmt.Sprintf("🎯 [Orchestrator]: Found local %s. Use: Task(subagent_type='%s', prompt='%s')",
-					specialist, specialist, strings.Join(append([]string{name}, args...), " "))
-			}
-		}
-		// Default to persona
-		return "🎯 [Orchestrator]: Using backend-persona. Use: Task(subagent_type='backend-persona', prompt='Handle " + name + " task')"
-
-	case "test", "validate", "qa":
-		return "🎯 [Orchestrator]: Using qa-persona. Use: Task(subagent_type='qa-persona', prompt='Test " + strings.Join(args, " ") + "')"
-
-	case "analyze", "investigate", "debug":
-		return "🎯 [Orchestrator]: Using analyzer-persona. Use: Task(subagent_type='analyzer-persona', prompt='Analyze " + strings.Join(args, " ") + "')"
-
-	case "document", "docs", "readme":
-		return "🎯 [Orchestrator]: Using scribe-persona. Use: Task(subagent_type='scribe-persona', prompt='Document " + strings.Join(args, " ") + "')"
-
-	case "design", "architecture":
-		return "🎯 [Orchestrator]: Using architect-persona. Use: Task(subagent_type='architect-persona', prompt='Design " + strings.Join(args, " ") + "')"
-
-	case "optimize", "profile", "performance":
-		return "🎯 [Orchestrator]: Using performance-persona. Use: Task(subagent_type='performance-persona', prompt='Optimize " + strings.Join(args, " ") + "')"
-
-	case "secure", "audit", "vulnerability":
-		return "🎯 [Orchestrator]: Using security-persona. Use: Task(subagent_type='security-persona', prompt='Security " + name + " for " + strings.Join(args, " ") + "')"
+	route := simpleRoute(name, specialists)
+	switch route.Kind {
+	case RouteSpecialist:
+		specialist := route.Agents[0]
+		return fmt.Sprintf("🎯 [Orchestrator]: Found local %s. Use: Task(subagent_type='%s', prompt='%s')",
+			specialist, specialist, strings.Join(append([]string{name}, args...), " "))
+
+	case RoutePersona:
+		persona := route.Agents[0]
+		prompt := fmt.Sprintf(personaRuleFor(name).prompt, name, strings.Join(args, " "))
+		return fmt.Sprintf("🎯 [Orchestrator]: Using %s. Use: Task(subagent_type='%s', prompt='%s')", persona, persona, prompt)
 
 	default:
 		return fmt.Sprintf(`🎯 [Orchestrator]: Analyzing request...
@@ -657,10 +854,23 @@ func (r *SlashCommandRegistry) handleOrchestratorCommand(name string, args []str
 
 // Helper types
 type CommandComplexity struct {
-	Score                 float64
-	RequiresOrchestration bool
-	Indicators            []string
-	Domains               []string
+	Score                 float64            `json:"score"`
+	RequiresOrchestration bool               `json:"requires_orchestration"`
+	Indicators            []string           `json:"indicators"`
+	Domains               []string           `json:"domains"`
+	Breakdown             []ComplexityFactor `json:"breakdown"`
+}
+
+// ComplexityFactor is one indicator and what it added to the score
+type ComplexityFactor struct {
+	Indicator string  `json:"indicator"`
+	Points    float64 `json:"points"`
+}
+
+func (c *CommandComplexity) add(indicator string, points float64) {
+	c.Score += points
+	c.Indicators = append(c.Indicators, indicator)
+	c.Breakdown = append(c.Breakdown, ComplexityFactor{Indicator: indicator, Points: points})
 }
 
 // listProjectSpecialists dynamically lists specialists in project
@@ -696,8 +906,7 @@ func (r *SlashCommandRegistry) showAvailableAgents() string {
 	output.WriteString("🎯 [Orchestrator]: Available agents for this project:\n\n")
 
 	// Check for project specialists
-	agentsDir := ".claude/agents"
-	specialists := r.listProjectSpecialists(agentsDir)
+	specialists := r.listProjectSpecialists(filepath.Join(r.projectClaudeDir(), pathsutil.AgentsDirName))
 
 	// Show project-level orchestrator (always present)
 	output.WriteString("PROJECT-LEVEL AGENT (Always installed):\n")
@@ -772,27 +981,89 @@ func (r *SlashCommandRegistry) GenerateCompletionScript(shell string) (string, e
 	}
 }
 
+// completionFlags returns the flags of cmd that are safe to complete
+func completionFlags(cmd *SlashCommand) []CommandArgument {
+	var flags []CommandArgument
+	for _, arg := range cmd.Arguments {
+		if strings.HasPrefix(arg.Name, "--") && isCompletionWord(strings.TrimPrefix(arg.Name, "--")) {
+			flags = append(flags, arg)
+		}
+	}
+	return flags
+}
+
 func (r *SlashCommandRegistry) generateBashCompletion(commands []*SlashCommand) string {
 	var script strings.Builder
 
 	script.WriteString("# SuperCrew bash completion\n")
+	script.WriteString("# Completes /crew: commands typed as claude arguments, then their flags and flag values\n")
 	script.WriteString("_crew_complete() {\n")
-	script.WriteString("    local cur prev opts\n")
+	script.WriteString("    local cur prev cmd word opts\n")
 	script.WriteString("    COMPREPLY=()\n")
-	script.WriteString("    cur=\"${COMP_WORDS[COMP_CWORD]}\"\n")
-	script.WriteString("    prev=\"${COMP_WORDS[COMP_CWORD-1]}\"\n")
-
-	script.WriteString("    if [[ ${cur} == /crew:* ]]; then\n")
-	script.WriteString("        opts=\"")
-	for _, cmd := range commands {
-		script.WriteString(fmt.Sprintf("/crew:%s ", cmd.Name))
+	// COMP_WORDS splits at the colon of /crew:, so words are read from the line itself
+	script.WriteString("    local -a words\n")
+	script.WriteString("    read -ra words <<< \"${COMP_LINE:0:COMP_POINT}\"\n")
+	script.WriteString("    if [[ \"${COMP_LINE:0:COMP_POINT}\" == *[[:space:]] ]]; then\n")
+	script.WriteString("        words+=(\"\")\n")
+	script.WriteString("    fi\n")
+	script.WriteString("    (( ${#words[@]} < 2 )) && return 0\n")
+	script.WriteString("    cur=\"${words[${#words[@]}-1]}\"\n")
+	script.WriteString("    prev=\"${words[${#words[@]}-2]}\"\n")
+	script.WriteString("    for word in \"${words[@]:1:${#words[@]}-2}\"; do\n")
+	script.WriteString("        if [[ ${word} == /crew:* ]]; then\n")
+	script.WriteString("            cmd=\"${word#/crew:}\"\n")
+	script.WriteString("            break\n")
+	script.WriteString("        fi\n")
+	script.WriteString("    done\n\n")
+
+	script.WriteString("    if [[ -z ${cmd} ]]; then\n")
+	script.WriteString("        if [[ ${cur} == /* ]]; then\n")
+	script.WriteString("            opts=\"")
+	for i, cmd := range commands {
+		if i > 0 {
+			script.WriteString(" ")
+		}
+		script.WriteString(fmt.Sprintf("/crew:%s", cmd.Name))
 	}
 	script.WriteString("\"\n")
-	script.WriteString("        COMPREPLY=( $(compgen -W \"${opts}\" -- ${cur}) )\n")
-	script.WriteString("        return 0\n")
+	script.WriteString("            COMPREPLY=( $(compgen -W \"${opts}\" -- \"${cur}\") )\n")
+	script.WriteString("        fi\n")
+	script.WriteString("    else\n")
+	script.WriteString("        case \"${cmd}\" in\n")
+	for _, cmd := range commands {
+		flags := completionFlags(cmd)
+		if len(flags) == 0 {
+			continue
+		}
+		script.WriteString(fmt.Sprintf("            %s)\n", cmd.Name))
+		script.WriteString("                case \"${prev}\" in\n")
+		for _, flag := range flags {
+			if len(flag.Choices) > 0 {
+				script.WriteString(fmt.Sprintf("                    %s) opts=\"%s\"; COMPREPLY=( $(compgen -W \"${opts}\" -- \"${cur}\") ); return 0 ;;\n", flag.Name, strings.Join(flag.Choices, " ")))
+			}
+		}
+		script.WriteString("                esac\n")
+		var names []string
+		for _, flag := range flags {
+			names = append(names, flag.Name)
+		}
+		script.WriteString(fmt.Sprintf("                opts=\"%s\"\n", strings.Join(names, " ")))
+		script.WriteString("                ;;\n")
+	}
+	script.WriteString("        esac\n")
+	script.WriteString("        if [[ ${cur} == -* ]]; then\n")
+	script.WriteString("            COMPREPLY=( $(compgen -W \"${opts}\" -- \"${cur}\") )\n")
+	script.WriteString("        fi\n")
+	script.WriteString("    fi\n\n")
+
+	// Replies replace only the part of the word after the last colon
+	script.WriteString("    if [[ ${COMP_WORDBREAKS} == *:* && ${cur} == *:* ]]; then\n")
+	script.WriteString("        local colon_prefix=\"${cur%\"${cur##*:}\"}\"\n")
+	script.WriteString("        COMPREPLY=( \"${COMPREPLY[@]#\"${colon_prefix}\"}\" )\n")
 	script.WriteString("    fi\n")
+	script.WriteString("    return 0\n")
 	script.WriteString("}\n")
-	script.WriteString("complete -F _crew_complete claude\n")
+	script.WriteString("complete -o default -F _crew_complete claude\n")
 
 	return script.String()
 }
@@ -800,38 +1071,105 @@ func (r *SlashCommandRegistry) generateBashCompletion(commands []*SlashCommand)
 func (r *SlashCommandRegistry) generateZshCompletion(commands []*SlashCommand) string {
 	var script strings.Builder
 
-	script.WriteString("# SuperCrew zsh completion\n")
 	script.WriteString("#compdef claude\n")
+	script.WriteString("# SuperCrew zsh completion\n")
 
 	script.WriteString("_crew_commands() {\n")
 	script.WriteString("    local commands=(")
 	for _, cmd := range commands {
-		script.WriteString(fmt.Sprintf("\n        '/crew:%s:%s'", cmd.Name, cmd.Description))
+		script.WriteString(fmt.Sprintf("\n        '/crew\\:%s:%s'", cmd.Name, zshQuote(cmd.Description)))
 	}
 	script.WriteString("\n    )\n")
 	script.WriteString("    _describe 'commands' commands\n")
-	script.WriteString("}\n")
+	script.WriteString("}\n\n")
+
+	script.WriteString("_crew_arguments() {\n")
+	script.WriteString("    local cmd=$1 prev=${words[CURRENT-1]}\n")
+	script.WriteString("    local -a flags\n")
+	script.WriteString("    case $cmd in\n")
+	for _, cmd := range commands {
+		flags := completionFlags(cmd)
+		if len(flags) == 0 {
+			continue
+		}
+		script.WriteString(fmt.Sprintf("        %s)\n", cmd.Name))
+		script.WriteString("            case $prev in\n")
+		for _, flag := range flags {
+			if len(flag.Choices) > 0 {
+				script.WriteString(fmt.Sprintf("                %s) compadd -- %s; return ;;\n", flag.Name, strings.Join(flag.Choices, " ")))
+			}
+		}
+		script.WriteString("            esac\n")
+		script.WriteString("            flags=(")
+		for _, flag := range flags {
+			script.WriteString(fmt.Sprintf("\n                '%s:%s'", flag.Name, zshQuote(flag.Description)))
+		}
+		script.WriteString("\n            )\n")
+		script.WriteString("            ;;\n")
+	}
+	script.WriteString("    esac\n")
+	script.WriteString("    if [[ $PREFIX == -* ]]; then\n")
+	script.WriteString("        _describe 'flags' flags\n")
+	script.WriteString("    else\n")
+	script.WriteString("        _files\n")
+	script.WriteString("    fi\n")
+	script.WriteString("}\n\n")
 
 	script.WriteString("_claude() {\n")
-	script.WriteString("    if [[ $words[CURRENT] == /crew:* ]]; then\n")
+	script.WriteString("    local word\n")
+	script.WriteString("    for word in ${words[2,CURRENT-1]}; do\n")
+	script.WriteString("        if [[ $word == /crew:* ]]; then\n")
+	script.WriteString("            _crew_arguments ${word#/crew:}\n")
+	script.WriteString("            return\n")
+	script.WriteString("        fi\n")
+	script.WriteString("    done\n")
+	script.WriteString("    if [[ $words[CURRENT] == /* ]]; then\n")
 	script.WriteString("        _crew_commands\n")
+	script.WriteString("    else\n")
+	script.WriteString("        _files\n")
 	script.WriteString("    fi\n")
 	script.WriteString("}\n")
+	script.WriteString("compdef _claude claude\n")
 
 	return script.String()
 }
 
+// zshQuote escapes s for a single-quoted _describe entry
+func zshQuote(s string) string {
+	return strings.ReplaceAll(s, "'", "'\\''")
+}
+
 func (r *SlashCommandRegistry) generateFishCompletion(commands []*SlashCommand) string {
 	var script strings.Builder
 
+	var names []string
+	for _, cmd := range commands {
+		names = append(names, fmt.Sprintf("/crew:%s", cmd.Name))
+	}
+	seen := fmt.Sprintf("__fish_seen_subcommand_from %s", strings.Join(names, " "))
+
 	script.WriteString("# SuperCrew fish completion\n")
 	for _, cmd := range commands {
-		script.WriteString(fmt.Sprintf("complete -c claude -x -a '/crew:%s' -d '%s'\n", cmd.Name, cmd.Description))
+		script.WriteString(fmt.Sprintf("complete -c claude -n 'not %s' -x -a '/crew:%s' -d '%s'\n", seen, cmd.Name, fishQuote(cmd.Description)))
+	}
+	for _, cmd := range commands {
+		for _, flag := range completionFlags(cmd) {
+			line := fmt.Sprintf("complete -c claude -n '__fish_seen_subcommand_from /crew:%s' -l %s", cmd.Name, strings.TrimPrefix(flag.Name, "--"))
+			if len(flag.Choices) > 0 {
+				line += fmt.Sprintf(" -x -a '%s'", strings.Join(flag.Choices, " "))
+			}
+			script.WriteString(fmt.Sprintf("%s -d '%s'\n", line, fishQuote(flag.Description)))
+		}
 	}
 
 	return script.String()
 }
 
+// fishQuote escapes s for a single-quoted fish string
+func fishQuote(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "'", `\'`).Replace(s)
+}
+
 // ExportCommandsJSON exports all commands as JSON for Claude Code integration
 func (r *SlashCommandRegistry) ExportCommandsJSON() ([]byte, error) {
 	commands := r.ListCommands()
@@ -0,0 +1,146 @@
+package claude
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const analyzeCommand = "---\ndescription: \"Analyze code\"\n---\n\n# /crew:analyze - Code Analysis\n\n## Usage\n```\n/crew:analyze [target] [--focus quality|security] [--safe|--aggressive]\n```\n\n## Arguments\n- `target` - Files to analyze\n- `--focus` - Analysis focus area\n- `--format` - Output format (text, json, report)\n- `--verbose` - Detailed output (default)\n\n---\n"
+
+func newTestRegistry(t *testing.T) *SlashCommandRegistry {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "analyze.md"), []byte(analyzeCommand), 0644); err != nil {
+		t.Fatal(err)
+	}
+	registry := NewSlashCommandRegistry(dir)
+	if err := registry.LoadCommands(); err != nil {
+		t.Fatal(err)
+	}
+	return registry
+}
+
+func TestLoadCommandArguments(t *testing.T) {
+	command, ok := newTestRegistry(t).GetCommand("analyze")
+	if !ok {
+		t.Fatal("analyze was not loaded")
+	}
+	if command.Description != "Analyze code" {
+		t.Errorf("Expected the frontmatter description, got %q", command.Description)
+	}
+
+	want := map[string][]string{
+		"target":       nil,
+		"--focus":      {"quality", "security"},
+		"--safe":       nil,
+		"--aggressive": nil,
+		"--format":     {"text", "json", "report"},
+		"--verbose":    nil,
+	}
+	if len(command.Arguments) != len(want) {
+		t.Fatalf("Expected %d arguments, got %+v", len(want), command.Arguments)
+	}
+	for _, arg := range command.Arguments {
+		choices, ok := want[arg.Name]
+		if !ok {
+			t.Errorf("Unexpected argument %q", arg.Name)
+			continue
+		}
+		if !reflect.DeepEqual(arg.Choices, choices) {
+			t.Errorf("Expected %s choices %v, got %v", arg.Name, choices, arg.Choices)
+		}
+	}
+	if command.Arguments[1].Description != "Analysis focus area" {
+		t.Errorf("Expected the Arguments section to describe --focus, got %q", command.Arguments[1].Description)
+	}
+}
+
+func TestGenerateCompletionScriptCompletesArguments(t *testing.T) {
+	registry := newTestRegistry(t)
+	for shell, fragments := range map[string][]string{
+		"bash": {"/crew:analyze", "--focus) opts=\"quality security\"", "opts=\"--focus --safe --aggressive --format --verbose\""},
+		"zsh":  {"'/crew\\:analyze:Analyze code'", "--format) compadd -- text json report", "'--safe:Flag: --safe'"},
+		"fish": {"-x -a '/crew:analyze'", "-n '__fish_seen_subcommand_from /crew:analyze' -l focus -x -a 'quality security'"},
+	} {
+		script, err := registry.GenerateCompletionScript(shell)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, fragment := range fragments {
+			if !strings.Contains(script, fragment) {
+				t.Errorf("%s completion is missing %q:\n%s", shell, fragment, script)
+			}
+		}
+	}
+}
+
+func writeCommandFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestLoadCommandResolvesExtendsAndIncludes(t *testing.T) {
+	dir := writeCommandFiles(t, map[string]string{
+		"analyze.md":             analyzeCommand,
+		"review.md":              "---\nextends: analyze\nincludes: [wave]\nallowed-tools: [Read, Edit]\ndescription: \"Review changes\"\n---\n\n## Arguments\n- `--diff` - Review only the diff\n",
+		IncludesDir + "/wave.md": "---\nallowed-tools: [TodoWrite]\nwave-enabled: true\ncomplexity: advanced\n---\n\n## Arguments\n- `--wave-mode` - Enable waves\n",
+	})
+	registry := NewSlashCommandRegistry(dir)
+	if err := registry.LoadCommands(); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := registry.GetCommand(IncludesDir); ok {
+		t.Error("Snippets should not load as commands")
+	}
+
+	review, ok := registry.GetCommand("review")
+	if !ok {
+		t.Fatal("review was not loaded")
+	}
+	if review.Description != "Review changes" || !review.WaveEnabled || review.Complexity != "advanced" {
+		t.Errorf("Unexpected review metadata %+v", review)
+	}
+	if !reflect.DeepEqual(review.AllowedTools, []string{"TodoWrite", "Read", "Edit"}) {
+		t.Errorf("Expected the snippet tools combined with the command's, got %v", review.AllowedTools)
+	}
+	if !strings.HasPrefix(review.Usage, "/crew:review [target]") {
+		t.Errorf("Expected the base usage under the variant's name, got %q", review.Usage)
+	}
+	names := make(map[string]bool)
+	for _, arg := range review.Arguments {
+		names[arg.Name] = true
+	}
+	for _, name := range []string{"--focus", "--format", "--wave-mode", "--diff"} {
+		if !names[name] {
+			t.Errorf("Expected review to have %s, got %+v", name, review.Arguments)
+		}
+	}
+}
+
+func TestLoadCommandRejectsInheritanceCycles(t *testing.T) {
+	dir := writeCommandFiles(t, map[string]string{
+		"a.md": "---\nextends: b\n---\n",
+		"b.md": "---\nextends: a\n---\n",
+		"c.md": "---\nextends: missing\n---\n",
+	})
+	registry := NewSlashCommandRegistry(dir)
+	if err := registry.loadCommand("c", filepath.Join(dir, "c.md")); err == nil {
+		t.Error("Expected a missing base command to fail")
+	}
+	if err := registry.loadCommand("a", filepath.Join(dir, "a.md")); err == nil || !strings.Contains(err.Error(), "cycle") {
+		t.Errorf("Expected a cycle error, got %v", err)
+	}
+}
@@ -0,0 +1,86 @@
+package claude
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/prompts"
+)
+
+// WaveConfig describes how a wave-enabled command runs: its stages in
+// order, the stages after which progress is checkpointed for the user, and
+// the validation gates every stage must pass. It is read from the
+// wave-strategy, wave-stages, wave-checkpoints and wave-gates frontmatter.
+type WaveConfig struct {
+	Strategy    string   `json:"strategy"`
+	Stages      []string `json:"stages"`
+	Checkpoints []string `json:"checkpoints,omitempty"`
+	Gates       []string `json:"gates,omitempty"`
+}
+
+// DefaultWaveStrategy is used when a wave-enabled command names none
+const DefaultWaveStrategy = "systematic"
+
+// WaveStrategies are the strategies of the --wave-strategy flag
+var WaveStrategies = []string{"progressive", "systematic", "adaptive", "enterprise"}
+
+// DefaultWaveStages are the stages of a wave-enabled command that lists none
+var DefaultWaveStages = []string{"review", "plan", "implement", "validate"}
+
+// parseWaveConfig returns the wave configuration of a command's resolved
+// frontmatter, or nil when the command is not wave-enabled. Problems are
+// returned as warnings and the offending values dropped.
+func parseWaveConfig(fm map[string]string) (*WaveConfig, []string) {
+	if fm["wave-enabled"] != "true" {
+		return nil, nil
+	}
+
+	var warnings []string
+	wave := &WaveConfig{
+		Strategy:    fm["wave-strategy"],
+		Stages:      parseFrontmatterList(fm["wave-stages"]),
+		Checkpoints: parseFrontmatterList(fm["wave-checkpoints"]),
+		Gates:       parseFrontmatterList(fm["wave-gates"]),
+	}
+	if wave.Strategy == "" {
+		wave.Strategy = DefaultWaveStrategy
+	} else if !contains(WaveStrategies, wave.Strategy) {
+		warnings = append(warnings, fmt.Sprintf("unknown wave-strategy %q (valid: %s)", wave.Strategy, strings.Join(WaveStrategies, ", ")))
+		wave.Strategy = DefaultWaveStrategy
+	}
+	if len(wave.Stages) == 0 {
+		wave.Stages = DefaultWaveStages
+	}
+
+	var checkpoints []string
+	for _, checkpoint := range wave.Checkpoints {
+		if contains(wave.Stages, checkpoint) {
+			checkpoints = append(checkpoints, checkpoint)
+		} else {
+			warnings = append(warnings, fmt.Sprintf("wave checkpoint %q is not one of the stages %s", checkpoint, strings.Join(wave.Stages, ", ")))
+		}
+	}
+	wave.Checkpoints = checkpoints
+	return wave, warnings
+}
+
+// Summary describes the stages on one line, e.g.
+// "plan → execute* → validate", marking checkpoints with a star
+func (w *WaveConfig) Summary(arrow string) string {
+	stages := make([]string, len(w.Stages))
+	for i, stage := range w.Stages {
+		if contains(w.Checkpoints, stage) {
+			stage += "*"
+		}
+		stages[i] = stage
+	}
+	return strings.Join(stages, " "+arrow+" ")
+}
+
+// promptData converts the configuration for prompt templates
+func (w *WaveConfig) promptData() *prompts.Wave {
+	if w == nil {
+		return nil
+	}
+	return &prompts.Wave{Strategy: w.Strategy, Stages: w.Stages, Checkpoints: w.Checkpoints, Gates: w.Gates}
+}
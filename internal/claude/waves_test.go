@@ -0,0 +1,34 @@
+package claude
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseWaveConfig(t *testing.T) {
+	if wave, _ := parseWaveConfig(map[string]string{"wave-stages": "[a, b]"}); wave != nil {
+		t.Errorf("Commands that are not wave-enabled should have no waves, got %+v", wave)
+	}
+
+	wave, warnings := parseWaveConfig(map[string]string{"wave-enabled": "true"})
+	if len(warnings) != 0 || wave.Strategy != DefaultWaveStrategy || !reflect.DeepEqual(wave.Stages, DefaultWaveStages) {
+		t.Errorf("Expected the defaults, got %+v, %v", wave, warnings)
+	}
+
+	wave, warnings = parseWaveConfig(map[string]string{
+		"wave-enabled":     "true",
+		"wave-strategy":    "heroic",
+		"wave-stages":      "[plan, execute, validate]",
+		"wave-checkpoints": "[plan, ship]",
+		"wave-gates":       "[tests pass]",
+	})
+	if len(warnings) != 2 {
+		t.Errorf("Expected the strategy and checkpoint to be reported, got %v", warnings)
+	}
+	if wave.Strategy != DefaultWaveStrategy || !reflect.DeepEqual(wave.Checkpoints, []string{"plan"}) || !reflect.DeepEqual(wave.Gates, []string{"tests pass"}) {
+		t.Errorf("Unexpected wave %+v", wave)
+	}
+	if got := wave.Summary("->"); got != "plan* -> execute -> validate" {
+		t.Errorf("Unexpected summary %q", got)
+	}
+}
@@ -0,0 +1,425 @@
+// Package claudemd edits the sections crew manages in CLAUDE.md files.
+//
+// A managed section sits between anchors that carry its id and, for the
+// framework, the version it was written by:
+//
+//	<!-- BEGIN crew:mcp-servers -->
+//	...
+//	<!-- END crew:mcp-servers -->
+//
+// Everything outside the anchors belongs to the user. Parse followed by
+// String reproduces a file byte for byte, and Upsert and Remove only touch
+// the section they name, so crew can update its sections any number of
+// times without disturbing the rest of the file.
+package claudemd
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Section ids
+const (
+	SectionFramework       = "framework"
+	SectionMCPServers      = "mcp-servers"
+	SectionCLITools        = "cli-tools"
+	SectionProjectOverview = "project-overview"
+)
+
+var (
+	beginAnchor = regexp.MustCompile(`^<!-- BEGIN crew:([a-z0-9-]+)(?: v(\S+))? -->$`)
+	endAnchor   = regexp.MustCompile(`^<!-- END crew:([a-z0-9-]+) -->$`)
+)
+
+// Section is a managed section
+type Section struct {
+	ID      string `json:"id"`
+	Version string `json:"version,omitempty"`
+	// Content is the text between the anchors, without surrounding blank
+	// lines
+	Content string `json:"content"`
+}
+
+// Placement says where Upsert puts a section the document does not have
+type Placement int
+
+const (
+	AtBottom Placement = iota
+	AtTop
+)
+
+// block is either user text or a managed section. Sections keep their
+// lines as read so that String reproduces the input.
+type block struct {
+	text    string
+	section *Section
+	begin   string
+	body    string
+	end     string
+}
+
+func (b *block) String() string {
+	if b.section == nil {
+		return b.text
+	}
+	return b.begin + b.body + b.end
+}
+
+// Document is a parsed CLAUDE.md
+type Document struct {
+	blocks []*block
+}
+
+// Problem is an anchor Parse could not make sense of
+type Problem struct {
+	Line    int
+	Message string
+
+	kind problemKind
+	// end is the closing line of a duplicate section
+	end int
+}
+
+type problemKind int
+
+const (
+	problemNested problemKind = iota
+	problemMismatchedEnd
+	problemStrayEnd
+	problemUnclosed
+	problemDuplicate
+)
+
+// ParseError lists every anchor problem in a document
+type ParseError struct {
+	Problems []Problem
+}
+
+func (e *ParseError) Error() string {
+	var msgs []string
+	for _, p := range e.Problems {
+		msgs = append(msgs, fmt.Sprintf("line %d: %s", p.Line, p.Message))
+	}
+	return "invalid managed sections: " + strings.Join(msgs, "; ")
+}
+
+// Parse reads a CLAUDE.md. Anchors inside fenced code blocks are text.
+// Unbalanced, nested or repeated anchors are reported together in a
+// *ParseError.
+func Parse(content string) (*Document, error) {
+	doc, problems := parse(content)
+	if len(problems) > 0 {
+		return nil, &ParseError{Problems: problems}
+	}
+	return doc, nil
+}
+
+func parse(content string) (*Document, []Problem) {
+	doc := &Document{}
+	var problems []Problem
+	seen := make(map[string]int)
+
+	var text strings.Builder
+	var current *block
+	var currentLine int
+	fence := ""
+
+	flushText := func() {
+		if text.Len() > 0 {
+			doc.blocks = append(doc.blocks, &block{text: text.String()})
+			text.Reset()
+		}
+	}
+
+	for i, line := range strings.SplitAfter(content, "\n") {
+		if line == "" {
+			continue
+		}
+		lineNo := i + 1
+		trimmed := strings.TrimSpace(line)
+
+		if marker := fenceMarker(trimmed); marker != "" && (fence == "" || strings.HasPrefix(trimmed, fence)) {
+			if fence == "" {
+				fence = marker
+			} else {
+				fence = ""
+			}
+		}
+
+		if fence == "" {
+			if m := beginAnchor.FindStringSubmatch(trimmed); m != nil {
+				if current != nil {
+					problems = append(problems, Problem{Line: lineNo, Message: fmt.Sprintf("section %s begins inside section %s", m[1], current.section.ID), kind: problemNested})
+					current.body += line
+					continue
+				}
+				flushText()
+				current = &block{section: &Section{ID: m[1], Version: m[2]}, begin: line}
+				currentLine = lineNo
+				continue
+			}
+			if m := endAnchor.FindStringSubmatch(trimmed); m != nil {
+				switch {
+				case current == nil:
+					problems = append(problems, Problem{Line: lineNo, Message: fmt.Sprintf("section %s ends without a beginning", m[1]), kind: problemStrayEnd})
+					text.WriteString(line)
+				case m[1] != current.section.ID:
+					problems = append(problems, Problem{Line: lineNo, Message: fmt.Sprintf("section %s ends while section %s is open", m[1], current.section.ID), kind: problemMismatchedEnd})
+					current.body += line
+				default:
+					if first, dup := seen[m[1]]; dup {
+						problems = append(problems, Problem{Line: currentLine, Message: fmt.Sprintf("section %s appears again (first on line %d)", m[1], first), kind: problemDuplicate, end: lineNo})
+					} else {
+						seen[m[1]] = currentLine
+					}
+					current.end = line
+					current.section.Content = strings.Trim(current.body, "\n")
+					doc.blocks = append(doc.blocks, current)
+					current = nil
+				}
+				continue
+			}
+		}
+
+		if current != nil {
+			current.body += line
+		} else {
+			text.WriteString(line)
+		}
+	}
+
+	if current != nil {
+		problems = append(problems, Problem{Line: currentLine, Message: fmt.Sprintf("section %s is never closed", current.section.ID), kind: problemUnclosed})
+	}
+	flushText()
+	return doc, problems
+}
+
+// fenceMarker returns the fence a line opens or closes, or ""
+func fenceMarker(line string) string {
+	for _, marker := range []string{"```", "~~~"} {
+		if strings.HasPrefix(line, marker) {
+			return marker
+		}
+	}
+	return ""
+}
+
+// String renders the document
+func (d *Document) String() string {
+	var b strings.Builder
+	for _, blk := range d.blocks {
+		b.WriteString(blk.String())
+	}
+	return b.String()
+}
+
+// Sections returns the managed sections in document order
+func (d *Document) Sections() []Section {
+	var sections []Section
+	for _, blk := range d.blocks {
+		if blk.section != nil {
+			sections = append(sections, *blk.section)
+		}
+	}
+	return sections
+}
+
+// Get returns the managed section with id
+func (d *Document) Get(id string) (Section, bool) {
+	if blk := d.find(id); blk != nil {
+		return *blk.section, true
+	}
+	return Section{}, false
+}
+
+func (d *Document) find(id string) *block {
+	for _, blk := range d.blocks {
+		if blk.section != nil && blk.section.ID == id {
+			return blk
+		}
+	}
+	return nil
+}
+
+// Upsert replaces the content and version of the section with s.ID, or
+// adds the section at placement. It reports whether the document changed.
+func (d *Document) Upsert(s Section, placement Placement) bool {
+	s.Content = strings.Trim(s.Content, "\n")
+	rendered := newBlock(s)
+
+	if blk := d.find(s.ID); blk != nil {
+		if blk.begin == rendered.begin && strings.Trim(blk.body, "\n") == s.Content {
+			return false
+		}
+		*blk = *rendered
+		return true
+	}
+
+	if placement == AtTop {
+		blocks := []*block{rendered}
+		if len(d.blocks) > 0 && !strings.HasPrefix(d.blocks[0].String(), "\n") {
+			blocks = append(blocks, &block{text: "\n"})
+		}
+		d.blocks = append(blocks, d.blocks...)
+		return true
+	}
+
+	if content := d.String(); content != "" {
+		if !strings.HasSuffix(content, "\n") {
+			d.blocks = append(d.blocks, &block{text: "\n\n"})
+		} else if !strings.HasSuffix(content, "\n\n") {
+			d.blocks = append(d.blocks, &block{text: "\n"})
+		}
+	}
+	d.blocks = append(d.blocks, rendered)
+	return true
+}
+
+func newBlock(s Section) *block {
+	begin := "<!-- BEGIN crew:" + s.ID
+	if s.Version != "" {
+		begin += " v" + s.Version
+	}
+	body := ""
+	if s.Content != "" {
+		body = s.Content + "\n"
+	}
+	return &block{
+		section: &s,
+		begin:   begin + " -->\n",
+		body:    body,
+		end:     "<!-- END crew:" + s.ID + " -->\n",
+	}
+}
+
+// Remove deletes the section with id together with one blank line that
+// separated it from the text around it. It reports whether the section
+// existed.
+func (d *Document) Remove(id string) bool {
+	for i, blk := range d.blocks {
+		if blk.section == nil || blk.section.ID != id {
+			continue
+		}
+		d.blocks = append(d.blocks[:i], d.blocks[i+1:]...)
+		if i == 0 && len(d.blocks) > 0 && d.blocks[0].section == nil {
+			next := d.blocks[0]
+			next.text = strings.TrimPrefix(next.text, "\n")
+			if next.text == "" {
+				d.blocks = d.blocks[1:]
+			}
+		}
+		if i > 0 && d.blocks[i-1].section == nil {
+			prev := d.blocks[i-1]
+			if strings.HasSuffix(prev.text, "\n\n") {
+				prev.text = strings.TrimSuffix(prev.text, "\n")
+			}
+			if prev.text == "\n" {
+				d.blocks = append(d.blocks[:i-1], d.blocks[i:]...)
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// Adopt turns an unmanaged Markdown section that starts with heading into
+// the managed section id, so that a later Upsert replaces it in place.
+// The section runs until the next heading of the same or a higher level.
+// Adopt does nothing and returns false if id is already managed or the
+// heading is not found outside managed sections.
+func (d *Document) Adopt(id, heading string) bool {
+	if d.find(id) != nil {
+		return false
+	}
+
+	for i, blk := range d.blocks {
+		if blk.section != nil {
+			continue
+		}
+		lines := strings.SplitAfter(blk.text, "\n")
+		start, end := sectionSpan(lines, heading, 0)
+		if start < 0 {
+			continue
+		}
+
+		body := strings.Join(lines[start:end], "")
+		if !strings.HasSuffix(body, "\n") {
+			body += "\n"
+		}
+		adopted := newBlock(Section{ID: id, Content: strings.Trim(body, "\n")})
+		adopted.body = body
+
+		var replacement []*block
+		if before := strings.Join(lines[:start], ""); before != "" {
+			replacement = append(replacement, &block{text: before})
+		}
+		replacement = append(replacement, adopted)
+		if after := strings.Join(lines[end:], ""); after != "" {
+			replacement = append(replacement, &block{text: after})
+		}
+		d.blocks = append(d.blocks[:i], append(replacement, d.blocks[i+1:]...)...)
+		return true
+	}
+	return false
+}
+
+// sectionSpan finds the Markdown section that starts with heading at or
+// after line from, outside code fences. It runs until the next heading of
+// the same or a higher level; blank lines before that heading are not part
+// of it. start is -1 if there is no such section.
+func sectionSpan(lines []string, heading string, from int) (start, end int) {
+	level := headingLevel(heading)
+	if level == 0 {
+		return -1, -1
+	}
+
+	start, end = -1, len(lines)
+	fence := ""
+	for j, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if marker := fenceMarker(trimmed); marker != "" && (fence == "" || strings.HasPrefix(trimmed, fence)) {
+			if fence == "" {
+				fence = marker
+			} else {
+				fence = ""
+			}
+			continue
+		}
+		if fence != "" {
+			continue
+		}
+		if start < 0 {
+			if j >= from && strings.TrimRight(line, " \t\r\n") == heading {
+				start = j
+			}
+			continue
+		}
+		if l := headingLevel(trimmed); l > 0 && l <= level {
+			end = j
+			break
+		}
+	}
+	if start < 0 {
+		return -1, -1
+	}
+
+	// Blank lines before the next heading stay user text
+	for end > start+1 && strings.TrimSpace(lines[end-1]) == "" {
+		end--
+	}
+	return start, end
+}
+
+// headingLevel returns the level of an ATX heading line, or 0
+func headingLevel(line string) int {
+	level := 0
+	for level < len(line) && line[level] == '#' {
+		level++
+	}
+	if level == 0 || level > 6 || level == len(line) || line[level] != ' ' {
+		return 0
+	}
+	return level
+}
@@ -0,0 +1,136 @@
+package claudemd
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+const userFile = `# My notes
+
+Always run the tests.
+
+<!-- BEGIN crew:mcp-servers -->
+## MCP Server Integrations
+- context7
+<!-- END crew:mcp-servers -->
+
+## Team conventions
+` + "```markdown\n<!-- BEGIN crew:example -->\n```\n" + `Tabs, not spaces.`
+
+func TestParseRoundTrip(t *testing.T) {
+	for name, content := range map[string]string{
+		"empty":           "",
+		"no sections":     "# Title\n\ntext without newline",
+		"sections":        userFile,
+		"crlf":            "a\r\n<!-- BEGIN crew:cli-tools -->\r\nx\r\n<!-- END crew:cli-tools -->\r\nb\r\n",
+		"empty section":   "<!-- BEGIN crew:framework v1.0.0 -->\n<!-- END crew:framework -->",
+		"anchor in fence": "```\n<!-- END crew:framework -->\n```\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			doc, err := Parse(content)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := doc.String(); got != content {
+				t.Errorf("Round trip changed the file:\n%q\n%q", content, got)
+			}
+		})
+	}
+}
+
+func TestParseProblems(t *testing.T) {
+	content := `<!-- BEGIN crew:framework -->
+<!-- BEGIN crew:cli-tools -->
+<!-- END crew:cli-tools -->
+<!-- END crew:framework -->
+<!-- END crew:mcp-servers -->
+<!-- BEGIN crew:framework -->
+<!-- END crew:framework -->
+<!-- BEGIN crew:project-overview -->
+`
+	_, err := Parse(content)
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("Expected a ParseError, got %v", err)
+	}
+	var lines []int
+	for _, p := range parseErr.Problems {
+		lines = append(lines, p.Line)
+	}
+	if len(lines) != 5 || lines[0] != 2 || lines[1] != 3 || lines[2] != 5 || lines[3] != 6 || lines[4] != 8 {
+		t.Errorf("Unexpected problems: %v", parseErr.Problems)
+	}
+}
+
+func TestUpsertIsIdempotent(t *testing.T) {
+	doc, err := Parse(userFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	update := Section{ID: SectionMCPServers, Content: "## MCP Server Integrations\n- context7\n- sequential\n"}
+	if !doc.Upsert(update, AtBottom) {
+		t.Error("Expected the update to change the document")
+	}
+	if doc.Upsert(update, AtBottom) {
+		t.Error("Expected the same update not to change the document again")
+	}
+	tools := Section{ID: SectionCLITools, Content: "## CLI Tools\n- rg"}
+	doc.Upsert(tools, AtBottom)
+	once := doc.String()
+	doc.Upsert(tools, AtBottom)
+	if doc.String() != once {
+		t.Error("Expected repeated upserts to produce the same file")
+	}
+
+	for _, want := range []string{
+		"# My notes\n\nAlways run the tests.\n\n<!-- BEGIN crew:mcp-servers -->\n## MCP Server Integrations\n- context7\n- sequential\n<!-- END crew:mcp-servers -->\n",
+		"Tabs, not spaces.\n\n<!-- BEGIN crew:cli-tools -->\n## CLI Tools\n- rg\n<!-- END crew:cli-tools -->\n",
+	} {
+		if !strings.Contains(once, want) {
+			t.Errorf("Expected the document to contain %q:\n%s", want, once)
+		}
+	}
+
+	if !doc.Remove(SectionCLITools) || doc.Remove(SectionCLITools) {
+		t.Error("Expected Remove to delete the section once")
+	}
+	if !doc.Remove(SectionMCPServers) {
+		t.Fatal("Expected the MCP section to be removed")
+	}
+	want := "# My notes\n\nAlways run the tests.\n\n## Team conventions\n```markdown\n<!-- BEGIN crew:example -->\n```\nTabs, not spaces."
+	if got := doc.String(); got != want {
+		t.Errorf("Expected only user content to remain:\n%q\n%q", want, got)
+	}
+}
+
+func TestUpsertAtTop(t *testing.T) {
+	doc, _ := Parse("# Mine\n")
+	doc.Upsert(Section{ID: SectionFramework, Version: "1.0.0", Content: "# Framework"}, AtTop)
+	want := "<!-- BEGIN crew:framework v1.0.0 -->\n# Framework\n<!-- END crew:framework -->\n\n# Mine\n"
+	if got := doc.String(); got != want {
+		t.Errorf("Unexpected document:\n%q\n%q", want, got)
+	}
+
+	doc.Remove(SectionFramework)
+	if got := doc.String(); got != "# Mine\n" {
+		t.Errorf("Expected removing the section to restore the file, got %q", got)
+	}
+}
+
+func TestAdopt(t *testing.T) {
+	doc, _ := Parse("# Project\n\n## 🔧 MCP Server Integrations\nold\n### Detail\nmore\n\n## Mine\nkeep\n")
+	if !doc.Adopt(SectionMCPServers, "## 🔧 MCP Server Integrations") {
+		t.Fatal("Expected the heading to be adopted")
+	}
+	if doc.Adopt(SectionMCPServers, "## 🔧 MCP Server Integrations") {
+		t.Error("Expected a managed section not to be adopted twice")
+	}
+	doc.Upsert(Section{ID: SectionMCPServers, Content: "## 🔧 MCP Server Integrations\nnew"}, AtBottom)
+
+	want := "# Project\n\n<!-- BEGIN crew:mcp-servers -->\n## 🔧 MCP Server Integrations\nnew\n<!-- END crew:mcp-servers -->\n\n## Mine\nkeep\n"
+	if got := doc.String(); got != want {
+		t.Errorf("Unexpected document:\n%q\n%q", want, got)
+	}
+}
@@ -0,0 +1,73 @@
+package claudemd
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Dump describes how MergeFramework sees content: whether an <sc-vX>
+// block was migrated, then every block of user text and managed section
+// with the lines it spans and its content, and finally any anchor
+// problems. Line numbers refer to the migrated content.
+func Dump(content string) string {
+	var b strings.Builder
+	migrated := MigrateLegacy(content)
+	if migrated != content {
+		b.WriteString("migrated an <sc-vX> block to the framework section\n")
+	}
+
+	doc, problems := parse(migrated)
+	line := 1
+	for _, blk := range doc.blocks {
+		s := blk.String()
+		lines := strings.Count(s, "\n")
+		if !strings.HasSuffix(s, "\n") {
+			lines++
+		}
+		span := fmt.Sprintf("%d-%d", line, line+lines-1)
+		if lines == 1 {
+			span = fmt.Sprint(line)
+		}
+
+		if blk.section == nil {
+			fmt.Fprintf(&b, "text lines %s\n", span)
+			dumpLines(&b, blk.text)
+		} else {
+			version := blk.section.Version
+			if version == "" {
+				version = "none"
+			}
+			fmt.Fprintf(&b, "section %s version %s lines %s\n", blk.section.ID, version, span)
+			if blk.section.Content != "" {
+				dumpLines(&b, blk.section.Content+"\n")
+			}
+		}
+		line += lines
+	}
+	if len(doc.blocks) == 0 {
+		b.WriteString("empty\n")
+	}
+
+	for _, p := range problems {
+		fmt.Fprintf(&b, "problem line %d: %s\n", p.Line, p.Message)
+	}
+	return b.String()
+}
+
+// dumpLines writes text indented under its block, spelling out carriage
+// returns
+func dumpLines(b *strings.Builder, text string) {
+	for _, l := range strings.SplitAfter(text, "\n") {
+		if l == "" {
+			continue
+		}
+		if trimmed := strings.ReplaceAll(strings.TrimSuffix(l, "\n"), "\r", `\r`); trimmed == "" {
+			b.WriteString("  |\n")
+		} else {
+			fmt.Fprintf(b, "  | %s\n", trimmed)
+		}
+		if !strings.HasSuffix(l, "\n") {
+			b.WriteString("  (no newline at end)\n")
+		}
+	}
+}
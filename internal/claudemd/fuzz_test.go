@@ -0,0 +1,71 @@
+package claudemd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// addMergeSeeds adds the existing.md of every golden merge case to f
+func addMergeSeeds(f *testing.F) {
+	cases, _ := filepath.Glob(filepath.Join("testdata", "merge", "*", "existing.md"))
+	for _, path := range cases {
+		if data, err := os.ReadFile(path); err == nil {
+			f.Add(string(data))
+		}
+	}
+	f.Add("<!-- BEGIN crew:a -->\n<!-- BEGIN crew:b -->\n<!-- END crew:a -->\n<!-- END crew:b -->")
+	f.Add("<sc-v1>\n<sc-v2>\n<sc-end-v1>\n<sc-end-v2>tail")
+}
+
+func FuzzParse(f *testing.F) {
+	addMergeSeeds(f)
+	f.Fuzz(func(t *testing.T, content string) {
+		Dump(content)
+		doc, err := Parse(content)
+		if err != nil {
+			return
+		}
+		if got := doc.String(); got != content {
+			t.Fatalf("Parse and String changed the content:\n%q\n%q", content, got)
+		}
+		for _, s := range doc.Sections() {
+			if !doc.Remove(s.ID) {
+				t.Fatalf("section %s could not be removed", s.ID)
+			}
+		}
+	})
+}
+
+func FuzzMergeFramework(f *testing.F) {
+	addMergeSeeds(f)
+	f.Fuzz(func(t *testing.T, existing string) {
+		const framework = "# SuperCrew\n\nFramework rules.\n"
+		merged, err := MergeFramework(existing, framework, "2.0.0")
+		if err != nil {
+			return
+		}
+		doc, err := Parse(merged)
+		if err != nil {
+			t.Fatalf("merge produced broken anchors: %v\n%q", err, merged)
+		}
+		section, ok := doc.Get(SectionFramework)
+		if !ok || section.Version != "2.0.0" || section.Content != strings.Trim(framework, "\n") {
+			t.Fatalf("merge wrote framework section %+v:\n%q", section, merged)
+		}
+		if again, err := MergeFramework(merged, framework, "2.0.0"); err != nil || again != merged {
+			t.Fatalf("merging again changed the result (%v):\n%q\n%q", err, merged, again)
+		}
+	})
+}
+
+func FuzzFix(f *testing.F) {
+	addMergeSeeds(f)
+	f.Fuzz(func(t *testing.T, content string) {
+		fixed, _ := Fix(content, "2.0.0")
+		if again, _ := Fix(fixed, "2.0.0"); again != fixed {
+			t.Fatalf("fixing again changed the result:\n%q\n%q", fixed, again)
+		}
+	})
+}
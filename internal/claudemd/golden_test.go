@@ -0,0 +1,71 @@
+package claudemd
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// goldenVersion is the framework version the merge cases are merged at
+const goldenVersion = "2.0.0"
+
+func checkGolden(t *testing.T, path, got string) {
+	t.Helper()
+	if *update {
+		if err := os.WriteFile(path, []byte(got), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v (run go test -update to create it)", err)
+	}
+	if got != string(want) {
+		t.Errorf("result differs from %s:\n%s", path, got)
+	}
+}
+
+// TestMergeGolden merges the framework.md of each case in testdata/merge
+// into its existing.md. merged.golden holds the result, or the error, and
+// sections.golden how the existing file parses.
+func TestMergeGolden(t *testing.T) {
+	cases, err := filepath.Glob(filepath.Join("testdata", "merge", "*"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cases) == 0 {
+		t.Fatal("no merge cases in testdata/merge")
+	}
+
+	for _, dir := range cases {
+		dir := dir
+		t.Run(filepath.Base(dir), func(t *testing.T) {
+			existing, err := os.ReadFile(filepath.Join(dir, "existing.md"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			framework, err := os.ReadFile(filepath.Join(dir, "framework.md"))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			checkGolden(t, filepath.Join(dir, "sections.golden"), Dump(string(existing)))
+
+			merged, err := MergeFramework(string(existing), string(framework), goldenVersion)
+			if err != nil {
+				checkGolden(t, filepath.Join(dir, "merged.golden"), "error: "+err.Error()+"\n")
+				return
+			}
+			checkGolden(t, filepath.Join(dir, "merged.golden"), merged)
+
+			again, err := MergeFramework(merged, string(framework), goldenVersion)
+			if err != nil || again != merged {
+				t.Errorf("merging again changed the result (%v):\n%s", err, again)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package claudemd
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Earlier releases wrapped the framework in <sc-v1.0.0> ... <sc-end-v1.0.0>
+var (
+	legacyBegin = regexp.MustCompile(`^<sc-v([^>]+)>`)
+	legacyEnd   = regexp.MustCompile(`^<sc-end-v[^>]*>`)
+)
+
+// legacyHeadings are unmanaged framework sections of earlier releases,
+// adopted as the framework section when a file has no framework anchors
+var legacyHeadings = []string{
+	"# Claude Code Super Crew Entry Point",
+	"# SuperCrew v3.0: Self-Improving AI Development Team System",
+}
+
+// legacyBlock is an <sc-vX> block from line start to line end
+type legacyBlock struct {
+	start, end int
+	version    string
+}
+
+// findLegacy returns the <sc-vX> blocks in lines and the lines of tags
+// without a partner. Tags around crew anchors are stray too: they are
+// left over from an earlier migration, not a framework block.
+func findLegacy(lines []string) (blocks []legacyBlock, stray []int) {
+	start := -1
+	var version string
+	for i, line := range lines {
+		if m := legacyBegin.FindStringSubmatch(line); m != nil {
+			if start >= 0 {
+				stray = append(stray, start)
+			}
+			start, version = i, m[1]
+			continue
+		}
+		if legacyEnd.MatchString(line) {
+			switch {
+			case start < 0:
+				stray = append(stray, i)
+			case hasAnchor(lines[start+1 : i]):
+				stray = append(stray, start, i)
+			default:
+				blocks = append(blocks, legacyBlock{start: start, end: i, version: version})
+			}
+			start = -1
+		}
+	}
+	if start >= 0 {
+		stray = append(stray, start)
+	}
+	return blocks, stray
+}
+
+// hasAnchor reports whether lines hold a crew section anchor
+func hasAnchor(lines []string) bool {
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if beginAnchor.MatchString(trimmed) || endAnchor.MatchString(trimmed) {
+			return true
+		}
+	}
+	return false
+}
+
+// replaceLegacy replaces block b with replacement. Text after the closing
+// tag on the same line is kept as user text.
+func replaceLegacy(lines []string, b legacyBlock, replacement string) string {
+	line := lines[b.end]
+	after := strings.TrimLeft(line[legacyEnd.FindStringIndex(line)[1]:], " \t")
+	switch {
+	case strings.TrimSpace(after) != "":
+		if replacement != "" {
+			replacement += "\n"
+		}
+		replacement += after
+	case !strings.HasSuffix(line, "\n"):
+		replacement = strings.TrimSuffix(replacement, "\n")
+	}
+	return strings.Join(lines[:b.start], "") + replacement + strings.Join(lines[b.end+1:], "")
+}
+
+// MigrateLegacy replaces an <sc-vX> framework block with framework anchors.
+// Text after the closing tag on the same line is kept as user text.
+func MigrateLegacy(content string) string {
+	lines := strings.SplitAfter(content, "\n")
+	blocks, _ := findLegacy(lines)
+	if len(blocks) == 0 {
+		return content
+	}
+
+	b := blocks[0]
+	body := strings.Join(lines[b.start+1:b.end], "")
+	block := newBlock(Section{ID: SectionFramework, Version: b.version, Content: strings.Trim(body, "\n")})
+	return replaceLegacy(lines, b, block.String())
+}
+
+// MergeFramework updates the framework section of an existing CLAUDE.md to
+// framework at version and keeps everything else. Files from earlier
+// releases are migrated first: an <sc-vX> block or an unmanaged copy of
+// the framework becomes the framework section.
+func MergeFramework(existing, framework, version string) (string, error) {
+	doc, err := Parse(MigrateLegacy(existing))
+	if err != nil {
+		return "", err
+	}
+
+	if _, ok := doc.Get(SectionFramework); !ok {
+		for _, heading := range frameworkHeadings(framework) {
+			if doc.Adopt(SectionFramework, heading) {
+				break
+			}
+		}
+	}
+
+	doc.Upsert(Section{ID: SectionFramework, Version: version, Content: framework}, AtTop)
+	return doc.String(), nil
+}
+
+// frameworkHeadings returns the headings an unmanaged copy of framework
+// may start with
+func frameworkHeadings(framework string) []string {
+	headings := []string{firstHeading(framework)}
+	for _, heading := range legacyHeadings {
+		if heading != headings[0] {
+			headings = append(headings, heading)
+		}
+	}
+	if headings[0] == "" {
+		return headings[1:]
+	}
+	return headings
+}
+
+// firstHeading returns the first level one heading of content
+func firstHeading(content string) string {
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimRight(line, " \t\r")
+		if headingLevel(line) == 1 {
+			return line
+		}
+	}
+	return ""
+}
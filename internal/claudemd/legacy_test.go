@@ -0,0 +1,55 @@
+package claudemd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMergeFrameworkLegacyBlock(t *testing.T) {
+	existing := "<sc-v1.0.0>\n# Old framework\n<sc-end-v1.0.0># My rules\nBe brief.\n"
+
+	merged, err := MergeFramework(existing, "# SuperCrew\nnew\n", "1.1.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "<!-- BEGIN crew:framework v1.1.0 -->\n# SuperCrew\nnew\n<!-- END crew:framework -->\n\n# My rules\nBe brief.\n"
+	if merged != want {
+		t.Errorf("Unexpected merge:\n%q\n%q", want, merged)
+	}
+
+	again, err := MergeFramework(merged, "# SuperCrew\nnew\n", "1.1.0")
+	if err != nil || again != merged {
+		t.Errorf("Expected merging twice to be a no-op, got %q, %v", again, err)
+	}
+}
+
+func TestMergeFrameworkUnmanagedCopy(t *testing.T) {
+	existing := "# SuperCrew\nold framework\n\n# My rules\nBe brief.\n"
+
+	merged, err := MergeFramework(existing, "# SuperCrew\nnew framework", "1.1.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(merged, "old framework") || strings.Count(merged, "# SuperCrew") != 1 {
+		t.Errorf("Expected the old copy to be replaced:\n%s", merged)
+	}
+	if !strings.HasSuffix(merged, "<!-- END crew:framework -->\n\n# My rules\nBe brief.\n") {
+		t.Errorf("Expected user sections to be kept after the framework:\n%s", merged)
+	}
+}
+
+func TestMergeFrameworkUserFile(t *testing.T) {
+	merged, err := MergeFramework("# My rules\n", "# SuperCrew", "1.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if merged != "<!-- BEGIN crew:framework v1.0.0 -->\n# SuperCrew\n<!-- END crew:framework -->\n\n# My rules\n" {
+		t.Errorf("Unexpected merge %q", merged)
+	}
+}
+
+func TestMergeFrameworkBrokenAnchors(t *testing.T) {
+	if _, err := MergeFramework("<!-- BEGIN crew:framework -->\n", "# SuperCrew", "1.0.0"); err == nil {
+		t.Error("Expected broken anchors to stop the merge")
+	}
+}
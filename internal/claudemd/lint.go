@@ -0,0 +1,265 @@
+package claudemd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/semver"
+)
+
+// Finding kinds
+const (
+	FindingBrokenAnchor = "broken-anchor"
+	FindingDuplicate    = "duplicate-section"
+	FindingStaleVersion = "stale-version"
+	FindingOrphan       = "orphaned-framework"
+)
+
+// Finding is a problem Lint found in a CLAUDE.md
+type Finding struct {
+	Kind    string `json:"kind"`
+	Line    int    `json:"line,omitempty"`
+	Message string `json:"message"`
+	Fixable bool   `json:"fixable"`
+}
+
+// Lint checks the managed sections of a CLAUDE.md. version is the framework
+// version crew installs; when it is empty stale versions are not reported.
+func Lint(content, version string) []Finding {
+	var findings []Finding
+	lines := strings.SplitAfter(content, "\n")
+	legacy, stray := findLegacy(lines)
+	doc, problems := parse(content)
+
+	for _, p := range problems {
+		f := Finding{Kind: FindingBrokenAnchor, Line: p.Line, Message: p.Message}
+		switch p.kind {
+		case problemDuplicate:
+			f.Kind, f.Fixable = FindingDuplicate, true
+		case problemStrayEnd, problemUnclosed:
+			f.Fixable = true
+		}
+		findings = append(findings, f)
+	}
+	for _, i := range stray {
+		findings = append(findings, Finding{
+			Kind:    FindingBrokenAnchor,
+			Line:    i + 1,
+			Message: fmt.Sprintf("legacy tag %s has no partner", legacyBegin.FindString(lines[i])+legacyEnd.FindString(lines[i])),
+			Fixable: true,
+		})
+	}
+
+	framework, managed := doc.Get(SectionFramework)
+	for i, b := range legacy {
+		f := Finding{Kind: FindingStaleVersion, Line: b.start + 1, Fixable: true,
+			Message: fmt.Sprintf("framework block uses the legacy <sc-v%s> tags", b.version)}
+		if managed || i > 0 {
+			f.Kind = FindingOrphan
+			f.Message = fmt.Sprintf("legacy <sc-v%s> block duplicates the framework section", b.version)
+		}
+		findings = append(findings, f)
+	}
+
+	// Line numbers are only reliable once the anchors are sound. A legacy
+	// block counts as the framework section it will be migrated to.
+	if len(problems) == 0 {
+		reference, hasFramework := framework.Content, managed
+		if !managed && len(legacy) > 0 {
+			reference, hasFramework = strings.Trim(strings.Join(lines[legacy[0].start+1:legacy[0].end], ""), "\n"), true
+		}
+		var copies []frameworkCopy
+		for _, c := range frameworkCopies(doc, frameworkHeadings(reference)) {
+			if !insideLegacy(legacy, c.line-1) {
+				copies = append(copies, c)
+			}
+		}
+		for i, c := range copies {
+			f := Finding{Kind: FindingOrphan, Line: c.line}
+			switch {
+			case !hasFramework && i == 0:
+				f.Message, f.Fixable = "framework is not inside a managed section", true
+			case hasFramework && c.content == reference:
+				f.Message, f.Fixable = "unmanaged copy of the framework duplicates the framework section", true
+			default:
+				f.Message = "unmanaged copy of the framework differs from the framework section; remove it by hand"
+			}
+			findings = append(findings, f)
+		}
+	}
+
+	if _, ok := doc.Get(SectionFramework); ok && version != "" {
+		switch {
+		case framework.Version == "":
+			findings = append(findings, Finding{Kind: FindingStaleVersion, Line: sectionLine(doc, SectionFramework),
+				Message: "framework section has no version tag"})
+		case semver.Compare(framework.Version, version) < 0:
+			findings = append(findings, Finding{Kind: FindingStaleVersion, Line: sectionLine(doc, SectionFramework),
+				Message: fmt.Sprintf("framework section is v%s, crew installs v%s", framework.Version, version)})
+		}
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool { return findings[i].Line < findings[j].Line })
+	return findings
+}
+
+// Fix repairs everything Lint reports as fixable and returns the new
+// content with the findings that remain. Content inside sound managed
+// sections and all user text are kept; a stale framework section is left
+// for crew install to update.
+func Fix(content, version string) (string, []Finding) {
+	// Each pass repairs one layer; removing an unclosed anchor can, for
+	// example, turn a nested section into a sound one
+	for pass := 0; pass < 10; pass++ {
+		fixed := fixOnce(content)
+		if fixed == content {
+			break
+		}
+		content = fixed
+	}
+	return content, Lint(content, version)
+}
+
+func fixOnce(content string) string {
+	lines := strings.SplitAfter(content, "\n")
+	legacy, stray := findLegacy(lines)
+	doc, problems := parse(content)
+
+	drop := make(map[int]bool)
+	for _, i := range stray {
+		drop[i] = true
+	}
+	for _, p := range problems {
+		switch p.kind {
+		case problemStrayEnd, problemUnclosed:
+			drop[p.Line-1] = true
+		case problemDuplicate:
+			for i := p.Line - 1; i < p.end; i++ {
+				drop[i] = true
+			}
+		}
+	}
+	if len(drop) > 0 {
+		return removeLines(lines, drop)
+	}
+	if len(problems) > 0 {
+		// Nested sections need a person to decide where they end
+		return content
+	}
+
+	framework, managed := doc.Get(SectionFramework)
+	if len(legacy) > 0 {
+		if managed {
+			return replaceLegacy(lines, legacy[0], "")
+		}
+		return MigrateLegacy(content)
+	}
+
+	copies := frameworkCopies(doc, frameworkHeadings(framework.Content))
+	if !managed {
+		if len(copies) > 0 && doc.Adopt(SectionFramework, copies[0].heading) {
+			return doc.String()
+		}
+		return content
+	}
+	for _, c := range copies {
+		if c.content == framework.Content {
+			drop := make(map[int]bool)
+			for i := c.line - 1; i < c.line-1+c.lines; i++ {
+				drop[i] = true
+			}
+			return removeLines(lines, drop)
+		}
+	}
+	return content
+}
+
+// removeLines drops the lines in drop. A blank line left directly after
+// another blank line where lines were removed, or left at the end of the
+// file, is dropped too.
+func removeLines(lines []string, drop map[int]bool) string {
+	var b strings.Builder
+	lastBlank, removed := false, false
+	for i, line := range lines {
+		if line == "" {
+			continue
+		}
+		if drop[i] {
+			removed = true
+			continue
+		}
+		blank := strings.TrimSpace(line) == ""
+		if blank && lastBlank && removed {
+			removed = false
+			continue
+		}
+		b.WriteString(line)
+		lastBlank, removed = blank, false
+	}
+	if removed && lastBlank {
+		return strings.TrimSuffix(b.String(), "\n")
+	}
+	return b.String()
+}
+
+// frameworkCopy is an unmanaged copy of the framework
+type frameworkCopy struct {
+	heading string
+	// line is the line of the heading and lines the length of the copy
+	line, lines int
+	content     string
+}
+
+// frameworkCopies returns the Markdown sections outside managed sections
+// that start with one of headings, in document order
+func frameworkCopies(doc *Document, headings []string) []frameworkCopy {
+	var copies []frameworkCopy
+	offset := 0
+	for _, blk := range doc.blocks {
+		text := blk.String()
+		if blk.section == nil {
+			lines := strings.SplitAfter(strings.TrimSuffix(text, "\n"), "\n")
+			for _, heading := range headings {
+				for from := 0; ; {
+					start, end := sectionSpan(lines, heading, from)
+					if start < 0 {
+						break
+					}
+					copies = append(copies, frameworkCopy{
+						heading: heading,
+						line:    offset + start + 1,
+						lines:   end - start,
+						content: strings.Trim(strings.Join(lines[start:end], ""), "\n"),
+					})
+					from = end
+				}
+			}
+		}
+		offset += strings.Count(text, "\n")
+	}
+	sort.Slice(copies, func(i, j int) bool { return copies[i].line < copies[j].line })
+	return copies
+}
+
+// insideLegacy reports whether line is part of one of the legacy blocks
+func insideLegacy(blocks []legacyBlock, line int) bool {
+	for _, b := range blocks {
+		if line >= b.start && line <= b.end {
+			return true
+		}
+	}
+	return false
+}
+
+// sectionLine returns the line of the BEGIN anchor of section id
+func sectionLine(doc *Document, id string) int {
+	lineNo := 1
+	for _, blk := range doc.blocks {
+		if blk.section != nil && blk.section.ID == id {
+			return lineNo
+		}
+		lineNo += strings.Count(blk.String(), "\n")
+	}
+	return 0
+}
@@ -0,0 +1,99 @@
+package claudemd
+
+import (
+	"strings"
+	"testing"
+)
+
+func kinds(findings []Finding) string {
+	var out []string
+	for _, f := range findings {
+		out = append(out, f.Kind)
+	}
+	return strings.Join(out, ",")
+}
+
+func TestLintCleanFile(t *testing.T) {
+	content, err := MergeFramework("# Mine\n", "# SuperCrew\nrules", "1.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if findings := Lint(content, "1.0.0"); len(findings) != 0 {
+		t.Errorf("Expected no findings, got %+v", findings)
+	}
+	if findings := Lint(content, "1.2.0"); kinds(findings) != FindingStaleVersion || findings[0].Line != 1 || findings[0].Fixable {
+		t.Errorf("Expected an unfixable stale version on line 1, got %+v", findings)
+	}
+}
+
+func TestFixBrokenAnchorsAndDuplicates(t *testing.T) {
+	content := `# Mine
+<!-- END crew:cli-tools -->
+<!-- BEGIN crew:framework v1.0.0 -->
+# SuperCrew
+<!-- END crew:framework -->
+
+<!-- BEGIN crew:framework v1.0.0 -->
+# SuperCrew
+<!-- END crew:framework -->
+
+<!-- BEGIN crew:mcp-servers -->
+keep this text
+`
+	findings := Lint(content, "1.0.0")
+	if kinds(findings) != "broken-anchor,duplicate-section,broken-anchor" {
+		t.Fatalf("Unexpected findings %+v", findings)
+	}
+
+	fixed, remaining := Fix(content, "1.0.0")
+	want := "# Mine\n<!-- BEGIN crew:framework v1.0.0 -->\n# SuperCrew\n<!-- END crew:framework -->\n\nkeep this text\n"
+	if fixed != want || len(remaining) != 0 {
+		t.Errorf("Unexpected fix %q, remaining %+v", fixed, remaining)
+	}
+}
+
+func TestFixLegacyAndOrphans(t *testing.T) {
+	content := "<sc-v0.9.0>\n# SuperCrew\nrules\n<sc-end-v0.9.0>\n# Mine\n\n# SuperCrew\nrules\n\n# Other\n<sc-end-v0.8.0>\n"
+
+	if got := kinds(Lint(content, "1.0.0")); got != "stale-version,orphaned-framework,broken-anchor" {
+		t.Errorf("Unexpected findings %s", got)
+	}
+
+	fixed, remaining := Fix(content, "1.0.0")
+	want := "<!-- BEGIN crew:framework v0.9.0 -->\n# SuperCrew\nrules\n<!-- END crew:framework -->\n# Mine\n\n# Other\n"
+	if fixed != want {
+		t.Errorf("Unexpected fix:\n%q\n%q", want, fixed)
+	}
+	if kinds(remaining) != FindingStaleVersion {
+		t.Errorf("Expected only the stale version to remain, got %+v", remaining)
+	}
+}
+
+func TestFixKeepsDifferentCopies(t *testing.T) {
+	content := "<!-- BEGIN crew:framework v1.0.0 -->\n# SuperCrew\n<!-- END crew:framework -->\n\n# SuperCrew\nmy edits\n"
+
+	fixed, remaining := Fix(content, "1.0.0")
+	if fixed != content || len(remaining) != 1 || remaining[0].Fixable || remaining[0].Line != 5 {
+		t.Errorf("Expected an edited copy to be left alone, got %q, %+v", fixed, remaining)
+	}
+}
+
+func TestFixAdoptsUnmanagedFramework(t *testing.T) {
+	content := "# Claude Code Super Crew Entry Point\nold\n"
+
+	fixed, remaining := Fix(content, "1.0.0")
+	if !strings.HasPrefix(fixed, "<!-- BEGIN crew:framework -->\n# Claude Code Super Crew Entry Point") {
+		t.Errorf("Expected the framework to be adopted, got %q", fixed)
+	}
+	if kinds(remaining) != FindingStaleVersion {
+		t.Errorf("Expected the missing version tag to remain, got %+v", remaining)
+	}
+}
+
+func TestFixLeavesNestedSections(t *testing.T) {
+	content := "<!-- BEGIN crew:framework -->\n<!-- BEGIN crew:cli-tools -->\n<!-- END crew:cli-tools -->\n<!-- END crew:framework -->\n"
+	fixed, remaining := Fix(content, "")
+	if fixed != content || len(remaining) != 2 || remaining[0].Fixable {
+		t.Errorf("Expected nested sections to be left for the user, got %q, %+v", fixed, remaining)
+	}
+}
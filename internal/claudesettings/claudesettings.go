@@ -0,0 +1,741 @@
+// Package claudesettings edits Claude Code's settings.json files on behalf
+// of crew.
+//
+// Settings files belong to the user, so every write keeps what crew did not
+// change: members stay in their order, comments stay in place, and a file
+// that does not parse is never overwritten. The previous version of a file
+// is backed up before each write.
+//
+// Merge adds settings for an owner, e.g. the hooks or mcp component, and
+// records in a ledger exactly which keys and array elements it added.
+// Remove takes those out again and nothing else: settings the user already
+// had, or changed since, are left alone. EditCommands records the same way
+// which hook commands an owner registered in the hooks stanza.
+package claudesettings
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/crewdirs"
+)
+
+// Settings files in a Claude directory
+const (
+	FileName      = "settings.json"
+	LocalFileName = "settings.local.json"
+)
+
+// LedgerFile records the settings crew added, in the crew config directory
+const LedgerFile = "settings-ledger.json"
+
+// DefaultKeepBackups is how many backups of each settings file are kept
+const DefaultKeepBackups = 10
+
+// Change describes what a write did to a settings file. Settings are named
+// by their dotted path; added array elements as path += value.
+type Change struct {
+	Path    string `json:"path"`
+	Changed bool   `json:"changed"`
+	// Backup is the copy of the previous version, if the file changed
+	Backup  string   `json:"backup,omitempty"`
+	Added   []string `json:"added,omitempty"`
+	Removed []string `json:"removed,omitempty"`
+	// Kept are settings crew wanted to change or remove but left alone
+	// because the user set them
+	Kept []string `json:"kept,omitempty"`
+}
+
+// Editor edits the settings files of one Claude directory, ~/.claude or a
+// project's .claude, keeping backups and the ledger in its crew directory
+type Editor struct {
+	dir string
+	// BackupDir receives the backups, by default in the crew directory of
+	// the Claude directory. Editors of a project's .claude point it into
+	// the installation, so backups stay out of the project.
+	BackupDir string
+	// LedgerPath is where the ledger is kept, by default in the crew
+	// directory of the Claude directory; project editors move it like
+	// BackupDir
+	LedgerPath string
+	// KeepBackups is how many backups of each file are kept
+	KeepBackups int
+}
+
+// NewEditor creates an editor for the settings files in claudeDir
+func NewEditor(claudeDir string) *Editor {
+	return &Editor{
+		dir:         claudeDir,
+		BackupDir:   crewdirs.Path(claudeDir, "backups", "settings"),
+		LedgerPath:  crewdirs.Path(claudeDir, "config", LedgerFile),
+		KeepBackups: DefaultKeepBackups,
+	}
+}
+
+// Path returns the path of the settings file name
+func (e *Editor) Path(name string) string {
+	return filepath.Join(e.dir, name)
+}
+
+// Edit applies edit to the settings file name and writes the result back
+// if it changed. A missing file is created only if edit leaves something
+// in it. The edit may not give a known setting the wrong type; problems
+// the file already had do not stop it.
+func (e *Editor) Edit(name string, edit func(settings map[string]interface{})) (*Change, error) {
+	file, err := e.load(name)
+	if err != nil {
+		return nil, err
+	}
+	before := Validate(file.settings)
+	edit(file.settings)
+	generic, err := normalize(file.settings)
+	if err != nil {
+		return nil, fmt.Errorf("cannot update %s: %w", file.path, err)
+	}
+	file.settings, _ = generic.(map[string]interface{})
+	if file.settings == nil {
+		file.settings = make(map[string]interface{})
+	}
+	if err := checkNewProblems(file.path, before, file.settings); err != nil {
+		return nil, err
+	}
+	change := &Change{Path: file.path}
+	return change, e.save(file, change)
+}
+
+// Merge adds patch to the settings file name on behalf of owner. Objects
+// are merged member by member and arrays gain the elements they lack.
+// Settings the user set to something else are kept and reported. Merge
+// describes everything owner wants in the file: what owner added before
+// and patch no longer has is removed.
+func (e *Editor) Merge(name, owner string, patch map[string]interface{}) (*Change, error) {
+	generic, err := normalize(patch)
+	if err != nil {
+		return nil, fmt.Errorf("invalid settings for %s: %w", owner, err)
+	}
+	patch, _ = generic.(map[string]interface{})
+	if errs := Validate(patch); len(errs) > 0 {
+		return nil, fmt.Errorf("invalid settings for %s: %v", owner, errs[0])
+	}
+
+	file, err := e.load(name)
+	if err != nil {
+		return nil, err
+	}
+	ledger, err := e.loadLedger()
+	if err != nil {
+		return nil, err
+	}
+	before := Validate(file.settings)
+	change := &Change{Path: file.path}
+
+	// Hook commands are recorded by EditCommands, not patches
+	var stale, current, commands []entry
+	for _, en := range ledger.entries(name, owner) {
+		switch {
+		case en.Command != "":
+			commands = append(commands, en)
+		case en.in(patch):
+			current = append(current, en)
+		default:
+			stale = append(stale, en)
+		}
+	}
+	removeEntries(file.settings, stale, change)
+
+	m := &merger{owned: current, change: change}
+	m.object(file.settings, patch, nil)
+	if err := checkNewProblems(file.path, before, file.settings); err != nil {
+		return nil, err
+	}
+
+	if err := e.save(file, change); err != nil {
+		return nil, err
+	}
+	ledger.set(name, owner, append(m.owned, commands...))
+	return change, e.saveLedger(ledger)
+}
+
+// EditCommands is Edit for an owner that registers hook commands in the
+// hooks stanza. edit gets the commands recorded for owner and returns the
+// ones it leaves registered, which the ledger records in their place, so
+// Remove can take out exactly the entries crew wrote.
+func (e *Editor) EditCommands(name, owner string, edit func(settings map[string]interface{}, recorded []string) []string) (*Change, error) {
+	ledger, err := e.loadLedger()
+	if err != nil {
+		return nil, err
+	}
+	var registered []string
+	change, err := e.Edit(name, func(settings map[string]interface{}) {
+		registered = edit(settings, ledger.commands(name, owner))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []entry
+	for _, en := range ledger.entries(name, owner) {
+		if en.Command == "" {
+			entries = append(entries, en)
+		}
+	}
+	sort.Strings(registered)
+	for i, command := range registered {
+		if i == 0 || command != registered[i-1] {
+			entries = append(entries, entry{Command: command})
+		}
+	}
+	ledger.set(name, owner, entries)
+	return change, e.saveLedger(ledger)
+}
+
+// Commands returns the hook commands recorded for owner in the settings
+// file name, or for every owner when owner is empty
+func (e *Editor) Commands(name, owner string) ([]string, error) {
+	ledger, err := e.loadLedger()
+	if err != nil {
+		return nil, err
+	}
+	return ledger.commands(name, owner), nil
+}
+
+// Remove takes out of the settings file name what Merge added for owner,
+// or for every owner when owner is empty. Added values the user changed
+// since are kept, as are objects and arrays that still hold anything.
+func (e *Editor) Remove(name, owner string) (*Change, error) {
+	ledger, err := e.loadLedger()
+	if err != nil {
+		return nil, err
+	}
+	change := &Change{Path: e.Path(name)}
+	entries := ledger.entries(name, owner)
+	if len(entries) == 0 {
+		return change, nil
+	}
+
+	if _, err := os.Stat(change.Path); err == nil {
+		file, err := e.load(name)
+		if err != nil {
+			return nil, err
+		}
+		removeEntries(file.settings, entries, change)
+		if err := e.save(file, change); err != nil {
+			return nil, err
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	ledger.set(name, owner, nil)
+	return change, e.saveLedger(ledger)
+}
+
+// Owners returns who has settings recorded in the ledger for the settings
+// file name
+func (e *Editor) Owners(name string) ([]string, error) {
+	ledger, err := e.loadLedger()
+	if err != nil {
+		return nil, err
+	}
+	var owners []string
+	for owner := range ledger.Files[name] {
+		owners = append(owners, owner)
+	}
+	sort.Strings(owners)
+	return owners, nil
+}
+
+// Read returns the settings in the file at path, accepting the comments
+// Edit and Merge preserve, or nil if there is no such file
+func Read(path string) (map[string]interface{}, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	doc, err := parseDocument(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSON in %s: %w", path, err)
+	}
+	return doc.root.value().(map[string]interface{}), nil
+}
+
+// settingsFile is a settings file as read
+type settingsFile struct {
+	path     string
+	original []byte
+	exists   bool
+	mode     os.FileMode
+	doc      *document
+	settings map[string]interface{}
+}
+
+func (e *Editor) load(name string) (*settingsFile, error) {
+	file := &settingsFile{path: e.Path(name), mode: 0644}
+	data, err := os.ReadFile(file.path)
+	switch {
+	case err == nil:
+		file.original, file.exists = data, true
+		if info, err := os.Stat(file.path); err == nil {
+			file.mode = info.Mode().Perm()
+		}
+	case !os.IsNotExist(err):
+		return nil, fmt.Errorf("failed to read %s: %w", file.path, err)
+	}
+
+	file.doc, err = parseDocument(data)
+	if err != nil {
+		return nil, fmt.Errorf("cannot update %s: invalid JSON: %w", file.path, err)
+	}
+	file.settings = file.doc.root.value().(map[string]interface{})
+	return file, nil
+}
+
+// save writes file with its edited, normalized settings if that changes
+// it, backing up the previous version first. The file is replaced in one
+// rename so Claude Code never reads it half written.
+func (e *Editor) save(file *settingsFile, change *Change) error {
+	if !file.exists && len(file.settings) == 0 {
+		return nil
+	}
+	file.doc.root = reconcile(file.doc.root, file.settings)
+	data := file.doc.bytes()
+	if file.exists && bytes.Equal(data, file.original) {
+		return nil
+	}
+
+	if file.exists {
+		backup, err := e.backup(file)
+		if err != nil {
+			return fmt.Errorf("failed to back up %s: %w", file.path, err)
+		}
+		change.Backup = backup
+	}
+	if err := os.MkdirAll(filepath.Dir(file.path), 0755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(file.path), "."+filepath.Base(file.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), file.mode); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), file.path); err != nil {
+		return fmt.Errorf("failed to write %s: %w", file.path, err)
+	}
+	change.Changed = true
+	return nil
+}
+
+// backup copies the current version of file to the backup directory and
+// prunes the oldest backups of it beyond KeepBackups
+func (e *Editor) backup(file *settingsFile) (string, error) {
+	if err := os.MkdirAll(e.BackupDir, 0755); err != nil {
+		return "", err
+	}
+	stem := strings.TrimSuffix(filepath.Base(file.path), ".json")
+	path := filepath.Join(e.BackupDir, fmt.Sprintf("%s_%s.json", stem, time.Now().Format("20060102_150405.000")))
+	if err := os.WriteFile(path, file.original, file.mode); err != nil {
+		return "", err
+	}
+
+	if e.KeepBackups > 0 {
+		// The timestamp sorts backups oldest first
+		backups, _ := filepath.Glob(filepath.Join(e.BackupDir, stem+"_*.json"))
+		sort.Strings(backups)
+		for len(backups) > e.KeepBackups {
+			os.Remove(backups[0])
+			backups = backups[1:]
+		}
+	}
+	return path, nil
+}
+
+// checkNewProblems rejects settings an edit gave the wrong type, ignoring
+// problems that were there before
+func checkNewProblems(path string, before []error, settings map[string]interface{}) error {
+	known := make(map[string]bool, len(before))
+	for _, err := range before {
+		known[err.Error()] = true
+	}
+	for _, err := range Validate(settings) {
+		if !known[err.Error()] {
+			return fmt.Errorf("refusing to update %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// entry is one thing Merge added to a settings file: an object or array it
+// created, a setting, or an array element; or a hook command EditCommands
+// recorded
+type entry struct {
+	Path      []string    `json:"path,omitempty"`
+	Value     interface{} `json:"value,omitempty"`
+	Element   bool        `json:"element,omitempty"`
+	Container bool        `json:"container,omitempty"`
+	Command   string      `json:"command,omitempty"`
+}
+
+func (en entry) String() string {
+	switch {
+	case en.Command != "":
+		return fmt.Sprintf("hooks += %s", marshal(en.Command))
+	case en.Element:
+		return fmt.Sprintf("%s += %s", dotted(en.Path), marshal(en.Value))
+	case en.Container:
+		return dotted(en.Path)
+	}
+	return fmt.Sprintf("%s = %s", dotted(en.Path), marshal(en.Value))
+}
+
+// in reports whether patch still asks for what en added. A setting whose
+// value changed still counts, so Merge can update it.
+func (en entry) in(patch map[string]interface{}) bool {
+	value, ok := lookup(patch, en.Path)
+	if !ok {
+		return false
+	}
+	switch {
+	case en.Container:
+		switch value.(type) {
+		case map[string]interface{}, []interface{}:
+			return true
+		}
+		return false
+	case en.Element:
+		list, _ := value.([]interface{})
+		return indexOf(list, en.Value) >= 0
+	}
+	return true
+}
+
+// ledger records per settings file and owner what Merge added
+type ledger struct {
+	Files map[string]map[string][]entry `json:"files"`
+}
+
+func (l *ledger) entries(name, owner string) []entry {
+	if owner != "" {
+		return l.Files[name][owner]
+	}
+	owners := make([]string, 0, len(l.Files[name]))
+	for o := range l.Files[name] {
+		owners = append(owners, o)
+	}
+	sort.Strings(owners)
+	var all []entry
+	for _, o := range owners {
+		all = append(all, l.Files[name][o]...)
+	}
+	return all
+}
+
+// commands returns the hook commands recorded for owner, or for every
+// owner when owner is empty
+func (l *ledger) commands(name, owner string) []string {
+	var commands []string
+	for _, en := range l.entries(name, owner) {
+		if en.Command != "" {
+			commands = append(commands, en.Command)
+		}
+	}
+	return commands
+}
+
+// set replaces the entries of owner, or of every owner when owner is empty
+func (l *ledger) set(name, owner string, entries []entry) {
+	if owner == "" {
+		delete(l.Files, name)
+		return
+	}
+	if len(entries) == 0 {
+		delete(l.Files[name], owner)
+		if len(l.Files[name]) == 0 {
+			delete(l.Files, name)
+		}
+		return
+	}
+	if l.Files[name] == nil {
+		l.Files[name] = make(map[string][]entry)
+	}
+	l.Files[name][owner] = entries
+}
+
+func (e *Editor) loadLedger() (*ledger, error) {
+	l := &ledger{Files: make(map[string]map[string][]entry)}
+	data, err := os.ReadFile(e.LedgerPath)
+	if os.IsNotExist(err) {
+		return l, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read settings ledger: %w", err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(l); err != nil {
+		return nil, fmt.Errorf("invalid settings ledger %s: %w", e.LedgerPath, err)
+	}
+	if l.Files == nil {
+		l.Files = make(map[string]map[string][]entry)
+	}
+	return l, nil
+}
+
+func (e *Editor) saveLedger(l *ledger) error {
+	if len(l.Files) == 0 {
+		if err := os.Remove(e.LedgerPath); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(e.LedgerPath), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(e.LedgerPath, data, 0644)
+}
+
+// merger merges a patch into settings, tracking what the owner added
+type merger struct {
+	owned  []entry
+	change *Change
+}
+
+func (m *merger) object(dst, patch map[string]interface{}, path []string) {
+	for _, key := range sortedKeys(patch) {
+		memberPath := append(path[:len(path):len(path)], key)
+		current, exists := dst[key]
+
+		switch want := patch[key].(type) {
+		case map[string]interface{}:
+			if !exists {
+				current = make(map[string]interface{})
+				dst[key] = current
+				m.add(entry{Path: memberPath, Container: true}, false)
+			}
+			if object, ok := current.(map[string]interface{}); ok {
+				m.object(object, want, memberPath)
+			} else {
+				m.change.Kept = append(m.change.Kept, dotted(memberPath))
+			}
+
+		case []interface{}:
+			if !exists {
+				current = []interface{}{}
+				m.add(entry{Path: memberPath, Container: true}, false)
+			}
+			list, ok := current.([]interface{})
+			if !ok {
+				m.change.Kept = append(m.change.Kept, dotted(memberPath))
+				continue
+			}
+			for _, element := range want {
+				if indexOf(list, element) < 0 {
+					list = append(list, element)
+					m.add(entry{Path: memberPath, Value: element, Element: true}, true)
+				}
+			}
+			dst[key] = list
+
+		default:
+			switch {
+			case !exists:
+				dst[key] = want
+				m.add(entry{Path: memberPath, Value: want}, true)
+			case reflect.DeepEqual(current, want):
+			case m.updates(memberPath, current, want):
+				dst[key] = want
+			default:
+				m.change.Kept = append(m.change.Kept, dotted(memberPath))
+			}
+		}
+	}
+}
+
+// add records en as owned, unless it already is, and reports it as added
+func (m *merger) add(en entry, report bool) {
+	for _, owned := range m.owned {
+		if reflect.DeepEqual(owned, en) {
+			return
+		}
+	}
+	m.owned = append(m.owned, en)
+	if report {
+		m.change.Added = append(m.change.Added, en.String())
+	}
+}
+
+// updates changes a setting the owner added and the user left as it was
+func (m *merger) updates(path []string, current, want interface{}) bool {
+	for i, owned := range m.owned {
+		if owned.Element || owned.Container || !reflect.DeepEqual(owned.Path, path) {
+			continue
+		}
+		if !reflect.DeepEqual(owned.Value, current) {
+			return false
+		}
+		m.owned[i].Value = want
+		m.change.Added = append(m.change.Added, m.owned[i].String())
+		return true
+	}
+	return false
+}
+
+// removeEntries undoes entries in reverse order, so objects and arrays
+// are emptied before they are considered for removal
+func removeEntries(settings map[string]interface{}, entries []entry, change *Change) {
+	for i := len(entries) - 1; i >= 0; i-- {
+		en := entries[i]
+		if en.Command != "" {
+			if removeCommand(settings, en.Command) {
+				change.Removed = append(change.Removed, en.String())
+			}
+			continue
+		}
+		parent, ok := lookupParent(settings, en.Path)
+		if !ok {
+			continue
+		}
+		key := en.Path[len(en.Path)-1]
+		current, exists := parent[key]
+		if !exists {
+			continue
+		}
+
+		switch {
+		case en.Container:
+			if isEmpty(current) {
+				delete(parent, key)
+			}
+		case en.Element:
+			list, _ := current.([]interface{})
+			if at := indexOf(list, en.Value); at >= 0 {
+				parent[key] = append(list[:at:at], list[at+1:]...)
+				change.Removed = append(change.Removed, en.String())
+			}
+		case reflect.DeepEqual(current, en.Value):
+			delete(parent, key)
+			change.Removed = append(change.Removed, en.String())
+		default:
+			change.Kept = append(change.Kept, dotted(en.Path))
+		}
+	}
+}
+
+// removeCommand takes the entries running command out of the hooks
+// stanza, dropping the matcher groups and events it leaves empty
+func removeCommand(settings map[string]interface{}, command string) bool {
+	stanza, ok := settings["hooks"].(map[string]interface{})
+	if !ok {
+		return false
+	}
+
+	removed := false
+	for event, rawGroups := range stanza {
+		groups, ok := rawGroups.([]interface{})
+		if !ok {
+			continue
+		}
+		kept := groups[:0:0]
+		for _, g := range groups {
+			group, _ := g.(map[string]interface{})
+			list, ok := group["hooks"].([]interface{})
+			if !ok {
+				kept = append(kept, g)
+				continue
+			}
+			var hooks []interface{}
+			for _, h := range list {
+				if hook, ok := h.(map[string]interface{}); ok && hook["command"] == command {
+					continue
+				}
+				hooks = append(hooks, h)
+			}
+			if len(hooks) == len(list) {
+				kept = append(kept, g)
+				continue
+			}
+			removed = true
+			if len(hooks) > 0 {
+				group["hooks"] = hooks
+				kept = append(kept, group)
+			}
+		}
+		if len(kept) == 0 && len(groups) > 0 {
+			delete(stanza, event)
+		} else {
+			stanza[event] = kept
+		}
+	}
+	if removed && len(stanza) == 0 {
+		delete(settings, "hooks")
+	}
+	return removed
+}
+
+// lookup returns the value at path in settings
+func lookup(settings map[string]interface{}, path []string) (interface{}, bool) {
+	parent, ok := lookupParent(settings, path)
+	if !ok {
+		return nil, false
+	}
+	value, ok := parent[path[len(path)-1]]
+	return value, ok
+}
+
+// lookupParent returns the object holding the last member of path
+func lookupParent(settings map[string]interface{}, path []string) (map[string]interface{}, bool) {
+	if len(path) == 0 {
+		return nil, false
+	}
+	object := settings
+	for _, key := range path[:len(path)-1] {
+		child, ok := object[key].(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		object = child
+	}
+	return object, true
+}
+
+func indexOf(list []interface{}, value interface{}) int {
+	for i, element := range list {
+		if reflect.DeepEqual(element, value) {
+			return i
+		}
+	}
+	return -1
+}
+
+func isEmpty(value interface{}) bool {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		return len(value) == 0
+	case []interface{}:
+		return len(value) == 0
+	}
+	return false
+}
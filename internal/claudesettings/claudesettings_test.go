@@ -0,0 +1,277 @@
+package claudesettings
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const userSettings = `// My Claude settings
+{
+  "model": "opus",
+  /* team defaults */
+  "permissions": {
+    "allow": [
+      "Bash(npm run lint)",
+      // read anything
+      "Read(**)",
+    ],
+    "defaultMode": "acceptEdits"
+  },
+  "cleanupPeriodDays": 30,
+  "env": {"EDITOR": "vim"}
+}
+`
+
+func writeSettings(t *testing.T, dir, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, FileName)
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestParseRoundTrip(t *testing.T) {
+	doc, err := parseDocument([]byte(userSettings))
+	if err != nil {
+		t.Fatal(err)
+	}
+	settings := doc.root.value().(map[string]interface{})
+	doc.root = reconcile(doc.root, settings)
+	got := string(doc.bytes())
+
+	for _, want := range []string{"// My Claude settings\n{", "  /* team defaults */\n  \"permissions\"", "      // read anything\n      \"Read(**)\"", "\"cleanupPeriodDays\": 30,"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("round trip lost %q:\n%s", want, got)
+		}
+	}
+	if strings.Index(got, `"model"`) > strings.Index(got, `"permissions"`) {
+		t.Errorf("round trip reordered members:\n%s", got)
+	}
+
+	for _, invalid := range []string{`{"a": }`, `{"a": 1`, `[1, 2]`, `{"a": 1} x`, `{/* open`} {
+		if _, err := parseDocument([]byte(invalid)); err == nil {
+			t.Errorf("parseDocument(%q) succeeded, want an error", invalid)
+		}
+	}
+}
+
+func TestEditKeepsCommentsAndBacksUp(t *testing.T) {
+	dir := t.TempDir()
+	path := writeSettings(t, dir, userSettings)
+	editor := NewEditor(dir)
+	editor.BackupDir = filepath.Join(t.TempDir(), "backups")
+
+	change, err := editor.Edit(FileName, func(settings map[string]interface{}) {
+		permissions := settings["permissions"].(map[string]interface{})
+		permissions["allow"] = append(permissions["allow"].([]interface{}), "Bash(crew:*)")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !change.Changed || filepath.Dir(change.Backup) != editor.BackupDir || readFile(t, change.Backup) != userSettings {
+		t.Errorf("expected the previous settings backed up in %s, got %+v", editor.BackupDir, change)
+	}
+	edited := readFile(t, path)
+	if !strings.Contains(edited, "// read anything") || !strings.Contains(edited, `"Bash(crew:*)"`) {
+		t.Errorf("unexpected edited settings:\n%s", edited)
+	}
+	if info, _ := os.Stat(path); info.Mode().Perm() != 0600 {
+		t.Errorf("mode = %v, want the file's own 0600", info.Mode().Perm())
+	}
+
+	// An edit that changes nothing leaves the file alone
+	if change, err := editor.Edit(FileName, func(map[string]interface{}) {}); err != nil || change.Changed {
+		t.Errorf("an empty edit changed the file: %+v, %v", change, err)
+	}
+}
+
+func TestMergeAndRemove(t *testing.T) {
+	dir := t.TempDir()
+	path := writeSettings(t, dir, userSettings)
+	editor := NewEditor(dir)
+
+	patch := map[string]interface{}{
+		"permissions": map[string]interface{}{
+			"allow":       []string{"Read(**)", "Bash(crew:*)"},
+			"defaultMode": "plan",
+		},
+		"env":                   map[string]string{"CREW_HOME": "/opt/crew"},
+		"enabledMcpjsonServers": []string{"context7"},
+	}
+	change, err := editor.Merge(FileName, "mcp", patch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantAdded := []string{`enabledMcpjsonServers += "context7"`, `env.CREW_HOME = "/opt/crew"`, `permissions.allow += "Bash(crew:*)"`}
+	if !change.Changed || !reflect.DeepEqual(change.Added, wantAdded) {
+		t.Errorf("Added = %v, want %v", change.Added, wantAdded)
+	}
+	// The user chose acceptEdits; crew does not override it
+	if !reflect.DeepEqual(change.Kept, []string{"permissions.defaultMode"}) {
+		t.Errorf("Kept = %v, want permissions.defaultMode", change.Kept)
+	}
+	if change.Backup == "" || readFile(t, change.Backup) != userSettings {
+		t.Errorf("expected the previous settings backed up, got %q", change.Backup)
+	}
+	merged := readFile(t, path)
+	if !strings.Contains(merged, "// read anything") || !strings.Contains(merged, `"Bash(crew:*)"`) {
+		t.Errorf("unexpected merged settings:\n%s", merged)
+	}
+	if info, _ := os.Stat(path); info.Mode().Perm() != 0600 {
+		t.Errorf("mode = %v, want the file's own 0600", info.Mode().Perm())
+	}
+
+	// Merging again changes nothing
+	if change, err := editor.Merge(FileName, "mcp", patch); err != nil || change.Changed {
+		t.Errorf("second Merge changed the file: %+v, %v", change, err)
+	}
+
+	// The user edits a value crew added
+	edited := strings.Replace(readFile(t, path), "/opt/crew", "/usr/local/crew", 1)
+	writeSettings(t, dir, edited)
+
+	change, err = editor.Remove(FileName, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(change.Kept, []string{"env.CREW_HOME"}) {
+		t.Errorf("Kept = %v, want env.CREW_HOME", change.Kept)
+	}
+	want := strings.Replace(userSettings, `"env": {"EDITOR": "vim"}`, "\"env\": {\n    \"EDITOR\": \"vim\",\n    \"CREW_HOME\": \"/usr/local/crew\"\n  }", 1)
+	want = strings.Replace(want, "\"Read(**)\",\n", "\"Read(**)\"\n", 1)
+	if got := readFile(t, path); got != want {
+		t.Errorf("Remove left:\n%s\nwant:\n%s", got, want)
+	}
+	if owners, _ := editor.Owners(FileName); len(owners) != 0 {
+		t.Errorf("ledger still has owners %v", owners)
+	}
+}
+
+func TestMergeDropsWhatThePatchNoLongerHas(t *testing.T) {
+	dir := t.TempDir()
+	editor := NewEditor(dir)
+	if _, err := editor.Merge(FileName, "hooks", map[string]interface{}{
+		"permissions": map[string]interface{}{"allow": []string{"Bash(a)", "Bash(b)"}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	change, err := editor.Merge(FileName, "hooks", map[string]interface{}{
+		"permissions": map[string]interface{}{"allow": []string{"Bash(b)"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(change.Removed, []string{`permissions.allow += "Bash(a)"`}) {
+		t.Errorf("Removed = %v", change.Removed)
+	}
+
+	// Removing the last owner takes out the objects crew created
+	if _, err := editor.Remove(FileName, "hooks"); err != nil {
+		t.Fatal(err)
+	}
+	if got := readFile(t, editor.Path(FileName)); got != "{}\n" {
+		t.Errorf("expected an empty object, got %q", got)
+	}
+}
+
+func TestEditCommandsRecordsHookCommands(t *testing.T) {
+	dir := t.TempDir()
+	path := writeSettings(t, dir, `{
+  "hooks": {
+    "PreToolUse": [
+      {"matcher": "Bash", "hooks": [{"type": "command", "command": "/home/me/guard.sh"}]}
+    ]
+  }
+}
+`)
+	editor := NewEditor(dir)
+
+	register := func(commands ...string) {
+		t.Helper()
+		_, err := editor.EditCommands(FileName, "hooks", func(settings map[string]interface{}, recorded []string) []string {
+			stanza := settings["hooks"].(map[string]interface{})
+			groups := stanza["PreToolUse"].([]interface{})
+			for _, command := range commands {
+				groups = append(groups, map[string]interface{}{
+					"matcher": "Edit",
+					"hooks":   []interface{}{map[string]interface{}{"type": "command", "command": command}},
+				})
+			}
+			stanza["PreToolUse"] = groups
+			return commands
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	register("/work/app/SuperCrew/Hooks/lint.sh")
+	if commands, _ := editor.Commands(FileName, "hooks"); !reflect.DeepEqual(commands, []string{"/work/app/SuperCrew/Hooks/lint.sh"}) {
+		t.Errorf("Commands = %v", commands)
+	}
+
+	// Merging for the same owner keeps the recorded commands
+	if _, err := editor.Merge(FileName, "hooks", map[string]interface{}{"env": map[string]interface{}{"CREW": "1"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	change, err := editor.Remove(FileName, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{`hooks += "/work/app/SuperCrew/Hooks/lint.sh"`, `env.CREW = "1"`}
+	if !reflect.DeepEqual(change.Removed, want) {
+		t.Errorf("Removed = %v, want %v", change.Removed, want)
+	}
+	got := readFile(t, path)
+	if strings.Contains(got, "lint.sh") || !strings.Contains(got, "/home/me/guard.sh") {
+		t.Errorf("expected only the user's hook left:\n%s", got)
+	}
+	if commands, _ := editor.Commands(FileName, ""); len(commands) != 0 {
+		t.Errorf("ledger still records %v", commands)
+	}
+}
+
+func TestEditRefusesBadFilesAndTypes(t *testing.T) {
+	dir := t.TempDir()
+	editor := NewEditor(dir)
+
+	path := writeSettings(t, dir, "{not json")
+	if _, err := editor.Edit(FileName, func(map[string]interface{}) {}); err == nil {
+		t.Error("expected an error for invalid settings")
+	}
+	if readFile(t, path) != "{not json" {
+		t.Error("invalid settings were overwritten")
+	}
+
+	writeSettings(t, dir, `{"model": 3}`)
+	_, err := editor.Edit(FileName, func(settings map[string]interface{}) {
+		settings["permissions"] = map[string]interface{}{"allow": "Bash(*)"}
+	})
+	if err == nil || !strings.Contains(err.Error(), "permissions.allow must be an array of strings") {
+		t.Errorf("expected the wrong type to be refused, got %v", err)
+	}
+	// The file's own problem does not stop unrelated edits
+	if _, err := editor.Edit(FileName, func(settings map[string]interface{}) {
+		settings["includeCoAuthoredBy"] = false
+	}); err != nil {
+		t.Errorf("Edit failed: %v", err)
+	}
+
+	missing := NewEditor(filepath.Join(dir, "none"))
+	if change, err := missing.Edit(FileName, func(map[string]interface{}) {}); err != nil || change.Changed {
+		t.Errorf("an empty edit created a settings file: %+v, %v", change, err)
+	}
+}
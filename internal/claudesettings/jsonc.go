@@ -0,0 +1,442 @@
+package claudesettings
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+type nodeKind int
+
+const (
+	scalarNode nodeKind = iota
+	objectNode
+	arrayNode
+)
+
+// node is a JSON value as read. Scalars keep their text so numbers are
+// written back exactly, and members and elements keep the comments before
+// them so that writing the document back preserves them.
+type node struct {
+	kind  nodeKind
+	raw   []byte
+	items []*item
+	// tail holds the comments after the last item
+	tail []string
+}
+
+// item is an object member or an array element; key is empty for elements
+type item struct {
+	key      string
+	comments []string
+	value    *node
+}
+
+// document is a settings file: a top-level object with the comments before
+// and after it
+type document struct {
+	head []string
+	root *node
+	tail []string
+}
+
+// parseDocument reads a settings file. Besides strict JSON it accepts the
+// // and /* */ comments and trailing commas people put in hand-edited
+// files. An empty file or null is an empty object.
+func parseDocument(src []byte) (*document, error) {
+	p := &parser{src: src}
+	if err := p.skip(); err != nil {
+		return nil, err
+	}
+	doc := &document{head: p.take(), root: &node{kind: objectNode}}
+	if p.eof() {
+		return doc, nil
+	}
+
+	root, err := p.value()
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case root.kind == objectNode:
+		doc.root = root
+	case string(root.raw) != "null":
+		return nil, fmt.Errorf("settings must be a JSON object")
+	}
+
+	if err := p.skip(); err != nil {
+		return nil, err
+	}
+	doc.tail = p.take()
+	if !p.eof() {
+		return nil, p.errorf("unexpected %q after the settings object", p.src[p.pos])
+	}
+	return doc, nil
+}
+
+type parser struct {
+	src      []byte
+	pos      int
+	comments []string
+}
+
+func (p *parser) eof() bool {
+	return p.pos >= len(p.src)
+}
+
+// errorf reports a syntax error at the current line
+func (p *parser) errorf(format string, args ...interface{}) error {
+	line := 1 + bytes.Count(p.src[:p.pos], []byte("\n"))
+	return fmt.Errorf("line %d: %s", line, fmt.Sprintf(format, args...))
+}
+
+// take returns the comments read since the last call
+func (p *parser) take() []string {
+	comments := p.comments
+	p.comments = nil
+	return comments
+}
+
+// skip moves past whitespace and comments, collecting the comments
+func (p *parser) skip() error {
+	for !p.eof() {
+		switch c := p.src[p.pos]; {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			p.pos++
+		case bytes.HasPrefix(p.src[p.pos:], []byte("//")):
+			end := bytes.IndexByte(p.src[p.pos:], '\n')
+			if end < 0 {
+				end = len(p.src) - p.pos
+			}
+			p.comments = append(p.comments, strings.TrimRight(string(p.src[p.pos:p.pos+end]), " \t\r"))
+			p.pos += end
+		case bytes.HasPrefix(p.src[p.pos:], []byte("/*")):
+			end := bytes.Index(p.src[p.pos+2:], []byte("*/"))
+			if end < 0 {
+				return p.errorf("unterminated comment")
+			}
+			p.comments = append(p.comments, string(p.src[p.pos:p.pos+end+4]))
+			p.pos += end + 4
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+func (p *parser) value() (*node, error) {
+	if p.eof() {
+		return nil, p.errorf("unexpected end of file")
+	}
+	switch p.src[p.pos] {
+	case '{':
+		return p.container(objectNode, '}')
+	case '[':
+		return p.container(arrayNode, ']')
+	case '"':
+		raw, err := p.str()
+		if err != nil {
+			return nil, err
+		}
+		return &node{kind: scalarNode, raw: raw}, nil
+	}
+
+	start := p.pos
+	for !p.eof() && !strings.ContainsRune(",]} \t\r\n/", rune(p.src[p.pos])) {
+		p.pos++
+	}
+	raw := p.src[start:p.pos]
+	if len(raw) == 0 || !json.Valid(raw) {
+		p.pos = start
+		return nil, p.errorf("invalid value %q", truncate(string(raw)))
+	}
+	return &node{kind: scalarNode, raw: raw}, nil
+}
+
+// container reads an object or array. Comments between a member's name and
+// its value are moved before the member.
+func (p *parser) container(kind nodeKind, closing byte) (*node, error) {
+	p.pos++
+	n := &node{kind: kind}
+	for {
+		if err := p.skip(); err != nil {
+			return nil, err
+		}
+		if p.eof() {
+			return nil, p.errorf("unexpected end of file, expected %q", closing)
+		}
+		if p.src[p.pos] == closing {
+			p.pos++
+			n.tail = p.take()
+			return n, nil
+		}
+
+		it := &item{}
+		if kind == objectNode {
+			if p.src[p.pos] != '"' {
+				return nil, p.errorf("expected a member name, found %q", p.src[p.pos])
+			}
+			raw, err := p.str()
+			if err != nil {
+				return nil, err
+			}
+			if err := json.Unmarshal(raw, &it.key); err != nil {
+				return nil, p.errorf("invalid member name %s", raw)
+			}
+			if err := p.skip(); err != nil {
+				return nil, err
+			}
+			if p.eof() || p.src[p.pos] != ':' {
+				return nil, p.errorf("expected ':' after %s", raw)
+			}
+			p.pos++
+			if err := p.skip(); err != nil {
+				return nil, err
+			}
+		}
+		it.comments = p.take()
+
+		value, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		it.value = value
+		n.items = append(n.items, it)
+
+		if err := p.skip(); err != nil {
+			return nil, err
+		}
+		if p.eof() {
+			return nil, p.errorf("unexpected end of file, expected %q", closing)
+		}
+		switch p.src[p.pos] {
+		case ',':
+			p.pos++
+		case closing:
+		default:
+			return nil, p.errorf("expected ',' or %q, found %q", closing, p.src[p.pos])
+		}
+	}
+}
+
+// str reads a string literal and returns it as written
+func (p *parser) str() ([]byte, error) {
+	start := p.pos
+	for p.pos++; !p.eof(); p.pos++ {
+		switch p.src[p.pos] {
+		case '\\':
+			p.pos++
+		case '"':
+			p.pos++
+			raw := p.src[start:p.pos]
+			if !json.Valid(raw) {
+				p.pos = start
+				return nil, p.errorf("invalid string %s", truncate(string(raw)))
+			}
+			return raw, nil
+		}
+	}
+	p.pos = start
+	return nil, p.errorf("unterminated string")
+}
+
+func truncate(s string) string {
+	if len(s) > 40 {
+		return s[:40] + "..."
+	}
+	return s
+}
+
+// value returns n as the generic values encoding/json decodes to, with
+// numbers as json.Number
+func (n *node) value() interface{} {
+	switch n.kind {
+	case objectNode:
+		m := make(map[string]interface{}, len(n.items))
+		for _, it := range n.items {
+			m[it.key] = it.value.value()
+		}
+		return m
+	case arrayNode:
+		list := make([]interface{}, 0, len(n.items))
+		for _, it := range n.items {
+			list = append(list, it.value.value())
+		}
+		return list
+	}
+	var v interface{}
+	decoder := json.NewDecoder(bytes.NewReader(n.raw))
+	decoder.UseNumber()
+	_ = decoder.Decode(&v)
+	return v
+}
+
+// normalize converts v to the generic values value returns, so values
+// built in Go compare equal to the same values read from a file
+func normalize(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var generic interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&generic); err != nil {
+		return nil, err
+	}
+	return generic, nil
+}
+
+// reconcile returns a node for v that reuses the parts of old that did
+// not change: members keep their position and comments, elements equal to
+// an old element keep its comments, and unchanged scalars their text. New
+// members are added at the end in name order. v must be normalized.
+func reconcile(old *node, v interface{}) *node {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		if old == nil || old.kind != objectNode {
+			return newNode(v)
+		}
+		n := &node{kind: objectNode, tail: old.tail}
+		seen := make(map[string]bool, len(v))
+		for _, it := range old.items {
+			value, ok := v[it.key]
+			if !ok || seen[it.key] {
+				continue
+			}
+			seen[it.key] = true
+			n.items = append(n.items, &item{key: it.key, comments: it.comments, value: reconcile(it.value, value)})
+		}
+		for _, key := range sortedKeys(v) {
+			if !seen[key] {
+				n.items = append(n.items, &item{key: key, value: newNode(v[key])})
+			}
+		}
+		return n
+
+	case []interface{}:
+		if old == nil || old.kind != arrayNode {
+			return newNode(v)
+		}
+		n := &node{kind: arrayNode, tail: old.tail}
+		used := make([]bool, len(old.items))
+		for _, element := range v {
+			it := &item{value: newNode(element)}
+			for i, candidate := range old.items {
+				if !used[i] && reflect.DeepEqual(candidate.value.value(), element) {
+					used[i] = true
+					it = candidate
+					break
+				}
+			}
+			n.items = append(n.items, it)
+		}
+		return n
+	}
+
+	if old != nil && old.kind == scalarNode && reflect.DeepEqual(old.value(), v) {
+		return old
+	}
+	return newNode(v)
+}
+
+// newNode builds a node for a normalized value
+func newNode(v interface{}) *node {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		n := &node{kind: objectNode}
+		for _, key := range sortedKeys(v) {
+			n.items = append(n.items, &item{key: key, value: newNode(v[key])})
+		}
+		return n
+	case []interface{}:
+		n := &node{kind: arrayNode}
+		for _, element := range v {
+			n.items = append(n.items, &item{value: newNode(element)})
+		}
+		return n
+	}
+	return &node{kind: scalarNode, raw: marshal(v)}
+}
+
+// marshal encodes a scalar without escaping <, > and &, which are common in
+// hook commands
+func marshal(v interface{}) []byte {
+	var b bytes.Buffer
+	encoder := json.NewEncoder(&b)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
+		return []byte("null")
+	}
+	return bytes.TrimRight(b.Bytes(), "\n")
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// bytes writes the document indented by two spaces, as Claude Code does
+func (d *document) bytes() []byte {
+	var b bytes.Buffer
+	writeComments(&b, d.head, 0)
+	d.root.write(&b, 0)
+	b.WriteByte('\n')
+	writeComments(&b, d.tail, 0)
+	return b.Bytes()
+}
+
+func (n *node) write(b *bytes.Buffer, depth int) {
+	if n.kind == scalarNode {
+		b.Write(n.raw)
+		return
+	}
+
+	open, closing := byte('{'), byte('}')
+	if n.kind == arrayNode {
+		open, closing = '[', ']'
+	}
+	b.WriteByte(open)
+	if len(n.items) == 0 && len(n.tail) == 0 {
+		b.WriteByte(closing)
+		return
+	}
+	b.WriteByte('\n')
+	for i, it := range n.items {
+		writeComments(b, it.comments, depth+1)
+		indent(b, depth+1)
+		if n.kind == objectNode {
+			b.Write(marshal(it.key))
+			b.WriteString(": ")
+		}
+		it.value.write(b, depth+1)
+		if i < len(n.items)-1 {
+			b.WriteByte(',')
+		}
+		b.WriteByte('\n')
+	}
+	writeComments(b, n.tail, depth+1)
+	indent(b, depth)
+	b.WriteByte(closing)
+}
+
+func writeComments(b *bytes.Buffer, comments []string, depth int) {
+	for _, comment := range comments {
+		indent(b, depth)
+		b.WriteString(comment)
+		b.WriteByte('\n')
+	}
+}
+
+func indent(b *bytes.Buffer, depth int) {
+	for i := 0; i < depth; i++ {
+		b.WriteString("  ")
+	}
+}
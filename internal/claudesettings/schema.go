@@ -0,0 +1,130 @@
+package claudesettings
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// valueType is the type Claude Code expects for a setting
+type valueType string
+
+const (
+	typeObject  valueType = "an object"
+	typeArray   valueType = "an array"
+	typeStrings valueType = "an array of strings"
+	typeString  valueType = "a string"
+	typeBool    valueType = "true or false"
+	typeNumber  valueType = "a number"
+)
+
+// schema holds the settings whose type crew checks before writing, by
+// dotted path; * stands for any member name. Settings not listed are
+// written as they are, so settings newer than crew pass through.
+var schema = map[string]valueType{
+	"apiKeyHelper":                      typeString,
+	"awsAuthRefresh":                    typeString,
+	"awsCredentialExport":               typeString,
+	"cleanupPeriodDays":                 typeNumber,
+	"disableAllHooks":                   typeBool,
+	"disabledMcpjsonServers":            typeStrings,
+	"enableAllProjectMcpServers":        typeBool,
+	"enabledMcpjsonServers":             typeStrings,
+	"env":                               typeObject,
+	"env.*":                             typeString,
+	"forceLoginMethod":                  typeString,
+	"hooks":                             typeObject,
+	"hooks.*":                           typeArray,
+	"includeCoAuthoredBy":               typeBool,
+	"model":                             typeString,
+	"outputStyle":                       typeString,
+	"permissions":                       typeObject,
+	"permissions.additionalDirectories": typeStrings,
+	"permissions.allow":                 typeStrings,
+	"permissions.ask":                   typeStrings,
+	"permissions.defaultMode":           typeString,
+	"permissions.deny":                  typeStrings,
+	"permissions.disableBypassPermissionsMode": typeString,
+	"statusLine": typeObject,
+}
+
+// Validate checks settings against the types Claude Code expects for the
+// settings crew knows. Each problem names the setting by its dotted path.
+func Validate(settings map[string]interface{}) []error {
+	var errs []error
+	validateObject(settings, nil, &errs)
+	return errs
+}
+
+func validateObject(object map[string]interface{}, path []string, errs *[]error) {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		memberPath := append(path[:len(path):len(path)], key)
+		value := object[key]
+		want, known := lookupType(memberPath)
+		if known && !hasType(value, want) {
+			*errs = append(*errs, fmt.Errorf("%s must be %s", dotted(memberPath), want))
+			continue
+		}
+		if child, ok := value.(map[string]interface{}); ok {
+			validateObject(child, memberPath, errs)
+		}
+	}
+}
+
+// lookupType finds the schema entry for path, trying wildcards for each
+// member name from the last
+func lookupType(path []string) (valueType, bool) {
+	if want, ok := schema[strings.Join(path, ".")]; ok {
+		return want, true
+	}
+	for i := len(path) - 1; i >= 0; i-- {
+		generic := append(append(append([]string{}, path[:i]...), "*"), path[i+1:]...)
+		if want, ok := schema[strings.Join(generic, ".")]; ok {
+			return want, true
+		}
+	}
+	return "", false
+}
+
+func hasType(value interface{}, want valueType) bool {
+	switch want {
+	case typeObject:
+		_, ok := value.(map[string]interface{})
+		return ok
+	case typeArray:
+		_, ok := value.([]interface{})
+		return ok
+	case typeStrings:
+		list, ok := value.([]interface{})
+		for _, element := range list {
+			if _, isString := element.(string); !isString {
+				return false
+			}
+		}
+		return ok
+	case typeString:
+		_, ok := value.(string)
+		return ok
+	case typeBool:
+		_, ok := value.(bool)
+		return ok
+	case typeNumber:
+		switch value.(type) {
+		case json.Number, float64, int:
+			return true
+		}
+	}
+	return false
+}
+
+// dotted names a setting for messages
+func dotted(path []string) string {
+	return strings.Join(path, ".")
+}
@@ -0,0 +1,398 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/agents"
+	"github.com/jonwraymond/claude-code-super-crew/internal/orchestrator"
+	"github.com/jonwraymond/claude-code-super-crew/internal/pathsutil"
+	"github.com/jonwraymond/claude-code-super-crew/internal/project"
+	"github.com/jonwraymond/claude-code-super-crew/internal/ui"
+	"github.com/jonwraymond/claude-code-super-crew/pkg/logger"
+	"github.com/spf13/cobra"
+)
+
+// AgentsFlags holds agents command flags
+type AgentsFlags struct {
+	ProjectDir string
+	JSON       bool
+	All        bool
+	Stale      bool
+}
+
+var agentsFlags AgentsFlags
+
+// NewAgentsCommand creates the agents command
+func NewAgentsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "agents",
+		Short: "Inspect the agents and personas available to a project",
+		Long: `Inspect the agents crew installed globally and the ones the project
+adds in .claude/agents. A project agent replaces the global agent of the
+same name. Commands act on the project containing the current directory
+unless --project-dir is given.`,
+	}
+
+	cmd.PersistentFlags().StringVar(&agentsFlags.ProjectDir, "project-dir", "",
+		"Project directory (default: the project containing the current directory)")
+	registerFlagCompletions(cmd, map[string]completionFunc{
+		"project-dir": func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+			return nil, cobra.ShellCompDirectiveFilterDirs
+		},
+	})
+
+	cmd.AddCommand(newAgentsMatchCommand())
+	cmd.AddCommand(newAgentsSpecialistsCommand())
+	cmd.AddCommand(newAgentsPruneCommand())
+
+	return cmd
+}
+
+func newAgentsMatchCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "match <request...>",
+		Short: "Rank the agents a request would activate",
+		Long: `Score a request against the activation_keywords of every agent and
+list the agents it matches, best first, to debug how the orchestrator
+routes it.
+
+A primary keyword scores 1.0, a secondary one 0.6 and a contextual one 0.3;
+words are compared after stripping plural and -ing/-ed endings, and
+multi-word keywords must appear as a phrase. An agent scoring at least
+0.9 activates.
+
+Examples:
+  crew agents match "fix the flaky integration tests"
+  crew agents match optimize the slow database queries --json
+  crew agents match "refactor auth" --all     # Also list agents that miss`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAgentsMatch(strings.Join(args, " "))
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().BoolVar(&agentsFlags.JSON, "json", false, "Output the ranking as JSON")
+	cmd.Flags().BoolVar(&agentsFlags.All, "all", false, "Also list agents the request does not match")
+	return cmd
+}
+
+// loadAgents reads the global agents and those of the project in
+// projectDir, the project around the current directory when empty
+func loadAgents(projectDir string) ([]*agents.Agent, error) {
+	projectDir, err := resolveProjectDir(projectDir)
+	if err != nil {
+		return nil, err
+	}
+
+	log := logger.GetLogger()
+	loaded, problems := agents.Load(filepath.Join(getGlobalInstallDir(), "agents"), pathsutil.AgentsDir(projectDir))
+	for _, problem := range problems {
+		log.Warnf("Skipping agent: %v", problem)
+	}
+	return loaded, nil
+}
+
+func runAgentsMatch(request string) error {
+	loaded, err := loadAgents(agentsFlags.ProjectDir)
+	if err != nil {
+		return err
+	}
+	index := agents.NewIndex(loaded)
+	matches := index.Match(request)
+
+	if agentsFlags.All {
+		matched := make(map[*agents.Agent]bool)
+		for _, match := range matches {
+			matched[match.Agent] = true
+		}
+		for _, agent := range loaded {
+			if !matched[agent] {
+				matches = append(matches, agents.Match{Agent: agent, Hits: []agents.Hit{}})
+			}
+		}
+	}
+
+	if agentsFlags.JSON || globalFlags.Output == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(struct {
+			Request   string         `json:"request"`
+			Threshold float64        `json:"threshold"`
+			Matches   []agents.Match `json:"matches"`
+			Agents    int            `json:"agents"`
+		}{request, agents.ActivationThreshold, matches, len(loaded)})
+	}
+
+	displayAgentMatches(request, loaded, matches)
+	return nil
+}
+
+func displayAgentMatches(request string, loaded []*agents.Agent, matches []agents.Match) {
+	fmt.Printf("\n%s%sAgent Routing%s\n", ui.ColorCyan, ui.ColorBright, ui.ColorReset)
+	fmt.Println(strings.Repeat("=", 50))
+	fmt.Printf("%sRequest:%s %s\n", ui.ColorBlue, ui.ColorReset, request)
+	fmt.Printf("%sTerms:%s   %s\n\n", ui.ColorBlue, ui.ColorReset, strings.Join(agents.Terms(request), " "))
+
+	if len(loaded) == 0 {
+		ui.DisplayWarning("No agents found; run 'crew install' or add agents to .claude/agents")
+		return
+	}
+
+	withoutKeywords := 0
+	for _, agent := range loaded {
+		if agent.Keywords.Empty() {
+			withoutKeywords++
+		}
+	}
+
+	if len(matches) == 0 {
+		ui.DisplayInfo(fmt.Sprintf("No agent declares a keyword of this request (%d agents, %d without activation_keywords)", len(loaded), withoutKeywords))
+		return
+	}
+
+	headers := []string{"#", "Agent", "Scope", "Score", "Activates", "Matched keywords"}
+	var rows [][]string
+	activated := 0
+	for i, match := range matches {
+		activates := ui.Icons.Cross
+		if match.Activates {
+			activates = ui.Icons.Check
+			activated++
+		}
+		var hits []string
+		for _, hit := range match.Hits {
+			hits = append(hits, fmt.Sprintf("%s (%s)", hit.Keyword, hit.Tier))
+		}
+		rows = append(rows, []string{
+			fmt.Sprintf("%d", i+1),
+			match.Agent.Name,
+			match.Agent.Scope,
+			fmt.Sprintf("%.1f", match.Score),
+			activates,
+			strings.Join(hits, ", "),
+		})
+	}
+	ui.DisplayTable(headers, rows, "")
+
+	if activated == 0 {
+		fmt.Printf("%s No agent reaches the activation threshold of %.1f; the orchestrator handles the request itself\n",
+			ui.Icons.Tip, agents.ActivationThreshold)
+	} else {
+		fmt.Printf("%s %d of %d agents activate (threshold %.1f)\n", ui.Icons.Arrow, activated, len(loaded), agents.ActivationThreshold)
+	}
+	if withoutKeywords == 1 {
+		fmt.Printf("%s 1 agent declares no activation_keywords and never matches\n", ui.Icons.Tip)
+	} else if withoutKeywords > 1 {
+		fmt.Printf("%s %d agents declare no activation_keywords and never match\n", ui.Icons.Tip, withoutKeywords)
+	}
+}
+
+func newAgentsSpecialistsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "specialists",
+		Short: "Show when project specialists were created and last used",
+		Long: `List the specialists generated for the project in .claude/agents with
+when each was created, how often and when it was last invoked, and whether
+the files that justified it still exist.
+
+Creation dates come from the created frontmatter, or the file's
+modification time. Invocations are the Task calls naming the specialist in
+the project's Claude Code session transcripts. A specialist is stale when
+none of its source_patterns matches a file any more; specialists generated
+before crew recorded patterns are checked against the patterns crew would
+generate them for today.
+
+Examples:
+  crew agents specialists              # Lifecycle of every specialist
+  crew agents specialists --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAgentsSpecialists()
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().BoolVar(&agentsFlags.JSON, "json", false, "Output the lifecycles as JSON")
+	return cmd
+}
+
+func newAgentsPruneCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Suggest removing specialists the project no longer needs",
+		Long: `Suggest removing project specialists whose justification has
+disappeared: with --stale, those none of whose source patterns matches a
+file any more, e.g. a database-specialist after the migrations were
+deleted. Nothing is removed; the commands that remove each specialist are
+printed for review.
+
+Examples:
+  crew agents prune --stale
+  crew agents prune --stale --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAgentsPrune()
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().BoolVar(&agentsFlags.Stale, "stale", false, "Suggest specialists whose source patterns no longer match")
+	cmd.Flags().BoolVar(&agentsFlags.JSON, "json", false, "Output the suggestions as JSON")
+	return cmd
+}
+
+// specialistLifecycles returns the lifecycles of the project's specialists
+// and the absolute project directory
+func specialistLifecycles() ([]agents.Lifecycle, string, error) {
+	projectDir, err := resolveProjectDir(agentsFlags.ProjectDir)
+	if err != nil {
+		return nil, "", err
+	}
+
+	loaded, err := loadAgents(projectDir)
+	if err != nil {
+		return nil, "", err
+	}
+	usage, err := agents.LoadUsage(agents.TranscriptDir(getGlobalInstallDir(), projectDir))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read session transcripts: %w", err)
+	}
+	return agents.Lifecycles(projectDir, loaded, usage, orchestrator.SpecialistSources), projectDir, nil
+}
+
+func runAgentsSpecialists() error {
+	lifecycles, _, err := specialistLifecycles()
+	if err != nil {
+		return err
+	}
+
+	if agentsFlags.JSON || globalFlags.Output == "json" {
+		if lifecycles == nil {
+			lifecycles = []agents.Lifecycle{}
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(lifecycles)
+	}
+
+	fmt.Printf("\n%s%sProject Specialists%s\n", ui.ColorCyan, ui.ColorBright, ui.ColorReset)
+	fmt.Println(strings.Repeat("=", 50))
+	if len(lifecycles) == 0 {
+		ui.DisplayInfo("No specialists in .claude/agents; /crew:onboard creates them when the project needs one")
+		return nil
+	}
+
+	headers := []string{"Specialist", "Created", "Last used", "Uses", "Status", "Justified by"}
+	var rows [][]string
+	for _, l := range lifecycles {
+		lastUsed := "never"
+		if l.LastUsed != nil {
+			lastUsed = formatAge(*l.LastUsed)
+		}
+		justification := strings.Join(l.Matched, ", ")
+		if len(l.SourcePatterns) == 0 {
+			justification = "no source patterns"
+		} else if !l.Justified() {
+			justification = "none of " + strings.Join(l.SourcePatterns, ", ")
+		}
+		rows = append(rows, []string{l.Name, l.CreatedAt.Format("2006-01-02"), lastUsed, fmt.Sprintf("%d", l.Uses), l.Status, justification})
+	}
+	ui.DisplayTable(headers, rows, "")
+
+	for _, l := range lifecycles {
+		if l.Status == agents.StatusStale {
+			fmt.Printf("%s Run 'crew agents prune --stale' to review stale specialists\n", ui.Icons.Tip)
+			break
+		}
+	}
+	return nil
+}
+
+// formatAge describes t as a date and how long ago it was
+func formatAge(t time.Time) string {
+	days := int(time.Since(t).Hours() / 24)
+	switch {
+	case days < 1:
+		return t.Format("2006-01-02") + " (today)"
+	case days == 1:
+		return t.Format("2006-01-02") + " (1 day ago)"
+	default:
+		return fmt.Sprintf("%s (%d days ago)", t.Format("2006-01-02"), days)
+	}
+}
+
+func runAgentsPrune() error {
+	if !agentsFlags.Stale {
+		return fmt.Errorf("choose the specialists to prune, e.g. --stale")
+	}
+	lifecycles, projectDir, err := specialistLifecycles()
+	if err != nil {
+		return err
+	}
+
+	stale := []agents.Lifecycle{}
+	for _, l := range lifecycles {
+		if l.Status == agents.StatusStale {
+			stale = append(stale, l)
+		}
+	}
+
+	// Specialists /crew:onboard recorded in the project configuration
+	var recorded []string
+	if cfg, err := project.LoadConfig(projectDir); err == nil {
+		recorded = cfg.Specialists
+	}
+	var remaining []string
+	for _, name := range recorded {
+		keep := true
+		for _, l := range stale {
+			if l.Name == name {
+				keep = false
+			}
+		}
+		if keep {
+			remaining = append(remaining, name)
+		}
+	}
+
+	if agentsFlags.JSON || globalFlags.Output == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(struct {
+			Stale []agents.Lifecycle `json:"stale"`
+		}{stale})
+	}
+
+	if len(stale) == 0 {
+		ui.DisplaySuccess(fmt.Sprintf("No stale specialists among %d in .claude/agents", len(lifecycles)))
+		return nil
+	}
+
+	fmt.Printf("\n%s%sStale Specialists%s\n", ui.ColorCyan, ui.ColorBright, ui.ColorReset)
+	fmt.Println(strings.Repeat("=", 50))
+	for _, l := range stale {
+		fmt.Printf("%s%s %s%s\n", ui.ColorYellow, ui.Icons.Warning, l.Name, ui.ColorReset)
+		fmt.Printf("  No file matches %s\n", strings.Join(l.SourcePatterns, ", "))
+		if l.LastUsed != nil {
+			fmt.Printf("  Last used %s (%d uses)\n", formatAge(*l.LastUsed), l.Uses)
+		} else {
+			fmt.Println("  Never used")
+		}
+	}
+
+	fmt.Printf("\n%s To remove them:\n", ui.Icons.Tip)
+	for _, l := range stale {
+		path := l.Path
+		if rel, err := filepath.Rel(projectDir, l.Path); err == nil {
+			path = rel
+		}
+		fmt.Printf("  rm %s\n", path)
+	}
+	if len(remaining) < len(recorded) {
+		fmt.Printf("  crew project config set specialists %s\n", strings.Join(remaining, " "))
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/cache"
+	"github.com/jonwraymond/claude-code-super-crew/internal/orchestrator"
+	"github.com/jonwraymond/claude-code-super-crew/internal/ui"
+	"github.com/jonwraymond/claude-code-super-crew/pkg/logger"
+	"github.com/spf13/cobra"
+)
+
+// AnalyzeFlags holds analyze command flags
+type AnalyzeFlags struct {
+	ProjectDir string
+	JSON       bool
+	Top        int
+	NoWrite    bool
+	NoCache    bool
+}
+
+var analyzeFlags AnalyzeFlags
+
+// NewAnalyzeCommand creates the analyze command
+func NewAnalyzeCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "analyze",
+		Short: "Analyze a project's code without Claude",
+		Long: `Analyze the project's files and print a report: languages, frameworks,
+hotspots, test coverage presence and TODO density. Nothing is sent to
+Claude.
+
+Hotspots are the source files with the highest lines × (commits + 1),
+counting the last 500 commits that changed them; outside a git repository
+they are the largest files. A directory with source files and no test
+files is listed as untested. TODO, FIXME, HACK and XXX markers count as
+TODOs.
+
+The report is also written to .claude/agents/project-analysis.json, where
+/crew:onboard and the project's agents read it (not with --dry-run or
+--no-write).
+
+Reports are cached (see crew cache) until a file of the project or its git
+HEAD changes; --no-cache analyzes again regardless.
+
+Examples:
+  crew analyze
+  crew analyze --top 20
+  crew analyze --json --no-write`,
+		Args:         cobra.NoArgs,
+		RunE:         runAnalyze,
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().StringVar(&analyzeFlags.ProjectDir, "project-dir", "",
+		"Project directory (default: the project containing the current directory)")
+	cmd.Flags().BoolVar(&analyzeFlags.JSON, "json", false, "Output the report as JSON")
+	cmd.Flags().IntVar(&analyzeFlags.Top, "top", 10, "Number of hotspots, untested directories and TODO files listed (0 for all)")
+	cmd.Flags().BoolVar(&analyzeFlags.NoWrite, "no-write", false, "Do not write .claude/agents/project-analysis.json")
+	cmd.Flags().BoolVar(&analyzeFlags.NoCache, "no-cache", false, "Analyze again even when a cached report is current")
+	registerFlagCompletions(cmd, map[string]completionFunc{
+		"project-dir": func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+			return nil, cobra.ShellCompDirectiveFilterDirs
+		},
+	})
+
+	return cmd
+}
+
+func runAnalyze(cmd *cobra.Command, args []string) error {
+	projectDir, err := resolveProjectDir(analyzeFlags.ProjectDir)
+	if err != nil {
+		return err
+	}
+	if analyzeFlags.Top < 0 {
+		return fmt.Errorf("--top must not be negative")
+	}
+
+	report, err := analyzeProject(projectDir, analyzeFlags.Top, !analyzeFlags.NoCache)
+	if err != nil {
+		return fmt.Errorf("failed to analyze project: %w", err)
+	}
+
+	written := ""
+	if !analyzeFlags.NoWrite && !globalFlags.DryRun {
+		if written, err = orchestrator.WriteAnalysis(projectDir, report); err != nil {
+			return err
+		}
+	}
+
+	if analyzeFlags.JSON || globalFlags.Output == "json" {
+		return encodeLoadJSON(report)
+	}
+	displayAnalysisReport(report)
+	if written != "" {
+		fmt.Printf("\n%s Report written to %s\n\n", ui.Icons.Success, written)
+	}
+	return nil
+}
+
+// analyzeProject returns the report of projectDir, from the analysis cache
+// when useCache is set and the project has not changed since it was stored
+func analyzeProject(projectDir string, top int, useCache bool) (*orchestrator.AnalysisReport, error) {
+	analyzer := orchestrator.NewProjectAnalyzer(projectDir)
+	c := openCache()
+	key := ""
+	if fingerprint, err := analyzer.Fingerprint(); err == nil {
+		key = fmt.Sprintf("%s top=%d", fingerprint, top)
+	}
+	if useCache && key != "" {
+		if data, ok := c.Get(cache.Analysis, key); ok {
+			var report orchestrator.AnalysisReport
+			if err := json.Unmarshal(data, &report); err == nil {
+				logger.GetLogger().Debugf("Using the cached analysis of %s", projectDir)
+				return &report, nil
+			}
+		}
+	}
+
+	report, err := analyzer.Report(top)
+	if err != nil {
+		return nil, err
+	}
+	if key != "" {
+		if data, err := json.Marshal(report); err == nil {
+			if err := c.Put(cache.Analysis, key, data); err != nil {
+				logger.GetLogger().Debugf("Failed to cache the analysis: %v", err)
+			}
+		}
+	}
+	return report, nil
+}
+
+func displayAnalysisReport(report *orchestrator.AnalysisReport) {
+	fmt.Printf("\n%s%sProject Report%s\n", ui.ColorCyan, ui.ColorBright, ui.ColorReset)
+	fmt.Println(strings.Repeat("=", 50))
+	fmt.Printf("%sProject:%s %s\n", ui.ColorBlue, ui.ColorReset, report.ProjectPath)
+	fmt.Printf("  %d source files, %d lines\n", report.TotalFiles, report.TotalLines)
+
+	fmt.Printf("\n%sLanguages:%s\n", ui.ColorBlue, ui.ColorReset)
+	if len(report.Languages) == 0 {
+		fmt.Println("  None detected")
+	}
+	for _, lang := range report.Languages {
+		primary := ""
+		if lang.Primary {
+			primary = " (primary)"
+		}
+		fmt.Printf("  %-12s %5d files %8d lines%s\n", lang.Name, lang.FileCount, lang.Lines, primary)
+	}
+	if len(report.Frameworks) > 0 {
+		fmt.Printf("  Frameworks: %s\n", strings.Join(report.Frameworks, ", "))
+	}
+	if len(report.Traits) > 0 {
+		fmt.Printf("  Detected:   %s\n", strings.Join(report.Traits, ", "))
+	}
+
+	fmt.Printf("\n%sHotspots:%s\n", ui.ColorBlue, ui.ColorReset)
+	if report.Git != nil {
+		fmt.Printf("  %s\n", report.Git.Summary())
+	} else {
+		fmt.Printf("  %sNot a git repository: ranked by size only%s\n", ui.ColorDim, ui.ColorReset)
+	}
+	for _, hotspot := range report.Hotspots {
+		fmt.Printf("  %-50s %6d lines %4d commits\n", hotspot.Path, hotspot.Lines, hotspot.Changes)
+	}
+
+	fmt.Printf("\n%sTests:%s\n", ui.ColorBlue, ui.ColorReset)
+	fmt.Printf("  %d test files for %d source files\n", report.Testing.TestFiles, report.Testing.SourceFiles)
+	if len(report.Testing.UntestedDirs) > 0 {
+		fmt.Printf("  %s Directories without tests:\n", ui.Icons.Warning)
+		for _, dir := range report.Testing.UntestedDirs {
+			fmt.Printf("    %s %s\n", ui.Icons.Bullet, dir)
+		}
+	}
+
+	fmt.Printf("\n%sTODOs:%s\n", ui.ColorBlue, ui.ColorReset)
+	fmt.Printf("  %d markers, %.1f per 1000 lines\n", report.TODOs.Count, report.TODOs.PerKLOC)
+	for _, file := range report.TODOs.Files {
+		fmt.Printf("    %s %-46s %4d\n", ui.Icons.Bullet, file.Path, file.Count)
+	}
+
+	if len(report.RecommendedAgents) > 0 {
+		fmt.Printf("\n%sRecommended specialists:%s %s\n", ui.ColorBlue, ui.ColorReset, strings.Join(report.RecommendedAgents, ", "))
+	}
+}
@@ -0,0 +1,283 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/audit"
+	"github.com/jonwraymond/claude-code-super-crew/internal/core"
+	"github.com/jonwraymond/claude-code-super-crew/internal/crewdirs"
+	"github.com/jonwraymond/claude-code-super-crew/internal/ui"
+	"github.com/jonwraymond/claude-code-super-crew/pkg/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// auditAnnotation marks commands that change the installation and are
+// recorded in the audit log. Its value lists the flags that make the
+// command mutating, comma separated, or is "*" when every run is.
+const auditAnnotation = "crew.audit"
+
+// auditAlways is the auditAnnotation value of commands that always mutate
+const auditAlways = "*"
+
+// pendingAudit carries what the running command reports about itself to
+// its audit entry
+var pendingAudit struct {
+	undo    *audit.Undo
+	reverts string
+}
+
+// noteUndo records in the audit entry of the running command how crew
+// undo can revert it
+func noteUndo(kind, ref string) {
+	pendingAudit.undo = &audit.Undo{Kind: kind, Ref: ref}
+}
+
+// missingTargets returns the targets of files that do not exist yet
+func missingTargets(files []core.FilePair) []string {
+	var missing []string
+	for _, f := range files {
+		if _, err := os.Lstat(f.Target); os.IsNotExist(err) {
+			missing = append(missing, f.Target)
+		}
+	}
+	return missing
+}
+
+// noteAdded records in the audit entry of the running command which of
+// paths, collected with missingTargets before it changed anything, it
+// created, so crew undo removes them. It does nothing for commands that
+// cannot be undone.
+func noteAdded(paths []string) {
+	if pendingAudit.undo == nil {
+		return
+	}
+	for _, path := range paths {
+		if _, err := os.Lstat(path); err != nil {
+			continue
+		}
+		if rel, err := filepath.Rel(globalFlags.InstallDir, path); err == nil && !strings.HasPrefix(rel, "..") {
+			pendingAudit.undo.Added = append(pendingAudit.undo.Added, filepath.ToSlash(rel))
+		}
+	}
+}
+
+// AuditFlags holds audit command flags
+type AuditFlags struct {
+	Since   string
+	Command string
+	Failed  bool
+	JSON    bool
+}
+
+// NewAuditCommand creates the audit command
+func NewAuditCommand() *cobra.Command {
+	var flags AuditFlags
+
+	cmd := &cobra.Command{
+		Use:   "audit",
+		Short: "Show the log of operations that changed the installation",
+		Long: `Show who ran which crew operation that changed the installation, when,
+with which flags and whether it succeeded.
+
+Installs, updates, uninstalls, backups and restores, configuration
+changes, hook changes and trash and permission repairs are appended to
+.crew/logs/audit.jsonl as they finish. Dry runs change nothing and are
+not recorded.
+
+Examples:
+  crew audit                       # Everything recorded
+  crew audit --since 7d            # The last week
+  crew audit --command uninstall   # One kind of operation
+  crew audit --failed --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAudit(flags)
+		},
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().StringVar(&flags.Since, "since", "",
+		"Only show operations since a duration ago (2h, 7d), a date or an RFC3339 time")
+	cmd.Flags().StringVar(&flags.Command, "command", "",
+		"Only show operations whose command contains this text, e.g. install")
+	cmd.Flags().BoolVar(&flags.Failed, "failed", false, "Only show operations that failed")
+	cmd.Flags().BoolVar(&flags.JSON, "json", false, "Output as JSON")
+
+	return cmd
+}
+
+func runAudit(flags AuditFlags) error {
+	var since time.Time
+	if flags.Since != "" {
+		var err error
+		if since, err = parseSince(flags.Since, time.Now()); err != nil {
+			return err
+		}
+	}
+
+	log := &audit.Log{Path: audit.Path(globalFlags.InstallDir)}
+	all, err := log.Read(since)
+	if err != nil {
+		return fmt.Errorf("failed to read audit log: %w", err)
+	}
+	entries := []audit.Entry{}
+	for _, entry := range all {
+		if flags.Command != "" && !strings.Contains(entry.Command, flags.Command) {
+			continue
+		}
+		if flags.Failed && entry.Success {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	if flags.JSON || globalFlags.Output == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(entries)
+	}
+
+	if len(entries) == 0 {
+		fmt.Println("No audited operations recorded")
+		return nil
+	}
+	for _, entry := range entries {
+		result := fmt.Sprintf("%s%s%s", ui.ColorGreen, ui.Icons.Check, ui.ColorReset)
+		if !entry.Success {
+			result = fmt.Sprintf("%s%s%s", ui.ColorRed, ui.Icons.Cross, ui.ColorReset)
+		}
+		line := fmt.Sprintf("%s %s %-10s %s", entry.Time.Local().Format("2006-01-02 15:04:05"), result, entry.User, entry.Command)
+		if len(entry.Args) > 0 {
+			line += " " + strings.Join(entry.Args, " ")
+		}
+		if flagText := formatAuditFlags(entry.Flags); flagText != "" {
+			line += " " + flagText
+		}
+		fmt.Println(line)
+		if entry.Error != "" {
+			fmt.Printf("    %s%s%s\n", ui.ColorRed, entry.Error, ui.ColorReset)
+		}
+	}
+	return nil
+}
+
+// formatAuditFlags renders recorded flags as they were typed, sorted by name
+func formatAuditFlags(flags map[string]string) string {
+	names := make([]string, 0, len(flags))
+	for name := range flags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		if flags[name] == "true" {
+			parts = append(parts, "--"+name)
+		} else {
+			parts = append(parts, fmt.Sprintf("--%s=%s", name, flags[name]))
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// auditCommands wraps every command carrying auditAnnotation so that its
+// runs are appended to the audit log
+func auditCommands(cmd *cobra.Command) {
+	for _, sub := range cmd.Commands() {
+		auditCommands(sub)
+	}
+	if _, ok := cmd.Annotations[auditAnnotation]; !ok || cmd.RunE == nil {
+		return
+	}
+	run := cmd.RunE
+	cmd.RunE = func(c *cobra.Command, args []string) error {
+		start := time.Now()
+		err := run(c, args)
+		recordAudit(c, args, start, err)
+		return err
+	}
+}
+
+// auditedRun reports whether this run of cmd changes the installation
+func auditedRun(cmd *cobra.Command) bool {
+	if globalFlags.DryRun {
+		return false
+	}
+	trigger := cmd.Annotations[auditAnnotation]
+	if trigger == auditAlways {
+		return true
+	}
+	for _, name := range strings.Split(trigger, ",") {
+		if cmd.Flags().Changed(strings.TrimSpace(name)) {
+			return true
+		}
+	}
+	return false
+}
+
+// recordAudit appends one entry for a finished command. Nothing is
+// written when the installation has no crew directory, e.g. after a
+// complete uninstall, and failures are only logged at debug level.
+func recordAudit(cmd *cobra.Command, args []string, start time.Time, runErr error) {
+	undo, reverts := pendingAudit.undo, pendingAudit.reverts
+	pendingAudit.undo, pendingAudit.reverts = nil, ""
+	if testMode || !auditedRun(cmd) {
+		return
+	}
+	if info, err := os.Stat(crewdirs.Path(globalFlags.InstallDir)); err != nil || !info.IsDir() {
+		return
+	}
+
+	host, _ := os.Hostname()
+	entry := audit.Entry{
+		ID:         strconv.FormatInt(start.UnixNano(), 36),
+		Time:       start.UTC(),
+		User:       audit.CurrentUser(),
+		Host:       host,
+		Command:    cmd.CommandPath(),
+		Args:       args,
+		Flags:      changedFlags(cmd),
+		InstallDir: globalFlags.InstallDir,
+		DurationMs: time.Since(start).Milliseconds(),
+		Success:    runErr == nil,
+		Undo:       undo,
+		Reverts:    reverts,
+	}
+	if runErr != nil {
+		entry.Error = runErr.Error()
+	}
+
+	log := &audit.Log{Path: audit.Path(globalFlags.InstallDir)}
+	if err := log.Append(entry); err != nil {
+		logger.GetLogger().Debugf("Failed to write audit log: %v", err)
+	}
+}
+
+// changedFlags returns the flags set on the command line. Values of flags
+// that look like they carry credentials are masked.
+func changedFlags(cmd *cobra.Command) map[string]string {
+	flags := make(map[string]string)
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		value := f.Value.String()
+		if sv, ok := f.Value.(pflag.SliceValue); ok {
+			value = strings.Join(sv.GetSlice(), ",")
+		}
+		for _, secret := range []string{"token", "password", "passphrase", "secret", "key"} {
+			if strings.Contains(f.Name, secret) {
+				value = "***"
+			}
+		}
+		flags[f.Name] = value
+	})
+	if len(flags) == 0 {
+		return nil
+	}
+	return flags
+}
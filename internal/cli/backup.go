@@ -1,11 +1,14 @@
 package cli
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 
+	"github.com/jonwraymond/claude-code-super-crew/internal/crewdirs"
+	"github.com/jonwraymond/claude-code-super-crew/internal/events"
 	"github.com/jonwraymond/claude-code-super-crew/internal/managers"
 	"github.com/jonwraymond/claude-code-super-crew/internal/ui"
 	"github.com/jonwraymond/claude-code-super-crew/pkg/backup"
@@ -15,17 +18,22 @@ import (
 
 // BackupFlags holds backup command flags
 type BackupFlags struct {
-	Create    bool
-	List      bool
-	Restore   string
-	Info      string
-	Cleanup   bool
-	BackupDir string
-	Name      string
-	Compress  string
-	Overwrite bool
-	Keep      int
-	OlderThan int
+	Create     bool
+	List       bool
+	Restore    string
+	Info       string
+	Cleanup    bool
+	BackupDir  string
+	Name       string
+	Compress   string
+	Level      int
+	Threads    int
+	Overwrite  bool
+	Keep       int
+	OlderThan  int
+	Project    bool
+	ProjectDir string
+	InProject  bool
 }
 
 var backupFlags BackupFlags
@@ -37,14 +45,22 @@ func NewBackupCommand() *cobra.Command {
 		Short: "Backup and restore Claude Code Super Crew installations",
 		Long: `Create, list, restore, and manage Claude Code Super Crew installation backups.
 
+Paths listed in .crewignore in the installation directory, in .gitignore
+syntax, are left out of backups; install and integrity checks skip them
+too. Use it for caches, virtualenvs or data kept under ~/.claude.
+
 Examples:
   crew backup --create               # Create new backup
   crew backup --list --verbose       # List available backups (verbose)
   crew backup --restore              # Interactive restore
   crew backup --restore backup.tar.gz  # Restore specific backup
   crew backup --info backup.tar.gz   # Show backup information
-  crew backup --cleanup --force      # Clean up old backups (forced)`,
-		RunE: runBackup,
+  crew backup --cleanup --force      # Clean up old backups (forced)
+  crew backup --create --compress-level 1  # Fastest backup of a large tree
+  crew backup --create --project     # Back up this project's .claude and CLAUDE.md
+  crew backup --list --project       # List backups of this project`,
+		Annotations: map[string]string{auditAnnotation: "create,restore,cleanup"},
+		RunE:        interruptible(runBackup),
 	}
 
 	// Backup operations
@@ -66,6 +82,18 @@ Examples:
 		"Custom backup name (for --create)")
 	cmd.Flags().StringVar(&backupFlags.Compress, "compress", "gzip",
 		"Compression method: none, gzip, bzip2 (default: gzip)")
+	cmd.Flags().IntVar(&backupFlags.Level, "compress-level", 6,
+		"gzip level from 1 (fastest) to 9 (smallest)")
+	cmd.Flags().IntVar(&backupFlags.Threads, "compress-threads", 0,
+		"CPUs compressing in parallel; 1 writes a single-threaded stream (default: all)")
+
+	// Project options
+	cmd.Flags().BoolVar(&backupFlags.Project, "project", false,
+		"Act on the current project's .claude directory and CLAUDE.md instead of the installation")
+	cmd.Flags().StringVar(&backupFlags.ProjectDir, "project-dir", "",
+		"Project directory for --project (default: current directory)")
+	cmd.Flags().BoolVar(&backupFlags.InProject, "in-project", false,
+		"Store project backups in <project>/.claude/backups instead of the global backup directory")
 
 	// Restore options
 	cmd.Flags().BoolVar(&backupFlags.Overwrite, "overwrite", false,
@@ -81,13 +109,19 @@ Examples:
 	cmd.MarkFlagsMutuallyExclusive("create", "list", "restore", "info", "cleanup")
 	cmd.MarkFlagsOneRequired("create", "list", "restore", "info", "cleanup")
 
+	registerFlagCompletions(cmd, map[string]completionFunc{
+		"restore":  completeBackupNames,
+		"info":     completeBackupNames,
+		"compress": completeStatic(backup.CompressionMethods...),
+	})
+
 	return cmd
 }
 
 func runBackup(cmd *cobra.Command, args []string) error {
 	log := logger.GetLogger()
 	log.SetVerbose(globalFlags.Verbose)
-	log.SetQuiet(globalFlags.Quiet)
+	log.SetQuiet(globalFlags.Quiet || globalFlags.Output == "json")
 
 	// Validate installation directory (skip in test mode)
 	if !testMode {
@@ -105,8 +139,12 @@ func runBackup(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if !backup.ValidCompression(backupFlags.Compress) {
+		return fmt.Errorf("unknown compression method %q (use %s)", backupFlags.Compress, strings.Join(backup.CompressionMethods, ", "))
+	}
+
 	// Display header
-	if !globalFlags.Quiet {
+	if showDecorations() {
 		ui.DisplayHeader(
 			"Claude Code Super Crew Backup v1.0",
 			"Backup and restore Claude Code Super Crew installations",
@@ -119,13 +157,13 @@ func runBackup(cmd *cobra.Command, args []string) error {
 	// Handle different backup operations
 	switch {
 	case backupFlags.Create:
-		return createBackup()
+		return createBackup(commandContext(cmd))
 
 	case backupFlags.List:
 		return listBackups(backupDir)
 
 	case backupFlags.Restore != "":
-		return restoreBackup(backupFlags.Restore, backupDir)
+		return restoreBackup(commandContext(cmd), backupFlags.Restore, backupDir)
 
 	case backupFlags.Info != "":
 		return showBackupInfo(backupFlags.Info, backupDir)
@@ -142,7 +180,25 @@ func getBackupDirectory() string {
 	if backupFlags.BackupDir != "" {
 		return backupFlags.BackupDir
 	}
-	return filepath.Join(globalFlags.InstallDir, ".crew", "backups")
+	if backupFlags.Project && backupFlags.InProject {
+		if projectDir, err := getBackupProjectDir(); err == nil {
+			return filepath.Join(projectDir, ".claude", "backups")
+		}
+	}
+	return crewdirs.Path(globalFlags.InstallDir, "backups")
+}
+
+// getBackupProjectDir returns the absolute project directory for --project
+func getBackupProjectDir() (string, error) {
+	dir := backupFlags.ProjectDir
+	if dir == "" {
+		wd, err := os.Getwd()
+		if err != nil {
+			return "", fmt.Errorf("failed to get current directory: %w", err)
+		}
+		dir = wd
+	}
+	return filepath.Abs(dir)
 }
 
 func checkInstallationExists() bool {
@@ -150,11 +206,20 @@ func checkInstallationExists() bool {
 	return settingsManager.CheckInstallationExists()
 }
 
-func createBackup() error {
+func createBackup(ctx context.Context) error {
 	log := logger.GetLogger()
 
-	// Check if installation exists
-	if !checkInstallationExists() {
+	var projectDir string
+	if backupFlags.Project {
+		var err error
+		if projectDir, err = getBackupProjectDir(); err != nil {
+			return err
+		}
+		if !hasProjectEntries(projectDir) {
+			return fmt.Errorf("nothing to back up: %s has no .claude directory or CLAUDE.md", projectDir)
+		}
+	} else if !checkInstallationExists() {
+		// Check if installation exists
 		log.Errorf("No Claude Code Super Crew installation found in %s", globalFlags.InstallDir)
 		return fmt.Errorf("no installation found")
 	}
@@ -169,10 +234,18 @@ func createBackup() error {
 	var backupName string
 	if backupFlags.Name != "" {
 		backupName = backupFlags.Name
+	} else if projectDir != "" {
+		backupName = "project_" + filepath.Base(projectDir)
 	} else {
 		backupName = "crew_backup"
 	}
 
+	// Report progress per top-level directory of the installation
+	tracker := newBackupProgressTracker(globalFlags.InstallDir)
+	if projectDir != "" {
+		tracker = newProjectBackupProgressTracker(projectDir)
+	}
+
 	// Create backup manager
 	mgr := backup.NewManager(backup.Options{
 		InstallDir: globalFlags.InstallDir,
@@ -181,8 +254,17 @@ func createBackup() error {
 		Compress:   backupFlags.Compress,
 		Verbose:    globalFlags.Verbose,
 		DryRun:     globalFlags.DryRun,
+		ProjectDir: projectDir,
+		OnFile:     tracker.file,
+
+		CompressLevel:   backupFlags.Level,
+		CompressThreads: backupFlags.Threads,
 	})
 
+	if err := publishEvent(ctx, events.BackupStarted, nil); err != nil {
+		return fmt.Errorf("backup aborted by pre-backup hook: %w", err)
+	}
+
 	log.Info("Creating backup...")
 
 	if globalFlags.DryRun {
@@ -191,11 +273,17 @@ func createBackup() error {
 	}
 
 	// Create backup
-	backupFile, err := mgr.Create()
+	tracker.start()
+	backupFile, err := mgr.Create(ctx)
+	tracker.finish(err)
 	if err != nil {
 		return fmt.Errorf("backup creation failed: %w", err)
 	}
 
+	if err := publishEvent(ctx, events.BackupCreated, map[string]string{"backup_file": backupFile}); err != nil {
+		log.Warnf("post-backup hook failed: %v", err)
+	}
+
 	// Get backup info
 	info := mgr.GetBackupInfo(backupFile)
 
@@ -203,13 +291,114 @@ func createBackup() error {
 	log.Infof("Backup file: %s", backupFile)
 	log.Infof("Backup size: %s", ui.FormatSize(info.Size))
 
-	if !globalFlags.Quiet {
+	if showDecorations() {
 		ui.DisplaySuccess("Backup operation completed successfully!")
 	}
 
 	return nil
 }
 
+// backupRootFiles groups files directly in the install dir in backup progress
+const backupRootFiles = "(files)"
+
+// backupProgressTracker maps archived files onto progress items, one per
+// top-level entry of the installation directory
+type backupProgressTracker struct {
+	items    []string
+	progress ui.Progress
+	current  string
+	seen     map[string]bool
+}
+
+func newBackupProgressTracker(installDir string) *backupProgressTracker {
+	t := &backupProgressTracker{seen: make(map[string]bool)}
+	if entries, err := os.ReadDir(installDir); err == nil {
+		hasFiles := false
+		for _, entry := range entries {
+			if entry.IsDir() {
+				t.items = append(t.items, entry.Name())
+			} else {
+				hasFiles = true
+			}
+		}
+		if hasFiles {
+			t.items = append(t.items, backupRootFiles)
+		}
+	}
+	return t
+}
+
+// newProjectBackupProgressTracker tracks the project entries that exist
+func newProjectBackupProgressTracker(projectDir string) *backupProgressTracker {
+	t := &backupProgressTracker{seen: make(map[string]bool)}
+	hasFiles := false
+	for _, entry := range backup.ProjectEntries {
+		info, err := os.Stat(filepath.Join(projectDir, entry))
+		switch {
+		case err != nil:
+		case info.IsDir():
+			t.items = append(t.items, entry)
+		default:
+			hasFiles = true
+		}
+	}
+	if hasFiles {
+		t.items = append(t.items, backupRootFiles)
+	}
+	return t
+}
+
+// hasProjectEntries reports whether projectDir has anything a project
+// backup would archive
+func hasProjectEntries(projectDir string) bool {
+	for _, entry := range backup.ProjectEntries {
+		if _, err := os.Stat(filepath.Join(projectDir, entry)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *backupProgressTracker) start() {
+	t.progress = newProgress("Creating backup", t.items)
+}
+
+// file is called for each archived file; directories are walked in order,
+// so a change of top-level entry means the previous one is complete
+func (t *backupProgressTracker) file(relPath string) {
+	if t.progress == nil {
+		return
+	}
+
+	item := backupRootFiles
+	if parts := strings.SplitN(filepath.ToSlash(relPath), "/", 2); len(parts) == 2 {
+		item = parts[0]
+	}
+
+	if item != t.current {
+		if t.current != "" {
+			t.progress.Done(t.current, nil)
+		}
+		t.progress.Start(item)
+		t.current = item
+		t.seen[item] = true
+	}
+}
+
+func (t *backupProgressTracker) finish(err error) {
+	if t.current != "" {
+		t.progress.Done(t.current, err)
+	}
+	if err == nil {
+		for _, item := range t.items {
+			if !t.seen[item] {
+				t.progress.Skip(item, "excluded")
+			}
+		}
+	}
+	t.progress.Finish()
+}
+
 func listBackups(backupDir string) error {
 	mgr := backup.NewManager(backup.Options{
 		BackupDir: backupDir,
@@ -220,8 +409,11 @@ func listBackups(backupDir string) error {
 	if err != nil {
 		return fmt.Errorf("failed to list backups: %w", err)
 	}
+	if backups, err = filterProjectBackups(backups); err != nil {
+		return err
+	}
 
-	if !globalFlags.Quiet {
+	if showDecorations() {
 		displayBackupList(backups)
 	} else {
 		// Simple list for quiet mode
@@ -233,6 +425,25 @@ func listBackups(backupDir string) error {
 	return nil
 }
 
+// filterProjectBackups keeps only the current project's backups when
+// --project is set
+func filterProjectBackups(backups []backup.BackupInfo) ([]backup.BackupInfo, error) {
+	if !backupFlags.Project {
+		return backups, nil
+	}
+	projectDir, err := getBackupProjectDir()
+	if err != nil {
+		return nil, err
+	}
+	var filtered []backup.BackupInfo
+	for _, b := range backups {
+		if b.ProjectDir() == projectDir {
+			filtered = append(filtered, b)
+		}
+	}
+	return filtered, nil
+}
+
 func displayBackupList(backups []backup.BackupInfo) {
 	fmt.Printf("\n%s%sAvailable Backups%s\n", ui.ColorCyan, ui.ColorBright, ui.ColorReset)
 	fmt.Println(strings.Repeat("=", 70))
@@ -242,7 +453,7 @@ func displayBackupList(backups []backup.BackupInfo) {
 		return
 	}
 
-	fmt.Printf("%-30s %-10s %-20s %-8s\n", "Name", "Size", "Created", "Files")
+	fmt.Printf("%-30s %-10s %-20s %-8s %s\n", "Name", "Size", "Created", "Files", "Scope")
 	fmt.Println(strings.Repeat("-", 70))
 
 	for _, backup := range backups {
@@ -250,14 +461,18 @@ func displayBackupList(backups []backup.BackupInfo) {
 		size := ui.FormatSize(backup.Size)
 		created := backup.Created.Format("2006-01-02 15:04")
 		files := fmt.Sprintf("%d", backup.FileCount)
+		scope := "install"
+		if project := backup.ProjectDir(); project != "" {
+			scope = "project " + filepath.Base(project)
+		}
 
-		fmt.Printf("%-30s %-10s %-20s %-8s\n", name, size, created, files)
+		fmt.Printf("%-30s %-10s %-20s %-8s %s\n", name, size, created, files, scope)
 	}
 
 	fmt.Println()
 }
 
-func restoreBackup(backupFile string, backupDir string) error {
+func restoreBackup(ctx context.Context, backupFile string, backupDir string) error {
 	log := logger.GetLogger()
 
 	// Handle interactive restore
@@ -271,12 +486,19 @@ func restoreBackup(backupFile string, backupDir string) error {
 		if err != nil {
 			return fmt.Errorf("failed to list backups: %w", err)
 		}
+		if backups, err = filterProjectBackups(backups); err != nil {
+			return err
+		}
 
 		if len(backups) == 0 {
 			log.Warn("No backups available for restore")
 			return nil
 		}
 
+		if ui.NonInteractive() {
+			return fmt.Errorf("specify the backup file to restore: %w", ui.ErrInputRequired)
+		}
+
 		selected := interactiveRestoreSelection(backups)
 		if selected == "" {
 			log.Info("Restore cancelled by user")
@@ -290,16 +512,31 @@ func restoreBackup(backupFile string, backupDir string) error {
 		backupFile = filepath.Join(backupDir, backupFile)
 	}
 
-	// Create backup manager
-	mgr := backup.NewManager(backup.Options{
+	// Project backups go back into the project they were taken from, or
+	// into the one given with --project
+	opts := backup.Options{
 		InstallDir: globalFlags.InstallDir,
 		BackupDir:  backupDir,
 		Verbose:    globalFlags.Verbose,
 		DryRun:     globalFlags.DryRun,
 		Overwrite:  backupFlags.Overwrite,
-	})
+	}
+	info := backup.NewManager(opts).GetBackupInfo(backupFile)
+	if opts.ProjectDir = info.ProjectDir(); opts.ProjectDir != "" && backupFlags.Project {
+		projectDir, err := getBackupProjectDir()
+		if err != nil {
+			return err
+		}
+		opts.ProjectDir = projectDir
+	}
+
+	// Create backup manager
+	mgr := backup.NewManager(opts)
 
 	log.Infof("Restoring from backup: %s", backupFile)
+	if opts.ProjectDir != "" {
+		log.Infof("Restoring into project: %s", opts.ProjectDir)
+	}
 
 	if globalFlags.DryRun {
 		log.Info("[DRY RUN] Would restore backup")
@@ -307,17 +544,24 @@ func restoreBackup(backupFile string, backupDir string) error {
 	}
 
 	// Create backup of current installation if it exists
-	if checkInstallationExists() {
+	if opts.ProjectDir == "" && checkInstallationExists() {
 		log.Info("Creating backup of current installation before restore")
 		// This would call create_backup internally
 	}
 
 	// Restore backup
-	if err := mgr.Restore(backupFile); err != nil {
+	if err := mgr.Restore(ctx, backupFile); err != nil {
 		return fmt.Errorf("backup restoration failed: %w", err)
 	}
 
-	if !globalFlags.Quiet {
+	// A backup taken on another machine may not carry usable modes
+	if opts.ProjectDir == "" {
+		if issues, err := permsChecker(opts.InstallDir).Check(); err == nil && len(issues) > 0 {
+			log.Warnf("%d permission problems in the restored files; run 'crew perms check --fix' to correct them", len(issues))
+		}
+	}
+
+	if showDecorations() {
 		ui.DisplaySuccess("Restore operation completed successfully!")
 	}
 
@@ -373,6 +617,9 @@ func showBackupInfo(backupFile string, backupDir string) error {
 	fmt.Printf("Size: %s\n", ui.FormatSize(info.Size))
 	fmt.Printf("Created: %s\n", info.Created)
 	fmt.Printf("Files: %d\n", info.FileCount)
+	if project := info.ProjectDir(); project != "" {
+		fmt.Printf("Project: %s\n", project)
+	}
 
 	if info.Metadata != nil {
 		fmt.Printf("Framework Version: %s\n", info.Metadata.FrameworkVersion)
@@ -390,11 +637,19 @@ func showBackupInfo(backupFile string, backupDir string) error {
 func cleanupBackups(backupDir string) error {
 	log := logger.GetLogger()
 
-	mgr := backup.NewManager(backup.Options{
+	opts := backup.Options{
 		BackupDir: backupDir,
 		Verbose:   globalFlags.Verbose,
 		DryRun:    globalFlags.DryRun,
-	})
+	}
+	if backupFlags.Project {
+		projectDir, err := getBackupProjectDir()
+		if err != nil {
+			return err
+		}
+		opts.ProjectDir = projectDir
+	}
+	mgr := backup.NewManager(opts)
 
 	log.Info("Cleaning up old backups...")
 
@@ -1,6 +1,8 @@
 package cli
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -565,7 +567,7 @@ func TestBackupManagerIntegration(t *testing.T) {
 		})
 
 		// Create backup
-		backupFile, err := mgr.Create()
+		backupFile, err := mgr.Create(context.Background())
 		if err != nil {
 			t.Fatalf("Failed to create backup: %v", err)
 		}
@@ -617,7 +619,7 @@ func TestBackupManagerIntegration(t *testing.T) {
 				Verbose:    false,
 				DryRun:     false,
 			})
-			_, err := newMgr.Create()
+			_, err := newMgr.Create(context.Background())
 			if err != nil {
 				t.Fatalf("Failed to create backup %d: %v", i, err)
 			}
@@ -640,6 +642,118 @@ func TestBackupManagerIntegration(t *testing.T) {
 	})
 }
 
+func TestProjectBackup(t *testing.T) {
+	tempDir := t.TempDir()
+	projectDir := filepath.Join(tempDir, "repo")
+	backupDir := filepath.Join(projectDir, ".claude", "backups")
+
+	files := map[string]string{
+		"CLAUDE.md":                "# Project\n",
+		".claude/settings.json":    "{}\n",
+		".claude/agents/custom.md": "# Custom agent\n",
+		"main.go":                  "package main\n",
+	}
+	for file, content := range files {
+		path := filepath.Join(projectDir, file)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var archived []string
+	mgr := backup.NewManager(backup.Options{
+		InstallDir: filepath.Join(tempDir, "install"),
+		BackupDir:  backupDir,
+		BackupName: "project_repo",
+		Compress:   "gzip",
+		ProjectDir: projectDir,
+		OnFile:     func(relPath string) { archived = append(archived, relPath) },
+	})
+	backupFile, err := mgr.Create(context.Background())
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if len(archived) != 3 {
+		t.Errorf("Expected .claude and CLAUDE.md files only, archived %v", archived)
+	}
+
+	info := mgr.GetBackupInfo(backupFile)
+	if info.ProjectDir() != projectDir {
+		t.Errorf("Expected project %s, got %q", projectDir, info.ProjectDir())
+	}
+	if info.Metadata.BackupType != backup.TypeProject || info.Metadata.ProjectName != "repo" {
+		t.Errorf("Unexpected metadata: %+v", info.Metadata)
+	}
+
+	// Installation cleanup leaves project backups alone
+	installMgr := backup.NewManager(backup.Options{BackupDir: backupDir})
+	if removed, err := installMgr.Cleanup(0, 0); err != nil || removed != 0 {
+		t.Errorf("Installation cleanup removed %d project backups (err %v)", removed, err)
+	}
+
+	// Restore into a fresh project
+	restored := filepath.Join(tempDir, "restored")
+	restoreMgr := backup.NewManager(backup.Options{BackupDir: backupDir, ProjectDir: restored})
+	if err := restoreMgr.Restore(context.Background(), backupFile); err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	for _, file := range []string{"CLAUDE.md", ".claude/settings.json", ".claude/agents/custom.md"} {
+		data, err := os.ReadFile(filepath.Join(restored, file))
+		if err != nil || string(data) != files[file] {
+			t.Errorf("%s not restored: %q, %v", file, data, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(restored, "main.go")); !os.IsNotExist(err) {
+		t.Error("Project backup should not include files outside .claude and CLAUDE.md")
+	}
+}
+
+func TestBackupCancelled(t *testing.T) {
+	tempDir := t.TempDir()
+	installDir := filepath.Join(tempDir, "install")
+	backupDir := filepath.Join(tempDir, "backups")
+	for _, file := range []string{"CLAUDE.md", "agents/a.md", "agents/b.md"} {
+		path := filepath.Join(installDir, file)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(file), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	mgr := backup.NewManager(backup.Options{
+		InstallDir: installDir,
+		BackupDir:  backupDir,
+		BackupName: "cancelled",
+		OnFile:     func(string) { cancel() },
+	})
+	if _, err := mgr.Create(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected a cancelled backup, got %v", err)
+	}
+	if entries, _ := os.ReadDir(backupDir); len(entries) != 0 {
+		t.Errorf("Cancelled backup left %d files behind", len(entries))
+	}
+
+	backupFile, err := backup.NewManager(backup.Options{
+		InstallDir: installDir,
+		BackupDir:  backupDir,
+		BackupName: "complete",
+	}).Create(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	restoreDir := filepath.Join(tempDir, "restored")
+	restoreMgr := backup.NewManager(backup.Options{InstallDir: restoreDir, BackupDir: backupDir})
+	if err := restoreMgr.Restore(ctx, backupFile); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected a cancelled restore, got %v", err)
+	}
+}
+
 func TestBackupCommandFlagValidation(t *testing.T) {
 	tests := []struct {
 		name          string
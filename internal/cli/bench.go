@@ -0,0 +1,454 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/buildinfo"
+	"github.com/jonwraymond/claude-code-super-crew/internal/core"
+	"github.com/jonwraymond/claude-code-super-crew/internal/fileops"
+	"github.com/jonwraymond/claude-code-super-crew/internal/metadata"
+	"github.com/jonwraymond/claude-code-super-crew/internal/ui"
+	"github.com/jonwraymond/claude-code-super-crew/pkg/backup"
+	"github.com/jonwraymond/claude-code-super-crew/pkg/logger"
+	"github.com/spf13/cobra"
+)
+
+// BenchFlags holds bench command flags
+type BenchFlags struct {
+	Iterations int
+	Files      int
+	FileSize   int
+	Only       []string
+	Baseline   string
+	Threshold  float64
+	JSON       bool
+}
+
+var benchFlags BenchFlags
+
+// benchFormat identifies the layout of the bench report, so tools that
+// track results across releases can tell versions apart
+const benchFormat = "crew-bench/1"
+
+// benchOperation is one timed operation; run gets the iteration number,
+// 0 for the untimed warm-up
+type benchOperation struct {
+	name        string
+	description string
+	run         func(b *benchEnv, iteration int) error
+}
+
+// benchEnv is the scratch installation the operations run against
+type benchEnv struct {
+	dir        string
+	installDir string
+}
+
+var benchOperations = []benchOperation{
+	{"discovery", "discover the component registry", benchDiscovery},
+	{"plan", "resolve and build a dry-run install plan for every component", benchPlan},
+	{"metadata-refresh", "rescan the installation metadata", benchMetadataRefresh},
+	{"integrity-scan", "hash every tracked file of the installation", benchIntegrityScan},
+	{"backup", "create a gzip backup of the installation", benchBackup},
+}
+
+// benchResult is the timing of one operation. Durations are nanoseconds.
+type benchResult struct {
+	Name       string `json:"name"`
+	Iterations int    `json:"iterations"`
+	MinNs      int64  `json:"min_ns"`
+	MedianNs   int64  `json:"median_ns"`
+	MeanNs     int64  `json:"mean_ns"`
+	MaxNs      int64  `json:"max_ns"`
+	// Baseline comparison, set with --baseline
+	BaselineNs int64   `json:"baseline_median_ns,omitempty"`
+	Change     float64 `json:"change_percent,omitempty"`
+	Regressed  bool    `json:"regressed,omitempty"`
+}
+
+// benchReport is the bench output, written with --json
+type benchReport struct {
+	Format     string         `json:"format"`
+	CreatedAt  time.Time      `json:"created_at"`
+	Crew       buildinfo.Info `json:"crew"`
+	Framework  string         `json:"framework_version"`
+	CPUs       int            `json:"cpus"`
+	Iterations int            `json:"iterations"`
+	Files      int            `json:"files"`
+	FileSize   int            `json:"file_size"`
+	Results    []benchResult  `json:"results"`
+}
+
+// NewBenchCommand creates the bench command
+func NewBenchCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bench",
+		Short: "Time key crew operations to track performance across releases",
+		Long: `Time the operations that dominate crew's run time against a synthetic
+installation in a temporary directory, leaving your installation alone:
+
+  discovery          discover the component registry
+  plan               resolve and build a dry-run install plan for every component
+  metadata-refresh   rescan the installation metadata
+  integrity-scan     hash every tracked file of the installation
+  backup             create a gzip backup of the installation
+
+Each operation runs once untimed to warm caches, then --iterations times.
+--json writes a report with the crew version, platform and the min, median,
+mean and max of each operation, suitable for storing per release. Pass an
+earlier report with --baseline to flag operations whose median got slower
+by more than --threshold percent; bench then exits with an error.
+
+Examples:
+  crew bench
+  crew bench --iterations 20 --files 5000 --json > bench-1.1.0.json
+  crew bench --baseline bench-1.0.0.json --threshold 15
+  crew bench --only integrity-scan,backup`,
+		Args:         cobra.NoArgs,
+		RunE:         runBench,
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().IntVarP(&benchFlags.Iterations, "iterations", "n", 5,
+		"Timed runs per operation")
+	cmd.Flags().IntVar(&benchFlags.Files, "files", 1000,
+		"Files in the synthetic installation")
+	cmd.Flags().IntVar(&benchFlags.FileSize, "file-size", 4096,
+		"Size in bytes of each synthetic file")
+	cmd.Flags().StringSliceVar(&benchFlags.Only, "only", nil,
+		"Only time these operations")
+	cmd.Flags().StringVar(&benchFlags.Baseline, "baseline", "",
+		"Earlier --json report to compare medians with")
+	cmd.Flags().Float64Var(&benchFlags.Threshold, "threshold", 20,
+		"Percent slowdown over the baseline that counts as a regression")
+	cmd.Flags().BoolVar(&benchFlags.JSON, "json", false,
+		"Output the report as JSON")
+
+	names := make([]string, len(benchOperations))
+	for i, op := range benchOperations {
+		names[i] = op.name
+	}
+	registerFlagCompletions(cmd, map[string]completionFunc{
+		"only": func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return completeList(names, toComplete)
+		},
+	})
+
+	return cmd
+}
+
+func runBench(cmd *cobra.Command, args []string) error {
+	if benchFlags.Iterations < 1 || benchFlags.Files < 1 || benchFlags.FileSize < 0 {
+		return fmt.Errorf("--iterations and --files must be at least 1")
+	}
+	var operations []benchOperation
+	for _, op := range benchOperations {
+		if len(benchFlags.Only) == 0 || contains(benchFlags.Only, op.name) {
+			operations = append(operations, op)
+		}
+	}
+	for _, name := range benchFlags.Only {
+		if !benchKnown(name) {
+			return fmt.Errorf("unknown operation %q", name)
+		}
+	}
+
+	var baseline *benchReport
+	if benchFlags.Baseline != "" {
+		data, err := os.ReadFile(expandPath(benchFlags.Baseline))
+		if err != nil {
+			return fmt.Errorf("failed to read baseline: %w", err)
+		}
+		baseline = &benchReport{}
+		if err := json.Unmarshal(data, baseline); err != nil || baseline.Format != benchFormat {
+			return fmt.Errorf("%s is not a crew bench report", benchFlags.Baseline)
+		}
+	}
+
+	// The operations log as they would in a real command, which would
+	// bury the results
+	logger.GetLogger().SetQuiet(!globalFlags.Verbose)
+
+	env, err := newBenchEnv(benchFlags.Files, benchFlags.FileSize)
+	if err != nil {
+		return fmt.Errorf("failed to create the synthetic installation: %w", err)
+	}
+	defer os.RemoveAll(env.dir)
+
+	jsonOutput := benchFlags.JSON || globalFlags.Output == "json"
+	report := benchReport{
+		Format:     benchFormat,
+		CreatedAt:  time.Now().UTC(),
+		Crew:       buildinfo.Get(),
+		Framework:  core.FrameworkVersion,
+		CPUs:       runtime.NumCPU(),
+		Iterations: benchFlags.Iterations,
+		Files:      benchFlags.Files,
+		FileSize:   benchFlags.FileSize,
+	}
+	for _, op := range operations {
+		if !jsonOutput {
+			fmt.Fprintf(os.Stderr, "%s %s...\n", ui.Icons.Arrow, op.name)
+		}
+		result, err := timeBenchOperation(env, op, benchFlags.Iterations)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op.name, err)
+		}
+		report.Results = append(report.Results, result)
+	}
+
+	regressions := 0
+	if baseline != nil {
+		if baseline.Files != report.Files || baseline.FileSize != report.FileSize {
+			logger.GetLogger().Warnf("The baseline used %d files of %d bytes; timings are only comparable for the same tree", baseline.Files, baseline.FileSize)
+		}
+		regressions = compareBench(report.Results, baseline.Results, benchFlags.Threshold)
+	}
+
+	if jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			return err
+		}
+	} else {
+		displayBench(report, baseline != nil)
+	}
+
+	if regressions > 0 {
+		return fmt.Errorf("%d operations regressed by more than %.0f%%", regressions, benchFlags.Threshold)
+	}
+	return nil
+}
+
+func benchKnown(name string) bool {
+	for _, op := range benchOperations {
+		if op.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// newBenchEnv writes a synthetic installation of files files: core docs at
+// the top and the rest spread over commands, agents and hooks, all tracked
+// in the metadata as a real installation would be
+func newBenchEnv(files, size int) (*benchEnv, error) {
+	dir, err := os.MkdirTemp("", "crew-bench-")
+	if err != nil {
+		return nil, err
+	}
+	env := &benchEnv{dir: dir, installDir: filepath.Join(dir, "install")}
+
+	content := []byte(strings.Repeat("crew bench synthetic content\n", size/29+1)[:size])
+	meta := &metadata.UnifiedMetadata{
+		Components: map[string]metadata.ComponentMeta{},
+		Documents:  map[string]metadata.DocumentMeta{},
+		Integrity:  metadata.IntegrityMeta{FileHashes: map[string]metadata.FileIntegrityMeta{}},
+	}
+	coreDocs := []string{"CLAUDE.md", "COMMANDS.md", "FLAGS.md", "MCP.md", "MODES.md", "ORCHESTRATOR.md", "PERSONAS.md", "PRINCIPLES.md", "RULES.md"}
+	dirs := []string{filepath.Join("commands", "crew"), "agents", "hooks"}
+	components := []string{"commands", "agents", "hooks"}
+
+	for i := 0; i < files; i++ {
+		rel, component := "", "core"
+		if i < len(coreDocs) {
+			rel = coreDocs[i]
+		} else {
+			rel = filepath.Join(dirs[i%len(dirs)], fmt.Sprintf("bench-%05d.md", i))
+			component = components[i%len(components)]
+		}
+		path := filepath.Join(env.installDir, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			os.RemoveAll(dir)
+			return nil, err
+		}
+		if err := os.WriteFile(path, content, 0644); err != nil {
+			os.RemoveAll(dir)
+			return nil, err
+		}
+		hash, err := fileops.HashFile(path)
+		if err != nil {
+			os.RemoveAll(dir)
+			return nil, err
+		}
+		meta.Integrity.FileHashes[filepath.ToSlash(rel)] = metadata.FileIntegrityMeta{
+			OriginalHash: hash,
+			CurrentHash:  hash,
+			Status:       "clean",
+			Component:    component,
+			FilePath:     filepath.ToSlash(rel),
+		}
+	}
+	meta.Framework.Version = core.FrameworkVersion
+	meta.Installation.InstallDir = env.installDir
+
+	if err := metadata.NewMetadataManager(env.installDir).SaveMetadata(meta); err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+	return env, nil
+}
+
+// timeBenchOperation runs op once to warm up, then iterations times
+func timeBenchOperation(env *benchEnv, op benchOperation, iterations int) (benchResult, error) {
+	if err := op.run(env, 0); err != nil {
+		return benchResult{}, err
+	}
+	durations := make([]time.Duration, iterations)
+	for i := range durations {
+		start := time.Now()
+		if err := op.run(env, i+1); err != nil {
+			return benchResult{}, err
+		}
+		durations[i] = time.Since(start)
+	}
+	return summarizeBench(op.name, durations), nil
+}
+
+// summarizeBench reduces the durations of one operation to its statistics
+func summarizeBench(name string, durations []time.Duration) benchResult {
+	sorted := append([]time.Duration(nil), durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var total time.Duration
+	for _, d := range sorted {
+		total += d
+	}
+	n := len(sorted)
+	median := sorted[n/2]
+	if n%2 == 0 {
+		median = (sorted[n/2-1] + sorted[n/2]) / 2
+	}
+	return benchResult{
+		Name:       name,
+		Iterations: n,
+		MinNs:      int64(sorted[0]),
+		MedianNs:   int64(median),
+		MeanNs:     int64(total) / int64(n),
+		MaxNs:      int64(sorted[n-1]),
+	}
+}
+
+// compareBench records each result's change against the baseline median
+// and returns how many regressed by more than threshold percent
+func compareBench(results, baseline []benchResult, threshold float64) int {
+	medians := make(map[string]int64)
+	for _, r := range baseline {
+		medians[r.Name] = r.MedianNs
+	}
+	regressions := 0
+	for i := range results {
+		base, ok := medians[results[i].Name]
+		if !ok || base <= 0 {
+			continue
+		}
+		results[i].BaselineNs = base
+		results[i].Change = float64(results[i].MedianNs-base) / float64(base) * 100
+		if results[i].Change > threshold {
+			results[i].Regressed = true
+			regressions++
+		}
+	}
+	return regressions
+}
+
+func displayBench(report benchReport, compared bool) {
+	fmt.Printf("\n%s%screw bench%s v%s, %s, %d CPUs, %d files of %d bytes, %d iterations\n\n",
+		ui.ColorCyan, ui.ColorBright, ui.ColorReset, report.Crew.Version, report.Crew.Platform,
+		report.CPUs, report.Files, report.FileSize, report.Iterations)
+
+	header := fmt.Sprintf("  %-18s %10s %10s %10s", "OPERATION", "MEDIAN", "MIN", "MAX")
+	if compared {
+		header += fmt.Sprintf(" %10s %8s", "BASELINE", "CHANGE")
+	}
+	fmt.Println(header)
+	for _, r := range report.Results {
+		line := fmt.Sprintf("  %-18s %10s %10s %10s", r.Name,
+			benchDuration(r.MedianNs), benchDuration(r.MinNs), benchDuration(r.MaxNs))
+		if compared && r.BaselineNs > 0 {
+			change := fmt.Sprintf("%+.1f%%", r.Change)
+			switch {
+			case r.Regressed:
+				change = ui.ColorRed + change + " " + ui.Icons.Warning + ui.ColorReset
+			case r.Change < 0:
+				change = ui.ColorGreen + change + ui.ColorReset
+			}
+			line += fmt.Sprintf(" %10s %8s", benchDuration(r.BaselineNs), change)
+		} else if compared {
+			line += fmt.Sprintf(" %10s %8s", "-", "new")
+		}
+		fmt.Println(line)
+	}
+}
+
+func benchDuration(ns int64) string {
+	d := time.Duration(ns)
+	switch {
+	case d >= time.Second:
+		return d.Round(time.Millisecond).String()
+	case d >= time.Millisecond:
+		return d.Round(10 * time.Microsecond).String()
+	}
+	return d.Round(time.Microsecond).String()
+}
+
+func benchDiscovery(env *benchEnv, iteration int) error {
+	_, err := newComponentRegistry()
+	return err
+}
+
+func benchPlan(env *benchEnv, iteration int) error {
+	registry, err := newComponentRegistry()
+	if err != nil {
+		return err
+	}
+	components := registry.ListComponents()
+	plan, err := registry.Solve(components, nil)
+	if err != nil {
+		return err
+	}
+	// Requirement checks run the tools themselves and would time them
+	// rather than crew, so the plan is built without them
+	buildInstallPlan(plan, registry, components, nil, InstallFlags{}, &GlobalFlags{InstallDir: env.installDir})
+	return nil
+}
+
+func benchMetadataRefresh(env *benchEnv, iteration int) error {
+	_, err := metadata.NewMetadataManager(env.installDir).RefreshMetadata()
+	return err
+}
+
+func benchIntegrityScan(env *benchEnv, iteration int) error {
+	integrity, err := metadata.NewMetadataManager(env.installDir).CheckFileIntegrity()
+	if err != nil {
+		return err
+	}
+	if integrity.TotalFiles == 0 {
+		return fmt.Errorf("no files were checked")
+	}
+	return nil
+}
+
+func benchBackup(env *benchEnv, iteration int) error {
+	backupDir := filepath.Join(env.dir, "backups")
+	mgr := backup.NewManager(backup.Options{
+		InstallDir: env.installDir,
+		BackupDir:  backupDir,
+		BackupName: fmt.Sprintf("bench-%d", iteration),
+		Compress:   "gzip",
+	})
+	path, err := mgr.Create(context.Background())
+	if err != nil {
+		return err
+	}
+	return os.Remove(path)
+}
@@ -0,0 +1,42 @@
+package cli
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSummarizeBench(t *testing.T) {
+	odd := summarizeBench("op", []time.Duration{30, 10, 20})
+	if odd.MinNs != 10 || odd.MedianNs != 20 || odd.MeanNs != 20 || odd.MaxNs != 30 || odd.Iterations != 3 {
+		t.Errorf("Unexpected summary %+v", odd)
+	}
+	even := summarizeBench("op", []time.Duration{40, 10, 20, 30})
+	if even.MedianNs != 25 {
+		t.Errorf("Expected the median of an even count to average the middle pair, got %d", even.MedianNs)
+	}
+}
+
+func TestCompareBench(t *testing.T) {
+	results := []benchResult{
+		{Name: "discovery", MedianNs: 130},
+		{Name: "plan", MedianNs: 110},
+		{Name: "backup", MedianNs: 500},
+	}
+	baseline := []benchResult{
+		{Name: "discovery", MedianNs: 100},
+		{Name: "plan", MedianNs: 100},
+	}
+
+	if n := compareBench(results, baseline, 20); n != 1 {
+		t.Fatalf("Expected 1 regression, got %d", n)
+	}
+	if !results[0].Regressed || results[0].BaselineNs != 100 || results[0].Change != 30 {
+		t.Errorf("discovery should regress by 30%%, got %+v", results[0])
+	}
+	if results[1].Regressed {
+		t.Errorf("plan is within the threshold, got %+v", results[1])
+	}
+	if results[2].BaselineNs != 0 || results[2].Regressed {
+		t.Errorf("Operations missing from the baseline should not be compared, got %+v", results[2])
+	}
+}
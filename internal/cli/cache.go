@@ -0,0 +1,159 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/cache"
+	"github.com/jonwraymond/claude-code-super-crew/internal/managers"
+	"github.com/jonwraymond/claude-code-super-crew/internal/ui"
+	"github.com/jonwraymond/claude-code-super-crew/pkg/logger"
+	"github.com/spf13/cobra"
+)
+
+// cacheMaxSizeKey caps the cache in megabytes
+const cacheMaxSizeKey = "settings.cache_max_mb"
+
+// CacheFlags holds cache command flags
+type CacheFlags struct {
+	OlderThan string
+	JSON      bool
+}
+
+var cacheFlags CacheFlags
+
+// NewCacheCommand creates the cache command
+func NewCacheCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Inspect and clean the download and operation cache",
+		Long: `crew caches downloaded release archives, rendered templates and analysis
+results in .crew/cache so repeated operations are faster. The cache is
+capped at 512 MB; past the cap the least recently used entries are
+evicted. Change the cap with settings.cache_max_mb in the crew config
+(0 disables eviction).
+
+Examples:
+  crew cache info
+  crew cache clean --older-than 30d
+  crew cache clean --dry-run`,
+	}
+
+	infoCmd := &cobra.Command{
+		Use:          "info",
+		Short:        "Show the size of each cache namespace",
+		Args:         cobra.NoArgs,
+		RunE:         runCacheInfo,
+		SilenceUsage: true,
+	}
+	infoCmd.Flags().BoolVar(&cacheFlags.JSON, "json", false, "Output as JSON")
+
+	cleanCmd := &cobra.Command{
+		Use:   "clean",
+		Short: "Remove cached files",
+		Long: `Remove cached files, or with --older-than only those not used within
+the given age, e.g. 12h or 30d.`,
+		Args:         cobra.NoArgs,
+		RunE:         runCacheClean,
+		SilenceUsage: true,
+	}
+	cleanCmd.Flags().StringVar(&cacheFlags.OlderThan, "older-than", "",
+		"Only remove files not used within this age (e.g. 12h, 30d)")
+	cleanCmd.Flags().BoolVar(&cacheFlags.JSON, "json", false, "Output as JSON")
+
+	cmd.AddCommand(infoCmd)
+	cmd.AddCommand(cleanCmd)
+
+	return cmd
+}
+
+// openCache returns the cache of the installation with the configured cap
+func openCache() *cache.Cache {
+	c := cache.New(cache.Dir(globalFlags.InstallDir))
+	if cm, err := managers.NewConfigManager(getCrewConfigDir(), ""); err == nil {
+		if mb, err := cm.GetInt(cacheMaxSizeKey); err == nil {
+			c.MaxSize = int64(mb) << 20
+		}
+	}
+	return c
+}
+
+// cacheReport is the --json output of cache info
+type cacheReport struct {
+	Dir        string        `json:"dir"`
+	MaxBytes   int64         `json:"max_bytes"`
+	TotalBytes int64         `json:"total_bytes"`
+	Namespaces []cache.Usage `json:"namespaces"`
+}
+
+func runCacheInfo(cmd *cobra.Command, args []string) error {
+	c := openCache()
+	usage, err := c.Info()
+	if err != nil {
+		return fmt.Errorf("failed to read cache: %w", err)
+	}
+	report := cacheReport{Dir: c.Dir, MaxBytes: c.MaxSize, Namespaces: usage}
+	for _, u := range usage {
+		report.TotalBytes += u.Bytes
+	}
+
+	if cacheFlags.JSON || globalFlags.Output == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	}
+
+	limit := "no limit"
+	if c.MaxSize > 0 {
+		limit = formatBytes(c.MaxSize)
+	}
+	fmt.Printf("\n%sCache:%s %s\n", ui.ColorCyan, ui.ColorReset, c.Dir)
+	fmt.Printf("  %s of %s\n", formatBytes(report.TotalBytes), limit)
+	if len(usage) == 0 {
+		fmt.Println("  Empty")
+		return nil
+	}
+	fmt.Println()
+	fmt.Printf("  %-12s %6s %10s  %s\n", "NAMESPACE", "FILES", "SIZE", "LAST USED")
+	for _, u := range usage {
+		fmt.Printf("  %-12s %6d %10s  %s\n", u.Namespace, u.Files, formatBytes(u.Bytes), u.Newest.Format("2006-01-02 15:04"))
+	}
+	return nil
+}
+
+func runCacheClean(cmd *cobra.Command, args []string) error {
+	log := logger.GetLogger()
+
+	var cutoff time.Time
+	if cacheFlags.OlderThan != "" {
+		var err error
+		if cutoff, err = parseSince(cacheFlags.OlderThan, time.Now()); err != nil {
+			return fmt.Errorf("invalid --older-than %q: use a duration like 12h or 30d", cacheFlags.OlderThan)
+		}
+	}
+
+	removal, err := openCache().Clean(cutoff, globalFlags.DryRun)
+	if err != nil {
+		return err
+	}
+
+	if cacheFlags.JSON || globalFlags.Output == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(removal)
+	}
+
+	verb := "Removed"
+	if globalFlags.DryRun {
+		verb = "[DRY RUN] Would remove"
+	}
+	if globalFlags.Verbose {
+		for _, path := range removal.Files {
+			fmt.Printf("  %s %s\n", ui.Icons.Bullet, path)
+		}
+	}
+	log.Infof("%s %d cached files (%s)", verb, len(removal.Files), formatBytes(removal.Bytes))
+	return nil
+}
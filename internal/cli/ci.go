@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/findings"
+	"github.com/jonwraymond/claude-code-super-crew/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// Exit statuses crew uses in CI mode, besides 1 for other failures and
+// ExitInterrupted
+const (
+	// ExitUsage is the status of invalid flags or arguments
+	ExitUsage = 2
+	// ExitInputRequired is the status of a command that needed an answer
+	// to a prompt that has no safe default
+	ExitInputRequired = 3
+	// ExitFindings is the status of doctor and integrity checks that found
+	// errors or warnings
+	ExitFindings = 4
+)
+
+// ciReason names what turned CI mode on: the --ci flag or CREW_CI, or ""
+// when crew is not in CI mode
+func ciReason() string {
+	if globalFlags.CI {
+		return "--ci"
+	}
+	if v := os.Getenv("CREW_CI"); v != "" && v != "0" && v != "false" {
+		return "CREW_CI"
+	}
+	return ""
+}
+
+// ciMode reports whether crew runs as a pipeline step
+func ciMode() bool {
+	return ciReason() != ""
+}
+
+// applyCIMode turns on the flags CI mode implies. An explicit --output is
+// kept, so a pipeline can still ask for text.
+func applyCIMode(cmd *cobra.Command) {
+	if !ciMode() {
+		return
+	}
+	globalFlags.Yes = true
+	globalFlags.NonInteractive = true
+	globalFlags.NoColor = true
+	if flag := cmd.Flags().Lookup("output"); flag == nil || !flag.Changed {
+		globalFlags.Output = "json"
+	}
+}
+
+// ciFlagError makes flag errors exit with ExitUsage in CI mode
+func ciFlagError(cmd *cobra.Command, err error) error {
+	if !ciMode() {
+		return err
+	}
+	return &ExitError{Code: ExitUsage, Err: err}
+}
+
+// ciExitCode returns the exit status of an error without one of its own
+// in CI mode, or 0 when the general rules apply
+func ciExitCode(err error) int {
+	if ciMode() && errors.Is(err, ui.ErrInputRequired) {
+		return ExitInputRequired
+	}
+	return 0
+}
+
+// ciFindingsError fails a check in CI mode when its report has errors or
+// warnings, so pipelines stop on them
+func ciFindingsError(check string, report *findings.Report) error {
+	if !ciMode() {
+		return nil
+	}
+	failing := report.Count(findings.LevelError) + report.Count(findings.LevelWarning)
+	if failing == 0 {
+		return nil
+	}
+	return &ExitError{Code: ExitFindings, Err: fmt.Errorf("%s reported %d errors or warnings", check, failing)}
+}
@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/findings"
+	"github.com/jonwraymond/claude-code-super-crew/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+func TestApplyCIMode(t *testing.T) {
+	originalFlags := globalFlags
+	defer func() { globalFlags = originalFlags }()
+	t.Setenv("CREW_CI", "")
+
+	newCmd := func() *cobra.Command {
+		cmd := &cobra.Command{Use: "test"}
+		cmd.Flags().StringVar(&globalFlags.Output, "output", "text", "")
+		return cmd
+	}
+
+	globalFlags = GlobalFlags{}
+	applyCIMode(newCmd())
+	if globalFlags.Yes || globalFlags.Output != "text" {
+		t.Errorf("flags changed outside CI mode: %+v", globalFlags)
+	}
+
+	t.Setenv("CREW_CI", "1")
+	globalFlags = GlobalFlags{}
+	applyCIMode(newCmd())
+	if !globalFlags.Yes || !globalFlags.NonInteractive || !globalFlags.NoColor || globalFlags.Output != "json" {
+		t.Errorf("CI mode did not imply its flags: %+v", globalFlags)
+	}
+
+	globalFlags = GlobalFlags{}
+	cmd := newCmd()
+	if err := cmd.Flags().Set("output", "text"); err != nil {
+		t.Fatal(err)
+	}
+	applyCIMode(cmd)
+	if globalFlags.Output != "text" {
+		t.Errorf("CI mode overrode an explicit --output: %q", globalFlags.Output)
+	}
+}
+
+func TestExitCodeInCIMode(t *testing.T) {
+	originalFlags := globalFlags
+	defer func() { globalFlags = originalFlags }()
+	t.Setenv("CREW_CI", "")
+
+	inputErr := fmt.Errorf("choose a backup: %w", ui.ErrInputRequired)
+	globalFlags.CI = false
+	if code := ExitCode(inputErr); code != 1 {
+		t.Errorf("ExitCode outside CI mode = %d, want 1", code)
+	}
+
+	globalFlags.CI = true
+	if code := ExitCode(inputErr); code != ExitInputRequired {
+		t.Errorf("ExitCode of missing input = %d, want %d", code, ExitInputRequired)
+	}
+	if code := ExitCode(ciFlagError(nil, errors.New("unknown flag"))); code != ExitUsage {
+		t.Errorf("ExitCode of a flag error = %d, want %d", code, ExitUsage)
+	}
+	if code := ExitCode(errors.New("boom")); code != 1 {
+		t.Errorf("ExitCode of other errors = %d, want 1", code)
+	}
+}
+
+func TestCIFindingsError(t *testing.T) {
+	originalFlags := globalFlags
+	defer func() { globalFlags = originalFlags }()
+	t.Setenv("CREW_CI", "")
+
+	rules := []findings.Rule{
+		{ID: "note", Level: findings.LevelNote},
+		{ID: "warning", Level: findings.LevelWarning},
+	}
+	notes := findings.NewReport("1.0.0", "", rules...)
+	notes.Add("note", "optional tool missing", "", nil)
+	warnings := findings.NewReport("1.0.0", "", rules...)
+	warnings.Add("warning", "file modified", "a.md", nil)
+
+	globalFlags.CI = false
+	if err := ciFindingsError("check", warnings); err != nil {
+		t.Errorf("findings failed outside CI mode: %v", err)
+	}
+
+	globalFlags.CI = true
+	if err := ciFindingsError("check", notes); err != nil {
+		t.Errorf("notes failed in CI mode: %v", err)
+	}
+	if code := ExitCode(ciFindingsError("check", warnings)); code != ExitFindings {
+		t.Errorf("ExitCode of warnings = %d, want %d", code, ExitFindings)
+	}
+}
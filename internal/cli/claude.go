@@ -7,12 +7,16 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
-	"time"
 
 	"github.com/jonwraymond/claude-code-super-crew/internal/claude"
 	"github.com/jonwraymond/claude-code-super-crew/internal/managers"
 	"github.com/jonwraymond/claude-code-super-crew/internal/orchestrator"
+	"github.com/jonwraymond/claude-code-super-crew/internal/pathsutil"
+	"github.com/jonwraymond/claude-code-super-crew/internal/project"
+	"github.com/jonwraymond/claude-code-super-crew/internal/prompts"
+	"github.com/jonwraymond/claude-code-super-crew/internal/semver"
 	"github.com/jonwraymond/claude-code-super-crew/internal/ui"
+	"github.com/jonwraymond/claude-code-super-crew/internal/versioning"
 	"github.com/jonwraymond/claude-code-super-crew/pkg/logger"
 	"github.com/spf13/cobra"
 )
@@ -25,11 +29,22 @@ type ClaudeFlags struct {
 	Update      bool
 	List        bool
 	Test        string
+	Run         string
+	Copy        bool
+	SavePrompt  bool
+	Template    string
 	ClaudeDir   string
 	CommandsDir string
 	ProjectDir  string
 	Shell       string
 	Export      string
+	LintMD      bool
+	Fix         bool
+	MergeDebug  bool
+	Validate    bool
+	GitBranch   string
+	GitCommit   bool
+	NoGitignore bool
 }
 
 var claudeFlags ClaudeFlags
@@ -43,32 +58,85 @@ func NewClaudeCommand() *cobra.Command {
 
 This command manages project-level Claude Code integration for the current project,
 enabling /crew: prefixed commands with tab completion and project-specific agents.
+Run from a subdirectory, it acts on the nearest directory up that has a
+.claude directory or .git; --project-dir names the project explicitly.
+
+--run takes a complete /crew: command line, checks its flags and values
+against the command's arguments and renders the Task invocation Claude Code
+would run. It is printed, copied to the clipboard with --copy, or written
+under .claude/prompts with --save-prompt for pasting into a session.
+
+The prompts are rendered from versioned templates. A project overrides one
+by saving a file of the same name in .claude/prompts/templates; start from
+the built-in copy that --prompt-template prints.
+
+Running --install on a project that is already integrated upgrades it in place:
+only integration files whose content changed are rewritten, and the project's
+agents are never touched. Use --force to rewrite every integration file.
+
+In a git repository, install warns about uncommitted changes and offers to
+add the generated crew files (.claude/.crew, the integration and project
+configs, settings.local.json) to .gitignore, keeping agents and commands
+tracked. --git-branch installs on a new branch and --git-commit commits the
+result.
 
 Examples:
   crew claude --install                    # Enable Claude integration for current project
+  crew claude --install --git-branch crew-setup --git-commit
   crew claude --status --verbose          # Check project integration status
   crew claude --list                      # List available /crew: commands
   crew claude --test /crew:analyze        # Test a specific command
+  crew claude --run "/crew:analyze src --focus security" --copy
+  crew claude --run "/crew:build --type prod" --save-prompt
+  crew claude --prompt-template analyze > .claude/prompts/templates/analyze.tmpl
   crew claude --export completions.json   # Export commands for external use
+  crew claude --lint-claude-md --fix      # Check and repair CLAUDE.md managed sections
+  crew claude --merge-debug               # Show how crew parses the CLAUDE.md sections
+  crew claude --validate                  # Check agent files against the subagent format
   crew claude --uninstall                 # Remove project integration`,
-		RunE: runClaude,
+		RunE:         runClaude,
+		SilenceUsage: true,
 	}
 
 	// Main operations
 	cmd.Flags().BoolVar(&claudeFlags.Install, "install", false,
-		"Install Claude Code integration for current project")
+		"Install Claude Code integration for current project, upgrading an existing one in place")
 	cmd.Flags().BoolVar(&claudeFlags.Uninstall, "uninstall", false,
 		"Uninstall Claude Code integration for current project")
 	cmd.Flags().BoolVar(&claudeFlags.Status, "status", false,
 		"Show integration status")
 	cmd.Flags().BoolVar(&claudeFlags.Update, "update", false,
-		"Update existing integration")
+		"Update existing integration (same as --install on an integrated project)")
 	cmd.Flags().BoolVar(&claudeFlags.List, "list", false,
 		"List available /crew: commands")
 	cmd.Flags().StringVar(&claudeFlags.Test, "test", "",
 		"Test a specific command (e.g., /crew:analyze)")
+	cmd.Flags().StringVar(&claudeFlags.Run, "run", "",
+		"Validate a full /crew: command line and render its Task invocation")
+	cmd.Flags().BoolVar(&claudeFlags.Copy, "copy", false,
+		"Copy the rendered --run invocation to the clipboard")
+	cmd.Flags().BoolVar(&claudeFlags.SavePrompt, "save-prompt", false,
+		"Write the rendered --run invocation to a file under .claude/prompts")
+	cmd.Flags().StringVar(&claudeFlags.Template, "prompt-template", "",
+		"Print a built-in prompt template, to start a project override from")
 	cmd.Flags().StringVar(&claudeFlags.Export, "export", "",
 		"Export commands to JSON file")
+	cmd.Flags().BoolVar(&claudeFlags.LintMD, "lint-claude-md", false,
+		"Check the global and project CLAUDE.md for broken crew sections")
+	cmd.Flags().BoolVar(&claudeFlags.Fix, "fix", false,
+		"Repair the problems --lint-claude-md can fix (keeps a .backup copy)")
+	cmd.Flags().BoolVar(&claudeFlags.MergeDebug, "merge-debug", false,
+		"Dump the blocks and managed sections crew parses from the global and project CLAUDE.md")
+	cmd.Flags().BoolVar(&claudeFlags.Validate, "validate", false,
+		"Check global and project agents against the Claude Code subagent format")
+
+	// Git handling for --install and --update
+	cmd.Flags().StringVar(&claudeFlags.GitBranch, "git-branch", "",
+		"Create and switch to this branch before installing")
+	cmd.Flags().BoolVar(&claudeFlags.GitCommit, "git-commit", false,
+		"Commit the integration files and .gitignore after installing")
+	cmd.Flags().BoolVar(&claudeFlags.NoGitignore, "no-gitignore", false,
+		"Do not offer to add generated crew files to .gitignore")
 
 	// Configuration options
 	cmd.Flags().StringVar(&claudeFlags.ClaudeDir, "claude-dir", "",
@@ -76,12 +144,17 @@ Examples:
 	cmd.Flags().StringVar(&claudeFlags.CommandsDir, "commands-dir", "",
 		"Commands directory (default: ~/.claude/commands for global commands)")
 	cmd.Flags().StringVar(&claudeFlags.ProjectDir, "project-dir", "",
-		"Project directory for installation (default: current working directory)")
+		"Project directory (default: the nearest directory up with .claude or .git, else the current one)")
 	cmd.Flags().StringVar(&claudeFlags.Shell, "shell", "",
 		"Generate completion for specific shell (bash, zsh, fish)")
 
 	// Mark operations as mutually exclusive
-	cmd.MarkFlagsMutuallyExclusive("install", "uninstall", "status", "update", "list", "test", "export")
+	cmd.MarkFlagsMutuallyExclusive("install", "uninstall", "status", "update", "list", "test", "run", "prompt-template", "export", "lint-claude-md", "merge-debug", "validate")
+
+	registerFlagCompletions(cmd, map[string]completionFunc{
+		"shell":           completeStatic("bash", "zsh", "fish"),
+		"prompt-template": completeStatic(prompts.Names()...),
+	})
 
 	return cmd
 }
@@ -91,6 +164,13 @@ func runClaude(cmd *cobra.Command, args []string) error {
 	log.SetVerbose(globalFlags.Verbose)
 	log.SetQuiet(globalFlags.Quiet)
 
+	if claudeFlags.Fix && !claudeFlags.LintMD {
+		return fmt.Errorf("--fix requires --lint-claude-md")
+	}
+	if (claudeFlags.Copy || claudeFlags.SavePrompt) && claudeFlags.Run == "" {
+		return fmt.Errorf("--copy and --save-prompt require --run")
+	}
+
 	// Validate shell parameter if provided
 	if claudeFlags.Shell != "" {
 		validShells := []string{"bash", "zsh", "fish"}
@@ -107,23 +187,42 @@ func runClaude(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	// Determine project directory
-	pwd, err := os.Getwd()
+	// Determine project directory, which the integration works relative to
+	projectDir, err := resolveProjectDir(claudeFlags.ProjectDir)
 	if err != nil {
-		return fmt.Errorf("failed to get current directory: %w", err)
+		return err
 	}
-	if claudeFlags.ProjectDir == "" {
-		claudeFlags.ProjectDir = pwd
-	} else {
-		// If a project directory is provided, we need to make sure we are in it
-		if err := os.Chdir(claudeFlags.ProjectDir); err != nil {
-			return fmt.Errorf("failed to change directory to %s: %w", claudeFlags.ProjectDir, err)
+	claudeFlags.ProjectDir = projectDir
+	if err := os.Chdir(projectDir); err != nil {
+		return fmt.Errorf("failed to change directory to %s: %w", projectDir, err)
+	}
+
+	if claudeFlags.Template != "" {
+		source, err := prompts.Source(claudeFlags.Template)
+		if err != nil {
+			return fmt.Errorf("%w (available: %s)", err, strings.Join(prompts.Names(), ", "))
 		}
+		fmt.Print(source)
+		return nil
+	}
+
+	// Linting and the merge dump only read CLAUDE.md files and work without
+	// the framework
+	if claudeFlags.LintMD {
+		return lintClaudeMD(claudeFlags.ProjectDir, claudeFlags.Fix)
+	}
+	if claudeFlags.MergeDebug {
+		return mergeDebugClaudeMD(claudeFlags.ProjectDir)
 	}
 
 	// Set claude directory based on project directory
 	if claudeFlags.ClaudeDir == "" {
-		claudeFlags.ClaudeDir = filepath.Join(claudeFlags.ProjectDir, ".claude")
+		claudeFlags.ClaudeDir = pathsutil.ClaudeDir(claudeFlags.ProjectDir)
+	}
+
+	// Validation only reads agent files and works without the framework
+	if claudeFlags.Validate {
+		return validateAgents(claudeFlags.ClaudeDir)
 	}
 
 	if claudeFlags.CommandsDir == "" {
@@ -170,6 +269,9 @@ func runClaude(cmd *cobra.Command, args []string) error {
 	case claudeFlags.Test != "":
 		return testClaudeCommand(integration, claudeFlags.Test)
 
+	case claudeFlags.Run != "":
+		return runClaudeSlashCommand(integration, claudeFlags.Run)
+
 	case claudeFlags.Export != "":
 		return exportClaudeCommands(integration, claudeFlags.Export)
 
@@ -202,17 +304,17 @@ func installClaudeIntegration(integration *claude.ClaudeIntegration) error {
 		return fmt.Errorf("failed to check integration status: %w", err)
 	}
 
-	if status.Installed && !globalFlags.Force {
-		log.Warn("Claude Code integration already installed for this project")
-		if !globalFlags.Yes && !ui.Confirm("Reinstall integration?", false) {
-			log.Info("Installation cancelled")
-			return nil
-		}
+	// An existing integration is upgraded in place: only files whose
+	// content changed are written and project agents are kept
+	frameworkVersion, _ := versioning.NewVersionManager(getGlobalInstallDir()).GetCurrentVersion()
+	integration.SetVersion(frameworkVersion)
+	upgrading := status.Installed
+	if upgrading {
+		describeIntegrationUpgrade(status.Version, frameworkVersion)
+	} else {
+		log.Info("Setting up project-level Claude Code integration...")
 	}
 
-	// Install project-specific integration
-	log.Info("Setting up project-level Claude Code integration...")
-
 	// Ensure all required components are available for project-level install
 	componentsToInstall := []string{"core", "commands", "agents"}
 	for _, component := range componentsToInstall {
@@ -222,9 +324,14 @@ func installClaudeIntegration(integration *claude.ClaudeIntegration) error {
 		}
 	}
 
+	repo, err := prepareIntegrationGit(projectDir)
+	if err != nil {
+		return err
+	}
+
 	// The claudeFlags.ClaudeDir is already set to project/.claude
 	projectClaudeDir := claudeFlags.ClaudeDir
-	projectAgentsDir := filepath.Join(projectClaudeDir, "agents")
+	projectAgentsDir := filepath.Join(projectClaudeDir, pathsutil.AgentsDirName)
 	if err := os.MkdirAll(projectAgentsDir, 0755); err != nil {
 		return fmt.Errorf("failed to create project agents directory: %w", err)
 	}
@@ -235,32 +342,44 @@ func installClaudeIntegration(integration *claude.ClaudeIntegration) error {
 		return fmt.Errorf("failed to install orchestrator: %w", err)
 	}
 
-	// Create project marker file
-	projectMarker := filepath.Join(projectClaudeDir, "project-config.json")
-	projectConfig := map[string]interface{}{
-		"version":         "1.0",
-		"project_path":    projectDir,
-		"global_commands": claudeFlags.CommandsDir,
-		"created_at":      time.Now().Format(time.RFC3339),
-		"type":            "project-integration",
-	}
-
-	configData, err := json.MarshalIndent(projectConfig, "", "  ")
-	if err != nil {
-		return fmt.Errorf("failed to marshal project config: %w", err)
+	// Create project marker file, keeping what /crew:onboard recorded.
+	// It is only rewritten when something changed.
+	projectConfig, err := project.LoadConfig(projectDir)
+	fresh := err != nil
+	if fresh {
+		if !os.IsNotExist(err) {
+			log.Warnf("Replacing unreadable project config: %v", err)
+		}
+		projectConfig = project.NewConfig(projectDir, claudeFlags.CommandsDir)
 	}
-
-	if err := os.WriteFile(projectMarker, configData, 0644); err != nil {
-		return fmt.Errorf("failed to write project config: %w", err)
+	if fresh || projectConfig.ProjectPath != projectDir || projectConfig.GlobalCommands != claudeFlags.CommandsDir {
+		projectConfig.ProjectPath = projectDir
+		projectConfig.GlobalCommands = claudeFlags.CommandsDir
+		if err := projectConfig.Save(projectDir); err != nil {
+			return err
+		}
 	}
 
 	// Install Claude Code integration in PROJECT directory (not globally)
 	log.Infof("Installing integration files to project directory: %s", projectClaudeDir)
-	if err := integration.InstallIntegration(); err != nil {
+	changed, err := integration.SyncIntegration(globalFlags.Force)
+	if err != nil {
 		return fmt.Errorf("integration installation failed: %w", err)
 	}
 
-	if !globalFlags.Quiet {
+	if upgrading && !globalFlags.Quiet {
+		reportIntegrationUpgrade(projectClaudeDir, changed)
+	}
+	if repo != nil {
+		if err := finishIntegrationGit(repo, projectDir, upgrading); err != nil {
+			return err
+		}
+	}
+	if upgrading {
+		return nil
+	}
+
+	if showDecorations() {
 		ui.DisplaySuccess("Project-level Claude Code integration installed!")
 
 		fmt.Printf("\n%sNext steps:%s\n", ui.ColorCyan, ui.ColorReset)
@@ -273,6 +392,39 @@ func installClaudeIntegration(integration *claude.ClaudeIntegration) error {
 	return nil
 }
 
+// describeIntegrationUpgrade logs how the recorded integration version
+// relates to the installed framework
+func describeIntegrationUpgrade(installed, framework string) {
+	log := logger.GetLogger()
+	switch {
+	case installed == "" || framework == "":
+		log.Info("Existing integration found, checking it for changes...")
+	case semver.Compare(installed, framework) < 0:
+		log.Infof("Upgrading integration from v%s to v%s in place...", installed, framework)
+	case semver.Compare(installed, framework) > 0:
+		log.Warnf("Integration v%s is newer than the framework v%s; aligning it with the framework", installed, framework)
+	default:
+		log.Infof("Integration v%s matches the framework, checking it for changes...", installed)
+	}
+}
+
+// reportIntegrationUpgrade shows which integration files an in-place
+// upgrade rewrote
+func reportIntegrationUpgrade(claudeDir string, changed []string) {
+	if len(changed) == 0 {
+		ui.DisplaySuccess("Project integration is already up to date; nothing changed")
+		return
+	}
+	ui.DisplaySuccess(fmt.Sprintf("Project integration upgraded: %d file(s) updated", len(changed)))
+	for _, path := range changed {
+		if rel, err := filepath.Rel(claudeDir, path); err == nil {
+			path = rel
+		}
+		fmt.Printf("  %s %s\n", ui.Icons.Success, path)
+	}
+	fmt.Println("Project agents and customizations were left unchanged.")
+}
+
 func uninstallClaudeIntegration(integration *claude.ClaudeIntegration) error {
 	log := logger.GetLogger()
 
@@ -293,7 +445,9 @@ func uninstallClaudeIntegration(integration *claude.ClaudeIntegration) error {
 	}
 
 	// Confirm uninstallation
-	if !globalFlags.Yes && !ui.Confirm("Remove Claude Code integration for this project?", false) {
+	if ok, err := confirmAction("Remove Claude Code integration for this project?", false); err != nil {
+		return err
+	} else if !ok {
 		log.Info("Uninstallation cancelled")
 		return nil
 	}
@@ -314,7 +468,7 @@ func uninstallClaudeIntegration(integration *claude.ClaudeIntegration) error {
 		}
 	}
 
-	if !globalFlags.Quiet {
+	if showDecorations() {
 		ui.DisplaySuccess("Project-level Claude Code integration removed!")
 		fmt.Printf("Project: %s\n", projectDir)
 	}
@@ -337,11 +491,11 @@ func showClaudeStatus(integration *claude.ClaudeIntegration) error {
 	// Check global framework installation
 	globalInstalled := isFrameworkInstalled()
 	if globalInstalled {
-		fmt.Printf("%s✅ Global Framework Installed%s\n", ui.ColorGreen, ui.ColorReset)
+		fmt.Printf("%s%s Global Framework Installed%s\n", ui.ColorGreen, ui.Icons.Success, ui.ColorReset)
 		// Show command count from the integration status check, which correctly reads the global command directory.
 		fmt.Printf("%sGlobal Commands:%s %d available\n", ui.ColorBlue, ui.ColorReset, status.CommandCount)
 	} else {
-		fmt.Printf("%s❌ Global Framework Not Installed%s\n", ui.ColorRed, ui.ColorReset)
+		fmt.Printf("%s%s Global Framework Not Installed%s\n", ui.ColorRed, ui.Icons.Failure, ui.ColorReset)
 	}
 
 	// Check project-level integration
@@ -349,16 +503,16 @@ func showClaudeStatus(integration *claude.ClaudeIntegration) error {
 
 	// Check for project .claude directory using configured project path
 	projectClaudeDir := claudeFlags.ClaudeDir
-	projectAgentsDir := filepath.Join(projectClaudeDir, "agents")
+	projectAgentsDir := filepath.Join(projectClaudeDir, pathsutil.AgentsDirName)
 	projectIntegrated := false
 	if _, err := os.Stat(projectAgentsDir); err == nil {
 		projectIntegrated = true
-		fmt.Printf("%s✅ Project Integration Active%s\n", ui.ColorGreen, ui.ColorReset)
+		fmt.Printf("%s%s Project Integration Active%s\n", ui.ColorGreen, ui.Icons.Success, ui.ColorReset)
 
 		// Check for orchestrator
-		orchestratorPath := filepath.Join(projectAgentsDir, "orchestrator-specialist.md")
+		orchestratorPath := filepath.Join(projectAgentsDir, pathsutil.OrchestratorFile)
 		if _, err := os.Stat(orchestratorPath); err == nil {
-			fmt.Printf("%s✅ Orchestrator Installed%s\n", ui.ColorGreen, ui.ColorReset)
+			fmt.Printf("%s%s Orchestrator Installed%s\n", ui.ColorGreen, ui.Icons.Success, ui.ColorReset)
 		}
 
 		// Count project specialists
@@ -366,7 +520,7 @@ func showClaudeStatus(integration *claude.ClaudeIntegration) error {
 			specialistCount := 0
 			for _, entry := range entries {
 				// Exclude the main orchestrator from the count of custom specialists
-				if strings.HasSuffix(entry.Name(), "-specialist.md") && entry.Name() != "orchestrator-specialist.md" {
+				if strings.HasSuffix(entry.Name(), "-specialist.md") && entry.Name() != pathsutil.OrchestratorFile {
 					specialistCount++
 				}
 			}
@@ -376,26 +530,26 @@ func showClaudeStatus(integration *claude.ClaudeIntegration) error {
 		// Show project integration path
 		fmt.Printf("%sProject Path:%s %s\n", ui.ColorBlue, ui.ColorReset, projectClaudeDir)
 	} else {
-		fmt.Printf("%s❌ Project Not Integrated%s\n", ui.ColorRed, ui.ColorReset)
+		fmt.Printf("%s%s Project Not Integrated%s\n", ui.ColorRed, ui.Icons.Failure, ui.ColorReset)
 	}
 
 	// Claude integration status
 	if status.Installed {
-		fmt.Printf("\n%s✅ Claude Code Integration Active%s", ui.ColorGreen, ui.ColorReset)
+		fmt.Printf("\n%s%s Claude Code Integration Active%s", ui.ColorGreen, ui.Icons.Success, ui.ColorReset)
 		if status.Version != "" {
 			fmt.Printf(" (v%s)", status.Version)
 		}
 		fmt.Println()
 		fmt.Printf("%sCommands Available:%s %d\n", ui.ColorBlue, ui.ColorReset, status.CommandCount)
 	} else {
-		fmt.Printf("\n%s❌ Claude Code Integration Not Active%s\n", ui.ColorRed, ui.ColorReset)
+		fmt.Printf("\n%s%s Claude Code Integration Not Active%s\n", ui.ColorRed, ui.Icons.Failure, ui.ColorReset)
 	}
 
 	// Issues and recommendations
 	if len(status.Issues) > 0 {
 		fmt.Printf("\n%sIssues Found:%s\n", ui.ColorYellow, ui.ColorReset)
 		for _, issue := range status.Issues {
-			fmt.Printf("  ⚠️  %s\n", issue)
+			fmt.Printf("  %s %s\n", ui.Icons.Warning, issue)
 		}
 	}
 
@@ -425,21 +579,10 @@ func updateClaudeIntegration(integration *claude.ClaudeIntegration) error {
 
 	if !status.Installed {
 		log.Info("Integration not installed, performing fresh installation")
-		return installClaudeIntegration(integration)
 	}
 
-	log.Info("Updating Claude Code integration...")
-
-	// Update integration
-	if err := integration.UpdateIntegration(); err != nil {
-		return fmt.Errorf("update failed: %w", err)
-	}
-
-	if !globalFlags.Quiet {
-		ui.DisplaySuccess("Claude Code integration updated successfully!")
-	}
-
-	return nil
+	// Updating is the in-place upgrade install performs
+	return installClaudeIntegration(integration)
 }
 
 func listClaudeCommands(integration *claude.ClaudeIntegration) error {
@@ -471,6 +614,8 @@ func listClaudeCommands(integration *claude.ClaudeIntegration) error {
 	fmt.Printf("\n%sTotal: %d commands%s\n", ui.ColorBlue, len(commands), ui.ColorReset)
 
 	if globalFlags.Verbose {
+		displayWaveCommands(commands)
+
 		fmt.Printf("\n%sUsage:%s\n", ui.ColorCyan, ui.ColorReset)
 		fmt.Println("  Type '/crew:' in Claude Code and press Tab to see completions")
 		fmt.Println("  Use '/crew:help' for detailed command documentation")
@@ -479,6 +624,28 @@ func listClaudeCommands(integration *claude.ClaudeIntegration) error {
 	return nil
 }
 
+// displayWaveCommands lists the stages, checkpoints and gates of the
+// wave-enabled commands
+func displayWaveCommands(commands []*claude.SlashCommand) {
+	var waves []*claude.SlashCommand
+	for _, cmd := range commands {
+		if cmd.Wave != nil {
+			waves = append(waves, cmd)
+		}
+	}
+	if len(waves) == 0 {
+		return
+	}
+
+	fmt.Printf("\n%sWave-enabled commands%s (* checkpoint):\n", ui.ColorCyan, ui.ColorReset)
+	for _, cmd := range waves {
+		fmt.Printf("  /crew:%-14s %s [%s]\n", cmd.Name, cmd.Wave.Summary(ui.Icons.Arrow), cmd.Wave.Strategy)
+		if len(cmd.Wave.Gates) > 0 {
+			fmt.Printf("  %-20s gates: %s\n", "", strings.Join(cmd.Wave.Gates, "; "))
+		}
+	}
+}
+
 func testClaudeCommand(integration *claude.ClaudeIntegration, commandLine string) error {
 	log := logger.GetLogger()
 
@@ -495,7 +662,7 @@ func testClaudeCommand(integration *claude.ClaudeIntegration, commandLine string
 
 		result := completionProvider.GetCompletions(commandLine)
 
-		fmt.Printf("%s[✓] Completion Test Results:%s\n", ui.ColorGreen, ui.ColorReset)
+		fmt.Printf("%s[%s] Completion Test Results:%s\n", ui.ColorGreen, ui.Icons.Check, ui.ColorReset)
 		fmt.Printf("Input: %s\n", result.Input)
 		fmt.Printf("Type: %s\n", result.Type)
 		fmt.Printf("Count: %d suggestions\n", result.Count)
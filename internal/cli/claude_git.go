@@ -0,0 +1,136 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/gitrepo"
+	"github.com/jonwraymond/claude-code-super-crew/internal/project"
+	"github.com/jonwraymond/claude-code-super-crew/internal/ui"
+	"github.com/jonwraymond/claude-code-super-crew/pkg/logger"
+)
+
+// generatedProjectFiles are the project integration files crew regenerates
+// on every install, relative to the project: the .crew directory with
+// completions, prompts and caches, the integration config, the project
+// config with its absolute path, and Claude's personal settings. Agents and
+// commands stay tracked so the team shares them.
+var generatedProjectFiles = []string{
+	".claude/.crew/",
+	".claude/" + project.IntegrationFile,
+	".claude/" + project.ConfigFile,
+	".claude/settings.local.json",
+}
+
+// prepareIntegrationGit finds the repository the project is in, warns about
+// uncommitted changes and creates the branch asked for with --git-branch.
+// It returns nil when the project is not in a repository.
+func prepareIntegrationGit(projectDir string) (*gitrepo.Repo, error) {
+	log := logger.GetLogger()
+
+	repo, err := gitrepo.Open(projectDir)
+	if errors.Is(err, gitrepo.ErrNotRepo) {
+		if claudeFlags.GitBranch != "" || claudeFlags.GitCommit {
+			return nil, fmt.Errorf("--git-branch and --git-commit need %s to be in a git repository", projectDir)
+		}
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := repo.Changes()
+	if err != nil {
+		return nil, err
+	}
+	if len(changes) > 0 {
+		log.Warnf("The working tree of %s has %d uncommitted changes", repo.Root, len(changes))
+		for i, change := range changes {
+			if i == 5 {
+				log.Warnf("  ... and %d more", len(changes)-i)
+				break
+			}
+			log.Warnf("  %s", change)
+		}
+		if claudeFlags.GitCommit && !globalFlags.Force {
+			return nil, fmt.Errorf("commit or stash your changes before using --git-commit, or pass --force")
+		}
+	}
+
+	if claudeFlags.GitBranch != "" && !globalFlags.DryRun {
+		if err := repo.CreateBranch(claudeFlags.GitBranch); err != nil {
+			return nil, err
+		}
+		log.Infof("Created and switched to branch %s", claudeFlags.GitBranch)
+	}
+	return repo, nil
+}
+
+// finishIntegrationGit offers to keep the generated integration files out
+// of git and commits the integration with --git-commit
+func finishIntegrationGit(repo *gitrepo.Repo, projectDir string, upgrading bool) error {
+	log := logger.GetLogger()
+
+	rel, err := repo.Rel(projectDir)
+	if err != nil {
+		return err
+	}
+	prefix := "/"
+	if rel != "." {
+		prefix += rel + "/"
+	}
+
+	if !claudeFlags.NoGitignore {
+		patterns := make([]string, len(generatedProjectFiles))
+		for i, file := range generatedProjectFiles {
+			patterns[i] = prefix + file
+		}
+		pending, err := repo.EnsureIgnored(patterns, true)
+		if err != nil {
+			return err
+		}
+		if pending && globalFlags.DryRun {
+			log.Info("[DRY RUN] Would add generated crew files to .gitignore")
+		} else if pending {
+			ok, err := confirmAction("Add the generated crew files to .gitignore (agents and commands stay tracked)?", true)
+			if err != nil {
+				return err
+			}
+			if ok {
+				if _, err := repo.EnsureIgnored(patterns, false); err != nil {
+					return fmt.Errorf("failed to update .gitignore: %w", err)
+				}
+				log.Infof("Updated %s", filepath.Join(repo.Root, ".gitignore"))
+			}
+		}
+	}
+
+	if !claudeFlags.GitCommit || globalFlags.DryRun {
+		return nil
+	}
+	paths := []string{path.Join(rel, ".claude")}
+	if _, err := os.Stat(filepath.Join(repo.Root, ".gitignore")); err == nil {
+		paths = append(paths, ".gitignore")
+	}
+	message := "Add crew project integration"
+	if upgrading {
+		message = "Update crew project integration"
+	}
+	committed, err := repo.Commit(message, paths...)
+	if err != nil {
+		return fmt.Errorf("failed to commit the integration: %w", err)
+	}
+	if committed {
+		branch := repo.Branch()
+		if branch == "" {
+			branch = "HEAD"
+		}
+		ui.DisplaySuccess(fmt.Sprintf("Committed the integration on %s: %s", branch, message))
+	} else {
+		log.Info("Nothing to commit; the integration is already committed")
+	}
+	return nil
+}
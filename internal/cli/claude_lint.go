@@ -0,0 +1,199 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/claudemd"
+	"github.com/jonwraymond/claude-code-super-crew/internal/core"
+	"github.com/jonwraymond/claude-code-super-crew/internal/ui"
+)
+
+// claudeLintReport is the lint result for one CLAUDE.md
+type claudeLintReport struct {
+	Scope    string             `json:"scope"`
+	Path     string             `json:"path"`
+	Found    bool               `json:"found"`
+	Fixed    bool               `json:"fixed,omitempty"`
+	Backup   string             `json:"backup,omitempty"`
+	Findings []claudemd.Finding `json:"findings"`
+}
+
+// claudeMDTarget is a CLAUDE.md crew manages sections in
+type claudeMDTarget struct{ scope, path string }
+
+// claudeMDTargets returns the global and project CLAUDE.md
+func claudeMDTargets(projectDir string) []claudeMDTarget {
+	return []claudeMDTarget{
+		{"global", filepath.Join(getGlobalInstallDir(), "CLAUDE.md")},
+		{"project", filepath.Join(projectDir, "CLAUDE.md")},
+	}
+}
+
+// lintClaudeMD checks the global and project CLAUDE.md files and, with
+// fix, rewrites them after keeping a backup of the original
+func lintClaudeMD(projectDir string, fix bool) error {
+	var reports []claudeLintReport
+	remaining, fixable, stale := 0, 0, false
+	for _, target := range claudeMDTargets(projectDir) {
+		report, err := lintClaudeFile(target.scope, target.path, fix)
+		if err != nil {
+			return err
+		}
+		for _, f := range report.Findings {
+			remaining++
+			if f.Fixable {
+				fixable++
+			}
+			if f.Kind == claudemd.FindingStaleVersion && !f.Fixable {
+				stale = true
+			}
+		}
+		reports = append(reports, report)
+	}
+
+	if globalFlags.Output == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(reports); err != nil {
+			return err
+		}
+	} else {
+		for _, report := range reports {
+			displayClaudeLintReport(report)
+		}
+		if fixable > 0 && !fix {
+			fmt.Printf("\n%s Run 'crew claude --lint-claude-md --fix' to repair %d of them\n", ui.Icons.Tip, fixable)
+		}
+		if stale {
+			fmt.Printf("%s Run 'crew update' to refresh a stale framework section\n", ui.Icons.Tip)
+		}
+	}
+
+	if remaining > 0 {
+		return fmt.Errorf("CLAUDE.md has %d problem(s)", remaining)
+	}
+	return nil
+}
+
+func lintClaudeFile(scope, path string, fix bool) (claudeLintReport, error) {
+	report := claudeLintReport{Scope: scope, Path: path, Findings: []claudemd.Finding{}}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return report, nil
+	}
+	if err != nil {
+		return report, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	report.Found = true
+
+	content := string(data)
+	if !fix {
+		report.Findings = append(report.Findings, claudemd.Lint(content, core.FrameworkVersion)...)
+		return report, nil
+	}
+
+	fixed, findings := claudemd.Fix(content, core.FrameworkVersion)
+	report.Findings = append(report.Findings, findings...)
+	if fixed == content {
+		return report, nil
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return report, fmt.Errorf("failed to stat %s: %w", path, err)
+	}
+	report.Backup = path + ".backup"
+	if err := os.WriteFile(report.Backup, data, info.Mode().Perm()); err != nil {
+		return report, fmt.Errorf("failed to back up %s: %w", path, err)
+	}
+	if err := os.WriteFile(path, []byte(fixed), info.Mode().Perm()); err != nil {
+		return report, fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	report.Fixed = true
+	return report, nil
+}
+
+func displayClaudeLintReport(report claudeLintReport) {
+	fmt.Printf("\n%s%s CLAUDE.md:%s %s\n", ui.ColorCyan, report.Scope, ui.ColorReset, report.Path)
+	if !report.Found {
+		fmt.Println("  not found")
+		return
+	}
+	if report.Fixed {
+		fmt.Printf("  %s%s Fixed%s (original saved to %s)\n", ui.ColorGreen, ui.Icons.Success, ui.ColorReset, report.Backup)
+	}
+	if len(report.Findings) == 0 {
+		fmt.Printf("  %s%s No problems%s\n", ui.ColorGreen, ui.Icons.Check, ui.ColorReset)
+		return
+	}
+	for _, f := range report.Findings {
+		color, note := ui.ColorRed, ""
+		if f.Fixable {
+			color, note = ui.ColorYellow, " (fixable)"
+		}
+		fmt.Printf("  %sline %d%s [%s] %s%s\n", color, f.Line, ui.ColorReset, f.Kind, f.Message, note)
+	}
+}
+
+// claudeMergeDump is how crew parses one CLAUDE.md
+type claudeMergeDump struct {
+	Scope    string             `json:"scope"`
+	Path     string             `json:"path"`
+	Found    bool               `json:"found"`
+	Sections []claudemd.Section `json:"sections"`
+	// Framework is the version of the framework section, if there is one
+	Framework string `json:"framework_version,omitempty"`
+	Dump      string `json:"dump,omitempty"`
+}
+
+// mergeDebugClaudeMD prints the blocks and managed sections of the global
+// and project CLAUDE.md as the framework merge sees them, to make a merge
+// that went wrong reviewable
+func mergeDebugClaudeMD(projectDir string) error {
+	var dumps []claudeMergeDump
+	for _, target := range claudeMDTargets(projectDir) {
+		dump := claudeMergeDump{Scope: target.scope, Path: target.path, Sections: []claudemd.Section{}}
+		data, err := os.ReadFile(target.path)
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to read %s: %w", target.path, err)
+		}
+		if err == nil {
+			dump.Found = true
+			dump.Dump = claudemd.Dump(string(data))
+			if doc, err := claudemd.Parse(claudemd.MigrateLegacy(string(data))); err == nil {
+				dump.Sections = append(dump.Sections, doc.Sections()...)
+				if section, ok := doc.Get(claudemd.SectionFramework); ok {
+					dump.Framework = section.Version
+				}
+			}
+		}
+		dumps = append(dumps, dump)
+	}
+
+	if globalFlags.Output == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(dumps)
+	}
+	for _, dump := range dumps {
+		fmt.Printf("\n%s%s CLAUDE.md:%s %s\n", ui.ColorCyan, dump.Scope, ui.ColorReset, dump.Path)
+		if !dump.Found {
+			fmt.Println("  not found")
+			continue
+		}
+		if dump.Framework != "" && dump.Framework != core.FrameworkVersion {
+			fmt.Printf("  %s%s framework section v%s, the next merge writes v%s%s\n",
+				ui.ColorYellow, ui.Icons.Warning, dump.Framework, core.FrameworkVersion, ui.ColorReset)
+		}
+		for _, line := range strings.SplitAfter(dump.Dump, "\n") {
+			if line != "" {
+				fmt.Print("  " + line)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/claudemd"
+	"github.com/jonwraymond/claude-code-super-crew/internal/core"
+	"github.com/jonwraymond/claude-code-super-crew/internal/textdiff"
+	"github.com/jonwraymond/claude-code-super-crew/internal/ui"
+)
+
+// claudePreviewContext is the number of unchanged lines shown around each
+// change
+const claudePreviewContext = 3
+
+// claudePreview holds what merging and overwriting would make of an
+// existing CLAUDE.md
+type claudePreview struct {
+	Path      string
+	Existing  string
+	Merged    string
+	MergeErr  error
+	Overwrite string
+}
+
+// newClaudePreview computes both outcomes for the framework CLAUDE.md at
+// srcFile and the installed one at dstFile, without writing anything
+func newClaudePreview(srcFile, dstFile string) (*claudePreview, error) {
+	src, err := os.ReadFile(srcFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read framework CLAUDE.md: %w", err)
+	}
+	existing, err := os.ReadFile(dstFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read existing CLAUDE.md: %w", err)
+	}
+
+	p := &claudePreview{Path: dstFile, Existing: string(existing), Overwrite: string(src)}
+	// Same merge the core component performs
+	p.Merged, p.MergeErr = claudemd.MergeFramework(p.Existing, p.Overwrite, core.FrameworkVersion)
+	return p, nil
+}
+
+// frameworkCLAUDESource returns the framework CLAUDE.md in the SuperCrew
+// source directory
+func frameworkCLAUDESource(superCrewSource string) (string, error) {
+	for _, dir := range []string{"Core", "core"} {
+		path := filepath.Join(superCrewSource, dir, "CLAUDE.md")
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("framework CLAUDE.md not found in %s", superCrewSource)
+}
+
+// display prints the merge diff and, with full, the overwrite diff too;
+// otherwise overwrite is summarized
+func (p *claudePreview) display(full bool) {
+	fmt.Printf("\n%sCLAUDE.md preview:%s %s\n", ui.ColorCyan, ui.ColorReset, p.Path)
+
+	fmt.Printf("\n%sMerge%s", ui.ColorBright, ui.ColorReset)
+	if p.MergeErr != nil {
+		fmt.Printf("\n  %s%s Not possible: %v%s\n", ui.ColorRed, ui.Icons.Failure, p.MergeErr, ui.ColorReset)
+		fmt.Println("  Run 'crew claude --lint-claude-md --fix' to repair the file first")
+	} else {
+		printClaudeDiff(p.Existing, p.Merged)
+	}
+
+	fmt.Printf("\n%sOverwrite%s", ui.ColorBright, ui.ColorReset)
+	if full {
+		printClaudeDiff(p.Existing, p.Overwrite)
+		return
+	}
+	stats := textdiff.Count(textdiff.Lines(p.Existing, p.Overwrite))
+	fmt.Printf(" %s\n", diffSummary(stats))
+	if stats.Removed > 0 {
+		fmt.Printf("  %s%s %d line(s) of the current file would be lost%s\n",
+			ui.ColorYellow, ui.Icons.Warning, stats.Removed, ui.ColorReset)
+	}
+}
+
+// printClaudeDiff prints a summary line followed by the colored diff
+func printClaudeDiff(from, to string) {
+	diff := textdiff.Unified("CLAUDE.md (current)", "CLAUDE.md (result)", from, to, claudePreviewContext)
+	stats := textdiff.Count(textdiff.Lines(from, to))
+	fmt.Printf(" %s\n", diffSummary(stats))
+	printColoredDiff(diff)
+}
+
+// printColoredDiff prints a unified diff indented, with added and removed
+// lines colored
+func printColoredDiff(diff string) {
+	if diff == "" {
+		return
+	}
+	for _, line := range strings.Split(strings.TrimSuffix(diff, "\n"), "\n") {
+		switch {
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+			fmt.Printf("  %s%s%s\n", ui.ColorBright, line, ui.ColorReset)
+		case strings.HasPrefix(line, "@@"):
+			fmt.Printf("  %s%s%s\n", ui.ColorCyan, line, ui.ColorReset)
+		case strings.HasPrefix(line, "+"):
+			fmt.Printf("  %s%s%s\n", ui.ColorGreen, line, ui.ColorReset)
+		case strings.HasPrefix(line, "-"):
+			fmt.Printf("  %s%s%s\n", ui.ColorRed, line, ui.ColorReset)
+		default:
+			fmt.Printf("  %s\n", line)
+		}
+	}
+}
+
+func diffSummary(stats textdiff.Stats) string {
+	if stats.Added == 0 && stats.Removed == 0 {
+		return "(no changes)"
+	}
+	return fmt.Sprintf("(%s+%d%s / %s-%d%s lines)",
+		ui.ColorGreen, stats.Added, ui.ColorReset, ui.ColorRed, stats.Removed, ui.ColorReset)
+}
+
+// showClaudePreview prints the preview for the CLAUDE.md in installDir
+func showClaudePreview(installDir string, full bool) error {
+	dstFile := filepath.Join(installDir, "CLAUDE.md")
+	if _, err := os.Stat(dstFile); os.IsNotExist(err) {
+		fmt.Printf("No CLAUDE.md in %s; the framework version will be installed as is\n", installDir)
+		return nil
+	}
+
+	srcFile, err := frameworkCLAUDESource(findSuperCrewSource())
+	if err != nil {
+		return err
+	}
+	preview, err := newClaudePreview(srcFile, dstFile)
+	if err != nil {
+		return err
+	}
+	preview.display(full)
+	return nil
+}
@@ -0,0 +1,38 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNewClaudePreview(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "framework.md")
+	dst := filepath.Join(dir, "CLAUDE.md")
+	if err := os.WriteFile(src, []byte("# SuperCrew\nrules\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(dst, []byte("# Mine\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	preview, err := newClaudePreview(src, dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if preview.MergeErr != nil || !strings.HasSuffix(preview.Merged, "\n\n# Mine\n") || preview.Overwrite != "# SuperCrew\nrules\n" {
+		t.Errorf("Unexpected preview %+v", preview)
+	}
+	if data, _ := os.ReadFile(dst); string(data) != "# Mine\n" {
+		t.Errorf("Expected the preview not to write, got %q", data)
+	}
+
+	if err := os.WriteFile(dst, []byte("<!-- END crew:framework -->\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if preview, err := newClaudePreview(src, dst); err != nil || preview.MergeErr == nil {
+		t.Errorf("Expected broken anchors to be reported as a merge error, got %v", err)
+	}
+}
@@ -0,0 +1,106 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/claude"
+	"github.com/jonwraymond/claude-code-super-crew/internal/ui"
+)
+
+// runClaudeSlashCommand renders a /crew: command line to its Task
+// invocation and prints, copies or saves it
+func runClaudeSlashCommand(integration *claude.ClaudeIntegration, commandLine string) error {
+	rendered, err := integration.RenderSlashCommand(commandLine)
+	if err != nil {
+		return err
+	}
+	task := rendered.Task()
+
+	var savedTo string
+	if claudeFlags.SavePrompt {
+		if savedTo, err = savePromptFile(claudeFlags.ClaudeDir, rendered); err != nil {
+			return err
+		}
+	}
+	if claudeFlags.Copy {
+		if err := copyToClipboard(task); err != nil {
+			return err
+		}
+	}
+
+	if globalFlags.Output == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(struct {
+			*claude.RenderedPrompt
+			Task       string `json:"task"`
+			PromptFile string `json:"prompt_file,omitempty"`
+			Copied     bool   `json:"copied,omitempty"`
+		}{rendered, task, savedTo, claudeFlags.Copy})
+	}
+
+	if !claudeFlags.Copy && !claudeFlags.SavePrompt {
+		fmt.Println(task)
+		return nil
+	}
+	if savedTo != "" {
+		ui.DisplaySuccess(fmt.Sprintf("Saved %s to %s", rendered.CommandLine, savedTo))
+	}
+	if claudeFlags.Copy {
+		ui.DisplaySuccess(fmt.Sprintf("Copied %s to the clipboard", rendered.CommandLine))
+	}
+	fmt.Printf("%sPaste it into Claude Code to run the command%s\n", ui.ColorBlue, ui.ColorReset)
+	return nil
+}
+
+// savePromptFile writes the invocation to claudeDir/prompts, named after the
+// command and the time, and returns the path
+func savePromptFile(claudeDir string, rendered *claude.RenderedPrompt) (string, error) {
+	dir := filepath.Join(claudeDir, "prompts")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create prompts directory: %w", err)
+	}
+	path := filepath.Join(dir, fmt.Sprintf("crew-%s-%s.md", rendered.Command, time.Now().Format("20060102-150405")))
+	content := fmt.Sprintf("<!-- %s -->\n%s\n", rendered.CommandLine, rendered.Task())
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return "", fmt.Errorf("failed to write prompt file: %w", err)
+	}
+	return path, nil
+}
+
+// clipboardCommands are the tools tried, in order, to copy text on each OS
+var clipboardCommands = map[string][][]string{
+	"darwin":  {{"pbcopy"}},
+	"windows": {{"clip"}},
+	"linux":   {{"wl-copy"}, {"xclip", "-selection", "clipboard"}, {"xsel", "--clipboard", "--input"}},
+}
+
+// copyToClipboard pipes text into the first clipboard tool available
+func copyToClipboard(text string) error {
+	candidates := clipboardCommands[runtime.GOOS]
+	if candidates == nil {
+		candidates = clipboardCommands["linux"]
+	}
+	var tried []string
+	for _, candidate := range candidates {
+		tried = append(tried, candidate[0])
+		path, err := exec.LookPath(candidate[0])
+		if err != nil {
+			continue
+		}
+		copy := exec.Command(path, candidate[1:]...)
+		copy.Stdin = strings.NewReader(text)
+		if output, err := copy.CombinedOutput(); err != nil {
+			return fmt.Errorf("%s failed: %w: %s", candidate[0], err, strings.TrimSpace(string(output)))
+		}
+		return nil
+	}
+	return fmt.Errorf("no clipboard tool found (tried %s); use --save-prompt instead", strings.Join(tried, ", "))
+}
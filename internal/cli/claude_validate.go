@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/agents"
+	"github.com/jonwraymond/claude-code-super-crew/internal/ui"
+)
+
+// validateAgents checks the global agents and those in claudeDir/agents
+// against the subagent frontmatter Claude Code accepts
+func validateAgents(claudeDir string) error {
+	globalDir := filepath.Join(getGlobalInstallDir(), "agents")
+	projectDir := filepath.Join(claudeDir, "agents")
+	issues := agents.Validate(globalDir, projectDir)
+
+	errorCount := 0
+	for _, issue := range issues {
+		if issue.Severity == agents.SeverityError {
+			errorCount++
+		}
+	}
+
+	if globalFlags.Output == "json" {
+		if issues == nil {
+			issues = []agents.Issue{}
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(issues); err != nil {
+			return err
+		}
+	} else {
+		displayAgentIssues(globalDir, projectDir, issues)
+	}
+
+	if errorCount > 0 {
+		return fmt.Errorf("agent definitions have %d error(s)", errorCount)
+	}
+	return nil
+}
+
+func displayAgentIssues(globalDir, projectDir string, issues []agents.Issue) {
+	fmt.Printf("\n%s%sAgent Validation%s\n", ui.ColorCyan, ui.ColorBright, ui.ColorReset)
+	fmt.Printf("  global:  %s\n  project: %s\n\n", globalDir, projectDir)
+	if len(issues) == 0 {
+		fmt.Printf("%s%s All agents are valid%s\n", ui.ColorGreen, ui.Icons.Check, ui.ColorReset)
+		return
+	}
+
+	for _, issue := range issues {
+		color, icon := ui.ColorRed, ui.Icons.Failure
+		if issue.Severity == agents.SeverityWarning {
+			color, icon = ui.ColorYellow, ui.Icons.Warning
+		}
+		fmt.Printf("%s%s %s%s: %s\n", color, icon, issue.Path, ui.ColorReset, issue.Message)
+	}
+	fmt.Println()
+}
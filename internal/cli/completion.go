@@ -0,0 +1,297 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/ui"
+	"github.com/jonwraymond/claude-code-super-crew/pkg/logger"
+	"github.com/spf13/cobra"
+)
+
+// CompletionInstallFlags holds completion install flags
+type CompletionInstallFlags struct {
+	Uninstall bool
+	Dir       string
+	RCFile    string
+}
+
+// Markers around the block that completion install adds to a shell rc file
+// when the shell does not load completions from a directory by itself
+const (
+	completionBeginMarker = "# >>> crew completion >>>"
+	completionEndMarker   = "# <<< crew completion <<<"
+)
+
+// completionTarget is where the completion script for a shell is installed
+type completionTarget struct {
+	Shell  string
+	File   string
+	RCFile string // empty when the shell picks up File without help
+	RCLine string
+}
+
+// NewCompletionCommand creates the completion command. It replaces cobra's
+// default completion command so that it can offer install.
+func NewCompletionCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "completion [bash|zsh|fish|powershell]",
+		Short: "Generate or install shell completion scripts",
+		Long: `Print the tab completion script for a shell, or install it with
+'crew completion install'.
+
+Examples:
+  crew completion bash > /etc/bash_completion.d/crew   # Print the script
+  crew completion install                              # Install for $SHELL
+  crew completion install zsh                          # Install for zsh
+  crew completion install --uninstall                  # Remove it again`,
+		ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
+		Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+		DisableFlagsInUseLine: true,
+		// Runs at every shell start through shellenv
+		Annotations: map[string]string{skipFileLogAnnotation: "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return writeCompletionScript(cmd.Root(), args[0], cmd.OutOrStdout())
+		},
+	}
+
+	cmd.AddCommand(newCompletionInstallCommand())
+
+	return cmd
+}
+
+func newCompletionInstallCommand() *cobra.Command {
+	var flags CompletionInstallFlags
+
+	cmd := &cobra.Command{
+		Use:   "install [bash|zsh|fish]",
+		Short: "Install the completion script where your shell finds it",
+		Long: `Write the completion script to the completion directory for your shell
+and operating system, and update the shell rc file when the shell does
+not load that directory by itself.
+
+Default locations:
+  bash    $XDG_DATA_HOME/bash-completion/completions/crew (bash-completion)
+          $HOMEBREW_PREFIX/etc/bash_completion.d/crew on macOS with Homebrew
+  zsh     ~/.zsh/completions/_crew, added to fpath in ~/.zshrc
+  fish    ~/.config/fish/completions/crew.fish
+
+The shell defaults to $SHELL. Run again after upgrading crew to refresh
+the script, or with --uninstall to remove it.`,
+		ValidArgs: []string{"bash", "zsh", "fish"},
+		Args:      cobra.MatchAll(cobra.MaximumNArgs(1), cobra.OnlyValidArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			shell := ""
+			if len(args) > 0 {
+				shell = args[0]
+			}
+			return runCompletionInstall(cmd, shell, flags)
+		},
+	}
+
+	cmd.Flags().BoolVar(&flags.Uninstall, "uninstall", false, "Remove the installed completion script and rc file changes")
+	cmd.Flags().StringVar(&flags.Dir, "dir", "", "Completion directory to use instead of the detected one")
+	cmd.Flags().StringVar(&flags.RCFile, "rc-file", "", "Shell rc file to update (default depends on the shell)")
+
+	return cmd
+}
+
+// writeCompletionScript writes the completion script for shell to w
+func writeCompletionScript(root *cobra.Command, shell string, w io.Writer) error {
+	switch shell {
+	case "bash":
+		return root.GenBashCompletionV2(w, true)
+	case "zsh":
+		return root.GenZshCompletion(w)
+	case "fish":
+		return root.GenFishCompletion(w, true)
+	case "powershell":
+		return root.GenPowerShellCompletionWithDesc(w)
+	default:
+		return fmt.Errorf("unsupported shell %q", shell)
+	}
+}
+
+func runCompletionInstall(cmd *cobra.Command, shell string, flags CompletionInstallFlags) error {
+	shell, err := resolveShell(shell)
+	if err != nil {
+		return err
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return fmt.Errorf("failed to get home directory: %w", err)
+	}
+	target, err := detectCompletionTarget(shell, runtime.GOOS, home, os.Getenv, flags)
+	if err != nil {
+		return err
+	}
+
+	if flags.Uninstall {
+		return uninstallCompletion(target)
+	}
+
+	var script bytes.Buffer
+	if err := writeCompletionScript(cmd.Root(), shell, &script); err != nil {
+		return fmt.Errorf("failed to generate %s completion: %w", shell, err)
+	}
+	return installCompletion(target, script.Bytes())
+}
+
+// detectCompletionTarget picks the completion file and, when needed, the rc
+// file change for shell on goos
+func detectCompletionTarget(shell, goos, home string, getenv func(string) string, flags CompletionInstallFlags) (completionTarget, error) {
+	target := completionTarget{Shell: shell}
+
+	dataHome := getenv("XDG_DATA_HOME")
+	if dataHome == "" {
+		dataHome = filepath.Join(home, ".local", "share")
+	}
+
+	dir := flags.Dir
+	switch shell {
+	case "bash":
+		if dir == "" {
+			if prefix := getenv("HOMEBREW_PREFIX"); goos == "darwin" && prefix != "" {
+				dir = filepath.Join(prefix, "etc", "bash_completion.d")
+			} else {
+				dir = filepath.Join(dataHome, "bash-completion", "completions")
+			}
+		}
+		target.File = filepath.Join(dir, "crew")
+		// Without bash-completion (the macOS default) nothing reads the
+		// directory, so source the script from the rc file instead
+		if goos == "darwin" || flags.Dir != "" {
+			target.RCFile = filepath.Join(home, ".bash_profile")
+			target.RCLine = fmt.Sprintf("[ -r %s ] && . %s", shellQuote(target.File), shellQuote(target.File))
+		}
+	case "zsh":
+		if dir == "" {
+			dir = filepath.Join(home, ".zsh", "completions")
+		}
+		target.File = filepath.Join(dir, "_crew")
+		target.RCFile = filepath.Join(home, ".zshrc")
+		if zdot := getenv("ZDOTDIR"); zdot != "" {
+			target.RCFile = filepath.Join(zdot, ".zshrc")
+		}
+		target.RCLine = fmt.Sprintf("fpath=(%s $fpath)\nautoload -Uz compinit && compinit", shellQuote(dir))
+	case "fish":
+		if dir == "" {
+			config := getenv("XDG_CONFIG_HOME")
+			if config == "" {
+				config = filepath.Join(home, ".config")
+			}
+			dir = filepath.Join(config, "fish", "completions")
+		}
+		target.File = filepath.Join(dir, "crew.fish")
+	default:
+		return target, fmt.Errorf("unsupported shell %q: must be bash, zsh or fish", shell)
+	}
+
+	if flags.RCFile != "" && target.RCLine != "" {
+		target.RCFile = flags.RCFile
+	}
+	return target, nil
+}
+
+// completionBlock is the marked rc file block for target
+func completionBlock(target completionTarget) string {
+	return completionBeginMarker + "\n" + target.RCLine + "\n" + completionEndMarker + "\n"
+}
+
+func installCompletion(target completionTarget, script []byte) error {
+	log := logger.GetLogger()
+
+	if globalFlags.DryRun {
+		log.Infof("[DRY RUN] Would write %s completion to %s", target.Shell, target.File)
+		if target.RCFile != "" {
+			log.Infof("[DRY RUN] Would add to %s:\n%s", target.RCFile, completionBlock(target))
+		}
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(target.File), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(target.File), err)
+	}
+	if err := os.WriteFile(target.File, script, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", target.File, err)
+	}
+	ui.DisplaySuccess(fmt.Sprintf("Installed %s completion to %s", target.Shell, target.File))
+
+	if target.RCFile != "" {
+		changed, err := updateRCFile(target.RCFile, func(content string) (string, bool) {
+			return replaceMarkedBlock(content, completionBeginMarker, completionEndMarker, completionBlock(target))
+		})
+		if err != nil {
+			return err
+		}
+		if changed {
+			ui.DisplaySuccess(fmt.Sprintf("Updated %s to load it", target.RCFile))
+		}
+	}
+
+	ui.DisplayInfo("Open a new shell to use tab completion")
+	return nil
+}
+
+func uninstallCompletion(target completionTarget) error {
+	log := logger.GetLogger()
+
+	if globalFlags.DryRun {
+		log.Infof("[DRY RUN] Would remove %s", target.File)
+		if target.RCFile != "" {
+			log.Infof("[DRY RUN] Would remove the crew completion block from %s", target.RCFile)
+		}
+		return nil
+	}
+
+	removed := false
+	if err := os.Remove(target.File); err == nil {
+		removed = true
+		ui.DisplaySuccess(fmt.Sprintf("Removed %s", target.File))
+	} else if !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove %s: %w", target.File, err)
+	}
+
+	if target.RCFile != "" {
+		changed, err := updateRCFile(target.RCFile, func(content string) (string, bool) {
+			return removeMarkedBlock(content, completionBeginMarker, completionEndMarker)
+		})
+		if err != nil {
+			return err
+		}
+		if changed {
+			removed = true
+			ui.DisplaySuccess(fmt.Sprintf("Removed crew completion from %s", target.RCFile))
+		}
+	}
+
+	if !removed {
+		log.Infof("No %s completion installed at %s", target.Shell, target.File)
+	}
+	return nil
+}
+
+// updateRCFile rewrites rcFile with edit, leaving it untouched when edit
+// makes no change. A missing rc file is treated as empty.
+func updateRCFile(rcFile string, edit func(string) (string, bool)) (bool, error) {
+	existing, err := os.ReadFile(rcFile)
+	if err != nil && !os.IsNotExist(err) {
+		return false, fmt.Errorf("failed to read %s: %w", rcFile, err)
+	}
+	updated, changed := edit(string(existing))
+	if !changed {
+		return false, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(rcFile), 0755); err != nil {
+		return false, fmt.Errorf("failed to create %s: %w", filepath.Dir(rcFile), err)
+	}
+	if err := os.WriteFile(rcFile, []byte(updated), 0644); err != nil {
+		return false, fmt.Errorf("failed to write %s: %w", rcFile, err)
+	}
+	return true, nil
+}
@@ -0,0 +1,259 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/core"
+	"github.com/jonwraymond/claude-code-super-crew/internal/crewdirs"
+	"github.com/jonwraymond/claude-code-super-crew/internal/hooks"
+	"github.com/jonwraymond/claude-code-super-crew/internal/managers"
+	"github.com/jonwraymond/claude-code-super-crew/internal/metadata"
+	"github.com/jonwraymond/claude-code-super-crew/pkg/backup"
+	"github.com/jonwraymond/claude-code-super-crew/pkg/logger"
+	"github.com/spf13/cobra"
+)
+
+// Completion functions run inside the shell's completion request, where
+// anything logged to stdout would be offered as a candidate. Each one
+// silences the console logger before touching the installation, and
+// returns no candidates rather than an error when something is missing.
+
+// completionFunc is the signature cobra uses for argument and flag completion
+type completionFunc func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective)
+
+// completeStatic offers a fixed list of values
+func completeStatic(values ...string) completionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return values, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completeList completes one element of a comma separated list flag such
+// as --components core,agents. Values already in the list are not offered
+// again.
+func completeList(values []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	prefix := ""
+	if i := strings.LastIndex(toComplete, ","); i >= 0 {
+		prefix = toComplete[:i+1]
+	}
+	used := make(map[string]bool)
+	for _, v := range strings.Split(prefix, ",") {
+		used[v] = true
+	}
+
+	var candidates []string
+	for _, v := range values {
+		if !used[v] {
+			candidates = append(candidates, prefix+v)
+		}
+	}
+	return candidates, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+}
+
+func silenceCompletionLogs() {
+	logger.GetLogger().SetQuiet(true)
+}
+
+// completionRegistry discovers components the same way install does
+func completionRegistry() (*core.EnhancedComponentRegistry, error) {
+	silenceCompletionLogs()
+	exe, _ := os.Executable()
+	projectRoot := filepath.Dir(filepath.Dir(filepath.Dir(exe)))
+
+	registry := core.NewEnhancedComponentRegistry(filepath.Join(projectRoot, "setup", "components"))
+	if err := discoverComponents(registry); err != nil {
+		return nil, err
+	}
+	return registry, nil
+}
+
+// availableComponentNames returns the components the installer can offer,
+// including experimental ones
+func availableComponentNames() []string {
+	registry, err := completionRegistry()
+	if err != nil {
+		return nil
+	}
+	return registry.FilterComponents(core.ComponentFilter{IncludeExperimental: true})
+}
+
+// componentTagsAndCategories returns the tags and categories of the
+// available components
+func componentTagsAndCategories() (tags, categories []string) {
+	registry, err := completionRegistry()
+	if err != nil {
+		return nil, nil
+	}
+
+	tagSet := make(map[string]bool)
+	categorySet := make(map[string]bool)
+	for _, name := range registry.ListComponents() {
+		meta := registry.GetComponentMetadata(name)
+		if meta == nil {
+			continue
+		}
+		if meta.Category != "" {
+			categorySet[meta.Category] = true
+		}
+		for _, tag := range meta.Tags {
+			tagSet[strings.ToLower(tag)] = true
+		}
+	}
+	return sortedKeys(tagSet), sortedKeys(categorySet)
+}
+
+// installedComponentNames returns the components recorded in the metadata
+func installedComponentNames() []string {
+	silenceCompletionLogs()
+	meta, err := metadata.NewMetadataManager(globalFlags.InstallDir).LoadMetadata()
+	if err != nil {
+		return nil
+	}
+	names := make(map[string]bool)
+	for name := range meta.Components {
+		names[name] = true
+	}
+	return sortedKeys(names)
+}
+
+func completeAvailableComponents(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return completeList(append(availableComponentNames(), componentGroups()...), toComplete)
+}
+
+// componentGroups returns the @group selectors --components accepts
+func componentGroups() []string {
+	tags, categories := componentTagsAndCategories()
+	groups := map[string]bool{"@" + core.GroupAll: true}
+	for _, name := range append(tags, categories...) {
+		groups["@"+name] = true
+	}
+	return sortedKeys(groups)
+}
+
+func completeInstalledComponents(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return completeList(installedComponentNames(), toComplete)
+}
+
+func completeComponentTags(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	tags, _ := componentTagsAndCategories()
+	return completeList(tags, toComplete)
+}
+
+func completeComponentCategories(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	_, categories := componentTagsAndCategories()
+	return categories, cobra.ShellCompDirectiveNoFileComp
+}
+
+func completeMCPServers(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	servers := make(map[string]bool)
+	for name := range core.NewMCPComponent().MCPServers {
+		servers[name] = true
+	}
+	return completeList(sortedKeys(servers), toComplete)
+}
+
+// completeBackupNames offers the backups in the backup directory by file
+// name, which is how --restore and --info resolve them
+func completeBackupNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	silenceCompletionLogs()
+	backups, err := backup.NewManager(backup.Options{BackupDir: getBackupDirectory()}).ListBackups()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	names := make([]string, 0, len(backups))
+	for _, b := range backups {
+		names = append(names, filepath.Base(b.Path))
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+func completeConfigProfiles(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	silenceCompletionLogs()
+	configManager, err := managers.NewConfigManager(crewdirs.Path(globalFlags.InstallDir, "config"), "")
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	profiles, err := configManager.ListConfigProfiles()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return profiles, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeHookNames offers lifecycle hooks and Claude Code hooks, the same
+// set --run resolves against
+func completeHookNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	silenceCompletionLogs()
+	names := make(map[string]bool)
+
+	runtime := hooks.NewRuntime(getLifecycleHooksDir())
+	if err := runtime.Load(); err == nil {
+		for _, hook := range runtime.Hooks() {
+			names[hook.Name] = true
+		}
+	}
+
+	projectRoot, err := findProjectRoot()
+	inProject := err == nil
+	if !inProject {
+		projectRoot, _ = os.UserHomeDir()
+	}
+	hm := hooks.NewHookManager(projectRoot)
+	hm.SetProjectOverlay(inProject)
+	if err := hm.DiscoverHooks(); err == nil {
+		for _, hook := range hm.ListHooks() {
+			names[hook.Name] = true
+		}
+	}
+
+	return sortedKeys(names), cobra.ShellCompDirectiveNoFileComp
+}
+
+func completeLifecycleEvents(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	events := make([]string, 0, len(hooks.LifecycleEvents))
+	for _, event := range hooks.LifecycleEvents {
+		events = append(events, string(event))
+	}
+	return events, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeTrackedDocuments offers the documents recorded in the metadata,
+// the targets update-document accepts
+func completeTrackedDocuments(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	silenceCompletionLogs()
+	meta, err := metadata.NewMetadataManager(globalFlags.InstallDir).LoadMetadata()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	docs := make(map[string]bool)
+	for path := range meta.Documents {
+		docs[path] = true
+	}
+	return sortedKeys(docs), cobra.ShellCompDirectiveNoFileComp
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// registerFlagCompletions attaches completion functions to cmd's flags by
+// name. A flag that does not exist is a programming error, so it is logged
+// rather than returned.
+func registerFlagCompletions(cmd *cobra.Command, funcs map[string]completionFunc) {
+	for name, fn := range funcs {
+		if err := cmd.RegisterFlagCompletionFunc(name, fn); err != nil {
+			logger.GetLogger().Debugf("Cannot register completion for --%s: %v", name, err)
+		}
+	}
+}
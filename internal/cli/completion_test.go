@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDetectCompletionTarget(t *testing.T) {
+	env := func(vars map[string]string) func(string) string {
+		return func(key string) string { return vars[key] }
+	}
+
+	tests := []struct {
+		name   string
+		shell  string
+		goos   string
+		vars   map[string]string
+		file   string
+		rcFile string
+	}{
+		{"bash linux", "bash", "linux", nil, "/home/u/.local/share/bash-completion/completions/crew", ""},
+		{"bash xdg", "bash", "linux", map[string]string{"XDG_DATA_HOME": "/data"}, "/data/bash-completion/completions/crew", ""},
+		{"bash homebrew", "bash", "darwin", map[string]string{"HOMEBREW_PREFIX": "/opt/homebrew"}, "/opt/homebrew/etc/bash_completion.d/crew", "/home/u/.bash_profile"},
+		{"zsh", "zsh", "linux", nil, "/home/u/.zsh/completions/_crew", "/home/u/.zshrc"},
+		{"zsh zdotdir", "zsh", "darwin", map[string]string{"ZDOTDIR": "/z"}, "/home/u/.zsh/completions/_crew", "/z/.zshrc"},
+		{"fish", "fish", "linux", nil, "/home/u/.config/fish/completions/crew.fish", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target, err := detectCompletionTarget(tt.shell, tt.goos, "/home/u", env(tt.vars), CompletionInstallFlags{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if target.File != filepath.FromSlash(tt.file) || target.RCFile != filepath.FromSlash(tt.rcFile) {
+				t.Errorf("Got file %q and rc file %q, expected %q and %q", target.File, target.RCFile, tt.file, tt.rcFile)
+			}
+		})
+	}
+
+	target, err := detectCompletionTarget("bash", "linux", "/home/u", env(nil), CompletionInstallFlags{Dir: "/custom", RCFile: "/home/u/.bashrc"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if target.File != filepath.FromSlash("/custom/crew") || target.RCFile != "/home/u/.bashrc" || !strings.Contains(target.RCLine, "/custom/crew") {
+		t.Errorf("Expected a custom directory to be sourced from the rc file, got %+v", target)
+	}
+}
+
+func TestRemoveMarkedBlock(t *testing.T) {
+	block := completionBlock(completionTarget{RCLine: "fpath=(~/.zsh/completions $fpath)"})
+
+	content, _ := replaceMarkedBlock("export EDITOR=vim\n", completionBeginMarker, completionEndMarker, block)
+	restored, changed := removeMarkedBlock(content+"alias ll='ls -l'\n", completionBeginMarker, completionEndMarker)
+	if !changed || restored != "export EDITOR=vim\nalias ll='ls -l'\n" {
+		t.Errorf("Unexpected content after removal: %q", restored)
+	}
+
+	if _, changed := removeMarkedBlock("export EDITOR=vim\n", completionBeginMarker, completionEndMarker); changed {
+		t.Error("Expected removing a missing block to be a no-op")
+	}
+}
+
+func TestCompleteList(t *testing.T) {
+	values := []string{"agents", "commands", "core"}
+
+	got, _ := completeList(values, "")
+	if strings.Join(got, " ") != "agents commands core" {
+		t.Errorf("Unexpected candidates for an empty list: %v", got)
+	}
+
+	got, _ = completeList(values, "core,ag")
+	if strings.Join(got, " ") != "core,agents core,commands" {
+		t.Errorf("Expected candidates to keep the list prefix and skip used values, got %v", got)
+	}
+}
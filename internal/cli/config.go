@@ -0,0 +1,209 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/crewdirs"
+	"github.com/jonwraymond/claude-code-super-crew/internal/fileops"
+	"github.com/jonwraymond/claude-code-super-crew/internal/managers"
+	"github.com/jonwraymond/claude-code-super-crew/internal/pathsutil"
+	"github.com/jonwraymond/claude-code-super-crew/internal/redact"
+	"github.com/jonwraymond/claude-code-super-crew/internal/retry"
+	"github.com/jonwraymond/claude-code-super-crew/pkg/logger"
+)
+
+// getCrewConfigDir returns the crew configuration directory for the install
+func getCrewConfigDir() string {
+	return crewdirs.Path(globalFlags.InstallDir, "config")
+}
+
+// loadCrewConfig loads the crew configuration, applying the profile selected
+// with --config-profile when one is given
+func loadCrewConfig() (*managers.ConfigManager, error) {
+	cm, err := managers.NewConfigManager(getCrewConfigDir(), "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load crew config: %w", err)
+	}
+
+	if globalFlags.ConfigProfile != "" {
+		if err := cm.ApplyConfigProfile(globalFlags.ConfigProfile); err != nil {
+			return nil, err
+		}
+	}
+
+	return cm, nil
+}
+
+// loadSettingsConfig loads the config the CLI settings are applied from,
+// once per command: the crew config with the profile selected with
+// --config-profile applied. Without a profile it returns nil when there is
+// no config yet, since loading it creates it. A config that cannot be read
+// or parsed is an error.
+func loadSettingsConfig() (*managers.ConfigManager, error) {
+	if globalFlags.ConfigProfile != "" {
+		return loadCrewConfig()
+	}
+	dir := getCrewConfigDir()
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read crew config in %s: %w", dir, err)
+	}
+	cm, err := managers.NewConfigManager(dir, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load crew config in %s: %w", dir, err)
+	}
+	return cm, nil
+}
+
+// applyConfigProfile applies the log level of the selected config profile.
+// Explicit --verbose or --quiet win over it.
+func applyConfigProfile(cm *managers.ConfigManager) {
+	if cm == nil || globalFlags.ConfigProfile == "" || globalFlags.Verbose || globalFlags.Quiet {
+		return
+	}
+
+	level, err := cm.GetString("log_level")
+	if err != nil {
+		level, err = cm.GetString("settings.log_level")
+	}
+	if err == nil && level != "" {
+		logger.GetLogger().SetLevel(logger.ParseLogLevel(level))
+	}
+}
+
+// ioMemoryKey caps, in megabytes, the memory the copy and hash buffers of
+// installs, backups and integrity checks hold at once
+const ioMemoryKey = "settings.io_max_memory_mb"
+
+// applyIOLimit applies settings.io_max_memory_mb from cm, which is nil
+// when there is no config
+func applyIOLimit(cm *managers.ConfigManager) {
+	if cm == nil {
+		return
+	}
+	if mb, err := cm.GetInt(ioMemoryKey); err == nil && mb > 0 {
+		fileops.SetMemoryLimit(int64(mb) << 20)
+	}
+}
+
+// retryKey is the config key of one retry setting of an operation, e.g.
+// settings.retry.network.attempts. Each operation takes attempts,
+// initial_ms and max_ms.
+func retryKey(op, setting string) string {
+	return "settings.retry." + op + "." + setting
+}
+
+// applyRetrySettings applies the per-operation overrides under
+// settings.retry in cm to the built-in retry policies
+func applyRetrySettings(cm *managers.ConfigManager) {
+	if cm == nil {
+		return
+	}
+	for _, op := range retry.Operations {
+		policy := retry.For(op)
+		if n, err := cm.GetInt(retryKey(op, "attempts")); err == nil && n > 0 {
+			policy.Attempts = n
+		}
+		if ms, err := cm.GetInt(retryKey(op, "initial_ms")); err == nil && ms >= 0 {
+			policy.Initial = time.Duration(ms) * time.Millisecond
+		}
+		if ms, err := cm.GetInt(retryKey(op, "max_ms")); err == nil && ms >= 0 {
+			policy.Max = time.Duration(ms) * time.Millisecond
+		}
+		retry.Set(op, policy)
+	}
+}
+
+// Redaction settings: extra names and regular expressions to hide, and
+// whether log files are redacted too
+const (
+	redactProjectsKey = "settings.redact.projects"
+	redactPatternsKey = "settings.redact.patterns"
+	redactLogFilesKey = "settings.redact.log_files"
+)
+
+// applyRedactionSettings sets up the redactor diagnostic bundles, log files
+// and telemetry pass through. Besides the home directory, user name and
+// credentials it hides the current project's name and what
+// settings.redact in cm lists.
+func applyRedactionSettings(cm *managers.ConfigManager) {
+	opts := redact.Options{}
+	opts.Home, _ = os.UserHomeDir()
+	if wd, err := os.Getwd(); err == nil {
+		if root, ok := pathsutil.FindProject(wd); ok {
+			opts.Projects = append(opts.Projects, filepath.Base(root))
+		}
+	}
+
+	logFiles := true
+	if cm != nil {
+		opts.Projects = append(opts.Projects, configStrings(cm, redactProjectsKey)...)
+		opts.Patterns = configStrings(cm, redactPatternsKey)
+		if enabled, err := cm.GetBool(redactLogFilesKey); err == nil {
+			logFiles = enabled
+		}
+	}
+
+	redactor, err := redact.New(opts)
+	if err != nil {
+		logger.GetLogger().Warnf("Ignoring %s: %v", redactPatternsKey, err)
+		opts.Patterns = nil
+		redactor, _ = redact.New(opts)
+	}
+	redact.SetDefault(redactor)
+	if logFiles {
+		logger.SetFileRedactor(redactor.Bytes)
+	} else {
+		logger.SetFileRedactor(nil)
+	}
+}
+
+// configStrings returns the strings of the array at key
+func configStrings(cm *managers.ConfigManager, key string) []string {
+	values, err := cm.GetArray(key)
+	if err != nil {
+		return nil
+	}
+	var strs []string
+	for _, v := range values {
+		if s, ok := v.(string); ok && s != "" {
+			strs = append(strs, s)
+		}
+	}
+	return strs
+}
+
+// watchSettingsConfig keeps applying the crew config to a long-running
+// command: each time the config file changes it is reloaded and the log
+// level, I/O limit and retry settings are applied again, with --verbose and
+// --quiet still winning over the log level. The returned function stops
+// watching. Without a config there is nothing to watch.
+func watchSettingsConfig() func() {
+	cm, err := loadSettingsConfig()
+	if err != nil {
+		logger.GetLogger().Warnf("Not watching the crew config: %v", err)
+		return func() {}
+	}
+	if cm == nil {
+		return func() {}
+	}
+
+	watcher := managers.NewConfigWatcher(cm)
+	if !globalFlags.Verbose && !globalFlags.Quiet {
+		watcher.OnChange(managers.LogLevelHandler(logger.GetLogger()))
+	}
+	watcher.OnChange(func(previous, current map[string]interface{}) error {
+		applyIOLimit(cm)
+		applyRetrySettings(cm)
+		return nil
+	})
+	if err := watcher.Start(); err != nil {
+		logger.GetLogger().Warnf("Config changes apply after a restart: %v", err)
+		return func() {}
+	}
+	return func() { watcher.Stop() }
+}
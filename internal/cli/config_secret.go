@@ -0,0 +1,170 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/secrets"
+	"github.com/jonwraymond/claude-code-super-crew/pkg/logger"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// NewConfigCommand creates the config command
+func NewConfigCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Manage the crew configuration",
+		Long: `Manage the crew configuration in .crew/config.
+
+Settings such as the proxy release downloads go through may hold
+credentials. Instead of writing them into the config in plain text, store
+them with 'crew config secret set' and refer to them from the config as
+secret://file/<key>. The environment and the OS keychain can be referred to
+as secret://env/<VARIABLE> and secret://keychain/<service>/<account>.`,
+	}
+
+	cmd.AddCommand(newConfigSecretCommand())
+	return cmd
+}
+
+func newConfigSecretCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "secret",
+		Short: "Store secrets the config refers to as secret://file/<key>",
+		Long: `Store secrets in .crew/config/secrets.enc, encrypted with the passphrase
+in $CREW_SECRETS_PASSPHRASE, and read them back.
+
+Examples:
+  crew config secret set proxy                  # Prompts for the value
+  echo "$TOKEN" | crew config secret set proxy  # Reads it from stdin
+  crew config secret get proxy
+  crew config secret list
+  # Then, in .crew/config/settings.json:
+  #   "network": {"proxy": "secret://file/proxy"}`,
+	}
+
+	set := &cobra.Command{
+		Use:         "set <key> [value]",
+		Short:       "Store a secret, read from stdin when no value is given",
+		Args:        cobra.RangeArgs(1, 2),
+		Annotations: map[string]string{auditAnnotation: auditAlways},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var value string
+			if len(args) == 2 {
+				value = args[1]
+			} else {
+				var err error
+				if value, err = readSecretValue(args[0]); err != nil {
+					return err
+				}
+			}
+			return runConfigSecretSet(args[0], value)
+		},
+		SilenceUsage: true,
+	}
+
+	get := &cobra.Command{
+		Use:               "get <key>",
+		Short:             "Print a stored secret",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeSecretKeys,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cm, err := loadCrewConfig()
+			if err != nil {
+				return err
+			}
+			value, err := cm.SecretStore().Get(args[0])
+			if err != nil {
+				return err
+			}
+			fmt.Println(value)
+			return nil
+		},
+		SilenceUsage: true,
+	}
+
+	list := &cobra.Command{
+		Use:   "list",
+		Short: "List the keys of the stored secrets",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cm, err := loadCrewConfig()
+			if err != nil {
+				return err
+			}
+			keys, err := cm.SecretStore().Keys()
+			if err != nil {
+				return err
+			}
+			if globalFlags.Output == "json" {
+				return json.NewEncoder(os.Stdout).Encode(keys)
+			}
+			for _, key := range keys {
+				fmt.Printf("%s  %s%s\n", key, secrets.URIPrefix+"file/", key)
+			}
+			return nil
+		},
+		SilenceUsage: true,
+	}
+
+	cmd.AddCommand(set, get, list)
+	return cmd
+}
+
+// readSecretValue prompts for the value of key without echoing it, or
+// reads it from stdin when that is not a terminal
+func readSecretValue(key string) (string, error) {
+	fd := int(os.Stdin.Fd())
+	if term.IsTerminal(fd) {
+		fmt.Fprintf(os.Stderr, "Value for %s: ", key)
+		value, err := term.ReadPassword(fd)
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return "", fmt.Errorf("failed to read the secret: %w", err)
+		}
+		return string(value), nil
+	}
+	value, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the secret: %w", err)
+	}
+	return strings.TrimRight(string(value), "\r\n"), nil
+}
+
+func runConfigSecretSet(key, value string) error {
+	log := logger.GetLogger()
+	if value == "" {
+		return fmt.Errorf("refusing to store an empty secret for %s", key)
+	}
+	if globalFlags.DryRun {
+		log.Infof("[DRY RUN] Would store secret %s", key)
+		return nil
+	}
+
+	cm, err := loadCrewConfig()
+	if err != nil {
+		return err
+	}
+	if err := cm.SecretStore().Set(key, value); err != nil {
+		return err
+	}
+	log.Successf("Stored secret %s; refer to it as %sfile/%s", key, secrets.URIPrefix, key)
+	return nil
+}
+
+// completeSecretKeys completes the keys of the stored secrets
+func completeSecretKeys(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	cm, err := loadCrewConfig()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	keys, _ := cm.SecretStore().Keys()
+	return keys, cobra.ShellCompDirectiveNoFileComp
+}
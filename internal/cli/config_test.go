@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/managers"
+	"github.com/jonwraymond/claude-code-super-crew/internal/retry"
+)
+
+func TestSettingsFollowConfigProfile(t *testing.T) {
+	saved := globalFlags
+	defer func() { globalFlags = saved }()
+	original := retry.For(retry.Network)
+	defer retry.Set(retry.Network, original)
+
+	globalFlags.InstallDir = t.TempDir()
+	profiles := filepath.Join(getCrewConfigDir(), managers.ConfigProfilesDirName)
+	if err := os.MkdirAll(profiles, 0755); err != nil {
+		t.Fatal(err)
+	}
+	profile := `{"settings": {"retry": {"network": {"attempts": 7}}}}`
+	if err := os.WriteFile(filepath.Join(profiles, "ci.json"), []byte(profile), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	globalFlags.ConfigProfile = "ci"
+	cm, err := loadSettingsConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	applyRetrySettings(cm)
+	if got := retry.For(retry.Network).Attempts; got != 7 {
+		t.Errorf("Expected the profile's 7 network attempts, got %d", got)
+	}
+
+	globalFlags.ConfigProfile = "missing"
+	if _, err := loadSettingsConfig(); err == nil {
+		t.Error("Expected an unknown profile to be an error")
+	}
+}
+
+func TestLoadSettingsConfigReportsBrokenConfig(t *testing.T) {
+	saved := globalFlags
+	defer func() { globalFlags = saved }()
+
+	globalFlags.InstallDir = t.TempDir()
+	if cm, err := loadSettingsConfig(); cm != nil || err != nil {
+		t.Fatalf("Expected no config before one exists, got %v, %v", cm, err)
+	}
+
+	cm, err := managers.NewConfigManager(getCrewConfigDir(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(cm.GetConfigPath(), []byte(`{"settings": `), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadSettingsConfig(); err == nil {
+		t.Error("Expected a config that does not parse to be an error")
+	}
+}
+
+func TestWatchSettingsConfigReappliesChanges(t *testing.T) {
+	saved := globalFlags
+	defer func() { globalFlags = saved }()
+	original := retry.For(retry.Network)
+	defer retry.Set(retry.Network, original)
+
+	globalFlags.InstallDir = t.TempDir()
+	if err := os.MkdirAll(getCrewConfigDir(), 0755); err != nil {
+		t.Fatal(err)
+	}
+	cm, err := managers.NewConfigManager(getCrewConfigDir(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stop := watchSettingsConfig()
+	defer stop()
+
+	// Ensure the new mtime is strictly after the one recorded on load
+	time.Sleep(20 * time.Millisecond)
+	config := `{"settings": {"retry": {"network": {"attempts": 9}}}}`
+	if err := os.WriteFile(cm.GetConfigPath(), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for retry.For(retry.Network).Attempts != 9 {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected the changed config to apply, still %d attempts", retry.For(retry.Network).Attempts)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
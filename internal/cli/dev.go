@@ -0,0 +1,151 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/devsync"
+	"github.com/jonwraymond/claude-code-super-crew/internal/events"
+	"github.com/jonwraymond/claude-code-super-crew/internal/ui"
+	"github.com/jonwraymond/claude-code-super-crew/pkg/logger"
+	"github.com/spf13/cobra"
+)
+
+// DevWatchFlags holds dev watch command flags
+type DevWatchFlags struct {
+	Source string
+	JSON   bool
+}
+
+// NewDevCommand creates the dev command for framework contributors
+func NewDevCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dev",
+		Short: "Tools for developing the SuperCrew framework itself",
+	}
+	cmd.AddCommand(newDevWatchCommand())
+	return cmd
+}
+
+func newDevWatchCommand() *cobra.Command {
+	var flags DevWatchFlags
+
+	cmd := &cobra.Command{
+		Use:   "watch",
+		Short: "Sync edits in a SuperCrew source tree into the installation",
+		Long: `Watch the SuperCrew directory of a source checkout and copy each file
+that changes to where crew install puts it, logging every synced path.
+
+Installed files you modified since they were installed are protected and
+left alone, as is CLAUDE.md, which install merges with your own content.
+Files deleted from the source stay installed; remove them with crew
+uninstall. Changes to the crew config, such as its log level, apply
+without restarting the watch. Components installed with --dev-link
+already follow the checkout and are skipped.
+
+Each change is published as a file.changed event, which runs the
+file-change lifecycle hooks with CREW_FILE, CREW_TARGET and CREW_ACTION
+set to the source file, its installed path and what the sync did.
+
+Examples:
+  crew dev watch --source ~/src/claude-code-super-crew
+  crew dev watch --dry-run      # Show what would sync
+  crew dev watch --json         # One JSON object per synced path`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDevWatch(cmd, flags)
+		},
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().StringVar(&flags.Source, "source", ".",
+		"Source checkout, or its SuperCrew directory")
+	cmd.Flags().BoolVar(&flags.JSON, "json", false, "Print each result as a JSON line")
+	cmd.MarkFlagDirname("source")
+
+	return cmd
+}
+
+func runDevWatch(cmd *cobra.Command, flags DevWatchFlags) error {
+	syncer, err := devsync.New(expandPath(flags.Source), globalFlags.InstallDir)
+	if err != nil {
+		return err
+	}
+	syncer.DryRun = globalFlags.DryRun
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	out := cmd.OutOrStdout()
+	asJSON := flags.JSON || globalFlags.Output == "json"
+	report := func(r devsync.Result) {
+		if asJSON {
+			json.NewEncoder(out).Encode(r)
+		} else {
+			printSyncResult(out, r)
+		}
+		publishFileChanged(ctx, r)
+	}
+
+	logger.GetLogger().Infof("Watching %s for changes, syncing into %s (Ctrl-C to stop)", syncer.Source, syncer.InstallDir)
+	defer watchSettingsConfig()()
+	return syncer.Watch(ctx, report)
+}
+
+// publishFileChanged publishes a file.changed event for a source file that
+// changed. A failing file-change hook is reported but does not stop the
+// watch.
+func publishFileChanged(ctx context.Context, r devsync.Result) {
+	switch r.Action {
+	case devsync.Synced, devsync.Protected, devsync.Removed:
+	default:
+		return
+	}
+	data := map[string]string{"file": r.Source, "target": r.Target, "action": r.Action}
+	if err := publishEvent(ctx, events.FileChanged, data); err != nil {
+		logger.GetLogger().Warnf("file-change hook failed for %s: %v", r.Source, err)
+	}
+}
+
+// printSyncResult writes one line of the live sync log. Unchanged files
+// are only shown with --verbose.
+func printSyncResult(out io.Writer, r devsync.Result) {
+	icon, color := ui.Icons.Bullet, ui.ColorReset
+	switch r.Action {
+	case devsync.Unchanged, devsync.Skipped:
+		if !globalFlags.Verbose {
+			return
+		}
+	case devsync.Synced:
+		icon, color = ui.Icons.Check, ui.ColorGreen
+	case devsync.Protected, devsync.Removed:
+		icon, color = ui.Icons.Warning, ui.ColorYellow
+	case devsync.Failed:
+		icon, color = ui.Icons.Cross, ui.ColorRed
+	}
+
+	line := fmt.Sprintf("%s %s%s %-9s%s %s", time.Now().Format("15:04:05"), color, icon, r.Action, ui.ColorReset, r.Source)
+	if r.Action == devsync.Synced && r.Target != "" {
+		line += fmt.Sprintf(" %s %s", ui.Icons.Arrow, displayPath(r.Target))
+	}
+	if r.Reason != "" {
+		line += fmt.Sprintf(" (%s)", r.Reason)
+	}
+	fmt.Fprintln(out, line)
+}
+
+// displayPath shortens paths below the home directory to ~/...
+func displayPath(path string) string {
+	home, err := os.UserHomeDir()
+	if err != nil || !strings.HasPrefix(path, home+string(filepath.Separator)) {
+		return path
+	}
+	return "~" + path[len(home):]
+}
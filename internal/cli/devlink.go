@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/fileops"
+	"github.com/jonwraymond/claude-code-super-crew/internal/metadata"
+	"github.com/jonwraymond/claude-code-super-crew/pkg/logger"
+)
+
+// markDevLinked records that components are symlinked from source, or with
+// an empty source that they are regular copies again
+func markDevLinked(installDir string, components []string, source string) error {
+	mm := metadata.NewMetadataManager(installDir)
+	meta, err := mm.LoadMetadata()
+	if err != nil {
+		return err
+	}
+	for _, name := range components {
+		comp, ok := meta.Components[name]
+		if !ok {
+			continue
+		}
+		comp.LinkedFrom = source
+		meta.Components[name] = comp
+	}
+	return mm.SaveMetadata(meta)
+}
+
+// linkedComponents maps the components of installDir installed with
+// --dev-link to the checkout they link to
+func linkedComponents(installDir string) map[string]string {
+	linked := make(map[string]string)
+	mm := metadata.NewMetadataManager(installDir)
+	if !mm.CheckInstallationExists() {
+		return linked
+	}
+	meta, err := mm.LoadMetadata()
+	if err != nil {
+		return linked
+	}
+	for name, comp := range meta.Components {
+		if comp.LinkedFrom != "" {
+			linked[strings.ToLower(name)] = comp.LinkedFrom
+		}
+	}
+	return linked
+}
+
+// removeDevLinks removes the symlinks in dir that point into source,
+// descending into subdirectories when recursive is set. The files they
+// point to are left alone.
+func removeDevLinks(dir, source string, recursive bool) int {
+	log := logger.GetLogger()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0
+	}
+
+	removed := 0
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+		if entry.IsDir() {
+			if recursive {
+				removed += removeDevLinks(path, source, true)
+			}
+			continue
+		}
+		if !fileops.IsLinkInto(path, source) {
+			continue
+		}
+		if err := os.Remove(path); err != nil {
+			log.Warnf("Failed to remove link %s: %v", path, err)
+			continue
+		}
+		removed++
+	}
+	return removed
+}
+
+// skipLinkedComponents drops the components installed with --dev-link from
+// an update: their links already show the checkout, and copying over them
+// would turn them back into copies
+func skipLinkedComponents(components []string) []string {
+	log := logger.GetLogger()
+	linked := linkedComponents(globalFlags.InstallDir)
+	var kept []string
+	for _, name := range components {
+		if source, ok := linked[strings.ToLower(name)]; ok {
+			log.Infof("Skipping %s: it is linked to %s; run 'crew install --dev-link' there to pick up new files", name, source)
+			continue
+		}
+		kept = append(kept, name)
+	}
+	return kept
+}
@@ -0,0 +1,219 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/core"
+	"github.com/jonwraymond/claude-code-super-crew/internal/releasediff"
+	"github.com/jonwraymond/claude-code-super-crew/internal/semver"
+	"github.com/jonwraymond/claude-code-super-crew/internal/textdiff"
+	"github.com/jonwraymond/claude-code-super-crew/internal/ui"
+	"github.com/jonwraymond/claude-code-super-crew/internal/versioning"
+	"github.com/spf13/cobra"
+)
+
+// DiffFlags holds diff command flags
+type DiffFlags struct {
+	From       string
+	To         string
+	Categories []string
+	Stat       bool
+	JSON       bool
+}
+
+var diffFlags DiffFlags
+
+// Release names --from and --to accept besides versions and paths
+const (
+	releaseInstalled = "installed"
+	releaseBundled   = "bundled"
+)
+
+// NewDiffCommand creates the diff command
+func NewDiffCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff",
+		Short: "Compare two framework releases before updating",
+		Long: `Compare two framework releases and report the commands, agents, personas
+and core docs that were added, removed or changed, with a diff per file.
+
+--from and --to each take one of:
+  installed    the framework installed in the install directory
+  bundled      the framework that ships with this crew binary
+  <version>    the installed or bundled framework, whichever has it
+  <path>       a SuperCrew source tree, an installation directory, or a
+               .tar/.tar.gz archive of either (e.g. a release or a backup)
+
+The default compares the installed framework with the bundled one, which
+is what 'crew update' would apply.
+
+Examples:
+  crew diff                                  # What would an update change?
+  crew diff --from 1.0.0 --to 1.1.0
+  crew diff --to ~/Downloads/supercrew-1.2.0.tar.gz --stat
+  crew diff --category commands,agents --json`,
+		Args:         cobra.NoArgs,
+		RunE:         runDiff,
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().StringVar(&diffFlags.From, "from", releaseInstalled,
+		"Release to compare from")
+	cmd.Flags().StringVar(&diffFlags.To, "to", releaseBundled,
+		"Release to compare to")
+	cmd.Flags().StringSliceVar(&diffFlags.Categories, "category", nil,
+		"Only compare these categories: "+strings.Join(releasediff.Categories, ", "))
+	cmd.Flags().BoolVar(&diffFlags.Stat, "stat", false,
+		"List changed files without per-file diffs")
+	cmd.Flags().BoolVar(&diffFlags.JSON, "json", false,
+		"Output the changes as JSON")
+
+	releases := func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{releaseInstalled, releaseBundled}, cobra.ShellCompDirectiveDefault
+	}
+	registerFlagCompletions(cmd, map[string]completionFunc{
+		"from": releases,
+		"to":   releases,
+		"category": func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return completeList(releasediff.Categories, toComplete)
+		},
+	})
+
+	return cmd
+}
+
+func runDiff(cmd *cobra.Command, args []string) error {
+	for _, category := range diffFlags.Categories {
+		if !contains(releasediff.Categories, category) {
+			return fmt.Errorf("unknown category %q: use %s", category, strings.Join(releasediff.Categories, ", "))
+		}
+	}
+
+	from, err := loadRelease(diffFlags.From)
+	if err != nil {
+		return fmt.Errorf("--from: %w", err)
+	}
+	to, err := loadRelease(diffFlags.To)
+	if err != nil {
+		return fmt.Errorf("--to: %w", err)
+	}
+
+	jsonOutput := diffFlags.JSON || globalFlags.Output == "json"
+	var changes []releasediff.Change
+	for _, change := range releasediff.Compare(from, to, !diffFlags.Stat) {
+		if len(diffFlags.Categories) == 0 || contains(diffFlags.Categories, change.Category) {
+			changes = append(changes, change)
+		}
+	}
+
+	if jsonOutput {
+		if changes == nil {
+			changes = []releasediff.Change{}
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(map[string]interface{}{
+			"from":    from.Label,
+			"to":      to.Label,
+			"changes": changes,
+		})
+	}
+
+	displayReleaseDiff(from, to, changes)
+	return nil
+}
+
+// loadRelease resolves a --from or --to value and reads the release
+func loadRelease(spec string) (*releasediff.Release, error) {
+	installDir := getGlobalInstallDir()
+	installed := ""
+	if isFrameworkInstalled() {
+		installed, _ = versioning.NewVersionManager(installDir).GetCurrentVersion()
+	}
+	bundled := core.FrameworkVersion
+
+	switch {
+	case spec == releaseInstalled:
+		if !isFrameworkInstalled() {
+			return nil, fmt.Errorf("no framework installed in %s", installDir)
+		}
+		return releasediff.Load(installDir, "installed-"+versionLabel(installed))
+	case spec == releaseBundled:
+		return releasediff.Load(findSuperCrewSource(), "bundled-"+versionLabel(bundled))
+	}
+
+	if _, err := os.Stat(expandPath(spec)); err == nil {
+		return releasediff.Load(expandPath(spec), filepath.Base(spec))
+	}
+
+	if semver.Valid(spec) {
+		switch {
+		case installed != "" && semver.Compare(spec, installed) == 0:
+			return releasediff.Load(installDir, "installed-"+versionLabel(installed))
+		case semver.Compare(spec, bundled) == 0:
+			return releasediff.Load(findSuperCrewSource(), "bundled-"+versionLabel(bundled))
+		}
+		known := "bundled v" + bundled
+		if installed != "" {
+			known = "installed v" + installed + ", " + known
+		}
+		return nil, fmt.Errorf("version %s is not available here (%s); pass a source directory or release archive instead", spec, known)
+	}
+	return nil, fmt.Errorf("%q is not a release name, version, directory or archive", spec)
+}
+
+func versionLabel(version string) string {
+	if version == "" {
+		return "unknown"
+	}
+	return "v" + version
+}
+
+func displayReleaseDiff(from, to *releasediff.Release, changes []releasediff.Change) {
+	fmt.Printf("\n%s%sFramework diff:%s %s %s %s\n", ui.ColorCyan, ui.ColorBright, ui.ColorReset, from.Label, ui.Icons.Arrow, to.Label)
+	if len(changes) == 0 {
+		fmt.Println("No differences in commands, agents, personas or core docs")
+		return
+	}
+
+	var total textdiff.Stats
+	for _, category := range releasediff.Categories {
+		var rows []releasediff.Change
+		for _, change := range changes {
+			if change.Category == category {
+				rows = append(rows, change)
+			}
+		}
+		if len(rows) == 0 {
+			continue
+		}
+
+		fmt.Printf("\n%s%s%s (%d changed)\n", ui.ColorBright, strings.ToUpper(category[:1])+category[1:], ui.ColorReset, len(rows))
+		for _, change := range rows {
+			marker, color := "~", ui.ColorYellow
+			switch change.Status {
+			case releasediff.Added:
+				marker, color = "+", ui.ColorGreen
+			case releasediff.Removed:
+				marker, color = "-", ui.ColorRed
+			}
+			fmt.Printf("  %s%s %-32s%s %s\n", color, marker, change.Name, ui.ColorReset,
+				diffSummary(textdiff.Stats{Added: change.Added, Removed: change.Removed}))
+			total.Added += change.Added
+			total.Removed += change.Removed
+		}
+	}
+
+	for _, change := range changes {
+		if change.Diff != "" {
+			fmt.Println()
+			printColoredDiff(change.Diff)
+		}
+	}
+
+	fmt.Printf("\n%d files changed %s\n", len(changes), diffSummary(total))
+}
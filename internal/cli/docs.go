@@ -0,0 +1,301 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/claude"
+	"github.com/jonwraymond/claude-code-super-crew/internal/core"
+	"github.com/jonwraymond/claude-code-super-crew/internal/docgen"
+	"github.com/jonwraymond/claude-code-super-crew/internal/hooks"
+	"github.com/jonwraymond/claude-code-super-crew/internal/ui"
+	"github.com/jonwraymond/claude-code-super-crew/pkg/logger"
+	"github.com/spf13/cobra"
+)
+
+// DocsGenerateFlags holds docs generate flags
+type DocsGenerateFlags struct {
+	Format    string
+	Dir       string
+	SourceDir string
+	Check     bool
+}
+
+// lifecycleEventDescriptions explains when each lifecycle event fires
+var lifecycleEventDescriptions = map[hooks.LifecycleEvent]string{
+	hooks.PreInstall:    "Before components are installed. An abort policy stops the install.",
+	hooks.PostInstall:   "After all components are installed.",
+	hooks.PreUpdate:     "Before components are updated. An abort policy stops the update.",
+	hooks.PostUpdate:    "After all components are updated.",
+	hooks.PreBackup:     "Before a backup is created. An abort policy stops the backup.",
+	hooks.PostBackup:    "After a backup is created.",
+	hooks.PreUninstall:  "Before components are removed. An abort policy stops the uninstall.",
+	hooks.PostUninstall: "After components are removed.",
+	hooks.FileChange:    "When crew dev watch sees a framework file change in the source tree.",
+}
+
+// NewDocsCommand creates the docs command
+func NewDocsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "docs",
+		Short: "Generate the crew reference documentation",
+	}
+
+	cmd.AddCommand(newDocsGenerateCommand())
+
+	return cmd
+}
+
+func newDocsGenerateCommand() *cobra.Command {
+	var flags DocsGenerateFlags
+
+	cmd := &cobra.Command{
+		Use:   "generate",
+		Short: "Generate the CLI reference as Markdown or man pages",
+		Long: `Generate a reference page for every crew command, plus pages listing the
+available components, hooks and slash commands.
+
+Pages are written to docs/cli (Markdown) or docs/man (man pages) unless
+--dir is given. Files that were generated before but no longer belong to
+a command are removed, so the directory always matches the command tree.
+Use --check in CI to fail when the checked-in reference is out of date.
+
+Examples:
+  crew docs generate                          # Markdown into docs/cli
+  crew docs generate --format man             # Man pages into docs/man
+  crew docs generate --dir /tmp/crew-docs     # Markdown into another directory
+  crew docs generate --check                  # Fail if docs/cli is stale`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDocsGenerate(cmd, flags)
+		},
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().StringVar(&flags.Format, "format", "markdown", "Output format: markdown or man")
+	cmd.Flags().StringVar(&flags.Dir, "dir", "", "Output directory (default docs/cli or docs/man)")
+	cmd.Flags().StringVar(&flags.SourceDir, "source-dir", "", "SuperCrew source directory for components and slash commands (default: detected)")
+	cmd.Flags().BoolVar(&flags.Check, "check", false, "Only report whether the generated files are up to date")
+
+	registerFlagCompletions(cmd, map[string]completionFunc{
+		"format": completeStatic("markdown", "man"),
+	})
+
+	return cmd
+}
+
+func runDocsGenerate(cmd *cobra.Command, flags DocsGenerateFlags) error {
+	log := logger.GetLogger()
+
+	gen := &docgen.Generator{
+		Root:   cmd.Root(),
+		Source: "crew " + cmd.Root().Version,
+		Manual: "Crew Manual",
+		// Plugins are installed per machine and document themselves
+		Skip: func(c *cobra.Command) bool {
+			_, isPlugin := c.Annotations[pluginAnnotation]
+			return isPlugin
+		},
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		gen.Replacer = strings.NewReplacer(home, "~")
+	}
+
+	sourceDir := flags.SourceDir
+	if sourceDir == "" {
+		sourceDir = findSuperCrewSource()
+	}
+	gen.Sections = docsSections(sourceDir)
+
+	var pages []docgen.Page
+	dir := flags.Dir
+	switch flags.Format {
+	case "markdown", "md":
+		pages = gen.Markdown()
+		if dir == "" {
+			dir = filepath.Join("docs", "cli")
+		}
+	case "man":
+		pages = gen.Man()
+		if dir == "" {
+			dir = filepath.Join("docs", "man")
+		}
+	default:
+		return fmt.Errorf("invalid --format %q: must be markdown or man", flags.Format)
+	}
+
+	if flags.Check {
+		outdated, err := docgen.Check(dir, pages)
+		if err != nil {
+			return err
+		}
+		if len(outdated) > 0 {
+			for _, path := range outdated {
+				log.Warnf("Out of date: %s", path)
+			}
+			return fmt.Errorf("%d generated files in %s are out of date; run 'crew docs generate'", len(outdated), dir)
+		}
+		ui.DisplaySuccess(fmt.Sprintf("%d files in %s are up to date", len(pages), dir))
+		return nil
+	}
+
+	if globalFlags.DryRun {
+		log.Infof("[DRY RUN] Would generate %d %s files in %s", len(pages), flags.Format, dir)
+		return nil
+	}
+
+	written, removed, err := docgen.Write(dir, pages)
+	if err != nil {
+		return err
+	}
+	for _, path := range removed {
+		log.Infof("Removed %s", path)
+	}
+	ui.DisplaySuccess(fmt.Sprintf("Generated %d files in %s (%d changed, %d removed)", len(pages), dir, len(written), len(removed)))
+	return nil
+}
+
+// findSuperCrewSource locates the SuperCrew source directory next to the
+// binary or, when running from a checkout, in the working directory
+func findSuperCrewSource() string {
+	exe, _ := os.Executable()
+	projectRoot := filepath.Dir(filepath.Dir(filepath.Dir(exe)))
+	if _, err := os.Stat(filepath.Join(projectRoot, "SuperCrew")); os.IsNotExist(err) {
+		if cwd, err := os.Getwd(); err == nil {
+			projectRoot = cwd
+		}
+	}
+	return filepath.Join(projectRoot, "SuperCrew")
+}
+
+// docsSections builds the reference pages that are not commands. Each one
+// is omitted when its source cannot be read.
+func docsSections(sourceDir string) []docgen.Section {
+	var sections []docgen.Section
+	if s, ok := componentsSection(sourceDir); ok {
+		sections = append(sections, s)
+	}
+	sections = append(sections, hooksSection())
+	if s, ok := slashCommandsSection(filepath.Join(sourceDir, "commands")); ok {
+		sections = append(sections, s)
+	}
+	return sections
+}
+
+func componentsSection(sourceDir string) (docgen.Section, bool) {
+	registry := core.NewEnhancedComponentRegistry(sourceDir)
+	if err := registry.DiscoverComponents(); err != nil {
+		return docgen.Section{}, false
+	}
+
+	section := docgen.Section{
+		Name:  "components",
+		Title: "Components",
+		Intro: `Components are the units crew installs. Select them with
+'crew install --components', '--tag' or '--category'. Experimental
+components are only offered with --experimental.`,
+	}
+	for _, name := range registry.FilterComponents(core.ComponentFilter{IncludeExperimental: true}) {
+		meta := registry.GetComponentMetadata(name)
+		if meta == nil {
+			continue
+		}
+		entry := docgen.Entry{
+			Term:        fmt.Sprintf("%s %s", meta.Name, meta.Version),
+			Description: meta.Description,
+			Details:     []string{"Category: " + meta.Category},
+		}
+		if len(meta.Tags) > 0 {
+			entry.Details = append(entry.Details, "Tags: "+strings.Join(meta.Tags, ", "))
+		}
+		if len(meta.Dependencies) > 0 {
+			entry.Details = append(entry.Details, "Depends on: "+strings.Join(meta.Dependencies, ", "))
+		}
+		if len(meta.Requirements) > 0 {
+			var reqs []string
+			for tool, constraint := range meta.Requirements {
+				reqs = append(reqs, tool+" "+constraint)
+			}
+			sort.Strings(reqs)
+			entry.Details = append(entry.Details, "Requires: "+strings.Join(reqs, ", "))
+		}
+		section.Entries = append(section.Entries, entry)
+	}
+	return section, true
+}
+
+func hooksSection() docgen.Section {
+	section := docgen.Section{
+		Name:  "hooks",
+		Title: "Hooks",
+		Intro: `Lifecycle hooks run scripts around crew operations. Each hook is a JSON
+descriptor in ~/.claude/.crew/hooks with the fields name, event, script,
+args, timeout, env, on_failure (ignore, warn or abort), required_tools
+and enabled. Scaffold one with 'crew hooks --new'.
+
+Claude Code hooks run inside Claude Code on tool use. Enable them with
+'crew hooks --enable <name>'; the built-in ones are listed after the
+lifecycle events.`,
+	}
+	for _, event := range hooks.LifecycleEvents {
+		section.Entries = append(section.Entries, docgen.Entry{
+			Term:        string(event),
+			Description: lifecycleEventDescriptions[event],
+		})
+	}
+
+	hm := hooks.NewHookManager("")
+	hm.SetProjectOverlay(false)
+	if err := hm.DiscoverHooks(); err == nil {
+		for _, hook := range hm.ListHooks() {
+			entry := docgen.Entry{
+				Term:        hook.Name,
+				Description: hook.Description,
+				Details:     []string{fmt.Sprintf("Runs on: %s (%s)", hook.Type, hook.Matcher)},
+			}
+			var settings []string
+			for key := range hook.Config {
+				settings = append(settings, key)
+			}
+			sort.Strings(settings)
+			if len(settings) > 0 {
+				entry.Details = append(entry.Details, "Settings: "+strings.Join(settings, ", "))
+			}
+			section.Entries = append(section.Entries, entry)
+		}
+	}
+	return section
+}
+
+func slashCommandsSection(commandsDir string) (docgen.Section, bool) {
+	registry := claude.NewSlashCommandRegistry(commandsDir)
+	if err := registry.LoadCommands(); err != nil {
+		return docgen.Section{}, false
+	}
+
+	section := docgen.Section{
+		Name:  "slash-commands",
+		Title: "Slash Commands",
+		Intro: `Slash commands are available in Claude Code once a project is set up
+with 'crew claude --install'. Type /crew: and press Tab to list them.`,
+	}
+	commands := registry.ListCommands()
+	sort.Slice(commands, func(i, j int) bool { return commands[i].Name < commands[j].Name })
+	for _, command := range commands {
+		entry := docgen.Entry{
+			Term:        "/crew:" + command.Name,
+			Description: command.Description,
+		}
+		if command.Usage != "" {
+			entry.Details = append(entry.Details, "Usage: "+command.Usage)
+		}
+		if command.Category != "" {
+			entry.Details = append(entry.Details, "Category: "+command.Category)
+		}
+		section.Entries = append(section.Entries, entry)
+	}
+	return section, true
+}
@@ -0,0 +1,126 @@
+package cli
+
+import (
+	"strings"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/buildinfo"
+	"github.com/jonwraymond/claude-code-super-crew/internal/core"
+	"github.com/jonwraymond/claude-code-super-crew/internal/findings"
+	"github.com/jonwraymond/claude-code-super-crew/internal/platform"
+	"github.com/spf13/cobra"
+)
+
+// DoctorFlags holds doctor command flags
+type DoctorFlags struct {
+	JSON           bool
+	Format         string
+	InstallMissing bool
+}
+
+var doctorFlags DoctorFlags
+
+// NewDoctorCommand creates the doctor command
+func NewDoctorCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Check that this system can run Claude Code Super Crew",
+		Long: `Check the tools crew and its components need, and the platform it runs on.
+
+Besides tool versions, doctor detects Windows Subsystem for Linux,
+containers (docker, podman, kubernetes), SELinux, and whether the install
+directory is writable and case-sensitive, with advice for each. It runs
+the same checks as 'crew install --diagnose'.
+
+For missing tools (git, node, code2prompt, ast-grep and others), doctor
+shows the commands installing them with this system's package manager;
+--install-missing runs them after you confirm.
+
+--format sarif writes the failed checks as a SARIF 2.1.0 log for CI
+dashboards and code-scanning UIs; --format json adds them as "findings" to
+the JSON output.
+
+Examples:
+  crew doctor
+  crew doctor --install-dir /opt/claude
+  crew doctor --json
+  crew doctor --format sarif > doctor.sarif
+  crew doctor --install-missing`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := findings.ValidateFormat(doctorFlags.Format); err != nil {
+				return err
+			}
+			if doctorFlags.JSON || doctorFlags.Format == findings.FormatJSON {
+				globalFlags.Output = "json"
+			}
+			return runSystemDiagnostics(doctorFlags.InstallMissing, doctorFlags.Format)
+		},
+	}
+
+	cmd.Flags().BoolVar(&doctorFlags.JSON, "json", false,
+		"Output the diagnostics as JSON")
+	cmd.Flags().StringVar(&doctorFlags.Format, "format", findings.FormatText,
+		"Output format: "+strings.Join(findings.Formats, ", "))
+	cmd.Flags().BoolVar(&doctorFlags.InstallMissing, "install-missing", false,
+		"Install the missing tools with the system package manager (brew, apt, dnf, pacman or winget) after confirmation")
+
+	registerFlagCompletions(cmd, map[string]completionFunc{
+		"format": completeStatic(findings.Formats...),
+	})
+
+	return cmd
+}
+
+// Rules of doctor findings
+var doctorRules = []findings.Rule{
+	{ID: "crew/doctor/missing-requirement", Level: findings.LevelError,
+		Description: "A tool crew or its components need is missing or too old",
+		Help:        "Install the tool; 'crew doctor' shows the command for this system"},
+	{ID: "crew/doctor/permissions", Level: findings.LevelError,
+		Description: "The Claude directory cannot be written",
+		Help:        "Ensure you have write permissions to your home directory"},
+	{ID: "crew/doctor/environment", Level: findings.LevelWarning,
+		Description: "The platform may keep crew from working as expected",
+		Help:        "Follow the recommendation of 'crew doctor' for the platform"},
+	{ID: "crew/doctor/missing-optional-tool", Level: findings.LevelNote,
+		Description: "A tool agents use when present is missing",
+		Help:        "Install the tool to let agents use it"},
+}
+
+// doctorFindings turns the failed checks of a diagnosis into findings
+func doctorFindings(diagnostics map[string]interface{}) *findings.Report {
+	report := findings.NewReport(buildinfo.Get().Version, "", doctorRules...)
+	results, _ := diagnostics["results"].([]core.RequirementResult)
+	for _, result := range results {
+		if result.Satisfied {
+			continue
+		}
+		props := map[string]string{"tool": result.Tool}
+		if result.Component != "" {
+			props["component"] = result.Component
+		}
+		if result.Required != "" {
+			props["required"] = result.Required
+		}
+		rule := "crew/doctor/missing-requirement"
+		if result.Tool == "permissions" {
+			rule = "crew/doctor/permissions"
+		}
+		report.Add(rule, result.Message, "", props)
+	}
+	if environment, ok := diagnostics["environment"].(platform.Environment); ok {
+		issues, _ := environment.Advice()
+		for _, issue := range issues {
+			report.Add("crew/doctor/environment", issue, "", nil)
+		}
+	}
+	optional, _ := diagnostics["optional"].([]core.RequirementResult)
+	for _, result := range optional {
+		if !result.Found {
+			report.Add("crew/doctor/missing-optional-tool", result.Message, "",
+				map[string]string{"tool": result.Tool})
+		}
+	}
+	return report
+}
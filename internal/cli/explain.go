@@ -0,0 +1,524 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/audit"
+	"github.com/jonwraymond/claude-code-super-crew/internal/cache"
+	"github.com/jonwraymond/claude-code-super-crew/internal/claudemd"
+	"github.com/jonwraymond/claude-code-super-crew/internal/core"
+	"github.com/jonwraymond/claude-code-super-crew/internal/crewdirs"
+	"github.com/jonwraymond/claude-code-super-crew/internal/fileops"
+	"github.com/jonwraymond/claude-code-super-crew/internal/managers"
+	"github.com/jonwraymond/claude-code-super-crew/internal/metadata"
+	"github.com/jonwraymond/claude-code-super-crew/internal/retry"
+	"github.com/jonwraymond/claude-code-super-crew/internal/textdiff"
+	"github.com/jonwraymond/claude-code-super-crew/internal/trash"
+	"github.com/jonwraymond/claude-code-super-crew/internal/ui"
+	"github.com/jonwraymond/claude-code-super-crew/internal/versioning"
+	"github.com/spf13/cobra"
+)
+
+// ExplainFlags holds explain command flags
+type ExplainFlags struct {
+	JSON bool
+}
+
+// explainItem is one resolved value and where it came from
+type explainItem struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Source string `json:"source,omitempty"`
+}
+
+// explanation is what crew explain reports for a topic
+type explanation struct {
+	Topic    string        `json:"topic"`
+	Summary  string        `json:"summary"`
+	Settings []explainItem `json:"settings,omitempty"`
+	Paths    []explainItem `json:"paths,omitempty"`
+	// Decisions explain which of several possible behaviors applies and why
+	Decisions []string `json:"decisions,omitempty"`
+	// Next describes what the next relevant command would do
+	Next []string `json:"next,omitempty"`
+}
+
+// explainTopics maps each topic to the function resolving it from the
+// current installation. args are the arguments after the topic.
+var explainTopics = map[string]struct {
+	usage   string
+	explain func(args []string) (*explanation, error)
+}{
+	"paths":         {"where crew keeps its files and why", explainPaths},
+	"settings":      {"crew settings in effect and where each comes from", explainSettings},
+	"update-policy": {"what crew update would do and what it skips", explainUpdatePolicy},
+	"claude-md":     {"how CLAUDE.md is managed and what install would change", explainClaudeMD},
+	"component":     {"the state of one component (component <name>)", explainComponent},
+}
+
+// NewExplainCommand creates the explain command
+func NewExplainCommand() *cobra.Command {
+	var flags ExplainFlags
+
+	cmd := &cobra.Command{
+		Use:   "explain <topic> [args]",
+		Short: "Explain how crew resolves a behavior for this installation",
+		Long: `Explain what crew would do and why, worked out from the installation,
+its metadata and configuration rather than from documentation: the
+resolved values, the files involved, which rule won where several apply,
+and what the next command would do.
+
+Topics:
+` + explainTopicList() + `
+
+Examples:
+  crew explain update-policy
+  crew explain claude-md
+  crew explain component commands --json`,
+		Args:              cobra.ArbitraryArgs,
+		ValidArgsFunction: completeExplainTopics,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runExplain(args, flags)
+		},
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().BoolVar(&flags.JSON, "json", false, "Output as JSON")
+
+	return cmd
+}
+
+func explainTopicList() string {
+	names := make([]string, 0, len(explainTopics))
+	for name := range explainTopics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var b strings.Builder
+	for _, name := range names {
+		fmt.Fprintf(&b, "  %-15s %s\n", name, explainTopics[name].usage)
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func completeExplainTopics(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	switch {
+	case len(args) == 0:
+		var topics []string
+		for name, topic := range explainTopics {
+			topics = append(topics, name+"\t"+topic.usage)
+		}
+		sort.Strings(topics)
+		return topics, cobra.ShellCompDirectiveNoFileComp
+	case len(args) == 1 && args[0] == "component":
+		return completeInstalledComponents(cmd, nil, toComplete)
+	}
+	return nil, cobra.ShellCompDirectiveNoFileComp
+}
+
+func runExplain(args []string, flags ExplainFlags) error {
+	if len(args) == 0 {
+		fmt.Printf("Topics:\n%s\n", explainTopicList())
+		return nil
+	}
+	topic, ok := explainTopics[args[0]]
+	if !ok {
+		return fmt.Errorf("unknown topic %q; topics are:\n%s", args[0], explainTopicList())
+	}
+	e, err := topic.explain(args[1:])
+	if err != nil {
+		return err
+	}
+	e.Topic = args[0]
+
+	if flags.JSON || globalFlags.Output == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(e)
+	}
+	displayExplanation(e)
+	return nil
+}
+
+func displayExplanation(e *explanation) {
+	fmt.Printf("\n%s%s%s: %s\n", ui.ColorCyan, e.Topic, ui.ColorReset, e.Summary)
+
+	printItems := func(title string, items []explainItem) {
+		if len(items) == 0 {
+			return
+		}
+		width := 0
+		for _, item := range items {
+			if len(item.Name) > width {
+				width = len(item.Name)
+			}
+		}
+		fmt.Printf("\n%s%s%s\n", ui.ColorBright, title, ui.ColorReset)
+		for _, item := range items {
+			value := item.Value
+			if value == "" {
+				value = "-"
+			}
+			line := fmt.Sprintf("  %-*s  %s", width, item.Name, value)
+			if item.Source != "" {
+				line += fmt.Sprintf("  %s(%s)%s", ui.ColorDim, item.Source, ui.ColorReset)
+			}
+			fmt.Println(line)
+		}
+	}
+	printList := func(title string, lines []string) {
+		if len(lines) == 0 {
+			return
+		}
+		fmt.Printf("\n%s%s%s\n", ui.ColorBright, title, ui.ColorReset)
+		for _, line := range lines {
+			fmt.Printf("  %s %s\n", ui.Icons.Bullet, line)
+		}
+	}
+
+	printItems("Settings", e.Settings)
+	printItems("Paths", e.Paths)
+	printList("Decisions", e.Decisions)
+	printList("Next", e.Next)
+}
+
+// pathItem describes path and whether it exists
+func pathItem(name, path string) explainItem {
+	state := "missing"
+	if info, err := os.Stat(path); err == nil {
+		state = "exists"
+		if info.IsDir() {
+			state = "directory"
+		}
+	}
+	return explainItem{Name: name, Value: displayPath(path), Source: state}
+}
+
+// installDirSource says where the install directory was taken from
+func installDirSource() string {
+	if globalFlags.InstallDir == expandPath("~/.claude") {
+		return "default"
+	}
+	return "--install-dir"
+}
+
+// loadInstalledMetadata returns the metadata of the installation, or nil
+// when crew is not installed there
+func loadInstalledMetadata(installDir string) *metadata.UnifiedMetadata {
+	mm := metadata.NewMetadataManager(installDir)
+	if !mm.CheckInstallationExists() {
+		return nil
+	}
+	meta, err := mm.LoadMetadata()
+	if err != nil {
+		return nil
+	}
+	return meta
+}
+
+func explainPaths(args []string) (*explanation, error) {
+	installDir := globalFlags.InstallDir
+	e := &explanation{Summary: "where crew reads and writes for " + displayPath(installDir)}
+
+	layout, why := "legacy", "state lives in .crew inside the install directory"
+	switch {
+	case globalFlags.XDG:
+		layout, why = "xdg", "--xdg was given"
+	case crewdirs.EnvXDG():
+		layout, why = "xdg", "CREW_XDG is set"
+	case crewdirs.XDG(installDir):
+		layout, why = "xdg", "the installation recorded the XDG layout (crew migrate-xdg)"
+	}
+	e.Settings = []explainItem{
+		{Name: "install dir", Value: displayPath(installDir), Source: installDirSource()},
+		{Name: "layout", Value: layout, Source: why},
+	}
+	if globalFlags.ConfigProfile != "" {
+		e.Settings = append(e.Settings, explainItem{Name: "config profile", Value: globalFlags.ConfigProfile, Source: "--config-profile"})
+	}
+
+	mm := metadata.NewMetadataManager(installDir)
+	e.Paths = []explainItem{
+		pathItem("install dir", installDir),
+		pathItem("CLAUDE.md", filepath.Join(installDir, "CLAUDE.md")),
+		pathItem("commands", filepath.Join(installDir, "commands", "crew")),
+		pathItem("agents", filepath.Join(installDir, "agents")),
+		pathItem("hooks", filepath.Join(installDir, "hooks")),
+		pathItem("settings.json", filepath.Join(installDir, "settings.json")),
+		pathItem("metadata", mm.Path()),
+		pathItem("config", getCrewConfigDir()),
+		pathItem("backups", crewdirs.Path(installDir, "backups")),
+		pathItem("logs", crewdirs.Path(installDir, "logs")),
+		pathItem("audit log", audit.Path(installDir)),
+		pathItem("cache", cache.Dir(installDir)),
+		pathItem("trash", trash.Dir(installDir)),
+	}
+
+	if shared := overlaySharedDir(installDir); shared != "" {
+		e.Decisions = append(e.Decisions, fmt.Sprintf("This is an overlay: framework files come from the shared installation in %s", displayPath(shared)))
+	}
+	if !mm.CheckInstallationExists() {
+		e.Next = append(e.Next, "crew install would create the installation in "+displayPath(installDir))
+	}
+	return e, nil
+}
+
+// explainedSetting is a crew setting with its built-in default
+type explainedSetting struct {
+	key, fallback string
+}
+
+func explainSettings(args []string) (*explanation, error) {
+	configDir := getCrewConfigDir()
+	e := &explanation{Summary: "crew settings in effect, highest precedence first where several apply"}
+
+	settings := []explainedSetting{
+		{cacheMaxSizeKey, fmt.Sprintf("%d", cache.DefaultMaxSize>>20)},
+		{ioMemoryKey, fmt.Sprintf("%d", fileops.DefaultMemoryLimit>>20)},
+		{proxyKey, ""},
+		{retryKey(retry.Network, "attempts"), fmt.Sprintf("%d", retry.For(retry.Network).Attempts)},
+		{retryKey(retry.Lock, "attempts"), fmt.Sprintf("%d", retry.For(retry.Lock).Attempts)},
+		{trashModeKey, "crew"},
+		{trashTTLKey, fmt.Sprintf("%d", int(trash.DefaultTTL.Hours()/24))},
+		{telemetryEnabledKey, "false"},
+		{telemetryEndpointKey, ""},
+		{"settings.log_level", "info"},
+	}
+
+	// Opening the config creates it, so only look when it exists
+	var cm *managers.ConfigManager
+	if _, err := os.Stat(configDir); err == nil {
+		if cm, err = loadCrewConfig(); err != nil {
+			return nil, err
+		}
+		e.Paths = append(e.Paths, pathItem("config file", cm.GetConfigPath()))
+	} else {
+		e.Decisions = append(e.Decisions, "No crew config exists yet; every setting has its built-in default")
+	}
+
+	for _, s := range settings {
+		item := explainItem{Name: s.key, Value: s.fallback, Source: "default"}
+		if cm != nil {
+			if value, err := cm.Get(s.key); err == nil {
+				item.Value = fmt.Sprint(value)
+				item.Source = "config"
+				if globalFlags.ConfigProfile != "" {
+					item.Source = "config, profile " + globalFlags.ConfigProfile
+				}
+			}
+		}
+		e.Settings = append(e.Settings, item)
+	}
+
+	if reason := telemetryEnvOverride(); reason != "" {
+		e.Decisions = append(e.Decisions, fmt.Sprintf("%s is set, which turns telemetry off whatever the config says", reason))
+	}
+	switch {
+	case globalFlags.Verbose:
+		e.Decisions = append(e.Decisions, "--verbose wins over settings.log_level")
+	case globalFlags.Quiet:
+		e.Decisions = append(e.Decisions, "--quiet wins over settings.log_level")
+	}
+	return e, nil
+}
+
+func explainUpdatePolicy(args []string) (*explanation, error) {
+	installDir := globalFlags.InstallDir
+	e := &explanation{Summary: "what crew update would do for " + displayPath(installDir)}
+
+	meta := loadInstalledMetadata(installDir)
+	if meta == nil {
+		e.Decisions = append(e.Decisions, "crew is not installed here, so there is nothing to update")
+		e.Next = append(e.Next, "crew update would stop and suggest crew install")
+		return e, nil
+	}
+	if shared := meta.Installation.SharedDir; shared != "" {
+		e.Decisions = append(e.Decisions, fmt.Sprintf("This is an overlay of %s: framework files are updated centrally", displayPath(shared)))
+		e.Next = append(e.Next, "crew update would only sync the overlay with the shared installation")
+		return e, nil
+	}
+	if meta.Installation.Shared {
+		e.Decisions = append(e.Decisions, "This is a shared base installation; updating it changes every overlay that links to it")
+	}
+
+	registry, err := newComponentRegistry()
+	if err != nil {
+		return nil, err
+	}
+	installed := make(map[string]string)
+	for name, comp := range meta.Components {
+		installed[name] = comp.Version
+	}
+	updates := getAvailableUpdates(installed, registry)
+	linked := linkedComponents(installDir)
+
+	names := make([]string, 0, len(installed))
+	for name := range installed {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		item := explainItem{Name: name, Value: installed[name], Source: "up to date"}
+		if source, ok := linked[strings.ToLower(name)]; ok {
+			item.Source = "linked to " + displayPath(source)
+			e.Next = append(e.Next, fmt.Sprintf("skip %s: it is installed with --dev-link and follows its checkout", name))
+		} else if update, ok := updates[name]; ok {
+			item.Source = "update available: " + update["available"]
+			e.Next = append(e.Next, fmt.Sprintf("update %s from %s to %s", name, update["current"], update["available"]))
+		}
+		e.Settings = append(e.Settings, item)
+	}
+
+	if len(updates) == 0 {
+		e.Next = append(e.Next, "report that no updates are available; --reinstall forces a reinstall")
+	} else {
+		e.Decisions = append(e.Decisions, "Without --components, every component with an update is selected; interactively you pick from them")
+		e.Next = append(e.Next, "take a backup first (skip with --no-backup); crew undo restores it")
+		e.Next = append(e.Next, "ask for confirmation unless --yes is given")
+	}
+	e.Paths = append(e.Paths, pathItem("backups", crewdirs.Path(installDir, "backups")))
+	return e, nil
+}
+
+func explainClaudeMD(args []string) (*explanation, error) {
+	installDir := globalFlags.InstallDir
+	dst := filepath.Join(installDir, "CLAUDE.md")
+	e := &explanation{Summary: "how crew manages " + displayPath(dst)}
+	e.Paths = append(e.Paths, pathItem("installed", dst))
+
+	src, err := frameworkCLAUDESource(findSuperCrewSource())
+	if err == nil {
+		e.Paths = append(e.Paths, pathItem("framework", src))
+	} else {
+		e.Decisions = append(e.Decisions, fmt.Sprintf("The framework CLAUDE.md was not found (%v), so what install would change is unknown", err))
+	}
+
+	e.Decisions = append(e.Decisions,
+		"crew install merges: only the sections between crew anchors are replaced and everything else is kept",
+		"--claude-overwrite replaces the whole file; --claude-skip leaves it untouched")
+
+	existing, readErr := os.ReadFile(dst)
+	if os.IsNotExist(readErr) {
+		e.Next = append(e.Next, "crew install would create CLAUDE.md from the framework version")
+		return e, nil
+	}
+	if readErr != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", dst, readErr)
+	}
+
+	if doc, err := claudemd.Parse(string(existing)); err == nil {
+		for _, section := range doc.Sections() {
+			e.Settings = append(e.Settings, explainItem{Name: "section " + section.ID, Value: section.Version, Source: "managed"})
+		}
+	}
+	findings := claudemd.Lint(string(existing), core.FrameworkVersion)
+	for _, f := range findings {
+		e.Decisions = append(e.Decisions, "Lint: "+f.Message)
+	}
+	if len(findings) > 0 {
+		e.Next = append(e.Next, "crew claude --lint-claude-md --fix would repair the findings above")
+	}
+
+	if src != "" {
+		preview, err := newClaudePreview(src, dst)
+		if err != nil {
+			return nil, err
+		}
+		if preview.MergeErr != nil {
+			e.Next = append(e.Next, fmt.Sprintf("crew install could not merge: %v", preview.MergeErr))
+		} else if stats := textdiff.Count(textdiff.Lines(preview.Existing, preview.Merged)); stats.Added == 0 && stats.Removed == 0 {
+			e.Next = append(e.Next, "crew install would leave CLAUDE.md unchanged")
+		} else {
+			e.Next = append(e.Next, fmt.Sprintf("crew install would merge in the framework sections: +%d / -%d lines (see crew install --claude-preview)", stats.Added, stats.Removed))
+		}
+	}
+	return e, nil
+}
+
+func explainComponent(args []string) (*explanation, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("usage: crew explain component <name>")
+	}
+	name := args[0]
+	installDir := globalFlags.InstallDir
+	e := &explanation{Summary: "the state of component " + name}
+
+	registry, err := newComponentRegistry()
+	if err != nil {
+		return nil, err
+	}
+	available := registry.GetComponentMetadata(name)
+	meta := loadInstalledMetadata(installDir)
+	var installed *metadata.ComponentMeta
+	if meta != nil {
+		if comp, ok := meta.Components[name]; ok {
+			installed = &comp
+		}
+	}
+	if available == nil && installed == nil {
+		return nil, fmt.Errorf("unknown component %q; see crew install --list-components", name)
+	}
+
+	if available != nil {
+		e.Settings = append(e.Settings,
+			explainItem{Name: "available", Value: available.Version, Source: "registry"},
+			explainItem{Name: "category", Value: available.Category},
+			explainItem{Name: "description", Value: available.Description})
+		if len(available.Dependencies) > 0 {
+			e.Settings = append(e.Settings, explainItem{Name: "depends on", Value: strings.Join(available.Dependencies, ", ")})
+		}
+		if len(available.Conflicts) > 0 {
+			e.Settings = append(e.Settings, explainItem{Name: "conflicts", Value: strings.Join(available.Conflicts, ", ")})
+		}
+	}
+
+	if installed == nil {
+		e.Settings = append(e.Settings, explainItem{Name: "installed", Value: "no"})
+		e.Next = append(e.Next, fmt.Sprintf("crew install --components %s would install %s", name, available.Version))
+		return e, nil
+	}
+	e.Settings = append(e.Settings,
+		explainItem{Name: "installed", Value: installed.Version, Source: "metadata"})
+	if installed.Status != "" {
+		e.Settings = append(e.Settings, explainItem{Name: "status", Value: installed.Status})
+	}
+	if !installed.UpdatedAt.IsZero() {
+		e.Settings = append(e.Settings, explainItem{Name: "updated", Value: installed.UpdatedAt.Local().Format("2006-01-02 15:04")})
+	}
+
+	guard := &uninstallGuard{installDir: installDir, hashes: meta.Integrity.FileHashes}
+	files, modified := 0, 0
+	for rel, tracked := range meta.Integrity.FileHashes {
+		if !strings.EqualFold(tracked.Component, name) {
+			continue
+		}
+		files++
+		if guard.modified(filepath.Join(installDir, rel)) {
+			modified++
+		}
+	}
+	e.Settings = append(e.Settings, explainItem{Name: "tracked files", Value: fmt.Sprintf("%d (%d modified since install)", files, modified)})
+	if modified > 0 {
+		e.Decisions = append(e.Decisions, "Modified files are moved to .crew/preserved instead of being deleted when the component is uninstalled")
+	}
+
+	if source := installed.LinkedFrom; source != "" {
+		e.Settings = append(e.Settings, explainItem{Name: "linked from", Value: displayPath(source), Source: "--dev-link"})
+		e.Decisions = append(e.Decisions, "The component is symlinked from a checkout, so crew update skips it")
+		e.Next = append(e.Next, "Run crew install --dev-link in the checkout to pick up new files")
+		return e, nil
+	}
+	if available != nil {
+		if versioning.NewVersionManager(installDir).CompareVersions(installed.Version, available.Version) < 0 {
+			e.Next = append(e.Next, fmt.Sprintf("crew update would update it from %s to %s", installed.Version, available.Version))
+		} else {
+			e.Next = append(e.Next, "crew update would leave it alone; crew update --reinstall --components "+name+" reinstalls it")
+		}
+	}
+	return e, nil
+}
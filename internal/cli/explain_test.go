@@ -0,0 +1,33 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExplainUnknownTopic(t *testing.T) {
+	err := runExplain([]string{"nope"}, ExplainFlags{})
+	if err == nil || !strings.Contains(err.Error(), "update-policy") {
+		t.Errorf("Expected an error listing the topics, got %v", err)
+	}
+}
+
+func TestExplainUpdatePolicyWithoutInstallation(t *testing.T) {
+	saved := globalFlags.InstallDir
+	defer func() { globalFlags.InstallDir = saved }()
+	globalFlags.InstallDir = t.TempDir()
+
+	e, err := explainUpdatePolicy(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(e.Next) != 1 || !strings.Contains(e.Next[0], "crew install") {
+		t.Errorf("Expected update to point at crew install, got %v", e.Next)
+	}
+}
+
+func TestExplainComponentNeedsName(t *testing.T) {
+	if _, err := explainComponent(nil); err == nil {
+		t.Error("Expected an error without a component name")
+	}
+}
@@ -0,0 +1,147 @@
+package cli
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// githubActions writes workflow commands and step outputs for a step of a
+// GitHub Actions job, so actions wrapping crew need not parse its output.
+// Its methods do nothing on a nil receiver, so callers need not check
+// whether --github-output is set.
+type githubActions struct {
+	// out receives the workflow commands: groups and annotations
+	out io.Writer
+	// outputFile is the file of $GITHUB_OUTPUT step outputs are appended to
+	outputFile string
+	inGroup    bool
+}
+
+// newGitHubActions returns the workflow command writer of the current step.
+// It fails outside of GitHub Actions, where GITHUB_OUTPUT is not set.
+func newGitHubActions(out io.Writer) (*githubActions, error) {
+	outputFile := os.Getenv("GITHUB_OUTPUT")
+	if outputFile == "" {
+		return nil, errors.New("--github-output needs GITHUB_OUTPUT, which GitHub Actions sets for each step")
+	}
+	return &githubActions{out: out, outputFile: outputFile}, nil
+}
+
+// group starts a collapsible group of log lines, ending the previous one
+func (g *githubActions) group(title string) {
+	if g == nil {
+		return
+	}
+	g.endGroup()
+	fmt.Fprintf(g.out, "::group::%s\n", escapeWorkflowData(title))
+	g.inGroup = true
+}
+
+// endGroup ends the current group, if any
+func (g *githubActions) endGroup() {
+	if g == nil || !g.inGroup {
+		return
+	}
+	fmt.Fprintln(g.out, "::endgroup::")
+	g.inGroup = false
+}
+
+// annotate shows message as an error, warning or notice on the run's summary
+func (g *githubActions) annotate(level, title, message string) {
+	if g == nil {
+		return
+	}
+	fmt.Fprintf(g.out, "::%s title=%s::%s\n", level, escapeWorkflowProperty(title), escapeWorkflowData(message))
+}
+
+// setOutputs appends the step outputs, in key order. Values spanning lines
+// use a random delimiter so they cannot end the value early.
+func (g *githubActions) setOutputs(outputs map[string]string) error {
+	if g == nil {
+		return nil
+	}
+	keys := make([]string, 0, len(outputs))
+	for key := range outputs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, key := range keys {
+		value := outputs[key]
+		if !strings.ContainsAny(value, "\r\n") {
+			fmt.Fprintf(&b, "%s=%s\n", key, value)
+			continue
+		}
+		delimiter, err := outputDelimiter()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(&b, "%s<<%s\n%s\n%s\n", key, delimiter, value, delimiter)
+	}
+
+	f, err := os.OpenFile(g.outputFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open GITHUB_OUTPUT: %w", err)
+	}
+	if _, err := f.WriteString(b.String()); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write step outputs: %w", err)
+	}
+	return f.Close()
+}
+
+func outputDelimiter() (string, error) {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return "CREW_EOF_" + hex.EncodeToString(buf), nil
+}
+
+// escapeWorkflowData escapes the message of a workflow command
+func escapeWorkflowData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+// escapeWorkflowProperty escapes a property value of a workflow command
+func escapeWorkflowProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
+
+// installOutputs are the step outputs of crew install: the outcome, the
+// installed components and their versions, and the install directory
+func installOutputs(installDir, version string, runErr error, dryRun bool) map[string]string {
+	installed := installedComponentVersions(installDir)
+	names := make([]string, 0, len(installed))
+	for name := range installed {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	versions, _ := json.Marshal(installed)
+	if installed == nil {
+		versions = []byte("{}")
+	}
+
+	status := "success"
+	switch {
+	case runErr != nil:
+		status = "failure"
+	case dryRun:
+		status = "dry-run"
+	}
+	return map[string]string{
+		"status":       status,
+		"components":   strings.Join(names, ","),
+		"versions":     string(versions),
+		"install-dir":  installDir,
+		"crew-version": version,
+	}
+}
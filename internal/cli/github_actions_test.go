@@ -0,0 +1,72 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+)
+
+func TestGitHubActionsNeedsOutputFile(t *testing.T) {
+	t.Setenv("GITHUB_OUTPUT", "")
+	if _, err := newGitHubActions(&bytes.Buffer{}); err == nil {
+		t.Error("newGitHubActions without GITHUB_OUTPUT = nil error")
+	}
+}
+
+func TestGitHubActionsCommands(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "output")
+	t.Setenv("GITHUB_OUTPUT", outputFile)
+	var out bytes.Buffer
+	step, err := newGitHubActions(&out)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	step.group("Resolve components")
+	step.group("Install components")
+	step.annotate("error", "crew: install", "failed\n50% done")
+	step.endGroup()
+	step.endGroup()
+
+	want := "::group::Resolve components\n::endgroup::\n::group::Install components\n" +
+		"::error title=crew%3A install::failed%0A50%25 done\n::endgroup::\n"
+	if out.String() != want {
+		t.Errorf("workflow commands =\n%s\nwant\n%s", out.String(), want)
+	}
+
+	if err := step.setOutputs(map[string]string{
+		"versions":   `{"core":"1.0.0"}`,
+		"components": "core",
+		"notes":      "line one\nline two",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(outputFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pattern := regexp.MustCompile("^components=core\nnotes<<(CREW_EOF_[0-9a-f]+)\nline one\nline two\n(CREW_EOF_[0-9a-f]+)\nversions=\\{\"core\":\"1.0.0\"\\}\n$")
+	match := pattern.FindStringSubmatch(string(data))
+	if match == nil || match[1] != match[2] {
+		t.Errorf("step outputs =\n%s", data)
+	}
+}
+
+func TestGitHubActionsNil(t *testing.T) {
+	var step *githubActions
+	step.group("ignored")
+	step.annotate("warning", "ignored", "ignored")
+	step.endGroup()
+	if err := step.setOutputs(map[string]string{"status": "success"}); err != nil {
+		t.Errorf("setOutputs on nil = %v", err)
+	}
+}
+
+func TestInstallOutputs(t *testing.T) {
+	outputs := installOutputs(t.TempDir(), "1.2.3", nil, true)
+	if outputs["status"] != "dry-run" || outputs["components"] != "" || outputs["versions"] != "{}" || outputs["crew-version"] != "1.2.3" {
+		t.Errorf("outputs of an empty dry run = %v", outputs)
+	}
+}
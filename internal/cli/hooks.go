@@ -2,15 +2,20 @@
 package cli
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/fatih/color"
+	"github.com/jonwraymond/claude-code-super-crew/internal/claudesettings"
 	"github.com/jonwraymond/claude-code-super-crew/internal/hooks"
+	"github.com/jonwraymond/claude-code-super-crew/internal/pathsutil"
+	"github.com/jonwraymond/claude-code-super-crew/internal/ui"
 	"github.com/jonwraymond/claude-code-super-crew/pkg/logger"
 	"github.com/spf13/cobra"
 )
@@ -22,6 +27,13 @@ func NewHooksCommand() *cobra.Command {
 		disableHook string
 		listHooks   bool
 		installHooksOnly bool
+		newHook     string
+		newHookEvent string
+		validateHooks bool
+		projectScope bool
+		runHook     string
+		traceHook   bool
+		removeFromSettings bool
 	)
 
 	cmd := &cobra.Command{
@@ -34,9 +46,26 @@ Hooks allow you to automate tasks like:
 - Running linters on file save
 - Running tests on code changes
 - Scanning for security vulnerabilities
-- Creating backups before modifications`,
+- Creating backups before modifications
+
+Lifecycle hooks run scripts around crew operations (pre-install,
+post-update, pre-backup, ...). Use --new to scaffold one and --validate
+to lint the descriptors in ~/.claude/.crew/hooks.`,
+		Annotations: map[string]string{auditAnnotation: "enable,disable,install-only,new,remove-from-settings"},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runHooksInteractive(cmd, args, enableHook, disableHook, listHooks, installHooksOnly)
+			if newHook != "" {
+				return scaffoldLifecycleHook(newHook, newHookEvent)
+			}
+			if validateHooks {
+				return validateLifecycleHooks()
+			}
+			if runHook != "" {
+				return runSingleHook(runHook, traceHook)
+			}
+			if removeFromSettings {
+				return removeCrewHooksFromSettings(globalFlags.InstallDir)
+			}
+			return runHooksInteractive(cmd, args, enableHook, disableHook, listHooks, installHooksOnly, projectScope)
 		},
 	}
 	
@@ -44,23 +73,44 @@ Hooks allow you to automate tasks like:
 	cmd.Flags().StringVar(&disableHook, "disable", "", "Disable a specific hook")
 	cmd.Flags().BoolVar(&listHooks, "list", false, "List all available hooks")
 	cmd.Flags().BoolVar(&installHooksOnly, "install-only", false, "Only install hook scripts without configuration")
+	cmd.Flags().StringVar(&newHook, "new", "", "Scaffold a new lifecycle hook script and descriptor")
+	cmd.Flags().StringVar(&newHookEvent, "event", string(hooks.PostInstall), "Lifecycle event for --new")
+	cmd.Flags().BoolVar(&validateHooks, "validate", false, "Validate lifecycle hook descriptors and scripts")
+	cmd.Flags().BoolVar(&projectScope, "project", false, "Apply --enable/--disable to the current project via .claude/hooks.json")
+	cmd.Flags().StringVar(&runHook, "run", "", "Run a single hook by name (with --dry-run, only show how it would run)")
+	cmd.Flags().BoolVar(&traceHook, "trace", false, "With --run, stream timestamped output and report exit status")
+	cmd.Flags().BoolVar(&removeFromSettings, "remove-from-settings", false, "Remove all crew hook entries from Claude settings, keeping user hooks")
+
+	registerFlagCompletions(cmd, map[string]completionFunc{
+		"enable":  completeHookNames,
+		"disable": completeHookNames,
+		"run":     completeHookNames,
+		"event":   completeLifecycleEvents,
+	})
 
 	return cmd
 }
 
-func runHooksInteractive(cmd *cobra.Command, args []string, enableHook, disableHook string, listHooks, installHooksOnly bool) error {
+func runHooksInteractive(cmd *cobra.Command, args []string, enableHook, disableHook string, listHooks, installHooksOnly, projectScope bool) error {
 	lg := logger.GetLogger()
 	
 	// Get project root
 	projectRoot, err := findProjectRoot()
+	inProject := err == nil
 	if err != nil {
 		// Use SuperCrew installation directory as fallback
 		homeDir, _ := os.UserHomeDir()
 		projectRoot = homeDir
 	}
 
+	if projectScope && !inProject {
+		return fmt.Errorf("--project requires running inside a project (no .git or go.mod found)")
+	}
+
 	// Create hook manager
 	hm := hooks.NewHookManager(projectRoot)
+	hm.SetInstallDir(globalFlags.InstallDir)
+	hm.SetProjectOverlay(inProject)
 	
 	// Discover available hooks
 	if err := hm.DiscoverHooks(); err != nil {
@@ -73,10 +123,16 @@ func runHooksInteractive(cmd *cobra.Command, args []string, enableHook, disableH
 	}
 
 	if enableHook != "" {
+		if projectScope {
+			return hm.SetProjectOverride(enableHook, true)
+		}
 		return hm.EnableHook(enableHook)
 	}
 
 	if disableHook != "" {
+		if projectScope {
+			return hm.SetProjectOverride(disableHook, false)
+		}
 		return hm.DisableHook(disableHook)
 	}
 
@@ -87,6 +143,9 @@ func runHooksInteractive(cmd *cobra.Command, args []string, enableHook, disableH
 	}
 
 	// Interactive mode
+	if ui.NonInteractive() {
+		return fmt.Errorf("choose an action such as --list, --enable or --disable: %w", ui.ErrInputRequired)
+	}
 	return runInteractiveHookManager(hm, lg)
 }
 
@@ -160,8 +219,8 @@ func listAvailableHooks(hm *hooks.HookManager) error {
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
 	
 	// Header
-	fmt.Fprintln(w, "NAME\tSTATUS\tTYPE\tDESCRIPTION")
-	fmt.Fprintln(w, "----\t------\t----\t-----------")
+	fmt.Fprintln(w, "NAME\tSTATUS\tORIGIN\tTYPE\tDESCRIPTION")
+	fmt.Fprintln(w, "----\t------\t------\t----\t-----------")
 	
 	// Hooks
 	for _, hook := range hooks {
@@ -170,9 +229,10 @@ func listAvailableHooks(hm *hooks.HookManager) error {
 			status = color.GreenString("enabled")
 		}
 		
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", 
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", 
 			hook.Name, 
 			status,
+			hook.Origin,
 			hook.Type,
 			hook.Description,
 		)
@@ -319,34 +379,192 @@ func configureHooksInteractive(hm *hooks.HookManager, lg logger.Logger) error {
 	return nil
 }
 
-// findProjectRoot attempts to find the project root directory
-func findProjectRoot() (string, error) {
-	// Start from current directory
-	dir, err := os.Getwd()
+func scaffoldLifecycleHook(name, event string) error {
+	lg := logger.GetLogger()
+
+	if !hooks.IsValidLifecycleEvent(event) {
+		valid := make([]string, len(hooks.LifecycleEvents))
+		for i, e := range hooks.LifecycleEvents {
+			valid[i] = string(e)
+		}
+		return fmt.Errorf("unknown event %q (valid: %s)", event, strings.Join(valid, ", "))
+	}
+
+	hooksDir := getLifecycleHooksDir()
+	if globalFlags.DryRun {
+		lg.Infof("[DRY RUN] Would create %s and %s in %s", name+".json", name+".sh", hooksDir)
+		return nil
+	}
+
+	descriptorPath, scriptPath, err := hooks.ScaffoldLifecycleHook(hooksDir, name, hooks.LifecycleEvent(event))
 	if err != nil {
-		return "", err
+		return err
 	}
 
-	// Look for .git directory or go.mod file
-	for {
-		// Check for .git directory
-		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
-			return dir, nil
+	lg.Successf("Created lifecycle hook: %s", name)
+	lg.Infof("Descriptor: %s", descriptorPath)
+	lg.Infof("Script: %s", scriptPath)
+	return nil
+}
+
+func validateLifecycleHooks() error {
+	lg := logger.GetLogger()
+	hooksDir := getLifecycleHooksDir()
+
+	descriptors, err := filepath.Glob(filepath.Join(hooksDir, "*.json"))
+	if err != nil {
+		return fmt.Errorf("failed to list hook descriptors: %w", err)
+	}
+
+	if len(descriptors) == 0 {
+		lg.Infof("No lifecycle hooks found in %s", hooksDir)
+		return nil
+	}
+
+	failed := 0
+	for _, path := range descriptors {
+		name := strings.TrimSuffix(filepath.Base(path), ".json")
+
+		var issues []string
+		hook, err := hooks.LoadLifecycleHook(path)
+		if err != nil {
+			issues = []string{err.Error()}
+		} else {
+			issues = hooks.ValidateLifecycleHook(hook)
 		}
 
-		// Check for go.mod file
-		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
-			return dir, nil
+		if len(issues) == 0 {
+			fmt.Printf("%s %s\n", color.GreenString(ui.Icons.Check), name)
+			continue
+		}
+
+		failed++
+		fmt.Printf("%s %s\n", color.RedString(ui.Icons.Cross), name)
+		for _, issue := range issues {
+			fmt.Printf("    - %s\n", issue)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d lifecycle hooks failed validation", failed, len(descriptors))
+	}
+
+	lg.Successf("All %d lifecycle hooks are valid", len(descriptors))
+	return nil
+}
+
+// resolveHookPlan finds a lifecycle hook or Claude Code hook by name and
+// returns its execution plan; lifecycle hooks take precedence
+func resolveHookPlan(name string) (*hooks.ExecutionPlan, error) {
+	runtime := hooks.NewRuntime(getLifecycleHooksDir())
+	if err := runtime.Load(); err != nil {
+		return nil, err
+	}
+	runtime.SetEnv("CREW_INSTALL_DIR", globalFlags.InstallDir)
+
+	if hook, ok := runtime.FindHook(name); ok {
+		return runtime.PlanHook(hook, nil)
+	}
+
+	projectRoot, err := findProjectRoot()
+	inProject := err == nil
+	if !inProject {
+		projectRoot, _ = os.UserHomeDir()
+	}
+
+	hm := hooks.NewHookManager(projectRoot)
+	hm.SetInstallDir(globalFlags.InstallDir)
+	hm.SetProjectOverlay(inProject)
+	if err := hm.DiscoverHooks(); err != nil {
+		return nil, fmt.Errorf("failed to discover hooks: %w", err)
+	}
+
+	return hm.PlanHook(name)
+}
+
+func runSingleHook(name string, trace bool) error {
+	plan, err := resolveHookPlan(name)
+	if err != nil {
+		return err
+	}
+
+	if globalFlags.DryRun || trace {
+		fmt.Printf("%s %s\n", color.CyanString("Hook:"), plan.Name)
+		fmt.Printf("%s %s\n", color.CyanString("Command:"), plan.CommandLine())
+		fmt.Printf("%s %s\n", color.CyanString("Working directory:"), plan.Dir)
+		fmt.Printf("%s %s\n", color.CyanString("Timeout:"), plan.Timeout)
+		if plan.Stdin != "" {
+			fmt.Printf("%s %s\n", color.CyanString("Stdin:"), plan.Stdin)
+		}
+		fmt.Println(color.CyanString("Environment:"))
+		for _, v := range plan.EnvList() {
+			fmt.Printf("  %s\n", v)
+		}
+		if _, err := os.Stat(plan.Command); err != nil {
+			fmt.Printf("%s script not found: %s\n", color.YellowString("Warning:"), plan.Command)
 		}
+	}
+
+	if globalFlags.DryRun {
+		return nil
+	}
 
-		// Move up one directory
-		parent := filepath.Dir(dir)
-		if parent == dir {
-			// Reached root
-			break
+	if trace {
+		fmt.Println(color.CyanString("Output:"))
+		result, err := hooks.Trace(context.Background(), plan, os.Stdout)
+		fmt.Printf("%s exit code %d in %s\n", color.CyanString("Finished:"), result.ExitCode, result.Duration.Round(time.Millisecond))
+		if err != nil {
+			return fmt.Errorf("hook %s failed: %w", name, err)
 		}
-		dir = parent
+		return nil
 	}
 
+	if _, err := hooks.Execute(context.Background(), plan, os.Stdout, os.Stderr); err != nil {
+		return fmt.Errorf("hook %s failed: %w", name, err)
+	}
+	logger.GetLogger().Successf("Hook %s completed", name)
+	return nil
+}
+
+// removeCrewHooksFromSettings strips crew-managed hook entries from the
+// Claude settings file of the installation in installDir while leaving
+// user-defined hooks and other settings intact
+func removeCrewHooksFromSettings(installDir string) error {
+	settingsPath := filepath.Join(installDir, claudesettings.FileName)
+	hm := hooks.NewHookManager("")
+	hm.SetInstallDir(installDir)
+	hm.SetProjectOverlay(false)
+	if err := hm.DiscoverHooks(); err != nil {
+		return fmt.Errorf("failed to discover hooks: %w", err)
+	}
+
+	if globalFlags.DryRun {
+		logger.GetLogger().Infof("[DRY RUN] Would remove crew hooks from %s", settingsPath)
+		return nil
+	}
+
+	removed, err := hm.RemoveFromClaudeSettings(settingsPath)
+	if err != nil {
+		return fmt.Errorf("failed to remove hooks from settings: %w", err)
+	}
+
+	if removed == 0 {
+		logger.GetLogger().Infof("No crew hooks found in %s", settingsPath)
+	} else {
+		logger.GetLogger().Infof("Removed %d crew hook entries from %s", removed, settingsPath)
+	}
+	return nil
+}
+
+// findProjectRoot returns the nearest directory from the current one up
+// that has a .git or go.mod
+func findProjectRoot() (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	if root, ok := pathsutil.FindUp(dir, pathsutil.GitDirName, "go.mod"); ok {
+		return root, nil
+	}
 	return "", fmt.Errorf("project root not found")
 }
\ No newline at end of file
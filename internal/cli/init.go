@@ -0,0 +1,256 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/cache"
+	"github.com/jonwraymond/claude-code-super-crew/internal/crewdirs"
+	"github.com/jonwraymond/claude-code-super-crew/internal/project"
+	"github.com/jonwraymond/claude-code-super-crew/internal/templates"
+	"github.com/jonwraymond/claude-code-super-crew/internal/ui"
+	"github.com/jonwraymond/claude-code-super-crew/pkg/logger"
+	"github.com/spf13/cobra"
+)
+
+// InitFlags holds init command flags
+type InitFlags struct {
+	Template   string
+	List       bool
+	ProjectDir string
+	JSON       bool
+}
+
+var initFlags InitFlags
+
+// NewInitCommand creates the init command
+func NewInitCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "init",
+		Short: "Bootstrap a project's .claude directory from a template",
+		Long: `Set up a new project from a template: an orchestrator with routing for the
+kind of project, suggested specialists, shadow commands and a CLAUDE.md
+skeleton.
+
+Templates ship with crew. To change one or add your own, create a directory
+of the same shape in the user templates directory (shown by --list); a user
+template replaces the built-in template of the same name. A template holds
+template.yaml (name, description, specialists), a claude/ tree copied to
+.claude and files for the project root such as CLAUDE.md. Files ending in
+.tmpl are rendered with Go templates and can use {{.ProjectName}},
+{{.ProjectDir}}, {{.Template}} and {{.Specialists}}.
+
+Existing files are kept unless --force is given.
+
+Examples:
+  crew init --list                       # Show available templates
+  crew init --template go-cli            # Bootstrap the current directory
+  crew init --template library --project-dir ../mylib --dry-run`,
+		Args:         cobra.NoArgs,
+		RunE:         runInit,
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().StringVarP(&initFlags.Template, "template", "t", "",
+		"Template to bootstrap the project from")
+	cmd.Flags().BoolVar(&initFlags.List, "list", false,
+		"List available templates")
+	cmd.Flags().StringVar(&initFlags.ProjectDir, "project-dir", "",
+		"Project directory (default: current directory)")
+	cmd.Flags().BoolVar(&initFlags.JSON, "json", false,
+		"Output the template list or created files as JSON")
+
+	registerFlagCompletions(cmd, map[string]completionFunc{
+		"template": completeTemplates,
+		"project-dir": func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+			return nil, cobra.ShellCompDirectiveFilterDirs
+		},
+	})
+
+	return cmd
+}
+
+// userTemplatesDir returns where user templates are looked up
+func userTemplatesDir() string {
+	return crewdirs.Path(getGlobalInstallDir(), "config", "templates")
+}
+
+func completeTemplates(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	silenceCompletionLogs()
+	list, err := templates.List(userTemplatesDir())
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var names []string
+	for _, t := range list {
+		names = append(names, t.Name+"\t"+t.Description)
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+func runInit(cmd *cobra.Command, args []string) error {
+	log := logger.GetLogger()
+	log.SetVerbose(globalFlags.Verbose)
+	log.SetQuiet(globalFlags.Quiet)
+
+	jsonOutput := initFlags.JSON || globalFlags.Output == "json"
+
+	if initFlags.List || initFlags.Template == "" {
+		return listTemplates(jsonOutput)
+	}
+
+	tmpl, err := templates.Find(userTemplatesDir(), initFlags.Template)
+	if err != nil {
+		return err
+	}
+
+	projectDir := initFlags.ProjectDir
+	if projectDir == "" {
+		if projectDir, err = os.Getwd(); err != nil {
+			return fmt.Errorf("failed to get current directory: %w", err)
+		}
+	}
+	if projectDir, err = filepath.Abs(projectDir); err != nil {
+		return fmt.Errorf("invalid project directory: %w", err)
+	}
+
+	files, err := renderTemplate(tmpl, templates.Data{
+		ProjectName: filepath.Base(projectDir),
+		ProjectDir:  projectDir,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to render template %s: %w", tmpl.Name, err)
+	}
+
+	result := initResult{Template: tmpl.Name, ProjectDir: projectDir, DryRun: globalFlags.DryRun}
+	for _, f := range files {
+		dst := filepath.Join(projectDir, f.Path)
+		if _, err := os.Stat(dst); err == nil && !globalFlags.Force {
+			result.Skipped = append(result.Skipped, f.Path)
+			continue
+		}
+		result.Created = append(result.Created, f.Path)
+		if globalFlags.DryRun {
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+			return fmt.Errorf("failed to create %s: %w", filepath.Dir(dst), err)
+		}
+		if err := os.WriteFile(dst, f.Content, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", dst, err)
+		}
+	}
+
+	// Record the suggested specialists for /crew:onboard to refine
+	if _, err := project.LoadConfig(projectDir); os.IsNotExist(err) {
+		result.Created = append(result.Created, filepath.Join(".claude", project.ConfigFile))
+		if !globalFlags.DryRun {
+			cfg := project.NewConfig(projectDir, "")
+			cfg.Specialists = tmpl.Specialists
+			if err := cfg.Save(projectDir); err != nil {
+				return err
+			}
+		}
+	}
+
+	if jsonOutput {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	}
+	displayInitResult(result)
+	return nil
+}
+
+// initResult is what init created from a template
+type initResult struct {
+	Template   string   `json:"template"`
+	ProjectDir string   `json:"project_dir"`
+	DryRun     bool     `json:"dry_run,omitempty"`
+	Created    []string `json:"created"`
+	Skipped    []string `json:"skipped,omitempty"`
+}
+
+func displayInitResult(result initResult) {
+	verb := "Created"
+	if result.DryRun {
+		verb = "Would create"
+	}
+	for _, path := range result.Created {
+		fmt.Printf("  %s %s %s\n", ui.Icons.Success, verb, path)
+	}
+	for _, path := range result.Skipped {
+		fmt.Printf("  %s Kept existing %s\n", ui.Icons.Bullet, path)
+	}
+	if len(result.Skipped) > 0 {
+		fmt.Printf("%sUse --force to overwrite existing files%s\n", ui.ColorYellow, ui.ColorReset)
+	}
+	if result.DryRun {
+		return
+	}
+
+	ui.DisplaySuccess(fmt.Sprintf("Project initialized from the %s template", result.Template))
+	fmt.Printf("\n%sNext steps:%s\n", ui.ColorCyan, ui.ColorReset)
+	fmt.Println("1. Run 'crew claude --install' to enable the /crew: commands")
+	fmt.Println("2. Review CLAUDE.md and the agents in .claude/agents")
+	fmt.Println("3. Run '/crew:onboard' in Claude Code to tailor them to the code")
+}
+
+func listTemplates(jsonOutput bool) error {
+	userDir := userTemplatesDir()
+	list, err := templates.List(userDir)
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(list)
+	}
+
+	fmt.Printf("\n%s%sProject Templates%s\n", ui.ColorCyan, ui.ColorBright, ui.ColorReset)
+	var rows [][]string
+	for _, t := range list {
+		rows = append(rows, []string{t.Name, t.Description, t.Source})
+	}
+	ui.DisplayTable([]string{"Template", "Description", "Source"}, rows, "")
+	fmt.Printf("\nUser templates: %s\n", userDir)
+	fmt.Println("Run 'crew init --template <name>' to bootstrap the current directory")
+	return nil
+}
+
+// renderTemplate renders tmpl with data through the templates cache, which
+// keeps the files of each template version and data rendered before
+func renderTemplate(tmpl *templates.Template, data templates.Data) ([]templates.File, error) {
+	c := openCache()
+	key := ""
+	if digest, err := tmpl.Digest(); err == nil {
+		if encoded, err := json.Marshal(data); err == nil {
+			key = digest + " " + string(encoded)
+		}
+	}
+	if key != "" {
+		if cached, ok := c.Get(cache.Templates, key); ok {
+			var files []templates.File
+			if err := json.Unmarshal(cached, &files); err == nil {
+				return files, nil
+			}
+		}
+	}
+
+	files, err := tmpl.Render(data)
+	if err != nil {
+		return nil, err
+	}
+	if key != "" {
+		if encoded, err := json.Marshal(files); err == nil {
+			if err := c.Put(cache.Templates, key, encoded); err != nil {
+				logger.GetLogger().Debugf("Failed to cache template %s: %v", tmpl.Name, err)
+			}
+		}
+	}
+	return files, nil
+}
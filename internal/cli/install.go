@@ -3,16 +3,33 @@ package cli
 import (
 	"archive/tar"
 	"compress/gzip"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"time"
 
+	"github.com/jonwraymond/claude-code-super-crew/internal/audit"
+	"github.com/jonwraymond/claude-code-super-crew/internal/buildinfo"
+	"github.com/jonwraymond/claude-code-super-crew/internal/claudemd"
 	"github.com/jonwraymond/claude-code-super-crew/internal/core"
+	"github.com/jonwraymond/claude-code-super-crew/internal/crewdirs"
+	"github.com/jonwraymond/claude-code-super-crew/internal/crewignore"
+	"github.com/jonwraymond/claude-code-super-crew/internal/events"
+	"github.com/jonwraymond/claude-code-super-crew/internal/fileops"
+	"github.com/jonwraymond/claude-code-super-crew/internal/findings"
+	"github.com/jonwraymond/claude-code-super-crew/internal/i18n"
 	"github.com/jonwraymond/claude-code-super-crew/internal/managers"
+	"github.com/jonwraymond/claude-code-super-crew/internal/platform"
+	"github.com/jonwraymond/claude-code-super-crew/internal/semver"
+	"github.com/jonwraymond/claude-code-super-crew/internal/toolinstall"
 	"github.com/jonwraymond/claude-code-super-crew/internal/ui"
 	"github.com/jonwraymond/claude-code-super-crew/internal/versioning"
 	"github.com/jonwraymond/claude-code-super-crew/pkg/logger"
@@ -27,14 +44,34 @@ type InstallFlags struct {
 	Components      []string
 	NoBackup        bool
 	ListComponents  bool
+	Tags            []string
+	Category        string
+	Experimental    bool
+	JSON            bool
 	Diagnose        bool
+	InstallMissing  bool
 	ClaudeMerge     bool
 	ClaudeOverwrite bool
 	ClaudeSkip      bool
+	ClaudePreview   bool
+	MCPServers      []string
+	ForceReinstall  bool
+	SharedBase      bool
+	Overlay         string
+	DevLink         bool
+	PlanOut         string
+	GitHubOutput    bool
+
+	// items are the item patterns --components limits components to,
+	// resolved by runInstall or read from a plan
+	items map[string][]string
 }
 
 var installFlags InstallFlags
 
+// githubStep reports the install to GitHub Actions with --github-output
+var githubStep *githubActions
+
 // NewInstallCommand creates the install command
 func NewInstallCommand() *cobra.Command {
 	cmd := &cobra.Command{
@@ -47,10 +84,29 @@ Examples:
   crew install --quick --dry-run        # Quick installation (dry-run)
   crew install --profile developer      # Developer profile  
   crew install --components core mcp    # Specific components
+  crew install --components @recommended         # Components tagged recommended
+  crew install --components "agents/*","commands/git*"  # Some of a component's items
   crew install --verbose --force        # Verbose with force mode
   crew install --claude-merge           # Merge existing CLAUDE.md
-  crew install --claude-skip --yes      # Auto-install, keep existing CLAUDE.md`,
-		RunE: runInstall,
+  crew install --claude-skip --yes      # Auto-install, keep existing CLAUDE.md
+  crew install --claude-preview         # Diff CLAUDE.md merge/overwrite results
+  crew install --diagnose --install-missing  # Install missing tools with the package manager
+  crew install --list-components --tag agents --json
+  crew install --tag agents --yes       # Install every component tagged agents
+  crew install --force-reinstall        # Reinstall even if nothing changed
+  crew install --install-dir /opt/supercrew --shared-base   # Shared framework (admin)
+  crew install --overlay /opt/supercrew # Per-user overlay of a shared framework
+  crew install --dev-link               # Symlink files from a source checkout (framework development)
+  crew install --quick --plan-out plan.json  # Write the resolved plan for 'crew apply'
+  crew install --quick --ci --github-output  # In a GitHub Actions step
+
+With --github-output the log is split into collapsible groups, failures are
+annotated, and the status, components, versions (a JSON object),
+install-dir and crew-version step outputs are written to $GITHUB_OUTPUT.
+
+For a guided first-time setup, run 'crew setup' instead.`,
+		Annotations: map[string]string{auditAnnotation: auditAlways},
+		RunE:        interruptible(runInstallStep),
 	}
 
 	// Register install flags
@@ -61,13 +117,23 @@ Examples:
 	cmd.Flags().StringVar(&installFlags.Profile, "profile", "",
 		"Installation profile (quick, minimal, developer, etc.)")
 	cmd.Flags().StringSliceVar(&installFlags.Components, "components", nil,
-		"Specific components to install")
+		"Components to install: names, globs, @groups (tags or categories, e.g. @recommended) or component/glob for some of a component's items")
 	cmd.Flags().BoolVar(&installFlags.NoBackup, "no-backup", false,
 		"Skip backup creation")
 	cmd.Flags().BoolVar(&installFlags.ListComponents, "list-components", false,
 		"List available components and exit")
+	cmd.Flags().StringSliceVar(&installFlags.Tags, "tag", nil,
+		"Only list or install components with all of these tags (e.g. agents, requires-node)")
+	cmd.Flags().StringVar(&installFlags.Category, "category", "",
+		"Only list or install components in this category")
+	cmd.Flags().BoolVar(&installFlags.Experimental, "experimental", false,
+		"Include components tagged experimental")
+	cmd.Flags().BoolVar(&installFlags.JSON, "json", false,
+		"Print --list-components output as JSON")
 	cmd.Flags().BoolVar(&installFlags.Diagnose, "diagnose", false,
 		"Run system diagnostics and show installation help")
+	cmd.Flags().BoolVar(&installFlags.InstallMissing, "install-missing", false,
+		"Run diagnostics, then install the missing tools with the system package manager (brew, apt, dnf, pacman or winget) after confirmation")
 
 	// CLAUDE.md handling flags
 	cmd.Flags().BoolVar(&installFlags.ClaudeMerge, "claude-merge", false,
@@ -76,34 +142,86 @@ Examples:
 		"Overwrite existing CLAUDE.md with new version")
 	cmd.Flags().BoolVar(&installFlags.ClaudeSkip, "claude-skip", false,
 		"Skip CLAUDE.md installation if it already exists")
+	cmd.Flags().BoolVar(&installFlags.ClaudePreview, "claude-preview", false,
+		"Show what merging or overwriting the existing CLAUDE.md would change, then exit")
+
+	cmd.Flags().StringSliceVar(&installFlags.MCPServers, "mcp-servers", nil,
+		"Optional MCP servers to install alongside the required ones")
+	cmd.Flags().BoolVar(&installFlags.ForceReinstall, "force-reinstall", false,
+		"Reinstall components even if the installed versions match")
+
+	// Shared installations
+	cmd.Flags().BoolVar(&installFlags.SharedBase, "shared-base", false,
+		"Install a shared, read-only framework that users overlay (may be outside the user profile)")
+	cmd.Flags().StringVar(&installFlags.Overlay, "overlay", "",
+		"Link the install directory to the shared framework in this directory instead of copying it")
+	cmd.MarkFlagsMutuallyExclusive("shared-base", "overlay")
+
+	// Framework development
+	cmd.Flags().BoolVar(&installFlags.DevLink, "dev-link", false,
+		"Symlink component files from the source checkout instead of copying them, so edits show up immediately")
+	cmd.MarkFlagsMutuallyExclusive("dev-link", "shared-base")
+	cmd.MarkFlagsMutuallyExclusive("dev-link", "overlay")
+	cmd.MarkFlagDirname("overlay")
+
+	cmd.Flags().StringVar(&installFlags.PlanOut, "plan-out", "",
+		"Write the resolved plan as JSON to this file (- for stdout) without installing; run it later with 'crew apply'")
+	cmd.MarkFlagFilename("plan-out", "json")
+	cmd.MarkFlagsMutuallyExclusive("plan-out", "overlay")
+
+	cmd.Flags().BoolVar(&installFlags.GitHubOutput, "github-output", false,
+		"Write the installed components, versions and install directory to $GITHUB_OUTPUT and group the log for GitHub Actions")
+	cmd.MarkFlagsMutuallyExclusive("github-output", "plan-out")
+
+	registerFlagCompletions(cmd, map[string]completionFunc{
+		"components":  completeAvailableComponents,
+		"tag":         completeComponentTags,
+		"category":    completeComponentCategories,
+		"profile":     completeStatic("quick", "minimal", "developer"),
+		"mcp-servers": completeMCPServers,
+	})
 
 	return cmd
 }
 
+// runInstallStep runs the install and, with --github-output, reports its
+// outcome to GitHub Actions
+func runInstallStep(cmd *cobra.Command, args []string) error {
+	if !installFlags.GitHubOutput {
+		return runInstall(cmd, args)
+	}
+	step, err := newGitHubActions(os.Stdout)
+	if err != nil {
+		return err
+	}
+	githubStep = step
+	defer func() { githubStep = nil }()
+
+	runErr := runInstall(cmd, args)
+	step.endGroup()
+	if runErr != nil {
+		step.annotate("error", "crew install failed", runErr.Error())
+	}
+	outputs := installOutputs(globalFlags.InstallDir, buildinfo.Get().Version, runErr, globalFlags.DryRun)
+	if err := step.setOutputs(outputs); err != nil && runErr == nil {
+		return err
+	}
+	return runErr
+}
+
 func runInstall(cmd *cobra.Command, args []string) error {
 	log := logger.GetLogger()
 	gFlags := GetGlobalFlags()
 	log.SetVerbose(gFlags.Verbose)
-	log.SetQuiet(gFlags.Quiet)
+	log.SetQuiet(gFlags.Quiet || gFlags.Output == "json" || installFlags.PlanOut == "-")
 
 	// Validate installation directory (skip in test mode)
-	if !testMode {
-		expectedHome := filepath.Join(os.Getenv("HOME"))
-		if expectedHome == "" {
-			expectedHome = filepath.Join(os.Getenv("USERPROFILE")) // Windows
-		}
-		actualDir, _ := filepath.Abs(gFlags.InstallDir)
-
-		if !strings.HasPrefix(actualDir, expectedHome) {
-			ui.DisplayError("Installation must be inside your user profile directory.")
-			fmt.Printf("    Expected prefix: %s\n", expectedHome)
-			fmt.Printf("    Provided path:   %s\n", actualDir)
-			return fmt.Errorf("invalid installation directory")
-		}
+	if err := validateInstallDir(gFlags.InstallDir, installFlags.SharedBase); err != nil {
+		return err
 	}
 
-	// Display header
-	if !gFlags.Quiet {
+	// Display header (but keep --list-components --json and --plan-out - parseable)
+	if showDecorations() && !(installFlags.ListComponents && installFlags.JSON) && installFlags.PlanOut != "-" {
 		ui.DisplayHeader(
 			"Claude Code Super Crew Installation v1.0",
 			"Installing Claude Code Super Crew framework components",
@@ -112,23 +230,38 @@ func runInstall(cmd *cobra.Command, args []string) error {
 
 	// Handle special modes
 	if installFlags.ListComponents {
-		return listAvailableComponents()
+		return listAvailableComponents(installFlags)
+	}
+
+	if installFlags.Diagnose || installFlags.InstallMissing {
+		return runSystemDiagnostics(installFlags.InstallMissing, findings.FormatText)
 	}
 
-	if installFlags.Diagnose {
-		return runSystemDiagnostics()
+	if installFlags.ClaudePreview {
+		return showClaudePreview(gFlags.InstallDir, true)
+	}
+
+	// An overlay links to the shared framework; copying components into it
+	// would write through the links
+	if installFlags.Overlay != "" {
+		return installOverlay(gFlags.InstallDir, installFlags.Overlay)
+	}
+	if sharedDir := overlaySharedDir(gFlags.InstallDir); sharedDir != "" {
+		return fmt.Errorf("%s is an overlay of the shared installation in %s; run 'crew update' to sync it or 'crew uninstall' to remove it",
+			gFlags.InstallDir, sharedDir)
 	}
 
 	// Initialize components
+	githubStep.group("Resolve components")
 	log.Info("Initializing installation system...")
 
 	// Get project root (parent of cmd directory)
 	exe, _ := os.Executable()
 	projectRoot := filepath.Dir(filepath.Dir(filepath.Dir(exe)))
 
-	registry := core.NewEnhancedComponentRegistry(filepath.Join(projectRoot, "setup", "components"))
-	if err := registry.DiscoverComponents(); err != nil {
-		return fmt.Errorf("failed to discover components: %w", err)
+	registry, err := newComponentRegistry()
+	if err != nil {
+		return err
 	}
 
 	configManager, err := managers.NewConfigManager(filepath.Join(projectRoot, "config"), "")
@@ -157,6 +290,13 @@ func runInstall(cmd *cobra.Command, args []string) error {
 		log.Error("No components selected for installation")
 		return fmt.Errorf("no components selected")
 	}
+	if len(installFlags.Components) > 0 && !contains(installFlags.Components, "all") {
+		selection, err := registry.SelectComponents(installFlags.Components, installFlags.Experimental)
+		if err != nil {
+			return err
+		}
+		installFlags.items = selection.Items
+	}
 
 	// Validate components exist in registry
 	availableComponents := registry.ListComponents()
@@ -180,8 +320,30 @@ func runInstall(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("conflicting flags: --quick and --minimal")
 	}
 
+	// Resolve versions, conflicts and cycles before anything is changed
+	plan, err := registry.Solve(components, installedComponentVersions(gFlags.InstallDir))
+	if err != nil {
+		displaySolveError(err)
+		return err
+	}
+
+	if installFlags.PlanOut != "" {
+		requirements := validator.ValidateRequirements(components, configManager.GetRequirementsForComponents(components))
+		return writeInstallPlan(installFlags.PlanOut,
+			buildInstallPlan(plan, registry, components, requirements, installFlags, gFlags))
+	}
+
+	// A repeated install of the same versions has nothing to do, unless it
+	// links new files from the checkout or replaces links with copies
+	relink := installFlags.DevLink || len(linkedComponents(gFlags.InstallDir)) > 0
+	if !installFlags.ForceReinstall && !relink && planUpToDate(plan) {
+		displayAlreadyInstalled(plan)
+		return nil
+	}
+
 	// Validate system requirements (skip in dry-run mode)
 	if !gFlags.DryRun {
+		githubStep.group("Check requirements")
 		requirements := configManager.GetRequirementsForComponents(components)
 		if !validateSystemRequirements(validator, components, requirements) {
 			if !gFlags.Force {
@@ -189,6 +351,7 @@ func runInstall(cmd *cobra.Command, args []string) error {
 				return fmt.Errorf("system requirements not met")
 			} else {
 				log.Warn("System requirements not met, but continuing due to --force flag")
+				githubStep.annotate("warning", "crew install", "System requirements not met, continuing due to --force")
 			}
 		}
 	}
@@ -197,63 +360,108 @@ func runInstall(cmd *cobra.Command, args []string) error {
 	if _, err := os.Stat(gFlags.InstallDir); err == nil && !gFlags.Force {
 		if !gFlags.DryRun {
 			log.Warnf("Installation directory already exists: %s", gFlags.InstallDir)
-			if !gFlags.Yes && !ui.Confirm("Continue and update existing installation?", false) {
-				log.Info("Installation cancelled by user")
+			if ok, err := confirmAction(i18n.T("install.confirm_existing"), false); err != nil {
+				return err
+			} else if !ok {
+				log.Info(i18n.T("install.cancelled"))
 				return nil
 			}
 		}
 	}
 
 	// Display installation plan
-	if !gFlags.Quiet {
-		displayInstallationPlan(components, registry, gFlags.InstallDir)
+	if showDecorations() {
+		displayInstallationPlan(plan, registry, gFlags.InstallDir, installFlags.items)
 
 		if !gFlags.DryRun {
-			if !gFlags.Yes && !ui.Confirm("Proceed with installation?", true) {
-				log.Info("Installation cancelled by user")
+			if ok, err := confirmAction(i18n.T("install.confirm_proceed"), true); err != nil {
+				return err
+			} else if !ok {
+				log.Info(i18n.T("install.cancelled"))
 				return nil
 			}
 		}
 	}
 
+	ctx := commandContext(cmd)
+	eventData := map[string]string{"components": strings.Join(components, ",")}
+	if err := publishEvent(ctx, events.InstallStarted, eventData); err != nil {
+		ui.DisplayError("Installation aborted by pre-install hook.")
+		return err
+	}
+
 	// Perform installation
-	success := performInstallation(components, installFlags, gFlags)
+	githubStep.group("Install components")
+	result := performInstallation(ctx, components, installFlags, gFlags)
+	if err := printOperationResult(result); err != nil {
+		return err
+	}
+
+	if result.ok() {
+		if installFlags.SharedBase && !gFlags.DryRun {
+			if err := markSharedBase(gFlags.InstallDir); err != nil {
+				return fmt.Errorf("failed to mark the shared installation: %w", err)
+			}
+		}
+		if err := publishEvent(ctx, events.InstallCompleted, result.eventData(eventData)); err != nil {
+			log.Warnf("post-install hook failed: %v", err)
+		}
 
-	if success {
-		if !gFlags.Quiet {
-			ui.DisplaySuccess("Claude Code Super Crew installation completed successfully!")
+		if showDecorations() {
+			ui.DisplaySuccess("install.success")
 
 			if !gFlags.DryRun {
-				fmt.Printf("\n%sNext steps:%s\n", ui.ColorCyan, ui.ColorReset)
-				fmt.Println("1. Restart your Claude Code session")
-				fmt.Printf("2. Framework files are now available in %s\n", gFlags.InstallDir)
-				fmt.Println("3. Use Claude Code Super Crew commands and features in Claude Code")
+				fmt.Printf("\n%s%s%s\n", ui.ColorCyan, i18n.T("install.next_steps"), ui.ColorReset)
+				fmt.Println(i18n.T("install.next.restart"))
+				fmt.Println(i18n.T("install.next.files", gFlags.InstallDir))
+				fmt.Println(i18n.T("install.next.use"))
 			}
 		}
 		return nil
 	} else {
-		ui.DisplayError("Installation failed. Check logs for details.")
-		return fmt.Errorf("installation failed")
+		publishEvent(ctx, events.InstallFailed, result.eventData(eventData))
+		ui.DisplayError("install.failed")
+		return result.err("installation failed")
 	}
 }
 
-func listAvailableComponents() error {
+// componentFilter builds the registry filter from the install flags
+func componentFilter(flags InstallFlags) core.ComponentFilter {
+	return core.ComponentFilter{
+		Category:            flags.Category,
+		Tags:                flags.Tags,
+		IncludeExperimental: flags.Experimental,
+	}
+}
+
+func listAvailableComponents(flags InstallFlags) error {
 	exe, _ := os.Executable()
 	projectRoot := filepath.Dir(filepath.Dir(filepath.Dir(exe)))
 
 	registry := core.NewEnhancedComponentRegistry(filepath.Join(projectRoot, "setup", "components"))
-	if err := registry.DiscoverComponents(); err != nil {
+	if err := discoverComponents(registry); err != nil {
 		return fmt.Errorf("failed to discover components: %w", err)
 	}
 
-	components := registry.ListComponents()
+	components := registry.FilterComponents(componentFilter(flags))
+
+	if flags.JSON || globalFlags.Output == "json" {
+		list := make([]core.ComponentMetadata, 0, len(components))
+		for _, name := range components {
+			list = append(list, *registry.GetComponentMetadata(name))
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(list)
+	}
+
 	if len(components) > 0 {
 		fmt.Printf("\n%sAvailable Components:%s\n", ui.ColorCyan, ui.ColorReset)
 		for _, name := range components {
-			if metadata := registry.GetComponentMetadata(name); metadata != nil {
-				fmt.Printf("  %s (%s) - %s\n", name, metadata.Category, metadata.Description)
-			} else {
-				fmt.Printf("  %s - Unknown component\n", name)
+			metadata := registry.GetComponentMetadata(name)
+			fmt.Printf("  %s (%s) - %s\n", name, metadata.Category, metadata.Description)
+			if len(metadata.Tags) > 0 {
+				fmt.Printf("      tags: %s\n", strings.Join(metadata.Tags, ", "))
 			}
 		}
 	} else {
@@ -263,39 +471,148 @@ func listAvailableComponents() error {
 	return nil
 }
 
-func runSystemDiagnostics() error {
+// displayEnvironment lists the detected platform traits
+func displayEnvironment(env platform.Environment) {
+	yesNo := func(b bool) string {
+		if b {
+			return "yes"
+		}
+		return "no"
+	}
+	orNone := func(s string) string {
+		if s == "" {
+			return "none"
+		}
+		return s
+	}
+	wsl := yesNo(env.WSL)
+	if env.WSLDistro != "" {
+		wsl += " (" + env.WSLDistro + ")"
+	}
+	selinux := env.SELinux
+	if selinux == "" {
+		selinux = "off"
+	}
+
+	fmt.Printf("\n%sEnvironment:%s\n", ui.ColorBlue, ui.ColorReset)
+	fmt.Printf("  %-18s %s\n", "WSL", wsl)
+	fmt.Printf("  %-18s %s\n", "Container", orNone(env.Container))
+	fmt.Printf("  %-18s %s\n", "SELinux", selinux)
+	if env.Dir != "" {
+		fmt.Printf("  %-18s %s\n", "Install filesystem", env.Dir)
+		fmt.Printf("  %-18s %s\n", "  writable", yesNo(!env.ReadOnly))
+		fmt.Printf("  %-18s %s\n", "  case-insensitive", yesNo(env.CaseInsensitive))
+	}
+}
+
+// diagnoseSystem checks the requirements of crew and its components
+func diagnoseSystem() map[string]interface{} {
 	validator := core.NewValidator()
-	diagnostics := validator.DiagnoseSystem()
+	validator.SetInstallDir(globalFlags.InstallDir)
+	requirements := managers.DefaultRequirements([]string{"mcp"})
+	exe, _ := os.Executable()
+	projectRoot := filepath.Dir(filepath.Dir(filepath.Dir(exe)))
+	if configManager, err := managers.NewConfigManager(filepath.Join(projectRoot, "config"), ""); err == nil {
+		requirements = configManager.GetRequirementsForComponents([]string{"mcp"})
+	}
+	return validator.DiagnoseRequirements(requirements)
+}
+
+// runSystemDiagnostics shows the diagnostics, and with installMissing
+// installs the missing tools it can
+func runSystemDiagnostics(installMissing bool, format string) error {
+	diagnostics := diagnoseSystem()
+	results := diagnostics["results"].([]core.RequirementResult)
+	optional := diagnostics["optional"].([]core.RequirementResult)
+
+	manager := toolinstall.Detect(runtime.GOOS, exec.LookPath)
+	steps, _ := toolinstall.Plan(manager, missingTools(results, optional))
+	if steps == nil {
+		steps = []toolinstall.Step{}
+	}
+
+	report := doctorFindings(diagnostics)
+	if format == findings.FormatSARIF {
+		if err := report.WriteSARIF(os.Stdout); err != nil {
+			return err
+		}
+		if installMissing {
+			return installMissingTools(steps, os.Stderr)
+		}
+		return ciFindingsError("doctor", report)
+	}
+
+	if globalFlags.Output == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(map[string]interface{}{
+			"platform":         diagnostics["platform"],
+			"environment":      diagnostics["environment"],
+			"results":          results,
+			"optional":         optional,
+			"issues":           diagnostics["issues"],
+			"recommendations":  diagnostics["recommendations"],
+			"install_commands": steps,
+			"findings":         report.Findings,
+		}); err != nil {
+			return err
+		}
+		if installMissing {
+			return installMissingTools(steps, os.Stderr)
+		}
+		return ciFindingsError("doctor", report)
+	}
 
 	fmt.Printf("\n%s%sClaude Code Super Crew System Diagnostics%s\n", ui.ColorCyan, ui.ColorBright, ui.ColorReset)
 	fmt.Println(strings.Repeat("=", 50))
 
 	fmt.Printf("%sPlatform:%s %v\n", ui.ColorBlue, ui.ColorReset, diagnostics["platform"])
+	if environment, ok := diagnostics["environment"].(platform.Environment); ok {
+		displayEnvironment(environment)
+	}
 
 	fmt.Printf("\n%sSystem Checks:%s\n", ui.ColorBlue, ui.ColorReset)
 	allPassed := true
 
-	checks := diagnostics["checks"].(map[string]map[string]string)
-	for checkName, checkInfo := range checks {
-		status := checkInfo["status"]
-		message := checkInfo["message"]
+	for _, result := range results {
+		name := result.Tool
+		if result.Component != "" {
+			name = fmt.Sprintf("%s (%s)", result.Tool, result.Component)
+		}
 
-		if status == "pass" {
-			fmt.Printf("  ✅ %s: %s\n", checkName, message)
+		if result.Satisfied {
+			fmt.Printf("  %s %s: %s\n", ui.Icons.Success, name, result.Message)
 		} else {
-			fmt.Printf("  ❌ %s: %s\n", checkName, message)
+			fmt.Printf("  %s %s: %s\n", ui.Icons.Failure, name, result.Message)
 			allPassed = false
 		}
+		if result.Path != "" && globalFlags.Verbose {
+			fmt.Printf("      %s\n", result.Path)
+		}
+	}
+
+	if len(optional) > 0 {
+		fmt.Printf("\n%sOptional Tools:%s\n", ui.ColorBlue, ui.ColorReset)
+		for _, result := range optional {
+			icon := ui.Icons.Success
+			if !result.Found {
+				icon = ui.Icons.Warning
+			}
+			fmt.Printf("  %s %s: %s\n", icon, result.Tool, result.Message)
+		}
 	}
 
 	issues := diagnostics["issues"].([]string)
 	if len(issues) > 0 {
+		allPassed = false
 		fmt.Printf("\n%sIssues Found:%s\n", ui.ColorYellow, ui.ColorReset)
 		for _, issue := range issues {
-			fmt.Printf("  ⚠️  %s\n", issue)
+			fmt.Printf("  %s %s\n", ui.Icons.Warning, issue)
 		}
+	}
 
-		recommendations := diagnostics["recommendations"].([]string)
+	// Platform notes come without an issue, so they are shown either way
+	if recommendations := diagnostics["recommendations"].([]string); len(recommendations) > 0 {
 		fmt.Printf("\n%sRecommendations:%s\n", ui.ColorCyan, ui.ColorReset)
 		for _, rec := range recommendations {
 			fmt.Println(rec)
@@ -303,9 +620,16 @@ func runSystemDiagnostics() error {
 	}
 
 	if allPassed {
-		fmt.Printf("\n%s✅ All system checks passed! Your system is ready for Claude Code Super Crew.%s\n", ui.ColorGreen, ui.ColorReset)
+		fmt.Printf("\n%s%s All system checks passed! Your system is ready for Claude Code Super Crew.%s\n", ui.ColorGreen, ui.Icons.Success, ui.ColorReset)
 	} else {
-		fmt.Printf("\n%s⚠️  Some issues found. Please address the recommendations above.%s\n", ui.ColorYellow, ui.ColorReset)
+		fmt.Printf("\n%s%s Some issues found. Please address the recommendations above.%s\n", ui.ColorYellow, ui.Icons.Warning, ui.ColorReset)
+	}
+
+	if installMissing {
+		return installMissingTools(steps, os.Stdout)
+	}
+	if len(steps) > 0 {
+		fmt.Println("\nRun 'crew doctor --install-missing' to run the install commands above.")
 	}
 
 	fmt.Printf("\n%sNext steps:%s\n", ui.ColorBlue, ui.ColorReset)
@@ -318,6 +642,63 @@ func runSystemDiagnostics() error {
 		fmt.Println("  3. Run 'crew install --diagnose' again to verify")
 	}
 
+	return ciFindingsError("doctor", report)
+}
+
+// missingTools are the tools of results that are not found and that crew
+// knows how to install
+func missingTools(results ...[]core.RequirementResult) []string {
+	var missing []string
+	seen := make(map[string]bool)
+	for _, list := range results {
+		for _, result := range list {
+			if !result.Found && !seen[result.Tool] && toolinstall.Known(result.Tool) {
+				seen[result.Tool] = true
+				missing = append(missing, result.Tool)
+			}
+		}
+	}
+	return missing
+}
+
+// installMissingTools shows the commands installing the missing tools and
+// runs them after confirmation. Their output goes to out.
+func installMissingTools(steps []toolinstall.Step, out io.Writer) error {
+	log := logger.GetLogger()
+	if len(steps) == 0 {
+		log.Info("No missing tools to install")
+		return nil
+	}
+
+	fmt.Fprintf(out, "\n%sInstall commands:%s\n", ui.ColorBlue, ui.ColorReset)
+	for _, step := range steps {
+		fmt.Fprintf(out, "  %s\n", step)
+	}
+	if globalFlags.DryRun {
+		log.Info("[DRY RUN] Would run the install commands above")
+		return nil
+	}
+	if ok, err := confirmAction(fmt.Sprintf("Run %d install command(s)?", len(steps)), false); err != nil {
+		return err
+	} else if !ok {
+		log.Info("Installation of missing tools cancelled")
+		return nil
+	}
+
+	failed := 0
+	for _, step := range steps {
+		log.Infof("Running %s", step)
+		if err := step.Run(os.Stdin, out, os.Stderr); err != nil {
+			log.Errorf("Installing %s failed: %v", strings.Join(step.Tools, ", "), err)
+			failed++
+			continue
+		}
+		log.Successf("Installed %s", strings.Join(step.Tools, ", "))
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d install commands failed", failed, len(steps))
+	}
+	fmt.Fprintln(out, "Restart your terminal, then run 'crew doctor' to verify.")
 	return nil
 }
 
@@ -327,7 +708,25 @@ func getComponentsToInstall(flags InstallFlags, registry *core.EnhancedComponent
 		if contains(flags.Components, "all") {
 			return []string{"core", "commands", "hooks", "mcp"}, nil
 		}
-		return flags.Components, nil
+		selection, err := registry.SelectComponents(flags.Components, flags.Experimental)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range selection.Components {
+			if metadata := registry.GetComponentMetadata(name); metadata != nil && metadata.IsExperimental() && !flags.Experimental {
+				return nil, fmt.Errorf("component %s is experimental; pass --experimental to install it", name)
+			}
+		}
+		return selection.Components, nil
+	}
+
+	// Selection by tag or category
+	if len(flags.Tags) > 0 || flags.Category != "" {
+		selected := registry.FilterComponents(componentFilter(flags))
+		if len(selected) == 0 {
+			return nil, fmt.Errorf("no components match the given --tag/--category")
+		}
+		return selected, nil
 	}
 
 	// Profile-based selection
@@ -355,79 +754,33 @@ func getComponentsToInstall(flags InstallFlags, registry *core.EnhancedComponent
 		return []string{"core"}, nil
 	}
 
-	// Interactive selection - but respect --yes flag for automation
+	// Interactive selection - but respect --yes and non-interactive mode for automation
 	gFlags := GetGlobalFlags()
-	if gFlags.Yes {
-		// If --yes is set, default to quick installation to avoid interactive prompts
+	if gFlags.Yes || ui.NonInteractive() {
+		// Without prompting, default to quick installation
 		return []string{"core", "commands", "hooks", "agents"}, nil
 	}
 
 	return interactiveComponentSelection(registry)
 }
 
+// interactiveComponentSelection runs the profile and component steps of the
+// setup wizard so install and setup offer the same choices
 func interactiveComponentSelection(registry *core.EnhancedComponentRegistry) ([]string, error) {
-	log := logger.GetLogger()
-
-	availableComponents := registry.ListComponents()
-	if len(availableComponents) == 0 {
-		log.Error("No components available for installation")
+	if len(registry.ListComponents()) == 0 {
+		logger.GetLogger().Error("No components available for installation")
 		return nil, fmt.Errorf("no components available")
 	}
 
-	// Create preset options
-	presetOptions := []string{
-		"Quick Installation (recommended components)",
-		"Minimal Installation (core only)",
-		"Custom Selection",
+	w := &setupWizard{registry: registry, experimental: installFlags.Experimental}
+	if err := w.chooseProfile(); err != nil {
+		return nil, err
 	}
-
-	fmt.Printf("\n%sSuperCrewInstallation Options:%s\n", ui.ColorCyan, ui.ColorReset)
-	menu := ui.NewMenu("Select installation type:", presetOptions, false)
-	result, err := menu.Display()
-	if err != nil {
-		return nil, fmt.Errorf("menu selection failed: %w", err)
-	}
-	choice := result.(int)
-
-	switch choice {
-	case -1: // Cancelled
-		return nil, fmt.Errorf("cancelled")
-	case 0: // Quick
-		return []string{"core", "commands", "hooks", "agents"}, nil
-	case 1: // Minimal
-		return []string{"core"}, nil
-	case 2: // Custom
-		// Create component menu with descriptions
-		menuOptions := []string{}
-		for _, name := range availableComponents {
-			if metadata := registry.GetComponentMetadata(name); metadata != nil {
-				menuOptions = append(menuOptions, fmt.Sprintf("%s (%s) - %s", name, metadata.Category, metadata.Description))
-			} else {
-				menuOptions = append(menuOptions, fmt.Sprintf("%s - Component description unavailable", name))
-			}
-		}
-
-		fmt.Printf("\n%sAvailable Components:%s\n", ui.ColorCyan, ui.ColorReset)
-		componentMenu := ui.NewMenu("Select components to install:", menuOptions, true)
-		result, err := componentMenu.Display()
-		if err != nil {
-			return nil, fmt.Errorf("component selection failed: %w", err)
-		}
-		selections := result.([]int)
-
-		if len(selections) == 0 {
-			log.Warn("No components selected")
-			return nil, fmt.Errorf("no components selected")
-		}
-
-		selected := []string{}
-		for _, idx := range selections {
-			selected = append(selected, availableComponents[idx])
-		}
-		return selected, nil
+	if err := w.chooseComponents(); err != nil {
+		return nil, err
 	}
 
-	return nil, fmt.Errorf("invalid selection")
+	return w.components, nil
 }
 
 func validateSystemRequirements(validator *core.Validator, components []string, requirements map[string]map[string]string) bool {
@@ -435,18 +788,28 @@ func validateSystemRequirements(validator *core.Validator, components []string,
 
 	log.Info("Validating system requirements...")
 
-	success, errors := validator.ValidateComponentRequirements(components, requirements)
+	var failed []core.RequirementResult
+	for _, result := range validator.ValidateRequirements(components, requirements) {
+		log.Debugf("Requirement %s %q: %s", result.Tool, result.Required, result.Message)
+		if !result.Satisfied {
+			failed = append(failed, result)
+		}
+	}
 
-	if success {
+	if len(failed) == 0 {
 		log.Success("All system requirements met")
 		return true
 	} else {
 		log.Error("System requirements not met:")
-		for _, err := range errors {
-			log.Errorf("  - %s", err)
+		for _, result := range failed {
+			if result.Component != "" {
+				log.Errorf("  - %s for %s: %s", result.Tool, result.Component, result.Message)
+			} else {
+				log.Errorf("  - %s: %s", result.Tool, result.Message)
+			}
 		}
 
-		fmt.Printf("\n%s💡 Installation Help:%s\n", ui.ColorCyan, ui.ColorReset)
+		fmt.Printf("\n%s%sInstallation Help:%s\n", ui.ColorCyan, ui.Emoji("💡 "), ui.ColorReset)
 		fmt.Println("  Run 'crew install --diagnose' for detailed system diagnostics")
 		fmt.Println("  and step-by-step installation instructions.")
 
@@ -454,33 +817,30 @@ func validateSystemRequirements(validator *core.Validator, components []string,
 	}
 }
 
-func displayInstallationPlan(components []string, registry *core.EnhancedComponentRegistry, installDir string) {
+func displayInstallationPlan(plan *core.Plan, registry *core.EnhancedComponentRegistry, installDir string, items map[string][]string) {
 	fmt.Printf("\n%s%sInstallation Plan%s\n", ui.ColorCyan, ui.ColorBright, ui.ColorReset)
 	fmt.Println(strings.Repeat("=", 50))
 
-	// Resolve dependencies
-	orderedComponents, err := registry.ResolveDependencies(components)
-	if err != nil {
-		logger.GetLogger().Errorf("Could not resolve dependencies: %v", err)
-		orderedComponents = components
-	}
-
 	fmt.Printf("%sInstallation Directory:%s %s\n", ui.ColorBlue, ui.ColorReset, installDir)
 	fmt.Printf("%sComponents to install:%s\n", ui.ColorBlue, ui.ColorReset)
 
 	totalSize := int64(0)
-	for i, name := range orderedComponents {
-		if metadata := registry.GetComponentMetadata(name); metadata != nil {
-			fmt.Printf("  %d. %s - %s\n", i+1, name, metadata.Description)
+	for i, step := range plan.Steps {
+		description := "Unknown component"
+		if metadata := registry.GetComponentMetadata(step.Name); metadata != nil {
+			description = metadata.Description
 
 			// Get size estimate
-			if comp, err := registry.GetComponentInstance(name, installDir); err == nil {
-				size := comp.GetSizeEstimate()
-				totalSize += size
+			if comp, err := registry.GetComponentInstance(step.Name, installDir); err == nil {
+				if patterns := items[step.Name]; len(patterns) > 0 {
+					_, size := selectedFiles(comp.GetFilesToInstall(), patterns)
+					totalSize += size
+				} else {
+					totalSize += comp.GetSizeEstimate()
+				}
 			}
-		} else {
-			fmt.Printf("  %d. %s - Unknown component\n", i+1, name)
 		}
+		fmt.Printf("  %d. %s v%s - %s%s\n", i+1, step.Name, step.Version, description, describePlanStep(step))
 	}
 
 	if totalSize > 0 {
@@ -490,8 +850,85 @@ func displayInstallationPlan(components []string, registry *core.EnhancedCompone
 	fmt.Println()
 }
 
-func performInstallation(components []string, flags InstallFlags, gFlags *GlobalFlags) bool {
+// describePlanStep explains why a component is in a plan and what happens
+// to an installed copy
+func describePlanStep(step core.PlanStep) string {
+	var notes []string
+	if step.Action != core.ActionInstall {
+		notes = append(notes, fmt.Sprintf("%s from v%s", step.Action, step.Installed))
+	}
+	if !step.Requested {
+		notes = append(notes, "required by "+strings.Join(step.RequiredBy, ", "))
+	}
+	if len(step.Constraints) > 0 {
+		notes = append(notes, "needs "+strings.Join(step.Constraints, ", "))
+	}
+	if len(notes) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" %s(%s)%s", ui.ColorYellow, strings.Join(notes, "; "), ui.ColorReset)
+}
+
+// planUpToDate reports whether every component in the plan is already
+// installed at the version it would install
+func planUpToDate(plan *core.Plan) bool {
+	if len(plan.Steps) == 0 {
+		return false
+	}
+	for _, step := range plan.Steps {
+		if step.Action != core.ActionReinstall {
+			return false
+		}
+	}
+	return true
+}
+
+func displayAlreadyInstalled(plan *core.Plan) {
+	versions := make(map[string]bool)
+	var installed []string
+	for _, step := range plan.Steps {
+		versions[step.Version] = true
+		installed = append(installed, fmt.Sprintf("%s %s", step.Name, step.Version))
+	}
+
+	if len(versions) == 1 {
+		ui.DisplaySuccess(fmt.Sprintf("Already installed at version %s, nothing to do", plan.Steps[0].Version))
+	} else {
+		ui.DisplaySuccess("Already installed, nothing to do")
+	}
+	if showDecorations() {
+		fmt.Printf("  %s\n", strings.Join(installed, ", "))
+		fmt.Println("  Use --force-reinstall to install again")
+	}
+}
+
+// displaySolveError prints each reason a component selection cannot be
+// resolved
+func displaySolveError(err error) {
+	var solveErr *core.SolveError
+	if !errors.As(err, &solveErr) {
+		ui.DisplayError(err.Error())
+		return
+	}
+	ui.DisplayError("The selected components cannot be installed together:")
+	for _, problem := range solveErr.Problems {
+		fmt.Printf("  %s %s\n", ui.Icons.Failure, problem)
+	}
+}
+
+// installedComponentVersions returns the installed components and their
+// versions, or nil when there is no installation metadata
+func installedComponentVersions(installDir string) map[string]string {
+	installed, err := managers.NewSettingsManager(installDir).GetInstalledComponents()
+	if err != nil {
+		return nil
+	}
+	return installed
+}
+
+func performInstallation(ctx context.Context, components []string, flags InstallFlags, gFlags *GlobalFlags) *operationResult {
 	log := logger.GetLogger()
+	result := newOperationResult("install", nil)
 
 	// Get project root (where the binary is built from)
 	exe, _ := os.Executable()
@@ -509,24 +946,25 @@ func performInstallation(components []string, flags InstallFlags, gFlags *Global
 
 	superCrewSource := filepath.Join(projectRoot, "SuperCrew")
 	if _, err := os.Stat(superCrewSource); os.IsNotExist(err) {
-		log.Errorf("SuperCrew source directory not found at: %s", superCrewSource)
 		log.Error("This usually means the binary was not built correctly or SuperCrew files are missing")
-		return false
+		return result.failf("SuperCrew source directory not found at: %s", superCrewSource)
 	}
 
 	// Create ~/.claude directory (skip in dry-run mode)
 	if !gFlags.DryRun {
 		if err := os.MkdirAll(gFlags.InstallDir, 0755); err != nil {
-			log.Errorf("Failed to create installation directory: %v", err)
-			return false
+			return result.failf("Failed to create installation directory: %v", err)
 		}
 	}
 
 	// Create backup if installation already exists
 	if _, err := os.Stat(gFlags.InstallDir); err == nil && !gFlags.DryRun && !flags.NoBackup {
 		log.Info("Creating backup of existing installation...")
-		if err := createSimpleBackup(gFlags.InstallDir); err != nil {
-			log.Warnf("Failed to create backup: %v", err)
+		if backupPath, err := createSimpleBackup(ctx, gFlags.InstallDir); err != nil {
+			result.warnf("Failed to create backup: %v", err)
+		} else {
+			result.Backup = backupPath
+			noteUndo(audit.UndoBackup, backupPath)
 		}
 	}
 
@@ -535,34 +973,44 @@ func performInstallation(components []string, flags InstallFlags, gFlags *Global
 
 	if gFlags.DryRun {
 		log.Info("[DRY RUN] Would copy SuperCrew framework to ~/.claude/")
-		return true
+		return result.finish()
 	}
 
 	// Use component system for installation
 	registry := core.NewEnhancedComponentRegistry(superCrewSource)
-	if err := registry.DiscoverComponents(); err != nil {
-		log.Errorf("Failed to discover components: %v", err)
-		return false
+	if err := discoverComponents(registry); err != nil {
+		return result.failf("Failed to discover components: %v", err)
 	}
 
-	success := true
 	installed := []string{}
 
 	// Resolve dependencies to get proper installation order
 	resolvedComponents, err := registry.ResolveDependencies(components)
 	if err != nil {
-		log.Errorf("Failed to resolve component dependencies: %v", err)
-		return false
+		return result.failf("Failed to resolve component dependencies: %v", err)
 	}
 
 	log.Infof("Original components: %v", components)
 	log.Infof("Resolved installation order: %v", resolvedComponents)
 
-	// Install components using the component system in dependency order
+	var toInstall []string
 	for _, componentName := range resolvedComponents {
-		if !shouldInstallComponent(componentName, components) {
-			continue
+		if shouldInstallComponent(componentName, components) {
+			toInstall = append(toInstall, componentName)
+			result.component(componentName)
+		}
+	}
+
+	progress := result.track(newProgress("Installing components", toInstall))
+
+	// Install components using the component system in dependency order
+	for _, componentName := range toInstall {
+		if err := ctx.Err(); err != nil {
+			log.Warnf("Stopped before installing %s: %v", componentName, err)
+			result.stop(err)
+			break
 		}
+		progress.Start(componentName)
 
 		// Get component description
 		descriptions := map[string]string{
@@ -578,6 +1026,7 @@ func performInstallation(components []string, flags InstallFlags, gFlags *Global
 		if gFlags.DryRun {
 			log.Infof("[DRY RUN] Would install %s to %s", componentName, gFlags.InstallDir)
 			installed = append(installed, componentName)
+			progress.Done(componentName, nil)
 			continue
 		}
 
@@ -585,7 +1034,7 @@ func performInstallation(components []string, flags InstallFlags, gFlags *Global
 		component, err := registry.GetComponentInstance(componentName, gFlags.InstallDir)
 		if err != nil {
 			log.Errorf("Failed to create component: %s", componentName)
-			success = false
+			progress.Done(componentName, err)
 			continue
 		}
 
@@ -595,54 +1044,77 @@ func performInstallation(components []string, flags InstallFlags, gFlags *Global
 			"claude_merge":     flags.ClaudeMerge,
 			"claude_overwrite": flags.ClaudeOverwrite,
 			"claude_skip":      flags.ClaudeSkip,
+			"mcp_servers":      flags.MCPServers,
+			"dev_link":         flags.DevLink,
+			core.ConfigItems:   flags.items,
 		}
-		if err := component.Install(gFlags.InstallDir, config); err != nil {
+		missing := missingTargets(component.GetFilesToInstall())
+		err = component.Install(ctx, gFlags.InstallDir, config)
+		noteAdded(missing)
+		if err != nil {
 			log.Errorf("Failed to install %s: %v", componentName, err)
-			success = false
+			progress.Done(componentName, err)
 		} else {
 			installed = append(installed, componentName)
 			log.Successf("Installed %s successfully", componentName)
+			publishEvent(ctx, events.ComponentInstalled, map[string]string{"component": componentName})
+			progress.Done(componentName, nil)
+			result.component(componentName).Files = len(component.GetFilesToInstall())
 		}
 	}
 
+	progress.Finish()
+	result.finish()
+
 	// Show results
-	if success && len(installed) > 0 {
+	if result.ok() && len(installed) > 0 {
 		log.Successf("Installed framework components: %s", strings.Join(installed, ", "))
 
 		if !gFlags.DryRun {
 			// Initialize version manager and set version
 			versionManager := versioning.NewVersionManager(gFlags.InstallDir)
 			if err := versionManager.StandardizeAllVersions(); err != nil {
-				log.Warnf("Failed to set version information: %v", err)
+				result.warnf("Failed to set version information: %v", err)
 			} else {
-				log.Infof("Framework version set to 1.0.0")
+				log.Infof("Framework version set to %s", core.FrameworkVersion)
 			}
 
 			// Save installation metadata for update detection
 			settingsManager := managers.NewSettingsManager(gFlags.InstallDir)
 			installInfo := &managers.InstallationInfo{
-				Version:          "1.0.0",
+				Version:          core.FrameworkVersion,
 				InstalledAt:      time.Now().Format(time.RFC3339),
 				LastUpdated:      time.Now().Format(time.RFC3339),
 				Components:       make(map[string]string),
 				InstallDir:       gFlags.InstallDir,
-				InstallerVersion: "1.0.0",
+				InstallerVersion: core.FrameworkVersion,
 			}
 
 			// Add installed components to metadata
 			for _, component := range installed {
-				installInfo.Components[component] = "1.0.0"
+				installInfo.Components[component] = core.FrameworkVersion
 			}
 
 			if err := settingsManager.SaveInstallationInfo(installInfo); err != nil {
-				log.Warnf("Failed to save installation metadata: %v", err)
+				result.warnf("Failed to save installation metadata: %v", err)
+			}
+
+			// Record which components follow the checkout, or that a copy
+			// replaced earlier links
+			linkedFrom := ""
+			if flags.DevLink {
+				linkedFrom, _ = filepath.Abs(superCrewSource)
+				log.Infof("Components linked to %s; edits there take effect immediately", linkedFrom)
+			}
+			if err := markDevLinked(gFlags.InstallDir, installed, linkedFrom); err != nil {
+				result.warnf("Failed to record linked components: %v", err)
 			}
 
 			log.Info("SuperCrew framework installed successfully!")
 
 			// Install orchestrator-specialist agent
 			if err := installOrchestratorAgent(log, gFlags.InstallDir, projectRoot); err != nil {
-				log.Warnf("Failed to install orchestrator-specialist agent: %v", err)
+				result.warnf("Failed to install orchestrator-specialist agent: %v", err)
 			} else {
 				log.Success("Orchestrator-specialist agent installed successfully")
 			}
@@ -664,7 +1136,7 @@ func performInstallation(components []string, flags InstallFlags, gFlags *Global
 		}
 	}
 
-	return success
+	return result.finish()
 }
 
 // shouldInstallComponent checks if a component should be installed based on the selected components
@@ -763,7 +1235,7 @@ func copyDirectoryRecursive(src, dst string) error {
 				return err
 			}
 		} else {
-			if err := copyFileSimple(srcPath, dstPath); err != nil {
+			if err := fileops.CopyFile(srcPath, dstPath); err != nil {
 				return err
 			}
 		}
@@ -849,7 +1321,7 @@ func copyFileSelective(src, dst string, component string) error {
 		logger.GetLogger().Debugf("Updating SuperCrew-controlled file: %s", filepath.Base(dst))
 	}
 
-	return copyFileSimple(src, dst)
+	return fileops.CopyFile(src, dst)
 }
 
 // shouldOverwriteFile determines if a file should be overwritten during installation
@@ -878,7 +1350,7 @@ func shouldUpdateBasedOnVersion(component string) bool {
 	}
 
 	// Try to read the current metadata file
-	metadataPath := filepath.Join(installDir, ".crew", "config", "crew-metadata.json")
+	metadataPath := crewdirs.Path(installDir, "config", "crew-metadata.json")
 	if _, err := os.Stat(metadataPath); os.IsNotExist(err) {
 		// No metadata file exists, this is a fresh install - allow all updates
 		log.Debug("No metadata file found, allowing all component updates")
@@ -909,10 +1381,10 @@ func shouldUpdateBasedOnVersion(component string) bool {
 	// Check if we have version info for this component
 	if componentInfo, exists := metadata.Components[component]; exists {
 		currentVersion := componentInfo.Version
-		newVersion := "1.0.0" // Current framework version being installed
+		newVersion := core.FrameworkVersion
 
-		// If versions are different, allow update
-		if currentVersion != newVersion {
+		// If versions differ (including downgrades), allow update
+		if semver.Compare(currentVersion, newVersion) != 0 {
 			log.Debugf("Component %s version change detected: %s -> %s", component, currentVersion, newVersion)
 			return true
 		}
@@ -927,58 +1399,36 @@ func shouldUpdateBasedOnVersion(component string) bool {
 	return true
 }
 
-// copyFileSimple copies a single file (internal helper)
-func copyFileSimple(src, dst string) error {
-	srcFile, err := os.Open(src)
-	if err != nil {
-		return err
-	}
-	defer srcFile.Close()
-
-	srcInfo, err := srcFile.Stat()
-	if err != nil {
-		return err
-	}
-
-	dstFile, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, srcInfo.Mode())
-	if err != nil {
-		return err
-	}
-	defer dstFile.Close()
-
-	_, err = io.Copy(dstFile, srcFile)
-	return err
-}
-
 // createSimpleBackup creates a simple backup of the installation directory
-func createSimpleBackup(installDir string) error {
+// and returns the path of the archive
+func createSimpleBackup(ctx context.Context, installDir string) (string, error) {
 	timestamp := time.Now().Format("20060102-150405")
-	backupDir := filepath.Join(installDir, ".crew", "backups")
+	backupDir := crewdirs.Path(installDir, "backups")
 	backupName := fmt.Sprintf("crew-backup-%s.tar.gz", timestamp)
 	finalBackupPath := filepath.Join(backupDir, backupName)
 
 	// Create backups directory if it doesn't exist
 	if err := os.MkdirAll(backupDir, 0755); err != nil {
-		return err
+		return "", err
 	}
 
 	// Create a temporary directory for the backup
 	tempDir, err := os.MkdirTemp("", "crew-backup-temp-*")
 	if err != nil {
-		return fmt.Errorf("failed to create temporary backup directory: %w", err)
+		return "", fmt.Errorf("failed to create temporary backup directory: %w", err)
 	}
 	defer os.RemoveAll(tempDir) // Clean up temp dir
 
 	tempBackupPath := filepath.Join(tempDir, "backup")
 
 	// Copy installation directory to temporary location, excluding backups
-	if err := copyDirectorySelectiveBackup(installDir, tempBackupPath); err != nil {
-		return fmt.Errorf("failed to create backup: %w", err)
+	if err := copyDirectorySelectiveBackup(ctx, installDir, tempBackupPath); err != nil {
+		return "", fmt.Errorf("failed to create backup: %w", err)
 	}
 
 	// Create tar.gz archive
 	if err := createTarGzArchive(tempBackupPath, finalBackupPath); err != nil {
-		return fmt.Errorf("failed to create backup archive: %w", err)
+		return "", fmt.Errorf("failed to create backup archive: %w", err)
 	}
 
 	// Create metadata file
@@ -988,7 +1438,7 @@ func createSimpleBackup(installDir string) error {
 		logger.GetLogger().Warn(fmt.Sprintf("Failed to create backup metadata: %v", err))
 	}
 
-	return nil
+	return finalBackupPath, nil
 }
 
 // createTarGzArchive creates a tar.gz archive from a directory
@@ -1036,7 +1486,7 @@ func createTarGzArchive(src, dst string) error {
 			}
 			defer file.Close()
 
-			if _, err := io.Copy(tarWriter, file); err != nil {
+			if _, err := fileops.Copy(tarWriter, file); err != nil {
 				return err
 			}
 		}
@@ -1065,7 +1515,7 @@ func createBackupMetadata(installDir, metaPath string) error {
 	versionManager := versioning.NewVersionManager(installDir)
 	frameworkVersion, _ := versionManager.GetCurrentVersion()
 	if frameworkVersion == "" {
-		frameworkVersion = "1.0.0"
+		frameworkVersion = core.FrameworkVersion
 	}
 
 	metadata := map[string]interface{}{
@@ -1082,47 +1532,18 @@ func createBackupMetadata(installDir, metaPath string) error {
 	return os.WriteFile(metaPath, data, 0644)
 }
 
-// copyDirectorySelectiveBackup copies a directory excluding the backups folder
-func copyDirectorySelectiveBackup(src, dst string) error {
-	// Get source directory info
-	srcInfo, err := os.Stat(src)
-	if err != nil {
-		return fmt.Errorf("failed to stat source: %w", err)
-	}
-
-	// Create destination directory
-	if err := os.MkdirAll(dst, srcInfo.Mode()); err != nil {
-		return fmt.Errorf("failed to create destination: %w", err)
-	}
-
-	// Read directory contents
-	entries, err := os.ReadDir(src)
+// copyDirectorySelectiveBackup copies a directory excluding the backups
+// folder and what its .crewignore excludes, stopping early when ctx is
+// cancelled
+func copyDirectorySelectiveBackup(ctx context.Context, src, dst string) error {
+	ignore, err := crewignore.Load(src)
 	if err != nil {
-		return fmt.Errorf("failed to read directory: %w", err)
+		return err
 	}
-
-	// Copy each entry
-	for _, entry := range entries {
-		// Skip the backups directory entirely
-		if entry.Name() == "backups" && entry.IsDir() {
-			continue
-		}
-
-		srcPath := filepath.Join(src, entry.Name())
-		dstPath := filepath.Join(dst, entry.Name())
-
-		if entry.IsDir() {
-			if err := copyDirectorySelectiveBackup(srcPath, dstPath); err != nil {
-				return err
-			}
-		} else {
-			if err := copyFileSimple(srcPath, dstPath); err != nil {
-				return err
-			}
-		}
+	skip := func(rel string, d fs.DirEntry) bool {
+		return d.IsDir() && d.Name() == "backups" || ignore.Match(rel, d.IsDir())
 	}
-
-	return nil
+	return fileops.CopyDir(ctx, src, dst, skip)
 }
 
 // installCoreWithCLAUDEHandling installs Core component files directly into the destination
@@ -1163,8 +1584,11 @@ func installCoreWithCLAUDEHandling(srcPath, dstPath string, gFlags *GlobalFlags)
 		}
 
 		// If no flags set and not in auto mode, ask interactively
-		if flagCount == 0 && !gFlags.Yes && !gFlags.Quiet {
+		if flagCount == 0 && !gFlags.Yes && !gFlags.Quiet && !ui.NonInteractive() {
 			logger.GetLogger().Warn("Existing CLAUDE.md detected")
+			if preview, err := newClaudePreview(claudeMdSrc, claudeMdDst); err == nil {
+				preview.display(false)
+			}
 
 			options := []string{
 				"Merge (preserve custom sections, update framework sections)",
@@ -1178,8 +1602,8 @@ func installCoreWithCLAUDEHandling(srcPath, dstPath string, gFlags *GlobalFlags)
 				choice = 2
 			}
 			action = choice
-		} else if flagCount == 0 && gFlags.Yes {
-			// In auto mode (--yes) without explicit CLAUDE.md flags, default to skip (safe)
+		} else if flagCount == 0 && (gFlags.Yes || ui.NonInteractive()) {
+			// In auto mode (--yes or non-interactive) without explicit CLAUDE.md flags, default to skip (safe)
 			action = 2
 			logger.GetLogger().Info("Auto mode: preserving existing CLAUDE.md")
 		}
@@ -1218,13 +1642,13 @@ func copyCoreContents(srcDir, dstDir string, excludeFiles []string) error {
 	// First, create the .crew directory structure for utilities
 	log.Info("Creating .crew directory structure...")
 	crewDirs := []string{
-		filepath.Join(dstDir, ".crew"),
-		filepath.Join(dstDir, ".crew", "logs"),
-		filepath.Join(dstDir, ".crew", "workflows"),
-		filepath.Join(dstDir, ".crew", "scripts"),
-		filepath.Join(dstDir, ".crew", "config"),
-		filepath.Join(dstDir, ".crew", "prompts"),
-		filepath.Join(dstDir, ".crew", "completions"),
+		crewdirs.Path(dstDir),
+		crewdirs.Path(dstDir, "logs"),
+		crewdirs.Path(dstDir, "workflows"),
+		crewdirs.Path(dstDir, "scripts"),
+		crewdirs.Path(dstDir, "config"),
+		crewdirs.Path(dstDir, "prompts"),
+		crewdirs.Path(dstDir, "completions"),
 	}
 
 	for _, dir := range crewDirs {
@@ -1241,6 +1665,10 @@ func copyCoreContents(srcDir, dstDir string, excludeFiles []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to read source directory: %w", err)
 	}
+	ignore, err := crewignore.Load(dstDir)
+	if err != nil {
+		return err
+	}
 
 	// Copy each entry directly to destination
 	for _, entry := range entries {
@@ -1248,6 +1676,10 @@ func copyCoreContents(srcDir, dstDir string, excludeFiles []string) error {
 		if excludeFiles != nil && contains(excludeFiles, entry.Name()) {
 			continue
 		}
+		if ignore.Match(entry.Name(), entry.IsDir()) {
+			log.Debugf("Skipping %s, excluded by %s", entry.Name(), crewignore.FileName)
+			continue
+		}
 
 		srcPath := filepath.Join(srcDir, entry.Name())
 		dstPath := filepath.Join(dstDir, entry.Name())
@@ -1259,7 +1691,7 @@ func copyCoreContents(srcDir, dstDir string, excludeFiles []string) error {
 			}
 		} else {
 			// Copy files
-			if err := copyFileSimple(srcPath, dstPath); err != nil {
+			if err := fileops.CopyFile(srcPath, dstPath); err != nil {
 				return err
 			}
 		}
@@ -1268,7 +1700,8 @@ func copyCoreContents(srcDir, dstDir string, excludeFiles []string) error {
 	return nil
 }
 
-// mergeCLAUDEmd merges the source CLAUDE.md with existing destination CLAUDE.md
+// mergeCLAUDEmd updates the framework section of the existing destination
+// CLAUDE.md from the source and keeps all user content around it
 func mergeCLAUDEmd(srcFile, dstFile string) error {
 	// Read source CLAUDE.md
 	srcContent, err := os.ReadFile(srcFile)
@@ -1282,169 +1715,33 @@ func mergeCLAUDEmd(srcFile, dstFile string) error {
 		return fmt.Errorf("failed to read existing CLAUDE.md: %w", err)
 	}
 
-	// Parse sections from both files
-	srcSections := parseCLAUDESections(string(srcContent))
-	existingSections := parseCLAUDESections(string(existingContent))
-
-	// Debug: log what sections we found
-	logger.GetLogger().Debug(fmt.Sprintf("Source sections found: %d", len(srcSections)))
-	logger.GetLogger().Debug(fmt.Sprintf("Existing sections found: %d", len(existingSections)))
-	if footerContent, hasFooter := existingSections["__footer__"]; hasFooter {
-		logger.GetLogger().Debug(fmt.Sprintf("Footer content found: %s", footerContent))
-	}
-
-	// Check versions
-	srcVersion := extractVersion(string(srcContent))
-	existingVersion := extractVersion(string(existingContent))
-	if srcVersion != "" && existingVersion != "" {
-		logger.GetLogger().Info(fmt.Sprintf("Version info - Source: %s, Existing: %s", srcVersion, existingVersion))
-		if srcVersion == existingVersion {
-			logger.GetLogger().Info("Versions match - preserving custom content only")
-		}
-	}
-
-	// Merge strategy:
-	// 1. Keep framework sections from source (they might have updates)
-	// 2. Preserve custom sections from existing file
-	// 3. Add any new sections from source
-
-	mergedContent := buildMergedCLAUDE(srcSections, existingSections)
-
-	// Write merged content
-	if err := os.WriteFile(dstFile, []byte(mergedContent), 0644); err != nil {
-		return fmt.Errorf("failed to write merged CLAUDE.md: %w", err)
-	}
-
-	return nil
-}
-
-// extractVersion extracts version from version block or content
-func extractVersion(content string) string {
-	lines := strings.Split(content, "\n")
-	for _, line := range lines {
-		if strings.HasPrefix(line, "<sc-v") && strings.Contains(line, ">") {
-			// Extract version between <sc-v and >
-			start := strings.Index(line, "<sc-v") + 5
-			end := strings.Index(line[start:], ">")
-			if end > 0 {
-				return line[start : start+end]
-			}
-		}
-	}
-	return ""
-}
-
-// parseCLAUDESections parses CLAUDE.md content into sections
-func parseCLAUDESections(content string) map[string]string {
-	sections := make(map[string]string)
-	lines := strings.Split(content, "\n")
-
-	currentSection := ""
-	currentContent := []string{}
-
-	// Track if we're still in the header or in a section
-	inSection := false
-	inVersionBlock := false
-	versionContent := []string{}
-
-	for i, line := range lines {
-		// Check for version tags
-		if strings.HasPrefix(line, "<sc-v") && strings.Contains(line, ">") {
-			inVersionBlock = true
-			versionContent = append(versionContent, line)
-			continue
-		}
-		if inVersionBlock && strings.HasPrefix(line, "<sc-end-v") && strings.Contains(line, ">") {
-			// Check if there's content after the closing tag on the same line
-			endTagPos := strings.Index(line, ">")
-			if endTagPos >= 0 && endTagPos < len(line)-1 {
-				// There's content after the closing tag
-				tagPart := line[:endTagPos+1]
-				afterTagContent := line[endTagPos+1:]
-
-				// Add only the tag part to version block
-				versionContent = append(versionContent, tagPart)
-				sections["__version_block__"] = strings.Join(versionContent, "\n")
-				inVersionBlock = false
-				versionContent = []string{}
-
-				// Start collecting the content after the tag as footer content
-				if strings.TrimSpace(afterTagContent) != "" {
-					currentContent = []string{afterTagContent}
-				}
-			} else {
-				// No content after closing tag, process normally
-				versionContent = append(versionContent, line)
-				sections["__version_block__"] = strings.Join(versionContent, "\n")
-				inVersionBlock = false
-				versionContent = []string{}
-			}
-			continue
-		}
-		if inVersionBlock {
-			versionContent = append(versionContent, line)
-			continue
-		}
-
-		// Check if line is a section header (starts with #)
-		if strings.HasPrefix(line, "#") && !strings.HasPrefix(line, "##") {
-			// Save previous section
-			if currentSection != "" {
-				sections[currentSection] = strings.Join(currentContent, "\n")
-			} else if !inSection && len(currentContent) > 0 {
-				// This is content before any section (shouldn't happen with new format)
-				sections["__header__"] = strings.Join(currentContent, "\n")
-			}
-			// Start new section
-			currentSection = strings.TrimSpace(line)
-			currentContent = []string{}
-			inSection = true
-		} else {
-			// Only add non-empty lines or if it's not the first line
-			if line != "" || i > 0 {
-				currentContent = append(currentContent, line)
+	// A source that carries its own anchors contributes only its framework
+	// section
+	framework, version := string(srcContent), core.FrameworkVersion
+	if doc, err := claudemd.Parse(claudemd.MigrateLegacy(framework)); err == nil {
+		if section, ok := doc.Get(claudemd.SectionFramework); ok {
+			framework = section.Content
+			if section.Version != "" {
+				version = section.Version
 			}
 		}
 	}
 
-	// Save last section or remaining content
-	if currentSection != "" {
-		sections[currentSection] = strings.Join(currentContent, "\n")
-	} else if len(currentContent) > 0 && strings.TrimSpace(strings.Join(currentContent, "\n")) != "" {
-		// Content after all sections
-		sections["__footer__"] = strings.Join(currentContent, "\n")
-	}
-
-	return sections
-}
-
-// buildMergedCLAUDE builds merged CLAUDE.md content
-func buildMergedCLAUDE(srcSections, existingSections map[string]string) string {
-	var result []string
-
-	// First, add the version block from source (which now contains the entire framework content)
-	if versionBlock, hasVersion := srcSections["__version_block__"]; hasVersion {
-		result = append(result, versionBlock)
+	merged, err := claudemd.MergeFramework(string(existingContent), framework, version)
+	if err != nil {
+		return fmt.Errorf("existing CLAUDE.md has broken crew anchors, fix or remove them first: %w", err)
 	}
-
-	// Then, add all custom sections from existing file (anything outside version block)
-	for section, content := range existingSections {
-		if section != "__header__" && section != "__footer__" && section != "__version_block__" &&
-			section != "# Claude Code Super Crew Entry Point" { // Skip the old header since it's now in version block
-			// This is a custom section, preserve it
-			result = append(result, "")
-			result = append(result, section)
-			result = append(result, content)
-		}
+	if merged == string(existingContent) {
+		logger.GetLogger().Info("CLAUDE.md framework section is up to date")
+		return nil
 	}
 
-	// Finally, add any footer content from existing file (custom content after all sections)
-	if footerContent, exists := existingSections["__footer__"]; exists && strings.TrimSpace(footerContent) != "" {
-		result = append(result, "")
-		result = append(result, footerContent)
+	// Write merged content
+	if err := os.WriteFile(dstFile, []byte(merged), 0644); err != nil {
+		return fmt.Errorf("failed to write merged CLAUDE.md: %w", err)
 	}
 
-	return strings.Join(result, "\n")
+	return nil
 }
 
 // installOrchestratorAgent installs the orchestrator-specialist.md agent file
@@ -1468,7 +1765,7 @@ func installOrchestratorAgent(log logger.Logger, installDir, projectRoot string)
 	}
 	
 	// Copy the file
-	if err := copyFileSimple(sourceFile, destFile); err != nil {
+	if err := fileops.CopyFile(sourceFile, destFile); err != nil {
 		return fmt.Errorf("failed to copy agent file: %w", err)
 	}
 	
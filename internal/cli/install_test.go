@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -394,6 +395,31 @@ func TestShouldInstallComponent(t *testing.T) {
 	}
 }
 
+func TestPlanUpToDate(t *testing.T) {
+	step := func(name, action string) core.PlanStep {
+		return core.PlanStep{Name: name, Version: "1.0.0", Installed: "1.0.0", Action: action}
+	}
+
+	tests := []struct {
+		name     string
+		steps    []core.PlanStep
+		expected bool
+	}{
+		{"Empty Plan", nil, false},
+		{"All Installed", []core.PlanStep{step("core", core.ActionReinstall), step("commands", core.ActionReinstall)}, true},
+		{"Upgrade Pending", []core.PlanStep{step("core", core.ActionReinstall), step("commands", core.ActionUpgrade)}, false},
+		{"New Component", []core.PlanStep{step("core", core.ActionReinstall), step("mcp", core.ActionInstall)}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := planUpToDate(&core.Plan{Steps: tt.steps}); result != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
 func TestCopyDirectoryRecursive(t *testing.T) {
 	// Create source directory structure
 	srcDir, err := os.MkdirTemp("", "copy-src-*")
@@ -456,7 +482,7 @@ func TestCreateSimpleBackup(t *testing.T) {
 	}
 
 	// Create backup
-	if err := createSimpleBackup(installDir); err != nil {
+	if _, err := createSimpleBackup(context.Background(), installDir); err != nil {
 		t.Fatalf("Backup creation failed: %v", err)
 	}
 
@@ -2,20 +2,30 @@ package cli
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 
+	"github.com/jonwraymond/claude-code-super-crew/internal/buildinfo"
+	"github.com/jonwraymond/claude-code-super-crew/internal/events"
+	"github.com/jonwraymond/claude-code-super-crew/internal/findings"
 	"github.com/jonwraymond/claude-code-super-crew/internal/managers"
 	"github.com/jonwraymond/claude-code-super-crew/internal/metadata"
+	"github.com/jonwraymond/claude-code-super-crew/internal/ui"
 	"github.com/jonwraymond/claude-code-super-crew/pkg/logger"
 	"github.com/spf13/cobra"
 )
 
 // IntegrityFlags contains flags for integrity checking
 type IntegrityFlags struct {
-	Check    bool
-	Fix      bool
-	Verbose  bool
-	AutoFix  bool
+	Check   bool
+	Fix     bool
+	Verbose bool
+	AutoFix bool
+	Fast    bool
+	Workers int
+	Format  string
 }
 
 // NewIntegrityCommand creates the integrity checking command
@@ -28,17 +38,51 @@ func NewIntegrityCommand() *cobra.Command {
 		Long: `Check the integrity of installed framework files and detect modifications.
 
 This command verifies that all framework files match their original hashes
-and provides visual status indicators for any detected changes.`,
+and provides visual status indicators for any detected changes.
+
+Files are hashed in parallel. On large installations --fast only hashes
+files whose size or modification time changed since the last check.
+
+--format json or sarif writes the modified, missing and corrupted files as
+findings for CI dashboards and code-scanning UIs; SARIF locations are
+relative to the install directory.
+
+Examples:
+  crew integrity
+  crew integrity scan --format sarif > integrity.sarif`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runIntegrity(cmd, args, flags)
 		},
 	}
 
 	// Add flags
-	cmd.Flags().BoolVarP(&flags.Check, "check", "c", false, "Check file integrity")
-	cmd.Flags().BoolVarP(&flags.Fix, "fix", "f", false, "Fix integrity issues by removing modified files")
-	cmd.Flags().BoolVarP(&flags.Verbose, "verbose", "v", false, "Show detailed integrity information")
-	cmd.Flags().BoolVarP(&flags.AutoFix, "auto-fix", "a", false, "Automatically fix integrity issues")
+	cmd.PersistentFlags().BoolVarP(&flags.Check, "check", "c", false, "Check file integrity")
+	cmd.PersistentFlags().BoolVarP(&flags.Fix, "fix", "f", false, "Fix integrity issues by removing modified files")
+	cmd.PersistentFlags().BoolVarP(&flags.Verbose, "verbose", "v", false, "Show detailed integrity information")
+	cmd.PersistentFlags().BoolVarP(&flags.AutoFix, "auto-fix", "a", false, "Automatically fix integrity issues")
+	cmd.PersistentFlags().BoolVar(&flags.Fast, "fast", false, "Skip hashing files whose size and modification time are unchanged")
+	cmd.PersistentFlags().IntVar(&flags.Workers, "workers", 0, "Number of files to hash at once (default: one per CPU)")
+	cmd.PersistentFlags().StringVar(&flags.Format, "format", findings.FormatText,
+		"Output format: "+strings.Join(findings.Formats, ", "))
+	registerFlagCompletions(cmd, map[string]completionFunc{
+		"format": completeStatic(findings.Formats...),
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "scan",
+		Short: "Scan the installed files for modifications",
+		Long: `Hash the installed framework files and report the modified, missing and
+corrupted ones. Accepts the flags of 'crew integrity'.
+
+Examples:
+  crew integrity scan
+  crew integrity scan --format json`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runIntegrity(cmd, args, flags)
+		},
+	})
 
 	return cmd
 }
@@ -57,59 +101,89 @@ func runIntegrity(cmd *cobra.Command, args []string, flags IntegrityFlags) error
 		return fmt.Errorf("no installation found at %s", installDir)
 	}
 
+	format := flags.Format
+	if format == findings.FormatText && globalFlags.Output == "json" {
+		format = findings.FormatJSON
+	}
+	if err := findings.ValidateFormat(format); err != nil {
+		return err
+	}
+
 	// Perform integrity check
-	log.Info("🔍 Checking file integrity...")
-	integrity, err := metadataManager.CheckFileIntegrity()
+	if format == findings.FormatText {
+		log.Info(ui.Emoji("🔍 ") + "Checking file integrity...")
+	}
+	integrity, err := metadataManager.CheckFileIntegrityWith(metadata.IntegrityOptions{
+		Workers: flags.Workers,
+		Fast:    flags.Fast,
+	})
 	if err != nil {
 		return fmt.Errorf("failed to check integrity: %w", err)
 	}
 
+	if integrity.Status != "clean" {
+		publishEvent(commandContext(cmd), events.IntegrityDrift, map[string]string{
+			"integrity_status": integrity.Status,
+			"modified_files":   strconv.Itoa(integrity.ModifiedFiles),
+			"missing_files":    strconv.Itoa(integrity.MissingFiles),
+		})
+	}
+
+	if format != findings.FormatText {
+		report := integrityFindings(installDir, integrity)
+		if err := report.Write(os.Stdout, format); err != nil {
+			return err
+		}
+		return ciFindingsError("integrity check", report)
+	}
+
 	// Display integrity status with visual indicators
 	displayIntegrityStatus(integrity, flags.Verbose)
 
 	// Handle auto-fix if requested
 	if flags.AutoFix && integrity.Status != "clean" {
-		log.Info("🔧 Auto-fixing integrity issues...")
+		log.Info(ui.Emoji("🔧 ") + "Auto-fixing integrity issues...")
 		if err := fixIntegrityIssues(metadataManager, integrity); err != nil {
 			return fmt.Errorf("failed to fix integrity issues: %w", err)
 		}
-		log.Info("✅ Integrity issues fixed")
+		log.Info(ui.Icons.Success + " Integrity issues fixed")
+		return nil
 	}
 
-	return nil
+	return ciFindingsError("integrity check", integrityFindings(installDir, integrity))
 }
 
 // displayIntegrityStatus displays the integrity status with visual indicators
 func displayIntegrityStatus(integrity *metadata.IntegrityMeta, verbose bool) {
 	fmt.Println("\n" + strings.Repeat("=", 60))
-	fmt.Println("🔒 CLAUDE CODE SUPER CREW - FILE INTEGRITY STATUS")
+	fmt.Println(ui.Emoji("🔒 ") + "CLAUDE CODE SUPER CREW - FILE INTEGRITY STATUS")
 	fmt.Println(strings.Repeat("=", 60))
 
 	// Overall status with traffic light indicator
-	fmt.Printf("\n📊 OVERALL STATUS: ")
+	fmt.Printf("\n%sOVERALL STATUS: ", ui.Emoji("📊 "))
 	switch integrity.Status {
 	case "clean":
-		fmt.Println("🟢 CLEAN - All files match original hashes")
+		fmt.Println(ui.Icons.StatusOK + " CLEAN - All files match original hashes")
 	case "warning":
-		fmt.Println("🟡 WARNING - Some files have been modified")
+		fmt.Println(ui.Icons.StatusWarn + " WARNING - Some files have been modified")
 	case "critical":
-		fmt.Println("🔴 CRITICAL - Files are missing or corrupted")
+		fmt.Println(ui.Icons.StatusError + " CRITICAL - Files are missing or corrupted")
 	default:
-		fmt.Println("⚪ UNKNOWN - Status cannot be determined")
+		fmt.Println(ui.Icons.StatusUnknown + " UNKNOWN - Status cannot be determined")
 	}
 
 	// Summary statistics
-	fmt.Printf("\n📈 INTEGRITY SUMMARY:\n")
+	fmt.Printf("\n%sINTEGRITY SUMMARY:\n", ui.Emoji("📈 "))
 	fmt.Printf("   Total Files Tracked: %d\n", integrity.TotalFiles)
-	fmt.Printf("   🟢 Clean Files: %d\n", integrity.CleanFiles)
-	fmt.Printf("   🟡 Modified Files: %d\n", integrity.ModifiedFiles)
-	fmt.Printf("   🔴 Missing Files: %d\n", integrity.MissingFiles)
-	fmt.Printf("   ⚫ Corrupted Files: %d\n", integrity.CorruptedFiles)
+	fmt.Printf("   %s Clean Files: %d\n", ui.Icons.StatusOK, integrity.CleanFiles)
+	fmt.Printf("   %s Modified Files: %d\n", ui.Icons.StatusWarn, integrity.ModifiedFiles)
+	fmt.Printf("   %s Missing Files: %d\n", ui.Icons.StatusError, integrity.MissingFiles)
+	fmt.Printf("   %s Corrupted Files: %d\n", ui.Icons.StatusCritical, integrity.CorruptedFiles)
 	fmt.Printf("   Last Checked: %s\n", integrity.LastScan.Format("2006-01-02 15:04:05"))
 
 	// Show detailed information if verbose or if there are issues
 	if verbose || integrity.Status != "clean" {
-		fmt.Printf("\n🔍 DETAILED FILE STATUS:\n")
+		fmt.Printf("\n%sDETAILED FILE STATUS:\n", ui.Emoji("🔍 "))
 		fmt.Println(strings.Repeat("-", 60))
 
 		for filePath, fileIntegrity := range integrity.FileHashes {
@@ -124,7 +198,7 @@ func displayIntegrityStatus(integrity *metadata.IntegrityMeta, verbose bool) {
 				if len(fileIntegrity.ModificationLog) > 0 {
 					fmt.Printf("   Recent Changes:\n")
 					for _, logEntry := range fileIntegrity.ModificationLog[len(fileIntegrity.ModificationLog)-3:] {
-						fmt.Printf("     • %s\n", logEntry)
+						fmt.Printf("     %s %s\n", ui.Icons.Bullet, logEntry)
 					}
 				}
 				fmt.Println()
@@ -134,38 +208,70 @@ func displayIntegrityStatus(integrity *metadata.IntegrityMeta, verbose bool) {
 
 	// Recommendations
 	if integrity.Status != "clean" {
-		fmt.Printf("\n💡 RECOMMENDATIONS:\n")
+		fmt.Printf("\n%sRECOMMENDATIONS:\n", ui.Emoji("💡 "))
 		if integrity.ModifiedFiles > 0 {
-			fmt.Println("   • Modified files may contain user customizations")
-			fmt.Println("   • Consider backing up before fixing")
-			fmt.Println("   • Use 'crew integrity --fix' to remove modified files")
+			fmt.Println("   " + ui.Icons.Bullet + " Modified files may contain user customizations")
+			fmt.Println("   " + ui.Icons.Bullet + " Consider backing up before fixing")
+			fmt.Println("   " + ui.Icons.Bullet + " Use 'crew integrity --fix' to remove modified files")
 		}
 		if integrity.MissingFiles > 0 {
-			fmt.Println("   • Missing files should be reinstalled")
-			fmt.Println("   • Run 'crew install' to restore missing files")
+			fmt.Println("   " + ui.Icons.Bullet + " Missing files should be reinstalled")
+			fmt.Println("   " + ui.Icons.Bullet + " Run 'crew install' to restore missing files")
 		}
 		if integrity.CorruptedFiles > 0 {
-			fmt.Println("   • Corrupted files cannot be read")
-			fmt.Println("   • These files should be removed and reinstalled")
+			fmt.Println("   " + ui.Icons.Bullet + " Corrupted files cannot be read")
+			fmt.Println("   " + ui.Icons.Bullet + " These files should be removed and reinstalled")
 		}
 	}
 
 	fmt.Println(strings.Repeat("=", 60))
 }
 
+// Rules of integrity findings
+var integrityRules = []findings.Rule{
+	{ID: "crew/integrity/modified", Level: findings.LevelWarning,
+		Description: "An installed framework file differs from the installed version",
+		Help:        "Keep the change, or restore the file with 'crew install --force'"},
+	{ID: "crew/integrity/missing", Level: findings.LevelError,
+		Description: "An installed framework file is missing",
+		Help:        "Restore the file with 'crew install'"},
+	{ID: "crew/integrity/corrupted", Level: findings.LevelError,
+		Description: "An installed framework file cannot be read",
+		Help:        "Remove the file and restore it with 'crew install'"},
+}
+
+// integrityFindings turns the files of a check that are not clean into
+// findings, with paths relative to installDir
+func integrityFindings(installDir string, integrity *metadata.IntegrityMeta) *findings.Report {
+	report := findings.NewReport(buildinfo.Get().Version, installDir, integrityRules...)
+	for filePath, file := range integrity.FileHashes {
+		if file.Status == "clean" {
+			continue
+		}
+		path := filePath
+		if rel, err := filepath.Rel(installDir, filePath); err == nil && !strings.HasPrefix(rel, "..") {
+			path = rel
+		}
+		report.Add("crew/integrity/"+file.Status, fmt.Sprintf("%s is %s", path, file.Status), path,
+			map[string]string{"component": file.Component})
+	}
+	report.Sort()
+	return report
+}
+
 // getStatusIcon returns the appropriate icon for a file status
 func getStatusIcon(status string) string {
 	switch status {
 	case "clean":
-		return "🟢"
+		return ui.Icons.StatusOK
 	case "modified":
-		return "🟡"
+		return ui.Icons.StatusWarn
 	case "missing":
-		return "🔴"
+		return ui.Icons.StatusError
 	case "corrupted":
-		return "⚫"
+		return ui.Icons.StatusCritical
 	default:
-		return "⚪"
+		return ui.Icons.StatusUnknown
 	}
 }
 
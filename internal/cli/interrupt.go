@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+)
+
+// ExitInterrupted is the exit status of a command stopped by Ctrl-C, as
+// shells report for SIGINT
+const ExitInterrupted = 130
+
+// commandContext returns the context of cmd, which is nil when the command
+// runs outside of Execute, e.g. in tests
+func commandContext(cmd *cobra.Command) context.Context {
+	if ctx := cmd.Context(); ctx != nil {
+		return ctx
+	}
+	return context.Background()
+}
+
+// interruptible wraps a RunE so that SIGINT and SIGTERM cancel the command
+// context instead of killing the process. The command stops after the step
+// in progress and exits with ExitInterrupted. Nothing is rolled back: the
+// steps that finished are kept, so running the command again resumes. A
+// second Ctrl-C quits immediately.
+//
+// Only commands that pass the context down are wrapped, so Ctrl-C keeps
+// its usual meaning everywhere else, including at prompts.
+func interruptible(run func(*cobra.Command, []string) error) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := context.WithCancel(commandContext(cmd))
+		defer cancel()
+
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+		defer signal.Stop(signals)
+		go func() {
+			select {
+			case <-signals:
+				fmt.Fprintln(os.Stderr, "\nInterrupted, stopping after the current step (press Ctrl-C again to quit now)")
+				signal.Stop(signals)
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+
+		cmd.SetContext(ctx)
+		err := run(cmd, args)
+		if ctx.Err() != nil {
+			return &ExitError{
+				Code: ExitInterrupted,
+				Err:  fmt.Errorf("%s interrupted: %w; changes made so far were kept, run it again to resume", cmd.CommandPath(), ctx.Err()),
+			}
+		}
+		return err
+	}
+}
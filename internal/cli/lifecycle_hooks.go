@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"context"
+	"sync"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/crewdirs"
+	"github.com/jonwraymond/claude-code-super-crew/internal/events"
+	"github.com/jonwraymond/claude-code-super-crew/internal/hooks"
+	"github.com/jonwraymond/claude-code-super-crew/pkg/logger"
+)
+
+// lifecycleHooksOnce guards the bus subscription so building the root
+// command more than once (as tests do) does not run hooks twice
+var lifecycleHooksOnce sync.Once
+
+// registerEventSubscribers connects the lifecycle hook engine, the
+// operation report writer and the shell prompt cache to the event bus
+func registerEventSubscribers() {
+	lifecycleHooksOnce.Do(func() {
+		hooks.SubscribeLifecycleHooks(events.Default(), runLifecycleHooks)
+		events.Default().Subscribe("install.*", recordOperationReport)
+		events.Default().Subscribe("update.*", recordOperationReport)
+		events.Default().Subscribe(events.InstallCompleted, refreshPromptCacheOnChange)
+		events.Default().Subscribe(events.UpdateCompleted, refreshPromptCacheOnChange)
+	})
+}
+
+// publishEvent publishes an event of the operation running under ctx on the
+// default bus. For *.started events a non-nil error means a subscriber
+// vetoed the operation.
+func publishEvent(ctx context.Context, eventType string, data map[string]string) error {
+	return events.Default().PublishContext(ctx, eventType, data)
+}
+
+// getLifecycleHooksDir returns the directory holding lifecycle hook descriptors
+func getLifecycleHooksDir() string {
+	return crewdirs.Path(globalFlags.InstallDir, "hooks")
+}
+
+// runLifecycleHooks runs the lifecycle hooks registered for event. The
+// returned error is non-nil only when a hook with the abort policy fails,
+// which callers of pre-* events treat as cancelling the operation. The hooks
+// are stopped when ctx is cancelled.
+func runLifecycleHooks(ctx context.Context, event hooks.LifecycleEvent, env map[string]string) error {
+	log := logger.GetLogger()
+
+	runtime := hooks.NewRuntime(getLifecycleHooksDir())
+	if err := runtime.Load(); err != nil {
+		log.Warnf("Failed to load lifecycle hooks: %v", err)
+		return nil
+	}
+
+	matched := runtime.HooksForEvent(event)
+	if len(matched) == 0 {
+		return nil
+	}
+
+	if globalFlags.DryRun {
+		for _, hook := range matched {
+			log.Infof("[DRY RUN] Would run %s hook: %s", event, hook.Name)
+		}
+		return nil
+	}
+
+	runtime.SetEnv("CREW_INSTALL_DIR", globalFlags.InstallDir)
+
+	results, err := runtime.Run(ctx, event, env)
+	for _, result := range results {
+		if result.Err == nil {
+			log.Debugf("Hook %s completed in %s", result.Hook.Name, result.Duration)
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,175 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/orchestrator"
+	"github.com/jonwraymond/claude-code-super-crew/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// LoadFlags holds load command flags
+type LoadFlags struct {
+	ProjectDir          string
+	RefreshOrchestrator bool
+	JSON                bool
+}
+
+var loadFlags LoadFlags
+
+// NewLoadCommand creates the load command
+func NewLoadCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "load",
+		Short: "Analyze a project and refresh its orchestrator",
+		Long: `Analyze the project the way /crew:onboard does and show the languages and
+frameworks it uses with a ranked list of the MCP servers, CLI tools and
+specialists that suit it. The MCP servers come from the catalog ('crew mcp
+list'); -v shows why each enhancement is recommended.
+
+--refresh-orchestrator re-renders .claude/agents/orchestrator-specialist.md
+from the latest global template and regenerates its routing rules from the
+analysis and the project's specialists. The section between the
+<!-- BEGIN crew:custom --> and <!-- END crew:custom --> anchors is kept as
+it is; the previous file is saved as orchestrator-specialist.md.backup.
+
+Examples:
+  crew load
+  crew load --refresh-orchestrator
+  crew load --refresh-orchestrator --project-dir ../api`,
+		RunE:         runLoad,
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().StringVar(&loadFlags.ProjectDir, "project-dir", "",
+		"Project directory (default: the project containing the current directory)")
+	cmd.Flags().BoolVar(&loadFlags.RefreshOrchestrator, "refresh-orchestrator", false,
+		"Re-render the project orchestrator from the latest template")
+	cmd.Flags().BoolVar(&loadFlags.JSON, "json", false, "Output the result as JSON")
+	registerFlagCompletions(cmd, map[string]completionFunc{
+		"project-dir": func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+			return nil, cobra.ShellCompDirectiveFilterDirs
+		},
+	})
+
+	return cmd
+}
+
+func runLoad(cmd *cobra.Command, args []string) error {
+	projectDir, err := resolveProjectDir(loadFlags.ProjectDir)
+	if err != nil {
+		return err
+	}
+	asJSON := loadFlags.JSON || globalFlags.Output == "json"
+
+	if loadFlags.RefreshOrchestrator {
+		template := filepath.Join(getGlobalInstallDir(), "agents", "orchestrator-specialist.md")
+		if _, err := os.Stat(template); os.IsNotExist(err) {
+			return fmt.Errorf("orchestrator template not found at %s; run 'crew install' first", template)
+		}
+		refresh, err := orchestrator.RefreshOrchestrator(projectDir, template)
+		if err != nil {
+			return err
+		}
+		if asJSON {
+			return encodeLoadJSON(refresh)
+		}
+		displayOrchestratorRefresh(refresh)
+		return nil
+	}
+
+	chars, err := orchestrator.NewProjectAnalyzer(projectDir).Analyze()
+	if err != nil {
+		return fmt.Errorf("failed to analyze project: %w", err)
+	}
+	catalog, err := loadMCPCatalog(projectDir)
+	if err != nil {
+		return err
+	}
+	report := orchestrator.Recommend(chars, catalog)
+	if asJSON {
+		return encodeLoadJSON(struct {
+			*orchestrator.ProjectCharacteristics
+			Recommendations *orchestrator.RecommendationReport `json:"recommendations"`
+		}{chars, report})
+	}
+	displayProjectAnalysis(projectDir, chars, report)
+	return nil
+}
+
+func encodeLoadJSON(v interface{}) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+func displayProjectAnalysis(projectDir string, chars *orchestrator.ProjectCharacteristics, report *orchestrator.RecommendationReport) {
+	fmt.Printf("\n%s%sProject Analysis%s\n", ui.ColorCyan, ui.ColorBright, ui.ColorReset)
+	fmt.Println(strings.Repeat("=", 50))
+	fmt.Printf("%sProject:%s %s\n\n", ui.ColorBlue, ui.ColorReset, projectDir)
+
+	language := chars.MainLanguage
+	if language == "" {
+		language = "not detected"
+	}
+	fmt.Printf("  Language:    %s\n", language)
+	if len(chars.Frameworks) > 0 {
+		fmt.Printf("  Frameworks:  %s\n", strings.Join(chars.Frameworks, ", "))
+	}
+	if traits := chars.Traits(); len(traits) > 0 {
+		fmt.Printf("  Detected:    %s\n", strings.Join(traits, ", "))
+	}
+	if chars.Git != nil {
+		fmt.Printf("  History:     %s\n", chars.Git.Summary())
+	}
+
+	for _, section := range []struct {
+		title string
+		recs  []orchestrator.Recommendation
+	}{
+		{"Recommended MCP servers", report.MCPServers},
+		{"Recommended CLI tools", report.CLITools},
+		{"Recommended specialists", report.Specialists},
+	} {
+		fmt.Printf("\n%s%s:%s\n", ui.ColorBlue, section.title, ui.ColorReset)
+		if len(section.recs) == 0 {
+			fmt.Println("  None")
+		}
+		for _, rec := range section.recs {
+			fmt.Printf("  %s %-28s %sscore %d%s\n", ui.Icons.Bullet, rec.Name, ui.ColorDim, rec.Score, ui.ColorReset)
+			if globalFlags.Verbose {
+				fmt.Printf("      %s\n", strings.Join(rec.Reasons, "; "))
+			}
+		}
+	}
+	if flags := report.LoadFlags(); len(flags) > 0 {
+		fmt.Printf("\n%s Enable them in Claude Code with: /crew:onboard %s\n", ui.Icons.Tip, strings.Join(flags, " "))
+	}
+	fmt.Printf("%s Run 'crew load --refresh-orchestrator' to update the orchestrator's routing rules\n\n", ui.Icons.Tip)
+}
+
+func displayOrchestratorRefresh(refresh *orchestrator.Refresh) {
+	if !refresh.Changed {
+		fmt.Printf("%s%s Orchestrator is up to date:%s %s\n", ui.ColorGreen, ui.Icons.Check, ui.ColorReset, refresh.Path)
+		return
+	}
+
+	fmt.Printf("%s%s Refreshed%s %s\n", ui.ColorGreen, ui.Icons.Success, ui.ColorReset, refresh.Path)
+	fmt.Printf("  Template: %s\n", refresh.Template)
+	if len(refresh.Specialists) > 0 {
+		fmt.Printf("  Routes to: %s\n", strings.Join(refresh.Specialists, ", "))
+	}
+	if refresh.Backup != "" {
+		fmt.Printf("  Previous version saved to %s\n", refresh.Backup)
+		if !refresh.PreservedCustom {
+			ui.DisplayWarning("The previous orchestrator had no crew:custom section; copy any changes you made from the backup into it")
+		}
+	}
+	if len(refresh.Missing) > 0 {
+		fmt.Printf("\n%s Recommended specialists not created yet: %s (run /crew:onboard)\n", ui.Icons.Tip, strings.Join(refresh.Missing, ", "))
+	}
+}
@@ -0,0 +1,53 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/crewdirs"
+	"github.com/jonwraymond/claude-code-super-crew/pkg/logger"
+	"github.com/spf13/cobra"
+)
+
+// skipFileLogAnnotation marks commands that must not write to the log file,
+// such as crew logs reading it
+const skipFileLogAnnotation = "crew.skip-file-log"
+
+// configureLogging applies --log-level and --log-format, tags the command's
+// log entries with a correlation ID and, for an existing installation,
+// starts writing the log file under .crew/logs
+func configureLogging(cmd *cobra.Command) error {
+	log := logger.GetLogger()
+
+	if cmd.Flags().Changed("log-level") {
+		switch strings.ToLower(globalFlags.LogLevel) {
+		case "debug", "info", "warn", "warning", "error":
+			log.SetLevel(logger.ParseLogLevel(strings.ToLower(globalFlags.LogLevel)))
+		default:
+			return fmt.Errorf("invalid --log-level %q: must be debug, info, warn or error", globalFlags.LogLevel)
+		}
+	}
+
+	format, err := logger.ParseFormat(globalFlags.LogFormat)
+	if err != nil {
+		return err
+	}
+	logger.SetFormat(format)
+
+	cid := logger.StartCommand(cmd.CommandPath())
+
+	// Only log to disk once crew state exists; a fresh machine or a custom
+	// --install-dir that was never installed is left untouched
+	crewDir := crewdirs.Path(globalFlags.InstallDir)
+	_, skipFileLog := cmd.Annotations[skipFileLogAnnotation]
+	if info, err := os.Stat(crewDir); err == nil && info.IsDir() && !testMode && !skipFileLog {
+		if err := log.InitializeFileLogging(filepath.Join(crewDir, "logs")); err != nil {
+			log.Debugf("File logging disabled: %v", err)
+		}
+	}
+
+	log.Debugf("Running %s (correlation id %s)", cmd.CommandPath(), cid)
+	return nil
+}
@@ -0,0 +1,409 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/crewdirs"
+	"github.com/jonwraymond/claude-code-super-crew/internal/ui"
+	"github.com/jonwraymond/claude-code-super-crew/pkg/logger"
+	"github.com/spf13/cobra"
+)
+
+// LogsFlags holds logs command flags
+type LogsFlags struct {
+	Last    int
+	Follow  bool
+	Grep    string
+	Since   string
+	Level   string
+	CID     string
+	Reports bool
+}
+
+// logFollowInterval is how often --follow polls the active log file
+const logFollowInterval = 500 * time.Millisecond
+
+// NewLogsCommand creates the logs command
+func NewLogsCommand() *cobra.Command {
+	var flags LogsFlags
+
+	cmd := &cobra.Command{
+		Use:   "logs",
+		Short: "View and filter crew operation logs",
+		Long: `Show entries from the crew log files in .crew/logs, including rotated
+files, or the reports written after each install and update.
+
+Examples:
+  crew logs                        # Last 50 entries
+  crew logs --since 2h --level warn
+  crew logs --grep "hooks" --last 0
+  crew logs --cid 3f9a1c2b7d4e     # One command invocation
+  crew logs --follow
+  crew logs --reports              # Install and update history
+  crew logs --output json          # Raw JSON lines`,
+		Annotations: map[string]string{skipFileLogAnnotation: "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runLogs(cmd, flags)
+		},
+	}
+
+	cmd.Flags().IntVarP(&flags.Last, "last", "n", 50, "Show the last N entries (0 for all)")
+	cmd.Flags().BoolVarP(&flags.Follow, "follow", "f", false, "Keep printing new entries as they are written")
+	cmd.Flags().StringVar(&flags.Grep, "grep", "", "Only show entries matching this regular expression (case-insensitive)")
+	cmd.Flags().StringVar(&flags.Since, "since", "", "Only show entries newer than a duration (30m, 2h, 7d) or a date (2006-01-02 or RFC3339)")
+	cmd.Flags().StringVar(&flags.Level, "level", "", "Minimum level to show: debug, info, warn or error")
+	cmd.Flags().StringVar(&flags.CID, "cid", "", "Only show entries with this correlation ID")
+	cmd.Flags().BoolVar(&flags.Reports, "reports", false, "List install and update reports instead of log entries")
+
+	registerFlagCompletions(cmd, map[string]completionFunc{
+		"level": completeStatic("debug", "info", "warn", "error"),
+	})
+
+	return cmd
+}
+
+// logEntry is one parsed line of a crew log file
+type logEntry struct {
+	Time    time.Time
+	Level   string
+	Message string
+	CID     string
+	Command string
+	Error   string
+	Success bool
+	Raw     string
+}
+
+// logFilter selects which entries are shown
+type logFilter struct {
+	since    time.Time
+	pattern  *regexp.Regexp
+	minLevel logger.LogLevel
+	levelSet bool
+	cid      string
+}
+
+func runLogs(cmd *cobra.Command, flags LogsFlags) error {
+	filter, err := newLogFilter(flags, time.Now())
+	if err != nil {
+		return err
+	}
+	out := cmd.OutOrStdout()
+	logDir := crewdirs.Path(globalFlags.InstallDir, "logs")
+
+	if flags.Reports {
+		return showReports(out, getReportsDir(), filter, flags.Last)
+	}
+
+	files := logger.LogFiles(logDir, logger.DefaultName)
+	if len(files) == 0 && !flags.Follow {
+		logger.GetLogger().Infof("No logs found in %s", logDir)
+		return nil
+	}
+
+	var entries []logEntry
+	for _, file := range files {
+		fileEntries, err := readLogFile(file)
+		if err != nil {
+			return err
+		}
+		for _, entry := range fileEntries {
+			if filter.matches(entry) {
+				entries = append(entries, entry)
+			}
+		}
+	}
+	if flags.Last > 0 && len(entries) > flags.Last {
+		entries = entries[len(entries)-flags.Last:]
+	}
+	for _, entry := range entries {
+		printLogEntry(out, entry)
+	}
+
+	if !flags.Follow {
+		return nil
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	return followLog(ctx, out, filepath.Join(logDir, logger.DefaultName+".log"), filter)
+}
+
+// newLogFilter builds the filter described by the command flags
+func newLogFilter(flags LogsFlags, now time.Time) (logFilter, error) {
+	filter := logFilter{cid: flags.CID}
+
+	if flags.Since != "" {
+		since, err := parseSince(flags.Since, now)
+		if err != nil {
+			return filter, err
+		}
+		filter.since = since
+	}
+	if flags.Grep != "" {
+		pattern, err := regexp.Compile("(?i)" + flags.Grep)
+		if err != nil {
+			return filter, fmt.Errorf("invalid --grep pattern: %w", err)
+		}
+		filter.pattern = pattern
+	}
+	if flags.Level != "" {
+		switch strings.ToLower(flags.Level) {
+		case "debug", "info", "warn", "warning", "error":
+			filter.minLevel = logger.ParseLogLevel(strings.ToLower(flags.Level))
+			filter.levelSet = true
+		default:
+			return filter, fmt.Errorf("invalid --level %q: must be debug, info, warn or error", flags.Level)
+		}
+	}
+	return filter, nil
+}
+
+// parseSince accepts a duration (with a d suffix for days) counted back from
+// now, a date, or an RFC3339 timestamp
+func parseSince(value string, now time.Time) (time.Time, error) {
+	if strings.HasSuffix(value, "d") {
+		if days, err := strconv.Atoi(strings.TrimSuffix(value, "d")); err == nil {
+			return now.AddDate(0, 0, -days), nil
+		}
+	}
+	if d, err := time.ParseDuration(value); err == nil {
+		return now.Add(-d), nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid --since %q: use a duration like 2h or 7d, or a date like 2006-01-02", value)
+}
+
+// matches reports whether entry passes every active filter
+func (f logFilter) matches(entry logEntry) bool {
+	if !f.since.IsZero() && (entry.Time.IsZero() || entry.Time.Before(f.since)) {
+		return false
+	}
+	if f.levelSet && (entry.Level == "" || logger.ParseLogLevel(entry.Level) < f.minLevel) {
+		return false
+	}
+	if f.cid != "" && entry.CID != f.cid {
+		return false
+	}
+	if f.pattern != nil && !f.pattern.MatchString(entry.Raw) {
+		return false
+	}
+	return true
+}
+
+// readLogFile parses every line of a log file
+func readLogFile(path string) ([]logEntry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open log file: %w", err)
+	}
+	defer file.Close()
+
+	var entries []logEntry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if line := scanner.Text(); strings.TrimSpace(line) != "" {
+			entries = append(entries, parseLogLine(line))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", filepath.Base(path), err)
+	}
+	return entries, nil
+}
+
+// parseLogLine decodes a JSON log line; anything else is kept as raw text
+func parseLogLine(line string) logEntry {
+	entry := logEntry{Raw: line, Message: line}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal([]byte(line), &fields); err != nil {
+		return entry
+	}
+
+	str := func(key string) string {
+		value, _ := fields[key].(string)
+		return value
+	}
+	entry.Level = str("level")
+	entry.Message = str("message")
+	entry.CID = str("cid")
+	entry.Command = str("command")
+	entry.Error = str("error")
+	entry.Success = str("type") == "SUCCESS"
+	if t, err := time.Parse(time.RFC3339, str("time")); err == nil {
+		entry.Time = t
+	}
+	return entry
+}
+
+// printLogEntry writes entry as a raw JSON line with --output json, or as a
+// colorized line otherwise
+func printLogEntry(w io.Writer, entry logEntry) {
+	if globalFlags.Output == "json" {
+		fmt.Fprintln(w, entry.Raw)
+		return
+	}
+	if entry.Level == "" && entry.Time.IsZero() {
+		fmt.Fprintln(w, entry.Raw)
+		return
+	}
+
+	label, color := strings.ToUpper(entry.Level), ui.Colors.Cyan
+	switch {
+	case entry.Success:
+		label, color = "OK", ui.Colors.Green
+	case entry.Level == "debug":
+		color = ui.Colors.Gray
+	case entry.Level == "warn":
+		color = ui.Colors.Yellow
+	case entry.Level == "error" || entry.Level == "fatal":
+		color = ui.Colors.Red
+	}
+
+	line := fmt.Sprintf("%s %s%-5s%s", entry.Time.Local().Format("2006-01-02 15:04:05"), color, label, ui.Colors.Reset)
+	if entry.CID != "" {
+		line += fmt.Sprintf(" %s[%s]%s", ui.Colors.Gray, entry.CID, ui.Colors.Reset)
+	}
+	line += " " + entry.Message
+	if entry.Error != "" {
+		line += ": " + entry.Error
+	}
+	fmt.Fprintln(w, line)
+}
+
+// followLog prints entries appended to path until ctx is cancelled. When
+// the file shrinks it has been rotated, so reading restarts from the top.
+func followLog(ctx context.Context, w io.Writer, path string, filter logFilter) error {
+	var offset int64
+	if info, err := os.Stat(path); err == nil {
+		offset = info.Size()
+	}
+
+	ticker := time.NewTicker(logFollowInterval)
+	defer ticker.Stop()
+
+	var partial string
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		if info.Size() < offset {
+			offset, partial = 0, ""
+		}
+		if info.Size() == offset {
+			continue
+		}
+
+		data, err := readFrom(path, offset)
+		if err != nil {
+			return err
+		}
+		offset += int64(len(data))
+
+		lines := strings.Split(partial+string(data), "\n")
+		partial = lines[len(lines)-1]
+		for _, line := range lines[:len(lines)-1] {
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+			if entry := parseLogLine(line); filter.matches(entry) {
+				printLogEntry(w, entry)
+			}
+		}
+	}
+}
+
+// readFrom returns the contents of path after offset
+func readFrom(path string, offset int64) ([]byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open log file: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("failed to seek log file: %w", err)
+	}
+	return io.ReadAll(file)
+}
+
+// showReports lists install and update reports matching filter
+func showReports(w io.Writer, dir string, filter logFilter, last int) error {
+	reports, err := loadOperationReports(dir)
+	if err != nil {
+		return err
+	}
+
+	var shown []operationReport
+	for _, report := range reports {
+		if !filter.since.IsZero() && report.Finished.Before(filter.since) {
+			continue
+		}
+		if filter.cid != "" && report.CorrelationID != filter.cid {
+			continue
+		}
+		if filter.pattern != nil && !filter.pattern.MatchString(report.Operation+" "+report.Status+" "+strings.Join(report.Components, ",")) {
+			continue
+		}
+		shown = append(shown, report)
+	}
+	if last > 0 && len(shown) > last {
+		shown = shown[len(shown)-last:]
+	}
+
+	if globalFlags.Output == "json" {
+		encoder := json.NewEncoder(w)
+		for _, report := range shown {
+			if err := encoder.Encode(report); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if len(shown) == 0 {
+		logger.GetLogger().Infof("No install or update reports found in %s", dir)
+		return nil
+	}
+	for _, report := range shown {
+		color := ui.Colors.Green
+		if report.Status != "completed" {
+			color = ui.Colors.Red
+		}
+		fmt.Fprintf(w, "%s %-8s %s%-9s%s %6.1fs  %s", report.Finished.Local().Format("2006-01-02 15:04:05"),
+			report.Operation, color, report.Status, ui.Colors.Reset, report.Duration, strings.Join(report.Components, ", "))
+		if len(report.Failed) > 0 {
+			fmt.Fprintf(w, "  %sfailed: %s%s", ui.Colors.Red, strings.Join(report.Failed, ", "), ui.Colors.Reset)
+		}
+		if report.CorrelationID != "" {
+			fmt.Fprintf(w, "  %s[%s]%s", ui.Colors.Gray, report.CorrelationID, ui.Colors.Reset)
+		}
+		fmt.Fprintln(w)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/ui"
+)
+
+const sampleLog = `{"level":"debug","logger":"supercrew","command":"crew install","cid":"aaa111","time":"2026-01-02T10:00:00Z","message":"Running crew install"}
+{"level":"warn","logger":"supercrew","command":"crew install","cid":"aaa111","time":"2026-01-02T10:00:05Z","message":"Hooks directory missing"}
+not a json line
+{"level":"info","logger":"supercrew","command":"crew update","cid":"bbb222","type":"SUCCESS","time":"2026-01-03T09:00:00Z","message":"Update completed"}
+`
+
+func TestLogFilter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "supercrew.log")
+	if err := os.WriteFile(path, []byte(sampleLog), 0644); err != nil {
+		t.Fatalf("Failed to write log: %v", err)
+	}
+	entries, err := readLogFile(path)
+	if err != nil {
+		t.Fatalf("readLogFile failed: %v", err)
+	}
+	if len(entries) != 4 {
+		t.Fatalf("Expected 4 entries, got %d", len(entries))
+	}
+
+	now := time.Date(2026, 1, 3, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		flags    LogsFlags
+		expected int
+	}{
+		{"no filter", LogsFlags{}, 4},
+		{"level warn", LogsFlags{Level: "warn"}, 1},
+		{"since 1d", LogsFlags{Since: "1d"}, 1},
+		{"grep", LogsFlags{Grep: "HOOKS"}, 1},
+		{"cid", LogsFlags{CID: "aaa111"}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := newLogFilter(tt.flags, now)
+			if err != nil {
+				t.Fatalf("newLogFilter failed: %v", err)
+			}
+			count := 0
+			for _, entry := range entries {
+				if filter.matches(entry) {
+					count++
+				}
+			}
+			if count != tt.expected {
+				t.Errorf("Expected %d entries, got %d", tt.expected, count)
+			}
+		})
+	}
+
+	if _, err := newLogFilter(LogsFlags{Since: "yesterday"}, now); err == nil {
+		t.Error("Expected an error for an invalid --since value")
+	}
+}
+
+func TestPrintLogEntry(t *testing.T) {
+	ui.Configure(ui.StyleOptions{Color: false})
+	defer ui.Configure(ui.StyleOptions{Color: true})
+
+	var out bytes.Buffer
+	printLogEntry(&out, parseLogLine(`{"level":"error","cid":"ccc333","time":"2026-01-02T10:00:00Z","message":"Install failed","error":"disk full"}`))
+	line := out.String()
+	if !strings.Contains(line, "ERROR") || !strings.Contains(line, "[ccc333]") || !strings.Contains(line, "Install failed: disk full") {
+		t.Errorf("Unexpected formatted entry: %q", line)
+	}
+}
+
+func TestOperationReports(t *testing.T) {
+	dir := t.TempDir()
+	finished := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	for i, op := range []string{"update", "install"} {
+		report := operationReport{
+			Operation:  op,
+			Status:     "completed",
+			Components: []string{"core"},
+			Started:    finished.Add(-time.Minute),
+			Finished:   finished.Add(-time.Duration(i) * time.Hour),
+			Duration:   60,
+		}
+		if err := writeOperationReport(dir, report); err != nil {
+			t.Fatalf("writeOperationReport failed: %v", err)
+		}
+	}
+
+	reports, err := loadOperationReports(dir)
+	if err != nil {
+		t.Fatalf("loadOperationReports failed: %v", err)
+	}
+	if len(reports) != 2 || reports[0].Operation != "install" || reports[1].Operation != "update" {
+		t.Errorf("Expected reports oldest first, got %+v", reports)
+	}
+}
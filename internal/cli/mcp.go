@@ -0,0 +1,168 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/mcpcatalog"
+	"github.com/jonwraymond/claude-code-super-crew/internal/pathsutil"
+	"github.com/jonwraymond/claude-code-super-crew/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// MCPFlags holds mcp command flags
+type MCPFlags struct {
+	ProjectDir string
+	JSON       bool
+	For        string
+}
+
+var mcpFlags MCPFlags
+
+// NewMCPCommand creates the mcp command
+func NewMCPCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "mcp",
+		Short: "Browse the catalog of MCP servers crew can set up",
+		Long: `Browse the MCP servers crew knows: what each does, how Claude Code
+starts it, the runtime it needs and the project types it is recommended
+for. /crew:onboard offers these servers and documents the chosen ones in
+CLAUDE.md; the mcp component installs the ones started with npx.
+
+Add servers, or change fields of built-in ones, in mcp-catalog.json in
+crew's config directory, globally (~/.claude/.crew/config) or for a
+project (.claude/.crew/config); the project's file wins:
+
+  {"servers": [
+    {"name": "github", "description": "GitHub issues and pull requests",
+     "command": ["docker", "run", "-i", "--rm", "ghcr.io/github/github-mcp-server"],
+     "runtime": "docker", "api_key_env": "GITHUB_PERSONAL_ACCESS_TOKEN",
+     "project_types": ["go", "backend"]},
+    {"name": "context7", "version": "1.0.14"}
+  ]}`,
+	}
+
+	cmd.PersistentFlags().StringVar(&mcpFlags.ProjectDir, "project-dir", "",
+		"Project directory (default: the project containing the current directory)")
+	registerFlagCompletions(cmd, map[string]completionFunc{
+		"project-dir": func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+			return nil, cobra.ShellCompDirectiveFilterDirs
+		},
+	})
+
+	cmd.AddCommand(newMCPListCommand())
+
+	return cmd
+}
+
+func newMCPListCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the MCP servers of the catalog and whether they can run here",
+		Long: `List the MCP servers of the catalog with their runtime, version,
+status and health. A server is unhealthy when its runtime (npx, uvx,
+docker) is missing, its API key is not set or it is deprecated.
+
+Examples:
+  crew mcp list
+  crew mcp list --for go       # Servers recommended for Go projects
+  crew mcp list --json`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runMCPList()
+		},
+	}
+	cmd.Flags().BoolVar(&mcpFlags.JSON, "json", false, "Output the catalog as JSON")
+	cmd.Flags().StringVar(&mcpFlags.For, "for", "",
+		"Only list servers recommended for a project type (e.g. go, backend, react, fullstack)")
+	return cmd
+}
+
+// mcpListEntry is a catalog server with its health on this system
+type mcpListEntry struct {
+	mcpcatalog.Server
+	InstallCommand []string `json:"install_command"`
+	Healthy        bool     `json:"healthy"`
+	Problems       []string `json:"problems,omitempty"`
+}
+
+// loadMCPCatalog reads the catalog of the installation and of the project
+func loadMCPCatalog(projectDir string) (*mcpcatalog.Catalog, error) {
+	projectDir, err := resolveProjectDir(projectDir)
+	if err != nil {
+		return nil, err
+	}
+	return mcpcatalog.Load(getGlobalInstallDir(), pathsutil.ClaudeDir(projectDir))
+}
+
+func runMCPList() error {
+	catalog, err := loadMCPCatalog(mcpFlags.ProjectDir)
+	if err != nil {
+		return err
+	}
+
+	servers := catalog.Servers()
+	if mcpFlags.For != "" {
+		servers = catalog.Recommended(mcpFlags.For)
+	}
+	entries := make([]mcpListEntry, 0, len(servers))
+	for _, server := range servers {
+		problems := server.Health(exec.LookPath, os.Getenv)
+		entries = append(entries, mcpListEntry{
+			Server:         server,
+			InstallCommand: server.InstallCommand(),
+			Healthy:        len(problems) == 0,
+			Problems:       problems,
+		})
+	}
+
+	if mcpFlags.JSON || globalFlags.Output == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(entries)
+	}
+
+	if len(entries) == 0 {
+		fmt.Printf("No MCP servers are recommended for %s projects.\n", mcpFlags.For)
+		return nil
+	}
+
+	fmt.Printf("%-22s %-8s %-10s %-10s %s\n", "NAME", "RUNTIME", "VERSION", "STATUS", "HEALTH")
+	for _, entry := range entries {
+		runtime, version, status := entry.Runtime, entry.Version, entry.Status
+		if runtime == "" {
+			runtime = "-"
+		}
+		if version == "" {
+			version = "latest"
+		}
+		if status == "" {
+			status = mcpcatalog.StatusStable
+		}
+		health := ui.Icons.Success + " ok"
+		if !entry.Healthy {
+			health = ui.Icons.Warning + " " + strings.Join(entry.Problems, ", ")
+		}
+		name := entry.Name
+		if entry.Required {
+			name += " *"
+		}
+		fmt.Printf("%-22s %-8s %-10s %-10s %s\n", name, runtime, version, status, health)
+		fmt.Printf("  %s\n", entry.Description)
+		if globalFlags.Verbose {
+			fmt.Printf("  %s%s%s\n", ui.ColorCyan, strings.Join(entry.InstallCommand, " "), ui.ColorReset)
+			if len(entry.ProjectTypes) > 0 {
+				fmt.Printf("  recommended for: %s\n", strings.Join(entry.ProjectTypes, ", "))
+			}
+			if entry.Source != mcpcatalog.BuiltinSource {
+				fmt.Printf("  from %s\n", entry.Source)
+			}
+		}
+	}
+	fmt.Println("\n* installed by the mcp component whatever servers are chosen")
+	return nil
+}
@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/relocate"
+	"github.com/jonwraymond/claude-code-super-crew/internal/ui"
+	"github.com/jonwraymond/claude-code-super-crew/pkg/logger"
+	"github.com/spf13/cobra"
+)
+
+// MigrateInstallFlags holds migrate-install command flags
+type MigrateInstallFlags struct {
+	To       string
+	Projects []string
+	Link     bool
+	JSON     bool
+}
+
+// NewMigrateInstallCommand creates the migrate-install command
+func NewMigrateInstallCommand() *cobra.Command {
+	var flags MigrateInstallFlags
+
+	cmd := &cobra.Command{
+		Use:   "migrate-install",
+		Short: "Move the installation to another directory",
+		Long: `Move an existing installation, e.g. from ~/.claude to a directory managed
+with your dotfiles, and rewrite the paths that point at the old location:
+
+  metadata   installation.install_dir in crew-metadata.json
+  settings   paths in settings.json and settings.local.json
+  project    global_commands and settings of the given projects
+
+By default a symlink is left at the old location, so Claude and crew keep
+finding the installation there. Without it, pass --install-dir to crew
+from then on. Use --dry-run to see the plan without changing anything.
+
+Examples:
+  crew migrate-install --to ~/dotfiles/claude --dry-run
+  crew migrate-install --to ~/dotfiles/claude --project ~/src/app
+  crew migrate-install --to /opt/claude --link=false`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runMigrateInstall(flags)
+		},
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().StringVar(&flags.To, "to", "", "Directory to move the installation to (must not exist or be empty)")
+	cmd.Flags().StringSliceVar(&flags.Projects, "project", nil,
+		"Project directory whose crew configuration to update (repeatable; default: current directory)")
+	cmd.Flags().BoolVar(&flags.Link, "link", true, "Leave a symlink at the old location")
+	cmd.Flags().BoolVar(&flags.JSON, "json", false, "Output the plan as JSON")
+	cmd.MarkFlagRequired("to")
+	cmd.MarkFlagDirname("to")
+
+	return cmd
+}
+
+func runMigrateInstall(flags MigrateInstallFlags) error {
+	log := logger.GetLogger()
+
+	projects := flags.Projects
+	if len(projects) == 0 {
+		wd, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("failed to get current directory: %w", err)
+		}
+		projects = []string{wd}
+	}
+
+	plan, err := relocate.NewPlan(relocate.Options{
+		From:     getGlobalInstallDir(),
+		To:       expandPath(flags.To),
+		Projects: projects,
+		Link:     flags.Link,
+	})
+	if err != nil {
+		return err
+	}
+
+	if flags.JSON || globalFlags.Output == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(plan); err != nil {
+			return err
+		}
+	} else {
+		displayMigratePlan(plan)
+	}
+
+	if globalFlags.DryRun {
+		log.Info("[DRY RUN] No changes made")
+		return nil
+	}
+	if ok, err := confirmAction(fmt.Sprintf("Move the installation to %s?", plan.To), false); err != nil {
+		return err
+	} else if !ok {
+		log.Info("Migration cancelled")
+		return nil
+	}
+
+	if err := plan.Apply(func(step relocate.Step) {
+		log.Debugf("%s: %s", step.Kind, step.Detail)
+	}); err != nil {
+		return fmt.Errorf("migration failed: %w", err)
+	}
+
+	if showDecorations() {
+		ui.DisplaySuccess(fmt.Sprintf("Installation moved to %s", plan.To))
+		if !flags.Link {
+			fmt.Printf("%s Pass --install-dir %s to crew from now on\n", ui.Icons.Tip, plan.To)
+		}
+	}
+	return nil
+}
+
+func displayMigratePlan(plan *relocate.Plan) {
+	fmt.Printf("\n%sMigration plan:%s %s %s %s\n", ui.ColorCyan, ui.ColorReset, plan.From, ui.Icons.Arrow, plan.To)
+	for i, step := range plan.Steps {
+		fmt.Printf("  %d. %-9s %s\n", i+1, step.Kind, step.Detail)
+		if step.Kind != relocate.StepMove && step.Kind != relocate.StepLink {
+			fmt.Printf("     %s\n", step.Path)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/crewdirs"
+	"github.com/jonwraymond/claude-code-super-crew/internal/ui"
+	"github.com/jonwraymond/claude-code-super-crew/pkg/logger"
+	"github.com/spf13/cobra"
+)
+
+// MigrateXDGFlags holds migrate-xdg command flags
+type MigrateXDGFlags struct {
+	JSON bool
+}
+
+// NewMigrateXDGCommand creates the migrate-xdg command
+func NewMigrateXDGCommand() *cobra.Command {
+	var flags MigrateXDGFlags
+
+	cmd := &cobra.Command{
+		Use:   "migrate-xdg",
+		Short: "Move crew state out of .crew into the XDG base directories",
+		Long: `Move the .crew directory of an existing installation into the XDG base
+directories:
+
+  .crew/config   $XDG_CONFIG_HOME/supercrew  (default ~/.config/supercrew)
+  .crew/cache    $XDG_CACHE_HOME/supercrew   (default ~/.cache/supercrew)
+  everything else $XDG_DATA_HOME/supercrew   (default ~/.local/share/supercrew)
+
+Framework files stay in the install directory. Afterwards crew finds the
+state there on its own; new installations choose the layout with --xdg or
+CREW_XDG=1. Use --dry-run to see the moves without making them.
+
+Examples:
+  crew migrate-xdg --dry-run
+  crew migrate-xdg --yes`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runMigrateXDG(flags)
+		},
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().BoolVar(&flags.JSON, "json", false, "Output the moves as JSON")
+
+	return cmd
+}
+
+func runMigrateXDG(flags MigrateXDGFlags) error {
+	log := logger.GetLogger()
+	installDir := getGlobalInstallDir()
+
+	if crewdirs.XDG(installDir) {
+		log.Infof("%s already keeps its state in the XDG base directories", installDir)
+		return nil
+	}
+	moves, err := crewdirs.PlanXDG(installDir)
+	if err != nil {
+		return err
+	}
+
+	if flags.JSON || globalFlags.Output == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(moves); err != nil {
+			return err
+		}
+	} else {
+		fmt.Printf("\n%sMoves:%s\n", ui.ColorCyan, ui.ColorReset)
+		for _, m := range moves {
+			fmt.Printf("  %s %s %s\n", m.From, ui.Icons.Arrow, m.To)
+		}
+	}
+
+	if globalFlags.DryRun {
+		log.Info("[DRY RUN] No changes made")
+		return nil
+	}
+	if ok, err := confirmAction("Move crew state to the XDG base directories?", false); err != nil {
+		return err
+	} else if !ok {
+		log.Info("Migration cancelled")
+		return nil
+	}
+
+	if err := crewdirs.MigrateXDG(installDir, moves); err != nil {
+		return fmt.Errorf("migration failed: %w", err)
+	}
+
+	if showDecorations() {
+		ui.DisplaySuccess(fmt.Sprintf("Moved %d entries out of %s", len(moves), filepath.Join(installDir, crewdirs.LegacyDir)))
+	}
+	return nil
+}
+
+// selectStateLayout applies --xdg and CREW_XDG. An installation that still
+// has a .crew directory has to be migrated first, or its state would be
+// ignored.
+func selectStateLayout(cmd *cobra.Command) error {
+	if !globalFlags.XDG && !crewdirs.EnvXDG() {
+		return nil
+	}
+	installDir := getGlobalInstallDir()
+	if crewdirs.XDG(installDir) || cmd.Name() == "migrate-xdg" {
+		return nil
+	}
+	if _, err := os.Stat(filepath.Join(installDir, crewdirs.LegacyDir)); err == nil {
+		return fmt.Errorf("%s keeps its state in %s; run 'crew migrate-xdg' to move it to the XDG base directories",
+			installDir, crewdirs.LegacyDir)
+	}
+	crewdirs.UseXDG(installDir)
+	return nil
+}
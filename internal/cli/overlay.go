@@ -0,0 +1,197 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/crewdirs"
+	"github.com/jonwraymond/claude-code-super-crew/internal/metadata"
+	"github.com/jonwraymond/claude-code-super-crew/internal/overlay"
+	"github.com/jonwraymond/claude-code-super-crew/internal/ui"
+	"github.com/jonwraymond/claude-code-super-crew/pkg/logger"
+)
+
+// validateInstallDir refuses installation directories outside the user
+// profile, unless the directory holds, or is about to hold, a shared
+// installation
+func validateInstallDir(installDir string, sharedBase bool) error {
+	if testMode || sharedBase || isSharedBase(installDir) {
+		return nil
+	}
+	expectedHome := filepath.Join(os.Getenv("HOME"))
+	if expectedHome == "" {
+		expectedHome = filepath.Join(os.Getenv("USERPROFILE")) // Windows
+	}
+	actualDir, _ := filepath.Abs(installDir)
+
+	if !strings.HasPrefix(actualDir, expectedHome) {
+		ui.DisplayError("Installation must be inside your user profile directory.")
+		fmt.Printf("    Expected prefix: %s\n", expectedHome)
+		fmt.Printf("    Provided path:   %s\n", actualDir)
+		fmt.Println("    Use --shared-base to install a shared framework for several users.")
+		return fmt.Errorf("invalid installation directory")
+	}
+	return nil
+}
+
+// isSharedBase reports whether installDir holds a shared installation
+func isSharedBase(installDir string) bool {
+	mm := metadata.NewMetadataManager(installDir)
+	if !mm.CheckInstallationExists() {
+		return false
+	}
+	meta, err := mm.LoadMetadata()
+	return err == nil && meta.Installation.Shared
+}
+
+// overlaySharedDir returns the shared installation installDir is an
+// overlay of, or "" when it is a regular installation
+func overlaySharedDir(installDir string) string {
+	mm := metadata.NewMetadataManager(installDir)
+	if !mm.CheckInstallationExists() {
+		return ""
+	}
+	meta, err := mm.LoadMetadata()
+	if err != nil {
+		return ""
+	}
+	return meta.Installation.SharedDir
+}
+
+// markSharedBase records that installDir is a shared installation
+func markSharedBase(installDir string) error {
+	mm := metadata.NewMetadataManager(installDir)
+	meta, err := mm.LoadMetadata()
+	if err != nil {
+		return err
+	}
+	meta.Installation.Shared = true
+	return mm.SaveMetadata(meta)
+}
+
+// installOverlay sets up installDir as an overlay of the shared
+// installation in sharedDir
+func installOverlay(installDir, sharedDir string) error {
+	sharedDir, err := filepath.Abs(expandPath(sharedDir))
+	if err != nil {
+		return err
+	}
+	if !isSharedBase(sharedDir) {
+		return fmt.Errorf("%s is not a shared installation; install it with 'crew install --install-dir %s --shared-base'", sharedDir, sharedDir)
+	}
+	if mm := metadata.NewMetadataManager(installDir); mm.CheckInstallationExists() {
+		if current := overlaySharedDir(installDir); current != sharedDir {
+			return fmt.Errorf("%s already has an installation; run 'crew uninstall' first", installDir)
+		}
+	}
+	return syncOverlay(installDir, sharedDir, false)
+}
+
+// syncOverlay links the current framework files of sharedDir into
+// installDir and records the shared framework version in the overlay
+// metadata. The shared installation itself is never written.
+func syncOverlay(installDir, sharedDir string, check bool) error {
+	log := logger.GetLogger()
+	dryRun := check || globalFlags.DryRun
+
+	shared, err := metadata.NewMetadataManager(sharedDir).LoadMetadata()
+	if err != nil {
+		return fmt.Errorf("failed to read shared installation metadata: %w", err)
+	}
+
+	log.Infof("Syncing overlay with shared installation %s", sharedDir)
+	result, err := overlay.Sync(sharedDir, installDir, dryRun)
+	if err != nil {
+		return fmt.Errorf("overlay sync failed: %w", err)
+	}
+
+	if !dryRun {
+		mm := metadata.NewMetadataManager(installDir)
+		meta, err := mm.LoadMetadata()
+		if err != nil {
+			return err
+		}
+		meta.Framework = shared.Framework
+		meta.Components = shared.Components
+		meta.Installation.InstallDir = installDir
+		meta.Installation.SharedDir = sharedDir
+		meta.Installation.LastUpdated = time.Now()
+		if meta.Installation.InstalledAt.IsZero() {
+			meta.Installation.InstalledAt = meta.Installation.LastUpdated
+		}
+		if err := mm.SaveMetadata(meta); err != nil {
+			return err
+		}
+	}
+
+	if globalFlags.Output == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(result)
+	}
+	displayOverlayResult(result, shared.Framework.Version, dryRun)
+	return nil
+}
+
+// uninstallOverlay removes the links into the shared installation and the
+// overlay metadata, keeping the user's own files
+func uninstallOverlay(installDir, sharedDir string) error {
+	log := logger.GetLogger()
+
+	removed, err := overlay.Remove(sharedDir, installDir, globalFlags.DryRun)
+	if err != nil {
+		return fmt.Errorf("failed to remove overlay links: %w", err)
+	}
+	if globalFlags.DryRun {
+		log.Infof("[DRY RUN] Would remove %d links to %s", len(removed), sharedDir)
+		return nil
+	}
+
+	metadataFile := crewdirs.Path(installDir, "config", "crew-metadata.json")
+	if err := os.Remove(metadataFile); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove overlay metadata: %w", err)
+	}
+
+	log.Successf("Removed %d links to the shared installation in %s", len(removed), sharedDir)
+	log.Info("Your own files were kept; the shared installation was not changed")
+	return nil
+}
+
+func displayOverlayResult(result *overlay.Result, version string, dryRun bool) {
+	if !showDecorations() {
+		return
+	}
+	verb := map[bool]string{false: "", true: "would be "}[dryRun]
+
+	fmt.Printf("\n%sShared framework:%s v%s\n", ui.ColorCyan, ui.ColorReset, version)
+	if !result.Changed() {
+		fmt.Printf("  %s%s Overlay is up to date%s (%d links)\n", ui.ColorGreen, ui.Icons.Check, ui.ColorReset, result.Unchanged)
+	}
+	for _, group := range []struct {
+		label string
+		paths []string
+	}{
+		{verb + "linked", result.Linked},
+		{verb + "relinked", result.Relinked},
+		{verb + "removed", result.Removed},
+	} {
+		if len(group.paths) > 0 {
+			fmt.Printf("  %d %s\n", len(group.paths), group.label)
+			if globalFlags.Verbose {
+				for _, path := range group.paths {
+					fmt.Printf("    %s %s\n", ui.Icons.Bullet, path)
+				}
+			}
+		}
+	}
+	if len(result.Overridden) > 0 {
+		fmt.Printf("  %d replaced by your own files:\n", len(result.Overridden))
+		for _, path := range result.Overridden {
+			fmt.Printf("    %s %s\n", ui.Icons.Bullet, path)
+		}
+	}
+}
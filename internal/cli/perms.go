@@ -0,0 +1,178 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/crewdirs"
+	"github.com/jonwraymond/claude-code-super-crew/internal/managers"
+	"github.com/jonwraymond/claude-code-super-crew/internal/metadata"
+	"github.com/jonwraymond/claude-code-super-crew/internal/perms"
+	"github.com/jonwraymond/claude-code-super-crew/internal/ui"
+	"github.com/jonwraymond/claude-code-super-crew/pkg/logger"
+	"github.com/spf13/cobra"
+)
+
+// PermsFlags holds perms command flags
+type PermsFlags struct {
+	Fix  bool
+	JSON bool
+}
+
+// permsReport is the --json output of perms check
+type permsReport struct {
+	InstallDir string        `json:"install_dir"`
+	Issues     []perms.Issue `json:"issues"`
+	// Fixed counts the issues --fix corrected; the others are in Failed
+	Fixed  int      `json:"fixed"`
+	Failed []string `json:"failed,omitempty"`
+}
+
+// NewPermsCommand creates the perms command
+func NewPermsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "perms",
+		Short: "Audit and repair permissions of the installation",
+	}
+	cmd.AddCommand(newPermsCheckCommand())
+	return cmd
+}
+
+func newPermsCheckCommand() *cobra.Command {
+	var flags PermsFlags
+
+	cmd := &cobra.Command{
+		Use:   "check",
+		Short: "Check ownership and mode bits of the installed files",
+		Long: `Check the files crew installed, and its own state, for permission
+problems that typically follow copying an installation between machines,
+such as restoring a backup:
+
+  not-executable   a hook script Claude cannot run
+  world-writable   a file or directory any user can modify
+  exposed          the secrets file is readable by other users
+  wrong-owner      not owned by the owner of the installation directory
+
+The command exits non-zero while problems remain. With --fix the modes
+are corrected; changing owners usually needs root.
+
+Examples:
+  crew perms check
+  crew perms check --fix
+  sudo crew perms check --fix --install-dir /home/me/.claude`,
+		Args:        cobra.NoArgs,
+		Annotations: map[string]string{auditAnnotation: "fix"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPermsCheck(cmd, flags)
+		},
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().BoolVar(&flags.Fix, "fix", false, "Correct the problems found")
+	cmd.Flags().BoolVar(&flags.JSON, "json", false, "Output the report as JSON")
+
+	return cmd
+}
+
+// permsChecker audits the files crew owns in installDir: the component
+// directories, the tracked framework files and the crew state directories
+func permsChecker(installDir string) *perms.Checker {
+	paths := []string{
+		filepath.Join(installDir, "hooks"),
+		filepath.Join(installDir, "agents"),
+		filepath.Join(installDir, "commands", "crew"),
+		filepath.Join(installDir, "settings.json"),
+	}
+	mm := metadata.NewMetadataManager(installDir)
+	if mm.CheckInstallationExists() {
+		if meta, err := mm.LoadMetadata(); err == nil {
+			for rel := range meta.Integrity.FileHashes {
+				paths = append(paths, filepath.Join(installDir, rel))
+			}
+		}
+	}
+	paths = append(paths, crewdirs.Roots(installDir)...)
+
+	return &perms.Checker{
+		InstallDir: installDir,
+		Paths:      paths,
+		Private:    []string{managers.SecretsFileName},
+	}
+}
+
+func runPermsCheck(cmd *cobra.Command, flags PermsFlags) error {
+	log := logger.GetLogger()
+	installDir := globalFlags.InstallDir
+
+	issues, err := permsChecker(installDir).Check()
+	if err != nil {
+		return fmt.Errorf("failed to check permissions: %w", err)
+	}
+	report := permsReport{InstallDir: installDir, Issues: issues}
+	if report.Issues == nil {
+		report.Issues = []perms.Issue{}
+	}
+
+	fix := flags.Fix && !globalFlags.DryRun
+	if fix {
+		for _, issue := range issues {
+			if err := issue.Fix(); err != nil {
+				log.Warnf("%v", err)
+				report.Failed = append(report.Failed, issue.Path)
+				continue
+			}
+			report.Fixed++
+		}
+	}
+
+	if flags.JSON || globalFlags.Output == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			return err
+		}
+	} else {
+		displayPermsReport(report, flags.Fix)
+	}
+
+	if remaining := len(issues) - report.Fixed; remaining > 0 {
+		if !flags.Fix {
+			return fmt.Errorf("%d permission problems found; run with --fix to correct them", remaining)
+		}
+		if fix {
+			return fmt.Errorf("%d permission problems could not be fixed", remaining)
+		}
+	}
+	return nil
+}
+
+func displayPermsReport(report permsReport, fix bool) {
+	fmt.Printf("\n%sPermissions:%s %s\n", ui.ColorCyan, ui.ColorReset, displayPath(report.InstallDir))
+	if len(report.Issues) == 0 {
+		fmt.Printf("  %s%s%s No problems found\n", ui.ColorGreen, ui.Icons.Check, ui.ColorReset)
+		return
+	}
+
+	failed := make(map[string]bool)
+	for _, path := range report.Failed {
+		failed[path] = true
+	}
+	for _, issue := range report.Issues {
+		icon, color := ui.Icons.Warning, ui.ColorYellow
+		if fix && !globalFlags.DryRun {
+			icon, color = ui.Icons.Check, ui.ColorGreen
+			if failed[issue.Path] {
+				icon, color = ui.Icons.Cross, ui.ColorRed
+			}
+		}
+		fmt.Printf("  %s%s%s %s: %s\n", color, icon, ui.ColorReset, displayPath(issue.Path), issue)
+	}
+
+	if fix && globalFlags.DryRun {
+		fmt.Printf("\n[DRY RUN] Would fix %d problems\n", len(report.Issues))
+	} else if fix {
+		fmt.Printf("\nFixed %d of %d problems\n", report.Fixed, len(report.Issues))
+	}
+}
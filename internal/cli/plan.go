@@ -0,0 +1,363 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/core"
+	"github.com/jonwraymond/claude-code-super-crew/internal/events"
+	"github.com/jonwraymond/claude-code-super-crew/internal/i18n"
+	"github.com/jonwraymond/claude-code-super-crew/internal/ui"
+	"github.com/jonwraymond/claude-code-super-crew/pkg/logger"
+	"github.com/spf13/cobra"
+)
+
+// installPlanFormat is the version of the plan file layout. apply refuses
+// plans of other versions.
+const installPlanFormat = 1
+
+// installPlan is a fully resolved install written by install --plan-out
+// and carried out by apply
+type installPlan struct {
+	Format     int       `json:"format"`
+	CreatedAt  time.Time `json:"created_at"`
+	Framework  string    `json:"framework_version"`
+	InstallDir string    `json:"install_dir"`
+	// Requested is the selection the plan was resolved from
+	Requested  []string           `json:"requested"`
+	Components []plannedComponent `json:"components"`
+	// Order lists the components with dependencies first; Levels groups
+	// them so that each level only depends on earlier ones
+	Order        []string                 `json:"order"`
+	Levels       [][]string               `json:"levels"`
+	TotalFiles   int                      `json:"total_files"`
+	TotalSize    int64                    `json:"total_size"`
+	Requirements []core.RequirementResult `json:"requirements"`
+	Options      plannedOptions           `json:"options"`
+}
+
+// plannedComponent is one step of an install plan with what it copies
+type plannedComponent struct {
+	core.PlanStep
+	Files int   `json:"files"`
+	Size  int64 `json:"size"`
+}
+
+// plannedOptions are the install flags that change what the plan does
+type plannedOptions struct {
+	NoBackup        bool     `json:"no_backup,omitempty"`
+	ClaudeMerge     bool     `json:"claude_merge,omitempty"`
+	ClaudeOverwrite bool     `json:"claude_overwrite,omitempty"`
+	ClaudeSkip      bool     `json:"claude_skip,omitempty"`
+	MCPServers      []string `json:"mcp_servers,omitempty"`
+	SharedBase      bool     `json:"shared_base,omitempty"`
+	DevLink         bool     `json:"dev_link,omitempty"`
+	// Items limits components to some of their items, from --components
+	// component/glob
+	Items map[string][]string `json:"items,omitempty"`
+	// Force records that the plan was made with --force and may be
+	// applied although requirements are unmet
+	Force bool `json:"force,omitempty"`
+}
+
+// unmet returns the requirements of the plan that were not satisfied
+func (p *installPlan) unmet() []core.RequirementResult {
+	var failed []core.RequirementResult
+	for _, result := range p.Requirements {
+		if !result.Satisfied {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// installFlags returns the install flags the plan was made with
+func (p *installPlan) installFlags() InstallFlags {
+	return InstallFlags{
+		Components:      p.Requested,
+		NoBackup:        p.Options.NoBackup,
+		ClaudeMerge:     p.Options.ClaudeMerge,
+		ClaudeOverwrite: p.Options.ClaudeOverwrite,
+		ClaudeSkip:      p.Options.ClaudeSkip,
+		MCPServers:      p.Options.MCPServers,
+		SharedBase:      p.Options.SharedBase,
+		DevLink:         p.Options.DevLink,
+		items:           p.Options.Items,
+	}
+}
+
+// buildInstallPlan records plan, resolved from components, together with
+// the file counts of each component and the requirement results
+func buildInstallPlan(plan *core.Plan, registry *core.EnhancedComponentRegistry, components []string,
+	requirements []core.RequirementResult, flags InstallFlags, gFlags *GlobalFlags) *installPlan {
+	installDir, _ := filepath.Abs(gFlags.InstallDir)
+	p := &installPlan{
+		Format:       installPlanFormat,
+		CreatedAt:    time.Now().UTC(),
+		Framework:    core.FrameworkVersion,
+		InstallDir:   installDir,
+		Requested:    components,
+		Order:        plan.Order,
+		Levels:       plan.Levels,
+		Requirements: requirements,
+		Options: plannedOptions{
+			NoBackup:        flags.NoBackup,
+			ClaudeMerge:     flags.ClaudeMerge,
+			ClaudeOverwrite: flags.ClaudeOverwrite,
+			ClaudeSkip:      flags.ClaudeSkip,
+			MCPServers:      flags.MCPServers,
+			SharedBase:      flags.SharedBase,
+			DevLink:         flags.DevLink,
+			Items:           flags.items,
+			Force:           gFlags.Force,
+		},
+	}
+	if p.Requirements == nil {
+		p.Requirements = []core.RequirementResult{}
+	}
+	for _, step := range plan.Steps {
+		planned := plannedComponent{PlanStep: step}
+		if comp, err := registry.GetComponentInstance(step.Name, installDir); err == nil {
+			planned.Files = len(comp.GetFilesToInstall())
+			planned.Size = comp.GetSizeEstimate()
+			if patterns := flags.items[step.Name]; len(patterns) > 0 {
+				planned.Files, planned.Size = selectedFiles(comp.GetFilesToInstall(), patterns)
+			}
+		}
+		p.TotalFiles += planned.Files
+		p.TotalSize += planned.Size
+		p.Components = append(p.Components, planned)
+	}
+	return p
+}
+
+// selectedFiles counts the files of a component's items matching patterns
+// and their size
+func selectedFiles(files []core.FilePair, patterns []string) (int, int64) {
+	var count int
+	var size int64
+	for _, pair := range files {
+		if len(core.MatchItems([]string{pair.Source}, patterns)) == 0 {
+			continue
+		}
+		count++
+		if info, err := os.Stat(pair.Source); err == nil {
+			size += info.Size()
+		}
+	}
+	return count, size
+}
+
+// writeInstallPlan saves p to path, or prints it when path is "-"
+func writeInstallPlan(path string, p *installPlan) error {
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	if path == "-" {
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write plan: %w", err)
+	}
+
+	ui.DisplaySuccess(fmt.Sprintf("Wrote the plan to %s", path))
+	if showDecorations() {
+		fmt.Printf("  %d components, %d files, %s into %s\n", len(p.Components), p.TotalFiles,
+			ui.FormatSize(p.TotalSize), displayPath(p.InstallDir))
+		if failed := p.unmet(); len(failed) > 0 {
+			fmt.Printf("  %s%s %d requirements are not met%s\n", ui.ColorYellow, ui.Icons.Warning, len(failed), ui.ColorReset)
+		}
+		fmt.Printf("  Review it, then run: crew apply %s\n", path)
+	}
+	return nil
+}
+
+// readInstallPlan loads a plan written by writeInstallPlan
+func readInstallPlan(path string) (*installPlan, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plan: %w", err)
+	}
+	var p installPlan
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("failed to parse plan %s: %w", path, err)
+	}
+	if p.Format != installPlanFormat {
+		return nil, fmt.Errorf("plan %s has format %d, this crew reads format %d; write it again with crew install --plan-out",
+			path, p.Format, installPlanFormat)
+	}
+	if p.InstallDir == "" || len(p.Requested) == 0 {
+		return nil, fmt.Errorf("plan %s has no install directory or components", path)
+	}
+	return &p, nil
+}
+
+// planChanges lists how plan, resolved now, differs from the steps p
+// recorded. An empty result means p can be applied as written.
+func planChanges(p *installPlan, plan *core.Plan) []string {
+	var changes []string
+	recorded := make(map[string]core.PlanStep)
+	for _, c := range p.Components {
+		recorded[c.Name] = c.PlanStep
+	}
+	for _, step := range plan.Steps {
+		was, ok := recorded[step.Name]
+		switch {
+		case !ok:
+			changes = append(changes, fmt.Sprintf("%s %s is now needed as well", step.Name, step.Version))
+		case was.Version != step.Version:
+			changes = append(changes, fmt.Sprintf("%s would now install %s instead of %s", step.Name, step.Version, was.Version))
+		case was.Installed != step.Installed:
+			changes = append(changes, fmt.Sprintf("%s is now %s, the plan expected %s",
+				step.Name, installedState(step.Installed), installedState(was.Installed)))
+		}
+		delete(recorded, step.Name)
+	}
+	for _, c := range p.Components {
+		if _, ok := recorded[c.Name]; ok {
+			changes = append(changes, fmt.Sprintf("%s is no longer needed", c.Name))
+		}
+	}
+	return changes
+}
+
+// installedState describes an installed version for planChanges
+func installedState(version string) string {
+	if version == "" {
+		return "not installed"
+	}
+	return "at " + version
+}
+
+// NewApplyCommand creates the apply command
+func NewApplyCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "apply <plan.json>",
+		Short: "Carry out an install plan written by install --plan-out",
+		Long: `Carry out an install plan written by crew install --plan-out, exactly as
+it was written: the same components at the same versions, into the same
+directory, with the same options.
+
+Before changing anything apply resolves the plan again. When a component
+version or the installation changed since the plan was written, apply
+refuses and lists the differences instead of installing something that
+was not reviewed. A plan that recorded unmet requirements is only applied
+when it was made with --force.
+
+Examples:
+  crew install --components core,agents --plan-out plan.json
+  crew apply plan.json --yes`,
+		Args:              cobra.ExactArgs(1),
+		Annotations:       map[string]string{auditAnnotation: auditAlways},
+		RunE:              interruptible(runApply),
+		ValidArgsFunction: completeJSONFiles,
+		SilenceUsage:      true,
+	}
+	return cmd
+}
+
+// completeJSONFiles completes plan files
+func completeJSONFiles(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return []string{"json"}, cobra.ShellCompDirectiveFilterFileExt
+}
+
+func runApply(cmd *cobra.Command, args []string) error {
+	log := logger.GetLogger()
+	gFlags := GetGlobalFlags()
+
+	p, err := readInstallPlan(args[0])
+	if err != nil {
+		return err
+	}
+	if cmd.Flags().Changed("install-dir") {
+		if dir, _ := filepath.Abs(gFlags.InstallDir); dir != p.InstallDir {
+			return fmt.Errorf("the plan installs into %s, not %s", p.InstallDir, dir)
+		}
+	}
+	gFlags.InstallDir = p.InstallDir
+
+	if err := validateInstallDir(p.InstallDir, p.Options.SharedBase); err != nil {
+		return err
+	}
+	if sharedDir := overlaySharedDir(p.InstallDir); sharedDir != "" {
+		return fmt.Errorf("%s has become an overlay of %s since the plan was written", p.InstallDir, sharedDir)
+	}
+
+	registry, err := newComponentRegistry()
+	if err != nil {
+		return err
+	}
+	plan, err := registry.Solve(p.Requested, installedComponentVersions(p.InstallDir))
+	if err != nil {
+		displaySolveError(err)
+		return err
+	}
+	if changes := planChanges(p, plan); len(changes) > 0 {
+		ui.DisplayError("The plan no longer matches what would be installed:")
+		for _, change := range changes {
+			fmt.Printf("  %s %s\n", ui.Icons.Failure, change)
+		}
+		return fmt.Errorf("plan %s is out of date; write a new one with crew install --plan-out", args[0])
+	}
+
+	if failed := p.unmet(); len(failed) > 0 {
+		for _, result := range failed {
+			log.Errorf("  - %s: %s", result.Tool, result.Message)
+		}
+		if !p.Options.Force {
+			return fmt.Errorf("the plan recorded %d unmet requirements; make it with --force to apply it anyway", len(failed))
+		}
+		log.Warn("System requirements not met, but the plan was made with --force")
+	}
+
+	if showDecorations() {
+		fmt.Printf("%sPlan:%s %s, written %s\n", ui.ColorBlue, ui.ColorReset, args[0],
+			p.CreatedAt.Local().Format("2006-01-02 15:04"))
+		displayInstallationPlan(plan, registry, p.InstallDir, p.Options.Items)
+	}
+	if !gFlags.DryRun {
+		if ok, err := confirmAction(i18n.T("install.confirm_proceed"), true); err != nil {
+			return err
+		} else if !ok {
+			log.Info(i18n.T("install.cancelled"))
+			return nil
+		}
+	}
+
+	ctx := commandContext(cmd)
+	eventData := map[string]string{"components": strings.Join(p.Requested, ",")}
+	if err := publishEvent(ctx, events.InstallStarted, eventData); err != nil {
+		ui.DisplayError("Installation aborted by pre-install hook.")
+		return err
+	}
+
+	result := performInstallation(ctx, p.Requested, p.installFlags(), gFlags)
+	if err := printOperationResult(result); err != nil {
+		return err
+	}
+	if !result.ok() {
+		publishEvent(ctx, events.InstallFailed, result.eventData(eventData))
+		ui.DisplayError("install.failed")
+		return result.err("installation failed")
+	}
+	if p.Options.SharedBase && !gFlags.DryRun {
+		if err := markSharedBase(p.InstallDir); err != nil {
+			return fmt.Errorf("failed to mark the shared installation: %w", err)
+		}
+	}
+	if err := publishEvent(ctx, events.InstallCompleted, result.eventData(eventData)); err != nil {
+		log.Warnf("post-install hook failed: %v", err)
+	}
+	ui.DisplaySuccess("install.success")
+	return nil
+}
@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/core"
+)
+
+func TestInstallPlanRoundTrip(t *testing.T) {
+	plan := &core.Plan{
+		Steps: []core.PlanStep{{Name: "core", Version: "1.0.0", Action: core.ActionInstall, Requested: true}},
+		Order: []string{"core"},
+	}
+	flags := InstallFlags{ClaudeSkip: true, NoBackup: true}
+	written := buildInstallPlan(plan, core.NewEnhancedComponentRegistry(t.TempDir()), []string{"core"},
+		nil, flags, &GlobalFlags{InstallDir: t.TempDir()})
+
+	path := filepath.Join(t.TempDir(), "plan.json")
+	if err := writeInstallPlan(path, written); err != nil {
+		t.Fatal(err)
+	}
+	read, err := readInstallPlan(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if read.InstallDir != written.InstallDir || !read.Options.ClaudeSkip || !read.installFlags().NoBackup {
+		t.Errorf("Plan changed on the way through the file: %+v", read)
+	}
+	if changes := planChanges(read, plan); len(changes) != 0 {
+		t.Errorf("Expected no changes against the same plan, got %v", changes)
+	}
+}
+
+func TestPlanChangesDetectsStalePlans(t *testing.T) {
+	p := &installPlan{Components: []plannedComponent{
+		{PlanStep: core.PlanStep{Name: "core", Version: "1.0.0"}},
+		{PlanStep: core.PlanStep{Name: "hooks", Version: "1.0.0"}},
+	}}
+	now := &core.Plan{Steps: []core.PlanStep{
+		{Name: "core", Version: "1.1.0"},
+		{Name: "agents", Version: "1.0.1"},
+	}}
+
+	changes := strings.Join(planChanges(p, now), "\n")
+	for _, want := range []string{"core would now install 1.1.0", "agents 1.0.1 is now needed", "hooks is no longer needed"} {
+		if !strings.Contains(changes, want) {
+			t.Errorf("Expected %q in changes:\n%s", want, changes)
+		}
+	}
+}
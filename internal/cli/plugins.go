@@ -0,0 +1,311 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strings"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/hooks"
+	"github.com/jonwraymond/claude-code-super-crew/internal/i18n"
+	"github.com/jonwraymond/claude-code-super-crew/internal/managers"
+	"github.com/jonwraymond/claude-code-super-crew/internal/plugins"
+	"github.com/jonwraymond/claude-code-super-crew/internal/ui"
+	"github.com/jonwraymond/claude-code-super-crew/pkg/logger"
+	"github.com/spf13/cobra"
+)
+
+// pluginHookPrefix marks lifecycle hook descriptors written by plugins sync
+const pluginHookPrefix = "plugin-"
+
+// NewPluginsCommand creates the plugins command
+func NewPluginsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "plugins",
+		Short: "List external plugins and register their hooks",
+		Long: `Any executable named crew-<name> on PATH becomes the subcommand
+"crew <name>". Plugins can also be declared in the crew config, which
+takes precedence over PATH and can disable a plugin:
+
+  "plugins": {
+    "deploy": {"path": "/opt/tools/crew-deploy", "description": "Deploy agents"},
+    "legacy": {"disabled": true}
+  }
+
+A plugin receives CREW_INSTALL_DIR, CREW_VERSION, CREW_CONFIG_DIR and the
+other CREW_* variables in its environment, and the same context as a JSON
+document on stdin. A plugin that prints {"hooks": [{"event": "post-install"}]}
+for --crew-plugin-info is run as "crew-<name> --crew-hook <event>" for each
+registered lifecycle event once "crew plugins sync" has been run.
+
+Examples:
+  crew plugins list
+  crew plugins sync`,
+	}
+
+	cmd.AddCommand(newPluginsListCommand())
+	cmd.AddCommand(newPluginsSyncCommand())
+
+	return cmd
+}
+
+func newPluginsListCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List discovered plugins",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			found := discoverPlugins()
+
+			if globalFlags.Output == "json" {
+				encoder := json.NewEncoder(cmd.OutOrStdout())
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(found)
+			}
+
+			if len(found) == 0 {
+				fmt.Fprintln(cmd.OutOrStdout(), "No plugins found. Add an executable named crew-<name> to your PATH.")
+				return nil
+			}
+			for _, p := range found {
+				status := ""
+				if isBuiltinCommand(cmd.Root(), p.Name) {
+					status = fmt.Sprintf(" %s(shadowed by built-in command)%s", ui.ColorYellow, ui.ColorReset)
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "  %-16s %-7s %s%s\n", p.Name, p.Source, p.Path, status)
+				if p.Description != "" {
+					fmt.Fprintf(cmd.OutOrStdout(), "  %-16s %s\n", "", p.Description)
+				}
+			}
+			return nil
+		},
+	}
+}
+
+func newPluginsSyncCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "sync",
+		Short: "Register the lifecycle hooks that plugins declare",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return syncPluginHooks(discoverPlugins(), getLifecycleHooksDir())
+		},
+	}
+}
+
+// discoverPlugins finds plugins on PATH and in the crew config
+func discoverPlugins() []plugins.Plugin {
+	return plugins.Discover(os.Getenv("PATH"), declaredPlugins())
+}
+
+// declaredPlugins reads the "plugins" config section. The config is only
+// read when it already exists, so startup never creates files.
+func declaredPlugins() map[string]plugins.Declared {
+	if _, err := os.Stat(getCrewConfigDir()); err != nil {
+		return nil
+	}
+	cm, err := managers.NewConfigManager(getCrewConfigDir(), "")
+	if err != nil {
+		return nil
+	}
+	section, err := cm.GetObject("plugins")
+	if err != nil {
+		return nil
+	}
+
+	declared := make(map[string]plugins.Declared)
+	data, _ := json.Marshal(section)
+	if err := json.Unmarshal(data, &declared); err != nil {
+		logger.GetLogger().Warnf("Ignoring invalid plugins config: %v", err)
+		return nil
+	}
+	return declared
+}
+
+// isBuiltinCommand reports whether name is a command or alias of root
+func isBuiltinCommand(root *cobra.Command, name string) bool {
+	if name == "help" || name == "completion" {
+		return true
+	}
+	for _, c := range root.Commands() {
+		if c.Annotations[pluginAnnotation] != "" {
+			continue
+		}
+		if c.Name() == name || c.HasAlias(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// pluginAnnotation marks commands that run an external plugin
+const pluginAnnotation = "crew.plugin"
+
+// addPluginCommands adds a subcommand for every discovered plugin that
+// does not collide with a built-in command
+func addPluginCommands(root *cobra.Command, version string) {
+	for _, p := range discoverPlugins() {
+		if isBuiltinCommand(root, p.Name) {
+			continue
+		}
+		root.AddCommand(newPluginCommand(p, version))
+	}
+}
+
+func newPluginCommand(p plugins.Plugin, version string) *cobra.Command {
+	short := p.Description
+	if short == "" {
+		short = fmt.Sprintf("Run the %s plugin (%s)", p.Name, p.Path)
+	}
+
+	var pluginArgs []string
+	return &cobra.Command{
+		Use:                p.Name,
+		Short:              short,
+		DisableFlagParsing: true,
+		SilenceUsage:       true,
+		SilenceErrors:      true,
+		Annotations:        map[string]string{pluginAnnotation: p.Path},
+		// Flag parsing is off so the plugin sees its own flags untouched;
+		// crew's global flags written before the plugin name are parsed
+		// here before the usual root setup runs
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			var crewArgs []string
+			crewArgs, pluginArgs = splitPluginArgs(os.Args, p.Name, args)
+			if err := cmd.Root().PersistentFlags().Parse(crewArgs); err != nil {
+				return err
+			}
+			return cmd.Root().PersistentPreRunE(cmd, pluginArgs)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPlugin(p, version, pluginArgs)
+		},
+	}
+}
+
+// splitPluginArgs separates the arguments cobra collected for a plugin
+// command into crew's own flags, given before the plugin name on the
+// command line, and the plugin's arguments after it. Without the name in
+// argv (as when the command is run from a test) everything goes to the
+// plugin.
+func splitPluginArgs(argv []string, name string, args []string) ([]string, []string) {
+	for i := 1; i < len(argv); i++ {
+		if argv[i] != name {
+			continue
+		}
+		after := len(argv) - i - 1
+		if after > len(args) {
+			break
+		}
+		return args[:len(args)-after], args[len(args)-after:]
+	}
+	return nil, args
+}
+
+// runPlugin runs a plugin with the terminal attached and the crew context
+// on stdin, returning an ExitError carrying a non-zero exit status
+func runPlugin(p plugins.Plugin, version string, args []string) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	command, err := p.Command(ctx, plugins.Context{
+		Version:        version,
+		InstallDir:     globalFlags.InstallDir,
+		ConfigDir:      getCrewConfigDir(),
+		Args:           args,
+		Verbose:        globalFlags.Verbose,
+		Quiet:          globalFlags.Quiet,
+		DryRun:         globalFlags.DryRun,
+		Output:         globalFlags.Output,
+		Locale:         i18n.Locale(),
+		NonInteractive: ui.NonInteractive(),
+	})
+	if err != nil {
+		return err
+	}
+	command.Stdout = os.Stdout
+	command.Stderr = os.Stderr
+
+	logger.GetLogger().Debugf("Running plugin %s: %s %s", p.Name, p.Path, strings.Join(args, " "))
+	if err := command.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return &ExitError{Code: exitErr.ExitCode(), Err: fmt.Errorf("plugin %s exited with status %d", p.Name, exitErr.ExitCode())}
+		}
+		return fmt.Errorf("failed to run plugin %s: %w", p.Name, err)
+	}
+	return nil
+}
+
+// syncPluginHooks replaces the plugin hook descriptors in hooksDir with
+// those declared by the current plugins
+func syncPluginHooks(found []plugins.Plugin, hooksDir string) error {
+	log := logger.GetLogger()
+
+	descriptors := make(map[string]hooks.LifecycleHook)
+	for _, p := range found {
+		info, err := p.QueryInfo()
+		if err != nil {
+			log.Debugf("%v", err)
+			continue
+		}
+		for _, spec := range info.Hooks {
+			if !hooks.IsValidLifecycleEvent(spec.Event) {
+				log.Warnf("Plugin %s declares unknown event %q", p.Name, spec.Event)
+				continue
+			}
+			name := fmt.Sprintf("%s%s-%s", pluginHookPrefix, p.Name, spec.Event)
+			descriptors[name] = hooks.LifecycleHook{
+				Name:        name,
+				Description: fmt.Sprintf("Registered by plugin %s", p.Name),
+				Event:       hooks.LifecycleEvent(spec.Event),
+				Script:      p.Path,
+				Args:        []string{plugins.HookFlag, spec.Event},
+				Timeout:     spec.Timeout,
+				OnFailure:   hooks.FailurePolicy(spec.OnFailure),
+				Env:         map[string]string{"CREW_PLUGIN": p.Name},
+			}
+		}
+	}
+
+	stale, _ := filepath.Glob(filepath.Join(hooksDir, pluginHookPrefix+"*.json"))
+	if globalFlags.DryRun {
+		for _, path := range stale {
+			if _, keep := descriptors[strings.TrimSuffix(filepath.Base(path), ".json")]; !keep {
+				log.Infof("[DRY RUN] Would remove hook %s", filepath.Base(path))
+			}
+		}
+		for name := range descriptors {
+			log.Infof("[DRY RUN] Would register hook %s", name)
+		}
+		return nil
+	}
+
+	for _, path := range stale {
+		if err := os.Remove(path); err != nil {
+			return fmt.Errorf("failed to remove stale plugin hook: %w", err)
+		}
+	}
+	if len(descriptors) == 0 {
+		log.Info("No plugin hooks to register")
+		return nil
+	}
+
+	if err := os.MkdirAll(hooksDir, 0755); err != nil {
+		return fmt.Errorf("failed to create hooks directory: %w", err)
+	}
+	for name, hook := range descriptors {
+		data, err := json.MarshalIndent(hook, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode hook %s: %w", name, err)
+		}
+		if err := os.WriteFile(filepath.Join(hooksDir, name+".json"), append(data, '\n'), 0644); err != nil {
+			return fmt.Errorf("failed to write hook %s: %w", name, err)
+		}
+		log.Successf("Registered hook %s (%s)", name, hook.Event)
+	}
+	return nil
+}
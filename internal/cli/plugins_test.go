@@ -0,0 +1,38 @@
+package cli
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSplitPluginArgs(t *testing.T) {
+	tests := []struct {
+		argv      []string
+		args      []string
+		crew      []string
+		forPlugin []string
+	}{
+		{[]string{"crew", "--install-dir", "/x", "deploy", "--verbose", "prod"}, []string{"--install-dir", "/x", "--verbose", "prod"}, []string{"--install-dir", "/x"}, []string{"--verbose", "prod"}},
+		{[]string{"crew", "deploy"}, nil, []string{}, []string{}},
+		{[]string{"crew.test", "-test.v"}, []string{"a"}, nil, []string{"a"}},
+	}
+	for _, tt := range tests {
+		crew, forPlugin := splitPluginArgs(tt.argv, "deploy", tt.args)
+		if len(crew) != len(tt.crew) || (len(crew) > 0 && !reflect.DeepEqual(crew, tt.crew)) {
+			t.Errorf("splitPluginArgs(%v) crew args = %v, expected %v", tt.argv, crew, tt.crew)
+		}
+		if len(forPlugin) != len(tt.forPlugin) || (len(forPlugin) > 0 && !reflect.DeepEqual(forPlugin, tt.forPlugin)) {
+			t.Errorf("splitPluginArgs(%v) plugin args = %v, expected %v", tt.argv, forPlugin, tt.forPlugin)
+		}
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	if code := ExitCode(errors.New("plain")); code != 1 {
+		t.Errorf("Expected 1 for a plain error, got %d", code)
+	}
+	if code := ExitCode(&ExitError{Code: 3, Err: errors.New("plugin failed")}); code != 3 {
+		t.Errorf("Expected the plugin status, got %d", code)
+	}
+}
@@ -0,0 +1,375 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/pathsutil"
+	"github.com/jonwraymond/claude-code-super-crew/internal/project"
+	"github.com/jonwraymond/claude-code-super-crew/internal/ui"
+	"github.com/jonwraymond/claude-code-super-crew/internal/versioning"
+	"github.com/jonwraymond/claude-code-super-crew/pkg/logger"
+	"github.com/spf13/cobra"
+)
+
+// ProjectFlags holds project command flags
+type ProjectFlags struct {
+	ProjectDir string
+	JSON       bool
+}
+
+var projectFlags ProjectFlags
+
+// NewProjectCommand creates the project command
+func NewProjectCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "project",
+		Short: "Manage the crew integration of a project",
+		Long: `Inspect and change the crew state of a project, kept in its .claude
+directory. Commands act on the project containing the current directory,
+the nearest one up with a .claude directory or .git, unless --project-dir
+is given.`,
+	}
+
+	cmd.PersistentFlags().StringVar(&projectFlags.ProjectDir, "project-dir", "",
+		"Project directory (default: the project containing the current directory)")
+	registerFlagCompletions(cmd, map[string]completionFunc{
+		"project-dir": func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+			return nil, cobra.ShellCompDirectiveFilterDirs
+		},
+	})
+
+	cmd.AddCommand(newProjectConfigCommand())
+	cmd.AddCommand(newProjectStatusCommand())
+	cmd.AddCommand(newProjectExportConfigCommand())
+	cmd.AddCommand(newProjectImportConfigCommand())
+
+	return cmd
+}
+
+func newProjectConfigCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Show or change .claude/project-config.json",
+		Long: `Show or change the project configuration in .claude/project-config.json.
+
+The file records the languages, frameworks, specialists, MCP servers and
+tools of the project and when /crew:onboard last analyzed it. It is
+validated whenever it is read or written; files from earlier releases are
+upgraded to the current schema on the first change.
+
+List keys take any number of values, each of which may be comma
+separated; setting a list key without values clears it.
+
+Examples:
+  crew project config show                       # Show the configuration
+  crew project config get languages              # One language per line
+  crew project config set languages go typescript
+  crew project config set mcp_servers context7,sequential
+  crew project config set analyzed_at now`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runProjectConfigShow()
+		},
+		SilenceUsage: true,
+	}
+
+	show := &cobra.Command{
+		Use:   "show",
+		Short: "Show the project configuration",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runProjectConfigShow()
+		},
+		SilenceUsage: true,
+	}
+	show.Flags().BoolVar(&projectFlags.JSON, "json", false, "Output the configuration as JSON")
+
+	get := &cobra.Command{
+		Use:               "get <key>",
+		Short:             "Print one value of the project configuration",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeProjectConfigKeys(project.Keys),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadProjectConfig()
+			if err != nil {
+				return err
+			}
+			value, err := cfg.Get(args[0])
+			if err != nil {
+				return err
+			}
+			if value != "" {
+				fmt.Println(value)
+			}
+			return nil
+		},
+		SilenceUsage: true,
+	}
+
+	set := &cobra.Command{
+		Use:               "set <key> [value...]",
+		Short:             "Change one value of the project configuration",
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: completeProjectConfigKeys(project.SettableKeys),
+		Annotations:       map[string]string{auditAnnotation: auditAlways},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runProjectConfigSet(args[0], args[1:])
+		},
+		SilenceUsage: true,
+	}
+
+	cmd.AddCommand(show, get, set)
+	return cmd
+}
+
+// completeProjectConfigKeys completes the key argument of get and set
+func completeProjectConfigKeys(keys []string) completionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return keys, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// getProjectDir returns the directory the project commands act on
+func getProjectDir() (string, error) {
+	return resolveProjectDir(projectFlags.ProjectDir)
+}
+
+// resolveProjectDir returns the absolute directory of the project a command
+// acts on: flagDir when given, or else the nearest directory from the
+// current one up that has a .claude directory or .git, so commands work
+// from anywhere inside a project. Outside of a project it is the current
+// directory.
+func resolveProjectDir(flagDir string) (string, error) {
+	if flagDir != "" {
+		return filepath.Abs(flagDir)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("failed to get current directory: %w", err)
+	}
+	projectDir := pathsutil.ProjectDir(wd)
+	if projectDir != wd {
+		logger.GetLogger().Debugf("Using project %s found above %s", projectDir, wd)
+	}
+	return projectDir, nil
+}
+
+func loadProjectConfig() (*project.Config, error) {
+	projectDir, err := getProjectDir()
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := project.LoadConfig(projectDir)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("no project configuration in %s; run 'crew claude --install' first", projectDir)
+	}
+	return cfg, err
+}
+
+func runProjectConfigShow() error {
+	cfg, err := loadProjectConfig()
+	if err != nil {
+		return err
+	}
+
+	if projectFlags.JSON || globalFlags.Output == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(cfg)
+	}
+
+	fmt.Printf("%sProject Configuration%s\n", ui.ColorCyan, ui.ColorReset)
+	fmt.Println(strings.Repeat("=", 50))
+	for _, key := range project.Keys {
+		value, _ := cfg.Get(key)
+		value = strings.ReplaceAll(value, "\n", ", ")
+		if value == "" {
+			value = ui.ColorGray + "(none)" + ui.ColorReset
+		}
+		fmt.Printf("  %-16s %s\n", key+":", value)
+	}
+
+	if len(cfg.Extra) > 0 {
+		var other []string
+		for key := range cfg.Extra {
+			other = append(other, key)
+		}
+		sort.Strings(other)
+		fmt.Printf("  %-16s %s\n", "other keys:", strings.Join(other, ", "))
+	}
+	return nil
+}
+
+func runProjectConfigSet(key string, values []string) error {
+	log := logger.GetLogger()
+
+	projectDir, err := getProjectDir()
+	if err != nil {
+		return err
+	}
+	cfg, err := loadProjectConfig()
+	if err != nil {
+		return err
+	}
+	if err := cfg.Set(key, values); err != nil {
+		return err
+	}
+
+	value, _ := cfg.Get(key)
+	value = strings.ReplaceAll(value, "\n", ", ")
+	if globalFlags.DryRun {
+		log.Infof("[DRY RUN] Would set %s to %q", key, value)
+		return nil
+	}
+	if err := cfg.Save(projectDir); err != nil {
+		return err
+	}
+	log.Infof("Set %s to %q", key, value)
+	return nil
+}
+
+func newProjectStatusCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show the crew integration of a project in one view",
+		Long: `Show the crew integration of the current project: the integration
+version, project agents and shadow commands, drift from the global
+framework, the MCP servers and tools the project uses and when /crew:onboard
+last analyzed it.
+
+Examples:
+  crew project status                        # Status of the current project
+  crew project status --project-dir ~/src/app
+  crew project status --json                 # Machine-readable status`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runProjectStatus()
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().BoolVar(&projectFlags.JSON, "json", false, "Output the status as JSON")
+	return cmd
+}
+
+func runProjectStatus() error {
+	projectDir, err := getProjectDir()
+	if err != nil {
+		return err
+	}
+	if abs, err := filepath.Abs(projectDir); err == nil {
+		projectDir = abs
+	}
+
+	global := project.Global{
+		CommandsDir: filepath.Join(getGlobalInstallDir(), "commands", "crew"),
+	}
+	if isFrameworkInstalled() {
+		if version, err := versioning.NewVersionManager(getGlobalInstallDir()).GetCurrentVersion(); err == nil {
+			global.Version = version
+		}
+	}
+	status := project.Inspect(projectDir, global)
+
+	if projectFlags.JSON || globalFlags.Output == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(status)
+	}
+
+	displayProjectStatus(status)
+	return nil
+}
+
+func displayProjectStatus(status *project.Status) {
+	fmt.Printf("\n%s%sProject Status%s\n", ui.ColorCyan, ui.ColorBright, ui.ColorReset)
+	fmt.Println(strings.Repeat("=", 50))
+	fmt.Printf("%sProject:%s %s\n", ui.ColorBlue, ui.ColorReset, status.ProjectDir)
+
+	if !status.Integrated {
+		fmt.Printf("%s%s Not integrated%s\n", ui.ColorRed, ui.Icons.Failure, ui.ColorReset)
+		fmt.Println("  Run 'crew claude --install' to enable crew for this project")
+		return
+	}
+
+	integration := "unknown version"
+	if status.IntegrationVersion != "" {
+		integration = "v" + status.IntegrationVersion
+	}
+	framework := "not installed"
+	if status.FrameworkVersion != "" {
+		framework = "v" + status.FrameworkVersion
+	}
+	fmt.Printf("%sIntegration:%s %s (global framework %s)\n", ui.ColorBlue, ui.ColorReset, integration, framework)
+
+	if status.ConfigError != "" {
+		fmt.Printf("%s%s %s%s\n", ui.ColorYellow, ui.Icons.Warning, status.ConfigError, ui.ColorReset)
+	}
+
+	fmt.Printf("\n%sAgents:%s\n", ui.ColorCyan, ui.ColorReset)
+	byKind := make(map[string][]string)
+	for _, agent := range status.Agents {
+		byKind[agent.Kind] = append(byKind[agent.Kind], agent.Name)
+	}
+	if len(byKind[project.AgentOrchestrator]) > 0 {
+		fmt.Printf("  %s%s Orchestrator installed%s\n", ui.ColorGreen, ui.Icons.Success, ui.ColorReset)
+	} else {
+		fmt.Printf("  %s%s No orchestrator; run /crew:onboard in Claude Code%s\n", ui.ColorYellow, ui.Icons.Warning, ui.ColorReset)
+	}
+	fmt.Printf("  %-16s %s\n", "Specialists:", listOrNone(byKind[project.AgentSpecialist]))
+	if len(byKind[project.AgentOther]) > 0 {
+		fmt.Printf("  %-16s %s\n", "Other agents:", strings.Join(byKind[project.AgentOther], ", "))
+	}
+
+	fmt.Printf("\n%sCommands:%s\n", ui.ColorCyan, ui.ColorReset)
+	fmt.Printf("  %-16s %s\n", "Local:", listOrNone(status.LocalCommands))
+	var shadows []string
+	for _, shadow := range status.ShadowCommands {
+		entry := shadow.Name
+		if shadow.BaseCommand != shadow.Name {
+			entry += " (/crew:" + shadow.BaseCommand + ")"
+		}
+		shadows = append(shadows, entry)
+	}
+	fmt.Printf("  %-16s %s\n", "Shadows:", listOrNone(shadows))
+
+	fmt.Printf("\n%sProject Configuration:%s\n", ui.ColorCyan, ui.ColorReset)
+	if cfg := status.Config; cfg != nil {
+		fmt.Printf("  %-16s %s\n", "Languages:", listOrNone(cfg.Languages))
+		fmt.Printf("  %-16s %s\n", "Frameworks:", listOrNone(cfg.Frameworks))
+		fmt.Printf("  %-16s %s\n", "MCP servers:", listOrNone(cfg.MCPServers))
+		fmt.Printf("  %-16s %s\n", "Tools:", listOrNone(cfg.Tools))
+	}
+	if analyzed := status.AnalyzedAt(); analyzed != nil {
+		days := int(time.Since(*analyzed).Hours() / 24)
+		fmt.Printf("  %-16s %s (%d days ago)\n", "Last analysis:", analyzed.Local().Format("2006-01-02 15:04"), days)
+	} else {
+		fmt.Printf("  %-16s never; run /crew:onboard in Claude Code\n", "Last analysis:")
+	}
+
+	fmt.Printf("\n%sDrift:%s\n", ui.ColorCyan, ui.ColorReset)
+	if len(status.Drift) == 0 {
+		fmt.Printf("  %s%s In sync with the global framework%s\n", ui.ColorGreen, ui.Icons.Success, ui.ColorReset)
+		return
+	}
+	for _, drift := range status.Drift {
+		fmt.Printf("  %s%s %s%s\n", ui.ColorYellow, ui.Icons.Warning, drift, ui.ColorReset)
+	}
+	fmt.Println("  Run 'crew claude --update' to refresh the integration")
+}
+
+// listOrNone joins items for display, or returns "(none)"
+func listOrNone(items []string) string {
+	if len(items) == 0 {
+		return "(none)"
+	}
+	return strings.Join(items, ", ")
+}
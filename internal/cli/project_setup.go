@@ -0,0 +1,159 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/project"
+	"github.com/jonwraymond/claude-code-super-crew/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+func newProjectExportConfigCommand() *cobra.Command {
+	var file string
+	cmd := &cobra.Command{
+		Use:   "export-config",
+		Short: "Write the project's crew setup to a committable file",
+		Long: `Write the crew setup of the project to one file teammates can commit and
+import: the languages, frameworks, specialists, MCP servers and tools from
+the project configuration, the agents in .claude/agents (including the
+orchestrator and its routing), the local and shadow commands in
+.claude/commands and the hooks overlay in .claude/hooks.json.
+
+Machine-specific values such as the project path are not exported.
+
+Examples:
+  crew project export-config                  # Write crew-project.json
+  crew project export-config --file - | less  # Print the setup`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runProjectExportConfig(file)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().StringVarP(&file, "file", "f", "",
+		"File to write, or - for stdout (default: crew-project.json in the project)")
+	return cmd
+}
+
+func newProjectImportConfigCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import-config [file]",
+		Short: "Reproduce a crew setup exported with export-config",
+		Long: `Apply a setup written by 'crew project export-config' to this project, so
+its .claude directory matches a teammate's.
+
+Files that already match are left alone. Local files that differ are kept
+and reported as conflicts; --force replaces them. The project configuration
+takes the setup's languages, frameworks, specialists, MCP servers and
+tools and keeps everything else.
+
+Examples:
+  crew project import-config                  # Import crew-project.json
+  crew project import-config --dry-run        # Show what would change
+  crew project import-config setup.json --force`,
+		Args:        cobra.MaximumNArgs(1),
+		Annotations: map[string]string{auditAnnotation: auditAlways},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			file := ""
+			if len(args) > 0 {
+				file = args[0]
+			}
+			return runProjectImportConfig(file)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().BoolVar(&projectFlags.JSON, "json", false, "Output the import result as JSON")
+	return cmd
+}
+
+func runProjectExportConfig(file string) error {
+	projectDir, err := getProjectDir()
+	if err != nil {
+		return err
+	}
+	setup, err := project.ExportSetup(projectDir)
+	if err != nil {
+		return err
+	}
+
+	if file == "-" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(setup)
+	}
+	if file == "" {
+		file = filepath.Join(projectDir, project.SetupFile)
+	}
+	if globalFlags.DryRun {
+		fmt.Printf("[DRY RUN] Would write %d files and the project tools to %s\n", len(setup.Files), file)
+		return nil
+	}
+	if err := project.WriteSetup(setup, file); err != nil {
+		return err
+	}
+
+	ui.DisplaySuccess(fmt.Sprintf("Exported the project setup to %s", file))
+	for _, p := range setup.Paths() {
+		fmt.Printf("  %s %s\n", ui.Icons.Bullet, p)
+	}
+	fmt.Println("Commit it; teammates run 'crew project import-config' to reproduce the setup")
+	return nil
+}
+
+func runProjectImportConfig(file string) error {
+	projectDir, err := getProjectDir()
+	if err != nil {
+		return err
+	}
+	if file == "" {
+		file = filepath.Join(projectDir, project.SetupFile)
+	}
+	setup, err := project.ReadSetup(file)
+	if os.IsNotExist(err) {
+		return fmt.Errorf("no project setup at %s; export one with 'crew project export-config'", file)
+	}
+	if err != nil {
+		return err
+	}
+
+	result, err := project.ImportSetup(setup, projectDir, globalFlags.Force, globalFlags.DryRun)
+	if err != nil {
+		return err
+	}
+
+	if projectFlags.JSON || globalFlags.Output == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(result)
+	}
+
+	verb := "Wrote"
+	if globalFlags.DryRun {
+		verb = "Would write"
+	}
+	for _, p := range result.Written {
+		fmt.Printf("  %s %s %s\n", ui.Icons.Success, verb, p)
+	}
+	if result.Config {
+		fmt.Printf("  %s %s %s\n", ui.Icons.Success, verb, filepath.Join(".claude", project.ConfigFile))
+	}
+	for _, p := range result.Conflicts {
+		fmt.Printf("  %s%s Kept local %s (differs from the setup)%s\n", ui.ColorYellow, ui.Icons.Warning, p, ui.ColorReset)
+	}
+	if len(result.Unchanged) > 0 {
+		fmt.Printf("  %d files already match\n", len(result.Unchanged))
+	}
+
+	if globalFlags.DryRun {
+		return nil
+	}
+	if len(result.Conflicts) > 0 {
+		fmt.Printf("%s%d local files differ; rerun with --force to replace them%s\n", ui.ColorYellow, len(result.Conflicts), ui.ColorReset)
+		return nil
+	}
+	ui.DisplaySuccess("Project setup imported")
+	return nil
+}
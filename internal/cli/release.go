@@ -0,0 +1,144 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/buildinfo"
+	"github.com/jonwraymond/claude-code-super-crew/internal/releases"
+	"github.com/jonwraymond/claude-code-super-crew/internal/semver"
+	"github.com/jonwraymond/claude-code-super-crew/pkg/logger"
+	"github.com/spf13/cobra"
+)
+
+// ReleaseManifestFlags holds release manifest command flags
+type ReleaseManifestFlags struct {
+	Brew      bool
+	Scoop     bool
+	Version   string
+	Dist      string
+	BaseURL   string
+	OutputDir string
+}
+
+// NewReleaseCommand creates the release command
+func NewReleaseCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "release",
+		Short: "Release engineering helpers",
+		Long: `Helpers for publishing crew releases.
+
+Examples:
+  make build-all VERSION=1.2.0
+  crew release manifest --version 1.2.0 --output-dir dist`,
+	}
+
+	cmd.AddCommand(newReleaseManifestCommand())
+
+	return cmd
+}
+
+func newReleaseManifestCommand() *cobra.Command {
+	var flags ReleaseManifestFlags
+
+	cmd := &cobra.Command{
+		Use:   "manifest",
+		Short: "Render the Homebrew formula and Scoop manifest of a release",
+		Long: `Render the Homebrew formula (crew.rb) and the Scoop manifest (crew.json)
+for a release from the binaries of 'make build-all'. Checksums are
+computed from the binaries; URLs point at the GitHub release of the
+version unless --base-url names another download directory.
+
+The version defaults to the version of this binary, which must then be a
+tagged release build. Without --brew or --scoop both are rendered.
+
+Examples:
+  crew release manifest --version 1.2.0
+  crew release manifest --brew --dist build --output-dir tap/Formula
+  crew release manifest --scoop --base-url https://mirror.example.com/crew/1.2.0`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runReleaseManifest(cmd, flags)
+		},
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().BoolVar(&flags.Brew, "brew", false, "Render the Homebrew formula")
+	cmd.Flags().BoolVar(&flags.Scoop, "scoop", false, "Render the Scoop manifest")
+	cmd.Flags().StringVar(&flags.Version, "version", "", "Release version (default: the version of this binary)")
+	cmd.Flags().StringVar(&flags.Dist, "dist", "build", "Directory holding the release binaries")
+	cmd.Flags().StringVar(&flags.BaseURL, "base-url", "", "Download directory of the binaries (default: the GitHub release)")
+	cmd.Flags().StringVar(&flags.OutputDir, "output-dir", "", "Write crew.rb and crew.json here instead of printing them")
+
+	return cmd
+}
+
+// manifestFile is a rendered package manager file
+type manifestFile struct {
+	name    string
+	content []byte
+}
+
+func runReleaseManifest(cmd *cobra.Command, flags ReleaseManifestFlags) error {
+	log := logger.GetLogger()
+
+	version := flags.Version
+	if version == "" {
+		info := buildinfo.Get()
+		if info.Dev {
+			return fmt.Errorf("%s is not a release build; pass --version", info.Version)
+		}
+		version = info.Version
+	}
+	if _, err := semver.Parse(version); err != nil {
+		return fmt.Errorf("invalid release version %q: %w", version, err)
+	}
+	if !flags.Brew && !flags.Scoop {
+		flags.Brew, flags.Scoop = true, true
+	}
+
+	artifacts, err := releases.ScanArtifacts(flags.Dist, version, flags.BaseURL)
+	if err != nil {
+		return err
+	}
+
+	var files []manifestFile
+	if flags.Brew {
+		formula, err := releases.BrewFormula(version, artifacts)
+		if err != nil {
+			return err
+		}
+		files = append(files, manifestFile{"crew.rb", []byte(formula)})
+	}
+	if flags.Scoop {
+		manifest, err := releases.NewScoopManifest(version, artifacts)
+		if err != nil {
+			return err
+		}
+		files = append(files, manifestFile{"crew.json", manifest})
+	}
+
+	for _, f := range files {
+		if flags.OutputDir == "" {
+			if len(files) > 1 {
+				fmt.Fprintf(cmd.OutOrStdout(), "# %s\n", f.name)
+			}
+			cmd.OutOrStdout().Write(f.content)
+			continue
+		}
+		path := filepath.Join(flags.OutputDir, f.name)
+		if globalFlags.DryRun {
+			log.Infof("[DRY RUN] Would write %s", path)
+			continue
+		}
+		if err := os.MkdirAll(flags.OutputDir, 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, f.content, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		log.Successf("Wrote %s for v%s", path, version)
+	}
+	return nil
+}
@@ -0,0 +1,402 @@
+package cli
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/buildinfo"
+	"github.com/jonwraymond/claude-code-super-crew/internal/core"
+	"github.com/jonwraymond/claude-code-super-crew/internal/crewdirs"
+	"github.com/jonwraymond/claude-code-super-crew/internal/metadata"
+	"github.com/jonwraymond/claude-code-super-crew/internal/platform"
+	"github.com/jonwraymond/claude-code-super-crew/internal/redact"
+	"github.com/jonwraymond/claude-code-super-crew/internal/releases"
+	"github.com/jonwraymond/claude-code-super-crew/internal/ui"
+	"github.com/jonwraymond/claude-code-super-crew/internal/versioning"
+	"github.com/jonwraymond/claude-code-super-crew/pkg/logger"
+	"github.com/spf13/cobra"
+)
+
+// ReportBugFlags holds report bug command flags
+type ReportBugFlags struct {
+	Bundle   string
+	Title    string
+	Open     bool
+	LogLines int
+}
+
+// bugReport is report.json, the summary at the top of a bug report bundle
+type bugReport struct {
+	Created       time.Time            `json:"created"`
+	Binary        buildinfo.Info       `json:"binary"`
+	Framework     string               `json:"framework,omitempty"`
+	Components    map[string]string    `json:"components"`
+	InstallDir    string               `json:"install_dir"`
+	Environment   platform.Environment `json:"environment"`
+	LastOperation *operationReport     `json:"last_operation,omitempty"`
+	FailedChecks  []string             `json:"failed_checks,omitempty"`
+}
+
+// bugReportMetadata is metadata.json: the installation metadata without
+// the inventory and per-file hashes, which only matter for the files that
+// are not clean
+type bugReportMetadata struct {
+	Framework    metadata.FrameworkMetadata        `json:"framework"`
+	Components   map[string]metadata.ComponentMeta `json:"components"`
+	Features     map[string]metadata.FeatureMeta   `json:"features,omitempty"`
+	Installation metadata.InstallationMeta         `json:"installation"`
+	CreatedFiles int                               `json:"created_files"`
+	Integrity    struct {
+		Status   string            `json:"status"`
+		LastScan time.Time         `json:"last_scan"`
+		Total    int               `json:"total_files"`
+		Problems map[string]string `json:"problems,omitempty"`
+	} `json:"integrity"`
+}
+
+// bugReportResult is the --output json result of crew report bug
+type bugReportResult struct {
+	Bundle   string   `json:"bundle"`
+	Files    []string `json:"files"`
+	IssueURL string   `json:"issue_url"`
+	Opened   bool     `json:"opened"`
+}
+
+// NewReportCommand creates the report command
+func NewReportCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "report",
+		Short: "Prepare reports for the crew maintainers",
+		Long: `Prepare reports for the crew maintainers.
+
+Examples:
+  crew report bug
+  crew report bug --title "Hooks missing after update" --open`,
+	}
+
+	cmd.AddCommand(newReportBugCommand())
+
+	return cmd
+}
+
+func newReportBugCommand() *cobra.Command {
+	var flags ReportBugFlags
+
+	cmd := &cobra.Command{
+		Use:   "bug",
+		Short: "Collect a diagnostic bundle for a bug report",
+		Long: `Collect what the maintainers need to act on a bug report into a zip:
+
+  report.json      crew and framework versions, platform, last operation
+  doctor.json      the checks of 'crew doctor'
+  metadata.json    installed components, features and integrity summary
+  reports.jsonl    the install and update history of 'crew logs --reports'
+  logs/last-operation.log  log entries of the last install or update
+  logs/recent.log  the last --log-lines log entries
+
+The home directory, the user name, the current project's name, the
+names and patterns under settings.redact, and anything that looks like a
+token, password or API key are redacted from every file. Review the
+bundle before attaching it anyway.
+
+crew then prints a link opening a GitHub issue prefilled with the
+versions and platform; --open opens it in the browser.
+
+Examples:
+  crew report bug
+  crew report bug --title "Hooks missing after update" --open
+  crew report bug --bundle /tmp/crew-report.zip --log-lines 500`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		Annotations:  map[string]string{skipFileLogAnnotation: "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runReportBug(flags)
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.Bundle, "bundle", "",
+		"Path of the zip to write (default crew-report-<time>.zip in the current directory)")
+	cmd.Flags().StringVar(&flags.Title, "title", "",
+		"Title of the prefilled GitHub issue")
+	cmd.Flags().BoolVar(&flags.Open, "open", false,
+		"Open the prefilled GitHub issue in the browser")
+	cmd.Flags().IntVar(&flags.LogLines, "log-lines", 200,
+		"Number of recent log entries to include (0 for none)")
+
+	return cmd
+}
+
+func runReportBug(flags ReportBugFlags) error {
+	log := logger.GetLogger()
+	if flags.LogLines < 0 {
+		return fmt.Errorf("invalid --log-lines %d: must not be negative", flags.LogLines)
+	}
+
+	now := time.Now()
+	path := flags.Bundle
+	if path == "" {
+		path = "crew-report-" + now.Format("20060102-150405") + ".zip"
+	}
+
+	files, report, err := collectBugReport(now, flags.LogLines)
+	if err != nil {
+		return err
+	}
+
+	result := bugReportResult{Bundle: path, IssueURL: bugIssueURL(flags.Title, report, filepath.Base(path))}
+	for _, f := range files {
+		result.Files = append(result.Files, f.name)
+	}
+	if globalFlags.DryRun {
+		log.Infof("[DRY RUN] Would write %s with %s", path, strings.Join(result.Files, ", "))
+	} else if err := writeBugBundle(path, files, redact.Default()); err != nil {
+		return err
+	}
+
+	if flags.Open && !globalFlags.DryRun {
+		if err := openBrowser(result.IssueURL); err != nil {
+			log.Warnf("Could not open the browser: %v", err)
+		} else {
+			result.Opened = true
+		}
+	}
+
+	if globalFlags.Output == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(result)
+	}
+
+	if !globalFlags.DryRun {
+		size := int64(0)
+		if info, err := os.Stat(path); err == nil {
+			size = info.Size()
+		}
+		log.Successf("Wrote diagnostic bundle %s (%d files, %s)", path, len(files), formatBytes(size))
+		fmt.Printf("  %s Review it before sharing; your home directory, user name, project name and credentials are redacted\n", ui.Icons.Tip)
+	}
+	if !result.Opened {
+		fmt.Printf("\nOpen an issue and attach the bundle:\n  %s\n", result.IssueURL)
+	}
+	return nil
+}
+
+// bugBundleFile is one file of the bundle before redaction
+type bugBundleFile struct {
+	name string
+	data []byte
+}
+
+// collectBugReport gathers the bundle files. Anything that cannot be read
+// is left out, so a broken installation can still be reported.
+func collectBugReport(now time.Time, logLines int) ([]bugBundleFile, bugReport, error) {
+	log := logger.GetLogger()
+	var files []bugBundleFile
+	addJSON := func(name string, v interface{}) error {
+		data, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode %s: %w", name, err)
+		}
+		files = append(files, bugBundleFile{name, append(data, '\n')})
+		return nil
+	}
+
+	report := bugReport{
+		Created:    now.UTC(),
+		Binary:     buildinfo.Get(),
+		Components: make(map[string]string),
+		InstallDir: globalFlags.InstallDir,
+	}
+	versionManager := versioning.NewVersionManager(globalFlags.InstallDir)
+	if version, err := versionManager.GetCurrentVersion(); err == nil {
+		report.Framework = version
+	}
+	for _, comp := range versionComponents {
+		if version, err := versionManager.GetComponentVersion(comp); err == nil && version != "" {
+			report.Components[comp] = version
+		}
+	}
+
+	diagnostics := diagnoseSystem()
+	if environment, ok := diagnostics["environment"].(platform.Environment); ok {
+		report.Environment = environment
+	} else {
+		report.Environment = platform.Detect(globalFlags.InstallDir)
+	}
+	results, _ := diagnostics["results"].([]core.RequirementResult)
+	for _, result := range results {
+		if !result.Satisfied {
+			report.FailedChecks = append(report.FailedChecks, result.Tool+": "+result.Message)
+		}
+	}
+
+	reports, err := loadOperationReports(getReportsDir())
+	if err != nil {
+		log.Debugf("Skipping operation reports: %v", err)
+	}
+	if len(reports) > 0 {
+		report.LastOperation = &reports[len(reports)-1]
+	}
+
+	if err := addJSON("report.json", report); err != nil {
+		return nil, report, err
+	}
+	if err := addJSON("doctor.json", diagnostics); err != nil {
+		return nil, report, err
+	}
+	if md, err := metadata.NewMetadataManager(globalFlags.InstallDir).LoadMetadata(); err != nil {
+		log.Debugf("Skipping metadata: %v", err)
+	} else if err := addJSON("metadata.json", summarizeMetadata(md)); err != nil {
+		return nil, report, err
+	}
+
+	if len(reports) > 0 {
+		var buf bytes.Buffer
+		encoder := json.NewEncoder(&buf)
+		for _, r := range reports {
+			if err := encoder.Encode(r); err != nil {
+				return nil, report, err
+			}
+		}
+		files = append(files, bugBundleFile{"reports.jsonl", buf.Bytes()})
+	}
+
+	var entries []logEntry
+	for _, file := range logger.LogFiles(crewdirs.Path(globalFlags.InstallDir, "logs"), logger.DefaultName) {
+		fileEntries, err := readLogFile(file)
+		if err != nil {
+			log.Debugf("Skipping log file: %v", err)
+			continue
+		}
+		entries = append(entries, fileEntries...)
+	}
+	if report.LastOperation != nil && report.LastOperation.CorrelationID != "" {
+		var lines []string
+		for _, entry := range entries {
+			if entry.CID == report.LastOperation.CorrelationID {
+				lines = append(lines, entry.Raw)
+			}
+		}
+		if len(lines) > 0 {
+			files = append(files, bugBundleFile{"logs/last-operation.log", []byte(strings.Join(lines, "\n") + "\n")})
+		}
+	}
+	if logLines > 0 && len(entries) > 0 {
+		if len(entries) > logLines {
+			entries = entries[len(entries)-logLines:]
+		}
+		lines := make([]string, len(entries))
+		for i, entry := range entries {
+			lines[i] = entry.Raw
+		}
+		files = append(files, bugBundleFile{"logs/recent.log", []byte(strings.Join(lines, "\n") + "\n")})
+	}
+
+	return files, report, nil
+}
+
+// summarizeMetadata keeps what a bug report needs of md
+func summarizeMetadata(md *metadata.UnifiedMetadata) bugReportMetadata {
+	summary := bugReportMetadata{
+		Framework:    md.Framework,
+		Components:   md.Components,
+		Features:     md.Features,
+		Installation: md.Installation,
+		CreatedFiles: len(md.Inventory.CreatedFiles),
+	}
+	summary.Integrity.Status = md.Integrity.Status
+	summary.Integrity.LastScan = md.Integrity.LastScan
+	summary.Integrity.Total = len(md.Integrity.FileHashes)
+	for path, file := range md.Integrity.FileHashes {
+		if file.Status != "clean" {
+			if summary.Integrity.Problems == nil {
+				summary.Integrity.Problems = make(map[string]string)
+			}
+			summary.Integrity.Problems[path] = file.Status
+		}
+	}
+	return summary
+}
+
+// writeBugBundle writes files, passed through redactor, into a zip at path
+func writeBugBundle(path string, files []bugBundleFile, redactor *redact.Redactor) error {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, f := range files {
+		w, err := zw.CreateHeader(&zip.FileHeader{Name: f.name, Method: zip.Deflate, Modified: time.Now()})
+		if err != nil {
+			return fmt.Errorf("failed to add %s to the bundle: %w", f.name, err)
+		}
+		if _, err := w.Write(redactor.Bytes(f.data)); err != nil {
+			return fmt.Errorf("failed to add %s to the bundle: %w", f.name, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("failed to write the bundle: %w", err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// bugIssueURL returns a link opening a GitHub issue prefilled with report
+func bugIssueURL(title string, report bugReport, bundle string) string {
+	var body strings.Builder
+	body.WriteString("**Describe the bug**\n\n<!-- What did you run, what happened, and what did you expect? -->\n\n")
+	body.WriteString("**Environment**\n\n")
+	fmt.Fprintf(&body, "- crew: %s", report.Binary.Version)
+	if report.Binary.Commit != "" {
+		fmt.Fprintf(&body, " (%s)", report.Binary.Commit)
+	}
+	fmt.Fprintf(&body, ", %s\n", report.Binary.GoVersion)
+	fmt.Fprintf(&body, "- Platform: %s\n", report.Environment)
+	if report.Framework != "" {
+		fmt.Fprintf(&body, "- Framework: %s\n", report.Framework)
+	}
+	if len(report.Components) > 0 {
+		var components []string
+		for name, version := range report.Components {
+			components = append(components, name+" "+version)
+		}
+		sort.Strings(components)
+		fmt.Fprintf(&body, "- Components: %s\n", strings.Join(components, ", "))
+	}
+	if op := report.LastOperation; op != nil {
+		fmt.Fprintf(&body, "- Last operation: %s %s (%.1fs)\n", op.Operation, op.Status, op.Duration)
+	}
+	for _, check := range report.FailedChecks {
+		fmt.Fprintf(&body, "- Failed check: %s\n", check)
+	}
+	fmt.Fprintf(&body, "\n**Diagnostic bundle**\n\n<!-- Attach %s, written by crew report bug -->\n", bundle)
+
+	values := url.Values{"body": {redact.String(body.String())}}
+	if title != "" {
+		values.Set("title", title)
+	}
+	return fmt.Sprintf("https://github.com/%s/issues/new?%s", releases.Repository, values.Encode())
+}
+
+// openBrowser opens target with the desktop's default handler
+func openBrowser(target string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", target)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", target)
+	default:
+		cmd = exec.Command("xdg-open", target)
+	}
+	return cmd.Start()
+}
@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"archive/zip"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/crewdirs"
+	"github.com/jonwraymond/claude-code-super-crew/internal/redact"
+)
+
+func TestCollectBugReport(t *testing.T) {
+	saved := globalFlags.InstallDir
+	defer func() { globalFlags.InstallDir = saved }()
+	globalFlags.InstallDir = t.TempDir()
+
+	logDir := crewdirs.Path(globalFlags.InstallDir, "logs")
+	if err := os.MkdirAll(logDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(logDir, "supercrew.log"), []byte(sampleLog), 0644); err != nil {
+		t.Fatal(err)
+	}
+	finished := time.Date(2026, 1, 2, 10, 0, 9, 0, time.UTC)
+	if err := writeOperationReport(getReportsDir(), operationReport{
+		Operation: "install", Status: "failed", Started: finished.Add(-9 * time.Second),
+		Finished: finished, Duration: 9, CorrelationID: "aaa111",
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	files, report, err := collectBugReport(finished, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.LastOperation == nil || report.LastOperation.CorrelationID != "aaa111" {
+		t.Fatalf("last operation = %+v", report.LastOperation)
+	}
+
+	redactor, err := redact.New(redact.Options{Home: globalFlags.InstallDir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "bundle.zip")
+	if err := writeBugBundle(path, files, redactor); err != nil {
+		t.Fatal(err)
+	}
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer zr.Close()
+	contents := make(map[string]string)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, _ := io.ReadAll(rc)
+		rc.Close()
+		contents[f.Name] = string(data)
+	}
+
+	for _, name := range []string{"report.json", "doctor.json", "metadata.json", "reports.jsonl", "logs/last-operation.log", "logs/recent.log"} {
+		if _, ok := contents[name]; !ok {
+			t.Errorf("bundle has no %s", name)
+		}
+	}
+	if got := strings.Count(contents["logs/last-operation.log"], "\n"); got != 2 {
+		t.Errorf("last operation log has %d entries, want the 2 of aaa111", got)
+	}
+	if strings.Contains(contents["report.json"], globalFlags.InstallDir) {
+		t.Errorf("report.json shows the install directory:\n%s", contents["report.json"])
+	}
+	if got := contents["logs/recent.log"]; !strings.Contains(got, "Update completed") || strings.Count(got, "\n") != 1 {
+		t.Errorf("recent log = %q", got)
+	}
+}
+
+func TestBugIssueURL(t *testing.T) {
+	report := bugReport{
+		Components:    map[string]string{"core": "2.0.0", "commands": "2.0.0"},
+		LastOperation: &operationReport{Operation: "update", Status: "failed", Duration: 1.5},
+		FailedChecks:  []string{"node: not found"},
+	}
+	link, err := url.Parse(bugIssueURL("Hooks missing", report, "crew-report.zip"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if link.Host != "github.com" || !strings.HasSuffix(link.Path, "/issues/new") {
+		t.Errorf("issue link %s", link)
+	}
+	query := link.Query()
+	if query.Get("title") != "Hooks missing" {
+		t.Errorf("title = %q", query.Get("title"))
+	}
+	body := query.Get("body")
+	for _, want := range []string{"commands 2.0.0, core 2.0.0", "update failed (1.5s)", "node: not found", "crew-report.zip"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("body lacks %q:\n%s", want, body)
+		}
+	}
+}
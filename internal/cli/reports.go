@@ -0,0 +1,142 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/crewdirs"
+	"github.com/jonwraymond/claude-code-super-crew/internal/events"
+	"github.com/jonwraymond/claude-code-super-crew/pkg/logger"
+)
+
+// operationReport summarizes one install or update run for crew logs
+type operationReport struct {
+	Operation     string    `json:"operation"`
+	Status        string    `json:"status"`
+	Components    []string  `json:"components,omitempty"`
+	Failed        []string  `json:"failed,omitempty"`
+	Warnings      int       `json:"warnings,omitempty"`
+	Started       time.Time `json:"started"`
+	Finished      time.Time `json:"finished"`
+	Duration      float64   `json:"duration_seconds"`
+	CorrelationID string    `json:"cid,omitempty"`
+}
+
+// operationStarts remembers when each operation's *.started event fired
+var operationStarts = struct {
+	sync.Mutex
+	times map[string]time.Time
+}{times: map[string]time.Time{}}
+
+// getReportsDir returns the directory holding install and update reports
+func getReportsDir() string {
+	return crewdirs.Path(globalFlags.InstallDir, "logs", "reports")
+}
+
+// recordOperationReport is subscribed to install.* and update.* events and
+// writes a report once the operation completes or fails. Reporting problems
+// are only logged so they never veto or fail the operation itself.
+func recordOperationReport(event events.Event) error {
+	operation, phase, ok := strings.Cut(event.Type, ".")
+	if !ok {
+		return nil
+	}
+
+	operationStarts.Lock()
+	defer operationStarts.Unlock()
+
+	switch phase {
+	case "started":
+		operationStarts.times[operation] = event.Time
+		return nil
+	case "completed", "failed":
+	default:
+		return nil
+	}
+
+	started, ok := operationStarts.times[operation]
+	delete(operationStarts.times, operation)
+	if !ok {
+		started = event.Time
+	}
+
+	if globalFlags.DryRun {
+		return nil
+	}
+	if _, err := os.Stat(crewdirs.Path(globalFlags.InstallDir)); err != nil {
+		return nil
+	}
+
+	report := operationReport{
+		Operation:     operation,
+		Status:        phase,
+		Started:       started,
+		Finished:      event.Time,
+		Duration:      event.Time.Sub(started).Seconds(),
+		CorrelationID: logger.CurrentCorrelationID(),
+	}
+	if components := event.Data["components"]; components != "" {
+		report.Components = strings.Split(components, ",")
+	}
+	if failed := event.Data["failed"]; failed != "" {
+		report.Failed = strings.Split(failed, ",")
+	}
+	report.Warnings, _ = strconv.Atoi(event.Data["warnings"])
+
+	if err := writeOperationReport(getReportsDir(), report); err != nil {
+		logger.GetLogger().Debugf("Failed to write %s report: %v", operation, err)
+	}
+	return nil
+}
+
+// writeOperationReport saves report as <operation>-<timestamp>.json in dir
+func writeOperationReport(dir string, report operationReport) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create reports directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode report: %w", err)
+	}
+
+	name := fmt.Sprintf("%s-%s.json", report.Operation, report.Finished.UTC().Format("20060102-150405.000"))
+	if err := os.WriteFile(filepath.Join(dir, name), data, 0644); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	return nil
+}
+
+// loadOperationReports reads every report in dir, oldest first
+func loadOperationReports(dir string) ([]operationReport, error) {
+	matches, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	var reports []operationReport
+	for _, path := range matches {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read report %s: %w", filepath.Base(path), err)
+		}
+		var report operationReport
+		if err := json.Unmarshal(data, &report); err != nil {
+			logger.GetLogger().Debugf("Skipping invalid report %s: %v", filepath.Base(path), err)
+			continue
+		}
+		reports = append(reports, report)
+	}
+
+	sort.SliceStable(reports, func(i, j int) bool {
+		return reports[i].Finished.Before(reports[j].Finished)
+	})
+	return reports, nil
+}
@@ -0,0 +1,271 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/ui"
+	"github.com/jonwraymond/claude-code-super-crew/pkg/logger"
+)
+
+// ExitPartial is the exit status of an install, update or uninstall that
+// changed some components but failed on others
+const ExitPartial = 5
+
+// Outcomes of a component in an operationResult
+const (
+	componentPending   = "pending"
+	componentSucceeded = "succeeded"
+	componentFailed    = "failed"
+	componentSkipped   = "skipped"
+)
+
+// Outcomes of a whole operationResult
+const (
+	resultSucceeded = "succeeded"
+	resultPartial   = "partial"
+	resultFailed    = "failed"
+)
+
+// componentResult is what an operation did to one component
+type componentResult struct {
+	Name     string  `json:"name"`
+	Status   string  `json:"status"`
+	Duration float64 `json:"duration_seconds"`
+	Files    int     `json:"files,omitempty"`
+	Error    string  `json:"error,omitempty"`
+
+	started time.Time
+}
+
+// operationResult is what install, update and uninstall return to the
+// command layer, which displays it, prints it with --output json, reports
+// it to event subscribers and turns it into the exit status
+type operationResult struct {
+	Operation  string             `json:"operation"`
+	Status     string             `json:"status"`
+	DryRun     bool               `json:"dry_run,omitempty"`
+	InstallDir string             `json:"install_dir"`
+	Components []*componentResult `json:"components"`
+	Warnings   []string           `json:"warnings,omitempty"`
+	Backup     string             `json:"backup,omitempty"`
+	Duration   float64            `json:"duration_seconds"`
+	Error      string             `json:"error,omitempty"`
+
+	started time.Time
+}
+
+// newOperationResult starts the result of operation over components, which
+// stay pending until they start and are skipped if they never do
+func newOperationResult(operation string, components []string) *operationResult {
+	r := &operationResult{
+		Operation:  operation,
+		DryRun:     globalFlags.DryRun,
+		InstallDir: globalFlags.InstallDir,
+		Components: []*componentResult{},
+		started:    time.Now(),
+	}
+	for _, name := range components {
+		r.component(name)
+	}
+	return r
+}
+
+// component returns the result of name, adding it when it is new
+func (r *operationResult) component(name string) *componentResult {
+	for _, c := range r.Components {
+		if c.Name == name {
+			return c
+		}
+	}
+	c := &componentResult{Name: name, Status: componentPending}
+	r.Components = append(r.Components, c)
+	return c
+}
+
+// Start marks name as started
+func (r *operationResult) Start(name string) {
+	r.component(name).started = time.Now()
+}
+
+// Done marks name as finished; a non-nil err marks it failed
+func (r *operationResult) Done(name string, err error) {
+	c := r.component(name)
+	if !c.started.IsZero() {
+		c.Duration = time.Since(c.started).Seconds()
+	}
+	c.Status = componentSucceeded
+	if err != nil {
+		c.Status = componentFailed
+		c.Error = err.Error()
+	}
+}
+
+// Skip marks name as skipped
+func (r *operationResult) Skip(name, reason string) {
+	c := r.component(name)
+	c.Status = componentSkipped
+	c.Error = reason
+}
+
+// track returns progress with every Start, Done and Skip also recorded in r
+func (r *operationResult) track(progress ui.Progress) ui.Progress {
+	return &trackedProgress{Progress: progress, result: r}
+}
+
+// trackedProgress is a ui.Progress that records components in a result
+type trackedProgress struct {
+	ui.Progress
+	result *operationResult
+}
+
+func (p *trackedProgress) Start(name string) {
+	p.result.Start(name)
+	p.Progress.Start(name)
+}
+
+func (p *trackedProgress) Done(name string, err error) {
+	p.result.Done(name, err)
+	p.Progress.Done(name, err)
+}
+
+func (p *trackedProgress) Skip(name, reason string) {
+	p.result.Skip(name, reason)
+	p.Progress.Skip(name, reason)
+}
+
+// warnf logs a warning and keeps it in the result
+func (r *operationResult) warnf(format string, args ...interface{}) {
+	message := fmt.Sprintf(format, args...)
+	logger.GetLogger().Warn(message)
+	r.Warnings = append(r.Warnings, message)
+}
+
+// failf logs an error that stopped the whole operation and returns the
+// finished result
+func (r *operationResult) failf(format string, args ...interface{}) *operationResult {
+	message := fmt.Sprintf(format, args...)
+	logger.GetLogger().Error(message)
+	if r.Error == "" {
+		r.Error = message
+	}
+	return r.finish()
+}
+
+// stop records why the operation stopped early without failing a component
+func (r *operationResult) stop(err error) {
+	if r.Error == "" {
+		r.Error = err.Error()
+	}
+}
+
+// finish skips the components that never ran and settles the status
+func (r *operationResult) finish() *operationResult {
+	r.Duration = time.Since(r.started).Seconds()
+	for _, c := range r.Components {
+		if c.Status == componentPending {
+			c.Status = componentSkipped
+		}
+	}
+
+	failed := len(r.names(componentFailed))
+	switch {
+	case failed == 0 && r.Error == "":
+		r.Status = resultSucceeded
+	case len(r.names(componentSucceeded)) > 0:
+		r.Status = resultPartial
+	default:
+		r.Status = resultFailed
+	}
+	return r
+}
+
+// names returns the components with status, in the order they were added
+func (r *operationResult) names(status string) []string {
+	var names []string
+	for _, c := range r.Components {
+		if c.Status == status {
+			names = append(names, c.Name)
+		}
+	}
+	return names
+}
+
+// ok reports whether every component succeeded
+func (r *operationResult) ok() bool {
+	return r.Status == resultSucceeded
+}
+
+// err returns nil for a successful result, and otherwise message with the
+// failed components. A partial result exits with ExitPartial.
+func (r *operationResult) err(message string) error {
+	if r.ok() {
+		return nil
+	}
+	err := errors.New(message)
+	if failed := r.names(componentFailed); len(failed) > 0 {
+		err = fmt.Errorf("%s for %s", message, strings.Join(failed, ", "))
+	} else if r.Error != "" {
+		err = fmt.Errorf("%s: %s", message, r.Error)
+	}
+	if r.Status == resultPartial {
+		return &ExitError{Code: ExitPartial, Err: err}
+	}
+	return err
+}
+
+// eventData adds the outcome to the data of the completed or failed event
+func (r *operationResult) eventData(data map[string]string) map[string]string {
+	merged := make(map[string]string, len(data)+3)
+	for key, value := range data {
+		merged[key] = value
+	}
+	merged["status"] = r.Status
+	if failed := r.names(componentFailed); len(failed) > 0 {
+		merged["failed"] = strings.Join(failed, ",")
+	}
+	if len(r.Warnings) > 0 {
+		merged["warnings"] = strconv.Itoa(len(r.Warnings))
+	}
+	return merged
+}
+
+// printOperationResult writes r with --output json, on one line so it reads
+// like the progress events before it, and otherwise lists each component's
+// outcome when there is more than one or any failed
+func printOperationResult(r *operationResult) error {
+	if globalFlags.Output == "json" {
+		return json.NewEncoder(os.Stdout).Encode(r)
+	}
+	if !showDecorations() || (len(r.Components) < 2 && r.ok()) {
+		return nil
+	}
+
+	fmt.Printf("\n%sComponents:%s\n", ui.ColorCyan, ui.ColorReset)
+	for _, c := range r.Components {
+		icon, detail := ui.Icons.Check, ""
+		switch c.Status {
+		case componentFailed:
+			icon, detail = ui.Icons.Cross, c.Error
+		case componentSkipped:
+			icon, detail = ui.Icons.Bullet, "skipped"
+			if c.Error != "" {
+				detail += ": " + c.Error
+			}
+		default:
+			if c.Files > 0 {
+				detail = fmt.Sprintf("%d files", c.Files)
+			}
+		}
+		fmt.Printf("  %s %-10s %6.1fs  %s\n", icon, c.Name, c.Duration, detail)
+	}
+	if len(r.Warnings) > 0 {
+		fmt.Printf("  %s %d warnings, see the log above\n", ui.Icons.Warning, len(r.Warnings))
+	}
+	return nil
+}
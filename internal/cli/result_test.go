@@ -0,0 +1,48 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestOperationResult(t *testing.T) {
+	r := newOperationResult("update", []string{"core", "hooks", "agents"})
+	r.Start("core")
+	r.Done("core", nil)
+	r.Start("hooks")
+	r.Done("hooks", errors.New("permission denied"))
+	r.warnf("Failed to update settings for %s", "core")
+	r.stop(context.Canceled)
+	r.finish()
+
+	if r.Status != resultPartial {
+		t.Fatalf("status = %s, want partial", r.Status)
+	}
+	if got := r.component("agents").Status; got != componentSkipped {
+		t.Errorf("agents never started but is %s", got)
+	}
+	err := r.err("update failed")
+	if ExitCode(err) != ExitPartial || !strings.Contains(err.Error(), "update failed for hooks") {
+		t.Errorf("err = %v (exit %d)", err, ExitCode(err))
+	}
+	data := r.eventData(map[string]string{"components": "core,hooks,agents"})
+	if data["failed"] != "hooks" || data["warnings"] != "1" || data["status"] != resultPartial || data["components"] == "" {
+		t.Errorf("event data = %v", data)
+	}
+
+	failed := newOperationResult("install", nil).failf("Failed to discover components: %v", errors.New("no such file"))
+	if failed.Status != resultFailed || ExitCode(failed.err("installation failed")) != 1 {
+		t.Errorf("failed result = %+v", failed)
+	}
+	if err := failed.err("installation failed"); !strings.Contains(err.Error(), "no such file") {
+		t.Errorf("err = %v, want the cause", err)
+	}
+
+	done := newOperationResult("uninstall", []string{"core"})
+	done.Done("core", nil)
+	if !done.finish().ok() || done.err("uninstall failed") != nil {
+		t.Errorf("succeeded result = %+v", done)
+	}
+}
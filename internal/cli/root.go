@@ -1,20 +1,34 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 
+	"github.com/jonwraymond/claude-code-super-crew/internal/i18n"
 	"github.com/jonwraymond/claude-code-super-crew/internal/ui"
+	"github.com/jonwraymond/claude-code-super-crew/pkg/logger"
 	"github.com/spf13/cobra"
 )
 
 // GlobalFlags holds all global command flags
 type GlobalFlags struct {
-	Verbose    bool
-	Quiet      bool
-	InstallDir string
-	DryRun     bool
-	Force      bool
-	Yes        bool
+	Verbose        bool
+	Quiet          bool
+	InstallDir     string
+	DryRun         bool
+	Force          bool
+	Yes            bool
+	ConfigProfile  string
+	Output         string
+	NoColor        bool
+	ASCII          bool
+	LogLevel       string
+	LogFormat      string
+	NonInteractive bool
+	Locale         string
+	XDG            bool
+	CI             bool
 }
 
 var globalFlags GlobalFlags
@@ -38,26 +52,61 @@ The Claude Code Super Crew CLI provides operations to install, update, manage,
 and configure the Super Crew framework for Claude AI.`,
 		Version: version,
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			applyCIMode(cmd)
+
+			// Styling applies before anything is printed, including flag errors
+			ui.Configure(ui.StyleOptions{
+				Color: ui.DetectColor(globalFlags.NoColor),
+				ASCII: globalFlags.ASCII,
+			})
+			// A program reading --output json cannot answer prompts
+			ui.SetNonInteractive(ui.DetectNonInteractive(globalFlags.NonInteractive || globalFlags.Output == "json"))
+			if err := i18n.SetLocale(globalFlags.Locale); err != nil {
+				logger.GetLogger().Warnf("%v; using %s", err, i18n.DefaultLocale)
+			}
+
 			// Check for conflicting flags
 			if globalFlags.Verbose && globalFlags.Quiet {
 				return fmt.Errorf("conflicting flags: --verbose and --quiet cannot be used together")
 			}
-			return nil
+			if globalFlags.Output != "text" && globalFlags.Output != "json" {
+				return fmt.Errorf("invalid --output %q: must be text or json", globalFlags.Output)
+			}
+			selectFrontend()
+			if err := selectStateLayout(cmd); err != nil {
+				return err
+			}
+			cm, err := loadSettingsConfig()
+			if err != nil {
+				if globalFlags.ConfigProfile != "" {
+					return err
+				}
+				// A broken config must not lock out the commands that
+				// repair or remove it
+				logger.GetLogger().Warnf("Using the default settings: %v", err)
+			}
+			applyConfigProfile(cm)
+			applyIOLimit(cm)
+			applyRetrySettings(cm)
+			applyRedactionSettings(cm)
+			return configureLogging(cmd)
 		},
 		Run: func(cmd *cobra.Command, args []string) {
 			if !globalFlags.Quiet {
-				ui.DisplayHeader("Claude Code Super Crew v"+version, "Unified CLI for all operations")
-				fmt.Printf("%sAvailable operations:%s\n", ui.ColorCyan, ui.ColorReset)
-				fmt.Printf("  %-12s %s\n", "install", "Install SuperCrew framework globally")
-				fmt.Printf("  %-12s %s\n", "status", "Show detailed component and feature status")
-				fmt.Printf("  %-12s %s\n", "claude", "Manage project-level Claude Code integration")
-				fmt.Printf("  %-12s %s\n", "update", "Update existing Claude Code Super Crew installation")
-				fmt.Printf("  %-12s %s\n", "update-document", "Update document version with pipeline propagation")
-				fmt.Printf("  %-12s %s\n", "uninstall", "Remove Claude Code Super Crew installation")
-				fmt.Printf("  %-12s %s\n", "backup", "Backup and restore operations")
-				fmt.Printf("\n%sQuick Start:%s\n", ui.ColorGreen, ui.ColorReset)
-				fmt.Printf("  1. crew install              # Install framework globally (once)\n")
-				fmt.Printf("  2. crew claude --install     # Enable for current project\n")
+				ui.DisplayHeader("Claude Code Super Crew v"+version, "root.subtitle")
+				fmt.Printf("%s%s%s\n", ui.ColorCyan, i18n.T("root.operations"), ui.ColorReset)
+				fmt.Printf("  %-12s %s\n", "setup", i18n.T("root.op.setup"))
+				fmt.Printf("  %-12s %s\n", "install", i18n.T("root.op.install"))
+				fmt.Printf("  %-12s %s\n", "status", i18n.T("root.op.status"))
+				fmt.Printf("  %-12s %s\n", "claude", i18n.T("root.op.claude"))
+				fmt.Printf("  %-12s %s\n", "update", i18n.T("root.op.update"))
+				fmt.Printf("  %-12s %s\n", "update-document", i18n.T("root.op.update_document"))
+				fmt.Printf("  %-12s %s\n", "uninstall", i18n.T("root.op.uninstall"))
+				fmt.Printf("  %-12s %s\n", "backup", i18n.T("root.op.backup"))
+				fmt.Printf("  %-12s %s\n", "logs", i18n.T("root.op.logs"))
+				fmt.Printf("\n%s%s%s\n", ui.ColorGreen, i18n.T("root.quick_start"), ui.ColorReset)
+				fmt.Printf("  1. crew setup                # %s\n", i18n.T("root.quick_start.setup"))
+				fmt.Printf("  2. crew claude --install     # %s\n", i18n.T("root.quick_start.claude"))
 			}
 		},
 	}
@@ -69,9 +118,33 @@ and configure the Super Crew framework for Claude AI.`,
 	rootCmd.PersistentFlags().BoolVar(&globalFlags.DryRun, "dry-run", false, "Simulate operation without making changes")
 	rootCmd.PersistentFlags().BoolVar(&globalFlags.Force, "force", false, "Force execution, skipping checks")
 	rootCmd.PersistentFlags().BoolVarP(&globalFlags.Yes, "yes", "y", false, "Automatically answer yes to all prompts")
+	rootCmd.PersistentFlags().StringVar(&globalFlags.Output, "output", "text", "Output format: text or json (json streams progress events)")
+	rootCmd.PersistentFlags().BoolVar(&globalFlags.NonInteractive, "non-interactive", false, "Never prompt: use documented defaults and fail when a choice is required (implied when stdin is not a terminal)")
+	rootCmd.PersistentFlags().BoolVar(&globalFlags.NoColor, "no-color", false, "Disable colored output (also honors NO_COLOR and CLICOLOR=0)")
+	rootCmd.PersistentFlags().BoolVar(&globalFlags.ASCII, "ascii", false, "Use plain ASCII instead of emoji and box-drawing characters")
+	rootCmd.PersistentFlags().StringVar(&globalFlags.LogLevel, "log-level", "info", "Console log level: debug, info, warn or error")
+	rootCmd.PersistentFlags().StringVar(&globalFlags.LogFormat, "log-format", "text", "Console log format: text or json (json lines on stderr)")
+	rootCmd.PersistentFlags().StringVar(&globalFlags.Locale, "locale", i18n.Detect(), "Language for CLI messages: "+strings.Join(i18n.Available(), ", ")+" (defaults to CREW_LOCALE or LANG)")
+	rootCmd.PersistentFlags().StringVar(&globalFlags.ConfigProfile, "config-profile", "", "Named configuration profile from .crew/config/profiles")
+	rootCmd.PersistentFlags().BoolVar(&globalFlags.XDG, "xdg", false, "Keep crew state, config and caches in the XDG base directories instead of .crew (also CREW_XDG=1)")
+	rootCmd.PersistentFlags().BoolVar(&globalFlags.CI, "ci", false, "Run as a pipeline step: implies --yes --non-interactive --no-color --output json, turns off telemetry and update notices, and uses stricter exit codes (also CREW_CI=1)")
+	rootCmd.SetFlagErrorFunc(ciFlagError)
+
+	registerFlagCompletions(rootCmd, map[string]completionFunc{
+		"config-profile": completeConfigProfiles,
+		"output":         completeStatic("text", "json"),
+		"log-level":      completeStatic("debug", "info", "warn", "error"),
+		"log-format":     completeStatic("text", "json"),
+		"locale":         completeStatic(i18n.Available()...),
+	})
+
+	// Connect cross-cutting subscribers (lifecycle hooks) to the event bus
+	registerEventSubscribers()
 
 	// Add subcommands
+	rootCmd.AddCommand(NewSetupCommand())
 	rootCmd.AddCommand(NewInstallCommand())
+	rootCmd.AddCommand(NewApplyCommand())
 	rootCmd.AddCommand(NewStatusCommand())
 	rootCmd.AddCommand(NewUpdateCommand())
 	rootCmd.AddCommand(NewUpdateDocumentCommand())
@@ -81,10 +154,79 @@ and configure the Super Crew framework for Claude AI.`,
 	rootCmd.AddCommand(NewHooksCommand())
 	rootCmd.AddCommand(NewVersionCommand())
 	rootCmd.AddCommand(NewIntegrityCommand())
+	rootCmd.AddCommand(NewLogsCommand())
+	rootCmd.AddCommand(NewTelemetryCommand())
+	rootCmd.AddCommand(NewShellenvCommand())
+	rootCmd.AddCommand(NewCompletionCommand())
+	rootCmd.AddCommand(NewDocsCommand())
+	rootCmd.AddCommand(NewPluginsCommand())
+	rootCmd.AddCommand(NewVerifyCommand())
+	rootCmd.AddCommand(NewDoctorCommand())
+	rootCmd.AddCommand(NewProjectCommand())
+	rootCmd.AddCommand(NewConfigCommand())
+	rootCmd.AddCommand(NewInitCommand())
+	rootCmd.AddCommand(NewMigrateInstallCommand())
+	rootCmd.AddCommand(NewMigrateXDGCommand())
+	rootCmd.AddCommand(NewReleaseCommand())
+	rootCmd.AddCommand(NewCacheCommand())
+	rootCmd.AddCommand(NewDevCommand())
+	rootCmd.AddCommand(NewTrashCommand())
+	rootCmd.AddCommand(NewPermsCommand())
+	rootCmd.AddCommand(NewAuditCommand())
+	rootCmd.AddCommand(NewUndoCommand())
+	rootCmd.AddCommand(NewExplainCommand())
+	rootCmd.AddCommand(NewDiffCommand())
+	rootCmd.AddCommand(NewBenchCommand())
+	rootCmd.AddCommand(NewAgentsCommand())
+	rootCmd.AddCommand(NewRouteCommand())
+	rootCmd.AddCommand(NewLoadCommand())
+	rootCmd.AddCommand(NewAnalyzeCommand())
+	rootCmd.AddCommand(NewMCPCommand())
+	rootCmd.AddCommand(NewSelftestCommand())
+	rootCmd.AddCommand(NewReportCommand())
+
+	// Record opt-in usage telemetry around every command
+	instrumentCommands(rootCmd, version)
+
+	// Append the commands that change the installation to the audit log
+	auditCommands(rootCmd)
+
+	// External crew-<name> plugins are added last so they never shadow a
+	// built-in command, and are left out of telemetry since their names
+	// are chosen by the user
+	addPluginCommands(rootCmd, version)
 
 	return rootCmd
 }
 
+// ExitError is an error that asks for a specific process exit status, such
+// as the status a plugin exited with
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+// ExitCode returns the process exit status for an error returned by the
+// root command
+func ExitCode(err error) int {
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) && exitErr.Code > 0 {
+		return exitErr.Code
+	}
+	if code := ciExitCode(err); code > 0 {
+		return code
+	}
+	return 1
+}
+
 // GetGlobalFlags returns the global flags
 func GetGlobalFlags() *GlobalFlags {
 	return &globalFlags
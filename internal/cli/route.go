@@ -0,0 +1,160 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/agents"
+	"github.com/jonwraymond/claude-code-super-crew/internal/claude"
+	"github.com/jonwraymond/claude-code-super-crew/internal/pathsutil"
+	"github.com/jonwraymond/claude-code-super-crew/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// RouteFlags holds route command flags
+type RouteFlags struct {
+	ProjectDir string
+	JSON       bool
+}
+
+var routeFlags RouteFlags
+
+// NewRouteCommand creates the route command
+func NewRouteCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "route",
+		Short: "Inspect how /crew: commands are routed to agents",
+		Long: `Inspect how the orchestrator routes /crew: commands to personas,
+project specialists and multi-agent workflows. Commands act on the project
+containing the current directory unless --project-dir is given.`,
+	}
+
+	cmd.PersistentFlags().StringVar(&routeFlags.ProjectDir, "project-dir", "",
+		"Project directory (default: the project containing the current directory)")
+	registerFlagCompletions(cmd, map[string]completionFunc{
+		"project-dir": func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+			return nil, cobra.ShellCompDirectiveFilterDirs
+		},
+	})
+
+	cmd.AddCommand(newRouteSimulateCommand())
+
+	return cmd
+}
+
+func newRouteSimulateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "simulate <command line>",
+		Short: "Show where a /crew: command line would be routed",
+		Long: `Run the complexity analyzer and routing rules on a /crew: command line
+without executing or rendering it, and show the chosen agents, how the
+complexity score was reached and the routes not taken. Agents whose
+activation keywords the line matches are listed as alternatives.
+
+Everything from the /crew: command on belongs to the command line, so its
+flags need no quoting; flags of crew itself go before it.
+
+Examples:
+  crew route simulate /crew:design the api and secure auth
+  crew route simulate "/crew:analyze src --focus security"
+  crew route simulate --json /crew:troubleshoot flaky tests`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRouteSimulate(joinRouteArgs(args))
+		},
+		SilenceUsage: true,
+	}
+	// Flags after the command line belong to it, not to crew
+	cmd.Flags().SetInterspersed(false)
+	cmd.Flags().BoolVar(&routeFlags.JSON, "json", false, "Output the simulation as JSON")
+	return cmd
+}
+
+// joinRouteArgs rebuilds the command line from the words the shell split,
+// quoting the ones that contained spaces
+func joinRouteArgs(args []string) string {
+	if len(args) == 1 {
+		return args[0]
+	}
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		if strings.ContainsAny(arg, " \t") {
+			arg = fmt.Sprintf("%q", arg)
+		}
+		quoted[i] = arg
+	}
+	return strings.Join(quoted, " ")
+}
+
+func runRouteSimulate(commandLine string) error {
+	projectDir, err := resolveProjectDir(routeFlags.ProjectDir)
+	if err != nil {
+		return err
+	}
+
+	integration, err := claude.NewClaudeIntegration(filepath.Join(getGlobalInstallDir(), "commands", "crew"), pathsutil.ClaudeDir(projectDir))
+	if err != nil {
+		return fmt.Errorf("cannot load crew commands: %w", err)
+	}
+	loaded, err := loadAgents(projectDir)
+	if err != nil {
+		return err
+	}
+
+	sim, err := integration.SimulateRoute(commandLine, agents.NewIndex(loaded))
+	if err != nil {
+		return err
+	}
+
+	if routeFlags.JSON || globalFlags.Output == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(sim)
+	}
+
+	displayRouteSimulation(sim)
+	return nil
+}
+
+func displayRouteSimulation(sim *claude.RouteSimulation) {
+	fmt.Printf("\n%s%sRoute Simulation%s\n", ui.ColorCyan, ui.ColorBright, ui.ColorReset)
+	fmt.Println(strings.Repeat("=", 50))
+	fmt.Printf("%sCommand:%s %s\n\n", ui.ColorBlue, ui.ColorReset, sim.CommandLine)
+
+	fmt.Printf("%s%s Route: %s%s\n", ui.ColorGreen, ui.Icons.Success, formatRoute(sim.Route), ui.ColorReset)
+	fmt.Printf("  %s\n\n", sim.Route.Reason)
+
+	complexity := sim.Complexity
+	fmt.Printf("%sComplexity:%s %.1f (orchestration from %.1f or %d domains)\n", ui.ColorBlue, ui.ColorReset,
+		complexity.Score, claude.OrchestrationThreshold, claude.OrchestrationDomains)
+	for _, factor := range complexity.Breakdown {
+		fmt.Printf("  %s %-14s +%.1f\n", ui.Icons.Bullet, factor.Indicator, factor.Points)
+	}
+	if len(complexity.Domains) > 0 {
+		fmt.Printf("  Domains: %s\n", strings.Join(complexity.Domains, ", "))
+	}
+	if len(complexity.Breakdown) == 0 && len(complexity.Domains) == 0 {
+		fmt.Println("  No complexity indicators")
+	}
+
+	fmt.Printf("\n%sAlternatives:%s\n", ui.ColorBlue, ui.ColorReset)
+	if len(sim.Alternatives) == 0 {
+		fmt.Println("  None")
+	}
+	for _, alt := range sim.Alternatives {
+		fmt.Printf("  %s %s\n", ui.Icons.Arrow, formatRoute(alt))
+		fmt.Printf("    %s\n", alt.Reason)
+	}
+	fmt.Println()
+}
+
+// formatRoute describes a route as its kind and agents
+func formatRoute(route claude.Route) string {
+	if len(route.Agents) == 0 {
+		return route.Kind
+	}
+	return fmt.Sprintf("%s %s %s", route.Kind, ui.Icons.Arrow, strings.Join(route.Agents, ", "))
+}
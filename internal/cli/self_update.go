@@ -0,0 +1,123 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/buildinfo"
+	"github.com/jonwraymond/claude-code-super-crew/internal/fileops"
+	"github.com/jonwraymond/claude-code-super-crew/internal/managers"
+	"github.com/jonwraymond/claude-code-super-crew/internal/releases"
+	"github.com/jonwraymond/claude-code-super-crew/internal/semver"
+	"github.com/jonwraymond/claude-code-super-crew/pkg/logger"
+)
+
+// proxyKey is the proxy release downloads go through. Without it the
+// HTTPS_PROXY and NO_PROXY environment variables apply. A proxy URL with
+// credentials can be kept out of the config as a secret:// reference.
+const proxyKey = "settings.network.proxy"
+
+// releaseAssetName returns the name the release binary for this platform is
+// published under, as 'make build-all' names it
+func releaseAssetName() string {
+	name := fmt.Sprintf("crew-%s-%s", runtime.GOOS, runtime.GOARCH)
+	if runtime.GOOS == "windows" {
+		name += ".exe"
+	}
+	return name
+}
+
+// newReleaseDownloader returns a downloader caching in the installation's
+// cache, through the configured proxy
+func newReleaseDownloader(cm *managers.ConfigManager) *releases.Downloader {
+	d := releases.NewDownloader(releases.CacheDir(globalFlags.InstallDir))
+	d.Cache = openCache()
+	if cm != nil {
+		proxy, err := cm.GetSecret(proxyKey)
+		if err == nil {
+			d.Proxy = proxy
+		} else if _, plain := cm.GetString(proxyKey); plain == nil {
+			logger.GetLogger().Warnf("Ignoring %s: %v", proxyKey, err)
+		}
+	}
+	return d
+}
+
+// runSelfUpdate replaces the running crew binary with the one of the
+// latest release. The download is verified against the checksums of the
+// release, resumed when an earlier one was interrupted and cached, so
+// running it again after a failure does not fetch the binary twice.
+func runSelfUpdate(ctx context.Context) error {
+	log := logger.GetLogger()
+
+	release, err := releases.NewFeed().Latest(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to check for a new release: %w", err)
+	}
+	current := buildinfo.Get()
+	if semver.Compare(release.Version(), current.Version) <= 0 && !globalFlags.Force {
+		log.Infof("crew v%s is the latest release", current.Version)
+		return nil
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate the crew binary: %w", err)
+	}
+	if resolved, err := filepath.EvalSymlinks(exe); err == nil {
+		exe = resolved
+	}
+
+	name := releaseAssetName()
+	if globalFlags.DryRun {
+		log.Infof("[DRY RUN] Would download %s of v%s and replace %s", name, release.Version(), exe)
+		return nil
+	}
+
+	cm, err := loadSettingsConfig()
+	if err != nil {
+		return err
+	}
+	log.Infof("Downloading %s of v%s...", name, release.Version())
+	path, err := newReleaseDownloader(cm).Fetch(ctx, release, name)
+	if err != nil {
+		return err
+	}
+
+	if err := replaceExecutable(exe, path); err != nil {
+		return fmt.Errorf("failed to replace %s: %w", exe, err)
+	}
+	log.Successf("Updated crew from v%s to v%s", current.Version, release.Version())
+	return nil
+}
+
+// replaceExecutable puts a copy of src in place of exe. The new binary is
+// written next to exe first so the swap is a rename; the running binary is
+// moved aside first, since Windows does not let it be overwritten.
+func replaceExecutable(exe, src string) error {
+	staged := exe + ".new"
+	if err := fileops.CopyFile(src, staged); err != nil {
+		return err
+	}
+	if err := os.Chmod(staged, 0755); err != nil {
+		os.Remove(staged)
+		return err
+	}
+
+	old := exe + ".old"
+	os.Remove(old)
+	if err := os.Rename(exe, old); err != nil {
+		os.Remove(staged)
+		return err
+	}
+	if err := os.Rename(staged, exe); err != nil {
+		os.Rename(old, exe)
+		return err
+	}
+	// Windows keeps the running binary locked; it is removed next time
+	os.Remove(old)
+	return nil
+}
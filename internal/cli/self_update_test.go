@@ -0,0 +1,38 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReplaceExecutable(t *testing.T) {
+	dir := t.TempDir()
+	exe := filepath.Join(dir, "crew")
+	download := filepath.Join(dir, "crew-linux-amd64")
+	if err := os.WriteFile(exe, []byte("old"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(download, []byte("new"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := replaceExecutable(exe, download); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(exe)
+	if err != nil || string(data) != "new" {
+		t.Errorf("Expected the new binary in place, got %q, %v", data, err)
+	}
+	if info, err := os.Stat(exe); err != nil || info.Mode().Perm()&0100 == 0 {
+		t.Errorf("Expected the new binary to be executable, got %v, %v", info.Mode(), err)
+	}
+	if _, err := os.Stat(download); err != nil {
+		t.Error("Expected the cached download to stay")
+	}
+	for _, leftover := range []string{exe + ".new", exe + ".old"} {
+		if _, err := os.Stat(leftover); !os.IsNotExist(err) {
+			t.Errorf("Expected %s to be gone", leftover)
+		}
+	}
+}
@@ -0,0 +1,278 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// Selftest step statuses
+const (
+	selftestPass = "pass"
+	selftestFail = "fail"
+	selftestSkip = "skip"
+)
+
+// selftestOutputLines is how much of a failed step's output the report keeps
+const selftestOutputLines = 20
+
+// SelftestFlags holds selftest command flags
+type SelftestFlags struct {
+	Components []string
+	Keep       bool
+}
+
+// selftestStep is one crew command of the cycle and its outcome
+type selftestStep struct {
+	Name string `json:"name"`
+	// Args are the command and its flags, without the --install-dir and
+	// non-interactive flags every step gets
+	Args       []string `json:"args"`
+	Status     string   `json:"status"`
+	DurationMs int64    `json:"duration_ms"`
+	Message    string   `json:"message,omitempty"`
+	Output     []string `json:"output,omitempty"`
+}
+
+// selftestReport is the outcome of the whole cycle
+type selftestReport struct {
+	Status     string         `json:"status"`
+	Binary     string         `json:"binary"`
+	Home       string         `json:"home"`
+	InstallDir string         `json:"install_dir"`
+	Steps      []selftestStep `json:"steps"`
+}
+
+// selftestRunner runs crew with args and returns its combined output
+type selftestRunner func(ctx context.Context, args []string) (string, error)
+
+// NewSelftestCommand creates the selftest command
+func NewSelftestCommand() *cobra.Command {
+	var flags SelftestFlags
+
+	cmd := &cobra.Command{
+		Use:   "selftest",
+		Short: "Run an install, backup, update and uninstall cycle in a throwaway home",
+		Long: `Check that this crew build works on this system by running it through a
+full lifecycle against a temporary home directory. Your own installation
+is never touched.
+
+Steps, in order:
+  install       crew install --quick (or --components)
+  status        crew status
+  backup        crew backup --create
+  update-check  crew update --check
+  uninstall     crew uninstall --complete
+
+Each step runs this crew binary non-interactively with HOME and the XDG
+directories pointing into the temporary home. When install fails, the
+remaining steps are skipped. The command exits non-zero when any step
+fails, so packagers can use it to validate a build on a target system.
+
+Examples:
+  crew selftest
+  crew selftest --components core,commands
+  crew selftest --keep --output json`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: interruptible(func(cmd *cobra.Command, args []string) error {
+			return runSelftest(cmd, flags)
+		}),
+	}
+
+	cmd.Flags().StringSliceVar(&flags.Components, "components", nil,
+		"Components to install instead of the quick selection")
+	cmd.Flags().BoolVar(&flags.Keep, "keep", false,
+		"Keep the temporary home directory for inspection")
+
+	registerFlagCompletions(cmd, map[string]completionFunc{
+		"components": completeAvailableComponents,
+	})
+
+	return cmd
+}
+
+func runSelftest(cmd *cobra.Command, flags SelftestFlags) error {
+	binary, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to find the crew binary: %w", err)
+	}
+	home, err := os.MkdirTemp("", "crew-selftest-")
+	if err != nil {
+		return fmt.Errorf("failed to create the temporary home: %w", err)
+	}
+	if !flags.Keep {
+		defer os.RemoveAll(home)
+	}
+
+	installDir := filepath.Join(home, ".claude")
+	report := runSelftestCycle(commandContext(cmd), selftestSteps(flags), installDir, selftestExec(binary, home))
+	report.Binary = binary
+	report.Home = home
+
+	if globalFlags.Output == "json" {
+		encoder := json.NewEncoder(cmd.OutOrStdout())
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			return err
+		}
+	} else {
+		displaySelftestReport(cmd.OutOrStdout(), report, flags.Keep)
+	}
+
+	if report.Status == selftestFail {
+		return fmt.Errorf("selftest failed")
+	}
+	return nil
+}
+
+// selftestSteps returns the commands of the cycle
+func selftestSteps(flags SelftestFlags) []selftestStep {
+	install := []string{"install", "--quick", "--no-backup"}
+	if len(flags.Components) > 0 {
+		install = []string{"install", "--components", strings.Join(flags.Components, ","), "--no-backup"}
+	}
+	return []selftestStep{
+		{Name: "install", Args: install},
+		{Name: "status", Args: []string{"status"}},
+		{Name: "backup", Args: []string{"backup", "--create"}},
+		{Name: "update-check", Args: []string{"update", "--check"}},
+		{Name: "uninstall", Args: []string{"uninstall", "--complete"}},
+	}
+}
+
+// runSelftestCycle runs steps in order against installDir. The steps after
+// a failed install are skipped, since they would fail for the same reason.
+func runSelftestCycle(ctx context.Context, steps []selftestStep, installDir string, run selftestRunner) selftestReport {
+	report := selftestReport{Status: selftestPass, InstallDir: installDir}
+	common := []string{"--install-dir", installDir, "--yes", "--non-interactive", "--no-color"}
+	installed := true
+	for _, step := range steps {
+		switch {
+		case !installed:
+			step.Status = selftestSkip
+			step.Message = "skipped because install failed"
+		case ctx.Err() != nil:
+			step.Status = selftestSkip
+			step.Message = "skipped because the selftest was interrupted"
+		default:
+			start := time.Now()
+			output, err := run(ctx, append(append([]string{}, step.Args...), common...))
+			step.DurationMs = time.Since(start).Milliseconds()
+			step.Status = selftestPass
+			if err != nil {
+				step.Status = selftestFail
+				step.Message = err.Error()
+				step.Output = tailLines(stripUsage(output), selftestOutputLines)
+				report.Status = selftestFail
+				installed = step.Name != "install"
+			}
+		}
+		report.Steps = append(report.Steps, step)
+	}
+	return report
+}
+
+// selftestExec runs binary with HOME and the XDG directories in home, and
+// with telemetry and CI mode off so the steps behave as on a workstation
+func selftestExec(binary, home string) selftestRunner {
+	env := make([]string, 0, len(os.Environ())+8)
+	for _, kv := range os.Environ() {
+		switch strings.SplitN(kv, "=", 2)[0] {
+		case "HOME", "USERPROFILE", "XDG_CONFIG_HOME", "XDG_DATA_HOME", "XDG_STATE_HOME", "XDG_CACHE_HOME", "CREW_CI", "CREW_XDG":
+			continue
+		}
+		env = append(env, kv)
+	}
+	env = append(env,
+		"HOME="+home,
+		"USERPROFILE="+home,
+		"XDG_CONFIG_HOME="+filepath.Join(home, ".config"),
+		"XDG_DATA_HOME="+filepath.Join(home, ".local", "share"),
+		"XDG_STATE_HOME="+filepath.Join(home, ".local", "state"),
+		"XDG_CACHE_HOME="+filepath.Join(home, ".cache"),
+		"CREW_TELEMETRY=off",
+	)
+
+	return func(ctx context.Context, args []string) (string, error) {
+		cmd := exec.CommandContext(ctx, binary, args...)
+		cmd.Env = env
+		output, err := cmd.CombinedOutput()
+		return string(output), err
+	}
+}
+
+// stripUsage removes the usage cobra prints after some errors, which
+// would push the lines explaining the failure out of the report
+func stripUsage(output string) string {
+	start := strings.LastIndex(output, "\nUsage:\n")
+	if start < 0 {
+		return output
+	}
+	end := strings.Index(output[start:], "\nError: ")
+	if end < 0 {
+		return output[:start]
+	}
+	return output[:start] + output[start+end:]
+}
+
+// tailLines returns the last n non-empty lines of output
+func tailLines(output string, n int) []string {
+	var lines []string
+	for _, line := range strings.Split(output, "\n") {
+		if line = strings.TrimRight(line, "\r "); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return lines
+}
+
+func displaySelftestReport(w io.Writer, report selftestReport, kept bool) {
+	fmt.Fprintf(w, "\n%s%sCrew Selftest%s\n", ui.ColorCyan, ui.ColorBright, ui.ColorReset)
+	fmt.Fprintln(w, strings.Repeat("=", 50))
+	fmt.Fprintf(w, "Binary: %s\n", report.Binary)
+	fmt.Fprintf(w, "Home:   %s\n\n", report.Home)
+
+	for _, step := range report.Steps {
+		icon := ui.Icons.Success
+		switch step.Status {
+		case selftestFail:
+			icon = ui.Icons.Failure
+		case selftestSkip:
+			icon = ui.Icons.Warning
+		}
+		fmt.Fprintf(w, "  %s %-13s crew %s", icon, step.Name, strings.Join(step.Args, " "))
+		if step.Status != selftestSkip {
+			fmt.Fprintf(w, " (%dms)", step.DurationMs)
+		}
+		fmt.Fprintln(w)
+		if step.Message != "" {
+			fmt.Fprintf(w, "      %s\n", step.Message)
+		}
+		for _, line := range step.Output {
+			fmt.Fprintf(w, "      | %s\n", line)
+		}
+	}
+
+	fmt.Fprintln(w)
+	if report.Status == selftestPass {
+		fmt.Fprintf(w, "%s%s All steps passed%s\n", ui.ColorGreen, ui.Icons.Success, ui.ColorReset)
+	} else {
+		fmt.Fprintf(w, "%s%s Selftest failed%s\n", ui.ColorRed, ui.Icons.Failure, ui.ColorReset)
+	}
+	if kept {
+		fmt.Fprintf(w, "Temporary home kept at %s\n", report.Home)
+	}
+}
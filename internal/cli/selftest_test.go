@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRunSelftestCycle(t *testing.T) {
+	var ran []string
+	fake := func(failing string) selftestRunner {
+		return func(ctx context.Context, args []string) (string, error) {
+			ran = append(ran, args[0])
+			if !contains(args, "--non-interactive") || !contains(args, "/tmp/home/.claude") {
+				t.Errorf("step run without the common flags: %v", args)
+			}
+			if args[0] == failing {
+				return "line 1\nline 2\n", errors.New("exit status 1")
+			}
+			return "ok\n", nil
+		}
+	}
+
+	report := runSelftestCycle(context.Background(), selftestSteps(SelftestFlags{}), "/tmp/home/.claude", fake(""))
+	if report.Status != selftestPass || strings.Join(ran, ",") != "install,status,backup,update,uninstall" {
+		t.Errorf("passing cycle: status %s, ran %v", report.Status, ran)
+	}
+	if got := report.Steps[0].Args; strings.Join(got, " ") != "install --quick --no-backup" {
+		t.Errorf("reported install args = %v", got)
+	}
+
+	ran = nil
+	report = runSelftestCycle(context.Background(), selftestSteps(SelftestFlags{}), "/tmp/home/.claude", fake("backup"))
+	if report.Status != selftestFail || len(ran) != 5 {
+		t.Errorf("failed backup: status %s, ran %v", report.Status, ran)
+	}
+	if backup := report.Steps[2]; backup.Status != selftestFail || strings.Join(backup.Output, "|") != "line 1|line 2" {
+		t.Errorf("backup step = %+v", backup)
+	}
+
+	ran = nil
+	report = runSelftestCycle(context.Background(), selftestSteps(SelftestFlags{Components: []string{"core", "commands"}}), "/tmp/home/.claude", fake("install"))
+	if len(ran) != 1 || report.Steps[4].Status != selftestSkip {
+		t.Errorf("failed install: ran %v, steps %+v", ran, report.Steps)
+	}
+	if got := strings.Join(report.Steps[0].Args, " "); got != "install --components core,commands --no-backup" {
+		t.Errorf("install args with --components = %q", got)
+	}
+}
+
+func TestStripUsage(t *testing.T) {
+	output := "Installing\nfailed to copy\nUsage:\n  crew install [flags]\n\nFlags:\n  --quick\nError: installation failed\n"
+	if got := stripUsage(output); got != "Installing\nfailed to copy\nError: installation failed\n" {
+		t.Errorf("stripUsage = %q", got)
+	}
+	if got := stripUsage("no usage\n"); got != "no usage\n" {
+		t.Errorf("stripUsage without usage = %q", got)
+	}
+}
+
+func TestTailLines(t *testing.T) {
+	if got := tailLines("a\n\nb\r\nc\n", 2); strings.Join(got, ",") != "b,c" {
+		t.Errorf("tailLines = %v", got)
+	}
+}
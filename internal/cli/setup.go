@@ -0,0 +1,417 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/core"
+	"github.com/jonwraymond/claude-code-super-crew/internal/hooks"
+	"github.com/jonwraymond/claude-code-super-crew/internal/i18n"
+	"github.com/jonwraymond/claude-code-super-crew/internal/managers"
+	"github.com/jonwraymond/claude-code-super-crew/internal/ui"
+	"github.com/jonwraymond/claude-code-super-crew/pkg/logger"
+	"github.com/spf13/cobra"
+)
+
+// Policies for an existing CLAUDE.md, mapped onto the install --claude-* flags
+const (
+	claudePolicyMerge     = "merge"
+	claudePolicyOverwrite = "overwrite"
+	claudePolicySkip      = "skip"
+)
+
+// setupProfiles are the installation profiles offered by the wizard and the
+// interactive install menu. An empty component list means custom selection.
+var setupProfiles = []ui.InstallationProfile{
+	{Name: "recommended", Description: "Core, commands, hooks and agents", Components: []string{"core", "commands", "hooks", "agents"}},
+	{Name: "minimal", Description: "Framework core only", Components: []string{"core"}},
+	{Name: "developer", Description: "Core, commands, hooks and MCP servers", Components: []string{"core", "commands", "hooks", "mcp"}},
+	{Name: "custom", Description: "Pick individual components"},
+}
+
+// setupEnvironment is what the wizard detected about the machine
+type setupEnvironment struct {
+	Platform        string
+	Checks          map[string]map[string]string
+	Issues          []string
+	Installed       map[string]string
+	ClaudeMDExists  bool
+	InstallDirInUse bool
+}
+
+// setupWizard collects the choices made in each step of crew setup
+type setupWizard struct {
+	env          setupEnvironment
+	registry     *core.EnhancedComponentRegistry
+	profile      string
+	components   []string
+	claudePolicy string
+	hooks        []string
+	mcpServers   []string
+	experimental bool
+}
+
+// NewSetupCommand creates the setup command
+func NewSetupCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "setup",
+		Short: "Guided first-run setup wizard",
+		Long: `Walk through a first-time setup of Claude Code Super Crew.
+
+The wizard detects your environment, lets you choose an installation
+profile and components, decides how to treat an existing CLAUDE.md,
+enables hooks and MCP servers, and finishes by running the install.
+
+With --yes every step takes its default (recommended profile, merge
+CLAUDE.md, no hooks, required MCP servers only).`,
+		RunE: runSetup,
+	}
+
+	return cmd
+}
+
+func runSetup(cmd *cobra.Command, args []string) error {
+	gFlags := GetGlobalFlags()
+
+	exe, _ := os.Executable()
+	projectRoot := filepath.Dir(filepath.Dir(filepath.Dir(exe)))
+	registry := core.NewEnhancedComponentRegistry(filepath.Join(projectRoot, "setup", "components"))
+	if err := discoverComponents(registry); err != nil {
+		return fmt.Errorf("failed to discover components: %w", err)
+	}
+
+	w := &setupWizard{registry: registry}
+
+	ui.DisplayHeader("setup.title", "setup.subtitle")
+
+	steps := []struct {
+		title string
+		run   func() error
+	}{
+		{"setup.step.detect", w.detectEnvironment},
+		{"setup.step.profile", w.chooseProfile},
+		{"setup.step.components", w.chooseComponents},
+		{"setup.step.claude_md", w.chooseClaudePolicy},
+		{"setup.step.hooks", w.chooseHooks},
+		{"setup.step.mcp", w.chooseMCPServers},
+	}
+
+	for i, step := range steps {
+		fmt.Println()
+		ui.DisplayStep(i+1, len(steps)+1, step.title)
+		if err := step.run(); err != nil {
+			return err
+		}
+	}
+
+	fmt.Println()
+	ui.DisplayStep(len(steps)+1, len(steps)+1, "setup.step.review")
+	w.displaySummary(gFlags.InstallDir)
+
+	if ok, err := confirmAction(i18n.T("setup.confirm"), true); err != nil {
+		return err
+	} else if !ok {
+		logger.GetLogger().Info(i18n.T("setup.cancelled"))
+		return nil
+	}
+
+	return w.install(cmd)
+}
+
+// detectEnvironment runs the system diagnostics and inspects the install dir
+func (w *setupWizard) detectEnvironment() error {
+	gFlags := GetGlobalFlags()
+	diagnostics := core.NewValidator().DiagnoseSystem()
+
+	w.env.Platform, _ = diagnostics["platform"].(string)
+	w.env.Checks, _ = diagnostics["checks"].(map[string]map[string]string)
+	w.env.Issues, _ = diagnostics["issues"].([]string)
+
+	settingsManager := managers.NewSettingsManager(gFlags.InstallDir)
+	if settingsManager.CheckInstallationExists() {
+		w.env.Installed, _ = settingsManager.GetInstalledComponents()
+	}
+	if _, err := os.Stat(gFlags.InstallDir); err == nil {
+		w.env.InstallDirInUse = true
+	}
+	if _, err := os.Stat(filepath.Join(gFlags.InstallDir, "CLAUDE.md")); err == nil {
+		w.env.ClaudeMDExists = true
+	}
+
+	fmt.Printf("  %-12s %s\n", "platform", w.env.Platform)
+	names := make([]string, 0, len(w.env.Checks))
+	for name := range w.env.Checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		check := w.env.Checks[name]
+		mark := ui.Icons.Check
+		if check["status"] != "pass" {
+			mark = ui.Icons.Cross
+		}
+		fmt.Printf("  %-12s %s %s\n", name, mark, check["message"])
+	}
+
+	if len(w.env.Installed) > 0 {
+		ui.DisplayInfo(i18n.T("setup.existing_install", gFlags.InstallDir))
+	}
+	for _, issue := range w.env.Issues {
+		ui.DisplayWarning(issue)
+	}
+
+	return nil
+}
+
+// useDefaults reports whether wizard steps should take their defaults
+// instead of prompting: with --yes or when no input is possible
+func useDefaults() bool {
+	return GetGlobalFlags().Yes || ui.NonInteractive()
+}
+
+// chooseProfile picks an installation profile
+func (w *setupWizard) chooseProfile() error {
+	if useDefaults() {
+		w.profile = setupProfiles[0].Name
+		fmt.Printf("  %s\n", i18n.T("setup.profile.default", w.profile))
+		return nil
+	}
+
+	profile, err := ui.NewProfileSelector(setupProfiles).SelectProfile()
+	if err != nil {
+		return fmt.Errorf("profile selection failed: %w", err)
+	}
+	w.profile = profile.Name
+	return nil
+}
+
+// chooseComponents applies the profile, asking for components only when
+// the custom profile was chosen. Components the registry does not know
+// about are dropped.
+func (w *setupWizard) chooseComponents() error {
+	available := w.registry.FilterComponents(core.ComponentFilter{IncludeExperimental: w.experimental})
+
+	var preset []string
+	for _, profile := range setupProfiles {
+		if profile.Name == w.profile {
+			preset = profile.Components
+		}
+	}
+
+	if len(preset) == 0 && !useDefaults() {
+		selected, err := ui.NewComponentSelector(describeComponents(w.registry, available), nil).SelectComponents()
+		if err != nil {
+			return fmt.Errorf("component selection failed: %w", err)
+		}
+		for _, option := range selected {
+			preset = append(preset, strings.Fields(option)[0])
+		}
+	}
+
+	w.components = nil
+	for _, name := range preset {
+		if contains(available, name) {
+			w.components = append(w.components, name)
+		}
+	}
+
+	if len(w.components) == 0 {
+		return fmt.Errorf("no components selected")
+	}
+
+	fmt.Printf("  %s %s\n", i18n.T("setup.summary.components"), strings.Join(w.components, ", "))
+	return nil
+}
+
+// chooseClaudePolicy decides what to do with an existing CLAUDE.md
+func (w *setupWizard) chooseClaudePolicy() error {
+	w.claudePolicy = claudePolicyMerge
+
+	if !w.env.ClaudeMDExists {
+		fmt.Printf("  %s\n", i18n.T("setup.claude_md.new"))
+		return nil
+	}
+	if useDefaults() {
+		fmt.Printf("  %s\n", i18n.T("setup.claude_md.merge_default"))
+		return nil
+	}
+
+	if err := showClaudePreview(GetGlobalFlags().InstallDir, false); err != nil {
+		logger.GetLogger().Warnf("Cannot preview CLAUDE.md changes: %v", err)
+	}
+
+	options := []string{
+		i18n.T("setup.claude_md.merge"),
+		i18n.T("setup.claude_md.overwrite"),
+		i18n.T("setup.claude_md.skip"),
+	}
+	choice, err := ui.PromptChoice(i18n.T("setup.claude_md.prompt"), options, 0)
+	if err != nil {
+		return fmt.Errorf("CLAUDE.md policy selection failed: %w", err)
+	}
+
+	w.claudePolicy = []string{claudePolicyMerge, claudePolicyOverwrite, claudePolicySkip}[choice]
+	return nil
+}
+
+// chooseHooks selects Claude Code hooks to enable once hooks are installed
+func (w *setupWizard) chooseHooks() error {
+	w.hooks = nil
+
+	if !contains(w.components, "hooks") {
+		fmt.Printf("  %s\n", i18n.T("setup.hooks.not_selected"))
+		return nil
+	}
+	if useDefaults() {
+		fmt.Printf("  %s\n", i18n.T("setup.hooks.none"))
+		return nil
+	}
+
+	hm := hooks.NewHookManager("")
+	hm.SetProjectOverlay(false)
+	if err := hm.DiscoverHooks(); err != nil {
+		return fmt.Errorf("failed to discover hooks: %w", err)
+	}
+
+	available := hm.ListHooks()
+	options := make([]string, len(available))
+	for i, hook := range available {
+		options[i] = fmt.Sprintf("%s - %s", hook.Name, hook.Description)
+	}
+
+	choices, err := ui.PromptMultiChoice(i18n.T("setup.hooks.prompt"), options)
+	if err != nil {
+		return fmt.Errorf("hook selection failed: %w", err)
+	}
+	for _, idx := range choices {
+		w.hooks = append(w.hooks, available[idx].Name)
+	}
+
+	return nil
+}
+
+// chooseMCPServers selects the optional MCP servers; required servers are
+// always installed with the mcp component
+func (w *setupWizard) chooseMCPServers() error {
+	// Non-nil so the mcp component installs only what was picked here
+	w.mcpServers = []string{}
+
+	if !contains(w.components, "mcp") {
+		fmt.Printf("  %s\n", i18n.T("setup.mcp.not_selected"))
+		return nil
+	}
+
+	var optional []core.MCPServerInfo
+	for _, server := range core.NewMCPComponent().MCPServers {
+		if !server.Required {
+			optional = append(optional, server)
+		}
+	}
+	sort.Slice(optional, func(i, j int) bool { return optional[i].Name < optional[j].Name })
+
+	if len(optional) == 0 || useDefaults() {
+		fmt.Printf("  %s\n", i18n.T("setup.mcp.required_only"))
+		return nil
+	}
+
+	options := make([]string, len(optional))
+	for i, server := range optional {
+		options[i] = fmt.Sprintf("%s - %s", server.Name, server.Description)
+		if server.APIKeyEnv != "" {
+			options[i] += " " + i18n.T("setup.mcp.needs_key", server.APIKeyEnv)
+		}
+	}
+
+	choices, err := ui.PromptMultiChoice(i18n.T("setup.mcp.prompt"), options)
+	if err != nil {
+		return fmt.Errorf("MCP server selection failed: %w", err)
+	}
+	for _, idx := range choices {
+		w.mcpServers = append(w.mcpServers, optional[idx].Name)
+	}
+
+	return nil
+}
+
+// displaySummary shows every choice before anything is written
+func (w *setupWizard) displaySummary(installDir string) {
+	none := func(values []string) string {
+		if len(values) == 0 {
+			return i18n.T("ui.answer.none")
+		}
+		return strings.Join(values, ", ")
+	}
+
+	fmt.Printf("  %-20s %s\n", i18n.T("setup.summary.install_dir"), installDir)
+	fmt.Printf("  %-20s %s\n", i18n.T("setup.summary.profile"), w.profile)
+	fmt.Printf("  %-20s %s\n", i18n.T("setup.summary.components"), strings.Join(w.components, ", "))
+	fmt.Printf("  %-20s %s\n", i18n.T("setup.summary.claude_md"), w.claudePolicy)
+	fmt.Printf("  %-20s %s\n", i18n.T("setup.summary.hooks"), none(w.hooks))
+	if contains(w.components, "mcp") {
+		fmt.Printf("  %-20s %s\n", i18n.T("setup.summary.optional_mcp"), none(w.mcpServers))
+	}
+	fmt.Println()
+}
+
+// install hands the collected choices to the regular install flow and then
+// enables the selected hooks
+func (w *setupWizard) install(cmd *cobra.Command) error {
+	gFlags := GetGlobalFlags()
+
+	installFlags = InstallFlags{
+		Components:      w.components,
+		ClaudeMerge:     w.claudePolicy == claudePolicyMerge,
+		ClaudeOverwrite: w.claudePolicy == claudePolicyOverwrite,
+		ClaudeSkip:      w.claudePolicy == claudePolicySkip,
+		MCPServers:      w.mcpServers,
+	}
+
+	// The wizard already asked for confirmation; Ctrl-C from here on stops
+	// the install after the step in progress, as it does for crew install
+	previousYes := gFlags.Yes
+	gFlags.Yes = true
+	err := interruptible(runInstall)(cmd, nil)
+	gFlags.Yes = previousYes
+	if err != nil {
+		return err
+	}
+
+	if len(w.hooks) == 0 {
+		return nil
+	}
+	if gFlags.DryRun {
+		logger.GetLogger().Infof("[DRY RUN] Would enable hooks: %s", strings.Join(w.hooks, ", "))
+		return nil
+	}
+
+	hm := hooks.NewHookManager("")
+	hm.SetInstallDir(gFlags.InstallDir)
+	hm.SetHooksDir(filepath.Join(gFlags.InstallDir, "hooks"))
+	hm.SetProjectOverlay(false)
+	if err := hm.DiscoverHooks(); err != nil {
+		return fmt.Errorf("failed to discover hooks: %w", err)
+	}
+	for _, name := range w.hooks {
+		if err := hm.EnableHook(name); err != nil {
+			ui.DisplayWarning(i18n.T("setup.hooks.enable_failed", name, err))
+		}
+	}
+
+	return nil
+}
+
+// describeComponents formats components as "name (category) - description"
+func describeComponents(registry *core.EnhancedComponentRegistry, names []string) []string {
+	options := make([]string, 0, len(names))
+	for _, name := range names {
+		if metadata := registry.GetComponentMetadata(name); metadata != nil {
+			options = append(options, fmt.Sprintf("%s (%s) - %s", name, metadata.Category, metadata.Description))
+		} else {
+			options = append(options, fmt.Sprintf("%s - Component description unavailable", name))
+		}
+	}
+	return options
+}
@@ -0,0 +1,57 @@
+package cli
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/core"
+)
+
+func TestSetupWizardDefaults(t *testing.T) {
+	registry := core.NewEnhancedComponentRegistry(t.TempDir())
+	if err := registry.DiscoverComponents(); err != nil {
+		t.Fatalf("DiscoverComponents failed: %v", err)
+	}
+
+	previous := globalFlags
+	defer func() { globalFlags = previous }()
+	globalFlags.Yes = true
+	globalFlags.InstallDir = filepath.Join(t.TempDir(), ".claude")
+
+	w := &setupWizard{registry: registry}
+	for _, step := range []func() error{w.chooseProfile, w.chooseComponents, w.chooseClaudePolicy, w.chooseHooks, w.chooseMCPServers} {
+		if err := step(); err != nil {
+			t.Fatalf("Wizard step failed: %v", err)
+		}
+	}
+
+	if w.profile != "recommended" {
+		t.Errorf("Expected recommended profile, got %s", w.profile)
+	}
+	if expected := []string{"core", "commands", "hooks", "agents"}; !reflect.DeepEqual(w.components, expected) {
+		t.Errorf("Expected components %v, got %v", expected, w.components)
+	}
+	if w.claudePolicy != claudePolicyMerge {
+		t.Errorf("Expected merge policy, got %s", w.claudePolicy)
+	}
+	if len(w.hooks) != 0 {
+		t.Errorf("Expected no hooks by default, got %v", w.hooks)
+	}
+}
+
+func TestSetupWizardMCPSelection(t *testing.T) {
+	previous := globalFlags
+	defer func() { globalFlags = previous }()
+	globalFlags.Yes = true
+
+	w := &setupWizard{components: []string{"core", "mcp"}}
+	if err := w.chooseMCPServers(); err != nil {
+		t.Fatalf("chooseMCPServers failed: %v", err)
+	}
+
+	// An empty, non-nil selection limits the install to required servers
+	if w.mcpServers == nil || len(w.mcpServers) != 0 {
+		t.Errorf("Expected empty optional server selection, got %#v", w.mcpServers)
+	}
+}
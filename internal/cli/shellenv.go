@@ -0,0 +1,365 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/crewdirs"
+	"github.com/jonwraymond/claude-code-super-crew/internal/events"
+	"github.com/jonwraymond/claude-code-super-crew/internal/ui"
+	"github.com/jonwraymond/claude-code-super-crew/internal/versioning"
+	"github.com/jonwraymond/claude-code-super-crew/pkg/logger"
+	"github.com/spf13/cobra"
+)
+
+// ShellenvFlags holds shellenv command flags
+type ShellenvFlags struct {
+	Shell     string
+	Prompt    bool
+	NoAliases bool
+	Install   bool
+	RCFile    string
+}
+
+// Markers around the block that --install adds to a shell rc file
+const (
+	shellenvBeginMarker = "# >>> crew shellenv >>>"
+	shellenvEndMarker   = "# <<< crew shellenv <<<"
+)
+
+// crewAliases are the short aliases defined by the shell integration
+var crewAliases = [][2]string{
+	{"cst", "crew status"},
+	{"cup", "crew update"},
+	{"clog", "crew logs"},
+	{"cbk", "crew backup --create"},
+}
+
+// NewShellenvCommand creates the shellenv command
+func NewShellenvCommand() *cobra.Command {
+	var flags ShellenvFlags
+
+	cmd := &cobra.Command{
+		Use:   "shellenv",
+		Short: "Print shell init code for PATH, completions, prompt and aliases",
+		Long: `Print shell initialization code for crew: adds the crew binary to PATH,
+loads tab completion, defines short aliases and, with --prompt, a prompt
+segment showing the framework version and whether an update is available.
+
+The prompt segment reads a small cache in .crew/cache that is refreshed by
+shellenv, install, update and 'crew version --check', so it never runs
+crew while drawing the prompt.
+
+Examples:
+  eval "$(crew shellenv)"                 # bash or zsh, current session
+  crew shellenv --shell fish | source     # fish, current session
+  crew shellenv --install --prompt        # add to your shell rc file`,
+		Args: cobra.NoArgs,
+		// Runs at every shell start, which would flood the log file
+		Annotations: map[string]string{skipFileLogAnnotation: "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runShellenv(cmd, flags)
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.Shell, "shell", "", "Shell to generate code for: bash, zsh or fish (default from $SHELL)")
+	cmd.Flags().BoolVar(&flags.Prompt, "prompt", false, "Add a prompt segment with the framework version and update availability")
+	cmd.Flags().BoolVar(&flags.NoAliases, "no-aliases", false, "Do not define the cst, cup, clog and cbk aliases")
+	cmd.Flags().BoolVar(&flags.Install, "install", false, "Add the integration to your shell rc file instead of printing it")
+	cmd.Flags().StringVar(&flags.RCFile, "rc-file", "", "Shell rc file to update with --install (default depends on the shell)")
+
+	return cmd
+}
+
+func runShellenv(cmd *cobra.Command, flags ShellenvFlags) error {
+	shell, err := resolveShell(flags.Shell)
+	if err != nil {
+		return err
+	}
+
+	binary, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate crew binary: %w", err)
+	}
+	if resolved, err := filepath.EvalSymlinks(binary); err == nil {
+		binary = resolved
+	}
+
+	if flags.Install {
+		return installShellenv(shell, binary, flags)
+	}
+
+	if flags.Prompt {
+		if err := refreshPromptCache(); err != nil {
+			logger.GetLogger().Debugf("Failed to refresh prompt cache: %v", err)
+		}
+	}
+	fmt.Fprint(cmd.OutOrStdout(), generateShellenv(shell, binary, globalFlags.InstallDir, flags))
+	return nil
+}
+
+// resolveShell validates --shell or picks the shell from $SHELL
+func resolveShell(shell string) (string, error) {
+	if shell == "" {
+		shell = filepath.Base(os.Getenv("SHELL"))
+	}
+	switch shell {
+	case "bash", "zsh", "fish":
+		return shell, nil
+	case "", ".":
+		return "", fmt.Errorf("cannot detect your shell; pass --shell bash, zsh or fish")
+	default:
+		return "", fmt.Errorf("unsupported shell %q: must be bash, zsh or fish", shell)
+	}
+}
+
+// generateShellenv returns the init code for shell
+func generateShellenv(shell, binary, installDir string, flags ShellenvFlags) string {
+	binDir := filepath.Dir(binary)
+	cacheFile := getPromptCacheFile(installDir)
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# crew shell integration (generated by crew shellenv)\n")
+	switch shell {
+	case "fish":
+		fmt.Fprintf(&b, "contains -- %s $PATH; or set -gx PATH %s $PATH\n", shellQuote(binDir), shellQuote(binDir))
+		fmt.Fprintf(&b, "set -gx CREW_INSTALL_DIR %s\n", shellQuote(installDir))
+		fmt.Fprintf(&b, "%s completion fish | source\n", shellQuote(binary))
+		if !flags.NoAliases {
+			for _, alias := range crewAliases {
+				fmt.Fprintf(&b, "alias %s %s\n", alias[0], shellQuote(alias[1]))
+			}
+		}
+		if flags.Prompt {
+			fmt.Fprintf(&b, "function __crew_prompt\n")
+			fmt.Fprintf(&b, "    test -r %s; and printf '[crew %%s] ' (cat %s)\n", shellQuote(cacheFile), shellQuote(cacheFile))
+			fmt.Fprintf(&b, "end\n")
+			fmt.Fprintf(&b, "if not functions -q __crew_original_prompt; and functions -q fish_prompt\n")
+			fmt.Fprintf(&b, "    functions -c fish_prompt __crew_original_prompt\n")
+			fmt.Fprintf(&b, "    function fish_prompt\n        __crew_prompt\n        __crew_original_prompt\n    end\n")
+			fmt.Fprintf(&b, "end\n")
+		}
+	default:
+		fmt.Fprintf(&b, "case \":$PATH:\" in\n  *:%s:*) ;;\n  *) export PATH=%s\"${PATH:+:$PATH}\" ;;\nesac\n", shellQuote(binDir), shellQuote(binDir))
+		fmt.Fprintf(&b, "export CREW_INSTALL_DIR=%s\n", shellQuote(installDir))
+		if shell == "zsh" {
+			fmt.Fprintf(&b, "(( $+functions[compdef] )) || { autoload -Uz compinit && compinit; }\n")
+		}
+		fmt.Fprintf(&b, "source <(%s completion %s)\n", shellQuote(binary), shell)
+		if !flags.NoAliases {
+			for _, alias := range crewAliases {
+				fmt.Fprintf(&b, "alias %s=%s\n", alias[0], shellQuote(alias[1]))
+			}
+		}
+		if flags.Prompt {
+			fmt.Fprintf(&b, "__crew_prompt() {\n  [ -r %s ] && printf '[crew %%s] ' \"$(cat %s)\"\n}\n", shellQuote(cacheFile), shellQuote(cacheFile))
+			if shell == "zsh" {
+				fmt.Fprintf(&b, "setopt PROMPT_SUBST\n")
+				fmt.Fprintf(&b, "[[ $PROMPT == *__crew_prompt* ]] || PROMPT='$(__crew_prompt)'\"$PROMPT\"\n")
+			} else {
+				fmt.Fprintf(&b, "[[ $PS1 == *__crew_prompt* ]] || PS1='$(__crew_prompt)'\"$PS1\"\n")
+			}
+		}
+	}
+	return b.String()
+}
+
+// shellQuote wraps s in single quotes, which bash, zsh and fish all read
+// literally
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// defaultRCFile returns the rc file --install updates for shell
+func defaultRCFile(shell string) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	switch shell {
+	case "zsh":
+		if zdot := os.Getenv("ZDOTDIR"); zdot != "" {
+			return filepath.Join(zdot, ".zshrc"), nil
+		}
+		return filepath.Join(home, ".zshrc"), nil
+	case "fish":
+		if config := os.Getenv("XDG_CONFIG_HOME"); config != "" {
+			return filepath.Join(config, "fish", "config.fish"), nil
+		}
+		return filepath.Join(home, ".config", "fish", "config.fish"), nil
+	default:
+		return filepath.Join(home, ".bashrc"), nil
+	}
+}
+
+// shellenvInvocation is the line --install writes, which regenerates the
+// integration on every shell start so it follows crew upgrades
+func shellenvInvocation(shell, binary string, flags ShellenvFlags) string {
+	args := []string{shellQuote(binary), "shellenv", "--shell", shell}
+	if flags.Prompt {
+		args = append(args, "--prompt")
+	}
+	if flags.NoAliases {
+		args = append(args, "--no-aliases")
+	}
+	if globalFlags.InstallDir != expandPath("~/.claude") {
+		args = append(args, "--install-dir", shellQuote(globalFlags.InstallDir))
+	}
+	command := strings.Join(args, " ")
+	if shell == "fish" {
+		return command + " | source"
+	}
+	return fmt.Sprintf("eval \"$(%s)\"", command)
+}
+
+// installShellenv adds or replaces the marked crew block in the rc file
+func installShellenv(shell, binary string, flags ShellenvFlags) error {
+	rcFile := flags.RCFile
+	if rcFile == "" {
+		var err error
+		if rcFile, err = defaultRCFile(shell); err != nil {
+			return err
+		}
+	}
+
+	block := shellenvBeginMarker + "\n" + shellenvInvocation(shell, binary, flags) + "\n" + shellenvEndMarker + "\n"
+
+	existing, err := os.ReadFile(rcFile)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read %s: %w", rcFile, err)
+	}
+	updated, changed := replaceShellenvBlock(string(existing), block)
+	if !changed {
+		logger.GetLogger().Infof("Shell integration is already installed in %s", rcFile)
+		return nil
+	}
+
+	if globalFlags.DryRun {
+		logger.GetLogger().Infof("[DRY RUN] Would add to %s:\n%s", rcFile, block)
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(rcFile), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(rcFile), err)
+	}
+	if err := os.WriteFile(rcFile, []byte(updated), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", rcFile, err)
+	}
+	if flags.Prompt {
+		if err := refreshPromptCache(); err != nil {
+			logger.GetLogger().Debugf("Failed to refresh prompt cache: %v", err)
+		}
+	}
+
+	ui.DisplaySuccess(fmt.Sprintf("Shell integration added to %s", rcFile))
+	ui.DisplayInfo("Open a new shell or source the file to use it")
+	return nil
+}
+
+// replaceShellenvBlock swaps an existing marked block for block, or appends
+// block when there is none. It reports whether the content changed.
+func replaceShellenvBlock(content, block string) (string, bool) {
+	return replaceMarkedBlock(content, shellenvBeginMarker, shellenvEndMarker, block)
+}
+
+// replaceMarkedBlock swaps the block between begin and end markers for
+// block, or appends block when there is none. It reports whether the
+// content changed.
+func replaceMarkedBlock(content, begin, end, block string) (string, bool) {
+	if start, stop, ok := findMarkedBlock(content, begin, end); ok {
+		if content[start:stop] == block {
+			return content, false
+		}
+		return content[:start] + block + content[stop:], true
+	}
+
+	if content != "" && !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+	if content != "" {
+		content += "\n"
+	}
+	return content + block, true
+}
+
+// removeMarkedBlock deletes the block between begin and end markers along
+// with the blank line that separated it. It reports whether the content
+// changed.
+func removeMarkedBlock(content, begin, end string) (string, bool) {
+	start, stop, ok := findMarkedBlock(content, begin, end)
+	if !ok {
+		return content, false
+	}
+	before := strings.TrimSuffix(content[:start], "\n")
+	if strings.HasSuffix(before, "\n") || before == "" {
+		return before + content[stop:], true
+	}
+	return before + "\n" + content[stop:], true
+}
+
+// findMarkedBlock returns the byte range of a marked block, including the
+// newline after the end marker
+func findMarkedBlock(content, begin, end string) (int, int, bool) {
+	start := strings.Index(content, begin)
+	stop := strings.Index(content, end)
+	if start < 0 || stop <= start {
+		return 0, 0, false
+	}
+	stop += len(end)
+	if stop < len(content) && content[stop] == '\n' {
+		stop++
+	}
+	return start, stop, true
+}
+
+// getPromptCacheFile returns the file read by the shell prompt segment
+func getPromptCacheFile(installDir string) string {
+	return crewdirs.Path(installDir, "cache", "prompt")
+}
+
+// getLatestVersionFile returns where crew version --check records the
+// newest available version
+func getLatestVersionFile() string {
+	return crewdirs.Path(globalFlags.InstallDir, "cache", "latest-version")
+}
+
+// refreshPromptCache writes the installed framework version, plus the newer
+// version when the last update check found one, for the prompt segment
+func refreshPromptCache() error {
+	current, err := versioning.NewVersionManager(globalFlags.InstallDir).GetCurrentVersion()
+	if err != nil || current == "" {
+		return fmt.Errorf("no installed version found: %w", err)
+	}
+
+	segment := "v" + current
+	if data, err := os.ReadFile(getLatestVersionFile()); err == nil {
+		latest := strings.TrimSpace(string(data))
+		if latest != "" && latest != current {
+			segment += " -> " + latest
+		}
+	}
+
+	path := getPromptCacheFile(globalFlags.InstallDir)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(segment+"\n"), 0644)
+}
+
+// refreshPromptCacheOnChange keeps an existing prompt cache current after
+// installs and updates. Users who never enabled the prompt have no cache,
+// and none is created for them, nor in CI mode.
+func refreshPromptCacheOnChange(event events.Event) error {
+	if ciMode() {
+		return nil
+	}
+	if _, err := os.Stat(getPromptCacheFile(globalFlags.InstallDir)); err != nil {
+		return nil
+	}
+	if err := refreshPromptCache(); err != nil {
+		logger.GetLogger().Debugf("Failed to refresh prompt cache: %v", err)
+	}
+	return nil
+}
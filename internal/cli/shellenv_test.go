@@ -0,0 +1,57 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateShellenv(t *testing.T) {
+	tests := []struct {
+		shell    string
+		flags    ShellenvFlags
+		contains []string
+		excludes []string
+	}{
+		{"bash", ShellenvFlags{}, []string{"export PATH='/opt/crew/bin'", "source <('/opt/crew/bin/crew' completion bash)", "alias cst='crew status'"}, []string{"__crew_prompt"}},
+		{"zsh", ShellenvFlags{Prompt: true}, []string{"compinit", "setopt PROMPT_SUBST", "PROMPT='$(__crew_prompt)'"}, nil},
+		{"fish", ShellenvFlags{NoAliases: true, Prompt: true}, []string{"set -gx PATH '/opt/crew/bin' $PATH", "completion fish | source", "function fish_prompt"}, []string{"alias"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.shell, func(t *testing.T) {
+			out := generateShellenv(tt.shell, "/opt/crew/bin/crew", "/home/u/.claude", tt.flags)
+			for _, want := range tt.contains {
+				if !strings.Contains(out, want) {
+					t.Errorf("Expected output to contain %q:\n%s", want, out)
+				}
+			}
+			for _, unwanted := range tt.excludes {
+				if strings.Contains(out, unwanted) {
+					t.Errorf("Expected output not to contain %q:\n%s", unwanted, out)
+				}
+			}
+		})
+	}
+
+	if _, err := resolveShell("tcsh"); err == nil {
+		t.Error("Expected an error for an unsupported shell")
+	}
+}
+
+func TestReplaceShellenvBlock(t *testing.T) {
+	block := shellenvBeginMarker + "\neval \"$(crew shellenv)\"\n" + shellenvEndMarker + "\n"
+
+	content, changed := replaceShellenvBlock("export EDITOR=vim", block)
+	if !changed || !strings.HasPrefix(content, "export EDITOR=vim\n\n") || !strings.HasSuffix(content, block) {
+		t.Fatalf("Unexpected appended content: %q", content)
+	}
+
+	if _, changed := replaceShellenvBlock(content, block); changed {
+		t.Error("Expected installing the same block twice to be a no-op")
+	}
+
+	newBlock := shellenvBeginMarker + "\neval \"$(crew shellenv --prompt)\"\n" + shellenvEndMarker + "\n"
+	updated, changed := replaceShellenvBlock(content+"alias ll='ls -l'\n", newBlock)
+	if !changed || strings.Count(updated, shellenvBeginMarker) != 1 || !strings.Contains(updated, "--prompt") || !strings.HasSuffix(updated, "alias ll='ls -l'\n") {
+		t.Errorf("Unexpected replaced content: %q", updated)
+	}
+}
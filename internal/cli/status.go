@@ -10,6 +10,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/jonwraymond/claude-code-super-crew/internal/metadata"
+	"github.com/jonwraymond/claude-code-super-crew/internal/ui"
 )
 
 type StatusCommand struct {
@@ -38,6 +39,11 @@ func NewStatusCommand() *cobra.Command {
 	cmd.Flags().StringSliceVar(&sc.components, "components", []string{}, "Show status for specific components only")
 	cmd.Flags().StringVar(&sc.installDir, "install-dir", "", "Installation directory (default: ~/.claude)")
 
+	registerFlagCompletions(cmd, map[string]completionFunc{
+		"format":     completeStatic("table", "json", "yaml"),
+		"components": completeInstalledComponents,
+	})
+
 	return cmd
 }
 
@@ -53,7 +59,7 @@ func (sc *StatusCommand) Execute(cmd *cobra.Command, args []string) error {
 
 	// Check if installation exists
 	if _, err := os.Stat(sc.installDir); os.IsNotExist(err) {
-		fmt.Printf("❌ Claude Code Super Crew is not installed in %s\n", sc.installDir)
+		fmt.Printf("%s Claude Code Super Crew is not installed in %s\n", ui.Icons.Failure, sc.installDir)
 		fmt.Println("Run 'crew install' to install the framework")
 		return nil
 	}
@@ -77,43 +83,43 @@ func (sc *StatusCommand) Execute(cmd *cobra.Command, args []string) error {
 }
 
 func (sc *StatusCommand) displayTable(meta *metadata.UnifiedMetadata) error {
-	fmt.Printf("\n%s\n", colorize("🔍 Claude Code Super Crew Status", "blue", true))
+	fmt.Printf("\n%s\n", colorize(ui.Emoji("🔍 ")+"Claude Code Super Crew Status", "blue", true))
 	fmt.Printf("%s\n\n", strings.Repeat("=", 60))
 
 	// Framework Information
-	fmt.Printf("%s\n", colorize("📋 Framework Information", "cyan", true))
-	fmt.Printf("├─ Version: %s\n", colorize(meta.Framework.Version, "green", false))
-	fmt.Printf("├─ Release Date: %s\n", meta.Framework.ReleaseDate)
-	fmt.Printf("├─ Last Updated: %s\n", meta.Framework.UpdatedAt.Format("2006-01-02 15:04:05"))
+	fmt.Printf("%s\n", colorize(ui.Emoji("📋 ")+"Framework Information", "cyan", true))
+	fmt.Printf("%s Version: %s\n", ui.Icons.TreeBranch, colorize(meta.Framework.Version, "green", false))
+	fmt.Printf("%s Release Date: %s\n", ui.Icons.TreeBranch, meta.Framework.ReleaseDate)
+	fmt.Printf("%s Last Updated: %s\n", ui.Icons.TreeBranch, meta.Framework.UpdatedAt.Format("2006-01-02 15:04:05"))
 	if meta.Framework.PreviousVersion != "" {
-		fmt.Printf("└─ Previous Version: %s\n", meta.Framework.PreviousVersion)
+		fmt.Printf("%s Previous Version: %s\n", ui.Icons.TreeLast, meta.Framework.PreviousVersion)
 	}
 	fmt.Println()
 
 	// Installation Information
-	fmt.Printf("%s\n", colorize("🏗️  Installation Information", "cyan", true))
-	fmt.Printf("├─ Install Directory: %s\n", meta.Installation.InstallDir)
-	fmt.Printf("├─ Installed At: %s\n", meta.Installation.InstalledAt.Format("2006-01-02 15:04:05"))
-	fmt.Printf("├─ Installer Version: %s\n", meta.Installation.InstallerVersion)
-	fmt.Printf("├─ Total Size: %s\n", formatBytes(meta.Installation.TotalSize))
-	fmt.Printf("└─ Total Files: %d\n", meta.Installation.TotalFiles)
+	fmt.Printf("%s\n", colorize(ui.Emoji("🏗️  ")+"Installation Information", "cyan", true))
+	fmt.Printf("%s Install Directory: %s\n", ui.Icons.TreeBranch, meta.Installation.InstallDir)
+	fmt.Printf("%s Installed At: %s\n", ui.Icons.TreeBranch, meta.Installation.InstalledAt.Format("2006-01-02 15:04:05"))
+	fmt.Printf("%s Installer Version: %s\n", ui.Icons.TreeBranch, meta.Installation.InstallerVersion)
+	fmt.Printf("%s Total Size: %s\n", ui.Icons.TreeBranch, formatBytes(meta.Installation.TotalSize))
+	fmt.Printf("%s Total Files: %d\n", ui.Icons.TreeLast, meta.Installation.TotalFiles)
 	fmt.Println()
 
 	// Components Status
-	fmt.Printf("%s\n", colorize("🧩 Components Status", "cyan", true))
+	fmt.Printf("%s\n", colorize(ui.Emoji("🧩 ")+"Components Status", "cyan", true))
 	sc.displayComponentsTable(meta.Components)
 	fmt.Println()
 
 	// Documents Status
 	if sc.verbose {
-		fmt.Printf("%s\n", colorize("📄 Documents Status", "cyan", true))
+		fmt.Printf("%s\n", colorize(ui.Emoji("📄 ")+"Documents Status", "cyan", true))
 		sc.displayDocumentsTable(meta.Documents)
 		fmt.Println()
 	}
 
 	// Features Status
 	if len(meta.Features) > 0 {
-		fmt.Printf("%s\n", colorize("🎛️  Feature Flags", "cyan", true))
+		fmt.Printf("%s\n", colorize(ui.Emoji("🎛️  ")+"Feature Flags", "cyan", true))
 		sc.displayFeaturesTable(meta.Features)
 		fmt.Println()
 	}
@@ -123,7 +129,7 @@ func (sc *StatusCommand) displayTable(meta *metadata.UnifiedMetadata) error {
 
 func (sc *StatusCommand) displayComponentsTable(components map[string]metadata.ComponentMeta) {
 	if len(components) == 0 {
-		fmt.Println("└─ No components found")
+		fmt.Println(ui.Icons.TreeLast + " No components found")
 		return
 	}
 
@@ -139,16 +145,16 @@ func (sc *StatusCommand) displayComponentsTable(components map[string]metadata.C
 	for i, name := range names {
 		comp := components[name]
 		isLast := i == len(names)-1
-		prefix := "├─"
+		prefix := ui.Icons.TreeBranch
 		if isLast {
-			prefix = "└─"
+			prefix = ui.Icons.TreeLast
 		}
 
 		status := sc.getStatusIcon(comp.Status)
 		fmt.Printf("%s %s %s (v%s) - %s\n", prefix, status, name, comp.Version, comp.Status)
 
 		if sc.verbose {
-			subPrefix := "│  "
+			subPrefix := ui.Icons.TreePipe + "  "
 			if isLast {
 				subPrefix = "   "
 			}
@@ -167,7 +173,7 @@ func (sc *StatusCommand) displayComponentsTable(components map[string]metadata.C
 
 func (sc *StatusCommand) displayDocumentsTable(documents map[string]metadata.DocumentMeta) {
 	if len(documents) == 0 {
-		fmt.Println("└─ No documents found")
+		fmt.Println(ui.Icons.TreeLast + " No documents found")
 		return
 	}
 
@@ -186,9 +192,9 @@ func (sc *StatusCommand) displayDocumentsTable(documents map[string]metadata.Doc
 
 	for i, comp := range components {
 		isLastComp := i == len(components)-1
-		prefix := "├─"
+		prefix := ui.Icons.TreeBranch
 		if isLastComp {
-			prefix = "└─"
+			prefix = ui.Icons.TreeLast
 		}
 
 		fmt.Printf("%s %s:\n", prefix, colorize(comp, "yellow", false))
@@ -204,13 +210,13 @@ func (sc *StatusCommand) displayDocumentsTable(documents map[string]metadata.Doc
 			if isLastComp {
 				subPrefix = "   "
 			} else {
-				subPrefix = "│  "
+				subPrefix = ui.Icons.TreePipe + "  "
 			}
 			
 			if isLastDoc {
-				subPrefix += "└─"
+				subPrefix += ui.Icons.TreeLast
 			} else {
-				subPrefix += "├─"
+				subPrefix += ui.Icons.TreeBranch
 			}
 
 			status := sc.getStatusIcon(doc.Status)
@@ -230,22 +236,22 @@ func (sc *StatusCommand) displayFeaturesTable(features map[string]metadata.Featu
 	for i, name := range names {
 		feature := features[name]
 		isLast := i == len(names)-1
-		prefix := "├─"
+		prefix := ui.Icons.TreeBranch
 		if isLast {
-			prefix = "└─"
+			prefix = ui.Icons.TreeLast
 		}
 
-		enabledIcon := "❌"
+		enabledIcon := ui.Icons.Failure
 		enabledText := "disabled"
 		if feature.Enabled {
-			enabledIcon = "✅"
+			enabledIcon = ui.Icons.Success
 			enabledText = "enabled"
 		}
 
 		fmt.Printf("%s %s %s (%s)\n", prefix, enabledIcon, name, enabledText)
 
 		if sc.verbose && feature.Description != "" {
-			subPrefix := "│  "
+			subPrefix := ui.Icons.TreePipe + "  "
 			if isLast {
 				subPrefix = "   "
 			}
@@ -272,15 +278,15 @@ func (sc *StatusCommand) displayYAML(meta *metadata.UnifiedMetadata) error {
 func (sc *StatusCommand) getStatusIcon(status string) string {
 	switch status {
 	case "installed", "present":
-		return "✅"
+		return ui.Icons.Success
 	case "missing":
-		return "❌"
+		return ui.Icons.Failure
 	case "corrupted":
-		return "⚠️ "
+		return ui.Icons.Warning
 	case "outdated":
-		return "🔄"
+		return ui.Icons.Refresh
 	default:
-		return "❓"
+		return ui.Icons.Unknown
 	}
 }
 
@@ -310,7 +316,7 @@ func colorize(text, color string, bold bool) string {
 	}
 
 	code, exists := colors[color]
-	if !exists {
+	if !exists || !ui.CurrentStyle().Color {
 		return text
 	}
 
@@ -0,0 +1,400 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/crewdirs"
+	"github.com/jonwraymond/claude-code-super-crew/internal/managers"
+	"github.com/jonwraymond/claude-code-super-crew/internal/telemetry"
+	"github.com/jonwraymond/claude-code-super-crew/internal/ui"
+	"github.com/jonwraymond/claude-code-super-crew/pkg/logger"
+	"github.com/spf13/cobra"
+)
+
+// Config keys for the telemetry setting in .crew/config
+const (
+	telemetryEnabledKey  = "settings.telemetry"
+	telemetryEndpointKey = "settings.telemetry_endpoint"
+)
+
+// telemetryUploadTimeout bounds the automatic upload at the end of a command
+const telemetryUploadTimeout = 3 * time.Second
+
+// NewTelemetryCommand creates the telemetry command
+func NewTelemetryCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "telemetry",
+		Short: "Manage opt-in anonymous usage telemetry",
+		Long: `Telemetry is off unless you enable it. When enabled, crew records the
+command name, duration, success and a coarse error category for each run
+in .crew/telemetry/events.jsonl. Arguments, paths and error messages are
+never recorded. Events stay local unless an upload endpoint is configured.
+
+DO_NOT_TRACK=1 or CREW_TELEMETRY=0 disables telemetry regardless of the
+setting.
+
+Examples:
+  crew telemetry status
+  crew telemetry enable
+  crew telemetry enable --endpoint https://telemetry.example.com/v1/events
+  crew telemetry export --format csv --file usage.csv
+  crew telemetry disable --purge`,
+	}
+
+	cmd.AddCommand(newTelemetryStatusCommand())
+	cmd.AddCommand(newTelemetryEnableCommand())
+	cmd.AddCommand(newTelemetryDisableCommand())
+	cmd.AddCommand(newTelemetryExportCommand())
+	cmd.AddCommand(newTelemetryUploadCommand())
+
+	return cmd
+}
+
+func newTelemetryStatusCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Show whether telemetry is enabled and what has been recorded",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTelemetryStatus(cmd.OutOrStdout())
+		},
+	}
+}
+
+func newTelemetryEnableCommand() *cobra.Command {
+	var endpoint string
+	cmd := &cobra.Command{
+		Use:         "enable",
+		Short:       "Opt in to recording anonymous usage data",
+		Annotations: map[string]string{auditAnnotation: auditAlways},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			settings := map[string]interface{}{telemetryEnabledKey: true}
+			if cmd.Flags().Changed("endpoint") {
+				if endpoint != "" {
+					if err := validateTelemetryEndpoint(endpoint); err != nil {
+						return err
+					}
+				}
+				settings[telemetryEndpointKey] = endpoint
+			}
+			if err := saveTelemetrySettings(settings); err != nil {
+				return err
+			}
+			ui.DisplaySuccess("Telemetry enabled")
+			if reason := telemetryEnvOverride(); reason != "" {
+				ui.DisplayWarning(fmt.Sprintf("%s is set, so nothing will be recorded", reason))
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&endpoint, "endpoint", "", "Upload recorded events in batches to this http(s) URL (empty to keep them local)")
+	return cmd
+}
+
+func newTelemetryDisableCommand() *cobra.Command {
+	var purge bool
+	cmd := &cobra.Command{
+		Use:         "disable",
+		Short:       "Stop recording usage data",
+		Annotations: map[string]string{auditAnnotation: auditAlways},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := saveTelemetrySettings(map[string]interface{}{telemetryEnabledKey: false}); err != nil {
+				return err
+			}
+			if purge {
+				if err := getTelemetryStore().Purge(); err != nil {
+					return err
+				}
+			}
+			ui.DisplaySuccess("Telemetry disabled")
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&purge, "purge", false, "Also delete recorded events and the anonymous install ID")
+	return cmd
+}
+
+func newTelemetryExportCommand() *cobra.Command {
+	var format, file string
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export recorded events as JSON lines or CSV",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			events, err := getTelemetryStore().Events()
+			if err != nil {
+				return err
+			}
+			if file == "" {
+				return telemetry.Export(cmd.OutOrStdout(), events, format)
+			}
+
+			out, err := os.Create(file)
+			if err != nil {
+				return fmt.Errorf("failed to create export file: %w", err)
+			}
+			defer out.Close()
+			if err := telemetry.Export(out, events, format); err != nil {
+				return err
+			}
+			logger.GetLogger().Infof("Exported %d telemetry events to %s", len(events), file)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&format, "format", "json", "Export format: json or csv")
+	cmd.Flags().StringVar(&file, "file", "", "Write to this file instead of stdout")
+	registerFlagCompletions(cmd, map[string]completionFunc{
+		"format": completeStatic("json", "csv"),
+	})
+	return cmd
+}
+
+func newTelemetryUploadCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "upload",
+		Short: "Upload pending events to the configured endpoint now",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_, endpoint := telemetrySettings()
+			if endpoint == "" {
+				return fmt.Errorf("no telemetry endpoint configured; set one with crew telemetry enable --endpoint URL")
+			}
+			sent, err := getTelemetryStore().Upload(cmd.Context(), endpoint, nil)
+			if err != nil {
+				return err
+			}
+			logger.GetLogger().Infof("Uploaded %d telemetry events", sent)
+			return nil
+		},
+	}
+}
+
+// getTelemetryStore returns the store for the current installation
+func getTelemetryStore() *telemetry.Store {
+	return telemetry.NewStore(crewdirs.Path(globalFlags.InstallDir, "telemetry"))
+}
+
+// telemetryEnvOverride names the environment variable or CI mode setting
+// that turns telemetry off, if one is set
+func telemetryEnvOverride() string {
+	if reason := ciReason(); reason != "" {
+		return reason
+	}
+	if v := os.Getenv("DO_NOT_TRACK"); v != "" && v != "0" && v != "false" {
+		return "DO_NOT_TRACK"
+	}
+	if v := os.Getenv("CREW_TELEMETRY"); v == "0" || v == "false" || v == "off" {
+		return "CREW_TELEMETRY"
+	}
+	return ""
+}
+
+// telemetrySettings reports whether telemetry is enabled and the upload
+// endpoint. It is never enabled without an installation or when an
+// environment override is set.
+func telemetrySettings() (bool, string) {
+	if _, err := os.Stat(crewdirs.Path(globalFlags.InstallDir)); err != nil {
+		return false, ""
+	}
+	cm, err := managers.NewConfigManager(getCrewConfigDir(), "")
+	if err != nil {
+		return false, ""
+	}
+	endpoint, _ := cm.GetString(telemetryEndpointKey)
+	if telemetryEnvOverride() != "" {
+		return false, endpoint
+	}
+	enabled, _ := cm.GetBool(telemetryEnabledKey)
+	return enabled, endpoint
+}
+
+// saveTelemetrySettings writes settings to the base crew config, never to a
+// profile
+func saveTelemetrySettings(settings map[string]interface{}) error {
+	if _, err := os.Stat(crewdirs.Path(globalFlags.InstallDir)); err != nil {
+		return fmt.Errorf("Claude Code Super Crew is not installed in %s", globalFlags.InstallDir)
+	}
+	cm, err := managers.NewConfigManager(getCrewConfigDir(), "")
+	if err != nil {
+		return fmt.Errorf("failed to load crew config: %w", err)
+	}
+	for key, value := range settings {
+		if err := cm.Set(key, value); err != nil {
+			return err
+		}
+	}
+	if err := cm.Save(); err != nil {
+		return fmt.Errorf("failed to save crew config: %w", err)
+	}
+	return nil
+}
+
+// validateTelemetryEndpoint requires an absolute http or https URL
+func validateTelemetryEndpoint(endpoint string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid telemetry endpoint %q: must be an http or https URL", endpoint)
+	}
+	return nil
+}
+
+// telemetryStatus is the --output json form of crew telemetry status
+type telemetryStatus struct {
+	Enabled    bool      `json:"enabled"`
+	Override   string    `json:"disabled_by,omitempty"`
+	Endpoint   string    `json:"endpoint,omitempty"`
+	InstallID  string    `json:"install_id,omitempty"`
+	Recorded   int       `json:"recorded"`
+	Pending    int       `json:"pending"`
+	LastUpload time.Time `json:"last_upload,omitempty"`
+	File       string    `json:"file"`
+}
+
+func runTelemetryStatus(w io.Writer) error {
+	store := getTelemetryStore()
+	enabled, endpoint := telemetrySettings()
+	status := telemetryStatus{
+		Enabled:  enabled,
+		Override: telemetryEnvOverride(),
+		Endpoint: endpoint,
+		File:     store.EventsPath(),
+	}
+
+	events, err := store.Events()
+	if err != nil {
+		return err
+	}
+	status.Recorded = len(events)
+	if len(events) > 0 {
+		pending, err := store.Pending()
+		if err != nil {
+			return err
+		}
+		status.Pending = len(pending)
+		status.InstallID, _ = store.InstallID()
+		status.LastUpload, _ = store.LastUpload()
+	}
+
+	if globalFlags.Output == "json" {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(status)
+	}
+
+	state := fmt.Sprintf("%sdisabled%s", ui.Colors.Yellow, ui.Colors.Reset)
+	if enabled {
+		state = fmt.Sprintf("%senabled%s", ui.Colors.Green, ui.Colors.Reset)
+	}
+	fmt.Fprintf(w, "%-12s %s\n", "Telemetry:", state)
+	if status.Override != "" {
+		fmt.Fprintf(w, "%-12s %s\n", "Disabled by:", status.Override)
+	}
+	if endpoint != "" {
+		fmt.Fprintf(w, "%-12s %s\n", "Endpoint:", endpoint)
+	} else {
+		fmt.Fprintf(w, "%-12s %s\n", "Endpoint:", "none (local only)")
+	}
+	fmt.Fprintf(w, "%-12s %d (%d pending upload)\n", "Recorded:", status.Recorded, status.Pending)
+	if !status.LastUpload.IsZero() {
+		fmt.Fprintf(w, "%-12s %s\n", "Last upload:", status.LastUpload.Local().Format("2006-01-02 15:04:05"))
+	}
+	fmt.Fprintf(w, "%-12s %s\n", "File:", status.File)
+	return nil
+}
+
+// instrumentCommands wraps the run function of cmd and its subcommands so
+// each invocation is recorded when telemetry is enabled
+func instrumentCommands(cmd *cobra.Command, version string) {
+	for _, sub := range cmd.Commands() {
+		instrumentCommands(sub, version)
+	}
+
+	switch {
+	case cmd.RunE != nil:
+		run := cmd.RunE
+		cmd.RunE = func(c *cobra.Command, args []string) error {
+			start := time.Now()
+			err := run(c, args)
+			recordTelemetry(c, version, start, err)
+			return err
+		}
+	case cmd.Run != nil:
+		run := cmd.Run
+		cmd.Run = func(c *cobra.Command, args []string) {
+			start := time.Now()
+			run(c, args)
+			recordTelemetry(c, version, start, nil)
+		}
+	}
+}
+
+// recordTelemetry stores one event and, once a full batch is pending,
+// uploads it. Failures are only logged at debug level.
+func recordTelemetry(cmd *cobra.Command, version string, start time.Time, runErr error) {
+	if testMode {
+		return
+	}
+	enabled, endpoint := telemetrySettings()
+	if !enabled {
+		return
+	}
+
+	log := logger.GetLogger()
+	store := getTelemetryStore()
+	event := telemetry.Event{
+		Time:          start.UTC(),
+		Command:       cmd.CommandPath(),
+		DurationMs:    time.Since(start).Milliseconds(),
+		Success:       runErr == nil,
+		ErrorCategory: telemetryErrorCategory(runErr),
+		Version:       version,
+		OS:            runtime.GOOS,
+		Arch:          runtime.GOARCH,
+	}
+	if err := store.Record(event); err != nil {
+		log.Debugf("Failed to record telemetry: %v", err)
+		return
+	}
+
+	if endpoint == "" {
+		return
+	}
+	if pending, err := store.Pending(); err != nil || len(pending) < telemetry.BatchSize {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), telemetryUploadTimeout)
+	defer cancel()
+	if _, err := store.Upload(ctx, endpoint, nil); err != nil {
+		log.Debugf("Telemetry upload deferred: %v", err)
+	}
+}
+
+// telemetryErrorCategory reduces err to a category that carries no user data
+func telemetryErrorCategory(err error) string {
+	var netErr net.Error
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, context.Canceled):
+		return "cancelled"
+	case errors.Is(err, ui.ErrInputRequired):
+		return "input_required"
+	case errors.Is(err, os.ErrPermission):
+		return "permission"
+	case errors.Is(err, os.ErrNotExist):
+		return "not_found"
+	case errors.As(err, &netErr):
+		return "network"
+	case strings.HasPrefix(err.Error(), "invalid"), strings.HasPrefix(err.Error(), "conflicting flags"):
+		return "usage"
+	default:
+		return "other"
+	}
+}
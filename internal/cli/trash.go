@@ -0,0 +1,253 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/managers"
+	"github.com/jonwraymond/claude-code-super-crew/internal/trash"
+	"github.com/jonwraymond/claude-code-super-crew/internal/ui"
+	"github.com/jonwraymond/claude-code-super-crew/pkg/logger"
+	"github.com/spf13/cobra"
+)
+
+// Trash settings in the crew config: settings.trash is "crew" (the
+// default) or "off", and settings.trash_ttl_days is how long removed files
+// are kept
+const (
+	trashModeKey = "settings.trash"
+	trashTTLKey  = "settings.trash_ttl_days"
+)
+
+// TrashFlags holds trash command flags
+type TrashFlags struct {
+	OlderThan string
+	Overwrite bool
+	JSON      bool
+}
+
+var trashFlags TrashFlags
+
+// NewTrashCommand creates the trash command
+func NewTrashCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "trash",
+		Short: "List, restore and empty files removed by crew",
+		Long: `crew uninstall moves the files it removes to .crew/trash instead of
+deleting them, one entry per run, so an accidental removal can be undone.
+Entries expire after 30 days; change that with settings.trash_ttl_days in
+the crew config, or set settings.trash to off to delete files right away.
+
+Examples:
+  crew trash list
+  crew trash restore 20261016-101500-123
+  crew trash empty --older-than 7d`,
+	}
+
+	listCmd := &cobra.Command{
+		Use:          "list",
+		Short:        "Show the entries in the trash",
+		Args:         cobra.NoArgs,
+		RunE:         runTrashList,
+		SilenceUsage: true,
+	}
+	listCmd.Flags().BoolVar(&trashFlags.JSON, "json", false, "Output as JSON")
+
+	restoreCmd := &cobra.Command{
+		Use:   "restore <id>",
+		Short: "Put the files of a trash entry back where they were",
+		Long: `Move the files of a trash entry back to where they were removed from.
+Files that exist again are left in the trash unless --overwrite is given.`,
+		Args:              cobra.ExactArgs(1),
+		Annotations:       map[string]string{auditAnnotation: auditAlways},
+		RunE:              runTrashRestore,
+		ValidArgsFunction: completeTrashEntries,
+		SilenceUsage:      true,
+	}
+	restoreCmd.Flags().BoolVar(&trashFlags.Overwrite, "overwrite", false,
+		"Replace files that exist again at their original location")
+	restoreCmd.Flags().BoolVar(&trashFlags.JSON, "json", false, "Output as JSON")
+
+	emptyCmd := &cobra.Command{
+		Use:   "empty",
+		Short: "Permanently delete trash entries",
+		Long: `Permanently delete all trash entries, or with --older-than only those
+removed before the given age, e.g. 12h or 7d.`,
+		Args:         cobra.NoArgs,
+		Annotations:  map[string]string{auditAnnotation: auditAlways},
+		RunE:         runTrashEmpty,
+		SilenceUsage: true,
+	}
+	emptyCmd.Flags().StringVar(&trashFlags.OlderThan, "older-than", "",
+		"Only delete entries removed before this age (e.g. 12h, 7d)")
+	emptyCmd.Flags().BoolVar(&trashFlags.JSON, "json", false, "Output as JSON")
+
+	cmd.AddCommand(listCmd)
+	cmd.AddCommand(restoreCmd)
+	cmd.AddCommand(emptyCmd)
+
+	return cmd
+}
+
+// openTrash returns the trash of the installation with the configured TTL,
+// or nil when settings.trash is off
+func openTrash() *trash.Trash {
+	t := trash.New(globalFlags.InstallDir)
+	cm, err := managers.NewConfigManager(getCrewConfigDir(), "")
+	if err != nil {
+		return t
+	}
+	if mode, err := cm.GetString(trashModeKey); err == nil && strings.EqualFold(mode, "off") {
+		return nil
+	}
+	if days, err := cm.GetInt(trashTTLKey); err == nil {
+		t.TTL = time.Duration(days) * 24 * time.Hour
+	}
+	return t
+}
+
+func runTrashList(cmd *cobra.Command, args []string) error {
+	t := trash.New(globalFlags.InstallDir)
+	entries, err := t.List()
+	if err != nil {
+		return fmt.Errorf("failed to read trash: %w", err)
+	}
+
+	if trashFlags.JSON || globalFlags.Output == "json" {
+		if entries == nil {
+			entries = []trash.Entry{}
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(entries)
+	}
+
+	fmt.Printf("\n%sTrash:%s %s\n", ui.ColorCyan, ui.ColorReset, t.Dir)
+	if len(entries) == 0 {
+		fmt.Println("  Empty")
+		return nil
+	}
+	fmt.Println()
+	fmt.Printf("  %-20s %-10s %6s %10s  %s\n", "ID", "OPERATION", "PATHS", "SIZE", "REMOVED")
+	for _, e := range entries {
+		fmt.Printf("  %-20s %-10s %6d %10s  %s\n", e.ID, e.Operation, len(e.Paths), formatBytes(e.Bytes), e.DeletedAt.Format("2006-01-02 15:04"))
+		if globalFlags.Verbose {
+			for _, path := range e.Paths {
+				fmt.Printf("    %s %s\n", ui.Icons.Bullet, path)
+			}
+		}
+	}
+	return nil
+}
+
+func runTrashRestore(cmd *cobra.Command, args []string) error {
+	log := logger.GetLogger()
+	t := trash.New(globalFlags.InstallDir)
+
+	if globalFlags.DryRun {
+		entries, err := t.List()
+		if err != nil {
+			return err
+		}
+		for _, e := range entries {
+			if e.ID == args[0] {
+				log.Infof("[DRY RUN] Would restore %d paths into %s", len(e.Paths), t.Root)
+				return nil
+			}
+		}
+		return fmt.Errorf("no trash entry %s; see crew trash list", args[0])
+	}
+
+	restored, err := t.Restore(args[0], trashFlags.Overwrite)
+	if err != nil {
+		return err
+	}
+
+	if trashFlags.JSON || globalFlags.Output == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(restored)
+	}
+
+	for _, path := range restored.Paths {
+		fmt.Printf("  %s%s%s %s\n", ui.ColorGreen, ui.Icons.Check, ui.ColorReset, path)
+	}
+	for _, path := range restored.Conflicts {
+		fmt.Printf("  %s%s%s %s (exists, kept in trash)\n", ui.ColorYellow, ui.Icons.Warning, ui.ColorReset, path)
+	}
+	log.Infof("Restored %d paths", len(restored.Paths))
+	if len(restored.Conflicts) > 0 {
+		log.Warnf("%d paths exist again and were left in the trash; use --overwrite to replace them", len(restored.Conflicts))
+	}
+	return nil
+}
+
+func runTrashEmpty(cmd *cobra.Command, args []string) error {
+	log := logger.GetLogger()
+
+	var cutoff time.Time
+	if trashFlags.OlderThan != "" {
+		var err error
+		if cutoff, err = parseSince(trashFlags.OlderThan, time.Now()); err != nil {
+			return fmt.Errorf("invalid --older-than %q: use a duration like 12h or 7d", trashFlags.OlderThan)
+		}
+	}
+
+	t := trash.New(globalFlags.InstallDir)
+	var purged []trash.Entry
+	if globalFlags.DryRun {
+		entries, err := t.List()
+		if err != nil {
+			return err
+		}
+		for _, e := range entries {
+			if cutoff.IsZero() || e.DeletedAt.Before(cutoff) {
+				purged = append(purged, e)
+			}
+		}
+	} else {
+		var err error
+		if purged, err = t.Empty(cutoff); err != nil {
+			return fmt.Errorf("failed to empty trash: %w", err)
+		}
+	}
+
+	if trashFlags.JSON || globalFlags.Output == "json" {
+		if purged == nil {
+			purged = []trash.Entry{}
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(purged)
+	}
+
+	verb := "Deleted"
+	if globalFlags.DryRun {
+		verb = "[DRY RUN] Would delete"
+	}
+	var bytes int64
+	for _, e := range purged {
+		bytes += e.Bytes
+		if globalFlags.Verbose {
+			fmt.Printf("  %s %s (%s)\n", ui.Icons.Bullet, e.ID, e.Operation)
+		}
+	}
+	log.Infof("%s %d trash entries (%s)", verb, len(purged), formatBytes(bytes))
+	return nil
+}
+
+// completeTrashEntries completes the IDs of the entries in the trash
+func completeTrashEntries(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	entries, _ := trash.New(globalFlags.InstallDir).List()
+	var ids []string
+	for _, e := range entries {
+		ids = append(ids, fmt.Sprintf("%s\t%s, %d paths", e.ID, e.Operation, len(e.Paths)))
+	}
+	return ids, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,203 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/audit"
+	"github.com/jonwraymond/claude-code-super-crew/internal/trash"
+	"github.com/jonwraymond/claude-code-super-crew/internal/ui"
+	"github.com/jonwraymond/claude-code-super-crew/pkg/backup"
+	"github.com/jonwraymond/claude-code-super-crew/pkg/logger"
+	"github.com/spf13/cobra"
+)
+
+// NewUndoCommand creates the undo command
+func NewUndoCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "undo",
+		Short: "Revert the most recent operation that changed the installation",
+		Long: `Revert the most recent operation in the audit log (see crew audit).
+
+An install or update is reverted by restoring the backup it took before
+changing anything, so files it changed get their earlier content back,
+and by moving the files it added to the trash. An uninstall is reverted by restoring what it
+moved to the trash. Operations without a backup or trash entry, and dry
+runs, cannot be undone.
+
+Undo shows what it will do and asks before changing anything. Running it
+again reverts the operation before that one.
+
+Examples:
+  crew undo --dry-run   # Only show what would be reverted
+  crew undo --yes`,
+		Args:         cobra.NoArgs,
+		Annotations:  map[string]string{auditAnnotation: auditAlways},
+		RunE:         interruptible(runUndo),
+		SilenceUsage: true,
+	}
+	return cmd
+}
+
+func runUndo(cmd *cobra.Command, args []string) error {
+	log := logger.GetLogger()
+
+	entries, err := (&audit.Log{Path: audit.Path(globalFlags.InstallDir)}).Read(time.Time{})
+	if err != nil {
+		return fmt.Errorf("failed to read audit log: %w", err)
+	}
+	last := audit.Latest(entries)
+	if last == nil {
+		return fmt.Errorf("nothing to undo: no operations are recorded in %s", audit.Path(globalFlags.InstallDir))
+	}
+
+	what := describeAuditEntry(*last)
+	if last.Undo == nil {
+		return fmt.Errorf("the last operation, %s, cannot be undone: it kept no backup or trash entry", what)
+	}
+
+	plan, err := previewUndo(*last.Undo)
+	if err != nil {
+		return fmt.Errorf("cannot undo %s: %w", what, err)
+	}
+	fmt.Printf("\n%sLast operation:%s %s\n", ui.ColorCyan, ui.ColorReset, what)
+	fmt.Printf("%sUndo will:%s\n", ui.ColorCyan, ui.ColorReset)
+	for _, step := range plan {
+		fmt.Printf("  %s %s\n", ui.Icons.Arrow, step)
+	}
+	fmt.Println()
+
+	if globalFlags.DryRun {
+		log.Info("[DRY RUN] Nothing was changed")
+		return nil
+	}
+	if !globalFlags.Yes {
+		confirmed, err := confirmAction("Undo this operation?", false)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			log.Info("Undo cancelled")
+			return nil
+		}
+	}
+
+	pendingAudit.reverts = last.ID
+	if err := applyUndo(cmd, *last.Undo); err != nil {
+		return fmt.Errorf("failed to undo %s: %w", what, err)
+	}
+	ui.DisplaySuccess(fmt.Sprintf("Reverted %s", what))
+	return nil
+}
+
+// describeAuditEntry names an operation as the user typed it, with when and
+// by whom it ran
+func describeAuditEntry(entry audit.Entry) string {
+	parts := append([]string{entry.Command}, entry.Args...)
+	if flags := formatAuditFlags(entry.Flags); flags != "" {
+		parts = append(parts, flags)
+	}
+	return fmt.Sprintf("'%s' (%s by %s)", strings.Join(parts, " "),
+		entry.Time.Local().Format("2006-01-02 15:04"), entry.User)
+}
+
+// previewUndo describes each step of undoing an operation and fails when
+// what it needs is gone
+func previewUndo(undo audit.Undo) ([]string, error) {
+	switch undo.Kind {
+	case audit.UndoBackup:
+		info := backup.NewManager(backup.Options{InstallDir: globalFlags.InstallDir}).GetBackupInfo(undo.Ref)
+		if !info.Exists {
+			return nil, fmt.Errorf("its backup %s no longer exists", undo.Ref)
+		}
+		if info.FileCount == 0 && len(undo.Added) == 0 {
+			return nil, fmt.Errorf("it started from an empty installation; use crew uninstall to remove it")
+		}
+		var steps []string
+		if info.FileCount > 0 {
+			steps = append(steps,
+				fmt.Sprintf("restore %d files from %s, taken %s", info.FileCount,
+					displayPath(undo.Ref), info.Created.Local().Format("2006-01-02 15:04")),
+				"overwrite the current versions of those files")
+		}
+		if len(undo.Added) > 0 {
+			steps = append(steps, fmt.Sprintf("move %d files it added to the trash", len(undo.Added)))
+			for _, path := range undo.Added {
+				steps = append(steps, "  "+path)
+			}
+		}
+		return steps, nil
+
+	case audit.UndoTrash:
+		entries, err := trash.New(globalFlags.InstallDir).List()
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range entries {
+			if e.ID != undo.Ref {
+				continue
+			}
+			steps := []string{fmt.Sprintf("move %d paths from trash entry %s back into %s",
+				len(e.Paths), e.ID, displayPath(globalFlags.InstallDir))}
+			for _, path := range e.Paths {
+				steps = append(steps, "  "+path)
+			}
+			return append(steps, "overwrite any of those paths that exist again"), nil
+		}
+		return nil, fmt.Errorf("trash entry %s was emptied or has expired", undo.Ref)
+	}
+	return nil, fmt.Errorf("unknown undo kind %q", undo.Kind)
+}
+
+// applyUndo carries out the undo previewed by previewUndo
+func applyUndo(cmd *cobra.Command, undo audit.Undo) error {
+	switch undo.Kind {
+	case audit.UndoBackup:
+		mgr := backup.NewManager(backup.Options{
+			InstallDir: globalFlags.InstallDir,
+			BackupDir:  filepath.Dir(undo.Ref),
+			Verbose:    globalFlags.Verbose,
+			Overwrite:  true,
+		})
+		if mgr.GetBackupInfo(undo.Ref).FileCount > 0 {
+			if err := mgr.Restore(commandContext(cmd), undo.Ref); err != nil {
+				return err
+			}
+		}
+		return trashAdded(undo.Added)
+
+	case audit.UndoTrash:
+		restored, err := trash.New(globalFlags.InstallDir).Restore(undo.Ref, true)
+		if err != nil {
+			return err
+		}
+		logger.GetLogger().Infof("Restored %d paths from the trash", len(restored.Paths))
+		return nil
+	}
+	return fmt.Errorf("unknown undo kind %q", undo.Kind)
+}
+
+// trashAdded moves the files an operation added, relative to the
+// installation, to the trash. Files that are gone already are skipped.
+func trashAdded(added []string) error {
+	if len(added) == 0 {
+		return nil
+	}
+	batch := trash.New(globalFlags.InstallDir).Begin("undo")
+	for _, rel := range added {
+		path := filepath.Join(globalFlags.InstallDir, filepath.FromSlash(rel))
+		if _, err := os.Lstat(path); os.IsNotExist(err) {
+			continue
+		}
+		if err := batch.Remove(path); err != nil {
+			return err
+		}
+	}
+	if batch.Len() > 0 {
+		logger.GetLogger().Infof("Moved %d added files to trash entry %s", batch.Len(), batch.ID())
+	}
+	return nil
+}
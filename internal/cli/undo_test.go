@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/audit"
+	"github.com/jonwraymond/claude-code-super-crew/internal/core"
+	"github.com/jonwraymond/claude-code-super-crew/internal/trash"
+)
+
+func TestUndoRemovesAddedFiles(t *testing.T) {
+	saved := globalFlags
+	defer func() { globalFlags = saved }()
+	defer func() { pendingAudit.undo = nil }()
+
+	globalFlags.InstallDir = t.TempDir()
+	existing := filepath.Join(globalFlags.InstallDir, "CLAUDE.md")
+	added := filepath.Join(globalFlags.InstallDir, "commands", "crew", "new.md")
+	if err := os.WriteFile(existing, []byte("mine"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	missing := missingTargets([]core.FilePair{{Target: existing}, {Target: added}})
+	if err := os.MkdirAll(filepath.Dir(added), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(added, []byte("new"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	noteUndo(audit.UndoBackup, "backup.tar.gz")
+	noteAdded(missing)
+
+	want := []string{"commands/crew/new.md"}
+	if got := pendingAudit.undo.Added; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Expected added %v, got %v", want, got)
+	}
+
+	if err := trashAdded(pendingAudit.undo.Added); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(added); !os.IsNotExist(err) {
+		t.Error("Expected the added file to be removed")
+	}
+	if _, err := os.Stat(existing); err != nil {
+		t.Error("Expected the file that existed before to stay")
+	}
+	entries, err := trash.New(globalFlags.InstallDir).List()
+	if err != nil || len(entries) != 1 || !reflect.DeepEqual(entries[0].Paths, want) {
+		t.Errorf("Expected the added file in the trash, got %+v, %v", entries, err)
+	}
+}
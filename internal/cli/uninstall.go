@@ -1,11 +1,17 @@
 package cli
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 
+	"github.com/jonwraymond/claude-code-super-crew/internal/audit"
+	"github.com/jonwraymond/claude-code-super-crew/internal/claudesettings"
+	"github.com/jonwraymond/claude-code-super-crew/internal/crewdirs"
+	"github.com/jonwraymond/claude-code-super-crew/internal/events"
+	"github.com/jonwraymond/claude-code-super-crew/internal/i18n"
 	"github.com/jonwraymond/claude-code-super-crew/internal/managers"
 	"github.com/jonwraymond/claude-code-super-crew/internal/ui"
 	"github.com/jonwraymond/claude-code-super-crew/pkg/logger"
@@ -20,6 +26,7 @@ type UninstallFlags struct {
 	KeepLogs     bool
 	KeepSettings bool
 	NoConfirm    bool
+	NoTrash      bool
 }
 
 var uninstallFlags UninstallFlags
@@ -31,12 +38,20 @@ func NewUninstallCommand() *cobra.Command {
 		Short: "Remove Claude Code Super Crew framework installation",
 		Long: `Uninstall Claude Code Super Crew Framework components.
 
+Framework files you modified after installing them are not deleted; they
+are moved to .crew/preserved/<time> so your changes survive. Everything
+else that is removed goes to the crew trash, where crew trash restore can
+bring it back until it expires (settings.trash_ttl_days, 30 by default).
+Set settings.trash to off in the crew config, or pass --no-trash, to
+delete files right away.
+
 Examples:
   crew uninstall                    # Interactive uninstall
   crew uninstall --components core  # Remove specific components
   crew uninstall --complete --force # Complete removal (forced)
   crew uninstall --keep-backups     # Keep backup files`,
-		RunE: runUninstall,
+		Annotations: map[string]string{auditAnnotation: auditAlways},
+		RunE:        interruptible(runUninstall),
 	}
 
 	// Uninstall mode options
@@ -52,11 +67,17 @@ Examples:
 		"Keep log files during uninstall")
 	cmd.Flags().BoolVar(&uninstallFlags.KeepSettings, "keep-settings", false,
 		"Keep user settings during uninstall")
+	cmd.Flags().BoolVar(&uninstallFlags.NoTrash, "no-trash", false,
+		"Delete removed files instead of moving them to the crew trash")
 
 	// Safety options
 	cmd.Flags().BoolVar(&uninstallFlags.NoConfirm, "no-confirm", false,
 		"Skip confirmation prompts (use with caution)")
 
+	registerFlagCompletions(cmd, map[string]completionFunc{
+		"components": completeInstalledComponents,
+	})
+
 	return cmd
 }
 
@@ -66,23 +87,12 @@ func runUninstall(cmd *cobra.Command, args []string) error {
 	log.SetQuiet(globalFlags.Quiet)
 
 	// Validate installation directory (skip in test mode)
-	if !testMode {
-		expectedHome := filepath.Join(os.Getenv("HOME"))
-		if expectedHome == "" {
-			expectedHome = filepath.Join(os.Getenv("USERPROFILE")) // Windows
-		}
-		actualDir, _ := filepath.Abs(globalFlags.InstallDir)
-
-		if !strings.HasPrefix(actualDir, expectedHome) {
-			ui.DisplayError("Installation must be inside your user profile directory.")
-			fmt.Printf("    Expected prefix: %s\n", expectedHome)
-			fmt.Printf("    Provided path:   %s\n", actualDir)
-			return fmt.Errorf("invalid installation directory")
-		}
+	if err := validateInstallDir(globalFlags.InstallDir, false); err != nil {
+		return err
 	}
 
 	// Display header
-	if !globalFlags.Quiet {
+	if showDecorations() {
 		ui.DisplayHeader(
 			"Claude Code Super Crew Uninstall v1.0",
 			"Removing Claude Code Super Crew framework components",
@@ -93,7 +103,7 @@ func runUninstall(cmd *cobra.Command, args []string) error {
 	info := getInstallationInfo(globalFlags.InstallDir)
 
 	// Display current installation
-	if !globalFlags.Quiet {
+	if showDecorations() {
 		displayUninstallInfo(info)
 	}
 
@@ -103,6 +113,19 @@ func runUninstall(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	// Uninstalling an overlay never touches the shared installation
+	if sharedDir := overlaySharedDir(globalFlags.InstallDir); sharedDir != "" {
+		if !uninstallFlags.NoConfirm && !globalFlags.Yes && !globalFlags.DryRun {
+			if ok, err := confirmAction(fmt.Sprintf("Remove the links to the shared installation in %s?", sharedDir), false); err != nil {
+				return err
+			} else if !ok {
+				log.Info(i18n.T("uninstall.cancelled"))
+				return nil
+			}
+		}
+		return uninstallOverlay(globalFlags.InstallDir, sharedDir)
+	}
+
 	// Get components to uninstall
 	installedComponents := info["components"].(map[string]string)
 	components, err := getComponentsToUninstall(uninstallFlags, installedComponents)
@@ -116,7 +139,7 @@ func runUninstall(cmd *cobra.Command, args []string) error {
 	}
 
 	// Display uninstall plan
-	if !globalFlags.Quiet {
+	if showDecorations() {
 		displayUninstallPlan(components, uninstallFlags, info)
 	}
 
@@ -124,27 +147,43 @@ func runUninstall(cmd *cobra.Command, args []string) error {
 	if !uninstallFlags.NoConfirm && !globalFlags.Yes && !globalFlags.DryRun {
 		var warningMsg string
 		if uninstallFlags.Complete {
-			warningMsg = "This will completely remove Claude Code Super Crew. Continue?"
+			warningMsg = i18n.T("uninstall.confirm_complete")
 		} else {
-			warningMsg = fmt.Sprintf("This will remove %d component(s). Continue?", len(components))
+			warningMsg = i18n.T("uninstall.confirm_components", len(components))
 		}
 
-		if !ui.Confirm(warningMsg, false) {
-			log.Info("Uninstall cancelled by user")
+		if ok, err := confirmAction(warningMsg, false); err != nil {
+			return err
+		} else if !ok {
+			log.Info(i18n.T("uninstall.cancelled"))
 			return nil
 		}
 	}
 
+	ctx := commandContext(cmd)
+	eventData := map[string]string{"components": strings.Join(components, ",")}
+	if err := publishEvent(ctx, events.UninstallStarted, eventData); err != nil {
+		ui.DisplayError("Uninstall aborted by pre-uninstall hook.")
+		return err
+	}
+
 	// Create backup if not dry run and not keeping backups
 	if !globalFlags.DryRun && !uninstallFlags.KeepBackups {
 		createUninstallBackup(globalFlags.InstallDir, components)
 	}
 
 	// Perform uninstall
-	success := performUninstall(components, uninstallFlags, info)
+	result := performUninstall(ctx, components, uninstallFlags, info)
+	if err := printOperationResult(result); err != nil {
+		return err
+	}
+
+	if result.ok() {
+		if err := publishEvent(ctx, events.UninstallCompleted, result.eventData(eventData)); err != nil {
+			log.Warnf("post-uninstall hook failed: %v", err)
+		}
 
-	if success {
-		if !globalFlags.Quiet {
+		if showDecorations() {
 			ui.DisplaySuccess("Claude Code Super Crew uninstall completed successfully!")
 
 			if !globalFlags.DryRun {
@@ -158,7 +197,7 @@ func runUninstall(cmd *cobra.Command, args []string) error {
 		return nil
 	} else {
 		ui.DisplayError("Uninstall completed with some failures. Check logs for details.")
-		return fmt.Errorf("uninstall failed")
+		return result.err("uninstall failed")
 	}
 }
 
@@ -284,6 +323,10 @@ func interactiveUninstallSelection(installed map[string]string) ([]string, error
 		return []string{}, nil
 	}
 
+	if ui.NonInteractive() {
+		return nil, fmt.Errorf("choose what to uninstall with --complete or --components: %w", ui.ErrInputRequired)
+	}
+
 	fmt.Printf("\n%sUninstall Options:%s\n", ui.ColorCyan, ui.ColorReset)
 
 	// Create menu options
@@ -296,12 +339,7 @@ func interactiveUninstallSelection(installed map[string]string) ([]string, error
 	menu := ui.NewMenu("Select uninstall option:", presetOptions, false)
 	result, err := menu.Display()
 	if err != nil {
-		// If menu fails (e.g., no stdin in test environment), default to complete uninstall
-		components := []string{}
-		for name := range installed {
-			components = append(components, name)
-		}
-		return components, nil
+		return nil, fmt.Errorf("menu selection failed: %w", err)
 	}
 	choice := result.(int)
 
@@ -386,7 +424,7 @@ func createUninstallBackup(installDir string, components []string) {
 	log := logger.GetLogger()
 
 	// Simple backup creation
-	backupDir := filepath.Join(installDir, ".crew", "backups")
+	backupDir := crewdirs.Path(installDir, "backups")
 	if err := os.MkdirAll(backupDir, 0755); err != nil {
 		log.Warnf("Could not create backup directory: %v", err)
 		return
@@ -398,28 +436,52 @@ func createUninstallBackup(installDir string, components []string) {
 	log.Warn("Automated backup not implemented - use 'crew backup --create' before uninstalling")
 }
 
-func performUninstall(components []string, flags UninstallFlags, info map[string]interface{}) bool {
+func performUninstall(ctx context.Context, components []string, flags UninstallFlags, info map[string]interface{}) *operationResult {
 	log := logger.GetLogger()
+	result := newOperationResult("uninstall", components)
 
 	// Setup progress tracking
-	progress := ui.NewProgressBar(len(components), 50, "Uninstalling: ", "")
+	progress := result.track(newProgress("Uninstalling components", components))
 
 	// Uninstall components using simplified approach
 	log.Infof("Uninstalling %d components...", len(components))
 
-	success := true
 	installDir := globalFlags.InstallDir
+	linked := linkedComponents(installDir)
+	guard := newUninstallGuard(installDir)
+	if !flags.NoTrash && !globalFlags.DryRun {
+		if t := openTrash(); t != nil {
+			guard.useTrash(t)
+		}
+	}
 
-	for i, component := range components {
-		progress.Update(i+1, fmt.Sprintf("Uninstalling %s", component))
+	for _, component := range components {
+		if err := ctx.Err(); err != nil {
+			log.Warnf("Stopped before uninstalling %s: %v", component, err)
+			result.stop(err)
+			break
+		}
+		progress.Start(component)
 
 		if globalFlags.DryRun {
 			log.Infof("[DRY RUN] Would remove component: %s", component)
+			progress.Done(component, nil)
 			continue
 		}
 
 		// Remove component based on known structure
 		componentPath := filepath.Join(installDir, component)
+
+		// Files of a --dev-link install live in the checkout; remove only
+		// the links to them
+		if source, ok := linked[strings.ToLower(component)]; ok {
+			dir, recursive := componentPath, true
+			if component == "Core" {
+				dir, recursive = installDir, false
+			}
+			removed := removeDevLinks(dir, source, recursive)
+			log.Infof("Removed %d links to %s from %s", removed, source, component)
+		}
 		if component == "Core" {
 			// Core files are installed directly in the root, not in a subdirectory
 			// Only remove files that we explicitly installed and tracked
@@ -453,9 +515,9 @@ func performUninstall(components []string, flags UninstallFlags, info map[string
 				}
 
 				if shouldRemove {
-					if err := os.Remove(filePath); err != nil {
-						log.Warnf("Failed to remove %s: %v", file, err)
-					} else {
+					if preserved, err := guard.remove(filePath); err != nil {
+						result.warnf("Failed to remove %s: %v", file, err)
+					} else if !preserved {
 						log.Infof("Removed tracked framework file: %s", file)
 						removedCount++
 					}
@@ -469,35 +531,50 @@ func performUninstall(components []string, flags UninstallFlags, info map[string
 			} else {
 				log.Infof("No tracked framework files found in core component")
 			}
+			progress.Done(component, nil)
+			result.component(component).Files = removedCount
 		} else {
 			// Other components are in subdirectories - use selective removal
-			if _, err := os.Stat(componentPath); err == nil {
-				if err := removeCrewFilesFromDirectory(componentPath); err != nil {
+			var err error
+			removed := 0
+			if _, statErr := os.Stat(componentPath); statErr == nil {
+				if removed, err = removeCrewFilesFromDirectory(componentPath, guard); err != nil {
 					log.Errorf("Failed to selectively remove component %s: %v", component, err)
-					success = false
 				} else {
 					log.Infof("Selectively removed component: %s", component)
 				}
 			}
+			progress.Done(component, err)
+			result.component(component).Files = removed
 		}
 	}
 
-	progress.Finish("Uninstall complete")
+	progress.Finish()
 
 	// Handle complete uninstall cleanup
-	if flags.Complete && !globalFlags.DryRun {
-		cleanupInstallationDirectory(globalFlags.InstallDir, flags)
+	if flags.Complete && !globalFlags.DryRun && ctx.Err() == nil {
+		cleanupInstallationDirectory(globalFlags.InstallDir, flags, guard)
+	}
+
+	if guard.trash != nil && guard.trash.Len() > 0 {
+		noteUndo(audit.UndoTrash, guard.trash.ID())
+	}
+	if showDecorations() {
+		guard.report()
 	}
 
-	return success
+	return result.finish()
 }
 
-func cleanupInstallationDirectory(installDir string, flags UninstallFlags) {
+func cleanupInstallationDirectory(installDir string, flags UninstallFlags, guard *uninstallGuard) {
 	log := logger.GetLogger()
 
+	// The settings ledger lives in the config directory removed below
+	stripCrewSettings(installDir, flags.KeepSettings, guard)
+
 	// Use selective removal based on metadata tracking instead of removing entire directory
 	if flags.Complete {
-		selectiveRemoveTrackedFiles(installDir, flags)
+		selectiveRemoveTrackedFiles(installDir, flags, guard)
 		return
 	}
 
@@ -506,21 +583,20 @@ func cleanupInstallationDirectory(installDir string, flags UninstallFlags) {
 
 	// Determine what to remove
 	if !flags.KeepBackups {
-		itemsToRemove = append(itemsToRemove, filepath.Join(installDir, ".crew", "backups"))
+		itemsToRemove = append(itemsToRemove, crewdirs.Path(installDir, "backups"))
 	}
 	if !flags.KeepLogs {
 		itemsToRemove = append(itemsToRemove, filepath.Join(installDir, "logs"))
-		itemsToRemove = append(itemsToRemove, filepath.Join(installDir, ".crew", "logs"))
+		itemsToRemove = append(itemsToRemove, crewdirs.Path(installDir, "logs"))
 	}
 	if !flags.KeepSettings {
-		itemsToRemove = append(itemsToRemove, filepath.Join(installDir, "settings.json"))
-		itemsToRemove = append(itemsToRemove, filepath.Join(installDir, ".crew", "config"))
+		itemsToRemove = append(itemsToRemove, crewdirs.Path(installDir, "config"))
 	}
 
 	// Remove selected items (only crew-created items)
 	for _, item := range itemsToRemove {
 		if _, err := os.Stat(item); err == nil {
-			if err := os.RemoveAll(item); err != nil {
+			if err := guard.removeAll(item); err != nil {
 				log.Warnf("Could not remove %s: %v", item, err)
 			} else {
 				log.Infof("Removed %s", item)
@@ -529,11 +605,53 @@ func cleanupInstallationDirectory(installDir string, flags UninstallFlags) {
 	}
 
 	// Remove .crew directory only if it's empty of user files
-	cleanupCrewDirectory(installDir)
+	cleanupCrewDirectory(installDir, flags, guard)
+}
+
+// stripCrewSettings takes what crew added out of settings.json: its hooks
+// and the settings recorded in the settings ledger. The rest of the file is
+// Claude Code's and the user's, so the file itself is only removed when
+// nothing else is left in it and the settings are not being kept.
+func stripCrewSettings(installDir string, keep bool, guard *uninstallGuard) {
+	log := logger.GetLogger()
+	settingsPath := filepath.Join(installDir, claudesettings.FileName)
+
+	// Kept settings must not reference hook scripts that are being removed
+	if err := removeCrewHooksFromSettings(installDir); err != nil {
+		log.Warnf("Could not remove crew hooks from settings.json: %v", err)
+	}
+	change, err := claudesettings.NewEditor(installDir).Remove(claudesettings.FileName, "")
+	if err != nil {
+		log.Warnf("Could not remove crew settings from settings.json: %v", err)
+	} else {
+		if len(change.Removed) > 0 {
+			log.Infof("Removed %d crew settings from settings.json", len(change.Removed))
+		}
+		for _, setting := range change.Kept {
+			log.Infof("Preserved %s in settings.json (changed since crew set it)", setting)
+		}
+	}
+
+	if keep {
+		return
+	}
+	settings, err := claudesettings.Read(settingsPath)
+	if err != nil || settings == nil {
+		return
+	}
+	if len(settings) > 0 {
+		log.Infof("Preserved settings.json (contains user settings)")
+		return
+	}
+	if preserved, err := guard.remove(settingsPath); err != nil {
+		log.Warnf("Could not remove settings.json: %v", err)
+	} else if !preserved {
+		log.Infof("Removed settings.json")
+	}
 }
 
 // selectiveRemoveTrackedFiles removes only files and directories that were created by crew using inventory
-func selectiveRemoveTrackedFiles(installDir string, flags UninstallFlags) {
+func selectiveRemoveTrackedFiles(installDir string, flags UninstallFlags, guard *uninstallGuard) {
 	log := logger.GetLogger()
 
 	// Load metadata to get inventory of created files
@@ -543,7 +661,7 @@ func selectiveRemoveTrackedFiles(installDir string, flags UninstallFlags) {
 	if err != nil {
 		log.Warnf("Could not load metadata for selective removal: %v", err)
 		// Fallback to pattern-based cleanup if metadata unavailable
-		fallbackPatternBasedRemoval(installDir, flags)
+		fallbackPatternBasedRemoval(installDir, flags, guard)
 		return
 	}
 
@@ -556,9 +674,9 @@ func selectiveRemoveTrackedFiles(installDir string, flags UninstallFlags) {
 		for _, relPath := range metadata.Inventory.CreatedFiles {
 			fullPath := filepath.Join(installDir, relPath)
 			if _, err := os.Stat(fullPath); err == nil {
-				if err := os.Remove(fullPath); err != nil {
+				if preserved, err := guard.remove(fullPath); err != nil {
 					log.Warnf("Could not remove tracked file %s: %v", relPath, err)
-				} else {
+				} else if !preserved {
 					log.Infof("Removed tracked file: %s", relPath)
 				}
 			}
@@ -571,7 +689,7 @@ func selectiveRemoveTrackedFiles(installDir string, flags UninstallFlags) {
 			if _, err := os.Stat(fullPath); err == nil {
 				// Check if directory is empty or only contains user files
 				if canSafelyRemoveDirectory(fullPath) {
-					if err := os.RemoveAll(fullPath); err != nil {
+					if err := guard.removeAll(fullPath); err != nil {
 						log.Warnf("Could not remove tracked directory %s: %v", relPath, err)
 					} else {
 						log.Infof("Removed tracked directory: %s", relPath)
@@ -583,23 +701,11 @@ func selectiveRemoveTrackedFiles(installDir string, flags UninstallFlags) {
 		}
 	} else {
 		log.Infof("No inventory found, falling back to pattern-based removal")
-		fallbackPatternBasedRemoval(installDir, flags)
-	}
-
-	// Handle preservation flags for crew-managed files
-	if !flags.KeepSettings {
-		settingsPath := filepath.Join(installDir, "settings.json")
-		if _, err := os.Stat(settingsPath); err == nil {
-			if err := os.Remove(settingsPath); err != nil {
-				log.Warnf("Could not remove settings.json: %v", err)
-			} else {
-				log.Infof("Removed settings.json")
-			}
-		}
+		fallbackPatternBasedRemoval(installDir, flags, guard)
 	}
 
 	// Clean up .crew directory structure
-	cleanupCrewDirectory(installDir)
+	cleanupCrewDirectory(installDir, flags, guard)
 
 	// Remove any empty directories we created (but preserve user content)
 	cleanupEmptyDirectories(installDir)
@@ -627,7 +733,7 @@ func canSafelyRemoveDirectory(dirPath string) bool {
 }
 
 // fallbackPatternBasedRemoval provides fallback removal when inventory is not available
-func fallbackPatternBasedRemoval(installDir string, flags UninstallFlags) {
+func fallbackPatternBasedRemoval(installDir string, flags UninstallFlags, guard *uninstallGuard) {
 	log := logger.GetLogger()
 
 	log.Infof("Using fallback pattern-based removal")
@@ -647,9 +753,9 @@ func fallbackPatternBasedRemoval(installDir string, flags UninstallFlags) {
 			if docMeta.Status == "present" {
 				fullPath := filepath.Join(installDir, docPath)
 				if _, err := os.Stat(fullPath); err == nil {
-					if err := os.Remove(fullPath); err != nil {
+					if preserved, err := guard.remove(fullPath); err != nil {
 						log.Warnf("Could not remove tracked file %s: %v", docPath, err)
-					} else {
+					} else if !preserved {
 						log.Infof("Removed tracked file: %s", docPath)
 					}
 				}
@@ -669,7 +775,7 @@ func fallbackPatternBasedRemoval(installDir string, flags UninstallFlags) {
 				componentPath := filepath.Join(installDir, componentName)
 				if _, err := os.Stat(componentPath); err == nil {
 					// Use pattern-based removal for component directories
-					if err := removeCrewFilesFromDirectory(componentPath); err != nil {
+					if _, err := removeCrewFilesFromDirectory(componentPath, guard); err != nil {
 						log.Warnf("Could not remove files from component directory %s: %v", componentName, err)
 					}
 				}
@@ -679,7 +785,7 @@ func fallbackPatternBasedRemoval(installDir string, flags UninstallFlags) {
 }
 
 // removeCrewOwnedDirectory recursively removes only crew-created files from a directory
-func removeCrewOwnedDirectory(dirPath string) error {
+func removeCrewOwnedDirectory(dirPath string, guard *uninstallGuard) error {
 	log := logger.GetLogger()
 
 	// Check if directory exists
@@ -705,12 +811,14 @@ func removeCrewOwnedDirectory(dirPath string) error {
 	}
 
 	// Instead of removing entire directory, selectively remove only crew files
-	return removeCrewFilesFromDirectory(dirPath)
+	_, err := removeCrewFilesFromDirectory(dirPath, guard)
+	return err
 }
 
 // removeCrewFilesFromDirectory removes only crew-created files from a directory
+// and returns how many it removed
 // This is a conservative approach that preserves user-created content
-func removeCrewFilesFromDirectory(dirPath string) error {
+func removeCrewFilesFromDirectory(dirPath string, guard *uninstallGuard) (int, error) {
 	log := logger.GetLogger()
 
 	// Get list of crew-created files based on known patterns
@@ -718,7 +826,7 @@ func removeCrewFilesFromDirectory(dirPath string) error {
 
 	entries, err := os.ReadDir(dirPath)
 	if err != nil {
-		return fmt.Errorf("failed to read directory %s: %w", dirPath, err)
+		return 0, fmt.Errorf("failed to read directory %s: %w", dirPath, err)
 	}
 
 	removedCount := 0
@@ -730,7 +838,9 @@ func removeCrewFilesFromDirectory(dirPath string) error {
 		if entry.IsDir() {
 			// For subdirectories, check if they are crew-created
 			if isCrewCreatedSubdirectory(entry.Name(), filepath.Base(dirPath)) {
-				if err := os.RemoveAll(entryPath); err != nil {
+				if err := guard.preserveTree(entryPath); err != nil {
+					log.Warnf("Failed to preserve modified files in %s: %v", entryPath, err)
+				} else if err := guard.removeAll(entryPath); err != nil {
 					log.Warnf("Failed to remove crew subdirectory %s: %v", entryPath, err)
 				} else {
 					log.Infof("Removed crew subdirectory: %s", entryPath)
@@ -749,8 +859,10 @@ func removeCrewFilesFromDirectory(dirPath string) error {
 				entry.Name(), isCrewFile, isFrameworkFile, crewFilePatterns, filepath.Base(dirPath))
 
 			if isCrewFile || isFrameworkFile {
-				if err := os.Remove(entryPath); err != nil {
+				if preserved, err := guard.remove(entryPath); err != nil {
 					log.Warnf("Failed to remove crew file %s: %v", entryPath, err)
+				} else if preserved {
+					userFileCount++
 				} else {
 					log.Infof("Removed crew file: %s", entryPath)
 					removedCount++
@@ -775,7 +887,7 @@ func removeCrewFilesFromDirectory(dirPath string) error {
 		log.Infof("No crew files found in directory: %s", dirPath)
 	}
 
-	return nil
+	return removedCount, nil
 }
 
 // getCrewFilePatterns returns file patterns that crew creates for each component
@@ -963,21 +1075,27 @@ func isCrewCreatedSubdirectory(subdirName, componentName string) bool {
 	}
 }
 
-// cleanupCrewDirectory handles .crew directory cleanup
-func cleanupCrewDirectory(installDir string) {
+// cleanupCrewDirectory handles .crew directory cleanup, or the cleanup of
+// crew's XDG directories. Subdirectories go through guard, so they end up in
+// the trash, and the backups, logs and config are left alone when flags
+// keep them.
+func cleanupCrewDirectory(installDir string, flags UninstallFlags, guard *uninstallGuard) {
 	log := logger.GetLogger()
-	crewDir := filepath.Join(installDir, ".crew")
-
-	if _, err := os.Stat(crewDir); os.IsNotExist(err) {
-		return // Already gone
-	}
 
 	// Remove known crew subdirectories
+	kept := map[string]bool{
+		"config":  flags.KeepSettings,
+		"backups": flags.KeepBackups,
+		"logs":    flags.KeepLogs,
+	}
 	crewSubdirs := []string{"config", "backups", "logs", "workflows", "scripts", "prompts", "completions"}
 	for _, subdir := range crewSubdirs {
-		subdirPath := filepath.Join(crewDir, subdir)
+		if kept[subdir] {
+			continue
+		}
+		subdirPath := crewdirs.Path(installDir, subdir)
 		if _, err := os.Stat(subdirPath); err == nil {
-			if err := os.RemoveAll(subdirPath); err != nil {
+			if err := guard.removeAll(subdirPath); err != nil {
 				log.Warnf("Could not remove .crew subdirectory %s: %v", subdir, err)
 			} else {
 				log.Infof("Removed .crew subdirectory: %s", subdir)
@@ -985,17 +1103,22 @@ func cleanupCrewDirectory(installDir string) {
 		}
 	}
 
-	// Try to remove .crew directory itself if it's empty
-	if isEmpty, err := isDirEmpty(crewDir); err == nil && isEmpty {
-		if err := os.Remove(crewDir); err != nil {
-			log.Warnf("Could not remove empty .crew directory: %v", err)
+	// Try to remove the crew directories themselves if they're empty
+	for _, crewDir := range crewdirs.Roots(installDir) {
+		if _, err := os.Stat(crewDir); os.IsNotExist(err) {
+			continue // Already gone
+		}
+		if isEmpty, err := isDirEmpty(crewDir); err == nil && isEmpty {
+			if err := os.Remove(crewDir); err != nil {
+				log.Warnf("Could not remove empty crew directory %s: %v", crewDir, err)
+			} else {
+				log.Infof("Removed empty crew directory %s", crewDir)
+			}
+		} else if err != nil {
+			log.Warnf("Could not check if crew directory %s is empty: %v", crewDir, err)
 		} else {
-			log.Infof("Removed empty .crew directory")
+			log.Infof("Preserved crew directory %s (contains user files)", crewDir)
 		}
-	} else if err != nil {
-		log.Warnf("Could not check if .crew directory is empty: %v", err)
-	} else {
-		log.Infof("Preserved .crew directory (contains user files)")
 	}
 }
 
@@ -0,0 +1,137 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/crewdirs"
+	"github.com/jonwraymond/claude-code-super-crew/internal/fileops"
+	"github.com/jonwraymond/claude-code-super-crew/internal/metadata"
+	"github.com/jonwraymond/claude-code-super-crew/internal/trash"
+	"github.com/jonwraymond/claude-code-super-crew/internal/ui"
+	"github.com/jonwraymond/claude-code-super-crew/pkg/logger"
+)
+
+// uninstallGuard keeps uninstall from deleting framework files the user
+// customized. A file whose hash no longer matches the one recorded when
+// crew installed it is moved to the preserved area instead.
+type uninstallGuard struct {
+	installDir string
+	hashes     map[string]metadata.FileIntegrityMeta
+	// dir is the preserved area of this uninstall run
+	dir       string
+	preserved []string
+	// trash receives the files that are removed; nil deletes them
+	trash *trash.Batch
+}
+
+// newUninstallGuard loads the install hashes of installDir. It must run
+// before the metadata is removed.
+func newUninstallGuard(installDir string) *uninstallGuard {
+	g := &uninstallGuard{
+		installDir: installDir,
+		dir:        crewdirs.Path(installDir, "preserved", time.Now().Format("20060102-150405")),
+	}
+	mm := metadata.NewMetadataManager(installDir)
+	if !mm.CheckInstallationExists() {
+		return g
+	}
+	if meta, err := mm.LoadMetadata(); err == nil {
+		g.hashes = meta.Integrity.FileHashes
+	}
+	return g
+}
+
+// useTrash moves what the uninstall removes into a new batch of t. The
+// metadata is kept in the batch as well, so that restoring it also brings
+// back the record of what was installed.
+func (g *uninstallGuard) useTrash(t *trash.Trash) {
+	g.trash = t.Begin("uninstall")
+	if err := g.trash.Keep(metadata.NewMetadataManager(g.installDir).Path()); err != nil {
+		logger.GetLogger().Debugf("Could not keep the installation metadata in the trash: %v", err)
+	}
+}
+
+// modified reports whether the file at path changed since crew installed
+// it. Files crew did not record a hash for count as unmodified.
+func (g *uninstallGuard) modified(path string) bool {
+	rel, err := filepath.Rel(g.installDir, path)
+	if err != nil {
+		return false
+	}
+	tracked, ok := g.hashes[rel]
+	if !ok || tracked.OriginalHash == "" {
+		return false
+	}
+	hash, err := fileops.HashFile(path)
+	return err == nil && hash != tracked.OriginalHash
+}
+
+// remove deletes the framework file at path, or moves it to the preserved
+// area when the user modified it. It reports whether the file was
+// preserved.
+func (g *uninstallGuard) remove(path string) (bool, error) {
+	if g == nil || !g.modified(path) {
+		return false, g.removeAll(path)
+	}
+	rel, _ := filepath.Rel(g.installDir, path)
+	dst := filepath.Join(g.dir, rel)
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return false, err
+	}
+	if err := os.Rename(path, dst); err != nil {
+		return false, fmt.Errorf("failed to preserve modified file %s: %w", rel, err)
+	}
+	logger.GetLogger().Infof("Preserved modified file %s in %s", rel, g.dir)
+	g.preserved = append(g.preserved, rel)
+	return true, nil
+}
+
+// preserveTree moves the modified files below dir to the preserved area,
+// so the rest of dir can be removed as a whole
+func (g *uninstallGuard) preserveTree(dir string) error {
+	if g == nil {
+		return nil
+	}
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !g.modified(path) {
+			return err
+		}
+		_, err = g.remove(path)
+		return err
+	})
+}
+
+// removeAll removes path and everything below it, moving it to the trash
+// when one is in use. Paths outside the installation, such as crew
+// directories relocated under XDG, are deleted.
+func (g *uninstallGuard) removeAll(path string) error {
+	if g == nil || g.trash == nil {
+		return os.RemoveAll(path)
+	}
+	err := g.trash.Remove(path)
+	if errors.Is(err, trash.ErrOutsideRoot) {
+		return os.RemoveAll(path)
+	}
+	return err
+}
+
+// report tells the user which customized files were kept and where
+func (g *uninstallGuard) report() {
+	if g.trash != nil && g.trash.Len() > 0 {
+		fmt.Printf("\n%s Removed files were moved to the trash; undo with: crew trash restore %s\n",
+			ui.Icons.Arrow, g.trash.ID())
+	}
+	if len(g.preserved) == 0 {
+		return
+	}
+	fmt.Printf("\n%s%s %d modified framework files were kept in %s:%s\n",
+		ui.ColorYellow, ui.Icons.Warning, len(g.preserved), g.dir, ui.ColorReset)
+	for _, rel := range g.preserved {
+		fmt.Printf("  %s %s\n", ui.Icons.Bullet, rel)
+	}
+}
@@ -0,0 +1,175 @@
+package cli
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/crewdirs"
+	"github.com/jonwraymond/claude-code-super-crew/internal/fileops"
+	"github.com/jonwraymond/claude-code-super-crew/internal/metadata"
+	"github.com/jonwraymond/claude-code-super-crew/internal/trash"
+	"github.com/jonwraymond/claude-code-super-crew/internal/ui"
+)
+
+func TestUninstallSelectionRequiresFlagsNonInteractively(t *testing.T) {
+	ui.SetNonInteractive(true)
+	defer ui.SetNonInteractive(false)
+
+	components, err := interactiveUninstallSelection(map[string]string{"core": "1.0.0", "hooks": "1.0.0"})
+	if !errors.Is(err, ui.ErrInputRequired) {
+		t.Fatalf("Expected ErrInputRequired, got %v", err)
+	}
+	if components != nil {
+		t.Errorf("Expected nothing selected for removal, got %v", components)
+	}
+}
+
+func TestConfirmActionNonInteractive(t *testing.T) {
+	ui.SetNonInteractive(true)
+	defer ui.SetNonInteractive(false)
+
+	previous := globalFlags
+	defer func() { globalFlags = previous }()
+	globalFlags.Yes = false
+
+	if ok, err := confirmAction("Proceed with update?", true); err != nil || !ok {
+		t.Errorf("Expected a yes default to proceed, got %v (%v)", ok, err)
+	}
+	if _, err := confirmAction("Remove everything?", false); !errors.Is(err, ui.ErrInputRequired) {
+		t.Errorf("Expected a no default to fail, got %v", err)
+	}
+
+	globalFlags.Yes = true
+	if ok, err := confirmAction("Remove everything?", false); err != nil || !ok {
+		t.Errorf("Expected --yes to confirm, got %v (%v)", ok, err)
+	}
+}
+
+func TestUninstallRemovesDevLinksOnly(t *testing.T) {
+	source := t.TempDir()
+	installDir := t.TempDir()
+	for _, file := range []string{"commands/crew/build.md", "commands/crew/test.md"} {
+		path := filepath.Join(source, file)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(file), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	crewDir := filepath.Join(installDir, "commands", "crew")
+	if err := os.MkdirAll(crewDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"build.md", "test.md"} {
+		if err := fileops.Link(filepath.Join(source, "commands", "crew", name), filepath.Join(crewDir, name)); err != nil {
+			t.Skipf("Symlinks not supported: %v", err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(crewDir, "mine.md"), []byte("user"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	mm := metadata.NewMetadataManager(installDir)
+	if err := mm.SaveMetadata(&metadata.UnifiedMetadata{Components: map[string]metadata.ComponentMeta{
+		"commands": {Version: "1.0.0"},
+		"hooks":    {Version: "1.0.0"},
+	}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := markDevLinked(installDir, []string{"commands"}, source); err != nil {
+		t.Fatal(err)
+	}
+
+	previous := globalFlags
+	defer func() { globalFlags = previous }()
+	globalFlags.InstallDir = installDir
+	if kept := skipLinkedComponents([]string{"commands", "hooks"}); len(kept) != 1 || kept[0] != "hooks" {
+		t.Errorf("Expected update to skip the linked component, kept %v", kept)
+	}
+
+	if removed := removeDevLinks(filepath.Join(installDir, "commands"), linkedComponents(installDir)["commands"], true); removed != 2 {
+		t.Errorf("Expected 2 links removed, got %d", removed)
+	}
+	if _, err := os.Stat(filepath.Join(crewDir, "mine.md")); err != nil {
+		t.Error("Expected the user file to stay")
+	}
+	if _, err := os.Stat(filepath.Join(source, "commands", "crew", "build.md")); err != nil {
+		t.Error("Expected the linked source file to stay")
+	}
+}
+
+func TestUninstallPreservesModifiedFiles(t *testing.T) {
+	installDir := t.TempDir()
+	crewDir := filepath.Join(installDir, "commands", "crew")
+	if err := os.MkdirAll(crewDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"build.md", "test.md"} {
+		if err := os.WriteFile(filepath.Join(crewDir, name), []byte("original"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	mm := metadata.NewMetadataManager(installDir)
+	if err := mm.SaveMetadata(&metadata.UnifiedMetadata{}); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"build.md", "test.md"} {
+		if err := mm.AddFileToIntegrityTracking(filepath.Join("commands", "crew", name), "commands"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(crewDir, "build.md"), []byte("customized"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	guard := newUninstallGuard(installDir)
+	if _, err := removeCrewFilesFromDirectory(filepath.Join(installDir, "commands"), guard); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := os.Stat(crewDir); !os.IsNotExist(err) {
+		t.Error("Expected the command directory to be removed")
+	}
+	if len(guard.preserved) != 1 || guard.preserved[0] != filepath.Join("commands", "crew", "build.md") {
+		t.Fatalf("Expected build.md to be preserved, got %v", guard.preserved)
+	}
+	data, err := os.ReadFile(filepath.Join(guard.dir, "commands", "crew", "build.md"))
+	if err != nil || string(data) != "customized" {
+		t.Errorf("Expected the customized file in the preserved area, got %q, %v", data, err)
+	}
+}
+
+func TestCompleteUninstallTrashesCrewDirectory(t *testing.T) {
+	installDir := t.TempDir()
+	backup := crewdirs.Path(installDir, "backups", "crew_backup.tar.gz")
+	logFile := crewdirs.Path(installDir, "logs", "crew.log")
+	for _, path := range []string{backup, logFile} {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("data"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tr := trash.New(installDir)
+	guard := newUninstallGuard(installDir)
+	guard.useTrash(tr)
+	cleanupInstallationDirectory(installDir, UninstallFlags{Complete: true, KeepLogs: true}, guard)
+
+	if _, err := os.Stat(backup); !os.IsNotExist(err) {
+		t.Fatalf("Expected the backups to be removed, got %v", err)
+	}
+	if _, err := os.Stat(logFile); err != nil {
+		t.Errorf("Expected --keep-logs to keep the logs: %v", err)
+	}
+	if _, err := tr.Restore(guard.trash.ID(), false); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := os.ReadFile(backup); err != nil || string(data) != "data" {
+		t.Errorf("Expected the backup back from the trash, got %q, %v", data, err)
+	}
+}
@@ -1,12 +1,17 @@
 package cli
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
+	"github.com/jonwraymond/claude-code-super-crew/internal/audit"
 	"github.com/jonwraymond/claude-code-super-crew/internal/core"
+	"github.com/jonwraymond/claude-code-super-crew/internal/events"
+	"github.com/jonwraymond/claude-code-super-crew/internal/i18n"
 	"github.com/jonwraymond/claude-code-super-crew/internal/installer"
 	"github.com/jonwraymond/claude-code-super-crew/internal/managers"
 	"github.com/jonwraymond/claude-code-super-crew/internal/ui"
@@ -22,6 +27,10 @@ type UpdateFlags struct {
 	Backup     bool
 	NoBackup   bool
 	Reinstall  bool
+	Self       bool
+
+	// items are the item patterns --components limits components to
+	items map[string][]string
 }
 
 var updateFlags UpdateFlags
@@ -33,19 +42,32 @@ func NewUpdateCommand() *cobra.Command {
 		Short: "Update existing Claude Code Super Crew installation",
 		Long: `Update Claude Code Super Crew Framework components to latest versions.
 
+When a release renames or removes components, update first migrates the
+installation: renamed files are moved, removed ones deleted and the
+metadata updated to match. Files you changed are kept and reported.
+
+With --self, update replaces the crew binary with the one of the latest
+release instead. The download is checked against the release checksums,
+resumed if interrupted and cached in .crew/cache/releases. It goes through
+settings.network.proxy from the crew config, or HTTPS_PROXY.
+
 Examples:
-  crew update                       # Interactive update
-  crew update --check --verbose     # Check for updates (verbose)
-  crew update --components core mcp # Update specific components
-  crew update --backup --force      # Create backup before update (forced)`,
-		RunE: runUpdate,
+  crew update                              # Interactive update
+  crew update --check --verbose            # Check for updates (verbose)
+  crew update --components core mcp        # Update specific components
+  crew update --components "commands/git*" # Update some of a component's items
+  crew update --components @recommended    # Update the installed recommended components
+  crew update --backup --force             # Create backup before update (forced)
+  crew update --self                       # Update the crew binary itself`,
+		Annotations: map[string]string{auditAnnotation: auditAlways},
+		RunE:        interruptible(runUpdate),
 	}
 
 	// Update mode options
 	cmd.Flags().BoolVar(&updateFlags.Check, "check", false,
 		"Check for available updates without installing")
 	cmd.Flags().StringSliceVar(&updateFlags.Components, "components", nil,
-		"Specific components to update")
+		"Components to update: names, globs, @groups or component/glob for some of a component's items")
 
 	// Backup options
 	cmd.Flags().BoolVar(&updateFlags.Backup, "backup", false,
@@ -56,6 +78,12 @@ Examples:
 	// Update options
 	cmd.Flags().BoolVar(&updateFlags.Reinstall, "reinstall", false,
 		"Reinstall components even if versions match")
+	cmd.Flags().BoolVar(&updateFlags.Self, "self", false,
+		"Update the crew binary to the latest release")
+
+	registerFlagCompletions(cmd, map[string]completionFunc{
+		"components": completeInstalledComponents,
+	})
 
 	return cmd
 }
@@ -63,26 +91,19 @@ Examples:
 func runUpdate(cmd *cobra.Command, args []string) error {
 	log := logger.GetLogger()
 	log.SetVerbose(globalFlags.Verbose)
-	log.SetQuiet(globalFlags.Quiet)
+	log.SetQuiet(globalFlags.Quiet || globalFlags.Output == "json")
 
-	// Validate installation directory (skip in test mode)
-	if !testMode {
-		expectedHome := filepath.Join(os.Getenv("HOME"))
-		if expectedHome == "" {
-			expectedHome = filepath.Join(os.Getenv("USERPROFILE")) // Windows
-		}
-		actualDir, _ := filepath.Abs(globalFlags.InstallDir)
+	if updateFlags.Self {
+		return runSelfUpdate(commandContext(cmd))
+	}
 
-		if !strings.HasPrefix(actualDir, expectedHome) {
-			ui.DisplayError("Installation must be inside your user profile directory.")
-			fmt.Printf("    Expected prefix: %s\n", expectedHome)
-			fmt.Printf("    Provided path:   %s\n", actualDir)
-			return fmt.Errorf("invalid installation directory")
-		}
+	// Validate installation directory (skip in test mode)
+	if err := validateInstallDir(globalFlags.InstallDir, false); err != nil {
+		return err
 	}
 
 	// Display header
-	if !globalFlags.Quiet {
+	if showDecorations() {
 		ui.DisplayHeader(
 			"Claude Code Super Crew Update v1.0",
 			"Updating Claude Code Super Crew framework components",
@@ -97,15 +118,17 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("no installation found")
 	}
 
+	// The shared framework is updated centrally; an overlay only follows it
+	if sharedDir := overlaySharedDir(globalFlags.InstallDir); sharedDir != "" {
+		return syncOverlay(globalFlags.InstallDir, sharedDir, updateFlags.Check)
+	}
+
 	// Initialize components
 	log.Info("Checking for available updates...")
 
-	exe, _ := os.Executable()
-	projectRoot := filepath.Dir(filepath.Dir(filepath.Dir(exe)))
-
-	registry := core.NewEnhancedComponentRegistry(filepath.Join(projectRoot, "setup", "components"))
-	if err := registry.DiscoverComponents(); err != nil {
-		return fmt.Errorf("failed to discover components: %w", err)
+	registry, err := newComponentRegistry()
+	if err != nil {
+		return err
 	}
 
 	// Get installed components
@@ -119,8 +142,11 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 	availableUpdates := getAvailableUpdates(installedComponents, registry)
 
 	// Display update check results
-	if !globalFlags.Quiet {
+	if showDecorations() {
 		displayUpdateCheck(installedComponents, availableUpdates)
+		if pending, _, err := pendingMigrations(globalFlags.InstallDir); err == nil {
+			displayPendingMigrations(pending)
+		}
 	}
 
 	// If only checking for updates, exit here
@@ -128,34 +154,74 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	if len(updateFlags.Components) > 0 {
+		if err := selectComponentsToUpdate(registry, &updateFlags, installedComponents); err != nil {
+			return err
+		}
+	}
+
 	// Get components to update
 	components, err := getComponentsToUpdate(updateFlags, installedComponents, availableUpdates)
 	if err != nil {
 		return err
 	}
 
+	// Linked components already follow their checkout
+	components = skipLinkedComponents(components)
+
 	if len(components) == 0 {
 		log.Info("No components selected for update")
 		return nil
 	}
 
+	// Check that the updated versions still fit together, including with
+	// installed components that are not being updated
+	plan, err := registry.Solve(components, installedComponents)
+	if err != nil {
+		displaySolveError(err)
+		return err
+	}
+
 	// Display update plan
-	if !globalFlags.Quiet {
-		displayUpdatePlan(components, availableUpdates, installedComponents, globalFlags.InstallDir)
+	if showDecorations() {
+		displayUpdatePlan(components, plan, availableUpdates, installedComponents, globalFlags.InstallDir)
 
 		if !globalFlags.DryRun {
-			if !globalFlags.Yes && !ui.Confirm("Proceed with update?", true) {
-				log.Info("Update cancelled by user")
+			if ok, err := confirmAction(i18n.T("update.confirm"), true); err != nil {
+				return err
+			} else if !ok {
+				log.Info(i18n.T("update.cancelled"))
 				return nil
 			}
 		}
 	}
 
+	ctx := commandContext(cmd)
+	eventData := map[string]string{"components": strings.Join(components, ",")}
+	if err := publishEvent(ctx, events.UpdateStarted, eventData); err != nil {
+		ui.DisplayError("Update aborted by pre-update hook.")
+		return err
+	}
+
+	// Carry renamed and removed components over before the new files land
+	if err := applyMigrations(globalFlags.InstallDir, globalFlags.DryRun); err != nil {
+		publishEvent(ctx, events.UpdateFailed, eventData)
+		ui.DisplayError("Update aborted: a component migration failed.")
+		return err
+	}
+
 	// Perform update
-	success := performUpdate(components, updateFlags)
+	result := performUpdate(ctx, components, updateFlags)
+	if err := printOperationResult(result); err != nil {
+		return err
+	}
 
-	if success {
-		if !globalFlags.Quiet {
+	if result.ok() {
+		if err := publishEvent(ctx, events.UpdateCompleted, result.eventData(eventData)); err != nil {
+			log.Warnf("post-update hook failed: %v", err)
+		}
+
+		if showDecorations() {
 			ui.DisplaySuccess("Claude Code Super Crew update completed successfully!")
 
 			if !globalFlags.DryRun {
@@ -167,9 +233,23 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 		}
 		return nil
 	} else {
+		publishEvent(ctx, events.UpdateFailed, result.eventData(eventData))
 		ui.DisplayError("Update failed. Check logs for details.")
-		return fmt.Errorf("update failed")
+		return result.err("update failed")
+	}
+}
+
+// newComponentRegistry returns the registry of the components this crew
+// binary can install
+func newComponentRegistry() (*core.EnhancedComponentRegistry, error) {
+	exe, _ := os.Executable()
+	projectRoot := filepath.Dir(filepath.Dir(filepath.Dir(exe)))
+
+	registry := core.NewEnhancedComponentRegistry(filepath.Join(projectRoot, "setup", "components"))
+	if err := discoverComponents(registry); err != nil {
+		return nil, fmt.Errorf("failed to discover components: %w", err)
 	}
+	return registry, nil
 }
 
 func getAvailableUpdates(installed map[string]string, registry *core.EnhancedComponentRegistry) map[string]map[string]string {
@@ -212,7 +292,7 @@ func displayUpdateCheck(installed map[string]string, updates map[string]map[stri
 	if len(updates) > 0 {
 		fmt.Printf("\n%sAvailable updates:%s\n", ui.ColorGreen, ui.ColorReset)
 		for component, info := range updates {
-			fmt.Printf("  %s: v%s → v%s\n", component, info["current"], info["available"])
+			fmt.Printf("  %s: v%s %s v%s\n", component, info["current"], ui.Icons.Arrow, info["available"])
 			fmt.Printf("    %s\n", info["description"])
 		}
 	} else {
@@ -222,6 +302,36 @@ func displayUpdateCheck(installed map[string]string, updates map[string]map[stri
 	fmt.Println()
 }
 
+// selectComponentsToUpdate resolves the --components selectors of flags
+// to component names and item patterns. Globs and groups only select the
+// installed components they match; a component named outright that is not
+// installed is left for getComponentsToUpdate to report.
+func selectComponentsToUpdate(registry *core.EnhancedComponentRegistry, flags *UpdateFlags, installed map[string]string) error {
+	selection, err := registry.SelectComponents(flags.Components, true)
+	if err != nil {
+		return err
+	}
+	named := make(map[string]bool)
+	for _, selector := range flags.Components {
+		if name := strings.SplitN(strings.TrimSpace(selector), "/", 2)[0]; !strings.ContainsAny(name, "@*?[") {
+			named[name] = true
+		}
+	}
+
+	var components []string
+	for _, name := range selection.Components {
+		if _, ok := installed[name]; ok || named[name] {
+			components = append(components, name)
+		}
+	}
+	if len(components) == 0 {
+		return fmt.Errorf("no installed component matches --components %s", strings.Join(flags.Components, ","))
+	}
+	flags.Components = components
+	flags.items = selection.Items
+	return nil
+}
+
 func getComponentsToUpdate(flags UpdateFlags, installed map[string]string, updates map[string]map[string]string) ([]string, error) {
 	log := logger.GetLogger()
 
@@ -247,8 +357,16 @@ func getComponentsToUpdate(flags UpdateFlags, installed map[string]string, updat
 		return []string{}, nil
 	}
 
-	// Interactive selection
-	if len(updates) > 0 {
+	// Interactive selection; without prompting, update everything that has
+	// an update available
+	if len(updates) > 0 && (globalFlags.Yes || ui.NonInteractive()) {
+		components := make([]string, 0, len(updates))
+		for name := range updates {
+			components = append(components, name)
+		}
+		sort.Strings(components)
+		return components, nil
+	} else if len(updates) > 0 {
 		return interactiveUpdateSelection(updates, installed)
 	} else if flags.Reinstall {
 		// Reinstall all components
@@ -274,7 +392,7 @@ func interactiveUpdateSelection(updates map[string]map[string]string, installed
 	componentNames := []string{}
 
 	for component, info := range updates {
-		updateOptions = append(updateOptions, fmt.Sprintf("%s: v%s → v%s", component, info["current"], info["available"]))
+		updateOptions = append(updateOptions, fmt.Sprintf("%s: v%s %s v%s", component, info["current"], ui.Icons.Arrow, info["available"]))
 		componentNames = append(componentNames, component)
 	}
 
@@ -319,7 +437,7 @@ func interactiveUpdateSelection(updates map[string]map[string]string, installed
 	return nil, fmt.Errorf("invalid selection")
 }
 
-func displayUpdatePlan(components []string, updates map[string]map[string]string, installed map[string]string, installDir string) {
+func displayUpdatePlan(components []string, plan *core.Plan, updates map[string]map[string]string, installed map[string]string, installDir string) {
 	fmt.Printf("\n%s%sUpdate Plan%s\n", ui.ColorCyan, ui.ColorBright, ui.ColorReset)
 	fmt.Println(strings.Repeat("=", 50))
 
@@ -328,18 +446,36 @@ func displayUpdatePlan(components []string, updates map[string]map[string]string
 
 	for i, componentName := range components {
 		if info, ok := updates[componentName]; ok {
-			fmt.Printf("  %d. %s: v%s → v%s\n", i+1, componentName, info["current"], info["available"])
+			fmt.Printf("  %d. %s: v%s %s v%s\n", i+1, componentName, info["current"], ui.Icons.Arrow, info["available"])
 		} else {
 			currentVersion := installed[componentName]
 			fmt.Printf("  %d. %s: v%s (reinstall)\n", i+1, componentName, currentVersion)
 		}
 	}
 
+	var dependencies []core.PlanStep
+	for _, step := range plan.Steps {
+		if !step.Requested {
+			dependencies = append(dependencies, step)
+		}
+	}
+	if len(dependencies) > 0 {
+		fmt.Printf("%sDependencies:%s\n", ui.ColorBlue, ui.ColorReset)
+		for _, step := range dependencies {
+			state := "installed v" + step.Installed
+			if step.Installed == "" {
+				state = "not installed"
+			}
+			fmt.Printf("  - %s: %s, required by %s\n", step.Name, state, strings.Join(step.RequiredBy, ", "))
+		}
+	}
+
 	fmt.Println()
 }
 
-func performUpdate(components []string, flags UpdateFlags) bool {
+func performUpdate(ctx context.Context, components []string, flags UpdateFlags) *operationResult {
 	log := logger.GetLogger()
+	result := newOperationResult("update", components)
 
 	exe, _ := os.Executable()
 	projectRoot := filepath.Dir(filepath.Dir(filepath.Dir(exe)))
@@ -349,21 +485,18 @@ func performUpdate(components []string, flags UpdateFlags) bool {
 
 	// Create component registry
 	registry := core.NewEnhancedComponentRegistry(filepath.Join(projectRoot, "setup", "components"))
-	if err := registry.DiscoverComponents(); err != nil {
-		log.Errorf("Failed to discover components: %v", err)
-		return false
+	if err := discoverComponents(registry); err != nil {
+		return result.failf("Failed to discover components: %v", err)
 	}
 
 	// Create component instances
 	componentInstances, err := registry.CreateComponentInstances(components, globalFlags.InstallDir)
 	if err != nil {
-		log.Errorf("Failed to create component instances: %v", err)
-		return false
+		return result.failf("Failed to create component instances: %v", err)
 	}
 
 	if len(componentInstances) == 0 {
-		log.Error("No valid component instances created")
-		return false
+		return result.failf("No valid component instances created")
 	}
 
 	// Register components with installer
@@ -373,9 +506,6 @@ func performUpdate(components []string, flags UpdateFlags) bool {
 	}
 	inst.RegisterComponents(compList)
 
-	// Setup progress tracking
-	progress := ui.NewProgressBar(len(components), 50, "Updating: ", "")
-
 	// Update components
 	log.Infof("Updating %d components...", len(components))
 
@@ -383,31 +513,43 @@ func performUpdate(components []string, flags UpdateFlags) bool {
 	backup := flags.Backup || (!flags.NoBackup && !globalFlags.DryRun)
 
 	config := map[string]interface{}{
-		"force":       globalFlags.Force,
-		"backup":      backup,
-		"dry_run":     globalFlags.DryRun,
-		"update_mode": true,
+		"force":          globalFlags.Force,
+		"backup":         backup,
+		"dry_run":        globalFlags.DryRun,
+		"update_mode":    true,
+		core.ConfigItems: flags.items,
 	}
 
-	success := inst.UpdateComponents(components, config)
+	var missing []string
+	for _, comp := range componentInstances {
+		missing = append(missing, missingTargets(comp.GetFilesToInstall())...)
+	}
+
+	progress := result.track(newProgress("Updating components", components))
+	inst.SetProgress(progress)
+	inst.UpdateComponents(ctx, components, config)
+	progress.Finish()
+	if err := ctx.Err(); err != nil {
+		result.stop(err)
+	}
+	for _, c := range result.Components {
+		if comp, ok := componentInstances[c.Name]; ok && c.Status == componentSucceeded {
+			c.Files = len(comp.GetFilesToInstall())
+		}
+	}
+	result.finish()
 
-	// Update progress
 	summary := inst.GetUpdateSummary()
 	updated := summary["updated"].([]string)
 	failed := summary["failed"].([]string)
-
-	for i, name := range components {
-		if contains(updated, name) {
-			progress.Update(i+1, fmt.Sprintf("Updated %s", name))
-		} else {
-			progress.Update(i+1, fmt.Sprintf("Failed %s", name))
-		}
+	if backupPath, ok := summary["backup_path"].(string); ok && backupPath != "" {
+		result.Backup = backupPath
+		noteUndo(audit.UndoBackup, backupPath)
+		noteAdded(missing)
 	}
 
-	progress.Finish("Update complete")
-
 	// Show results
-	if success {
+	if result.ok() {
 		if len(updated) > 0 {
 			log.Infof("Updated components: %s", strings.Join(updated, ", "))
 		}
@@ -421,5 +563,5 @@ func performUpdate(components []string, flags UpdateFlags) bool {
 		}
 	}
 
-	return success
+	return result
 }
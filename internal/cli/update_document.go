@@ -8,7 +8,9 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/jonwraymond/claude-code-super-crew/internal/crewdirs"
 	"github.com/jonwraymond/claude-code-super-crew/internal/metadata"
+	"github.com/jonwraymond/claude-code-super-crew/internal/ui"
 )
 
 type UpdateDocumentCommand struct {
@@ -35,8 +37,9 @@ func NewUpdateDocumentCommand() *cobra.Command {
 Example:
   crew update-document COMMANDS.md 1.0.1
   crew update-document agents/architect-persona.md 1.1.0`,
-		Args: cobra.ExactArgs(2),
-		RunE: cmd.Execute,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completeTrackedDocuments,
+		RunE:              cmd.Execute,
 	}
 
 	cobraCmd.Flags().StringVar(&cmd.installDir, "install-dir", "", "Installation directory (default: ~/.claude)")
@@ -84,8 +87,8 @@ func (cmd *UpdateDocumentCommand) Execute(cobraCmd *cobra.Command, args []string
 	}
 
 	// Display current state
-	fmt.Printf("\n🔍 Document Version Update Analysis\n")
-	fmt.Printf("═══════════════════════════════════════════════\n")
+	fmt.Printf("\n%sDocument Version Update Analysis\n", ui.Emoji("🔍 "))
+	fmt.Printf("%s\n", strings.Repeat(ui.Icons.Rule, 47))
 	fmt.Printf("Document: %s\n", cmd.documentPath)
 	fmt.Printf("Current Version: %s\n", docMeta.Version)
 	fmt.Printf("Target Version: %s\n", cmd.newVersion)
@@ -95,17 +98,17 @@ func (cmd *UpdateDocumentCommand) Execute(cobraCmd *cobra.Command, args []string
 	fmt.Printf("\n")
 
 	if cmd.dryRun {
-		fmt.Printf("🔮 Dry Run - Changes that would be made:\n")
-		fmt.Printf("───────────────────────────────────────────────\n")
-		fmt.Printf("✅ Update document version: %s → %s\n", docMeta.Version, cmd.newVersion)
-		fmt.Printf("✅ Update previous_version field: %s\n", docMeta.Version)
-		fmt.Printf("✅ Refresh file checksum and size\n")
-		fmt.Printf("✅ Update timestamp\n")
-		fmt.Printf("✅ Propagate to dependency management\n")
+		fmt.Printf("%sDry Run - Changes that would be made:\n", ui.Emoji("🔮 "))
+		fmt.Printf("%s\n", strings.Repeat(ui.Icons.LightRule, 47))
+		fmt.Printf("%s Update document version: %s %s %s\n", ui.Icons.Success, docMeta.Version, ui.Icons.Arrow, cmd.newVersion)
+		fmt.Printf("%s Update previous_version field: %s\n", ui.Icons.Success, docMeta.Version)
+		fmt.Printf("%s Refresh file checksum and size\n", ui.Icons.Success)
+		fmt.Printf("%s Update timestamp\n", ui.Icons.Success)
+		fmt.Printf("%s Propagate to dependency management\n", ui.Icons.Success)
 		if cmd.updateChangelog {
-			fmt.Printf("✅ Update component changelog\n")
+			fmt.Printf("%s Update component changelog\n", ui.Icons.Success)
 		}
-		fmt.Printf("✅ Unified metadata system validated\n")
+		fmt.Printf("%s Unified metadata system validated\n", ui.Icons.Success)
 		return nil
 	}
 
@@ -114,8 +117,8 @@ func (cmd *UpdateDocumentCommand) Execute(cobraCmd *cobra.Command, args []string
 }
 
 func (cmd *UpdateDocumentCommand) performUpdate(metaMgr *metadata.MetadataManager, currentMeta *metadata.UnifiedMetadata, docMeta metadata.DocumentMeta) error {
-	fmt.Printf("🚀 Executing Document Version Update\n")
-	fmt.Printf("═══════════════════════════════════════════════\n")
+	fmt.Printf("%sExecuting Document Version Update\n", ui.Emoji("🚀 "))
+	fmt.Printf("%s\n", strings.Repeat(ui.Icons.Rule, 47))
 
 	// Step 1: Update document metadata
 	fmt.Printf("Step 1: Updating document metadata...\n")
@@ -136,7 +139,7 @@ func (cmd *UpdateDocumentCommand) performUpdate(metaMgr *metadata.MetadataManage
 
 	// Update in metadata
 	currentMeta.Documents[cmd.documentPath] = docMeta
-	fmt.Printf("✅ Document metadata updated\n")
+	fmt.Printf("%s Document metadata updated\n", ui.Icons.Success)
 
 	// Step 2: Update component version if needed
 	fmt.Printf("Step 2: Checking component version update...\n")
@@ -149,47 +152,47 @@ func (cmd *UpdateDocumentCommand) performUpdate(metaMgr *metadata.MetadataManage
 		componentMeta.Version = cmd.newVersion
 		componentMeta.UpdatedAt = time.Now()
 		currentMeta.Components[docMeta.Component] = componentMeta
-		fmt.Printf("✅ Component %s version updated: %s → %s\n", docMeta.Component, previousComponentVersion, cmd.newVersion)
+		fmt.Printf("%s Component %s version updated: %s %s %s\n", ui.Icons.Success, docMeta.Component, previousComponentVersion, ui.Icons.Arrow, cmd.newVersion)
 	} else {
-		fmt.Printf("ℹ️  Component version unchanged (document not version-significant)\n")
+		fmt.Printf("%sComponent version unchanged (document not version-significant)\n", ui.Emoji("ℹ️  "))
 	}
 
 	// Step 3: Update framework metadata
 	fmt.Printf("Step 3: Updating framework metadata...\n")
 	currentMeta.Framework.UpdatedAt = time.Now()
-	fmt.Printf("✅ Framework metadata updated\n")
+	fmt.Printf("%s Framework metadata updated\n", ui.Icons.Success)
 
 	// Step 4: Save updated metadata (preserving comprehensive schema)
 	fmt.Printf("Step 4: Saving updated metadata...\n")
 	if err := metaMgr.SaveMetadata(currentMeta); err != nil {
 		return fmt.Errorf("failed to save metadata: %w", err)
 	}
-	fmt.Printf("✅ Metadata saved successfully (comprehensive schema preserved)\n")
+	fmt.Printf("%s Metadata saved successfully (comprehensive schema preserved)\n", ui.Icons.Success)
 
 	// Step 5: Metadata saved (no legacy sync needed in unified system)
 	fmt.Printf("Step 5: Unified metadata system complete\n")
-	fmt.Printf("✅ No legacy synchronization required\n")
+	fmt.Printf("%s No legacy synchronization required\n", ui.Icons.Success)
 
 	// Step 6: Generate changelog entry
 	if cmd.updateChangelog {
 		fmt.Printf("Step 6: Updating changelog...\n")
 		if err := cmd.updateChangelogEntry(docMeta); err != nil {
-			fmt.Printf("⚠️  Warning: Failed to update changelog: %v\n", err)
+			fmt.Printf("%s Warning: Failed to update changelog: %v\n", ui.Icons.Warning, err)
 		} else {
-			fmt.Printf("✅ Changelog updated\n")
+			fmt.Printf("%s Changelog updated\n", ui.Icons.Success)
 		}
 	}
 
 	// Success summary
-	fmt.Printf("═══════════════════════════════════════════════\n")
-	fmt.Printf("🎉 Document Version Update Complete!\n")
+	fmt.Printf("%s\n", strings.Repeat(ui.Icons.Rule, 47))
+	fmt.Printf("%sDocument Version Update Complete!\n", ui.Emoji("🎉 "))
 	fmt.Printf("Document: %s\n", cmd.documentPath)
-	fmt.Printf("Version: %s → %s\n", docMeta.PreviousVersion, cmd.newVersion)
+	fmt.Printf("Version: %s %s %s\n", docMeta.PreviousVersion, ui.Icons.Arrow, cmd.newVersion)
 	fmt.Printf("Component: %s\n", docMeta.Component)
 	fmt.Printf("Updated At: %s\n", docMeta.UpdatedAt.Format("2006-01-02 15:04:05"))
-	fmt.Printf("\n✅ All dependency management pipelines updated\n")
-	fmt.Printf("✅ Documentation synchronization complete\n")
-	fmt.Printf("✅ Metadata consistency verified\n")
+	fmt.Printf("\n%s All dependency management pipelines updated\n", ui.Icons.Success)
+	fmt.Printf("%s Documentation synchronization complete\n", ui.Icons.Success)
+	fmt.Printf("%s Metadata consistency verified\n", ui.Icons.Success)
 
 	return nil
 }
@@ -224,7 +227,7 @@ func (cmd *UpdateDocumentCommand) isSignificantDocument(docPath string) bool {
 }
 
 func (cmd *UpdateDocumentCommand) updateChangelogEntry(docMeta metadata.DocumentMeta) error {
-	changelogPath := filepath.Join(cmd.installDir, ".crew", "CHANGELOG.md")
+	changelogPath := crewdirs.Path(cmd.installDir, "CHANGELOG.md")
 	
 	// Create changelog entry
 	entry := fmt.Sprintf("## %s - %s\n\n", cmd.newVersion, time.Now().Format("2006-01-02"))
@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/metadata"
+	"github.com/jonwraymond/claude-code-super-crew/internal/migrations"
+	"github.com/jonwraymond/claude-code-super-crew/internal/ui"
+	"github.com/jonwraymond/claude-code-super-crew/pkg/logger"
+)
+
+// pendingMigrations returns the component migrations the installation in
+// installDir has not had yet, with its metadata
+func pendingMigrations(installDir string) ([]migrations.Migration, *metadata.UnifiedMetadata, error) {
+	list, err := migrations.Builtin()
+	if err != nil {
+		return nil, nil, err
+	}
+	meta, err := metadata.NewMetadataManager(installDir).LoadMetadata()
+	if err != nil {
+		return nil, nil, err
+	}
+	return migrations.Pending(list, meta.Framework.Version, meta.Installation.Migrations), meta, nil
+}
+
+// displayPendingMigrations lists what the next update will move, rename or
+// remove because a release dropped or renamed components
+func displayPendingMigrations(pending []migrations.Migration) {
+	if len(pending) == 0 {
+		return
+	}
+	fmt.Printf("\n%sComponent migrations:%s\n", ui.ColorCyan, ui.ColorReset)
+	for _, m := range pending {
+		fmt.Printf("  %s v%s: %s\n", ui.Icons.Bullet, m.Version, m.Description)
+		for _, step := range m.Steps {
+			fmt.Printf("      %s\n", describeMigrationStep(step))
+		}
+	}
+}
+
+// applyMigrations runs the pending component migrations before components
+// are updated, so renamed files are carried over and removed ones do not
+// linger next to their replacements
+func applyMigrations(installDir string, dryRun bool) error {
+	log := logger.GetLogger()
+
+	pending, meta, err := pendingMigrations(installDir)
+	if err != nil {
+		return fmt.Errorf("failed to read component migrations: %w", err)
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	outcomes, err := migrations.Apply(installDir, meta, pending, dryRun)
+	if !dryRun {
+		// Record whatever was done, even when a later step failed
+		if saveErr := metadata.NewMetadataManager(installDir).SaveMetadata(meta); saveErr != nil && err == nil {
+			err = fmt.Errorf("failed to save metadata: %w", saveErr)
+		}
+	}
+
+	for _, outcome := range outcomes {
+		line := describeMigrationStep(outcome.Step)
+		if outcome.Detail != "" {
+			line += " (" + outcome.Detail + ")"
+		}
+		switch {
+		case outcome.Status == migrations.Kept:
+			log.Warnf("Kept %s", line)
+		case outcome.Status == migrations.Skipped:
+			log.Debugf("Skipped %s", line)
+		case dryRun:
+			log.Infof("[DRY RUN] Would %s", line)
+		default:
+			log.Infof("Migrated: %s", line)
+		}
+	}
+	if err != nil || dryRun {
+		return err
+	}
+
+	for _, m := range pending {
+		log.Infof("Applied migration for v%s: %s", m.Version, m.Description)
+	}
+	return nil
+}
+
+func describeMigrationStep(step migrations.Step) string {
+	switch step.Action {
+	case migrations.Move:
+		return fmt.Sprintf("move %s %s %s", step.From, ui.Icons.Arrow, step.To)
+	case migrations.RenameComponent:
+		return fmt.Sprintf("rename component %s %s %s", step.From, ui.Icons.Arrow, step.To)
+	}
+	return "remove " + step.From
+}
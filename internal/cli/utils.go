@@ -1,9 +1,14 @@
 package cli
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/core"
+	"github.com/jonwraymond/claude-code-super-crew/internal/crewdirs"
+	"github.com/jonwraymond/claude-code-super-crew/internal/ui"
 )
 
 // expandPath expands ~ to home directory
@@ -25,4 +30,46 @@ func contains(slice []string, item string) bool {
 		}
 	}
 	return false
-}
\ No newline at end of file
+}
+
+// discoverComponents registers the built-in components plus any plugin
+// components installed under <install-dir>/.crew/components
+func discoverComponents(registry *core.EnhancedComponentRegistry) error {
+	registry.AddDiscoveryDir(crewdirs.Path(globalFlags.InstallDir, "components"))
+	return registry.DiscoverComponents()
+}
+
+// selectFrontend picks the UI frontend from --output and whether stdout is
+// a terminal. --quiet and --verbose keep progress to the log lines, and JSON
+// log lines on stderr would tear the live panel, so they do too.
+func selectFrontend() {
+	ui.SetFrontend(ui.DetectFrontend(ui.FrontendOptions{
+		JSON:          globalFlags.Output == "json",
+		PlainProgress: globalFlags.Quiet || globalFlags.Verbose || globalFlags.LogFormat == "json",
+	}))
+}
+
+// newProgress creates the progress display of the current frontend for an
+// operation over items
+func newProgress(title string, items []string) ui.Progress {
+	return ui.CurrentFrontend().Progress(title, items)
+}
+
+// showDecorations reports whether headers, plans and other human-oriented
+// output should be printed
+func showDecorations() bool {
+	return !globalFlags.Quiet && globalFlags.Output != "json"
+}
+
+// confirmAction asks for confirmation before an operation. --yes accepts
+// without asking. Without a terminal, a yes default proceeds, while a no
+// default fails with an error instead of quietly skipping the operation.
+func confirmAction(message string, defaultYes bool) (bool, error) {
+	if globalFlags.Yes {
+		return true, nil
+	}
+	if ui.NonInteractive() && !defaultYes {
+		return false, fmt.Errorf("%s %w; pass --yes to confirm", message, ui.ErrInputRequired)
+	}
+	return ui.Confirm(message, defaultYes), nil
+}
@@ -0,0 +1,404 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/claude"
+	"github.com/jonwraymond/claude-code-super-crew/internal/core"
+	"github.com/jonwraymond/claude-code-super-crew/internal/crewdirs"
+	"github.com/jonwraymond/claude-code-super-crew/internal/metadata"
+	"github.com/jonwraymond/claude-code-super-crew/internal/pathsutil"
+	"github.com/jonwraymond/claude-code-super-crew/internal/semver"
+	"github.com/jonwraymond/claude-code-super-crew/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// Verify check statuses, in increasing order of severity
+const (
+	verifyPass = "pass"
+	verifyWarn = "warn"
+	verifyFail = "fail"
+)
+
+// VerifyFlags holds verify command flags
+type VerifyFlags struct {
+	JSON bool
+}
+
+// verifyCheck is the outcome of one step in the verification chain
+type verifyCheck struct {
+	Name    string   `json:"name"`
+	Status  string   `json:"status"`
+	Message string   `json:"message"`
+	Details []string `json:"details,omitempty"`
+}
+
+// verifyReport is the full verification result
+type verifyReport struct {
+	Status     string        `json:"status"`
+	InstallDir string        `json:"install_dir"`
+	ProjectDir string        `json:"project_dir"`
+	Checks     []verifyCheck `json:"checks"`
+}
+
+// NewVerifyCommand creates the verify command
+func NewVerifyCommand() *cobra.Command {
+	var flags VerifyFlags
+
+	cmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Verify the whole crew environment end to end",
+		Long: `Run every environment check in one pass and summarize the result.
+
+Checks, in order:
+  binary        crew version compared with the installed framework
+  metadata      crew-metadata.json parses and has the required fields
+  integrity     installed files match their recorded hashes
+  settings      Claude settings files are valid JSON
+  project       crew integration in the current directory
+  mcp           tools needed by the MCP servers are available
+  completion    shell integration or completion scripts are installed
+
+Each check passes, warns or fails. The command exits non-zero when any
+check fails.
+
+Examples:
+  crew verify           # Human readable report
+  crew verify --json    # Machine readable report`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runVerify(cmd, flags)
+		},
+		// A failed check is reported in the summary, not as a usage error
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().BoolVar(&flags.JSON, "json", false, "Output the report as JSON")
+
+	return cmd
+}
+
+func runVerify(cmd *cobra.Command, flags VerifyFlags) error {
+	installDir := getGlobalInstallDir()
+	projectDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	report := buildVerifyReport(cmd.Root().Version, installDir, projectDir, core.NewValidator())
+
+	if flags.JSON || globalFlags.Output == "json" {
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			return err
+		}
+	} else {
+		displayVerifyReport(cmd.OutOrStdout(), report)
+	}
+
+	if report.Status == verifyFail {
+		return fmt.Errorf("verification failed")
+	}
+	return nil
+}
+
+// buildVerifyReport runs every check against installDir and projectDir
+func buildVerifyReport(version, installDir, projectDir string, validator *core.Validator) verifyReport {
+	meta, metaCheck := verifyMetadata(installDir)
+
+	report := verifyReport{
+		InstallDir: installDir,
+		ProjectDir: projectDir,
+		Checks: []verifyCheck{
+			verifyBinary(version, meta),
+			metaCheck,
+			verifyIntegrity(installDir, meta),
+			verifySettings(installDir),
+			verifyProject(installDir, projectDir),
+			verifyMCP(meta, validator),
+			verifyCompletion(installDir),
+		},
+	}
+	report.Status = worstStatus(report.Checks)
+	return report
+}
+
+// worstStatus returns the most severe status among checks
+func worstStatus(checks []verifyCheck) string {
+	status := verifyPass
+	for _, check := range checks {
+		switch {
+		case check.Status == verifyFail:
+			return verifyFail
+		case check.Status == verifyWarn:
+			status = verifyWarn
+		}
+	}
+	return status
+}
+
+func verifyBinary(version string, meta *metadata.UnifiedMetadata) verifyCheck {
+	check := verifyCheck{Name: "binary", Status: verifyPass, Message: "crew " + version}
+	if meta == nil || meta.Framework.Version == "" {
+		return check
+	}
+
+	installed := meta.Framework.Version
+	switch cmp := semver.Compare(version, installed); {
+	case cmp > 0:
+		check.Status = verifyWarn
+		check.Message = fmt.Sprintf("crew %s is newer than the installed framework %s; run 'crew update'", version, installed)
+	case cmp < 0:
+		check.Status = verifyWarn
+		check.Message = fmt.Sprintf("crew %s is older than the installed framework %s; upgrade the binary", version, installed)
+	default:
+		check.Message = fmt.Sprintf("crew %s matches the installed framework", version)
+	}
+	return check
+}
+
+// verifyMetadata loads the metadata file directly so that a missing file
+// is reported rather than replaced with empty metadata
+func verifyMetadata(installDir string) (*metadata.UnifiedMetadata, verifyCheck) {
+	check := verifyCheck{Name: "metadata"}
+	path := crewdirs.Path(installDir, "config", "crew-metadata.json")
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		check.Status = verifyFail
+		check.Message = fmt.Sprintf("no installation found at %s; run 'crew install'", installDir)
+		return nil, check
+	}
+	if err != nil {
+		check.Status = verifyFail
+		check.Message = fmt.Sprintf("cannot read %s: %v", path, err)
+		return nil, check
+	}
+
+	var meta metadata.UnifiedMetadata
+	if err := json.Unmarshal(data, &meta); err != nil {
+		check.Status = verifyFail
+		check.Message = fmt.Sprintf("%s is not valid metadata: %v", path, err)
+		return nil, check
+	}
+
+	if meta.Framework.Version == "" {
+		check.Details = append(check.Details, "framework.version is missing")
+	}
+	if len(meta.Components) == 0 {
+		check.Details = append(check.Details, "no components are recorded")
+	}
+	for name, comp := range meta.Components {
+		if comp.Version == "" {
+			check.Details = append(check.Details, fmt.Sprintf("component %s has no version", name))
+		}
+	}
+
+	if len(check.Details) > 0 {
+		check.Status = verifyWarn
+		check.Message = "metadata is incomplete"
+	} else {
+		check.Status = verifyPass
+		check.Message = fmt.Sprintf("%d components recorded", len(meta.Components))
+	}
+	return &meta, check
+}
+
+func verifyIntegrity(installDir string, meta *metadata.UnifiedMetadata) verifyCheck {
+	check := verifyCheck{Name: "integrity"}
+	if meta == nil {
+		check.Status = verifyWarn
+		check.Message = "skipped: no readable metadata"
+		return check
+	}
+
+	integrity, err := metadata.NewMetadataManager(installDir).CheckFileIntegrity()
+	if err != nil {
+		check.Status = verifyFail
+		check.Message = fmt.Sprintf("integrity check failed: %v", err)
+		return check
+	}
+
+	switch integrity.Status {
+	case "clean":
+		check.Status = verifyPass
+		check.Message = fmt.Sprintf("%d files match their recorded hashes", integrity.TotalFiles)
+	case "warning":
+		check.Status = verifyWarn
+		check.Message = fmt.Sprintf("%d files modified; see 'crew integrity --check'", integrity.ModifiedFiles)
+	default:
+		check.Status = verifyFail
+		check.Message = fmt.Sprintf("%d files missing, %d corrupted; see 'crew integrity --check'",
+			integrity.MissingFiles, integrity.CorruptedFiles)
+	}
+	return check
+}
+
+// verifySettings checks that the Claude settings files crew merges into
+// are valid JSON. Missing files are fine.
+func verifySettings(installDir string) verifyCheck {
+	check := verifyCheck{Name: "settings", Status: verifyPass}
+
+	found := 0
+	for _, name := range []string{"settings.json", "settings.local.json"} {
+		data, err := os.ReadFile(filepath.Join(installDir, name))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			check.Details = append(check.Details, fmt.Sprintf("%s: %v", name, err))
+			continue
+		}
+		found++
+		var settings map[string]interface{}
+		if err := json.Unmarshal(data, &settings); err != nil {
+			check.Details = append(check.Details, fmt.Sprintf("%s: %v", name, err))
+		}
+	}
+
+	switch {
+	case len(check.Details) > 0:
+		check.Status = verifyFail
+		check.Message = "invalid Claude settings"
+	case found == 0:
+		check.Message = "no settings files"
+	default:
+		check.Message = fmt.Sprintf("%d settings files valid", found)
+	}
+	return check
+}
+
+func verifyProject(installDir, projectDir string) verifyCheck {
+	check := verifyCheck{Name: "project"}
+
+	claudeDir := pathsutil.ClaudeDir(projectDir)
+	if _, err := os.Stat(claudeDir); os.IsNotExist(err) {
+		check.Status = verifyWarn
+		check.Message = "current directory is not integrated; run 'crew claude --install'"
+		return check
+	}
+
+	integration, err := claude.NewClaudeIntegration(filepath.Join(installDir, "commands", "crew"), claudeDir)
+	if err != nil {
+		check.Status = verifyFail
+		check.Message = fmt.Sprintf("cannot load crew commands: %v", err)
+		return check
+	}
+	status, err := integration.CheckIntegration()
+	if err != nil {
+		check.Status = verifyFail
+		check.Message = fmt.Sprintf("integration check failed: %v", err)
+		return check
+	}
+
+	check.Details = status.Issues
+	switch {
+	case len(status.Issues) > 0:
+		check.Status = verifyFail
+		check.Message = "project integration has issues"
+	case !status.Installed:
+		check.Status = verifyWarn
+		check.Message = "current directory is not integrated; run 'crew claude --install'"
+	default:
+		check.Status = verifyPass
+		check.Message = fmt.Sprintf("integrated with %d commands", status.CommandCount)
+	}
+	return check
+}
+
+// verifyMCP checks the tools the MCP servers run on, but only when the
+// mcp component is installed
+func verifyMCP(meta *metadata.UnifiedMetadata, validator *core.Validator) verifyCheck {
+	check := verifyCheck{Name: "mcp", Status: verifyPass}
+	if meta == nil {
+		check.Message = "mcp component not installed"
+		return check
+	}
+	if _, ok := meta.Components["mcp"]; !ok {
+		check.Message = "mcp component not installed"
+		return check
+	}
+
+	requirements := core.NewMCPComponent().Metadata.Requirements
+	results := validator.ValidateRequirements([]string{"mcp"}, map[string]map[string]string{"mcp": requirements})
+	for _, result := range results {
+		if !result.Satisfied {
+			check.Details = append(check.Details, result.Message)
+		}
+	}
+
+	if len(check.Details) > 0 {
+		check.Status = verifyFail
+		check.Message = "MCP servers cannot start"
+	} else {
+		check.Message = fmt.Sprintf("%d required tools available", len(results))
+	}
+	return check
+}
+
+// verifyCompletion looks for the shellenv block in the user's rc file, a
+// script from 'crew completion install' and completion scripts installed
+// by the Claude integration
+func verifyCompletion(installDir string) verifyCheck {
+	check := verifyCheck{Name: "completion"}
+
+	if shell, err := resolveShell(""); err == nil {
+		if rcFile, err := defaultRCFile(shell); err == nil {
+			if data, err := os.ReadFile(rcFile); err == nil && strings.Contains(string(data), shellenvBeginMarker) {
+				check.Status = verifyPass
+				check.Message = fmt.Sprintf("shell integration installed in %s", rcFile)
+				return check
+			}
+		}
+		if home, err := os.UserHomeDir(); err == nil {
+			target, err := detectCompletionTarget(shell, runtime.GOOS, home, os.Getenv, CompletionInstallFlags{})
+			if _, statErr := os.Stat(target.File); err == nil && statErr == nil {
+				check.Status = verifyPass
+				check.Message = fmt.Sprintf("%s completion installed in %s", shell, target.File)
+				return check
+			}
+		}
+	}
+
+	completionDir := claude.NewPathResolver(installDir).GetCompletionsDir()
+	if entries, err := os.ReadDir(completionDir); err == nil && len(entries) > 0 {
+		check.Status = verifyPass
+		check.Message = fmt.Sprintf("completion scripts installed in %s", completionDir)
+		return check
+	}
+
+	check.Status = verifyWarn
+	check.Message = "shell completion not installed; run 'crew completion install'"
+	return check
+}
+
+func displayVerifyReport(w io.Writer, report verifyReport) {
+	icons := map[string]string{
+		verifyPass: ui.Icons.StatusOK,
+		verifyWarn: ui.Icons.StatusWarn,
+		verifyFail: ui.Icons.StatusError,
+	}
+
+	fmt.Fprintf(w, "Install directory: %s\n", report.InstallDir)
+	fmt.Fprintf(w, "Project directory: %s\n\n", report.ProjectDir)
+	for _, check := range report.Checks {
+		fmt.Fprintf(w, "%s %-12s %s\n", icons[check.Status], check.Name, check.Message)
+		for _, detail := range check.Details {
+			fmt.Fprintf(w, "    - %s\n", detail)
+		}
+	}
+
+	counts := make(map[string]int)
+	for _, check := range report.Checks {
+		counts[check.Status]++
+	}
+	fmt.Fprintf(w, "\n%s %s: %d passed, %d warnings, %d failed\n",
+		icons[report.Status], strings.ToUpper(report.Status),
+		counts[verifyPass], counts[verifyWarn], counts[verifyFail])
+}
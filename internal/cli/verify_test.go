@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/core"
+)
+
+func TestWorstStatus(t *testing.T) {
+	tests := []struct {
+		statuses []string
+		expected string
+	}{
+		{nil, verifyPass},
+		{[]string{verifyPass, verifyPass}, verifyPass},
+		{[]string{verifyPass, verifyWarn}, verifyWarn},
+		{[]string{verifyWarn, verifyFail, verifyPass}, verifyFail},
+	}
+	for _, tt := range tests {
+		var checks []verifyCheck
+		for _, status := range tt.statuses {
+			checks = append(checks, verifyCheck{Status: status})
+		}
+		if got := worstStatus(checks); got != tt.expected {
+			t.Errorf("worstStatus(%v) = %s, expected %s", tt.statuses, got, tt.expected)
+		}
+	}
+}
+
+func TestBuildVerifyReportWithoutInstallation(t *testing.T) {
+	installDir := t.TempDir()
+	report := buildVerifyReport("1.0.0", installDir, t.TempDir(), core.NewValidator())
+
+	if report.Status != verifyFail {
+		t.Errorf("Expected a missing installation to fail, got %s", report.Status)
+	}
+	checks := make(map[string]verifyCheck)
+	for _, check := range report.Checks {
+		checks[check.Name] = check
+	}
+	if checks["metadata"].Status != verifyFail {
+		t.Errorf("Expected the metadata check to fail, got %+v", checks["metadata"])
+	}
+	if checks["project"].Status != verifyWarn {
+		t.Errorf("Expected an unintegrated project to warn, got %+v", checks["project"])
+	}
+}
+
+func TestVerifySettingsRejectsInvalidJSON(t *testing.T) {
+	installDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(installDir, "settings.json"), []byte(`{"hooks": {}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if check := verifySettings(installDir); check.Status != verifyPass {
+		t.Errorf("Expected valid settings to pass, got %+v", check)
+	}
+
+	if err := os.WriteFile(filepath.Join(installDir, "settings.local.json"), []byte(`{"hooks":`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if check := verifySettings(installDir); check.Status != verifyFail || len(check.Details) != 1 {
+		t.Errorf("Expected invalid settings to fail, got %+v", check)
+	}
+}
@@ -1,11 +1,18 @@
 package cli
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"os"
 	"strings"
 
+	"github.com/jonwraymond/claude-code-super-crew/internal/buildinfo"
+	"github.com/jonwraymond/claude-code-super-crew/internal/releases"
+	"github.com/jonwraymond/claude-code-super-crew/internal/semver"
 	"github.com/jonwraymond/claude-code-super-crew/internal/ui"
 	"github.com/jonwraymond/claude-code-super-crew/internal/versioning"
+	"github.com/jonwraymond/claude-code-super-crew/pkg/logger"
 	"github.com/spf13/cobra"
 )
 
@@ -14,10 +21,39 @@ type VersionFlags struct {
 	Components bool
 	All        bool
 	Check      bool
+	JSON       bool
 }
 
 var versionFlags VersionFlags
 
+// versionComponents are the components whose versions are reported
+var versionComponents = []string{"core", "commands", "hooks", "mcp"}
+
+// versionReport is the --json output of the version command
+type versionReport struct {
+	Binary     buildinfo.Info    `json:"binary"`
+	Framework  string            `json:"framework"`
+	InstallDir string            `json:"install_dir"`
+	Components map[string]string `json:"components"`
+	Check      *versionCheck     `json:"check,omitempty"`
+}
+
+// Update check statuses
+const (
+	versionUpToDate        = "up-to-date"
+	versionUpdateAvailable = "update-available"
+	versionAhead           = "ahead"
+)
+
+// versionCheck compares the running binary with the latest release
+type versionCheck struct {
+	Status  string `json:"status"`
+	Current string `json:"current"`
+	Latest  string `json:"latest"`
+	Dev     bool   `json:"dev"`
+	URL     string `json:"url,omitempty"`
+}
+
 // NewVersionCommand creates the version command
 func NewVersionCommand() *cobra.Command {
 	cmd := &cobra.Command{
@@ -29,8 +65,11 @@ Examples:
   crew version                  # Show framework version
   crew version --components     # Show all component versions
   crew version --all           # Show detailed version information
-  crew version --check         # Check if updates are available`,
-		RunE: runVersion,
+  crew version --check         # Compare with the latest release
+  crew version --check --json  # Update status for scripts and CI
+  crew version --json          # Binary build and installed versions as JSON`,
+		RunE:         runVersion,
+		SilenceUsage: true,
 	}
 
 	cmd.Flags().BoolVar(&versionFlags.Components, "components", false,
@@ -38,7 +77,9 @@ Examples:
 	cmd.Flags().BoolVar(&versionFlags.All, "all", false,
 		"Show all version information")
 	cmd.Flags().BoolVar(&versionFlags.Check, "check", false,
-		"Check for available updates")
+		"Compare the binary with the latest release")
+	cmd.Flags().BoolVar(&versionFlags.JSON, "json", false,
+		"Output version information as JSON")
 
 	return cmd
 }
@@ -52,6 +93,28 @@ func runVersion(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to get version: %w", err)
 	}
 
+	if versionFlags.JSON || globalFlags.Output == "json" {
+		report := versionReport{
+			Binary:     buildinfo.Get(),
+			Framework:  currentVersion,
+			InstallDir: globalFlags.InstallDir,
+			Components: make(map[string]string),
+		}
+		for _, comp := range versionComponents {
+			if version, err := versionManager.GetComponentVersion(comp); err == nil && version != "" {
+				report.Components[comp] = version
+			}
+		}
+		if versionFlags.Check {
+			if report.Check, err = checkLatestVersion(cmd.Context(), releases.NewFeed(), report.Binary); err != nil {
+				return err
+			}
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	}
+
 	if !versionFlags.Components && !versionFlags.All && !versionFlags.Check {
 		// Simple version display
 		fmt.Printf("Claude Code Super Crew v%s\n", currentVersion)
@@ -71,8 +134,7 @@ func runVersion(cmd *cobra.Command, args []string) error {
 		fmt.Printf("\n%sComponent Versions:%s\n", ui.ColorCyan, ui.ColorReset)
 		fmt.Println(strings.Repeat("-", 30))
 		
-		components := []string{"core", "commands", "hooks", "mcp"}
-		for _, comp := range components {
+		for _, comp := range versionComponents {
 			version, err := versionManager.GetComponentVersion(comp)
 			if err != nil {
 				fmt.Printf("  %-10s: %s\n", comp, "unknown")
@@ -84,6 +146,8 @@ func runVersion(cmd *cobra.Command, args []string) error {
 
 	// Show detailed information if requested
 	if versionFlags.All {
+		displayBuildInfo(buildinfo.Get())
+
 		metadata, err := versionManager.LoadMetadata()
 		if err == nil {
 			fmt.Printf("\n%sInstallation Details:%s\n", ui.ColorCyan, ui.ColorReset)
@@ -120,25 +184,105 @@ func runVersion(cmd *cobra.Command, args []string) error {
 	// Check for updates if requested
 	if versionFlags.Check {
 		fmt.Printf("\n%sChecking for updates...%s\n", ui.ColorCyan, ui.ColorReset)
-		
-		// For now, we'll check against a fixed target version
-		// In production, this would check against a remote repository
-		targetVersion := "1.0.1" // This would come from a remote source
-		
-		updateInfo, err := versionManager.CheckUpdateStatus(targetVersion)
+
+		check, err := checkLatestVersion(cmd.Context(), releases.NewFeed(), buildinfo.Get())
 		if err != nil {
-			return fmt.Errorf("failed to check for updates: %w", err)
-		}
-		
-		if updateInfo.UpdateAvailable {
-			fmt.Printf("\n%sUpdate available:%s\n", ui.ColorGreen, ui.ColorReset)
-			fmt.Printf("  Current:   v%s\n", updateInfo.CurrentVersion)
-			fmt.Printf("  Available: v%s\n", updateInfo.AvailableVersion)
-			fmt.Printf("\nRun 'crew update' to update to the latest version.\n")
-		} else {
-			fmt.Printf("\n%sYou are running the latest version.%s\n", ui.ColorGreen, ui.ColorReset)
+			return err
 		}
+		displayVersionCheck(check)
 	}
 
 	return nil
-}
\ No newline at end of file
+}
+
+// displayBuildInfo prints how the running binary was built
+func displayBuildInfo(info buildinfo.Info) {
+	fmt.Printf("\n%sBinary:%s\n", ui.ColorCyan, ui.ColorReset)
+	fmt.Println(strings.Repeat("-", 30))
+
+	version := "v" + info.Version
+	if info.Dev {
+		version += fmt.Sprintf(" %s(development build)%s", ui.ColorYellow, ui.ColorReset)
+	}
+	fmt.Printf("  Version:     %s\n", version)
+	if info.Commit != "" {
+		commit := info.ShortCommit()
+		if info.Modified {
+			commit += " (modified)"
+		}
+		fmt.Printf("  Commit:      %s\n", commit)
+	}
+	if info.Date != "" {
+		fmt.Printf("  Built:       %s\n", info.Date)
+	}
+	fmt.Printf("  Go:          %s %s\n", info.GoVersion, info.Platform)
+}
+
+// checkLatestVersion asks the release feed for the latest release and
+// compares it with the running binary
+func checkLatestVersion(ctx context.Context, feed *releases.Feed, info buildinfo.Info) (*versionCheck, error) {
+	release, err := feed.Latest(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check for updates: %w", err)
+	}
+
+	check := &versionCheck{
+		Current: info.Version,
+		Latest:  release.Version(),
+		Dev:     info.Dev,
+		URL:     release.URL,
+	}
+	switch c := semver.Compare(check.Latest, check.Current); {
+	case c > 0:
+		check.Status = versionUpdateAvailable
+	case c < 0:
+		check.Status = versionAhead
+	default:
+		check.Status = versionUpToDate
+	}
+
+	recordLatestVersion(check.Latest)
+	return check, nil
+}
+
+func displayVersionCheck(check *versionCheck) {
+	current := "v" + check.Current
+	if check.Dev {
+		current += fmt.Sprintf(" %s(development build)%s", ui.ColorYellow, ui.ColorReset)
+	}
+	fmt.Printf("  Current:   %s\n", current)
+	fmt.Printf("  Latest:    v%s\n", check.Latest)
+
+	switch check.Status {
+	case versionUpdateAvailable:
+		fmt.Printf("\n%sUpdate available:%s v%s\n", ui.ColorGreen, ui.ColorReset, check.Latest)
+		if check.URL != "" {
+			fmt.Printf("  Download it from %s\n", check.URL)
+		}
+	case versionAhead:
+		fmt.Printf("\n%sYou are running a build newer than the latest release.%s\n", ui.ColorYellow, ui.ColorReset)
+	default:
+		fmt.Printf("\n%sYou are running the latest version.%s\n", ui.ColorGreen, ui.ColorReset)
+	}
+	if check.Dev && check.Status != versionAhead {
+		fmt.Println("  Development builds are not tied to a release; versions are approximate.")
+	}
+}
+
+// recordLatestVersion remembers the newest available version for the shell
+// prompt segment. Installs that have not enabled the prompt are left alone.
+func recordLatestVersion(latest string) {
+	if ciMode() {
+		return
+	}
+	if _, err := os.Stat(getPromptCacheFile(globalFlags.InstallDir)); err != nil {
+		return
+	}
+	if err := os.WriteFile(getLatestVersionFile(), []byte(latest+"\n"), 0644); err != nil {
+		logger.GetLogger().Debugf("Failed to record latest version: %v", err)
+		return
+	}
+	if err := refreshPromptCache(); err != nil {
+		logger.GetLogger().Debugf("Failed to refresh prompt cache: %v", err)
+	}
+}
@@ -0,0 +1,44 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/buildinfo"
+	"github.com/jonwraymond/claude-code-super-crew/internal/releases"
+)
+
+func TestCheckLatestVersion(t *testing.T) {
+	originalFlags := globalFlags
+	defer func() { globalFlags = originalFlags }()
+	globalFlags.InstallDir = t.TempDir()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"tag_name": "v1.2.0", "html_url": "https://example.com/releases/v1.2.0"}`)
+	}))
+	defer server.Close()
+	feed := &releases.Feed{URL: server.URL}
+
+	tests := []struct {
+		current string
+		want    string
+	}{
+		{"1.1.0", versionUpdateAvailable},
+		{"1.2.0", versionUpToDate},
+		{"1.3.0-rc.1", versionAhead},
+	}
+	for _, tt := range tests {
+		t.Run(tt.current, func(t *testing.T) {
+			check, err := checkLatestVersion(context.Background(), feed, buildinfo.Info{Version: tt.current, Dev: true})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if check.Status != tt.want || check.Latest != "1.2.0" || !check.Dev {
+				t.Errorf("Unexpected check %+v, want status %s", check, tt.want)
+			}
+		})
+	}
+}
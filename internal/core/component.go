@@ -9,27 +9,20 @@
 package core
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 
+	"github.com/jonwraymond/claude-code-super-crew/internal/fsys"
 	"github.com/jonwraymond/claude-code-super-crew/internal/managers"
+	"github.com/jonwraymond/claude-code-super-crew/pkg/component"
 )
 
-// ComponentMetadata holds metadata about a component
-type ComponentMetadata struct {
-	Name         string            `json:"name"`
-	Version      string            `json:"version"`
-	Description  string            `json:"description"`
-	Category     string            `json:"category"`
-	Author       string            `json:"author,omitempty"`
-	URL          string            `json:"url,omitempty"`
-	Tags         []string          `json:"tags,omitempty"`
-	Dependencies []string          `json:"dependencies,omitempty"`
-	Conflicts    []string          `json:"conflicts,omitempty"`
-	Requirements map[string]string `json:"requirements,omitempty"`
-}
+// ComponentMetadata holds metadata about a component. It is the public
+// component.Metadata so external components share the same type.
+type ComponentMetadata = component.Metadata
 
 // ComponentFactory creates component instances
 type ComponentFactory func(installDir, sourceDir string) Component
@@ -40,13 +33,13 @@ type Component interface {
 	GetMetadata() ComponentMetadata
 
 	// Install installs the component
-	Install(installDir string, config map[string]interface{}) error
+	Install(ctx context.Context, installDir string, config map[string]interface{}) error
 
 	// Update updates the component
-	Update(installDir string, config map[string]interface{}) error
+	Update(ctx context.Context, installDir string, config map[string]interface{}) error
 
 	// Uninstall removes the component
-	Uninstall(installDir string, config map[string]interface{}) error
+	Uninstall(ctx context.Context, installDir string, config map[string]interface{}) error
 
 	// Validate checks if component can be installed
 	Validate(installDir string) error
@@ -71,10 +64,7 @@ type Component interface {
 }
 
 // FilePair represents a source and destination file pair
-type FilePair struct {
-	Source string
-	Target string
-}
+type FilePair = component.FilePair
 
 // BaseComponent provides common functionality for components
 type BaseComponent struct {
@@ -154,6 +144,22 @@ func (b *BaseComponent) InitManagers(installDir string) {
 	}
 }
 
+// SetFS makes the component install its files into fs and keep their
+// inventory and its version in the installation metadata there. The
+// installer calls it for components registered with an Installer that
+// was given another filesystem than the OS one.
+func (b *BaseComponent) SetFS(fs fsys.FS) {
+	b.FileManager = managers.NewFileManagerWithMetadataFS(b.InstallDir, fs)
+	b.SettingsManager = managers.NewSettingsManagerFS(b.InstallDir, fs)
+}
+
+// applyLinkMode switches the file manager to symlinking when config sets
+// dev_link, as crew install --dev-link does
+func (b *BaseComponent) applyLinkMode(config map[string]interface{}) {
+	link, _ := config["dev_link"].(bool)
+	b.FileManager.SetLinkMode(link)
+}
+
 // ValidatePrerequisites provides base validation logic
 func (b *BaseComponent) ValidatePrerequisites(installDir string) (bool, []string) {
 	var errors []string
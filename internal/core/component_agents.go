@@ -1,11 +1,14 @@
 package core
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 
+	"github.com/jonwraymond/claude-code-super-crew/internal/crewdirs"
+	"github.com/jonwraymond/claude-code-super-crew/internal/semver"
 	"github.com/jonwraymond/claude-code-super-crew/pkg/logger"
 )
 
@@ -29,12 +32,12 @@ func NewAgentsComponent(installDir, sourceDir string) *AgentsComponent {
 				Description:  "Claude Code Super Crew persona subagent files and templates",
 				Category:     "agents",
 				Author:       "Claude Code Super Crew Team",
-				Tags:         []string{"personas", "subagents", "templates"},
+				Tags:         []string{"agents", "personas", "subagents", "templates", TagRecommended},
 				Dependencies: []string{"core"}, // Agents depend on core being installed
 			},
 		},
 		sourceDir: sourceDir,
-		log:       logger.GetLogger(),
+		log:       logger.With("component", "agents"),
 	}
 
 	// Initialize managers
@@ -158,9 +161,11 @@ func (c *AgentsComponent) ValidatePrerequisites(installDir string) (bool, []stri
 }
 
 // Install creates the agents directory and installs agent files
-func (c *AgentsComponent) Install(installDir string, config map[string]interface{}) error {
+func (c *AgentsComponent) Install(ctx context.Context, installDir string, config map[string]interface{}) error {
 	c.log.Info("=== AGENTS COMPONENT INSTALL METHOD CALLED ===")
 	c.log.Info(fmt.Sprintf("Installing agents component version %s", c.Metadata.Version))
+	c.applyLinkMode(config)
+	c.applyItemFilter(config)
 
 	// Validate prerequisites
 	isValid, errors := c.ValidatePrerequisites(installDir)
@@ -182,6 +187,9 @@ func (c *AgentsComponent) Install(installDir string, config map[string]interface
 	successCount := 0
 
 	for _, pair := range filesToInstall {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 		c.log.Debug(fmt.Sprintf("Installing agent file from %s to %s", pair.Source, pair.Target))
 
 		// Ensure target directory exists (for subdirectories like templates/)
@@ -191,14 +199,17 @@ func (c *AgentsComponent) Install(installDir string, config map[string]interface
 			continue
 		}
 
-		if err := c.FileManager.CopyFile(pair.Source, pair.Target); err != nil {
+		if err := c.FileManager.CopyFileWithInventory(pair.Source, pair.Target); err != nil {
 			c.log.Error(fmt.Sprintf("Failed to install agent file %s: %v", filepath.Base(pair.Source), err))
 			continue
 		}
 
-		// Set appropriate permissions for agent files
-		if err := os.Chmod(pair.Target, 0644); err != nil {
-			c.log.Warn(fmt.Sprintf("Failed to set permissions on agent file %s: %v", filepath.Base(pair.Target), err))
+		// Set appropriate permissions for agent files; a linked file keeps
+		// the mode it has in the checkout
+		if !c.FileManager.Linking() {
+			if err := os.Chmod(pair.Target, 0644); err != nil {
+				c.log.Warn(fmt.Sprintf("Failed to set permissions on agent file %s: %v", filepath.Base(pair.Target), err))
+			}
 		}
 
 		successCount++
@@ -220,14 +231,14 @@ func (c *AgentsComponent) Install(installDir string, config map[string]interface
 }
 
 // Update backs up existing agent files and installs the new version
-func (c *AgentsComponent) Update(installDir string, config map[string]interface{}) error {
+func (c *AgentsComponent) Update(ctx context.Context, installDir string, config map[string]interface{}) error {
 	c.log.Info(fmt.Sprintf("Updating agents component from version %s to %s",
 		c.GetInstalledVersion(installDir), c.Metadata.Version))
 
 	// Create backup of existing agent files
 	agentsDir := filepath.Join(installDir, "agents")
 	if c.FileManager.IsDirectory(agentsDir) {
-		backupDir := filepath.Join(installDir, ".crew", "backups", fmt.Sprintf("agents-backup-%s", c.GetInstalledVersion(installDir)))
+		backupDir := crewdirs.Path(installDir, "backups", fmt.Sprintf("agents-backup-%s", c.GetInstalledVersion(installDir)))
 		if err := c.FileManager.EnsureDirectory(backupDir); err == nil {
 			// Copy existing agent files to backup
 			if entries, err := os.ReadDir(agentsDir); err == nil {
@@ -244,11 +255,11 @@ func (c *AgentsComponent) Update(installDir string, config map[string]interface{
 	}
 
 	// Perform installation (will overwrite existing files)
-	return c.Install(installDir, config)
+	return c.Install(ctx, installDir, config)
 }
 
 // Uninstall removes agent files but preserves user-created agent files
-func (c *AgentsComponent) Uninstall(installDir string, config map[string]interface{}) error {
+func (c *AgentsComponent) Uninstall(ctx context.Context, installDir string, config map[string]interface{}) error {
 	c.log.Info("Uninstalling agents component")
 
 	agentsDir := filepath.Join(installDir, "agents")
@@ -477,7 +488,7 @@ func (c *AgentsComponent) RequiresUpdate(installDir string) (bool, string, error
 	}
 
 	// Compare with available version
-	if installedVersion != c.Metadata.Version {
+	if semver.Compare(installedVersion, c.Metadata.Version) < 0 {
 		return true, c.Metadata.Version, nil
 	}
 
@@ -500,10 +511,9 @@ func (c *AgentsComponent) GetUpdateStrategy(installDir string) string {
 	}
 
 	// Check if this is a major version change
-	installedMajor := strings.Split(installedVersion, ".")[0]
-	newMajor := strings.Split(c.Metadata.Version, ".")[0]
-
-	if installedMajor != newMajor {
+	installed, errInstalled := semver.ParseTolerant(installedVersion)
+	available, errAvailable := semver.ParseTolerant(c.Metadata.Version)
+	if errInstalled != nil || errAvailable != nil || installed.Major != available.Major {
 		return "upgrade" // Major version upgrade
 	}
 
@@ -518,7 +528,7 @@ func (c *AgentsComponent) PrepareForUpdate(installDir string) error {
 	}
 
 	// Ensure backup directory exists
-	backupDir := filepath.Join(installDir, ".crew", "backups")
+	backupDir := crewdirs.Path(installDir, "backups")
 	if err := c.FileManager.EnsureDirectory(backupDir); err != nil {
 		return fmt.Errorf("failed to create backup directory: %w", err)
 	}
@@ -1,6 +1,7 @@
 package core
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -28,12 +29,12 @@ func NewCommandsComponent(installDir, sourceDir string) *CommandsComponent {
 				Description:  "Claude Code Super Crew command library",
 				Category:     "extension",
 				Author:       "Claude Code Super Crew Team",
-				Tags:         []string{"commands", "slash-commands"},
+				Tags:         []string{"commands", "slash-commands", TagRecommended},
 				Dependencies: []string{"core"},
 			},
 		},
 		sourceDir: sourceDir,
-		log:       logger.GetLogger(),
+		log:       logger.With("component", "commands"),
 	}
 
 	// Initialize managers
@@ -93,7 +94,7 @@ func (c *CommandsComponent) ValidatePrerequisites(installDir string) (bool, []st
 }
 
 // Install creates the commands directory and installs command files
-func (c *CommandsComponent) Install(installDir string, config map[string]interface{}) error {
+func (c *CommandsComponent) Install(ctx context.Context, installDir string, config map[string]interface{}) error {
 	// Check for dry-run mode
 	dryRun := false
 	if dryRunVal, exists := config["dry_run"]; exists {
@@ -108,6 +109,8 @@ func (c *CommandsComponent) Install(installDir string, config map[string]interfa
 	}
 
 	c.log.Info(fmt.Sprintf("Installing commands component version %s", c.Metadata.Version))
+	c.applyLinkMode(config)
+	c.applyItemFilter(config)
 
 	// Validate prerequisites
 	isValid, errors := c.ValidatePrerequisites(installDir)
@@ -129,6 +132,9 @@ func (c *CommandsComponent) Install(installDir string, config map[string]interfa
 	successCount := 0
 
 	for _, pair := range filesToInstall {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 		c.log.Debug(fmt.Sprintf("Copying file from %s to %s", pair.Source, pair.Target))
 
 		if err := c.FileManager.CopyFileWithInventory(pair.Source, pair.Target); err != nil {
@@ -153,13 +159,13 @@ func (c *CommandsComponent) Install(installDir string, config map[string]interfa
 }
 
 // Update installs the new version of commands
-func (c *CommandsComponent) Update(installDir string, config map[string]interface{}) error {
+func (c *CommandsComponent) Update(ctx context.Context, installDir string, config map[string]interface{}) error {
 	// Simply reinstall for now
-	return c.Install(installDir, config)
+	return c.Install(ctx, installDir, config)
 }
 
 // Uninstall removes the commands directory
-func (c *CommandsComponent) Uninstall(installDir string, config map[string]interface{}) error {
+func (c *CommandsComponent) Uninstall(ctx context.Context, installDir string, config map[string]interface{}) error {
 	cmdDir := filepath.Join(installDir, ".claude", "commands")
 	if err := os.RemoveAll(cmdDir); err != nil {
 		return fmt.Errorf("failed to remove commands directory: %w", err)
@@ -1,12 +1,14 @@
 package core
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 	"time"
 
+	"github.com/jonwraymond/claude-code-super-crew/internal/crewdirs"
 	"github.com/jonwraymond/claude-code-super-crew/internal/metadata"
 	"github.com/jonwraymond/claude-code-super-crew/pkg/logger"
 )
@@ -31,11 +33,11 @@ func NewCoreComponent(installDir, sourceDir string) *CoreComponent {
 				Description: "Core Claude Code Super Crew framework files",
 				Category:    "core",
 				Author:      "Claude Code Super Crew Team",
-				Tags:        []string{"essential", "framework"},
+				Tags:        []string{"essential", "framework", TagRecommended},
 			},
 		},
 		sourceDir: sourceDir,
-		log:       logger.GetLogger(),
+		log:       logger.With("component", "core"),
 	}
 
 	// Initialize managers
@@ -113,7 +115,7 @@ func (c *CoreComponent) ValidatePrerequisites(installDir string) (bool, []string
 }
 
 // Install creates the core directory structure and installs framework files
-func (c *CoreComponent) Install(installDir string, config map[string]interface{}) error {
+func (c *CoreComponent) Install(ctx context.Context, installDir string, config map[string]interface{}) error {
 	c.log.Info("=== CORE COMPONENT INSTALL METHOD CALLED ===")
 	c.log.Info(fmt.Sprintf("Installing core component version %s", c.Metadata.Version))
 
@@ -131,6 +133,8 @@ func (c *CoreComponent) Install(installDir string, config map[string]interface{}
 	}
 
 	// FileManager is already initialized with metadata tracking via InitManagers
+	c.applyLinkMode(config)
+	c.applyItemFilter(config)
 
 	// Validate prerequisites
 	isValid, errors := c.ValidatePrerequisites(installDir)
@@ -149,14 +153,14 @@ func (c *CoreComponent) Install(installDir string, config map[string]interface{}
 		filepath.Join(installDir, "hooks"),
 		filepath.Join(installDir, "agents"),
 		// Utility directories (moved to .crew/)
-		filepath.Join(installDir, ".crew"),
-		filepath.Join(installDir, ".crew", "backups"),
-		filepath.Join(installDir, ".crew", "logs"),
-		filepath.Join(installDir, ".crew", "workflows"),
-		filepath.Join(installDir, ".crew", "scripts"),
-		filepath.Join(installDir, ".crew", "config"),
-		filepath.Join(installDir, ".crew", "prompts"),
-		filepath.Join(installDir, ".crew", "completions"),
+		crewdirs.Path(installDir),
+		crewdirs.Path(installDir, "backups"),
+		crewdirs.Path(installDir, "logs"),
+		crewdirs.Path(installDir, "workflows"),
+		crewdirs.Path(installDir, "scripts"),
+		crewdirs.Path(installDir, "config"),
+		crewdirs.Path(installDir, "prompts"),
+		crewdirs.Path(installDir, "completions"),
 	}
 
 	for _, dir := range dirs {
@@ -187,6 +191,9 @@ func (c *CoreComponent) Install(installDir string, config map[string]interface{}
 	}
 
 	for _, pair := range filesToInstall {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 		c.log.Debug(fmt.Sprintf("Copying file from %s to %s", pair.Source, pair.Target))
 
 		// Handle CLAUDE.md files based on flags
@@ -205,7 +212,7 @@ func (c *CoreComponent) Install(installDir string, config map[string]interface{}
 				c.log.Debug(fmt.Sprintf("Successfully overwrote file %s", filepath.Base(pair.Source)))
 			} else {
 				// Default: merge functionality for CLAUDE.md files to preserve user content
-				if err := c.FileManager.CopyFileWithMerge(pair.Source, pair.Target); err != nil {
+				if err := c.FileManager.MergeClaudeFile(pair.Source, pair.Target, FrameworkVersion); err != nil {
 					c.log.Error(fmt.Sprintf("Failed to copy/merge file %s: %v", filepath.Base(pair.Source), err))
 					continue
 				}
@@ -233,7 +240,7 @@ func (c *CoreComponent) Install(installDir string, config map[string]interface{}
 	}
 
 	// Create default config.json if it doesn't exist
-	configFile := filepath.Join(installDir, ".crew", "config", "config.json")
+	configFile := crewdirs.Path(installDir, "config", "config.json")
 	if _, err := os.Stat(configFile); os.IsNotExist(err) {
 		defaultConfig := map[string]interface{}{
 			"version":     c.Metadata.Version,
@@ -273,14 +280,14 @@ func (c *CoreComponent) Install(installDir string, config map[string]interface{}
 }
 
 // Update backs up existing files and installs the new version
-func (c *CoreComponent) Update(installDir string, config map[string]interface{}) error {
+func (c *CoreComponent) Update(ctx context.Context, installDir string, config map[string]interface{}) error {
 	// For now, update is the same as install
 	// In production, this would backup existing customizations
-	return c.Install(installDir, config)
+	return c.Install(ctx, installDir, config)
 }
 
 // Uninstall removes core framework files while preserving user data
-func (c *CoreComponent) Uninstall(installDir string, config map[string]interface{}) error {
+func (c *CoreComponent) Uninstall(ctx context.Context, installDir string, config map[string]interface{}) error {
 	// List of core files to remove (preserve user-created content)
 	coreFiles := []string{
 		"CLAUDE.md",
@@ -0,0 +1,115 @@
+package core
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jonwraymond/claude-code-super-crew/pkg/component"
+)
+
+// ExternalComponent adapts a component.Component from the public SDK to the
+// installer's Component interface, adding the installation tracking that
+// external implementations do not have to provide themselves. SDK
+// components take no context; a cancelled context stops them from starting.
+type ExternalComponent struct {
+	BaseComponent
+	impl component.Component
+}
+
+// NewExternalComponent wraps an SDK component
+func NewExternalComponent(installDir string, impl component.Component) *ExternalComponent {
+	return &ExternalComponent{
+		BaseComponent: BaseComponent{
+			Metadata:   impl.Metadata(),
+			InstallDir: installDir,
+		},
+		impl: impl,
+	}
+}
+
+// Unwrap returns the wrapped SDK component
+func (c *ExternalComponent) Unwrap() component.Component {
+	return c.impl
+}
+
+// Install installs the component and records its version
+func (c *ExternalComponent) Install(ctx context.Context, installDir string, config map[string]interface{}) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := c.impl.Install(installDir, config); err != nil {
+		return err
+	}
+	return c.recordVersion(installDir, config)
+}
+
+// Update updates the component and records its new version
+func (c *ExternalComponent) Update(ctx context.Context, installDir string, config map[string]interface{}) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := c.impl.Update(installDir, config); err != nil {
+		return err
+	}
+	return c.recordVersion(installDir, config)
+}
+
+// Uninstall removes the component and its registration
+func (c *ExternalComponent) Uninstall(ctx context.Context, installDir string, config map[string]interface{}) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := c.impl.Uninstall(installDir, config); err != nil {
+		return err
+	}
+	if dryRun, _ := config["dry_run"].(bool); dryRun {
+		return nil
+	}
+	c.InitManagers(installDir)
+	if _, err := c.SettingsManager.RemoveComponentRegistration(c.Metadata.Name); err != nil {
+		return fmt.Errorf("failed to unregister component %s: %w", c.Metadata.Name, err)
+	}
+	return nil
+}
+
+// Validate delegates to the SDK component
+func (c *ExternalComponent) Validate(installDir string) error {
+	return c.impl.Validate(installDir)
+}
+
+// GetSizeEstimate delegates to the SDK component
+func (c *ExternalComponent) GetSizeEstimate() int64 {
+	return c.impl.SizeEstimate()
+}
+
+// GetFilesToInstall returns the SDK component's file manifest
+func (c *ExternalComponent) GetFilesToInstall() []FilePair {
+	return c.impl.FileManifest()
+}
+
+// ValidateInstallation checks the registration and every manifest file
+func (c *ExternalComponent) ValidateInstallation(installDir string) (bool, []string) {
+	c.InitManagers(installDir)
+
+	var errors []string
+	if c.GetInstalledVersion(installDir) == "" {
+		errors = append(errors, "Component not registered in metadata or settings")
+	}
+	for _, pair := range c.GetFilesToInstall() {
+		if !c.FileManager.FileExists(pair.Target) {
+			errors = append(errors, fmt.Sprintf("Missing file: %s", pair.Target))
+		}
+	}
+	return len(errors) == 0, errors
+}
+
+func (c *ExternalComponent) recordVersion(installDir string, config map[string]interface{}) error {
+	if dryRun, _ := config["dry_run"].(bool); dryRun {
+		return nil
+	}
+	c.InitManagers(installDir)
+	if err := c.SettingsManager.UpdateComponentVersion(c.Metadata.Name, c.Metadata.Version); err != nil {
+		return fmt.Errorf("failed to record version of %s: %w", c.Metadata.Name, err)
+	}
+	return nil
+}
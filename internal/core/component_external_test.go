@@ -0,0 +1,46 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDiscoverManifestComponents(t *testing.T) {
+	pluginDir := t.TempDir()
+	for name, manifest := range map[string]string{
+		"reviewers": `{"name": "reviewers", "version": "1.2.0", "dependencies": ["core"], "files": []}`,
+		"shadow":    `{"name": "core", "version": "9.9.9", "files": []}`,
+	} {
+		dir := filepath.Join(pluginDir, name)
+		os.MkdirAll(dir, 0755)
+		os.WriteFile(filepath.Join(dir, "component.json"), []byte(manifest), 0644)
+	}
+
+	registry := NewEnhancedComponentRegistry(t.TempDir())
+	registry.AddDiscoveryDir(pluginDir)
+	if err := registry.DiscoverComponents(); err != nil {
+		t.Fatalf("DiscoverComponents failed: %v", err)
+	}
+
+	meta := registry.GetComponentMetadata("reviewers")
+	if meta == nil || meta.Version != "1.2.0" {
+		t.Fatalf("Expected the reviewers plugin to be registered, got %+v", meta)
+	}
+	if meta := registry.GetComponentMetadata("core"); meta.Version == "9.9.9" {
+		t.Error("Expected a plugin not to replace the built-in core component")
+	}
+
+	order, err := registry.ResolveDependencies([]string{"reviewers"})
+	if err != nil || len(order) != 2 || order[0] != "core" {
+		t.Errorf("Expected core before reviewers, got %v (%v)", order, err)
+	}
+
+	comp, err := registry.GetComponentInstance("reviewers", t.TempDir())
+	if err != nil {
+		t.Fatalf("GetComponentInstance failed: %v", err)
+	}
+	if _, ok := comp.(*ExternalComponent); !ok {
+		t.Errorf("Expected an ExternalComponent, got %T", comp)
+	}
+}
@@ -1,6 +1,7 @@
 package core
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -25,7 +26,7 @@ func NewHooksComponent(installDir, sourceDir string) *HooksComponent {
 				Description:  "Event hooks and automation",
 				Category:     "automation",
 				Author:       "Claude Code Super Crew Team",
-				Tags:         []string{"hooks", "automation", "events"},
+				Tags:         []string{"hooks", "automation", "events", TagRecommended},
 				Dependencies: []string{"core"},
 			},
 		},
@@ -38,7 +39,7 @@ func NewHooksComponent(installDir, sourceDir string) *HooksComponent {
 }
 
 // Install creates the hooks directory structure and installs hook templates
-func (c *HooksComponent) Install(installDir string, config map[string]interface{}) error {
+func (c *HooksComponent) Install(ctx context.Context, installDir string, config map[string]interface{}) error {
 	// Check for dry-run mode
 	dryRun := false
 	if dryRunVal, exists := config["dry_run"]; exists {
@@ -51,6 +52,8 @@ func (c *HooksComponent) Install(installDir string, config map[string]interface{
 		fmt.Printf("[DRY RUN] Would install hooks component files\n")
 		return nil
 	}
+	c.applyLinkMode(config)
+
 	// Create hooks directory structure with inventory tracking
 	dirs := []string{
 		filepath.Join(installDir, "hooks"),
@@ -81,6 +84,9 @@ func (c *HooksComponent) Install(installDir string, config map[string]interface{
 
 	if len(entries) > 0 {
 		for _, entry := range entries {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
 			// Copy .sh scripts and .md documentation files
 			if strings.HasSuffix(entry.Name(), ".sh") || strings.HasSuffix(entry.Name(), ".md") {
 				src := filepath.Join(sourceHooksDir, entry.Name())
@@ -93,8 +99,9 @@ func (c *HooksComponent) Install(installDir string, config map[string]interface{
 					continue
 				}
 
-				// Set executable permissions for .sh files only
-				if strings.HasSuffix(entry.Name(), ".sh") {
+				// Set executable permissions for .sh files only; a linked
+				// script keeps the mode it has in the checkout
+				if strings.HasSuffix(entry.Name(), ".sh") && !c.FileManager.Linking() {
 					if err := os.Chmod(dst, 0755); err != nil {
 						fmt.Printf("Warning: Could not set executable permissions on %s: %v\n", entry.Name(), err)
 					}
@@ -154,14 +161,14 @@ func (c *HooksComponent) Install(installDir string, config map[string]interface{
 }
 
 // Update installs the new version of hooks
-func (c *HooksComponent) Update(installDir string, config map[string]interface{}) error {
+func (c *HooksComponent) Update(ctx context.Context, installDir string, config map[string]interface{}) error {
 	// Preserve user hooks while updating templates
 	// For now, just reinstall
-	return c.Install(installDir, config)
+	return c.Install(ctx, installDir, config)
 }
 
 // Uninstall removes the hooks directory
-func (c *HooksComponent) Uninstall(installDir string, config map[string]interface{}) error {
+func (c *HooksComponent) Uninstall(ctx context.Context, installDir string, config map[string]interface{}) error {
 	hooksDir := filepath.Join(installDir, "hooks")
 
 	// Optionally preserve user-created hooks
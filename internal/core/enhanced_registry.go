@@ -7,6 +7,10 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
+
+	"github.com/jonwraymond/claude-code-super-crew/pkg/component"
+	"github.com/jonwraymond/claude-code-super-crew/pkg/logger"
 )
 
 // EnhancedComponentRegistry provides advanced dependency resolution and component management.
@@ -19,6 +23,7 @@ type EnhancedComponentRegistry struct {
 	versions      map[string]string // Track component versions
 	installed     map[string]bool   // Track installation status
 	dependencies  map[string][]string // Cached dependency graph
+	pluginDirs    []string            // Directories scanned for component manifests
 }
 
 // NewEnhancedComponentRegistry creates a new enhanced component registry
@@ -30,9 +35,24 @@ func NewEnhancedComponentRegistry(componentsDir string) *EnhancedComponentRegist
 		versions:      make(map[string]string),
 		installed:     make(map[string]bool),
 		dependencies:  make(map[string][]string),
+		pluginDirs:    filepath.SplitList(os.Getenv("CREW_COMPONENT_PATH")),
 	}
 }
 
+// AddDiscoveryDir adds a directory whose subdirectories are searched for
+// component.json manifests by DiscoverComponents
+func (r *EnhancedComponentRegistry) AddDiscoveryDir(dir string) {
+	r.pluginDirs = append(r.pluginDirs, dir)
+}
+
+// RegisterExternal registers a component implemented with the public
+// component SDK
+func (r *EnhancedComponentRegistry) RegisterExternal(name string, factory component.Factory) {
+	r.RegisterFactory(name, func(installDir, srcDir string) Component {
+		return NewExternalComponent(installDir, factory(installDir, srcDir))
+	})
+}
+
 // RegisterFactory registers a component factory with version tracking
 func (r *EnhancedComponentRegistry) RegisterFactory(name string, factory ComponentFactory) {
 	r.factories[name] = factory
@@ -43,7 +63,7 @@ func (r *EnhancedComponentRegistry) RegisterFactory(name string, factory Compone
 	r.components[name] = meta
 	r.versions[name] = meta.Version
 	r.installed[name] = false
-	r.dependencies[name] = meta.Dependencies
+	r.dependencies[name] = dependencyNames(meta.Dependencies)
 }
 
 // DiscoverComponents discovers all available components with enhanced metadata
@@ -134,167 +154,70 @@ func (r *EnhancedComponentRegistry) DiscoverComponents() error {
 		return NewAgentsComponent(installDir, srcDir)
 	})
 
+	r.discoverExternalComponents()
 	return nil
 }
 
-// ResolveDependencies resolves component dependencies with cycle detection and returns ordered list
-func (r *EnhancedComponentRegistry) ResolveDependencies(components []string) ([]string, error) {
-	// Build dependency graph with full dependency resolution
-	graph := make(map[string][]string)
-	visited := make(map[string]bool)
-	recStack := make(map[string]bool)
-	result := []string{}
-
-	// Initialize graph with all dependencies
-	allComponents := make(map[string]bool)
-	for _, comp := range components {
-		allComponents[comp] = true
-		if deps, ok := r.dependencies[comp]; ok {
-			graph[comp] = deps
-			// Add dependencies to the component set recursively
-			for _, dep := range deps {
-				allComponents[dep] = true
-				if depDeps, exists := r.dependencies[dep]; exists {
-					graph[dep] = depDeps
-				}
-			}
-		} else {
-			return nil, fmt.Errorf("component %s not found in registry", comp)
-		}
-	}
-
-	// Cycle detection using DFS
-	var detectCycle func(string) bool
-	detectCycle = func(node string) bool {
-		if recStack[node] {
-			return true // Back edge found - cycle detected
-		}
-		if visited[node] {
-			return false
-		}
-
-		visited[node] = true
-		recStack[node] = true
-
-		for _, neighbor := range graph[node] {
-			if detectCycle(neighbor) {
-				return true
-			}
+// discoverExternalComponents registers components compiled in through
+// component.Register and those described by manifests in the discovery
+// directories. Built-in components cannot be replaced; the first plugin
+// with a given name wins.
+func (r *EnhancedComponentRegistry) discoverExternalComponents() {
+	log := logger.GetLogger()
+
+	for _, name := range component.Registered() {
+		if _, exists := r.factories[name]; exists {
+			log.Warnf("Ignoring component %s: the name is already registered", name)
+			continue
 		}
-
-		recStack[node] = false
-		return false
+		factory, _ := component.Lookup(name)
+		r.RegisterExternal(name, factory)
 	}
 
-	// Check for cycles
-	for comp := range allComponents {
-		if detectCycle(comp) {
-			return nil, fmt.Errorf("circular dependency detected involving component: %s", comp)
+	for _, dir := range r.pluginDirs {
+		if dir == "" {
+			continue
 		}
-	}
-
-	// Reset visited for topological sort
-	visited = make(map[string]bool)
-
-	// Topological sort with dependency order calculation
-	var visit func(string) error
-	visit = func(node string) error {
-		if visited[node] {
-			return nil
+		manifests, errs := component.Discover(dir)
+		for _, err := range errs {
+			log.Warnf("Skipping component manifest: %v", err)
 		}
-
-		visited[node] = true
-
-		// Visit dependencies first
-		if deps, ok := graph[node]; ok {
-			for _, dep := range deps {
-				if err := visit(dep); err != nil {
-					return err
-				}
+		for _, m := range manifests {
+			if _, exists := r.factories[m.Name]; exists {
+				log.Warnf("Ignoring component %s in %s: the name is already registered", m.Name, m.Dir())
+				continue
 			}
+			log.Debugf("Discovered component %s %s in %s", m.Name, m.Version, m.Dir())
+			r.RegisterExternal(m.Name, m.Factory())
 		}
-
-		// Add to result if it was in original request or is a dependency
-		if allComponents[node] {
-			result = append(result, node)
-		}
-
-		return nil
 	}
+}
 
-	// Visit all components in deterministic order
-	compList := make([]string, 0, len(allComponents))
-	for comp := range allComponents {
-		compList = append(compList, comp)
-	}
-	sort.Strings(compList)
-
-	for _, comp := range compList {
-		if err := visit(comp); err != nil {
-			return nil, err
-		}
+// ResolveDependencies resolves component dependencies, including version
+// constraints and conflicts, and returns them in installation order
+func (r *EnhancedComponentRegistry) ResolveDependencies(components []string) ([]string, error) {
+	plan, err := r.Solve(components, nil)
+	if err != nil {
+		return nil, err
 	}
-
-	return result, nil
+	return plan.Order, nil
 }
 
 // GetInstallationOrder calculates optimal installation order for parallel installation
 func (r *EnhancedComponentRegistry) GetInstallationOrder(components []string) ([][]string, error) {
-	// Get dependency-resolved order
-	orderedComponents, err := r.ResolveDependencies(components)
+	plan, err := r.Solve(components, nil)
 	if err != nil {
 		return nil, err
 	}
-
-	// Build dependency levels for parallel installation
-	levels := [][]string{}
-	processed := make(map[string]bool)
-	
-	for len(processed) < len(orderedComponents) {
-		currentLevel := []string{}
-		
-		for _, comp := range orderedComponents {
-			if processed[comp] {
-				continue
-			}
-			
-			// Check if all dependencies are processed
-			canInstall := true
-			if deps, ok := r.dependencies[comp]; ok {
-				for _, dep := range deps {
-					if !processed[dep] {
-						canInstall = false
-						break
-					}
-				}
-			}
-			
-			if canInstall {
-				currentLevel = append(currentLevel, comp)
-			}
-		}
-		
-		if len(currentLevel) == 0 {
-			break // Avoid infinite loop
-		}
-		
-		levels = append(levels, currentLevel)
-		for _, comp := range currentLevel {
-			processed[comp] = true
-		}
-	}
-	
-	return levels, nil
+	return plan.Levels, nil
 }
 
 // ValidateDependencies validates that all dependencies are available
 func (r *EnhancedComponentRegistry) ValidateDependencies(components []string) error {
 	for _, comp := range components {
-		if deps, ok := r.dependencies[comp]; ok {
-			for _, dep := range deps {
-				if _, exists := r.factories[dep]; !exists {
-					return fmt.Errorf("component %s depends on unavailable component %s", comp, dep)
-				}
+		for _, dep := range r.dependencies[comp] {
+			if _, exists := r.factories[dep]; !exists {
+				return fmt.Errorf("component %s depends on unavailable component %s", comp, dep)
 			}
 		}
 	}
@@ -321,23 +244,48 @@ func (r *EnhancedComponentRegistry) GetComponentsByCategory() map[string][]strin
 	return categories
 }
 
-// GetConflicts checks for component conflicts
+// ComponentFilter selects components by category and tags. Experimental
+// components are left out unless IncludeExperimental is set.
+type ComponentFilter struct {
+	Category            string
+	Tags                []string // all must match
+	IncludeExperimental bool
+}
+
+// Matches reports whether meta passes the filter
+func (f ComponentFilter) Matches(meta ComponentMetadata) bool {
+	if meta.IsExperimental() && !f.IncludeExperimental {
+		return false
+	}
+	if f.Category != "" && !strings.EqualFold(meta.Category, f.Category) {
+		return false
+	}
+	for _, tag := range f.Tags {
+		if !meta.HasTag(tag) && !strings.EqualFold(meta.Category, tag) {
+			return false
+		}
+	}
+	return true
+}
+
+// FilterComponents returns the sorted names of components matching filter
+func (r *EnhancedComponentRegistry) FilterComponents(filter ComponentFilter) []string {
+	var names []string
+	for _, name := range r.ListComponents() {
+		if filter.Matches(r.components[name]) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// GetConflicts checks for conflicts among components selected together
 func (r *EnhancedComponentRegistry) GetConflicts(components []string) []string {
-	conflicts := []string{}
-	
+	selected := make(map[string]bool)
 	for _, comp := range components {
-		if meta, ok := r.components[comp]; ok {
-			for _, conflict := range meta.Conflicts {
-				for _, other := range components {
-					if other == conflict {
-						conflicts = append(conflicts, fmt.Sprintf("%s conflicts with %s", comp, conflict))
-					}
-				}
-			}
-		}
+		selected[comp] = true
 	}
-	
-	return conflicts
+	return r.findConflicts(selected, nil, selected, nil)
 }
 
 // MarkInstalled marks a component as installed
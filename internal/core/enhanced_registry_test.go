@@ -0,0 +1,35 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFilterComponents(t *testing.T) {
+	r := registryWith(
+		ComponentMetadata{Name: "core", Version: "1.0.0", Category: "core"},
+		ComponentMetadata{Name: "agents", Version: "1.0.0", Category: "agents", Tags: []string{"agents", "personas"}},
+		ComponentMetadata{Name: "mcp", Version: "1.0.0", Category: "integration", Tags: []string{"mcp", "requires-node"}},
+		ComponentMetadata{Name: "swarm", Version: "0.1.0", Category: "agents", Tags: []string{"agents", "Experimental"}},
+	)
+
+	tests := []struct {
+		filter   ComponentFilter
+		expected []string
+	}{
+		{ComponentFilter{}, []string{"agents", "core", "mcp"}},
+		{ComponentFilter{IncludeExperimental: true}, []string{"agents", "core", "mcp", "swarm"}},
+		{ComponentFilter{Tags: []string{"agents"}}, []string{"agents"}},
+		{ComponentFilter{Tags: []string{"agents"}, IncludeExperimental: true}, []string{"agents", "swarm"}},
+		{ComponentFilter{Tags: []string{"agents", "personas"}}, []string{"agents"}},
+		{ComponentFilter{Tags: []string{"REQUIRES-NODE"}}, []string{"mcp"}},
+		{ComponentFilter{Category: "integration"}, []string{"mcp"}},
+		{ComponentFilter{Tags: []string{"core"}}, []string{"core"}},
+		{ComponentFilter{Tags: []string{"missing"}}, nil},
+	}
+	for _, tt := range tests {
+		if got := r.FilterComponents(tt.filter); !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("FilterComponents(%+v) = %v, expected %v", tt.filter, got, tt.expected)
+		}
+	}
+}
@@ -1,12 +1,16 @@
 package core
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
 	"runtime"
-	"strconv"
 	"strings"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/mcpcatalog"
+	"github.com/jonwraymond/claude-code-super-crew/internal/semver"
+	"github.com/jonwraymond/claude-code-super-crew/pkg/component"
 )
 
 // MCPServerInfo represents configuration for an MCP server
@@ -35,7 +39,7 @@ func NewMCPComponent() *MCPComponent {
 				Description:  "MCP server integration (Context7, Sequential, Magic, Playwright)",
 				Category:     "integration",
 				Author:       "Claude Code Super Crew Team",
-				Tags:         []string{"mcp", "servers", "integration", "claude-desktop"},
+				Tags:         []string{"mcp", "servers", "integration", "claude-desktop", component.TagRequiresNode},
 				Dependencies: []string{"core"},
 				Requirements: map[string]string{
 					"node":   ">=18.0.0",
@@ -44,38 +48,31 @@ func NewMCPComponent() *MCPComponent {
 				},
 			},
 		},
-		MCPServers: map[string]MCPServerInfo{
-			"sequential-thinking": {
-				Name:        "sequential-thinking",
-				Description: "Multi-step problem solving and systematic analysis",
-				NPMPackage:  "@modelcontextprotocol/server-sequential-thinking",
-				Required:    true,
-			},
-			"context7": {
-				Name:        "context7",
-				Description: "Official library documentation and code examples",
-				NPMPackage:  "@upstash/context7-mcp",
-				Required:    true,
-			},
-			"magic": {
-				Name:              "magic",
-				Description:       "Modern UI component generation and design systems",
-				NPMPackage:        "@21st-dev/magic",
-				Required:          false,
-				APIKeyEnv:         "TWENTYFIRST_API_KEY",
-				APIKeyDescription: "21st.dev API key for UI component generation",
-			},
-			"playwright": {
-				Name:        "playwright",
-				Description: "Cross-browser E2E testing and automation",
-				NPMPackage:  "@playwright/mcp@latest",
-				Required:    false,
-			},
-		},
+		MCPServers: catalogServers(mcpcatalog.Builtin()),
 	}
 	return comp
 }
 
+// catalogServers are the servers of an MCP catalog the component installs:
+// the npm packages started with npx
+func catalogServers(catalog *mcpcatalog.Catalog) map[string]MCPServerInfo {
+	servers := make(map[string]MCPServerInfo)
+	for _, server := range catalog.Servers() {
+		if server.Package == "" || len(server.Command) > 0 {
+			continue
+		}
+		servers[server.Name] = MCPServerInfo{
+			Name:              server.Name,
+			Description:       server.Description,
+			NPMPackage:        server.PackageSpec(),
+			Required:          server.Required,
+			APIKeyEnv:         server.APIKeyEnv,
+			APIKeyDescription: server.APIKeyDescription,
+		}
+	}
+	return servers
+}
+
 // Validate checks prerequisites for MCP component
 func (c *MCPComponent) Validate(installDir string) error {
 	isValid, errors := c.ValidatePrerequisites(installDir)
@@ -127,15 +124,8 @@ func (c *MCPComponent) checkNodeJS() error {
 
 	version := strings.TrimSpace(string(output))
 	// Check version (require 18+)
-	if len(version) > 1 && version[0] == 'v' {
-		versionParts := strings.Split(version[1:], ".")
-		if len(versionParts) > 0 {
-			if major, err := strconv.Atoi(versionParts[0]); err == nil {
-				if major < 18 {
-					return fmt.Errorf("Node.js version %s found, but version 18+ required", version)
-				}
-			}
-		}
+	if parsed, err := semver.ParseTolerant(version); err == nil && parsed.Major < 18 {
+		return fmt.Errorf("Node.js version %s found, but version 18+ required", version)
 	}
 
 	return nil
@@ -172,7 +162,7 @@ func (c *MCPComponent) checkNPM() error {
 }
 
 // Install installs the MCP component and all servers
-func (c *MCPComponent) Install(installDir string, config map[string]interface{}) error {
+func (c *MCPComponent) Install(ctx context.Context, installDir string, config map[string]interface{}) error {
 	c.InitManagers(installDir)
 
 	// Validate prerequisites
@@ -185,7 +175,13 @@ func (c *MCPComponent) Install(installDir string, config map[string]interface{})
 	var failedServers []string
 
 	for serverName, serverInfo := range c.MCPServers {
-		if err := c.installMCPServer(serverInfo, config); err != nil {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if !c.shouldInstallServer(serverInfo, config) {
+			continue
+		}
+		if err := c.installMCPServer(ctx, serverInfo, config); err != nil {
 			failedServers = append(failedServers, serverName)
 			if serverInfo.Required {
 				return fmt.Errorf("required MCP server %s failed to install: %w", serverName, err)
@@ -214,8 +210,30 @@ func (c *MCPComponent) Install(installDir string, config map[string]interface{})
 	return nil
 }
 
+// shouldInstallServer reports whether a server is part of this install.
+// Required servers always are; optional ones only when listed in the
+// "mcp_servers" config entry. A nil entry means no selection was made and
+// keeps the previous behaviour of installing every server.
+func (c *MCPComponent) shouldInstallServer(serverInfo MCPServerInfo, config map[string]interface{}) bool {
+	if serverInfo.Required {
+		return true
+	}
+
+	selected, ok := config["mcp_servers"].([]string)
+	if !ok || selected == nil {
+		return true
+	}
+
+	for _, name := range selected {
+		if name == serverInfo.Name {
+			return true
+		}
+	}
+	return false
+}
+
 // installMCPServer installs a single MCP server
-func (c *MCPComponent) installMCPServer(serverInfo MCPServerInfo, config map[string]interface{}) error {
+func (c *MCPComponent) installMCPServer(ctx context.Context, serverInfo MCPServerInfo, config map[string]interface{}) error {
 	// Check if already installed
 	if installed, err := c.checkMCPServerInstalled(serverInfo.Name); err == nil && installed {
 		return nil // Already installed
@@ -239,10 +257,10 @@ func (c *MCPComponent) installMCPServer(serverInfo MCPServerInfo, config map[str
 	}
 
 	// Install using Claude CLI
-	cmd := exec.Command("claude", "mcp", "add", "-s", "user", "--",
+	cmd := exec.CommandContext(ctx, "claude", "mcp", "add", "-s", "user", "--",
 		serverInfo.Name, "npx", "-y", serverInfo.NPMPackage)
 	if runtime.GOOS == "windows" {
-		cmd = exec.Command("cmd", "/C",
+		cmd = exec.CommandContext(ctx, "cmd", "/C",
 			fmt.Sprintf("claude mcp add -s user -- %s npx -y %s",
 				serverInfo.Name, serverInfo.NPMPackage))
 	}
@@ -337,12 +355,12 @@ func (c *MCPComponent) getMetadataModifications() map[string]interface{} {
 }
 
 // Update updates the MCP component
-func (c *MCPComponent) Update(installDir string, config map[string]interface{}) error {
+func (c *MCPComponent) Update(ctx context.Context, installDir string, config map[string]interface{}) error {
 	c.InitManagers(installDir)
 
 	// Check current version
 	currentVersion := c.GetInstalledVersion(installDir)
-	if currentVersion == MCPComponentVersion {
+	if currentVersion != "" && semver.Compare(currentVersion, MCPComponentVersion) >= 0 {
 		return nil // Already up to date
 	}
 
@@ -351,16 +369,19 @@ func (c *MCPComponent) Update(installDir string, config map[string]interface{})
 	updatedCount := 0
 
 	for serverName, serverInfo := range c.MCPServers {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 		// Uninstall old version
 		if installed, err := c.checkMCPServerInstalled(serverName); err == nil && installed {
-			if err := c.uninstallMCPServer(serverName); err != nil {
+			if err := c.uninstallMCPServer(ctx, serverName); err != nil {
 				failedServers = append(failedServers, serverName)
 				continue
 			}
 		}
 
 		// Install new version
-		if err := c.installMCPServer(serverInfo, config); err != nil {
+		if err := c.installMCPServer(ctx, serverInfo, config); err != nil {
 			failedServers = append(failedServers, serverName)
 		} else {
 			updatedCount++
@@ -380,12 +401,15 @@ func (c *MCPComponent) Update(installDir string, config map[string]interface{})
 }
 
 // Uninstall removes the MCP component and all servers
-func (c *MCPComponent) Uninstall(installDir string, config map[string]interface{}) error {
+func (c *MCPComponent) Uninstall(ctx context.Context, installDir string, config map[string]interface{}) error {
 	c.InitManagers(installDir)
 
 	// Uninstall each MCP server
 	for serverName := range c.MCPServers {
-		if err := c.uninstallMCPServer(serverName); err != nil {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := c.uninstallMCPServer(ctx, serverName); err != nil {
 			// Log error but continue with other servers
 			fmt.Printf("Warning: failed to uninstall MCP server %s: %v\n", serverName, err)
 		}
@@ -409,15 +433,15 @@ func (c *MCPComponent) Uninstall(installDir string, config map[string]interface{
 }
 
 // uninstallMCPServer removes a single MCP server
-func (c *MCPComponent) uninstallMCPServer(serverName string) error {
+func (c *MCPComponent) uninstallMCPServer(ctx context.Context, serverName string) error {
 	// Check if installed
 	if installed, err := c.checkMCPServerInstalled(serverName); err != nil || !installed {
 		return nil // Not installed or can't check
 	}
 
-	cmd := exec.Command("claude", "mcp", "remove", serverName)
+	cmd := exec.CommandContext(ctx, "claude", "mcp", "remove", serverName)
 	if runtime.GOOS == "windows" {
-		cmd = exec.Command("cmd", "/C", fmt.Sprintf("claude mcp remove %s", serverName))
+		cmd = exec.CommandContext(ctx, "cmd", "/C", fmt.Sprintf("claude mcp remove %s", serverName))
 	}
 
 	output, err := cmd.CombinedOutput()
@@ -454,7 +478,7 @@ func (c *MCPComponent) ValidateInstallation(installDir string) (bool, []string)
 
 	// Check version matches
 	installedVersion := c.GetInstalledVersion(installDir)
-	if installedVersion != MCPComponentVersion {
+	if semver.Compare(installedVersion, MCPComponentVersion) != 0 {
 		errors = append(errors, fmt.Sprintf("Version mismatch: installed %s, expected %s",
 			installedVersion, MCPComponentVersion))
 	}
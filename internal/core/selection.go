@@ -0,0 +1,196 @@
+package core
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ConfigItems is the install and update config key limiting components to
+// some of their items: a map from component name to the glob patterns
+// given with --components component/glob
+const ConfigItems = "items"
+
+// GroupAll is the group of every component
+const GroupAll = "all"
+
+// TagRecommended marks the components a default installation selects; it
+// is also the @recommended group
+const TagRecommended = "recommended"
+
+// Selection is what --components selects
+type Selection struct {
+	// Components are in the order the selectors name them
+	Components []string
+	// Items limits components to the items matching their patterns, for
+	// the config key ConfigItems. Components not in it are selected whole.
+	Items map[string][]string
+}
+
+// SelectComponents resolves --components selectors against the registry.
+// A selector is one of
+//
+//	name            the component
+//	glob            the components whose name matches, e.g. c*
+//	@group          the components tagged group or in that category;
+//	                @all is every component
+//	component/glob  the component, limited to the items matching glob,
+//	                e.g. commands/git*
+//
+// A component selected whole by one selector ignores item selectors for
+// it. Experimental components are only matched by globs and groups when
+// includeExperimental is set. A selector that matches nothing is an error.
+func (r *EnhancedComponentRegistry) SelectComponents(selectors []string, includeExperimental bool) (*Selection, error) {
+	selection := &Selection{}
+	seen := make(map[string]bool)
+	whole := make(map[string]bool)
+	items := make(map[string][]string)
+	add := func(names ...string) {
+		for _, name := range names {
+			if !seen[name] {
+				seen[name] = true
+				selection.Components = append(selection.Components, name)
+			}
+		}
+	}
+	addWhole := func(names ...string) {
+		for _, name := range names {
+			whole[name] = true
+		}
+		add(names...)
+	}
+
+	for _, selector := range selectors {
+		selector = strings.TrimSpace(selector)
+		switch {
+		case selector == "":
+			continue
+
+		case strings.HasPrefix(selector, "@"):
+			group := strings.TrimPrefix(selector, "@")
+			filter := ComponentFilter{IncludeExperimental: includeExperimental}
+			if group != GroupAll {
+				filter.Tags = []string{group}
+			}
+			names := r.FilterComponents(filter)
+			if len(names) == 0 {
+				return nil, fmt.Errorf("component group %s is empty; groups are component tags and categories", selector)
+			}
+			addWhole(names...)
+
+		case strings.Contains(selector, "/"):
+			name, pattern := splitItemSelector(selector)
+			if _, ok := r.components[name]; !ok {
+				return nil, fmt.Errorf("%s: component %s not found in registry", selector, name)
+			}
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, fmt.Errorf("invalid pattern %s: %w", selector, err)
+			}
+			available, err := r.Items(name)
+			if err != nil {
+				return nil, err
+			}
+			if len(available) == 0 {
+				return nil, fmt.Errorf("%s: component %s cannot be installed in part", selector, name)
+			}
+			if len(MatchItems(available, []string{pattern})) == 0 {
+				return nil, fmt.Errorf("%s matches none of the %d items of %s", selector, len(available), name)
+			}
+			items[name] = append(items[name], pattern)
+			add(name)
+
+		case strings.ContainsAny(selector, "*?["):
+			var names []string
+			for _, name := range r.FilterComponents(ComponentFilter{IncludeExperimental: includeExperimental}) {
+				if matched, err := path.Match(selector, name); err != nil {
+					return nil, fmt.Errorf("invalid pattern %s: %w", selector, err)
+				} else if matched {
+					names = append(names, name)
+				}
+			}
+			if len(names) == 0 {
+				return nil, fmt.Errorf("%s matches no component", selector)
+			}
+			addWhole(names...)
+
+		default:
+			addWhole(selector)
+		}
+	}
+
+	for name, patterns := range items {
+		if !whole[name] {
+			if selection.Items == nil {
+				selection.Items = make(map[string][]string)
+			}
+			selection.Items[name] = patterns
+		}
+	}
+	return selection, nil
+}
+
+// splitItemSelector splits component/glob; a bare component/ selects every
+// item
+func splitItemSelector(selector string) (string, string) {
+	name, pattern := selector, "*"
+	if i := strings.Index(selector, "/"); i >= 0 {
+		name, pattern = selector[:i], selector[i+1:]
+	}
+	if pattern == "" {
+		pattern = "*"
+	}
+	return name, pattern
+}
+
+// Items returns the sorted names of the items a component installs, which
+// --components component/glob chooses from. Components that do not
+// install individual files have none.
+func (r *EnhancedComponentRegistry) Items(name string) ([]string, error) {
+	instance, err := r.GetComponentInstance(name, "")
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	var items []string
+	for _, pair := range instance.GetFilesToInstall() {
+		if item := ItemName(pair.Source); !seen[item] {
+			seen[item] = true
+			items = append(items, item)
+		}
+	}
+	sort.Strings(items)
+	return items, nil
+}
+
+// ItemName is the name an item selector matches a component file by: its
+// base name without the extension, e.g. git for commands/git.md
+func ItemName(file string) string {
+	base := filepath.Base(file)
+	return strings.TrimSuffix(base, filepath.Ext(base))
+}
+
+// MatchItems returns the files whose item name matches any of patterns
+func MatchItems(files []string, patterns []string) []string {
+	var matched []string
+	for _, file := range files {
+		item := ItemName(file)
+		for _, pattern := range patterns {
+			if ok, _ := path.Match(pattern, item); ok {
+				matched = append(matched, file)
+				break
+			}
+		}
+	}
+	return matched
+}
+
+// applyItemFilter limits ComponentFiles to the items config selects for
+// this component, leaving them all when it selects none
+func (b *BaseComponent) applyItemFilter(config map[string]interface{}) {
+	items, _ := config[ConfigItems].(map[string][]string)
+	if patterns := items[b.Metadata.Name]; len(patterns) > 0 {
+		b.ComponentFiles = MatchItems(b.ComponentFiles, patterns)
+	}
+}
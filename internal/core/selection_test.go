@@ -0,0 +1,100 @@
+package core
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jonwraymond/claude-code-super-crew/pkg/component"
+)
+
+// filesComponent is an SDK component that installs the given files
+type filesComponent struct {
+	metadataComponent
+	files []string
+}
+
+func (c filesComponent) FileManifest() []component.FilePair {
+	pairs := make([]component.FilePair, 0, len(c.files))
+	for _, file := range c.files {
+		pairs = append(pairs, component.FilePair{Source: file, Target: file})
+	}
+	return pairs
+}
+
+func selectionRegistry() *EnhancedComponentRegistry {
+	r := registryWith(
+		ComponentMetadata{Name: "core", Version: "1.0.0", Category: "core", Tags: []string{TagRecommended}},
+		ComponentMetadata{Name: "hooks", Version: "1.0.0", Category: "integration"},
+		ComponentMetadata{Name: "swarm", Version: "0.1.0", Category: "agents", Tags: []string{"experimental"}},
+	)
+	r.RegisterExternal("commands", func(installDir, sourceDir string) component.Component {
+		return filesComponent{
+			metadataComponent: metadataComponent{meta: ComponentMetadata{Name: "commands", Version: "1.0.0", Category: "commands", Tags: []string{TagRecommended}}},
+			files:             []string{"commands/git.md", "commands/git-flow.md", "commands/test.md"},
+		}
+	})
+	return r
+}
+
+func TestSelectComponents(t *testing.T) {
+	r := selectionRegistry()
+	tests := []struct {
+		selectors []string
+		expected  Selection
+	}{
+		{[]string{"core", "hooks"}, Selection{Components: []string{"core", "hooks"}}},
+		{[]string{"@recommended"}, Selection{Components: []string{"commands", "core"}}},
+		{[]string{"@integration", "core"}, Selection{Components: []string{"hooks", "core"}}},
+		{[]string{"@all"}, Selection{Components: []string{"commands", "core", "hooks"}}},
+		{[]string{"*o*"}, Selection{Components: []string{"commands", "core", "hooks"}}},
+		{[]string{"commands/git*"}, Selection{Components: []string{"commands"}, Items: map[string][]string{"commands": {"git*"}}}},
+		{[]string{"commands/git", " commands/test "}, Selection{Components: []string{"commands"}, Items: map[string][]string{"commands": {"git", "test"}}}},
+		{[]string{"commands/git*", "@recommended"}, Selection{Components: []string{"commands", "core"}}},
+	}
+	for _, tt := range tests {
+		got, err := r.SelectComponents(tt.selectors, false)
+		if err != nil {
+			t.Errorf("SelectComponents(%q) failed: %v", tt.selectors, err)
+			continue
+		}
+		if !reflect.DeepEqual(*got, tt.expected) {
+			t.Errorf("SelectComponents(%q) = %+v, expected %+v", tt.selectors, *got, tt.expected)
+		}
+	}
+
+	if got, err := r.SelectComponents([]string{"s*"}, true); err != nil || !reflect.DeepEqual(got.Components, []string{"swarm"}) {
+		t.Errorf("expected s* to match the experimental swarm, got %+v, %v", got, err)
+	}
+
+	failures := map[string]string{
+		"@missing":       "is empty",
+		"s*":             "matches no component",
+		"nope/x":         "not found in registry",
+		"hooks/x":        "cannot be installed in part",
+		"commands/lint*": "matches none of the 3 items",
+		"commands/[":     "invalid pattern",
+	}
+	for selector, want := range failures {
+		if _, err := r.SelectComponents([]string{selector}, false); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("SelectComponents(%q) error = %v, expected it to contain %q", selector, err, want)
+		}
+	}
+}
+
+func TestApplyItemFilter(t *testing.T) {
+	files := []string{"git.md", "git-flow.md", "test.md", "scripts/git.sh"}
+	if got := MatchItems(files, []string{"git"}); !reflect.DeepEqual(got, []string{"git.md", "scripts/git.sh"}) {
+		t.Errorf("MatchItems = %v", got)
+	}
+
+	b := &BaseComponent{Metadata: ComponentMetadata{Name: "commands"}, ComponentFiles: files}
+	b.applyItemFilter(map[string]interface{}{ConfigItems: map[string][]string{"agents": {"x"}}})
+	if len(b.ComponentFiles) != len(files) {
+		t.Errorf("another component's items filtered commands: %v", b.ComponentFiles)
+	}
+	b.applyItemFilter(map[string]interface{}{ConfigItems: map[string][]string{"commands": {"test", "git-*"}}})
+	if !reflect.DeepEqual(b.ComponentFiles, []string{"git-flow.md", "test.md"}) {
+		t.Errorf("ComponentFiles = %v", b.ComponentFiles)
+	}
+}
@@ -0,0 +1,419 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/semver"
+)
+
+// Plan actions
+const (
+	ActionInstall   = "install"
+	ActionUpgrade   = "upgrade"
+	ActionDowngrade = "downgrade"
+	ActionReinstall = "reinstall"
+)
+
+// Requirement is a dependency or conflict entry from component metadata:
+// a component name with an optional version range, written "core",
+// "core>=1.2.0", "core ^1.2" or "core@~1.2"
+type Requirement struct {
+	Name       string `json:"name"`
+	Constraint string `json:"constraint,omitempty"`
+}
+
+// ParseRequirement parses a dependency or conflict entry
+func ParseRequirement(s string) (Requirement, error) {
+	s = strings.TrimSpace(s)
+	end := strings.IndexAny(s, " @<>=!^~")
+	if end < 0 {
+		if s == "" {
+			return Requirement{}, fmt.Errorf("empty component requirement")
+		}
+		return Requirement{Name: s}, nil
+	}
+
+	req := Requirement{Name: s[:end], Constraint: strings.TrimSpace(strings.TrimPrefix(s[end:], "@"))}
+	if req.Name == "" {
+		return Requirement{}, fmt.Errorf("component requirement %q has no name", s)
+	}
+	if req.Constraint != "" {
+		if _, err := semver.ParseRange(req.Constraint); err != nil {
+			return Requirement{}, fmt.Errorf("component requirement %q: %w", s, err)
+		}
+	}
+	return req, nil
+}
+
+// String formats the requirement as it would be written in metadata
+func (r Requirement) String() string {
+	if r.Constraint == "" {
+		return r.Name
+	}
+	return r.Name + " " + r.Constraint
+}
+
+// matches reports whether version satisfies the requirement's range. An
+// unparseable version only satisfies an unconstrained requirement.
+func (r Requirement) matches(version string) bool {
+	if r.Constraint == "" {
+		return true
+	}
+	ok, err := semver.Satisfies(version, r.Constraint)
+	return err == nil && ok
+}
+
+// PlanStep is one component in a resolved plan
+type PlanStep struct {
+	Name        string   `json:"name"`
+	Version     string   `json:"version"`
+	Installed   string   `json:"installed,omitempty"`
+	Action      string   `json:"action"`
+	Requested   bool     `json:"requested"`
+	RequiredBy  []string `json:"required_by,omitempty"`
+	Constraints []string `json:"constraints,omitempty"`
+}
+
+// Plan is the outcome of resolving a component selection. Order lists
+// components with dependencies first; Levels groups them so that each
+// level only depends on earlier ones.
+type Plan struct {
+	Steps  []PlanStep `json:"steps"`
+	Order  []string   `json:"order"`
+	Levels [][]string `json:"levels"`
+}
+
+// Step returns the plan step for name
+func (p *Plan) Step(name string) (PlanStep, bool) {
+	for _, step := range p.Steps {
+		if step.Name == name {
+			return step, true
+		}
+	}
+	return PlanStep{}, false
+}
+
+// SolveError explains why a selection cannot be installed. Each problem
+// is a complete sentence naming the components involved.
+type SolveError struct {
+	Problems []string
+}
+
+func (e *SolveError) Error() string {
+	if len(e.Problems) == 1 {
+		return "cannot resolve components: " + e.Problems[0]
+	}
+	return "cannot resolve components:\n  - " + strings.Join(e.Problems, "\n  - ")
+}
+
+// Solve resolves requested components and their transitive dependencies
+// against the available components. installed maps already installed
+// components to their versions; they are checked for conflicts with the
+// selection and for dependencies the selection would break.
+func (r *EnhancedComponentRegistry) Solve(requested []string, installed map[string]string) (*Plan, error) {
+	var problems []string
+
+	requestedSet := make(map[string]bool)
+	for _, name := range requested {
+		requestedSet[name] = true
+	}
+
+	// Walk the dependency closure breadth first, recording why each
+	// component is selected and what is required of it
+	requiredBy := make(map[string][]string)
+	constraints := make(map[string][]Requirement)
+	constraintSource := make(map[Requirement][]string)
+	deps := make(map[string][]string)
+	selected := make(map[string]bool)
+
+	queue := append([]string(nil), requested...)
+	sort.Strings(queue)
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		if selected[name] {
+			continue
+		}
+		meta, ok := r.components[name]
+		if !ok {
+			if requestedSet[name] {
+				problems = append(problems, fmt.Sprintf("component %s not found in registry", name))
+			} else {
+				problems = append(problems, fmt.Sprintf("component %s, required by %s, is not available", name, strings.Join(requiredBy[name], ", ")))
+			}
+			selected[name] = true
+			continue
+		}
+		selected[name] = true
+
+		for _, entry := range meta.Dependencies {
+			req, err := ParseRequirement(entry)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("component %s: %v", name, err))
+				continue
+			}
+			deps[name] = append(deps[name], req.Name)
+			requiredBy[req.Name] = appendUnique(requiredBy[req.Name], name)
+			if req.Constraint != "" {
+				constraints[req.Name] = append(constraints[req.Name], req)
+				constraintSource[req] = appendUnique(constraintSource[req], name)
+			}
+			if !selected[req.Name] {
+				queue = append(queue, req.Name)
+			}
+		}
+	}
+
+	// Every selected component must satisfy what its dependents require
+	for name := range selected {
+		meta, ok := r.components[name]
+		if !ok {
+			continue
+		}
+		for _, req := range constraints[name] {
+			if !req.matches(meta.Version) {
+				problems = append(problems, fmt.Sprintf("%s requires %s, but %s %s is available",
+					strings.Join(constraintSource[req], " and "), req, name, meta.Version))
+			}
+		}
+	}
+
+	// Installed components outside the selection keep their current
+	// version; the selection must not break their dependencies
+	for name := range installed {
+		if selected[name] {
+			continue
+		}
+		meta, ok := r.components[name]
+		if !ok {
+			continue
+		}
+		for _, entry := range meta.Dependencies {
+			req, err := ParseRequirement(entry)
+			if err != nil || !selected[req.Name] {
+				continue
+			}
+			if next, ok := r.components[req.Name]; ok && !req.matches(next.Version) {
+				problems = append(problems, fmt.Sprintf("installed %s requires %s, but %s would become %s",
+					name, req, req.Name, next.Version))
+			}
+		}
+	}
+
+	problems = append(problems, r.findConflicts(selected, installed, requestedSet, requiredBy)...)
+
+	if cycle := findCycle(selected, deps); cycle != nil {
+		problems = append(problems, fmt.Sprintf("circular dependency: %s", strings.Join(cycle, " -> ")))
+	}
+
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return nil, &SolveError{Problems: problems}
+	}
+
+	plan := &Plan{Levels: levelize(selected, deps)}
+	for _, level := range plan.Levels {
+		plan.Order = append(plan.Order, level...)
+	}
+	for _, name := range plan.Order {
+		meta := r.components[name]
+		step := PlanStep{
+			Name:       name,
+			Version:    meta.Version,
+			Installed:  installed[name],
+			Action:     planAction(installed[name], meta.Version),
+			Requested:  requestedSet[name],
+			RequiredBy: requiredBy[name],
+		}
+		sort.Strings(step.RequiredBy)
+		for _, req := range constraints[name] {
+			step.Constraints = appendUnique(step.Constraints, req.Constraint)
+		}
+		plan.Steps = append(plan.Steps, step)
+	}
+	return plan, nil
+}
+
+// findConflicts checks the conflict declarations of every component that
+// will be present after the operation, in both directions
+func (r *EnhancedComponentRegistry) findConflicts(selected map[string]bool, installed map[string]string, requested map[string]bool, requiredBy map[string][]string) []string {
+	present := make(map[string]string)
+	for name := range installed {
+		present[name] = installed[name]
+	}
+	for name := range selected {
+		if meta, ok := r.components[name]; ok {
+			present[name] = meta.Version
+		}
+	}
+
+	reason := func(name string) string {
+		switch {
+		case requested[name]:
+			return "requested"
+		case selected[name]:
+			return "required by " + strings.Join(requiredBy[name], ", ")
+		default:
+			return "installed"
+		}
+	}
+
+	var problems []string
+	seen := make(map[string]bool)
+	names := make([]string, 0, len(present))
+	for name := range present {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		meta, ok := r.components[name]
+		if !ok {
+			continue
+		}
+		for _, entry := range meta.Conflicts {
+			req, err := ParseRequirement(entry)
+			if err != nil {
+				continue
+			}
+			version, exists := present[req.Name]
+			if !exists || req.Name == name || !req.matches(version) {
+				continue
+			}
+			// Two components already installed side by side are not this
+			// operation's problem
+			if !selected[name] && !selected[req.Name] {
+				continue
+			}
+			pair := name + "\x00" + req.Name
+			if req.Name < name {
+				pair = req.Name + "\x00" + name
+			}
+			if seen[pair] {
+				continue
+			}
+			seen[pair] = true
+			problems = append(problems, fmt.Sprintf("%s (%s) conflicts with %s %s (%s)",
+				name, reason(name), req.Name, version, reason(req.Name)))
+		}
+	}
+	return problems
+}
+
+// findCycle returns the first dependency cycle among selected components
+// as a path that starts and ends with the same component
+func findCycle(selected map[string]bool, deps map[string][]string) []string {
+	const (
+		unvisited = iota
+		active
+		done
+	)
+	state := make(map[string]int)
+	var stack []string
+
+	var visit func(string) []string
+	visit = func(name string) []string {
+		state[name] = active
+		stack = append(stack, name)
+		for _, dep := range deps[name] {
+			switch state[dep] {
+			case active:
+				for i, n := range stack {
+					if n == dep {
+						return append(append([]string(nil), stack[i:]...), dep)
+					}
+				}
+			case unvisited:
+				if cycle := visit(dep); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[name] = done
+		return nil
+	}
+
+	names := make([]string, 0, len(selected))
+	for name := range selected {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if state[name] == unvisited {
+			if cycle := visit(name); cycle != nil {
+				return cycle
+			}
+		}
+	}
+	return nil
+}
+
+// levelize groups an acyclic selection into dependency levels, each sorted
+func levelize(selected map[string]bool, deps map[string][]string) [][]string {
+	placed := make(map[string]bool)
+	var levels [][]string
+	for len(placed) < len(selected) {
+		var level []string
+		for name := range selected {
+			if placed[name] {
+				continue
+			}
+			ready := true
+			for _, dep := range deps[name] {
+				if !placed[dep] {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				level = append(level, name)
+			}
+		}
+		if len(level) == 0 {
+			break
+		}
+		sort.Strings(level)
+		for _, name := range level {
+			placed[name] = true
+		}
+		levels = append(levels, level)
+	}
+	return levels
+}
+
+func planAction(installed, available string) string {
+	switch {
+	case installed == "":
+		return ActionInstall
+	case semver.Compare(available, installed) > 0:
+		return ActionUpgrade
+	case semver.Compare(available, installed) < 0:
+		return ActionDowngrade
+	default:
+		return ActionReinstall
+	}
+}
+
+// dependencyNames returns the component names of dependency entries,
+// dropping version ranges and entries that do not parse
+func dependencyNames(entries []string) []string {
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if req, err := ParseRequirement(entry); err == nil {
+			names = append(names, req.Name)
+		}
+	}
+	return names
+}
+
+func appendUnique(list []string, s string) []string {
+	for _, existing := range list {
+		if existing == s {
+			return list
+		}
+	}
+	return append(list, s)
+}
@@ -0,0 +1,163 @@
+package core
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jonwraymond/claude-code-super-crew/pkg/component"
+)
+
+// metadataComponent is an SDK component that only has metadata
+type metadataComponent struct {
+	meta ComponentMetadata
+}
+
+func (c metadataComponent) Metadata() component.Metadata                   { return c.meta }
+func (c metadataComponent) Validate(string) error                          { return nil }
+func (c metadataComponent) Install(string, map[string]interface{}) error   { return nil }
+func (c metadataComponent) Uninstall(string, map[string]interface{}) error { return nil }
+func (c metadataComponent) Update(string, map[string]interface{}) error    { return nil }
+func (c metadataComponent) SizeEstimate() int64                            { return 0 }
+func (c metadataComponent) FileManifest() []component.FilePair             { return nil }
+
+// registryWith builds a registry from metadata alone
+func registryWith(metas ...ComponentMetadata) *EnhancedComponentRegistry {
+	r := NewEnhancedComponentRegistry("")
+	for _, meta := range metas {
+		meta := meta
+		r.RegisterExternal(meta.Name, func(installDir, sourceDir string) component.Component {
+			return metadataComponent{meta: meta}
+		})
+	}
+	return r
+}
+
+func TestParseRequirement(t *testing.T) {
+	tests := map[string]Requirement{
+		"core":         {Name: "core"},
+		"core>=1.2.0":  {Name: "core", Constraint: ">=1.2.0"},
+		"core ^1.2":    {Name: "core", Constraint: "^1.2"},
+		"core@~1.2":    {Name: "core", Constraint: "~1.2"},
+		" hooks <2.0 ": {Name: "hooks", Constraint: "<2.0"},
+	}
+	for input, expected := range tests {
+		req, err := ParseRequirement(input)
+		if err != nil || req != expected {
+			t.Errorf("ParseRequirement(%q) = %+v, %v; expected %+v", input, req, err, expected)
+		}
+	}
+	for _, input := range []string{"", ">=1.0", "core >=abc"} {
+		if _, err := ParseRequirement(input); err == nil {
+			t.Errorf("Expected ParseRequirement(%q) to fail", input)
+		}
+	}
+}
+
+func TestSolveOrdersTransitiveDependencies(t *testing.T) {
+	r := registryWith(
+		ComponentMetadata{Name: "core", Version: "1.2.0"},
+		ComponentMetadata{Name: "agents", Version: "1.0.0", Dependencies: []string{"core >=1.1"}},
+		ComponentMetadata{Name: "reviewers", Version: "0.3.0", Dependencies: []string{"agents"}},
+		ComponentMetadata{Name: "hooks", Version: "1.0.0", Dependencies: []string{"core"}},
+	)
+
+	plan, err := r.Solve([]string{"reviewers", "hooks"}, map[string]string{"core": "1.0.0"})
+	if err != nil {
+		t.Fatalf("Solve failed: %v", err)
+	}
+	expected := [][]string{{"core"}, {"agents", "hooks"}, {"reviewers"}}
+	if !reflect.DeepEqual(plan.Levels, expected) {
+		t.Errorf("Expected levels %v, got %v", expected, plan.Levels)
+	}
+
+	core, _ := plan.Step("core")
+	if core.Action != ActionUpgrade || core.Requested || !reflect.DeepEqual(core.RequiredBy, []string{"agents", "hooks"}) {
+		t.Errorf("Unexpected core step: %+v", core)
+	}
+	if agents, _ := plan.Step("agents"); agents.Action != ActionInstall || agents.RequiredBy[0] != "reviewers" {
+		t.Errorf("Unexpected agents step: %+v", agents)
+	}
+}
+
+func TestSolveExplainsProblems(t *testing.T) {
+	tests := []struct {
+		name      string
+		metas     []ComponentMetadata
+		requested []string
+		installed map[string]string
+		problem   string
+	}{
+		{
+			name: "version",
+			metas: []ComponentMetadata{
+				{Name: "core", Version: "1.0.0"},
+				{Name: "agents", Version: "2.0.0", Dependencies: []string{"core ^2.0"}},
+			},
+			requested: []string{"agents"},
+			problem:   "agents requires core ^2.0, but core 1.0.0 is available",
+		},
+		{
+			name: "missing",
+			metas: []ComponentMetadata{
+				{Name: "agents", Version: "1.0.0", Dependencies: []string{"core"}},
+			},
+			requested: []string{"agents"},
+			problem:   "component core, required by agents, is not available",
+		},
+		{
+			name: "cycle",
+			metas: []ComponentMetadata{
+				{Name: "a", Version: "1.0.0", Dependencies: []string{"b"}},
+				{Name: "b", Version: "1.0.0", Dependencies: []string{"a"}},
+			},
+			requested: []string{"a"},
+			problem:   "circular dependency: a -> b -> a",
+		},
+		{
+			name: "conflict",
+			metas: []ComponentMetadata{
+				{Name: "core", Version: "1.0.0"},
+				{Name: "hooks", Version: "1.0.0", Dependencies: []string{"core"}},
+				{Name: "legacy-hooks", Version: "0.9.0", Conflicts: []string{"hooks"}},
+			},
+			requested: []string{"hooks"},
+			installed: map[string]string{"legacy-hooks": "0.9.0"},
+			problem:   "legacy-hooks (installed) conflicts with hooks 1.0.0 (requested)",
+		},
+		{
+			name: "broken dependent",
+			metas: []ComponentMetadata{
+				{Name: "core", Version: "2.0.0"},
+				{Name: "agents", Version: "1.0.0", Dependencies: []string{"core ^1.0"}},
+			},
+			requested: []string{"core"},
+			installed: map[string]string{"core": "1.0.0", "agents": "1.0.0"},
+			problem:   "installed agents requires core ^1.0, but core would become 2.0.0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := registryWith(tt.metas...).Solve(tt.requested, tt.installed)
+			var solveErr *SolveError
+			if !errors.As(err, &solveErr) {
+				t.Fatalf("Expected a SolveError, got %v", err)
+			}
+			if !strings.Contains(strings.Join(solveErr.Problems, "\n"), tt.problem) {
+				t.Errorf("Expected problem %q, got %v", tt.problem, solveErr.Problems)
+			}
+		})
+	}
+}
+
+func TestSolveConflictVersionRange(t *testing.T) {
+	r := registryWith(
+		ComponentMetadata{Name: "core", Version: "2.0.0"},
+		ComponentMetadata{Name: "compat", Version: "1.0.0", Conflicts: []string{"core <2.0.0"}},
+	)
+	if _, err := r.Solve([]string{"compat", "core"}, nil); err != nil {
+		t.Errorf("Expected core 2.0.0 to be outside the conflicting range, got %v", err)
+	}
+}
@@ -6,9 +6,10 @@ import (
 	"os/exec"
 	"regexp"
 	"runtime"
-	"strconv"
 	"strings"
 	"time"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/semver"
 )
 
 // ValidationResult represents the result of a system validation check
@@ -479,56 +480,18 @@ func (v *SystemValidator) getPythonVersion(pythonCmd string) (string, error) {
 	return "", fmt.Errorf("unexpected Python version output: %s", versionOutput)
 }
 
+// compareVersions reports whether version1 >= version2. Both are parsed
+// tolerantly, so tool output such as "3.11" or "v18.19.0" is accepted.
 func (v *SystemValidator) compareVersions(version1, version2 string) (bool, error) {
-	// Simple version comparison: version1 >= version2
-	v1Parts, err := parseVersion(version1)
+	v1, err := semver.ParseTolerant(version1)
 	if err != nil {
-		return false, err
+		return false, fmt.Errorf("invalid version format: %s", version1)
 	}
-
-	v2Parts, err := parseVersion(version2)
+	v2, err := semver.ParseTolerant(version2)
 	if err != nil {
-		return false, err
+		return false, fmt.Errorf("invalid version format: %s", version2)
 	}
-
-	// Pad shorter version with zeros
-	maxLen := len(v1Parts)
-	if len(v2Parts) > maxLen {
-		maxLen = len(v2Parts)
-	}
-
-	for len(v1Parts) < maxLen {
-		v1Parts = append(v1Parts, 0)
-	}
-	for len(v2Parts) < maxLen {
-		v2Parts = append(v2Parts, 0)
-	}
-
-	// Compare each part
-	for i := 0; i < maxLen; i++ {
-		if v1Parts[i] > v2Parts[i] {
-			return true, nil
-		} else if v1Parts[i] < v2Parts[i] {
-			return false, nil
-		}
-	}
-
-	return true, nil // Equal versions
-}
-
-func parseVersion(version string) ([]int, error) {
-	parts := strings.Split(version, ".")
-	var intParts []int
-
-	for _, part := range parts {
-		intPart, err := strconv.Atoi(part)
-		if err != nil {
-			return nil, fmt.Errorf("invalid version format: %s", version)
-		}
-		intParts = append(intParts, intPart)
-	}
-
-	return intParts, nil
+	return !v1.LessThan(v2), nil
 }
 
 func (v *SystemValidator) getInstallationHelp(tool string) string {
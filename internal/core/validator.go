@@ -1,22 +1,80 @@
 package core
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
 	"runtime"
+	"sort"
 	"strings"
+	"time"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/managers"
+	"github.com/jonwraymond/claude-code-super-crew/internal/platform"
+	"github.com/jonwraymond/claude-code-super-crew/internal/semver"
+	"github.com/jonwraymond/claude-code-super-crew/internal/toolinstall"
 )
 
 // Validator handles system requirement validation
 type Validator struct {
-	checks map[string]func() (bool, string)
+	checks   map[string]func() (bool, string)
+	tools    map[string]toolProbe
+	lookPath func(string) (string, error)
+	run      func(name string, args ...string) (string, error)
+	detected map[string]ToolInfo
+	// environment detects the platform traits diagnostics advise on
+	environment func() platform.Environment
+	installDir  string
+}
+
+// toolProbe describes how to find a tool and ask for its version
+type toolProbe struct {
+	label   string
+	command string
+	args    []string
+}
+
+// ToolInfo is what was found for one tool on this system
+type ToolInfo struct {
+	Name    string `json:"name"`
+	Found   bool   `json:"found"`
+	Path    string `json:"path,omitempty"`
+	Version string `json:"version,omitempty"`
+	Output  string `json:"output,omitempty"`
 }
 
+// RequirementResult is the outcome of checking one tool against one
+// requirement. Component is empty for global requirements.
+type RequirementResult struct {
+	Tool      string `json:"tool"`
+	Component string `json:"component,omitempty"`
+	Required  string `json:"required,omitempty"`
+	Found     bool   `json:"found"`
+	Version   string `json:"version,omitempty"`
+	Path      string `json:"path,omitempty"`
+	Satisfied bool   `json:"satisfied"`
+	Message   string `json:"message"`
+}
+
+// OptionalTools are the tools agents use when they are present.
+// Diagnostics report them, and how to install them, without failing.
+var OptionalTools = []string{"ast-grep", "code2prompt"}
+
+// toolVersionTimeout bounds each version command, which may hang on a
+// misconfigured tool
+const toolVersionTimeout = 10 * time.Second
+
 // NewValidator creates a new validator
 func NewValidator() *Validator {
 	v := &Validator{
-		checks: make(map[string]func() (bool, string)),
+		checks:   make(map[string]func() (bool, string)),
+		lookPath: exec.LookPath,
+		run:      runVersionCommand,
+		detected: make(map[string]ToolInfo),
+	}
+	v.environment = func() platform.Environment {
+		return platform.Detect(v.diagnosedDir())
 	}
 
 	// Register default checks
@@ -25,43 +83,27 @@ func NewValidator() *Validator {
 	return v
 }
 
-// registerDefaultChecks registers default system checks
-func (v *Validator) registerDefaultChecks() {
-	// Go toolchain check
-	v.checks["go"] = func() (bool, string) {
-		if _, err := exec.LookPath("go"); err == nil {
-			if out, err := exec.Command("go", "version").Output(); err == nil {
-				version := strings.TrimSpace(string(out))
-				return true, version
-			}
-			return true, "Go found"
-		}
-		return false, "Go not found in PATH"
-	}
+// runVersionCommand runs a tool's version command and returns its output
+func runVersionCommand(name string, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), toolVersionTimeout)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, name, args...).CombinedOutput()
+	return strings.TrimSpace(string(out)), err
+}
 
-	// Claude CLI check
-	v.checks["claude"] = func() (bool, string) {
-		if _, err := exec.LookPath("claude"); err == nil {
-			if out, err := exec.Command("claude", "--version").Output(); err == nil {
-				version := strings.TrimSpace(string(out))
-				return true, fmt.Sprintf("Claude CLI: %s", version)
-			}
-			return true, "Claude CLI found"
-		}
-		return false, "Claude CLI not found in PATH"
-	}
+// registerDefaultChecks registers the known tools and the checks that are
+// not about a tool
+func (v *Validator) registerDefaultChecks() {
+	v.tools = map[string]toolProbe{
+		"go":     {label: "Go", command: "go", args: []string{"version"}},
+		"git":    {label: "Git", command: "git", args: []string{"--version"}},
+		"node":   {label: "Node.js", command: "node", args: []string{"--version"}},
+		"npm":    {label: "npm", command: "npm", args: []string{"--version"}},
+		"python": {label: "Python", command: "python3", args: []string{"--version"}},
+		"claude": {label: "Claude CLI", command: "claude", args: []string{"--version"}},
 
-	// Git check
-	v.checks["git"] = func() (bool, string) {
-		if _, err := exec.LookPath("git"); err == nil {
-			// Get version
-			if out, err := exec.Command("git", "--version").Output(); err == nil {
-				version := strings.TrimSpace(string(out))
-				return true, version
-			}
-			return true, "Git found"
-		}
-		return false, "Git not found in PATH"
+		"code2prompt": {label: "code2prompt", command: "code2prompt", args: []string{"--version"}},
+		"ast-grep":    {label: "ast-grep", command: "ast-grep", args: []string{"--version"}},
 	}
 
 	// Directory permissions check
@@ -91,146 +133,255 @@ func (v *Validator) registerDefaultChecks() {
 			return true, fmt.Sprintf("%s can be created", claudeDir)
 		}
 	}
+}
+
+// DetectTool looks up a known tool and parses its version. Results are
+// cached for the lifetime of the validator.
+func (v *Validator) DetectTool(name string) ToolInfo {
+	if info, ok := v.detected[name]; ok {
+		return info
+	}
 
-	// Node.js check (for MCP servers)
-	v.checks["node"] = func() (bool, string) {
-		if _, err := exec.LookPath("node"); err == nil {
-			if out, err := exec.Command("node", "--version").Output(); err == nil {
-				version := strings.TrimSpace(string(out))
-				return true, fmt.Sprintf("Node.js %s", version)
+	info := ToolInfo{Name: name}
+	probe, known := v.tools[name]
+	if !known {
+		probe = toolProbe{label: name, command: name, args: []string{"--version"}}
+	}
+	if path, err := v.lookPath(probe.command); err == nil {
+		info.Found = true
+		info.Path = path
+		if out, err := v.run(probe.command, probe.args...); err == nil {
+			info.Output = out
+			if version, err := semver.ParseTolerant(out); err == nil {
+				info.Version = version.String()
 			}
-			return true, "Node.js found"
 		}
-		return false, "Node.js not found in PATH"
 	}
+
+	v.detected[name] = info
+	return info
 }
 
-// ValidateComponentRequirements validates requirements for specific components
-func (v *Validator) ValidateComponentRequirements(components []string, requirements map[string]map[string]string) (bool, []string) {
-	errors := []string{}
-	allPassed := true
-
-	// Check global requirements
-	if reqs, ok := requirements["global"]; ok {
-		for check, minVersion := range reqs {
-			if checkFunc, exists := v.checks[check]; exists {
-				passed, msg := checkFunc()
-				if !passed {
-					errors = append(errors, fmt.Sprintf("%s: %s (required: %s)", check, msg, minVersion))
-					allPassed = false
-				}
-			}
+// presenceOnly reports whether a requirement only asks for the tool to exist
+func presenceOnly(constraint string) bool {
+	switch strings.TrimSpace(strings.ToLower(constraint)) {
+	case "", "*", "any", "latest", "required":
+		return true
+	}
+	return false
+}
+
+// CheckRequirement checks one tool against a version constraint such as
+// ">=18.0.0" or "^2.30". "latest", "required" and "" only require the tool
+// to be present, since the installed version cannot be checked offline.
+func (v *Validator) CheckRequirement(tool, constraint string) RequirementResult {
+	result := RequirementResult{Tool: tool, Required: constraint}
+
+	if check, ok := v.checks[tool]; ok {
+		result.Satisfied, result.Message = check()
+		result.Found = result.Satisfied
+		return result
+	}
+
+	label := tool
+	if probe, ok := v.tools[tool]; ok {
+		label = probe.label
+	}
+
+	info := v.DetectTool(tool)
+	result.Found, result.Version, result.Path = info.Found, info.Version, info.Path
+	switch {
+	case !info.Found:
+		result.Message = fmt.Sprintf("%s not found in PATH", label)
+	case presenceOnly(constraint):
+		result.Satisfied = true
+		result.Message = strings.TrimSpace(fmt.Sprintf("%s %s", label, info.Version))
+	case info.Version == "":
+		// Present but unreadable: report it without blocking the install
+		result.Satisfied = true
+		result.Message = fmt.Sprintf("%s found (version unknown, requires %s)", label, constraint)
+	default:
+		r, err := semver.ParseRange(constraint)
+		if err != nil {
+			result.Message = fmt.Sprintf("%s: %v", label, err)
+			break
+		}
+		result.Satisfied = r.Contains(semver.MustParse(info.Version))
+		if result.Satisfied {
+			result.Message = fmt.Sprintf("%s %s (requires %s)", label, info.Version, constraint)
+		} else {
+			result.Message = fmt.Sprintf("%s %s found, %s required", label, info.Version, constraint)
 		}
 	}
+	return result
+}
+
+// ValidateRequirements checks the global requirements and those of each
+// component, in that order, with tools sorted by name within each scope
+func (v *Validator) ValidateRequirements(components []string, requirements map[string]map[string]string) []RequirementResult {
+	var results []RequirementResult
+	for _, scope := range append([]string{"global"}, components...) {
+		reqs := requirements[scope]
+		tools := make([]string, 0, len(reqs))
+		for tool := range reqs {
+			tools = append(tools, tool)
+		}
+		sort.Strings(tools)
 
-	// Check component-specific requirements
-	for _, comp := range components {
-		if reqs, ok := requirements[comp]; ok {
-			for check, minVersion := range reqs {
-				if checkFunc, exists := v.checks[check]; exists {
-					passed, msg := checkFunc()
-					if !passed {
-						errors = append(errors, fmt.Sprintf("%s for %s: %s (required: %s)", check, comp, msg, minVersion))
-						allPassed = false
-					}
-				}
+		for _, tool := range tools {
+			result := v.CheckRequirement(tool, reqs[tool])
+			if scope != "global" {
+				result.Component = scope
 			}
+			results = append(results, result)
 		}
 	}
+	return results
+}
 
-	return allPassed, errors
+// ValidateComponentRequirements validates requirements for specific components
+func (v *Validator) ValidateComponentRequirements(components []string, requirements map[string]map[string]string) (bool, []string) {
+	errors := []string{}
+	for _, result := range v.ValidateRequirements(components, requirements) {
+		if result.Satisfied {
+			continue
+		}
+		if result.Component == "" {
+			errors = append(errors, fmt.Sprintf("%s: %s", result.Tool, result.Message))
+		} else {
+			errors = append(errors, fmt.Sprintf("%s for %s: %s", result.Tool, result.Component, result.Message))
+		}
+	}
+	return len(errors) == 0, errors
 }
 
-// DiagnoseSystem runs comprehensive system diagnostics
+// SetInstallDir sets the directory whose filesystem diagnostics inspect;
+// ~/.claude by default
+func (v *Validator) SetInstallDir(dir string) {
+	v.installDir = dir
+}
+
+func (v *Validator) diagnosedDir() string {
+	if v.installDir != "" {
+		return v.installDir
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return home + string(os.PathSeparator) + ".claude"
+}
+
+// DiagnoseSystem runs the default requirement checks for every component
 func (v *Validator) DiagnoseSystem() map[string]interface{} {
-	diagnostics := map[string]interface{}{
-		"platform":        fmt.Sprintf("%s/%s", runtime.GOOS, runtime.GOARCH),
-		"checks":          make(map[string]map[string]string),
-		"issues":          []string{},
-		"recommendations": []string{},
+	return v.DiagnoseRequirements(managers.DefaultRequirements([]string{"mcp"}))
+}
+
+// DiagnoseRequirements checks every requirement in requirements and adds
+// the issues and platform-specific install help for the ones that failed.
+// "results" holds the structured per-tool results; "checks" summarizes
+// them by tool name, and "optional" holds the results for OptionalTools.
+// "environment" is the detected platform, whose
+// WSL, container, SELinux and filesystem advice is added to the issues
+// and recommendations.
+func (v *Validator) DiagnoseRequirements(requirements map[string]map[string]string) map[string]interface{} {
+	scopes := make([]string, 0, len(requirements))
+	for scope := range requirements {
+		if scope != "global" {
+			scopes = append(scopes, scope)
+		}
 	}
+	sort.Strings(scopes)
+	results := v.ValidateRequirements(scopes, requirements)
 
-	checks := diagnostics["checks"].(map[string]map[string]string)
-	issues := diagnostics["issues"].([]string)
-	recommendations := diagnostics["recommendations"].([]string)
+	checks := make(map[string]map[string]string)
+	issues := []string{}
+	recommendations := []string{}
 
-	// Run all checks
-	for name, checkFunc := range v.checks {
-		passed, msg := checkFunc()
+	for _, result := range results {
 		status := "pass"
-		if !passed {
+		if !result.Satisfied {
 			status = "fail"
 		}
+		checks[result.Tool] = map[string]string{
+			"status":   status,
+			"message":  result.Message,
+			"version":  result.Version,
+			"required": result.Required,
+		}
 
-		checks[name] = map[string]string{
-			"status":  status,
-			"message": msg,
-		}
-
-		// Add issues and recommendations
-		if !passed {
-			switch name {
-			case "go":
-				issues = append(issues, "Go toolchain is not installed")
-				recommendations = append(recommendations, v.getGoInstallCommand())
-			case "git":
-				issues = append(issues, "Git is not installed")
-				recommendations = append(recommendations, v.getGitInstallCommand())
-			case "node":
-				issues = append(issues, "Node.js is not installed (required for MCP components)")
-				recommendations = append(recommendations, v.getNodeInstallCommand())
-			case "claude":
-				issues = append(issues, "Claude CLI is not installed")
-				recommendations = append(recommendations, "Install Claude CLI from https://claude.ai/cli")
-			case "permissions":
-				issues = append(issues, "Cannot access ~/.claude directory")
-				recommendations = append(recommendations, "Ensure you have write permissions to your home directory")
-			}
+		if result.Satisfied {
+			continue
+		}
+		switch result.Tool {
+		case "go":
+			issues = append(issues, "Go toolchain is not installed or too old")
+			recommendations = append(recommendations, v.installAdvice("go", ""))
+		case "git":
+			issues = append(issues, "Git is not installed or too old")
+			recommendations = append(recommendations, v.installAdvice("git", ""))
+		case "node":
+			issues = append(issues, "Node.js is not installed or too old (required for MCP components)")
+			recommendations = append(recommendations, v.installAdvice("node", ""))
+		case "claude":
+			issues = append(issues, "Claude CLI is not installed")
+			recommendations = append(recommendations, v.installAdvice("claude", ""))
+		case "permissions":
+			issues = append(issues, "Cannot access ~/.claude directory")
+			recommendations = append(recommendations, "Ensure you have write permissions to your home directory")
+		default:
+			issues = append(issues, result.Message)
 		}
 	}
 
-	diagnostics["issues"] = issues
-	diagnostics["recommendations"] = recommendations
+	optional := make([]RequirementResult, 0, len(OptionalTools))
+	for _, tool := range OptionalTools {
+		result := v.CheckRequirement(tool, "latest")
+		optional = append(optional, result)
+		if !result.Found {
+			recommendations = append(recommendations, v.installAdvice(tool, "optional, used by agents"))
+		}
+	}
 
-	return diagnostics
-}
+	environment := v.environment()
+	envIssues, envRecommendations := environment.Advice()
+	issues = append(issues, envIssues...)
+	recommendations = append(recommendations, envRecommendations...)
 
-// Platform-specific installation commands
-func (v *Validator) getGitInstallCommand() string {
-	switch runtime.GOOS {
-	case "darwin":
-		return "Install Git:\n  brew install git\n  # or download from https://git-scm.com/"
-	case "linux":
-		return "Install Git:\n  sudo apt-get install git\n  # or: sudo yum install git\n  # or: sudo pacman -S git"
-	case "windows":
-		return "Install Git:\n  winget install Git.Git\n  # or download from https://git-scm.com/"
-	default:
-		return "Install Git from https://git-scm.com/"
+	return map[string]interface{}{
+		"platform":        environment.String(),
+		"environment":     environment,
+		"checks":          checks,
+		"results":         results,
+		"optional":        optional,
+		"issues":          issues,
+		"recommendations": recommendations,
 	}
 }
 
-func (v *Validator) getGoInstallCommand() string {
-	switch runtime.GOOS {
-	case "darwin":
-		return "Install Go:\n  brew install go\n  # or download from https://go.dev/dl/"
-	case "linux":
-		return "Install Go:\n  # Download from https://go.dev/dl/\n  # Extract: tar -C /usr/local -xzf go*.tar.gz\n  # Add to PATH: export PATH=$PATH:/usr/local/go/bin"
-	case "windows":
-		return "Install Go:\n  winget install GoLang.Go\n  # or download from https://go.dev/dl/"
-	default:
-		return "Install Go from https://go.dev/dl/"
+// installAdvice is the recommendation for a missing tool: the command
+// installing it with this system's package manager, then where to
+// download it. note is added to the tool's name.
+func (v *Validator) installAdvice(tool, note string) string {
+	label := tool
+	if probe, ok := v.tools[tool]; ok {
+		label = probe.label
+	}
+	if note != "" {
+		label += " (" + note + ")"
 	}
-}
 
-func (v *Validator) getNodeInstallCommand() string {
-	switch runtime.GOOS {
-	case "darwin":
-		return "Install Node.js:\n  brew install node\n  # or download from https://nodejs.org/"
-	case "linux":
-		return "Install Node.js:\n  curl -fsSL https://deb.nodesource.com/setup_lts.x | sudo -E bash -\n  sudo apt-get install nodejs\n  # or download from https://nodejs.org/"
-	case "windows":
-		return "Install Node.js:\n  winget install OpenJS.NodeJS\n  # or download from https://nodejs.org/"
-	default:
-		return "Install Node.js from https://nodejs.org/"
+	lines := []string{"Install " + label + ":"}
+	steps, _ := toolinstall.Plan(toolinstall.Detect(runtime.GOOS, v.lookPath), []string{tool})
+	for _, step := range steps {
+		lines = append(lines, "  "+step.String())
+	}
+	if homepage := toolinstall.Homepage(tool); homepage != "" {
+		if len(steps) > 0 {
+			lines = append(lines, "  # or download from "+homepage)
+		} else {
+			lines = append(lines, "  Download from "+homepage)
+		}
 	}
+	return strings.Join(lines, "\n")
 }
@@ -0,0 +1,110 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/platform"
+)
+
+// newFakeValidator returns a validator whose tools answer with the given
+// version output; tools missing from outputs are not installed
+func newFakeValidator(outputs map[string]string) *Validator {
+	v := NewValidator()
+	v.lookPath = func(name string) (string, error) {
+		if _, ok := outputs[name]; ok {
+			return "/usr/bin/" + name, nil
+		}
+		return "", fmt.Errorf("%s: not found", name)
+	}
+	v.run = func(name string, args ...string) (string, error) {
+		return outputs[name], nil
+	}
+	v.environment = func() platform.Environment {
+		return platform.Environment{OS: "linux", Arch: "amd64"}
+	}
+	return v
+}
+
+func TestDetectTool(t *testing.T) {
+	v := newFakeValidator(map[string]string{
+		"go":   "go version go1.21.5 linux/amd64",
+		"git":  "git version 2.39.2 (Apple Git-143)",
+		"node": "v18.19.0",
+	})
+
+	for tool, expected := range map[string]string{"go": "1.21.5", "git": "2.39.2", "node": "18.19.0"} {
+		info := v.DetectTool(tool)
+		if !info.Found || info.Version != expected {
+			t.Errorf("DetectTool(%q) = %+v, expected version %s", tool, info, expected)
+		}
+	}
+	if info := v.DetectTool("claude"); info.Found {
+		t.Errorf("Expected claude to be missing, got %+v", info)
+	}
+}
+
+func TestValidateRequirements(t *testing.T) {
+	v := newFakeValidator(map[string]string{
+		"go":     "go version go1.19.2 linux/amd64",
+		"git":    "git version 2.39.2",
+		"claude": "unreadable",
+	})
+	requirements := map[string]map[string]string{
+		"global": {"go": ">=1.20", "git": ">=2.0.0", "claude": "latest"},
+		"mcp":    {"node": ">=18.0.0"},
+	}
+
+	results := v.ValidateRequirements([]string{"mcp"}, requirements)
+	byTool := make(map[string]RequirementResult)
+	for _, result := range results {
+		byTool[result.Tool] = result
+	}
+	if len(results) != 4 || results[0].Tool != "claude" || results[3].Component != "mcp" {
+		t.Fatalf("Unexpected result order: %+v", results)
+	}
+
+	if r := byTool["go"]; r.Satisfied || r.Version != "1.19.2" {
+		t.Errorf("Expected go 1.19.2 to fail >=1.20, got %+v", r)
+	}
+	if r := byTool["git"]; !r.Satisfied {
+		t.Errorf("Expected git 2.39.2 to satisfy >=2.0.0, got %+v", r)
+	}
+	if r := byTool["claude"]; !r.Satisfied || r.Version != "" {
+		t.Errorf("Expected claude to pass on presence alone, got %+v", r)
+	}
+	if r := byTool["node"]; r.Satisfied || r.Found {
+		t.Errorf("Expected missing node to fail, got %+v", r)
+	}
+
+	ok, errors := v.ValidateComponentRequirements([]string{"mcp"}, requirements)
+	if ok || len(errors) != 2 || !strings.HasPrefix(errors[1], "node for mcp:") {
+		t.Errorf("Unexpected component validation: %v %v", ok, errors)
+	}
+}
+
+func TestDiagnoseRequirements(t *testing.T) {
+	v := newFakeValidator(map[string]string{"node": "v16.20.0"})
+	diagnostics := v.DiagnoseRequirements(map[string]map[string]string{"mcp": {"node": ">=18.0.0"}})
+
+	checks := diagnostics["checks"].(map[string]map[string]string)
+	if checks["node"]["status"] != "fail" || checks["node"]["version"] != "16.20.0" {
+		t.Errorf("Unexpected node check: %v", checks["node"])
+	}
+	if issues := diagnostics["issues"].([]string); len(issues) != 1 {
+		t.Errorf("Expected one issue, got %v", issues)
+	}
+
+	// Missing optional tools are recommended without raising an issue
+	optional := diagnostics["optional"].([]RequirementResult)
+	if len(optional) != len(OptionalTools) || optional[0].Tool != "ast-grep" || optional[0].Found {
+		t.Errorf("Unexpected optional results: %+v", optional)
+	}
+	recommendations := strings.Join(diagnostics["recommendations"].([]string), "\n")
+	for _, want := range []string{"Install Node.js:", "Install ast-grep (optional, used by agents):", "https://ast-grep.github.io/"} {
+		if !strings.Contains(recommendations, want) {
+			t.Errorf("Expected recommendations to contain %q:\n%s", want, recommendations)
+		}
+	}
+}
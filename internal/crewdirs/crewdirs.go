@@ -0,0 +1,136 @@
+// Package crewdirs locates crew's own state, configuration and caches.
+//
+// By default they live in <install-dir>/.crew. With the XDG layout,
+// .crew/config moves to $XDG_CONFIG_HOME/supercrew, .crew/cache to
+// $XDG_CACHE_HOME/supercrew and everything else to $XDG_DATA_HOME/supercrew.
+// An installation uses the XDG layout once its metadata is found in
+// $XDG_CONFIG_HOME/supercrew, or when it is chosen with UseXDG.
+package crewdirs
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+)
+
+// Name is the directory crew uses under each XDG base directory
+const Name = "supercrew"
+
+// LegacyDir is the directory under the install directory of the default
+// layout
+const LegacyDir = ".crew"
+
+// MetadataFile is the installation metadata, kept in the config directory
+const MetadataFile = "crew-metadata.json"
+
+// Top-level .crew entries that move to their own XDG base directory
+const (
+	configEntry = "config"
+	cacheEntry  = "cache"
+)
+
+// xdgDirs holds the installations known to use the XDG layout, so the
+// layout stays put while an uninstall removes the metadata
+var xdgDirs sync.Map
+
+// UseXDG makes installDir use the XDG layout for the rest of the process,
+// e.g. for a new installation
+func UseXDG(installDir string) {
+	if dir, err := filepath.Abs(installDir); err == nil {
+		xdgDirs.Store(dir, true)
+	}
+}
+
+// EnvXDG reports whether CREW_XDG asks for the XDG layout
+func EnvXDG() bool {
+	enabled, _ := strconv.ParseBool(os.Getenv("CREW_XDG"))
+	return enabled
+}
+
+// XDG reports whether the installation in installDir uses the XDG layout
+func XDG(installDir string) bool {
+	abs, err := filepath.Abs(installDir)
+	if err != nil {
+		return false
+	}
+	if _, ok := xdgDirs.Load(abs); ok {
+		return true
+	}
+	data, err := os.ReadFile(filepath.Join(ConfigHome(), MetadataFile))
+	if err != nil {
+		return false
+	}
+	var meta struct {
+		Installation struct {
+			InstallDir string `json:"install_dir"`
+		} `json:"installation"`
+	}
+	if json.Unmarshal(data, &meta) != nil || meta.Installation.InstallDir == "" {
+		return false
+	}
+	if recorded, err := filepath.Abs(meta.Installation.InstallDir); err != nil || recorded != abs {
+		return false
+	}
+	xdgDirs.Store(abs, true)
+	return true
+}
+
+// Path returns the path of elem under the .crew directory of installDir,
+// e.g. Path(dir, "config", "config.json"), in the layout the installation
+// uses. Without elem it returns the directory holding crew's state.
+func Path(installDir string, elem ...string) string {
+	if !XDG(installDir) {
+		return filepath.Join(append([]string{installDir, LegacyDir}, elem...)...)
+	}
+	return xdgPath(elem...)
+}
+
+// Roots returns every directory crew keeps its files of installDir in
+func Roots(installDir string) []string {
+	if !XDG(installDir) {
+		return []string{filepath.Join(installDir, LegacyDir)}
+	}
+	return []string{DataHome(), ConfigHome(), CacheHome()}
+}
+
+// DataHome returns the directory crew keeps its state in with the XDG layout
+func DataHome() string {
+	return filepath.Join(baseDir("XDG_DATA_HOME", ".local", "share"), Name)
+}
+
+// ConfigHome returns the directory crew keeps its configuration in with the
+// XDG layout
+func ConfigHome() string {
+	return filepath.Join(baseDir("XDG_CONFIG_HOME", ".config"), Name)
+}
+
+// CacheHome returns the directory crew keeps its caches in with the XDG
+// layout
+func CacheHome() string {
+	return filepath.Join(baseDir("XDG_CACHE_HOME", ".cache"), Name)
+}
+
+func xdgPath(elem ...string) string {
+	if len(elem) > 0 {
+		switch elem[0] {
+		case configEntry:
+			return filepath.Join(append([]string{ConfigHome()}, elem[1:]...)...)
+		case cacheEntry:
+			return filepath.Join(append([]string{CacheHome()}, elem[1:]...)...)
+		}
+	}
+	return filepath.Join(append([]string{DataHome()}, elem...)...)
+}
+
+// baseDir returns the XDG base directory in env, or its default under the
+// home directory. Relative values are invalid and ignored, as the
+// specification requires.
+func baseDir(env string, fallback ...string) string {
+	if dir := os.Getenv(env); filepath.IsAbs(dir) {
+		return dir
+	}
+	home, _ := os.UserHomeDir()
+	return filepath.Join(append([]string{home}, fallback...)...)
+}
@@ -0,0 +1,107 @@
+package crewdirs
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func setXDGHomes(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	t.Setenv("HOME", root)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(root, "cfg"))
+	t.Setenv("XDG_DATA_HOME", filepath.Join(root, "data"))
+	t.Setenv("XDG_CACHE_HOME", "relative/is/ignored")
+	return root
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPathLayouts(t *testing.T) {
+	root := setXDGHomes(t)
+	legacyDir := filepath.Join(root, "legacy")
+	xdgDir := filepath.Join(root, "xdg")
+	UseXDG(xdgDir)
+
+	tests := []struct {
+		dir  string
+		elem []string
+		want string
+	}{
+		{legacyDir, []string{"config", "config.json"}, filepath.Join(legacyDir, ".crew", "config", "config.json")},
+		{legacyDir, nil, filepath.Join(legacyDir, ".crew")},
+		{xdgDir, []string{"config", "config.json"}, filepath.Join(root, "cfg", "supercrew", "config.json")},
+		{xdgDir, []string{"cache", "releases"}, filepath.Join(root, ".cache", "supercrew", "releases")},
+		{xdgDir, []string{"logs"}, filepath.Join(root, "data", "supercrew", "logs")},
+		{xdgDir, nil, filepath.Join(root, "data", "supercrew")},
+	}
+	for _, tt := range tests {
+		if got := Path(tt.dir, tt.elem...); got != tt.want {
+			t.Errorf("Path(%s, %v) = %s, want %s", tt.dir, tt.elem, got, tt.want)
+		}
+	}
+}
+
+func TestMigrateXDG(t *testing.T) {
+	root := setXDGHomes(t)
+	installDir := filepath.Join(root, ".claude")
+	legacy := filepath.Join(installDir, ".crew")
+	writeFile(t, filepath.Join(legacy, "config", MetadataFile), `{"installation":{"version":"1.0.0"}}`)
+	writeFile(t, filepath.Join(legacy, "cache", "releases", "index.json"), "{}")
+	writeFile(t, filepath.Join(legacy, "logs", "crew.log"), "log")
+
+	if XDG(installDir) {
+		t.Fatal("installation detected as XDG before the migration")
+	}
+	moves, err := PlanXDG(installDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(moves) != 3 {
+		t.Fatalf("got %d moves, want 3: %v", len(moves), moves)
+	}
+	if err := MigrateXDG(installDir, moves); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := os.Stat(legacy); !os.IsNotExist(err) {
+		t.Errorf("%s still exists", legacy)
+	}
+	if !XDG(installDir) {
+		t.Fatal("installation not detected as XDG after the migration")
+	}
+	for _, elem := range [][]string{{"config", MetadataFile}, {"cache", "releases", "index.json"}, {"logs", "crew.log"}} {
+		if _, err := os.Stat(Path(installDir, elem...)); err != nil {
+			t.Errorf("%v not migrated: %v", elem, err)
+		}
+	}
+
+	data, err := os.ReadFile(Path(installDir, "config", MetadataFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `"version": "1.0.0"`; !strings.Contains(string(data), want) {
+		t.Errorf("metadata lost its fields: %s", data)
+	}
+}
+
+func TestPlanXDGRefusesExistingTargets(t *testing.T) {
+	root := setXDGHomes(t)
+	installDir := filepath.Join(root, ".claude")
+	writeFile(t, filepath.Join(installDir, ".crew", "logs", "crew.log"), "log")
+	writeFile(t, filepath.Join(root, "data", "supercrew", "logs", "other.log"), "log")
+
+	if _, err := PlanXDG(installDir); err == nil {
+		t.Error("expected an error for an existing target")
+	}
+}
@@ -0,0 +1,113 @@
+package crewdirs
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Move is one file or directory a migration moves
+type Move struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// PlanXDG returns the moves that bring the .crew directory of installDir
+// to the XDG layout. It fails when a target already exists.
+func PlanXDG(installDir string) ([]Move, error) {
+	legacy := filepath.Join(installDir, LegacyDir)
+	entries, err := os.ReadDir(legacy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", legacy, err)
+	}
+
+	var moves []Move
+	for _, entry := range entries {
+		name := entry.Name()
+		if (name != configEntry && name != cacheEntry) || !entry.IsDir() {
+			moves = append(moves, Move{From: filepath.Join(legacy, name), To: xdgPath(name)})
+			continue
+		}
+		// config and cache become base directories of their own, so their
+		// entries move one by one
+		children, err := os.ReadDir(filepath.Join(legacy, name))
+		if err != nil {
+			return nil, err
+		}
+		for _, child := range children {
+			moves = append(moves, Move{
+				From: filepath.Join(legacy, name, child.Name()),
+				To:   xdgPath(name, child.Name()),
+			})
+		}
+	}
+
+	for _, m := range moves {
+		if _, err := os.Lstat(m.To); err == nil {
+			return nil, fmt.Errorf("%s already exists", m.To)
+		}
+	}
+	return moves, nil
+}
+
+// MigrateXDG makes the moves of PlanXDG, records installDir in the moved
+// metadata so the layout is detected from then on, and removes the emptied
+// .crew directory
+func MigrateXDG(installDir string, moves []Move) error {
+	installDir, err := filepath.Abs(installDir)
+	if err != nil {
+		return err
+	}
+	for _, m := range moves {
+		if err := os.MkdirAll(filepath.Dir(m.To), 0755); err != nil {
+			return err
+		}
+		if err := os.Rename(m.From, m.To); err != nil {
+			return fmt.Errorf("failed to move %s: %w", m.From, err)
+		}
+	}
+	if err := recordInstallDir(filepath.Join(ConfigHome(), MetadataFile), installDir); err != nil {
+		return fmt.Errorf("failed to update metadata: %w", err)
+	}
+
+	legacy := filepath.Join(installDir, LegacyDir)
+	os.Remove(filepath.Join(legacy, configEntry))
+	os.Remove(filepath.Join(legacy, cacheEntry))
+	if err := os.Remove(legacy); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove %s: %w", legacy, err)
+	}
+	return nil
+}
+
+// recordInstallDir sets installation.install_dir in the metadata file,
+// keeping every other field as is
+func recordInstallDir(path, installDir string) error {
+	meta := map[string]interface{}{}
+	data, err := os.ReadFile(path)
+	switch {
+	case os.IsNotExist(err):
+	case err != nil:
+		return err
+	default:
+		if err := json.Unmarshal(data, &meta); err != nil {
+			return err
+		}
+	}
+
+	installation, _ := meta["installation"].(map[string]interface{})
+	if installation == nil {
+		installation = map[string]interface{}{}
+	}
+	installation["install_dir"] = installDir
+	meta["installation"] = installation
+
+	data, err = json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
@@ -0,0 +1,188 @@
+// Package crewignore reads .crewignore files, which exclude paths in an
+// installation directory from backups, install copies and integrity
+// scans. The syntax is that of .gitignore: one pattern per line, # starts
+// a comment, ! re-includes, a trailing / only matches directories, a
+// pattern with a / in it is relative to the installation directory, and
+// ** matches any number of directories.
+package crewignore
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// FileName is the name of the ignore file in the installation directory
+const FileName = ".crewignore"
+
+// pattern is one line of an ignore file
+type pattern struct {
+	re      *regexp.Regexp
+	negate  bool
+	dirOnly bool
+}
+
+// Matcher decides which paths an ignore file excludes. A nil Matcher
+// excludes nothing.
+type Matcher struct {
+	patterns []pattern
+}
+
+// Load reads the .crewignore of installDir. A missing file gives a
+// Matcher that excludes nothing.
+func Load(installDir string) (*Matcher, error) {
+	f, err := os.Open(filepath.Join(installDir, FileName))
+	if os.IsNotExist(err) {
+		return &Matcher{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Parse(f)
+}
+
+// Parse reads patterns in .gitignore syntax from r
+func Parse(r io.Reader) (*Matcher, error) {
+	m := &Matcher{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if p, ok := parseLine(scanner.Text()); ok {
+			m.patterns = append(m.patterns, p)
+		}
+	}
+	return m, scanner.Err()
+}
+
+func parseLine(line string) (pattern, bool) {
+	line = strings.TrimSuffix(line, "\r")
+	// Trailing spaces are ignored unless escaped
+	for strings.HasSuffix(line, " ") && !strings.HasSuffix(line, "\\ ") {
+		line = line[:len(line)-1]
+	}
+	if line == "" || strings.HasPrefix(line, "#") {
+		return pattern{}, false
+	}
+
+	var p pattern
+	if strings.HasPrefix(line, "!") {
+		p.negate = true
+		line = line[1:]
+	}
+	if strings.HasSuffix(line, "/") {
+		p.dirOnly = true
+		line = strings.TrimRight(line, "/")
+	}
+	if line == "" {
+		return pattern{}, false
+	}
+
+	anchored := strings.Contains(line, "/")
+	line = strings.TrimPrefix(line, "/")
+	expr := translate(line)
+	if anchored {
+		expr = "^" + expr + "$"
+	} else {
+		expr = "^(?:.*/)?" + expr + "$"
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return pattern{}, false
+	}
+	p.re = re
+	return p, true
+}
+
+// translate turns a glob into a regular expression where * and ? stay
+// within one path element and ** crosses any number of them
+func translate(glob string) string {
+	var b strings.Builder
+	for i := 0; i < len(glob); i++ {
+		c := glob[i]
+		switch {
+		case strings.HasPrefix(glob[i:], "**/"):
+			b.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(glob[i:], "**") && i+2 == len(glob):
+			b.WriteString(".*")
+			i++
+		case c == '*':
+			b.WriteString("[^/]*")
+		case c == '?':
+			b.WriteString("[^/]")
+		case c == '\\' && i+1 < len(glob):
+			i++
+			b.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		case c == '[':
+			end := strings.IndexByte(glob[i+1:], ']')
+			if end < 0 {
+				b.WriteString(`\[`)
+				continue
+			}
+			class := glob[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + strings.ReplaceAll(class, `\`, `\\`) + "]")
+			i += end + 1
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	return b.String()
+}
+
+// Match reports whether rel, a path relative to the installation
+// directory, is excluded. As with git, nothing below an excluded
+// directory can be included again.
+func (m *Matcher) Match(rel string, isDir bool) bool {
+	if m == nil || len(m.patterns) == 0 {
+		return false
+	}
+	rel = filepath.ToSlash(filepath.Clean(rel))
+	if rel == "." || strings.HasPrefix(rel, "../") {
+		return false
+	}
+	parts := strings.Split(rel, "/")
+	for i := 1; i < len(parts); i++ {
+		if m.matchOne(strings.Join(parts[:i], "/"), true) {
+			return true
+		}
+	}
+	return m.matchOne(rel, isDir)
+}
+
+// matchOne applies the patterns to rel alone; the last match wins
+func (m *Matcher) matchOne(rel string, isDir bool) bool {
+	excluded := false
+	for _, p := range m.patterns {
+		if p.dirOnly && !isDir {
+			continue
+		}
+		if p.re.MatchString(rel) {
+			excluded = !p.negate
+		}
+	}
+	return excluded
+}
+
+// MatchPath is Match for an absolute path below root. Paths outside root
+// are never excluded.
+func (m *Matcher) MatchPath(root, path string, isDir bool) bool {
+	if m == nil {
+		return false
+	}
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return false
+	}
+	return m.Match(rel, isDir)
+}
+
+// Empty reports whether the matcher excludes nothing
+func (m *Matcher) Empty() bool {
+	return m == nil || len(m.patterns) == 0
+}
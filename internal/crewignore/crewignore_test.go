@@ -0,0 +1,86 @@
+package crewignore
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMatch(t *testing.T) {
+	m, err := Parse(strings.NewReader(`
+# caches and environments
+__pycache__/
+*.pyc
+/data
+projects/**/venv
+!keep.pyc
+logs/*.jsonl
+\#literal
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		rel   string
+		isDir bool
+		want  bool
+	}{
+		{"__pycache__", true, true},
+		{"agents/__pycache__", true, true},
+		{"agents/__pycache__/x.py", false, true},
+		{"__pycache__", false, false}, // a file, the pattern wants a directory
+		{"hooks/tool.pyc", false, true},
+		{"hooks/keep.pyc", false, false},
+		{"data", true, true},
+		{"data/big.bin", false, true},
+		{"agents/data", true, false}, // anchored to the root
+		{"projects/a/venv", true, true},
+		{"projects/venv", true, true},
+		{"projects/a/b/venv/lib/site.py", false, true},
+		{"logs/audit.jsonl", false, true},
+		{"logs/old/audit.jsonl", false, false}, // * stays within one directory
+		{"#literal", false, true},
+		{"agents/reviewer.md", false, false},
+		{".", true, false},
+	}
+	for _, tt := range tests {
+		if got := m.Match(tt.rel, tt.isDir); got != tt.want {
+			t.Errorf("Match(%q, %v) = %v, expected %v", tt.rel, tt.isDir, got, tt.want)
+		}
+	}
+}
+
+func TestExcludedDirectoryCannotBeReincluded(t *testing.T) {
+	m, _ := Parse(strings.NewReader("cache/\n!cache/keep.txt\n"))
+	if !m.Match("cache/keep.txt", false) {
+		t.Error("A file below an excluded directory should stay excluded, as in git")
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	m, err := Load(dir)
+	if err != nil || !m.Empty() {
+		t.Fatalf("Expected an empty matcher without a .crewignore, got %v, %v", m, err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, FileName), []byte("venv/\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if m, err = Load(dir); err != nil {
+		t.Fatal(err)
+	}
+	if !m.MatchPath(dir, filepath.Join(dir, "venv"), true) {
+		t.Error("Expected venv to be excluded")
+	}
+	if m.MatchPath(dir, filepath.Join(filepath.Dir(dir), "venv"), true) {
+		t.Error("Paths outside the root should never be excluded")
+	}
+
+	var none *Matcher
+	if none.Match("anything", false) {
+		t.Error("A nil matcher should exclude nothing")
+	}
+}
@@ -0,0 +1,190 @@
+// Package devsync keeps an installation in step with a SuperCrew source
+// tree while the framework is being edited. Each changed source file is
+// copied to where crew install puts it, unless the installed copy was
+// modified by the user since it was installed.
+package devsync
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/fileops"
+	"github.com/jonwraymond/claude-code-super-crew/internal/metadata"
+)
+
+// Actions reported for a synced path
+const (
+	Synced    = "synced"
+	Unchanged = "unchanged"
+	Protected = "protected"
+	Linked    = "linked"
+	Skipped   = "skipped"
+	Removed   = "removed"
+	Failed    = "failed"
+)
+
+// Result is the outcome of syncing one source file
+type Result struct {
+	Source string `json:"source"`
+	Target string `json:"target,omitempty"`
+	Action string `json:"action"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// Syncer copies files from the SuperCrew directory of a checkout into an
+// installation
+type Syncer struct {
+	// Source is the SuperCrew directory, holding core, commands, agents
+	// and hooks
+	Source     string
+	InstallDir string
+	DryRun     bool
+}
+
+// New returns a syncer for the checkout or SuperCrew directory source
+func New(source, installDir string) (*Syncer, error) {
+	abs, err := filepath.Abs(source)
+	if err != nil {
+		return nil, err
+	}
+	if info, err := os.Stat(filepath.Join(abs, "SuperCrew")); err == nil && info.IsDir() {
+		abs = filepath.Join(abs, "SuperCrew")
+	}
+	for _, dir := range []string{"core", "commands"} {
+		if info, err := os.Stat(filepath.Join(abs, dir)); err != nil || !info.IsDir() {
+			return nil, fmt.Errorf("%s is not a SuperCrew source tree (no %s directory)", source, dir)
+		}
+	}
+	return &Syncer{Source: abs, InstallDir: installDir}, nil
+}
+
+// Target maps a path relative to Source to the path crew install gives it,
+// relative to the install directory, and the component owning it
+func Target(rel string) (target, component string, ok bool) {
+	parts := strings.SplitN(filepath.ToSlash(rel), "/", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return "", "", false
+	}
+	dir, name := parts[0], parts[1]
+	switch dir {
+	case "core":
+		// CLAUDE.md is merged with the user's content on install rather
+		// than copied
+		if strings.Contains(name, "/") || !strings.HasSuffix(name, ".md") || name == "CLAUDE.md" {
+			return "", "", false
+		}
+		return name, "core", true
+	case "commands":
+		if strings.Contains(name, "/") || !strings.HasSuffix(name, ".md") {
+			return "", "", false
+		}
+		return filepath.Join("commands", "crew", name), "commands", true
+	case "agents":
+		if !strings.HasSuffix(name, ".md") {
+			return "", "", false
+		}
+		return filepath.Join("agents", filepath.FromSlash(name)), "agents", true
+	case "hooks":
+		if strings.Contains(name, "/") || !(strings.HasSuffix(name, ".sh") || strings.HasSuffix(name, ".md")) {
+			return "", "", false
+		}
+		return filepath.Join("hooks", name), "hooks", true
+	}
+	return "", "", false
+}
+
+// Sync brings the installed copy of the source file path up to date
+func (s *Syncer) Sync(path string) Result {
+	rel, err := filepath.Rel(s.Source, path)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return Result{Source: path, Action: Skipped, Reason: "outside the source tree"}
+	}
+	result := Result{Source: filepath.ToSlash(rel)}
+	targetRel, component, ok := Target(rel)
+	if !ok {
+		result.Action, result.Reason = Skipped, "not installed by crew install"
+		return result
+	}
+	target := filepath.Join(s.InstallDir, targetRel)
+	result.Target = target
+
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		// Removing installed files is left to crew uninstall, which knows
+		// what else depends on them
+		result.Action, result.Reason = Removed, "deleted from the source; installed copy left in place"
+		return result
+	}
+	if err != nil || info.IsDir() {
+		result.Action, result.Reason = Skipped, "not a file"
+		return result
+	}
+
+	if fileops.IsLinkInto(target, s.Source) {
+		result.Action, result.Reason = Linked, "installed with --dev-link"
+		return result
+	}
+
+	want, err := fileHash(path)
+	if err != nil {
+		return failed(result, err)
+	}
+	current, err := fileHash(target)
+	switch {
+	case err == nil && current == want:
+		result.Action = Unchanged
+		return result
+	case err == nil && s.userModified(targetRel, current):
+		result.Action, result.Reason = Protected, "modified since it was installed"
+		return result
+	case err != nil && !os.IsNotExist(err):
+		return failed(result, err)
+	}
+
+	if s.DryRun {
+		result.Action, result.Reason = Synced, "dry run"
+		return result
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return failed(result, err)
+	}
+	if err := fileops.CopyFile(path, target); err != nil {
+		return failed(result, err)
+	}
+	// The synced content is the new baseline for spotting user edits
+	mm := metadata.NewMetadataManager(s.InstallDir)
+	if mm.CheckInstallationExists() {
+		if err := mm.AddFileToIntegrityTracking(targetRel, component); err != nil {
+			result.Reason = fmt.Sprintf("synced, but not tracked: %v", err)
+		}
+	}
+	result.Action = Synced
+	return result
+}
+
+// userModified reports whether the installed file rel, now hashing to
+// current, differs from what crew last installed there. Files crew does not
+// track belong to the framework and are never protected.
+func (s *Syncer) userModified(rel, current string) bool {
+	mm := metadata.NewMetadataManager(s.InstallDir)
+	if !mm.CheckInstallationExists() {
+		return false
+	}
+	meta, err := mm.LoadMetadata()
+	if err != nil {
+		return false
+	}
+	tracked, ok := meta.Integrity.FileHashes[rel]
+	return ok && tracked.OriginalHash != "" && tracked.OriginalHash != current
+}
+
+func failed(result Result, err error) Result {
+	result.Action, result.Reason = Failed, err.Error()
+	return result
+}
+
+func fileHash(path string) (string, error) {
+	return fileops.HashFile(path)
+}
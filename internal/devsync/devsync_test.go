@@ -0,0 +1,138 @@
+package devsync
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/metadata"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func newSyncer(t *testing.T) *Syncer {
+	t.Helper()
+	repo := t.TempDir()
+	writeFile(t, filepath.Join(repo, "SuperCrew", "core", "RULES.md"), "rules v1")
+	writeFile(t, filepath.Join(repo, "SuperCrew", "commands", "build.md"), "build v1")
+	s, err := New(repo, t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := metadata.NewMetadataManager(s.InstallDir).SaveMetadata(&metadata.UnifiedMetadata{}); err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestTarget(t *testing.T) {
+	tests := map[string]string{
+		"core/RULES.md":          "RULES.md",
+		"commands/build.md":      filepath.Join("commands", "crew", "build.md"),
+		"agents/templates/a.md":  filepath.Join("agents", "templates", "a.md"),
+		"hooks/pre-commit.sh":    filepath.Join("hooks", "pre-commit.sh"),
+		"core/CLAUDE.md":         "",
+		"addons/README.md":       "",
+		"commands/notes.txt":     "",
+		"commands/nested/foo.md": "",
+	}
+	for rel, want := range tests {
+		got, _, ok := Target(filepath.FromSlash(rel))
+		if ok != (want != "") || got != want {
+			t.Errorf("Target(%s) = %q, %v; want %q", rel, got, ok, want)
+		}
+	}
+}
+
+func TestSync(t *testing.T) {
+	s := newSyncer(t)
+	source := filepath.Join(s.Source, "commands", "build.md")
+	target := filepath.Join(s.InstallDir, "commands", "crew", "build.md")
+
+	if r := s.Sync(source); r.Action != Synced {
+		t.Fatalf("Expected a sync, got %+v", r)
+	}
+	if data, _ := os.ReadFile(target); string(data) != "build v1" {
+		t.Errorf("Unexpected content %q", data)
+	}
+	if r := s.Sync(source); r.Action != Unchanged {
+		t.Errorf("Expected no change, got %+v", r)
+	}
+
+	writeFile(t, source, "build v2")
+	if r := s.Sync(source); r.Action != Synced {
+		t.Errorf("Expected the edit to sync, got %+v", r)
+	}
+
+	// A user edit of the installed copy is protected
+	writeFile(t, target, "my build")
+	writeFile(t, source, "build v3")
+	if r := s.Sync(source); r.Action != Protected {
+		t.Errorf("Expected the user edit to be protected, got %+v", r)
+	}
+	if data, _ := os.ReadFile(target); string(data) != "my build" {
+		t.Errorf("Protected file overwritten with %q", data)
+	}
+
+	os.Remove(source)
+	if r := s.Sync(source); r.Action != Removed {
+		t.Errorf("Expected a removal report, got %+v", r)
+	}
+	if r := s.Sync(filepath.Join(s.Source, "core", "CLAUDE.md")); r.Action != Skipped {
+		t.Errorf("Expected CLAUDE.md to be skipped, got %+v", r)
+	}
+}
+
+func TestSyncDryRun(t *testing.T) {
+	s := newSyncer(t)
+	s.DryRun = true
+	if r := s.Sync(filepath.Join(s.Source, "core", "RULES.md")); r.Action != Synced {
+		t.Fatalf("Expected a sync, got %+v", r)
+	}
+	if _, err := os.Stat(filepath.Join(s.InstallDir, "RULES.md")); !os.IsNotExist(err) {
+		t.Error("Dry run wrote a file")
+	}
+}
+
+func TestNewRejectsOtherTrees(t *testing.T) {
+	if _, err := New(t.TempDir(), t.TempDir()); err == nil {
+		t.Error("Expected an error for a directory without a SuperCrew tree")
+	}
+}
+
+func TestWatch(t *testing.T) {
+	s := newSyncer(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	results := make(chan Result, 10)
+	done := make(chan error, 1)
+	go func() { done <- s.Watch(ctx, func(r Result) { results <- r }) }()
+
+	// Give the watcher time to register before editing
+	time.Sleep(100 * time.Millisecond)
+	writeFile(t, filepath.Join(s.Source, "core", "RULES.md"), "rules v2")
+
+	select {
+	case r := <-results:
+		if r.Action != Synced || r.Target != filepath.Join(s.InstallDir, "RULES.md") {
+			t.Errorf("Unexpected result %+v", r)
+		}
+	case <-ctx.Done():
+		t.Fatal("No sync reported")
+	}
+	cancel()
+	if err := <-done; err != nil {
+		t.Error(err)
+	}
+}
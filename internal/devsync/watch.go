@@ -0,0 +1,111 @@
+package devsync
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// Debounce coalesces the burst of events an editor emits on save
+const Debounce = 200 * time.Millisecond
+
+// Watch syncs each source file that changes until ctx is done, reporting
+// every result to report. Directories created later are watched too.
+func (s *Syncer) Watch(ctx context.Context, report func(Result)) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create file watcher: %w", err)
+	}
+	defer watcher.Close()
+
+	if err := addTree(watcher, s.Source); err != nil {
+		return fmt.Errorf("failed to watch %s: %w", s.Source, err)
+	}
+
+	pending := make(map[string]bool)
+	timer := time.NewTimer(Debounce)
+	timer.Stop()
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if ignored(event.Name) {
+				continue
+			}
+			if event.Has(fsnotify.Create) {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					// Files saved into a new directory before it was
+					// watched are picked up by the sync of the tree
+					addTree(watcher, event.Name)
+					filepath.WalkDir(event.Name, func(path string, d fs.DirEntry, err error) error {
+						if err == nil && !d.IsDir() {
+							pending[path] = true
+						}
+						return nil
+					})
+					timer.Reset(Debounce)
+					continue
+				}
+			}
+			if event.Has(fsnotify.Chmod) && !event.Has(fsnotify.Write) {
+				continue
+			}
+			pending[event.Name] = true
+			timer.Reset(Debounce)
+
+		case <-timer.C:
+			paths := make([]string, 0, len(pending))
+			for path := range pending {
+				paths = append(paths, path)
+			}
+			sort.Strings(paths)
+			for _, path := range paths {
+				delete(pending, path)
+				report(s.Sync(path))
+			}
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			report(Result{Source: s.Source, Action: Failed, Reason: fmt.Sprintf("file watcher error: %v", err)})
+		}
+	}
+}
+
+// addTree watches dir and every directory below it
+func addTree(watcher *fsnotify.Watcher, dir string) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if path != dir && ignored(path) {
+			return filepath.SkipDir
+		}
+		return watcher.Add(path)
+	})
+}
+
+// ignored reports editor swap and backup files and hidden directories
+func ignored(path string) bool {
+	name := filepath.Base(path)
+	return strings.HasPrefix(name, ".") || strings.HasSuffix(name, "~") ||
+		strings.HasSuffix(name, ".swp") || strings.HasSuffix(name, ".tmp")
+}
@@ -0,0 +1,216 @@
+// Package docgen renders the crew command tree as a Markdown reference and
+// as man pages, together with reference pages that are not commands, such
+// as the available components and hooks.
+//
+// Every generated file carries GeneratedMarker so that Write can remove
+// pages for commands that no longer exist without touching files a person
+// wrote, and Check can tell whether a checked-in reference is stale.
+package docgen
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// GeneratedMarker appears in the first line of every generated file
+const GeneratedMarker = "generated by crew docs generate; DO NOT EDIT"
+
+// Section is a reference page that is not a command
+type Section struct {
+	// Name is used in file names, e.g. "components"
+	Name    string
+	Title   string
+	Intro   string
+	Entries []Entry
+}
+
+// Entry is one item in a Section
+type Entry struct {
+	Term        string
+	Description string
+	Details     []string
+}
+
+// Page is a generated file, with Path relative to the output directory
+type Page struct {
+	Path    string
+	Content []byte
+}
+
+// Generator renders a command tree
+type Generator struct {
+	Root     *cobra.Command
+	Sections []Section
+
+	// Skip excludes commands, in addition to hidden and deprecated ones
+	Skip func(*cobra.Command) bool
+
+	// Replacer rewrites generated text, e.g. to replace the home directory
+	// in flag defaults with ~ so that output does not depend on who runs it
+	Replacer *strings.Replacer
+
+	// Source and Manual fill the man page header
+	Source string
+	Manual string
+}
+
+// commands returns the documented commands in depth-first order
+func (g *Generator) commands() []*cobra.Command {
+	var cmds []*cobra.Command
+	var walk func(*cobra.Command)
+	walk = func(cmd *cobra.Command) {
+		cmds = append(cmds, cmd)
+		for _, child := range g.children(cmd) {
+			walk(child)
+		}
+	}
+	walk(g.Root)
+	return cmds
+}
+
+// children returns the documented subcommands of cmd, sorted by name
+func (g *Generator) children(cmd *cobra.Command) []*cobra.Command {
+	var children []*cobra.Command
+	for _, child := range cmd.Commands() {
+		if !child.IsAvailableCommand() || child.IsAdditionalHelpTopicCommand() {
+			continue
+		}
+		if g.Skip != nil && g.Skip(child) {
+			continue
+		}
+		children = append(children, child)
+	}
+	sort.Slice(children, func(i, j int) bool { return children[i].Name() < children[j].Name() })
+	return children
+}
+
+func (g *Generator) page(path string, content string) Page {
+	if g.Replacer != nil {
+		content = g.Replacer.Replace(content)
+	}
+	return Page{Path: path, Content: []byte(content)}
+}
+
+// visibleFlags returns the flags of set that are not hidden
+func visibleFlags(set *pflag.FlagSet) []*pflag.Flag {
+	var flags []*pflag.Flag
+	set.VisitAll(func(f *pflag.Flag) {
+		if !f.Hidden {
+			flags = append(flags, f)
+		}
+	})
+	return flags
+}
+
+// flagDefault returns the default worth showing for f, or ""
+func flagDefault(f *pflag.Flag) string {
+	switch f.DefValue {
+	case "", "false", "[]", "0":
+		return ""
+	}
+	if f.Value.Type() == "string" {
+		return fmt.Sprintf("%q", f.DefValue)
+	}
+	return f.DefValue
+}
+
+// Write writes pages into dir, leaving unchanged files alone, and removes
+// generated files that are no longer produced. It returns the paths it
+// wrote and removed.
+func Write(dir string, pages []Page) (written, removed []string, err error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, nil, fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+
+	for _, p := range pages {
+		path := filepath.Join(dir, p.Path)
+		if existing, err := os.ReadFile(path); err == nil && bytes.Equal(existing, p.Content) {
+			continue
+		}
+		if err := os.WriteFile(path, p.Content, 0644); err != nil {
+			return written, removed, fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		written = append(written, path)
+	}
+
+	stale, err := staleFiles(dir, pages)
+	if err != nil {
+		return written, removed, err
+	}
+	for _, path := range stale {
+		if err := os.Remove(path); err != nil {
+			return written, removed, fmt.Errorf("failed to remove %s: %w", path, err)
+		}
+		removed = append(removed, path)
+	}
+	return written, removed, nil
+}
+
+// Check compares pages with the files in dir and returns the paths that
+// are missing, differ or are generated but no longer produced
+func Check(dir string, pages []Page) ([]string, error) {
+	var outdated []string
+	for _, p := range pages {
+		path := filepath.Join(dir, p.Path)
+		existing, err := os.ReadFile(path)
+		if err != nil || !bytes.Equal(existing, p.Content) {
+			outdated = append(outdated, path)
+		}
+	}
+
+	stale, err := staleFiles(dir, pages)
+	if err != nil {
+		return nil, err
+	}
+	return append(outdated, stale...), nil
+}
+
+// staleFiles returns generated files in dir that are not among pages
+func staleFiles(dir string, pages []Page) ([]string, error) {
+	current := make(map[string]bool)
+	for _, p := range pages {
+		current[p.Path] = true
+	}
+
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+
+	var stale []string
+	for _, entry := range entries {
+		if entry.IsDir() || current[entry.Name()] {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		if isGenerated(path) {
+			stale = append(stale, path)
+		}
+	}
+	return stale, nil
+}
+
+// isGenerated reports whether the first line of the file at path carries
+// GeneratedMarker
+func isGenerated(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	buf := make([]byte, 256)
+	n, _ := f.Read(buf)
+	firstLine, _, _ := bytes.Cut(buf[:n], []byte("\n"))
+	return bytes.Contains(firstLine, []byte(GeneratedMarker))
+}
@@ -0,0 +1,111 @@
+package docgen
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func testTree() *cobra.Command {
+	root := &cobra.Command{Use: "crew", Short: "Root command"}
+	root.PersistentFlags().String("install-dir", "/home/u/.claude", "Target installation directory")
+
+	install := &cobra.Command{
+		Use:   "install",
+		Short: "Install components",
+		Long:  "Install components.\n\nExamples:\n  crew install --components core",
+		Run:   func(*cobra.Command, []string) {},
+	}
+	install.Flags().StringSlice("components", nil, "Components to install")
+	root.AddCommand(install)
+	root.AddCommand(&cobra.Command{Use: "secret", Hidden: true, Run: func(*cobra.Command, []string) {}})
+	return root
+}
+
+func pageMap(pages []Page) map[string]string {
+	m := make(map[string]string)
+	for _, p := range pages {
+		m[p.Path] = string(p.Content)
+	}
+	return m
+}
+
+func TestMarkdown(t *testing.T) {
+	g := &Generator{
+		Root:     testTree(),
+		Sections: []Section{{Name: "components", Title: "Components", Entries: []Entry{{Term: "core 1.0.0", Details: []string{"Category: core"}}}}},
+		Replacer: strings.NewReplacer("/home/u", "~"),
+	}
+	pages := pageMap(g.Markdown())
+
+	if _, ok := pages["crew_secret.md"]; ok {
+		t.Error("Expected hidden commands to be skipped")
+	}
+	install, ok := pages["crew_install.md"]
+	if !ok {
+		t.Fatalf("Expected a page for crew install, got %v", pages)
+	}
+	for _, want := range []string{GeneratedMarker, "## crew install", "```\n  crew install --components core\n```", "--components strings", `(default "~/.claude")`, "* [crew](crew.md)"} {
+		if !strings.Contains(install, want) {
+			t.Errorf("Expected the install page to contain %q:\n%s", want, install)
+		}
+	}
+	if !strings.Contains(pages["crew.md"], "* [Components](crew-components.md)") {
+		t.Errorf("Expected the root page to link the sections:\n%s", pages["crew.md"])
+	}
+	if !strings.Contains(pages["crew-components.md"], "### core 1.0.0\n\n- Category: core") {
+		t.Errorf("Unexpected section page:\n%s", pages["crew-components.md"])
+	}
+}
+
+func TestMan(t *testing.T) {
+	pages := pageMap((&Generator{Root: testTree(), Source: "crew 1.0.0", Sections: []Section{{Name: "hooks", Title: "Hooks"}}}).Man())
+
+	install := pages["crew-install.1"]
+	for _, want := range []string{`.TH "CREW-INSTALL" "1" "" "crew 1.0.0"`, `\fB\-\-components\fR \fIstrings\fR`, ".nf\n  crew install \\-\\-components core\n.fi", `\fBcrew\fR(1)`} {
+		if !strings.Contains(install, want) {
+			t.Errorf("Expected the install man page to contain %q:\n%s", want, install)
+		}
+	}
+	if _, ok := pages["crew-hooks.7"]; !ok {
+		t.Errorf("Expected sections in man section 7, got %v", pages)
+	}
+}
+
+func TestWriteRemovesStaleGeneratedFiles(t *testing.T) {
+	dir := t.TempDir()
+	stale := filepath.Join(dir, "crew_removed.md")
+	manual := filepath.Join(dir, "README.md")
+	if err := os.WriteFile(stale, []byte("<!-- "+GeneratedMarker+" -->\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(manual, []byte("# Hand written\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	pages := (&Generator{Root: testTree()}).Markdown()
+	if outdated, err := Check(dir, pages); err != nil || len(outdated) != len(pages)+1 {
+		t.Errorf("Expected every page and the stale file to be outdated, got %v, %v", outdated, err)
+	}
+
+	written, removed, err := Write(dir, pages)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(written) != len(pages) || len(removed) != 1 || removed[0] != stale {
+		t.Errorf("Unexpected written %v and removed %v", written, removed)
+	}
+	if _, err := os.Stat(manual); err != nil {
+		t.Error("Expected a hand written file to be kept")
+	}
+
+	if outdated, err := Check(dir, pages); err != nil || len(outdated) != 0 {
+		t.Errorf("Expected the directory to be up to date, got %v, %v", outdated, err)
+	}
+	if written, _, _ := Write(dir, pages); len(written) != 0 {
+		t.Errorf("Expected unchanged pages not to be rewritten, got %v", written)
+	}
+}
@@ -0,0 +1,186 @@
+package docgen
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// Man page sections: commands go in section 1, reference pages such as
+// the component list in section 7
+const (
+	manCommandSection   = "1"
+	manReferenceSection = "7"
+)
+
+// manName returns the man page name of a command, e.g. crew-install
+func manName(cmd *cobra.Command) string {
+	return strings.ReplaceAll(cmd.CommandPath(), " ", "-")
+}
+
+// Man renders one man page per command and per section
+func (g *Generator) Man() []Page {
+	var pages []Page
+	for _, cmd := range g.commands() {
+		pages = append(pages, g.page(manName(cmd)+"."+manCommandSection, g.manCommand(cmd)))
+	}
+	for _, s := range g.Sections {
+		pages = append(pages, g.page(g.sectionManName(s)+"."+manReferenceSection, g.manSection(s)))
+	}
+	return pages
+}
+
+func (g *Generator) sectionManName(s Section) string {
+	return g.Root.Name() + "-" + s.Name
+}
+
+func (g *Generator) manHeader(b *strings.Builder, name, section string) {
+	fmt.Fprintf(b, ".\\\" %s\n", GeneratedMarker)
+	fmt.Fprintf(b, ".TH %q %q \"\" %q %q\n", strings.ToUpper(name), section, g.Source, g.Manual)
+}
+
+func (g *Generator) manCommand(cmd *cobra.Command) string {
+	var b strings.Builder
+
+	g.manHeader(&b, manName(cmd), manCommandSection)
+	fmt.Fprintf(&b, ".SH NAME\n%s \\- %s\n", manName(cmd), roffEscape(cmd.Short))
+
+	fmt.Fprintf(&b, ".SH SYNOPSIS\n")
+	if cmd.Runnable() {
+		fmt.Fprintf(&b, ".B %s\n%s\n", cmd.CommandPath(), roffEscape(strings.TrimSpace(strings.TrimPrefix(cmd.UseLine(), cmd.CommandPath()))))
+	} else {
+		fmt.Fprintf(&b, ".B %s\n[command]\n", cmd.CommandPath())
+	}
+
+	description := cmd.Long
+	if description == "" {
+		description = cmd.Short
+	}
+	fmt.Fprintf(&b, ".SH DESCRIPTION\n%s\n", roffText(description))
+
+	if flags := visibleFlags(cmd.NonInheritedFlags()); len(flags) > 0 {
+		fmt.Fprintf(&b, ".SH OPTIONS\n%s", manFlags(flags))
+	}
+	if flags := visibleFlags(cmd.InheritedFlags()); len(flags) > 0 {
+		fmt.Fprintf(&b, ".SH OPTIONS INHERITED FROM PARENT COMMANDS\n%s", manFlags(flags))
+	}
+	if cmd.Example != "" {
+		fmt.Fprintf(&b, ".SH EXAMPLES\n.nf\n%s\n.fi\n", roffEscape(strings.TrimRight(cmd.Example, "\n")))
+	}
+
+	var seeAlso []string
+	if parent := cmd.Parent(); parent != nil {
+		seeAlso = append(seeAlso, manReference(manName(parent), manCommandSection))
+	}
+	for _, child := range g.children(cmd) {
+		seeAlso = append(seeAlso, manReference(manName(child), manCommandSection))
+	}
+	if cmd == g.Root {
+		for _, s := range g.Sections {
+			seeAlso = append(seeAlso, manReference(g.sectionManName(s), manReferenceSection))
+		}
+	}
+	if len(seeAlso) > 0 {
+		fmt.Fprintf(&b, ".SH SEE ALSO\n%s\n", strings.Join(seeAlso, ",\n"))
+	}
+
+	return b.String()
+}
+
+func (g *Generator) manSection(s Section) string {
+	var b strings.Builder
+
+	name := g.sectionManName(s)
+	g.manHeader(&b, name, manReferenceSection)
+	fmt.Fprintf(&b, ".SH NAME\n%s \\- %s\n", name, roffEscape(s.Title))
+	if s.Intro != "" {
+		fmt.Fprintf(&b, ".SH DESCRIPTION\n%s\n", roffText(s.Intro))
+	}
+	if len(s.Entries) > 0 {
+		fmt.Fprintf(&b, ".SH %s\n", strings.ToUpper(roffEscape(s.Title)))
+		for _, e := range s.Entries {
+			fmt.Fprintf(&b, ".TP\n\\fB%s\\fR\n%s\n", roffEscape(e.Term), roffEscape(e.Description))
+			for _, d := range e.Details {
+				fmt.Fprintf(&b, ".br\n%s\n", roffEscape(d))
+			}
+		}
+	}
+	fmt.Fprintf(&b, ".SH SEE ALSO\n%s\n", manReference(g.Root.Name(), manCommandSection))
+
+	return b.String()
+}
+
+func manReference(name, section string) string {
+	return fmt.Sprintf("\\fB%s\\fR(%s)", roffEscape(name), section)
+}
+
+func manFlags(flags []*pflag.Flag) string {
+	var b strings.Builder
+	for _, f := range flags {
+		varname, usage := pflag.UnquoteUsage(f)
+
+		b.WriteString(".TP\n")
+		if f.Shorthand != "" && f.ShorthandDeprecated == "" {
+			fmt.Fprintf(&b, "\\fB\\-%s\\fR, ", f.Shorthand)
+		}
+		fmt.Fprintf(&b, "\\fB\\-\\-%s\\fR", roffEscape(f.Name))
+		if varname != "" {
+			fmt.Fprintf(&b, " \\fI%s\\fR", roffEscape(varname))
+		}
+		b.WriteString("\n" + roffEscape(usage))
+		if def := flagDefault(f); def != "" {
+			fmt.Fprintf(&b, " (default %s)", roffEscape(def))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// roffText renders help text: paragraphs are filled, indented blocks such
+// as example lists keep their layout
+func roffText(text string) string {
+	var lines []string
+	inBlock := false
+	blank := false
+	for _, line := range strings.Split(strings.TrimRight(text, "\n"), "\n") {
+		line = strings.TrimRight(line, " \t")
+		if line == "" {
+			blank = true
+			continue
+		}
+		indented := strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")
+		switch {
+		case indented && !inBlock:
+			lines = append(lines, ".PP", ".nf")
+			inBlock = true
+		case !indented && inBlock:
+			lines = append(lines, ".fi", ".PP")
+			inBlock = false
+		case blank && inBlock:
+			lines = append(lines, "")
+		case blank:
+			lines = append(lines, ".PP")
+		}
+		blank = false
+		lines = append(lines, roffEscape(line))
+	}
+	if inBlock {
+		lines = append(lines, ".fi")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// roffEscape escapes text so that roff prints it literally
+func roffEscape(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\e`)
+	s = strings.ReplaceAll(s, "-", `\-`)
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, ".") || strings.HasPrefix(line, "'") {
+			lines[i] = `\&` + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,131 @@
+package docgen
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// markdownFile returns the file name of a command's Markdown page, e.g.
+// crew_install.md
+func markdownFile(cmd *cobra.Command) string {
+	return strings.ReplaceAll(cmd.CommandPath(), " ", "_") + ".md"
+}
+
+// Markdown renders one page per command and per section
+func (g *Generator) Markdown() []Page {
+	var pages []Page
+	for _, cmd := range g.commands() {
+		pages = append(pages, g.page(markdownFile(cmd), g.markdownCommand(cmd)))
+	}
+	for _, s := range g.Sections {
+		pages = append(pages, g.page(g.sectionMarkdownFile(s), markdownSection(s)))
+	}
+	return pages
+}
+
+// sectionMarkdownFile returns the file name of a section's page. It uses a
+// dash so that it cannot collide with a command page, e.g. crew-hooks.md
+// next to crew_hooks.md.
+func (g *Generator) sectionMarkdownFile(s Section) string {
+	return g.Root.Name() + "-" + s.Name + ".md"
+}
+
+func (g *Generator) markdownCommand(cmd *cobra.Command) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "<!-- %s -->\n\n", GeneratedMarker)
+	fmt.Fprintf(&b, "## %s\n\n%s\n\n", cmd.CommandPath(), cmd.Short)
+
+	if cmd.Long != "" {
+		fmt.Fprintf(&b, "### Synopsis\n\n%s\n\n", markdownText(cmd.Long))
+	}
+	if cmd.Runnable() {
+		fmt.Fprintf(&b, "```\n%s\n```\n\n", cmd.UseLine())
+	}
+	if cmd.Example != "" {
+		fmt.Fprintf(&b, "### Examples\n\n```\n%s\n```\n\n", strings.TrimRight(cmd.Example, "\n"))
+	}
+
+	if flags := cmd.NonInheritedFlags(); flags.HasAvailableFlags() {
+		fmt.Fprintf(&b, "### Options\n\n```\n%s```\n\n", flags.FlagUsages())
+	}
+	if flags := cmd.InheritedFlags(); flags.HasAvailableFlags() {
+		fmt.Fprintf(&b, "### Options inherited from parent commands\n\n```\n%s```\n\n", flags.FlagUsages())
+	}
+
+	var seeAlso []string
+	if parent := cmd.Parent(); parent != nil {
+		seeAlso = append(seeAlso, fmt.Sprintf("* [%s](%s)\t - %s", parent.CommandPath(), markdownFile(parent), parent.Short))
+	}
+	for _, child := range g.children(cmd) {
+		seeAlso = append(seeAlso, fmt.Sprintf("* [%s](%s)\t - %s", child.CommandPath(), markdownFile(child), child.Short))
+	}
+	if cmd == g.Root {
+		for _, s := range g.Sections {
+			seeAlso = append(seeAlso, fmt.Sprintf("* [%s](%s)", s.Title, g.sectionMarkdownFile(s)))
+		}
+	}
+	if len(seeAlso) > 0 {
+		fmt.Fprintf(&b, "### SEE ALSO\n\n%s\n", strings.Join(seeAlso, "\n"))
+	}
+
+	return strings.TrimRight(b.String(), "\n") + "\n"
+}
+
+func markdownSection(s Section) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "<!-- %s -->\n\n", GeneratedMarker)
+	fmt.Fprintf(&b, "## %s\n\n", s.Title)
+	if s.Intro != "" {
+		fmt.Fprintf(&b, "%s\n\n", markdownText(s.Intro))
+	}
+	for _, e := range s.Entries {
+		fmt.Fprintf(&b, "### %s\n\n", e.Term)
+		if e.Description != "" {
+			fmt.Fprintf(&b, "%s\n\n", e.Description)
+		}
+		for _, d := range e.Details {
+			fmt.Fprintf(&b, "- %s\n", d)
+		}
+		if len(e.Details) > 0 {
+			b.WriteString("\n")
+		}
+	}
+
+	return strings.TrimRight(b.String(), "\n") + "\n"
+}
+
+// markdownText fences the indented blocks of help text, such as example
+// lists, so that Markdown keeps their layout
+func markdownText(text string) string {
+	var b strings.Builder
+	inBlock := false
+	blanks := 0
+	for _, line := range strings.Split(strings.TrimRight(text, "\n"), "\n") {
+		line = strings.TrimRight(line, " \t")
+		if line == "" {
+			blanks++
+			continue
+		}
+		indented := strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")
+		switch {
+		case indented && !inBlock:
+			b.WriteString(strings.Repeat("\n", blanks) + "```\n")
+			inBlock = true
+		case !indented && inBlock:
+			b.WriteString("```\n" + strings.Repeat("\n", blanks))
+			inBlock = false
+		default:
+			b.WriteString(strings.Repeat("\n", blanks))
+		}
+		blanks = 0
+		b.WriteString(line + "\n")
+	}
+	if inBlock {
+		b.WriteString("```\n")
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
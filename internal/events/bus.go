@@ -0,0 +1,171 @@
+// Package events provides an in-process event bus that decouples
+// cross-cutting reactions (hooks, plugins, telemetry) from command bodies.
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Event types published by crew operations
+const (
+	InstallStarted     = "install.started"
+	InstallCompleted   = "install.completed"
+	InstallFailed      = "install.failed"
+	ComponentInstalled = "component.installed"
+	UpdateStarted      = "update.started"
+	UpdateCompleted    = "update.completed"
+	UpdateFailed       = "update.failed"
+	BackupStarted      = "backup.started"
+	BackupCreated      = "backup.created"
+	UninstallStarted   = "uninstall.started"
+	UninstallCompleted = "uninstall.completed"
+	IntegrityDrift     = "integrity.drift"
+	FileChanged        = "file.changed"
+)
+
+// Event is a single published occurrence. Data values are strings so they
+// can be passed to hook scripts as environment variables unchanged.
+type Event struct {
+	Type string
+	Time time.Time
+	Data map[string]string
+	// Context is the context of the operation that published the event;
+	// handlers that run processes stop them when it is cancelled
+	Context context.Context
+}
+
+// Handler reacts to an event. Returning an error vetoes the operation for
+// events that are published before work starts (*.started).
+type Handler func(Event) error
+
+// subscription binds a handler to a topic pattern
+type subscription struct {
+	id      int
+	pattern string
+	handler Handler
+}
+
+// Bus dispatches events to subscribers synchronously, in subscription order
+type Bus struct {
+	mu            sync.RWMutex
+	subscriptions []subscription
+	nextID        int
+}
+
+// NewBus creates an empty event bus
+func NewBus() *Bus {
+	return &Bus{}
+}
+
+var (
+	defaultBus     *Bus
+	defaultBusOnce sync.Once
+)
+
+// Default returns the process-wide event bus
+func Default() *Bus {
+	defaultBusOnce.Do(func() {
+		defaultBus = NewBus()
+	})
+	return defaultBus
+}
+
+// Subscribe registers handler for events matching pattern and returns a
+// function that removes the subscription. Patterns are exact event types,
+// a prefix wildcard such as "component.*", or "*" for every event.
+func (b *Bus) Subscribe(pattern string, handler Handler) func() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.nextID++
+	id := b.nextID
+	b.subscriptions = append(b.subscriptions, subscription{id: id, pattern: pattern, handler: handler})
+
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		for i, sub := range b.subscriptions {
+			if sub.id == id {
+				b.subscriptions = append(b.subscriptions[:i], b.subscriptions[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Publish delivers an event to every matching subscriber. All handlers run
+// even if one fails; their errors are joined in the result.
+func (b *Bus) Publish(eventType string, data map[string]string) error {
+	return b.PublishContext(context.Background(), eventType, data)
+}
+
+// PublishContext is Publish for an operation running under ctx
+func (b *Bus) PublishContext(ctx context.Context, eventType string, data map[string]string) error {
+	event := Event{Type: eventType, Time: time.Now(), Data: data, Context: ctx}
+	if event.Data == nil {
+		event.Data = map[string]string{}
+	}
+
+	b.mu.RLock()
+	var handlers []Handler
+	for _, sub := range b.subscriptions {
+		if matches(sub.pattern, eventType) {
+			handlers = append(handlers, sub.handler)
+		}
+	}
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, handler := range handlers {
+		if err := safeCall(handler, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Topics returns the distinct patterns with at least one subscriber
+func (b *Bus) Topics() []string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	seen := make(map[string]bool)
+	var topics []string
+	for _, sub := range b.subscriptions {
+		if !seen[sub.pattern] {
+			seen[sub.pattern] = true
+			topics = append(topics, sub.pattern)
+		}
+	}
+	sort.Strings(topics)
+	return topics
+}
+
+// matches reports whether eventType satisfies pattern
+func matches(pattern, eventType string) bool {
+	if pattern == "*" || pattern == eventType {
+		return true
+	}
+	if prefix, ok := strings.CutSuffix(pattern, ".*"); ok {
+		return strings.HasPrefix(eventType, prefix+".")
+	}
+	return false
+}
+
+// safeCall runs a handler, turning a panic into an error so one faulty
+// subscriber cannot take down the command that published the event
+func safeCall(handler Handler, event Event) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%s handler panicked: %v", event.Type, r)
+		}
+	}()
+	return handler(event)
+}
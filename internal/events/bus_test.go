@@ -0,0 +1,73 @@
+package events
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBusPublishMatchesPatterns(t *testing.T) {
+	bus := NewBus()
+
+	var exact, wildcard, all []string
+	bus.Subscribe(ComponentInstalled, func(e Event) error {
+		exact = append(exact, e.Data["component"])
+		return nil
+	})
+	bus.Subscribe("component.*", func(e Event) error {
+		wildcard = append(wildcard, e.Type)
+		return nil
+	})
+	bus.Subscribe("*", func(e Event) error {
+		all = append(all, e.Type)
+		return nil
+	})
+
+	if err := bus.Publish(ComponentInstalled, map[string]string{"component": "core"}); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+	if err := bus.Publish(InstallStarted, nil); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+
+	if len(exact) != 1 || exact[0] != "core" {
+		t.Errorf("Unexpected exact deliveries: %v", exact)
+	}
+	if len(wildcard) != 1 || wildcard[0] != ComponentInstalled {
+		t.Errorf("Unexpected wildcard deliveries: %v", wildcard)
+	}
+	if len(all) != 2 {
+		t.Errorf("Expected catch-all to see both events, got %v", all)
+	}
+}
+
+func TestBusPublishCollectsErrors(t *testing.T) {
+	bus := NewBus()
+	veto := errors.New("veto")
+
+	ran := 0
+	bus.Subscribe(InstallStarted, func(Event) error { ran++; return veto })
+	bus.Subscribe(InstallStarted, func(Event) error { ran++; panic("boom") })
+	bus.Subscribe(InstallStarted, func(Event) error { ran++; return nil })
+
+	err := bus.Publish(InstallStarted, nil)
+	if !errors.Is(err, veto) {
+		t.Errorf("Expected veto error, got %v", err)
+	}
+	if ran != 3 {
+		t.Errorf("Expected all handlers to run, ran %d", ran)
+	}
+}
+
+func TestBusUnsubscribe(t *testing.T) {
+	bus := NewBus()
+
+	calls := 0
+	unsubscribe := bus.Subscribe(UpdateCompleted, func(Event) error { calls++; return nil })
+	bus.Publish(UpdateCompleted, nil)
+	unsubscribe()
+	bus.Publish(UpdateCompleted, nil)
+
+	if calls != 1 {
+		t.Errorf("Expected 1 call after unsubscribe, got %d", calls)
+	}
+}
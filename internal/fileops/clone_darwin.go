@@ -0,0 +1,15 @@
+package fileops
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// clone replaces dst with an APFS clone of src
+func clone(src, dst string) error {
+	if err := os.Remove(dst); err != nil {
+		return err
+	}
+	return unix.Clonefile(src, dst, unix.CLONE_NOFOLLOW)
+}
@@ -0,0 +1,7 @@
+//go:build !linux && !darwin
+
+package fileops
+
+func clone(src, dst string) error {
+	return errNoClone
+}
@@ -0,0 +1,189 @@
+// Package fileops copies files and directory trees for installs and
+// backups. Where the filesystem supports it (reflinks on Btrfs and XFS,
+// clonefile on APFS) file contents are cloned instead of copied, which
+// shares the data blocks until either side changes and makes a copy
+// nearly free. Elsewhere the contents are copied.
+//
+// Copies keep permissions and modification times, recreate symlinks
+// rather than following them and replace the destination atomically, so
+// an interrupted copy never leaves a truncated file behind. Development
+// installs use Link instead, which symlinks files from a checkout.
+package fileops
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/retry"
+)
+
+// errNoClone is returned by clone where the platform cannot clone files
+var errNoClone = errors.New("file cloning not supported")
+
+// CopyFile copies src to dst, replacing dst if it exists. The parent
+// directory of dst must exist.
+func CopyFile(src, dst string) error {
+	info, err := os.Lstat(src)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case info.Mode()&os.ModeSymlink != 0:
+		return copySymlink(src, dst)
+	case !info.Mode().IsRegular():
+		return fmt.Errorf("cannot copy %s: not a regular file", src)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(dst), "."+filepath.Base(dst)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	tmp.Close()
+	done := false
+	defer func() {
+		if !done {
+			os.Remove(tmpPath)
+		}
+	}()
+
+	if err := clone(src, tmpPath); err != nil {
+		if err := copyContents(src, tmpPath); err != nil {
+			return fmt.Errorf("failed to copy %s: %w", src, err)
+		}
+	}
+	if err := os.Chmod(tmpPath, info.Mode().Perm()); err != nil {
+		return err
+	}
+	if err := os.Chtimes(tmpPath, info.ModTime(), info.ModTime()); err != nil {
+		return err
+	}
+	if err := renameRetrying(tmpPath, dst); err != nil {
+		return err
+	}
+	done = true
+	return nil
+}
+
+// renameRetrying replaces dst with src, waiting out another process, such
+// as a virus scanner or an editor on Windows, that holds dst for a moment
+func renameRetrying(src, dst string) error {
+	return retry.Do(context.Background(), retry.Lock, func() error {
+		return os.Rename(src, dst)
+	})
+}
+
+// copyContents copies the bytes of src into dst
+func copyContents(src, dst string) error {
+	var in *os.File
+	err := retry.Do(context.Background(), retry.Filesystem, func() error {
+		var err error
+		in, err = os.Open(src)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+func copySymlink(src, dst string) error {
+	target, err := os.Readlink(src)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(dst); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return os.Symlink(target, dst)
+}
+
+// Link replaces dst with a symlink to the absolute path of src, so dst
+// follows later edits to src
+func Link(src, dst string) error {
+	abs, err := filepath.Abs(src)
+	if err != nil {
+		return err
+	}
+	tmp := filepath.Join(filepath.Dir(dst), fmt.Sprintf(".%s.%d.link", filepath.Base(dst), os.Getpid()))
+	os.Remove(tmp)
+	if err := os.Symlink(abs, tmp); err != nil {
+		return err
+	}
+	if err := renameRetrying(tmp, dst); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+// IsLinkInto reports whether path is a symlink to a file below dir
+func IsLinkInto(path, dir string) bool {
+	info, err := os.Lstat(path)
+	if err != nil || info.Mode()&os.ModeSymlink == 0 {
+		return false
+	}
+	target, err := os.Readlink(path)
+	if err != nil {
+		return false
+	}
+	if !filepath.IsAbs(target) {
+		target = filepath.Join(filepath.Dir(path), target)
+	}
+	rel, err := filepath.Rel(dir, target)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// CopyDir copies the tree at src to dst. skip, if set, is called with the
+// path relative to src of each entry; a skipped directory is left out
+// with everything below it. Directories keep their permissions. A
+// cancelled ctx stops the copy between entries.
+func CopyDir(ctx context.Context, src, dst string, skip func(rel string, d fs.DirEntry) bool) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		if rel != "." && skip != nil && skip(rel, d) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		target := filepath.Join(dst, rel)
+		if d.IsDir() {
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+			return os.Chmod(target, info.Mode().Perm())
+		}
+		return CopyFile(path, target)
+	})
+}
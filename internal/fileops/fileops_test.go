@@ -0,0 +1,123 @@
+package fileops
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCopyFile(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "hook.sh")
+	if err := os.WriteFile(src, []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	modTime := time.Now().Add(-48 * time.Hour).Truncate(time.Second)
+	if err := os.Chtimes(src, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+
+	dst := filepath.Join(dir, "copy.sh")
+	if err := os.WriteFile(dst, []byte("a longer file that gets replaced\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := CopyFile(src, dst); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := os.Stat(dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(dst); string(data) != "#!/bin/sh\n" {
+		t.Errorf("Unexpected content %q", data)
+	}
+	if info.Mode().Perm() != 0755 {
+		t.Errorf("Expected mode 0755, got %v", info.Mode().Perm())
+	}
+	if !info.ModTime().Equal(modTime) {
+		t.Errorf("Expected modification time %v, got %v", modTime, info.ModTime())
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 2 {
+		t.Errorf("Expected no temporary files, got %v", entries)
+	}
+}
+
+func TestCopyFileSymlink(t *testing.T) {
+	dir := t.TempDir()
+	link := filepath.Join(dir, "link")
+	if err := os.Symlink("target.md", link); err != nil {
+		t.Skipf("Symlinks not supported: %v", err)
+	}
+
+	dst := filepath.Join(dir, "copy")
+	if err := CopyFile(link, dst); err != nil {
+		t.Fatal(err)
+	}
+	if target, err := os.Readlink(dst); err != nil || target != "target.md" {
+		t.Errorf("Expected a symlink to target.md, got %q, %v", target, err)
+	}
+}
+
+func TestLink(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "source", "agent.md")
+	if err := os.MkdirAll(filepath.Dir(src), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(src, []byte("v1"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	dst := filepath.Join(dir, "agent.md")
+	if err := os.WriteFile(dst, []byte("copied"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Link(src, dst); err != nil {
+		t.Skipf("Symlinks not supported: %v", err)
+	}
+	os.WriteFile(src, []byte("v2"), 0644)
+	if data, _ := os.ReadFile(dst); string(data) != "v2" {
+		t.Errorf("Expected the link to follow the source, got %q", data)
+	}
+	if !IsLinkInto(dst, filepath.Join(dir, "source")) {
+		t.Error("Expected a link into the source directory")
+	}
+	if IsLinkInto(dst, filepath.Join(dir, "other")) || IsLinkInto(src, dir) {
+		t.Error("Expected only links into the given directory to match")
+	}
+}
+
+func TestCopyDir(t *testing.T) {
+	src := t.TempDir()
+	for _, file := range []string{"CLAUDE.md", "agents/a.md", "backups/old.tar.gz"} {
+		path := filepath.Join(src, file)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(file), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	dst := filepath.Join(t.TempDir(), "copy")
+	skip := func(rel string, d fs.DirEntry) bool { return d.IsDir() && rel == "backups" }
+	if err := CopyDir(context.Background(), src, dst, skip); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dst, "agents", "a.md")); string(data) != "agents/a.md" {
+		t.Errorf("Unexpected content %q", data)
+	}
+	if _, err := os.Stat(filepath.Join(dst, "backups")); !os.IsNotExist(err) {
+		t.Error("Expected the skipped directory to be left out")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := CopyDir(ctx, src, filepath.Join(t.TempDir(), "cancelled"), nil); err != context.Canceled {
+		t.Errorf("Expected a cancelled copy, got %v", err)
+	}
+}
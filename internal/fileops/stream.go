@@ -0,0 +1,85 @@
+package fileops
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"sync"
+)
+
+// BufferSize is the size of the buffers Copy streams through
+const BufferSize = 256 << 10
+
+// DefaultMemoryLimit bounds the memory held by copy buffers at once
+const DefaultMemoryLimit = 64 << 20
+
+var (
+	buffers = sync.Pool{New: func() any { b := make([]byte, BufferSize); return &b }}
+
+	limitMu sync.Mutex
+	// slots holds one token per buffer that may be in use at once
+	slots = newSlots(DefaultMemoryLimit)
+)
+
+func newSlots(limit int64) chan struct{} {
+	n := limit / BufferSize
+	if n < 1 {
+		n = 1
+	}
+	return make(chan struct{}, n)
+}
+
+// SetMemoryLimit bounds the memory the buffers of concurrent copies and
+// hashes may hold together. Streams beyond the limit wait for a buffer.
+// At least one buffer is always allowed.
+func SetMemoryLimit(bytes int64) {
+	limitMu.Lock()
+	defer limitMu.Unlock()
+	slots = newSlots(bytes)
+}
+
+// MemoryLimit returns the limit set by SetMemoryLimit, rounded down to
+// whole buffers
+func MemoryLimit() int64 {
+	limitMu.Lock()
+	defer limitMu.Unlock()
+	return int64(cap(slots)) * BufferSize
+}
+
+// Copy streams src into dst through a pooled buffer, waiting while the
+// memory limit is used up. Like io.Copy it lets files copy between
+// themselves in the kernel where they can.
+func Copy(dst io.Writer, src io.Reader) (int64, error) {
+	limitMu.Lock()
+	s := slots
+	limitMu.Unlock()
+
+	s <- struct{}{}
+	defer func() { <-s }()
+	buf := buffers.Get().(*[]byte)
+	defer buffers.Put(buf)
+
+	return io.CopyBuffer(dst, src, *buf)
+}
+
+// HashFile returns the hex SHA-256 of the file at path without reading it
+// into memory
+func HashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	return HashReader(f)
+}
+
+// HashReader returns the hex SHA-256 of what r yields, streaming it like
+// HashFile
+func HashReader(r io.Reader) (string, error) {
+	hash := sha256.New()
+	if _, err := Copy(hash, r); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
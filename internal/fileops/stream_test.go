@@ -0,0 +1,141 @@
+package fileops
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"testing"
+)
+
+// sparseSize is large enough that reading a file into memory would show
+// up as gigabytes of allocations
+const sparseSize = 2 << 30
+
+// zeros reads as an endless run of zero bytes
+type zeros struct{}
+
+func (zeros) Read(p []byte) (int, error) {
+	clear(p)
+	return len(p), nil
+}
+
+// sparseFile creates a file of size bytes that takes no disk space, with a
+// marker at the end so that truncated copies hash differently
+func sparseFile(t *testing.T, size int64) string {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("multi-GB sparse file test skipped in short mode")
+	}
+	path := filepath.Join(t.TempDir(), "large.bin")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if err := f.Truncate(size - 3); err != nil {
+		t.Skipf("sparse files not supported: %v", err)
+	}
+	if _, err := f.WriteAt([]byte("end"), size-3); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// allocated runs fn and returns the bytes it allocated on the heap
+func allocated(fn func()) uint64 {
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	fn()
+	runtime.ReadMemStats(&after)
+	return after.TotalAlloc - before.TotalAlloc
+}
+
+func TestHashFileStreamsLargeFiles(t *testing.T) {
+	path := sparseFile(t, sparseSize)
+
+	var hash string
+	var err error
+	used := allocated(func() { hash, err = HashFile(path) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if used > 16<<20 {
+		t.Errorf("Hashing a %d byte file allocated %d bytes", sparseSize, used)
+	}
+
+	want := sha256.New()
+	io.CopyN(want, zeros{}, sparseSize-3)
+	want.Write([]byte("end"))
+	if expected := hex.EncodeToString(want.Sum(nil)); hash != expected {
+		t.Errorf("HashFile = %s, expected %s", hash, expected)
+	}
+}
+
+func TestCopyFileStreamsLargeFiles(t *testing.T) {
+	src := sparseFile(t, sparseSize)
+	dst := filepath.Join(t.TempDir(), "copy.bin")
+
+	var err error
+	used := allocated(func() { err = CopyFile(src, dst) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if used > 16<<20 {
+		t.Errorf("Copying a %d byte file allocated %d bytes", sparseSize, used)
+	}
+
+	info, err := os.Stat(dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Size() != sparseSize {
+		t.Errorf("Copy has %d bytes, expected %d", info.Size(), sparseSize)
+	}
+	f, err := os.Open(dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	tail := make([]byte, 3)
+	if _, err := f.ReadAt(tail, sparseSize-3); err != nil || string(tail) != "end" {
+		t.Errorf("Copy ends in %q (%v), expected the marker", tail, err)
+	}
+}
+
+func TestMemoryLimitBoundsConcurrentCopies(t *testing.T) {
+	defer SetMemoryLimit(DefaultMemoryLimit)
+
+	SetMemoryLimit(0)
+	if got := MemoryLimit(); got != BufferSize {
+		t.Errorf("A limit below one buffer should allow one, got %d", got)
+	}
+	SetMemoryLimit(3*BufferSize + 1)
+	if got := MemoryLimit(); got != 3*BufferSize {
+		t.Errorf("MemoryLimit = %d, expected 3 buffers", got)
+	}
+
+	// More copies than buffers still all finish, with the right contents
+	SetMemoryLimit(BufferSize)
+	data := bytes.Repeat([]byte("crew"), BufferSize)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var out bytes.Buffer
+			if n, err := Copy(&out, bytes.NewReader(data)); err != nil || n != int64(len(data)) {
+				t.Errorf("Copy = %d, %v", n, err)
+			}
+			if !bytes.Equal(out.Bytes(), data) {
+				t.Error("Copy changed the data")
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,289 @@
+// Package findings gives the problems crew's checks report one shape, so
+// they can be written as JSON or as SARIF 2.1.0 for CI dashboards and
+// code-scanning UIs.
+package findings
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Output formats of the commands reporting findings
+const (
+	FormatText  = "text"
+	FormatJSON  = "json"
+	FormatSARIF = "sarif"
+)
+
+// Formats lists the output formats, for flag help and completion
+var Formats = []string{FormatText, FormatJSON, FormatSARIF}
+
+// ValidateFormat returns an error for an unknown output format
+func ValidateFormat(format string) error {
+	for _, f := range Formats {
+		if format == f {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid --format %q: must be %s", format, strings.Join(Formats, ", "))
+}
+
+// Level is how serious a finding is, with SARIF's names
+type Level string
+
+// Levels of findings
+const (
+	LevelError   Level = "error"
+	LevelWarning Level = "warning"
+	LevelNote    Level = "note"
+)
+
+// Rule is a kind of finding
+type Rule struct {
+	ID          string `json:"id"`
+	Description string `json:"description"`
+	Level       Level  `json:"level"`
+	// Help tells how to fix findings of the rule
+	Help string `json:"help,omitempty"`
+}
+
+// Finding is one problem found by a check
+type Finding struct {
+	RuleID  string `json:"rule_id"`
+	Level   Level  `json:"level"`
+	Message string `json:"message"`
+	// Path is the file the finding is about, relative to the report's
+	// BaseDir, if it is about a file
+	Path string `json:"path,omitempty"`
+	// Properties hold details such as the component or tool concerned
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+// Report is the findings of one run of a check
+type Report struct {
+	// Tool and Version identify crew in SARIF output
+	Tool    string `json:"tool"`
+	Version string `json:"version"`
+	// BaseDir is the directory finding paths are relative to
+	BaseDir  string    `json:"base_dir,omitempty"`
+	Rules    []Rule    `json:"rules"`
+	Findings []Finding `json:"findings"`
+}
+
+// NewReport returns an empty report for the given rules
+func NewReport(version, baseDir string, rules ...Rule) *Report {
+	return &Report{Tool: "crew", Version: version, BaseDir: baseDir, Rules: rules, Findings: []Finding{}}
+}
+
+// Add appends a finding of rule; its level is the rule's
+func (r *Report) Add(ruleID, message, path string, properties map[string]string) {
+	level := LevelWarning
+	if rule, ok := r.rule(ruleID); ok {
+		level = rule.Level
+	}
+	r.Findings = append(r.Findings, Finding{
+		RuleID:     ruleID,
+		Level:      level,
+		Message:    message,
+		Path:       filepath.ToSlash(path),
+		Properties: properties,
+	})
+}
+
+func (r *Report) rule(id string) (Rule, bool) {
+	for _, rule := range r.Rules {
+		if rule.ID == id {
+			return rule, true
+		}
+	}
+	return Rule{}, false
+}
+
+// Count returns the number of findings at level
+func (r *Report) Count(level Level) int {
+	n := 0
+	for _, f := range r.Findings {
+		if f.Level == level {
+			n++
+		}
+	}
+	return n
+}
+
+// Sort orders the findings by path, then rule, so reports of unchanged
+// installations are identical
+func (r *Report) Sort() {
+	sort.SliceStable(r.Findings, func(i, j int) bool {
+		if r.Findings[i].Path != r.Findings[j].Path {
+			return r.Findings[i].Path < r.Findings[j].Path
+		}
+		return r.Findings[i].RuleID < r.Findings[j].RuleID
+	})
+}
+
+// WriteJSON writes the report as indented JSON
+func (r *Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+// baseURIID names the base directory in SARIF locations
+const baseURIID = "INSTALLDIR"
+
+// sarifLog and the types below are the subset of SARIF 2.1.0 crew writes
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool               sarifTool                   `json:"tool"`
+	OriginalURIBaseIDs map[string]sarifArtifactLoc `json:"originalUriBaseIds,omitempty"`
+	Results            []sarifResult               `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string             `json:"id"`
+	ShortDescription     sarifMessage       `json:"shortDescription"`
+	Help                 *sarifMessage      `json:"help,omitempty"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifConfiguration struct {
+	Level Level `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID     string            `json:"ruleId"`
+	RuleIndex  int               `json:"ruleIndex"`
+	Level      Level             `json:"level"`
+	Message    sarifMessage      `json:"message"`
+	Locations  []sarifLocation   `json:"locations,omitempty"`
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLoc `json:"artifactLocation"`
+}
+
+type sarifArtifactLoc struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
+}
+
+// InformationURI is where SARIF viewers link the tool to
+const InformationURI = "https://github.com/jonwraymond/claude-code-super-crew"
+
+// WriteSARIF writes the report as a SARIF 2.1.0 log with one run
+func (r *Report) WriteSARIF(w io.Writer) error {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           r.Tool,
+			Version:        r.Version,
+			InformationURI: InformationURI,
+			Rules:          make([]sarifRule, 0, len(r.Rules)),
+		}},
+		Results: make([]sarifResult, 0, len(r.Findings)),
+	}
+	index := make(map[string]int, len(r.Rules))
+	for i, rule := range r.Rules {
+		index[rule.ID] = i
+		sr := sarifRule{
+			ID:                   rule.ID,
+			ShortDescription:     sarifMessage{Text: rule.Description},
+			DefaultConfiguration: sarifConfiguration{Level: rule.Level},
+		}
+		if rule.Help != "" {
+			sr.Help = &sarifMessage{Text: rule.Help}
+		}
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sr)
+	}
+	if r.BaseDir != "" {
+		run.OriginalURIBaseIDs = map[string]sarifArtifactLoc{baseURIID: {URI: dirURI(r.BaseDir)}}
+	}
+
+	for _, f := range r.Findings {
+		ruleIndex, ok := index[f.RuleID]
+		if !ok {
+			return fmt.Errorf("finding %q has no rule %s", f.Message, f.RuleID)
+		}
+		result := sarifResult{
+			RuleID:     f.RuleID,
+			RuleIndex:  ruleIndex,
+			Level:      f.Level,
+			Message:    sarifMessage{Text: f.Message},
+			Properties: f.Properties,
+		}
+		if f.Path != "" {
+			loc := sarifArtifactLoc{URI: (&url.URL{Path: f.Path}).EscapedPath()}
+			if r.BaseDir != "" {
+				loc.URIBaseID = baseURIID
+			}
+			result.Locations = []sarifLocation{{PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: loc}}}
+		}
+		run.Results = append(run.Results, result)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{run},
+	})
+}
+
+// dirURI is the file URI of dir, ending with a slash as SARIF requires
+// for base URIs
+func dirURI(dir string) string {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		abs = dir
+	}
+	p := filepath.ToSlash(abs)
+	if !strings.HasPrefix(p, "/") {
+		// Windows drive letters: file:///C:/Users/...
+		p = "/" + p
+	}
+	if !strings.HasSuffix(p, "/") {
+		p += "/"
+	}
+	return (&url.URL{Scheme: "file", Path: p}).String()
+}
+
+// Write writes the report in format, json or sarif
+func (r *Report) Write(w io.Writer, format string) error {
+	switch format {
+	case FormatJSON:
+		return r.WriteJSON(w)
+	case FormatSARIF:
+		return r.WriteSARIF(w)
+	}
+	return fmt.Errorf("findings cannot be written as %s", format)
+}
@@ -0,0 +1,122 @@
+package findings
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+var testRules = []Rule{
+	{ID: "crew/test/modified", Level: LevelWarning, Description: "A file was modified", Help: "Restore it"},
+	{ID: "crew/test/missing", Level: LevelError, Description: "A file is missing"},
+}
+
+func TestValidateFormat(t *testing.T) {
+	for _, format := range Formats {
+		if err := ValidateFormat(format); err != nil {
+			t.Errorf("ValidateFormat(%q) = %v", format, err)
+		}
+	}
+	if err := ValidateFormat("xml"); err == nil {
+		t.Error("ValidateFormat(xml) = nil, want an error")
+	}
+}
+
+func TestReportAddUsesRuleLevel(t *testing.T) {
+	report := NewReport("1.0.0", "", testRules...)
+	report.Add("crew/test/missing", "gone", "", nil)
+	report.Add("crew/test/unknown", "odd", "", nil)
+
+	if got := report.Findings[0].Level; got != LevelError {
+		t.Errorf("level of missing = %s, want error", got)
+	}
+	if got := report.Findings[1].Level; got != LevelWarning {
+		t.Errorf("level of unknown rule = %s, want warning", got)
+	}
+	if report.Count(LevelError) != 1 || report.Count(LevelWarning) != 1 {
+		t.Errorf("counts = %d errors, %d warnings", report.Count(LevelError), report.Count(LevelWarning))
+	}
+}
+
+func TestReportSort(t *testing.T) {
+	report := NewReport("1.0.0", "", testRules...)
+	report.Add("crew/test/modified", "b", "b.md", nil)
+	report.Add("crew/test/missing", "a2", "a.md", nil)
+	report.Add("crew/test/modified", "a1", "a.md", nil)
+	report.Sort()
+
+	var got []string
+	for _, f := range report.Findings {
+		got = append(got, f.Message)
+	}
+	if strings.Join(got, ",") != "a2,a1,b" {
+		t.Errorf("sorted messages = %v", got)
+	}
+}
+
+func TestWriteSARIF(t *testing.T) {
+	report := NewReport("1.2.3", "/opt/claude", testRules...)
+	report.Add("crew/test/modified", "agents/my agent.md is modified", "agents/my agent.md",
+		map[string]string{"component": "agents"})
+	report.Add("crew/test/missing", "no path", "", nil)
+
+	var buf bytes.Buffer
+	if err := report.WriteSARIF(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var log sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("invalid SARIF JSON: %v", err)
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("version %s with %d runs", log.Version, len(log.Runs))
+	}
+	run := log.Runs[0]
+	if run.Tool.Driver.Name != "crew" || run.Tool.Driver.Version != "1.2.3" {
+		t.Errorf("driver = %+v", run.Tool.Driver)
+	}
+	if len(run.Tool.Driver.Rules) != 2 || run.Tool.Driver.Rules[0].Help == nil || run.Tool.Driver.Rules[1].Help != nil {
+		t.Errorf("rules = %+v", run.Tool.Driver.Rules)
+	}
+	if base := run.OriginalURIBaseIDs[baseURIID].URI; !strings.HasPrefix(base, "file:///") || !strings.HasSuffix(base, "/") {
+		t.Errorf("base URI = %q", base)
+	}
+
+	if len(run.Results) != 2 {
+		t.Fatalf("%d results, want 2", len(run.Results))
+	}
+	first := run.Results[0]
+	if first.RuleIndex != 0 || first.Level != LevelWarning || first.Properties["component"] != "agents" {
+		t.Errorf("first result = %+v", first)
+	}
+	loc := first.Locations[0].PhysicalLocation.ArtifactLocation
+	if loc.URI != "agents/my%20agent.md" || loc.URIBaseID != baseURIID {
+		t.Errorf("location = %+v", loc)
+	}
+	if second := run.Results[1]; second.RuleIndex != 1 || len(second.Locations) != 0 {
+		t.Errorf("second result = %+v", second)
+	}
+}
+
+func TestWriteSARIFUnknownRule(t *testing.T) {
+	report := NewReport("1.0.0", "", testRules...)
+	report.Add("crew/test/unknown", "odd", "", nil)
+	if err := report.WriteSARIF(&bytes.Buffer{}); err == nil {
+		t.Error("WriteSARIF with an unknown rule = nil, want an error")
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	report := NewReport("1.0.0", "", testRules...)
+	var buf bytes.Buffer
+	if err := report.Write(&buf, FormatJSON); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), `"findings": []`) {
+		t.Errorf("empty report JSON = %s", buf.String())
+	}
+	if err := report.Write(&buf, FormatText); err == nil {
+		t.Error("Write(text) = nil, want an error")
+	}
+}
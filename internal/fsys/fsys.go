@@ -0,0 +1,170 @@
+// Package fsys is the filesystem crew's installers, managers and backups
+// write through. Production code uses OS, which calls the os package;
+// unit tests use NewMemFS, an in-memory filesystem, so they run in
+// parallel without a real $HOME, temporary directories or environment
+// changes.
+//
+// The interface follows the os functions it replaces, in the style of
+// afero, so converting code is a matter of calling fs.Stat instead of
+// os.Stat. Errors are *fs.PathError values, which os.IsNotExist and
+// errors.Is(err, fs.ErrNotExist) recognize for either implementation.
+package fsys
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// File is an open file of an FS
+type File interface {
+	io.Reader
+	io.Writer
+	io.Closer
+	Name() string
+	Stat() (fs.FileInfo, error)
+}
+
+// FS is a writable filesystem. Paths are native paths, as for the os
+// package.
+type FS interface {
+	Open(name string) (File, error)
+	Create(name string) (File, error)
+	OpenFile(name string, flag int, perm fs.FileMode) (File, error)
+	Stat(name string) (fs.FileInfo, error)
+	Lstat(name string) (fs.FileInfo, error)
+	ReadFile(name string) ([]byte, error)
+	WriteFile(name string, data []byte, perm fs.FileMode) error
+	ReadDir(name string) ([]fs.DirEntry, error)
+	MkdirAll(path string, perm fs.FileMode) error
+	Remove(name string) error
+	RemoveAll(path string) error
+	Rename(oldpath, newpath string) error
+	Chtimes(name string, atime, mtime time.Time) error
+}
+
+// OS is the filesystem of the operating system
+var OS FS = osFS{}
+
+// osFS implements FS with the os package
+type osFS struct{}
+
+func (osFS) Open(name string) (File, error) { return openOS(os.Open(name)) }
+
+func (osFS) Create(name string) (File, error) { return openOS(os.Create(name)) }
+
+func (osFS) OpenFile(name string, flag int, perm fs.FileMode) (File, error) {
+	return openOS(os.OpenFile(name, flag, perm))
+}
+
+// openOS returns a nil File rather than a nil *os.File in an interface
+func openOS(f *os.File, err error) (File, error) {
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (osFS) Stat(name string) (fs.FileInfo, error)      { return os.Stat(name) }
+func (osFS) Lstat(name string) (fs.FileInfo, error)     { return os.Lstat(name) }
+func (osFS) ReadFile(name string) ([]byte, error)       { return os.ReadFile(name) }
+func (osFS) ReadDir(name string) ([]fs.DirEntry, error) { return os.ReadDir(name) }
+func (osFS) Remove(name string) error                   { return os.Remove(name) }
+func (osFS) RemoveAll(path string) error                { return os.RemoveAll(path) }
+func (osFS) Rename(oldpath, newpath string) error       { return os.Rename(oldpath, newpath) }
+
+func (osFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	return os.WriteFile(name, data, perm)
+}
+
+func (osFS) MkdirAll(path string, perm fs.FileMode) error { return os.MkdirAll(path, perm) }
+
+func (osFS) Chtimes(name string, atime, mtime time.Time) error {
+	return os.Chtimes(name, atime, mtime)
+}
+
+// IsOS reports whether fsys is the operating system's filesystem, where
+// callers may use os-only features such as file cloning and symlinks
+func IsOS(fsys FS) bool {
+	_, ok := fsys.(osFS)
+	return ok
+}
+
+// Or returns fsys, or OS when fsys is nil, for optional FS fields
+func Or(fsys FS) FS {
+	if fsys == nil {
+		return OS
+	}
+	return fsys
+}
+
+// Exists reports whether path exists in fsys
+func Exists(fsys FS, path string) bool {
+	_, err := fsys.Stat(path)
+	return !errors.Is(err, fs.ErrNotExist)
+}
+
+// Walk walks the tree rooted at root like filepath.Walk, in lexical order,
+// without following symlinks
+func Walk(fsys FS, root string, fn filepath.WalkFunc) error {
+	info, err := fsys.Lstat(root)
+	if err != nil {
+		err = fn(root, nil, err)
+	} else {
+		err = walk(fsys, root, info, fn)
+	}
+	if err == filepath.SkipDir || err == filepath.SkipAll {
+		return nil
+	}
+	return err
+}
+
+func walk(fsys FS, path string, info fs.FileInfo, fn filepath.WalkFunc) error {
+	if !info.IsDir() {
+		return fn(path, info, nil)
+	}
+
+	entries, err := fsys.ReadDir(path)
+	if err := fn(path, info, err); err != nil || entries == nil {
+		return err
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+
+	for _, entry := range entries {
+		name := filepath.Join(path, entry.Name())
+		info, err := fsys.Lstat(name)
+		if err != nil {
+			if err := fn(name, info, err); err != nil && err != filepath.SkipDir {
+				return err
+			}
+			continue
+		}
+		if err := walk(fsys, name, info, fn); err != nil {
+			if !info.IsDir() || err != filepath.SkipDir {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// CopyFile copies src to dst within fsys, keeping the permissions and
+// modification time of src. The parent directory of dst must exist.
+func CopyFile(fsys FS, src, dst string) error {
+	info, err := fsys.Stat(src)
+	if err != nil {
+		return err
+	}
+	data, err := fsys.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	if err := fsys.WriteFile(dst, data, info.Mode().Perm()); err != nil {
+		return err
+	}
+	return fsys.Chtimes(dst, info.ModTime(), info.ModTime())
+}
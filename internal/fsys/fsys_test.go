@@ -0,0 +1,193 @@
+package fsys
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// filesystems runs fn against MemFS and OS, so MemFS is held to the
+// behavior of the real filesystem
+func filesystems(t *testing.T, fn func(t *testing.T, fsys FS, root string)) {
+	t.Run("mem", func(t *testing.T) {
+		t.Parallel()
+		fn(t, NewMemFS(), filepath.Join(string(filepath.Separator), "home", "user"))
+	})
+	t.Run("os", func(t *testing.T) {
+		t.Parallel()
+		fn(t, OS, t.TempDir())
+	})
+}
+
+func TestReadWrite(t *testing.T) {
+	filesystems(t, func(t *testing.T, fsys FS, root string) {
+		dir := filepath.Join(root, ".claude", "agents")
+		if err := fsys.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		path := filepath.Join(dir, "agent.md")
+		if err := fsys.WriteFile(path, []byte("# Agent\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		f, err := fsys.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := io.WriteString(f, "more\n"); err != nil {
+			t.Fatal(err)
+		}
+		if err := f.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		data, err := fsys.ReadFile(path)
+		if err != nil || string(data) != "# Agent\nmore\n" {
+			t.Errorf("ReadFile = %q, %v", data, err)
+		}
+		info, err := fsys.Stat(path)
+		if err != nil || info.Size() != 13 || info.IsDir() || info.Name() != "agent.md" {
+			t.Errorf("Stat = %v, %v", info, err)
+		}
+		if info, err := fsys.Stat(dir); err != nil || !info.IsDir() {
+			t.Errorf("Stat of directory = %v, %v", info, err)
+		}
+	})
+}
+
+func TestErrors(t *testing.T) {
+	filesystems(t, func(t *testing.T, fsys FS, root string) {
+		missing := filepath.Join(root, "missing", "file")
+		if _, err := fsys.Stat(missing); !os.IsNotExist(err) {
+			t.Errorf("Stat of missing file = %v", err)
+		}
+		if _, err := fsys.Open(missing); !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("Open of missing file = %v", err)
+		}
+		if err := fsys.WriteFile(missing, nil, 0644); !os.IsNotExist(err) {
+			t.Errorf("WriteFile without parent directory = %v", err)
+		}
+		if err := fsys.Remove(missing); !os.IsNotExist(err) {
+			t.Errorf("Remove of missing file = %v", err)
+		}
+		if err := fsys.RemoveAll(missing); err != nil {
+			t.Errorf("RemoveAll of missing path = %v", err)
+		}
+		if Exists(fsys, missing) {
+			t.Error("Exists of missing file")
+		}
+	})
+}
+
+func TestRenameAndRemove(t *testing.T) {
+	filesystems(t, func(t *testing.T, fsys FS, root string) {
+		from := filepath.Join(root, "a")
+		if err := fsys.MkdirAll(filepath.Join(from, "sub"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := fsys.WriteFile(filepath.Join(from, "sub", "f"), []byte("x"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := fsys.Remove(from); err == nil {
+			t.Error("Remove of a non-empty directory succeeded")
+		}
+
+		to := filepath.Join(root, "b")
+		if err := fsys.Rename(from, to); err != nil {
+			t.Fatal(err)
+		}
+		if Exists(fsys, from) {
+			t.Error("renamed directory still exists")
+		}
+		if data, err := fsys.ReadFile(filepath.Join(to, "sub", "f")); err != nil || string(data) != "x" {
+			t.Errorf("file of renamed directory = %q, %v", data, err)
+		}
+
+		if err := fsys.RemoveAll(to); err != nil {
+			t.Fatal(err)
+		}
+		if entries, err := fsys.ReadDir(root); err != nil || len(entries) != 0 {
+			t.Errorf("ReadDir after RemoveAll = %v, %v", entries, err)
+		}
+	})
+}
+
+func TestWalk(t *testing.T) {
+	filesystems(t, func(t *testing.T, fsys FS, root string) {
+		for _, name := range []string{"b/2", "a/1", "a/skip/3", "c"} {
+			path := filepath.Join(root, filepath.FromSlash(name))
+			if err := fsys.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := fsys.WriteFile(path, []byte(name), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		var seen []string
+		err := Walk(fsys, root, func(path string, info fs.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			rel, _ := filepath.Rel(root, path)
+			if info.IsDir() && info.Name() == "skip" {
+				return filepath.SkipDir
+			}
+			seen = append(seen, filepath.ToSlash(rel))
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := strings.Join(seen, ","); got != ".,a,a/1,b,b/2,c" {
+			t.Errorf("Walk visited %s", got)
+		}
+	})
+}
+
+func TestCopyFile(t *testing.T) {
+	filesystems(t, func(t *testing.T, fsys FS, root string) {
+		if err := fsys.MkdirAll(root, 0755); err != nil {
+			t.Fatal(err)
+		}
+		src, dst := filepath.Join(root, "src"), filepath.Join(root, "dst")
+		if err := fsys.WriteFile(src, []byte("payload"), 0600); err != nil {
+			t.Fatal(err)
+		}
+		modTime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+		if err := fsys.Chtimes(src, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := CopyFile(fsys, src, dst); err != nil {
+			t.Fatal(err)
+		}
+		info, err := fsys.Stat(dst)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !info.ModTime().Equal(modTime) || info.Mode().Perm() != 0600 {
+			t.Errorf("copy has time %v and mode %v", info.ModTime(), info.Mode())
+		}
+	})
+}
+
+func TestMemFSIsolated(t *testing.T) {
+	t.Parallel()
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skip("no home directory")
+	}
+	mem := NewMemFS()
+	if Exists(mem, home) {
+		t.Errorf("MemFS sees the real %s", home)
+	}
+	if !Exists(mem, string(filepath.Separator)) || !IsOS(OS) || IsOS(mem) {
+		t.Error("unexpected root or IsOS result")
+	}
+}
@@ -0,0 +1,355 @@
+package fsys
+
+import (
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MemFS is an in-memory FS for tests. The root directory, and the current
+// directory of relative paths, always exist; other directories have to be
+// created, as on disk. It is safe for concurrent use.
+type MemFS struct {
+	mu    sync.Mutex
+	nodes map[string]*memNode
+	// now returns the modification time of changed files
+	now func() time.Time
+}
+
+var _ FS = (*MemFS)(nil)
+
+type memNode struct {
+	dir     bool
+	data    []byte
+	mode    fs.FileMode
+	modTime time.Time
+}
+
+// NewMemFS returns an empty in-memory filesystem
+func NewMemFS() *MemFS {
+	return &MemFS{nodes: make(map[string]*memNode), now: time.Now}
+}
+
+// SetClock makes the filesystem stamp changed files with the times now
+// returns, for tests asserting modification times
+func (m *MemFS) SetClock(now func() time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.now = now
+}
+
+// isRoot reports whether the clean path name has no parent
+func isRoot(name string) bool {
+	return filepath.Dir(name) == name
+}
+
+// lookup returns the node of the clean path name; the root is a directory
+// without a node
+func (m *MemFS) lookup(name string) (*memNode, bool) {
+	if isRoot(name) {
+		return &memNode{dir: true, mode: fs.ModeDir | 0755}, true
+	}
+	node, ok := m.nodes[name]
+	return node, ok
+}
+
+// parentDir checks that the parent of the clean path name is a directory
+func (m *MemFS) parentDir(op, name string) error {
+	parent, ok := m.lookup(filepath.Dir(name))
+	if !ok {
+		return &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+	}
+	if !parent.dir {
+		return &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+	return nil
+}
+
+func (m *MemFS) Open(name string) (File, error) {
+	return m.OpenFile(name, os.O_RDONLY, 0)
+}
+
+func (m *MemFS) Create(name string) (File, error) {
+	return m.OpenFile(name, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0666)
+}
+
+func (m *MemFS) OpenFile(name string, flag int, perm fs.FileMode) (File, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	clean := filepath.Clean(name)
+	node, ok := m.lookup(clean)
+	switch {
+	case ok && flag&os.O_CREATE != 0 && flag&os.O_EXCL != 0:
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrExist}
+	case ok && node.dir && flag&(os.O_WRONLY|os.O_RDWR) != 0:
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	case !ok && flag&os.O_CREATE == 0:
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	case !ok:
+		if err := m.parentDir("open", clean); err != nil {
+			return nil, err
+		}
+		node = &memNode{mode: perm.Perm(), modTime: m.now()}
+		m.nodes[clean] = node
+	case flag&os.O_TRUNC != 0:
+		node.data = nil
+		node.modTime = m.now()
+	}
+
+	f := &memFile{fs: m, name: name, node: node, flag: flag}
+	if flag&os.O_APPEND != 0 {
+		f.offset = len(node.data)
+	}
+	return f, nil
+}
+
+func (m *MemFS) Stat(name string) (fs.FileInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	clean := filepath.Clean(name)
+	node, ok := m.lookup(clean)
+	if !ok {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrNotExist}
+	}
+	return node.info(filepath.Base(clean)), nil
+}
+
+// Lstat is Stat: MemFS has no symlinks
+func (m *MemFS) Lstat(name string) (fs.FileInfo, error) {
+	return m.Stat(name)
+}
+
+func (m *MemFS) ReadFile(name string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	node, ok := m.lookup(filepath.Clean(name))
+	switch {
+	case !ok:
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	case node.dir:
+		return nil, &fs.PathError{Op: "read", Path: name, Err: fs.ErrInvalid}
+	}
+	return append([]byte(nil), node.data...), nil
+}
+
+func (m *MemFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	f, err := m.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func (m *MemFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	clean := filepath.Clean(name)
+	node, ok := m.lookup(clean)
+	switch {
+	case !ok:
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	case !node.dir:
+		return nil, &fs.PathError{Op: "readdirent", Path: name, Err: fs.ErrInvalid}
+	}
+
+	var entries []fs.DirEntry
+	for path, child := range m.nodes {
+		if path != clean && filepath.Dir(path) == clean {
+			entries = append(entries, fs.FileInfoToDirEntry(child.info(filepath.Base(path))))
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	return entries, nil
+}
+
+func (m *MemFS) MkdirAll(path string, perm fs.FileMode) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	clean := filepath.Clean(path)
+	var missing []string
+	for p := clean; ; p = filepath.Dir(p) {
+		node, ok := m.lookup(p)
+		if ok {
+			if !node.dir {
+				return &fs.PathError{Op: "mkdir", Path: p, Err: fs.ErrInvalid}
+			}
+			break
+		}
+		missing = append(missing, p)
+	}
+	for _, p := range missing {
+		m.nodes[p] = &memNode{dir: true, mode: fs.ModeDir | perm.Perm(), modTime: m.now()}
+	}
+	return nil
+}
+
+func (m *MemFS) Remove(name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	clean := filepath.Clean(name)
+	node, ok := m.nodes[clean]
+	if !ok {
+		return &fs.PathError{Op: "remove", Path: name, Err: fs.ErrNotExist}
+	}
+	if node.dir && len(m.children(clean)) > 0 {
+		return &fs.PathError{Op: "remove", Path: name, Err: fs.ErrExist}
+	}
+	delete(m.nodes, clean)
+	return nil
+}
+
+func (m *MemFS) RemoveAll(path string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	clean := filepath.Clean(path)
+	for _, child := range m.children(clean) {
+		delete(m.nodes, child)
+	}
+	delete(m.nodes, clean)
+	return nil
+}
+
+func (m *MemFS) Rename(oldpath, newpath string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	from, to := filepath.Clean(oldpath), filepath.Clean(newpath)
+	node, ok := m.nodes[from]
+	if !ok {
+		return &os.LinkError{Op: "rename", Old: oldpath, New: newpath, Err: fs.ErrNotExist}
+	}
+	if err := m.parentDir("rename", to); err != nil {
+		return &os.LinkError{Op: "rename", Old: oldpath, New: newpath, Err: fs.ErrNotExist}
+	}
+	if existing, ok := m.nodes[to]; ok && existing.dir != node.dir {
+		return &os.LinkError{Op: "rename", Old: oldpath, New: newpath, Err: fs.ErrExist}
+	}
+
+	for _, child := range m.children(from) {
+		m.nodes[to+child[len(from):]] = m.nodes[child]
+		delete(m.nodes, child)
+	}
+	delete(m.nodes, from)
+	m.nodes[to] = node
+	return nil
+}
+
+func (m *MemFS) Chtimes(name string, atime, mtime time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	node, ok := m.nodes[filepath.Clean(name)]
+	if !ok {
+		return &fs.PathError{Op: "chtimes", Path: name, Err: fs.ErrNotExist}
+	}
+	node.modTime = mtime
+	return nil
+}
+
+// children returns the paths below the clean directory dir
+func (m *MemFS) children(dir string) []string {
+	prefix := dir + string(filepath.Separator)
+	if isRoot(dir) {
+		prefix = dir
+	}
+	var paths []string
+	for path := range m.nodes {
+		if dir == "." && !filepath.IsAbs(path) || path != dir && strings.HasPrefix(path, prefix) {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+func (n *memNode) info(name string) fs.FileInfo {
+	return &memInfo{name: name, size: int64(len(n.data)), mode: n.mode, modTime: n.modTime}
+}
+
+// memInfo is a snapshot of a memNode
+type memInfo struct {
+	name    string
+	size    int64
+	mode    fs.FileMode
+	modTime time.Time
+}
+
+func (i *memInfo) Name() string       { return i.name }
+func (i *memInfo) Size() int64        { return i.size }
+func (i *memInfo) Mode() fs.FileMode  { return i.mode }
+func (i *memInfo) ModTime() time.Time { return i.modTime }
+func (i *memInfo) IsDir() bool        { return i.mode.IsDir() }
+func (i *memInfo) Sys() any           { return nil }
+
+// memFile is an open MemFS file; writes go straight to its node
+type memFile struct {
+	fs     *MemFS
+	name   string
+	node   *memNode
+	flag   int
+	offset int
+	closed bool
+}
+
+func (f *memFile) Name() string { return f.name }
+
+func (f *memFile) Read(p []byte) (int, error) {
+	f.fs.mu.Lock()
+	defer f.fs.mu.Unlock()
+	switch {
+	case f.closed:
+		return 0, &fs.PathError{Op: "read", Path: f.name, Err: fs.ErrClosed}
+	case f.node.dir || f.flag&os.O_WRONLY != 0:
+		return 0, &fs.PathError{Op: "read", Path: f.name, Err: fs.ErrInvalid}
+	case f.offset >= len(f.node.data):
+		return 0, io.EOF
+	}
+	n := copy(p, f.node.data[f.offset:])
+	f.offset += n
+	return n, nil
+}
+
+func (f *memFile) Write(p []byte) (int, error) {
+	f.fs.mu.Lock()
+	defer f.fs.mu.Unlock()
+	if f.closed {
+		return 0, &fs.PathError{Op: "write", Path: f.name, Err: fs.ErrClosed}
+	}
+	if f.flag&(os.O_WRONLY|os.O_RDWR) == 0 {
+		return 0, &fs.PathError{Op: "write", Path: f.name, Err: fs.ErrPermission}
+	}
+	if f.flag&os.O_APPEND != 0 {
+		f.offset = len(f.node.data)
+	}
+	if end := f.offset + len(p); end > len(f.node.data) {
+		f.node.data = append(f.node.data, make([]byte, end-len(f.node.data))...)
+	}
+	copy(f.node.data[f.offset:], p)
+	f.offset += len(p)
+	f.node.modTime = f.fs.now()
+	return len(p), nil
+}
+
+func (f *memFile) Stat() (fs.FileInfo, error) {
+	f.fs.mu.Lock()
+	defer f.fs.mu.Unlock()
+	return f.node.info(filepath.Base(f.name)), nil
+}
+
+func (f *memFile) Close() error {
+	f.fs.mu.Lock()
+	defer f.fs.mu.Unlock()
+	if f.closed {
+		return &fs.PathError{Op: "close", Path: f.name, Err: fs.ErrClosed}
+	}
+	f.closed = true
+	return nil
+}
@@ -0,0 +1,239 @@
+// Package gitrepo runs the few git operations crew needs on a user's
+// repository by shelling out to git, so it works with whatever version and
+// configuration the user has.
+package gitrepo
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// ErrNotRepo is returned by Open when the directory is not in a work tree
+var ErrNotRepo = errors.New("not a git repository")
+
+// Repo is a git work tree
+type Repo struct {
+	// Root is the top-level directory of the work tree
+	Root string
+}
+
+// Open returns the repository containing dir. It returns ErrNotRepo when
+// dir is outside a work tree or git is not installed.
+func Open(dir string) (*Repo, error) {
+	if _, err := exec.LookPath("git"); err != nil {
+		return nil, ErrNotRepo
+	}
+	out, err := run(dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, ErrNotRepo
+	}
+	return &Repo{Root: filepath.Clean(out)}, nil
+}
+
+// Changes returns the uncommitted changes of the work tree, one porcelain
+// status line each, e.g. " M main.go" or "?? notes.txt"
+func (r *Repo) Changes() ([]string, error) {
+	out, err := r.git("status", "--porcelain")
+	if err != nil {
+		return nil, err
+	}
+	if out == "" {
+		return nil, nil
+	}
+	return strings.Split(out, "\n"), nil
+}
+
+// Branch returns the current branch, empty on a detached HEAD
+func (r *Repo) Branch() string {
+	out, err := r.git("symbolic-ref", "--quiet", "--short", "HEAD")
+	if err != nil {
+		return ""
+	}
+	return out
+}
+
+// Head returns the commit HEAD points at, empty before the first commit
+func (r *Repo) Head() string {
+	out, err := r.git("rev-parse", "--verify", "--quiet", "HEAD")
+	if err != nil {
+		return ""
+	}
+	return out
+}
+
+// CreateBranch creates name from the current HEAD and checks it out
+func (r *Repo) CreateBranch(name string) error {
+	_, err := r.git("checkout", "-b", name)
+	return err
+}
+
+// Commit stages paths and commits them, and only them, with message. Paths
+// are relative to the work tree root. It reports false when the paths have
+// nothing to commit.
+func (r *Repo) Commit(message string, paths ...string) (bool, error) {
+	args := append([]string{"add", "--"}, paths...)
+	if _, err := r.git(args...); err != nil {
+		return false, err
+	}
+	// Commit the staged files by name: a path without tracked files, such
+	// as an empty directory, is not a valid pathspec for commit
+	args = append([]string{"diff", "--cached", "--name-only", "--"}, paths...)
+	staged, err := r.git(args...)
+	if err != nil {
+		return false, err
+	}
+	if staged == "" {
+		return false, nil
+	}
+	args = append([]string{"commit", "--quiet", "-m", message, "--"}, strings.Split(staged, "\n")...)
+	if _, err := r.git(args...); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Commit is a commit of the history with the files it changed
+type Commit struct {
+	// Author is the author's email address, lower-cased
+	Author string
+	// Files are the changed paths relative to the work tree root
+	Files []string
+}
+
+// Log returns the last limit commits, newest first, without merges. A
+// repository without commits has an empty log.
+func (r *Repo) Log(limit int) ([]Commit, error) {
+	out, err := r.git("log", "--no-merges", "--name-only", "--format=%x00%aE", fmt.Sprintf("-n%d", limit))
+	if err != nil {
+		if _, headErr := r.git("rev-parse", "--verify", "--quiet", "HEAD"); headErr != nil {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var commits []Commit
+	for _, line := range strings.Split(out, "\n") {
+		switch {
+		case strings.HasPrefix(line, "\x00"):
+			commits = append(commits, Commit{Author: strings.ToLower(strings.TrimPrefix(line, "\x00"))})
+		case line != "" && len(commits) > 0:
+			last := &commits[len(commits)-1]
+			last.Files = append(last.Files, line)
+		}
+	}
+	return commits, nil
+}
+
+// FileChanges counts the commits of the last limit commits that changed
+// each file, by path relative to the work tree root. Files deleted since
+// are included; callers keep the ones they still find.
+func (r *Repo) FileChanges(limit int) (map[string]int, error) {
+	commits, err := r.Log(limit)
+	if err != nil {
+		return nil, err
+	}
+	return CountFiles(commits), nil
+}
+
+// CountFiles counts the commits that changed each file
+func CountFiles(commits []Commit) map[string]int {
+	changes := make(map[string]int)
+	for _, commit := range commits {
+		for _, file := range commit.Files {
+			changes[file]++
+		}
+	}
+	return changes
+}
+
+// Rel returns path relative to the work tree root, with forward slashes
+func (r *Repo) Rel(path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	// The root git reports has symlinks resolved
+	if resolved, err := filepath.EvalSymlinks(abs); err == nil {
+		abs = resolved
+	}
+	rel, err := filepath.Rel(r.Root, abs)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%s is outside the repository %s", path, r.Root)
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+// Block markers delimit the .gitignore entries crew manages
+const (
+	blockStart = "# >>> crew (managed by 'crew claude --install')"
+	blockEnd   = "# <<< crew"
+)
+
+// EnsureIgnored makes the .gitignore at the work tree root contain exactly
+// patterns in crew's managed block, adding the block when missing. Lines
+// outside the block are never changed. It reports whether the file
+// changed, or with dryRun whether it would.
+func (r *Repo) EnsureIgnored(patterns []string, dryRun bool) (bool, error) {
+	path := filepath.Join(r.Root, ".gitignore")
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return false, err
+	}
+
+	block := blockStart + "\n" + strings.Join(patterns, "\n") + "\n" + blockEnd + "\n"
+	content := string(data)
+	var updated string
+	start := strings.Index(content, blockStart)
+	end := strings.Index(content, blockEnd)
+	if start >= 0 && end > start {
+		end += len(blockEnd)
+		if end < len(content) && content[end] == '\n' {
+			end++
+		}
+		updated = content[:start] + block + content[end:]
+	} else {
+		updated = content
+		if updated != "" && !strings.HasSuffix(updated, "\n") {
+			updated += "\n"
+		}
+		if updated != "" {
+			updated += "\n"
+		}
+		updated += block
+	}
+
+	if updated == content {
+		return false, nil
+	}
+	if dryRun {
+		return true, nil
+	}
+	if err := os.WriteFile(path, []byte(updated), 0644); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *Repo) git(args ...string) (string, error) {
+	return run(r.Root, args...)
+}
+
+func run(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			return "", fmt.Errorf("git %s: %w", args[0], err)
+		}
+		return "", fmt.Errorf("git %s: %s", args[0], msg)
+	}
+	return strings.TrimRight(stdout.String(), "\n"), nil
+}
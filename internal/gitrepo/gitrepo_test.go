@@ -0,0 +1,162 @@
+package gitrepo
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func newRepo(t *testing.T) *Repo {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	for key, value := range map[string]string{
+		"GIT_CONFIG_GLOBAL":   os.DevNull,
+		"GIT_AUTHOR_NAME":     "crew",
+		"GIT_AUTHOR_EMAIL":    "crew@example.com",
+		"GIT_COMMITTER_NAME":  "crew",
+		"GIT_COMMITTER_EMAIL": "crew@example.com",
+	} {
+		t.Setenv(key, value)
+	}
+
+	dir := t.TempDir()
+	if _, err := run(dir, "init", "--quiet"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := run(dir, "commit", "--quiet", "--allow-empty", "-m", "initial"); err != nil {
+		t.Fatal(err)
+	}
+	repo, err := Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return repo
+}
+
+func TestOpenOutsideRepository(t *testing.T) {
+	if _, err := Open(t.TempDir()); !errors.Is(err, ErrNotRepo) {
+		t.Errorf("Expected ErrNotRepo, got %v", err)
+	}
+}
+
+func TestEnsureIgnored(t *testing.T) {
+	repo := newRepo(t)
+	path := filepath.Join(repo.Root, ".gitignore")
+	if err := os.WriteFile(path, []byte("node_modules"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if pending, err := repo.EnsureIgnored([]string{"/.claude/.crew/"}, true); err != nil || !pending {
+		t.Fatalf("Expected a pending change, got %v, %v", pending, err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "node_modules" {
+		t.Fatal("A dry run should not write")
+	}
+
+	if _, err := repo.EnsureIgnored([]string{"/.claude/.crew/"}, false); err != nil {
+		t.Fatal(err)
+	}
+	if changed, _ := repo.EnsureIgnored([]string{"/.claude/.crew/"}, false); changed {
+		t.Error("A second run should change nothing")
+	}
+
+	// The block is replaced in place; the user's lines stay
+	if _, err := repo.EnsureIgnored([]string{"/.claude/.crew/", "/.claude/settings.local.json"}, false); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(path)
+	if got := string(data); !strings.HasPrefix(got, "node_modules\n\n") || strings.Count(got, blockStart) != 1 ||
+		!strings.Contains(got, "/.claude/settings.local.json\n"+blockEnd+"\n") {
+		t.Errorf("Unexpected .gitignore:\n%s", got)
+	}
+}
+
+func TestCommitOnlyGivenPaths(t *testing.T) {
+	repo := newRepo(t)
+	if err := os.MkdirAll(filepath.Join(repo.Root, ".claude", "agents"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(repo.Root, ".claude", "agents", "a.md"), []byte("a"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(repo.Root, "other.txt"), []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	changes, err := repo.Changes()
+	if err != nil || len(changes) != 2 {
+		t.Fatalf("Expected 2 changes, got %v, %v", changes, err)
+	}
+
+	if committed, err := repo.Commit("Add agents", ".claude"); err != nil || !committed {
+		t.Fatalf("Expected a commit, got %v, %v", committed, err)
+	}
+	if changes, _ = repo.Changes(); len(changes) != 1 || changes[0] != "?? other.txt" {
+		t.Errorf("Only .claude should have been committed, left %v", changes)
+	}
+	if committed, err := repo.Commit("Again", ".claude"); err != nil || committed {
+		t.Errorf("Expected nothing to commit, got %v, %v", committed, err)
+	}
+
+	if err := repo.CreateBranch("crew-setup"); err != nil {
+		t.Fatal(err)
+	}
+	if branch := repo.Branch(); branch != "crew-setup" {
+		t.Errorf("Expected branch crew-setup, got %q", branch)
+	}
+}
+
+func TestFileChanges(t *testing.T) {
+	repo := newRepo(t)
+	for i, content := range []string{"a", "b"} {
+		if err := os.WriteFile(filepath.Join(repo.Root, "main.go"), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		paths := []string{"main.go"}
+		if i == 0 {
+			if err := os.WriteFile(filepath.Join(repo.Root, "README.md"), []byte("x"), 0644); err != nil {
+				t.Fatal(err)
+			}
+			paths = append(paths, "README.md")
+		}
+		if _, err := repo.Commit("change", paths...); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	changes, err := repo.FileChanges(100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changes["main.go"] != 2 || changes["README.md"] != 1 || len(changes) != 2 {
+		t.Errorf("FileChanges() = %v", changes)
+	}
+	if changes, _ := repo.FileChanges(1); changes["main.go"] != 1 || len(changes) != 1 {
+		t.Errorf("FileChanges(1) = %v", changes)
+	}
+}
+
+func TestLog(t *testing.T) {
+	repo := newRepo(t)
+	if err := os.WriteFile(filepath.Join(repo.Root, "a.go"), []byte("a"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GIT_AUTHOR_EMAIL", "Other@Example.com")
+	if _, err := repo.Commit("add a", "a.go"); err != nil {
+		t.Fatal(err)
+	}
+
+	commits, err := repo.Log(10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(commits) != 2 || commits[0].Author != "other@example.com" || strings.Join(commits[0].Files, ",") != "a.go" ||
+		commits[1].Author != "crew@example.com" || len(commits[1].Files) != 0 {
+		t.Errorf("Log() = %+v", commits)
+	}
+}
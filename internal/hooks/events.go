@@ -0,0 +1,54 @@
+package hooks
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/events"
+)
+
+// EventLifecycle maps bus events to the lifecycle hooks they trigger
+var EventLifecycle = map[string]LifecycleEvent{
+	events.InstallStarted:     PreInstall,
+	events.InstallCompleted:   PostInstall,
+	events.UpdateStarted:      PreUpdate,
+	events.UpdateCompleted:    PostUpdate,
+	events.BackupStarted:      PreBackup,
+	events.BackupCreated:      PostBackup,
+	events.UninstallStarted:   PreUninstall,
+	events.UninstallCompleted: PostUninstall,
+	events.FileChanged:        FileChange,
+}
+
+// EventEnv converts event data into hook environment variables, so
+// {"backup_file": "..."} becomes CREW_BACKUP_FILE
+func EventEnv(event events.Event) map[string]string {
+	env := make(map[string]string, len(event.Data))
+	for k, v := range event.Data {
+		env["CREW_"+strings.ToUpper(k)] = v
+	}
+	return env
+}
+
+// SubscribeLifecycleHooks wires the lifecycle hook runner to the bus. run is
+// called with the context of the publishing operation, the mapped lifecycle
+// event and the event's environment.
+func SubscribeLifecycleHooks(bus *events.Bus, run func(context.Context, LifecycleEvent, map[string]string) error) func() {
+	var unsubscribers []func()
+	for eventType, lifecycle := range EventLifecycle {
+		lifecycle := lifecycle
+		unsubscribers = append(unsubscribers, bus.Subscribe(eventType, func(e events.Event) error {
+			ctx := e.Context
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			return run(ctx, lifecycle, EventEnv(e))
+		}))
+	}
+
+	return func() {
+		for _, unsubscribe := range unsubscribers {
+			unsubscribe()
+		}
+	}
+}
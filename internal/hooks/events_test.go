@@ -0,0 +1,36 @@
+package hooks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/events"
+)
+
+func TestSubscribeLifecycleHooks(t *testing.T) {
+	bus := events.NewBus()
+
+	var gotEvent LifecycleEvent
+	var gotEnv map[string]string
+	unsubscribe := SubscribeLifecycleHooks(bus, func(ctx context.Context, event LifecycleEvent, env map[string]string) error {
+		gotEvent, gotEnv = event, env
+		return nil
+	})
+
+	if err := bus.Publish(events.BackupCreated, map[string]string{"backup_file": "/tmp/b.tar.gz"}); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+	if gotEvent != PostBackup {
+		t.Errorf("Expected post-backup, got %q", gotEvent)
+	}
+	if gotEnv["CREW_BACKUP_FILE"] != "/tmp/b.tar.gz" {
+		t.Errorf("Expected CREW_BACKUP_FILE in env, got %v", gotEnv)
+	}
+
+	unsubscribe()
+	gotEvent = ""
+	bus.Publish(events.InstallStarted, nil)
+	if gotEvent != "" {
+		t.Errorf("Expected no delivery after unsubscribe, got %q", gotEvent)
+	}
+}
@@ -6,8 +6,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
+	"github.com/jonwraymond/claude-code-super-crew/internal/claudesettings"
+	"github.com/jonwraymond/claude-code-super-crew/internal/fileops"
 	"github.com/jonwraymond/claude-code-super-crew/pkg/logger"
 )
 
@@ -34,26 +37,93 @@ type Hook struct {
 	Command     string            `json:"command"`
 	Enabled     bool              `json:"enabled"`
 	Config      map[string]string `json:"config,omitempty"`
+	Origin      HookOrigin        `json:"origin,omitempty"`
+}
+
+// HookOrigin records where a hook's effective state comes from
+type HookOrigin string
+
+const (
+	// OriginGlobal hooks use the state from ~/.claude/settings.json
+	OriginGlobal HookOrigin = "global"
+	// OriginProjectOverride hooks are global hooks overridden in .claude/hooks.json
+	OriginProjectOverride HookOrigin = "project-override"
+	// OriginProject hooks are defined only in the project's .claude/hooks.json
+	OriginProject HookOrigin = "project"
+)
+
+// ProjectHookConfig is a hook entry in a project's .claude/hooks.json. For
+// global hooks only Enabled is used; project-only hooks must also set Type
+// and Command.
+type ProjectHookConfig struct {
+	Enabled     *bool             `json:"enabled,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Type        HookType          `json:"type,omitempty"`
+	Matcher     string            `json:"matcher,omitempty"`
+	Command     string            `json:"command,omitempty"`
+	Config      map[string]string `json:"config,omitempty"`
+}
+
+// ProjectHooksFile is the layout of a project's .claude/hooks.json
+type ProjectHooksFile struct {
+	Hooks map[string]*ProjectHookConfig `json:"hooks"`
 }
 
 // HookManager manages SuperCrew hooks
 type HookManager struct {
-	hooksDir     string
-	globalHooks  map[string]*Hook
-	enabledHooks map[string]bool
-	logger       logger.Logger
+	hooksDir       string
+	installDir     string
+	projectRoot    string
+	projectOverlay bool
+	globalHooks    map[string]*Hook
+	enabledHooks   map[string]bool
+	projectHooks   *ProjectHooksFile
+	logger         logger.Logger
 }
 
 // NewHookManager creates a new hook manager
 func NewHookManager(projectRoot string) *HookManager {
+	homeDir, _ := os.UserHomeDir()
 	return &HookManager{
-		hooksDir:     filepath.Join(projectRoot, "SuperCrew", "Hooks"),
-		globalHooks:  make(map[string]*Hook),
-		enabledHooks: make(map[string]bool),
-		logger:       logger.GetLogger(),
+		hooksDir:       filepath.Join(projectRoot, "SuperCrew", "Hooks"),
+		installDir:     filepath.Join(homeDir, ".claude"),
+		projectRoot:    projectRoot,
+		projectOverlay: true,
+		globalHooks:    make(map[string]*Hook),
+		enabledHooks:   make(map[string]bool),
+		projectHooks:   &ProjectHooksFile{Hooks: make(map[string]*ProjectHookConfig)},
+		logger:         logger.GetLogger(),
 	}
 }
 
+// SetProjectOverlay controls whether the project's .claude/hooks.json is
+// applied; callers disable it when no project root was found
+func (hm *HookManager) SetProjectOverlay(enabled bool) {
+	hm.projectOverlay = enabled
+}
+
+// SetHooksDir points the manager at a directory of hook scripts, such as
+// the installed copies in ~/.claude/hooks. Call before DiscoverHooks.
+func (hm *HookManager) SetHooksDir(dir string) {
+	hm.hooksDir = dir
+}
+
+// SetInstallDir points the manager at the installation whose settings.json
+// it registers hooks in, ~/.claude by default
+func (hm *HookManager) SetInstallDir(dir string) {
+	hm.installDir = dir
+}
+
+// settingsPath returns the Claude settings file of the installation
+func (hm *HookManager) settingsPath() string {
+	return filepath.Join(hm.installDir, claudesettings.FileName)
+}
+
+// installedHooksDir returns where installs copy the hook scripts
+func (hm *HookManager) installedHooksDir() string {
+	return filepath.Join(hm.installDir, "hooks")
+}
+
 // DiscoverHooks finds all available hooks
 func (hm *HookManager) DiscoverHooks() error {
 	// Define our global hooks
@@ -119,9 +189,20 @@ func (hm *HookManager) DiscoverHooks() error {
 		},
 	}
 
+	for _, hook := range hm.globalHooks {
+		hook.Origin = OriginGlobal
+	}
+
 	// Load enabled status from config
 	hm.loadEnabledStatus()
 
+	// Apply project-level overrides and project-only hooks
+	if hm.projectOverlay {
+		if err := hm.loadProjectOverlay(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -131,6 +212,9 @@ func (hm *HookManager) ListHooks() []*Hook {
 	for _, hook := range hm.globalHooks {
 		hooks = append(hooks, hook)
 	}
+	sort.Slice(hooks, func(i, j int) bool {
+		return hooks[i].Name < hooks[j].Name
+	})
 	return hooks
 }
 
@@ -141,6 +225,10 @@ func (hm *HookManager) EnableHook(name string) error {
 		return fmt.Errorf("hook not found: %s", name)
 	}
 
+	if hook.Origin == OriginProject {
+		return fmt.Errorf("hook %s is defined by the project; use a project-scoped enable", name)
+	}
+
 	// Check if hook script exists
 	if _, err := os.Stat(hook.Command); os.IsNotExist(err) {
 		return fmt.Errorf("hook script not found: %s", hook.Command)
@@ -151,8 +239,8 @@ func (hm *HookManager) EnableHook(name string) error {
 		return fmt.Errorf("failed to make hook executable: %w", err)
 	}
 
-	hook.Enabled = true
 	hm.enabledHooks[name] = true
+	hook.Enabled = hm.effectiveEnabled(name)
 
 	// Save to Claude settings
 	if err := hm.updateClaudeSettings(); err != nil {
@@ -170,8 +258,12 @@ func (hm *HookManager) DisableHook(name string) error {
 		return fmt.Errorf("hook not found: %s", name)
 	}
 
-	hook.Enabled = false
+	if hook.Origin == OriginProject {
+		return fmt.Errorf("hook %s is defined by the project; use a project-scoped disable", name)
+	}
+
 	delete(hm.enabledHooks, name)
+	hook.Enabled = hm.effectiveEnabled(name)
 
 	// Update Claude settings
 	if err := hm.updateClaudeSettings(); err != nil {
@@ -201,14 +293,11 @@ func (hm *HookManager) InstallHooks(targetDir string) error {
 			src := filepath.Join(hm.hooksDir, entry.Name())
 			dst := filepath.Join(hooksDir, entry.Name())
 
-			// Read and copy file
-			content, err := os.ReadFile(src)
-			if err != nil {
-				hm.logger.Warnf("Failed to read hook %s: %v", entry.Name(), err)
-				continue
+			err := fileops.CopyFile(src, dst)
+			if err == nil {
+				err = os.Chmod(dst, 0755)
 			}
-
-			if err := os.WriteFile(dst, content, 0755); err != nil {
+			if err != nil {
 				hm.logger.Warnf("Failed to install hook %s: %v", entry.Name(), err)
 				continue
 			}
@@ -222,80 +311,20 @@ func (hm *HookManager) InstallHooks(targetDir string) error {
 
 // updateClaudeSettings updates the Claude Code settings.json with hook configuration
 func (hm *HookManager) updateClaudeSettings() error {
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return err
-	}
-
-	settingsPath := filepath.Join(homeDir, ".claude", "settings.json")
-
-	// Read existing settings
-	var settings map[string]interface{}
-	if data, err := os.ReadFile(settingsPath); err == nil {
-		json.Unmarshal(data, &settings)
-	} else {
-		settings = make(map[string]interface{})
-	}
-
-	// Build hooks configuration
-	hooks := make(map[string]interface{})
-	
-	// Group hooks by type
-	for _, hook := range hm.globalHooks {
-		if !hook.Enabled {
-			continue
-		}
-
-		hookConfig := map[string]interface{}{
-			"type":    "command",
-			"command": hook.Command,
-		}
-
-		// Add environment variables from config
-		if len(hook.Config) > 0 {
-			env := make(map[string]string)
-			for k, v := range hook.Config {
-				env[k] = v
-			}
-			hookConfig["env"] = env
-		}
-
-		// Create hook entry
-		hookEntry := map[string]interface{}{
-			"matcher": hook.Matcher,
-			"hooks":   []interface{}{hookConfig},
-		}
-
-		// Add to appropriate hook type
-		typeName := string(hook.Type)
-		if existing, ok := hooks[typeName]; ok {
-			hooks[typeName] = append(existing.([]interface{}), hookEntry)
-		} else {
-			hooks[typeName] = []interface{}{hookEntry}
+	managed := hm.globalManagedHooks()
+	var enabled []*Hook
+	for _, hook := range managed {
+		if hm.enabledHooks[hook.Name] {
+			enabled = append(enabled, hook)
 		}
 	}
 
-	// Update settings
-	settings["hooks"] = hooks
-
-	// Write settings
-	data, err := json.MarshalIndent(settings, "", "  ")
-	if err != nil {
-		return err
-	}
-
-	// Ensure directory exists
-	os.MkdirAll(filepath.Dir(settingsPath), 0755)
-
-	return os.WriteFile(settingsPath, data, 0644)
+	return UpdateSettingsFile(hm.settingsPath(), hm.managedCommands(managed), enabled)
 }
 
 // loadEnabledStatus loads which hooks are enabled from Claude settings
 func (hm *HookManager) loadEnabledStatus() {
-	homeDir, _ := os.UserHomeDir()
-	settingsPath := filepath.Join(homeDir, ".claude", "settings.json")
-
-	data, err := os.ReadFile(settingsPath)
+	data, err := os.ReadFile(hm.settingsPath())
 	if err != nil {
 		return
 	}
@@ -308,6 +337,7 @@ func (hm *HookManager) loadEnabledStatus() {
 	// Check which hooks are configured
 	if hooks, ok := settings["hooks"].(map[string]interface{}); ok {
 		for _, hook := range hm.globalHooks {
+			isHook := hm.managedCommands([]*Hook{hook})
 			if hookType, ok := hooks[string(hook.Type)].([]interface{}); ok {
 				for _, entry := range hookType {
 					if m, ok := entry.(map[string]interface{}); ok {
@@ -315,7 +345,7 @@ func (hm *HookManager) loadEnabledStatus() {
 							if hooksList, ok := m["hooks"].([]interface{}); ok {
 								for _, h := range hooksList {
 									if hookMap, ok := h.(map[string]interface{}); ok {
-										if cmd, ok := hookMap["command"].(string); ok && strings.Contains(cmd, hook.Name) {
+										if cmd, ok := hookMap["command"].(string); ok && isHook(cmd) {
 											hook.Enabled = true
 											hm.enabledHooks[hook.Name] = true
 										}
@@ -339,6 +369,30 @@ func (hm *HookManager) GetHookInfo(name string) (*Hook, error) {
 	return hook, nil
 }
 
+// PlanHook resolves how Claude Code would invoke a hook: from the project
+// root, with the hook's config as environment and tool JSON on stdin. An
+// empty JSON object stands in for the tool payload.
+func (hm *HookManager) PlanHook(name string) (*ExecutionPlan, error) {
+	hook, exists := hm.globalHooks[name]
+	if !exists {
+		return nil, fmt.Errorf("hook not found: %s", name)
+	}
+
+	env := map[string]string{"CLAUDE_PROJECT_DIR": hm.projectRoot}
+	for k, v := range hook.Config {
+		env[k] = v
+	}
+
+	return &ExecutionPlan{
+		Name:    hook.Name,
+		Command: hook.Command,
+		Dir:     hm.projectRoot,
+		Env:     env,
+		Timeout: DefaultHookTimeout,
+		Stdin:   "{}",
+	}, nil
+}
+
 // ConfigureHook updates hook configuration
 func (hm *HookManager) ConfigureHook(name string, config map[string]string) error {
 	hook, exists := hm.globalHooks[name]
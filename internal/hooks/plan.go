@@ -0,0 +1,161 @@
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ExecutionPlan describes exactly how a hook will be invoked
+type ExecutionPlan struct {
+	Name    string
+	Command string
+	Args    []string
+	Dir     string
+	// Env holds only the variables injected on top of the process environment
+	Env     map[string]string
+	Timeout time.Duration
+	// Stdin is fed to the process; Claude Code hooks read tool JSON from it
+	Stdin string
+}
+
+// CommandLine returns the shell-quoted command line
+func (p *ExecutionPlan) CommandLine() string {
+	parts := []string{shellQuote(p.Command)}
+	for _, arg := range p.Args {
+		parts = append(parts, shellQuote(arg))
+	}
+	return strings.Join(parts, " ")
+}
+
+// EnvList returns the injected variables as sorted KEY=value pairs
+func (p *ExecutionPlan) EnvList() []string {
+	vars := make([]string, 0, len(p.Env))
+	for k, v := range p.Env {
+		vars = append(vars, k+"="+v)
+	}
+	sort.Strings(vars)
+	return vars
+}
+
+// waitDelay bounds how long a cancelled hook may hold its output open,
+// e.g. through a child that escaped its process group
+const waitDelay = 2 * time.Second
+
+// command builds the exec.Cmd for the plan. Cancelling ctx kills the hook
+// and everything it started.
+func (p *ExecutionPlan) command(ctx context.Context) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, p.Command, p.Args...)
+	killProcessGroup(cmd)
+	cmd.WaitDelay = waitDelay
+	cmd.Dir = p.Dir
+	cmd.Env = append(os.Environ(), p.EnvList()...)
+	if p.Stdin != "" {
+		cmd.Stdin = strings.NewReader(p.Stdin)
+	}
+	return cmd
+}
+
+// ExecResult summarizes a single plan execution
+type ExecResult struct {
+	ExitCode int
+	Duration time.Duration
+	TimedOut bool
+}
+
+// Execute runs the plan with its timeout, copying output to stdout and stderr
+func Execute(ctx context.Context, plan *ExecutionPlan, stdout, stderr io.Writer) (ExecResult, error) {
+	var result ExecResult
+
+	timeout := plan.Timeout
+	if timeout <= 0 {
+		timeout = DefaultHookTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	cmd := plan.command(ctx)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	start := time.Now()
+	err := cmd.Run()
+	result.Duration = time.Since(start)
+
+	if cmd.ProcessState != nil {
+		result.ExitCode = cmd.ProcessState.ExitCode()
+	}
+
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		result.TimedOut = true
+		return result, fmt.Errorf("timed out after %s", timeout)
+	}
+	return result, err
+}
+
+// Trace executes the plan, streaming stdout and stderr to out as they are
+// produced, one timestamped line at a time
+func Trace(ctx context.Context, plan *ExecutionPlan, out io.Writer) (ExecResult, error) {
+	var mu sync.Mutex
+	stdout := &traceWriter{out: out, stream: "out", mu: &mu}
+	stderr := &traceWriter{out: out, stream: "err", mu: &mu}
+
+	result, err := Execute(ctx, plan, stdout, stderr)
+	stdout.Flush()
+	stderr.Flush()
+
+	return result, err
+}
+
+// traceWriter prefixes each complete line with a timestamp and stream name
+type traceWriter struct {
+	out     io.Writer
+	stream  string
+	mu      *sync.Mutex
+	partial bytes.Buffer
+}
+
+func (w *traceWriter) Write(p []byte) (int, error) {
+	w.partial.Write(p)
+	for {
+		line, err := w.partial.ReadString('\n')
+		if err != nil {
+			// Keep the incomplete line until more output arrives
+			w.partial.Reset()
+			w.partial.WriteString(line)
+			break
+		}
+		w.emit(strings.TrimRight(line, "\r\n"))
+	}
+	return len(p), nil
+}
+
+// Flush writes any trailing output that did not end with a newline
+func (w *traceWriter) Flush() {
+	if w.partial.Len() > 0 {
+		w.emit(w.partial.String())
+		w.partial.Reset()
+	}
+}
+
+func (w *traceWriter) emit(line string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	fmt.Fprintf(w.out, "%s %s| %s\n", time.Now().Format("15:04:05.000"), w.stream, line)
+}
+
+// shellQuote quotes s for display when it contains shell metacharacters
+func shellQuote(s string) string {
+	if s != "" && !strings.ContainsAny(s, " \t\n'\"\\$`|&;<>()*?[]{}~!#") {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,82 @@
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestPlanHook(t *testing.T) {
+	dir := t.TempDir()
+	writeLifecycleHook(t, dir, "notify",
+		`{"event": "post-update", "script": "notify.sh", "args": ["--channel", "#ops team"], "timeout": "5s", "env": {"LEVEL": "info"}}`,
+		"#!/bin/sh\n")
+
+	rt := NewRuntime(dir)
+	if err := rt.Load(); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	hook, ok := rt.FindHook("notify")
+	if !ok {
+		t.Fatal("notify hook not found")
+	}
+
+	plan, err := rt.PlanHook(hook, map[string]string{"CREW_COMPONENTS": "core"})
+	if err != nil {
+		t.Fatalf("PlanHook failed: %v", err)
+	}
+
+	expected := filepath.Join(dir, "notify.sh") + " --channel '#ops team'"
+	if plan.CommandLine() != expected {
+		t.Errorf("CommandLine() = %q, want %q", plan.CommandLine(), expected)
+	}
+	if plan.Dir != dir {
+		t.Errorf("Expected working directory %s, got %s", dir, plan.Dir)
+	}
+
+	env := strings.Join(plan.EnvList(), "\n")
+	for _, want := range []string{"LEVEL=info", "CREW_COMPONENTS=core", "CREW_EVENT=post-update", "CREW_HOOK_NAME=notify"} {
+		if !strings.Contains(env, want) {
+			t.Errorf("Expected %s in plan environment:\n%s", want, env)
+		}
+	}
+}
+
+func TestTraceTimestampsOutput(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell scripts not supported on windows")
+	}
+
+	script := filepath.Join(t.TempDir(), "trace.sh")
+	if err := os.WriteFile(script, []byte("#!/bin/sh\necho first\necho oops >&2\nprintf partial\nexit 2\n"), 0755); err != nil {
+		t.Fatalf("Failed to write script: %v", err)
+	}
+
+	var out bytes.Buffer
+	result, err := Trace(context.Background(), &ExecutionPlan{Name: "trace", Command: script}, &out)
+	if err == nil {
+		t.Error("Expected non-zero exit to be reported as an error")
+	}
+	if result.ExitCode != 2 {
+		t.Errorf("Expected exit code 2, got %d", result.ExitCode)
+	}
+
+	line := regexp.MustCompile(`^\d{2}:\d{2}:\d{2}\.\d{3} (out|err)\| `)
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Expected 3 traced lines, got %d:\n%s", len(lines), out.String())
+	}
+	for _, l := range lines {
+		if !line.MatchString(l) {
+			t.Errorf("Line missing timestamp prefix: %q", l)
+		}
+	}
+	if !strings.Contains(out.String(), "err| oops") || !strings.Contains(out.String(), "out| partial") {
+		t.Errorf("Unexpected trace output:\n%s", out.String())
+	}
+}
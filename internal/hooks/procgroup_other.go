@@ -0,0 +1,9 @@
+//go:build !unix
+
+package hooks
+
+import "os/exec"
+
+// killProcessGroup is not available on this platform; cancelling cmd kills
+// the hook process only
+func killProcessGroup(cmd *exec.Cmd) {}
@@ -0,0 +1,204 @@
+package hooks
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/claudesettings"
+	"github.com/jonwraymond/claude-code-super-crew/internal/crewdirs"
+)
+
+// ProjectHooksFileName is the per-project hooks overlay inside .claude
+const ProjectHooksFileName = "hooks.json"
+
+// ProjectHooksPath returns the path of the project's hooks overlay
+func (hm *HookManager) ProjectHooksPath() string {
+	return filepath.Join(hm.projectRoot, ".claude", ProjectHooksFileName)
+}
+
+// projectSettingsPath returns the project's Claude settings file, where
+// enabled project-only hooks are registered
+func (hm *HookManager) projectSettingsPath() string {
+	return filepath.Join(hm.projectRoot, ".claude", "settings.json")
+}
+
+// projectKey names the project in the installation's crew directory
+func (hm *HookManager) projectKey() string {
+	sum := sha256.Sum256([]byte(hm.projectRoot))
+	return fmt.Sprintf("%s-%x", filepath.Base(hm.projectRoot), sum[:4])
+}
+
+// projectBackupDir returns where backups of the project's settings go: the
+// crew directory of the installation, one directory per project, so they
+// stay out of the project
+func (hm *HookManager) projectBackupDir() string {
+	return crewdirs.Path(hm.installDir, "backups", "settings", "projects", hm.projectKey())
+}
+
+// projectSettingsEditor returns the editor for the project's settings,
+// with its backups and ledger in the installation's crew directory
+func (hm *HookManager) projectSettingsEditor() *claudesettings.Editor {
+	editor := claudesettings.NewEditor(filepath.Dir(hm.projectSettingsPath()))
+	editor.BackupDir = hm.projectBackupDir()
+	editor.LedgerPath = crewdirs.Path(hm.installDir, "config", "projects", hm.projectKey(), claudesettings.LedgerFile)
+	return editor
+}
+
+// loadProjectOverlay applies .claude/hooks.json on top of the global hooks
+func (hm *HookManager) loadProjectOverlay() error {
+	data, err := os.ReadFile(hm.ProjectHooksPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read project hooks: %w", err)
+	}
+
+	var file ProjectHooksFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("invalid project hooks file %s: %w", hm.ProjectHooksPath(), err)
+	}
+	if file.Hooks == nil {
+		file.Hooks = make(map[string]*ProjectHookConfig)
+	}
+	hm.projectHooks = &file
+
+	for name, override := range file.Hooks {
+		if hook, exists := hm.globalHooks[name]; exists {
+			if override.Enabled != nil {
+				hook.Origin = OriginProjectOverride
+			}
+			hook.Enabled = hm.effectiveEnabled(name)
+			continue
+		}
+
+		if override.Type == "" || override.Command == "" {
+			hm.logger.Warnf("Skipping project hook %s: type and command are required", name)
+			continue
+		}
+
+		command := override.Command
+		if !filepath.IsAbs(command) {
+			command = filepath.Join(hm.projectRoot, command)
+		}
+
+		hm.globalHooks[name] = &Hook{
+			Name:        name,
+			Description: override.Description,
+			Type:        override.Type,
+			Matcher:     override.Matcher,
+			Command:     command,
+			Enabled:     override.Enabled != nil && *override.Enabled,
+			Config:      override.Config,
+			Origin:      OriginProject,
+		}
+	}
+
+	return nil
+}
+
+// effectiveEnabled resolves a hook's enabled state: a project override wins
+// over the global setting
+func (hm *HookManager) effectiveEnabled(name string) bool {
+	if override, ok := hm.projectHooks.Hooks[name]; ok && override.Enabled != nil {
+		return *override.Enabled
+	}
+	return hm.enabledHooks[name]
+}
+
+// SetProjectOverride enables or disables a hook for the current project
+// only. Global hooks get an override entry, and are registered in the
+// project's Claude settings when enabled there but not globally, since
+// Claude Code would not run them otherwise. Project-only hooks are
+// registered in or removed from the project's Claude settings.
+func (hm *HookManager) SetProjectOverride(name string, enabled bool) error {
+	hook, exists := hm.globalHooks[name]
+	if !exists {
+		return fmt.Errorf("hook not found: %s", name)
+	}
+
+	override, ok := hm.projectHooks.Hooks[name]
+	if !ok {
+		override = &ProjectHookConfig{}
+		hm.projectHooks.Hooks[name] = override
+	}
+	override.Enabled = &enabled
+
+	if hook.Origin == OriginGlobal {
+		hook.Origin = OriginProjectOverride
+	}
+	hook.Enabled = hm.effectiveEnabled(name)
+
+	if err := hm.saveProjectOverlay(); err != nil {
+		return err
+	}
+
+	if err := hm.updateProjectSettings(); err != nil {
+		return fmt.Errorf("failed to update project settings: %w", err)
+	}
+
+	state := "Disabled"
+	if enabled {
+		state = "Enabled"
+	}
+	hm.logger.Successf("%s hook for this project: %s", state, name)
+	return nil
+}
+
+// ClearProjectOverride removes a project override so the global state applies
+func (hm *HookManager) ClearProjectOverride(name string) error {
+	hook, exists := hm.globalHooks[name]
+	if !exists {
+		return fmt.Errorf("hook not found: %s", name)
+	}
+	if hook.Origin == OriginProject {
+		return fmt.Errorf("hook %s is defined by the project; edit %s to remove it", name, hm.ProjectHooksPath())
+	}
+
+	delete(hm.projectHooks.Hooks, name)
+	hook.Origin = OriginGlobal
+	hook.Enabled = hm.effectiveEnabled(name)
+
+	if err := hm.saveProjectOverlay(); err != nil {
+		return err
+	}
+	if err := hm.updateProjectSettings(); err != nil {
+		return fmt.Errorf("failed to update project settings: %w", err)
+	}
+	return nil
+}
+
+// saveProjectOverlay writes .claude/hooks.json
+func (hm *HookManager) saveProjectOverlay() error {
+	path := hm.ProjectHooksPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create project .claude directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(hm.projectHooks, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write project hooks: %w", err)
+	}
+	return nil
+}
+
+// updateProjectSettings registers in the project's Claude settings the
+// hooks Claude Code would not run otherwise: enabled project-only hooks,
+// and global hooks the project enables that are not enabled globally
+func (hm *HookManager) updateProjectSettings() error {
+	var managed, enabled []*Hook
+	for _, hook := range hm.globalHooks {
+		managed = append(managed, hook)
+		if hook.Enabled && !hm.enabledHooks[hook.Name] {
+			enabled = append(enabled, hook)
+		}
+	}
+	return updateSettings(hm.projectSettingsEditor(), claudesettings.FileName, hm.managedCommands(managed), enabled)
+}
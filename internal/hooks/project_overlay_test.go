@@ -0,0 +1,102 @@
+package hooks
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestProjectOverlay(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	projectRoot := t.TempDir()
+	claudeDir := filepath.Join(projectRoot, ".claude")
+	if err := os.MkdirAll(claudeDir, 0755); err != nil {
+		t.Fatalf("Failed to create .claude: %v", err)
+	}
+
+	overlay := `{
+  "hooks": {
+    "lint-on-save": {"enabled": true},
+    "format-docs": {"type": "PostToolUse", "matcher": "Write", "command": ".claude/hooks/format-docs.sh"}
+  }
+}`
+	if err := os.WriteFile(filepath.Join(claudeDir, ProjectHooksFileName), []byte(overlay), 0644); err != nil {
+		t.Fatalf("Failed to write overlay: %v", err)
+	}
+
+	hm := NewHookManager(projectRoot)
+	if err := hm.DiscoverHooks(); err != nil {
+		t.Fatalf("DiscoverHooks failed: %v", err)
+	}
+
+	lint, err := hm.GetHookInfo("lint-on-save")
+	if err != nil {
+		t.Fatalf("lint-on-save missing: %v", err)
+	}
+	if !lint.Enabled || lint.Origin != OriginProjectOverride {
+		t.Errorf("Expected lint-on-save enabled by project override, got enabled=%v origin=%s", lint.Enabled, lint.Origin)
+	}
+
+	docs, err := hm.GetHookInfo("format-docs")
+	if err != nil {
+		t.Fatalf("format-docs missing: %v", err)
+	}
+	if docs.Enabled || docs.Origin != OriginProject {
+		t.Errorf("Expected disabled project-only hook, got enabled=%v origin=%s", docs.Enabled, docs.Origin)
+	}
+	if docs.Command != filepath.Join(projectRoot, ".claude", "hooks", "format-docs.sh") {
+		t.Errorf("Expected command relative to project root, got %s", docs.Command)
+	}
+
+	commit, _ := hm.GetHookInfo("git-auto-commit")
+	if commit.Origin != OriginGlobal {
+		t.Errorf("Expected git-auto-commit to keep global origin, got %s", commit.Origin)
+	}
+
+	// Enabling the project-only hook registers it in the project settings
+	if err := hm.SetProjectOverride("format-docs", true); err != nil {
+		t.Fatalf("SetProjectOverride failed: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(claudeDir, "settings.json"))
+	if err != nil {
+		t.Fatalf("Expected project settings to be written: %v", err)
+	}
+	var settings map[string]interface{}
+	if err := json.Unmarshal(data, &settings); err != nil {
+		t.Fatalf("Invalid project settings: %v", err)
+	}
+	if _, ok := settings["hooks"].(map[string]interface{})["PostToolUse"]; !ok {
+		t.Errorf("Expected PostToolUse hook in project settings: %s", data)
+	}
+	// lint-on-save is only enabled for the project, so it is registered too
+	if !strings.Contains(string(data), lint.Command) {
+		t.Errorf("Expected the project-enabled lint-on-save in project settings: %s", data)
+	}
+
+	// Backups of the project settings are kept out of the project
+	if err := hm.SetProjectOverride("format-docs", false); err != nil {
+		t.Fatalf("SetProjectOverride failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(claudeDir, ".crew")); !os.IsNotExist(err) {
+		t.Error("Expected no crew directory in the project")
+	}
+	if backups, _ := filepath.Glob(filepath.Join(hm.projectBackupDir(), "settings_*.json")); len(backups) != 1 {
+		t.Errorf("Expected one backup in %s, got %v", hm.projectBackupDir(), backups)
+	}
+
+	// Project overrides never touch the global settings
+	if _, err := os.Stat(filepath.Join(home, ".claude", "settings.json")); !os.IsNotExist(err) {
+		t.Error("Expected global settings to be left alone")
+	}
+
+	if err := hm.ClearProjectOverride("lint-on-save"); err != nil {
+		t.Fatalf("ClearProjectOverride failed: %v", err)
+	}
+	if lint.Enabled || lint.Origin != OriginGlobal {
+		t.Errorf("Expected lint-on-save to fall back to global state, got enabled=%v origin=%s", lint.Enabled, lint.Origin)
+	}
+}
@@ -0,0 +1,351 @@
+package hooks
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jonwraymond/claude-code-super-crew/pkg/logger"
+)
+
+// LifecycleEvent is a crew operation stage that lifecycle hooks can attach to.
+// Unlike HookType, these fire during crew commands rather than inside Claude Code.
+type LifecycleEvent string
+
+const (
+	PreInstall    LifecycleEvent = "pre-install"
+	PostInstall   LifecycleEvent = "post-install"
+	PreUpdate     LifecycleEvent = "pre-update"
+	PostUpdate    LifecycleEvent = "post-update"
+	PreBackup     LifecycleEvent = "pre-backup"
+	PostBackup    LifecycleEvent = "post-backup"
+	PreUninstall  LifecycleEvent = "pre-uninstall"
+	PostUninstall LifecycleEvent = "post-uninstall"
+	FileChange    LifecycleEvent = "file-change"
+)
+
+// LifecycleEvents lists all supported lifecycle events
+var LifecycleEvents = []LifecycleEvent{
+	PreInstall, PostInstall,
+	PreUpdate, PostUpdate,
+	PreBackup, PostBackup,
+	PreUninstall, PostUninstall,
+	FileChange,
+}
+
+// IsValidLifecycleEvent reports whether event is a known lifecycle event
+func IsValidLifecycleEvent(event string) bool {
+	for _, e := range LifecycleEvents {
+		if string(e) == event {
+			return true
+		}
+	}
+	return false
+}
+
+// FailurePolicy controls how a failing lifecycle hook affects the operation
+type FailurePolicy string
+
+const (
+	// FailIgnore logs the failure at debug level and continues
+	FailIgnore FailurePolicy = "ignore"
+	// FailWarn logs a warning and continues (default)
+	FailWarn FailurePolicy = "warn"
+	// FailAbort stops the operation; only meaningful for pre-* events
+	FailAbort FailurePolicy = "abort"
+)
+
+// DefaultHookTimeout applies when a descriptor does not set a timeout
+const DefaultHookTimeout = 60 * time.Second
+
+// LifecycleHook is a hook descriptor loaded from <name>.json in the hooks
+// directory. Script paths are relative to the descriptor.
+type LifecycleHook struct {
+	Name          string            `json:"name"`
+	Description   string            `json:"description,omitempty"`
+	Event         LifecycleEvent    `json:"event"`
+	Script        string            `json:"script"`
+	Args          []string          `json:"args,omitempty"`
+	Timeout       string            `json:"timeout,omitempty"`
+	Env           map[string]string `json:"env,omitempty"`
+	OnFailure     FailurePolicy     `json:"on_failure,omitempty"`
+	RequiredTools []string          `json:"required_tools,omitempty"`
+	Enabled       *bool             `json:"enabled,omitempty"`
+
+	// Path is the descriptor file the hook was loaded from
+	Path string `json:"-"`
+}
+
+// IsEnabled reports whether the hook should run; hooks are enabled by default
+func (h *LifecycleHook) IsEnabled() bool {
+	return h.Enabled == nil || *h.Enabled
+}
+
+// ScriptPath returns the absolute path of the hook script
+func (h *LifecycleHook) ScriptPath() string {
+	if filepath.IsAbs(h.Script) {
+		return h.Script
+	}
+	return filepath.Join(filepath.Dir(h.Path), h.Script)
+}
+
+// GetTimeout returns the parsed timeout or DefaultHookTimeout
+func (h *LifecycleHook) GetTimeout() (time.Duration, error) {
+	if h.Timeout == "" {
+		return DefaultHookTimeout, nil
+	}
+	timeout, err := time.ParseDuration(h.Timeout)
+	if err != nil {
+		return 0, fmt.Errorf("invalid timeout %q: %w", h.Timeout, err)
+	}
+	if timeout <= 0 {
+		return 0, fmt.Errorf("timeout must be positive, got %s", h.Timeout)
+	}
+	return timeout, nil
+}
+
+// GetFailurePolicy returns the configured policy, defaulting to FailWarn
+func (h *LifecycleHook) GetFailurePolicy() FailurePolicy {
+	if h.OnFailure == "" {
+		return FailWarn
+	}
+	return h.OnFailure
+}
+
+// HookResult records the outcome of a single hook execution
+type HookResult struct {
+	Hook     *LifecycleHook
+	ExitCode int
+	Output   string
+	Duration time.Duration
+	TimedOut bool
+	Err      error
+}
+
+// Runtime loads lifecycle hook descriptors and executes them for events
+type Runtime struct {
+	hooksDir string
+	hooks    []*LifecycleHook
+	baseEnv  map[string]string
+	logger   logger.Logger
+}
+
+// NewRuntime creates a lifecycle hook runtime for the given hooks directory
+func NewRuntime(hooksDir string) *Runtime {
+	return &Runtime{
+		hooksDir: hooksDir,
+		baseEnv:  make(map[string]string),
+		logger:   logger.GetLogger(),
+	}
+}
+
+// GetHooksDir returns the directory descriptors are loaded from
+func (r *Runtime) GetHooksDir() string {
+	return r.hooksDir
+}
+
+// SetEnv adds an environment variable injected into every hook
+func (r *Runtime) SetEnv(key, value string) {
+	r.baseEnv[key] = value
+}
+
+// Load reads all hook descriptors. A missing hooks directory is not an error.
+func (r *Runtime) Load() error {
+	r.hooks = nil
+
+	entries, err := os.ReadDir(r.hooksDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read hooks directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+
+		hook, err := LoadLifecycleHook(filepath.Join(r.hooksDir, entry.Name()))
+		if err != nil {
+			r.logger.Warnf("Skipping hook descriptor %s: %v", entry.Name(), err)
+			continue
+		}
+		r.hooks = append(r.hooks, hook)
+	}
+
+	sort.Slice(r.hooks, func(i, j int) bool {
+		return r.hooks[i].Name < r.hooks[j].Name
+	})
+
+	return nil
+}
+
+// LoadLifecycleHook reads a single hook descriptor
+func LoadLifecycleHook(path string) (*LifecycleHook, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var hook LifecycleHook
+	if err := json.Unmarshal(data, &hook); err != nil {
+		return nil, fmt.Errorf("invalid hook descriptor: %w", err)
+	}
+
+	hook.Path = path
+	if hook.Name == "" {
+		hook.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+
+	return &hook, nil
+}
+
+// Hooks returns all loaded hooks
+func (r *Runtime) Hooks() []*LifecycleHook {
+	return r.hooks
+}
+
+// FindHook returns the loaded hook with the given name
+func (r *Runtime) FindHook(name string) (*LifecycleHook, bool) {
+	for _, hook := range r.hooks {
+		if hook.Name == name {
+			return hook, true
+		}
+	}
+	return nil, false
+}
+
+// HooksForEvent returns the enabled hooks registered for event
+func (r *Runtime) HooksForEvent(event LifecycleEvent) []*LifecycleHook {
+	var matched []*LifecycleHook
+	for _, hook := range r.hooks {
+		if hook.Event == event && hook.IsEnabled() {
+			matched = append(matched, hook)
+		}
+	}
+	return matched
+}
+
+// Run executes every enabled hook for event in name order. It returns an
+// error only when a hook with the abort policy fails; other failures are
+// logged according to their policy and reported in the results.
+func (r *Runtime) Run(ctx context.Context, event LifecycleEvent, env map[string]string) ([]HookResult, error) {
+	var results []HookResult
+
+	for _, hook := range r.HooksForEvent(event) {
+		result := r.RunHook(ctx, hook, env)
+		results = append(results, result)
+
+		if result.Err == nil {
+			continue
+		}
+
+		switch hook.GetFailurePolicy() {
+		case FailAbort:
+			r.logger.Errorf("Hook %s failed: %v", hook.Name, result.Err)
+			return results, fmt.Errorf("%s hook %s failed: %w", event, hook.Name, result.Err)
+		case FailIgnore:
+			r.logger.Debugf("Hook %s failed (ignored): %v", hook.Name, result.Err)
+		default:
+			r.logger.Warnf("Hook %s failed: %v", hook.Name, result.Err)
+		}
+	}
+
+	return results, nil
+}
+
+// PlanHook resolves how a hook would be executed without running it
+func (r *Runtime) PlanHook(hook *LifecycleHook, env map[string]string) (*ExecutionPlan, error) {
+	timeout, err := hook.GetTimeout()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ExecutionPlan{
+		Name:    hook.Name,
+		Command: hook.ScriptPath(),
+		Args:    hook.Args,
+		Dir:     filepath.Dir(hook.Path),
+		Env:     r.buildEnv(hook, env),
+		Timeout: timeout,
+	}, nil
+}
+
+// RunHook executes a single hook with its timeout and injected environment
+func (r *Runtime) RunHook(ctx context.Context, hook *LifecycleHook, env map[string]string) HookResult {
+	result := HookResult{Hook: hook}
+
+	plan, err := r.PlanHook(hook, env)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, plan.Timeout)
+	defer cancel()
+
+	var output bytes.Buffer
+	cmd := plan.command(ctx)
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+
+	r.logger.Debugf("Running %s hook: %s", hook.Event, hook.Name)
+
+	start := time.Now()
+	err = cmd.Run()
+	result.Duration = time.Since(start)
+	result.Output = output.String()
+	r.logOutput(hook, result.Output)
+
+	if cmd.ProcessState != nil {
+		result.ExitCode = cmd.ProcessState.ExitCode()
+	}
+
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		result.TimedOut = true
+		result.Err = fmt.Errorf("timed out after %s", plan.Timeout)
+	} else if err != nil {
+		result.Err = err
+	}
+
+	return result
+}
+
+// buildEnv collects the injected variables: runtime, hook, then
+// per-invocation values, so later sources win
+func (r *Runtime) buildEnv(hook *LifecycleHook, env map[string]string) map[string]string {
+	vars := make(map[string]string)
+	add := func(values map[string]string) {
+		for k, v := range values {
+			vars[k] = v
+		}
+	}
+
+	add(r.baseEnv)
+	add(hook.Env)
+	add(env)
+	add(map[string]string{
+		"CREW_EVENT":     string(hook.Event),
+		"CREW_HOOK_NAME": hook.Name,
+		"CREW_HOOKS_DIR": r.hooksDir,
+	})
+
+	return vars
+}
+
+// logOutput writes hook output to the debug log line by line
+func (r *Runtime) logOutput(hook *LifecycleHook, output string) {
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		r.logger.Debugf("[hook:%s] %s", hook.Name, scanner.Text())
+	}
+}
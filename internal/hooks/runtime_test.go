@@ -0,0 +1,116 @@
+package hooks
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeLifecycleHook(t *testing.T, dir, name, descriptor, script string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name+".json"), []byte(descriptor), 0644); err != nil {
+		t.Fatalf("Failed to write descriptor: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name+".sh"), []byte(script), 0755); err != nil {
+		t.Fatalf("Failed to write script: %v", err)
+	}
+}
+
+func TestRuntimeRun(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell scripts not supported on windows")
+	}
+
+	dir := t.TempDir()
+	writeLifecycleHook(t, dir, "announce",
+		`{"event": "post-install", "script": "announce.sh", "env": {"GREETING": "hello"}}`,
+		"#!/bin/sh\necho \"$GREETING $CREW_EVENT $CREW_COMPONENTS\"\n")
+	writeLifecycleHook(t, dir, "disabled",
+		`{"event": "post-install", "script": "disabled.sh", "enabled": false}`,
+		"#!/bin/sh\nexit 1\n")
+	writeLifecycleHook(t, dir, "other-event",
+		`{"event": "pre-backup", "script": "other-event.sh"}`,
+		"#!/bin/sh\nexit 0\n")
+
+	rt := NewRuntime(dir)
+	if err := rt.Load(); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	results, err := rt.Run(context.Background(), PostInstall, map[string]string{"CREW_COMPONENTS": "core"})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("Expected 1 result, got %d", len(results))
+	}
+	if got := strings.TrimSpace(results[0].Output); got != "hello post-install core" {
+		t.Errorf("Unexpected hook output: %q", got)
+	}
+}
+
+func TestRuntimeFailurePolicies(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell scripts not supported on windows")
+	}
+
+	dir := t.TempDir()
+	writeLifecycleHook(t, dir, "a-warn",
+		`{"event": "pre-update", "script": "a-warn.sh"}`,
+		"#!/bin/sh\nexit 3\n")
+	writeLifecycleHook(t, dir, "b-abort",
+		`{"event": "pre-update", "script": "b-abort.sh", "on_failure": "abort"}`,
+		"#!/bin/sh\nexit 1\n")
+	writeLifecycleHook(t, dir, "c-never",
+		`{"event": "pre-update", "script": "c-never.sh"}`,
+		"#!/bin/sh\nexit 0\n")
+
+	rt := NewRuntime(dir)
+	if err := rt.Load(); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	results, err := rt.Run(context.Background(), PreUpdate, nil)
+	if err == nil {
+		t.Fatal("Expected abort policy to return an error")
+	}
+	if len(results) != 2 {
+		t.Fatalf("Expected run to stop after aborting hook, got %d results", len(results))
+	}
+	if results[0].ExitCode != 3 || results[0].Err == nil {
+		t.Errorf("Expected warn hook to record exit code 3, got %d (%v)", results[0].ExitCode, results[0].Err)
+	}
+}
+
+func TestRuntimeTimeout(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell scripts not supported on windows")
+	}
+
+	dir := t.TempDir()
+	writeLifecycleHook(t, dir, "slow",
+		`{"event": "file-change", "script": "slow.sh", "timeout": "100ms"}`,
+		"#!/bin/sh\nsleep 3; true\n")
+
+	rt := NewRuntime(dir)
+	if err := rt.Load(); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	start := time.Now()
+	results, err := rt.Run(context.Background(), FileChange, nil)
+	if err != nil {
+		t.Fatalf("Expected warn policy not to return an error: %v", err)
+	}
+	if len(results) != 1 || !results[0].TimedOut {
+		t.Errorf("Expected hook to time out, got %+v", results)
+	}
+	// The shell's sleep holds the output pipe open unless it is killed too
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected the hook and its children to be killed, took %s", elapsed)
+	}
+}
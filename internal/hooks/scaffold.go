@@ -0,0 +1,169 @@
+package hooks
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// hookNamePattern restricts hook names to safe file names
+var hookNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// scaffoldScript is the starting point for new lifecycle hook scripts
+const scaffoldScript = `#!/bin/sh
+# %s - %s hook
+#
+# Available environment:
+#   CREW_EVENT        lifecycle event that triggered this hook
+#   CREW_HOOK_NAME    name of this hook
+#   CREW_INSTALL_DIR  crew installation directory
+#   CREW_COMPONENTS   comma-separated components (install/update/uninstall)
+#
+# Exit non-zero to report failure; the descriptor's on_failure policy
+# decides whether the operation continues.
+set -eu
+
+echo "Running $CREW_HOOK_NAME for $CREW_EVENT"
+`
+
+// ScaffoldLifecycleHook creates <name>.json and <name>.sh in dir and returns
+// their paths. Existing files are never overwritten.
+func ScaffoldLifecycleHook(dir, name string, event LifecycleEvent) (string, string, error) {
+	if !hookNamePattern.MatchString(name) {
+		return "", "", fmt.Errorf("invalid hook name %q: use lowercase letters, digits, '-' and '_'", name)
+	}
+	if !IsValidLifecycleEvent(string(event)) {
+		return "", "", fmt.Errorf("unknown lifecycle event %q", event)
+	}
+
+	descriptorPath := filepath.Join(dir, name+".json")
+	scriptPath := filepath.Join(dir, name+".sh")
+	for _, path := range []string{descriptorPath, scriptPath} {
+		if _, err := os.Stat(path); err == nil {
+			return "", "", fmt.Errorf("hook file already exists: %s", path)
+		}
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", "", fmt.Errorf("failed to create hooks directory: %w", err)
+	}
+
+	descriptor := LifecycleHook{
+		Name:          name,
+		Description:   fmt.Sprintf("Runs on %s", event),
+		Event:         event,
+		Script:        name + ".sh",
+		Timeout:       DefaultHookTimeout.String(),
+		OnFailure:     FailWarn,
+		RequiredTools: []string{},
+	}
+
+	data, err := json.MarshalIndent(descriptor, "", "  ")
+	if err != nil {
+		return "", "", err
+	}
+
+	if err := os.WriteFile(descriptorPath, append(data, '\n'), 0644); err != nil {
+		return "", "", fmt.Errorf("failed to write hook descriptor: %w", err)
+	}
+
+	script := fmt.Sprintf(scaffoldScript, name, event)
+	if err := os.WriteFile(scriptPath, []byte(script), 0755); err != nil {
+		return "", "", fmt.Errorf("failed to write hook script: %w", err)
+	}
+
+	return descriptorPath, scriptPath, nil
+}
+
+// ValidateLifecycleHook lints a hook descriptor and its script. It returns a
+// list of problems; an empty list means the hook is valid.
+func ValidateLifecycleHook(hook *LifecycleHook) []string {
+	var issues []string
+
+	if !hookNamePattern.MatchString(hook.Name) {
+		issues = append(issues, fmt.Sprintf("invalid name %q", hook.Name))
+	}
+	if !IsValidLifecycleEvent(string(hook.Event)) {
+		issues = append(issues, fmt.Sprintf("unknown event %q", hook.Event))
+	}
+	if _, err := hook.GetTimeout(); err != nil {
+		issues = append(issues, err.Error())
+	}
+
+	switch hook.GetFailurePolicy() {
+	case FailIgnore, FailWarn, FailAbort:
+	default:
+		issues = append(issues, fmt.Sprintf("unknown on_failure policy %q", hook.OnFailure))
+	}
+
+	for _, tool := range hook.RequiredTools {
+		if _, err := exec.LookPath(tool); err != nil {
+			issues = append(issues, fmt.Sprintf("required tool not found in PATH: %s", tool))
+		}
+	}
+
+	if hook.Script == "" {
+		issues = append(issues, "script is not set")
+		return issues
+	}
+
+	info, err := os.Stat(hook.ScriptPath())
+	if err != nil {
+		issues = append(issues, fmt.Sprintf("script not found: %s", hook.ScriptPath()))
+		return issues
+	}
+	if info.Mode().Perm()&0111 == 0 {
+		issues = append(issues, fmt.Sprintf("script is not executable: %s", hook.ScriptPath()))
+	}
+
+	issues = append(issues, shellcheck(hook.ScriptPath())...)
+
+	return issues
+}
+
+// shellcheck runs shellcheck on shell scripts when it is installed
+func shellcheck(scriptPath string) []string {
+	if !isShellScript(scriptPath) {
+		return nil
+	}
+
+	bin, err := exec.LookPath("shellcheck")
+	if err != nil {
+		return nil
+	}
+
+	var output bytes.Buffer
+	cmd := exec.Command(bin, "--format=gcc", scriptPath)
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	if err := cmd.Run(); err == nil {
+		return nil
+	}
+
+	var issues []string
+	for _, line := range strings.Split(strings.TrimSpace(output.String()), "\n") {
+		if line != "" {
+			issues = append(issues, "shellcheck: "+line)
+		}
+	}
+	return issues
+}
+
+// isShellScript reports whether path looks like a POSIX shell script
+func isShellScript(path string) bool {
+	if ext := filepath.Ext(path); ext == ".sh" || ext == ".bash" {
+		return true
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	firstLine, _, _ := strings.Cut(string(data), "\n")
+	return strings.HasPrefix(firstLine, "#!") && (strings.HasSuffix(firstLine, "sh") || strings.Contains(firstLine, "bash"))
+}
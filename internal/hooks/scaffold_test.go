@@ -0,0 +1,59 @@
+package hooks
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestScaffoldLifecycleHook(t *testing.T) {
+	dir := t.TempDir()
+
+	descriptorPath, scriptPath, err := ScaffoldLifecycleHook(dir, "notify-slack", PostUpdate)
+	if err != nil {
+		t.Fatalf("Scaffold failed: %v", err)
+	}
+
+	hook, err := LoadLifecycleHook(descriptorPath)
+	if err != nil {
+		t.Fatalf("Failed to load scaffolded descriptor: %v", err)
+	}
+	if hook.Event != PostUpdate || hook.ScriptPath() != scriptPath {
+		t.Errorf("Unexpected descriptor: %+v", hook)
+	}
+
+	if issues := ValidateLifecycleHook(hook); len(issues) != 0 {
+		t.Errorf("Expected scaffolded hook to be valid, got %v", issues)
+	}
+
+	if _, _, err := ScaffoldLifecycleHook(dir, "notify-slack", PostUpdate); err == nil {
+		t.Error("Expected scaffolding over an existing hook to fail")
+	}
+	if _, _, err := ScaffoldLifecycleHook(dir, "../escape", PostUpdate); err == nil {
+		t.Error("Expected invalid name to be rejected")
+	}
+}
+
+func TestValidateLifecycleHookReportsProblems(t *testing.T) {
+	dir := t.TempDir()
+	scriptPath := filepath.Join(dir, "broken.sh")
+	if err := os.WriteFile(scriptPath, []byte("#!/bin/sh\n"), 0644); err != nil {
+		t.Fatalf("Failed to write script: %v", err)
+	}
+
+	hook := &LifecycleHook{
+		Name:          "broken",
+		Event:         "before-everything",
+		Script:        "broken.sh",
+		Timeout:       "soon",
+		OnFailure:     "explode",
+		RequiredTools: []string{"definitely-not-a-real-tool-xyz"},
+		Path:          filepath.Join(dir, "broken.json"),
+	}
+
+	issues := ValidateLifecycleHook(hook)
+	// event, timeout, policy, tool and executable bit
+	if len(issues) < 5 {
+		t.Errorf("Expected at least 5 issues, got %d: %v", len(issues), issues)
+	}
+}
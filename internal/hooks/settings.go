@@ -0,0 +1,247 @@
+package hooks
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/claudesettings"
+)
+
+// LedgerOwner is who the hook commands crew registers are recorded under
+// in the settings ledger
+const LedgerOwner = "hooks"
+
+// CommandMatcher reports whether a hook command in a Claude settings file is
+// managed by crew. Entries it rejects belong to the user and are preserved.
+type CommandMatcher func(command string) bool
+
+// MergeHooksStanza rewrites the crew-managed part of a settings "hooks"
+// stanza: managed commands are removed, then each enabled hook is added to
+// the matcher group for its type, creating the group if needed. User
+// entries, including ones sharing a matcher with crew hooks, are kept.
+func MergeHooksStanza(settings map[string]interface{}, managed CommandMatcher, enabled []*Hook) {
+	stanza := RemoveHooksStanza(settings, managed)
+	if stanza == nil {
+		stanza = make(map[string]interface{})
+	}
+
+	// Add hooks in a stable order so repeated runs produce identical files
+	sorted := append([]*Hook(nil), enabled...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+	for _, hook := range sorted {
+		typeName := string(hook.Type)
+		groups, _ := stanza[typeName].([]interface{})
+
+		entry := settingsHookEntry(hook)
+		added := false
+		for _, g := range groups {
+			group, ok := g.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if matcher, _ := group["matcher"].(string); matcher == hook.Matcher {
+				list, _ := group["hooks"].([]interface{})
+				group["hooks"] = append(list, entry)
+				added = true
+				break
+			}
+		}
+
+		if !added {
+			groups = append(groups, map[string]interface{}{
+				"matcher": hook.Matcher,
+				"hooks":   []interface{}{entry},
+			})
+		}
+		stanza[typeName] = groups
+	}
+
+	if len(stanza) > 0 {
+		settings["hooks"] = stanza
+	} else {
+		delete(settings, "hooks")
+	}
+}
+
+// RemoveHooksStanza strips managed commands from the settings "hooks"
+// stanza, dropping matcher groups and hook types left empty. It returns the
+// remaining stanza, or nil when nothing is left.
+func RemoveHooksStanza(settings map[string]interface{}, managed CommandMatcher) map[string]interface{} {
+	stanza, ok := settings["hooks"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	for typeName, rawGroups := range stanza {
+		groups, ok := rawGroups.([]interface{})
+		if !ok {
+			continue
+		}
+
+		var keptGroups []interface{}
+		for _, g := range groups {
+			group, ok := g.(map[string]interface{})
+			if !ok {
+				keptGroups = append(keptGroups, g)
+				continue
+			}
+
+			list, _ := group["hooks"].([]interface{})
+			var keptHooks []interface{}
+			for _, h := range list {
+				if entry, ok := h.(map[string]interface{}); ok {
+					if command, _ := entry["command"].(string); command != "" && managed(command) {
+						continue
+					}
+				}
+				keptHooks = append(keptHooks, h)
+			}
+
+			if len(keptHooks) == 0 {
+				continue
+			}
+			group["hooks"] = keptHooks
+			keptGroups = append(keptGroups, group)
+		}
+
+		if len(keptGroups) == 0 {
+			delete(stanza, typeName)
+		} else {
+			stanza[typeName] = keptGroups
+		}
+	}
+
+	if len(stanza) == 0 {
+		delete(settings, "hooks")
+		return nil
+	}
+	return stanza
+}
+
+// settingsHookEntry builds the command entry Claude Code expects
+func settingsHookEntry(hook *Hook) map[string]interface{} {
+	entry := map[string]interface{}{
+		"type":    "command",
+		"command": hook.Command,
+	}
+
+	// Add environment variables from config
+	if len(hook.Config) > 0 {
+		env := make(map[string]interface{}, len(hook.Config))
+		for k, v := range hook.Config {
+			env[k] = v
+		}
+		entry["env"] = env
+	}
+
+	return entry
+}
+
+// UpdateSettingsFile merges enabled hooks into a Claude settings file,
+// replacing only entries matched by managed or recorded in the settings
+// ledger. Other settings are untouched.
+func UpdateSettingsFile(settingsPath string, managed CommandMatcher, enabled []*Hook) error {
+	return updateSettings(settingsEditor(settingsPath), filepath.Base(settingsPath), managed, enabled)
+}
+
+// updateSettings merges enabled hooks into the settings file name and
+// records their commands in the editor's ledger, so they can be found
+// again wherever the scripts were registered from
+func updateSettings(editor *claudesettings.Editor, name string, managed CommandMatcher, enabled []*Hook) error {
+	_, err := editor.EditCommands(name, LedgerOwner, func(settings map[string]interface{}, recorded []string) []string {
+		MergeHooksStanza(settings, managed.or(recorded), enabled)
+		commands := make([]string, 0, len(enabled))
+		for _, hook := range enabled {
+			commands = append(commands, hook.Command)
+		}
+		return commands
+	})
+	return err
+}
+
+// RemoveFromSettingsFile removes from a Claude settings file the hook
+// entries matched by managed or recorded in the settings ledger, and
+// returns how many it removed. A missing file is not an error.
+func RemoveFromSettingsFile(settingsPath string, managed CommandMatcher) (int, error) {
+	if _, err := os.Stat(settingsPath); os.IsNotExist(err) {
+		return 0, nil
+	}
+	removed := 0
+	_, err := settingsEditor(settingsPath).EditCommands(filepath.Base(settingsPath), LedgerOwner, func(settings map[string]interface{}, recorded []string) []string {
+		match := managed.or(recorded)
+		RemoveHooksStanza(settings, func(command string) bool {
+			if match(command) {
+				removed++
+				return true
+			}
+			return false
+		})
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return removed, nil
+}
+
+// settingsEditor returns the settings editor for a settings file, which
+// keeps the user's ordering and comments, backs the file up and leaves an
+// unparseable file alone rather than overwriting it
+func settingsEditor(settingsPath string) *claudesettings.Editor {
+	return claudesettings.NewEditor(filepath.Dir(settingsPath))
+}
+
+// or extends m to the recorded commands
+func (m CommandMatcher) or(recorded []string) CommandMatcher {
+	commands := make(map[string]bool, len(recorded))
+	for _, command := range recorded {
+		commands[filepath.Clean(command)] = true
+	}
+	return func(command string) bool {
+		return commands[filepath.Clean(command)] || m(command)
+	}
+}
+
+// managedCommands returns a matcher for the commands of the given hooks,
+// and for their scripts in each of scriptDirs, the other places crew puts
+// them. Commands are compared as whole paths, so a user's own script that
+// shares a name with a crew hook is left alone.
+func managedCommands(hooks []*Hook, scriptDirs ...string) CommandMatcher {
+	commands := make(map[string]bool)
+	for _, hook := range hooks {
+		commands[filepath.Clean(hook.Command)] = true
+		for _, dir := range scriptDirs {
+			commands[filepath.Join(dir, filepath.Base(hook.Command))] = true
+		}
+	}
+
+	return func(command string) bool {
+		return commands[filepath.Clean(command)]
+	}
+}
+
+// globalManagedHooks returns the hooks crew manages in the user settings
+func (hm *HookManager) globalManagedHooks() []*Hook {
+	var managed []*Hook
+	for _, hook := range hm.globalHooks {
+		if hook.Origin != OriginProject {
+			managed = append(managed, hook)
+		}
+	}
+	return managed
+}
+
+// managedCommands matches the commands of hooks wherever crew registers
+// them: in the manager's hooks directory and in the installation's
+func (hm *HookManager) managedCommands(hooks []*Hook) CommandMatcher {
+	return managedCommands(hooks, hm.installedHooksDir())
+}
+
+// RemoveFromClaudeSettings removes every crew-managed hook entry from the
+// given Claude settings file, leaving user-defined hooks in place, and
+// returns how many it removed
+func (hm *HookManager) RemoveFromClaudeSettings(settingsPath string) (int, error) {
+	return RemoveFromSettingsFile(settingsPath, hm.managedCommands(hm.globalManagedHooks()))
+}
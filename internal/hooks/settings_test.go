@@ -0,0 +1,214 @@
+package hooks
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const userSettings = `{
+  "model": "opus",
+  "hooks": {
+    "PostToolUse": [
+      {
+        "matcher": "Write|Edit|MultiEdit",
+        "hooks": [
+          {"type": "command", "command": "/usr/local/bin/my-formatter"},
+          {"type": "command", "command": "/home/me/bin/lint-on-save.sh"},
+          {"type": "command", "command": "INSTALL/hooks/lint-on-save.sh"}
+        ]
+      }
+    ],
+    "Stop": [
+      {"matcher": "", "hooks": [{"type": "command", "command": "notify-send done"}]}
+    ]
+  }
+}`
+
+// writeSettings writes userSettings with its crew entry in installDir
+func writeSettings(t *testing.T, path, installDir string) {
+	t.Helper()
+	data := strings.ReplaceAll(userSettings, "INSTALL", installDir)
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatalf("Failed to write settings: %v", err)
+	}
+}
+
+func readSettings(t *testing.T, path string) map[string]interface{} {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read settings: %v", err)
+	}
+	var settings map[string]interface{}
+	if err := json.Unmarshal(data, &settings); err != nil {
+		t.Fatalf("Invalid settings JSON: %v", err)
+	}
+	return settings
+}
+
+// commandsFor returns the commands registered for a hook type and matcher
+func commandsFor(settings map[string]interface{}, hookType, matcher string) []string {
+	var commands []string
+	stanza, _ := settings["hooks"].(map[string]interface{})
+	groups, _ := stanza[hookType].([]interface{})
+	for _, g := range groups {
+		group := g.(map[string]interface{})
+		if group["matcher"] != matcher {
+			continue
+		}
+		for _, h := range group["hooks"].([]interface{}) {
+			commands = append(commands, h.(map[string]interface{})["command"].(string))
+		}
+	}
+	return commands
+}
+
+func TestUpdateSettingsFileMergesUserEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings.json")
+	writeSettings(t, path, "/crew")
+
+	lint := &Hook{Name: "lint-on-save", Type: PostToolUse, Matcher: "Write|Edit|MultiEdit", Command: "/crew/hooks/lint-on-save.sh"}
+	backup := &Hook{Name: "backup-before-change", Type: PreToolUse, Matcher: "Write", Command: "/crew/hooks/backup-before-change.sh",
+		Config: map[string]string{"SUPERCREW_BACKUP_DAYS": "7"}}
+	managed := managedCommands([]*Hook{lint, backup})
+
+	// Running twice must not duplicate entries
+	for i := 0; i < 2; i++ {
+		if err := UpdateSettingsFile(path, managed, []*Hook{lint, backup}); err != nil {
+			t.Fatalf("UpdateSettingsFile failed: %v", err)
+		}
+	}
+
+	settings := readSettings(t, path)
+	if settings["model"] != "opus" {
+		t.Errorf("Expected unrelated settings to be preserved, got %v", settings["model"])
+	}
+
+	post := commandsFor(settings, "PostToolUse", "Write|Edit|MultiEdit")
+	if len(post) != 3 || post[0] != "/usr/local/bin/my-formatter" || post[1] != "/home/me/bin/lint-on-save.sh" || post[2] != lint.Command {
+		t.Errorf("Expected the user hooks followed by crew lint hook, got %v", post)
+	}
+	if got := commandsFor(settings, "Stop", ""); len(got) != 1 || got[0] != "notify-send done" {
+		t.Errorf("Expected user Stop hook to be preserved, got %v", got)
+	}
+	if got := commandsFor(settings, "PreToolUse", "Write"); len(got) != 1 || got[0] != backup.Command {
+		t.Errorf("Expected crew PreToolUse hook, got %v", got)
+	}
+
+	// Disabling everything leaves only the user entries
+	if err := UpdateSettingsFile(path, managed, nil); err != nil {
+		t.Fatalf("UpdateSettingsFile failed: %v", err)
+	}
+	settings = readSettings(t, path)
+	if got := commandsFor(settings, "PostToolUse", "Write|Edit|MultiEdit"); len(got) != 2 {
+		t.Errorf("Expected only the user hooks, got %v", got)
+	}
+	if _, ok := settings["hooks"].(map[string]interface{})["PreToolUse"]; ok {
+		t.Error("Expected empty PreToolUse stanza to be pruned")
+	}
+}
+
+func TestRemoveFromSettingsFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "settings.json")
+
+	// A missing file is left missing
+	if _, err := RemoveFromSettingsFile(path, func(string) bool { return true }); err != nil {
+		t.Fatalf("RemoveFromSettingsFile on missing file failed: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("Expected no settings file to be created")
+	}
+
+	writeSettings(t, path, dir)
+
+	hm := NewHookManager(dir)
+	hm.SetInstallDir(dir)
+	hm.SetProjectOverlay(false)
+	if err := hm.DiscoverHooks(); err != nil {
+		t.Fatalf("DiscoverHooks failed: %v", err)
+	}
+	if removed, err := hm.RemoveFromClaudeSettings(path); err != nil || removed != 1 {
+		t.Fatalf("RemoveFromClaudeSettings = %d, %v; want 1 entry removed", removed, err)
+	}
+
+	settings := readSettings(t, path)
+	if got := commandsFor(settings, "PostToolUse", "Write|Edit|MultiEdit"); len(got) != 2 || got[1] != "/home/me/bin/lint-on-save.sh" {
+		t.Errorf("Expected installed crew hook removed and user hooks kept, got %v", got)
+	}
+	if got := commandsFor(settings, "Stop", ""); len(got) != 1 {
+		t.Errorf("Expected user Stop hook to be preserved, got %v", got)
+	}
+}
+
+func TestRemoveEnabledProjectHook(t *testing.T) {
+	installDir := t.TempDir()
+	projectRoot := t.TempDir()
+	path := filepath.Join(installDir, "settings.json")
+	writeSettings(t, path, installDir)
+
+	// crew hooks --enable registers the script from the project
+	scriptDir := filepath.Join(projectRoot, "SuperCrew", "Hooks")
+	if err := os.MkdirAll(scriptDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	script := filepath.Join(scriptDir, "git-auto-commit.sh")
+	if err := os.WriteFile(script, []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	hm := NewHookManager(projectRoot)
+	hm.SetInstallDir(installDir)
+	hm.SetProjectOverlay(false)
+	if err := hm.DiscoverHooks(); err != nil {
+		t.Fatalf("DiscoverHooks failed: %v", err)
+	}
+	if err := hm.EnableHook("git-auto-commit"); err != nil {
+		t.Fatalf("EnableHook failed: %v", err)
+	}
+	// The enabled lint-on-save of the fixture moves to the project's script
+	got := commandsFor(readSettings(t, path), "PostToolUse", "Write|Edit|MultiEdit")
+	if len(got) != 4 || got[2] != script || got[3] != filepath.Join(scriptDir, "lint-on-save.sh") {
+		t.Fatalf("Expected the project's scripts registered, got %v", got)
+	}
+
+	// Removal knows nothing of the project, as for crew uninstall
+	remover := NewHookManager("")
+	remover.SetInstallDir(installDir)
+	remover.SetProjectOverlay(false)
+	if err := remover.DiscoverHooks(); err != nil {
+		t.Fatalf("DiscoverHooks failed: %v", err)
+	}
+	removed, err := remover.RemoveFromClaudeSettings(path)
+	if err != nil {
+		t.Fatalf("RemoveFromClaudeSettings failed: %v", err)
+	}
+	if removed != 2 {
+		t.Errorf("Expected 2 entries removed, got %d", removed)
+	}
+	got = commandsFor(readSettings(t, path), "PostToolUse", "Write|Edit|MultiEdit")
+	if len(got) != 2 || got[0] != "/usr/local/bin/my-formatter" || got[1] != "/home/me/bin/lint-on-save.sh" {
+		t.Errorf("Expected only the user hooks left, got %v", got)
+	}
+
+	// Nothing is left to remove the second time
+	if removed, err := remover.RemoveFromClaudeSettings(path); err != nil || removed != 0 {
+		t.Errorf("second RemoveFromClaudeSettings = %d, %v; want 0", removed, err)
+	}
+}
+
+func TestUpdateSettingsFileRejectsInvalidJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0644); err != nil {
+		t.Fatalf("Failed to write settings: %v", err)
+	}
+
+	if err := UpdateSettingsFile(path, func(string) bool { return false }, nil); err == nil {
+		t.Fatal("Expected an error for invalid settings JSON")
+	}
+	if data, _ := os.ReadFile(path); string(data) != "{not json" {
+		t.Error("Expected invalid settings file to be left untouched")
+	}
+}
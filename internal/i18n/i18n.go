@@ -0,0 +1,152 @@
+// Package i18n holds the message catalogs for user-facing CLI text and
+// resolves messages for the active locale, falling back to English.
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DefaultLocale is used when no catalog matches the requested locale
+const DefaultLocale = "en"
+
+//go:embed catalogs/*.json
+var catalogFS embed.FS
+
+var (
+	mu       sync.RWMutex
+	catalogs map[string]map[string]string
+	locale   = DefaultLocale
+	loadOnce sync.Once
+	loadErr  error
+)
+
+// load parses the embedded catalogs once
+func load() {
+	loadOnce.Do(func() {
+		catalogs = map[string]map[string]string{}
+		entries, err := catalogFS.ReadDir("catalogs")
+		if err != nil {
+			loadErr = err
+			return
+		}
+		for _, entry := range entries {
+			data, err := catalogFS.ReadFile(path.Join("catalogs", entry.Name()))
+			if err != nil {
+				loadErr = err
+				return
+			}
+			messages := map[string]string{}
+			if err := json.Unmarshal(data, &messages); err != nil {
+				loadErr = fmt.Errorf("invalid catalog %s: %w", entry.Name(), err)
+				return
+			}
+			catalogs[strings.TrimSuffix(entry.Name(), ".json")] = messages
+		}
+	})
+}
+
+// Available returns the locales that have a catalog, sorted
+func Available() []string {
+	load()
+	locales := make([]string, 0, len(catalogs))
+	for name := range catalogs {
+		locales = append(locales, name)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// Normalize reduces a locale such as "es_ES.UTF-8" or "ja-JP" to the
+// language code used for catalogs. "C" and "POSIX" map to English.
+func Normalize(tag string) string {
+	tag = strings.TrimSpace(tag)
+	if i := strings.IndexAny(tag, ".@"); i >= 0 {
+		tag = tag[:i]
+	}
+	if i := strings.IndexAny(tag, "_-"); i >= 0 {
+		tag = tag[:i]
+	}
+	tag = strings.ToLower(tag)
+	if tag == "c" || tag == "posix" {
+		return DefaultLocale
+	}
+	return tag
+}
+
+// Detect picks the locale from CREW_LOCALE, LC_ALL, LC_MESSAGES or LANG,
+// in that order
+func Detect() string {
+	for _, name := range []string{"CREW_LOCALE", "LC_ALL", "LC_MESSAGES", "LANG"} {
+		if value := os.Getenv(name); value != "" {
+			return Normalize(value)
+		}
+	}
+	return DefaultLocale
+}
+
+// SetLocale selects the catalog used by T. Unknown locales fall back to
+// English and are reported as an error so callers can warn about them.
+func SetLocale(tag string) error {
+	load()
+	if loadErr != nil {
+		return loadErr
+	}
+
+	normalized := Normalize(tag)
+	mu.Lock()
+	defer mu.Unlock()
+	if _, ok := catalogs[normalized]; !ok {
+		locale = DefaultLocale
+		return fmt.Errorf("unsupported locale %q (available: %s)", tag, strings.Join(Available(), ", "))
+	}
+	locale = normalized
+	return nil
+}
+
+// Locale returns the active locale
+func Locale() string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return locale
+}
+
+// lookup finds key in the active catalog, then in English
+func lookup(key string) (string, bool) {
+	load()
+	mu.RLock()
+	defer mu.RUnlock()
+	if message, ok := catalogs[locale][key]; ok {
+		return message, true
+	}
+	message, ok := catalogs[DefaultLocale][key]
+	return message, ok
+}
+
+// T returns the message for key in the active locale, formatted with args.
+// A key missing from every catalog is returned as is so gaps stay visible.
+func T(key string, args ...interface{}) string {
+	message, ok := lookup(key)
+	if !ok {
+		message = key
+	}
+	if len(args) == 0 {
+		return message
+	}
+	return fmt.Sprintf(message, args...)
+}
+
+// Text translates s when it is a catalog key and returns it unchanged
+// otherwise, so display helpers accept both keys and literal text
+func Text(s string) string {
+	if message, ok := lookup(s); ok {
+		return message
+	}
+	return s
+}
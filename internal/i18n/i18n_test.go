@@ -0,0 +1,77 @@
+package i18n
+
+import (
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := map[string]string{
+		"es_ES.UTF-8": "es",
+		"ja-JP":       "ja",
+		"EN":          "en",
+		"C":           "en",
+		"POSIX":       "en",
+		"de_DE@euro":  "de",
+	}
+	for input, expected := range tests {
+		if got := Normalize(input); got != expected {
+			t.Errorf("Normalize(%q) = %q, expected %q", input, got, expected)
+		}
+	}
+}
+
+func TestSetLocale(t *testing.T) {
+	defer SetLocale(DefaultLocale)
+
+	if err := SetLocale("es_MX.UTF-8"); err != nil {
+		t.Fatalf("SetLocale failed: %v", err)
+	}
+	if Locale() != "es" {
+		t.Errorf("Expected locale es, got %s", Locale())
+	}
+	if got := T("update.confirm"); got != "¿Continuar con la actualización?" {
+		t.Errorf("Unexpected Spanish message: %q", got)
+	}
+
+	if err := SetLocale("xx"); err == nil {
+		t.Error("Expected an error for an unsupported locale")
+	}
+	if Locale() != DefaultLocale {
+		t.Errorf("Expected fallback to %s, got %s", DefaultLocale, Locale())
+	}
+}
+
+func TestT(t *testing.T) {
+	defer SetLocale(DefaultLocale)
+	SetLocale("ja")
+
+	if got := T("ui.menu.single_hint", 4); got != "番号を選択してください (1-4):" {
+		t.Errorf("Unexpected formatted message: %q", got)
+	}
+	if got := T("missing.key"); got != "missing.key" {
+		t.Errorf("Expected a missing key to be returned as is, got %q", got)
+	}
+	if got := Text("Plain text"); got != "Plain text" {
+		t.Errorf("Expected literal text to pass through, got %q", got)
+	}
+}
+
+func TestCatalogsComplete(t *testing.T) {
+	load()
+	if loadErr != nil {
+		t.Fatalf("Failed to load catalogs: %v", loadErr)
+	}
+	english := catalogs[DefaultLocale]
+	for _, name := range Available() {
+		for key := range english {
+			if _, ok := catalogs[name][key]; !ok {
+				t.Errorf("Catalog %s is missing %q", name, key)
+			}
+		}
+		for key := range catalogs[name] {
+			if _, ok := english[key]; !ok {
+				t.Errorf("Catalog %s has %q, which is not in the %s catalog", name, key, DefaultLocale)
+			}
+		}
+	}
+}
@@ -1,11 +1,13 @@
 package installer
 
 import (
+	"context"
 	"fmt"
-	"io"
 	"os"
 	"path/filepath"
 
+	"github.com/jonwraymond/claude-code-super-crew/internal/crewdirs"
+	"github.com/jonwraymond/claude-code-super-crew/internal/fsys"
 	"github.com/jonwraymond/claude-code-super-crew/pkg/backup"
 	"github.com/jonwraymond/claude-code-super-crew/internal/core"
 	"github.com/jonwraymond/claude-code-super-crew/internal/managers"
@@ -16,57 +18,107 @@ import (
 type Installer struct {
 	installDir          string
 	dryRun              bool
+	fs                  fsys.FS
 	components          map[string]core.Component
 	installedComponents []string
 	updatedComponents   []string
 	failedComponents    []string
 	backupPath          string
 	settingsManager     *managers.SettingsManager
+	progress            ProgressReporter
 	logger              logger.Logger
 }
 
+// ProgressReporter receives per-component progress while the installer runs
+type ProgressReporter interface {
+	Start(name string)
+	Done(name string, err error)
+}
+
+// noProgress is the default reporter and ignores all progress
+type noProgress struct{}
+
+func (noProgress) Start(string)       {}
+func (noProgress) Done(string, error) {}
+
 // NewInstaller creates a new installer
 func NewInstaller(installDir string, dryRun bool) *Installer {
 	return &Installer{
 		installDir:          installDir,
 		dryRun:              dryRun,
+		fs:                  fsys.OS,
 		components:          make(map[string]core.Component),
 		installedComponents: []string{},
 		updatedComponents:   []string{},
 		failedComponents:    []string{},
 		settingsManager:     managers.NewSettingsManager(installDir),
+		progress:            noProgress{},
 		logger:              logger.GetLogger(),
 	}
 }
 
+// SetProgress sets the reporter notified as each component starts and ends
+func (i *Installer) SetProgress(progress ProgressReporter) {
+	i.progress = progress
+}
+
+// SetFS sets the filesystem the installation is in; nil restores the OS
+// filesystem. The installer backs the installation up and records
+// component versions in fs, and components that support it install their
+// files there too.
+func (i *Installer) SetFS(fs fsys.FS) {
+	i.fs = fsys.Or(fs)
+	i.settingsManager = managers.NewSettingsManagerFS(i.installDir, i.fs)
+	for _, comp := range i.components {
+		i.useFS(comp)
+	}
+}
+
 // RegisterComponents registers components with the installer
 func (i *Installer) RegisterComponents(components []core.Component) {
 	for _, comp := range components {
 		metadata := comp.GetMetadata()
 		i.components[metadata.Name] = comp
+		i.useFS(comp)
 	}
 }
 
-// InstallComponents installs the specified components
-func (i *Installer) InstallComponents(componentNames []string, config map[string]interface{}) bool {
+// useFS points comp at the installer's filesystem when that is not the OS
+// one, which components use by default
+func (i *Installer) useFS(comp core.Component) {
+	if i.fs == fsys.OS {
+		return
+	}
+	if c, ok := comp.(interface{ SetFS(fsys.FS) }); ok {
+		c.SetFS(i.fs)
+	}
+}
+
+// InstallComponents installs the specified components. A cancelled ctx
+// stops before the next component; the ones not reached count as failed.
+func (i *Installer) InstallComponents(ctx context.Context, componentNames []string, config map[string]interface{}) bool {
 	success := true
 
 	// Check if installation already exists and create backup
-	if _, err := os.Stat(i.installDir); err == nil && !i.dryRun {
+	if _, err := i.fs.Stat(i.installDir); err == nil && !i.dryRun {
 		// Installation exists, create backup regardless of config
 		i.logger.Info("Existing installation detected, creating backup...")
-		if err := i.createBackup("pre-install"); err != nil {
+		if err := i.createBackup(ctx, "pre-install"); err != nil {
 			i.logger.Warnf("Failed to create pre-install backup: %v", err)
 		}
 	} else if backup, ok := config["backup"].(bool); ok && backup && !i.dryRun {
 		// Create backup if explicitly requested
-		if err := i.createBackup("pre-install"); err != nil {
+		if err := i.createBackup(ctx, "pre-install"); err != nil {
 			i.logger.Warnf("Failed to create backup: %v", err)
 		}
 	}
 
 	// Install each component
 	for _, name := range componentNames {
+		if i.interrupted(ctx, name) {
+			success = false
+			continue
+		}
 		comp, ok := i.components[name]
 		if !ok {
 			i.logger.Errorf("Component %s not found", name)
@@ -92,7 +144,7 @@ func (i *Installer) InstallComponents(componentNames []string, config map[string
 		}
 
 		// Install component
-		if err := comp.Install(i.installDir, config); err != nil {
+		if err := comp.Install(ctx, i.installDir, config); err != nil {
 			i.logger.Errorf("Installation failed for %s: %v", name, err)
 			i.failedComponents = append(i.failedComponents, name)
 			success = false
@@ -154,24 +206,32 @@ func (i *Installer) runPostInstallValidation() {
 	}
 }
 
-// UpdateComponents updates the specified components
-func (i *Installer) UpdateComponents(componentNames []string, config map[string]interface{}) bool {
+// UpdateComponents updates the specified components, stopping like
+// InstallComponents when ctx is cancelled
+func (i *Installer) UpdateComponents(ctx context.Context, componentNames []string, config map[string]interface{}) bool {
 	success := true
 
 	// Create backup if requested
 	if backup, ok := config["backup"].(bool); ok && backup && !i.dryRun {
-		if err := i.createBackup("pre-update"); err != nil {
+		if err := i.createBackup(ctx, "pre-update"); err != nil {
 			i.logger.Warnf("Failed to create backup: %v", err)
 		}
 	}
 
 	// Update each component
 	for _, name := range componentNames {
+		if i.interrupted(ctx, name) {
+			success = false
+			continue
+		}
+		i.progress.Start(name)
+
 		comp, ok := i.components[name]
 		if !ok {
 			i.logger.Errorf("Component %s not found", name)
 			i.failedComponents = append(i.failedComponents, name)
 			success = false
+			i.progress.Done(name, fmt.Errorf("component not found"))
 			continue
 		}
 
@@ -180,14 +240,16 @@ func (i *Installer) UpdateComponents(componentNames []string, config map[string]
 		if i.dryRun {
 			i.logger.Infof("[DRY RUN] Would update %s", name)
 			i.updatedComponents = append(i.updatedComponents, name)
+			i.progress.Done(name, nil)
 			continue
 		}
 
 		// Update component
-		if err := comp.Update(i.installDir, config); err != nil {
+		if err := comp.Update(ctx, i.installDir, config); err != nil {
 			i.logger.Errorf("Update failed for %s: %v", name, err)
 			i.failedComponents = append(i.failedComponents, name)
 			success = false
+			i.progress.Done(name, err)
 			continue
 		}
 
@@ -199,18 +261,20 @@ func (i *Installer) UpdateComponents(componentNames []string, config map[string]
 
 		i.updatedComponents = append(i.updatedComponents, name)
 		i.logger.Successf("Updated %s successfully", name)
+		i.progress.Done(name, nil)
 	}
 
 	return success
 }
 
-// UninstallComponents uninstalls the specified components
-func (i *Installer) UninstallComponents(componentNames []string, config map[string]interface{}) bool {
+// UninstallComponents uninstalls the specified components, stopping like
+// InstallComponents when ctx is cancelled
+func (i *Installer) UninstallComponents(ctx context.Context, componentNames []string, config map[string]interface{}) bool {
 	success := true
 
 	// Create backup if requested
 	if backup, ok := config["backup"].(bool); ok && backup && !i.dryRun {
-		if err := i.createBackup("pre-uninstall"); err != nil {
+		if err := i.createBackup(ctx, "pre-uninstall"); err != nil {
 			i.logger.Warnf("Failed to create backup: %v", err)
 		}
 	}
@@ -218,6 +282,10 @@ func (i *Installer) UninstallComponents(componentNames []string, config map[stri
 	// Uninstall in reverse order
 	for idx := len(componentNames) - 1; idx >= 0; idx-- {
 		name := componentNames[idx]
+		if i.interrupted(ctx, name) {
+			success = false
+			continue
+		}
 		comp, ok := i.components[name]
 		if !ok {
 			i.logger.Errorf("Component %s not found", name)
@@ -234,7 +302,7 @@ func (i *Installer) UninstallComponents(componentNames []string, config map[stri
 		}
 
 		// Uninstall component
-		if err := comp.Uninstall(i.installDir, config); err != nil {
+		if err := comp.Uninstall(ctx, i.installDir, config); err != nil {
 			i.logger.Errorf("Uninstall failed for %s: %v", name, err)
 			i.failedComponents = append(i.failedComponents, name)
 			success = false
@@ -265,9 +333,18 @@ func (i *Installer) GetUpdateSummary() map[string]interface{} {
 	}
 }
 
+// interrupted reports whether ctx is cancelled, recording name as failed
+func (i *Installer) interrupted(ctx context.Context, name string) bool {
+	if ctx.Err() == nil {
+		return false
+	}
+	i.failedComponents = append(i.failedComponents, name)
+	return true
+}
+
 // createBackup creates a backup of the installation
-func (i *Installer) createBackup(reason string) error {
-	backupsDir := filepath.Join(i.installDir, ".crew", "backups")
+func (i *Installer) createBackup(ctx context.Context, reason string) error {
+	backupsDir := crewdirs.Path(i.installDir, "backups")
 
 	// Create backup manager
 	mgr := backup.NewManager(backup.Options{
@@ -280,10 +357,11 @@ func (i *Installer) createBackup(reason string) error {
 		IncludeConfig: true,
 		IncludeLogs:   false,
 		Description:   reason,
+		FS:            i.fs,
 	})
 
 	// Create the backup
-	backupPath, err := mgr.Create()
+	backupPath, err := mgr.Create(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to create backup: %w", err)
 	}
@@ -293,77 +371,8 @@ func (i *Installer) createBackup(reason string) error {
 	return nil
 }
 
-// copyDir copies a directory recursively
-func copyDir(src, dst string) error {
-	// Get source directory info
-	srcInfo, err := os.Stat(src)
-	if err != nil {
-		return err
-	}
-
-	// Create destination directory
-	if err := os.MkdirAll(dst, srcInfo.Mode()); err != nil {
-		return err
-	}
-
-	// Read directory contents
-	entries, err := os.ReadDir(src)
-	if err != nil {
-		return err
-	}
-
-	// Copy each entry
-	for _, entry := range entries {
-		srcPath := filepath.Join(src, entry.Name())
-		dstPath := filepath.Join(dst, entry.Name())
-
-		if entry.IsDir() {
-			// Recursively copy directory
-			if err := copyDir(srcPath, dstPath); err != nil {
-				return err
-			}
-		} else {
-			// Copy file
-			if err := copyFile(srcPath, dstPath); err != nil {
-				return err
-			}
-		}
-	}
-
-	return nil
-}
-
-// copyFile copies a single file
-func copyFile(src, dst string) error {
-	srcFile, err := os.Open(src)
-	if err != nil {
-		return err
-	}
-	defer srcFile.Close()
-
-	// Get source file info
-	srcInfo, err := srcFile.Stat()
-	if err != nil {
-		return err
-	}
-
-	// Create destination file
-	dstFile, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, srcInfo.Mode())
-	if err != nil {
-		return err
-	}
-	defer dstFile.Close()
-
-	// Copy content
-	if _, err := io.Copy(dstFile, srcFile); err != nil {
-		return err
-	}
-
-	return nil
-}
-
 // createTarball creates a tar.gz archive from the source directory
-func createTarball(sourceDir, targetFile string) error {
+func createTarball(ctx context.Context, sourceDir, targetFile string) error {
 	// Use the backup package to create the tarball
 	mgr := backup.NewManager(backup.Options{
 		InstallDir: sourceDir,
@@ -374,7 +383,7 @@ func createTarball(sourceDir, targetFile string) error {
 	})
 
 	// Create the backup
-	backupPath, err := mgr.Create()
+	backupPath, err := mgr.Create(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to create tarball: %w", err)
 	}
@@ -0,0 +1,101 @@
+package installer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/core"
+	"github.com/jonwraymond/claude-code-super-crew/internal/crewdirs"
+	"github.com/jonwraymond/claude-code-super-crew/internal/fsys"
+	"github.com/jonwraymond/claude-code-super-crew/internal/metadata"
+)
+
+// fileComponent installs its files through the file manager of its base
+type fileComponent struct {
+	core.BaseComponent
+	files []core.FilePair
+}
+
+func newFileComponent(installDir string, files []core.FilePair) *fileComponent {
+	c := &fileComponent{files: files}
+	c.InstallDir = installDir
+	c.Metadata = core.ComponentMetadata{Name: "files", Version: "1.2.3"}
+	c.InitManagers(installDir)
+	return c
+}
+
+func (c *fileComponent) Install(ctx context.Context, installDir string, config map[string]interface{}) error {
+	for _, pair := range c.files {
+		if err := c.FileManager.CopyFileWithInventory(pair.Source, pair.Target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *fileComponent) Update(ctx context.Context, installDir string, config map[string]interface{}) error {
+	return c.Install(ctx, installDir, config)
+}
+
+func (c *fileComponent) Uninstall(ctx context.Context, installDir string, config map[string]interface{}) error {
+	for _, pair := range c.files {
+		if err := c.FileManager.RemoveFile(pair.Target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *fileComponent) Validate(installDir string) error { return nil }
+
+func (c *fileComponent) GetFilesToInstall() []core.FilePair { return c.files }
+
+func TestInstallComponentsMemFS(t *testing.T) {
+	mem := fsys.NewMemFS()
+	installDir := filepath.Join(t.TempDir(), ".claude")
+	source := filepath.Join(string(filepath.Separator), "src", "agent.md")
+	target := filepath.Join(installDir, "agents", "agent.md")
+	if err := mem.MkdirAll(filepath.Dir(source), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := mem.WriteFile(source, []byte("# Agent\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// An existing installation is backed up first
+	if err := mem.MkdirAll(installDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := mem.WriteFile(filepath.Join(installDir, "notes.md"), []byte("mine"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	inst := NewInstaller(installDir, false)
+	inst.SetFS(mem)
+	inst.RegisterComponents([]core.Component{newFileComponent(installDir, []core.FilePair{{Source: source, Target: target}})})
+	if !inst.InstallComponents(context.Background(), []string{"files"}, map[string]interface{}{}) {
+		t.Fatalf("InstallComponents failed: %v", inst.GetInstallationSummary())
+	}
+
+	if data, err := mem.ReadFile(target); err != nil || string(data) != "# Agent\n" {
+		t.Errorf("installed agent = %q, %v", data, err)
+	}
+	if backups, _ := mem.ReadDir(crewdirs.Path(installDir, "backups")); len(backups) == 0 {
+		t.Error("expected a pre-install backup in the MemFS")
+	}
+	meta, err := metadata.NewMetadataManagerFS(installDir, mem).LoadMetadata()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if meta.Components["files"].Version != "1.2.3" {
+		t.Errorf("recorded version = %q, want 1.2.3", meta.Components["files"].Version)
+	}
+	if len(meta.Inventory.CreatedFiles) != 1 || meta.Inventory.CreatedFiles[0] != filepath.Join("agents", "agent.md") {
+		t.Errorf("inventory = %v", meta.Inventory.CreatedFiles)
+	}
+
+	if _, err := os.Stat(installDir); !os.IsNotExist(err) {
+		t.Errorf("expected nothing written to disk, got %v", err)
+	}
+}
@@ -0,0 +1,227 @@
+package managers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFormat identifies the on-disk encoding of a configuration file
+type ConfigFormat string
+
+const (
+	// FormatJSON is the default configuration format
+	FormatJSON ConfigFormat = "json"
+	// FormatYAML is selected for .yaml and .yml files
+	FormatYAML ConfigFormat = "yaml"
+	// FormatTOML is selected for .toml files
+	FormatTOML ConfigFormat = "toml"
+)
+
+// configFileCandidates lists the config file names searched in a config
+// directory, in order of preference.
+var configFileCandidates = []string{
+	"config.json",
+	"config.yaml",
+	"config.yml",
+	"config.toml",
+}
+
+// DetectConfigFormat returns the format implied by a file's extension
+func DetectConfigFormat(path string) (ConfigFormat, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return FormatJSON, nil
+	case ".yaml", ".yml":
+		return FormatYAML, nil
+	case ".toml":
+		return FormatTOML, nil
+	default:
+		return "", fmt.Errorf("unsupported config file extension: %s", filepath.Ext(path))
+	}
+}
+
+// findConfigFile returns the first existing config file in configDir,
+// falling back to config.json when none exists yet.
+func findConfigFile(configDir string) string {
+	for _, name := range configFileCandidates {
+		path := filepath.Join(configDir, name)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return filepath.Join(configDir, configFileCandidates[0])
+}
+
+// decodeConfig parses data in the given format. The result is normalized
+// through JSON so numbers, nested maps and arrays have the same Go types
+// regardless of the source format, keeping validation and dot-path access
+// consistent.
+func decodeConfig(format ConfigFormat, data []byte) (map[string]interface{}, error) {
+	var raw map[string]interface{}
+
+	switch format {
+	case FormatJSON:
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return nil, fmt.Errorf("invalid JSON config: %w", err)
+		}
+		return raw, nil
+	case FormatYAML:
+		if err := yaml.Unmarshal(data, &raw); err != nil {
+			return nil, fmt.Errorf("invalid YAML config: %w", err)
+		}
+	case FormatTOML:
+		if _, err := toml.Decode(string(data), &raw); err != nil {
+			return nil, fmt.Errorf("invalid TOML config: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported config format: %s", format)
+	}
+
+	if raw == nil {
+		raw = make(map[string]interface{})
+	}
+
+	normalized, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to normalize %s config: %w", format, err)
+	}
+
+	var config map[string]interface{}
+	if err := json.Unmarshal(normalized, &config); err != nil {
+		return nil, fmt.Errorf("failed to normalize %s config: %w", format, err)
+	}
+
+	return config, nil
+}
+
+// encodeConfig serializes config in the given format. existing is the
+// current content of the file, if any: YAML is written over its document so
+// comments and key order survive, and a TOML file with comments is refused,
+// since the TOML encoder cannot keep them.
+func encodeConfig(format ConfigFormat, config map[string]interface{}, existing []byte) ([]byte, error) {
+	switch format {
+	case FormatJSON:
+		return json.MarshalIndent(config, "", "  ")
+	case FormatYAML:
+		var doc yaml.Node
+		if err := yaml.Unmarshal(existing, &doc); err != nil || len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+			doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{}}}
+		}
+		if err := updateYAMLNode(doc.Content[0], config); err != nil {
+			return nil, err
+		}
+		var buf bytes.Buffer
+		encoder := yaml.NewEncoder(&buf)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(&doc); err != nil {
+			return nil, err
+		}
+		if err := encoder.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	case FormatTOML:
+		if tomlHasComments(existing) {
+			return nil, fmt.Errorf("the TOML config has comments that saving would drop; edit it by hand, or convert it to YAML to have crew keep them")
+		}
+		var buf bytes.Buffer
+		if err := toml.NewEncoder(&buf).Encode(config); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	default:
+		return nil, fmt.Errorf("unsupported config format: %s", format)
+	}
+}
+
+// updateYAMLNode makes node hold value. Mapping keys that are kept stay in
+// place with their comments, removed ones are dropped and new ones are
+// appended in sorted order. Other values are only replaced when they
+// changed, keeping the comments attached to them.
+func updateYAMLNode(node *yaml.Node, value interface{}) error {
+	if m, ok := value.(map[string]interface{}); ok && node.Kind == yaml.MappingNode {
+		var content []*yaml.Node
+		seen := make(map[string]bool, len(m))
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, item := node.Content[i], node.Content[i+1]
+			v, ok := m[key.Value]
+			if !ok {
+				continue
+			}
+			if err := updateYAMLNode(item, v); err != nil {
+				return err
+			}
+			seen[key.Value] = true
+			content = append(content, key, item)
+		}
+
+		var added []string
+		for key := range m {
+			if !seen[key] {
+				added = append(added, key)
+			}
+		}
+		sort.Strings(added)
+		for _, key := range added {
+			item := &yaml.Node{}
+			if err := item.Encode(m[key]); err != nil {
+				return err
+			}
+			content = append(content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, item)
+		}
+		node.Content = content
+		return nil
+	}
+
+	if node.Kind != 0 && yamlNodeEquals(node, value) {
+		return nil
+	}
+	replacement := &yaml.Node{}
+	if err := replacement.Encode(value); err != nil {
+		return err
+	}
+	replacement.HeadComment = node.HeadComment
+	replacement.LineComment = node.LineComment
+	replacement.FootComment = node.FootComment
+	*node = *replacement
+	return nil
+}
+
+// yamlNodeEquals reports whether node decodes to value, comparing both in
+// their JSON form as decodeConfig normalizes them
+func yamlNodeEquals(node *yaml.Node, value interface{}) bool {
+	var current interface{}
+	if err := node.Decode(&current); err != nil {
+		return false
+	}
+	a, errA := json.Marshal(current)
+	b, errB := json.Marshal(value)
+	return errA == nil && errB == nil && bytes.Equal(a, b)
+}
+
+// tomlHasComments reports whether data has a # comment outside of strings
+func tomlHasComments(data []byte) bool {
+	var quote byte
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		switch {
+		case quote == 0 && c == '#':
+			return true
+		case quote == 0 && (c == '"' || c == '\''):
+			quote = c
+		case quote == '"' && c == '\\':
+			i++
+		case c == quote:
+			quote = 0
+		}
+	}
+	return false
+}
@@ -0,0 +1,147 @@
+package managers
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestConfigManagerFormats(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string
+		content  string
+		expected ConfigFormat
+	}{
+		{
+			name:     "json",
+			file:     "config.json",
+			content:  `{"log_level": "debug", "settings": {"retries": 3}}`,
+			expected: FormatJSON,
+		},
+		{
+			name:     "yaml",
+			file:     "config.yaml",
+			content:  "# commented config\nlog_level: debug\nsettings:\n  retries: 3\n",
+			expected: FormatYAML,
+		},
+		{
+			name:     "toml",
+			file:     "config.toml",
+			content:  "log_level = \"debug\"\nnote = 'a # in a string'\n\n[settings]\nretries = 3\n",
+			expected: FormatTOML,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(tempDir, tt.file), []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+
+			cm, err := NewConfigManager(tempDir, "")
+			if err != nil {
+				t.Fatalf("Failed to create config manager: %v", err)
+			}
+
+			if cm.GetFormat() != tt.expected {
+				t.Errorf("Expected format %s, got %s", tt.expected, cm.GetFormat())
+			}
+
+			level, err := cm.GetString("log_level")
+			if err != nil || level != "debug" {
+				t.Errorf("Expected log_level debug, got %q (%v)", level, err)
+			}
+
+			retries, err := cm.GetInt("settings.retries")
+			if err != nil || retries != 3 {
+				t.Errorf("Expected settings.retries 3, got %d (%v)", retries, err)
+			}
+
+			// Round-trip through Save and Load in the same format
+			if err := cm.Set("settings.theme", "light"); err != nil {
+				t.Fatalf("Failed to set value: %v", err)
+			}
+			if err := cm.Save(); err != nil {
+				t.Fatalf("Failed to save config: %v", err)
+			}
+			if err := cm.Load(); err != nil {
+				t.Fatalf("Failed to reload config: %v", err)
+			}
+			if theme, _ := cm.GetString("settings.theme"); theme != "light" {
+				t.Errorf("Expected settings.theme light after reload, got %q", theme)
+			}
+		})
+	}
+}
+
+func TestSaveKeepsYAMLComments(t *testing.T) {
+	tempDir := t.TempDir()
+	content := `# Crew settings
+log_level: debug # or info
+settings:
+  # Retried operations
+  retries: 3
+  theme: dark
+`
+	path := filepath.Join(tempDir, "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cm, err := NewConfigManager(tempDir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := cm.Set("settings.retries", 5); err != nil {
+		t.Fatal(err)
+	}
+	if err := cm.Set("settings.cache_max_mb", 64); err != nil {
+		t.Fatal(err)
+	}
+	if err := cm.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `# Crew settings
+log_level: debug # or info
+settings:
+  # Retried operations
+  retries: 5
+  theme: dark
+  cache_max_mb: 64
+`
+	if data, _ := os.ReadFile(path); string(data) != want {
+		t.Errorf("Expected comments and key order to survive:\n%s", data)
+	}
+}
+
+func TestSaveRefusesCommentedTOML(t *testing.T) {
+	tempDir := t.TempDir()
+	content := "# Crew settings\nlog_level = \"debug\"\n"
+	path := filepath.Join(tempDir, "config.toml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cm, err := NewConfigManager(tempDir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := cm.Set("log_level", "info"); err != nil {
+		t.Fatal(err)
+	}
+	if err := cm.Save(); err == nil {
+		t.Error("Expected saving a commented TOML config to be refused")
+	}
+	if data, _ := os.ReadFile(path); string(data) != content {
+		t.Errorf("Expected the TOML config to be left alone, got:\n%s", data)
+	}
+}
+
+func TestDetectConfigFormatRejectsUnknownExtension(t *testing.T) {
+	if _, err := DetectConfigFormat("config.ini"); err == nil {
+		t.Error("Expected error for unsupported extension")
+	}
+}
@@ -7,9 +7,12 @@ import (
 	"path/filepath"
 	"reflect"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-playground/validator/v10"
+	"github.com/jonwraymond/claude-code-super-crew/internal/fileops"
+	"github.com/jonwraymond/claude-code-super-crew/internal/secrets"
 )
 
 // Profile represents an installation profile
@@ -37,13 +40,18 @@ type Config struct {
 
 // ConfigManager provides advanced configuration management with JSON schema validation
 type ConfigManager struct {
-	configDir     string
-	configFile    string
-	schema        *ConfigSchema
-	validator     *validator.Validate
-	config        map[string]interface{}
-	lastModified  time.Time
-	watchEnabled  bool
+	configDir      string
+	configFile     string
+	format         ConfigFormat
+	schema         *ConfigSchema
+	validator      *validator.Validate
+	config         map[string]interface{}
+	lastModified   time.Time
+	watchEnabled   bool
+	profile        string
+	profileConfig  map[string]interface{}
+	secretResolver *secrets.Resolver
+	mu             sync.RWMutex
 }
 
 // ConfigSchema defines the structure and validation rules for configuration
@@ -71,13 +79,26 @@ type PropertySchema struct {
 	Description string                     `json:"description,omitempty"`
 }
 
-// NewConfigManager creates a new configuration manager
+// NewConfigManager creates a new configuration manager. An existing
+// config.json, config.yaml, config.yml or config.toml in configDir is used,
+// in that order; otherwise a new config.json is created.
 func NewConfigManager(configDir string, schemaPath string) (*ConfigManager, error) {
-	configFile := filepath.Join(configDir, "config.json")
+	return NewConfigManagerForFile(findConfigFile(configDir), schemaPath)
+}
+
+// NewConfigManagerForFile creates a configuration manager for an explicit
+// config file, with the format chosen by its extension
+func NewConfigManagerForFile(configFile string, schemaPath string) (*ConfigManager, error) {
+	format, err := DetectConfigFormat(configFile)
+	if err != nil {
+		return nil, err
+	}
+	configDir := filepath.Dir(configFile)
 	
 	cm := &ConfigManager{
 		configDir:  configDir,
 		configFile: configFile,
+		format:     format,
 		validator:  validator.New(),
 		config:     make(map[string]interface{}),
 	}
@@ -133,9 +154,14 @@ func (cm *ConfigManager) Load() error {
 		return err
 	}
 	
-	var config map[string]interface{}
-	if err := json.Unmarshal(data, &config); err != nil {
-		return fmt.Errorf("invalid JSON config: %w", err)
+	config, err := decodeConfig(cm.format, data)
+	if err != nil {
+		return err
+	}
+	
+	// Re-apply the active profile overlay, if any
+	if cm.profileConfig != nil {
+		config = cm.deepMerge(config, cm.deepCopy(cm.profileConfig))
 	}
 	
 	// Validate against schema if available
@@ -145,7 +171,9 @@ func (cm *ConfigManager) Load() error {
 		}
 	}
 	
+	cm.mu.Lock()
 	cm.config = config
+	cm.mu.Unlock()
 	
 	// Update last modified time
 	if info, err := os.Stat(cm.configFile); err == nil {
@@ -157,6 +185,10 @@ func (cm *ConfigManager) Load() error {
 
 // Save saves configuration to file
 func (cm *ConfigManager) Save() error {
+	if cm.profile != "" {
+		return fmt.Errorf("cannot save configuration while profile %q is active", cm.profile)
+	}
+	
 	// Validate before saving
 	if cm.schema != nil {
 		if err := cm.validateConfig(cm.config); err != nil {
@@ -164,7 +196,11 @@ func (cm *ConfigManager) Save() error {
 		}
 	}
 	
-	data, err := json.MarshalIndent(cm.config, "", "  ")
+	existing, err := os.ReadFile(cm.configFile)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	data, err := encodeConfig(cm.format, cm.config, existing)
 	if err != nil {
 		return err
 	}
@@ -172,7 +208,7 @@ func (cm *ConfigManager) Save() error {
 	// Create backup of existing config
 	if _, err := os.Stat(cm.configFile); err == nil {
 		backupFile := cm.configFile + ".backup"
-		if err := copyFile(cm.configFile, backupFile); err != nil {
+		if err := fileops.CopyFile(cm.configFile, backupFile); err != nil {
 			// Log warning but don't fail
 			fmt.Printf("Warning: failed to create config backup: %v\n", err)
 		}
@@ -190,6 +226,8 @@ func (cm *ConfigManager) Save() error {
 
 // Get retrieves a configuration value by key path (dot notation supported)
 func (cm *ConfigManager) Get(keyPath string) (interface{}, error) {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
 	return cm.getNestedValue(cm.config, keyPath)
 }
 
@@ -275,11 +313,15 @@ func (cm *ConfigManager) Set(keyPath string, value interface{}) error {
 		}
 	}
 	
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
 	return cm.setNestedValue(cm.config, keyPath, value)
 }
 
 // Delete removes a configuration value by key path
 func (cm *ConfigManager) Delete(keyPath string) error {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
 	return cm.deleteNestedValue(cm.config, keyPath)
 }
 
@@ -291,6 +333,8 @@ func (cm *ConfigManager) Has(keyPath string) bool {
 
 // GetAll returns all configuration data
 func (cm *ConfigManager) GetAll() map[string]interface{} {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
 	return cm.deepCopy(cm.config)
 }
 
@@ -355,12 +399,12 @@ func (cm *ConfigManager) Reload() error {
 
 // Backup creates a backup of the current configuration
 func (cm *ConfigManager) Backup(backupPath string) error {
-	return copyFile(cm.configFile, backupPath)
+	return fileops.CopyFile(cm.configFile, backupPath)
 }
 
 // Restore restores configuration from backup
 func (cm *ConfigManager) Restore(backupPath string) error {
-	if err := copyFile(backupPath, cm.configFile); err != nil {
+	if err := fileops.CopyFile(backupPath, cm.configFile); err != nil {
 		return err
 	}
 	
@@ -372,6 +416,11 @@ func (cm *ConfigManager) GetConfigPath() string {
 	return cm.configFile
 }
 
+// GetFormat returns the format of the configuration file
+func (cm *ConfigManager) GetFormat() ConfigFormat {
+	return cm.format
+}
+
 // GetConfigDir returns the configuration directory
 func (cm *ConfigManager) GetConfigDir() string {
 	return cm.configDir
@@ -708,9 +757,10 @@ func (cm *ConfigManager) LoadProfile(profilePath string) (*Profile, error) {
 	return &profile, nil
 }
 
-// GetRequirementsForComponents gets requirements for specific components
-func (cm *ConfigManager) GetRequirementsForComponents(components []string) map[string]map[string]string {
-	// Default requirements
+// DefaultRequirements returns the built-in tool requirements for the
+// given components. Values are semver ranges, or "latest"/"required" when
+// the tool only has to be present.
+func DefaultRequirements(components []string) map[string]map[string]string {
 	reqs := map[string]map[string]string{
 		"global": {
 			"go": ">=1.20",
@@ -734,6 +784,34 @@ func (cm *ConfigManager) GetRequirementsForComponents(components []string) map[s
 	return reqs
 }
 
+// GetRequirementsForComponents gets requirements for specific components.
+// A "requirements" section in the config ({"global": {...}, "components":
+// {"mcp": {...}}}) overrides or adds to the defaults.
+func (cm *ConfigManager) GetRequirementsForComponents(components []string) map[string]map[string]string {
+	reqs := DefaultRequirements(components)
+
+	overlay := func(scope string, values map[string]interface{}) {
+		if reqs[scope] == nil {
+			reqs[scope] = make(map[string]string)
+		}
+		for tool, constraint := range values {
+			if s, ok := constraint.(string); ok {
+				reqs[scope][tool] = s
+			}
+		}
+	}
+	if global, err := cm.GetObject("requirements.global"); err == nil {
+		overlay("global", global)
+	}
+	for _, comp := range components {
+		if values, err := cm.GetObject("requirements.components." + comp); err == nil {
+			overlay(comp, values)
+		}
+	}
+	
+	return reqs
+}
+
 // ValidateConfigFiles validates configuration files
 func (cm *ConfigManager) ValidateConfigFiles() []string {
 	errors := []string{}
@@ -817,13 +895,3 @@ func (cm *ConfigManager) GetDefaultSettings() map[string]interface{} {
 		"theme":           "dark",
 	}
 }
-
-// Utility function to copy files
-func copyFile(src, dst string) error {
-	data, err := os.ReadFile(src)
-	if err != nil {
-		return err
-	}
-	
-	return os.WriteFile(dst, data, 0644)
-}
\ No newline at end of file
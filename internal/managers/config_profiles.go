@@ -0,0 +1,116 @@
+package managers
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ConfigProfilesDirName is the directory under the config directory that
+// holds named configuration profiles
+const ConfigProfilesDirName = "profiles"
+
+// GetProfilesDir returns the directory holding named configuration profiles
+func (cm *ConfigManager) GetProfilesDir() string {
+	return filepath.Join(cm.configDir, ConfigProfilesDirName)
+}
+
+// ListConfigProfiles returns the names of available configuration profiles
+func (cm *ConfigManager) ListConfigProfiles() ([]string, error) {
+	entries, err := os.ReadDir(cm.GetProfilesDir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []string{}, nil
+		}
+		return nil, fmt.Errorf("failed to read profiles directory: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	profiles := []string{}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		if _, err := DetectConfigFormat(entry.Name()); err != nil {
+			continue
+		}
+		name := strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name()))
+		if !seen[name] {
+			seen[name] = true
+			profiles = append(profiles, name)
+		}
+	}
+
+	sort.Strings(profiles)
+	return profiles, nil
+}
+
+// findProfileFile locates the file for a named profile in any supported format
+func (cm *ConfigManager) findProfileFile(name string) (string, error) {
+	if name == "" || strings.ContainsAny(name, `/\`) || name == "." || name == ".." {
+		return "", fmt.Errorf("invalid config profile name: %q", name)
+	}
+
+	for _, candidate := range configFileCandidates {
+		path := filepath.Join(cm.GetProfilesDir(), name+filepath.Ext(candidate))
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+
+	available, _ := cm.ListConfigProfiles()
+	if len(available) == 0 {
+		return "", fmt.Errorf("config profile %q not found (no profiles in %s)", name, cm.GetProfilesDir())
+	}
+	return "", fmt.Errorf("config profile %q not found (available: %s)", name, strings.Join(available, ", "))
+}
+
+// ApplyConfigProfile overlays a named profile on top of the base
+// configuration. The overlay is kept across Load and Reload, and the merged
+// result is validated against the schema. Profiles are read-only presets, so
+// Save is refused while one is active.
+func (cm *ConfigManager) ApplyConfigProfile(name string) error {
+	path, err := cm.findProfileFile(name)
+	if err != nil {
+		return err
+	}
+
+	format, err := DetectConfigFormat(path)
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config profile %s: %w", name, err)
+	}
+
+	overlay, err := decodeConfig(format, data)
+	if err != nil {
+		return fmt.Errorf("failed to parse config profile %s: %w", name, err)
+	}
+
+	merged := cm.deepMerge(cm.GetAll(), cm.deepCopy(overlay))
+	if cm.schema != nil {
+		if err := cm.validateConfig(merged); err != nil {
+			return fmt.Errorf("config profile %s failed validation: %w", name, err)
+		}
+	}
+
+	cm.mu.Lock()
+	cm.config = merged
+	cm.profile = name
+	cm.profileConfig = overlay
+	cm.mu.Unlock()
+
+	return nil
+}
+
+// ActiveProfile returns the name of the applied configuration profile, if any
+func (cm *ConfigManager) ActiveProfile() string {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+	return cm.profile
+}
@@ -0,0 +1,70 @@
+package managers
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestApplyConfigProfile(t *testing.T) {
+	tempDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tempDir, "config.json"), []byte(`{"log_level": "info", "auto_update": false}`), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	profilesDir := filepath.Join(tempDir, ConfigProfilesDirName)
+	if err := os.MkdirAll(profilesDir, 0755); err != nil {
+		t.Fatalf("Failed to create profiles dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(profilesDir, "aggressive-updates.yaml"), []byte("auto_update: true\n"), 0644); err != nil {
+		t.Fatalf("Failed to write profile: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(profilesDir, "conservative.json"), []byte(`{"log_level": "warn"}`), 0644); err != nil {
+		t.Fatalf("Failed to write profile: %v", err)
+	}
+
+	cm, err := NewConfigManager(tempDir, "")
+	if err != nil {
+		t.Fatalf("Failed to create config manager: %v", err)
+	}
+
+	profiles, err := cm.ListConfigProfiles()
+	if err != nil {
+		t.Fatalf("Failed to list profiles: %v", err)
+	}
+	if len(profiles) != 2 || profiles[0] != "aggressive-updates" || profiles[1] != "conservative" {
+		t.Errorf("Unexpected profiles: %v", profiles)
+	}
+
+	if err := cm.ApplyConfigProfile("aggressive-updates"); err != nil {
+		t.Fatalf("Failed to apply profile: %v", err)
+	}
+	if enabled, _ := cm.GetBool("auto_update"); !enabled {
+		t.Error("Expected profile to enable auto_update")
+	}
+	if level, _ := cm.GetString("log_level"); level != "info" {
+		t.Errorf("Expected base log_level to be kept, got %q", level)
+	}
+	if cm.ActiveProfile() != "aggressive-updates" {
+		t.Errorf("Unexpected active profile: %q", cm.ActiveProfile())
+	}
+
+	// Overlay survives a reload of the base file
+	if err := cm.Load(); err != nil {
+		t.Fatalf("Failed to reload: %v", err)
+	}
+	if enabled, _ := cm.GetBool("auto_update"); !enabled {
+		t.Error("Expected profile overlay to survive reload")
+	}
+
+	if err := cm.Save(); err == nil {
+		t.Error("Expected Save to be refused while a profile is active")
+	}
+
+	if err := cm.ApplyConfigProfile("missing"); err == nil {
+		t.Error("Expected error for unknown profile")
+	}
+	if err := cm.ApplyConfigProfile("../config"); err == nil {
+		t.Error("Expected error for profile name with path separator")
+	}
+}
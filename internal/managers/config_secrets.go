@@ -0,0 +1,51 @@
+package managers
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/secrets"
+)
+
+// SecretsFileName is the encrypted secrets file kept alongside the config
+const SecretsFileName = "secrets.enc"
+
+// SetSecretResolver overrides the resolver used for secret:// values
+func (cm *ConfigManager) SetSecretResolver(resolver *secrets.Resolver) {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	cm.secretResolver = resolver
+}
+
+// SecretStore returns the encrypted file secret://file/<key> references
+// are resolved from. Its passphrase comes from $CREW_SECRETS_PASSPHRASE.
+func (cm *ConfigManager) SecretStore() *secrets.EncryptedFileProvider {
+	return secrets.NewEncryptedFileProvider(filepath.Join(cm.configDir, SecretsFileName), "")
+}
+
+// getSecretResolver returns the configured resolver, creating the default
+// env/keychain/file resolver on first use
+func (cm *ConfigManager) getSecretResolver() *secrets.Resolver {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	if cm.secretResolver == nil {
+		cm.secretResolver = secrets.NewDefaultResolver(filepath.Join(cm.configDir, SecretsFileName))
+	}
+	return cm.secretResolver
+}
+
+// GetSecret retrieves a string value by key path, resolving it when it is a
+// secret:// reference. Plain values are returned as-is, so settings can move
+// into a secret store without changing callers.
+func (cm *ConfigManager) GetSecret(keyPath string) (string, error) {
+	value, err := cm.GetString(keyPath)
+	if err != nil {
+		return "", err
+	}
+
+	resolved, err := cm.getSecretResolver().Resolve(value)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve secret for %s: %w", keyPath, err)
+	}
+	return resolved, nil
+}
@@ -0,0 +1,244 @@
+package managers
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/jonwraymond/claude-code-super-crew/pkg/logger"
+)
+
+// defaultWatchDebounce coalesces the burst of events editors emit on save
+const defaultWatchDebounce = 200 * time.Millisecond
+
+// ConfigChangeHandler is called after a modified config file has been
+// reloaded and validated. It receives copies of the previous and current
+// configuration.
+type ConfigChangeHandler func(previous, current map[string]interface{}) error
+
+// ConfigWatcher reloads a ConfigManager whenever its config file changes on
+// disk. It is intended for long-running operations such as watch modes and
+// schedulers; one-shot commands should keep using ConfigManager directly.
+type ConfigWatcher struct {
+	cm       *ConfigManager
+	watcher  *fsnotify.Watcher
+	debounce time.Duration
+	handlers []ConfigChangeHandler
+	onError  func(error)
+	done     chan struct{}
+	wg       sync.WaitGroup
+	mu       sync.Mutex
+}
+
+// NewConfigWatcher creates a watcher for the given configuration manager
+func NewConfigWatcher(cm *ConfigManager) *ConfigWatcher {
+	return &ConfigWatcher{
+		cm:       cm,
+		debounce: defaultWatchDebounce,
+		onError: func(err error) {
+			logger.GetLogger().Warnf("Config reload failed: %v", err)
+		},
+	}
+}
+
+// SetDebounce overrides the delay used to coalesce rapid file events
+func (w *ConfigWatcher) SetDebounce(d time.Duration) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.debounce = d
+}
+
+// OnChange registers a handler invoked after each successful reload
+func (w *ConfigWatcher) OnChange(handler ConfigChangeHandler) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.handlers = append(w.handlers, handler)
+}
+
+// OnError registers a handler for reload and handler failures. A failed
+// reload leaves the previously loaded configuration in place.
+func (w *ConfigWatcher) OnError(handler func(error)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.onError = handler
+}
+
+// Start begins watching the config file. The containing directory is watched
+// rather than the file itself so atomic rename-on-save is picked up.
+func (w *ConfigWatcher) Start() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.watcher != nil {
+		return fmt.Errorf("config watcher already started")
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create file watcher: %w", err)
+	}
+
+	if err := watcher.Add(w.cm.GetConfigDir()); err != nil {
+		watcher.Close()
+		return fmt.Errorf("failed to watch config directory: %w", err)
+	}
+
+	w.watcher = watcher
+	w.done = make(chan struct{})
+	w.cm.watchEnabled = true
+
+	w.wg.Add(1)
+	go w.loop(watcher, w.done)
+
+	return nil
+}
+
+// Stop stops watching and waits for any in-flight reload to finish
+func (w *ConfigWatcher) Stop() error {
+	w.mu.Lock()
+	if w.watcher == nil {
+		w.mu.Unlock()
+		return nil
+	}
+	watcher := w.watcher
+	close(w.done)
+	w.watcher = nil
+	w.cm.watchEnabled = false
+	w.mu.Unlock()
+
+	err := watcher.Close()
+	w.wg.Wait()
+	return err
+}
+
+// IsWatching reports whether the watcher is currently running
+func (w *ConfigWatcher) IsWatching() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.watcher != nil
+}
+
+func (w *ConfigWatcher) loop(watcher *fsnotify.Watcher, done chan struct{}) {
+	defer w.wg.Done()
+
+	configName := filepath.Base(w.cm.GetConfigPath())
+	var timer *time.Timer
+	var fire <-chan time.Time
+
+	for {
+		select {
+		case <-done:
+			if timer != nil {
+				timer.Stop()
+			}
+			return
+
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			if filepath.Base(event.Name) != configName {
+				continue
+			}
+			if !event.Has(fsnotify.Write) && !event.Has(fsnotify.Create) && !event.Has(fsnotify.Rename) {
+				continue
+			}
+
+			w.mu.Lock()
+			debounce := w.debounce
+			w.mu.Unlock()
+
+			if timer == nil {
+				timer = time.NewTimer(debounce)
+			} else {
+				if !timer.Stop() {
+					select {
+					case <-timer.C:
+					default:
+					}
+				}
+				timer.Reset(debounce)
+			}
+			fire = timer.C
+
+		case <-fire:
+			fire = nil
+			w.reload()
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			w.reportError(fmt.Errorf("file watcher error: %w", err))
+		}
+	}
+}
+
+// reload re-reads the config file and notifies handlers when it changed
+func (w *ConfigWatcher) reload() {
+	modified, err := w.cm.IsModified()
+	if err != nil {
+		// The file may be mid-replace; the following create event retries
+		return
+	}
+	if !modified {
+		return
+	}
+
+	previous := w.cm.GetAll()
+	if err := w.cm.Load(); err != nil {
+		w.reportError(err)
+		return
+	}
+	current := w.cm.GetAll()
+
+	w.mu.Lock()
+	handlers := append([]ConfigChangeHandler(nil), w.handlers...)
+	w.mu.Unlock()
+
+	for _, handler := range handlers {
+		if err := handler(previous, current); err != nil {
+			w.reportError(err)
+		}
+	}
+}
+
+func (w *ConfigWatcher) reportError(err error) {
+	w.mu.Lock()
+	onError := w.onError
+	w.mu.Unlock()
+
+	if onError != nil {
+		onError(err)
+	}
+}
+
+// LogLevelHandler returns a change handler that applies the log_level
+// setting (top-level or under settings) to the given logger.
+func LogLevelHandler(log logger.Logger) ConfigChangeHandler {
+	return func(previous, current map[string]interface{}) error {
+		newLevel := configLogLevel(current)
+		if newLevel == "" || strings.EqualFold(newLevel, configLogLevel(previous)) {
+			return nil
+		}
+
+		log.SetLevel(logger.ParseLogLevel(newLevel))
+		log.Infof("Log level changed to %s", strings.ToLower(newLevel))
+		return nil
+	}
+}
+
+func configLogLevel(config map[string]interface{}) string {
+	if level, ok := config["log_level"].(string); ok {
+		return level
+	}
+	if settings, ok := config["settings"].(map[string]interface{}); ok {
+		if level, ok := settings["log_level"].(string); ok {
+			return level
+		}
+	}
+	return ""
+}
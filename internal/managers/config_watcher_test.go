@@ -0,0 +1,96 @@
+package managers
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestConfigWatcherReloadsOnChange(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "config-watch-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	cm, err := NewConfigManager(tempDir, "")
+	if err != nil {
+		t.Fatalf("Failed to create config manager: %v", err)
+	}
+
+	watcher := NewConfigWatcher(cm)
+	watcher.SetDebounce(20 * time.Millisecond)
+
+	changes := make(chan map[string]interface{}, 4)
+	watcher.OnChange(func(previous, current map[string]interface{}) error {
+		changes <- current
+		return nil
+	})
+
+	if err := watcher.Start(); err != nil {
+		t.Fatalf("Failed to start watcher: %v", err)
+	}
+	defer watcher.Stop()
+
+	// Ensure the new mtime is strictly after the one recorded on load
+	time.Sleep(20 * time.Millisecond)
+	if err := os.WriteFile(cm.GetConfigPath(), []byte(`{"log_level": "debug"}`), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	select {
+	case current := <-changes:
+		if current["log_level"] != "debug" {
+			t.Errorf("Expected log_level debug, got %v", current["log_level"])
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for config reload")
+	}
+
+	level, err := cm.GetString("log_level")
+	if err != nil || level != "debug" {
+		t.Errorf("Expected reloaded config to be visible, got %q (%v)", level, err)
+	}
+}
+
+func TestConfigWatcherKeepsConfigOnInvalidFile(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "config-watch-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	cm, err := NewConfigManager(tempDir, "")
+	if err != nil {
+		t.Fatalf("Failed to create config manager: %v", err)
+	}
+	if err := cm.Set("theme", "light"); err != nil {
+		t.Fatalf("Failed to set value: %v", err)
+	}
+
+	watcher := NewConfigWatcher(cm)
+	watcher.SetDebounce(20 * time.Millisecond)
+
+	errs := make(chan error, 4)
+	watcher.OnError(func(err error) { errs <- err })
+
+	if err := watcher.Start(); err != nil {
+		t.Fatalf("Failed to start watcher: %v", err)
+	}
+	defer watcher.Stop()
+
+	time.Sleep(20 * time.Millisecond)
+	if err := os.WriteFile(cm.GetConfigPath(), []byte(`{not json`), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	select {
+	case <-errs:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for reload error")
+	}
+
+	if theme, _ := cm.GetString("theme"); theme != "light" {
+		t.Errorf("Expected previous config to be kept, got theme %q", theme)
+	}
+}
@@ -3,32 +3,84 @@
 package managers
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"io"
-	"os"
+	"io/fs"
 	"path/filepath"
 	"strings"
 
+	"github.com/jonwraymond/claude-code-super-crew/internal/claudemd"
+	"github.com/jonwraymond/claude-code-super-crew/internal/crewignore"
+	"github.com/jonwraymond/claude-code-super-crew/internal/fileops"
+	"github.com/jonwraymond/claude-code-super-crew/internal/fsys"
 	"github.com/jonwraymond/claude-code-super-crew/internal/metadata"
 )
 
 // FileManager handles file operations for the installation system
 type FileManager struct {
+	// fs is the filesystem files are read and written in
+	fs              fsys.FS
 	metadataManager *metadata.MetadataManager
 	installDir      string
+	// link makes CopyFile symlink files instead of copying them
+	link bool
+	// ignore holds the .crewignore of installDir; CopyFile leaves the
+	// paths it excludes alone
+	ignore *crewignore.Matcher
 }
 
 // NewFileManager creates a new file manager instance
 func NewFileManager() *FileManager {
-	return &FileManager{}
+	return &FileManager{fs: fsys.OS}
+}
+
+// NewFileManagerFS creates a file manager working in fs, such as an
+// in-memory filesystem in tests
+func NewFileManagerFS(fs fsys.FS) *FileManager {
+	return &FileManager{fs: fsys.Or(fs)}
 }
 
 // NewFileManagerWithMetadata creates a file manager with inventory tracking
 func NewFileManagerWithMetadata(installDir string) *FileManager {
-	return &FileManager{
-		metadataManager: metadata.NewMetadataManager(installDir),
-		installDir:      installDir,
+	return NewFileManagerWithMetadataFS(installDir, fsys.OS)
+}
+
+// NewFileManagerWithMetadataFS creates a file manager with inventory
+// tracking for an installation in fs, keeping the metadata there too
+func NewFileManagerWithMetadataFS(installDir string, fs fsys.FS) *FileManager {
+	fm := NewFileManagerFS(fs)
+	fm.metadataManager = metadata.NewMetadataManagerFS(installDir, fm.fs)
+	fm.installDir = installDir
+	fm.ignore = loadIgnore(fm.fs, installDir)
+	return fm
+}
+
+// loadIgnore reads the .crewignore of installDir in fs. A file that is
+// missing or cannot be read excludes nothing.
+func loadIgnore(fs fsys.FS, installDir string) *crewignore.Matcher {
+	f, err := fs.Open(filepath.Join(installDir, crewignore.FileName))
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+	ignore, _ := crewignore.Parse(f)
+	return ignore
+}
+
+// Ignored reports whether dst is excluded by the .crewignore of the
+// installation directory
+func (fm *FileManager) Ignored(dst string) bool {
+	if fm.installDir == "" {
+		return false
 	}
+	info, err := fm.fs.Stat(dst)
+	return fm.ignore.MatchPath(fm.installDir, dst, err == nil && info.IsDir())
+}
+
+// FS returns the filesystem the file manager works in
+func (fm *FileManager) FS() fsys.FS {
+	return fm.fs
 }
 
 // SetMetadataManager sets the metadata manager for inventory tracking
@@ -36,6 +88,17 @@ func (fm *FileManager) SetMetadataManager(mm *metadata.MetadataManager) {
 	fm.metadataManager = mm
 }
 
+// SetLinkMode makes CopyFile symlink files to their source instead of
+// copying them, for development installs from a checkout
+func (fm *FileManager) SetLinkMode(enabled bool) {
+	fm.link = enabled
+}
+
+// Linking reports whether CopyFile symlinks files
+func (fm *FileManager) Linking() bool {
+	return fm.link
+}
+
 // HasMetadataManager checks if metadata manager is available
 func (fm *FileManager) HasMetadataManager() bool {
 	return fm.metadataManager != nil
@@ -56,7 +119,7 @@ func (fm *FileManager) CopyFileWithMerge(src, dst string) error {
 	if fm.Exists(dst) {
 		// For CLAUDE.md files, attempt to merge instead of overwrite
 		if filepath.Base(dst) == "CLAUDE.md" {
-			return fm.mergeClaudeFile(src, dst)
+			return fm.MergeClaudeFile(src, dst, "")
 		}
 	}
 
@@ -64,25 +127,28 @@ func (fm *FileManager) CopyFileWithMerge(src, dst string) error {
 	return fm.CopyFileWithInventory(src, dst)
 }
 
-// mergeClaudeFile merges framework sections into existing CLAUDE.md while preserving user content
-func (fm *FileManager) mergeClaudeFile(src, dst string) error {
+// MergeClaudeFile writes the framework CLAUDE.md at src into the framework
+// section of dst, tagged with version, and keeps the user content of dst
+func (fm *FileManager) MergeClaudeFile(src, dst, version string) error {
 	// Read source (new framework content)
-	srcContent, err := os.ReadFile(src)
+	srcContent, err := fm.fs.ReadFile(src)
 	if err != nil {
 		return fmt.Errorf("failed to read source file: %w", err)
 	}
 
 	// Read destination (existing file with potential user content)
-	dstContent, err := os.ReadFile(dst)
-	if err != nil {
+	dstContent, err := fm.fs.ReadFile(dst)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
 		return fmt.Errorf("failed to read destination file: %w", err)
 	}
 
-	// Merge the content
-	mergedContent := fm.mergeClaudeContent(string(dstContent), string(srcContent))
+	mergedContent, err := claudemd.MergeFramework(string(dstContent), string(srcContent), version)
+	if err != nil {
+		return fmt.Errorf("failed to merge %s: %w", dst, err)
+	}
 
 	// Write merged content
-	if err := os.WriteFile(dst, []byte(mergedContent), 0644); err != nil {
+	if err := fm.fs.WriteFile(dst, []byte(mergedContent), 0644); err != nil {
 		return fmt.Errorf("failed to write merged file: %w", err)
 	}
 
@@ -97,31 +163,14 @@ func (fm *FileManager) mergeClaudeFile(src, dst string) error {
 	return nil
 }
 
-// mergeClaudeContent merges framework sections into existing CLAUDE.md content
-func (fm *FileManager) mergeClaudeContent(existing, framework string) string {
-	// Simple merge strategy: preserve user sections, update framework sections
-	// This is a basic implementation - could be enhanced with more sophisticated parsing
-
-	// For now, append framework content to existing content with a separator
-	// In a more sophisticated implementation, we would:
-	// 1. Parse both files into sections
-	// 2. Identify framework vs user sections
-	// 3. Update only framework sections
-	// 4. Preserve all user sections
-
-	separator := "\n\n<!-- FRAMEWORK CONTENT BELOW - DO NOT EDIT MANUALLY -->\n\n"
-	return existing + separator + framework
-}
-
 // Exists checks if a file or directory exists
 func (fm *FileManager) Exists(path string) bool {
-	_, err := os.Stat(path)
-	return !os.IsNotExist(err)
+	return fsys.Exists(fm.fs, path)
 }
 
 // EnsureDirectory creates a directory and all parent directories
 func (fm *FileManager) EnsureDirectory(path string) error {
-	return os.MkdirAll(path, 0755)
+	return fm.fs.MkdirAll(path, 0755)
 }
 
 // EnsureDirectoryWithInventory creates a directory and tracks it in inventory
@@ -130,7 +179,7 @@ func (fm *FileManager) EnsureDirectoryWithInventory(path string) error {
 
 	// Create directory if it doesn't exist
 	if !existed {
-		if err := os.MkdirAll(path, 0755); err != nil {
+		if err := fm.fs.MkdirAll(path, 0755); err != nil {
 			return err
 		}
 	}
@@ -147,19 +196,12 @@ func (fm *FileManager) EnsureDirectoryWithInventory(path string) error {
 	return nil
 }
 
-// CopyFile copies a file from source to destination
+// CopyFile copies a file from source to destination, keeping its
+// permissions and modification time. In link mode it symlinks instead,
+// which only the OS filesystem supports.
 func (fm *FileManager) CopyFile(src, dst string) error {
-	// Open source file
-	sourceFile, err := os.Open(src)
-	if err != nil {
-		return fmt.Errorf("failed to open source file: %w", err)
-	}
-	defer sourceFile.Close()
-
-	// Get source file info
-	sourceInfo, err := sourceFile.Stat()
-	if err != nil {
-		return fmt.Errorf("failed to stat source file: %w", err)
+	if fm.Ignored(dst) {
+		return nil
 	}
 
 	// Create destination directory if needed
@@ -168,28 +210,33 @@ func (fm *FileManager) CopyFile(src, dst string) error {
 		return fmt.Errorf("failed to create destination directory: %w", err)
 	}
 
-	// Create destination file
-	destFile, err := os.Create(dst)
-	if err != nil {
-		return fmt.Errorf("failed to create destination file: %w", err)
+	if !fsys.IsOS(fm.fs) {
+		if fm.link {
+			return fmt.Errorf("failed to link file: link mode needs the OS filesystem")
+		}
+		if err := fsys.CopyFile(fm.fs, src, dst); err != nil {
+			return fmt.Errorf("failed to copy file: %w", err)
+		}
+		return nil
 	}
-	defer destFile.Close()
-
-	// Copy file contents
-	if _, err := io.Copy(destFile, sourceFile); err != nil {
-		return fmt.Errorf("failed to copy file contents: %w", err)
+	if fm.link {
+		if err := fileops.Link(src, dst); err != nil {
+			return fmt.Errorf("failed to link file: %w", err)
+		}
+		return nil
 	}
-
-	// Preserve file permissions
-	if err := os.Chmod(dst, sourceInfo.Mode()); err != nil {
-		return fmt.Errorf("failed to set file permissions: %w", err)
+	if err := fileops.CopyFile(src, dst); err != nil {
+		return fmt.Errorf("failed to copy file: %w", err)
 	}
-
 	return nil
 }
 
 // CopyFileWithInventory copies a file from source to destination and tracks it
 func (fm *FileManager) CopyFileWithInventory(src, dst string) error {
+	if fm.Ignored(dst) {
+		return nil
+	}
+
 	// Copy the file
 	if err := fm.CopyFile(src, dst); err != nil {
 		return err
@@ -246,31 +293,40 @@ func (fm *FileManager) determineComponentFromPath(relPath string) string {
 
 // CopyDirectory recursively copies a directory
 func (fm *FileManager) CopyDirectory(src, dst string) error {
+	return fm.CopyDirectoryContext(context.Background(), src, dst)
+}
+
+// CopyDirectoryContext recursively copies a directory, stopping between
+// files once ctx is cancelled
+func (fm *FileManager) CopyDirectoryContext(ctx context.Context, src, dst string) error {
 	// Get source directory info
-	srcInfo, err := os.Stat(src)
+	srcInfo, err := fm.fs.Stat(src)
 	if err != nil {
 		return fmt.Errorf("failed to stat source directory: %w", err)
 	}
 
 	// Create destination directory
-	if err := os.MkdirAll(dst, srcInfo.Mode()); err != nil {
+	if err := fm.fs.MkdirAll(dst, srcInfo.Mode()); err != nil {
 		return fmt.Errorf("failed to create destination directory: %w", err)
 	}
 
 	// Read directory entries
-	entries, err := os.ReadDir(src)
+	entries, err := fm.fs.ReadDir(src)
 	if err != nil {
 		return fmt.Errorf("failed to read source directory: %w", err)
 	}
 
 	// Copy each entry
 	for _, entry := range entries {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 		srcPath := filepath.Join(src, entry.Name())
 		dstPath := filepath.Join(dst, entry.Name())
 
 		if entry.IsDir() {
 			// Recursively copy subdirectory
-			if err := fm.CopyDirectory(srcPath, dstPath); err != nil {
+			if err := fm.CopyDirectoryContext(ctx, srcPath, dstPath); err != nil {
 				return err
 			}
 		} else {
@@ -286,35 +342,35 @@ func (fm *FileManager) CopyDirectory(src, dst string) error {
 
 // RemoveFile safely removes a file
 func (fm *FileManager) RemoveFile(path string) error {
-	return os.Remove(path)
+	return fm.fs.Remove(path)
 }
 
 // RemoveDirectory safely removes a directory and its contents
 func (fm *FileManager) RemoveDirectory(path string) error {
-	return os.RemoveAll(path)
+	return fm.fs.RemoveAll(path)
 }
 
 // FileExists checks if a file exists
 func (fm *FileManager) FileExists(path string) bool {
-	_, err := os.Stat(path)
+	_, err := fm.fs.Stat(path)
 	return err == nil
 }
 
 // IsDirectory checks if a path is a directory
 func (fm *FileManager) IsDirectory(path string) bool {
-	info, err := os.Stat(path)
+	info, err := fm.fs.Stat(path)
 	return err == nil && info.IsDir()
 }
 
 // IsFile checks if a path is a regular file
 func (fm *FileManager) IsFile(path string) bool {
-	info, err := os.Stat(path)
+	info, err := fm.fs.Stat(path)
 	return err == nil && !info.IsDir()
 }
 
 // GetFileSize returns the size of a file in bytes
 func (fm *FileManager) GetFileSize(path string) (int64, error) {
-	info, err := os.Stat(path)
+	info, err := fm.fs.Stat(path)
 	if err != nil {
 		return 0, err
 	}
@@ -325,16 +381,12 @@ func (fm *FileManager) GetFileSize(path string) (int64, error) {
 func (fm *FileManager) GetDirectorySize(path string) (int64, error) {
 	var size int64
 
-	err := filepath.WalkDir(path, func(path string, d os.DirEntry, err error) error {
+	err := fsys.Walk(fm.fs, path, func(path string, info fs.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
 
-		if !d.IsDir() {
-			info, err := d.Info()
-			if err != nil {
-				return err
-			}
+		if !info.IsDir() {
 			size += info.Size()
 		}
 
@@ -0,0 +1,100 @@
+package managers
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/fsys"
+)
+
+func TestMergeClaudeFile(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "src.md")
+	dst := filepath.Join(dir, "CLAUDE.md")
+	if err := os.WriteFile(src, []byte("# SuperCrew\nrules\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(dst, []byte("# My rules\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	fm := NewFileManager()
+	for i := 0; i < 2; i++ {
+		if err := fm.MergeClaudeFile(src, dst, "1.0.0"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	data, err := os.ReadFile(dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := string(data)
+	if strings.Count(content, "# SuperCrew") != 1 || !strings.HasSuffix(content, "\n\n# My rules\n") {
+		t.Errorf("Expected one framework section above the user rules:\n%s", content)
+	}
+}
+
+func TestCopyFileHonorsCrewignore(t *testing.T) {
+	installDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(installDir, ".crewignore"), []byte("agents/local/\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	src := filepath.Join(t.TempDir(), "agent.md")
+	if err := os.WriteFile(src, []byte("agent"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	fm := NewFileManagerWithMetadata(installDir)
+	ignored := filepath.Join(installDir, "agents", "local", "agent.md")
+	copied := filepath.Join(installDir, "agents", "agent.md")
+	for _, dst := range []string{ignored, copied} {
+		if err := fm.CopyFile(src, dst); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := os.Stat(ignored); !os.IsNotExist(err) {
+		t.Errorf("Expected %s to be left alone, got %v", ignored, err)
+	}
+	if _, err := os.Stat(copied); err != nil {
+		t.Errorf("Expected %s to be copied: %v", copied, err)
+	}
+}
+
+func TestFileManagerMemFS(t *testing.T) {
+	t.Parallel()
+	mem := fsys.NewMemFS()
+	home := filepath.Join(string(filepath.Separator), "home", "user")
+	src := filepath.Join(home, "src")
+	for name, content := range map[string]string{"CLAUDE.md": "# SuperCrew\n", "agents/agent.md": "# Agent\n"} {
+		path := filepath.Join(src, filepath.FromSlash(name))
+		if err := mem.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := mem.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	fm := NewFileManagerFS(mem)
+	dst := filepath.Join(home, ".claude")
+	if err := fm.CopyDirectory(src, dst); err != nil {
+		t.Fatal(err)
+	}
+	if !fm.IsFile(filepath.Join(dst, "agents", "agent.md")) || !fm.IsDirectory(filepath.Join(dst, "agents")) {
+		t.Error("Expected the directory tree to be copied into the MemFS")
+	}
+	if size, err := fm.GetDirectorySize(dst); err != nil || size != 20 {
+		t.Errorf("GetDirectorySize = %d, %v", size, err)
+	}
+
+	fm.SetLinkMode(true)
+	if err := fm.CopyFile(filepath.Join(src, "CLAUDE.md"), filepath.Join(dst, "linked.md")); err == nil {
+		t.Error("Expected link mode to fail outside the OS filesystem")
+	}
+	if err := fm.RemoveDirectory(dst); err != nil || fm.Exists(dst) {
+		t.Errorf("RemoveDirectory = %v, exists %v", err, fm.Exists(dst))
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"path/filepath"
 	"strings"
 	"time"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/crewdirs"
 )
 
 // MetadataManager handles complex metadata operations with dual file system support
@@ -23,8 +25,8 @@ func NewMetadataManager(installDir string) *MetadataManager {
 	return &MetadataManager{
 		installDir:   installDir,
 		settingsFile: filepath.Join(installDir, "settings.json"),
-		metadataFile: filepath.Join(installDir, ".crew", "config", "crew-metadata.json"),
-		backupDir:    filepath.Join(installDir, ".crew", "backups", "settings"),
+		metadataFile: crewdirs.Path(installDir, "config", "crew-metadata.json"),
+		backupDir:    crewdirs.Path(installDir, "backups", "settings"),
 	}
 }
 
@@ -3,10 +3,11 @@ package managers
 import (
 	"crypto/sha256"
 	"fmt"
-	"io"
 	"os"
 	"path/filepath"
 	"strings"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/fileops"
 )
 
 // SecurityValidator provides security checks for the installation system
@@ -233,7 +234,7 @@ func (sv *SecurityValidator) CheckFileIntegrity(path string, expectedHash string
 	
 	// Calculate SHA-256 hash
 	hasher := sha256.New()
-	if _, err := io.Copy(hasher, file); err != nil {
+	if _, err := fileops.Copy(hasher, file); err != nil {
 		return false, fmt.Errorf("failed to read file: %w", err)
 	}
 	
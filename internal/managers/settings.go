@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/jonwraymond/claude-code-super-crew/internal/fsys"
 	"github.com/jonwraymond/claude-code-super-crew/internal/metadata"
 )
 
@@ -17,9 +18,15 @@ type SettingsManager struct {
 
 // NewSettingsManager creates a new settings manager with unified metadata
 func NewSettingsManager(installDir string) *SettingsManager {
+	return NewSettingsManagerFS(installDir, fsys.OS)
+}
+
+// NewSettingsManagerFS creates a settings manager whose unified metadata
+// is kept in fs
+func NewSettingsManagerFS(installDir string, fs fsys.FS) *SettingsManager {
 	return &SettingsManager{
 		installDir:      installDir,
-		metadataManager: metadata.NewMetadataManager(installDir),
+		metadataManager: metadata.NewMetadataManagerFS(installDir, fs),
 	}
 }
 
@@ -0,0 +1,305 @@
+// Package mcpcatalog describes the MCP servers crew knows: what each does,
+// how Claude Code starts it, the runtime it needs and the kinds of project
+// it suits. The built-in catalog is embedded; users add servers, or change
+// fields of built-in ones, in mcp-catalog.json in crew's config directory
+// of their installation or of a project.
+package mcpcatalog
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/crewdirs"
+	"github.com/jonwraymond/claude-code-super-crew/internal/pathsutil"
+	"github.com/jonwraymond/claude-code-super-crew/pkg/logger"
+)
+
+//go:embed catalog.json
+var builtinData []byte
+
+// FileName is the catalog file users keep in crew's config directory
+const FileName = "mcp-catalog.json"
+
+// BuiltinSource is the Source of servers from the embedded catalog
+const BuiltinSource = "builtin"
+
+// Server statuses
+const (
+	StatusStable     = "stable"
+	StatusBeta       = "beta"
+	StatusDeprecated = "deprecated"
+)
+
+// runtimeCommands are the commands each runtime needs on PATH
+var runtimeCommands = map[string]string{
+	"node":   "npx",
+	"python": "uvx",
+	"docker": "docker",
+}
+
+var namePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]*$`)
+
+// Server is one MCP server of the catalog
+type Server struct {
+	// Name is how Claude Code and crew refer to the server
+	Name string `json:"name"`
+	// Aliases are other names accepted for it, e.g. in load flags
+	Aliases     []string `json:"aliases,omitempty"`
+	Title       string   `json:"title,omitempty"`
+	Description string   `json:"description"`
+	// Package is the npm package started with npx; Version pins it and
+	// is empty for whatever npx resolves
+	Package string `json:"package,omitempty"`
+	Version string `json:"version,omitempty"`
+	// Command starts servers that are not npm packages
+	Command []string `json:"command,omitempty"`
+	// Runtime is what the server needs installed: node, python or docker
+	Runtime string `json:"runtime,omitempty"`
+	// ProjectTypes are the project types the server is recommended for,
+	// such as go, backend or react
+	ProjectTypes []string `json:"project_types,omitempty"`
+	Tools        []string `json:"tools,omitempty"`
+	// Required servers are always installed by the mcp component
+	Required bool `json:"required,omitempty"`
+	// Enabled servers are documented in a project's CLAUDE.md whether or
+	// not they were chosen
+	Enabled           bool   `json:"enabled,omitempty"`
+	APIKeyEnv         string `json:"api_key_env,omitempty"`
+	APIKeyDescription string `json:"api_key_description,omitempty"`
+	// Status is stable, beta or deprecated
+	Status   string `json:"status,omitempty"`
+	Homepage string `json:"homepage,omitempty"`
+	// Guide and Examples document the server in CLAUDE.md
+	Guide    string    `json:"guide,omitempty"`
+	Examples []Example `json:"examples,omitempty"`
+	// Source is the catalog file the server was last set in, or
+	// BuiltinSource
+	Source string `json:"source"`
+}
+
+// Example is a usage example for CLAUDE.md
+type Example struct {
+	Description string `json:"description"`
+	Code        string `json:"code"`
+}
+
+// DisplayName is the title, or the name when there is none
+func (s Server) DisplayName() string {
+	if s.Title != "" {
+		return s.Title
+	}
+	return s.Name
+}
+
+// PackageSpec is the package with its version for npx, e.g.
+// @playwright/mcp@latest
+func (s Server) PackageSpec() string {
+	if s.Version == "" {
+		return s.Package
+	}
+	return s.Package + "@" + s.Version
+}
+
+// InstallCommand is the command Claude Code runs to start the server
+func (s Server) InstallCommand() []string {
+	if len(s.Command) > 0 {
+		return s.Command
+	}
+	return []string{"npx", "-y", s.PackageSpec()}
+}
+
+// Health reports what keeps the server from working on this system: a
+// missing runtime or API key. It is empty for a healthy server.
+func (s Server) Health(lookPath func(string) (string, error), getenv func(string) string) []string {
+	var problems []string
+	if s.Status == StatusDeprecated {
+		problems = append(problems, "deprecated")
+	}
+	command := runtimeCommands[s.Runtime]
+	if len(s.Command) > 0 {
+		command = s.Command[0]
+	}
+	if command != "" {
+		if _, err := lookPath(command); err != nil {
+			problems = append(problems, fmt.Sprintf("%s not found", command))
+		}
+	}
+	if s.APIKeyEnv != "" && getenv(s.APIKeyEnv) == "" {
+		problems = append(problems, fmt.Sprintf("%s not set", s.APIKeyEnv))
+	}
+	return problems
+}
+
+// RecommendedFor reports whether the server suits a project type
+func (s Server) RecommendedFor(projectType string) bool {
+	for _, t := range s.ProjectTypes {
+		if strings.EqualFold(t, projectType) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s Server) validate() error {
+	if !namePattern.MatchString(s.Name) {
+		return fmt.Errorf("invalid server name %q", s.Name)
+	}
+	if s.Package == "" && len(s.Command) == 0 {
+		return fmt.Errorf("server %s needs a package or a command", s.Name)
+	}
+	switch s.Status {
+	case "", StatusStable, StatusBeta, StatusDeprecated:
+	default:
+		return fmt.Errorf("server %s: unknown status %q", s.Name, s.Status)
+	}
+	return nil
+}
+
+// Catalog is a set of servers by name
+type Catalog struct {
+	servers map[string]Server
+}
+
+var (
+	builtin     *Catalog
+	builtinOnce sync.Once
+)
+
+// Builtin returns the embedded catalog
+func Builtin() *Catalog {
+	builtinOnce.Do(func() {
+		builtin = &Catalog{servers: make(map[string]Server)}
+		if err := builtin.merge(builtinData, BuiltinSource); err != nil {
+			panic(fmt.Sprintf("mcpcatalog: embedded catalog: %v", err))
+		}
+	})
+	return builtin.clone()
+}
+
+// Load returns the built-in catalog extended by the catalog files of the
+// given .claude directories, in order. Missing files are skipped.
+func Load(claudeDirs ...string) (*Catalog, error) {
+	c := Builtin()
+	for _, dir := range claudeDirs {
+		if dir == "" {
+			continue
+		}
+		path := crewdirs.Path(dir, "config", FileName)
+		data, err := os.ReadFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		if err := c.merge(data, path); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return c, nil
+}
+
+// ForProject loads the catalog of the installation in ~/.claude and of the
+// project in projectDir. A broken catalog file is logged and the catalog
+// without it is used.
+func ForProject(projectDir string) *Catalog {
+	home, _ := os.UserHomeDir()
+	dirs := []string{pathsutil.ClaudeDir(home)}
+	if projectDir != "" && projectDir != home {
+		dirs = append(dirs, pathsutil.ClaudeDir(projectDir))
+	}
+	c, err := Load(dirs...)
+	if err != nil {
+		logger.GetLogger().Warnf("Ignoring the MCP catalog: %v", err)
+		return Builtin()
+	}
+	return c
+}
+
+// merge adds the servers of a catalog file. A server already in the
+// catalog keeps the fields the file does not set.
+func (c *Catalog) merge(data []byte, source string) error {
+	var file struct {
+		Servers []json.RawMessage `json:"servers"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return err
+	}
+	for _, raw := range file.Servers {
+		var named struct {
+			Name string `json:"name"`
+		}
+		if err := json.Unmarshal(raw, &named); err != nil {
+			return err
+		}
+		server := c.servers[named.Name]
+		decoder := json.NewDecoder(bytes.NewReader(raw))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&server); err != nil {
+			return fmt.Errorf("server %s: %w", named.Name, err)
+		}
+		server.Source = source
+		if err := server.validate(); err != nil {
+			return err
+		}
+		c.servers[server.Name] = server
+	}
+	return nil
+}
+
+func (c *Catalog) clone() *Catalog {
+	servers := make(map[string]Server, len(c.servers))
+	for name, server := range c.servers {
+		servers[name] = server
+	}
+	return &Catalog{servers: servers}
+}
+
+// Servers returns the servers sorted by name
+func (c *Catalog) Servers() []Server {
+	names := make([]string, 0, len(c.servers))
+	for name := range c.servers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	servers := make([]Server, 0, len(names))
+	for _, name := range names {
+		servers = append(servers, c.servers[name])
+	}
+	return servers
+}
+
+// Lookup finds a server by name or alias, ignoring case
+func (c *Catalog) Lookup(name string) (Server, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if server, ok := c.servers[name]; ok {
+		return server, true
+	}
+	for _, server := range c.Servers() {
+		for _, alias := range server.Aliases {
+			if strings.EqualFold(alias, name) {
+				return server, true
+			}
+		}
+	}
+	return Server{}, false
+}
+
+// Recommended returns the servers recommended for a project type, sorted
+// by name
+func (c *Catalog) Recommended(projectType string) []Server {
+	var servers []Server
+	for _, server := range c.Servers() {
+		if server.RecommendedFor(projectType) {
+			servers = append(servers, server)
+		}
+	}
+	return servers
+}
@@ -0,0 +1,112 @@
+package mcpcatalog
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/crewdirs"
+)
+
+func writeCatalog(t *testing.T, claudeDir, content string) {
+	t.Helper()
+	path := crewdirs.Path(claudeDir, "config", FileName)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBuiltin(t *testing.T) {
+	c := Builtin()
+	var names []string
+	for _, server := range c.Servers() {
+		names = append(names, server.Name)
+		if server.Source != BuiltinSource || server.Description == "" {
+			t.Errorf("unexpected built-in server %+v", server)
+		}
+	}
+	if want := []string{"context7", "magic", "playwright", "sequential-thinking", "serena"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("Servers() = %v, want %v", names, want)
+	}
+
+	if server, ok := c.Lookup("Sequential"); !ok || server.Name != "sequential-thinking" {
+		t.Errorf("Lookup(Sequential) = %+v, %v", server, ok)
+	}
+	playwright, _ := c.Lookup("playwright")
+	if got := strings.Join(playwright.InstallCommand(), " "); got != "npx -y @playwright/mcp@latest" {
+		t.Errorf("InstallCommand() = %s", got)
+	}
+
+	var recommended []string
+	for _, server := range c.Recommended("go") {
+		recommended = append(recommended, server.Name)
+	}
+	if want := []string{"context7", "sequential-thinking", "serena"}; !reflect.DeepEqual(recommended, want) {
+		t.Errorf("Recommended(go) = %v, want %v", recommended, want)
+	}
+}
+
+func TestLoadExtendsAndOverrides(t *testing.T) {
+	user, project := t.TempDir(), t.TempDir()
+	writeCatalog(t, user, `{"servers": [
+		{"name": "github", "description": "GitHub issues and pull requests", "command": ["docker", "run", "-i", "ghcr.io/github/github-mcp-server"], "runtime": "docker", "api_key_env": "GITHUB_TOKEN"},
+		{"name": "context7", "version": "1.0.14"}
+	]}`)
+	writeCatalog(t, project, `{"servers": [{"name": "github", "project_types": ["go"]}]}`)
+
+	c, err := Load(user, filepath.Join(project, "none"), project)
+	if err != nil {
+		t.Fatal(err)
+	}
+	github, ok := c.Lookup("github")
+	if !ok || github.Runtime != "docker" || !github.RecommendedFor("go") || !strings.HasPrefix(github.Source, project) {
+		t.Errorf("unexpected github server %+v", github)
+	}
+	context7, _ := c.Lookup("context7")
+	if context7.PackageSpec() != "@upstash/context7-mcp@1.0.14" || !context7.Required || context7.Description == "" {
+		t.Errorf("expected the override to keep the other fields: %+v", context7)
+	}
+	if builtin, _ := Builtin().Lookup("context7"); builtin.Version != "" {
+		t.Errorf("Load changed the built-in catalog: %+v", builtin)
+	}
+
+	for content, want := range map[string]string{
+		`{"servers": [{"name": "x"}]}`:                                 "needs a package or a command",
+		`{"servers": [{"name": "Bad Name", "package": "p"}]}`:          "invalid server name",
+		`{"servers": [{"name": "x", "package": "p", "runtim": ""}]}`:   "unknown field",
+		`{"servers": [{"name": "x", "package": "p", "status": "ok"}]}`: "unknown status",
+	} {
+		writeCatalog(t, user, content)
+		if _, err := Load(user); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Load(%s) error = %v, want %q", content, err, want)
+		}
+	}
+}
+
+func TestHealth(t *testing.T) {
+	lookPath := func(name string) (string, error) {
+		if name == "npx" {
+			return "/usr/bin/npx", nil
+		}
+		return "", fmt.Errorf("%s: not found", name)
+	}
+	getenv := func(string) string { return "" }
+
+	c := Builtin()
+	for name, want := range map[string][]string{
+		"context7": nil,
+		"magic":    {"TWENTYFIRST_API_KEY not set"},
+		"serena":   {"uvx not found"},
+	} {
+		server, _ := c.Lookup(name)
+		if got := server.Health(lookPath, getenv); !reflect.DeepEqual(got, want) {
+			t.Errorf("Health(%s) = %v, want %v", name, got, want)
+		}
+	}
+}
@@ -2,15 +2,20 @@
 package metadata
 
 import (
-	"crypto/sha256"
-	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io/fs"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
+	"sync"
 	"time"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/crewdirs"
+	"github.com/jonwraymond/claude-code-super-crew/internal/crewignore"
+	"github.com/jonwraymond/claude-code-super-crew/internal/fileops"
+	"github.com/jonwraymond/claude-code-super-crew/internal/fsys"
 )
 
 // UnifiedMetadata represents the comprehensive metadata for the entire installation
@@ -43,6 +48,9 @@ type ComponentMeta struct {
 	Size            int64     `json:"size,omitempty"`
 	FileCount       int       `json:"file_count,omitempty"`
 	Checksum        string    `json:"checksum,omitempty"`
+	// LinkedFrom is the source checkout the component's files are
+	// symlinked from, set by crew install --dev-link
+	LinkedFrom string `json:"linked_from,omitempty"`
 }
 
 // DocumentMeta tracks individual .md file versions
@@ -73,6 +81,15 @@ type InstallationMeta struct {
 	InstallerVersion string    `json:"installer_version"`
 	TotalSize        int64     `json:"total_size"`
 	TotalFiles       int       `json:"total_files"`
+	// Shared marks a framework installation that users layer an overlay
+	// over; crew leaves it to whoever manages it centrally
+	Shared bool `json:"shared,omitempty"`
+	// SharedDir is set on a per-user overlay to the shared installation it
+	// links to
+	SharedDir string `json:"shared_dir,omitempty"`
+	// Migrations are the IDs of the component migrations crew update has
+	// applied, so none runs twice
+	Migrations []string `json:"migrations,omitempty"`
 }
 
 // InventoryMeta tracks all files and directories created by crew
@@ -93,6 +110,19 @@ type FileIntegrityMeta struct {
 	Component       string    `json:"component"`        // Which component owns this file
 	FilePath        string    `json:"file_path"`        // Relative path to the file
 	ModificationLog []string  `json:"modification_log"` // History of detected changes
+	// Size and ModTime are the file's when CurrentHash was computed; a fast
+	// check trusts CurrentHash while both are unchanged
+	Size    int64     `json:"size,omitempty"`
+	ModTime time.Time `json:"mod_time,omitempty"`
+}
+
+// IntegrityOptions tune CheckFileIntegrityWith
+type IntegrityOptions struct {
+	// Workers is the number of files hashed at once; 0 uses one per CPU
+	Workers int
+	// Fast skips hashing files whose size and modification time match the
+	// last check, which misses edits that preserve both
+	Fast bool
 }
 
 // IntegrityMeta tracks file integrity across the entire installation
@@ -122,23 +152,33 @@ type IntegrityMeta struct {
 type MetadataManager struct {
 	installDir   string
 	metadataFile string
+	// fs holds the metadata file and the files whose integrity it tracks;
+	// the directory scans of RefreshMetadata walk the OS filesystem
+	fs fsys.FS
 }
 
 // NewMetadataManager creates a new metadata manager
 func NewMetadataManager(installDir string) *MetadataManager {
+	return NewMetadataManagerFS(installDir, fsys.OS)
+}
+
+// NewMetadataManagerFS creates a metadata manager for an installation in
+// fs, such as an in-memory filesystem in tests
+func NewMetadataManagerFS(installDir string, fs fsys.FS) *MetadataManager {
 	return &MetadataManager{
 		installDir:   installDir,
-		metadataFile: filepath.Join(installDir, ".crew", "config", "crew-metadata.json"),
+		metadataFile: crewdirs.Path(installDir, "config", "crew-metadata.json"),
+		fs:           fsys.Or(fs),
 	}
 }
 
 // LoadMetadata loads the unified metadata from disk
 func (m *MetadataManager) LoadMetadata() (*UnifiedMetadata, error) {
-	if _, err := os.Stat(m.metadataFile); os.IsNotExist(err) {
+	if _, err := m.fs.Stat(m.metadataFile); os.IsNotExist(err) {
 		return m.createEmptyMetadata(), nil
 	}
 
-	data, err := os.ReadFile(m.metadataFile)
+	data, err := m.fs.ReadFile(m.metadataFile)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read metadata file: %w", err)
 	}
@@ -154,7 +194,7 @@ func (m *MetadataManager) LoadMetadata() (*UnifiedMetadata, error) {
 // SaveMetadata saves the unified metadata to disk
 func (m *MetadataManager) SaveMetadata(metadata *UnifiedMetadata) error {
 	// Ensure directory exists
-	if err := os.MkdirAll(filepath.Dir(m.metadataFile), 0755); err != nil {
+	if err := m.fs.MkdirAll(filepath.Dir(m.metadataFile), 0755); err != nil {
 		return fmt.Errorf("failed to create metadata directory: %w", err)
 	}
 
@@ -163,7 +203,7 @@ func (m *MetadataManager) SaveMetadata(metadata *UnifiedMetadata) error {
 		return fmt.Errorf("failed to marshal metadata: %w", err)
 	}
 
-	if err := os.WriteFile(m.metadataFile, data, 0644); err != nil {
+	if err := m.fs.WriteFile(m.metadataFile, data, 0644); err != nil {
 		return fmt.Errorf("failed to write metadata file: %w", err)
 	}
 
@@ -214,7 +254,7 @@ func (m *MetadataManager) scanComponents(metadata *UnifiedMetadata) error {
 		"commands": filepath.Join(m.installDir, "commands"),
 		"agents":   filepath.Join(m.installDir, "agents"),
 		"hooks":    filepath.Join(m.installDir, "hooks"),
-		"mcp":      filepath.Join(m.installDir, ".crew", "mcp"),
+		"mcp":      crewdirs.Path(m.installDir, "mcp"),
 	}
 
 	for component, path := range componentPaths {
@@ -326,7 +366,7 @@ func (m *MetadataManager) scanSingleDocument(path, component string, existingMet
 		meta.Version = "1.0.0" // Default version for new documents only
 	}
 
-	if stat, err := os.Stat(path); err == nil {
+	if stat, err := m.fs.Stat(path); err == nil {
 		meta.Status = "present"
 		meta.Size = stat.Size()
 
@@ -365,14 +405,12 @@ func (m *MetadataManager) calculateDirectorySize(dirPath string) (int64, int, er
 
 // calculateFileChecksum calculates a simple checksum for a file
 func (m *MetadataManager) calculateFileChecksum(path string) (string, error) {
-	data, err := os.ReadFile(path)
+	f, err := m.fs.Open(path)
 	if err != nil {
 		return "", err
 	}
-
-	// Calculate SHA-256 hash
-	hash := sha256.Sum256(data)
-	return hex.EncodeToString(hash[:]), nil
+	defer f.Close()
+	return fileops.HashReader(f)
 }
 
 // updateTotals updates the total size and file count in installation metadata
@@ -477,9 +515,14 @@ func (m *MetadataManager) SetFeatureFlag(featureName string, enabled bool, descr
 	return m.SaveMetadata(metadata)
 }
 
+// Path returns the metadata file
+func (m *MetadataManager) Path() string {
+	return m.metadataFile
+}
+
 // CheckInstallationExists checks if the installation exists by looking for metadata
 func (m *MetadataManager) CheckInstallationExists() bool {
-	_, err := os.Stat(m.metadataFile)
+	_, err := m.fs.Stat(m.metadataFile)
 	return err == nil
 }
 
@@ -572,6 +615,12 @@ func (m *MetadataManager) GetInventory() (*InventoryMeta, error) {
 
 // CheckFileIntegrity verifies the integrity of all tracked files
 func (m *MetadataManager) CheckFileIntegrity() (*IntegrityMeta, error) {
+	return m.CheckFileIntegrityWith(IntegrityOptions{})
+}
+
+// CheckFileIntegrityWith verifies the integrity of all tracked files,
+// hashing them on a pool of opts.Workers goroutines
+func (m *MetadataManager) CheckFileIntegrityWith(opts IntegrityOptions) (*IntegrityMeta, error) {
 	metadata, err := m.LoadMetadata()
 	if err != nil {
 		return nil, err
@@ -582,17 +631,66 @@ func (m *MetadataManager) CheckFileIntegrity() (*IntegrityMeta, error) {
 		metadata.Integrity.FileHashes = make(map[string]FileIntegrityMeta)
 	}
 
+	// Files the user excluded with .crewignore are kept as they were
+	ignore, err := crewignore.Load(m.installDir)
+	if err != nil {
+		return nil, err
+	}
+
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	type checked struct {
+		path      string
+		integrity FileIntegrityMeta
+		status    string
+	}
+	jobs := make(chan string)
+	results := make(chan checked)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for filePath := range jobs {
+				integrity := metadata.Integrity.FileHashes[filePath]
+				status := m.checkSingleFileIntegrity(filePath, &integrity, opts.Fast)
+				results <- checked{filePath, integrity, status}
+			}
+		}()
+	}
+	updated := make(map[string]FileIntegrityMeta, len(metadata.Integrity.FileHashes))
+	skipped := make(map[string]bool)
+	for filePath, integrity := range metadata.Integrity.FileHashes {
+		if ignore.Match(filePath, false) {
+			updated[filePath] = integrity
+			skipped[filePath] = true
+		}
+	}
+	go func() {
+		for filePath := range metadata.Integrity.FileHashes {
+			if !skipped[filePath] {
+				jobs <- filePath
+			}
+		}
+		close(jobs)
+		wg.Wait()
+		close(results)
+	}()
+
 	// Check each tracked file
 	cleanCount := 0
 	modifiedCount := 0
 	missingCount := 0
 	corruptedCount := 0
 
-	for filePath, integrity := range metadata.Integrity.FileHashes {
-		currentStatus := m.checkSingleFileIntegrity(filePath, &integrity)
-		metadata.Integrity.FileHashes[filePath] = integrity
+	// Results go into a new map while the workers still read the old one
+	for result := range results {
+		updated[result.path] = result.integrity
 
-		switch currentStatus {
+		switch result.status {
 		case "clean":
 			cleanCount++
 		case "modified":
@@ -603,10 +701,11 @@ func (m *MetadataManager) CheckFileIntegrity() (*IntegrityMeta, error) {
 			corruptedCount++
 		}
 	}
+	metadata.Integrity.FileHashes = updated
 
 	// Update integrity summary
 	metadata.Integrity.LastScan = time.Now()
-	metadata.Integrity.TotalFiles = len(metadata.Integrity.FileHashes)
+	metadata.Integrity.TotalFiles = len(metadata.Integrity.FileHashes) - len(skipped)
 	metadata.Integrity.CleanFiles = cleanCount
 	metadata.Integrity.ModifiedFiles = modifiedCount
 	metadata.Integrity.MissingFiles = missingCount
@@ -629,12 +728,14 @@ func (m *MetadataManager) CheckFileIntegrity() (*IntegrityMeta, error) {
 	return &metadata.Integrity, nil
 }
 
-// checkSingleFileIntegrity checks the integrity of a single file
-func (m *MetadataManager) checkSingleFileIntegrity(filePath string, integrity *FileIntegrityMeta) string {
+// checkSingleFileIntegrity checks the integrity of a single file. In fast
+// mode the last hash is reused while the size and modification time match.
+func (m *MetadataManager) checkSingleFileIntegrity(filePath string, integrity *FileIntegrityMeta, fast bool) string {
 	fullPath := filepath.Join(m.installDir, filePath)
 
 	// Check if file exists
-	if _, err := os.Stat(fullPath); os.IsNotExist(err) {
+	info, err := m.fs.Stat(fullPath)
+	if os.IsNotExist(err) {
 		integrity.Status = "missing"
 		integrity.LastChecked = time.Now()
 		integrity.ModificationLog = append(integrity.ModificationLog,
@@ -643,7 +744,12 @@ func (m *MetadataManager) checkSingleFileIntegrity(filePath string, integrity *F
 	}
 
 	// Calculate current hash
-	currentHash, err := m.calculateFileChecksum(fullPath)
+	currentHash := integrity.CurrentHash
+	unchanged := err == nil && currentHash != "" && !integrity.ModTime.IsZero() &&
+		info.Size() == integrity.Size && info.ModTime().Equal(integrity.ModTime)
+	if err == nil && !(fast && unchanged) {
+		currentHash, err = m.calculateFileChecksum(fullPath)
+	}
 	if err != nil {
 		integrity.Status = "corrupted"
 		integrity.LastChecked = time.Now()
@@ -654,6 +760,8 @@ func (m *MetadataManager) checkSingleFileIntegrity(filePath string, integrity *F
 
 	// Update current hash and check against original
 	integrity.CurrentHash = currentHash
+	integrity.Size = info.Size()
+	integrity.ModTime = info.ModTime()
 	integrity.LastChecked = time.Now()
 
 	if currentHash == integrity.OriginalHash {
@@ -0,0 +1,116 @@
+package metadata
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// trackFiles installs count files under installDir and tracks them
+func trackFiles(t *testing.T, m *MetadataManager, count int) {
+	t.Helper()
+	if err := m.SaveMetadata(m.createEmptyMetadata()); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < count; i++ {
+		rel := fmt.Sprintf("agents/agent-%d.md", i)
+		path := filepath.Join(m.installDir, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(rel), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := m.AddFileToIntegrityTracking(rel, "agents"); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestCheckFileIntegrityWithWorkers(t *testing.T) {
+	m := NewMetadataManager(t.TempDir())
+	trackFiles(t, m, 20)
+	if err := os.WriteFile(filepath.Join(m.installDir, "agents/agent-3.md"), []byte("edited"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(filepath.Join(m.installDir, "agents/agent-7.md")); err != nil {
+		t.Fatal(err)
+	}
+
+	integrity, err := m.CheckFileIntegrityWith(IntegrityOptions{Workers: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if integrity.TotalFiles != 20 || integrity.CleanFiles != 18 || integrity.ModifiedFiles != 1 || integrity.MissingFiles != 1 {
+		t.Errorf("Unexpected counts: %d total, %d clean, %d modified, %d missing",
+			integrity.TotalFiles, integrity.CleanFiles, integrity.ModifiedFiles, integrity.MissingFiles)
+	}
+	if status := integrity.FileHashes["agents/agent-3.md"].Status; status != "modified" {
+		t.Errorf("Expected the edited file to be modified, got %s", status)
+	}
+}
+
+func TestFastCheckTrustsUnchangedFiles(t *testing.T) {
+	m := NewMetadataManager(t.TempDir())
+	trackFiles(t, m, 2)
+	if _, err := m.CheckFileIntegrity(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Same size and modification time: fast mode does not notice the edit
+	path := filepath.Join(m.installDir, "agents/agent-0.md")
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("agents/agent-X.md"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, info.ModTime(), info.ModTime()); err != nil {
+		t.Fatal(err)
+	}
+	integrity, err := m.CheckFileIntegrityWith(IntegrityOptions{Fast: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if integrity.ModifiedFiles != 0 {
+		t.Errorf("Expected fast mode to reuse the last hash, got %d modified", integrity.ModifiedFiles)
+	}
+
+	// A new modification time makes fast mode hash the file again
+	later := info.ModTime().Add(time.Minute)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatal(err)
+	}
+	if integrity, err = m.CheckFileIntegrityWith(IntegrityOptions{Fast: true}); err != nil {
+		t.Fatal(err)
+	}
+	if integrity.ModifiedFiles != 1 {
+		t.Errorf("Expected the touched file to be hashed and found modified, got %d", integrity.ModifiedFiles)
+	}
+}
+
+func TestCheckFileIntegritySkipsCrewignore(t *testing.T) {
+	m := NewMetadataManager(t.TempDir())
+	trackFiles(t, m, 3)
+	if err := os.Remove(filepath.Join(m.installDir, "agents/agent-1.md")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(m.installDir, ".crewignore"), []byte("agent-1.md\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	integrity, err := m.CheckFileIntegrity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if integrity.TotalFiles != 2 || integrity.MissingFiles != 0 || integrity.Status != "clean" {
+		t.Errorf("Expected the ignored file to be skipped, got %d total, %d missing, %s",
+			integrity.TotalFiles, integrity.MissingFiles, integrity.Status)
+	}
+	if _, ok := integrity.FileHashes["agents/agent-1.md"]; !ok {
+		t.Error("The ignored file should stay tracked")
+	}
+}
@@ -0,0 +1,357 @@
+// Package migrations carries the steps that bring an installation along
+// when a release removes or renames components. Each migration names the
+// framework version that made the change and the files or components it
+// moves, renames or removes; crew update applies the ones an installation
+// has not seen yet, so no stale component is left behind.
+//
+// The catalog is embedded from migrations.json. An entry looks like:
+//
+//	{
+//	  "id": "1.1.0-personas",
+//	  "version": "1.1.0",
+//	  "description": "Agents were split into personas",
+//	  "steps": [
+//	    {"action": "move", "from": "agents/analyzer.md", "to": "agents/analyzer-persona.md"},
+//	    {"action": "remove", "from": "commands/crew/legacy.md"},
+//	    {"action": "rename-component", "from": "personas", "to": "agents"}
+//	  ]
+//	}
+package migrations
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/fileops"
+	"github.com/jonwraymond/claude-code-super-crew/internal/metadata"
+	"github.com/jonwraymond/claude-code-super-crew/internal/semver"
+)
+
+// Step actions
+const (
+	// Move moves a file or directory, relative to the install directory
+	Move = "move"
+	// Remove deletes a file or directory the release no longer ships
+	Remove = "remove"
+	// RenameComponent renames a component in the installation metadata
+	RenameComponent = "rename-component"
+)
+
+// Outcome statuses
+const (
+	Applied = "applied"
+	Skipped = "skipped"
+	Kept    = "kept"
+)
+
+//go:embed migrations.json
+var catalog []byte
+
+// Step is one change a migration makes
+type Step struct {
+	Action string `json:"action"`
+	From   string `json:"from"`
+	To     string `json:"to,omitempty"`
+}
+
+// Migration is the steps that follow one release's removals and renames
+type Migration struct {
+	ID          string `json:"id"`
+	Version     string `json:"version"`
+	Description string `json:"description"`
+	Steps       []Step `json:"steps"`
+}
+
+// Outcome reports what applying one step did
+type Outcome struct {
+	Migration string `json:"migration"`
+	Step
+	Status string `json:"status"`
+	// Detail says why a step was skipped or its files kept
+	Detail string `json:"detail,omitempty"`
+}
+
+// Builtin returns the migrations this crew binary carries, oldest first
+func Builtin() ([]Migration, error) {
+	return Parse(catalog)
+}
+
+// Parse reads and validates a migration catalog, sorting it oldest first
+func Parse(data []byte) ([]Migration, error) {
+	var list []Migration
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("invalid migration catalog: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	for i, m := range list {
+		if m.ID == "" || seen[m.ID] {
+			return nil, fmt.Errorf("migration %q: missing or duplicate id", m.ID)
+		}
+		seen[m.ID] = true
+		if !semver.Valid(m.Version) {
+			return nil, fmt.Errorf("migration %s: invalid version %q", m.ID, m.Version)
+		}
+		for j, step := range m.Steps {
+			if err := step.validate(); err != nil {
+				return nil, fmt.Errorf("migration %s: %w", m.ID, err)
+			}
+			if step.Action != RenameComponent {
+				list[i].Steps[j].From = path.Clean(step.From)
+				if step.To != "" {
+					list[i].Steps[j].To = path.Clean(step.To)
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(list, func(i, j int) bool {
+		return semver.Compare(list[i].Version, list[j].Version) < 0
+	})
+	return list, nil
+}
+
+func (s Step) validate() error {
+	switch s.Action {
+	case Move, RenameComponent:
+		if s.From == "" || s.To == "" {
+			return fmt.Errorf("%s needs from and to", s.Action)
+		}
+	case Remove:
+		if s.From == "" {
+			return fmt.Errorf("remove needs from")
+		}
+	default:
+		return fmt.Errorf("unknown action %q", s.Action)
+	}
+	if s.Action != RenameComponent {
+		for _, p := range []string{s.From, s.To} {
+			if p != "" && (path.IsAbs(p) || strings.HasPrefix(path.Clean(p), "..")) {
+				return fmt.Errorf("path %q must stay inside the install directory", p)
+			}
+		}
+	}
+	return nil
+}
+
+// Pending returns the migrations an installation at version installed still
+// needs: those of later releases that are not in applied. An installation
+// without a recorded version gets every migration not yet applied.
+func Pending(list []Migration, installed string, applied []string) []Migration {
+	done := make(map[string]bool)
+	for _, id := range applied {
+		done[id] = true
+	}
+
+	var pending []Migration
+	for _, m := range list {
+		if done[m.ID] {
+			continue
+		}
+		if semver.Valid(installed) && semver.Compare(m.Version, installed) <= 0 {
+			continue
+		}
+		pending = append(pending, m)
+	}
+	return pending
+}
+
+// Apply runs the migrations against installDir and updates meta to match:
+// moved files keep their document and integrity records, removed ones
+// lose them and renamed components keep their versions. A file the user
+// changed since it was installed is never removed or overwritten; it is
+// kept and reported. With dryRun nothing is changed and the outcomes say
+// what would be done. The caller saves meta.
+func Apply(installDir string, meta *metadata.UnifiedMetadata, list []Migration, dryRun bool) ([]Outcome, error) {
+	var outcomes []Outcome
+	for _, m := range list {
+		for _, step := range m.Steps {
+			outcome := Outcome{Migration: m.ID, Step: step}
+			var err error
+			switch step.Action {
+			case Move:
+				outcome.Status, outcome.Detail, err = move(installDir, meta, step, dryRun)
+			case Remove:
+				outcome.Status, outcome.Detail, err = remove(installDir, meta, step, dryRun)
+			case RenameComponent:
+				outcome.Status, outcome.Detail = renameComponent(meta, step, dryRun)
+			}
+			if err != nil {
+				return outcomes, fmt.Errorf("migration %s: %s %s: %w", m.ID, step.Action, step.From, err)
+			}
+			outcomes = append(outcomes, outcome)
+		}
+		if !dryRun {
+			meta.Installation.Migrations = append(meta.Installation.Migrations, m.ID)
+		}
+	}
+	return outcomes, nil
+}
+
+func move(installDir string, meta *metadata.UnifiedMetadata, step Step, dryRun bool) (string, string, error) {
+	from := filepath.Join(installDir, filepath.FromSlash(step.From))
+	to := filepath.Join(installDir, filepath.FromSlash(step.To))
+
+	if _, err := os.Lstat(from); os.IsNotExist(err) {
+		return Skipped, "not installed", nil
+	}
+	if _, err := os.Lstat(to); err == nil {
+		// The release already installed the new file; the old one goes
+		// unless the user changed it
+		if modified(installDir, meta, step.From) {
+			return Kept, step.To + " already exists and " + step.From + " has local changes", nil
+		}
+		if dryRun {
+			return Applied, step.To + " already exists; " + step.From + " would be removed", nil
+		}
+		if err := os.RemoveAll(from); err != nil {
+			return "", "", err
+		}
+		forget(meta, step.From)
+		return Applied, step.To + " already exists; removed " + step.From, nil
+	}
+
+	if dryRun {
+		return Applied, "", nil
+	}
+	if err := os.MkdirAll(filepath.Dir(to), 0755); err != nil {
+		return "", "", err
+	}
+	if err := os.Rename(from, to); err != nil {
+		return "", "", err
+	}
+	rekey(meta, step.From, step.To)
+	return Applied, "", nil
+}
+
+func remove(installDir string, meta *metadata.UnifiedMetadata, step Step, dryRun bool) (string, string, error) {
+	target := filepath.Join(installDir, filepath.FromSlash(step.From))
+	if _, err := os.Lstat(target); os.IsNotExist(err) {
+		return Skipped, "not installed", nil
+	}
+	if modified(installDir, meta, step.From) {
+		return Kept, "has local changes; remove it when you no longer need it", nil
+	}
+	if dryRun {
+		return Applied, "", nil
+	}
+	if err := os.RemoveAll(target); err != nil {
+		return "", "", err
+	}
+	forget(meta, step.From)
+	return Applied, "", nil
+}
+
+func renameComponent(meta *metadata.UnifiedMetadata, step Step, dryRun bool) (string, string) {
+	comp, ok := meta.Components[step.From]
+	if !ok {
+		return Skipped, "not installed"
+	}
+	_, exists := meta.Components[step.To]
+	if dryRun {
+		return Applied, ""
+	}
+
+	delete(meta.Components, step.From)
+	if exists {
+		return Applied, step.To + " is already installed; dropped " + step.From
+	}
+	meta.Components[step.To] = comp
+	for name, doc := range meta.Documents {
+		if doc.Component == step.From {
+			doc.Component = step.To
+			meta.Documents[name] = doc
+		}
+	}
+	for name, integrity := range meta.Integrity.FileHashes {
+		if integrity.Component == step.From {
+			integrity.Component = step.To
+			meta.Integrity.FileHashes[name] = integrity
+		}
+	}
+	return Applied, ""
+}
+
+// modified reports whether a file or any file under a directory differs
+// from what crew installed. Files crew does not track count as unchanged.
+func modified(installDir string, meta *metadata.UnifiedMetadata, rel string) bool {
+	for name, integrity := range meta.Integrity.FileHashes {
+		if !under(name, rel) || integrity.OriginalHash == "" {
+			continue
+		}
+		hash, err := fileops.HashFile(filepath.Join(installDir, filepath.FromSlash(name)))
+		if err == nil && hash != integrity.OriginalHash {
+			return true
+		}
+	}
+	return false
+}
+
+// rekey moves every metadata record at or under from to the same place
+// under to
+func rekey(meta *metadata.UnifiedMetadata, from, to string) {
+	for name, doc := range meta.Documents {
+		if under(name, from) {
+			delete(meta.Documents, name)
+			meta.Documents[to+strings.TrimPrefix(filepath.ToSlash(name), from)] = doc
+		}
+	}
+	for name, integrity := range meta.Integrity.FileHashes {
+		if under(name, from) {
+			delete(meta.Integrity.FileHashes, name)
+			name = to + strings.TrimPrefix(filepath.ToSlash(name), from)
+			integrity.FilePath = name
+			meta.Integrity.FileHashes[name] = integrity
+		}
+	}
+	for i, name := range meta.Inventory.CreatedFiles {
+		if under(name, from) {
+			meta.Inventory.CreatedFiles[i] = to + strings.TrimPrefix(filepath.ToSlash(name), from)
+		}
+	}
+	for i, name := range meta.Inventory.CreatedDirectories {
+		if under(name, from) {
+			meta.Inventory.CreatedDirectories[i] = to + strings.TrimPrefix(filepath.ToSlash(name), from)
+		}
+	}
+}
+
+// forget drops every metadata record at or under rel
+func forget(meta *metadata.UnifiedMetadata, rel string) {
+	for name := range meta.Documents {
+		if under(name, rel) {
+			delete(meta.Documents, name)
+		}
+	}
+	for name := range meta.Integrity.FileHashes {
+		if under(name, rel) {
+			delete(meta.Integrity.FileHashes, name)
+		}
+	}
+	meta.Inventory.CreatedFiles = without(meta.Inventory.CreatedFiles, rel)
+	meta.Inventory.CreatedDirectories = without(meta.Inventory.CreatedDirectories, rel)
+	meta.Inventory.TotalCreatedFiles = len(meta.Inventory.CreatedFiles)
+	meta.Inventory.TotalCreatedDirs = len(meta.Inventory.CreatedDirectories)
+}
+
+func without(names []string, rel string) []string {
+	var kept []string
+	for _, name := range names {
+		if !under(name, rel) {
+			kept = append(kept, name)
+		}
+	}
+	return kept
+}
+
+// under reports whether the metadata path name is rel or inside it
+func under(name, rel string) bool {
+	name = filepath.ToSlash(name)
+	return name == rel || strings.HasPrefix(name, strings.TrimSuffix(rel, "/")+"/")
+}
@@ -0,0 +1,144 @@
+package migrations
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/fileops"
+	"github.com/jonwraymond/claude-code-super-crew/internal/metadata"
+)
+
+func TestBuiltinCatalogParses(t *testing.T) {
+	if _, err := Builtin(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestParseRejectsInvalidSteps(t *testing.T) {
+	for _, catalog := range []string{
+		`[{"id": "a", "version": "1.1.0", "steps": [{"action": "copy", "from": "x"}]}]`,
+		`[{"id": "a", "version": "1.1.0", "steps": [{"action": "move", "from": "x"}]}]`,
+		`[{"id": "a", "version": "1.1.0", "steps": [{"action": "remove", "from": "../x"}]}]`,
+		`[{"id": "a", "version": "next", "steps": []}]`,
+		`[{"id": "a", "version": "1.1.0"}, {"id": "a", "version": "1.2.0"}]`,
+	} {
+		if _, err := Parse([]byte(catalog)); err == nil {
+			t.Errorf("Expected an error for %s", catalog)
+		}
+	}
+}
+
+func TestPending(t *testing.T) {
+	list, err := Parse([]byte(`[
+		{"id": "two", "version": "1.2.0", "steps": []},
+		{"id": "one", "version": "1.1.0", "steps": []},
+		{"id": "three", "version": "1.3.0", "steps": []}
+	]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ids := func(list []Migration) string {
+		var names []string
+		for _, m := range list {
+			names = append(names, m.ID)
+		}
+		return strings.Join(names, ",")
+	}
+	if got := ids(Pending(list, "1.1.0", nil)); got != "two,three" {
+		t.Errorf("Pending from 1.1.0 = %s", got)
+	}
+	if got := ids(Pending(list, "", []string{"two"})); got != "one,three" {
+		t.Errorf("Pending without a version = %s", got)
+	}
+}
+
+func TestApply(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"agents/analyzer.md":       "analyzer\n",
+		"agents/scribe.md":         "scribe\n",
+		"agents/scribe-persona.md": "scribe persona\n",
+		"commands/crew/old.md":     "old\n",
+		"commands/crew/mine.md":    "installed\n",
+	} {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		os.MkdirAll(filepath.Dir(path), 0755)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	meta := &metadata.UnifiedMetadata{
+		Components: map[string]metadata.ComponentMeta{"personas": {Version: "1.0.0"}},
+		Documents:  map[string]metadata.DocumentMeta{"agents/analyzer.md": {Component: "personas"}},
+		Integrity:  metadata.IntegrityMeta{FileHashes: map[string]metadata.FileIntegrityMeta{}},
+	}
+	for _, name := range []string{"agents/analyzer.md", "commands/crew/old.md", "commands/crew/mine.md"} {
+		hash, _ := fileops.HashFile(filepath.Join(dir, name))
+		meta.Integrity.FileHashes[name] = metadata.FileIntegrityMeta{OriginalHash: hash, FilePath: name, Component: "personas"}
+	}
+	// The user edited mine.md after it was installed
+	os.WriteFile(filepath.Join(dir, "commands", "crew", "mine.md"), []byte("edited\n"), 0644)
+
+	list, err := Parse([]byte(`[{"id": "1.1.0-personas", "version": "1.1.0", "steps": [
+		{"action": "move", "from": "agents/analyzer.md", "to": "agents/analyzer-persona.md"},
+		{"action": "move", "from": "agents/scribe.md", "to": "agents/scribe-persona.md"},
+		{"action": "move", "from": "agents/gone.md", "to": "agents/gone-persona.md"},
+		{"action": "remove", "from": "commands/crew/old.md"},
+		{"action": "remove", "from": "commands/crew/mine.md"},
+		{"action": "rename-component", "from": "personas", "to": "agents"}
+	]}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Apply(dir, meta, list, true); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "agents", "analyzer.md")); err != nil || len(meta.Installation.Migrations) != 0 {
+		t.Fatal("A dry run should change nothing")
+	}
+
+	outcomes, err := Apply(dir, meta, list, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var statuses []string
+	for _, outcome := range outcomes {
+		statuses = append(statuses, outcome.Status)
+	}
+	if got := strings.Join(statuses, ","); got != "applied,applied,skipped,applied,kept,applied" {
+		t.Errorf("Statuses = %s", got)
+	}
+
+	for name, want := range map[string]bool{
+		"agents/analyzer-persona.md": true,
+		"agents/analyzer.md":         false,
+		"agents/scribe.md":           false,
+		"commands/crew/old.md":       false,
+		"commands/crew/mine.md":      true,
+	} {
+		if _, err := os.Stat(filepath.Join(dir, name)); (err == nil) != want {
+			t.Errorf("%s exists = %v, expected %v", name, err == nil, want)
+		}
+	}
+
+	if doc, ok := meta.Documents["agents/analyzer-persona.md"]; !ok || doc.Component != "agents" {
+		t.Errorf("Document record not moved: %v", meta.Documents)
+	}
+	if integrity, ok := meta.Integrity.FileHashes["agents/analyzer-persona.md"]; !ok || integrity.FilePath != "agents/analyzer-persona.md" {
+		t.Errorf("Integrity record not moved: %v", meta.Integrity.FileHashes)
+	}
+	if _, ok := meta.Integrity.FileHashes["commands/crew/old.md"]; ok {
+		t.Error("Removed file is still tracked")
+	}
+	if _, ok := meta.Components["agents"]; !ok || len(meta.Components) != 1 {
+		t.Errorf("Component not renamed: %v", meta.Components)
+	}
+	if len(meta.Installation.Migrations) != 1 || meta.Installation.Migrations[0] != "1.1.0-personas" {
+		t.Errorf("Applied migrations = %v", meta.Installation.Migrations)
+	}
+}
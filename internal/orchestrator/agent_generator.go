@@ -7,6 +7,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/jonwraymond/claude-code-super-crew/internal/agents"
+	"github.com/jonwraymond/claude-code-super-crew/internal/pathsutil"
 	"github.com/jonwraymond/claude-code-super-crew/pkg/logger"
 )
 
@@ -27,7 +29,7 @@ func NewAgentGenerator(projectPath string) *AgentGenerator {
 // GenerateAgents generates agent configuration files based on project characteristics
 func (ag *AgentGenerator) GenerateAgents(chars *ProjectCharacteristics) error {
 	// Create local .claude/agents directory
-	agentsDir := filepath.Join(ag.projectPath, ".claude", "agents")
+	agentsDir := pathsutil.AgentsDir(ag.projectPath)
 	if err := os.MkdirAll(agentsDir, 0755); err != nil {
 		return fmt.Errorf("failed to create agents directory: %w", err)
 	}
@@ -56,6 +58,15 @@ func (ag *AgentGenerator) GenerateAgents(chars *ProjectCharacteristics) error {
 		ag.logger.Info(fmt.Sprintf("Generated agent: %s", agentType))
 	}
 
+	// Check what was written against the subagent schema Claude Code loads
+	invalid := false
+	for _, agentType := range generatedAgents {
+		for _, issue := range ag.validateAgent(filepath.Join(agentsDir, agentType+".md")) {
+			ag.logger.Warn(fmt.Sprintf("Generated agent %s: %s", agentType, issue.Message))
+			invalid = invalid || issue.Severity == agents.SeverityError
+		}
+	}
+
 	// Log summary
 	if len(generatedAgents) > 0 {
 		ag.logger.Success(fmt.Sprintf("Project-specific agents created in .claude/agents/: %s",
@@ -64,9 +75,21 @@ func (ag *AgentGenerator) GenerateAgents(chars *ProjectCharacteristics) error {
 		ag.logger.Info("No new agents generated (may already exist)")
 	}
 
+	if invalid {
+		return fmt.Errorf("generated agents do not pass validation")
+	}
 	return nil
 }
 
+// validateAgent parses and validates the agent file at path
+func (ag *AgentGenerator) validateAgent(path string) []agents.Issue {
+	agent, err := agents.ParseFile(path)
+	if err != nil {
+		return []agents.Issue{{Path: path, Severity: agents.SeverityError, Message: err.Error()}}
+	}
+	return agents.ValidateAgent(agent)
+}
+
 // generateAgentContent generates the content for a specific agent type
 func (ag *AgentGenerator) generateAgentContent(agentType string, chars *ProjectCharacteristics) string {
 	// Get visual identity for this agent type
@@ -86,11 +109,12 @@ visual_identity:
   background_color: "%s"
   text_color: "%s"
 tags: ["%s", "project-specific", "auto-generated"]
+source_patterns: %s
 activation_keywords:
   primary: %s
   secondary: %s
   contextual: %s
-slash_commands: %s
+slash_commands:%s
 tools:
   - Read
   - Write
@@ -189,6 +213,7 @@ Remember: You are specifically tuned for this project's needs. Always consider t
 		bgColor,
 		textColor,
 		tag,
+		formatFrameworks(SpecialistSources(agentType)),
 		primaryKeywords,
 		secondaryKeywords,
 		contextualKeywords,
@@ -609,8 +634,9 @@ func (ag *AgentGenerator) getSlashCommands(agentType string, chars *ProjectChara
 	}
 
 	if len(commands) == 0 {
-		return "[]"
+		return " []"
 	}
 
-	return "[\n" + strings.Join(commands, "\n") + "\n]"
+	// A block list under the key; wrapping it in brackets is not YAML
+	return "\n" + strings.Join(commands, "\n")
 }
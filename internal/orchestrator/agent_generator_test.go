@@ -68,6 +68,11 @@ func TestAgentGenerator_GenerateAgents(t *testing.T) {
 			t.Errorf("Agent %s missing name in frontmatter", agentType)
 		}
 
+		// Check the files that justify the agent are recorded
+		if !strings.Contains(contentStr, "source_patterns: "+formatFrameworks(SpecialistSources(agentType))) || len(SpecialistSources(agentType)) == 0 {
+			t.Errorf("Agent %s missing source_patterns", agentType)
+		}
+
 		// Check language reference
 		if !strings.Contains(contentStr, "language: \"go\"") {
 			t.Errorf("Agent %s missing correct language", agentType)
@@ -0,0 +1,414 @@
+package orchestrator
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/gitrepo"
+	"github.com/jonwraymond/claude-code-super-crew/internal/pathsutil"
+)
+
+// AnalysisFile is the file in the project's agents directory the analysis
+// is written to, shared with the template /crew:onboard has Claude fill in
+const AnalysisFile = "project-analysis.json"
+
+// maxScannedFileSize is the largest file read for lines and TODOs
+const maxScannedFileSize = 2 << 20
+
+// sourceExtensions maps the extensions of source files to their language
+var sourceExtensions = map[string]string{
+	".go":    "go",
+	".js":    "javascript",
+	".jsx":   "javascript",
+	".mjs":   "javascript",
+	".cjs":   "javascript",
+	".ts":    "typescript",
+	".tsx":   "typescript",
+	".py":    "python",
+	".rs":    "rust",
+	".java":  "java",
+	".kt":    "kotlin",
+	".rb":    "ruby",
+	".php":   "php",
+	".cs":    "csharp",
+	".c":     "c",
+	".h":     "c",
+	".cpp":   "cpp",
+	".hpp":   "cpp",
+	".swift": "swift",
+	".vue":   "vue",
+	".sh":    "shell",
+}
+
+// skippedDirs are never scanned: dependencies, build output and tool state
+var skippedDirs = map[string]bool{
+	"node_modules": true,
+	"vendor":       true,
+	"dist":         true,
+	"build":        true,
+	"target":       true,
+	"__pycache__":  true,
+	"venv":         true,
+}
+
+// todoPattern matches the markers counted as TODOs
+var todoPattern = regexp.MustCompile(`\b(TODO|FIXME|HACK|XXX)\b`)
+
+// AnalysisReport is the static analysis crew analyze writes to
+// .claude/agents/project-analysis.json
+type AnalysisReport struct {
+	AnalysisVersion string          `json:"analysis_version"`
+	AnalyzedBy      string          `json:"analyzed_by"`
+	AnalysisDate    string          `json:"analysis_date"`
+	ProjectPath     string          `json:"project_path"`
+	Languages       []LanguageStats `json:"languages"`
+	Frameworks      []string        `json:"frameworks"`
+	Traits          []string        `json:"traits"`
+	// Hotspots are the largest and most changed source files
+	Hotspots []Hotspot    `json:"hotspots"`
+	Testing  TestingStats `json:"testing"`
+	TODOs    TODOStats    `json:"todos"`
+	// History is false when the project is not in a git repository and
+	// hotspots are ranked by size alone
+	History           bool        `json:"history"`
+	Git               *GitSignals `json:"git,omitempty"`
+	RecommendedAgents []string    `json:"recommended_agents"`
+	TotalFiles        int         `json:"total_files"`
+	TotalLines        int         `json:"total_lines"`
+}
+
+// LanguageStats counts the source files of a language
+type LanguageStats struct {
+	Name      string `json:"name"`
+	FileCount int    `json:"file_count"`
+	Lines     int    `json:"lines"`
+	Primary   bool   `json:"primary"`
+}
+
+// Hotspot is a source file likely to need attention. Score is its lines
+// times one more than the commits that changed it.
+type Hotspot struct {
+	Path    string `json:"path"`
+	Lines   int    `json:"lines"`
+	Changes int    `json:"changes"`
+	Score   int    `json:"score"`
+}
+
+// TestingStats tells whether the project's code has tests next to it
+type TestingStats struct {
+	TestFiles   int `json:"test_files"`
+	SourceFiles int `json:"source_files"`
+	// UntestedDirs are directories with source files and no test files,
+	// largest first
+	UntestedDirs []string `json:"untested_dirs"`
+}
+
+// TODOStats counts TODO, FIXME, HACK and XXX markers
+type TODOStats struct {
+	Count int `json:"count"`
+	// PerKLOC is the number of markers per thousand source lines
+	PerKLOC float64 `json:"per_kloc"`
+	// Files are the files with the most markers
+	Files []FileCount `json:"files"`
+}
+
+// FileCount is a count for one file
+type FileCount struct {
+	Path  string `json:"path"`
+	Count int    `json:"count"`
+}
+
+// sourceFile is what the scan learns about one source file
+type sourceFile struct {
+	path     string
+	language string
+	lines    int
+	todos    int
+	test     bool
+}
+
+// isTestFile reports whether a source file holds tests, by the naming
+// conventions of its language
+func isTestFile(rel string) bool {
+	base := path.Base(rel)
+	switch {
+	case strings.HasSuffix(base, "_test.go"),
+		strings.Contains(base, ".test."), strings.Contains(base, ".spec."),
+		strings.HasPrefix(base, "test_") && strings.HasSuffix(base, ".py"),
+		strings.HasSuffix(base, "_test.py"),
+		strings.HasSuffix(base, "Test.java"), strings.HasSuffix(base, "Tests.cs"):
+		return true
+	}
+	for _, dir := range strings.Split(path.Dir(rel), "/") {
+		if dir == "__tests__" || dir == "tests" || dir == "test" {
+			return true
+		}
+	}
+	return false
+}
+
+// Report analyzes the project statically: the characteristics Analyze
+// finds plus languages, hotspots, tests and TODOs. top limits the
+// hotspots, untested directories and TODO files listed.
+func (pa *ProjectAnalyzer) Report(top int) (*AnalysisReport, error) {
+	chars, err := pa.Analyze()
+	if err != nil {
+		return nil, err
+	}
+	files, err := pa.scanSources()
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan project: %w", err)
+	}
+
+	report := &AnalysisReport{
+		AnalysisVersion:   "2.0",
+		AnalyzedBy:        "crew",
+		AnalysisDate:      time.Now().Format(time.RFC3339),
+		ProjectPath:       pa.rootPath,
+		Languages:         []LanguageStats{},
+		Frameworks:        chars.Frameworks,
+		Traits:            chars.Traits(),
+		Hotspots:          []Hotspot{},
+		RecommendedAgents: chars.DetectedAgents,
+		TotalFiles:        len(files),
+	}
+	if report.Traits == nil {
+		report.Traits = []string{}
+	}
+
+	var changes map[string]int
+	if chars.Git != nil {
+		changes = chars.Git.changes
+		report.History = true
+		report.Git = chars.Git
+	}
+
+	languages := map[string]*LanguageStats{}
+	dirs := map[string]*struct{ sources, tests, lines int }{}
+	var todoFiles []FileCount
+	for _, f := range files {
+		report.TotalLines += f.lines
+		stats, ok := languages[f.language]
+		if !ok {
+			stats = &LanguageStats{Name: f.language}
+			languages[f.language] = stats
+		}
+		stats.FileCount++
+		stats.Lines += f.lines
+
+		dir := dirs[path.Dir(f.path)]
+		if dir == nil {
+			dir = &struct{ sources, tests, lines int }{}
+			dirs[path.Dir(f.path)] = dir
+		}
+		if f.test {
+			report.Testing.TestFiles++
+			dir.tests++
+		} else {
+			report.Testing.SourceFiles++
+			dir.sources++
+			dir.lines += f.lines
+			report.Hotspots = append(report.Hotspots, Hotspot{
+				Path:    f.path,
+				Lines:   f.lines,
+				Changes: changes[f.path],
+				Score:   f.lines * (changes[f.path] + 1),
+			})
+		}
+
+		if f.todos > 0 {
+			report.TODOs.Count += f.todos
+			todoFiles = append(todoFiles, FileCount{Path: f.path, Count: f.todos})
+		}
+	}
+
+	for _, stats := range languages {
+		report.Languages = append(report.Languages, *stats)
+	}
+	sort.Slice(report.Languages, func(i, j int) bool {
+		if report.Languages[i].Lines != report.Languages[j].Lines {
+			return report.Languages[i].Lines > report.Languages[j].Lines
+		}
+		return report.Languages[i].Name < report.Languages[j].Name
+	})
+	if len(report.Languages) > 0 {
+		report.Languages[0].Primary = true
+	}
+
+	sort.Slice(report.Hotspots, func(i, j int) bool {
+		if report.Hotspots[i].Score != report.Hotspots[j].Score {
+			return report.Hotspots[i].Score > report.Hotspots[j].Score
+		}
+		return report.Hotspots[i].Path < report.Hotspots[j].Path
+	})
+	report.Hotspots = limitHotspots(report.Hotspots, top)
+
+	var untested []string
+	for name, dir := range dirs {
+		if dir.sources > 0 && dir.tests == 0 {
+			untested = append(untested, name)
+		}
+	}
+	sort.Slice(untested, func(i, j int) bool {
+		if dirs[untested[i]].lines != dirs[untested[j]].lines {
+			return dirs[untested[i]].lines > dirs[untested[j]].lines
+		}
+		return untested[i] < untested[j]
+	})
+	report.Testing.UntestedDirs = limitStrings(untested, top)
+
+	if report.TotalLines > 0 {
+		report.TODOs.PerKLOC = float64(report.TODOs.Count) * 1000 / float64(report.TotalLines)
+	}
+	sort.Slice(todoFiles, func(i, j int) bool {
+		if todoFiles[i].Count != todoFiles[j].Count {
+			return todoFiles[i].Count > todoFiles[j].Count
+		}
+		return todoFiles[i].Path < todoFiles[j].Path
+	})
+	if top > 0 && len(todoFiles) > top {
+		todoFiles = todoFiles[:top]
+	}
+	report.TODOs.Files = todoFiles
+	if report.TODOs.Files == nil {
+		report.TODOs.Files = []FileCount{}
+	}
+
+	return report, nil
+}
+
+func limitHotspots(hotspots []Hotspot, top int) []Hotspot {
+	if top > 0 && len(hotspots) > top {
+		return hotspots[:top]
+	}
+	return hotspots
+}
+
+func limitStrings(s []string, top int) []string {
+	if s == nil {
+		return []string{}
+	}
+	if top > 0 && len(s) > top {
+		return s[:top]
+	}
+	return s
+}
+
+// fingerprintedHiddenPaths are the hidden paths Analyze looks at, which
+// the walk in Fingerprint skips like scanSources does
+var fingerprintedHiddenPaths = []string{".github/workflows", ".gitlab-ci.yml", ".circleci", ".k8s"}
+
+// Fingerprint identifies the state Report reads: the name, size and
+// modification time of every file it may look at, and the commit HEAD
+// points at. It changes whenever a report of the project could.
+func (pa *ProjectAnalyzer) Fingerprint() (string, error) {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\n", pa.rootPath)
+	err := filepath.WalkDir(pa.rootPath, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if p != pa.rootPath && (strings.HasPrefix(d.Name(), ".") || skippedDirs[d.Name()]) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		rel, _ := filepath.Rel(pa.rootPath, p)
+		fmt.Fprintf(h, "%s %d %d\n", filepath.ToSlash(rel), info.Size(), info.ModTime().UnixNano())
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	for _, hidden := range fingerprintedHiddenPaths {
+		if info, err := os.Stat(filepath.Join(pa.rootPath, filepath.FromSlash(hidden))); err == nil {
+			fmt.Fprintf(h, "%s %d\n", hidden, info.ModTime().UnixNano())
+		}
+	}
+	if repo, err := gitrepo.Open(pa.rootPath); err == nil {
+		fmt.Fprintf(h, "HEAD %s\n", repo.Head())
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// scanSources reads the project's source files, skipping hidden
+// directories, dependencies and build output
+func (pa *ProjectAnalyzer) scanSources() ([]sourceFile, error) {
+	var files []sourceFile
+	err := filepath.WalkDir(pa.rootPath, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		name := d.Name()
+		if d.IsDir() {
+			if p != pa.rootPath && (strings.HasPrefix(name, ".") || skippedDirs[name]) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		language, ok := sourceExtensions[strings.ToLower(filepath.Ext(name))]
+		if !ok || !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(pa.rootPath, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		f := sourceFile{path: rel, language: language, test: isTestFile(rel)}
+		if info, err := d.Info(); err == nil && info.Size() <= maxScannedFileSize {
+			if data, err := os.ReadFile(p); err == nil {
+				f.lines, f.todos = countLines(data)
+			}
+		}
+		files = append(files, f)
+		return nil
+	})
+	return files, err
+}
+
+// countLines counts the lines of data and the TODO markers in them
+func countLines(data []byte) (lines, todos int) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), maxScannedFileSize)
+	for scanner.Scan() {
+		lines++
+		todos += len(todoPattern.FindAllIndex(scanner.Bytes(), -1))
+	}
+	return lines, todos
+}
+
+// WriteAnalysis writes the report to .claude/agents/project-analysis.json
+// of the project and returns the path
+func WriteAnalysis(projectRoot string, report *AnalysisReport) (string, error) {
+	agentsDir := pathsutil.AgentsDir(projectRoot)
+	if err := os.MkdirAll(agentsDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", agentsDir, err)
+	}
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	analysisPath := filepath.Join(agentsDir, AnalysisFile)
+	if err := os.WriteFile(analysisPath, append(data, '\n'), 0644); err != nil {
+		return "", fmt.Errorf("failed to write analysis: %w", err)
+	}
+	return analysisPath, nil
+}
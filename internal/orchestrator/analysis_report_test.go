@@ -0,0 +1,101 @@
+package orchestrator
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReport(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"go.mod":                    "module x\n",
+		"main.go":                   "package main\n\n// TODO: flags\nfunc main() {}\n",
+		"internal/api/api.go":       "package api\n\n// FIXME one\n// XXX two\n" + strings.Repeat("\n", 20),
+		"internal/api/api_test.go":  "package api\n",
+		"web/app.ts":                "export const a = 1\n",
+		"node_modules/dep/index.js": "// TODO ignored\n",
+		".git/hooks/pre-commit.sh":  "# TODO ignored\n",
+	} {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	report, err := NewProjectAnalyzer(dir).Report(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.TotalFiles != 4 || len(report.Languages) != 2 || report.Languages[0].Name != "go" || !report.Languages[0].Primary {
+		t.Errorf("unexpected files and languages: %d %+v", report.TotalFiles, report.Languages)
+	}
+	if report.History {
+		t.Error("Expected no history outside a git repository")
+	}
+	var hotspots []string
+	for _, h := range report.Hotspots {
+		hotspots = append(hotspots, h.Path)
+	}
+	if !reflect.DeepEqual(hotspots, []string{"internal/api/api.go", "main.go"}) {
+		t.Errorf("Hotspots = %v", hotspots)
+	}
+	if report.Testing.TestFiles != 1 || report.Testing.SourceFiles != 3 || !reflect.DeepEqual(report.Testing.UntestedDirs, []string{".", "web"}) {
+		t.Errorf("Testing = %+v", report.Testing)
+	}
+	if report.TODOs.Count != 3 || report.TODOs.Files[0] != (FileCount{Path: "internal/api/api.go", Count: 2}) {
+		t.Errorf("TODOs = %+v", report.TODOs)
+	}
+
+	path, err := WriteAnalysis(dir, report)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var written AnalysisReport
+	if err := json.Unmarshal(data, &written); err != nil || written.AnalyzedBy != "crew" || filepath.Base(path) != AnalysisFile {
+		t.Errorf("unexpected analysis file %s: %v", path, err)
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("main.go", "package main\n")
+	pa := NewProjectAnalyzer(dir)
+
+	first, err := pa.Fingerprint()
+	if err != nil {
+		t.Fatal(err)
+	}
+	write("node_modules/dep/index.js", "ignored\n")
+	if again, _ := pa.Fingerprint(); again != first {
+		t.Error("Expected skipped directories not to change the fingerprint")
+	}
+	write(".github/workflows/ci.yml", "on: push\n")
+	ci, _ := pa.Fingerprint()
+	if ci == first {
+		t.Error("Expected a CI workflow to change the fingerprint")
+	}
+	write("main.go", "package main\n\nfunc main() {}\n")
+	if edited, _ := pa.Fingerprint(); edited == ci {
+		t.Error("Expected an edited source file to change the fingerprint")
+	}
+}
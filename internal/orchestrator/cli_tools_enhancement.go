@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"os/exec"
 	"strings"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/claudemd"
 )
 
 // CLITool represents an external CLI tool that can be used by agents
@@ -364,19 +366,5 @@ func (e *CLIToolsEnhancer) InstallationGuide(requiredTools []string) string {
 func (e *CLIToolsEnhancer) EnhanceProjectCLAUDEWithTools(existingContent string, enabledTools []string) string {
 	toolsSection := e.GenerateToolsSection(enabledTools)
 
-	// If existing content has CLI tools section, replace it
-	if strings.Contains(existingContent, "## 🛠️ CLI Tool Integrations") {
-		// Find and replace existing CLI tools section
-		startIdx := strings.Index(existingContent, "## 🛠️ CLI Tool Integrations")
-		endIdx := strings.Index(existingContent[startIdx:], "\n## ")
-		if endIdx == -1 {
-			// CLI tools section is at the end
-			return existingContent[:startIdx] + toolsSection
-		}
-		endIdx += startIdx
-		return existingContent[:startIdx] + toolsSection + existingContent[endIdx:]
-	}
-
-	// Otherwise, append CLI tools section
-	return existingContent + "\n\n" + toolsSection
+	return upsertManagedSection(existingContent, claudemd.SectionCLITools, "## 🛠️ CLI Tool Integrations", toolsSection)
 }
@@ -0,0 +1,203 @@
+package orchestrator
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/gitrepo"
+)
+
+// churnCommits is how many recent commits hotspots are measured over
+const churnCommits = 500
+
+// recentCommits is how many of the latest commits count as recent activity.
+// A number of commits rather than a period keeps the analysis of an
+// unchanged repository the same from one day to the next.
+const recentCommits = 100
+
+// gitSignalsListed is how many files and areas the signals list
+const gitSignalsListed = 5
+
+// GitSignals is what the project's git history says about where work
+// happens
+type GitSignals struct {
+	// Commits is the number of commits examined, at most churnCommits
+	Commits int `json:"commits"`
+	// Contributors is the number of authors of those commits
+	Contributors int `json:"contributors"`
+	// MostChanged are the files changed by the most commits
+	MostChanged []FileCount `json:"most_changed"`
+	// ActiveAreas are the directories changed by the most recent commits
+	ActiveAreas []FileCount `json:"active_areas"`
+
+	changes map[string]int
+	recent  map[string]int
+}
+
+// detectGitSignals reads the history of the project's files. It returns
+// nil outside a git repository.
+func (pa *ProjectAnalyzer) detectGitSignals() *GitSignals {
+	repo, err := gitrepo.Open(pa.rootPath)
+	if err != nil {
+		return nil
+	}
+	prefix, err := repo.Rel(pa.rootPath)
+	if err != nil {
+		return nil
+	}
+	commits, err := repo.Log(churnCommits)
+	if err != nil {
+		return nil
+	}
+	if prefix != "." {
+		commits = commitsUnder(commits, prefix+"/")
+	}
+	return newGitSignals(commits)
+}
+
+// commitsUnder keeps the commits that changed files under prefix, with
+// the paths made relative to it
+func commitsUnder(commits []gitrepo.Commit, prefix string) []gitrepo.Commit {
+	var kept []gitrepo.Commit
+	for _, commit := range commits {
+		var files []string
+		for _, file := range commit.Files {
+			if strings.HasPrefix(file, prefix) {
+				files = append(files, strings.TrimPrefix(file, prefix))
+			}
+		}
+		if len(files) > 0 {
+			kept = append(kept, gitrepo.Commit{Author: commit.Author, Files: files})
+		}
+	}
+	return kept
+}
+
+// newGitSignals summarizes commits, newest first
+func newGitSignals(commits []gitrepo.Commit) *GitSignals {
+	signals := &GitSignals{
+		Commits: len(commits),
+		changes: gitrepo.CountFiles(commits),
+	}
+	recent := commits
+	if len(recent) > recentCommits {
+		recent = recent[:recentCommits]
+	}
+	signals.recent = gitrepo.CountFiles(recent)
+
+	authors := map[string]bool{}
+	for _, commit := range commits {
+		authors[commit.Author] = true
+	}
+	signals.Contributors = len(authors)
+
+	signals.MostChanged = topCounts(signals.changes, gitSignalsListed)
+	areas := map[string]int{}
+	for _, commit := range recent {
+		seen := map[string]bool{}
+		for _, file := range commit.Files {
+			if area := areaOf(file); !seen[area] {
+				seen[area] = true
+				areas[area]++
+			}
+		}
+	}
+	signals.ActiveAreas = topCounts(areas, gitSignalsListed)
+	return signals
+}
+
+// areaOf is the directory a file belongs to, at most two levels deep, e.g.
+// internal/cli for internal/cli/root.go, or . for files at the root
+func areaOf(file string) string {
+	dir := path.Dir(file)
+	if parts := strings.Split(dir, "/"); len(parts) > 2 {
+		dir = parts[0] + "/" + parts[1]
+	}
+	return dir
+}
+
+// topCounts returns the n largest counts, ties by path
+func topCounts(counts map[string]int, n int) []FileCount {
+	top := make([]FileCount, 0, len(counts))
+	for p, count := range counts {
+		top = append(top, FileCount{Path: p, Count: count})
+	}
+	sort.Slice(top, func(i, j int) bool {
+		if top[i].Count != top[j].Count {
+			return top[i].Count > top[j].Count
+		}
+		return top[i].Path < top[j].Path
+	})
+	if len(top) > n {
+		top = top[:n]
+	}
+	return top
+}
+
+// RecentChanges counts the changes the recent commits made to files
+// matching any of patterns, in the syntax of specialistSources
+func (g *GitSignals) RecentChanges(patterns []string) int {
+	if g == nil {
+		return 0
+	}
+	total := 0
+	for file, count := range g.recent {
+		for _, pattern := range patterns {
+			if matchSource(pattern, file) {
+				total += count
+				break
+			}
+		}
+	}
+	return total
+}
+
+// Summary describes the signals in one line, e.g. for a prompt
+func (g *GitSignals) Summary() string {
+	var areas []string
+	for _, area := range g.ActiveAreas {
+		areas = append(areas, area.Path)
+	}
+	contributors := "contributors"
+	if g.Contributors == 1 {
+		contributors = "contributor"
+	}
+	summary := fmt.Sprintf("%d %s over the last %d commits", g.Contributors, contributors, g.Commits)
+	if len(areas) > 0 {
+		summary += "; most active: " + strings.Join(areas, ", ")
+	}
+	return summary
+}
+
+// matchSource reports whether the slash-separated file matches pattern.
+// ** matches any number of directories, a pattern without wildcards also
+// matches the files under it, and other segments match as in path.Match.
+func matchSource(pattern, file string) bool {
+	if !strings.ContainsAny(pattern, "*?[") {
+		return file == pattern || strings.HasPrefix(file, pattern+"/")
+	}
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(file, "/"))
+}
+
+func matchSegments(pattern, parts []string) bool {
+	if len(pattern) == 0 {
+		return len(parts) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(parts); i++ {
+			if matchSegments(pattern[1:], parts[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(parts) == 0 {
+		return false
+	}
+	if ok, _ := path.Match(pattern[0], parts[0]); !ok {
+		return false
+	}
+	return matchSegments(pattern[1:], parts[1:])
+}
@@ -0,0 +1,71 @@
+package orchestrator
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/agents"
+	"github.com/jonwraymond/claude-code-super-crew/internal/gitrepo"
+	"github.com/jonwraymond/claude-code-super-crew/internal/mcpcatalog"
+)
+
+func TestMatchSource(t *testing.T) {
+	tests := []struct {
+		pattern, file string
+		want          bool
+	}{
+		{"go.mod", "go.mod", true},
+		{".github/workflows", ".github/workflows/ci.yml", true},
+		{"cmd", "cmdline.go", false},
+		{"**/*.go", "main.go", true},
+		{"**/*.go", "internal/cli/root.go", true},
+		{"**/migrations/**", "db/migrations/001.sql", true},
+		{"src/**/*.tsx", "src/App.tsx", true},
+		{"src/**/*.tsx", "web/src/App.tsx", false},
+	}
+	for _, tt := range tests {
+		if got := matchSource(tt.pattern, tt.file); got != tt.want {
+			t.Errorf("matchSource(%q, %q) = %v, want %v", tt.pattern, tt.file, got, tt.want)
+		}
+	}
+}
+
+func TestGitSignals(t *testing.T) {
+	commits := []gitrepo.Commit{
+		{Author: "a@x", Files: []string{"api/internal/db/migrations/002.sql", "api/main.go"}},
+		{Author: "b@x", Files: []string{"api/main.go", "web/App.tsx"}},
+		{Author: "a@x", Files: []string{"api/internal/db/migrations/001.sql", "api/internal/db/db.go"}},
+	}
+	signals := newGitSignals(commitsUnder(commits, "api/"))
+	if signals.Commits != 3 || signals.Contributors != 2 {
+		t.Errorf("unexpected counts %+v", signals)
+	}
+	if want := []FileCount{{"main.go", 2}, {"internal/db/db.go", 1}}; !reflect.DeepEqual(signals.MostChanged[:2], want) {
+		t.Errorf("MostChanged = %v", signals.MostChanged)
+	}
+	if want := []FileCount{{".", 2}, {"internal/db", 2}}; !reflect.DeepEqual(signals.ActiveAreas, want) {
+		t.Errorf("ActiveAreas = %v", signals.ActiveAreas)
+	}
+	if got := signals.RecentChanges(SpecialistSources("database-specialist")); got != 2 {
+		t.Errorf("RecentChanges(database) = %d", got)
+	}
+	if !strings.HasPrefix(signals.Summary(), "2 contributors over the last 3 commits; most active: ., internal/db") {
+		t.Errorf("Summary() = %s", signals.Summary())
+	}
+
+	// The most active specialist is routed to first and recommended higher
+	chars := &ProjectCharacteristics{MainLanguage: "go", HasBackend: true, Git: signals,
+		DetectedAgents: []string{"api-specialist", "database-specialist"}}
+	report := Recommend(chars, mcpcatalog.Builtin())
+	if report.Specialists[0].Name != "database-specialist" || !contains(report.Specialists[0].Reasons, "2 recent changes to its files") {
+		t.Errorf("Expected the active specialist first: %+v", report.Specialists)
+	}
+	routing := renderRouting(chars, []*agents.Agent{{Name: "qa-specialist"}, {Name: "database-specialist"}}, nil)
+	if strings.Index(routing, "`database-specialist`") > strings.Index(routing, "`qa-specialist`") {
+		t.Errorf("Expected the active specialist first:\n%s", routing)
+	}
+	if !strings.Contains(routing, "- **Active areas**: `.` (2), `internal/db` (2)") {
+		t.Errorf("Expected the active areas in the routing:\n%s", routing)
+	}
+}
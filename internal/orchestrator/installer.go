@@ -6,6 +6,8 @@ import (
 	"path/filepath"
 	"strings"
 	"time"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/pathsutil"
 )
 
 // OrchestratorInstaller handles creation prompts for orchestrator-specialist
@@ -19,7 +21,7 @@ type OrchestratorInstaller struct {
 func NewOrchestratorInstaller(projectRoot string) *OrchestratorInstaller {
 	return &OrchestratorInstaller{
 		ProjectRoot: projectRoot,
-		AgentsDir:   filepath.Join(projectRoot, ".claude", "agents"),
+		AgentsDir:   pathsutil.AgentsDir(projectRoot),
 	}
 }
 
@@ -31,7 +33,7 @@ func (oi *OrchestratorInstaller) InstallOrValidate() error {
 		return fmt.Errorf("failed to create agents directory: %w", err)
 	}
 
-	orchestratorPath := filepath.Join(oi.AgentsDir, "orchestrator-specialist.md")
+	orchestratorPath := filepath.Join(oi.AgentsDir, pathsutil.OrchestratorFile)
 
 	// Check if orchestrator exists
 	if _, err := os.Stat(orchestratorPath); os.IsNotExist(err) {
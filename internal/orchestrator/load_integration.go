@@ -5,6 +5,10 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/claudemd"
+	"github.com/jonwraymond/claude-code-super-crew/internal/mcpcatalog"
+	"github.com/jonwraymond/claude-code-super-crew/internal/pathsutil"
 )
 
 // LoadCommandHandler integrates with /crew:onboard command
@@ -13,15 +17,19 @@ type LoadCommandHandler struct {
 	Installer     *OrchestratorInstaller
 	MCPEnhancer   *MCPEnhancer
 	ToolsEnhancer *CLIToolsEnhancer
+	// MCPCatalog holds the MCP servers offered to the project
+	MCPCatalog *mcpcatalog.Catalog
 }
 
 // NewLoadCommandHandler creates a new handler
 func NewLoadCommandHandler(projectRoot string) *LoadCommandHandler {
+	catalog := mcpcatalog.ForProject(projectRoot)
 	return &LoadCommandHandler{
 		ProjectRoot:   projectRoot,
 		Installer:     NewOrchestratorInstaller(projectRoot),
-		MCPEnhancer:   NewMCPEnhancer(),
+		MCPEnhancer:   NewMCPEnhancerWithCatalog(catalog),
 		ToolsEnhancer: NewCLIToolsEnhancer(projectRoot),
+		MCPCatalog:    catalog,
 	}
 }
 
@@ -32,7 +40,7 @@ func (lch *LoadCommandHandler) Execute() error {
 
 	// Step 1: Check if local orchestrator exists, prompt creation if needed
 	fmt.Println("\n📋 Step 1: Checking for orchestrator-specialist...")
-	orchestratorPath := filepath.Join(lch.ProjectRoot, ".claude", "agents", "orchestrator-specialist.md")
+	orchestratorPath := pathsutil.OrchestratorPath(lch.ProjectRoot)
 	if _, err := os.Stat(orchestratorPath); os.IsNotExist(err) {
 		// Orchestrator doesn't exist, prompt Claude to create from global template
 		if err := lch.promptOrchestratorCreation(); err != nil {
@@ -64,8 +72,8 @@ func (lch *LoadCommandHandler) Execute() error {
 
 // saveAnalysisTemplate saves the empty template for Claude
 func (lch *LoadCommandHandler) saveAnalysisTemplate(template string) error {
-	agentsDir := filepath.Join(lch.ProjectRoot, ".claude", "agents")
-	analysisPath := filepath.Join(agentsDir, "project-analysis.json")
+	agentsDir := pathsutil.AgentsDir(lch.ProjectRoot)
+	analysisPath := filepath.Join(agentsDir, AnalysisFile)
 
 	if err := os.WriteFile(analysisPath, []byte(template), 0644); err != nil {
 		return fmt.Errorf("failed to write analysis template: %w", err)
@@ -186,7 +194,7 @@ func (lch *LoadCommandHandler) enhanceProjectCLAUDE(enabledServers []string) err
 	claudeFile := filepath.Join(lch.ProjectRoot, "CLAUDE.md")
 	
 	// Still ensure .claude directory exists for other files
-	claudeDir := filepath.Join(lch.ProjectRoot, ".claude")
+	claudeDir := pathsutil.ClaudeDir(lch.ProjectRoot)
 	if err := os.MkdirAll(claudeDir, 0755); err != nil {
 		return fmt.Errorf("failed to create .claude directory: %w", err)
 	}
@@ -217,26 +225,29 @@ func (lch *LoadCommandHandler) enhanceProjectCLAUDE(enabledServers []string) err
 	return nil
 }
 
-// createDefaultCLAUDE creates a basic CLAUDE.md template
+// createDefaultCLAUDE creates a basic CLAUDE.md template. The overview is a
+// managed section; the conventions belong to the project.
 func (lch *LoadCommandHandler) createDefaultCLAUDE() string {
 	projectName := filepath.Base(lch.ProjectRoot)
 	return fmt.Sprintf(`# %s Project Configuration
 
+<!-- BEGIN crew:%s -->
 ## 🎯 Project Overview
 This project uses Claude Code Super Crew for intelligent code assistance and orchestration.
 
-## 📋 Project Conventions
-- Follow existing code patterns and style
-- Maintain test coverage
-- Document significant changes
-
 ## 🤖 Orchestrator Integration
 The project includes an orchestrator-specialist at .claude/agents/orchestrator-specialist.md that:
 - Routes commands intelligently based on complexity
 - Coordinates multi-agent workflows when needed
 - Suggests specialist creation for repeated patterns
+<!-- END crew:%[2]s -->
 
-`, projectName)
+## 📋 Project Conventions
+- Follow existing code patterns and style
+- Maintain test coverage
+- Document significant changes
+
+`, projectName, claudemd.SectionProjectOverview)
 }
 
 // detectProjectType attempts to detect the project type
@@ -328,7 +339,7 @@ func (lch *LoadCommandHandler) shouldCreateSecondOpinionAgent(enabledTools []str
 
 // createSecondOpinionAgent creates the second-opinion-generator agent
 func (lch *LoadCommandHandler) createSecondOpinionAgent() error {
-	agentsDir := filepath.Join(lch.ProjectRoot, ".claude", "agents")
+	agentsDir := pathsutil.AgentsDir(lch.ProjectRoot)
 	agentFile := filepath.Join(agentsDir, "second-opinion-generator.md")
 
 	// Check if agent already exists
@@ -385,6 +396,15 @@ See full documentation in SuperCrew/Agents/second-opinion-generator.md
 `
 }
 
+// Recommendations analyzes the project and ranks the enhancements that suit it
+func (lch *LoadCommandHandler) Recommendations() (*RecommendationReport, error) {
+	chars, err := NewProjectAnalyzer(lch.ProjectRoot).Analyze()
+	if err != nil {
+		return nil, fmt.Errorf("failed to analyze project: %w", err)
+	}
+	return Recommend(chars, lch.MCPCatalog), nil
+}
+
 // promptForIntelligentEnhancements prompts Claude to analyze and determine what to enable
 // promptOrchestratorCreation prompts Claude to create local orchestrator from global template
 func (lch *LoadCommandHandler) promptOrchestratorCreation() error {
@@ -446,11 +466,9 @@ func (lch *LoadCommandHandler) promptForIntelligentEnhancements() {
 	fmt.Println("## 📋 Available Resources")
 	fmt.Println()
 	fmt.Println("### MCP Servers (Enable what helps):")
-	fmt.Println("- **Context7**: Documentation lookup for any library")
-	fmt.Println("- **Sequential**: Complex multi-step analysis") 
-	fmt.Println("- **Magic**: UI component generation")
-	fmt.Println("- **Playwright**: Browser testing automation")
-	fmt.Println("- **Serena**: Semantic code understanding")
+	for _, server := range lch.MCPCatalog.Servers() {
+		fmt.Printf("- **%s** (--%s): %s\n", server.DisplayName(), server.Name, server.Description)
+	}
 	fmt.Println()
 	fmt.Println("### CLI Tools (Enable if useful):")
 	fmt.Println("- **code2prompt**: Generate comprehensive code context")
@@ -460,6 +478,11 @@ func (lch *LoadCommandHandler) promptForIntelligentEnhancements() {
 	fmt.Println("- **orchestrator-specialist**: Already installed for routing")
 	fmt.Println("- **second-opinion-generator**: Created when code2prompt enabled")
 	fmt.Println()
+	if report, err := lch.Recommendations(); err == nil {
+		fmt.Println(report.Markdown())
+		fmt.Println("Start from these recommendations; they come from the project's files, so adjust them to what you find.")
+		fmt.Println()
+	}
 	fmt.Println("## 🚀 Your Task")
 	fmt.Println()
 	fmt.Println("1. **Analyze** the project deeply - understand its purpose and patterns")
@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/jonwraymond/claude-code-super-crew/internal/pathsutil"
 	"github.com/jonwraymond/claude-code-super-crew/pkg/logger"
 	"gopkg.in/yaml.v3"
 )
@@ -123,7 +124,7 @@ func NewLocalCommandRouter(projectPath string) *LocalCommandRouter {
 		localCommands:    make(map[string]*LocalCommand),
 		shadowCommands:   make(map[string]*ShadowCommand),
 		specialists:      make(map[string]*Specialist),
-		orchestratorPath: filepath.Join(projectPath, ".claude", "agents", "orchestrator-specialist.md"),
+		orchestratorPath: pathsutil.OrchestratorPath(projectPath),
 		logger:           logger.GetLogger(),
 	}
 }
@@ -180,7 +181,7 @@ func (r *LocalCommandRouter) ensureLocalOrchestrator() error {
 // loadGlobalCommands loads commands from ~/.claude/commands
 func (r *LocalCommandRouter) loadGlobalCommands() error {
 	homeDir, _ := os.UserHomeDir()
-	globalPath := filepath.Join(homeDir, ".claude", "commands")
+	globalPath := pathsutil.CommandsDir(homeDir)
 	
 	if _, err := os.Stat(globalPath); os.IsNotExist(err) {
 		return nil // No global commands is OK
@@ -206,7 +207,7 @@ func (r *LocalCommandRouter) loadGlobalCommands() error {
 
 // loadLocalCommands loads project-specific commands
 func (r *LocalCommandRouter) loadLocalCommands() error {
-	localPath := filepath.Join(r.projectPath, ".claude", "commands")
+	localPath := pathsutil.CommandsDir(r.projectPath)
 	
 	if _, err := os.Stat(localPath); os.IsNotExist(err) {
 		return nil // No local commands is OK
@@ -304,7 +305,7 @@ func (r *LocalCommandRouter) loadShadowCommand(path string) (*ShadowCommand, err
 
 // discoverSpecialists finds all project specialists
 func (r *LocalCommandRouter) discoverSpecialists() error {
-	agentsPath := filepath.Join(r.projectPath, ".claude", "agents")
+	agentsPath := pathsutil.AgentsDir(r.projectPath)
 	
 	if _, err := os.Stat(agentsPath); os.IsNotExist(err) {
 		return nil // No agents is OK
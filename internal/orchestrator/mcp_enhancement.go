@@ -2,7 +2,11 @@ package orchestrator
 
 import (
 	"fmt"
+	"sort"
 	"strings"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/claudemd"
+	"github.com/jonwraymond/claude-code-super-crew/internal/mcpcatalog"
 )
 
 // MCPIntegration represents an MCP server integration
@@ -23,37 +27,70 @@ type MCPExample struct {
 
 // MCPEnhancer handles the enhancement of CLAUDE.md with MCP integrations
 type MCPEnhancer struct {
+	catalog      *mcpcatalog.Catalog
 	integrations map[string]MCPIntegration
 }
 
-// NewMCPEnhancer creates a new MCP enhancer
+// NewMCPEnhancer creates an MCP enhancer for the built-in MCP catalog
 func NewMCPEnhancer() *MCPEnhancer {
+	return NewMCPEnhancerWithCatalog(mcpcatalog.Builtin())
+}
+
+// NewMCPEnhancerWithCatalog creates an MCP enhancer for the servers of a
+// catalog, such as one extended by the user
+func NewMCPEnhancerWithCatalog(catalog *mcpcatalog.Catalog) *MCPEnhancer {
+	integrations := make(map[string]MCPIntegration)
+	for _, server := range catalog.Servers() {
+		integrations[server.Name] = newMCPIntegration(server)
+	}
 	return &MCPEnhancer{
-		integrations: initializeMCPIntegrations(),
+		catalog:      catalog,
+		integrations: integrations,
 	}
 }
 
-// initializeMCPIntegrations sets up all available MCP integrations
-func initializeMCPIntegrations() map[string]MCPIntegration {
-	return map[string]MCPIntegration{
-		"context7": {
-			Name:        "Context7",
-			Description: "Official library documentation, code examples, best practices",
-			Tools: []string{
-				"mcp__context7__resolve-library-id",
-				"mcp__context7__get-library-docs",
-			},
-			UsageGuide: `## Context7 Integration
+// newMCPIntegration documents a catalog server, with the guide and
+// examples of the catalog entry or else the built-in ones
+func newMCPIntegration(server mcpcatalog.Server) MCPIntegration {
+	integration := MCPIntegration{
+		Name:        server.DisplayName(),
+		Description: server.Description,
+		Tools:       server.Tools,
+		UsageGuide:  server.Guide,
+		Enabled:     server.Enabled,
+	}
+	for _, example := range server.Examples {
+		integration.Examples = append(integration.Examples, MCPExample{Description: example.Description, Code: example.Code})
+	}
+
+	guide, ok := mcpGuides[server.Name]
+	if integration.UsageGuide == "" {
+		integration.UsageGuide = guide.UsageGuide
+		if !ok {
+			integration.UsageGuide = fmt.Sprintf("## %s Integration\n\n%s", integration.Name, server.Description)
+		}
+	}
+	if len(integration.Examples) == 0 {
+		integration.Examples = guide.Examples
+	}
+	return integration
+}
+
+// mcpGuides are the usage guides and examples of the built-in catalog
+// servers, by server name
+var mcpGuides = map[string]MCPIntegration{
+	"context7": {
+		UsageGuide: `## Context7 Integration
 
 Context7 provides access to up-to-date documentation for any library or framework. Use it for:
 - Finding official documentation and examples
 - Understanding best practices and patterns
 - Resolving version-specific implementations
 - Getting framework-specific conventions`,
-			Examples: []MCPExample{
-				{
-					Description: "Get React hooks documentation",
-					Code: `# First resolve the library ID
+		Examples: []MCPExample{
+			{
+				Description: "Get React hooks documentation",
+				Code: `# First resolve the library ID
 mcp__context7__resolve-library-id:
   libraryName: "react"
 
@@ -62,106 +99,82 @@ mcp__context7__get-library-docs:
   context7CompatibleLibraryID: "/facebook/react"
   topic: "hooks"
   tokens: 10000`,
-				},
-				{
-					Description: "Find Next.js routing patterns",
-					Code: `mcp__context7__get-library-docs:
+			},
+			{
+				Description: "Find Next.js routing patterns",
+				Code: `mcp__context7__get-library-docs:
   context7CompatibleLibraryID: "/vercel/next.js"
   topic: "routing"`,
-				},
 			},
-			Enabled: true,
 		},
-		"sequential": {
-			Name:        "Sequential Thinking",
-			Description: "Multi-step problem solving, architectural analysis, systematic debugging",
-			Tools: []string{
-				"mcp__sequential-thinking__sequentialthinking",
-			},
-			UsageGuide: `## Sequential Thinking Integration
+	},
+	"sequential-thinking": {
+		UsageGuide: `## Sequential Thinking Integration
 
 Sequential provides structured, multi-step analysis for complex problems. Use it for:
 - Breaking down complex problems into manageable steps
 - Architectural decision-making with revision capability
 - Root cause analysis with hypothesis testing
 - Planning implementations with adaptive thinking`,
-			Examples: []MCPExample{
-				{
-					Description: "Analyze a complex bug",
-					Code: `mcp__sequential-thinking__sequentialthinking:
+		Examples: []MCPExample{
+			{
+				Description: "Analyze a complex bug",
+				Code: `mcp__sequential-thinking__sequentialthinking:
   thought: "First, let me understand the error pattern..."
   nextThoughtNeeded: true
   thoughtNumber: 1
   totalThoughts: 5
   isRevision: false`,
-				},
-				{
-					Description: "Plan a feature implementation",
-					Code: `mcp__sequential-thinking__sequentialthinking:
+			},
+			{
+				Description: "Plan a feature implementation",
+				Code: `mcp__sequential-thinking__sequentialthinking:
   thought: "Breaking down the feature requirements..."
   nextThoughtNeeded: true
   thoughtNumber: 1
   totalThoughts: 8
   needsMoreThoughts: true`,
-				},
 			},
-			Enabled: true,
 		},
-		"magic": {
-			Name:        "Magic UI Components",
-			Description: "Modern UI component generation, design system integration",
-			Tools: []string{
-				"mcp__magic__21st_magic_component_builder",
-				"mcp__magic__21st_magic_component_inspiration",
-				"mcp__magic__21st_magic_component_refiner",
-				"mcp__magic__logo_search",
-			},
-			UsageGuide: `## Magic UI Integration
+	},
+	"magic": {
+		UsageGuide: `## Magic UI Integration
 
 Magic provides AI-powered UI component generation and refinement. Use it for:
 - Creating modern, accessible UI components
 - Finding design inspiration from 21st.dev
 - Refining existing components for better UX
 - Adding company logos to projects`,
-			Examples: []MCPExample{
-				{
-					Description: "Create a dashboard component",
-					Code: `mcp__magic__21st_magic_component_builder:
+		Examples: []MCPExample{
+			{
+				Description: "Create a dashboard component",
+				Code: `mcp__magic__21st_magic_component_builder:
   message: "Create a modern analytics dashboard"
   searchQuery: "dashboard analytics"
   absolutePathToCurrentFile: "/src/components/Dashboard.tsx"
   absolutePathToProjectDirectory: "/path/to/project"
   standaloneRequestQuery: "Analytics dashboard with charts and metrics"`,
-				},
-				{
-					Description: "Add company logos",
-					Code: `mcp__magic__logo_search:
+			},
+			{
+				Description: "Add company logos",
+				Code: `mcp__magic__logo_search:
   queries: ["github", "slack", "discord"]
   format: "TSX"`,
-				},
 			},
-			Enabled: true,
 		},
-		"playwright": {
-			Name:        "Playwright Testing",
-			Description: "Browser automation, E2E testing, performance monitoring",
-			Tools: []string{
-				"playwright_navigate",
-				"playwright_screenshot",
-				"playwright_click",
-				"playwright_fill",
-			},
-			UsageGuide: `## Playwright Integration
+	},
+	"playwright": {
+		UsageGuide: `## Playwright Integration
 
 Playwright enables browser automation and testing. Use it for:
 - End-to-end testing of web applications
 - Visual regression testing with screenshots
 - Performance monitoring and metrics
 - Cross-browser compatibility testing`,
-			Examples: []MCPExample{
-				{
-					Description: "Test a login flow",
-					Code: `# Navigate to login page
+		Examples: []MCPExample{
+			{
+				Description: "Test a login flow",
+				Code: `# Navigate to login page
 playwright_navigate:
   url: "https://app.example.com/login"
 
@@ -173,28 +186,11 @@ playwright_fill:
 # Take screenshot for visual validation
 playwright_screenshot:
   name: "login-page"`,
-				},
 			},
-			Enabled: false, // Disabled by default
 		},
-		"serena": {
-			Name:        "Serena Code Intelligence",
-			Description: "Powerful coding agent toolkit providing semantic code retrieval and editing",
-			Tools: []string{
-				"mcp__serena__get_symbols_overview",
-				"mcp__serena__find_symbol",
-				"mcp__serena__find_referencing_symbols",
-				"mcp__serena__find_referencing_code_snippets",
-				"mcp__serena__search_for_pattern",
-				"mcp__serena__read_file",
-				"mcp__serena__create_text_file",
-				"mcp__serena__replace_symbol_body",
-				"mcp__serena__insert_before_symbol",
-				"mcp__serena__insert_after_symbol",
-				"mcp__serena__replace_lines",
-				"mcp__serena__delete_lines",
-			},
-			UsageGuide: `## Serena Code Intelligence Integration
+	},
+	"serena": {
+		UsageGuide: `## Serena Code Intelligence Integration
 
 Serena provides powerful semantic code understanding and editing capabilities. Use it for:
 - AST-aware code navigation and understanding
@@ -208,10 +204,10 @@ Key advantages over basic tools:
 - Can modify code at the symbol level
 - Maintains semantic correctness during edits
 - Works across multiple programming languages`,
-			Examples: []MCPExample{
-				{
-					Description: "Find and understand code structure",
-					Code: `# Get overview of symbols in a module
+		Examples: []MCPExample{
+			{
+				Description: "Find and understand code structure",
+				Code: `# Get overview of symbols in a module
 mcp__serena__get_symbols_overview:
   relative_path: "pkg/your_package"
 
@@ -229,10 +225,10 @@ mcp__serena__find_referencing_symbols:
 mcp__serena__find_referencing_code_snippets:
   name_path: "YourFunction"
   relative_path: "pkg/your_package"`,
-				},
-				{
-					Description: "Semantic code modifications",
-					Code: `# Replace entire function body
+			},
+			{
+				Description: "Semantic code modifications",
+				Code: `# Replace entire function body
 mcp__serena__replace_symbol_body:
   name_path: "YourFunction"
   relative_path: "pkg/your_package"
@@ -261,10 +257,10 @@ mcp__serena__insert_after_symbol:
     func (s *YourStruct) Helper() string {
         return s.Name
     }`,
-				},
-				{
-					Description: "Search for patterns across codebase",
-					Code: `# Search for error handling patterns
+			},
+			{
+				Description: "Search for patterns across codebase",
+				Code: `# Search for error handling patterns
 mcp__serena__search_for_pattern:
   substring_pattern: "if err != nil"
   restrict_search_to_code_files: true
@@ -274,36 +270,9 @@ mcp__serena__search_for_pattern:
 mcp__serena__search_for_pattern:
   substring_pattern: "TODO"
   restrict_search_to_code_files: true`,
-				},
-			},
-			Enabled: true, // Official tool in our framework
-		},
-		"astgrep": {
-			Name:        "AST-GREP",
-			Description: "Pattern-based semantic code search",
-			Tools: []string{
-				"ast-grep",
-			},
-			UsageGuide: `## AST-GREP Integration
-
-AST-GREP provides pattern-based semantic searching. Use it for:
-- Finding structural code patterns
-- Identifying similar implementations
-- Refactoring assistance
-- Code quality checks`,
-			Examples: []MCPExample{
-				{
-					Description: "Find error handling patterns",
-					Code: `ast-grep --pattern 'if err != nil { return err }'`,
-				},
-				{
-					Description: "Find all struct definitions",
-					Code: `ast-grep --pattern 'type $NAME struct { $$$ }'`,
-				},
 			},
-			Enabled: false, // Optional tool
 		},
-	}
+	},
 }
 
 // GenerateEnhancedSection generates the MCP section for CLAUDE.md
@@ -316,10 +285,11 @@ func (e *MCPEnhancer) GenerateEnhancedSection(enabledServers []string, projectTy
 	sections = append(sections, "")
 	
 	// Enable specified servers
-	for _, server := range enabledServers {
-		if integration, exists := e.integrations[strings.ToLower(server)]; exists {
+	for _, name := range enabledServers {
+		if server, exists := e.catalog.Lookup(name); exists {
+			integration := e.integrations[server.Name]
 			integration.Enabled = true
-			e.integrations[strings.ToLower(server)] = integration
+			e.integrations[server.Name] = integration
 		}
 	}
 	
@@ -333,9 +303,15 @@ func (e *MCPEnhancer) GenerateEnhancedSection(enabledServers []string, projectTy
 		sections = append(sections, "")
 	}
 	
-	// Add enabled integrations
-	for _, integration := range e.integrations {
-		if integration.Enabled {
+	// Add enabled integrations in a stable order so regenerating the
+	// section leaves CLAUDE.md unchanged
+	names := make([]string, 0, len(e.integrations))
+	for name := range e.integrations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if integration := e.integrations[name]; integration.Enabled {
 			sections = append(sections, e.formatIntegration(integration))
 		}
 	}
@@ -371,40 +347,13 @@ func (e *MCPEnhancer) formatIntegration(integration MCPIntegration) string {
 	return strings.Join(parts, "\n")
 }
 
-// getProjectRecommendations returns recommended MCP servers based on project type
+// getProjectRecommendations returns the MCP servers the catalog
+// recommends for a project type
 func (e *MCPEnhancer) getProjectRecommendations(projectType string) []MCPIntegration {
 	var recommendations []MCPIntegration
-	
-	switch projectType {
-	case "react", "vue", "angular", "frontend":
-		if integration, exists := e.integrations["magic"]; exists {
-			recommendations = append(recommendations, integration)
-		}
-		if integration, exists := e.integrations["context7"]; exists {
-			recommendations = append(recommendations, integration)
-		}
-	case "go", "rust", "backend":
-		if integration, exists := e.integrations["serena"]; exists {
-			recommendations = append(recommendations, integration)
-		}
-		if integration, exists := e.integrations["sequential"]; exists {
-			recommendations = append(recommendations, integration)
-		}
-		if integration, exists := e.integrations["context7"]; exists {
-			recommendations = append(recommendations, integration)
-		}
-	case "fullstack":
-		if integration, exists := e.integrations["context7"]; exists {
-			recommendations = append(recommendations, integration)
-		}
-		if integration, exists := e.integrations["magic"]; exists {
-			recommendations = append(recommendations, integration)
-		}
-		if integration, exists := e.integrations["sequential"]; exists {
-			recommendations = append(recommendations, integration)
-		}
+	for _, server := range e.catalog.Recommended(projectType) {
+		recommendations = append(recommendations, e.integrations[server.Name])
 	}
-	
 	return recommendations
 }
 
@@ -468,20 +417,21 @@ func (e *MCPEnhancer) generateWorkflowExamples(projectType string) string {
 func (e *MCPEnhancer) EnhanceProjectCLAUDE(existingContent string, enabledServers []string, projectType string) string {
 	// Generate MCP section
 	mcpSection := e.GenerateEnhancedSection(enabledServers, projectType)
-	
-	// If existing content has MCP section, replace it
-	if strings.Contains(existingContent, "## 🔧 MCP Server Integrations") {
-		// Find and replace existing MCP section
-		startIdx := strings.Index(existingContent, "## 🔧 MCP Server Integrations")
-		endIdx := strings.Index(existingContent[startIdx:], "\n## ")
-		if endIdx == -1 {
-			// MCP section is at the end
-			return existingContent[:startIdx] + mcpSection
-		}
-		endIdx += startIdx
-		return existingContent[:startIdx] + mcpSection + existingContent[endIdx:]
+
+	return upsertManagedSection(existingContent, claudemd.SectionMCPServers, "## 🔧 MCP Server Integrations", mcpSection)
+}
+
+// upsertManagedSection replaces the managed section id in a CLAUDE.md with
+// content, or appends it. A section written by earlier releases without
+// anchors is found by its heading and replaced in place. Files with broken
+// anchors are returned unchanged rather than guessed at.
+func upsertManagedSection(existingContent, id, heading, content string) string {
+	doc, err := claudemd.Parse(existingContent)
+	if err != nil {
+		return existingContent
 	}
-	
-	// Otherwise, append MCP section
-	return existingContent + "\n\n" + mcpSection
-}
\ No newline at end of file
+
+	doc.Adopt(id, heading)
+	doc.Upsert(claudemd.Section{ID: id, Content: content}, claudemd.AtBottom)
+	return doc.String()
+}
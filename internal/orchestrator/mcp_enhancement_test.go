@@ -0,0 +1,62 @@
+package orchestrator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/crewdirs"
+	"github.com/jonwraymond/claude-code-super-crew/internal/mcpcatalog"
+	"github.com/jonwraymond/claude-code-super-crew/internal/pathsutil"
+)
+
+func TestEnhanceProjectCLAUDEIsIdempotent(t *testing.T) {
+	e := NewMCPEnhancer()
+	legacy := "# Project\n\n## 🔧 MCP Server Integrations\nold list\n\n## Notes\nkeep me\n"
+
+	once := e.EnhanceProjectCLAUDE(legacy, []string{"context7"}, "go")
+	twice := e.EnhanceProjectCLAUDE(once, []string{"context7"}, "go")
+	if once != twice {
+		t.Errorf("Expected a second run to leave the file unchanged:\n%s\n---\n%s", once, twice)
+	}
+	if strings.Contains(once, "old list") || strings.Count(once, "## 🔧 MCP Server Integrations") != 1 {
+		t.Errorf("Expected the unmanaged section to be replaced:\n%s", once)
+	}
+	if !strings.Contains(once, "<!-- BEGIN crew:mcp-servers -->") || !strings.HasSuffix(once, "\n\n## Notes\nkeep me\n") {
+		t.Errorf("Expected a managed section before the user notes:\n%s", once)
+	}
+
+	withTools := NewCLIToolsEnhancer(t.TempDir()).EnhanceProjectCLAUDEWithTools(once, []string{"rg"})
+	if !strings.HasPrefix(withTools, once) || !strings.Contains(withTools, "<!-- END crew:cli-tools -->") {
+		t.Errorf("Expected the tools section to be appended:\n%s", withTools)
+	}
+}
+
+func TestMCPEnhancerUsesCatalog(t *testing.T) {
+	project := t.TempDir()
+	path := crewdirs.Path(pathsutil.ClaudeDir(project), "config", mcpcatalog.FileName)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	catalog := `{"servers": [{"name": "github", "title": "GitHub", "description": "GitHub issues and pull requests", "command": ["docker", "run", "-i", "ghcr.io/github/github-mcp-server"], "project_types": ["go"]}]}`
+	if err := os.WriteFile(path, []byte(catalog), 0644); err != nil {
+		t.Fatal(err)
+	}
+	c, err := mcpcatalog.Load(pathsutil.ClaudeDir(project))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	section := NewMCPEnhancerWithCatalog(c).GenerateEnhancedSection([]string{"sequential", "github"}, "go")
+	for _, want := range []string{"GitHub issues and pull requests", "Sequential Thinking"} {
+		if !strings.Contains(section, want) {
+			t.Errorf("Expected %q in the section:\n%s", want, section)
+		}
+	}
+
+	o := &SlashCommandOrchestrator{}
+	if got := o.parseMCPFlags([]string{"--github", "--sequential", "--seq", "--unknown"}, c); strings.Join(got, ",") != "github,sequential-thinking" {
+		t.Errorf("parseMCPFlags() = %v", got)
+	}
+}
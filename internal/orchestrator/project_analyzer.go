@@ -27,18 +27,22 @@ const (
 
 // ProjectCharacteristics holds the analyzed characteristics of a project
 type ProjectCharacteristics struct {
-	RootPath       string
-	MainLanguage   string
-	Frameworks     []string
-	ProjectTypes   []ProjectType
-	HasBackend     bool
-	HasFrontend    bool
-	HasDatabase    bool
-	HasDocker      bool
-	HasKubernetes  bool
-	HasTesting     bool
-	HasCI          bool
-	DetectedAgents []string // List of agent types to generate
+	RootPath       string        `json:"root_path"`
+	MainLanguage   string        `json:"main_language"`
+	Frameworks     []string      `json:"frameworks"`
+	ProjectTypes   []ProjectType `json:"-"`
+	HasBackend     bool          `json:"has_backend"`
+	HasFrontend    bool          `json:"has_frontend"`
+	HasDatabase    bool          `json:"has_database"`
+	HasDocker      bool          `json:"has_docker"`
+	HasKubernetes  bool          `json:"has_kubernetes"`
+	HasTesting     bool          `json:"has_testing"`
+	HasCI          bool          `json:"has_ci"`
+	HasCLI         bool          `json:"has_cli"`
+	DetectedAgents []string      `json:"detected_agents"` // List of agent types to generate
+	// Git holds the signals of the project's history, nil outside a git
+	// repository
+	Git *GitSignals `json:"git,omitempty"`
 }
 
 // ProjectAnalyzer analyzes project structure and characteristics
@@ -75,6 +79,8 @@ func (pa *ProjectAnalyzer) Analyze() (*ProjectCharacteristics, error) {
 	// Determine which agents to generate
 	pa.determineAgents(chars)
 
+	chars.Git = pa.detectGitSignals()
+
 	return chars, nil
 }
 
@@ -87,8 +93,20 @@ func (pa *ProjectAnalyzer) detectLanguagesAndFrameworks(chars *ProjectCharacteri
 		chars.HasBackend = true
 	}
 
+	// Check for a Go command-line tool
+	if chars.MainLanguage == "go" {
+		if existsPattern(pa.rootPath, "cmd/*/main.go") || fileContains(filepath.Join(pa.rootPath, "go.mod"), "github.com/spf13/cobra", "github.com/urfave/cli") {
+			chars.HasCLI = true
+		}
+	}
+
 	// Check for JavaScript/TypeScript
 	if exists(filepath.Join(pa.rootPath, "package.json")) {
+		// A package with executables is a command-line tool
+		if fileContains(filepath.Join(pa.rootPath, "package.json"), `"bin"`) {
+			chars.HasCLI = true
+		}
+
 		// Read package.json to detect frameworks
 		if exists(filepath.Join(pa.rootPath, "tsconfig.json")) {
 			if chars.MainLanguage == "" {
@@ -273,6 +291,60 @@ func (pa *ProjectAnalyzer) determineAgents(chars *ProjectCharacteristics) {
 	chars.DetectedAgents = agents
 }
 
+// specialistSources are the files and globs, relative to the project root,
+// whose presence makes determineAgents pick each specialist. A generated
+// specialist records them so crew can tell when its justification is gone.
+var specialistSources = map[string][]string{
+	"go-backend-specialist":       {"go.mod"},
+	"node-backend-specialist":     {"server.js", "app.js", "src/controllers/**/*.js", "api/**/*.js"},
+	"python-backend-specialist":   {"manage.py", "**/flask_app.py", "**/app.py"},
+	"rust-backend-specialist":     {"Cargo.toml"},
+	"java-backend-specialist":     {"pom.xml", "build.gradle"},
+	"react-frontend-specialist":   {"src/**/*.jsx", "src/**/*.tsx", "src/App.js", "src/App.tsx"},
+	"vue-frontend-specialist":     {"vue.config.js", "src/**/*.vue"},
+	"angular-frontend-specialist": {"angular.json"},
+	"devops-specialist": {
+		"Dockerfile", "docker-compose.yml", "docker-compose.yaml",
+		"k8s/**/*.yaml", "k8s/**/*.yml", "kubernetes/**/*.yaml", ".k8s/**/*.yaml", "skaffold.yaml",
+		".github/workflows", ".gitlab-ci.yml", "Jenkinsfile", ".circleci",
+	},
+	"database-specialist": {"**/migrations/**", "**/models/**", "schema.sql", "database"},
+	"qa-specialist":       {"**/*_test.go", "**/*.test.js", "**/*.spec.js", "**/test_*.py", "tests", "__tests__"},
+	"api-specialist": {
+		"go.mod", "Cargo.toml", "pom.xml", "build.gradle",
+		"server.js", "app.js", "src/controllers/**/*.js", "api/**/*.js", "manage.py", "**/app.py",
+	},
+}
+
+// SpecialistSources returns the source patterns that justify agentType, or
+// nil for agents the analyzer does not generate
+func SpecialistSources(agentType string) []string {
+	return specialistSources[agentType]
+}
+
+// Traits lists what the analysis found in the project
+func (chars *ProjectCharacteristics) Traits() []string {
+	var traits []string
+	for _, trait := range []struct {
+		name    string
+		present bool
+	}{
+		{"backend", chars.HasBackend},
+		{"frontend", chars.HasFrontend},
+		{"database", chars.HasDatabase},
+		{"Docker", chars.HasDocker},
+		{"Kubernetes", chars.HasKubernetes},
+		{"tests", chars.HasTesting},
+		{"CI", chars.HasCI},
+		{"CLI", chars.HasCLI},
+	} {
+		if trait.present {
+			traits = append(traits, trait.name)
+		}
+	}
+	return traits
+}
+
 // Helper functions
 
 func exists(path string) bool {
@@ -288,6 +360,20 @@ func existsPattern(root, pattern string) bool {
 	return len(matches) > 0
 }
 
+// fileContains reports whether the file at path contains any of substrs
+func fileContains(path string, substrs ...string) bool {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	for _, substr := range substrs {
+		if strings.Contains(string(data), substr) {
+			return true
+		}
+	}
+	return false
+}
+
 func contains(slice []string, item string) bool {
 	for _, s := range slice {
 		if s == item {
@@ -0,0 +1,360 @@
+package orchestrator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/mcpcatalog"
+)
+
+// Recommendation kinds
+const (
+	KindMCPServer  = "mcp"
+	KindCLITool    = "tool"
+	KindSpecialist = "specialist"
+)
+
+// Recommendation is one enhancement suggested for a project, with the
+// reasons the rules that matched gave for it
+type Recommendation struct {
+	Kind        string   `json:"kind"`
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Score       int      `json:"score"`
+	Reasons     []string `json:"reasons"`
+}
+
+// RecommendationReport ranks the MCP servers, CLI tools and specialists
+// that suit a project, highest score first
+type RecommendationReport struct {
+	// Tags are the project types the analysis found, such as go, react,
+	// backend or cli; catalog servers are matched against them
+	Tags        []string         `json:"tags"`
+	MCPServers  []Recommendation `json:"mcp_servers"`
+	CLITools    []Recommendation `json:"cli_tools"`
+	Specialists []Recommendation `json:"specialists"`
+	// Git are the history signals that ranked the specialists, nil
+	// outside a git repository; the analysis already carries them
+	Git *GitSignals `json:"-"`
+}
+
+// recommendationRule recommends enhancements for projects it matches
+type recommendationRule struct {
+	// reason is why the rule's recommendations suit the project
+	reason string
+	match  func(chars *ProjectCharacteristics) bool
+	// mcp, tools and specialists map names to the score the rule adds
+	mcp         map[string]int
+	tools       map[string]int
+	specialists map[string]int
+}
+
+// recommendationRules are evaluated in order; the scores of every rule that
+// matches add up
+var recommendationRules = []recommendationRule{
+	{
+		reason: "frontend framework",
+		match:  func(c *ProjectCharacteristics) bool { return c.HasFrontend },
+		mcp:    map[string]int{"magic": 3, "playwright": 3, "context7": 1},
+	},
+	{
+		reason: "frontend with tests",
+		match:  func(c *ProjectCharacteristics) bool { return c.HasFrontend && c.HasTesting },
+		mcp:    map[string]int{"playwright": 2},
+	},
+	{
+		reason:      "Go project",
+		match:       func(c *ProjectCharacteristics) bool { return c.MainLanguage == "go" },
+		mcp:         map[string]int{"serena": 2, "context7": 1},
+		tools:       map[string]int{"ast-grep": 1},
+		specialists: map[string]int{"go-specialist": 2},
+	},
+	{
+		reason:      "Go command-line tool",
+		match:       func(c *ProjectCharacteristics) bool { return c.MainLanguage == "go" && c.HasCLI },
+		mcp:         map[string]int{"serena": 2},
+		specialists: map[string]int{"go-specialist": 1, "cli-specialist": 3},
+	},
+	{
+		reason:      "Rust project",
+		match:       func(c *ProjectCharacteristics) bool { return c.MainLanguage == "rust" },
+		mcp:         map[string]int{"serena": 2, "context7": 1},
+		tools:       map[string]int{"ast-grep": 1},
+		specialists: map[string]int{"rust-specialist": 2},
+	},
+	{
+		reason:      "Python project",
+		match:       func(c *ProjectCharacteristics) bool { return c.MainLanguage == "python" },
+		mcp:         map[string]int{"serena": 2, "context7": 1},
+		specialists: map[string]int{"python-specialist": 2},
+	},
+	{
+		reason:      "TypeScript project",
+		match:       func(c *ProjectCharacteristics) bool { return c.MainLanguage == "typescript" },
+		mcp:         map[string]int{"context7": 1},
+		tools:       map[string]int{"ast-grep": 1},
+		specialists: map[string]int{"ts-specialist": 2},
+	},
+	{
+		reason:      "JavaScript project",
+		match:       func(c *ProjectCharacteristics) bool { return c.MainLanguage == "javascript" },
+		mcp:         map[string]int{"context7": 1},
+		tools:       map[string]int{"ast-grep": 1},
+		specialists: map[string]int{"js-specialist": 2},
+	},
+	{
+		reason: "Node.js command-line tool",
+		match: func(c *ProjectCharacteristics) bool {
+			return c.HasCLI && (c.MainLanguage == "javascript" || c.MainLanguage == "typescript")
+		},
+		specialists: map[string]int{"cli-specialist": 3},
+	},
+	{
+		reason:      "several languages",
+		match:       func(c *ProjectCharacteristics) bool { return len(c.languages()) > 1 },
+		tools:       map[string]int{"code2prompt": 2},
+		specialists: map[string]int{"polyglot-specialist": 2},
+	},
+	{
+		reason: "backend and frontend",
+		match:  func(c *ProjectCharacteristics) bool { return c.HasBackend && c.HasFrontend },
+		mcp:    map[string]int{"sequential-thinking": 2},
+		tools:  map[string]int{"code2prompt": 2},
+	},
+	{
+		reason: "backend with a database",
+		match:  func(c *ProjectCharacteristics) bool { return c.HasBackend && c.HasDatabase },
+		mcp:    map[string]int{"sequential-thinking": 2},
+	},
+	{
+		reason: "test suite",
+		match:  func(c *ProjectCharacteristics) bool { return c.HasTesting },
+		tools:  map[string]int{"ast-grep": 1},
+	},
+	{
+		reason: "every project",
+		match:  func(*ProjectCharacteristics) bool { return true },
+		mcp:    map[string]int{"sequential-thinking": 1},
+		tools:  map[string]int{"code2prompt": 1},
+	},
+}
+
+// ruleSpecialistSources are the files of the specialists the rules
+// recommend, in the syntax of specialistSources
+var ruleSpecialistSources = map[string][]string{
+	"go-specialist":     {"**/*.go"},
+	"rust-specialist":   {"**/*.rs"},
+	"python-specialist": {"**/*.py"},
+	"ts-specialist":     {"**/*.ts", "**/*.tsx"},
+	"js-specialist":     {"**/*.js", "**/*.jsx"},
+	"cli-specialist":    {"cmd", "bin"},
+}
+
+// specialistFiles returns the files a specialist covers, for the analyzer's
+// specialists and for the ones the rules recommend
+func specialistFiles(name string) []string {
+	if sources := SpecialistSources(name); sources != nil {
+		return sources
+	}
+	return ruleSpecialistSources[name]
+}
+
+// activeChanges is how many recent changes to a specialist's files make
+// its area very active
+const activeChanges = 10
+
+// languages lists the programming languages among the project types
+func (chars *ProjectCharacteristics) languages() []string {
+	var languages []string
+	for _, pt := range chars.ProjectTypes {
+		switch pt {
+		case ProjectTypeGo, ProjectTypeJavaScript, ProjectTypeTypeScript, ProjectTypePython, ProjectTypeRust, ProjectTypeJava:
+			languages = append(languages, strings.ToLower(GetProjectTypeName(pt)))
+		}
+	}
+	return languages
+}
+
+// Tags lists the project types of the analysis in the vocabulary of the
+// MCP catalog: languages, frameworks, backend, frontend or fullstack, cli
+func (chars *ProjectCharacteristics) Tags() []string {
+	var tags []string
+	add := func(tag string) {
+		if tag != "" && !contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	add(chars.MainLanguage)
+	for _, language := range chars.languages() {
+		add(language)
+	}
+	for _, framework := range chars.Frameworks {
+		add(framework)
+	}
+	switch {
+	case chars.HasBackend && chars.HasFrontend:
+		add("fullstack")
+	case chars.HasBackend:
+		add("backend")
+	case chars.HasFrontend:
+		add("frontend")
+	}
+	if chars.HasCLI {
+		add("cli")
+	}
+	return tags
+}
+
+// Recommend ranks the enhancements for an analyzed project: MCP servers of
+// catalog, CLI tools crew documents and specialists. The specialists the
+// analyzer detected are always included.
+func Recommend(chars *ProjectCharacteristics, catalog *mcpcatalog.Catalog) *RecommendationReport {
+	tags := chars.Tags()
+	scores := map[string]*Recommendation{}
+	add := func(kind, name string, score int, reason string) {
+		key := kind + "/" + name
+		rec, ok := scores[key]
+		if !ok {
+			rec = &Recommendation{Kind: kind, Name: name}
+			scores[key] = rec
+		}
+		rec.Score += score
+		if !contains(rec.Reasons, reason) {
+			rec.Reasons = append(rec.Reasons, reason)
+		}
+	}
+
+	for _, rule := range recommendationRules {
+		if !rule.match(chars) {
+			continue
+		}
+		for name, score := range rule.mcp {
+			if server, ok := catalog.Lookup(name); ok {
+				add(KindMCPServer, server.Name, score, rule.reason)
+			}
+		}
+		for name, score := range rule.tools {
+			add(KindCLITool, name, score, rule.reason)
+		}
+		for name, score := range rule.specialists {
+			add(KindSpecialist, name, score, rule.reason)
+		}
+	}
+
+	// Servers, including the ones users added, recommend themselves for
+	// project types in the catalog
+	for _, server := range catalog.Servers() {
+		var matched []string
+		for _, tag := range tags {
+			if server.RecommendedFor(tag) {
+				matched = append(matched, tag)
+			}
+		}
+		if len(matched) > 0 {
+			add(KindMCPServer, server.Name, 1, fmt.Sprintf("catalog recommends it for %s projects", strings.Join(matched, ", ")))
+		}
+	}
+	for _, agent := range chars.DetectedAgents {
+		add(KindSpecialist, agent, 2, "detected by the project analysis")
+	}
+
+	// Specialists for the parts of the project that change most come first
+	if chars.Git != nil {
+		for _, rec := range scores {
+			if rec.Kind != KindSpecialist {
+				continue
+			}
+			changes := chars.Git.RecentChanges(specialistFiles(rec.Name))
+			if changes == 0 {
+				continue
+			}
+			rec.Score++
+			if changes >= activeChanges {
+				rec.Score++
+			}
+			rec.Reasons = append(rec.Reasons, fmt.Sprintf("%d recent changes to its files", changes))
+		}
+	}
+
+	tools := initializeCLITools()
+	report := &RecommendationReport{
+		Tags:        tags,
+		MCPServers:  []Recommendation{},
+		CLITools:    []Recommendation{},
+		Specialists: []Recommendation{},
+		Git:         chars.Git,
+	}
+	for _, rec := range scores {
+		switch rec.Kind {
+		case KindMCPServer:
+			server, _ := catalog.Lookup(rec.Name)
+			rec.Description = server.Description
+			report.MCPServers = append(report.MCPServers, *rec)
+		case KindCLITool:
+			rec.Description = tools[rec.Name].Description
+			report.CLITools = append(report.CLITools, *rec)
+		case KindSpecialist:
+			rec.Description = GetAgentDescription(rec.Name)
+			report.Specialists = append(report.Specialists, *rec)
+		}
+	}
+	for _, recs := range [][]Recommendation{report.MCPServers, report.CLITools, report.Specialists} {
+		sortRecommendations(recs)
+	}
+	return report
+}
+
+// sortRecommendations orders by score, highest first, then by name
+func sortRecommendations(recs []Recommendation) {
+	sort.Slice(recs, func(i, j int) bool {
+		if recs[i].Score != recs[j].Score {
+			return recs[i].Score > recs[j].Score
+		}
+		return recs[i].Name < recs[j].Name
+	})
+}
+
+// LoadFlags returns the /crew:onboard flags that enable the recommended MCP
+// servers and CLI tools
+func (r *RecommendationReport) LoadFlags() []string {
+	var flags []string
+	for _, recs := range [][]Recommendation{r.MCPServers, r.CLITools} {
+		for _, rec := range recs {
+			flags = append(flags, "--"+rec.Name)
+		}
+	}
+	return flags
+}
+
+// Markdown renders the report for the /crew:onboard prompt
+func (r *RecommendationReport) Markdown() string {
+	var b strings.Builder
+	b.WriteString("## 📊 Recommended Enhancements\n\n")
+	if len(r.Tags) > 0 {
+		fmt.Fprintf(&b, "Detected: %s\n", strings.Join(r.Tags, ", "))
+	}
+	if r.Git != nil {
+		fmt.Fprintf(&b, "History: %s\n", r.Git.Summary())
+	}
+	for _, section := range []struct {
+		title string
+		recs  []Recommendation
+	}{
+		{"MCP Servers", r.MCPServers},
+		{"CLI Tools", r.CLITools},
+		{"Specialists", r.Specialists},
+	} {
+		if len(section.recs) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n### %s\n", section.title)
+		for i, rec := range section.recs {
+			fmt.Fprintf(&b, "%d. **%s** (score %d): %s\n", i+1, rec.Name, rec.Score, strings.Join(rec.Reasons, "; "))
+		}
+	}
+	if flags := r.LoadFlags(); len(flags) > 0 {
+		fmt.Fprintf(&b, "\nEnable them with: /crew:onboard %s\n", strings.Join(flags, " "))
+	}
+	return b.String()
+}
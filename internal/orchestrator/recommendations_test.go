@@ -0,0 +1,97 @@
+package orchestrator
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/mcpcatalog"
+)
+
+func names(recs []Recommendation, n int) []string {
+	var out []string
+	for i, rec := range recs {
+		if i == n {
+			break
+		}
+		out = append(out, rec.Name)
+	}
+	return out
+}
+
+func TestRecommend(t *testing.T) {
+	catalog := mcpcatalog.Builtin()
+
+	react := &ProjectCharacteristics{
+		MainLanguage: "typescript",
+		Frameworks:   []string{"react"},
+		ProjectTypes: []ProjectType{ProjectTypeTypeScript, ProjectTypeReact},
+		HasFrontend:  true,
+		HasTesting:   true,
+	}
+	report := Recommend(react, catalog)
+	if got := names(report.MCPServers, 2); !reflect.DeepEqual(got, []string{"playwright", "magic"}) {
+		t.Errorf("React MCP servers = %v", names(report.MCPServers, -1))
+	}
+	if !reflect.DeepEqual(report.Tags, []string{"typescript", "react", "frontend"}) {
+		t.Errorf("Tags = %v", report.Tags)
+	}
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module x\n\nrequire github.com/spf13/cobra v1.8.0\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	goCLI, err := NewProjectAnalyzer(dir).Analyze()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !goCLI.HasCLI {
+		t.Fatal("Expected a cobra dependency to mark a CLI")
+	}
+	report = Recommend(goCLI, catalog)
+	if got := names(report.MCPServers, 1); !reflect.DeepEqual(got, []string{"serena"}) {
+		t.Errorf("Go CLI MCP servers = %v", names(report.MCPServers, -1))
+	}
+	if got := names(report.Specialists, 2); !reflect.DeepEqual(got, []string{"cli-specialist", "go-specialist"}) {
+		t.Errorf("Go CLI specialists = %v", names(report.Specialists, -1))
+	}
+	for _, rec := range report.MCPServers {
+		if rec.Name == "magic" {
+			t.Errorf("Did not expect magic for a Go CLI: %+v", rec)
+		}
+	}
+	if flags := strings.Join(report.LoadFlags(), " "); !strings.HasPrefix(flags, "--serena ") || !strings.Contains(flags, "--ast-grep") {
+		t.Errorf("LoadFlags() = %s", flags)
+	}
+}
+
+func TestRecommendUsesCatalogProjectTypes(t *testing.T) {
+	catalog, err := mcpcatalog.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	user := t.TempDir()
+	path := filepath.Join(user, ".crew", "config", mcpcatalog.FileName)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(`{"servers": [{"name": "pgtools", "description": "Postgres", "package": "pg-mcp", "project_types": ["cli"]}]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if catalog, err = mcpcatalog.Load(user); err != nil {
+		t.Fatal(err)
+	}
+
+	report := Recommend(&ProjectCharacteristics{MainLanguage: "go", HasCLI: true}, catalog)
+	for _, rec := range report.MCPServers {
+		if rec.Name == "pgtools" {
+			if rec.Reasons[0] != "catalog recommends it for cli projects" || rec.Description != "Postgres" {
+				t.Errorf("unexpected recommendation %+v", rec)
+			}
+			return
+		}
+	}
+	t.Errorf("Expected the user's server to be recommended: %v", names(report.MCPServers, -1))
+}
@@ -0,0 +1,201 @@
+package orchestrator
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/agents"
+	"github.com/jonwraymond/claude-code-super-crew/internal/claudemd"
+	"github.com/jonwraymond/claude-code-super-crew/internal/pathsutil"
+)
+
+// Managed sections of the project orchestrator-specialist.md
+const (
+	// SectionRouting holds the routing rules crew derives from the project
+	// analysis and is rewritten on every refresh
+	SectionRouting = "project-routing"
+	// SectionCustom belongs to the user and survives every refresh
+	SectionCustom = "custom"
+)
+
+// Refresh is the outcome of re-rendering a project orchestrator
+type Refresh struct {
+	Path     string `json:"path"`
+	Template string `json:"template"`
+	// Backup is where the previous orchestrator was kept, if it changed
+	Backup  string `json:"backup,omitempty"`
+	Changed bool   `json:"changed"`
+	// PreservedCustom reports whether the previous orchestrator had a
+	// custom section that was carried over
+	PreservedCustom bool `json:"preserved_custom"`
+	// Specialists are the project specialists the routing rules name and
+	// Missing the ones the analysis recommends that do not exist yet
+	Specialists []string `json:"specialists"`
+	Missing     []string `json:"missing,omitempty"`
+}
+
+// RefreshOrchestrator re-renders .claude/agents/orchestrator-specialist.md
+// in projectRoot from the orchestrator template at templatePath. The
+// routing section is regenerated from a fresh analysis of the project and
+// its specialists; the custom section of the current orchestrator is kept.
+// A changed orchestrator is first copied to a .backup file.
+func RefreshOrchestrator(projectRoot, templatePath string) (*Refresh, error) {
+	installer := NewOrchestratorInstaller(projectRoot)
+	path := pathsutil.OrchestratorPath(projectRoot)
+	refresh := &Refresh{Path: path, Template: templatePath, Specialists: []string{}}
+
+	source, err := os.ReadFile(templatePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read orchestrator template: %w", err)
+	}
+	doc, err := claudemd.Parse(string(source))
+	if err != nil {
+		return nil, fmt.Errorf("orchestrator template %s: %w", templatePath, err)
+	}
+
+	chars, err := NewProjectAnalyzer(projectRoot).Analyze()
+	if err != nil {
+		return nil, fmt.Errorf("failed to analyze project: %w", err)
+	}
+	// Agents that do not parse cannot be routed to; crew claude --validate
+	// reports them
+	loaded, _ := agents.LoadDir(installer.AgentsDir, agents.Project)
+	var specialists []*agents.Agent
+	for _, agent := range loaded {
+		if agents.IsSpecialist(agent) {
+			specialists = append(specialists, agent)
+			refresh.Specialists = append(refresh.Specialists, agent.Name)
+		}
+	}
+	for _, name := range chars.DetectedAgents {
+		if !contains(refresh.Specialists, name) {
+			refresh.Missing = append(refresh.Missing, name)
+		}
+	}
+
+	routing, _ := doc.Get(SectionRouting)
+	doc.Upsert(claudemd.Section{
+		ID:      SectionRouting,
+		Version: routing.Version,
+		Content: renderRouting(chars, specialists, refresh.Missing),
+	}, claudemd.AtBottom)
+
+	current, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read orchestrator: %w", err)
+	}
+	if err == nil {
+		previous, parseErr := claudemd.Parse(string(current))
+		if parseErr != nil {
+			return nil, fmt.Errorf("cannot keep the custom section of %s: %w", path, parseErr)
+		}
+		if custom, ok := previous.Get(SectionCustom); ok {
+			doc.Upsert(custom, claudemd.AtBottom)
+			refresh.PreservedCustom = true
+		}
+	}
+
+	rendered := doc.String()
+	if string(current) == rendered {
+		return refresh, nil
+	}
+	if err := os.MkdirAll(installer.AgentsDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create agents directory: %w", err)
+	}
+	if len(current) > 0 {
+		refresh.Backup = path + ".backup"
+		if err := os.WriteFile(refresh.Backup, current, 0644); err != nil {
+			return nil, fmt.Errorf("failed to back up orchestrator: %w", err)
+		}
+	}
+	if err := os.WriteFile(path, []byte(rendered), 0644); err != nil {
+		return nil, fmt.Errorf("failed to write orchestrator: %w", err)
+	}
+	refresh.Changed = true
+	return refresh, nil
+}
+
+// renderRouting writes the routing rules for the analyzed project. It has
+// no dates, and measures activity in commits rather than days, so that
+// refreshing an unchanged project changes nothing.
+func renderRouting(chars *ProjectCharacteristics, specialists []*agents.Agent, missing []string) string {
+	var b strings.Builder
+	b.WriteString("### Project Routing\n\n")
+	b.WriteString("*Generated by `crew load --refresh-orchestrator` from the project analysis. " +
+		"This section is rewritten on every refresh; keep your own rules in Project Customizations.*\n\n")
+
+	language := chars.MainLanguage
+	if language == "" {
+		language = "not detected"
+	}
+	fmt.Fprintf(&b, "- **Language**: %s\n", language)
+	if len(chars.Frameworks) > 0 {
+		fmt.Fprintf(&b, "- **Frameworks**: %s\n", strings.Join(chars.Frameworks, ", "))
+	}
+	if traits := chars.Traits(); len(traits) > 0 {
+		fmt.Fprintf(&b, "- **Detected**: %s\n", strings.Join(traits, ", "))
+	}
+	if chars.Git != nil && len(chars.Git.ActiveAreas) > 0 {
+		fmt.Fprintf(&b, "- **Active areas**: %s\n", joinCounts(chars.Git.ActiveAreas))
+		fmt.Fprintf(&b, "- **Hotspots**: %s\n", joinCounts(chars.Git.MostChanged))
+	}
+
+	b.WriteString("\n")
+	if len(specialists) == 0 {
+		b.WriteString("No project specialists exist yet; route every request to the user-level personas.\n")
+	} else {
+		if chars.Git != nil {
+			specialists = byActivity(specialists, chars.Git)
+			b.WriteString("Specialists are listed by recent activity in their files; changes to the hotspots deserve a second look from the first one that covers them.\n\n")
+		}
+		b.WriteString("Route a request to the first specialist whose triggers it mentions, before falling back to the personas:\n\n")
+		b.WriteString("| Specialist | Triggers |\n")
+		b.WriteString("|------------|----------|\n")
+		for _, agent := range specialists {
+			fmt.Fprintf(&b, "| `%s` | %s |\n", agent.Name, specialistTriggers(agent))
+		}
+	}
+
+	if len(missing) > 0 {
+		fmt.Fprintf(&b, "\n**Recommended but not created**: %s. Run `/crew:onboard` to create them.\n", strings.Join(missing, ", "))
+	}
+	return b.String()
+}
+
+// joinCounts lists paths with their commit counts, e.g. "internal/cli (12)"
+func joinCounts(counts []FileCount) string {
+	parts := make([]string, len(counts))
+	for i, count := range counts {
+		parts[i] = fmt.Sprintf("`%s` (%d)", count.Path, count.Count)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// byActivity orders specialists by the recent changes to the files that
+// justify them, keeping the order of equally active ones
+func byActivity(specialists []*agents.Agent, git *GitSignals) []*agents.Agent {
+	sorted := append([]*agents.Agent{}, specialists...)
+	activity := make(map[string]int, len(sorted))
+	for _, agent := range sorted {
+		activity[agent.Name] = git.RecentChanges(specialistFiles(agent.Name))
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return activity[sorted[i].Name] > activity[sorted[j].Name]
+	})
+	return sorted
+}
+
+// specialistTriggers describes when to route to agent: its primary and
+// secondary activation keywords, or else its description
+func specialistTriggers(agent *agents.Agent) string {
+	keywords := append(append([]string{}, agent.Keywords.Primary...), agent.Keywords.Secondary...)
+	if len(keywords) > 0 {
+		return strings.Join(keywords, ", ")
+	}
+	if agent.Description != "" {
+		return strings.NewReplacer("|", "/", "\n", " ").Replace(agent.Description)
+	}
+	return "requests in its domain"
+}
@@ -0,0 +1,86 @@
+package orchestrator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const refreshTemplate = `---
+name: orchestrator-specialist
+description: Routes requests
+---
+
+# Orchestrator v2
+
+<!-- BEGIN crew:project-routing -->
+placeholder
+<!-- END crew:project-routing -->
+
+<!-- BEGIN crew:custom -->
+Add your rules here.
+<!-- END crew:custom -->
+`
+
+func TestRefreshOrchestrator(t *testing.T) {
+	root := t.TempDir()
+	template := filepath.Join(t.TempDir(), "orchestrator-specialist.md")
+	agentsDir := filepath.Join(root, ".claude", "agents")
+	files := map[string]string{
+		template:                      refreshTemplate,
+		filepath.Join(root, "go.mod"): "module example\n",
+		filepath.Join(agentsDir, "go-backend-specialist.md"):   "---\nname: go-backend-specialist\nactivation_keywords:\n  primary: [goroutine]\n---\n",
+		filepath.Join(agentsDir, "orchestrator-specialist.md"): "# Orchestrator v1\n\n<!-- BEGIN crew:custom -->\nAlways run make lint first.\n<!-- END crew:custom -->\n",
+	}
+	for path, content := range files {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	refresh, err := RefreshOrchestrator(root, template)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !refresh.Changed || !refresh.PreservedCustom || refresh.Backup == "" {
+		t.Errorf("unexpected refresh: %+v", refresh)
+	}
+	if strings.Join(refresh.Specialists, ",") != "go-backend-specialist" || strings.Join(refresh.Missing, ",") != "api-specialist" {
+		t.Errorf("specialists = %v, missing = %v", refresh.Specialists, refresh.Missing)
+	}
+
+	data, err := os.ReadFile(refresh.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := string(data)
+	for _, want := range []string{
+		"# Orchestrator v2",
+		"- **Language**: go",
+		"| `go-backend-specialist` | goroutine |",
+		"**Recommended but not created**: api-specialist.",
+		"<!-- BEGIN crew:custom -->\nAlways run make lint first.\n<!-- END crew:custom -->",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("refreshed orchestrator is missing %q:\n%s", want, content)
+		}
+	}
+	if strings.Contains(content, "placeholder") || strings.Contains(content, "Add your rules here.") {
+		t.Errorf("template placeholders were kept:\n%s", content)
+	}
+	if backup, _ := os.ReadFile(refresh.Backup); !strings.Contains(string(backup), "# Orchestrator v1") {
+		t.Errorf("backup does not hold the previous orchestrator: %q", backup)
+	}
+
+	again, err := RefreshOrchestrator(root, template)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.Changed {
+		t.Error("refreshing an unchanged project rewrote the orchestrator")
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/jonwraymond/claude-code-super-crew/internal/mcpcatalog"
 	"github.com/jonwraymond/claude-code-super-crew/pkg/logger"
 )
 
@@ -44,7 +45,7 @@ func (o *SlashCommandOrchestrator) HandleLoadCommand(args []string, workingDir s
 	loadHandler := NewLoadCommandHandler(workingDir)
 	
 	// Parse MCP and tool flags from args
-	mcpFlags := o.parseMCPFlags(args)
+	mcpFlags := o.parseMCPFlags(args, loadHandler.MCPCatalog)
 	toolFlags := o.parseToolFlags(args)
 	
 	// Execute with enhancements if any flags provided
@@ -62,48 +63,38 @@ func (o *SlashCommandOrchestrator) HandleLoadCommand(args []string, workingDir s
 	return loadHandler.Execute()
 }
 
-// parseMCPFlags extracts MCP server flags from command arguments
-func (o *SlashCommandOrchestrator) parseMCPFlags(args []string) []string {
+// parseMCPFlags extracts the MCP servers command arguments enable, by
+// catalog name or alias, as catalog names
+func (o *SlashCommandOrchestrator) parseMCPFlags(args []string, catalog *mcpcatalog.Catalog) []string {
 	var mcpFlags []string
-	
-	// Official MCP servers in our framework
-	officialServers := map[string]bool{
-		"context7":    true,
-		"sequential":  true,
-		"magic":       true,
-		"playwright":  true,
-		"serena":      true,
-	}
-	
+
 	for _, arg := range args {
 		// Check for --mcp=server1,server2 format
 		if strings.HasPrefix(arg, "--mcp=") {
-			servers := strings.Split(strings.TrimPrefix(arg, "--mcp="), ",")
-			for _, server := range servers {
-				server = strings.TrimSpace(strings.ToLower(server))
-				if officialServers[server] {
-					mcpFlags = append(mcpFlags, server)
+			for _, name := range strings.Split(strings.TrimPrefix(arg, "--mcp="), ",") {
+				if server, ok := catalog.Lookup(name); ok {
+					mcpFlags = append(mcpFlags, server.Name)
 				}
 			}
+			continue
 		}
-		
-		// Check for individual --context7, --sequential, etc.
-		for server := range officialServers {
-			if arg == "--"+server {
-				mcpFlags = append(mcpFlags, server)
-			}
-		}
-		
+
 		// Check for --all-mcp flag
 		if arg == "--all-mcp" {
-			// Add all official servers
-			for server := range officialServers {
-				mcpFlags = append(mcpFlags, server)
+			for _, server := range catalog.Servers() {
+				mcpFlags = append(mcpFlags, server.Name)
 			}
 			break // No need to check more flags
 		}
+
+		// Check for individual --context7, --sequential, etc.
+		if strings.HasPrefix(arg, "--") {
+			if server, ok := catalog.Lookup(strings.TrimPrefix(arg, "--")); ok {
+				mcpFlags = append(mcpFlags, server.Name)
+			}
+		}
 	}
-	
+
 	// Remove duplicates
 	seen := make(map[string]bool)
 	unique := []string{}
@@ -8,6 +8,9 @@ import (
 	"path/filepath"
 	"strings"
 	"time"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/mcpcatalog"
+	"github.com/jonwraymond/claude-code-super-crew/internal/pathsutil"
 )
 
 // StructuredPrompt represents a comprehensive prompt with full context
@@ -229,14 +232,13 @@ func (pb *PromptBuilder) identifyPainPoints(patterns []string) []string {
 
 // getAvailableResources lists all available resources
 func (pb *PromptBuilder) getAvailableResources() AvailableResources {
+	var servers []MCPServer
+	for _, server := range mcpcatalog.ForProject(pb.projectRoot).Servers() {
+		servers = append(servers, MCPServer{Name: server.Name, Enabled: server.Enabled, Purpose: server.Description})
+	}
+
 	return AvailableResources{
-		MCPServers: []MCPServer{
-			{Name: "context7", Enabled: true, Purpose: "Documentation lookup"},
-			{Name: "sequential", Enabled: true, Purpose: "Complex analysis"},
-			{Name: "serena", Enabled: true, Purpose: "Semantic code understanding"},
-			{Name: "magic", Enabled: false, Purpose: "UI components"},
-			{Name: "playwright", Enabled: false, Purpose: "Browser testing"},
-		},
+		MCPServers: servers,
 		CLITools: []CLITool{
 			{Name: "code2prompt", Command: "code2prompt", Description: "Generate code context"},
 			{Name: "ast-grep", Command: "ast-grep", Description: "Semantic search"},
@@ -255,7 +257,7 @@ func (pb *PromptBuilder) listExistingAgents() []string {
 	agents := []string{}
 	
 	// Check local agents
-	localAgentsDir := filepath.Join(pb.projectRoot, ".claude", "agents")
+	localAgentsDir := pathsutil.AgentsDir(pb.projectRoot)
 	if entries, err := os.ReadDir(localAgentsDir); err == nil {
 		for _, entry := range entries {
 			if strings.HasSuffix(entry.Name(), ".md") && entry.Name() != "project-analysis.json" {
@@ -0,0 +1,208 @@
+// Package overlay layers a per-user installation over a shared, read-only
+// framework installation. Framework files are symlinked from the shared
+// directory; directories are real, so users can keep their own agents and
+// commands next to the shared ones, and a user file replaces the shared
+// file of the same name.
+package overlay
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// userOwned are top-level entries of the shared directory that every user
+// keeps their own copy of
+var userOwned = map[string]bool{
+	"settings.json":       true,
+	"settings.local.json": true,
+	"backups":             true,
+	"logs":                true,
+}
+
+// Result describes what Sync changed or would change. Paths are relative
+// to the user directory.
+type Result struct {
+	Linked     []string `json:"linked"`
+	Relinked   []string `json:"relinked"`
+	Removed    []string `json:"removed"`
+	Overridden []string `json:"overridden"`
+	Unchanged  int      `json:"unchanged"`
+}
+
+// Changed reports whether Sync changed anything
+func (r *Result) Changed() bool {
+	return len(r.Linked)+len(r.Relinked)+len(r.Removed) > 0
+}
+
+// skip reports whether rel, relative to the shared directory, stays out of
+// the overlay: crew state, hidden files and user-owned entries at the top
+func skip(rel string) bool {
+	if strings.Contains(rel, string(filepath.Separator)) {
+		return false
+	}
+	return strings.HasPrefix(rel, ".") || userOwned[rel]
+}
+
+// Sync links every framework file of sharedDir into userDir that the user
+// has not replaced, and removes links to files the shared installation no
+// longer has. With dryRun nothing is written.
+func Sync(sharedDir, userDir string, dryRun bool) (*Result, error) {
+	res := &Result{}
+	if !dryRun {
+		if err := os.MkdirAll(userDir, 0755); err != nil {
+			return res, err
+		}
+	}
+	err := filepath.WalkDir(sharedDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(sharedDir, path)
+		if err != nil || rel == "." {
+			return err
+		}
+		if skip(rel) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		target := filepath.Join(userDir, rel)
+		info, err := os.Lstat(target)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+
+		if d.IsDir() {
+			switch {
+			case err != nil:
+				if !dryRun {
+					return os.MkdirAll(target, 0755)
+				}
+				return nil
+			case info.IsDir():
+				return nil
+			}
+			// A user file in place of a shared directory hides all of it
+			res.Overridden = append(res.Overridden, rel)
+			return filepath.SkipDir
+		}
+
+		switch {
+		case err != nil:
+			res.Linked = append(res.Linked, rel)
+			if !dryRun {
+				return os.Symlink(path, target)
+			}
+		case !linksInto(target, sharedDir):
+			res.Overridden = append(res.Overridden, rel)
+		case readlink(target) != path:
+			res.Relinked = append(res.Relinked, rel)
+			if !dryRun {
+				if err := os.Remove(target); err != nil {
+					return err
+				}
+				return os.Symlink(path, target)
+			}
+		default:
+			res.Unchanged++
+		}
+		return nil
+	})
+	if err != nil {
+		return res, err
+	}
+
+	// Links whose shared file is gone
+	stale, err := links(sharedDir, userDir, func(target string) bool {
+		_, err := os.Stat(target)
+		return os.IsNotExist(err)
+	})
+	if err != nil {
+		return res, err
+	}
+	for _, rel := range stale {
+		res.Removed = append(res.Removed, rel)
+		if !dryRun {
+			if err := os.Remove(filepath.Join(userDir, rel)); err != nil {
+				return res, err
+			}
+		}
+	}
+	return res, nil
+}
+
+// Remove deletes the links from userDir into sharedDir and the directories
+// left empty by that. Files of the user are kept. It returns the removed
+// links.
+func Remove(sharedDir, userDir string, dryRun bool) ([]string, error) {
+	removed, err := links(sharedDir, userDir, func(string) bool { return true })
+	if err != nil || dryRun {
+		return removed, err
+	}
+
+	dirs := make(map[string]bool)
+	for _, rel := range removed {
+		if err := os.Remove(filepath.Join(userDir, rel)); err != nil {
+			return removed, err
+		}
+		for dir := filepath.Dir(rel); dir != "."; dir = filepath.Dir(dir) {
+			dirs[dir] = true
+		}
+	}
+
+	// Deepest first, so parents are empty by the time they are reached
+	sorted := make([]string, 0, len(dirs))
+	for dir := range dirs {
+		sorted = append(sorted, dir)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return len(sorted[i]) > len(sorted[j]) })
+	for _, dir := range sorted {
+		// Fails, and is kept, while it still holds user files
+		os.Remove(filepath.Join(userDir, dir))
+	}
+	return removed, nil
+}
+
+// links returns the links in userDir into sharedDir whose target matches
+func links(sharedDir, userDir string, match func(target string) bool) ([]string, error) {
+	var found []string
+	err := filepath.WalkDir(userDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(userDir, path)
+		if err != nil || rel == "." {
+			return err
+		}
+		if d.IsDir() && skip(rel) {
+			return filepath.SkipDir
+		}
+		if d.Type()&fs.ModeSymlink != 0 && linksInto(path, sharedDir) && match(readlink(path)) {
+			found = append(found, rel)
+		}
+		return nil
+	})
+	return found, err
+}
+
+// linksInto reports whether path is a symlink into dir
+func linksInto(path, dir string) bool {
+	target := readlink(path)
+	return target != "" && strings.HasPrefix(target, dir+string(filepath.Separator))
+}
+
+func readlink(path string) string {
+	target, err := os.Readlink(path)
+	if err != nil {
+		return ""
+	}
+	if !filepath.IsAbs(target) {
+		target = filepath.Join(filepath.Dir(path), target)
+	}
+	return target
+}
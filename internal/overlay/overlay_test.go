@@ -0,0 +1,108 @@
+package overlay
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func newShared(t *testing.T) string {
+	t.Helper()
+	shared := filepath.Join(t.TempDir(), "shared")
+	for _, file := range []string{
+		"CLAUDE.md",
+		"agents/architect.md",
+		"commands/crew/build.md",
+		"settings.json",
+		".crew/config/crew-metadata.json",
+	} {
+		writeFile(t, filepath.Join(shared, file), "shared "+file)
+	}
+	return shared
+}
+
+func TestSync(t *testing.T) {
+	shared := newShared(t)
+	user := filepath.Join(t.TempDir(), "user")
+	writeFile(t, filepath.Join(user, "agents", "mine.md"), "personal agent")
+	writeFile(t, filepath.Join(user, "CLAUDE.md"), "my CLAUDE.md")
+
+	dry, err := Sync(shared, user, true)
+	if err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+	if _, err := os.Lstat(filepath.Join(user, "commands")); !os.IsNotExist(err) {
+		t.Fatal("dry run must not write")
+	}
+
+	res, err := Sync(shared, user, false)
+	if err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	wantLinked := []string{filepath.Join("agents", "architect.md"), filepath.Join("commands", "crew", "build.md")}
+	if !reflect.DeepEqual(res.Linked, wantLinked) || !reflect.DeepEqual(dry.Linked, wantLinked) {
+		t.Errorf("linked = %v (dry run %v), want %v", res.Linked, dry.Linked, wantLinked)
+	}
+	if !reflect.DeepEqual(res.Overridden, []string{"CLAUDE.md"}) {
+		t.Errorf("overridden = %v", res.Overridden)
+	}
+	for _, skipped := range []string{"settings.json", ".crew"} {
+		if _, err := os.Lstat(filepath.Join(user, skipped)); !os.IsNotExist(err) {
+			t.Errorf("%s should stay out of the overlay", skipped)
+		}
+	}
+	if data, _ := os.ReadFile(filepath.Join(user, "agents", "architect.md")); string(data) != "shared agents/architect.md" {
+		t.Errorf("linked file reads %q", data)
+	}
+
+	// A second sync has nothing to do
+	if again, err := Sync(shared, user, false); err != nil || again.Changed() || again.Unchanged != 2 {
+		t.Errorf("second sync = %+v, %v", again, err)
+	}
+
+	// Files dropped from the shared installation lose their links
+	if err := os.Remove(filepath.Join(shared, "commands", "crew", "build.md")); err != nil {
+		t.Fatal(err)
+	}
+	res, err = Sync(shared, user, false)
+	if err != nil || !reflect.DeepEqual(res.Removed, []string{filepath.Join("commands", "crew", "build.md")}) {
+		t.Errorf("removed = %+v, %v", res, err)
+	}
+}
+
+func TestRemove(t *testing.T) {
+	shared := newShared(t)
+	user := filepath.Join(t.TempDir(), "user")
+	writeFile(t, filepath.Join(user, "agents", "mine.md"), "personal agent")
+	if _, err := Sync(shared, user, false); err != nil {
+		t.Fatal(err)
+	}
+
+	removed, err := Remove(shared, user, false)
+	if err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+	if len(removed) != 3 {
+		t.Errorf("removed = %v", removed)
+	}
+	if _, err := os.Stat(filepath.Join(user, "agents", "mine.md")); err != nil {
+		t.Error("personal files must be kept")
+	}
+	if _, err := os.Stat(filepath.Join(user, "commands")); !os.IsNotExist(err) {
+		t.Error("directories left empty should be removed")
+	}
+	if _, err := os.Stat(filepath.Join(shared, "commands", "crew", "build.md")); err != nil {
+		t.Error("shared files must not be touched")
+	}
+}
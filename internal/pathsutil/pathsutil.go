@@ -0,0 +1,107 @@
+// Package pathsutil resolves where Claude Code and crew keep their files
+// inside a project, and finds the project a directory belongs to. Paths
+// are joined with the platform's separator, so code should build them here
+// rather than from literals such as ".claude/agents".
+package pathsutil
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// Names of the project entries crew reads and writes
+const (
+	ClaudeDirName    = ".claude"
+	AgentsDirName    = "agents"
+	CommandsDirName  = "commands"
+	OrchestratorFile = "orchestrator-specialist.md"
+	GitDirName       = ".git"
+)
+
+// ClaudeDir returns the .claude directory of the project in projectDir
+func ClaudeDir(projectDir string) string {
+	return filepath.Join(projectDir, ClaudeDirName)
+}
+
+// AgentsDir returns the directory of the project's agents
+func AgentsDir(projectDir string) string {
+	return filepath.Join(projectDir, ClaudeDirName, AgentsDirName)
+}
+
+// CommandsDir returns the directory of the project's slash commands
+func CommandsDir(projectDir string) string {
+	return filepath.Join(projectDir, ClaudeDirName, CommandsDirName)
+}
+
+// OrchestratorPath returns the project's orchestrator specialist
+func OrchestratorPath(projectDir string) string {
+	return filepath.Join(AgentsDir(projectDir), OrchestratorFile)
+}
+
+// FindUp returns the nearest of dir and its ancestors that has an entry
+// named one of names
+func FindUp(dir string, names ...string) (string, bool) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", false
+	}
+	for {
+		for _, name := range names {
+			if _, err := os.Lstat(filepath.Join(dir, name)); err == nil {
+				return dir, true
+			}
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+// RepoRoot returns the top of the git work tree dir is in. .git is a
+// directory in a clone and a file in a worktree or submodule; either
+// marks the root.
+func RepoRoot(dir string) (string, bool) {
+	return FindUp(dir, GitDirName)
+}
+
+// FindProject returns the nearest of dir and its ancestors that holds a
+// project: one with a .claude directory or the top of a git work tree.
+// The .claude directory in the home directory holds Claude Code's user
+// settings and does not make home a project.
+func FindProject(dir string) (string, bool) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", false
+	}
+	home, _ := os.UserHomeDir()
+	if home != "" {
+		home = filepath.Clean(home)
+	}
+	for {
+		if _, err := os.Lstat(filepath.Join(dir, GitDirName)); err == nil {
+			return dir, true
+		}
+		if info, err := os.Stat(ClaudeDir(dir)); err == nil && info.IsDir() && dir != home {
+			return dir, true
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+// ProjectDir returns the project dir belongs to, as found by FindProject,
+// or dir itself outside of one
+func ProjectDir(dir string) string {
+	if root, ok := FindProject(dir); ok {
+		return root
+	}
+	if abs, err := filepath.Abs(dir); err == nil {
+		return abs
+	}
+	return dir
+}
@@ -0,0 +1,88 @@
+package pathsutil
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestProjectPaths(t *testing.T) {
+	project := filepath.Join("home", "me", "app")
+	if got, want := OrchestratorPath(project), filepath.Join(project, ".claude", "agents", "orchestrator-specialist.md"); got != want {
+		t.Errorf("OrchestratorPath = %s, want %s", got, want)
+	}
+	if got, want := CommandsDir(project), filepath.Join(project, ".claude", "commands"); got != want {
+		t.Errorf("CommandsDir = %s, want %s", got, want)
+	}
+}
+
+func TestRepoRoot(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "services", "api")
+	worktree := filepath.Join(root, "worktree")
+	for _, dir := range []string{filepath.Join(root, ".git"), nested, filepath.Join(worktree, "cmd")} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// A worktree or submodule has a .git file pointing at the repository
+	if err := os.WriteFile(filepath.Join(worktree, ".git"), []byte("gitdir: ../.git/worktrees/w\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct{ dir, want string }{
+		{root, root},
+		{nested, root},
+		{filepath.Join(worktree, "cmd"), worktree},
+	}
+	for _, tt := range tests {
+		if got, ok := RepoRoot(tt.dir); !ok || got != tt.want {
+			t.Errorf("RepoRoot(%s) = %s, %v; want %s", tt.dir, got, ok, tt.want)
+		}
+		if got := ProjectDir(tt.dir); got != tt.want {
+			t.Errorf("ProjectDir(%s) = %s, want %s", tt.dir, got, tt.want)
+		}
+	}
+
+	outside := t.TempDir()
+	if _, ok := RepoRoot(outside); ok {
+		t.Skip("the temporary directory is inside a git repository")
+	}
+	if got := ProjectDir(outside); got != outside {
+		t.Errorf("ProjectDir outside a repository = %s, want %s", got, outside)
+	}
+}
+
+func TestFindProject(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	repo := filepath.Join(home, "src", "repo")
+	pkg := filepath.Join(repo, "packages", "web")
+	loose := filepath.Join(home, "notes", "drafts")
+	for _, dir := range []string{ClaudeDir(home), filepath.Join(repo, ".git"), ClaudeDir(pkg), filepath.Join(pkg, "src"), loose} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct{ dir, want string }{
+		{filepath.Join(repo, "packages"), repo},
+		// A nested project with its own .claude wins over the repository
+		{filepath.Join(pkg, "src"), pkg},
+		{repo, repo},
+	}
+	for _, tt := range tests {
+		if got, ok := FindProject(tt.dir); !ok || got != tt.want {
+			t.Errorf("FindProject(%s) = %s, %v; want %s", tt.dir, got, ok, tt.want)
+		}
+	}
+
+	// ~/.claude is Claude Code's user configuration, not a project
+	got, ok := FindProject(loose)
+	if ok && got == home {
+		t.Errorf("FindProject(%s) took the home directory for a project", loose)
+	}
+	if !ok && ProjectDir(loose) != loose {
+		t.Errorf("ProjectDir(%s) = %s, want the directory itself", loose, ProjectDir(loose))
+	}
+}
@@ -0,0 +1,17 @@
+//go:build unix
+
+package perms
+
+import (
+	"io/fs"
+	"syscall"
+)
+
+// owner returns the uid and gid owning the file described by info
+func owner(info fs.FileInfo) (uid, gid int, ok bool) {
+	st, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return 0, 0, false
+	}
+	return int(st.Uid), int(st.Gid), true
+}
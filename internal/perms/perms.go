@@ -0,0 +1,170 @@
+// Package perms audits the ownership and mode bits of an installation and
+// repairs them. Copying an installation between machines, e.g. by
+// restoring a backup, easily loses the executable bit on hook scripts or
+// leaves files owned by another user.
+package perms
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+)
+
+// Kind names what is wrong with a path
+type Kind string
+
+const (
+	// NotExecutable is a hook script Claude cannot run
+	NotExecutable Kind = "not-executable"
+	// WorldWritable is a path any user can modify
+	WorldWritable Kind = "world-writable"
+	// Exposed is a private file other users can read
+	Exposed Kind = "exposed"
+	// WrongOwner is a path not owned by the owner of the installation
+	WrongOwner Kind = "wrong-owner"
+)
+
+// Issue is one deviation found by Check
+type Issue struct {
+	Path string `json:"path"`
+	Kind Kind   `json:"kind"`
+	// Mode and Want are the current and the expected permission bits
+	Mode fs.FileMode `json:"mode"`
+	Want fs.FileMode `json:"want"`
+	// UID and WantUID are set for WrongOwner
+	UID     int `json:"uid,omitempty"`
+	WantUID int `json:"want_uid,omitempty"`
+	wantGID int
+}
+
+// String describes the issue for the report
+func (i Issue) String() string {
+	switch i.Kind {
+	case NotExecutable:
+		return fmt.Sprintf("hook script is not executable (%04o, want %04o)", i.Mode, i.Want)
+	case WorldWritable:
+		return fmt.Sprintf("writable by any user (%04o, want %04o)", i.Mode, i.Want)
+	case Exposed:
+		return fmt.Sprintf("private file readable by other users (%04o, want %04o)", i.Mode, i.Want)
+	case WrongOwner:
+		return fmt.Sprintf("owned by uid %d, the installation by uid %d", i.UID, i.WantUID)
+	}
+	return string(i.Kind)
+}
+
+// Fix corrects the issue. Changing the owner usually needs root.
+func (i Issue) Fix() error {
+	if i.Kind == WrongOwner {
+		if err := os.Lchown(i.Path, i.WantUID, i.wantGID); err != nil {
+			return fmt.Errorf("failed to change owner of %s (try again as root): %w", i.Path, err)
+		}
+		return nil
+	}
+	return os.Chmod(i.Path, i.Want)
+}
+
+// Checker audits the crew files of an installation
+type Checker struct {
+	InstallDir string
+	// Paths are the files and directories to audit, recursively
+	Paths []string
+	// Private lists the base names of files only the owner may read
+	Private []string
+}
+
+// Check audits every path below c.Paths and returns the deviations,
+// sorted by path. Missing paths and symlinks are skipped; links made by
+// install --dev-link carry the modes of the checkout. Mode bits mean
+// little on Windows, so nothing is reported there.
+func (c *Checker) Check() ([]Issue, error) {
+	if runtime.GOOS == "windows" {
+		return nil, nil
+	}
+	root, err := os.Stat(c.InstallDir)
+	if err != nil {
+		return nil, err
+	}
+	uid, gid, hasOwner := owner(root)
+
+	seen := make(map[string]bool)
+	var issues []Issue
+	for _, top := range c.Paths {
+		err := filepath.WalkDir(top, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) {
+					return nil
+				}
+				return err
+			}
+			if seen[path] || d.Type()&fs.ModeSymlink != 0 {
+				return nil
+			}
+			seen[path] = true
+			info, err := d.Info()
+			if err != nil {
+				return nil
+			}
+			issues = append(issues, c.checkMode(path, info)...)
+			if fileUID, _, ok := owner(info); hasOwner && ok && fileUID != uid {
+				issues = append(issues, Issue{Path: path, Kind: WrongOwner, Mode: info.Mode().Perm(),
+					Want: info.Mode().Perm(), UID: fileUID, WantUID: uid, wantGID: gid})
+			}
+			return nil
+		})
+		if err != nil {
+			return issues, err
+		}
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool { return issues[i].Path < issues[j].Path })
+	return issues, nil
+}
+
+// checkMode applies the mode rules to one path. A path breaking several
+// rules gets one issue per rule, each wanting the mode with all fixes
+// applied, so fixing them in any order ends up at the same mode.
+func (c *Checker) checkMode(path string, info fs.FileInfo) []Issue {
+	mode := info.Mode().Perm()
+	want := mode &^ 0002
+
+	var kinds []Kind
+	if mode&0002 != 0 {
+		kinds = append(kinds, WorldWritable)
+	}
+	if info.Mode().IsRegular() && c.isHookScript(path) && mode&0100 == 0 {
+		// Executable by the owner and by whoever may read it
+		want |= 0100 | (mode&0444)>>2
+		kinds = append(kinds, NotExecutable)
+	}
+	if info.Mode().IsRegular() && c.isPrivate(path) && mode&0077 != 0 {
+		want &^= 0077
+		kinds = append(kinds, Exposed)
+	}
+
+	var issues []Issue
+	for _, kind := range kinds {
+		issues = append(issues, Issue{Path: path, Kind: kind, Mode: mode, Want: want})
+	}
+	return issues
+}
+
+// isHookScript reports whether path is a shell script in the hooks
+// directory of the installation
+func (c *Checker) isHookScript(path string) bool {
+	hooks := filepath.Join(c.InstallDir, "hooks") + string(filepath.Separator)
+	return strings.HasPrefix(path, hooks) && strings.HasSuffix(path, ".sh")
+}
+
+func (c *Checker) isPrivate(path string) bool {
+	base := filepath.Base(path)
+	for _, name := range c.Private {
+		if base == name {
+			return true
+		}
+	}
+	return false
+}
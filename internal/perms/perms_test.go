@@ -0,0 +1,70 @@
+package perms
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestCheckAndFix(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("mode bits are not audited on Windows")
+	}
+	dir := t.TempDir()
+	files := map[string]os.FileMode{
+		"hooks/pre.sh":             0644,
+		"hooks/README.md":          0644,
+		"agents/shared.md":         0666,
+		".crew/config/secrets.enc": 0644,
+		".crew/config/config.json": 0644,
+	}
+	for rel, mode := range files {
+		path := filepath.Join(dir, rel)
+		os.MkdirAll(filepath.Dir(path), 0755)
+		if err := os.WriteFile(path, []byte("x"), mode); err != nil {
+			t.Fatal(err)
+		}
+		// Undo the umask
+		os.Chmod(path, mode)
+	}
+	os.Symlink(filepath.Join(dir, "hooks", "README.md"), filepath.Join(dir, "hooks", "link.sh"))
+
+	c := &Checker{
+		InstallDir: dir,
+		Paths:      []string{filepath.Join(dir, "hooks"), filepath.Join(dir, "agents"), filepath.Join(dir, ".crew"), filepath.Join(dir, "missing")},
+		Private:    []string{"secrets.enc"},
+	}
+	issues, err := c.Check()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]Kind{
+		"hooks/pre.sh":             NotExecutable,
+		"agents/shared.md":         WorldWritable,
+		".crew/config/secrets.enc": Exposed,
+	}
+	if len(issues) != len(want) {
+		t.Fatalf("Expected %d issues, got %+v", len(want), issues)
+	}
+	for _, issue := range issues {
+		rel, _ := filepath.Rel(dir, issue.Path)
+		if want[filepath.ToSlash(rel)] != issue.Kind {
+			t.Errorf("Unexpected issue %s: %s", rel, issue)
+		}
+		if err := issue.Fix(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	modes := map[string]os.FileMode{"hooks/pre.sh": 0755, "agents/shared.md": 0664, ".crew/config/secrets.enc": 0600}
+	for rel, mode := range modes {
+		info, _ := os.Stat(filepath.Join(dir, rel))
+		if info.Mode().Perm() != mode {
+			t.Errorf("Expected %s to be %04o after fixing, got %04o", rel, mode, info.Mode().Perm())
+		}
+	}
+	if issues, _ := c.Check(); len(issues) != 0 {
+		t.Errorf("Expected no issues after fixing, got %+v", issues)
+	}
+}
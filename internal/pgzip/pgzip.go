@@ -0,0 +1,147 @@
+// Package pgzip writes gzip streams using several CPUs. The input is cut
+// into blocks that are compressed concurrently, each as a complete gzip
+// member, and written in order. A stream of concatenated members is valid
+// gzip that gzip.Reader and gunzip read as one file; it is a little
+// larger than a single-member stream because blocks share no history.
+package pgzip
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"runtime"
+	"sync"
+)
+
+// BlockSize is the amount of input compressed as one gzip member
+const BlockSize = 1 << 20
+
+// NewWriter returns a writer compressing into w at level (gzip.BestSpeed
+// to gzip.BestCompression, or gzip.DefaultCompression) on threads
+// goroutines. threads 0 uses one per CPU; with 1 it is a plain
+// gzip.Writer. The stream is complete once Close returns.
+func NewWriter(w io.Writer, level, threads int) (io.WriteCloser, error) {
+	if level != gzip.DefaultCompression && (level < gzip.BestSpeed || level > gzip.BestCompression) {
+		return nil, fmt.Errorf("invalid gzip level %d: use %d to %d", level, gzip.BestSpeed, gzip.BestCompression)
+	}
+	if threads <= 0 {
+		threads = runtime.NumCPU()
+	}
+	if threads == 1 {
+		return gzip.NewWriterLevel(w, level)
+	}
+
+	pw := &writer{
+		w:       w,
+		level:   level,
+		pending: make(chan chan block, threads),
+		done:    make(chan struct{}),
+	}
+	go pw.drain()
+	return pw, nil
+}
+
+// block is the compressed form of one block of input
+type block struct {
+	data []byte
+	err  error
+}
+
+type writer struct {
+	w     io.Writer
+	level int
+	buf   []byte
+	// pending holds the blocks being compressed in input order; its
+	// capacity bounds how many are in memory at once
+	pending chan chan block
+	done    chan struct{}
+	wrote   bool
+	closed  bool
+
+	mu  sync.Mutex
+	err error
+}
+
+func (pw *writer) Write(p []byte) (int, error) {
+	if err := pw.failed(); err != nil {
+		return 0, err
+	}
+	if pw.closed {
+		return 0, fmt.Errorf("pgzip: write after close")
+	}
+	n := len(p)
+	for len(p) > 0 {
+		take := BlockSize - len(pw.buf)
+		if take > len(p) {
+			take = len(p)
+		}
+		pw.buf = append(pw.buf, p[:take]...)
+		p = p[take:]
+		if len(pw.buf) == BlockSize {
+			pw.flushBlock()
+		}
+	}
+	return n, nil
+}
+
+// flushBlock starts compressing the buffered input
+func (pw *writer) flushBlock() {
+	data := pw.buf
+	pw.buf = make([]byte, 0, BlockSize)
+	pw.wrote = true
+
+	result := make(chan block, 1)
+	pw.pending <- result
+	go func() {
+		var out bytes.Buffer
+		zw, err := gzip.NewWriterLevel(&out, pw.level)
+		if err == nil {
+			if _, err = zw.Write(data); err == nil {
+				err = zw.Close()
+			}
+		}
+		result <- block{out.Bytes(), err}
+	}()
+}
+
+// drain writes the compressed blocks to the underlying writer in order
+func (pw *writer) drain() {
+	defer close(pw.done)
+	for result := range pw.pending {
+		b := <-result
+		if pw.failed() != nil {
+			continue
+		}
+		err := b.err
+		if err == nil {
+			_, err = pw.w.Write(b.data)
+		}
+		if err != nil {
+			pw.mu.Lock()
+			pw.err = err
+			pw.mu.Unlock()
+		}
+	}
+}
+
+func (pw *writer) failed() error {
+	pw.mu.Lock()
+	defer pw.mu.Unlock()
+	return pw.err
+}
+
+// Close compresses the remaining input and waits until every block is
+// written. Empty input still produces a valid gzip stream.
+func (pw *writer) Close() error {
+	if pw.closed {
+		return pw.failed()
+	}
+	pw.closed = true
+	if len(pw.buf) > 0 || !pw.wrote {
+		pw.flushBlock()
+	}
+	close(pw.pending)
+	<-pw.done
+	return pw.failed()
+}
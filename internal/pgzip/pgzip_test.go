@@ -0,0 +1,84 @@
+package pgzip
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"math/rand"
+	"testing"
+)
+
+func roundTrip(t *testing.T, input []byte, level, threads int) []byte {
+	t.Helper()
+	var out bytes.Buffer
+	w, err := NewWriter(&out, level, threads)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Uneven writes cross block boundaries
+	for rest := input; len(rest) > 0; {
+		n := 70000
+		if n > len(rest) {
+			n = len(rest)
+		}
+		if _, err := w.Write(rest[:n]); err != nil {
+			t.Fatal(err)
+		}
+		rest = rest[n:]
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := gzip.NewReader(&out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return got
+}
+
+func TestParallelRoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	input := make([]byte, 3*BlockSize+12345)
+	rng.Read(input)
+
+	for _, threads := range []int{1, 2, 8} {
+		for _, level := range []int{gzip.BestSpeed, gzip.DefaultCompression, gzip.BestCompression} {
+			if got := roundTrip(t, input, level, threads); !bytes.Equal(got, input) {
+				t.Errorf("threads %d level %d: output differs from input", threads, level)
+			}
+		}
+	}
+}
+
+func TestEmptyInputIsValidGzip(t *testing.T) {
+	if got := roundTrip(t, nil, gzip.DefaultCompression, 4); len(got) != 0 {
+		t.Errorf("Expected nothing back, got %d bytes", len(got))
+	}
+}
+
+func TestInvalidLevel(t *testing.T) {
+	if _, err := NewWriter(io.Discard, 12, 2); err == nil {
+		t.Error("Expected an error for level 12")
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) { return 0, errors.New("disk full") }
+
+func TestWriteErrorsSurface(t *testing.T) {
+	w, err := NewWriter(failingWriter{}, gzip.BestSpeed, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Write(make([]byte, 4*BlockSize))
+	if err := w.Close(); err == nil || err.Error() != "disk full" {
+		t.Errorf("Expected the write error from Close, got %v", err)
+	}
+}
@@ -0,0 +1,187 @@
+// Package platform detects the parts of the environment that change how
+// crew should be installed: Windows Subsystem for Linux, containers,
+// SELinux, and install directories that are read-only or case-insensitive.
+package platform
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// Environment is what was detected about the system crew runs on
+type Environment struct {
+	OS   string `json:"os"`
+	Arch string `json:"arch"`
+	// WSL is set under Windows Subsystem for Linux, with the distribution
+	// when it is known
+	WSL       bool   `json:"wsl"`
+	WSLDistro string `json:"wsl_distro,omitempty"`
+	// Container is the container runtime crew runs in: docker, podman,
+	// kubernetes, lxc or container when it cannot tell which
+	Container string `json:"container,omitempty"`
+	// SELinux is enforcing or permissive, empty when it is off or absent
+	SELinux string `json:"selinux,omitempty"`
+	// Dir is the directory the filesystem checks ran in
+	Dir string `json:"dir,omitempty"`
+	// ReadOnly is set when Dir cannot be written
+	ReadOnly bool `json:"read_only"`
+	// CaseInsensitive is set when Dir's filesystem ignores case, as on
+	// macOS and Windows by default
+	CaseInsensitive bool `json:"case_insensitive"`
+}
+
+// Detect inspects the running system. dir is the install directory, or
+// the nearest existing directory above it, where the filesystem checks run.
+func Detect(dir string) Environment {
+	return detect("/", os.Getenv, dir)
+}
+
+// detect reads system files below root, so tests can fake them
+func detect(root string, getenv func(string) string, dir string) Environment {
+	env := Environment{OS: runtime.GOOS, Arch: runtime.GOARCH}
+	read := func(name string) string {
+		data, _ := os.ReadFile(filepath.Join(root, name))
+		return strings.TrimSpace(string(data))
+	}
+	exists := func(name string) bool {
+		_, err := os.Stat(filepath.Join(root, name))
+		return err == nil
+	}
+
+	if getenv("WSL_DISTRO_NAME") != "" || strings.Contains(strings.ToLower(read("proc/sys/kernel/osrelease")), "microsoft") {
+		env.WSL = true
+		env.WSLDistro = getenv("WSL_DISTRO_NAME")
+	}
+
+	cgroup := read("proc/1/cgroup")
+	switch {
+	case getenv("KUBERNETES_SERVICE_HOST") != "" || strings.Contains(cgroup, "kubepods"):
+		env.Container = "kubernetes"
+	case exists("run/.containerenv") || getenv("container") == "podman" || strings.Contains(cgroup, "libpod"):
+		env.Container = "podman"
+	case exists(".dockerenv") || strings.Contains(cgroup, "docker"):
+		env.Container = "docker"
+	case getenv("container") == "lxc" || strings.Contains(cgroup, "lxc"):
+		env.Container = "lxc"
+	case getenv("container") != "":
+		env.Container = "container"
+	}
+
+	switch read("sys/fs/selinux/enforce") {
+	case "1":
+		env.SELinux = "enforcing"
+	case "0":
+		env.SELinux = "permissive"
+	}
+
+	if dir != "" {
+		env.Dir = existingParent(dir)
+		env.ReadOnly, env.CaseInsensitive = probeFilesystem(env.Dir)
+	}
+	return env
+}
+
+// existingParent returns dir or its nearest ancestor that exists
+func existingParent(dir string) string {
+	for {
+		if _, err := os.Stat(dir); err == nil {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return dir
+		}
+		dir = parent
+	}
+}
+
+// probeFilesystem writes a scratch file in dir to learn whether dir is
+// writable and whether its filesystem ignores case
+func probeFilesystem(dir string) (readOnly, caseInsensitive bool) {
+	f, err := os.CreateTemp(dir, ".crew-probe-")
+	if err != nil {
+		return true, false
+	}
+	f.Close()
+	defer os.Remove(f.Name())
+
+	base := filepath.Base(f.Name())
+	if _, err := os.Stat(filepath.Join(dir, strings.ToUpper(base))); err == nil {
+		caseInsensitive = true
+	}
+	return false, caseInsensitive
+}
+
+// String describes the environment in one line, e.g.
+// "linux/amd64 (WSL Ubuntu, docker, SELinux enforcing)"
+func (e Environment) String() string {
+	var traits []string
+	if e.WSL {
+		traits = append(traits, strings.TrimSpace("WSL "+e.WSLDistro))
+	}
+	if e.Container != "" {
+		traits = append(traits, e.Container)
+	}
+	if e.SELinux != "" {
+		traits = append(traits, "SELinux "+e.SELinux)
+	}
+	if e.ReadOnly {
+		traits = append(traits, "read-only filesystem")
+	}
+	if e.CaseInsensitive {
+		traits = append(traits, "case-insensitive filesystem")
+	}
+
+	s := e.OS + "/" + e.Arch
+	if len(traits) > 0 {
+		s += " (" + strings.Join(traits, ", ") + ")"
+	}
+	return s
+}
+
+// Advice returns the issues the environment raises and what to do about
+// each. Issues are problems an installation will hit; recommendations are
+// the advice for them and notes that only call for care.
+func (e Environment) Advice() (issues, recommendations []string) {
+	if e.ReadOnly {
+		issues = append(issues, fmt.Sprintf("%s is on a read-only or restricted filesystem", e.Dir))
+		recommendations = append(recommendations,
+			"Install to a writable directory with --install-dir, or mount a writable volume there")
+	}
+
+	if e.WSL {
+		advice := "WSL: keep the install directory and projects in the Linux filesystem (e.g. ~/.claude), not under /mnt/c; Windows drives are slow and do not keep Unix permissions"
+		if strings.HasPrefix(filepath.ToSlash(e.Dir), "/mnt/") {
+			issues = append(issues, fmt.Sprintf("%s is on a Windows drive mounted into WSL", e.Dir))
+		}
+		recommendations = append(recommendations, advice)
+	}
+
+	switch e.Container {
+	case "":
+	case "podman":
+		recommendations = append(recommendations,
+			"Container (podman): rootless containers map your user to another UID on the host; mount ~/.claude with :U or --userns=keep-id so files stay yours")
+	default:
+		recommendations = append(recommendations,
+			fmt.Sprintf("Container (%s): ~/.claude is lost with the container unless it is on a volume; files written as root in a bind mount are root-owned on the host, so run as your own UID", e.Container))
+	}
+
+	if e.SELinux == "enforcing" {
+		recommendations = append(recommendations,
+			"SELinux is enforcing: hooks run from ~/.claude need an executable context; if they are denied, run 'restorecon -R ~/.claude' and check 'ausearch -m avc'")
+		if e.Container != "" {
+			recommendations = append(recommendations,
+				"SELinux in a container: label bind-mounted directories with :z or :Z so the container can write them")
+		}
+	}
+
+	if e.CaseInsensitive {
+		recommendations = append(recommendations,
+			"The filesystem ignores case: commands and agents whose names differ only in case overwrite each other, so keep their names lower-case")
+	}
+	return issues, recommendations
+}
@@ -0,0 +1,63 @@
+package platform
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func fakeRoot(t *testing.T, files map[string]string) string {
+	t.Helper()
+	root := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func TestDetect(t *testing.T) {
+	root := fakeRoot(t, map[string]string{
+		"proc/sys/kernel/osrelease": "5.15.153.1-microsoft-standard-WSL2\n",
+		"proc/1/cgroup":             "0::/\n",
+		"run/.containerenv":         "",
+		"sys/fs/selinux/enforce":    "1",
+	})
+	env := map[string]string{"WSL_DISTRO_NAME": "Ubuntu"}
+
+	dir := t.TempDir()
+	got := detect(root, func(key string) string { return env[key] }, filepath.Join(dir, "missing", ".claude"))
+	if !got.WSL || got.WSLDistro != "Ubuntu" || got.Container != "podman" || got.SELinux != "enforcing" {
+		t.Errorf("Unexpected environment: %+v", got)
+	}
+	if got.Dir != dir || got.ReadOnly {
+		t.Errorf("Filesystem probed in %s, read-only %v", got.Dir, got.ReadOnly)
+	}
+	if !strings.Contains(got.String(), "(WSL Ubuntu, podman, SELinux enforcing") {
+		t.Errorf("Unexpected description %q", got.String())
+	}
+
+	if bare := detect(fakeRoot(t, nil), func(string) string { return "" }, ""); bare.WSL || bare.Container != "" || bare.SELinux != "" {
+		t.Errorf("Expected nothing detected, got %+v", bare)
+	}
+}
+
+func TestAdvice(t *testing.T) {
+	issues, recommendations := Environment{WSL: true, Dir: "/mnt/c/Users/me/.claude", Container: "docker"}.Advice()
+	if len(issues) != 1 || !strings.Contains(issues[0], "Windows drive") {
+		t.Errorf("Unexpected issues %v", issues)
+	}
+	if len(recommendations) != 2 || !strings.HasPrefix(recommendations[1], "Container (docker)") {
+		t.Errorf("Unexpected recommendations %v", recommendations)
+	}
+
+	if issues, recommendations := (Environment{OS: "linux"}).Advice(); len(issues)+len(recommendations) != 0 {
+		t.Errorf("Expected no advice, got %v %v", issues, recommendations)
+	}
+}
@@ -0,0 +1,218 @@
+// Package plugins discovers crew plugins: external executables named
+// crew-<name>, found on PATH or declared in the crew config, that run as
+// "crew <name>" subcommands.
+//
+// A plugin receives its context in CREW_* environment variables and as a
+// JSON Context document on stdin. Plugins that answer --crew-plugin-info
+// with an Info document can also register lifecycle hooks.
+package plugins
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Prefix is the executable name prefix that marks a crew plugin
+const Prefix = "crew-"
+
+// InfoFlag asks a plugin to print its Info document and exit
+const InfoFlag = "--crew-plugin-info"
+
+// HookFlag is passed, followed by the event name, when a plugin runs as a
+// lifecycle hook
+const HookFlag = "--crew-hook"
+
+// infoTimeout bounds --crew-plugin-info, which should answer immediately
+const infoTimeout = 5 * time.Second
+
+// Source records where a plugin was found
+type Source string
+
+const (
+	SourcePath   Source = "path"
+	SourceConfig Source = "config"
+)
+
+// Plugin is a discovered plugin executable
+type Plugin struct {
+	Name        string `json:"name"`
+	Path        string `json:"path"`
+	Source      Source `json:"source"`
+	Description string `json:"description,omitempty"`
+}
+
+// Declared is a plugin entry in the "plugins" section of the crew config
+type Declared struct {
+	Path        string `json:"path"`
+	Description string `json:"description,omitempty"`
+	Disabled    bool   `json:"disabled,omitempty"`
+}
+
+// Info is what a plugin prints for --crew-plugin-info
+type Info struct {
+	Description string     `json:"description,omitempty"`
+	Hooks       []HookSpec `json:"hooks,omitempty"`
+}
+
+// HookSpec is a lifecycle hook a plugin registers. The plugin is invoked
+// with HookFlag and the event name.
+type HookSpec struct {
+	Event     string `json:"event"`
+	Timeout   string `json:"timeout,omitempty"`
+	OnFailure string `json:"on_failure,omitempty"`
+}
+
+// Context is the JSON document written to a plugin's stdin
+type Context struct {
+	Version        string   `json:"version"`
+	InstallDir     string   `json:"install_dir"`
+	ConfigDir      string   `json:"config_dir"`
+	Plugin         string   `json:"plugin"`
+	Args           []string `json:"args"`
+	Verbose        bool     `json:"verbose"`
+	Quiet          bool     `json:"quiet"`
+	DryRun         bool     `json:"dry_run"`
+	Output         string   `json:"output"`
+	Locale         string   `json:"locale"`
+	NonInteractive bool     `json:"non_interactive"`
+}
+
+// Discover finds plugins in the directories of pathList (a PATH-style
+// list) and adds those declared in config, which take precedence. Within
+// PATH the first match wins, as it does for the shell.
+func Discover(pathList string, declared map[string]Declared) []Plugin {
+	found := make(map[string]Plugin)
+
+	for _, dir := range filepath.SplitList(pathList) {
+		if dir == "" {
+			continue
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			name, ok := pluginName(entry.Name())
+			if !ok || entry.IsDir() {
+				continue
+			}
+			if _, seen := found[name]; seen {
+				continue
+			}
+			path := filepath.Join(dir, entry.Name())
+			if !isExecutable(path) {
+				continue
+			}
+			found[name] = Plugin{Name: name, Path: path, Source: SourcePath}
+		}
+	}
+
+	for name, d := range declared {
+		if d.Disabled {
+			delete(found, name)
+			continue
+		}
+		if d.Path == "" {
+			continue
+		}
+		found[name] = Plugin{Name: name, Path: d.Path, Source: SourceConfig, Description: d.Description}
+	}
+
+	list := make([]Plugin, 0, len(found))
+	for _, p := range found {
+		list = append(list, p)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+// pluginName returns the plugin name for an executable file name
+func pluginName(file string) (string, bool) {
+	if !strings.HasPrefix(file, Prefix) {
+		return "", false
+	}
+	name := strings.TrimPrefix(file, Prefix)
+	if runtime.GOOS == "windows" {
+		name = strings.TrimSuffix(name, filepath.Ext(name))
+	}
+	if name == "" || strings.ContainsAny(name, " .") {
+		return "", false
+	}
+	return name, true
+}
+
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		return strings.EqualFold(filepath.Ext(path), ".exe")
+	}
+	return info.Mode().Perm()&0111 != 0
+}
+
+// Env returns the CREW_* environment variables describing ctx
+func (ctx Context) Env() []string {
+	boolEnv := func(b bool) string {
+		if b {
+			return "1"
+		}
+		return "0"
+	}
+	return []string{
+		"CREW_PLUGIN=" + ctx.Plugin,
+		"CREW_VERSION=" + ctx.Version,
+		"CREW_INSTALL_DIR=" + ctx.InstallDir,
+		"CREW_CONFIG_DIR=" + ctx.ConfigDir,
+		"CREW_VERBOSE=" + boolEnv(ctx.Verbose),
+		"CREW_QUIET=" + boolEnv(ctx.Quiet),
+		"CREW_DRY_RUN=" + boolEnv(ctx.DryRun),
+		"CREW_OUTPUT=" + ctx.Output,
+		"CREW_LOCALE=" + ctx.Locale,
+		"CREW_NON_INTERACTIVE=" + boolEnv(ctx.NonInteractive),
+	}
+}
+
+// Command builds the command that runs p with ctx: arguments from
+// ctx.Args, CREW_* variables added to the environment and the context
+// document on stdin. Stdout and stderr are left for the caller to set.
+func (p Plugin) Command(c context.Context, ctx Context) (*exec.Cmd, error) {
+	ctx.Plugin = p.Name
+	doc, err := json.Marshal(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode plugin context: %w", err)
+	}
+
+	cmd := exec.CommandContext(c, p.Path, ctx.Args...)
+	cmd.Env = append(os.Environ(), ctx.Env()...)
+	cmd.Stdin = bytes.NewReader(append(doc, '\n'))
+	return cmd, nil
+}
+
+// QueryInfo runs the plugin with InfoFlag and parses its Info document.
+// Plugins that do not support the flag return an error.
+func (p Plugin) QueryInfo() (*Info, error) {
+	c, cancel := context.WithTimeout(context.Background(), infoTimeout)
+	defer cancel()
+
+	out, err := exec.CommandContext(c, p.Path, InfoFlag).Output()
+	if err != nil {
+		return nil, fmt.Errorf("plugin %s does not answer %s: %w", p.Name, InfoFlag, err)
+	}
+
+	var info Info
+	if err := json.Unmarshal(out, &info); err != nil {
+		return nil, fmt.Errorf("plugin %s returned invalid info: %w", p.Name, err)
+	}
+	return &info, nil
+}
@@ -0,0 +1,100 @@
+package plugins
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func writeExecutable(t *testing.T, dir, name, script string, mode os.FileMode) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(script), mode); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestDiscover(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugin discovery by file mode is Unix-specific")
+	}
+
+	first, second := t.TempDir(), t.TempDir()
+	deploy := writeExecutable(t, first, "crew-deploy", "#!/bin/sh\n", 0755)
+	writeExecutable(t, second, "crew-deploy", "#!/bin/sh\n", 0755)
+	writeExecutable(t, second, "crew-notes", "not executable", 0644)
+	writeExecutable(t, second, "crew-lint", "#!/bin/sh\n", 0755)
+	writeExecutable(t, second, "crew-old", "#!/bin/sh\n", 0755)
+	writeExecutable(t, second, "other-tool", "#!/bin/sh\n", 0755)
+
+	found := Discover(first+string(os.PathListSeparator)+second, map[string]Declared{
+		"lint":   {Path: "/opt/lint/crew-lint", Description: "Team lint rules"},
+		"old":    {Disabled: true},
+		"report": {Path: "/opt/report"},
+	})
+
+	byName := make(map[string]Plugin)
+	for _, p := range found {
+		byName[p.Name] = p
+	}
+	if len(found) != 3 {
+		t.Fatalf("Expected deploy, lint and report, got %+v", found)
+	}
+	if byName["deploy"].Path != deploy || byName["deploy"].Source != SourcePath {
+		t.Errorf("Expected the first deploy on PATH to win, got %+v", byName["deploy"])
+	}
+	if byName["lint"].Source != SourceConfig || byName["lint"].Description != "Team lint rules" {
+		t.Errorf("Expected config to override PATH for lint, got %+v", byName["lint"])
+	}
+	if _, ok := byName["old"]; ok {
+		t.Error("Expected a disabled plugin to be dropped")
+	}
+}
+
+func TestCommandContext(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires a POSIX shell")
+	}
+
+	dir := t.TempDir()
+	path := writeExecutable(t, dir, "crew-echo", "#!/bin/sh\necho \"$CREW_PLUGIN $CREW_DRY_RUN $*\"\ncat\n", 0755)
+	p := Plugin{Name: "echo", Path: path}
+
+	cmd, err := p.Command(context.Background(), Context{Version: "1.0.0", InstallDir: "/tmp/claude", DryRun: true, Args: []string{"a", "b"}})
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("Plugin failed: %v", err)
+	}
+
+	lines := strings.SplitN(string(out), "\n", 2)
+	if lines[0] != "echo 1 a b" {
+		t.Errorf("Unexpected environment and arguments: %q", lines[0])
+	}
+	if !strings.Contains(lines[1], `"install_dir":"/tmp/claude"`) || !strings.Contains(lines[1], `"plugin":"echo"`) {
+		t.Errorf("Unexpected stdin context: %q", lines[1])
+	}
+}
+
+func TestQueryInfo(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires a POSIX shell")
+	}
+
+	dir := t.TempDir()
+	path := writeExecutable(t, dir, "crew-hooked", "#!/bin/sh\n[ \"$1\" = --crew-plugin-info ] && echo '{\"hooks\":[{\"event\":\"post-install\"}]}'\n", 0755)
+
+	info, err := Plugin{Name: "hooked", Path: path}.QueryInfo()
+	if err != nil {
+		t.Fatalf("QueryInfo failed: %v", err)
+	}
+	if len(info.Hooks) != 1 || info.Hooks[0].Event != "post-install" {
+		t.Errorf("Unexpected info: %+v", info)
+	}
+}
@@ -0,0 +1,217 @@
+// Package project manages the crew state of a project, kept in the
+// project's .claude directory.
+package project
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ConfigFile is the name of the project configuration in .claude
+const ConfigFile = "project-config.json"
+
+// SchemaVersion is the version of the project configuration schema this
+// build reads and writes. Version 1 is the untyped file of early releases,
+// which carried "version": "1.0" instead of schema_version.
+const SchemaVersion = 2
+
+// IntegrationType marks a project set up with 'crew claude --install'
+const IntegrationType = "project-integration"
+
+// Config is the project configuration. Fields other than the ones below,
+// e.g. notes /crew:onboard adds, are kept in Extra and written back as is.
+type Config struct {
+	SchemaVersion  int        `json:"schema_version"`
+	Type           string     `json:"type"`
+	ProjectPath    string     `json:"project_path"`
+	GlobalCommands string     `json:"global_commands,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      *time.Time `json:"updated_at,omitempty"`
+
+	// Filled in by /crew:onboard
+	Languages   []string   `json:"languages"`
+	Frameworks  []string   `json:"frameworks"`
+	Specialists []string   `json:"specialists"`
+	MCPServers  []string   `json:"mcp_servers"`
+	Tools       []string   `json:"tools"`
+	AnalyzedAt  *time.Time `json:"analyzed_at,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// Keys are the keys of the project configuration
+var Keys = []string{
+	"schema_version", "type", "project_path", "global_commands", "created_at", "updated_at",
+	"languages", "frameworks", "specialists", "mcp_servers", "tools", "analyzed_at",
+}
+
+// configAlias has the fields of Config without its JSON methods
+type configAlias Config
+
+// ConfigPath returns the path of the project configuration
+func ConfigPath(projectDir string) string {
+	return filepath.Join(projectDir, ".claude", ConfigFile)
+}
+
+// NewConfig returns the configuration of a newly integrated project
+func NewConfig(projectDir, globalCommands string) *Config {
+	return &Config{
+		SchemaVersion:  SchemaVersion,
+		Type:           IntegrationType,
+		ProjectPath:    projectDir,
+		GlobalCommands: globalCommands,
+		CreatedAt:      time.Now().UTC().Truncate(time.Second),
+	}
+}
+
+// LoadConfig reads and validates the configuration of the project in
+// projectDir. A version 1 file is upgraded in memory; Save writes the
+// current schema.
+func LoadConfig(projectDir string) (*Config, error) {
+	path := ConfigPath(projectDir)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", path, err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", path, err)
+	}
+	return &cfg, nil
+}
+
+// Save validates the configuration and writes it to projectDir
+func (c *Config) Save(projectDir string) error {
+	c.SchemaVersion = SchemaVersion
+	now := time.Now().UTC().Truncate(time.Second)
+	c.UpdatedAt = &now
+	if err := c.Validate(); err != nil {
+		return fmt.Errorf("invalid project config: %w", err)
+	}
+
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal project config: %w", err)
+	}
+
+	path := ConfigPath(projectDir)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write project config: %w", err)
+	}
+	return nil
+}
+
+// Validate checks the configuration against the schema
+func (c *Config) Validate() error {
+	if c.SchemaVersion > SchemaVersion {
+		return fmt.Errorf("schema_version %d is newer than this crew supports (%d); upgrade crew", c.SchemaVersion, SchemaVersion)
+	}
+	if c.SchemaVersion < 1 {
+		return fmt.Errorf("schema_version is missing")
+	}
+	if c.Type != IntegrationType {
+		return fmt.Errorf("type must be %q, got %q", IntegrationType, c.Type)
+	}
+	if c.ProjectPath == "" {
+		return fmt.Errorf("project_path is required")
+	}
+	for _, key := range ListKeys {
+		if err := validateList(key, *c.list(key)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateList(key string, values []string) error {
+	seen := make(map[string]bool)
+	for _, v := range values {
+		if strings.TrimSpace(v) == "" {
+			return fmt.Errorf("%s contains an empty entry", key)
+		}
+		if seen[v] {
+			return fmt.Errorf("%s lists %q twice", key, v)
+		}
+		seen[v] = true
+	}
+	return nil
+}
+
+// UnmarshalJSON reads the current schema and version 1 files
+func (c *Config) UnmarshalJSON(data []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	var alias configAlias
+	if err := json.Unmarshal(data, &alias); err != nil {
+		return err
+	}
+	*c = Config(alias)
+
+	// Version 1 files carried "version": "1.0" instead of schema_version
+	if _, ok := fields["schema_version"]; !ok {
+		if _, legacy := fields["version"]; legacy {
+			c.SchemaVersion = 1
+			delete(fields, "version")
+		}
+	}
+
+	for _, key := range Keys {
+		delete(fields, key)
+	}
+	if len(fields) > 0 {
+		c.Extra = fields
+	}
+	return nil
+}
+
+// MarshalJSON writes the known fields followed by Extra
+func (c *Config) MarshalJSON() ([]byte, error) {
+	alias := configAlias(*c)
+	alias.Languages = nonNil(alias.Languages)
+	alias.Frameworks = nonNil(alias.Frameworks)
+	alias.Specialists = nonNil(alias.Specialists)
+	alias.MCPServers = nonNil(alias.MCPServers)
+	alias.Tools = nonNil(alias.Tools)
+
+	data, err := json.Marshal(alias)
+	if err != nil || len(c.Extra) == 0 {
+		return data, err
+	}
+
+	keys := make([]string, 0, len(c.Extra))
+	for key := range c.Extra {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.Write(data[:len(data)-1])
+	for _, key := range keys {
+		name, _ := json.Marshal(key)
+		fmt.Fprintf(&b, ",%s:%s", name, c.Extra[key])
+	}
+	b.WriteString("}")
+	return []byte(b.String()), nil
+}
+
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
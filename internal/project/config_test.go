@@ -0,0 +1,105 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeConfig(t *testing.T, dir, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Join(dir, ".claude"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(ConfigPath(dir), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadLegacyConfig(t *testing.T) {
+	dir := t.TempDir()
+	writeConfig(t, dir, `{
+  "version": "1.0",
+  "project_path": "/src/app",
+  "global_commands": "/home/u/.claude/commands/crew",
+  "created_at": "2024-05-01T10:00:00+02:00",
+  "type": "project-integration",
+  "architecture": {"style": "hexagonal"}
+}`)
+
+	cfg, err := LoadConfig(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.SchemaVersion != 1 || cfg.ProjectPath != "/src/app" || cfg.CreatedAt.IsZero() {
+		t.Errorf("Unexpected config %+v", cfg)
+	}
+
+	if err := cfg.Set("languages", []string{"go,typescript"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.Save(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	data, _ := os.ReadFile(ConfigPath(dir))
+	for _, want := range []string{`"schema_version": 2`, `"architecture": {`, `"style": "hexagonal"`, `"frameworks": []`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("Expected the saved config to contain %s:\n%s", want, data)
+		}
+	}
+	if strings.Contains(string(data), `"version"`) {
+		t.Errorf("Expected the legacy version field to be dropped:\n%s", data)
+	}
+
+	reloaded, err := LoadConfig(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := reloaded.Get("languages"); got != "go\ntypescript" {
+		t.Errorf("Expected languages to round-trip, got %q", got)
+	}
+}
+
+func TestLoadConfigValidation(t *testing.T) {
+	tests := map[string]string{
+		"newer schema":    `{"schema_version": 9, "type": "project-integration", "project_path": "/src"}`,
+		"wrong type":      `{"schema_version": 2, "type": "other", "project_path": "/src"}`,
+		"no project path": `{"schema_version": 2, "type": "project-integration"}`,
+		"duplicate entry": `{"schema_version": 2, "type": "project-integration", "project_path": "/src", "tools": ["git", "git"]}`,
+		"wrong list type": `{"schema_version": 2, "type": "project-integration", "project_path": "/src", "languages": "go"}`,
+	}
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeConfig(t, dir, content)
+			if _, err := LoadConfig(dir); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+}
+
+func TestSet(t *testing.T) {
+	cfg := NewConfig("/src", "")
+
+	if err := cfg.Set("tools", []string{"git", "docker, make"}); err != nil || len(cfg.Tools) != 3 {
+		t.Errorf("Expected three tools, got %v, %v", cfg.Tools, err)
+	}
+	if err := cfg.Set("tools", nil); err != nil || len(cfg.Tools) != 0 {
+		t.Errorf("Expected no values to clear the list, got %v, %v", cfg.Tools, err)
+	}
+	if err := cfg.Set("analyzed_at", []string{"now"}); err != nil || cfg.AnalyzedAt == nil {
+		t.Errorf("Expected analyzed_at to be set, got %v", err)
+	}
+	if err := cfg.Set("analyzed_at", []string{"yesterday"}); err == nil {
+		t.Error("Expected an invalid time to fail")
+	}
+	if err := cfg.Set("project_path", []string{"/elsewhere"}); err == nil {
+		t.Error("Expected project_path to be read only")
+	}
+	if err := cfg.Set("specialists", []string{"go", "go"}); err == nil {
+		t.Error("Expected duplicate entries to fail")
+	}
+}
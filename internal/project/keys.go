@@ -0,0 +1,109 @@
+package project
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ListKeys are the list settings of the project configuration
+var ListKeys = []string{"languages", "frameworks", "specialists", "mcp_servers", "tools"}
+
+// SettableKeys are the keys 'crew project config set' accepts. The others
+// describe the integration and are maintained by crew.
+var SettableKeys = append(append([]string{}, ListKeys...), "analyzed_at", "global_commands")
+
+func (c *Config) list(key string) *[]string {
+	switch key {
+	case "languages":
+		return &c.Languages
+	case "frameworks":
+		return &c.Frameworks
+	case "specialists":
+		return &c.Specialists
+	case "mcp_servers":
+		return &c.MCPServers
+	case "tools":
+		return &c.Tools
+	}
+	return nil
+}
+
+// Get returns the value of key as text, one line per list entry
+func (c *Config) Get(key string) (string, error) {
+	if list := c.list(key); list != nil {
+		return strings.Join(*list, "\n"), nil
+	}
+	switch key {
+	case "schema_version":
+		return fmt.Sprint(c.SchemaVersion), nil
+	case "type":
+		return c.Type, nil
+	case "project_path":
+		return c.ProjectPath, nil
+	case "global_commands":
+		return c.GlobalCommands, nil
+	case "created_at":
+		return formatTime(&c.CreatedAt), nil
+	case "updated_at":
+		return formatTime(c.UpdatedAt), nil
+	case "analyzed_at":
+		return formatTime(c.AnalyzedAt), nil
+	}
+	return "", unknownKeyError(key, Keys)
+}
+
+// Set replaces the value of key. List keys take any number of values,
+// each of which may be a comma separated list; no values clear the list.
+// analyzed_at takes an RFC 3339 time or "now".
+func (c *Config) Set(key string, values []string) error {
+	if list := c.list(key); list != nil {
+		var entries []string
+		for _, v := range values {
+			for _, entry := range strings.Split(v, ",") {
+				if entry = strings.TrimSpace(entry); entry != "" {
+					entries = append(entries, entry)
+				}
+			}
+		}
+		if err := validateList(key, entries); err != nil {
+			return err
+		}
+		*list = entries
+		return nil
+	}
+
+	value := strings.Join(values, " ")
+	switch key {
+	case "global_commands":
+		c.GlobalCommands = value
+	case "analyzed_at":
+		switch value {
+		case "":
+			c.AnalyzedAt = nil
+		case "now":
+			now := time.Now().UTC().Truncate(time.Second)
+			c.AnalyzedAt = &now
+		default:
+			t, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return fmt.Errorf("analyzed_at must be an RFC 3339 time or \"now\": %w", err)
+			}
+			c.AnalyzedAt = &t
+		}
+	default:
+		return unknownKeyError(key, SettableKeys)
+	}
+	return nil
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func unknownKeyError(key string, valid []string) error {
+	return fmt.Errorf("unknown key %q; valid keys: %s", key, strings.Join(valid, ", "))
+}
@@ -0,0 +1,240 @@
+package project
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SetupFile is the default name of an exported project setup, written to
+// the project root so it can be committed
+const SetupFile = "crew-project.json"
+
+// SetupFormat identifies the export format
+const SetupFormat = "crew-project-setup/1"
+
+// Setup is the part of a project's crew state teammates share: the tool
+// choices from the project configuration and the agent, command and hook
+// files that define routing. Machine-specific values such as the project
+// path are left out.
+type Setup struct {
+	Format      string    `json:"format"`
+	ExportedAt  time.Time `json:"exported_at"`
+	Languages   []string  `json:"languages"`
+	Frameworks  []string  `json:"frameworks"`
+	Specialists []string  `json:"specialists"`
+	MCPServers  []string  `json:"mcp_servers"`
+	Tools       []string  `json:"tools"`
+	// Files maps paths relative to the project, with forward slashes, to
+	// their content
+	Files map[string]string `json:"files"`
+}
+
+// sharedDirs are the .claude directories whose Markdown files are shared:
+// agents, including the orchestrator, and local and shadow commands
+var sharedDirs = []string{"agents", "commands"}
+
+// sharedFiles are single .claude files that are shared
+var sharedFiles = []string{"hooks.json"}
+
+// ExportSetup collects the shareable setup of the project in projectDir
+func ExportSetup(projectDir string) (*Setup, error) {
+	setup := &Setup{
+		Format:     SetupFormat,
+		ExportedAt: time.Now().UTC().Truncate(time.Second),
+		Files:      make(map[string]string),
+	}
+
+	cfg, err := LoadConfig(projectDir)
+	switch {
+	case err == nil:
+		setup.Languages = nonNil(cfg.Languages)
+		setup.Frameworks = nonNil(cfg.Frameworks)
+		setup.Specialists = nonNil(cfg.Specialists)
+		setup.MCPServers = nonNil(cfg.MCPServers)
+		setup.Tools = nonNil(cfg.Tools)
+	case os.IsNotExist(err):
+		return nil, fmt.Errorf("no project configuration in %s; run 'crew claude --install' first", projectDir)
+	default:
+		return nil, err
+	}
+
+	claudeDir := filepath.Join(projectDir, ".claude")
+	for _, dir := range sharedDirs {
+		root := filepath.Join(claudeDir, dir)
+		err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) && p == root {
+					return nil
+				}
+				return err
+			}
+			if d.IsDir() || !strings.HasSuffix(d.Name(), ".md") {
+				return nil
+			}
+			return setup.addFile(projectDir, p)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", root, err)
+		}
+	}
+	for _, name := range sharedFiles {
+		p := filepath.Join(claudeDir, name)
+		if _, err := os.Stat(p); err == nil {
+			if err := setup.addFile(projectDir, p); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return setup, nil
+}
+
+func (s *Setup) addFile(projectDir, p string) error {
+	data, err := os.ReadFile(p)
+	if err != nil {
+		return err
+	}
+	rel, err := filepath.Rel(projectDir, p)
+	if err != nil {
+		return err
+	}
+	s.Files[filepath.ToSlash(rel)] = string(data)
+	return nil
+}
+
+// Paths returns the paths of the shared files, sorted
+func (s *Setup) Paths() []string {
+	paths := make([]string, 0, len(s.Files))
+	for p := range s.Files {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// WriteSetup writes setup to path as indented JSON
+func WriteSetup(setup *Setup, path string) error {
+	data, err := json.MarshalIndent(setup, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal project setup: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// ReadSetup reads and validates an exported setup
+func ReadSetup(path string) (*Setup, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var setup Setup
+	if err := json.Unmarshal(data, &setup); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", path, err)
+	}
+	if setup.Format != SetupFormat {
+		return nil, fmt.Errorf("%s is not a crew project setup (format %q, expected %q)", path, setup.Format, SetupFormat)
+	}
+	for p := range setup.Files {
+		if err := validSetupPath(p); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", path, err)
+		}
+	}
+	return &setup, nil
+}
+
+// validSetupPath accepts only the files ExportSetup writes, so an
+// imported file cannot write outside the project's .claude directory
+func validSetupPath(p string) error {
+	clean := path.Clean(p)
+	if clean != p || path.IsAbs(p) || !strings.HasPrefix(clean, ".claude/") {
+		return fmt.Errorf("file %q is outside .claude", p)
+	}
+	for _, dir := range sharedDirs {
+		if strings.HasPrefix(clean, ".claude/"+dir+"/") && strings.HasSuffix(clean, ".md") {
+			return nil
+		}
+	}
+	for _, name := range sharedFiles {
+		if clean == ".claude/"+name {
+			return nil
+		}
+	}
+	return fmt.Errorf("file %q is not part of a project setup", p)
+}
+
+// ImportResult reports what ImportSetup did with each file
+type ImportResult struct {
+	Written   []string `json:"written"`
+	Unchanged []string `json:"unchanged"`
+	// Conflicts are local files that differ from the setup and were kept
+	Conflicts []string `json:"conflicts"`
+	Config    bool     `json:"config_updated"`
+}
+
+// ImportSetup applies setup to the project in projectDir. Files that
+// differ locally are reported as conflicts and kept unless force is set.
+// The project configuration gets the setup's tool choices; its other
+// fields, such as when /crew:onboard ran, stay as they are. With dryRun
+// nothing is written.
+func ImportSetup(setup *Setup, projectDir string, force, dryRun bool) (*ImportResult, error) {
+	result := &ImportResult{Written: []string{}, Unchanged: []string{}, Conflicts: []string{}}
+
+	for _, p := range setup.Paths() {
+		content := []byte(setup.Files[p])
+		dst := filepath.Join(projectDir, filepath.FromSlash(p))
+		if current, err := os.ReadFile(dst); err == nil {
+			if bytes.Equal(current, content) {
+				result.Unchanged = append(result.Unchanged, p)
+				continue
+			}
+			if !force {
+				result.Conflicts = append(result.Conflicts, p)
+				continue
+			}
+		}
+		result.Written = append(result.Written, p)
+		if dryRun {
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+			return nil, fmt.Errorf("failed to create %s: %w", filepath.Dir(dst), err)
+		}
+		if err := os.WriteFile(dst, content, 0644); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", dst, err)
+		}
+	}
+
+	cfg, err := LoadConfig(projectDir)
+	if os.IsNotExist(err) {
+		cfg, err = NewConfig(projectDir, ""), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	before, _ := json.Marshal(cfg)
+	cfg.Languages = nonNil(setup.Languages)
+	cfg.Frameworks = nonNil(setup.Frameworks)
+	cfg.Specialists = nonNil(setup.Specialists)
+	cfg.MCPServers = nonNil(setup.MCPServers)
+	cfg.Tools = nonNil(setup.Tools)
+	after, _ := json.Marshal(cfg)
+	if _, statErr := os.Stat(ConfigPath(projectDir)); os.IsNotExist(statErr) || !bytes.Equal(before, after) {
+		result.Config = true
+		if !dryRun {
+			if err := cfg.Save(projectDir); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return result, nil
+}
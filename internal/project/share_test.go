@@ -0,0 +1,81 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExportImportSetup(t *testing.T) {
+	source := t.TempDir()
+	cfg := NewConfig(source, "/home/me/.claude/commands/crew")
+	cfg.Languages = []string{"go"}
+	cfg.MCPServers = []string{"context7"}
+	if err := cfg.Save(source); err != nil {
+		t.Fatal(err)
+	}
+	claudeDir := filepath.Join(source, ".claude")
+	writeFile(t, filepath.Join(claudeDir, "agents", "orchestrator-specialist.md"), "# routing")
+	writeFile(t, filepath.Join(claudeDir, "commands", "shadows", "build.md"), "---\nbase_command: /crew:build\n---\n")
+	writeFile(t, filepath.Join(claudeDir, "hooks.json"), `{"hooks": {}}`)
+	writeFile(t, filepath.Join(claudeDir, "settings.local.json"), `{"personal": true}`)
+
+	setup, err := ExportSetup(source)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{".claude/agents/orchestrator-specialist.md", ".claude/commands/shadows/build.md", ".claude/hooks.json"}
+	if got := setup.Paths(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Exported %v, expected %v", got, want)
+	}
+
+	file := filepath.Join(t.TempDir(), SetupFile)
+	if err := WriteSetup(setup, file); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(file); strings.Contains(string(data), source) {
+		t.Error("The export should not contain the machine-specific project path")
+	}
+	if setup, err = ReadSetup(file); err != nil {
+		t.Fatal(err)
+	}
+
+	target := t.TempDir()
+	writeFile(t, filepath.Join(target, ".claude", "agents", "orchestrator-specialist.md"), "# mine")
+	result, err := ImportSetup(setup, target, false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Written) != 2 || !reflect.DeepEqual(result.Conflicts, []string{".claude/agents/orchestrator-specialist.md"}) || !result.Config {
+		t.Errorf("Unexpected import result: %+v", result)
+	}
+	if data, _ := os.ReadFile(filepath.Join(target, ".claude", "agents", "orchestrator-specialist.md")); string(data) != "# mine" {
+		t.Error("A conflicting local file should be kept without --force")
+	}
+	imported, err := LoadConfig(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if imported.ProjectPath != target || !reflect.DeepEqual(imported.MCPServers, []string{"context7"}) {
+		t.Errorf("Unexpected imported config: %+v", imported)
+	}
+
+	if result, err = ImportSetup(setup, target, true, false); err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Written) != 1 || len(result.Unchanged) != 2 || result.Config {
+		t.Errorf("Expected force to replace only the conflict, got %+v", result)
+	}
+}
+
+func TestReadSetupRejectsUnsafePaths(t *testing.T) {
+	for _, p := range []string{"../evil.md", ".claude/../../evil.md", "/etc/passwd", ".claude/settings.json", "CLAUDE.md"} {
+		file := filepath.Join(t.TempDir(), SetupFile)
+		writeFile(t, file, `{"format": "`+SetupFormat+`", "files": {"`+p+`": "x"}}`)
+		if _, err := ReadSetup(file); err == nil {
+			t.Errorf("Expected %q to be rejected", p)
+		}
+	}
+}
@@ -0,0 +1,228 @@
+package project
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/semver"
+	"gopkg.in/yaml.v3"
+)
+
+// IntegrationFile is the integration config 'crew claude --install' writes
+// to .claude, listing the slash commands the project was set up with
+const IntegrationFile = "supercrew-commands.json"
+
+// Global describes the global framework a project is compared with
+type Global struct {
+	// Version is the installed framework version, empty if not installed
+	Version     string
+	CommandsDir string
+}
+
+// Agent kinds
+const (
+	AgentOrchestrator = "orchestrator"
+	AgentSpecialist   = "specialist"
+	AgentOther        = "agent"
+)
+
+// Agent is an agent installed in the project
+type Agent struct {
+	Name string `json:"name"`
+	Kind string `json:"kind"`
+}
+
+// ShadowCommand is a project command that replaces a global one
+type ShadowCommand struct {
+	Name        string `json:"name"`
+	BaseCommand string `json:"base_command"`
+	// Drift is empty while the global command is unchanged
+	Drift string `json:"drift,omitempty"`
+}
+
+// Status is the crew state of a project
+type Status struct {
+	ProjectDir         string          `json:"project_dir"`
+	Integrated         bool            `json:"integrated"`
+	IntegrationVersion string          `json:"integration_version,omitempty"`
+	FrameworkVersion   string          `json:"framework_version,omitempty"`
+	Config             *Config         `json:"config,omitempty"`
+	ConfigError        string          `json:"config_error,omitempty"`
+	Agents             []Agent         `json:"agents"`
+	LocalCommands      []string        `json:"local_commands"`
+	ShadowCommands     []ShadowCommand `json:"shadow_commands"`
+	Drift              []string        `json:"drift"`
+}
+
+// AnalyzedAt returns when /crew:onboard last analyzed the project
+func (s *Status) AnalyzedAt() *time.Time {
+	if s.Config == nil {
+		return nil
+	}
+	return s.Config.AnalyzedAt
+}
+
+// Inspect collects the status of the project in projectDir
+func Inspect(projectDir string, global Global) *Status {
+	claudeDir := filepath.Join(projectDir, ".claude")
+	status := &Status{
+		ProjectDir:       projectDir,
+		FrameworkVersion: global.Version,
+		Agents:           []Agent{},
+		LocalCommands:    []string{},
+		ShadowCommands:   []ShadowCommand{},
+		Drift:            []string{},
+	}
+
+	cfg, err := LoadConfig(projectDir)
+	switch {
+	case err == nil:
+		status.Config = cfg
+		status.Integrated = true
+	case !os.IsNotExist(err):
+		status.ConfigError = err.Error()
+		status.Integrated = true
+	}
+
+	var integrated []string
+	if data, err := os.ReadFile(filepath.Join(claudeDir, IntegrationFile)); err == nil {
+		var integration struct {
+			Version  string `json:"version"`
+			Commands []struct {
+				Name string `json:"name"`
+			} `json:"commands"`
+		}
+		if err := json.Unmarshal(data, &integration); err != nil {
+			status.Drift = append(status.Drift, fmt.Sprintf("%s is invalid: %v", IntegrationFile, err))
+		}
+		status.Integrated = true
+		status.IntegrationVersion = integration.Version
+		for _, c := range integration.Commands {
+			integrated = append(integrated, c.Name)
+		}
+	}
+
+	status.Agents = listAgents(filepath.Join(claudeDir, "agents"))
+	status.LocalCommands = listMarkdown(filepath.Join(claudeDir, "commands"))
+	globalCommands := listMarkdown(global.CommandsDir)
+	status.ShadowCommands = listShadows(filepath.Join(claudeDir, "commands", "shadows"), global.CommandsDir, globalCommands)
+
+	if global.Version == "" {
+		status.Drift = append(status.Drift, "the global framework is not installed")
+	} else if status.IntegrationVersion != "" && semver.Compare(status.IntegrationVersion, global.Version) < 0 {
+		status.Drift = append(status.Drift, fmt.Sprintf("integration v%s is older than the framework v%s", status.IntegrationVersion, global.Version))
+	}
+	if status.IntegrationVersion != "" && global.Version != "" {
+		if added := missing(globalCommands, integrated); len(added) > 0 {
+			status.Drift = append(status.Drift, "global commands not in the integration: "+strings.Join(added, ", "))
+		}
+		if removed := missing(integrated, globalCommands); len(removed) > 0 {
+			status.Drift = append(status.Drift, "integrated commands no longer global: "+strings.Join(removed, ", "))
+		}
+	}
+	for _, shadow := range status.ShadowCommands {
+		if shadow.Drift != "" {
+			status.Drift = append(status.Drift, fmt.Sprintf("shadow command %s: %s", shadow.Name, shadow.Drift))
+		}
+	}
+
+	return status
+}
+
+func listAgents(dir string) []Agent {
+	agents := []Agent{}
+	for _, name := range listMarkdown(dir) {
+		kind := AgentOther
+		switch {
+		case name == "orchestrator-specialist":
+			kind = AgentOrchestrator
+		case strings.HasSuffix(name, "-specialist"):
+			kind = AgentSpecialist
+		}
+		agents = append(agents, Agent{Name: name, Kind: kind})
+	}
+	return agents
+}
+
+// listMarkdown returns the names of the Markdown files in dir, without
+// extension and sorted
+func listMarkdown(dir string) []string {
+	names := []string{}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return names
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".md") {
+			names = append(names, strings.TrimSuffix(entry.Name(), ".md"))
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// listShadows reads the shadow commands in dir and compares each with the
+// global command it replaces
+func listShadows(dir, globalDir string, globalCommands []string) []ShadowCommand {
+	shadows := []ShadowCommand{}
+	available := make(map[string]bool)
+	for _, name := range globalCommands {
+		available[name] = true
+	}
+
+	for _, name := range listMarkdown(dir) {
+		path := filepath.Join(dir, name+".md")
+		shadow := ShadowCommand{Name: name, BaseCommand: shadowBase(path, name)}
+
+		globalPath := filepath.Join(globalDir, shadow.BaseCommand+".md")
+		if !available[shadow.BaseCommand] {
+			shadow.Drift = "the global command no longer exists"
+		} else if shadowInfo, err := os.Stat(path); err == nil {
+			if globalInfo, err := os.Stat(globalPath); err == nil && globalInfo.ModTime().After(shadowInfo.ModTime()) {
+				shadow.Drift = "the global command changed after it was shadowed"
+			}
+		}
+		shadows = append(shadows, shadow)
+	}
+	return shadows
+}
+
+// shadowBase returns the global command a shadow replaces: base_command
+// from its front matter, or the command with the same name
+func shadowBase(path, name string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return name
+	}
+	parts := strings.SplitN(string(data), "---", 3)
+	if len(parts) < 3 {
+		return name
+	}
+	var front struct {
+		BaseCommand string `yaml:"base_command"`
+	}
+	if err := yaml.Unmarshal([]byte(parts[1]), &front); err != nil || front.BaseCommand == "" {
+		return name
+	}
+	return strings.TrimPrefix(front.BaseCommand, "/crew:")
+}
+
+// missing returns the entries of want that are not in have
+func missing(want, have []string) []string {
+	present := make(map[string]bool)
+	for _, name := range have {
+		present[name] = true
+	}
+	var result []string
+	for _, name := range want {
+		if !present[name] {
+			result = append(result, name)
+		}
+	}
+	return result
+}
@@ -0,0 +1,77 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestInspect(t *testing.T) {
+	projectDir := t.TempDir()
+	globalDir := t.TempDir()
+	claudeDir := filepath.Join(projectDir, ".claude")
+
+	if err := NewConfig(projectDir, globalDir).Save(projectDir); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(claudeDir, IntegrationFile), `{"version": "1.0.0", "commands": [{"name": "build"}, {"name": "legacy"}]}`)
+	writeFile(t, filepath.Join(claudeDir, "agents", "orchestrator-specialist.md"), "# orchestrator")
+	writeFile(t, filepath.Join(claudeDir, "agents", "go-specialist.md"), "# go")
+	writeFile(t, filepath.Join(claudeDir, "commands", "deploy.md"), "# deploy")
+	writeFile(t, filepath.Join(claudeDir, "commands", "shadows", "build.md"), "---\nname: build\n---\n")
+	writeFile(t, filepath.Join(claudeDir, "commands", "shadows", "ship.md"), "---\nbase_command: /crew:release\n---\n")
+
+	writeFile(t, filepath.Join(globalDir, "build.md"), "# build")
+	writeFile(t, filepath.Join(globalDir, "test.md"), "# test")
+	future := time.Now().Add(time.Hour)
+	if err := os.Chtimes(filepath.Join(globalDir, "build.md"), future, future); err != nil {
+		t.Fatal(err)
+	}
+
+	status := Inspect(projectDir, Global{Version: "1.1.0", CommandsDir: globalDir})
+
+	if !status.Integrated || status.IntegrationVersion != "1.0.0" || status.Config == nil {
+		t.Fatalf("Unexpected status %+v", status)
+	}
+	if len(status.Agents) != 2 || status.Agents[0].Kind != AgentSpecialist || status.Agents[1].Kind != AgentOrchestrator {
+		t.Errorf("Unexpected agents %+v", status.Agents)
+	}
+	if len(status.LocalCommands) != 1 || status.LocalCommands[0] != "deploy" {
+		t.Errorf("Unexpected local commands %v", status.LocalCommands)
+	}
+	if len(status.ShadowCommands) != 2 || status.ShadowCommands[1].BaseCommand != "release" {
+		t.Errorf("Unexpected shadow commands %+v", status.ShadowCommands)
+	}
+
+	drift := strings.Join(status.Drift, "\n")
+	for _, want := range []string{
+		"integration v1.0.0 is older than the framework v1.1.0",
+		"global commands not in the integration: test",
+		"integrated commands no longer global: legacy",
+		"shadow command build: the global command changed after it was shadowed",
+		"shadow command ship: the global command no longer exists",
+	} {
+		if !strings.Contains(drift, want) {
+			t.Errorf("Expected drift %q, got:\n%s", want, drift)
+		}
+	}
+}
+
+func TestInspectNotIntegrated(t *testing.T) {
+	status := Inspect(t.TempDir(), Global{Version: "1.0.0", CommandsDir: t.TempDir()})
+	if status.Integrated || len(status.Drift) != 0 {
+		t.Errorf("Unexpected status %+v", status)
+	}
+}
@@ -0,0 +1,234 @@
+// Package prompts renders the orchestrator prompts crew hands to Claude Code
+// from versioned text/template files. The built-in templates are embedded;
+// a project replaces one by putting a file of the same name in
+// .claude/prompts/templates.
+package prompts
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/project"
+	"github.com/jonwraymond/claude-code-super-crew/pkg/logger"
+)
+
+//go:embed templates/*.tmpl
+var builtin embed.FS
+
+// OverrideDir is where a project keeps its templates, relative to the
+// project's .claude directory
+const OverrideDir = "prompts/templates"
+
+// Command is the template used for commands without one of their own
+const Command = "command"
+
+// WavePlan is the template appended to the prompt of wave-enabled commands
+const WavePlan = "_wave"
+
+// versionPattern matches the header every template starts with:
+// {{/* crew-prompt version: N */ -}}
+var versionPattern = regexp.MustCompile(`^\{\{/\* crew-prompt version: (\d+) \*/`)
+
+// Data is what templates can refer to
+type Data struct {
+	Command      string
+	Description  string
+	ProjectDir   string
+	Args         string
+	AllowedTools []string
+	// Analysis is nil until /crew:onboard records the project's analysis
+	Analysis *Analysis
+	// Wave is set for wave-enabled commands, whose prompt then ends with the
+	// stage plan of the _wave template
+	Wave *Wave
+}
+
+// Wave is the stage plan of a wave-enabled command
+type Wave struct {
+	Strategy    string
+	Stages      []string
+	Checkpoints []string
+	Gates       []string
+}
+
+// Checkpoint reports whether progress is confirmed after stage
+func (w *Wave) Checkpoint(stage string) bool {
+	for _, checkpoint := range w.Checkpoints {
+		if checkpoint == stage {
+			return true
+		}
+	}
+	return false
+}
+
+// Analysis summarizes what /crew:onboard recorded in the project config
+type Analysis struct {
+	Languages   []string
+	Frameworks  []string
+	Specialists []string
+	AnalyzedAt  string
+}
+
+// LoadAnalysis returns the analysis recorded for the project in
+// projectDir, or nil when there is none
+func LoadAnalysis(projectDir string) *Analysis {
+	cfg, err := project.LoadConfig(projectDir)
+	if err != nil {
+		return nil
+	}
+	if cfg.AnalyzedAt == nil && len(cfg.Languages) == 0 && len(cfg.Frameworks) == 0 && len(cfg.Specialists) == 0 {
+		return nil
+	}
+	analysis := &Analysis{Languages: cfg.Languages, Frameworks: cfg.Frameworks, Specialists: cfg.Specialists}
+	if cfg.AnalyzedAt != nil {
+		analysis.AnalyzedAt = cfg.AnalyzedAt.Format("2006-01-02")
+	}
+	return analysis
+}
+
+// Template is a parsed prompt template
+type Template struct {
+	Name    string
+	Version int
+	// Path is the project file the template came from, "" when built in
+	Path string
+	tmpl *template.Template
+}
+
+var funcs = template.FuncMap{
+	"join": strings.Join,
+	"inc":  func(i int) int { return i + 1 },
+}
+
+// Names returns the names of the built-in templates
+func Names() []string {
+	entries, _ := fs.ReadDir(builtin, "templates")
+	var names []string
+	for _, entry := range entries {
+		names = append(names, strings.TrimSuffix(entry.Name(), ".tmpl"))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Has reports whether name has a template, built in or in the project
+func Has(name, claudeDir string) bool {
+	if _, err := fs.Stat(builtin, "templates/"+name+".tmpl"); err == nil {
+		return true
+	}
+	if claudeDir == "" {
+		return false
+	}
+	_, err := os.Stat(overridePath(claudeDir, name))
+	return err == nil
+}
+
+func overridePath(claudeDir, name string) string {
+	return filepath.Join(claudeDir, OverrideDir, name+".tmpl")
+}
+
+// Load returns the template name, preferring the project's copy in
+// claudeDir. A project template older than the built-in one still wins,
+// with a warning, since the built-in prompt may have gained instructions.
+func Load(name, claudeDir string) (*Template, error) {
+	builtinSource, builtinErr := fs.ReadFile(builtin, "templates/"+name+".tmpl")
+
+	if claudeDir != "" {
+		path := overridePath(claudeDir, name)
+		if source, err := os.ReadFile(path); err == nil {
+			t, err := parse(name, path, string(source))
+			if err != nil {
+				return nil, err
+			}
+			if builtinErr == nil {
+				if latest := version(string(builtinSource)); t.Version < latest {
+					logger.GetLogger().Warnf("Project prompt template %s is version %d, crew ships version %d; compare it with 'crew claude --prompt-template %s'", path, t.Version, latest, name)
+				}
+			}
+			return t, nil
+		} else if !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to read prompt template %s: %w", path, err)
+		}
+	}
+
+	if builtinErr != nil {
+		return nil, fmt.Errorf("no prompt template %s", name)
+	}
+	return parse(name, "", string(builtinSource))
+}
+
+func parse(name, path, source string) (*Template, error) {
+	tmpl, err := template.New(name).Funcs(funcs).Option("missingkey=error").Parse(source)
+	if err != nil {
+		where := path
+		if where == "" {
+			where = "built-in " + name
+		}
+		return nil, fmt.Errorf("invalid prompt template %s: %w", where, err)
+	}
+	return &Template{Name: name, Version: version(source), Path: path, tmpl: tmpl}, nil
+}
+
+// version returns the version in the header of source, 0 without one
+func version(source string) int {
+	match := versionPattern.FindStringSubmatch(source)
+	if match == nil {
+		return 0
+	}
+	v, _ := strconv.Atoi(match[1])
+	return v
+}
+
+// Source returns the built-in source of name
+func Source(name string) (string, error) {
+	source, err := fs.ReadFile(builtin, "templates/"+name+".tmpl")
+	if err != nil {
+		return "", fmt.Errorf("no prompt template %s", name)
+	}
+	return string(source), nil
+}
+
+// Execute renders the template with data
+func (t *Template) Execute(data Data) (string, error) {
+	var out bytes.Buffer
+	if err := t.tmpl.Execute(&out, data); err != nil {
+		return "", fmt.Errorf("failed to render prompt template %s: %w", t.Name, err)
+	}
+	return strings.TrimSpace(out.String()), nil
+}
+
+// Render loads name, falling back to the generic command template when
+// neither the project nor crew has one, and renders it with data. The
+// stage plan follows when data.Wave is set.
+func Render(name, claudeDir string, data Data) (string, error) {
+	if !Has(name, claudeDir) {
+		name = Command
+	}
+	t, err := Load(name, claudeDir)
+	if err != nil {
+		return "", err
+	}
+	prompt, err := t.Execute(data)
+	if err != nil || data.Wave == nil || len(data.Wave.Stages) == 0 {
+		return prompt, err
+	}
+
+	plan, err := Load(WavePlan, claudeDir)
+	if err != nil {
+		return "", err
+	}
+	stages, err := plan.Execute(data)
+	if err != nil {
+		return "", err
+	}
+	return prompt + "\n\n" + stages, nil
+}
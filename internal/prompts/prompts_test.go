@@ -0,0 +1,166 @@
+package prompts
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/project"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+var goldenData = Data{
+	Command:      "analyze",
+	Description:  "Analyze code quality",
+	ProjectDir:   "/work/app",
+	Args:         `src --focus security "two words"`,
+	AllowedTools: []string{"Read", "Grep"},
+}
+
+var goldenAnalysis = &Analysis{
+	Languages:   []string{"go", "typescript"},
+	Frameworks:  []string{"cobra"},
+	Specialists: []string{"go-specialist"},
+	AnalyzedAt:  "2026-01-02",
+}
+
+var goldenWave = &Wave{
+	Strategy:    "systematic",
+	Stages:      []string{"plan", "execute", "validate"},
+	Checkpoints: []string{"plan"},
+	Gates:       []string{"tests pass", "no lint errors"},
+}
+
+func checkGolden(t *testing.T, name, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := os.WriteFile(path, []byte(got+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v (run go test -update to create it)", err)
+	}
+	if got+"\n" != string(want) {
+		t.Errorf("%s differs from %s:\n%s", name, path, got)
+	}
+}
+
+func TestBuiltinTemplatesMatchGoldenFiles(t *testing.T) {
+	for _, name := range Names() {
+		tmpl, err := Load(name, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tmpl.Version < 1 {
+			t.Errorf("%s has no crew-prompt version header", name)
+		}
+
+		data := goldenData
+		if name == WavePlan {
+			data.Wave = goldenWave
+		} else {
+			data.Command = name
+		}
+		got, err := tmpl.Execute(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkGolden(t, name, got)
+
+		data.Analysis = goldenAnalysis
+		if got, err = tmpl.Execute(data); err != nil {
+			t.Fatal(err)
+		}
+		checkGolden(t, name+"-analyzed", got)
+	}
+}
+
+func TestRenderAppendsWavePlan(t *testing.T) {
+	data := goldenData
+	data.Wave = goldenWave
+	got, err := Render("analyze", "", data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plain, _ := Render("analyze", "", goldenData)
+	if !strings.HasPrefix(got, plain+"\n\nWAVE EXECUTION (systematic strategy)") {
+		t.Errorf("Expected the wave plan after the analyze prompt, got:\n%s", got)
+	}
+	if !strings.Contains(got, "1. plan - CHECKPOINT") || strings.Contains(got, "2. execute - CHECKPOINT") {
+		t.Errorf("Expected only plan to be a checkpoint, got:\n%s", got)
+	}
+}
+
+func TestProjectTemplateOverridesBuiltin(t *testing.T) {
+	claudeDir := t.TempDir()
+	dir := filepath.Join(claudeDir, OverrideDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "analyze.tmpl"), []byte("Review {{.Args}} in {{.ProjectDir}}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "deploy.tmpl"), []byte("{{/* crew-prompt version: 1 */ -}}\nDeploy {{.Args}}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl, err := Load("analyze", claudeDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tmpl.Path == "" || tmpl.Version != 0 {
+		t.Errorf("Expected the unversioned project template, got %+v", tmpl)
+	}
+	if got, _ := tmpl.Execute(goldenData); got != `Review src --focus security "two words" in /work/app` {
+		t.Errorf("Unexpected override output %q", got)
+	}
+
+	// Commands without a template of their own use the generic one, unless
+	// the project adds one
+	if got, err := Render("deploy", claudeDir, goldenData); err != nil || got != `Deploy src --focus security "two words"` {
+		t.Errorf("Expected the project's deploy template, got %q, %v", got, err)
+	}
+	if got, err := Render("estimate", claudeDir, goldenData); err != nil || !strings.Contains(got, "Execute /crew:analyze (Analyze code quality)") {
+		t.Errorf("Expected the generic command template, got %q, %v", got, err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "build.tmpl"), []byte("{{.Missing"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load("build", claudeDir); err == nil || !strings.Contains(err.Error(), "build.tmpl") {
+		t.Errorf("Expected a parse error naming the project file, got %v", err)
+	}
+}
+
+func TestLoadAnalysis(t *testing.T) {
+	projectDir := t.TempDir()
+	if LoadAnalysis(projectDir) != nil {
+		t.Error("Expected no analysis without a project config")
+	}
+
+	cfg := project.NewConfig(projectDir, "")
+	if err := cfg.Save(projectDir); err != nil {
+		t.Fatal(err)
+	}
+	if LoadAnalysis(projectDir) != nil {
+		t.Error("Expected no analysis before /crew:onboard records one")
+	}
+
+	analyzed := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+	cfg.Languages = []string{"go"}
+	cfg.AnalyzedAt = &analyzed
+	if err := cfg.Save(projectDir); err != nil {
+		t.Fatal(err)
+	}
+	if analysis := LoadAnalysis(projectDir); analysis == nil || analysis.AnalyzedAt != "2026-03-04" || analysis.Languages[0] != "go" {
+		t.Errorf("Unexpected analysis %+v", analysis)
+	}
+}
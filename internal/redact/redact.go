@@ -0,0 +1,160 @@
+// Package redact hides personal information in what crew writes for
+// others to read: diagnostic bundles, log files and telemetry. It replaces
+// the home directory with ~, the user name and project names with
+// placeholders where they are path elements or whole quoted values, and
+// anything that looks like a credential with <redacted>.
+package redact
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Placeholders the redacted text is replaced with
+const (
+	Home     = "~"
+	User     = "<user>"
+	Project  = "<project>"
+	Redacted = "<redacted>"
+)
+
+// rule replaces the matches of re with replacement, which may refer to
+// the groups of re
+type rule struct {
+	re          *regexp.Regexp
+	replacement string
+}
+
+// secrets match credentials. The groups keep what says which credential
+// it was, so the text stays readable.
+var secrets = []rule{
+	{regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{20,}|github_pat_[A-Za-z0-9_]{20,})`), Redacted},
+	{regexp.MustCompile(`\bsk-[A-Za-z0-9_-]{20,}`), Redacted},
+	{regexp.MustCompile(`\bAKIA[0-9A-Z]{16}\b`), Redacted},
+	{regexp.MustCompile(`(?i)\b(bearer\s+)[A-Za-z0-9._~+/=-]{8,}`), "${1}" + Redacted},
+	{regexp.MustCompile(`(?i)\b((?:token|secret|password|passwd|api[_-]?key)["']?\s*[:=]\s*["']?)[^"'\s,}]+`), "${1}" + Redacted},
+	{regexp.MustCompile(`(://[^/\s:@"]+:)[^/\s@"]+@`), "${1}" + Redacted + "@"},
+}
+
+// Options select what a Redactor hides besides credentials
+type Options struct {
+	// Home is replaced with ~; its last element is the user name unless
+	// User is set
+	Home string
+	User string
+	// Projects are project names, hidden like the user name
+	Projects []string
+	// Patterns are extra regular expressions. A match is replaced with
+	// <redacted>, keeping the first group when the pattern has one.
+	// Patterns applied to log files should not match quotes.
+	Patterns []string
+}
+
+// Redactor hides personal information in text. A nil Redactor only hides
+// credentials.
+type Redactor struct {
+	homes []string
+	rules []rule
+}
+
+// New builds a Redactor from opts. A home directory that is the
+// filesystem root, and names of a single character, are left alone.
+func New(opts Options) (*Redactor, error) {
+	r := &Redactor{}
+	user := opts.User
+	if opts.Home != "" && filepath.Dir(opts.Home) != opts.Home {
+		r.homes = []string{opts.Home}
+		if slash := filepath.ToSlash(opts.Home); slash != opts.Home {
+			r.homes = append(r.homes, slash)
+		}
+		// Paths inside JSON strings have their backslashes escaped
+		if escaped := strings.ReplaceAll(opts.Home, `\`, `\\`); escaped != opts.Home {
+			r.homes = append(r.homes, escaped)
+		}
+		if user == "" {
+			user = filepath.Base(opts.Home)
+		}
+	}
+	if len(user) > 1 {
+		r.rules = append(r.rules, nameRule(user, User))
+	}
+	for _, project := range opts.Projects {
+		if len(project) > 1 {
+			r.rules = append(r.rules, nameRule(project, Project))
+		}
+	}
+
+	for _, pattern := range opts.Patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction pattern %q: %w", pattern, err)
+		}
+		replacement := Redacted
+		if re.NumSubexp() > 0 {
+			replacement = "${1}" + Redacted
+		}
+		r.rules = append(r.rules, rule{re, replacement})
+	}
+	r.rules = append(r.rules, secrets...)
+	return r, nil
+}
+
+// nameRule replaces name where it is a path element or a whole quoted
+// value, so the word in ordinary text is left alone
+func nameRule(name, placeholder string) rule {
+	re := regexp.MustCompile(`(^|[/\\"'])` + regexp.QuoteMeta(name) + `([/\\"']|$)`)
+	return rule{re, "${1}" + placeholder + "${2}"}
+}
+
+// String returns text with everything r hides replaced
+func (r *Redactor) String(text string) string {
+	rules := secrets
+	if r != nil {
+		for _, home := range r.homes {
+			text = strings.ReplaceAll(text, home, Home)
+		}
+		rules = r.rules
+	}
+	for _, rule := range rules {
+		text = rule.re.ReplaceAllString(text, rule.replacement)
+	}
+	return text
+}
+
+// Bytes is String for a byte slice
+func (r *Redactor) Bytes(data []byte) []byte {
+	return []byte(r.String(string(data)))
+}
+
+var (
+	defaultMu       sync.Mutex
+	defaultRedactor *Redactor
+)
+
+// Default returns the Redactor set with SetDefault, or else one hiding the
+// current user's home directory, user name and credentials
+func Default() *Redactor {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	if defaultRedactor == nil {
+		home, _ := os.UserHomeDir()
+		defaultRedactor, _ = New(Options{Home: home})
+	}
+	return defaultRedactor
+}
+
+// SetDefault replaces the Redactor returned by Default
+func SetDefault(r *Redactor) {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	defaultRedactor = r
+}
+
+// String redacts text with the Default Redactor
+func String(text string) string {
+	return Default().String(text)
+}
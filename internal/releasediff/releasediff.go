@@ -0,0 +1,251 @@
+// Package releasediff compares two framework releases file by file. A
+// release is read from a SuperCrew source tree, an installation such as
+// ~/.claude, or a .tar/.tar.gz archive of either, and reduced to the files
+// users care about: commands, agents, personas and core docs.
+package releasediff
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/textdiff"
+)
+
+// Categories of framework files, in report order
+const (
+	Commands = "commands"
+	Agents   = "agents"
+	Personas = "personas"
+	Core     = "core"
+)
+
+// Categories lists every category in report order
+var Categories = []string{Commands, Agents, Personas, Core}
+
+// Change statuses
+const (
+	Added    = "added"
+	Removed  = "removed"
+	Modified = "modified"
+)
+
+// Release is the framework files of one release, keyed by category and
+// file name
+type Release struct {
+	Label string
+	Files map[string]map[string]string
+}
+
+// Change is one file that differs between two releases
+type Change struct {
+	Category string `json:"category"`
+	Name     string `json:"name"`
+	Status   string `json:"status"`
+	Added    int    `json:"added"`
+	Removed  int    `json:"removed"`
+	// Diff is the unified diff from the old to the new file
+	Diff string `json:"diff,omitempty"`
+}
+
+// Load reads the release at src, a directory or a tar archive
+func Load(src, label string) (*Release, error) {
+	info, err := os.Stat(src)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string]string)
+	if info.IsDir() {
+		err = filepath.WalkDir(src, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			// Crew's own state and VCS data hold no framework files
+			if d.IsDir() && p != src && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			if d.IsDir() || !strings.HasSuffix(d.Name(), ".md") {
+				return nil
+			}
+			data, err := os.ReadFile(p)
+			if err != nil {
+				return err
+			}
+			rel, _ := filepath.Rel(src, p)
+			files[filepath.ToSlash(rel)] = string(data)
+			return nil
+		})
+	} else {
+		err = readArchive(src, files)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", src, err)
+	}
+
+	release := classify(files)
+	release.Label = label
+	if release.empty() {
+		return nil, fmt.Errorf("%s holds no framework commands, agents or core docs", src)
+	}
+	return release, nil
+}
+
+// readArchive collects the Markdown files of a tar or tar.gz archive
+func readArchive(archive string, files map[string]string) error {
+	f, err := os.Open(archive)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if strings.HasSuffix(archive, ".gz") || strings.HasSuffix(archive, ".tgz") {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if header.Typeflag != tar.TypeReg || !strings.HasSuffix(header.Name, ".md") {
+			continue
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return err
+		}
+		files[path.Clean(strings.TrimPrefix(header.Name, "./"))] = string(data)
+	}
+}
+
+// classify sorts files into categories. The release root is where the
+// commands, agents or core directory is found, so archives with a leading
+// directory work. Both layouts are understood: the source tree keeps core
+// docs in core/ and commands in commands/, an installation keeps core docs
+// at the top and commands in commands/crew/.
+func classify(files map[string]string) *Release {
+	root := ""
+	found := false
+	for _, p := range sortedKeys(files) {
+		parts := strings.Split(p, "/")
+		for i, part := range parts[:len(parts)-1] {
+			if part == "commands" || part == "agents" || part == "core" {
+				candidate := strings.Join(parts[:i], "/")
+				if !found || len(candidate) < len(root) {
+					root, found = candidate, true
+				}
+				break
+			}
+		}
+	}
+
+	release := &Release{Files: make(map[string]map[string]string)}
+	for _, category := range Categories {
+		release.Files[category] = make(map[string]string)
+	}
+	for p, content := range files {
+		rel := p
+		if root != "" {
+			if !strings.HasPrefix(p, root+"/") {
+				continue
+			}
+			rel = strings.TrimPrefix(p, root+"/")
+		}
+		name := path.Base(rel)
+		switch dir := path.Dir(rel); {
+		case dir == "commands" || dir == "commands/crew":
+			release.Files[Commands][name] = content
+		case dir == "agents" && strings.HasSuffix(name, "-persona.md"):
+			release.Files[Personas][name] = content
+		case dir == "agents":
+			release.Files[Agents][name] = content
+		case dir == "core" || (dir == "." && isCoreDoc(name)):
+			release.Files[Core][name] = content
+		}
+	}
+	return release
+}
+
+// isCoreDoc reports whether a top-level file of an installation is a core
+// doc: they are named in capitals, like CLAUDE.md and FLAGS.md
+func isCoreDoc(name string) bool {
+	base := strings.TrimSuffix(name, ".md")
+	return base != "" && base == strings.ToUpper(base) && base != "README"
+}
+
+func (r *Release) empty() bool {
+	for _, files := range r.Files {
+		if len(files) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Compare returns the files that differ from one release to the next,
+// ordered by category and name. With withDiff each change carries its
+// unified diff.
+func Compare(from, to *Release, withDiff bool) []Change {
+	var changes []Change
+	for _, category := range Categories {
+		names := sortedKeys(from.Files[category])
+		for _, name := range sortedKeys(to.Files[category]) {
+			if _, ok := from.Files[category][name]; !ok {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			old, inFrom := from.Files[category][name]
+			current, inTo := to.Files[category][name]
+			if inFrom && inTo && old == current {
+				continue
+			}
+
+			change := Change{Category: category, Name: name, Status: Modified}
+			switch {
+			case !inFrom:
+				change.Status = Added
+			case !inTo:
+				change.Status = Removed
+			}
+			stats := textdiff.Count(textdiff.Lines(old, current))
+			change.Added, change.Removed = stats.Added, stats.Removed
+			if withDiff {
+				fromName := path.Join(from.Label, category, name)
+				toName := path.Join(to.Label, category, name)
+				change.Diff = textdiff.Unified(fromName, toName, old, current, 3)
+			}
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
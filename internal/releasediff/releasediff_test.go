@@ -0,0 +1,107 @@
+package releasediff
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeTree(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestCompareSourceWithInstallation(t *testing.T) {
+	source := t.TempDir()
+	writeTree(t, source, map[string]string{
+		"commands/build.md":      "build\nv2\n",
+		"commands/new.md":        "new\n",
+		"agents/qa-persona.md":   "qa\n",
+		"agents/orchestrator.md": "route\n",
+		"core/FLAGS.md":          "flags\n",
+		"agents/templates/x.md":  "not a release file\n",
+	})
+
+	installed := t.TempDir()
+	writeTree(t, installed, map[string]string{
+		"commands/crew/build.md": "build\nv1\n",
+		"commands/crew/old.md":   "old\n",
+		"agents/qa-persona.md":   "qa\n",
+		"agents/orchestrator.md": "route\n",
+		"FLAGS.md":               "flags\n",
+		"README.md":              "not core\n",
+		".crew/backups/notes.md": "crew state\n",
+		"projects/x/agents/y.md": "a project\n",
+	})
+
+	from, err := Load(installed, "installed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	to, err := Load(source, "bundled")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, change := range Compare(from, to, true) {
+		got = append(got, change.Category+"/"+change.Name+":"+change.Status)
+		if change.Name == "build.md" && (change.Added != 1 || change.Removed != 1 || !strings.Contains(change.Diff, "+v2")) {
+			t.Errorf("Unexpected build.md change: %+v", change)
+		}
+	}
+	want := "commands/build.md:modified,commands/new.md:added,commands/old.md:removed"
+	if strings.Join(got, ",") != want {
+		t.Errorf("Changes = %s\nexpected  %s", strings.Join(got, ","), want)
+	}
+
+	if len(from.Files[Personas]) != 1 || len(from.Files[Agents]) != 1 || len(from.Files[Core]) != 1 {
+		t.Errorf("Installation classified wrongly: %v", from.Files)
+	}
+}
+
+func TestLoadArchiveWithLeadingDirectory(t *testing.T) {
+	archive := filepath.Join(t.TempDir(), "release.tar.gz")
+	f, err := os.Create(archive)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	for name, content := range map[string]string{
+		"supercrew-1.1.0/SuperCrew/commands/build.md": "build\n",
+		"supercrew-1.1.0/SuperCrew/core/RULES.md":     "rules\n",
+		"supercrew-1.1.0/Docs/guide.md":               "docs\n",
+	} {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		tw.Write([]byte(content))
+	}
+	tw.Close()
+	gz.Close()
+	f.Close()
+
+	release, err := Load(archive, "1.1.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if release.Files[Commands]["build.md"] != "build\n" || release.Files[Core]["RULES.md"] != "rules\n" {
+		t.Errorf("Unexpected release: %v", release.Files)
+	}
+
+	if _, err := Load(t.TempDir(), "empty"); err == nil {
+		t.Error("Expected an error for a directory without framework files")
+	}
+}
@@ -0,0 +1,274 @@
+package releases
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/cache"
+	"github.com/jonwraymond/claude-code-super-crew/internal/retry"
+)
+
+// ChecksumAssets are the names a release may publish its SHA-256 sums
+// under, in the format of sha256sum
+var ChecksumAssets = []string{"checksums.txt", "SHA256SUMS", "sha256sums.txt"}
+
+// partSuffix marks an interrupted download that the next Fetch resumes
+const partSuffix = ".part"
+
+// CacheDir returns where downloaded release assets of the installation in
+// installDir are kept
+func CacheDir(installDir string) string {
+	return cache.New(cache.Dir(installDir)).Namespace(cache.Releases)
+}
+
+// Downloader fetches release assets into a cache, verifying each against
+// the checksums file of its release
+type Downloader struct {
+	CacheDir string
+	// Cache, if set, is kept within its size cap after each download
+	Cache *cache.Cache
+	// Client defaults to a client that honors HTTPS_PROXY and NO_PROXY
+	Client *http.Client
+	// Proxy, if set, is used instead of the proxy environment variables
+	Proxy string
+	// Progress, if set, is called as bytes arrive; total is -1 when unknown
+	Progress func(done, total int64)
+}
+
+// NewDownloader returns a downloader caching in cacheDir
+func NewDownloader(cacheDir string) *Downloader {
+	return &Downloader{CacheDir: cacheDir}
+}
+
+func (d *Downloader) client() (*http.Client, error) {
+	if d.Client != nil {
+		return d.Client, nil
+	}
+	proxy := http.ProxyFromEnvironment
+	if d.Proxy != "" {
+		proxyURL, err := url.Parse(d.Proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy %s: %w", d.Proxy, err)
+		}
+		proxy = http.ProxyURL(proxyURL)
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = proxy
+	return &http.Client{Transport: transport}, nil
+}
+
+// Fetch returns the path of the asset name of release in the cache,
+// downloading it first unless a verified copy is there. An interrupted
+// download is resumed. The asset must be listed in the checksums file of
+// the release and match its sum.
+func (d *Downloader) Fetch(ctx context.Context, release *Release, name string) (string, error) {
+	asset := release.Asset(name)
+	if asset == nil {
+		return "", fmt.Errorf("release %s has no asset %s", release.Tag, name)
+	}
+	client, err := d.client()
+	if err != nil {
+		return "", err
+	}
+
+	want, err := d.checksum(ctx, client, release, name)
+	if err != nil {
+		return "", err
+	}
+
+	dir := filepath.Join(d.CacheDir, release.Version())
+	path := filepath.Join(dir, name)
+	if sum, err := fileSHA256(path); err == nil {
+		if sum == want {
+			cache.Touch(path)
+			return path, nil
+		}
+		os.Remove(path)
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	part := path + partSuffix
+	// Each retry resumes from what the last attempt wrote
+	err = retry.Do(ctx, retry.Network, func() error {
+		return d.download(ctx, client, asset.DownloadURL, part)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	sum, err := fileSHA256(part)
+	if err != nil {
+		return "", err
+	}
+	if sum != want {
+		// Resuming cannot repair a corrupt download
+		os.Remove(part)
+		return "", fmt.Errorf("checksum mismatch for %s: expected %s, got %s", name, want, sum)
+	}
+	if err := os.Rename(part, path); err != nil {
+		return "", err
+	}
+	if d.Cache != nil {
+		if _, err := d.Cache.Evict(path); err != nil {
+			return "", err
+		}
+	}
+	return path, nil
+}
+
+// checksum returns the SHA-256 sum the release publishes for name
+func (d *Downloader) checksum(ctx context.Context, client *http.Client, release *Release, name string) (string, error) {
+	var sums *Asset
+	for _, candidate := range ChecksumAssets {
+		if sums = release.Asset(candidate); sums != nil {
+			break
+		}
+	}
+	if sums == nil {
+		return "", fmt.Errorf("release %s publishes no checksums file (%s)", release.Tag, strings.Join(ChecksumAssets, ", "))
+	}
+
+	var table map[string]string
+	err := retry.Do(ctx, retry.Network, func() error {
+		resp, err := get(ctx, client, sums.DownloadURL, 0)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return statusError(fmt.Errorf("failed to download %s: %s", sums.Name, resp.Status), resp.StatusCode)
+		}
+
+		if table, err = ParseChecksums(resp.Body); err != nil {
+			return fmt.Errorf("invalid %s: %w", sums.Name, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	sum, ok := table[name]
+	if !ok {
+		return "", fmt.Errorf("%s of release %s has no checksum for %s", sums.Name, release.Tag, name)
+	}
+	return sum, nil
+}
+
+// ParseChecksums reads sha256sum output: a hex digest and a file name per
+// line, the name optionally marked binary with a leading '*'
+func ParseChecksums(r io.Reader) (map[string]string, error) {
+	sums := make(map[string]string)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 2 || len(fields[0]) != sha256.Size*2 {
+			return nil, fmt.Errorf("malformed line %q", scanner.Text())
+		}
+		if _, err := hex.DecodeString(fields[0]); err != nil {
+			return nil, fmt.Errorf("malformed line %q", scanner.Text())
+		}
+		sums[strings.TrimPrefix(fields[1], "*")] = strings.ToLower(fields[0])
+	}
+	return sums, scanner.Err()
+}
+
+// download writes src to part, continuing after the bytes part already
+// holds when the server supports ranges
+func (d *Downloader) download(ctx context.Context, client *http.Client, src, part string) error {
+	var offset int64
+	if info, err := os.Stat(part); err == nil {
+		offset = info.Size()
+	}
+
+	resp, err := get(ctx, client, src, offset)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	flags := os.O_CREATE | os.O_WRONLY
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		flags |= os.O_APPEND
+	case http.StatusOK:
+		offset = 0
+		flags |= os.O_TRUNC
+	case http.StatusRequestedRangeNotSatisfiable:
+		// part already holds everything
+		return nil
+	default:
+		return statusError(fmt.Errorf("failed to download %s: %s", src, resp.Status), resp.StatusCode)
+	}
+
+	f, err := os.OpenFile(part, flags, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	total := int64(-1)
+	if resp.ContentLength >= 0 {
+		total = offset + resp.ContentLength
+	}
+	var body io.Reader = resp.Body
+	if d.Progress != nil {
+		body = &progressReader{r: resp.Body, done: offset, total: total, report: d.Progress}
+	}
+	if _, err := io.Copy(f, body); err != nil {
+		return fmt.Errorf("download of %s interrupted, run again to resume: %w", src, err)
+	}
+	return f.Close()
+}
+
+// statusError marks err, caused by an HTTP response with code, as worth
+// retrying when the status is
+func statusError(err error, code int) error {
+	if retry.RetryableStatus(code) {
+		return retry.Retryable(err)
+	}
+	return err
+}
+
+func get(ctx context.Context, client *http.Client, src string, offset int64) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, src, nil)
+	if err != nil {
+		return nil, retry.Permanent(fmt.Errorf("invalid download URL %s: %w", src, err))
+	}
+	req.Header.Set("Accept", "application/octet-stream")
+	req.Header.Set("User-Agent", "crew")
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", src, err)
+	}
+	return resp, nil
+}
+
+type progressReader struct {
+	r           io.Reader
+	done, total int64
+	report      func(done, total int64)
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	p.done += int64(n)
+	p.report(p.done, p.total)
+	return n, err
+}
@@ -0,0 +1,154 @@
+package releases
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// assetServer serves a release with one asset and its checksums file
+type assetServer struct {
+	*httptest.Server
+	content  []byte
+	sum      string
+	requests []string
+}
+
+func newAssetServer(t *testing.T, content []byte) *assetServer {
+	t.Helper()
+	hash := sha256.Sum256(content)
+	s := &assetServer{content: content, sum: hex.EncodeToString(hash[:])}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.requests = append(s.requests, r.URL.Path+" "+r.Header.Get("Range"))
+		switch r.URL.Path {
+		case "/checksums.txt":
+			fmt.Fprintf(w, "%s *crew-linux-amd64\n%s  other\n", s.sum, strings.Repeat("0", 64))
+		case "/crew-linux-amd64":
+			http.ServeContent(w, r, "crew-linux-amd64", time.Time{}, bytes.NewReader(s.content))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *assetServer) release() *Release {
+	return &Release{Tag: "v1.2.0", Assets: []Asset{
+		{Name: "crew-linux-amd64", DownloadURL: s.URL + "/crew-linux-amd64"},
+		{Name: "checksums.txt", DownloadURL: s.URL + "/checksums.txt"},
+	}}
+}
+
+func TestFetchCachesVerifiedAsset(t *testing.T) {
+	server := newAssetServer(t, []byte("crew binary"))
+	d := NewDownloader(t.TempDir())
+
+	path, err := d.Fetch(context.Background(), server.release(), "crew-linux-amd64")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if path != filepath.Join(d.CacheDir, "1.2.0", "crew-linux-amd64") {
+		t.Errorf("Unexpected cache path %s", path)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "crew binary" {
+		t.Errorf("Unexpected content %q", data)
+	}
+
+	server.requests = nil
+	if _, err := d.Fetch(context.Background(), server.release(), "crew-linux-amd64"); err != nil {
+		t.Fatal(err)
+	}
+	for _, req := range server.requests {
+		if strings.HasPrefix(req, "/crew-linux-amd64") {
+			t.Errorf("Cached asset downloaded again: %v", server.requests)
+		}
+	}
+}
+
+func TestFetchResumes(t *testing.T) {
+	server := newAssetServer(t, []byte("0123456789"))
+	d := NewDownloader(t.TempDir())
+	part := filepath.Join(d.CacheDir, "1.2.0", "crew-linux-amd64"+partSuffix)
+	if err := os.MkdirAll(filepath.Dir(part), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(part, []byte("01234"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var done int64
+	d.Progress = func(n, total int64) { done = n }
+	path, err := d.Fetch(context.Background(), server.release(), "crew-linux-amd64")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "0123456789" {
+		t.Errorf("Unexpected content %q", data)
+	}
+	if done != 10 {
+		t.Errorf("Expected progress to reach 10 bytes, got %d", done)
+	}
+	if !contains(server.requests, "/crew-linux-amd64 bytes=5-") {
+		t.Errorf("Download not resumed: %v", server.requests)
+	}
+}
+
+func TestFetchRejectsChecksumMismatch(t *testing.T) {
+	server := newAssetServer(t, []byte("crew binary"))
+	server.sum = strings.Repeat("a", 64)
+	d := NewDownloader(t.TempDir())
+
+	if _, err := d.Fetch(context.Background(), server.release(), "crew-linux-amd64"); err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Fatalf("Expected a checksum mismatch, got %v", err)
+	}
+	if entries, _ := os.ReadDir(filepath.Join(d.CacheDir, "1.2.0")); len(entries) != 0 {
+		t.Errorf("Rejected download left files behind: %v", entries)
+	}
+}
+
+func TestFetchRequiresChecksums(t *testing.T) {
+	server := newAssetServer(t, []byte("crew binary"))
+	release := server.release()
+	release.Assets = release.Assets[:1]
+
+	if _, err := NewDownloader(t.TempDir()).Fetch(context.Background(), release, "crew-linux-amd64"); err == nil {
+		t.Error("Expected an error for a release without checksums")
+	}
+	if _, err := NewDownloader(t.TempDir()).Fetch(context.Background(), server.release(), "missing"); err == nil {
+		t.Error("Expected an error for a missing asset")
+	}
+}
+
+func TestParseChecksums(t *testing.T) {
+	sum := strings.Repeat("ab", 32)
+	sums, err := ParseChecksums(strings.NewReader(sum + "  crew.tar.gz\n\n" + strings.ToUpper(sum) + " *crew.zip\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sums["crew.tar.gz"] != sum || sums["crew.zip"] != sum {
+		t.Errorf("Unexpected sums %v", sums)
+	}
+
+	if _, err := ParseChecksums(strings.NewReader("not a checksum\n")); err == nil {
+		t.Error("Expected an error for a malformed line")
+	}
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
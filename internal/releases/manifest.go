@@ -0,0 +1,220 @@
+package releases
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// Homepage is the project page package managers link to
+const Homepage = "https://github.com/" + Repository
+
+// Description is the one-line summary package managers show
+const Description = "Agent orchestration, personas and workflows for Claude Code"
+
+// License is the SPDX identifier of the crew license
+const License = "MIT"
+
+// artifactName matches the binaries of 'make build-all', e.g.
+// crew-darwin-arm64 or crew-windows-amd64.exe
+var artifactName = regexp.MustCompile(`^crew-([a-z0-9]+)-([a-z0-9]+)(\.exe)?$`)
+
+// Artifact is a release binary for one platform
+type Artifact struct {
+	Name   string `json:"name"`
+	OS     string `json:"os"`
+	Arch   string `json:"arch"`
+	URL    string `json:"url"`
+	SHA256 string `json:"sha256"`
+}
+
+// DownloadURL returns the URL a release asset of version is published at
+func DownloadURL(version, name string) string {
+	return fmt.Sprintf("%s/releases/download/v%s/%s", Homepage, strings.TrimPrefix(version, "v"), name)
+}
+
+// ScanArtifacts checksums the release binaries in dir. baseURL is the
+// download directory of the release; empty means the GitHub release of
+// version.
+func ScanArtifacts(dir, version, baseURL string) ([]Artifact, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read artifacts: %w", err)
+	}
+
+	var artifacts []Artifact
+	for _, entry := range entries {
+		m := artifactName.FindStringSubmatch(entry.Name())
+		if m == nil || entry.IsDir() {
+			continue
+		}
+		sum, err := fileSHA256(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		url := DownloadURL(version, entry.Name())
+		if baseURL != "" {
+			url = strings.TrimSuffix(baseURL, "/") + "/" + entry.Name()
+		}
+		artifacts = append(artifacts, Artifact{
+			Name:   entry.Name(),
+			OS:     m[1],
+			Arch:   m[2],
+			URL:    url,
+			SHA256: sum,
+		})
+	}
+	if len(artifacts) == 0 {
+		return nil, fmt.Errorf("no release binaries (crew-<os>-<arch>) in %s; run 'make build-all' first", dir)
+	}
+	sort.Slice(artifacts, func(i, j int) bool { return artifacts[i].Name < artifacts[j].Name })
+	return artifacts, nil
+}
+
+func fileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", fmt.Errorf("failed to checksum %s: %w", path, err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// brewArch maps GOARCH to the Homebrew architecture blocks
+var brewArch = map[string]string{"amd64": "on_intel", "arm64": "on_arm"}
+
+// brewOS maps GOOS to the Homebrew OS blocks
+var brewOS = map[string]string{"darwin": "on_macos", "linux": "on_linux"}
+
+var formulaTemplate = template.Must(template.New("formula").Parse(`class Crew < Formula
+  desc "{{.Description}}"
+  homepage "{{.Homepage}}"
+  version "{{.Version}}"
+  license "{{.License}}"
+{{range .Platforms}}
+  {{.Block}} do
+{{- range .Arches}}
+    {{.Block}} do
+      url "{{.URL}}"
+      sha256 "{{.SHA256}}"
+    end
+{{- end}}
+  end
+{{end}}
+  def install
+    bin.install Dir["crew-*"].first => "crew"
+  end
+
+  test do
+    assert_match version.to_s, shell_output("#{bin}/crew version")
+  end
+end
+`))
+
+type brewBlock struct {
+	Block  string
+	Arches []brewArtifact
+}
+
+type brewArtifact struct {
+	Block string
+	Artifact
+}
+
+// BrewFormula renders a Homebrew formula for the macOS and Linux artifacts
+func BrewFormula(version string, artifacts []Artifact) (string, error) {
+	var platforms []brewBlock
+	for _, goos := range []string{"darwin", "linux"} {
+		block := brewBlock{Block: brewOS[goos]}
+		for _, a := range artifacts {
+			if arch, ok := brewArch[a.Arch]; ok && a.OS == goos {
+				block.Arches = append(block.Arches, brewArtifact{Block: arch, Artifact: a})
+			}
+		}
+		if len(block.Arches) > 0 {
+			platforms = append(platforms, block)
+		}
+	}
+	if len(platforms) == 0 {
+		return "", fmt.Errorf("no macOS or Linux binaries for the Homebrew formula")
+	}
+
+	var buf bytes.Buffer
+	err := formulaTemplate.Execute(&buf, map[string]interface{}{
+		"Description": Description,
+		"Homepage":    Homepage,
+		"Version":     strings.TrimPrefix(version, "v"),
+		"License":     License,
+		"Platforms":   platforms,
+	})
+	return buf.String(), err
+}
+
+// scoopArch maps GOARCH to the Scoop architecture keys
+var scoopArch = map[string]string{"amd64": "64bit", "386": "32bit", "arm64": "arm64"}
+
+// ScoopManifest is a Scoop app manifest
+type ScoopManifest struct {
+	Version      string                              `json:"version"`
+	Description  string                              `json:"description"`
+	Homepage     string                              `json:"homepage"`
+	License      string                              `json:"license"`
+	Architecture map[string]ScoopResource            `json:"architecture"`
+	Bin          string                              `json:"bin"`
+	Checkver     map[string]string                   `json:"checkver"`
+	Autoupdate   map[string]map[string]ScoopResource `json:"autoupdate"`
+}
+
+// ScoopResource is the download of one architecture
+type ScoopResource struct {
+	URL  string `json:"url"`
+	Hash string `json:"hash,omitempty"`
+}
+
+// NewScoopManifest renders a Scoop manifest for the Windows artifacts. The
+// binary is renamed to crew.exe on download.
+func NewScoopManifest(version string, artifacts []Artifact) ([]byte, error) {
+	version = strings.TrimPrefix(version, "v")
+	manifest := ScoopManifest{
+		Version:      version,
+		Description:  Description,
+		Homepage:     Homepage,
+		License:      License,
+		Architecture: make(map[string]ScoopResource),
+		Bin:          "crew.exe",
+		Checkver:     map[string]string{"github": Homepage},
+		Autoupdate:   map[string]map[string]ScoopResource{"architecture": {}},
+	}
+	for _, a := range artifacts {
+		arch, ok := scoopArch[a.Arch]
+		if !ok || a.OS != "windows" {
+			continue
+		}
+		manifest.Architecture[arch] = ScoopResource{URL: a.URL + "#/crew.exe", Hash: a.SHA256}
+		manifest.Autoupdate["architecture"][arch] = ScoopResource{
+			URL: strings.ReplaceAll(a.URL, version, "$version") + "#/crew.exe",
+		}
+	}
+	if len(manifest.Architecture) == 0 {
+		return nil, fmt.Errorf("no Windows binaries for the Scoop manifest")
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "    ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
@@ -0,0 +1,117 @@
+package releases
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeArtifacts(t *testing.T, names ...string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for _, name := range names {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(name), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestScanArtifacts(t *testing.T) {
+	dir := writeArtifacts(t, "crew-linux-amd64", "crew-windows-amd64.exe", "notes.txt")
+
+	artifacts, err := ScanArtifacts(dir, "v1.2.0", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(artifacts) != 2 {
+		t.Fatalf("Expected 2 artifacts, got %+v", artifacts)
+	}
+	linux := artifacts[0]
+	if linux.OS != "linux" || linux.Arch != "amd64" {
+		t.Errorf("Unexpected platform %s/%s", linux.OS, linux.Arch)
+	}
+	if len(linux.SHA256) != 64 {
+		t.Errorf("Unexpected checksum %q", linux.SHA256)
+	}
+	if want := Homepage + "/releases/download/v1.2.0/crew-linux-amd64"; linux.URL != want {
+		t.Errorf("Expected URL %s, got %s", want, linux.URL)
+	}
+
+	artifacts, err = ScanArtifacts(dir, "1.2.0", "https://mirror.example.com/1.2.0/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if artifacts[0].URL != "https://mirror.example.com/1.2.0/crew-linux-amd64" {
+		t.Errorf("Unexpected mirror URL %s", artifacts[0].URL)
+	}
+
+	if _, err := ScanArtifacts(writeArtifacts(t, "notes.txt"), "1.2.0", ""); err == nil {
+		t.Error("Expected an error without release binaries")
+	}
+}
+
+func TestBrewFormula(t *testing.T) {
+	dir := writeArtifacts(t, "crew-darwin-amd64", "crew-darwin-arm64", "crew-linux-amd64", "crew-windows-amd64.exe")
+	artifacts, err := ScanArtifacts(dir, "1.2.0", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	formula, err := BrewFormula("1.2.0", artifacts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`version "1.2.0"`,
+		"on_macos do\n    on_intel do",
+		"on_arm do",
+		"on_linux do\n    on_intel do",
+		`url "` + DownloadURL("1.2.0", "crew-darwin-arm64") + `"`,
+		`sha256 "` + artifacts[1].SHA256 + `"`,
+	} {
+		if !strings.Contains(formula, want) {
+			t.Errorf("Formula is missing %q:\n%s", want, formula)
+		}
+	}
+	if strings.Contains(formula, "windows") {
+		t.Errorf("Formula includes the Windows binary:\n%s", formula)
+	}
+
+	if _, err := BrewFormula("1.2.0", artifacts[3:]); err == nil {
+		t.Error("Expected an error without macOS or Linux binaries")
+	}
+}
+
+func TestScoopManifest(t *testing.T) {
+	dir := writeArtifacts(t, "crew-linux-amd64", "crew-windows-amd64.exe")
+	artifacts, err := ScanArtifacts(dir, "1.2.0", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := NewScoopManifest("v1.2.0", artifacts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var manifest ScoopManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		t.Fatal(err)
+	}
+	x64, ok := manifest.Architecture["64bit"]
+	if manifest.Version != "1.2.0" || !ok || len(manifest.Architecture) != 1 {
+		t.Fatalf("Unexpected manifest %s", data)
+	}
+	if x64.URL != DownloadURL("1.2.0", "crew-windows-amd64.exe")+"#/crew.exe" || x64.Hash != artifacts[1].SHA256 {
+		t.Errorf("Unexpected 64bit download %+v", x64)
+	}
+	if got := manifest.Autoupdate["architecture"]["64bit"].URL; !strings.Contains(got, "/v$version/") {
+		t.Errorf("Autoupdate URL does not follow the version: %s", got)
+	}
+
+	if _, err := NewScoopManifest("1.2.0", artifacts[:1]); err == nil {
+		t.Error("Expected an error without Windows binaries")
+	}
+}
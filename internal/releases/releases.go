@@ -0,0 +1,117 @@
+// Package releases reads the crew release feed, the GitHub releases of the
+// crew repository unless CREW_RELEASE_FEED points somewhere else, and
+// downloads verified release assets.
+package releases
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/retry"
+)
+
+// Repository is the GitHub repository crew is released from
+const Repository = "jonwraymond/claude-code-super-crew"
+
+// FeedEnv overrides the URL of the latest release, e.g. for a mirror or
+// an internal fork. The URL must answer like GitHub's releases/latest.
+const FeedEnv = "CREW_RELEASE_FEED"
+
+// DefaultTimeout bounds a feed request when the context has no deadline
+const DefaultTimeout = 10 * time.Second
+
+// Release is a published crew release
+type Release struct {
+	Tag         string    `json:"tag_name"`
+	Name        string    `json:"name"`
+	URL         string    `json:"html_url"`
+	PublishedAt time.Time `json:"published_at"`
+	Prerelease  bool      `json:"prerelease"`
+	Assets      []Asset   `json:"assets"`
+}
+
+// Asset is a file attached to a release
+type Asset struct {
+	Name        string `json:"name"`
+	Size        int64  `json:"size"`
+	DownloadURL string `json:"browser_download_url"`
+}
+
+// Version returns the release version without the leading v of its tag
+func (r *Release) Version() string {
+	return strings.TrimPrefix(r.Tag, "v")
+}
+
+// Asset returns the asset called name, or nil
+func (r *Release) Asset(name string) *Asset {
+	for i := range r.Assets {
+		if r.Assets[i].Name == name {
+			return &r.Assets[i]
+		}
+	}
+	return nil
+}
+
+// Feed fetches releases
+type Feed struct {
+	URL    string
+	Client *http.Client
+}
+
+// NewFeed returns the feed configured by FeedEnv, or the GitHub feed
+func NewFeed() *Feed {
+	url := os.Getenv(FeedEnv)
+	if url == "" {
+		url = fmt.Sprintf("https://api.github.com/repos/%s/releases/latest", Repository)
+	}
+	return &Feed{URL: url}
+}
+
+// Latest returns the newest published release
+func (f *Feed) Latest(ctx context.Context) (*Release, error) {
+	client := f.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, DefaultTimeout)
+		defer cancel()
+	}
+
+	var release Release
+	err := retry.Do(ctx, retry.Network, func() error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, f.URL, nil)
+		if err != nil {
+			return retry.Permanent(fmt.Errorf("invalid release feed %s: %w", f.URL, err))
+		}
+		req.Header.Set("Accept", "application/vnd.github+json")
+		req.Header.Set("User-Agent", "crew")
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return fmt.Errorf("failed to read release feed: %w", err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return statusError(fmt.Errorf("failed to read release feed %s: %s", f.URL, resp.Status), resp.StatusCode)
+		}
+
+		if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+			return fmt.Errorf("invalid release feed response: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if release.Tag == "" {
+		return nil, fmt.Errorf("invalid release feed response: no tag_name")
+	}
+	return &release, nil
+}
@@ -0,0 +1,77 @@
+package releases
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/retry"
+)
+
+func TestLatest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("User-Agent") != "crew" {
+			t.Errorf("Expected a crew User-Agent, got %q", r.Header.Get("User-Agent"))
+		}
+		w.Write([]byte(`{"tag_name": "v1.2.0", "html_url": "https://example.com/v1.2.0", "assets": [{"name": "crew-linux-amd64", "size": 10}]}`))
+	}))
+	defer server.Close()
+
+	release, err := (&Feed{URL: server.URL}).Latest(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if release.Version() != "1.2.0" || release.URL != "https://example.com/v1.2.0" || len(release.Assets) != 1 {
+		t.Errorf("Unexpected release %+v", release)
+	}
+}
+
+func TestLatestErrors(t *testing.T) {
+	for name, handler := range map[string]http.HandlerFunc{
+		"status":  func(w http.ResponseWriter, r *http.Request) { http.Error(w, "rate limited", http.StatusForbidden) },
+		"no tag":  func(w http.ResponseWriter, r *http.Request) { w.Write([]byte(`{}`)) },
+		"invalid": func(w http.ResponseWriter, r *http.Request) { w.Write([]byte(`<html>`)) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(handler)
+			defer server.Close()
+			if _, err := (&Feed{URL: server.URL}).Latest(context.Background()); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+}
+
+func TestLatestRetriesServerErrors(t *testing.T) {
+	original := retry.For(retry.Network)
+	defer retry.Set(retry.Network, original)
+	retry.Set(retry.Network, retry.Policy{Attempts: 3, Initial: time.Millisecond})
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests < 3 {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"tag_name": "v1.2.0"}`))
+	}))
+	defer server.Close()
+
+	if _, err := (&Feed{URL: server.URL}).Latest(context.Background()); err != nil || requests != 3 {
+		t.Errorf("Expected success on the third request, got %v after %d", err, requests)
+	}
+}
+
+func TestNewFeed(t *testing.T) {
+	t.Setenv(FeedEnv, "")
+	if got := NewFeed().URL; got != "https://api.github.com/repos/"+Repository+"/releases/latest" {
+		t.Errorf("Unexpected default feed %q", got)
+	}
+	t.Setenv(FeedEnv, "https://mirror.example.com/latest")
+	if got := NewFeed().URL; got != "https://mirror.example.com/latest" {
+		t.Errorf("Expected %s to override the feed, got %q", FeedEnv, got)
+	}
+}
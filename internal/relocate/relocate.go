@@ -0,0 +1,306 @@
+// Package relocate moves a crew installation to another directory and
+// rewrites the paths that point at the old location.
+package relocate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/crewdirs"
+	"github.com/jonwraymond/claude-code-super-crew/internal/managers"
+	"github.com/jonwraymond/claude-code-super-crew/internal/metadata"
+	"github.com/jonwraymond/claude-code-super-crew/internal/project"
+)
+
+// Step kinds
+const (
+	StepMove     = "move"
+	StepLink     = "link"
+	StepMetadata = "metadata"
+	StepSettings = "settings"
+	StepProject  = "project"
+)
+
+// settingsFiles are the Claude settings files whose paths are rewritten
+var settingsFiles = []string{"settings.json", "settings.local.json"}
+
+// Options configures a migration
+type Options struct {
+	From string
+	To   string
+	// Projects are project directories whose crew configuration and
+	// settings may point at the installation
+	Projects []string
+	// Link leaves a symlink at From so Claude and crew keep finding the
+	// installation there
+	Link bool
+}
+
+// Step is one change of a migration. Path is where the change is made
+// once the installation has moved.
+type Step struct {
+	Kind   string `json:"kind"`
+	Path   string `json:"path"`
+	Detail string `json:"detail"`
+
+	apply func() error
+}
+
+// Plan is the list of changes that migrate an installation
+type Plan struct {
+	From  string `json:"from"`
+	To    string `json:"to"`
+	Steps []Step `json:"steps"`
+}
+
+// NewPlan checks that the installation in opts.From can move to opts.To and
+// returns the changes that would do it. Nothing is written.
+func NewPlan(opts Options) (*Plan, error) {
+	from, err := filepath.Abs(opts.From)
+	if err != nil {
+		return nil, err
+	}
+	to, err := filepath.Abs(opts.To)
+	if err != nil {
+		return nil, err
+	}
+
+	if !metadata.NewMetadataManager(from).CheckInstallationExists() {
+		return nil, fmt.Errorf("no crew installation found in %s", from)
+	}
+	if from == to {
+		return nil, fmt.Errorf("installation is already in %s", to)
+	}
+	if within(to, from) {
+		return nil, fmt.Errorf("cannot move %s into itself", from)
+	}
+	if info, err := os.Lstat(to); err == nil {
+		if !info.IsDir() {
+			return nil, fmt.Errorf("%s exists and is not a directory", to)
+		}
+		if entries, err := os.ReadDir(to); err != nil || len(entries) > 0 {
+			return nil, fmt.Errorf("%s exists and is not empty", to)
+		}
+	}
+
+	p := &Plan{From: from, To: to}
+	p.add(StepMove, to, fmt.Sprintf("move %s to %s", from, to), func() error { return move(from, to) })
+	if opts.Link {
+		p.add(StepLink, from, fmt.Sprintf("link %s to %s", from, to), func() error { return os.Symlink(to, from) })
+	}
+	// The metadata moves along unless it is kept in the XDG directories
+	metadataFile := crewdirs.Path(from, "config", crewdirs.MetadataFile)
+	if rel, err := filepath.Rel(from, metadataFile); err == nil && within(metadataFile, from) {
+		metadataFile = filepath.Join(to, rel)
+	}
+	p.add(StepMetadata, metadataFile, "set installation.install_dir to "+to,
+		func() error { return updateMetadata(metadataFile, to) })
+
+	for _, name := range settingsFiles {
+		p.addSettings(filepath.Join(from, name), filepath.Join(to, name))
+	}
+
+	for _, dir := range opts.Projects {
+		dir, err := filepath.Abs(dir)
+		if err != nil {
+			return nil, err
+		}
+		// The home directory's .claude is the installation itself
+		if within(dir, from) || filepath.Join(dir, ".claude") == from {
+			continue
+		}
+		if err := p.addProject(dir); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// Apply makes the changes of the plan in order, calling onStep before each
+// one. It stops at the first failure.
+func (p *Plan) Apply(onStep func(Step)) error {
+	for _, step := range p.Steps {
+		if onStep != nil {
+			onStep(step)
+		}
+		if err := step.apply(); err != nil {
+			return fmt.Errorf("%s %s: %w", step.Kind, step.Path, err)
+		}
+	}
+	return nil
+}
+
+func (p *Plan) add(kind, path, detail string, apply func() error) {
+	p.Steps = append(p.Steps, Step{Kind: kind, Path: path, Detail: detail, apply: apply})
+}
+
+// addSettings adds a step for a settings file that mentions the
+// installation. current is where the file is now, target where it is
+// rewritten.
+func (p *Plan) addSettings(current, target string) {
+	data, err := os.ReadFile(current)
+	if err != nil {
+		return
+	}
+	if n := countPaths(data, p.From); n > 0 {
+		p.add(StepSettings, target, fmt.Sprintf("rewrite %d path(s)", n), func() error {
+			return rewriteFile(target, p.From, p.To)
+		})
+	}
+}
+
+// addProject adds the steps for a project whose configuration or settings
+// point at the installation
+func (p *Plan) addProject(dir string) error {
+	config, err := project.LoadConfig(dir)
+	switch {
+	case os.IsNotExist(err):
+	case err != nil:
+		return fmt.Errorf("failed to load project configuration of %s: %w", dir, err)
+	case rewritePaths(config.GlobalCommands, p.From, p.To) != config.GlobalCommands:
+		config.GlobalCommands = rewritePaths(config.GlobalCommands, p.From, p.To)
+		p.add(StepProject, project.ConfigPath(dir), "set global_commands to "+config.GlobalCommands, func() error {
+			return config.Save(dir)
+		})
+	}
+
+	for _, name := range settingsFiles {
+		path := filepath.Join(dir, ".claude", name)
+		p.addSettings(path, path)
+	}
+	return nil
+}
+
+// move renames from to to, copying when they are on different file systems
+func move(from, to string) error {
+	if err := os.MkdirAll(filepath.Dir(to), 0755); err != nil {
+		return err
+	}
+	// An empty target directory is replaced
+	if err := os.Remove(to); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err := os.Rename(from, to); err == nil {
+		return nil
+	}
+	if err := managers.NewFileManager().CopyDirectory(from, to); err != nil {
+		os.RemoveAll(to)
+		return err
+	}
+	return os.RemoveAll(from)
+}
+
+func updateMetadata(path, installDir string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var meta metadata.UnifiedMetadata
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return err
+	}
+	meta.Installation.InstallDir = installDir
+	if data, err = json.MarshalIndent(meta, "", "  "); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// rewriteFile rewrites the paths in a JSON file as text, so its formatting
+// and key order are kept
+func rewriteFile(path, from, to string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	rewritten := []byte(rewritePaths(string(data), jsonString(from), jsonString(to)))
+	if !json.Valid(rewritten) {
+		return fmt.Errorf("rewriting paths would make the file invalid JSON")
+	}
+	return os.WriteFile(path, rewritten, info.Mode().Perm())
+}
+
+// countPaths counts the mentions of the installation in a JSON file
+func countPaths(data []byte, from string) int {
+	n := 0
+	for _, alias := range aliases(jsonString(from)) {
+		for i := bytes.Index(data, []byte(alias)); i >= 0; {
+			if end := i + len(alias); end == len(data) || !isNameByte(data[end]) {
+				n++
+			}
+			next := bytes.Index(data[i+1:], []byte(alias))
+			if next < 0 {
+				break
+			}
+			i += 1 + next
+		}
+	}
+	return n
+}
+
+// rewritePaths replaces the path from, and the ~ and $HOME forms of it, with
+// to wherever it is a whole path or a prefix of one
+func rewritePaths(s, from, to string) string {
+	for _, alias := range aliases(from) {
+		var b strings.Builder
+		for {
+			i := strings.Index(s, alias)
+			if i < 0 {
+				break
+			}
+			end := i + len(alias)
+			b.WriteString(s[:i])
+			if end == len(s) || !isNameByte(s[end]) {
+				b.WriteString(to)
+			} else {
+				b.WriteString(alias)
+			}
+			s = s[end:]
+		}
+		b.WriteString(s)
+		s = b.String()
+	}
+	return s
+}
+
+// aliases returns path and, when it is in the home directory, the forms
+// that refer to it through ~ and $HOME
+func aliases(path string) []string {
+	paths := []string{path}
+	home, err := os.UserHomeDir()
+	if err != nil || home == "" {
+		return paths
+	}
+	home = jsonString(home)
+	if rest := strings.TrimPrefix(path, home); rest != path && strings.HasPrefix(rest, "/") {
+		paths = append(paths, "~"+rest, "$HOME"+rest, "${HOME}"+rest)
+	}
+	return paths
+}
+
+// isNameByte reports whether c can continue a file name, so that a path
+// followed by c names a different file
+func isNameByte(c byte) bool {
+	return c == '.' || c == '-' || c == '_' ||
+		'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9'
+}
+
+// jsonString returns s as it appears inside a JSON string
+func jsonString(s string) string {
+	data, _ := json.Marshal(s)
+	return string(data[1 : len(data)-1])
+}
+
+// within reports whether path is dir or inside it
+func within(path, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
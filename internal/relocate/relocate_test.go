@@ -0,0 +1,134 @@
+package relocate
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/metadata"
+	"github.com/jonwraymond/claude-code-super-crew/internal/project"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func newInstallation(t *testing.T, dir string) {
+	t.Helper()
+	mm := metadata.NewMetadataManager(dir)
+	meta, err := mm.LoadMetadata()
+	if err != nil {
+		t.Fatal(err)
+	}
+	meta.Installation.InstallDir = dir
+	if err := mm.SaveMetadata(meta); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRewritePaths(t *testing.T) {
+	t.Setenv("HOME", "/home/u")
+	tests := []struct{ in, want string }{
+		{"/home/u/.claude", "/data/claude"},
+		{"python3 /home/u/.claude/hooks/a.py", "python3 /data/claude/hooks/a.py"},
+		{"~/.claude/hooks/a.py and $HOME/.claude", "/data/claude/hooks/a.py and /data/claude"},
+		{"${HOME}/.claude/x", "/data/claude/x"},
+		{"/home/u/.claude-old/x", "/home/u/.claude-old/x"},
+		{"/home/u/.claudex", "/home/u/.claudex"},
+	}
+	for _, tt := range tests {
+		if got := rewritePaths(tt.in, "/home/u/.claude", "/data/claude"); got != tt.want {
+			t.Errorf("rewritePaths(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestNewPlanRejects(t *testing.T) {
+	tmp := t.TempDir()
+	from := filepath.Join(tmp, ".claude")
+
+	if _, err := NewPlan(Options{From: from, To: filepath.Join(tmp, "to")}); err == nil {
+		t.Error("expected an error without an installation")
+	}
+
+	newInstallation(t, from)
+	writeFile(t, filepath.Join(tmp, "full", "file"), "x")
+	for _, to := range []string{from, filepath.Join(from, "sub"), filepath.Join(tmp, "full")} {
+		if _, err := NewPlan(Options{From: from, To: to}); err == nil {
+			t.Errorf("expected an error moving to %s", to)
+		}
+	}
+}
+
+func TestApply(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv("HOME", tmp)
+	from := filepath.Join(tmp, ".claude")
+	to := filepath.Join(tmp, "dotfiles", "claude")
+	app := filepath.Join(tmp, "src", "app")
+
+	newInstallation(t, from)
+	writeFile(t, filepath.Join(from, "CLAUDE.md"), "# Framework\n")
+	writeFile(t, filepath.Join(from, "settings.json"),
+		`{"hooks": {"PreToolUse": [{"command": "python3 ~/.claude/hooks/guard.py"}]}, "z": 1, "a": 2}`+"\n")
+
+	config := project.NewConfig(app, filepath.Join(from, "commands", "crew"))
+	if err := os.MkdirAll(filepath.Join(app, ".claude"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := config.Save(app); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(app, ".claude", "settings.local.json"), `{"dir": "`+from+`"}`)
+
+	plan, err := NewPlan(Options{From: from, To: to, Projects: []string{app}, Link: true})
+	if err != nil {
+		t.Fatalf("NewPlan: %v", err)
+	}
+	var kinds []string
+	for _, step := range plan.Steps {
+		kinds = append(kinds, step.Kind)
+	}
+	want := []string{StepMove, StepLink, StepMetadata, StepSettings, StepProject, StepSettings}
+	if strings.Join(kinds, " ") != strings.Join(want, " ") {
+		t.Fatalf("steps = %v, want %v", kinds, want)
+	}
+	if _, err := os.Stat(to); !os.IsNotExist(err) {
+		t.Fatal("NewPlan must not change anything")
+	}
+
+	if err := plan.Apply(nil); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+
+	if data, err := os.ReadFile(filepath.Join(to, "CLAUDE.md")); err != nil || string(data) != "# Framework\n" {
+		t.Errorf("CLAUDE.md not moved: %q, %v", data, err)
+	}
+	if target, err := os.Readlink(from); err != nil || target != to {
+		t.Errorf("expected a link at %s to %s, got %q, %v", from, to, target, err)
+	}
+	meta, err := metadata.NewMetadataManager(to).LoadMetadata()
+	if err != nil || meta.Installation.InstallDir != to {
+		t.Errorf("install_dir not updated: %v", err)
+	}
+	settings, _ := os.ReadFile(filepath.Join(to, "settings.json"))
+	wantSettings := `{"hooks": {"PreToolUse": [{"command": "python3 ` + to + `/hooks/guard.py"}]}, "z": 1, "a": 2}` + "\n"
+	if string(settings) != wantSettings {
+		t.Errorf("settings.json = %s, want %s", settings, wantSettings)
+	}
+	loaded, err := project.LoadConfig(app)
+	if err != nil || loaded.GlobalCommands != filepath.Join(to, "commands", "crew") {
+		t.Errorf("global_commands not updated: %+v, %v", loaded, err)
+	}
+	local, _ := os.ReadFile(filepath.Join(app, ".claude", "settings.local.json"))
+	if string(local) != `{"dir": "`+to+`"}` {
+		t.Errorf("project settings = %s", local)
+	}
+}
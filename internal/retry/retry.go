@@ -0,0 +1,188 @@
+// Package retry runs operations that fail transiently again after an
+// exponential backoff with jitter. Each kind of operation has its own
+// policy, so network fetches can wait longer than a rename blocked by a
+// virus scanner holding the file, and each can be tuned in the config.
+package retry
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"runtime"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/jonwraymond/claude-code-super-crew/pkg/logger"
+)
+
+// Operations with their own policy
+const (
+	// Network is for HTTP requests and downloads
+	Network = "network"
+	// Filesystem is for reading files that may briefly be unavailable
+	Filesystem = "filesystem"
+	// Lock is for replacing files another process may hold open or locked
+	Lock = "lock"
+)
+
+// Operations lists every operation with a policy
+var Operations = []string{Network, Filesystem, Lock}
+
+// Policy says how often and how patiently an operation is retried
+type Policy struct {
+	// Attempts is the number of tries including the first; 1 disables
+	// retrying
+	Attempts int
+	// Initial is the wait before the second try, which doubles per try up
+	// to Max
+	Initial time.Duration
+	Max     time.Duration
+	// Jitter spreads each wait randomly by up to this fraction of it, so
+	// many clients do not retry in step
+	Jitter float64
+}
+
+var (
+	mu       sync.RWMutex
+	policies = map[string]Policy{
+		Network:    {Attempts: 4, Initial: 500 * time.Millisecond, Max: 8 * time.Second, Jitter: 0.2},
+		Filesystem: {Attempts: 3, Initial: 50 * time.Millisecond, Max: 500 * time.Millisecond, Jitter: 0.2},
+		Lock:       {Attempts: 5, Initial: 100 * time.Millisecond, Max: 2 * time.Second, Jitter: 0.2},
+	}
+)
+
+// sleep waits d or until ctx is done; tests replace it
+var sleep = func(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// For returns the policy of op; unknown operations are not retried
+func For(op string) Policy {
+	mu.RLock()
+	defer mu.RUnlock()
+	if p, ok := policies[op]; ok {
+		return p
+	}
+	return Policy{Attempts: 1}
+}
+
+// Set replaces the policy of op
+func Set(op string, p Policy) {
+	mu.Lock()
+	defer mu.Unlock()
+	policies[op] = p
+}
+
+// Do runs fn under the policy of op
+func Do(ctx context.Context, op string, fn func() error) error {
+	return For(op).Do(ctx, op, fn)
+}
+
+// Do runs fn until it succeeds, fails with an error that is not
+// transient, or runs out of attempts. The last error is returned. name
+// labels the retries in debug logs.
+func (p Policy) Do(ctx context.Context, name string, fn func() error) error {
+	wait := p.Initial
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || attempt >= p.Attempts || !Transient(err) {
+			return err
+		}
+
+		delay := wait
+		if p.Jitter > 0 {
+			delay += time.Duration((rand.Float64()*2 - 1) * p.Jitter * float64(wait))
+		}
+		logger.GetLogger().Debugf("%s failed (attempt %d of %d), retrying in %s: %v", name, attempt, p.Attempts, delay.Round(time.Millisecond), err)
+		if sleep(ctx, delay) != nil {
+			return err
+		}
+
+		wait *= 2
+		if p.Max > 0 && wait > p.Max {
+			wait = p.Max
+		}
+	}
+}
+
+// mark wraps an error whose transience the caller decided
+type mark struct {
+	err       error
+	transient bool
+}
+
+func (m *mark) Error() string { return m.err.Error() }
+func (m *mark) Unwrap() error { return m.err }
+
+// Retryable marks err as transient, e.g. an HTTP 503 response
+func Retryable(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &mark{err: err, transient: true}
+}
+
+// Permanent marks err as not worth retrying even when its cause is
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &mark{err: err, transient: false}
+}
+
+// RetryableStatus reports whether an HTTP response status is worth
+// retrying: rate limiting and server errors other than 501
+func RetryableStatus(code int) bool {
+	return code == 429 || code == 408 || (code >= 500 && code != 501)
+}
+
+// Windows errors for a file another process holds
+const (
+	errorSharingViolation syscall.Errno = 32
+	errorLockViolation    syscall.Errno = 33
+)
+
+// Transient reports whether err is likely to go away on its own: network
+// timeouts and dropped connections, interrupted or busy system calls, and
+// files locked by another process. Retryable and Permanent override this.
+func Transient(err error) bool {
+	if err == nil {
+		return false
+	}
+	var m *mark
+	if errors.As(err, &m) {
+		return m.transient
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	var errno syscall.Errno
+	if errors.As(err, &errno) {
+		switch errno {
+		case syscall.EAGAIN, syscall.EINTR, syscall.EBUSY, syscall.ETXTBSY,
+			syscall.ECONNRESET, syscall.ECONNREFUSED, syscall.ECONNABORTED,
+			syscall.EPIPE, syscall.ETIMEDOUT:
+			return true
+		}
+		if runtime.GOOS == "windows" && (errno == errorSharingViolation || errno == errorLockViolation) {
+			return true
+		}
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
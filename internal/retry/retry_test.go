@@ -0,0 +1,92 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func recordSleeps(t *testing.T) *[]time.Duration {
+	t.Helper()
+	var waits []time.Duration
+	original := sleep
+	sleep = func(ctx context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return ctx.Err()
+	}
+	t.Cleanup(func() { sleep = original })
+	return &waits
+}
+
+func TestDoBacksOffUntilSuccess(t *testing.T) {
+	waits := recordSleeps(t)
+	policy := Policy{Attempts: 5, Initial: 10 * time.Millisecond, Max: 25 * time.Millisecond}
+
+	calls := 0
+	err := policy.Do(context.Background(), "test", func() error {
+		calls++
+		if calls < 4 {
+			return &os.PathError{Op: "rename", Path: "x", Err: syscall.EBUSY}
+		}
+		return nil
+	})
+	if err != nil || calls != 4 {
+		t.Fatalf("Expected success on the 4th call, got %v after %d", err, calls)
+	}
+	if fmt.Sprint(*waits) != "[10ms 20ms 25ms]" {
+		t.Errorf("Unexpected waits %v", *waits)
+	}
+}
+
+func TestDoStopsOnPermanentErrors(t *testing.T) {
+	recordSleeps(t)
+	policy := Policy{Attempts: 3}
+
+	calls := 0
+	notFound := &os.PathError{Op: "open", Path: "x", Err: syscall.ENOENT}
+	if err := policy.Do(context.Background(), "test", func() error { calls++; return notFound }); err != notFound || calls != 1 {
+		t.Errorf("Expected one call, got %d: %v", calls, err)
+	}
+
+	calls = 0
+	err := policy.Do(context.Background(), "test", func() error { calls++; return Permanent(io.ErrUnexpectedEOF) })
+	if !errors.Is(err, io.ErrUnexpectedEOF) || calls != 1 {
+		t.Errorf("Permanent should stop retrying, got %d calls: %v", calls, err)
+	}
+
+	calls = 0
+	err = policy.Do(context.Background(), "test", func() error { calls++; return Retryable(errors.New("503")) })
+	if err == nil || calls != 3 {
+		t.Errorf("Expected all 3 attempts, got %d", calls)
+	}
+}
+
+func TestDoGivesUpWhenCanceled(t *testing.T) {
+	recordSleeps(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	calls := 0
+	For(Network).Do(ctx, Network, func() error { calls++; return io.ErrUnexpectedEOF })
+	if calls != 1 {
+		t.Errorf("Expected no retry after cancel, got %d calls", calls)
+	}
+}
+
+func TestForAndSet(t *testing.T) {
+	original := For(Lock)
+	defer Set(Lock, original)
+
+	Set(Lock, Policy{Attempts: 9})
+	if For(Lock).Attempts != 9 {
+		t.Error("Set did not replace the policy")
+	}
+	if For("unknown").Attempts != 1 {
+		t.Error("Unknown operations should not retry")
+	}
+}
@@ -0,0 +1,226 @@
+package secrets
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// PassphraseEnv is the environment variable holding the passphrase for the
+// encrypted secrets file when none is configured explicitly
+const PassphraseEnv = "CREW_SECRETS_PASSPHRASE"
+
+const (
+	fileFormatVersion = 1
+	saltSize          = 16
+	keySize           = 32
+)
+
+// encryptedFile is the on-disk layout of the secrets file
+type encryptedFile struct {
+	Version int    `json:"version"`
+	Salt    []byte `json:"salt"`
+	Nonce   []byte `json:"nonce"`
+	Data    []byte `json:"data"`
+}
+
+// EncryptedFileProvider stores secrets in a passphrase-protected file using
+// AES-256-GCM with an scrypt-derived key
+type EncryptedFileProvider struct {
+	path       string
+	passphrase string
+	mu         sync.Mutex
+}
+
+// NewEncryptedFileProvider creates a provider backed by path. An empty
+// passphrase falls back to $CREW_SECRETS_PASSPHRASE at access time.
+func NewEncryptedFileProvider(path, passphrase string) *EncryptedFileProvider {
+	return &EncryptedFileProvider{path: path, passphrase: passphrase}
+}
+
+// Name returns the provider name
+func (p *EncryptedFileProvider) Name() string {
+	return "file"
+}
+
+// Get returns the secret stored under key
+func (p *EncryptedFileProvider) Get(key string) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	values, err := p.load()
+	if err != nil {
+		return "", err
+	}
+
+	value, ok := values[key]
+	if !ok {
+		return "", fmt.Errorf("secret %q not found in %s", key, p.path)
+	}
+	return value, nil
+}
+
+// Set stores a secret, creating the file if needed
+func (p *EncryptedFileProvider) Set(key, value string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	values, err := p.load()
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if values == nil {
+		values = make(map[string]string)
+	}
+
+	values[key] = value
+	return p.save(values)
+}
+
+// Delete removes a secret from the file
+func (p *EncryptedFileProvider) Delete(key string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	values, err := p.load()
+	if err != nil {
+		return err
+	}
+
+	if _, ok := values[key]; !ok {
+		return fmt.Errorf("secret %q not found in %s", key, p.path)
+	}
+	delete(values, key)
+	return p.save(values)
+}
+
+// Keys returns the names of stored secrets
+func (p *EncryptedFileProvider) Keys() ([]string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	values, err := p.load()
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []string{}, nil
+		}
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+func (p *EncryptedFileProvider) getPassphrase() (string, error) {
+	if p.passphrase != "" {
+		return p.passphrase, nil
+	}
+	if env := os.Getenv(PassphraseEnv); env != "" {
+		return env, nil
+	}
+	return "", fmt.Errorf("no passphrase for encrypted secrets file (set %s)", PassphraseEnv)
+}
+
+func (p *EncryptedFileProvider) load() (map[string]string, error) {
+	raw, err := os.ReadFile(p.path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file encryptedFile
+	if err := json.Unmarshal(raw, &file); err != nil {
+		return nil, fmt.Errorf("invalid secrets file %s: %w", p.path, err)
+	}
+	if file.Version != fileFormatVersion {
+		return nil, fmt.Errorf("unsupported secrets file version %d", file.Version)
+	}
+
+	gcm, err := p.cipher(file.Salt)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := gcm.Open(nil, file.Nonce, file.Data, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt secrets file (wrong passphrase?)")
+	}
+
+	var values map[string]string
+	if err := json.Unmarshal(plaintext, &values); err != nil {
+		return nil, fmt.Errorf("corrupt secrets file %s: %w", p.path, err)
+	}
+	return values, nil
+}
+
+func (p *EncryptedFileProvider) save(values map[string]string) error {
+	plaintext, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	gcm, err := p.cipher(salt)
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	data, err := json.MarshalIndent(encryptedFile{
+		Version: fileFormatVersion,
+		Salt:    salt,
+		Nonce:   nonce,
+		Data:    gcm.Seal(nil, nonce, plaintext, nil),
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(p.path), 0700); err != nil {
+		return fmt.Errorf("failed to create secrets directory: %w", err)
+	}
+
+	// Write to a temp file first so a failed write never truncates secrets
+	tmp := p.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write secrets file: %w", err)
+	}
+	return os.Rename(tmp, p.path)
+}
+
+func (p *EncryptedFileProvider) cipher(salt []byte) (cipher.AEAD, error) {
+	passphrase, err := p.getPassphrase()
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := scrypt.Key([]byte(passphrase), salt, 1<<15, 8, 1, keySize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive key: %w", err)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,90 @@
+package secrets
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// EnvProvider reads secrets from environment variables
+type EnvProvider struct{}
+
+// NewEnvProvider creates an environment variable provider
+func NewEnvProvider() *EnvProvider {
+	return &EnvProvider{}
+}
+
+// Name returns the provider name
+func (p *EnvProvider) Name() string {
+	return "env"
+}
+
+// Get returns the value of the environment variable named key
+func (p *EnvProvider) Get(key string) (string, error) {
+	value, ok := os.LookupEnv(key)
+	if !ok {
+		return "", fmt.Errorf("environment variable %s is not set", key)
+	}
+	return value, nil
+}
+
+// KeychainProvider reads secrets from the OS keychain. Keys take the form
+// <service>/<account>. macOS uses the security tool and Linux uses
+// secret-tool from libsecret.
+type KeychainProvider struct {
+	goos string
+	run  func(name string, args ...string) (string, error)
+}
+
+// NewKeychainProvider creates an OS keychain provider
+func NewKeychainProvider() *KeychainProvider {
+	return &KeychainProvider{
+		goos: runtime.GOOS,
+		run:  runCommand,
+	}
+}
+
+// Name returns the provider name
+func (p *KeychainProvider) Name() string {
+	return "keychain"
+}
+
+// Get looks up the password stored for service/account
+func (p *KeychainProvider) Get(key string) (string, error) {
+	service, account, found := strings.Cut(key, "/")
+	if !found || service == "" || account == "" {
+		return "", fmt.Errorf("keychain key must be <service>/<account>, got %q", key)
+	}
+
+	switch p.goos {
+	case "darwin":
+		return p.run("security", "find-generic-password", "-s", service, "-a", account, "-w")
+	case "linux":
+		return p.run("secret-tool", "lookup", "service", service, "account", account)
+	default:
+		return "", fmt.Errorf("keychain secrets are not supported on %s", p.goos)
+	}
+}
+
+func runCommand(name string, args ...string) (string, error) {
+	if _, err := exec.LookPath(name); err != nil {
+		return "", fmt.Errorf("%s not found in PATH", name)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(name, args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%s failed: %s", name, msg)
+		}
+		return "", fmt.Errorf("%s failed: %w", name, err)
+	}
+
+	return strings.TrimRight(stdout.String(), "\r\n"), nil
+}
@@ -0,0 +1,169 @@
+// Package secrets resolves secret:// references in configuration values so
+// credentials can live outside plaintext settings files.
+//
+// A reference has the form secret://<provider>/<key>, for example:
+//
+//	secret://env/CREW_REGISTRY_TOKEN
+//	secret://keychain/crew/registry-token
+//	secret://file/s3-secret-key
+package secrets
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// URIPrefix marks a configuration value as a secret reference
+const URIPrefix = "secret://"
+
+// Provider looks up secret values by key
+type Provider interface {
+	// Name returns the provider name used in secret:// URIs
+	Name() string
+	// Get returns the secret stored under key
+	Get(key string) (string, error)
+}
+
+// Reference is a parsed secret:// URI
+type Reference struct {
+	Provider string
+	Key      string
+}
+
+// String returns the URI form of the reference
+func (r Reference) String() string {
+	return URIPrefix + r.Provider + "/" + r.Key
+}
+
+// IsReference reports whether value is a secret:// URI
+func IsReference(value string) bool {
+	return strings.HasPrefix(value, URIPrefix)
+}
+
+// ParseReference parses a secret://<provider>/<key> URI
+func ParseReference(uri string) (Reference, error) {
+	if !IsReference(uri) {
+		return Reference{}, fmt.Errorf("not a secret reference: %s", uri)
+	}
+
+	rest := strings.TrimPrefix(uri, URIPrefix)
+	provider, key, found := strings.Cut(rest, "/")
+	if !found || provider == "" || key == "" {
+		return Reference{}, fmt.Errorf("invalid secret reference %q: expected secret://<provider>/<key>", uri)
+	}
+
+	return Reference{Provider: provider, Key: key}, nil
+}
+
+// Resolver resolves secret references using registered providers
+type Resolver struct {
+	mu        sync.RWMutex
+	providers map[string]Provider
+}
+
+// NewResolver creates a resolver with the given providers
+func NewResolver(providers ...Provider) *Resolver {
+	r := &Resolver{providers: make(map[string]Provider)}
+	for _, p := range providers {
+		r.Register(p)
+	}
+	return r
+}
+
+// NewDefaultResolver creates a resolver with the env and keychain providers
+// and an encrypted file provider backed by secretsFile
+func NewDefaultResolver(secretsFile string) *Resolver {
+	return NewResolver(
+		NewEnvProvider(),
+		NewKeychainProvider(),
+		NewEncryptedFileProvider(secretsFile, ""),
+	)
+}
+
+// Register adds or replaces a provider
+func (r *Resolver) Register(p Provider) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.providers[p.Name()] = p
+}
+
+// Providers returns the names of registered providers
+func (r *Resolver) Providers() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.providers))
+	for name := range r.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Resolve returns the secret for a secret:// URI. Values that are not
+// secret references are returned unchanged.
+func (r *Resolver) Resolve(value string) (string, error) {
+	if !IsReference(value) {
+		return value, nil
+	}
+
+	ref, err := ParseReference(value)
+	if err != nil {
+		return "", err
+	}
+
+	r.mu.RLock()
+	provider, ok := r.providers[ref.Provider]
+	r.mu.RUnlock()
+	if !ok {
+		return "", fmt.Errorf("unknown secret provider %q in %s", ref.Provider, ref)
+	}
+
+	secret, err := provider.Get(ref.Key)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", ref, err)
+	}
+
+	return secret, nil
+}
+
+// ResolveAll returns a copy of config with every secret reference replaced
+// by its value, descending into nested maps and arrays
+func (r *Resolver) ResolveAll(config map[string]interface{}) (map[string]interface{}, error) {
+	resolved, err := r.resolveValue(config)
+	if err != nil {
+		return nil, err
+	}
+	return resolved.(map[string]interface{}), nil
+}
+
+func (r *Resolver) resolveValue(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		return r.Resolve(v)
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			resolved, err := r.resolveValue(item)
+			if err != nil {
+				return nil, err
+			}
+			result[key] = resolved
+		}
+		return result, nil
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, item := range v {
+			resolved, err := r.resolveValue(item)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = resolved
+		}
+		return result, nil
+	default:
+		return value, nil
+	}
+}
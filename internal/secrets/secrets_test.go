@@ -0,0 +1,120 @@
+package secrets
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestParseReference(t *testing.T) {
+	tests := []struct {
+		uri      string
+		expected Reference
+		wantErr  bool
+	}{
+		{uri: "secret://env/TOKEN", expected: Reference{Provider: "env", Key: "TOKEN"}},
+		{uri: "secret://keychain/crew/registry", expected: Reference{Provider: "keychain", Key: "crew/registry"}},
+		{uri: "secret://env", wantErr: true},
+		{uri: "secret:///TOKEN", wantErr: true},
+		{uri: "plain-value", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		ref, err := ParseReference(tt.uri)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseReference(%q): expected error", tt.uri)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseReference(%q): unexpected error: %v", tt.uri, err)
+			continue
+		}
+		if ref != tt.expected {
+			t.Errorf("ParseReference(%q) = %+v, want %+v", tt.uri, ref, tt.expected)
+		}
+	}
+}
+
+func TestResolverResolveAll(t *testing.T) {
+	t.Setenv("CREW_TEST_TOKEN", "s3cr3t")
+
+	resolver := NewResolver(NewEnvProvider())
+	config := map[string]interface{}{
+		"registry": map[string]interface{}{
+			"url":   "https://example.com",
+			"token": "secret://env/CREW_TEST_TOKEN",
+		},
+		"list": []interface{}{"secret://env/CREW_TEST_TOKEN", 3.0},
+	}
+
+	resolved, err := resolver.ResolveAll(config)
+	if err != nil {
+		t.Fatalf("ResolveAll failed: %v", err)
+	}
+
+	registry := resolved["registry"].(map[string]interface{})
+	if registry["token"] != "s3cr3t" || registry["url"] != "https://example.com" {
+		t.Errorf("Unexpected registry values: %v", registry)
+	}
+	if resolved["list"].([]interface{})[0] != "s3cr3t" {
+		t.Errorf("Expected array entries to be resolved: %v", resolved["list"])
+	}
+
+	// The input must not be modified
+	if config["registry"].(map[string]interface{})["token"] != "secret://env/CREW_TEST_TOKEN" {
+		t.Error("ResolveAll modified its input")
+	}
+
+	if _, err := resolver.Resolve("secret://vault/token"); err == nil {
+		t.Error("Expected error for unknown provider")
+	}
+}
+
+func TestEncryptedFileProvider(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "secrets.enc")
+
+	provider := NewEncryptedFileProvider(path, "passphrase")
+	if err := provider.Set("s3-key", "AKIA123"); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+
+	value, err := NewEncryptedFileProvider(path, "passphrase").Get("s3-key")
+	if err != nil || value != "AKIA123" {
+		t.Errorf("Expected AKIA123, got %q (%v)", value, err)
+	}
+
+	if _, err := NewEncryptedFileProvider(path, "wrong").Get("s3-key"); err == nil {
+		t.Error("Expected decryption to fail with wrong passphrase")
+	}
+
+	t.Setenv(PassphraseEnv, "passphrase")
+	resolver := NewResolver(NewEncryptedFileProvider(path, ""))
+	if value, err := resolver.Resolve("secret://file/s3-key"); err != nil || value != "AKIA123" {
+		t.Errorf("Expected resolver to read file secret, got %q (%v)", value, err)
+	}
+}
+
+func TestKeychainProviderCommands(t *testing.T) {
+	var gotName string
+	var gotArgs []string
+	provider := &KeychainProvider{
+		goos: "darwin",
+		run: func(name string, args ...string) (string, error) {
+			gotName, gotArgs = name, args
+			return "pw", nil
+		},
+	}
+
+	value, err := provider.Get("crew/registry")
+	if err != nil || value != "pw" {
+		t.Fatalf("Expected pw, got %q (%v)", value, err)
+	}
+	if gotName != "security" || gotArgs[2] != "crew" || gotArgs[4] != "registry" {
+		t.Errorf("Unexpected command: %s %v", gotName, gotArgs)
+	}
+
+	if _, err := provider.Get("no-account"); err == nil {
+		t.Error("Expected error for key without account")
+	}
+}
@@ -0,0 +1,56 @@
+package semver
+
+import "testing"
+
+func FuzzParse(f *testing.F) {
+	for _, s := range []string{"1.2.3", "v0.0.0-alpha.1+build.5", "01.2.3", "1.2.3-01", "18446744073709551616.0.0", "1.2"} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		v, err := Parse(s)
+		if err != nil {
+			return
+		}
+		again, err := Parse(v.String())
+		if err != nil {
+			t.Fatalf("%q parsed as %s, which does not parse: %v", s, v, err)
+		}
+		if again.String() != v.String() || again.Compare(v) != 0 {
+			t.Fatalf("%q parsed as %s, then as %s", s, v, again)
+		}
+	})
+}
+
+// FuzzParseTolerant checks the version extraction used on tool output
+func FuzzParseTolerant(f *testing.F) {
+	for _, s := range []string{"git version 2.39.2", "go version go1.21.5 linux/amd64", "v18", "3.11", "node 99999999999999999999", "1.-"} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		v, err := ParseTolerant(s)
+		if err != nil {
+			return
+		}
+		if _, err := Parse(v.String()); err != nil {
+			t.Fatalf("%q extracted as %s, which is not a valid version: %v", s, v, err)
+		}
+	})
+}
+
+func FuzzParseRange(f *testing.F) {
+	for _, s := range []string{">=1.2.0 <2.0.0", "^1.4", "~2.1.3", "1.x || 3.*", "1.2 - 2.0", "!=1.0.0-rc.1", "*", "^0.0", ">=1.2.3-"} {
+		f.Add(s, "1.2.3")
+	}
+	f.Fuzz(func(t *testing.T, constraint, version string) {
+		r, err := ParseRange(constraint)
+		if err != nil {
+			return
+		}
+		if _, err := ParseRange(r.String()); err != nil {
+			t.Fatalf("range %q prints as %q, which does not parse: %v", constraint, r, err)
+		}
+		if v, err := Parse(version); err == nil {
+			r.Contains(v)
+		}
+	})
+}
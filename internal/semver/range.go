@@ -0,0 +1,328 @@
+package semver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// operator is a primitive comparison used by ranges
+type operator int
+
+const (
+	opEQ operator = iota
+	opNE
+	opGT
+	opGE
+	opLT
+	opLE
+)
+
+// comparator is one primitive condition. explicitPre marks bounds written
+// with a prerelease by the user, which opt that release into prereleases.
+type comparator struct {
+	op          operator
+	version     Version
+	explicitPre bool
+}
+
+// Range is a set of version constraints. Comparators separated by spaces or
+// commas must all match; sets separated by "||" are alternatives.
+//
+// Supported forms: "1.2.3", "=1.2.3", "!=1.2.3", ">1.2", ">=1.2.0",
+// "<2", "<=2.1", "^1.2.3", "~1.2.3", "1.x", "1.2.*", "*" and "1.2 - 2.0".
+// Like npm, a prerelease only matches when a comparator in the same set
+// names a prerelease of the same major.minor.patch.
+type Range struct {
+	raw  string
+	sets [][]comparator
+}
+
+// ParseRange parses a version range
+func ParseRange(s string) (Range, error) {
+	r := Range{raw: strings.TrimSpace(s)}
+	for _, alternative := range strings.Split(s, "||") {
+		set, err := parseSet(strings.TrimSpace(alternative))
+		if err != nil {
+			return Range{}, fmt.Errorf("invalid version range %q: %w", s, err)
+		}
+		r.sets = append(r.sets, set)
+	}
+	return r, nil
+}
+
+// MustParseRange is ParseRange for constants; it panics on an invalid range
+func MustParseRange(s string) Range {
+	r, err := ParseRange(s)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// Satisfies reports whether version is within the range constraint
+func Satisfies(version, constraint string) (bool, error) {
+	v, err := ParseTolerant(version)
+	if err != nil {
+		return false, err
+	}
+	r, err := ParseRange(constraint)
+	if err != nil {
+		return false, err
+	}
+	return r.Contains(v), nil
+}
+
+// String returns the range as written
+func (r Range) String() string {
+	return r.raw
+}
+
+// Contains reports whether v satisfies the range
+func (r Range) Contains(v Version) bool {
+	for _, set := range r.sets {
+		if setContains(set, v) {
+			return true
+		}
+	}
+	return false
+}
+
+func setContains(set []comparator, v Version) bool {
+	for _, c := range set {
+		if !c.matches(v) {
+			return false
+		}
+	}
+	if !v.IsPrerelease() {
+		return true
+	}
+	for _, c := range set {
+		if c.explicitPre && c.version.Major == v.Major && c.version.Minor == v.Minor && c.version.Patch == v.Patch {
+			return true
+		}
+	}
+	return false
+}
+
+func (c comparator) matches(v Version) bool {
+	cmp := v.Compare(c.version)
+	switch c.op {
+	case opEQ:
+		return cmp == 0
+	case opNE:
+		return cmp != 0
+	case opGT:
+		return cmp > 0
+	case opGE:
+		return cmp >= 0
+	case opLT:
+		return cmp < 0
+	default:
+		return cmp <= 0
+	}
+}
+
+// parseSet parses one "||" alternative
+func parseSet(s string) ([]comparator, error) {
+	if s == "" || s == "*" || s == "x" || s == "X" {
+		return []comparator{{op: opGE}}, nil
+	}
+
+	if lo, hi, ok := strings.Cut(s, " - "); ok {
+		return parseHyphen(strings.TrimSpace(lo), strings.TrimSpace(hi))
+	}
+
+	var tokens []string
+	pending := ""
+	for _, field := range strings.FieldsFunc(s, func(r rune) bool { return r == ' ' || r == ',' || r == '\t' }) {
+		// Join an operator written apart from its version, as in ">= 1.2"
+		if strings.Trim(field, "<>=!^~") == "" {
+			pending += field
+			continue
+		}
+		tokens = append(tokens, pending+field)
+		pending = ""
+	}
+	if pending != "" {
+		return nil, fmt.Errorf("operator %q without a version", pending)
+	}
+
+	var set []comparator
+	for _, token := range tokens {
+		comparators, err := parseComparator(token)
+		if err != nil {
+			return nil, err
+		}
+		set = append(set, comparators...)
+	}
+	return set, nil
+}
+
+// partial is a version that may leave minor and patch unspecified or
+// wildcarded; missing parts are -1
+type partial struct {
+	major, minor, patch int64
+	pre                 []string
+}
+
+func parsePartial(s string) (partial, error) {
+	p := partial{major: -1, minor: -1, patch: -1}
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	if i := strings.IndexByte(s, '+'); i >= 0 {
+		s = s[:i]
+	}
+	if core, pre, ok := strings.Cut(s, "-"); ok {
+		if pre == "" {
+			return p, fmt.Errorf("empty prerelease in %q", s)
+		}
+		p.pre = strings.Split(pre, ".")
+		s = core
+	}
+
+	parts := strings.Split(s, ".")
+	if len(parts) > 3 {
+		return p, fmt.Errorf("too many version parts in %q", s)
+	}
+	fields := []*int64{&p.major, &p.minor, &p.patch}
+	for i, part := range parts {
+		if part == "*" || part == "x" || part == "X" {
+			break
+		}
+		n, err := strconv.ParseInt(part, 10, 64)
+		if err != nil || n < 0 {
+			return p, fmt.Errorf("invalid version %q", s)
+		}
+		*fields[i] = n
+	}
+	if p.pre != nil && p.patch < 0 {
+		return p, fmt.Errorf("prerelease requires a full version in %q", s)
+	}
+	return p, nil
+}
+
+// floor returns the lowest version matching p
+func (p partial) floor() Version {
+	v := Version{Prerelease: p.pre}
+	if p.major > 0 {
+		v.Major = uint64(p.major)
+	}
+	if p.minor > 0 {
+		v.Minor = uint64(p.minor)
+	}
+	if p.patch > 0 {
+		v.Patch = uint64(p.patch)
+	}
+	return v
+}
+
+// ceiling returns the lowest version above everything matching a
+// wildcarded p, as a -0 prerelease so prereleases of it are excluded too
+func (p partial) ceiling() Version {
+	v := p.floor()
+	v.Prerelease = []string{"0"}
+	switch {
+	case p.minor < 0:
+		return Version{Major: v.Major + 1, Prerelease: v.Prerelease}
+	default:
+		return Version{Major: v.Major, Minor: v.Minor + 1, Prerelease: v.Prerelease}
+	}
+}
+
+func (p partial) complete() bool {
+	return p.patch >= 0
+}
+
+func parseComparator(token string) ([]comparator, error) {
+	op := strings.TrimRight(token[:len(token)-len(strings.TrimLeft(token, "<>=!^~"))], " ")
+	p, err := parsePartial(token[len(op):])
+	if err != nil {
+		return nil, err
+	}
+	explicitPre := p.pre != nil
+	floor := p.floor()
+
+	if p.major < 0 {
+		switch op {
+		case "", "=", ">=", "<=", "^", "~":
+			return []comparator{{op: opGE}}, nil
+		default:
+			return nil, fmt.Errorf("%q matches no version", token)
+		}
+	}
+
+	switch op {
+	case "", "=":
+		if p.complete() {
+			return []comparator{{op: opEQ, version: floor, explicitPre: explicitPre}}, nil
+		}
+		return []comparator{{op: opGE, version: floor}, {op: opLT, version: p.ceiling()}}, nil
+	case "!=":
+		if !p.complete() {
+			return nil, fmt.Errorf("%q needs a full version", token)
+		}
+		return []comparator{{op: opNE, version: floor, explicitPre: explicitPre}}, nil
+	case ">":
+		if p.complete() {
+			return []comparator{{op: opGT, version: floor, explicitPre: explicitPre}}, nil
+		}
+		return []comparator{{op: opGE, version: p.ceiling()}}, nil
+	case ">=":
+		return []comparator{{op: opGE, version: floor, explicitPre: explicitPre}}, nil
+	case "<":
+		if p.complete() {
+			return []comparator{{op: opLT, version: floor, explicitPre: explicitPre}}, nil
+		}
+		bound := floor
+		bound.Prerelease = []string{"0"}
+		return []comparator{{op: opLT, version: bound}}, nil
+	case "<=":
+		if p.complete() {
+			return []comparator{{op: opLE, version: floor, explicitPre: explicitPre}}, nil
+		}
+		return []comparator{{op: opLT, version: p.ceiling()}}, nil
+	case "~":
+		upper := p
+		if p.minor >= 0 {
+			upper.patch = -1
+		}
+		return []comparator{{op: opGE, version: floor, explicitPre: explicitPre}, {op: opLT, version: upper.ceiling()}}, nil
+	case "^":
+		var upper Version
+		switch {
+		case floor.Major > 0 || p.minor < 0:
+			upper = Version{Major: floor.Major + 1}
+		case floor.Minor > 0 || p.patch < 0:
+			upper = Version{Minor: floor.Minor + 1}
+		default:
+			upper = Version{Patch: floor.Patch + 1}
+		}
+		upper.Prerelease = []string{"0"}
+		return []comparator{{op: opGE, version: floor, explicitPre: explicitPre}, {op: opLT, version: upper}}, nil
+	default:
+		return nil, fmt.Errorf("unknown operator %q", op)
+	}
+}
+
+// parseHyphen expands "lo - hi" to >=lo and <=hi, where a partial hi
+// covers everything it matches
+func parseHyphen(lo, hi string) ([]comparator, error) {
+	low, err := parsePartial(lo)
+	if err != nil {
+		return nil, err
+	}
+	high, err := parsePartial(hi)
+	if err != nil {
+		return nil, err
+	}
+
+	set := []comparator{{op: opGE, version: low.floor(), explicitPre: low.pre != nil}}
+	switch {
+	case high.major < 0:
+	case high.complete():
+		set = append(set, comparator{op: opLE, version: high.floor(), explicitPre: high.pre != nil})
+	default:
+		set = append(set, comparator{op: opLT, version: high.ceiling()})
+	}
+	return set, nil
+}
@@ -0,0 +1,227 @@
+// Package semver parses and compares semantic versions (https://semver.org)
+// and evaluates version ranges such as ">=1.2.0 <2.0.0", "^1.4" or "~2.1.3".
+package semver
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Version is a parsed semantic version
+type Version struct {
+	Major      uint64
+	Minor      uint64
+	Patch      uint64
+	Prerelease []string
+	Build      string
+}
+
+var versionPattern = regexp.MustCompile(`^v?(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)` +
+	`(?:-([0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*))?(?:\+([0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*))?$`)
+
+// tolerantPattern finds the first version-like token in free text such as
+// "git version 2.39.2" or "go1.21.5"
+var tolerantPattern = regexp.MustCompile(`(\d+)(?:\.(\d+))?(?:\.(\d+))?(?:-([0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*))?`)
+
+// Parse parses a strict semantic version. A leading "v" is accepted.
+func Parse(s string) (Version, error) {
+	m := versionPattern.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return Version{}, fmt.Errorf("invalid semantic version %q", s)
+	}
+
+	var v Version
+	var err error
+	if v.Major, err = strconv.ParseUint(m[1], 10, 64); err != nil {
+		return Version{}, fmt.Errorf("invalid semantic version %q: %w", s, err)
+	}
+	if v.Minor, err = strconv.ParseUint(m[2], 10, 64); err != nil {
+		return Version{}, fmt.Errorf("invalid semantic version %q: %w", s, err)
+	}
+	if v.Patch, err = strconv.ParseUint(m[3], 10, 64); err != nil {
+		return Version{}, fmt.Errorf("invalid semantic version %q: %w", s, err)
+	}
+	if m[4] != "" {
+		v.Prerelease = strings.Split(m[4], ".")
+		if id, ok := leadingZero(v.Prerelease); ok {
+			return Version{}, fmt.Errorf("invalid semantic version %q: prerelease %q has a leading zero", s, id)
+		}
+	}
+	v.Build = m[5]
+	return v, nil
+}
+
+// MustParse is Parse for constants; it panics on an invalid version
+func MustParse(s string) Version {
+	v, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// ParseTolerant extracts a version from tool output or loose input. Missing
+// minor and patch numbers default to zero, so "v18", "3.11" and
+// "go version go1.21.5 linux/amd64" all parse.
+func ParseTolerant(s string) (Version, error) {
+	if v, err := Parse(s); err == nil {
+		return v, nil
+	}
+	m := tolerantPattern.FindStringSubmatch(s)
+	if m == nil {
+		return Version{}, fmt.Errorf("no version found in %q", s)
+	}
+
+	var v Version
+	fields := []*uint64{&v.Major, &v.Minor, &v.Patch}
+	for i, field := range fields {
+		if m[i+1] == "" {
+			continue
+		}
+		n, err := strconv.ParseUint(m[i+1], 10, 64)
+		if err != nil {
+			return Version{}, fmt.Errorf("invalid version in %q: %w", s, err)
+		}
+		*field = n
+	}
+	// A prerelease strict parsing would reject is not part of the version
+	if m[4] != "" {
+		pre := strings.Split(m[4], ".")
+		if _, invalid := leadingZero(pre); !invalid {
+			v.Prerelease = pre
+		}
+	}
+	return v, nil
+}
+
+// Valid reports whether s is a strict semantic version
+func Valid(s string) bool {
+	_, err := Parse(s)
+	return err == nil
+}
+
+// String formats v without a leading "v"
+func (v Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if len(v.Prerelease) > 0 {
+		s += "-" + strings.Join(v.Prerelease, ".")
+	}
+	if v.Build != "" {
+		s += "+" + v.Build
+	}
+	return s
+}
+
+// IsPrerelease reports whether v has prerelease identifiers
+func (v Version) IsPrerelease() bool {
+	return len(v.Prerelease) > 0
+}
+
+// Compare returns -1, 0 or 1 as v is lower than, equal to or higher than o.
+// Build metadata is ignored, and a prerelease sorts before its release.
+func (v Version) Compare(o Version) int {
+	if c := compareUint(v.Major, o.Major); c != 0 {
+		return c
+	}
+	if c := compareUint(v.Minor, o.Minor); c != 0 {
+		return c
+	}
+	if c := compareUint(v.Patch, o.Patch); c != 0 {
+		return c
+	}
+	return comparePrerelease(v.Prerelease, o.Prerelease)
+}
+
+// LessThan reports whether v < o
+func (v Version) LessThan(o Version) bool { return v.Compare(o) < 0 }
+
+// GreaterThan reports whether v > o
+func (v Version) GreaterThan(o Version) bool { return v.Compare(o) > 0 }
+
+// Equal reports whether v and o have the same precedence
+func (v Version) Equal(o Version) bool { return v.Compare(o) == 0 }
+
+// Compare parses and compares two version strings with ParseTolerant.
+// Unparseable versions sort before every valid one, and two unparseable
+// versions compare as equal only when the strings are identical.
+func Compare(a, b string) int {
+	va, errA := ParseTolerant(a)
+	vb, errB := ParseTolerant(b)
+	switch {
+	case errA != nil && errB != nil:
+		return strings.Compare(a, b)
+	case errA != nil:
+		return -1
+	case errB != nil:
+		return 1
+	}
+	return va.Compare(vb)
+}
+
+func compareUint(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// comparePrerelease orders prerelease identifiers as semver section 11 does
+func comparePrerelease(a, b []string) int {
+	switch {
+	case len(a) == 0 && len(b) == 0:
+		return 0
+	case len(a) == 0:
+		return 1
+	case len(b) == 0:
+		return -1
+	}
+
+	for i := 0; i < len(a) && i < len(b); i++ {
+		aNum, bNum := isNumeric(a[i]), isNumeric(b[i])
+		switch {
+		case aNum && bNum:
+			x, _ := strconv.ParseUint(a[i], 10, 64)
+			y, _ := strconv.ParseUint(b[i], 10, 64)
+			if c := compareUint(x, y); c != 0 {
+				return c
+			}
+		case aNum:
+			return -1
+		case bNum:
+			return 1
+		default:
+			if c := strings.Compare(a[i], b[i]); c != 0 {
+				return c
+			}
+		}
+	}
+	return compareUint(uint64(len(a)), uint64(len(b)))
+}
+
+// leadingZero returns the first numeric identifier of prerelease with a
+// leading zero, which semantic versions forbid
+func leadingZero(prerelease []string) (string, bool) {
+	for _, id := range prerelease {
+		if len(id) > 1 && id[0] == '0' && isNumeric(id) {
+			return id, true
+		}
+	}
+	return "", false
+}
+
+func isNumeric(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
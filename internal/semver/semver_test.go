@@ -0,0 +1,137 @@
+package semver
+
+import (
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	valid := map[string]string{
+		"1.2.3":                "1.2.3",
+		"v1.2.3":               "1.2.3",
+		"1.0.0-alpha.1":        "1.0.0-alpha.1",
+		"1.0.0-rc.1+build.5":   "1.0.0-rc.1+build.5",
+		"10.20.30":             "10.20.30",
+		"0.0.0-0":              "0.0.0-0",
+		" 2.0.0 ":              "2.0.0",
+		"1.0.0+20260101.sha.1": "1.0.0+20260101.sha.1",
+	}
+	for input, expected := range valid {
+		v, err := Parse(input)
+		if err != nil {
+			t.Errorf("Parse(%q) failed: %v", input, err)
+			continue
+		}
+		if v.String() != expected {
+			t.Errorf("Parse(%q) = %s, expected %s", input, v, expected)
+		}
+	}
+
+	for _, input := range []string{"", "1", "1.2", "1.2.3.4", "01.2.3", "1.2.3-01", "1.2.3-", "latest"} {
+		if _, err := Parse(input); err == nil {
+			t.Errorf("Expected Parse(%q) to fail", input)
+		}
+	}
+}
+
+func TestParseTolerant(t *testing.T) {
+	tests := map[string]string{
+		"v18.19.0":                           "18.19.0",
+		"go version go1.21.5 linux/amd64":    "1.21.5",
+		"git version 2.39.2 (Apple Git-143)": "2.39.2",
+		"Python 3.11":                        "3.11.0",
+		"v20":                                "20.0.0",
+	}
+	for input, expected := range tests {
+		v, err := ParseTolerant(input)
+		if err != nil {
+			t.Errorf("ParseTolerant(%q) failed: %v", input, err)
+			continue
+		}
+		if v.String() != expected {
+			t.Errorf("ParseTolerant(%q) = %s, expected %s", input, v, expected)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	// Ordered from lowest to highest, per the semver specification example
+	ordered := []string{
+		"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta",
+		"1.0.0-beta.2", "1.0.0-beta.11", "1.0.0-rc.1", "1.0.0", "1.0.1", "1.2.0", "1.10.0", "2.0.0",
+	}
+	for i := 0; i < len(ordered)-1; i++ {
+		a, b := MustParse(ordered[i]), MustParse(ordered[i+1])
+		if !a.LessThan(b) || !b.GreaterThan(a) {
+			t.Errorf("Expected %s < %s", a, b)
+		}
+	}
+
+	if !MustParse("1.0.0+build.1").Equal(MustParse("1.0.0+build.2")) {
+		t.Error("Expected build metadata to be ignored")
+	}
+	if Compare("1.10.0", "1.9.0") != 1 {
+		t.Error("Expected numeric rather than string comparison")
+	}
+	if Compare("garbage", "0.0.1") != -1 {
+		t.Error("Expected an invalid version to sort first")
+	}
+}
+
+func TestRange(t *testing.T) {
+	tests := []struct {
+		constraint string
+		matches    []string
+		rejects    []string
+	}{
+		{">=1.2.0 <2.0.0", []string{"1.2.0", "1.9.9"}, []string{"1.1.9", "2.0.0", "2.0.0-beta"}},
+		{">= 1.2, < 2", []string{"1.2.0", "1.99.0"}, []string{"2.0.0"}},
+		{"^1.2.3", []string{"1.2.3", "1.9.0"}, []string{"1.2.2", "2.0.0", "1.3.0-beta"}},
+		{"^0.2.3", []string{"0.2.3", "0.2.9"}, []string{"0.3.0"}},
+		{"^0.0.3", []string{"0.0.3"}, []string{"0.0.4"}},
+		{"~1.2.3", []string{"1.2.3", "1.2.9"}, []string{"1.3.0"}},
+		{"~1", []string{"1.0.0", "1.9.0"}, []string{"2.0.0"}},
+		{"1.x", []string{"1.0.0", "1.5.2"}, []string{"0.9.0", "2.0.0"}},
+		{"1.2.*", []string{"1.2.0", "1.2.7"}, []string{"1.3.0"}},
+		{"*", []string{"0.0.1", "99.0.0"}, []string{"1.0.0-rc.1"}},
+		{">1.2", []string{"1.3.0"}, []string{"1.2.9"}},
+		{"<=1.2", []string{"1.2.9"}, []string{"1.3.0"}},
+		{"1.2 - 2.0", []string{"1.2.0", "2.0.5"}, []string{"1.1.0", "2.1.0"}},
+		{"1.2.3 - 2.3.4", []string{"2.3.4"}, []string{"2.3.5"}},
+		{"<1.0.0 || >=2.0.0", []string{"0.5.0", "2.1.0"}, []string{"1.5.0"}},
+		{"!=1.5.0", []string{"1.4.0"}, []string{"1.5.0"}},
+		{">=1.0.0-beta.2 <2.0.0", []string{"1.0.0-beta.3", "1.0.0"}, []string{"1.0.0-beta.1", "1.1.0-beta.1"}},
+	}
+	for _, tt := range tests {
+		r, err := ParseRange(tt.constraint)
+		if err != nil {
+			t.Errorf("ParseRange(%q) failed: %v", tt.constraint, err)
+			continue
+		}
+		for _, v := range tt.matches {
+			if !r.Contains(MustParse(v)) {
+				t.Errorf("Expected %s to satisfy %q", v, tt.constraint)
+			}
+		}
+		for _, v := range tt.rejects {
+			if r.Contains(MustParse(v)) {
+				t.Errorf("Expected %s not to satisfy %q", v, tt.constraint)
+			}
+		}
+	}
+
+	for _, constraint := range []string{">=", "1.2.3.4", "?1.0", ">=abc", "!=1.x"} {
+		if _, err := ParseRange(constraint); err == nil {
+			t.Errorf("Expected ParseRange(%q) to fail", constraint)
+		}
+	}
+}
+
+func TestSatisfies(t *testing.T) {
+	ok, err := Satisfies("v18.19.0", ">=18")
+	if err != nil || !ok {
+		t.Errorf("Expected v18.19.0 to satisfy >=18, got %v, %v", ok, err)
+	}
+	if _, err := Satisfies("not a version", ">=1"); err == nil {
+		t.Error("Expected an error for an unparseable version")
+	}
+}
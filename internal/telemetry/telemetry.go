@@ -0,0 +1,290 @@
+// Package telemetry records anonymous command usage for users who opt in.
+// Events are kept in a local JSON lines file first; uploading them to an
+// endpoint is a separate, optional step.
+package telemetry
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/redact"
+	"github.com/jonwraymond/claude-code-super-crew/internal/retry"
+)
+
+// BatchSize is the number of events sent per upload request
+const BatchSize = 50
+
+// Event is one recorded command invocation. It never contains arguments,
+// paths or error messages, only the command name and an error category.
+type Event struct {
+	Time          time.Time `json:"time"`
+	Command       string    `json:"command"`
+	DurationMs    int64     `json:"duration_ms"`
+	Success       bool      `json:"success"`
+	ErrorCategory string    `json:"error_category,omitempty"`
+	Version       string    `json:"version"`
+	OS            string    `json:"os"`
+	Arch          string    `json:"arch"`
+	InstallID     string    `json:"install_id"`
+}
+
+// state tracks the anonymous install ID and how many events were uploaded
+type state struct {
+	InstallID  string    `json:"install_id"`
+	Uploaded   int       `json:"uploaded"`
+	LastUpload time.Time `json:"last_upload,omitempty"`
+}
+
+// Store keeps telemetry events and upload state in a directory
+type Store struct {
+	dir string
+}
+
+// NewStore creates a store rooted at dir
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// EventsPath returns the JSON lines file holding recorded events
+func (s *Store) EventsPath() string {
+	return filepath.Join(s.dir, "events.jsonl")
+}
+
+func (s *Store) statePath() string {
+	return filepath.Join(s.dir, "state.json")
+}
+
+// loadState reads the upload state, generating an install ID on first use
+func (s *Store) loadState() (state, error) {
+	var st state
+	data, err := os.ReadFile(s.statePath())
+	if err != nil && !os.IsNotExist(err) {
+		return st, fmt.Errorf("failed to read telemetry state: %w", err)
+	}
+	if err == nil {
+		if err := json.Unmarshal(data, &st); err != nil {
+			return st, fmt.Errorf("invalid telemetry state: %w", err)
+		}
+	}
+	if st.InstallID == "" {
+		id := make([]byte, 8)
+		if _, err := rand.Read(id); err != nil {
+			return st, fmt.Errorf("failed to generate install id: %w", err)
+		}
+		st.InstallID = hex.EncodeToString(id)
+		if err := s.saveState(st); err != nil {
+			return st, err
+		}
+	}
+	return st, nil
+}
+
+func (s *Store) saveState(st state) error {
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create telemetry directory: %w", err)
+	}
+	data, err := json.MarshalIndent(st, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.statePath(), data, 0644)
+}
+
+// InstallID returns the random identifier attached to every event
+func (s *Store) InstallID() (string, error) {
+	st, err := s.loadState()
+	return st.InstallID, err
+}
+
+// Record appends event to the local file, filling in the install ID. The
+// command passes through the redactor, since plugin commands are named by
+// their authors.
+func (s *Store) Record(event Event) error {
+	st, err := s.loadState()
+	if err != nil {
+		return err
+	}
+	event.InstallID = st.InstallID
+	event.Command = redact.String(event.Command)
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode telemetry event: %w", err)
+	}
+	file, err := os.OpenFile(s.EventsPath(), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open telemetry file: %w", err)
+	}
+	defer file.Close()
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write telemetry event: %w", err)
+	}
+	return nil
+}
+
+// Events returns every recorded event, oldest first. Lines that cannot be
+// decoded are skipped.
+func (s *Store) Events() ([]Event, error) {
+	file, err := os.Open(s.EventsPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to open telemetry file: %w", err)
+	}
+	defer file.Close()
+
+	var events []Event
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var event Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err == nil {
+			events = append(events, event)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read telemetry file: %w", err)
+	}
+	return events, nil
+}
+
+// Pending returns the events not yet uploaded
+func (s *Store) Pending() ([]Event, error) {
+	events, err := s.Events()
+	if err != nil {
+		return nil, err
+	}
+	st, err := s.loadState()
+	if err != nil {
+		return nil, err
+	}
+	if st.Uploaded > len(events) {
+		st.Uploaded = len(events)
+	}
+	return events[st.Uploaded:], nil
+}
+
+// Upload posts pending events to endpoint in batches of BatchSize and
+// returns how many were accepted. Progress is saved after each batch, so
+// a failed upload resumes where it stopped.
+func (s *Store) Upload(ctx context.Context, endpoint string, client *http.Client) (int, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	pending, err := s.Pending()
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for start := 0; start < len(pending); start += BatchSize {
+		end := start + BatchSize
+		if end > len(pending) {
+			end = len(pending)
+		}
+		if err := postBatch(ctx, client, endpoint, pending[start:end]); err != nil {
+			return sent, err
+		}
+		sent += end - start
+
+		st, err := s.loadState()
+		if err != nil {
+			return sent, err
+		}
+		st.Uploaded += end - start
+		st.LastUpload = time.Now().UTC()
+		if err := s.saveState(st); err != nil {
+			return sent, err
+		}
+	}
+	return sent, nil
+}
+
+// postBatch sends one batch as {"events": [...]}
+func postBatch(ctx context.Context, client *http.Client, endpoint string, batch []Event) error {
+	body, err := json.Marshal(map[string][]Event{"events": batch})
+	if err != nil {
+		return err
+	}
+	return retry.Do(ctx, retry.Network, func() error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+		if err != nil {
+			return retry.Permanent(fmt.Errorf("invalid telemetry endpoint: %w", err))
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return fmt.Errorf("telemetry upload failed: %w", err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			err := fmt.Errorf("telemetry upload failed: %s", resp.Status)
+			if retry.RetryableStatus(resp.StatusCode) {
+				return retry.Retryable(err)
+			}
+			return err
+		}
+		return nil
+	})
+}
+
+// LastUpload returns when events were last uploaded, or the zero time
+func (s *Store) LastUpload() (time.Time, error) {
+	st, err := s.loadState()
+	return st.LastUpload, err
+}
+
+// Purge deletes recorded events and upload state, including the install ID
+func (s *Store) Purge() error {
+	if err := os.RemoveAll(s.dir); err != nil {
+		return fmt.Errorf("failed to remove telemetry data: %w", err)
+	}
+	return nil
+}
+
+// Export writes events to w as JSON lines ("json") or CSV ("csv")
+func Export(w io.Writer, events []Event, format string) error {
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(w)
+		for _, event := range events {
+			if err := encoder.Encode(event); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "csv":
+		writer := csv.NewWriter(w)
+		writer.Write([]string{"time", "command", "duration_ms", "success", "error_category", "version", "os", "arch", "install_id"})
+		for _, event := range events {
+			writer.Write([]string{
+				event.Time.UTC().Format(time.RFC3339),
+				event.Command,
+				strconv.FormatInt(event.DurationMs, 10),
+				strconv.FormatBool(event.Success),
+				event.ErrorCategory,
+				event.Version,
+				event.OS,
+				event.Arch,
+				event.InstallID,
+			})
+		}
+		writer.Flush()
+		return writer.Error()
+	default:
+		return fmt.Errorf("unsupported export format %q: must be json or csv", format)
+	}
+}
@@ -0,0 +1,97 @@
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRecordAndUpload(t *testing.T) {
+	store := NewStore(t.TempDir())
+	for i := 0; i < BatchSize+5; i++ {
+		if err := store.Record(Event{Time: time.Now(), Command: "crew status", Success: true}); err != nil {
+			t.Fatalf("Record failed: %v", err)
+		}
+	}
+
+	events, err := store.Events()
+	if err != nil {
+		t.Fatalf("Events failed: %v", err)
+	}
+	if len(events) != BatchSize+5 {
+		t.Fatalf("Expected %d events, got %d", BatchSize+5, len(events))
+	}
+	if events[0].InstallID == "" || events[0].InstallID != events[len(events)-1].InstallID {
+		t.Errorf("Expected a stable install ID, got %q and %q", events[0].InstallID, events[len(events)-1].InstallID)
+	}
+
+	var batches []int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Events []Event `json:"events"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("Invalid upload body: %v", err)
+		}
+		batches = append(batches, len(body.Events))
+	}))
+	defer server.Close()
+
+	sent, err := store.Upload(context.Background(), server.URL, server.Client())
+	if err != nil {
+		t.Fatalf("Upload failed: %v", err)
+	}
+	if sent != BatchSize+5 || len(batches) != 2 || batches[0] != BatchSize || batches[1] != 5 {
+		t.Errorf("Unexpected upload: sent %d in batches %v", sent, batches)
+	}
+
+	pending, err := store.Pending()
+	if err != nil {
+		t.Fatalf("Pending failed: %v", err)
+	}
+	if len(pending) != 0 {
+		t.Errorf("Expected no pending events after upload, got %d", len(pending))
+	}
+}
+
+func TestUploadFailureKeepsEvents(t *testing.T) {
+	store := NewStore(t.TempDir())
+	if err := store.Record(Event{Command: "crew install"}); err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	if _, err := store.Upload(context.Background(), server.URL, server.Client()); err == nil {
+		t.Fatal("Expected an error for a failed upload")
+	}
+	pending, _ := store.Pending()
+	if len(pending) != 1 {
+		t.Errorf("Expected the event to stay pending, got %d", len(pending))
+	}
+}
+
+func TestExport(t *testing.T) {
+	events := []Event{{Time: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC), Command: "crew update", DurationMs: 1200, ErrorCategory: "network"}}
+
+	var out bytes.Buffer
+	if err := Export(&out, events, "csv"); err != nil {
+		t.Fatalf("CSV export failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[1], "2026-01-02T03:04:05Z,crew update,1200,false,network") {
+		t.Errorf("Unexpected CSV export: %q", out.String())
+	}
+
+	if err := Export(&out, events, "xml"); err == nil {
+		t.Error("Expected an error for an unsupported format")
+	}
+}
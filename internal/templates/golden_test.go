@@ -0,0 +1,62 @@
+package templates
+
+import (
+	"flag"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+func checkGolden(t *testing.T, path, got string) {
+	t.Helper()
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v (run go test -update to create it)", err)
+	}
+	if got != string(want) {
+		t.Errorf("rendered output differs from %s:\n%s", path, got)
+	}
+}
+
+// TestBuiltinTemplatesMatchGoldenFiles renders each built-in template and
+// compares the list of files it writes, and every file rendered from a
+// .tmpl, with testdata/<template>
+func TestBuiltinTemplatesMatchGoldenFiles(t *testing.T) {
+	list, err := List("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tmpl := range list {
+		files, err := tmpl.Render(Data{ProjectName: "demo", ProjectDir: "/src/demo"})
+		if err != nil {
+			t.Fatalf("%s: %v", tmpl.Name, err)
+		}
+
+		dir := filepath.Join("testdata", tmpl.Name)
+		var paths []string
+		for _, f := range files {
+			path := filepath.ToSlash(f.Path)
+			paths = append(paths, path)
+
+			source := strings.TrimPrefix(path, ".") + Suffix
+			if _, err := fs.Stat(tmpl.fsys, source); err != nil {
+				continue
+			}
+			checkGolden(t, filepath.Join(dir, filepath.FromSlash(path)+".golden"), string(f.Content))
+		}
+		checkGolden(t, filepath.Join(dir, "files.golden"), strings.Join(paths, "\n")+"\n")
+	}
+}
@@ -0,0 +1,211 @@
+// Package templates holds the project templates 'crew init' bootstraps a
+// new project from. A template is a directory with a template.yaml
+// manifest; its claude/ tree becomes the project's .claude directory and
+// any other file is written to the project root, e.g. CLAUDE.md. Files
+// ending in .tmpl are rendered with text/template and lose the suffix.
+//
+// Templates ship with crew and can be added or replaced by placing a
+// directory of the same shape in the user templates directory.
+package templates
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ManifestFile describes a template
+const ManifestFile = "template.yaml"
+
+// Suffix marks files rendered with text/template
+const Suffix = ".tmpl"
+
+// BuiltinSource is the Source of the templates that ship with crew
+const BuiltinSource = "builtin"
+
+//go:embed builtin
+var builtinFS embed.FS
+
+// Template is a project template
+type Template struct {
+	Name        string   `yaml:"name" json:"name"`
+	Description string   `yaml:"description" json:"description"`
+	Specialists []string `yaml:"specialists" json:"specialists"`
+	// Source is BuiltinSource or the directory of a user template
+	Source string `yaml:"-" json:"source"`
+
+	fsys fs.FS
+}
+
+// Data is what .tmpl files are rendered with
+type Data struct {
+	ProjectName string
+	ProjectDir  string
+	Template    string
+	Specialists []string
+}
+
+// File is a rendered template file
+type File struct {
+	// Path is relative to the project directory
+	Path    string
+	Content []byte
+}
+
+// List returns the built-in templates and those in userDir, sorted by
+// name. A user template replaces the built-in one of the same name.
+func List(userDir string) ([]*Template, error) {
+	byName := make(map[string]*Template)
+
+	builtin, err := fs.Sub(builtinFS, "builtin")
+	if err != nil {
+		return nil, err
+	}
+	if err := collect(builtin, BuiltinSource, byName); err != nil {
+		return nil, err
+	}
+	if userDir != "" {
+		if _, err := os.Stat(userDir); err == nil {
+			if err := collect(os.DirFS(userDir), userDir, byName); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	list := make([]*Template, 0, len(byName))
+	for _, t := range byName {
+		list = append(list, t)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list, nil
+}
+
+// Find returns the template called name
+func Find(userDir, name string) (*Template, error) {
+	list, err := List(userDir)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, t := range list {
+		if t.Name == name {
+			return t, nil
+		}
+		names = append(names, t.Name)
+	}
+	return nil, fmt.Errorf("unknown template %q (available: %s)", name, strings.Join(names, ", "))
+}
+
+// collect loads every template directory in fsys into byName
+func collect(fsys fs.FS, source string, byName map[string]*Template) error {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		sub, err := fs.Sub(fsys, entry.Name())
+		if err != nil {
+			return err
+		}
+		t := &Template{Name: entry.Name(), fsys: sub, Source: source}
+		if source != BuiltinSource {
+			t.Source = filepath.Join(source, entry.Name())
+		}
+		data, err := fs.ReadFile(sub, ManifestFile)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		if err == nil {
+			if err := yaml.Unmarshal(data, t); err != nil {
+				return fmt.Errorf("invalid %s of template %s: %w", ManifestFile, entry.Name(), err)
+			}
+		}
+		// The directory name is the name the template is selected by
+		t.Name = entry.Name()
+		byName[t.Name] = t
+	}
+	return nil
+}
+
+// Digest identifies the content of the template: it changes whenever a
+// file of the template does, so it can key a cache of rendered files
+func (t *Template) Digest() (string, error) {
+	h := sha256.New()
+	err := fs.WalkDir(t.fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		content, err := fs.ReadFile(t.fsys, name)
+		if err != nil {
+			return err
+		}
+		sum := sha256.Sum256(content)
+		fmt.Fprintf(h, "%s %x\n", name, sum)
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// Render returns the files of the template rendered with data, sorted by
+// path
+func (t *Template) Render(data Data) ([]File, error) {
+	data.Template = t.Name
+	if data.Specialists == nil {
+		data.Specialists = t.Specialists
+	}
+
+	var files []File
+	err := fs.WalkDir(t.fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || name == ManifestFile {
+			return nil
+		}
+		content, err := fs.ReadFile(t.fsys, name)
+		if err != nil {
+			return err
+		}
+
+		target := name
+		if strings.HasSuffix(name, Suffix) {
+			target = strings.TrimSuffix(name, Suffix)
+			tmpl, err := template.New(name).Option("missingkey=error").Parse(string(content))
+			if err != nil {
+				return fmt.Errorf("template %s: %w", name, err)
+			}
+			var buf bytes.Buffer
+			if err := tmpl.Execute(&buf, data); err != nil {
+				return fmt.Errorf("template %s: %w", name, err)
+			}
+			content = buf.Bytes()
+		}
+		if target == "claude" || strings.HasPrefix(target, "claude/") {
+			target = "." + target
+		}
+		files = append(files, File{Path: filepath.FromSlash(path.Clean(target)), Content: content})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+	return files, nil
+}
@@ -0,0 +1,94 @@
+package templates
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBuiltinTemplatesRender(t *testing.T) {
+	list, err := List("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, tmpl := range list {
+		names = append(names, tmpl.Name)
+	}
+	if got := strings.Join(names, ","); got != "go-cli,library,web-service" {
+		t.Fatalf("Expected the three built-in templates, got %s", got)
+	}
+
+	for _, tmpl := range list {
+		if tmpl.Description == "" || len(tmpl.Specialists) == 0 {
+			t.Errorf("%s: manifest should describe the template and suggest specialists", tmpl.Name)
+		}
+		files, err := tmpl.Render(Data{ProjectName: "demo", ProjectDir: "/src/demo"})
+		if err != nil {
+			t.Fatalf("%s: %v", tmpl.Name, err)
+		}
+
+		byPath := make(map[string]string)
+		for _, f := range files {
+			byPath[filepath.ToSlash(f.Path)] = string(f.Content)
+		}
+		orchestrator := byPath[".claude/agents/orchestrator-specialist.md"]
+		if !strings.Contains(orchestrator, "demo") || !strings.Contains(orchestrator, "Completion Verification Process") {
+			t.Errorf("%s: orchestrator not rendered as expected:\n%s", tmpl.Name, orchestrator)
+		}
+		if !strings.HasPrefix(byPath["CLAUDE.md"], "# demo") {
+			t.Errorf("%s: CLAUDE.md skeleton missing or not rendered", tmpl.Name)
+		}
+		for _, specialist := range tmpl.Specialists {
+			if _, ok := byPath[".claude/agents/"+specialist+".md"]; !ok {
+				t.Errorf("%s: suggested specialist %s has no agent file", tmpl.Name, specialist)
+			}
+		}
+		shadows := 0
+		for path := range byPath {
+			if strings.HasPrefix(path, ".claude/commands/shadows/") {
+				shadows++
+			}
+			if strings.HasSuffix(path, Suffix) || strings.HasSuffix(path, ManifestFile) {
+				t.Errorf("%s: %s should not be written to the project", tmpl.Name, path)
+			}
+		}
+		if shadows == 0 {
+			t.Errorf("%s: expected at least one shadow command", tmpl.Name)
+		}
+	}
+}
+
+func TestUserTemplateReplacesBuiltin(t *testing.T) {
+	userDir := t.TempDir()
+	dir := filepath.Join(userDir, "go-cli", "claude")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(userDir, "go-cli", ManifestFile), []byte("description: ours\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "notes.md.tmpl"), []byte("{{.ProjectName}} via {{.Template}}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl, err := Find(userDir, "go-cli")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tmpl.Description != "ours" || tmpl.Source != filepath.Join(userDir, "go-cli") {
+		t.Errorf("Expected the user template, got %+v", tmpl)
+	}
+	files, err := tmpl.Render(Data{ProjectName: "demo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 || files[0].Path != filepath.Join(".claude", "notes.md") || string(files[0].Content) != "demo via go-cli" {
+		t.Errorf("Unexpected files: %+v", files)
+	}
+
+	if _, err := Find(userDir, "missing"); err == nil || !strings.Contains(err.Error(), "library") {
+		t.Errorf("Expected an error listing the available templates, got %v", err)
+	}
+}
@@ -0,0 +1,183 @@
+// Package testfixtures points tests at the fixture component set under
+// test/fixtures: a few small, stable payloads for the core, commands and
+// agents components, and manifest.json recording the size and SHA-256 of
+// every file they install. Tests install from the fixtures and assert the
+// exact files, hashes and integrity results instead of whatever the real
+// SuperCrew payload holds today.
+//
+// After changing a fixture, regenerate the manifest with
+//
+//	go test ./internal/testfixtures -update
+package testfixtures
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/core"
+	"github.com/jonwraymond/claude-code-super-crew/internal/fileops"
+)
+
+// ManifestFile is the name of the manifest in Dir
+const ManifestFile = "manifest.json"
+
+// Components are the components the fixtures provide, in installation order
+var Components = []string{"core", "commands", "agents"}
+
+// File is a fixture file and where its component installs it
+type File struct {
+	// Source is the path of the fixture, relative to Dir
+	Source string `json:"source"`
+	// Target is the installed path, relative to the install directory
+	Target string `json:"target"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// Manifest lists the files of each fixture component
+type Manifest struct {
+	Components map[string][]File `json:"components"`
+}
+
+// Dir returns the absolute path of test/fixtures
+func Dir() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(file), "..", "..", "test", "fixtures")
+}
+
+// NewRegistry returns a component registry whose built-in components
+// install from the fixtures
+func NewRegistry() (*core.EnhancedComponentRegistry, error) {
+	registry := core.NewEnhancedComponentRegistry(Dir())
+	if err := registry.DiscoverComponents(); err != nil {
+		return nil, fmt.Errorf("failed to discover fixture components: %w", err)
+	}
+	return registry, nil
+}
+
+// LoadManifest reads the manifest of the fixtures
+func LoadManifest() (*Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(Dir(), ManifestFile))
+	if err != nil {
+		return nil, err
+	}
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", ManifestFile, err)
+	}
+	return &m, nil
+}
+
+// BuildManifest describes the fixtures as the registry's components see
+// them now, which is what LoadManifest should return
+func BuildManifest(registry *core.EnhancedComponentRegistry) (*Manifest, error) {
+	const installDir = "/install"
+	dir := Dir()
+	m := &Manifest{Components: make(map[string][]File)}
+	for _, name := range Components {
+		comp, err := registry.GetComponentInstance(name, installDir)
+		if err != nil {
+			return nil, err
+		}
+		var files []File
+		for _, pair := range comp.GetFilesToInstall() {
+			source, err := filepath.Rel(dir, pair.Source)
+			if err != nil {
+				return nil, err
+			}
+			target, err := filepath.Rel(installDir, pair.Target)
+			if err != nil {
+				return nil, err
+			}
+			info, err := os.Stat(pair.Source)
+			if err != nil {
+				return nil, err
+			}
+			hash, err := fileops.HashFile(pair.Source)
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, File{
+				Source: filepath.ToSlash(source),
+				Target: filepath.ToSlash(target),
+				Size:   info.Size(),
+				SHA256: hash,
+			})
+		}
+		sort.Slice(files, func(i, j int) bool { return files[i].Target < files[j].Target })
+		m.Components[name] = files
+	}
+	return m, nil
+}
+
+// Write saves the manifest to Dir
+func (m *Manifest) Write() error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(Dir(), ManifestFile), append(data, '\n'), 0644)
+}
+
+// Files returns the files of the named components, or of all of them,
+// in installation order
+func (m *Manifest) Files(components ...string) []File {
+	if len(components) == 0 {
+		components = Components
+	}
+	var files []File
+	for _, name := range components {
+		files = append(files, m.Components[name]...)
+	}
+	return files
+}
+
+// Size returns the total size of files
+func Size(files []File) int64 {
+	var size int64
+	for _, f := range files {
+		size += f.Size
+	}
+	return size
+}
+
+// Verify checks that installDir holds each of files with its recorded
+// size and hash, reporting every mismatch
+func Verify(installDir string, files []File) error {
+	var errs []error
+	for _, f := range files {
+		path := filepath.Join(installDir, filepath.FromSlash(f.Target))
+		info, err := os.Stat(path)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if info.Size() != f.Size {
+			errs = append(errs, fmt.Errorf("%s: size %d, want %d", f.Target, info.Size(), f.Size))
+			continue
+		}
+		if hash, err := fileops.HashFile(path); err != nil {
+			errs = append(errs, err)
+		} else if hash != f.SHA256 {
+			errs = append(errs, fmt.Errorf("%s: sha256 %s, want %s", f.Target, hash, f.SHA256))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Absent checks that none of files is left in installDir
+func Absent(installDir string, files []File) error {
+	var errs []error
+	for _, f := range files {
+		path := filepath.Join(installDir, filepath.FromSlash(f.Target))
+		if _, err := os.Lstat(path); !errors.Is(err, os.ErrNotExist) {
+			errs = append(errs, fmt.Errorf("%s: still installed", f.Target))
+		}
+	}
+	return errors.Join(errs...)
+}
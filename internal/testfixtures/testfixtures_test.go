@@ -0,0 +1,102 @@
+package testfixtures
+
+import (
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/metadata"
+)
+
+var update = flag.Bool("update", false, "rewrite manifest.json from the fixtures")
+
+func TestManifestMatchesFixtures(t *testing.T) {
+	registry, err := NewRegistry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	built, err := BuildManifest(registry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *update {
+		if err := built.Write(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	m, err := LoadManifest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(m, built) {
+		t.Errorf("%s is stale; run go test ./internal/testfixtures -update", ManifestFile)
+	}
+	for _, name := range Components {
+		if len(m.Components[name]) == 0 {
+			t.Errorf("fixture component %s installs no files", name)
+		}
+	}
+}
+
+func TestInstallFixtures(t *testing.T) {
+	registry, err := NewRegistry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := LoadManifest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	installDir := filepath.Join(t.TempDir(), ".claude")
+	if err := Absent(installDir, m.Files()); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range Components {
+		comp, err := registry.GetComponentInstance(name, installDir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := comp.Install(context.Background(), installDir, map[string]interface{}{}); err != nil {
+			t.Fatalf("install %s: %v", name, err)
+		}
+	}
+	if err := Verify(installDir, m.Files()); err != nil {
+		t.Fatal(err)
+	}
+
+	// Uninstall removes what integrity tracking recorded, so every
+	// fixture file has to be tracked under its component
+	mm := metadata.NewMetadataManager(installDir)
+	integrity, err := mm.CheckFileIntegrity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range Components {
+		for _, f := range m.Files(name) {
+			tracked, ok := integrity.FileHashes[filepath.FromSlash(f.Target)]
+			if !ok || tracked.CurrentHash != f.SHA256 || tracked.Status != "clean" {
+				t.Errorf("%s tracked as %+v", f.Target, tracked)
+			}
+		}
+	}
+
+	rules := filepath.Join(installDir, "RULES.md")
+	if err := os.WriteFile(rules, []byte("edited\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := Verify(installDir, m.Files("core")); err == nil {
+		t.Error("Verify accepted an edited RULES.md")
+	}
+	if integrity, err = mm.CheckFileIntegrity(); err != nil {
+		t.Fatal(err)
+	}
+	if integrity.ModifiedFiles != 1 || integrity.FileHashes["RULES.md"].Status != "modified" {
+		t.Errorf("integrity after editing RULES.md: %d modified, RULES.md %q",
+			integrity.ModifiedFiles, integrity.FileHashes["RULES.md"].Status)
+	}
+}
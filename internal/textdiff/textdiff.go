@@ -0,0 +1,212 @@
+// Package textdiff computes line diffs between two texts and renders them
+// in unified format.
+package textdiff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Op is what a diff line does
+type Op int
+
+const (
+	Equal Op = iota
+	Delete
+	Insert
+)
+
+// Line is one line of a diff, without its line ending
+type Line struct {
+	Op   Op
+	Text string
+}
+
+// Stats counts the changed lines of a diff
+type Stats struct {
+	Added   int
+	Removed int
+}
+
+// Lines diffs a and b line by line. The result is a shortest edit script:
+// every line of a and b appears once, deletions before insertions.
+func Lines(a, b string) []Line {
+	x, y := splitLines(a), splitLines(b)
+
+	// Common prefix and suffix keep the table small for typical edits
+	prefix := 0
+	for prefix < len(x) && prefix < len(y) && x[prefix] == y[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(x)-prefix && suffix < len(y)-prefix && x[len(x)-1-suffix] == y[len(y)-1-suffix] {
+		suffix++
+	}
+
+	var diff []Line
+	for _, line := range x[:prefix] {
+		diff = append(diff, Line{Equal, line})
+	}
+	diff = append(diff, lcs(x[prefix:len(x)-suffix], y[prefix:len(y)-suffix])...)
+	for _, line := range x[len(x)-suffix:] {
+		diff = append(diff, Line{Equal, line})
+	}
+	return diff
+}
+
+// lcs diffs x and y through their longest common subsequence
+func lcs(x, y []string) []Line {
+	n, m := len(x), len(y)
+	// length[i][j] is the LCS length of x[i:] and y[j:]
+	length := make([][]int, n+1)
+	for i := range length {
+		length[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if x[i] == y[j] {
+				length[i][j] = length[i+1][j+1] + 1
+			} else {
+				length[i][j] = max(length[i+1][j], length[i][j+1])
+			}
+		}
+	}
+
+	var diff []Line
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case x[i] == y[j]:
+			diff = append(diff, Line{Equal, x[i]})
+			i++
+			j++
+		case length[i+1][j] >= length[i][j+1]:
+			diff = append(diff, Line{Delete, x[i]})
+			i++
+		default:
+			diff = append(diff, Line{Insert, y[j]})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		diff = append(diff, Line{Delete, x[i]})
+	}
+	for ; j < m; j++ {
+		diff = append(diff, Line{Insert, y[j]})
+	}
+	return diff
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	lines := strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSuffix(line, "\r")
+	}
+	return lines
+}
+
+// Count returns the added and removed lines of diff
+func Count(diff []Line) Stats {
+	var stats Stats
+	for _, line := range diff {
+		switch line.Op {
+		case Insert:
+			stats.Added++
+		case Delete:
+			stats.Removed++
+		}
+	}
+	return stats
+}
+
+// Unified renders the changes from a to b as a unified diff with context
+// lines around each change. It returns "" when the texts have the same
+// lines.
+func Unified(fromName, toName, a, b string, context int) string {
+	diff := Lines(a, b)
+	if stats := Count(diff); stats.Added == 0 && stats.Removed == 0 {
+		return ""
+	}
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "--- %s\n+++ %s\n", fromName, toName)
+
+	// aLine and bLine are the 1-based numbers of the next line of a and b
+	aLine, bLine := 1, 1
+	for start := 0; start < len(diff); {
+		// Find the next change
+		first := start
+		for first < len(diff) && diff[first].Op == Equal {
+			first++
+			aLine++
+			bLine++
+		}
+		if first == len(diff) {
+			break
+		}
+
+		// Extend the hunk until a run of more than 2*context equal lines
+		end := first
+		for end < len(diff) {
+			if diff[end].Op != Equal {
+				end++
+				continue
+			}
+			run := end
+			for run < len(diff) && diff[run].Op == Equal {
+				run++
+			}
+			if run == len(diff) || run-end > 2*context {
+				break
+			}
+			end = run
+		}
+
+		lead := context
+		if first-lead < start {
+			lead = first - start
+		}
+		trail := context
+		for k := 0; k < context; k++ {
+			if end+k >= len(diff) || diff[end+k].Op != Equal {
+				trail = k
+				break
+			}
+		}
+
+		hunk := diff[first-lead : end+trail]
+		aStart, bStart := aLine-lead, bLine-lead
+		var aCount, bCount int
+		for _, line := range hunk {
+			if line.Op != Insert {
+				aCount++
+			}
+			if line.Op != Delete {
+				bCount++
+			}
+		}
+		fmt.Fprintf(&out, "@@ -%s +%s @@\n", hunkRange(aStart, aCount), hunkRange(bStart, bCount))
+		for _, line := range hunk {
+			out.WriteString([]string{" ", "-", "+"}[line.Op] + line.Text + "\n")
+		}
+
+		aLine += aCount - lead
+		bLine += bCount - lead
+		start = end + trail
+	}
+	return out.String()
+}
+
+// hunkRange formats a hunk range the way diff -u does
+func hunkRange(start, count int) string {
+	switch count {
+	case 0:
+		return fmt.Sprintf("%d,0", start-1)
+	case 1:
+		return fmt.Sprintf("%d", start)
+	}
+	return fmt.Sprintf("%d,%d", start, count)
+}
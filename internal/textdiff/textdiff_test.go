@@ -0,0 +1,70 @@
+package textdiff
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLines(t *testing.T) {
+	diff := Lines("a\nb\nc\n", "a\nx\nc\nd\n")
+	var got []string
+	for _, line := range diff {
+		got = append(got, []string{" ", "-", "+"}[line.Op]+line.Text)
+	}
+	if strings.Join(got, ",") != " a,-b,+x, c,+d" {
+		t.Errorf("Unexpected diff %v", got)
+	}
+	if stats := Count(diff); stats.Added != 2 || stats.Removed != 1 {
+		t.Errorf("Unexpected stats %+v", stats)
+	}
+}
+
+func TestUnified(t *testing.T) {
+	var a, b []string
+	for i := 1; i <= 20; i++ {
+		line := string(rune('a' + i - 1))
+		a = append(a, line)
+		switch i {
+		case 2:
+			b = append(b, "B")
+		case 15:
+			// deleted
+		default:
+			b = append(b, line)
+		}
+	}
+	b = append(b, "new")
+
+	got := Unified("old", "new", strings.Join(a, "\n")+"\n", strings.Join(b, "\n")+"\n", 2)
+	want := `--- old
++++ new
+@@ -1,4 +1,4 @@
+ a
+-b
++B
+ c
+ d
+@@ -13,5 +13,4 @@
+ m
+ n
+-o
+ p
+ q
+@@ -19,2 +18,3 @@
+ s
+ t
++new
+`
+	if got != want {
+		t.Errorf("Unexpected diff:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestUnifiedEdges(t *testing.T) {
+	if got := Unified("a", "b", "same\n", "same", 3); got != "" {
+		t.Errorf("Expected no diff for equal lines, got %q", got)
+	}
+	if got := Unified("a", "b", "", "x\n", 3); got != "--- a\n+++ b\n@@ -0,0 +1 @@\n+x\n" {
+		t.Errorf("Unexpected diff for a new file %q", got)
+	}
+}
@@ -0,0 +1,193 @@
+// Package toolinstall knows how to install the tools crew and its agents
+// use with the system's package manager: Homebrew on macOS, apt, dnf,
+// pacman or Homebrew on Linux, and winget on Windows. Tools a package
+// manager does not carry fall back to their own installers, such as npm or
+// cargo.
+package toolinstall
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Manager is a package manager
+type Manager struct {
+	// Name is how packages are keyed: brew, apt, dnf, pacman or winget
+	Name string
+	// Install is the command line installing packages, which are appended
+	Install []string
+	// Sudo is set when installing needs root
+	Sudo bool
+	// Single is set when a command installs one package
+	Single bool
+}
+
+// managers are tried in order for each OS; the first one found is used
+var managers = map[string][]Manager{
+	"darwin": {
+		{Name: "brew", Install: []string{"brew", "install"}},
+	},
+	"linux": {
+		{Name: "apt", Install: []string{"apt-get", "install", "-y"}, Sudo: true},
+		{Name: "dnf", Install: []string{"dnf", "install", "-y"}, Sudo: true},
+		{Name: "pacman", Install: []string{"pacman", "-S", "--noconfirm"}, Sudo: true},
+		{Name: "brew", Install: []string{"brew", "install"}},
+	},
+	"windows": {
+		{Name: "winget", Install: []string{"winget", "install", "--exact", "--id"}, Single: true},
+	},
+}
+
+// tool is what crew knows about installing one tool
+type tool struct {
+	// packages maps a package manager to the tool's package
+	packages map[string]string
+	// fallback installs the tool where no package manager carries it
+	fallback []string
+	homepage string
+}
+
+var tools = map[string]tool{
+	"git": {
+		packages: map[string]string{"brew": "git", "apt": "git", "dnf": "git", "pacman": "git", "winget": "Git.Git"},
+		homepage: "https://git-scm.com/",
+	},
+	"go": {
+		packages: map[string]string{"brew": "go", "apt": "golang-go", "dnf": "golang", "pacman": "go", "winget": "GoLang.Go"},
+		homepage: "https://go.dev/dl/",
+	},
+	"node": {
+		packages: map[string]string{"brew": "node", "apt": "nodejs", "dnf": "nodejs", "pacman": "nodejs", "winget": "OpenJS.NodeJS.LTS"},
+		homepage: "https://nodejs.org/",
+	},
+	"npm": {
+		packages: map[string]string{"brew": "node", "apt": "npm", "dnf": "npm", "pacman": "npm", "winget": "OpenJS.NodeJS.LTS"},
+		homepage: "https://nodejs.org/",
+	},
+	"python": {
+		packages: map[string]string{"brew": "python", "apt": "python3", "dnf": "python3", "pacman": "python", "winget": "Python.Python.3.12"},
+		homepage: "https://www.python.org/downloads/",
+	},
+	"code2prompt": {
+		packages: map[string]string{"brew": "code2prompt"},
+		fallback: []string{"cargo", "install", "code2prompt"},
+		homepage: "https://github.com/mufeedvh/code2prompt",
+	},
+	"ast-grep": {
+		packages: map[string]string{"brew": "ast-grep", "pacman": "ast-grep"},
+		fallback: []string{"npm", "install", "-g", "@ast-grep/cli"},
+		homepage: "https://ast-grep.github.io/",
+	},
+	"claude": {
+		fallback: []string{"npm", "install", "-g", "@anthropic-ai/claude-code"},
+		homepage: "https://claude.ai/cli",
+	},
+}
+
+// geteuid is replaced in tests
+var geteuid = os.Geteuid
+
+// Detect returns the package manager of an OS that lookPath finds, or nil
+// when there is none
+func Detect(goos string, lookPath func(string) (string, error)) *Manager {
+	for _, m := range managers[goos] {
+		if _, err := lookPath(m.Install[0]); err == nil {
+			m := m
+			return &m
+		}
+	}
+	return nil
+}
+
+// Known reports whether crew knows how to install a tool
+func Known(name string) bool {
+	_, ok := tools[name]
+	return ok
+}
+
+// Homepage is where to download a tool by hand
+func Homepage(name string) string {
+	return tools[name].homepage
+}
+
+// Step is one command of an installation
+type Step struct {
+	// Tools are the tools the command installs
+	Tools []string `json:"tools"`
+	Args  []string `json:"command"`
+}
+
+// String is the command as it would be typed in a shell
+func (s Step) String() string {
+	quoted := make([]string, len(s.Args))
+	for i, arg := range s.Args {
+		quoted[i] = arg
+		if arg == "" || strings.ContainsAny(arg, " \t'\"$&|;<>()*?") {
+			quoted[i] = "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+		}
+	}
+	return strings.Join(quoted, " ")
+}
+
+// Run runs the command, connected to the given streams so the package
+// manager can ask for a password
+func (s Step) Run(stdin io.Reader, stdout, stderr io.Writer) error {
+	cmd := exec.Command(s.Args[0], s.Args[1:]...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = stdin, stdout, stderr
+	return cmd.Run()
+}
+
+// Plan returns the commands installing names with m, which may be nil,
+// in the order they should run: the package manager first, then the
+// fallback installers, which may need what it installs. unavailable are
+// the tools that can only be installed by hand.
+func Plan(m *Manager, names []string) (steps []Step, unavailable []string) {
+	var managed, packages []string
+	// packaged holds the tools each package installs
+	packaged := make(map[string][]string)
+	var fallbacks []Step
+
+	for _, name := range names {
+		t, known := tools[name]
+		pkg := ""
+		if m != nil {
+			pkg = t.packages[m.Name]
+		}
+		switch {
+		case !known:
+			unavailable = append(unavailable, name)
+		case pkg != "":
+			managed = append(managed, name)
+			if len(packaged[pkg]) == 0 {
+				packages = append(packages, pkg)
+			}
+			packaged[pkg] = append(packaged[pkg], name)
+		case len(t.fallback) > 0:
+			fallbacks = append(fallbacks, Step{Tools: []string{name}, Args: t.fallback})
+		default:
+			unavailable = append(unavailable, name)
+		}
+	}
+
+	if len(packages) > 0 {
+		install := m.Install
+		if m.Sudo && geteuid() != 0 {
+			install = append([]string{"sudo"}, install...)
+		}
+		if m.Single {
+			for _, pkg := range packages {
+				steps = append(steps, Step{Tools: packaged[pkg], Args: appendArgs(install, pkg)})
+			}
+		} else {
+			steps = append(steps, Step{Tools: managed, Args: appendArgs(install, packages...)})
+		}
+	}
+	return append(steps, fallbacks...), unavailable
+}
+
+// appendArgs appends to a copy of args
+func appendArgs(args []string, more ...string) []string {
+	return append(append([]string{}, args...), more...)
+}
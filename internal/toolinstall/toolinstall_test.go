@@ -0,0 +1,89 @@
+package toolinstall
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func lookPathOf(found ...string) func(string) (string, error) {
+	return func(name string) (string, error) {
+		for _, f := range found {
+			if f == name {
+				return "/usr/bin/" + name, nil
+			}
+		}
+		return "", fmt.Errorf("%s: not found", name)
+	}
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		goos  string
+		found []string
+		want  string
+	}{
+		{"darwin", []string{"brew"}, "brew"},
+		{"linux", []string{"brew", "dnf"}, "dnf"},
+		{"linux", []string{"brew"}, "brew"},
+		{"windows", []string{"winget"}, "winget"},
+		{"darwin", nil, ""},
+		{"plan9", []string{"brew"}, ""},
+	}
+	for _, tt := range tests {
+		got := ""
+		if m := Detect(tt.goos, lookPathOf(tt.found...)); m != nil {
+			got = m.Name
+		}
+		if got != tt.want {
+			t.Errorf("Detect(%s, %v) = %q, want %q", tt.goos, tt.found, got, tt.want)
+		}
+	}
+}
+
+func TestPlan(t *testing.T) {
+	defer func(saved func() int) { geteuid = saved }(geteuid)
+	geteuid = func() int { return 1000 }
+
+	apt := Detect("linux", lookPathOf("apt-get"))
+	steps, unavailable := Plan(apt, []string{"git", "ast-grep", "node", "permissions"})
+	want := []Step{
+		{Tools: []string{"git", "node"}, Args: []string{"sudo", "apt-get", "install", "-y", "git", "nodejs"}},
+		{Tools: []string{"ast-grep"}, Args: []string{"npm", "install", "-g", "@ast-grep/cli"}},
+	}
+	if !reflect.DeepEqual(steps, want) || !reflect.DeepEqual(unavailable, []string{"permissions"}) {
+		t.Errorf("Plan(apt) = %+v, %v", steps, unavailable)
+	}
+	if got := steps[1].String(); got != "npm install -g @ast-grep/cli" {
+		t.Errorf("String() = %q", got)
+	}
+
+	// winget installs one package at a time; node and npm are one package
+	winget := Detect("windows", lookPathOf("winget"))
+	steps, _ = Plan(winget, []string{"node", "npm", "git"})
+	want = []Step{
+		{Tools: []string{"node", "npm"}, Args: []string{"winget", "install", "--exact", "--id", "OpenJS.NodeJS.LTS"}},
+		{Tools: []string{"git"}, Args: []string{"winget", "install", "--exact", "--id", "Git.Git"}},
+	}
+	if !reflect.DeepEqual(steps, want) {
+		t.Errorf("Plan(winget) = %+v", steps)
+	}
+
+	// Without a package manager only the fallbacks remain
+	steps, unavailable = Plan(nil, []string{"git", "code2prompt"})
+	if len(steps) != 1 || steps[0].String() != "cargo install code2prompt" || !reflect.DeepEqual(unavailable, []string{"git"}) {
+		t.Errorf("Plan(nil) = %+v, %v", steps, unavailable)
+	}
+
+	geteuid = func() int { return 0 }
+	if steps, _ := Plan(apt, []string{"git"}); steps[0].Args[0] != "apt-get" {
+		t.Errorf("root should not need sudo: %v", steps[0].Args)
+	}
+}
+
+func TestStepString(t *testing.T) {
+	step := Step{Args: []string{"sh", "-c", "echo 'hi' > out"}}
+	if got, want := step.String(), `sh -c 'echo '\''hi'\'' > out'`; got != want {
+		t.Errorf("String() = %s, want %s", got, want)
+	}
+}
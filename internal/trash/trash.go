@@ -0,0 +1,339 @@
+// Package trash makes destructive operations recoverable. Instead of
+// deleting files, an operation moves them into a batch in the trash
+// directory of the installation, which remembers where each file came
+// from so the whole batch can be put back. Batches older than the TTL are
+// purged the next time something is trashed.
+package trash
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/crewdirs"
+	"github.com/jonwraymond/claude-code-super-crew/internal/fileops"
+)
+
+// DefaultTTL is how long trashed files are kept
+const DefaultTTL = 30 * 24 * time.Hour
+
+// entryFile describes a batch; its files sit next to it in filesDir
+const (
+	entryFile = "entry.json"
+	filesDir  = "files"
+)
+
+// ErrOutsideRoot is returned for paths the trash cannot record relative to
+// its root
+var ErrOutsideRoot = errors.New("path is outside the trash root")
+
+// Dir returns the trash directory of the installation in installDir
+func Dir(installDir string) string {
+	return crewdirs.Path(installDir, "trash")
+}
+
+// Trash is the trash directory of an installation
+type Trash struct {
+	Dir string
+	// Root is the directory trashed paths are recorded relative to, and
+	// restored into
+	Root string
+	// TTL after which batches are purged; 0 or less keeps them
+	TTL time.Duration
+}
+
+// New returns the trash of the installation in installDir
+func New(installDir string) *Trash {
+	return &Trash{Dir: Dir(installDir), Root: installDir, TTL: DefaultTTL}
+}
+
+// Entry describes one batch of trashed files
+type Entry struct {
+	ID        string    `json:"id"`
+	Operation string    `json:"operation"`
+	DeletedAt time.Time `json:"deleted_at"`
+	// Paths are relative to the root, with forward slashes
+	Paths []string `json:"paths"`
+	Bytes int64    `json:"bytes"`
+}
+
+// Batch collects the files one operation removes
+type Batch struct {
+	trash *Trash
+	entry Entry
+	dir   string
+}
+
+// Begin starts a batch for operation, e.g. "uninstall", purging expired
+// batches first
+func (t *Trash) Begin(operation string) *Batch {
+	if t.TTL > 0 {
+		t.Empty(time.Now().Add(-t.TTL))
+	}
+	now := time.Now()
+	id := now.Format("20060102-150405.000")
+	id = strings.Replace(id, ".", "-", 1)
+	return &Batch{
+		trash: t,
+		entry: Entry{ID: id, Operation: operation, DeletedAt: now},
+		dir:   filepath.Join(t.Dir, id),
+	}
+}
+
+// Remove moves path, a file or a directory below the root, into the batch
+func (b *Batch) Remove(path string) error {
+	rel, err := b.rel(path)
+	if err != nil {
+		return err
+	}
+	info, err := os.Lstat(path)
+	if err != nil {
+		return err
+	}
+
+	dst := filepath.Join(b.dir, filesDir, rel)
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	if err := move(path, dst, info); err != nil {
+		return fmt.Errorf("failed to move %s to the trash: %w", rel, err)
+	}
+
+	b.entry.Paths = append(b.entry.Paths, filepath.ToSlash(rel))
+	b.entry.Bytes += treeSize(dst)
+	return b.save()
+}
+
+// Keep copies the file at path into the batch without removing it, so
+// restoring the batch also puts back the version the operation started
+// from. Missing files are ignored.
+func (b *Batch) Keep(path string) error {
+	rel, err := b.rel(path)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Lstat(path); os.IsNotExist(err) {
+		return nil
+	}
+	dst := filepath.Join(b.dir, filesDir, rel)
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	if err := fileops.CopyFile(path, dst); err != nil {
+		return fmt.Errorf("failed to keep a copy of %s: %w", rel, err)
+	}
+	b.entry.Paths = append(b.entry.Paths, filepath.ToSlash(rel))
+	b.entry.Bytes += treeSize(dst)
+	return b.save()
+}
+
+// rel returns path relative to the root of the trash
+func (b *Batch) rel(path string) (string, error) {
+	rel, err := filepath.Rel(b.trash.Root, path)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%s: %w", path, ErrOutsideRoot)
+	}
+	return rel, nil
+}
+
+// ID returns the ID crew trash restore takes for this batch
+func (b *Batch) ID() string {
+	return b.entry.ID
+}
+
+// Len returns the number of paths in the batch
+func (b *Batch) Len() int {
+	return len(b.entry.Paths)
+}
+
+func (b *Batch) save() error {
+	data, err := json.MarshalIndent(b.entry, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(b.dir, entryFile), data, 0644)
+}
+
+// move renames src to dst, copying across filesystems
+func move(src, dst string, info fs.FileInfo) error {
+	if err := os.Rename(src, dst); err == nil {
+		return nil
+	}
+	var err error
+	if info.IsDir() {
+		err = fileops.CopyDir(context.Background(), src, dst, nil)
+	} else {
+		err = fileops.CopyFile(src, dst)
+	}
+	if err != nil {
+		os.RemoveAll(dst)
+		return err
+	}
+	return os.RemoveAll(src)
+}
+
+func treeSize(path string) int64 {
+	var size int64
+	filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			if info, err := d.Info(); err == nil {
+				size += info.Size()
+			}
+		}
+		return nil
+	})
+	return size
+}
+
+// List returns the batches in the trash, newest first
+func (t *Trash) List() ([]Entry, error) {
+	dirs, err := os.ReadDir(t.Dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var entries []Entry
+	for _, d := range dirs {
+		if !d.IsDir() {
+			continue
+		}
+		entry, err := t.load(d.Name())
+		if err != nil {
+			continue
+		}
+		entries = append(entries, *entry)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].DeletedAt.After(entries[j].DeletedAt) })
+	return entries, nil
+}
+
+func (t *Trash) load(id string) (*Entry, error) {
+	data, err := os.ReadFile(filepath.Join(t.Dir, id, entryFile))
+	if err != nil {
+		return nil, err
+	}
+	var entry Entry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, fmt.Errorf("invalid trash entry %s: %w", id, err)
+	}
+	return &entry, nil
+}
+
+// Restored lists what Restore put back and what it left in the trash
+type Restored struct {
+	Paths []string `json:"paths"`
+	// Conflicts exist again at their original location and were kept in
+	// the trash
+	Conflicts []string `json:"conflicts,omitempty"`
+}
+
+// Restore moves the files of batch id back to where they were removed
+// from. Paths that exist again are left in the trash unless overwrite is
+// set. The batch is dropped once it is empty.
+func (t *Trash) Restore(id string, overwrite bool) (*Restored, error) {
+	entry, err := t.load(id)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("no trash entry %s; see crew trash list", id)
+		}
+		return nil, err
+	}
+
+	restored := &Restored{}
+	var remaining []string
+	for _, rel := range entry.Paths {
+		src := filepath.Join(t.Dir, id, filesDir, filepath.FromSlash(rel))
+		if _, err := os.Lstat(src); err != nil {
+			// Restored earlier
+			continue
+		}
+		conflicts := len(restored.Conflicts)
+		if err := t.restore(src, rel, overwrite, restored); err != nil {
+			return restored, err
+		}
+		if len(restored.Conflicts) > conflicts {
+			remaining = append(remaining, rel)
+		}
+	}
+
+	if len(remaining) == 0 {
+		return restored, os.RemoveAll(filepath.Join(t.Dir, id))
+	}
+	entry.Paths = remaining
+	batch := &Batch{trash: t, entry: *entry, dir: filepath.Join(t.Dir, id)}
+	return restored, batch.save()
+}
+
+// restore moves src back to rel below the root. Directories that exist
+// again are merged file by file.
+func (t *Trash) restore(src, rel string, overwrite bool, restored *Restored) error {
+	info, err := os.Lstat(src)
+	if err != nil {
+		return err
+	}
+	dst := filepath.Join(t.Root, filepath.FromSlash(rel))
+	if existing, err := os.Lstat(dst); err == nil {
+		if info.IsDir() && existing.IsDir() {
+			entries, err := os.ReadDir(src)
+			if err != nil {
+				return err
+			}
+			for _, e := range entries {
+				if err := t.restore(filepath.Join(src, e.Name()), path.Join(rel, e.Name()), overwrite, restored); err != nil {
+					return err
+				}
+			}
+			// Only conflicts are left behind
+			os.Remove(src)
+			return nil
+		}
+		if !overwrite {
+			restored.Conflicts = append(restored.Conflicts, rel)
+			return nil
+		}
+		if err := os.RemoveAll(dst); err != nil {
+			return err
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	if err := move(src, dst, info); err != nil {
+		return fmt.Errorf("failed to restore %s: %w", rel, err)
+	}
+	restored.Paths = append(restored.Paths, rel)
+	return nil
+}
+
+// Empty purges the batches deleted before cutoff; a zero cutoff purges
+// everything. It returns the purged batches.
+func (t *Trash) Empty(cutoff time.Time) ([]Entry, error) {
+	entries, err := t.List()
+	if err != nil {
+		return nil, err
+	}
+	var purged []Entry
+	for _, entry := range entries {
+		if !cutoff.IsZero() && !entry.DeletedAt.Before(cutoff) {
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(t.Dir, entry.ID)); err != nil {
+			return purged, err
+		}
+		purged = append(purged, entry)
+	}
+	if cutoff.IsZero() {
+		os.Remove(t.Dir)
+	}
+	return purged, nil
+}
@@ -0,0 +1,136 @@
+package trash
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func write(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRemoveAndRestore(t *testing.T) {
+	root := t.TempDir()
+	tr := New(root)
+	write(t, filepath.Join(root, "CLAUDE.md"), "claude")
+	write(t, filepath.Join(root, "commands", "crew", "build.md"), "build")
+
+	batch := tr.Begin("uninstall")
+	for _, rel := range []string{"CLAUDE.md", "commands"} {
+		if err := batch.Remove(filepath.Join(root, rel)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(root, "commands")); !os.IsNotExist(err) {
+		t.Fatal("Expected the directory to be moved out")
+	}
+	if err := batch.Remove(filepath.Dir(root)); !errors.Is(err, ErrOutsideRoot) {
+		t.Errorf("Expected ErrOutsideRoot, got %v", err)
+	}
+
+	entries, err := tr.List()
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("Expected one entry, got %+v, %v", entries, err)
+	}
+	if e := entries[0]; e.ID != batch.ID() || e.Operation != "uninstall" || len(e.Paths) != 2 || e.Bytes != 11 {
+		t.Errorf("Unexpected entry %+v", e)
+	}
+
+	// A file that exists again is kept in the trash, a directory is merged
+	write(t, filepath.Join(root, "CLAUDE.md"), "new")
+	write(t, filepath.Join(root, "commands", "crew", "mine.md"), "mine")
+	restored, err := tr.Restore(batch.ID(), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(restored.Paths) != 1 || restored.Paths[0] != "commands/crew/build.md" ||
+		len(restored.Conflicts) != 1 || restored.Conflicts[0] != "CLAUDE.md" {
+		t.Fatalf("Unexpected restore %+v", restored)
+	}
+	if data, _ := os.ReadFile(filepath.Join(root, "commands", "crew", "build.md")); string(data) != "build" {
+		t.Errorf("Expected the directory to be restored, got %q", data)
+	}
+
+	if _, err := tr.Restore(batch.ID(), true); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(root, "CLAUDE.md")); string(data) != "claude" {
+		t.Errorf("Expected --overwrite to restore the trashed file, got %q", data)
+	}
+	if entries, _ := tr.List(); len(entries) != 0 {
+		t.Errorf("Expected the restored entry to be dropped, got %+v", entries)
+	}
+	if _, err := tr.Restore("missing", false); err == nil {
+		t.Error("Expected an error for an unknown entry")
+	}
+}
+
+func TestEmptyAndExpiry(t *testing.T) {
+	root := t.TempDir()
+	tr := New(root)
+	write(t, filepath.Join(root, "old.md"), "old")
+	old := tr.Begin("uninstall")
+	if err := old.Remove(filepath.Join(root, "old.md")); err != nil {
+		t.Fatal(err)
+	}
+	old.entry.DeletedAt = time.Now().Add(-48 * time.Hour)
+	old.save()
+
+	write(t, filepath.Join(root, "new.md"), "new")
+	time.Sleep(2 * time.Millisecond)
+	recent := tr.Begin("uninstall")
+	if err := recent.Remove(filepath.Join(root, "new.md")); err != nil {
+		t.Fatal(err)
+	}
+
+	purged, err := tr.Empty(time.Now().Add(-24 * time.Hour))
+	if err != nil || len(purged) != 1 || purged[0].ID != old.ID() {
+		t.Fatalf("Expected the old entry to be purged, got %+v, %v", purged, err)
+	}
+
+	// Starting a batch purges entries past the TTL
+	tr.TTL = time.Nanosecond
+	tr.Begin("uninstall")
+	if entries, _ := tr.List(); len(entries) != 0 {
+		t.Errorf("Expected expired entries to be purged, got %+v", entries)
+	}
+
+	if _, err := tr.Empty(time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(tr.Dir); !os.IsNotExist(err) {
+		t.Error("Expected the emptied trash to be removed")
+	}
+}
+
+func TestKeepRestoresStartingVersion(t *testing.T) {
+	root := t.TempDir()
+	tr := New(root)
+	meta := filepath.Join(root, ".crew", "config", "crew-metadata.json")
+	write(t, meta, "before")
+
+	batch := tr.Begin("uninstall")
+	if err := batch.Keep(meta); err != nil {
+		t.Fatal(err)
+	}
+	if err := batch.Keep(filepath.Join(root, "missing.json")); err != nil || batch.Len() != 1 {
+		t.Fatalf("Expected a missing file to be ignored, got %v with %d paths", err, batch.Len())
+	}
+	write(t, meta, "after")
+
+	if _, err := tr.Restore(batch.ID(), true); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(meta); string(data) != "before" {
+		t.Errorf("Expected the kept version back, got %q", data)
+	}
+}
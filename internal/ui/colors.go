@@ -1,7 +1,8 @@
 package ui
 
-// Color constants for terminal output
-const (
+// Color codes for terminal output. They are emptied by Configure when
+// color output is disabled.
+var (
 	ColorReset   = "\033[0m"
 	ColorBright  = "\033[1m"
 	ColorDim     = "\033[2m"
@@ -16,8 +17,8 @@ const (
 	ColorGray    = "\033[90m"
 )
 
-// Colors provides a struct-based interface to color constants
-var Colors = struct {
+// colorPalette groups the color codes for struct-style access
+type colorPalette struct {
 	Reset   string
 	Bright  string
 	Red     string
@@ -28,7 +29,10 @@ var Colors = struct {
 	Cyan    string
 	White   string
 	Gray    string
-}{
+}
+
+// Colors provides a struct-based interface to color constants
+var Colors = colorPalette{
 	Reset:   ColorReset,
 	Bright:  ColorBright,
 	Red:     ColorRed,
@@ -0,0 +1,256 @@
+package ui
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/i18n"
+	"github.com/mattn/go-isatty"
+)
+
+// Frontend presents everything a command shows besides its own output:
+// headers, status messages, tables, confirmations, menus and progress.
+// Commands keep calling DisplayHeader, Confirm, NewMenu and the like, which
+// hand their already translated text to the frontend chosen by SetFrontend,
+// so one command reads well on a terminal, in a log and to a wrapper.
+type Frontend interface {
+	// Header introduces a command
+	Header(title, subtitle string)
+	// Message shows a status line
+	Message(level MessageLevel, text string)
+	// Table shows rows under headers
+	Table(headers []string, rows [][]string, title string)
+	// Confirm asks a yes/no question
+	Confirm(message string, defaultYes bool) bool
+	// Menu asks for an option of m, returning an int, or a []int when
+	// m.MultiSelect is set
+	Menu(m *Menu) (interface{}, error)
+	// Answered reports the answer a prompt resolved to without asking
+	Answered(prompt, answer string)
+	// Progress reports the progress of an operation over items
+	Progress(title string, items []string) Progress
+}
+
+// MessageLevel is the kind of a status message
+type MessageLevel string
+
+const (
+	LevelInfo    MessageLevel = "info"
+	LevelSuccess MessageLevel = "success"
+	LevelWarning MessageLevel = "warning"
+	LevelError   MessageLevel = "error"
+	LevelStep    MessageLevel = "step"
+)
+
+// FrontendOptions tune the frontend DetectFrontend picks
+type FrontendOptions struct {
+	// JSON selects the JSON event stream, as --output json does
+	JSON bool
+	// PlainProgress keeps progress to the log lines even on a terminal,
+	// for --quiet, --verbose and JSON log lines
+	PlainProgress bool
+}
+
+var (
+	frontendMu sync.Mutex
+	frontend   Frontend = &fancyFrontend{}
+)
+
+// SetFrontend replaces the frontend used by the package functions
+func SetFrontend(f Frontend) {
+	frontendMu.Lock()
+	defer frontendMu.Unlock()
+	frontend = f
+}
+
+// CurrentFrontend returns the frontend set with SetFrontend, the fancy one
+// until then
+func CurrentFrontend() Frontend {
+	frontendMu.Lock()
+	defer frontendMu.Unlock()
+	return frontend
+}
+
+// DetectFrontend picks the JSON event stream when requested, the fancy
+// frontend on an interactive terminal and the plain one for pipes, files
+// and TERM=dumb
+func DetectFrontend(opts FrontendOptions) Frontend {
+	if opts.JSON {
+		return &jsonFrontend{}
+	}
+	fd := os.Stdout.Fd()
+	if os.Getenv("TERM") == "dumb" || !(isatty.IsTerminal(fd) || isatty.IsCygwinTerminal(fd)) {
+		return &plainFrontend{}
+	}
+	return &fancyFrontend{plainProgress: opts.PlainProgress}
+}
+
+// plainFrontend writes line by line and never moves the cursor, for output
+// that ends up in a pipe or a file
+type plainFrontend struct{}
+
+func (plainFrontend) Header(title, subtitle string) {
+	fmt.Printf("\n%s%s%s\n", Colors.Bright, title, Colors.Reset)
+	if subtitle != "" {
+		fmt.Println(subtitle)
+	}
+	fmt.Println(strings.Repeat(Icons.Rule, len([]rune(title))))
+	fmt.Println()
+}
+
+func (plainFrontend) Message(level MessageLevel, text string) {
+	switch level {
+	case LevelSuccess:
+		fmt.Printf("%s[%s] %s%s\n", Colors.Green, Icons.Check, text, Colors.Reset)
+	case LevelWarning:
+		fmt.Printf("%s[!] %s%s\n", Colors.Yellow, text, Colors.Reset)
+	case LevelError:
+		fmt.Printf("%s[%s] %s%s\n", Colors.Red, Icons.Cross, text, Colors.Reset)
+	case LevelStep:
+		fmt.Printf("%s%s%s\n", Colors.Cyan, text, Colors.Reset)
+	default:
+		fmt.Printf("%s[%s] %s%s\n", Colors.Blue, i18n.T("ui.label.info"), text, Colors.Reset)
+	}
+}
+
+func (plainFrontend) Table(headers []string, rows [][]string, title string) {
+	printTable(headers, rows, title)
+}
+
+func (plainFrontend) Confirm(message string, defaultYes bool) bool {
+	return confirmLine(message, defaultYes)
+}
+
+func (plainFrontend) Menu(m *Menu) (interface{}, error) {
+	return m.run(false)
+}
+
+func (plainFrontend) Answered(prompt, answer string) {
+	announceLine(prompt, answer)
+}
+
+func (plainFrontend) Progress(title string, items []string) Progress {
+	return &plainProgress{}
+}
+
+// fancyFrontend draws boxed headers, full-screen menus and the live
+// progress panel on an interactive terminal
+type fancyFrontend struct {
+	plainFrontend
+	plainProgress bool
+}
+
+func (fancyFrontend) Header(title, subtitle string) {
+	fmt.Printf("\n%s%s%s%s\n", Colors.Cyan, Colors.Bright, strings.Repeat("=", 60), Colors.Reset)
+	fmt.Printf("%s%s%s%s\n", Colors.Cyan, Colors.Bright, centerString(title, 60), Colors.Reset)
+	if subtitle != "" {
+		fmt.Printf("%s%s%s\n", Colors.White, centerString(subtitle, 60), Colors.Reset)
+	}
+	fmt.Printf("%s%s%s%s\n\n", Colors.Cyan, Colors.Bright, strings.Repeat("=", 60), Colors.Reset)
+}
+
+func (fancyFrontend) Menu(m *Menu) (interface{}, error) {
+	return m.run(true)
+}
+
+func (f fancyFrontend) Progress(title string, items []string) Progress {
+	return NewProgress(DetectProgressMode(false, f.plainProgress, "text"), title, items)
+}
+
+// jsonFrontend writes every header, message, table and prompt as a JSON
+// line on stdout, alongside the progress events, for programs that wrap
+// crew. It never reads stdin: confirmations take their default and menus
+// fail with ErrInputRequired.
+type jsonFrontend struct {
+	mu sync.Mutex
+}
+
+// emit writes ev to the current stdout, which a running progress captures
+func (f *jsonFrontend) emit(ev progressEvent) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	ev.Time = time.Now().UTC()
+	json.NewEncoder(os.Stdout).Encode(ev)
+}
+
+func (f *jsonFrontend) Header(title, subtitle string) {
+	f.emit(progressEvent{Event: "header", Title: title, Message: subtitle})
+}
+
+func (f *jsonFrontend) Message(level MessageLevel, text string) {
+	f.emit(progressEvent{Event: "message", Level: string(level), Message: text})
+}
+
+func (f *jsonFrontend) Table(headers []string, rows [][]string, title string) {
+	if len(rows) == 0 {
+		return
+	}
+	f.emit(progressEvent{Event: "table", Title: title, Headers: headers, Rows: rows})
+}
+
+func (f *jsonFrontend) Confirm(message string, defaultYes bool) bool {
+	f.Answered(message, yesNo(defaultYes))
+	return defaultYes
+}
+
+func (f *jsonFrontend) Menu(m *Menu) (interface{}, error) {
+	return nil, inputRequired(m.Title)
+}
+
+func (f *jsonFrontend) Answered(prompt, answer string) {
+	f.emit(progressEvent{Event: "prompt", Message: prompt, Answer: answer})
+}
+
+func (f *jsonFrontend) Progress(title string, items []string) Progress {
+	return NewProgress(ProgressJSON, title, items)
+}
+
+// yesNo returns the translated answer for b
+func yesNo(b bool) string {
+	if b {
+		return i18n.T("ui.answer.yes")
+	}
+	return i18n.T("ui.answer.no")
+}
+
+// confirmLine asks message on one line until the answer is yes, no or
+// empty for defaultYes
+func confirmLine(message string, defaultYes bool) bool {
+	suffix := "[Y/n]"
+	if !defaultYes {
+		suffix = "[y/N]"
+	}
+	if nonInteractive {
+		announceLine(message+" "+suffix, yesNo(defaultYes))
+		return defaultYes
+	}
+
+	scanner := bufio.NewScanner(os.Stdin)
+	for {
+		fmt.Printf("%s%s %s%s ", Colors.Blue, message, suffix, Colors.Reset)
+
+		if !scanner.Scan() {
+			return false
+		}
+
+		response := strings.ToLower(strings.TrimSpace(scanner.Text()))
+
+		if response == "" {
+			return defaultYes
+		}
+
+		switch {
+		case answerIn(response, "ui.confirm.yes_words"):
+			return true
+		case answerIn(response, "ui.confirm.no_words"):
+			return false
+		default:
+			fmt.Printf("%s%s%s\n", Colors.Red, i18n.T("ui.confirm.invalid"), Colors.Reset)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package ui
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// captureLines runs fn and returns what it printed to stdout
+func captureLines(fn func()) []string {
+	var mu sync.Mutex
+	var lines []string
+	restore := captureStdout(func(line string) {
+		mu.Lock()
+		defer mu.Unlock()
+		lines = append(lines, line)
+	})
+	fn()
+	restore()
+	return lines
+}
+
+func TestJSONFrontendEvents(t *testing.T) {
+	SetFrontend(&jsonFrontend{})
+	defer SetFrontend(&fancyFrontend{})
+
+	var confirmed bool
+	var menuErr error
+	lines := captureLines(func() {
+		DisplayHeader("Crew", "Installing")
+		DisplayWarning("node not found")
+		DisplayTable([]string{"name"}, [][]string{{"core"}}, "Components")
+		confirmed = Confirm("Proceed?", true)
+		_, menuErr = NewMenu("Select", []string{"a"}, false).Display()
+	})
+
+	var events []progressEvent
+	for _, line := range lines {
+		var ev progressEvent
+		if err := json.Unmarshal([]byte(line), &ev); err != nil {
+			t.Fatalf("Invalid JSON line %q: %v", line, err)
+		}
+		events = append(events, ev)
+	}
+	want := []string{"header", "message", "table", "prompt"}
+	if len(events) != len(want) {
+		t.Fatalf("Expected %d events, got %q", len(want), lines)
+	}
+	for i, ev := range events {
+		if ev.Event != want[i] {
+			t.Errorf("Event %d: expected %s, got %s", i, want[i], ev.Event)
+		}
+	}
+	if events[1].Level != "warning" || events[2].Rows[0][0] != "core" || events[3].Answer == "" {
+		t.Errorf("Unexpected events: %+v", events)
+	}
+	if !confirmed {
+		t.Error("Expected Confirm to take its default")
+	}
+	if !errors.Is(menuErr, ErrInputRequired) {
+		t.Errorf("Expected ErrInputRequired from a menu, got %v", menuErr)
+	}
+}
+
+func TestPlainFrontendHeader(t *testing.T) {
+	SetFrontend(&plainFrontend{})
+	defer SetFrontend(&fancyFrontend{})
+
+	lines := captureLines(func() {
+		DisplayHeader("Crew", "Installing")
+		DisplayStep(1, 2, "Copy files")
+	})
+	output := strings.Join(lines, "\n")
+	if strings.Contains(output, strings.Repeat("=", 60)) || strings.Contains(output, "     Crew") {
+		t.Errorf("Expected an unboxed header:\n%s", output)
+	}
+	for _, want := range []string{"Crew", "Installing", "[1/2] Copy files"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q:\n%s", want, output)
+		}
+	}
+	if _, ok := CurrentFrontend().Progress("Installing", []string{"core"}).(*plainProgress); !ok {
+		t.Error("Expected plain progress from the plain frontend")
+	}
+}
+
+func TestDetectFrontend(t *testing.T) {
+	if _, ok := DetectFrontend(FrontendOptions{JSON: true}).(*jsonFrontend); !ok {
+		t.Error("Expected the JSON frontend for --output json")
+	}
+	t.Setenv("TERM", "dumb")
+	if _, ok := DetectFrontend(FrontendOptions{}).(*plainFrontend); !ok {
+		t.Error("Expected the plain frontend for TERM=dumb")
+	}
+}
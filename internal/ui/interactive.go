@@ -6,6 +6,9 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"unicode/utf8"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/i18n"
 )
 
 // Menu represents an interactive menu system with keyboard navigation
@@ -26,12 +29,25 @@ func NewMenu(title string, options []string, multiSelect bool) *Menu {
 	}
 }
 
-// Display shows the menu and returns user selection
+// Display shows the menu through the current frontend and returns the
+// user selection
 func (m *Menu) Display() (interface{}, error) {
+	if nonInteractive {
+		return nil, inputRequired(m.Title)
+	}
+	return CurrentFrontend().Menu(m)
+}
+
+// run asks for a selection until the input is valid, clearing the screen
+// before each attempt when clear is set
+func (m *Menu) run(clear bool) (interface{}, error) {
 	scanner := bufio.NewScanner(os.Stdin)
 	
 	for {
 		// Clear screen and display menu
+		if clear {
+			clearScreen()
+		}
 		m.displayMenu()
 		
 		fmt.Print("> ")
@@ -45,7 +61,7 @@ func (m *Menu) Display() (interface{}, error) {
 			result, err := m.handleMultiSelectInput(input)
 			if err != nil {
 				fmt.Printf("%s%s%s\n", Colors.Red, err.Error(), Colors.Reset)
-				waitForKey("Press Enter to continue...")
+				waitForKey(i18n.T("ui.press_enter"))
 				continue
 			}
 			return result, nil
@@ -53,7 +69,7 @@ func (m *Menu) Display() (interface{}, error) {
 			result, err := m.handleSingleSelectInput(input)
 			if err != nil {
 				fmt.Printf("%s%s%s\n", Colors.Red, err.Error(), Colors.Reset)
-				waitForKey("Press Enter to continue...")
+				waitForKey(i18n.T("ui.press_enter"))
 				continue
 			}
 			return result, nil
@@ -63,11 +79,10 @@ func (m *Menu) Display() (interface{}, error) {
 
 // displayMenu shows the menu options
 func (m *Menu) displayMenu() {
-	clearScreen()
-	
 	// Display header
-	fmt.Printf("\n%s%s%s%s\n", Colors.Cyan, Colors.Bright, m.Title, Colors.Reset)
-	fmt.Println(strings.Repeat("=", len(m.Title)))
+	title := i18n.Text(m.Title)
+	fmt.Printf("\n%s%s%s%s\n", Colors.Cyan, Colors.Bright, title, Colors.Reset)
+	fmt.Println(strings.Repeat("=", len([]rune(title))))
 	fmt.Println()
 	
 	// Display options
@@ -88,10 +103,10 @@ func (m *Menu) displayMenu() {
 	
 	// Display instructions
 	if m.MultiSelect {
-		fmt.Printf("%sEnter numbers separated by commas (e.g., 1,3,5) or 'all' for all options:%s\n", Colors.Blue, Colors.Reset)
-		fmt.Printf("%sPress Enter without input to continue with current selection%s\n", Colors.Blue, Colors.Reset)
+		fmt.Printf("%s%s%s\n", Colors.Blue, i18n.T("ui.menu.multi_hint"), Colors.Reset)
+		fmt.Printf("%s%s%s\n", Colors.Blue, i18n.T("ui.menu.multi_keep"), Colors.Reset)
 	} else {
-		fmt.Printf("%sEnter your choice (1-%d):%s\n", Colors.Blue, len(m.Options), Colors.Reset)
+		fmt.Printf("%s%s%s\n", Colors.Blue, i18n.T("ui.menu.single_hint", len(m.Options)), Colors.Reset)
 	}
 	fmt.Println()
 }
@@ -99,16 +114,16 @@ func (m *Menu) displayMenu() {
 // handleSingleSelectInput processes single selection input
 func (m *Menu) handleSingleSelectInput(input string) (int, error) {
 	if input == "" {
-		return -1, fmt.Errorf("please enter a valid number")
+		return -1, fmt.Errorf("%s", i18n.T("ui.menu.invalid_number"))
 	}
 	
 	choice, err := strconv.Atoi(input)
 	if err != nil {
-		return -1, fmt.Errorf("please enter a valid number")
+		return -1, fmt.Errorf("%s", i18n.T("ui.menu.invalid_number"))
 	}
 	
 	if choice < 1 || choice > len(m.Options) {
-		return -1, fmt.Errorf("invalid choice. Please enter a number between 1 and %d", len(m.Options))
+		return -1, fmt.Errorf("%s", i18n.T("ui.menu.out_of_range", len(m.Options)))
 	}
 	
 	return choice - 1, nil
@@ -127,7 +142,7 @@ func (m *Menu) handleMultiSelectInput(input string) ([]int, error) {
 		return selected, nil
 	}
 	
-	if strings.ToLower(input) == "all" {
+	if lowered := strings.ToLower(input); lowered == "all" || lowered == i18n.T("ui.menu.all") {
 		var all []int
 		for i := range m.Options {
 			all = append(all, i)
@@ -162,41 +177,37 @@ func (m *Menu) handleMultiSelectInput(input string) ([]int, error) {
 	return selections, nil
 }
 
-// Confirm displays a confirmation dialog
+// Confirm asks a yes/no question through the current frontend
 func Confirm(message string, defaultResponse bool) bool {
-	scanner := bufio.NewScanner(os.Stdin)
-	
-	suffix := "[Y/n]"
-	if !defaultResponse {
-		suffix = "[y/N]"
-	}
-	
-	for {
-		fmt.Printf("%s%s %s%s ", Colors.Blue, message, suffix, Colors.Reset)
-		
-		if !scanner.Scan() {
-			return false
-		}
-		
-		response := strings.ToLower(strings.TrimSpace(scanner.Text()))
-		
-		if response == "" {
-			return defaultResponse
-		}
-		
-		switch response {
-		case "y", "yes", "true", "1":
+	return CurrentFrontend().Confirm(i18n.Text(message), defaultResponse)
+}
+
+// answerIn reports whether response is one of the comma-separated words in
+// the catalog entry key
+func answerIn(response, key string) bool {
+	for _, word := range strings.Split(i18n.T(key), ",") {
+		if response == strings.ToLower(strings.TrimSpace(word)) {
 			return true
-		case "n", "no", "false", "0":
-			return false
-		default:
-			fmt.Printf("%sPlease enter 'y' or 'n' (or press Enter for default).%s\n", Colors.Red, Colors.Reset)
 		}
 	}
+	return false
 }
 
 // PromptString prompts for string input with validation
 func PromptString(message string, defaultValue string, validator func(string) error) (string, error) {
+	if nonInteractive {
+		if defaultValue == "" {
+			return "", inputRequired(message)
+		}
+		if validator != nil {
+			if err := validator(defaultValue); err != nil {
+				return "", fmt.Errorf("%s: invalid default: %w", message, err)
+			}
+		}
+		announceDefault(message, defaultValue)
+		return defaultValue, nil
+	}
+
 	scanner := bufio.NewScanner(os.Stdin)
 	
 	for {
@@ -232,11 +243,11 @@ func PromptInt(message string, defaultValue int, min, max int) (int, error) {
 	validator := func(input string) error {
 		value, err := strconv.Atoi(input)
 		if err != nil {
-			return fmt.Errorf("please enter a valid number")
+			return fmt.Errorf("%s", i18n.T("ui.menu.invalid_number"))
 		}
 		
 		if value < min || value > max {
-			return fmt.Errorf("please enter a number between %d and %d", min, max)
+			return fmt.Errorf("%s", i18n.T("ui.menu.number_range", min, max))
 		}
 		
 		return nil
@@ -257,6 +268,14 @@ func PromptInt(message string, defaultValue int, min, max int) (int, error) {
 
 // PromptChoice prompts for a choice from a list of options
 func PromptChoice(message string, options []string, defaultIndex int) (int, error) {
+	if nonInteractive {
+		if defaultIndex < 0 || defaultIndex >= len(options) {
+			return -1, inputRequired(message)
+		}
+		announceDefault(message, options[defaultIndex])
+		return defaultIndex, nil
+	}
+
 	menu := NewMenu(message, options, false)
 	
 	result, err := menu.Display()
@@ -273,6 +292,12 @@ func PromptChoice(message string, options []string, defaultIndex int) (int, erro
 
 // PromptMultiChoice prompts for multiple choices from a list of options
 func PromptMultiChoice(message string, options []string) ([]int, error) {
+	// With nothing pre-selected, the default answer is the empty selection
+	if nonInteractive {
+		announceDefault(i18n.Text(message), i18n.T("ui.answer.none"))
+		return []int{}, nil
+	}
+
 	menu := NewMenu(message, options, true)
 	
 	result, err := menu.Display()
@@ -287,43 +312,43 @@ func PromptMultiChoice(message string, options []string) ([]int, error) {
 	return []int{}, nil
 }
 
-// DisplayHeader shows a formatted header
+// DisplayHeader shows a header through the current frontend
 func DisplayHeader(title string, subtitle string) {
-	fmt.Printf("\n%s%s%s%s\n", Colors.Cyan, Colors.Bright, strings.Repeat("=", 60), Colors.Reset)
-	fmt.Printf("%s%s%s%s\n", Colors.Cyan, Colors.Bright, centerString(title, 60), Colors.Reset)
-	if subtitle != "" {
-		fmt.Printf("%s%s%s\n", Colors.White, centerString(subtitle, 60), Colors.Reset)
-	}
-	fmt.Printf("%s%s%s%s\n\n", Colors.Cyan, Colors.Bright, strings.Repeat("=", 60), Colors.Reset)
+	CurrentFrontend().Header(i18n.Text(title), i18n.Text(subtitle))
 }
 
 // DisplayInfo shows an info message
 func DisplayInfo(message string) {
-	fmt.Printf("%s[INFO] %s%s\n", Colors.Blue, message, Colors.Reset)
+	CurrentFrontend().Message(LevelInfo, i18n.Text(message))
 }
 
 // DisplaySuccess shows a success message
 func DisplaySuccess(message string) {
-	fmt.Printf("%s[✓] %s%s\n", Colors.Green, message, Colors.Reset)
+	CurrentFrontend().Message(LevelSuccess, i18n.Text(message))
 }
 
 // DisplayWarning shows a warning message
 func DisplayWarning(message string) {
-	fmt.Printf("%s[!] %s%s\n", Colors.Yellow, message, Colors.Reset)
+	CurrentFrontend().Message(LevelWarning, i18n.Text(message))
 }
 
 // DisplayError shows an error message
 func DisplayError(message string) {
-	fmt.Printf("%s[✗] %s%s\n", Colors.Red, message, Colors.Reset)
+	CurrentFrontend().Message(LevelError, i18n.Text(message))
 }
 
 // DisplayStep shows step progress
 func DisplayStep(step, total int, message string) {
-	fmt.Printf("%s[%d/%d] %s%s\n", Colors.Cyan, step, total, message, Colors.Reset)
+	CurrentFrontend().Message(LevelStep, fmt.Sprintf("[%d/%d] %s", step, total, i18n.Text(message)))
 }
 
-// DisplayTable shows data in table format
+// DisplayTable shows data in table format through the current frontend
 func DisplayTable(headers []string, rows [][]string, title string) {
+	CurrentFrontend().Table(headers, rows, title)
+}
+
+// printTable is how the plain and fancy frontends show a table
+func printTable(headers []string, rows [][]string, title string) {
 	if len(rows) == 0 {
 		return
 	}
@@ -390,11 +415,12 @@ func clearScreen() {
 
 // centerString centers text within a given width
 func centerString(text string, width int) string {
-	if len(text) >= width {
+	length := utf8.RuneCountInString(text)
+	if length >= width {
 		return text
 	}
 	
-	padding := width - len(text)
+	padding := width - length
 	leftPad := padding / 2
 	rightPad := padding - leftPad
 	
@@ -449,8 +475,8 @@ func (pb *ProgressBar) Update(current int, message string) {
 		filledWidth = pb.Width
 	}
 	
-	filled := strings.Repeat("█", filledWidth)
-	empty := strings.Repeat("░", pb.Width-filledWidth)
+	filled := strings.Repeat(Icons.BarFull, filledWidth)
+	empty := strings.Repeat(Icons.BarEmpty, pb.Width-filledWidth)
 	
 	// Format progress line
 	status := ""
@@ -493,7 +519,7 @@ type StatusSpinner struct {
 func NewStatusSpinner(message string) *StatusSpinner {
 	return &StatusSpinner{
 		Message: message,
-		chars:   []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"},
+		chars:   Icons.Spinner,
 	}
 }
 
@@ -542,7 +568,7 @@ func (ps *ProfileSelector) SelectProfile() (*InstallationProfile, error) {
 		options[i] = fmt.Sprintf("%s - %s", profile.Name, profile.Description)
 	}
 	
-	menu := NewMenu("Select Installation Profile", options, false)
+	menu := NewMenu(i18n.T("ui.select_profile"), options, false)
 	result, err := menu.Display()
 	if err != nil {
 		return nil, err
@@ -571,7 +597,7 @@ func NewComponentSelector(available []string, preSelected []string) *ComponentSe
 
 // SelectComponents displays component options and returns selected components
 func (cs *ComponentSelector) SelectComponents() ([]string, error) {
-	menu := NewMenu("Select Components to Install", cs.Available, true)
+	menu := NewMenu(i18n.T("ui.select_components"), cs.Available, true)
 	
 	// Pre-select components
 	for i, component := range cs.Available {
@@ -0,0 +1,57 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/i18n"
+	"github.com/mattn/go-isatty"
+)
+
+// ErrInputRequired is returned by prompts that have no safe default when
+// input is disabled
+var ErrInputRequired = errors.New("input required but running non-interactively")
+
+var nonInteractive bool
+
+// SetNonInteractive enables or disables prompting. When disabled, Confirm,
+// PromptChoice and PromptMultiChoice return their defaults and menus
+// without a default fail with ErrInputRequired.
+func SetNonInteractive(enabled bool) {
+	nonInteractive = enabled
+}
+
+// NonInteractive reports whether prompts are disabled
+func NonInteractive() bool {
+	return nonInteractive
+}
+
+// DetectNonInteractive decides whether prompting must be disabled: always
+// with --non-interactive or CREW_NON_INTERACTIVE, and whenever stdin is not
+// a terminal
+func DetectNonInteractive(flag bool) bool {
+	if flag {
+		return true
+	}
+	if v := os.Getenv("CREW_NON_INTERACTIVE"); v != "" && v != "0" && v != "false" {
+		return true
+	}
+	fd := os.Stdin.Fd()
+	return !isatty.IsTerminal(fd) && !isatty.IsCygwinTerminal(fd)
+}
+
+// inputRequired wraps ErrInputRequired with the prompt that needed an answer
+func inputRequired(prompt string) error {
+	return fmt.Errorf("%s: %w", prompt, ErrInputRequired)
+}
+
+// announceDefault records which default a skipped prompt resolved to
+func announceDefault(prompt, answer string) {
+	CurrentFrontend().Answered(prompt, answer)
+}
+
+// announceLine is how the plain and fancy frontends show a default answer
+func announceLine(prompt, answer string) {
+	fmt.Printf("%s%s %s %s%s\n", Colors.Gray, prompt, answer, i18n.T("ui.noninteractive_default"), Colors.Reset)
+}
@@ -0,0 +1,41 @@
+package ui
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNonInteractivePrompts(t *testing.T) {
+	SetNonInteractive(true)
+	defer SetNonInteractive(false)
+
+	if !Confirm("Proceed?", true) || Confirm("Delete?", false) {
+		t.Error("Expected Confirm to return its default")
+	}
+	if choice, err := PromptChoice("Pick", []string{"a", "b"}, 1); err != nil || choice != 1 {
+		t.Errorf("Expected default choice 1, got %d (%v)", choice, err)
+	}
+	if choices, err := PromptMultiChoice("Pick", []string{"a", "b"}); err != nil || len(choices) != 0 {
+		t.Errorf("Expected empty selection, got %v (%v)", choices, err)
+	}
+	if value, err := PromptString("Name", "crew", nil); err != nil || value != "crew" {
+		t.Errorf("Expected default value, got %q (%v)", value, err)
+	}
+
+	if _, err := PromptString("Name", "", nil); !errors.Is(err, ErrInputRequired) {
+		t.Errorf("Expected ErrInputRequired without a default, got %v", err)
+	}
+	if _, err := NewMenu("Select", []string{"a"}, false).Display(); !errors.Is(err, ErrInputRequired) {
+		t.Errorf("Expected ErrInputRequired from a menu, got %v", err)
+	}
+}
+
+func TestDetectNonInteractive(t *testing.T) {
+	if !DetectNonInteractive(true) {
+		t.Error("Expected --non-interactive to force non-interactive mode")
+	}
+	t.Setenv("CREW_NON_INTERACTIVE", "1")
+	if !DetectNonInteractive(false) {
+		t.Error("Expected CREW_NON_INTERACTIVE to force non-interactive mode")
+	}
+}
@@ -0,0 +1,518 @@
+package ui
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/jonwraymond/claude-code-super-crew/pkg/logger"
+	"github.com/mattn/go-isatty"
+	"golang.org/x/term"
+)
+
+// Progress reports per-item progress of a multi-step operation such as
+// installing components. Implementations must be safe for concurrent use.
+type Progress interface {
+	// Start marks an item as running
+	Start(name string)
+	// Done marks an item as finished; a non-nil err marks it failed
+	Done(name string, err error)
+	// Skip marks an item as skipped with a short reason
+	Skip(name, reason string)
+	// Log adds a line to the operation log
+	Log(line string)
+	// Finish stops rendering and restores normal console output
+	Finish()
+}
+
+// ProgressMode selects how progress is presented
+type ProgressMode int
+
+const (
+	// ProgressPlain leaves output to the regular log lines
+	ProgressPlain ProgressMode = iota
+	// ProgressTUI redraws a live multi-line panel on the terminal
+	ProgressTUI
+	// ProgressJSON writes one JSON event per line
+	ProgressJSON
+)
+
+// DetectProgressMode picks the richest mode the environment supports: JSON
+// when requested, the live panel on an interactive terminal, plain logs
+// otherwise (pipes, CI, TERM=dumb, --quiet, or --verbose where every log
+// line should stay visible)
+func DetectProgressMode(quiet, verbose bool, output string) ProgressMode {
+	if output == "json" {
+		return ProgressJSON
+	}
+	if quiet || verbose || os.Getenv("TERM") == "dumb" {
+		return ProgressPlain
+	}
+	fd := os.Stdout.Fd()
+	if isatty.IsTerminal(fd) || isatty.IsCygwinTerminal(fd) {
+		return ProgressTUI
+	}
+	return ProgressPlain
+}
+
+// NewProgress creates a progress reporter for the given items. The TUI and
+// JSON modes take over stdout until Finish is called, so anything else the
+// operation prints lands in the log tail or as JSON log events.
+func NewProgress(mode ProgressMode, title string, items []string) Progress {
+	switch mode {
+	case ProgressTUI:
+		p := newTUIProgress(os.Stdout, title, items)
+		p.restore = captureStdout(p.capture)
+		return p
+	case ProgressJSON:
+		p := newJSONProgress(os.Stdout, title, items)
+		p.restore = captureStdout(p.capture)
+		return p
+	default:
+		return &plainProgress{}
+	}
+}
+
+// ansiEscape matches terminal color sequences in captured output
+var ansiEscape = regexp.MustCompile(`\x1b\[[0-9;]*[A-Za-z]`)
+
+// captureStdout redirects os.Stdout into sink line by line and returns a
+// function that restores it. If no pipe can be created, output is left as is.
+func captureStdout(sink func(line string)) func() {
+	original := os.Stdout
+	r, w, err := os.Pipe()
+	if err != nil {
+		return func() {}
+	}
+	os.Stdout = w
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		scanner := bufio.NewScanner(r)
+		for scanner.Scan() {
+			sink(ansiEscape.ReplaceAllString(scanner.Text(), ""))
+		}
+		// Keep draining so writers never block on a full pipe
+		io.Copy(io.Discard, r)
+	}()
+
+	return func() {
+		os.Stdout = original
+		w.Close()
+		<-done
+		r.Close()
+	}
+}
+
+// itemState is the lifecycle of a single progress item
+type itemState int
+
+const (
+	statePending itemState = iota
+	stateRunning
+	stateDone
+	stateFailed
+	stateSkipped
+)
+
+// progressItem is one row of the progress panel
+type progressItem struct {
+	name    string
+	state   itemState
+	detail  string
+	started time.Time
+	elapsed time.Duration
+}
+
+// plainProgress relies on the log lines the operation already prints
+type plainProgress struct{}
+
+func (p *plainProgress) Start(name string) {}
+
+func (p *plainProgress) Done(name string, err error) {}
+
+func (p *plainProgress) Skip(name, reason string) {
+	logger.GetLogger().Debugf("Skipped %s: %s", name, reason)
+}
+
+func (p *plainProgress) Log(line string) {
+	logger.GetLogger().Info(line)
+}
+
+func (p *plainProgress) Finish() {}
+
+// Log tail length and redraw interval of the live panel
+const (
+	tuiTailLines     = 5
+	tuiFrameInterval = 100 * time.Millisecond
+)
+
+// tuiProgress renders a spinner header, one status line per item and the
+// tail of the operation log, redrawing in place
+type tuiProgress struct {
+	mu       sync.Mutex
+	out      io.Writer
+	title    string
+	items    []*progressItem
+	tail     []string
+	notices  []string
+	frame    int
+	drawn    int
+	width    int
+	restore  func()
+	stop     chan struct{}
+	stopped  chan struct{}
+	finished bool
+}
+
+func newTUIProgress(out io.Writer, title string, names []string) *tuiProgress {
+	p := &tuiProgress{
+		out:     out,
+		title:   title,
+		restore: func() {},
+		stop:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	// A row wider than the terminal wraps and throws off the cursor-up
+	// count of the next frame, so rows are cut to the width
+	if f, ok := out.(*os.File); ok {
+		if width, _, err := term.GetSize(int(f.Fd())); err == nil {
+			p.width = width
+		}
+	}
+	for _, name := range names {
+		p.items = append(p.items, &progressItem{name: name})
+	}
+
+	go p.animate()
+	return p
+}
+
+func (p *tuiProgress) animate() {
+	defer close(p.stopped)
+	ticker := time.NewTicker(tuiFrameInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+			p.mu.Lock()
+			p.frame++
+			p.render()
+			p.mu.Unlock()
+		}
+	}
+}
+
+// item returns the named item, adding it when the operation reports one
+// that was not known up front
+func (p *tuiProgress) item(name string) *progressItem {
+	for _, it := range p.items {
+		if it.name == name {
+			return it
+		}
+	}
+	it := &progressItem{name: name}
+	p.items = append(p.items, it)
+	return it
+}
+
+func (p *tuiProgress) Start(name string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	it := p.item(name)
+	it.state = stateRunning
+	it.started = time.Now()
+	p.render()
+}
+
+func (p *tuiProgress) Done(name string, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	it := p.item(name)
+	if !it.started.IsZero() {
+		it.elapsed = time.Since(it.started)
+	}
+	it.state = stateDone
+	if err != nil {
+		it.state = stateFailed
+		it.detail = err.Error()
+	}
+	p.render()
+}
+
+func (p *tuiProgress) Skip(name, reason string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	it := p.item(name)
+	it.state = stateSkipped
+	it.detail = reason
+	p.render()
+}
+
+func (p *tuiProgress) Log(line string) {
+	p.capture(line)
+}
+
+// capture receives output printed while the panel is shown. Warnings and
+// errors are also kept to print once the panel is gone.
+func (p *tuiProgress) capture(line string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if strings.TrimSpace(line) == "" {
+		return
+	}
+	p.tail = append(p.tail, line)
+	if len(p.tail) > tuiTailLines {
+		p.tail = p.tail[len(p.tail)-tuiTailLines:]
+	}
+	if level := lineLevel(line); level == "warn" || level == "error" {
+		p.notices = append(p.notices, line)
+	}
+	if !p.finished {
+		p.render()
+	}
+}
+
+// lineLevel guesses the level of a captured log line from the markers the
+// logger prints for warnings and errors
+func lineLevel(line string) string {
+	switch {
+	case strings.Contains(line, "❌"), strings.Contains(line, "ERROR"):
+		return "error"
+	case strings.Contains(line, "⚠"), strings.Contains(line, "WARN"):
+		return "warn"
+	default:
+		return "info"
+	}
+}
+
+func (p *tuiProgress) Finish() {
+	p.mu.Lock()
+	if p.finished {
+		p.mu.Unlock()
+		return
+	}
+	p.finished = true
+	p.mu.Unlock()
+
+	close(p.stop)
+	<-p.stopped
+	p.restore()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// Leave the item list on screen but drop the log tail
+	p.tail = nil
+	p.render()
+
+	for _, notice := range p.notices {
+		fmt.Fprintf(p.out, "%s%s%s\n", Colors.Yellow, notice, Colors.Reset)
+	}
+}
+
+// render redraws the panel over the previous frame. Callers hold p.mu.
+func (p *tuiProgress) render() {
+	var b strings.Builder
+	if p.drawn > 0 {
+		fmt.Fprintf(&b, "\033[%dA", p.drawn)
+	}
+
+	lines := p.lines()
+	for _, line := range lines {
+		b.WriteString("\r\033[2K")
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	// Clear rows left over from a taller previous frame
+	for i := len(lines); i < p.drawn; i++ {
+		b.WriteString("\r\033[2K\n")
+	}
+	if extra := p.drawn - len(lines); extra > 0 {
+		fmt.Fprintf(&b, "\033[%dA", extra)
+	}
+
+	p.drawn = len(lines)
+	io.WriteString(p.out, b.String())
+}
+
+// lines builds the panel content
+func (p *tuiProgress) lines() []string {
+	completed := 0
+	width := 0
+	for _, it := range p.items {
+		if it.state == stateDone || it.state == stateFailed || it.state == stateSkipped {
+			completed++
+		}
+		if len(it.name) > width {
+			width = len(it.name)
+		}
+	}
+
+	header := fmt.Sprintf("%s%s %s%s  %d/%d", Colors.Cyan, Icons.Spinner[p.frame%len(Icons.Spinner)], p.title, Colors.Reset, completed, len(p.items))
+	if p.finished {
+		header = fmt.Sprintf("%s%s%s  %d/%d", Colors.Cyan, p.title, Colors.Reset, completed, len(p.items))
+	}
+
+	lines := []string{header}
+	for _, it := range p.items {
+		lines = append(lines, p.itemLine(it, width))
+	}
+
+	if len(p.tail) > 0 {
+		lines = append(lines, "")
+		for _, line := range p.tail {
+			lines = append(lines, fmt.Sprintf("  %s%s %s%s", Colors.Gray, Icons.TreePipe, line, Colors.Reset))
+		}
+	}
+	if p.width > 0 {
+		for i, line := range lines {
+			lines[i] = truncateVisible(line, p.width)
+		}
+	}
+	return lines
+}
+
+// truncateVisible cuts line to width visible columns. Color sequences do
+// not count towards the width and are kept, so a cut line still resets.
+func truncateVisible(line string, width int) string {
+	var b strings.Builder
+	columns := 0
+	for len(line) > 0 {
+		if loc := ansiEscape.FindStringIndex(line); loc != nil && loc[0] == 0 {
+			b.WriteString(line[:loc[1]])
+			line = line[loc[1]:]
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(line)
+		if !zeroWidth(r) {
+			if columns == width {
+				b.WriteString(Colors.Reset)
+				return b.String()
+			}
+			columns++
+		}
+		b.WriteString(line[:size])
+		line = line[size:]
+	}
+	return b.String()
+}
+
+// zeroWidth reports whether r takes no column of its own, as the variation
+// selectors and joiners of emoji icons
+func zeroWidth(r rune) bool {
+	return r == '\u200d' || r == '\ufe0f' || unicode.In(r, unicode.Mn, unicode.Me)
+}
+
+func (p *tuiProgress) itemLine(it *progressItem, width int) string {
+	var icon, color, suffix string
+	switch it.state {
+	case stateRunning:
+		icon, color = Icons.Spinner[p.frame%len(Icons.Spinner)], Colors.Blue
+		suffix = time.Since(it.started).Truncate(100 * time.Millisecond).String()
+	case stateDone:
+		icon, color = Icons.Check, Colors.Green
+		suffix = it.elapsed.Truncate(10 * time.Millisecond).String()
+	case stateFailed:
+		icon, color = Icons.Cross, Colors.Red
+		suffix = it.detail
+	case stateSkipped:
+		icon, color = "-", Colors.Gray
+		suffix = it.detail
+	default:
+		icon, color = Icons.Bullet, Colors.Gray
+	}
+
+	return fmt.Sprintf("  %s%s %-*s%s  %s", color, icon, width, it.name, Colors.Reset, suffix)
+}
+
+// jsonProgress writes newline-delimited JSON events so scripts can follow
+// an operation; captured log lines become "log" events
+type jsonProgress struct {
+	mu       sync.Mutex
+	enc      *json.Encoder
+	restore  func()
+	finished bool
+}
+
+// progressEvent is a single line of JSON progress output, also written by
+// the JSON frontend for headers, messages, tables and prompts
+type progressEvent struct {
+	Event   string     `json:"event"`
+	Time    time.Time  `json:"time"`
+	Title   string     `json:"title,omitempty"`
+	Items   []string   `json:"items,omitempty"`
+	Name    string     `json:"name,omitempty"`
+	Level   string     `json:"level,omitempty"`
+	Message string     `json:"message,omitempty"`
+	Error   string     `json:"error,omitempty"`
+	Headers []string   `json:"headers,omitempty"`
+	Rows    [][]string `json:"rows,omitempty"`
+	Answer  string     `json:"answer,omitempty"`
+}
+
+func newJSONProgress(out io.Writer, title string, items []string) *jsonProgress {
+	p := &jsonProgress{enc: json.NewEncoder(out), restore: func() {}}
+	p.emit(progressEvent{Event: "begin", Title: title, Items: items})
+	return p
+}
+
+func (p *jsonProgress) emit(ev progressEvent) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	ev.Time = time.Now().UTC()
+	p.enc.Encode(ev)
+}
+
+func (p *jsonProgress) Start(name string) {
+	p.emit(progressEvent{Event: "start", Name: name})
+}
+
+func (p *jsonProgress) Done(name string, err error) {
+	if err != nil {
+		p.emit(progressEvent{Event: "failed", Name: name, Error: err.Error()})
+		return
+	}
+	p.emit(progressEvent{Event: "done", Name: name})
+}
+
+func (p *jsonProgress) Skip(name, reason string) {
+	p.emit(progressEvent{Event: "skipped", Name: name, Message: reason})
+}
+
+func (p *jsonProgress) Log(line string) {
+	p.capture(line)
+}
+
+func (p *jsonProgress) capture(line string) {
+	if strings.TrimSpace(line) == "" {
+		return
+	}
+	p.emit(progressEvent{Event: "log", Level: lineLevel(line), Message: strings.TrimSpace(line)})
+}
+
+func (p *jsonProgress) Finish() {
+	p.mu.Lock()
+	if p.finished {
+		p.mu.Unlock()
+		return
+	}
+	p.finished = true
+	p.mu.Unlock()
+
+	p.restore()
+	p.emit(progressEvent{Event: "end"})
+}
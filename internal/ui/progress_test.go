@@ -0,0 +1,92 @@
+package ui
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestJSONProgressEvents(t *testing.T) {
+	var out bytes.Buffer
+	p := newJSONProgress(&out, "Installing", []string{"core", "hooks"})
+	p.Start("core")
+	p.Done("core", nil)
+	p.Log("copying hooks")
+	p.Done("hooks", errors.New("boom"))
+	p.Finish()
+	p.Finish()
+
+	var events []progressEvent
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		var ev progressEvent
+		if err := json.Unmarshal([]byte(line), &ev); err != nil {
+			t.Fatalf("Invalid JSON line %q: %v", line, err)
+		}
+		events = append(events, ev)
+	}
+
+	want := []string{"begin", "start", "done", "log", "failed", "end"}
+	if len(events) != len(want) {
+		t.Fatalf("Expected %d events, got %d: %s", len(want), len(events), out.String())
+	}
+	for i, ev := range events {
+		if ev.Event != want[i] {
+			t.Errorf("Event %d: expected %s, got %s", i, want[i], ev.Event)
+		}
+	}
+	if events[4].Error != "boom" {
+		t.Errorf("Expected failure error to be reported, got %q", events[4].Error)
+	}
+}
+
+func TestTUIProgressRender(t *testing.T) {
+	var out bytes.Buffer
+	p := newTUIProgress(&out, "Installing", []string{"core", "hooks", "mcp"})
+	p.Start("core")
+	p.capture("⚠️  node not found")
+	p.Done("core", nil)
+	p.Done("hooks", errors.New("permission denied"))
+	p.Skip("mcp", "not selected")
+	p.Finish()
+
+	rendered := out.String()
+	for _, want := range []string{"✓ core", "✗ hooks", "permission denied", "- mcp", "3/3", "node not found"} {
+		if !strings.Contains(rendered, want) {
+			t.Errorf("Expected output to contain %q:\n%s", want, rendered)
+		}
+	}
+
+	if len(p.notices) != 1 {
+		t.Errorf("Expected the warning to be kept for after the panel, got %v", p.notices)
+	}
+}
+
+func TestLineLevel(t *testing.T) {
+	cases := map[string]string{
+		"❌ Failed to install core": "error",
+		"⚠️  Slow disk":            "warn",
+		"Installing core...":       "info",
+	}
+	for line, want := range cases {
+		if got := lineLevel(line); got != want {
+			t.Errorf("lineLevel(%q) = %s, want %s", line, got, want)
+		}
+	}
+}
+
+func TestTruncateVisible(t *testing.T) {
+	line := "\x1b[32m✓ core\x1b[0m  1.2s"
+	if got := truncateVisible(line, 40); got != line {
+		t.Errorf("Expected a short line to be kept, got %q", got)
+	}
+
+	got := truncateVisible(line, 4)
+	if visible := ansiEscape.ReplaceAllString(got, ""); visible != "✓ co" {
+		t.Errorf("Expected 4 visible columns, got %q", visible)
+	}
+	if !strings.HasSuffix(got, Colors.Reset) {
+		t.Errorf("Expected a cut line to end with a color reset, got %q", got)
+	}
+}
@@ -0,0 +1,140 @@
+package ui
+
+import (
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/jonwraymond/claude-code-super-crew/pkg/logger"
+	"github.com/mattn/go-isatty"
+)
+
+// IconSet holds the markers used in command output
+type IconSet struct {
+	Success string
+	Failure string
+	Warning string
+	Check   string
+	Cross   string
+	Tip     string
+	Arrow   string
+	Bullet  string
+	Unknown string
+	Refresh string
+
+	// Status markers used by integrity and status reports
+	StatusOK       string
+	StatusWarn     string
+	StatusError    string
+	StatusCritical string
+	StatusUnknown  string
+
+	TreeBranch string
+	TreeLast   string
+	TreePipe   string
+	Rule       string
+	LightRule  string
+	BarFull    string
+	BarEmpty   string
+	Spinner    []string
+}
+
+var unicodeIcons = IconSet{
+	Success: "✅", Failure: "❌", Warning: "⚠️ ", Check: "✓", Cross: "✗",
+	Tip: "💡", Arrow: "→", Bullet: "•", Unknown: "❓", Refresh: "🔄",
+	StatusOK: "🟢", StatusWarn: "🟡", StatusError: "🔴", StatusCritical: "⚫", StatusUnknown: "⚪",
+	TreeBranch: "├─", TreeLast: "└─", TreePipe: "│", Rule: "═", LightRule: "─",
+	BarFull: "█", BarEmpty: "░",
+	Spinner: []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"},
+}
+
+var asciiIcons = IconSet{
+	Success: "[OK]", Failure: "[FAIL]", Warning: "[WARN]", Check: "+", Cross: "x",
+	Tip: "Tip:", Arrow: "->", Bullet: "*", Unknown: "?", Refresh: "~",
+	StatusOK: "[OK]", StatusWarn: "[WARN]", StatusError: "[ERROR]", StatusCritical: "[CRITICAL]", StatusUnknown: "[UNKNOWN]",
+	TreeBranch: "|-", TreeLast: "`-", TreePipe: "|", Rule: "=", LightRule: "-",
+	BarFull: "#", BarEmpty: "-",
+	Spinner: []string{"|", "/", "-", "\\"},
+}
+
+// Icons is the active icon set; Configure switches it to ASCII on request
+var Icons = unicodeIcons
+
+var defaultColors = Colors
+
+// StyleOptions describes how terminal output should be styled
+type StyleOptions struct {
+	Color bool
+	ASCII bool
+}
+
+var currentStyle = StyleOptions{Color: true}
+
+// DetectColor decides whether color output should be used. The --no-color
+// flag and a non-empty NO_COLOR always disable it, CLICOLOR_FORCE enables
+// it, and otherwise color requires CLICOLOR!=0 and a terminal on stdout.
+func DetectColor(noColorFlag bool) bool {
+	if noColorFlag {
+		return false
+	}
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	if v := os.Getenv("CLICOLOR_FORCE"); v != "" && v != "0" {
+		return true
+	}
+	if os.Getenv("CLICOLOR") == "0" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	fd := os.Stdout.Fd()
+	return isatty.IsTerminal(fd) || isatty.IsCygwinTerminal(fd)
+}
+
+// Configure applies the style to the ui package, fatih/color and the
+// console logger so every command renders consistently
+func Configure(opts StyleOptions) {
+	currentStyle = opts
+
+	palette := defaultColors
+	if !opts.Color {
+		palette = colorPalette{}
+	}
+	Colors = palette
+	ColorReset, ColorBright, ColorDim = palette.Reset, palette.Bright, ""
+	if opts.Color {
+		ColorDim = "\033[2m"
+	}
+	ColorRed, ColorGreen, ColorYellow = palette.Red, palette.Green, palette.Yellow
+	ColorBlue, ColorMagenta, ColorCyan = palette.Blue, palette.Magenta, palette.Cyan
+	ColorWhite, ColorGray = palette.White, palette.Gray
+
+	Icons = unicodeIcons
+	if opts.ASCII {
+		Icons = asciiIcons
+	}
+
+	color.NoColor = !opts.Color
+	logger.SetConsoleStyle(opts.Color, opts.ASCII)
+}
+
+// CurrentStyle returns the style last applied by Configure
+func CurrentStyle() StyleOptions {
+	return currentStyle
+}
+
+// Emoji returns s unless ASCII output is requested, in which case decorative
+// emoji are dropped entirely
+func Emoji(s string) string {
+	if currentStyle.ASCII {
+		return ""
+	}
+	return s
+}
+
+// Paint wraps text in a color code, or returns it unchanged when color
+// output is disabled
+func Paint(code, text string) string {
+	if !currentStyle.Color || code == "" {
+		return text
+	}
+	return code + text + defaultColors.Reset
+}
@@ -0,0 +1,48 @@
+package ui
+
+import (
+	"testing"
+)
+
+func TestDetectColor(t *testing.T) {
+	t.Setenv("CLICOLOR_FORCE", "1")
+	if !DetectColor(false) {
+		t.Error("Expected CLICOLOR_FORCE to enable color")
+	}
+	if DetectColor(true) {
+		t.Error("Expected --no-color to override CLICOLOR_FORCE")
+	}
+
+	// no-color.org: an empty NO_COLOR does not count
+	t.Setenv("NO_COLOR", "")
+	if !DetectColor(false) {
+		t.Error("Expected an empty NO_COLOR to keep color")
+	}
+	t.Setenv("NO_COLOR", "1")
+	if DetectColor(false) {
+		t.Error("Expected NO_COLOR to disable color")
+	}
+}
+
+func TestConfigurePlainASCII(t *testing.T) {
+	defer Configure(StyleOptions{Color: true})
+
+	Configure(StyleOptions{Color: false, ASCII: true})
+	if ColorGreen != "" || Colors.Reset != "" {
+		t.Errorf("Expected color codes to be cleared, got %q %q", ColorGreen, Colors.Reset)
+	}
+	if Icons.Success != "[OK]" || Icons.TreeBranch != "|-" {
+		t.Errorf("Expected ASCII icons, got %q %q", Icons.Success, Icons.TreeBranch)
+	}
+	if got := Paint(defaultColors.Red, "text"); got != "text" {
+		t.Errorf("Expected unstyled text, got %q", got)
+	}
+	if got := Emoji("🔍 "); got != "" {
+		t.Errorf("Expected emoji to be dropped, got %q", got)
+	}
+
+	Configure(StyleOptions{Color: true})
+	if ColorGreen != "\033[32m" || Icons.Success != "✅" {
+		t.Errorf("Expected default styling to be restored, got %q %q", ColorGreen, Icons.Success)
+	}
+}
@@ -5,11 +5,12 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"strconv"
 	"strings"
 	"time"
 
 	"github.com/jonwraymond/claude-code-super-crew/internal/core"
+	"github.com/jonwraymond/claude-code-super-crew/internal/crewdirs"
+	"github.com/jonwraymond/claude-code-super-crew/internal/semver"
 )
 
 // VersionManager handles version tracking and updates for Claude Code Super Crew
@@ -22,7 +23,7 @@ type VersionManager struct {
 func NewVersionManager(installDir string) *VersionManager {
 	return &VersionManager{
 		installDir:     installDir,
-		metadataFile:   filepath.Join(installDir, ".crew", "config", "crew-metadata.json"),
+		metadataFile:   crewdirs.Path(installDir, "config", "crew-metadata.json"),
 	}
 }
 
@@ -149,38 +150,16 @@ func (vm *VersionManager) CheckForUpdates(targetVersion string) (bool, error) {
 	return comparison < 0, nil
 }
 
-// CompareVersions compares two semantic versions
+// CompareVersions compares two semantic versions, including prereleases
 // Returns: -1 if v1 < v2, 0 if v1 == v2, 1 if v1 > v2
 func (vm *VersionManager) CompareVersions(v1, v2 string) int {
-	parts1 := vm.parseVersion(v1)
-	parts2 := vm.parseVersion(v2)
-	
-	for i := 0; i < 3; i++ {
-		if parts1[i] < parts2[i] {
-			return -1
-		}
-		if parts1[i] > parts2[i] {
-			return 1
-		}
-	}
-	
-	return 0
+	return semver.Compare(v1, v2)
 }
 
-// IsValidVersion checks if a version string follows semantic versioning
+// IsValidVersion checks if a version string follows semantic versioning.
+// Stored versions never carry a "v" prefix.
 func (vm *VersionManager) IsValidVersion(version string) bool {
-	parts := strings.Split(version, ".")
-	if len(parts) != 3 {
-		return false
-	}
-	
-	for _, part := range parts {
-		if _, err := strconv.Atoi(part); err != nil {
-			return false
-		}
-	}
-	
-	return true
+	return !strings.HasPrefix(version, "v") && semver.Valid(version)
 }
 
 // LoadMetadata loads the installation metadata
@@ -242,9 +221,9 @@ func (vm *VersionManager) GetVersionHistory() ([]string, error) {
 	return history, nil
 }
 
-// StandardizeAllVersions ensures all components use version 1.0.0
+// StandardizeAllVersions ensures all components use the framework version
 func (vm *VersionManager) StandardizeAllVersions() error {
-	standardVersion := "1.0.0"
+	standardVersion := core.FrameworkVersion
 	
 	// Set framework version
 	if err := vm.SetVersion(standardVersion); err != nil {
@@ -262,19 +241,6 @@ func (vm *VersionManager) StandardizeAllVersions() error {
 	return nil
 }
 
-// parseVersion parses a semantic version string into major, minor, patch
-func (vm *VersionManager) parseVersion(version string) [3]int {
-	var result [3]int
-	parts := strings.Split(version, ".")
-	
-	for i := 0; i < 3 && i < len(parts); i++ {
-		val, _ := strconv.Atoi(parts[i])
-		result[i] = val
-	}
-	
-	return result
-}
-
 // UpdateInfo represents information about an available update
 type UpdateInfo struct {
 	CurrentVersion   string `json:"current_version"`
@@ -52,6 +52,8 @@ func TestVersionManager(t *testing.T) {
 			{"1.0.0", "1.0.0", 0},
 			{"1.0.0", "1.0.1", -1},
 			{"1.0.1", "1.0.0", 1},
+			{"1.10.0", "1.9.0", 1},
+			{"1.0.0-rc.1", "1.0.0", -1},
 			{"1.0.0", "2.0.0", -1},
 			{"2.0.0", "1.9.9", 1},
 			{"1.2.3", "1.2.3", 0},
@@ -74,6 +76,7 @@ func TestVersionManager(t *testing.T) {
 			{"1.0.0", true},
 			{"0.0.1", true},
 			{"10.20.30", true},
+			{"1.0.0-rc.1", true},
 			{"1.0", false},
 			{"1.0.0.0", false},
 			{"v1.0.0", false},
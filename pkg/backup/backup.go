@@ -3,34 +3,71 @@ package backup
 import (
 	"archive/tar"
 	"compress/gzip"
-	"crypto/sha256"
-	"encoding/hex"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"sort"
 	"strings"
 	"time"
 
+	"github.com/jonwraymond/claude-code-super-crew/internal/crewignore"
+	"github.com/jonwraymond/claude-code-super-crew/internal/fileops"
+	"github.com/jonwraymond/claude-code-super-crew/internal/fsys"
 	"github.com/jonwraymond/claude-code-super-crew/internal/managers"
+	"github.com/jonwraymond/claude-code-super-crew/internal/pgzip"
 	"github.com/jonwraymond/claude-code-super-crew/internal/versioning"
 	"github.com/jonwraymond/claude-code-super-crew/pkg/logger"
 )
 
+// CompressionMethods are the values Options.Compress accepts; empty is
+// the same as none
+var CompressionMethods = []string{"none", "gzip", "bzip2"}
+
+// ValidCompression reports whether Create accepts the compression method
+func ValidCompression(method string) bool {
+	if method == "" {
+		return true
+	}
+	for _, known := range CompressionMethods {
+		if method == known {
+			return true
+		}
+	}
+	return false
+}
+
 // Options holds backup configuration
 type Options struct {
 	InstallDir    string
 	BackupDir     string
 	BackupName    string
 	Compress      string // none, gzip, bzip2
+	// CompressLevel is the gzip level from 1 (fastest) to 9 (smallest);
+	// 0 uses the gzip default
+	CompressLevel int
+	// CompressThreads is how many CPUs compress gzip backups; 0 uses all
+	CompressThreads int
 	Verbose       bool
 	DryRun        bool
 	Overwrite     bool
 	IncludeConfig bool
 	IncludeLogs   bool
 	Description   string
+	// ProjectDir switches to a project backup: Create archives the
+	// project's .claude directory and CLAUDE.md, Restore extracts into the
+	// project, and Cleanup only considers that project's backups
+	ProjectDir string
+	// OnFile, when set, is called with the relative path of each file
+	// added to the archive
+	OnFile func(relPath string)
+	// FS is the filesystem backups are read from and written to; nil
+	// uses the OS filesystem
+	FS fsys.FS
 }
 
 // BackupMetadata represents backup metadata
@@ -45,8 +82,20 @@ type BackupMetadata struct {
 	Checksum         string            `json:"checksum"`
 	BackupType       string            `json:"backup_type"`
 	Description      string            `json:"description"`
+	ProjectDir       string            `json:"project_dir,omitempty"`
+	ProjectName      string            `json:"project_name,omitempty"`
 }
 
+// Backup types
+const (
+	TypeFull    = "full"
+	TypeProject = "project"
+)
+
+// ProjectEntries are the paths of a project, relative to its root, that a
+// project backup archives
+var ProjectEntries = []string{".claude", "CLAUDE.md"}
+
 // BackupInfo represents information about a backup
 type BackupInfo struct {
 	Path      string
@@ -58,24 +107,37 @@ type BackupInfo struct {
 	Error     error
 }
 
+// ProjectDir returns the project a backup belongs to, or "" for a backup
+// of the installation
+func (b BackupInfo) ProjectDir() string {
+	if b.Metadata == nil || b.Metadata.BackupType != TypeProject {
+		return ""
+	}
+	return b.Metadata.ProjectDir
+}
+
 // Manager handles backup operations
 type Manager struct {
 	opts        Options
+	fs          fsys.FS
 	logger      logger.Logger
 	fileManager *managers.FileManager
 }
 
 // NewManager creates a new backup manager
 func NewManager(opts Options) *Manager {
+	filesystem := fsys.Or(opts.FS)
 	return &Manager{
 		opts:        opts,
-		logger:      logger.GetLogger(),
-		fileManager: managers.NewFileManager(),
+		fs:          filesystem,
+		logger:      logger.With("op", "backup"),
+		fileManager: managers.NewFileManagerFS(filesystem),
 	}
 }
 
-// Create creates a new backup
-func (m *Manager) Create() (string, error) {
+// Create creates a new backup. A cancelled ctx stops it between files and
+// removes the partial archive.
+func (m *Manager) Create(ctx context.Context) (string, error) {
 	// Generate timestamp
 	timestamp := time.Now().Format("20060102_150405")
 	backupName := fmt.Sprintf("%s_%s", m.opts.BackupName, timestamp)
@@ -97,9 +159,11 @@ func (m *Manager) Create() (string, error) {
 	case "bzip2":
 		backupFile = filepath.Join(m.opts.BackupDir, backupName+".tar.bz2")
 		mode = "bz2"
-	default:
+	case "none", "":
 		backupFile = filepath.Join(m.opts.BackupDir, backupName+".tar")
 		mode = "none"
+	default:
+		return "", fmt.Errorf("unknown compression method %q (use %s)", m.opts.Compress, strings.Join(CompressionMethods, ", "))
 	}
 
 	if m.opts.Verbose {
@@ -107,7 +171,7 @@ func (m *Manager) Create() (string, error) {
 	}
 
 	// Ensure backup directory exists
-	if err := os.MkdirAll(m.opts.BackupDir, 0755); err != nil {
+	if err := m.fs.MkdirAll(m.opts.BackupDir, 0755); err != nil {
 		return "", fmt.Errorf("failed to create backup directory: %w", err)
 	}
 
@@ -115,17 +179,30 @@ func (m *Manager) Create() (string, error) {
 	metadata := m.createBackupMetadata()
 
 	// Create backup file
-	file, err := os.Create(backupFile)
+	file, err := m.fs.Create(backupFile)
 	if err != nil {
 		return "", fmt.Errorf("failed to create backup file: %w", err)
 	}
+	complete := false
+	defer func() {
+		if !complete {
+			file.Close()
+			m.fs.Remove(backupFile)
+		}
+	}()
 	defer file.Close()
 
 	// Create tar writer with optional compression
 	var tarWriter *tar.Writer
-	var gzWriter *gzip.Writer
+	var gzWriter io.WriteCloser
 	if mode == "gz" {
-		gzWriter = gzip.NewWriter(file)
+		level := m.opts.CompressLevel
+		if level == 0 {
+			level = gzip.DefaultCompression
+		}
+		if gzWriter, err = pgzip.NewWriter(file, level, m.opts.CompressThreads); err != nil {
+			return "", err
+		}
 		defer gzWriter.Close()
 		tarWriter = tar.NewWriter(gzWriter)
 	} else {
@@ -154,9 +231,29 @@ func (m *Manager) Create() (string, error) {
 		return "", fmt.Errorf("failed to write metadata: %w", err)
 	}
 
-	// Add installation directory contents
+	// Add installation directory contents, or the project entries
+	root, paths := m.root(), []string{m.opts.InstallDir}
+	if m.opts.ProjectDir != "" {
+		paths = nil
+		for _, entry := range ProjectEntries {
+			paths = append(paths, filepath.Join(root, entry))
+		}
+	}
+
+	// The installation's .crewignore excludes caches and data users keep
+	// under it; project backups only cover crew's own entries
+	ignore := &crewignore.Matcher{}
+	if m.opts.ProjectDir == "" {
+		if ignore, err = m.loadIgnore(root); err != nil {
+			m.logger.Warnf("Ignoring unreadable %s: %v", crewignore.FileName, err)
+		}
+	}
+
 	filesAdded := 0
-	err = filepath.Walk(m.opts.InstallDir, func(path string, info os.FileInfo, err error) error {
+	walk := func(path string, info os.FileInfo, err error) error {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
 		if err != nil {
 			return nil // Skip files with errors
 		}
@@ -165,6 +262,12 @@ func (m *Manager) Create() (string, error) {
 		if path == backupFile {
 			return nil
 		}
+		if ignore.MatchPath(root, path, info.IsDir()) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
 
 		// Skip files based on options
 		if !m.shouldIncludeFile(path, info) {
@@ -172,7 +275,7 @@ func (m *Manager) Create() (string, error) {
 		}
 
 		// Create relative path
-		relPath, err := filepath.Rel(m.opts.InstallDir, path)
+		relPath, err := filepath.Rel(root, path)
 		if err != nil {
 			return nil
 		}
@@ -192,25 +295,36 @@ func (m *Manager) Create() (string, error) {
 
 		// Write file content if not a directory
 		if !info.IsDir() {
-			file, err := os.Open(path)
+			file, err := m.fs.Open(path)
 			if err != nil {
 				return nil
 			}
 			defer file.Close()
 
-			if _, err := io.Copy(tarWriter, file); err != nil {
+			if _, err := fileops.Copy(tarWriter, file); err != nil {
 				m.logger.Warnf("Could not copy %s to backup: %v", path, err)
 				return nil
 			}
 
 			filesAdded++
+			if m.opts.OnFile != nil {
+				m.opts.OnFile(relPath)
+			}
 			if filesAdded%10 == 0 && m.opts.Verbose {
 				m.logger.Debugf("Added %d files to backup", filesAdded)
 			}
 		}
 
 		return nil
-	})
+	}
+	for _, path := range paths {
+		if !fsys.Exists(m.fs, path) && m.opts.ProjectDir != "" {
+			continue
+		}
+		if err = fsys.Walk(m.fs, path, walk); err != nil {
+			break
+		}
+	}
 
 	if err != nil {
 		return "", fmt.Errorf("failed to create backup: %w", err)
@@ -232,9 +346,10 @@ func (m *Manager) Create() (string, error) {
 	if err := file.Close(); err != nil {
 		return "", fmt.Errorf("failed to close backup file: %w", err)
 	}
+	complete = true
 
 	// Calculate final size and checksum
-	backupInfo, err := os.Stat(backupFile)
+	backupInfo, err := m.fs.Stat(backupFile)
 	if err != nil {
 		return "", fmt.Errorf("failed to get backup file info: %w", err)
 	}
@@ -261,8 +376,10 @@ func (m *Manager) Create() (string, error) {
 	return backupFile, nil
 }
 
-// Restore restores from a backup file
-func (m *Manager) Restore(backupFile string) error {
+// Restore restores from a backup file. A cancelled ctx stops it between
+// files; files already restored are kept, so running it again without
+// Overwrite picks up where it stopped.
+func (m *Manager) Restore(ctx context.Context, backupFile string) error {
 	if m.opts.Verbose {
 		m.logger.Infof("Restoring from %s", backupFile)
 	}
@@ -286,7 +403,7 @@ func (m *Manager) Restore(backupFile string) error {
 	}
 
 	// Open backup file
-	file, err := os.Open(backupFile)
+	file, err := m.fs.Open(backupFile)
 	if err != nil {
 		return fmt.Errorf("failed to open backup file: %w", err)
 	}
@@ -308,6 +425,9 @@ func (m *Manager) Restore(backupFile string) error {
 	// Extract files
 	filesRestored := 0
 	for {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("restore stopped after %d files: %w", filesRestored, err)
+		}
 		header, err := tarReader.Next()
 		if err == io.EOF {
 			break
@@ -321,41 +441,41 @@ func (m *Manager) Restore(backupFile string) error {
 			continue
 		}
 
-		targetPath := filepath.Join(m.opts.InstallDir, header.Name)
+		targetPath := filepath.Join(m.root(), header.Name)
 
 		// Security check: ensure path is within target directory
-		if !strings.HasPrefix(targetPath, m.opts.InstallDir) {
+		if !strings.HasPrefix(targetPath, m.root()) {
 			return fmt.Errorf("invalid path in backup: %s", header.Name)
 		}
 
 		// Check if file exists and overwrite flag
-		if _, err := os.Stat(targetPath); err == nil && !m.opts.Overwrite {
+		if _, err := m.fs.Stat(targetPath); err == nil && !m.opts.Overwrite {
 			m.logger.Warnf("Skipping existing file: %s", targetPath)
 			continue
 		}
 
 		// Create directory if needed
 		if header.Typeflag == tar.TypeDir {
-			if err := os.MkdirAll(targetPath, os.FileMode(header.Mode)); err != nil {
+			if err := m.fs.MkdirAll(targetPath, os.FileMode(header.Mode)); err != nil {
 				m.logger.Warnf("Could not create directory %s: %v", targetPath, err)
 			}
 			continue
 		}
 
 		// Create parent directory
-		if err := os.MkdirAll(filepath.Dir(targetPath), 0755); err != nil {
+		if err := m.fs.MkdirAll(filepath.Dir(targetPath), 0755); err != nil {
 			m.logger.Warnf("Could not create parent directory for %s: %v", targetPath, err)
 			continue
 		}
 
 		// Extract file
-		outFile, err := os.OpenFile(targetPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.FileMode(header.Mode))
+		outFile, err := m.fs.OpenFile(targetPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.FileMode(header.Mode))
 		if err != nil {
 			m.logger.Warnf("Could not create file %s: %v", targetPath, err)
 			continue
 		}
 
-		if _, err := io.Copy(outFile, tarReader); err != nil {
+		if _, err := fileops.Copy(outFile, tarReader); err != nil {
 			outFile.Close()
 			m.logger.Warnf("Could not extract file %s: %v", targetPath, err)
 			continue
@@ -363,7 +483,7 @@ func (m *Manager) Restore(backupFile string) error {
 		outFile.Close()
 
 		// Set modification time
-		if err := os.Chtimes(targetPath, header.ModTime, header.ModTime); err != nil {
+		if err := m.fs.Chtimes(targetPath, header.ModTime, header.ModTime); err != nil {
 			m.logger.Warnf("Failed to set modification time for %s: %v", targetPath, err)
 		}
 
@@ -383,12 +503,12 @@ func (m *Manager) ListBackups() ([]BackupInfo, error) {
 	backups := []BackupInfo{}
 
 	// Check if backup directory exists
-	if _, err := os.Stat(m.opts.BackupDir); os.IsNotExist(err) {
+	if !fsys.Exists(m.fs, m.opts.BackupDir) {
 		return backups, nil
 	}
 
 	// Find all backup files
-	err := filepath.Walk(m.opts.BackupDir, func(path string, info os.FileInfo, err error) error {
+	err := fsys.Walk(m.fs, m.opts.BackupDir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return nil // Skip files with errors
 		}
@@ -428,7 +548,7 @@ func (m *Manager) GetBackupInfo(backupPath string) BackupInfo {
 	}
 
 	// Check if file exists
-	fileInfo, err := os.Stat(backupPath)
+	fileInfo, err := m.fs.Stat(backupPath)
 	if err != nil {
 		info.Error = err
 		return info
@@ -467,6 +587,15 @@ func (m *Manager) Cleanup(keep int, olderThan int) (int, error) {
 		return 0, err
 	}
 
+	// Installation and project backups are kept separately
+	scoped := backups[:0]
+	for _, backup := range backups {
+		if backup.ProjectDir() == m.opts.ProjectDir {
+			scoped = append(scoped, backup)
+		}
+	}
+	backups = scoped
+
 	if len(backups) == 0 {
 		return 0, nil
 	}
@@ -505,7 +634,7 @@ func (m *Manager) Cleanup(keep int, olderThan int) (int, error) {
 	removed := 0
 	for _, backup := range uniqueToRemove {
 		// Remove backup file
-		if err := os.Remove(backup.Path); err != nil {
+		if err := m.fs.Remove(backup.Path); err != nil {
 			m.logger.Warnf("Could not remove %s: %v", backup.Path, err)
 		} else {
 			m.logger.Infof("Removed backup: %s", filepath.Base(backup.Path))
@@ -514,7 +643,7 @@ func (m *Manager) Cleanup(keep int, olderThan int) (int, error) {
 
 		// Remove metadata file if exists
 		metadataPath := backup.Path + ".meta"
-		if err := os.Remove(metadataPath); err != nil && !os.IsNotExist(err) {
+		if err := m.fs.Remove(metadataPath); err != nil && !os.IsNotExist(err) {
 			m.logger.Warnf("Could not remove metadata %s: %v", metadataPath, err)
 		}
 	}
@@ -524,6 +653,14 @@ func (m *Manager) Cleanup(keep int, olderThan int) (int, error) {
 
 // Private helper methods
 
+// root is the directory backups are taken from and restored into
+func (m *Manager) root() string {
+	if m.opts.ProjectDir != "" {
+		return m.opts.ProjectDir
+	}
+	return m.opts.InstallDir
+}
+
 func (m *Manager) createBackupMetadata() *BackupMetadata {
 	// Get version information
 	versionManager := versioning.NewVersionManager(m.opts.InstallDir)
@@ -539,9 +676,14 @@ func (m *Manager) createBackupMetadata() *BackupMetadata {
 		InstallDir:       m.opts.InstallDir,
 		Components:       make(map[string]string),
 		FrameworkVersion: frameworkVersion,
-		BackupType:       "full",
+		BackupType:       TypeFull,
 		Description:      m.opts.Description,
 	}
+	if m.opts.ProjectDir != "" {
+		metadata.BackupType = TypeProject
+		metadata.ProjectDir = m.opts.ProjectDir
+		metadata.ProjectName = filepath.Base(m.opts.ProjectDir)
+	}
 
 	// Get component versions from version manager
 	components := []string{"core", "commands", "hooks", "mcp"}
@@ -585,18 +727,26 @@ func (m *Manager) shouldIncludeFile(path string, _ os.FileInfo) bool {
 }
 
 func (m *Manager) calculateChecksum(filePath string) (string, error) {
-	file, err := os.Open(filePath)
+	file, err := m.fs.Open(filePath)
 	if err != nil {
 		return "", err
 	}
 	defer file.Close()
+	return fileops.HashReader(file)
+}
 
-	hasher := sha256.New()
-	if _, err := io.Copy(hasher, file); err != nil {
-		return "", err
+// loadIgnore reads the .crewignore of root like crewignore.Load, from the
+// manager's filesystem
+func (m *Manager) loadIgnore(root string) (*crewignore.Matcher, error) {
+	file, err := m.fs.Open(filepath.Join(root, crewignore.FileName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return &crewignore.Matcher{}, nil
 	}
-
-	return hex.EncodeToString(hasher.Sum(nil)), nil
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return crewignore.Parse(file)
 }
 
 func (m *Manager) saveBackupMetadata(metadata *BackupMetadata, metadataPath string) error {
@@ -605,11 +755,11 @@ func (m *Manager) saveBackupMetadata(metadata *BackupMetadata, metadataPath stri
 		return err
 	}
 
-	return os.WriteFile(metadataPath, data, 0644)
+	return m.fs.WriteFile(metadataPath, data, 0644)
 }
 
 func (m *Manager) loadBackupMetadata(metadataPath string) (*BackupMetadata, error) {
-	data, err := os.ReadFile(metadataPath)
+	data, err := m.fs.ReadFile(metadataPath)
 	if err != nil {
 		return nil, err
 	}
@@ -630,7 +780,7 @@ func (m *Manager) verifyBackup(backupPath string, metadata *BackupMetadata) erro
 
 	// Verify file size if metadata available
 	if metadata != nil && metadata.Size > 0 {
-		info, err := os.Stat(backupPath)
+		info, err := m.fs.Stat(backupPath)
 		if err != nil {
 			return fmt.Errorf("failed to get backup file info: %w", err)
 		}
@@ -653,7 +803,7 @@ func (m *Manager) verifyBackup(backupPath string, metadata *BackupMetadata) erro
 	}
 
 	// Try to read tar headers to verify archive integrity
-	file, err := os.Open(backupPath)
+	file, err := m.fs.Open(backupPath)
 	if err != nil {
 		return fmt.Errorf("failed to open backup file: %w", err)
 	}
@@ -689,7 +839,7 @@ func (m *Manager) verifyBackup(backupPath string, metadata *BackupMetadata) erro
 }
 
 func (m *Manager) getMetadataFromArchive(backupPath string) *BackupMetadata {
-	file, err := os.Open(backupPath)
+	file, err := m.fs.Open(backupPath)
 	if err != nil {
 		return nil
 	}
@@ -729,7 +879,7 @@ func (m *Manager) getMetadataFromArchive(backupPath string) *BackupMetadata {
 }
 
 func (m *Manager) countFilesInArchive(backupPath string) int {
-	file, err := os.Open(backupPath)
+	file, err := m.fs.Open(backupPath)
 	if err != nil {
 		return 0
 	}
@@ -0,0 +1,75 @@
+package backup
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/fsys"
+)
+
+func TestCreateRestoreMemFS(t *testing.T) {
+	t.Parallel()
+	mem := fsys.NewMemFS()
+	installDir := filepath.Join(string(filepath.Separator), "home", "user", ".claude")
+	agent := filepath.Join(installDir, "agents", "agent.md")
+	if err := mem.MkdirAll(filepath.Dir(agent), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := mem.WriteFile(agent, []byte("# Agent\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := mem.WriteFile(filepath.Join(installDir, "notes.tmp"), []byte("scratch"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	mgr := NewManager(Options{
+		InstallDir: installDir,
+		BackupDir:  filepath.Join(installDir, "backups"),
+		BackupName: "test",
+		Compress:   "gzip",
+		Overwrite:  true,
+		FS:         mem,
+	})
+	backupFile, err := mgr.Create(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !fsys.Exists(mem, backupFile) || !fsys.Exists(mem, backupFile+".meta") {
+		t.Fatalf("backup %s or its metadata missing from the MemFS", backupFile)
+	}
+
+	if err := mem.WriteFile(agent, []byte("changed"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := mgr.Restore(context.Background(), backupFile); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := mem.ReadFile(agent); err != nil || string(data) != "# Agent\n" {
+		t.Errorf("restored agent = %q, %v", data, err)
+	}
+
+	backups, err := mgr.ListBackups()
+	if err != nil || len(backups) != 1 || backups[0].Metadata == nil || backups[0].FileCount != 1 {
+		t.Errorf("ListBackups = %+v, %v", backups, err)
+	}
+}
+
+func TestCreateRejectsUnknownCompression(t *testing.T) {
+	t.Parallel()
+	mem := fsys.NewMemFS()
+	backupDir := filepath.Join(string(filepath.Separator), "backups")
+	mgr := NewManager(Options{
+		InstallDir: filepath.Join(string(filepath.Separator), "home", "user", ".claude"),
+		BackupDir:  backupDir,
+		BackupName: "test",
+		Compress:   "zstd",
+		FS:         mem,
+	})
+	if _, err := mgr.Create(context.Background()); err == nil {
+		t.Fatal("expected an error for zstd compression")
+	}
+	if fsys.Exists(mem, backupDir) {
+		t.Error("expected nothing written for a rejected backup")
+	}
+}
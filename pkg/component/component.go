@@ -0,0 +1,137 @@
+// Package component defines the contract for crew framework components.
+//
+// External Go modules implement Component and make it available in one of
+// two ways:
+//
+//   - compiled in: call Register from an init function, in the same way
+//     database/sql drivers register themselves
+//   - declarative: ship a directory containing a component.json manifest
+//     (see Manifest) in a discovery directory such as
+//     ~/.claude/.crew/components or one listed in $CREW_COMPONENT_PATH
+//
+// The installer's component registry picks up both and treats them like
+// the built-in components.
+package component
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Metadata describes a component
+type Metadata struct {
+	Name         string            `json:"name"`
+	Version      string            `json:"version"`
+	Description  string            `json:"description"`
+	Category     string            `json:"category"`
+	Author       string            `json:"author,omitempty"`
+	URL          string            `json:"url,omitempty"`
+	Tags         []string          `json:"tags,omitempty"`
+	Dependencies []string          `json:"dependencies,omitempty"`
+	Conflicts    []string          `json:"conflicts,omitempty"`
+	Requirements map[string]string `json:"requirements,omitempty"`
+}
+
+// Well-known tags. Tags are free-form, but these have meaning to the
+// installer: experimental components are hidden from listings and
+// selection unless the user opts in, and requires-* tags name a tool the
+// component needs at runtime.
+const (
+	TagExperimental = "experimental"
+	TagRequiresNode = "requires-node"
+	TagRequiresGit  = "requires-git"
+)
+
+// HasTag reports whether the metadata carries tag, ignoring case
+func (m Metadata) HasTag(tag string) bool {
+	for _, t := range m.Tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsExperimental reports whether the component is tagged experimental
+func (m Metadata) IsExperimental() bool {
+	return m.HasTag(TagExperimental)
+}
+
+// FilePair is a source file and the path it is installed to
+type FilePair struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+}
+
+// Component is the contract every installable component implements. The
+// config map carries installer options such as "dry_run".
+type Component interface {
+	// Metadata returns the component's metadata
+	Metadata() Metadata
+
+	// Validate checks whether the component can be installed into installDir
+	Validate(installDir string) error
+
+	// Install installs the component into installDir
+	Install(installDir string, config map[string]interface{}) error
+
+	// Uninstall removes the component from installDir
+	Uninstall(installDir string, config map[string]interface{}) error
+
+	// Update replaces an installed version of the component
+	Update(installDir string, config map[string]interface{}) error
+
+	// SizeEstimate returns the approximate installed size in bytes
+	SizeEstimate() int64
+
+	// FileManifest returns the files the component installs
+	FileManifest() []FilePair
+}
+
+// Factory creates a component for an install directory and an optional
+// source directory override
+type Factory func(installDir, sourceDir string) Component
+
+var (
+	mu        sync.RWMutex
+	factories = make(map[string]Factory)
+)
+
+// Register makes a component available to the installer under name. It
+// panics if name is empty or already registered.
+func Register(name string, factory Factory) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if name == "" || factory == nil {
+		panic("component: Register requires a name and a factory")
+	}
+	if _, exists := factories[name]; exists {
+		panic(fmt.Sprintf("component: %s registered twice", name))
+	}
+	factories[name] = factory
+}
+
+// Registered returns the names of all registered components, sorted
+func Registered() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	names := make([]string, 0, len(factories))
+	for name := range factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Lookup returns the factory registered under name
+func Lookup(name string) (Factory, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	factory, ok := factories[name]
+	return factory, ok
+}
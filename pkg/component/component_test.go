@@ -0,0 +1,87 @@
+package component
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeManifest(t *testing.T, dir, manifest string) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ManifestFile), []byte(manifest), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestManifestInstallAndUninstall(t *testing.T) {
+	root := t.TempDir()
+	compDir := filepath.Join(root, "reviewers")
+	writeManifest(t, compDir, `{
+		"name": "reviewers",
+		"version": "1.2.0",
+		"dependencies": ["core"],
+		"files": [{"source": "agents/reviewer.md", "target": "agents/reviewer.md"}]
+	}`)
+	os.MkdirAll(filepath.Join(compDir, "agents"), 0755)
+	os.WriteFile(filepath.Join(compDir, "agents", "reviewer.md"), []byte("# Reviewer\n"), 0644)
+
+	manifests, errs := Discover(root)
+	if len(errs) != 0 || len(manifests) != 1 {
+		t.Fatalf("Expected one manifest, got %v and errors %v", manifests, errs)
+	}
+	m := manifests[0]
+	if m.Category != "plugin" || m.Dependencies[0] != "core" {
+		t.Errorf("Unexpected metadata: %+v", m.Metadata)
+	}
+
+	installDir := t.TempDir()
+	c := m.Factory()(installDir, "")
+	if c.SizeEstimate() != int64(len("# Reviewer\n")) {
+		t.Errorf("Unexpected size estimate %d", c.SizeEstimate())
+	}
+	if err := c.Install(installDir, map[string]interface{}{}); err != nil {
+		t.Fatalf("Install failed: %v", err)
+	}
+	target := filepath.Join(installDir, "agents", "reviewer.md")
+	if _, err := os.Stat(target); err != nil {
+		t.Fatalf("Expected %s to be installed: %v", target, err)
+	}
+	if err := c.Uninstall(installDir, map[string]interface{}{}); err != nil {
+		t.Fatalf("Uninstall failed: %v", err)
+	}
+	if _, err := os.Stat(target); !os.IsNotExist(err) {
+		t.Errorf("Expected %s to be removed", target)
+	}
+}
+
+func TestLoadManifestRejectsEscapingPaths(t *testing.T) {
+	for _, files := range []string{
+		`[{"source": "../secret", "target": "agents/x.md"}]`,
+		`[{"source": "x.md", "target": "../../.bashrc"}]`,
+		`[{"source": "x.md", "target": "/etc/passwd"}]`,
+	} {
+		dir := t.TempDir()
+		writeManifest(t, dir, `{"name": "bad", "version": "1.0.0", "files": `+files+`}`)
+		if _, err := LoadManifest(dir); err == nil || !strings.Contains(err.Error(), "relative path") {
+			t.Errorf("Expected %s to be rejected, got %v", files, err)
+		}
+	}
+}
+
+func TestRegister(t *testing.T) {
+	Register("sdk-test", func(installDir, sourceDir string) Component { return nil })
+	if _, ok := Lookup("sdk-test"); !ok {
+		t.Fatal("Expected sdk-test to be registered")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a duplicate registration to panic")
+		}
+	}()
+	Register("sdk-test", func(installDir, sourceDir string) Component { return nil })
+}
@@ -0,0 +1,207 @@
+package component
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jonwraymond/claude-code-super-crew/internal/fileops"
+)
+
+// ManifestFile is the file name that marks a directory as a component
+const ManifestFile = "component.json"
+
+// Manifest is a declarative component: its metadata plus the files to copy,
+// with sources relative to the manifest's directory and targets relative to
+// the install directory.
+//
+//	{
+//	  "name": "reviewers",
+//	  "version": "1.2.0",
+//	  "description": "Extra code review agents",
+//	  "category": "agents",
+//	  "dependencies": ["core"],
+//	  "files": [{"source": "agents/reviewer.md", "target": "agents/reviewer.md"}]
+//	}
+type Manifest struct {
+	Metadata
+	Files []FilePair `json:"files"`
+
+	dir string
+}
+
+// LoadManifest reads and validates the component.json in dir
+func LoadManifest(dir string) (*Manifest, error) {
+	path := filepath.Join(dir, ManifestFile)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read component manifest: %w", err)
+	}
+
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if m.Name == "" || m.Version == "" {
+		return nil, fmt.Errorf("%s: name and version are required", path)
+	}
+	for _, f := range m.Files {
+		if f.Source == "" || !isRelativeInside(f.Source) {
+			return nil, fmt.Errorf("%s: source %q must be a relative path inside the component", path, f.Source)
+		}
+		if f.Target == "" || !isRelativeInside(f.Target) {
+			return nil, fmt.Errorf("%s: target %q must be a relative path inside the install directory", path, f.Target)
+		}
+	}
+	if m.Category == "" {
+		m.Category = "plugin"
+	}
+
+	m.dir = dir
+	return &m, nil
+}
+
+// Discover loads every component manifest in the immediate subdirectories
+// of dir. A missing dir yields no manifests; invalid manifests are returned
+// as errors alongside the valid ones.
+func Discover(dir string) ([]*Manifest, []error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, []error{fmt.Errorf("failed to read component directory: %w", err)}
+	}
+
+	var manifests []*Manifest
+	var errs []error
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		sub := filepath.Join(dir, entry.Name())
+		if _, err := os.Stat(filepath.Join(sub, ManifestFile)); err != nil {
+			continue
+		}
+		m, err := LoadManifest(sub)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		manifests = append(manifests, m)
+	}
+	sort.Slice(manifests, func(i, j int) bool { return manifests[i].Name < manifests[j].Name })
+	return manifests, errs
+}
+
+// Dir returns the directory the manifest was loaded from
+func (m *Manifest) Dir() string {
+	return m.dir
+}
+
+// Factory returns a factory for components built from the manifest
+func (m *Manifest) Factory() Factory {
+	return func(installDir, sourceDir string) Component {
+		dir := m.dir
+		if sourceDir != "" {
+			dir = sourceDir
+		}
+		return &manifestComponent{manifest: m, installDir: installDir, dir: dir}
+	}
+}
+
+// manifestComponent installs a manifest's files by copying them
+type manifestComponent struct {
+	manifest   *Manifest
+	installDir string
+	dir        string
+}
+
+func (c *manifestComponent) Metadata() Metadata {
+	return c.manifest.Metadata
+}
+
+func (c *manifestComponent) files(installDir string) []FilePair {
+	if installDir == "" {
+		installDir = c.installDir
+	}
+	pairs := make([]FilePair, 0, len(c.manifest.Files))
+	for _, f := range c.manifest.Files {
+		pairs = append(pairs, FilePair{
+			Source: filepath.Join(c.dir, filepath.FromSlash(f.Source)),
+			Target: filepath.Join(installDir, filepath.FromSlash(f.Target)),
+		})
+	}
+	return pairs
+}
+
+func (c *manifestComponent) FileManifest() []FilePair {
+	return c.files("")
+}
+
+func (c *manifestComponent) Validate(installDir string) error {
+	for _, pair := range c.files(installDir) {
+		if _, err := os.Stat(pair.Source); err != nil {
+			return fmt.Errorf("component %s is missing %s", c.manifest.Name, pair.Source)
+		}
+	}
+	return nil
+}
+
+func (c *manifestComponent) Install(installDir string, config map[string]interface{}) error {
+	if dryRun, _ := config["dry_run"].(bool); dryRun {
+		return nil
+	}
+	if err := c.Validate(installDir); err != nil {
+		return err
+	}
+	for _, pair := range c.files(installDir) {
+		if err := copyFile(pair.Source, pair.Target); err != nil {
+			return fmt.Errorf("failed to install %s: %w", pair.Target, err)
+		}
+	}
+	return nil
+}
+
+func (c *manifestComponent) Update(installDir string, config map[string]interface{}) error {
+	return c.Install(installDir, config)
+}
+
+func (c *manifestComponent) Uninstall(installDir string, config map[string]interface{}) error {
+	if dryRun, _ := config["dry_run"].(bool); dryRun {
+		return nil
+	}
+	for _, pair := range c.files(installDir) {
+		if err := os.Remove(pair.Target); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove %s: %w", pair.Target, err)
+		}
+	}
+	return nil
+}
+
+func (c *manifestComponent) SizeEstimate() int64 {
+	var total int64
+	for _, pair := range c.files("") {
+		if info, err := os.Stat(pair.Source); err == nil {
+			total += info.Size()
+		}
+	}
+	return total
+}
+
+// isRelativeInside reports whether p is relative and does not climb out of
+// the directory it is joined to
+func isRelativeInside(p string) bool {
+	clean := filepath.Clean(filepath.FromSlash(p))
+	return !filepath.IsAbs(clean) && clean != ".." && !strings.HasPrefix(clean, ".."+string(filepath.Separator))
+}
+
+func copyFile(src, dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	return fileops.CopyFile(src, dst)
+}
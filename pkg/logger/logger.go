@@ -8,11 +8,11 @@ import (
 	"io"
 	"os"
 	"path/filepath"
-	"sort"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/jonwraymond/claude-code-super-crew/internal/crewdirs"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/pkgerrors"
 )
@@ -98,25 +98,54 @@ type Logger interface {
 	GetStatistics() map[string]interface{}
 	Flush()
 	Close()
+	// With returns a child logger scoped with key=value
+	With(key, value string) Logger
 }
 
 // UnifiedLogger wraps zerolog to implement our Logger interface.
 // It provides thread-safe logging with support for console and file output,
-// file rotation, statistics tracking, and enhanced formatting.
+// file rotation, statistics tracking, and enhanced formatting. A logger
+// shares its outputs with the children With returns, while each has its
+// own scope fields and levels.
 type UnifiedLogger struct {
-	name         string
-	logDir       string
+	*loggerState
+	loggerSettings
+	// fields scope every entry of this logger and never change once With
+	// has created it; prefix renders them for the text console
+	fields []field
+	prefix string
+}
+
+// loggerSettings are the levels and console options of one logger. With
+// copies them into the child, so changing them on a parent or a child
+// leaves the other as it was. The shared mu guards them.
+type loggerSettings struct {
 	consoleLevel LogLevel
 	fileLevel    LogLevel
-	sessionStart time.Time
-	logFile      *os.File
-	logger       zerolog.Logger
-	logCounts    map[string]int
-	statistics   map[string]interface{}
 	verbose      bool
 	quiet        bool
 	console      zerolog.ConsoleWriter
-	mu           sync.Mutex
+	logger       zerolog.Logger
+}
+
+// loggerState is what a logger shares with its children: the outputs and
+// the statistics. mu guards it and the settings of every logger sharing it,
+// and is held while an entry is written, so entries logged from concurrent
+// goroutines never interleave.
+type loggerState struct {
+	name          string
+	logDir        string
+	sessionStart  time.Time
+	logFile       *rotatingFile
+	fileLogger    zerolog.Logger
+	jsonConsole   zerolog.Logger
+	logCounts     map[string]int
+	statistics    map[string]interface{}
+	format        Format
+	command       string
+	correlationID string
+	fileRedact    func([]byte) []byte
+	mu            sync.Mutex
 }
 
 var (
@@ -132,29 +161,38 @@ func GetLogger() Logger {
 	return globalLogger
 }
 
+// DefaultName is the name of the global logger and of its log file
+const DefaultName = "supercrew"
+
 // NewLogger creates a new unified logger
 func NewLogger() Logger {
-	return NewNamedLogger("supercrew")
+	return NewNamedLogger(DefaultName)
 }
 
 // NewNamedLogger creates a new logger with a specific name
 func NewNamedLogger(name string) Logger {
 	logger := &UnifiedLogger{
-		name:         name,
-		consoleLevel: InfoLevel,
-		fileLevel:    DebugLevel,
-		sessionStart: time.Now(),
-		logCounts: map[string]int{
-			"debug":    0,
-			"info":     0,
-			"warning":  0,
-			"error":    0,
-			"critical": 0,
+		loggerState: &loggerState{
+			name:         name,
+			sessionStart: time.Now(),
+			logCounts: map[string]int{
+				"debug":    0,
+				"info":     0,
+				"warning":  0,
+				"error":    0,
+				"critical": 0,
+			},
+			statistics: make(map[string]interface{}),
+			format:     FormatText,
+		},
+		loggerSettings: loggerSettings{
+			consoleLevel: InfoLevel,
+			fileLevel:    DebugLevel,
+			verbose:      false,
+			quiet:        false,
 		},
-		statistics: make(map[string]interface{}),
-		verbose:    false,
-		quiet:      false,
 	}
+	logger.jsonConsole = logger.newJSONLogger(os.Stderr)
 
 	// Setup zerolog with stack trace support
 	zerolog.ErrorStackMarshaler = pkgerrors.MarshalStack
@@ -175,15 +213,15 @@ func NewNamedLogger(name string) Logger {
 		level := strings.ToUpper(fmt.Sprintf("%s", i))
 		switch level {
 		case "DEBUG":
-			return "\033[90mDEBUG  \033[0m" // Gray
+			return levelLabel("90", "DEBUG  ") // Gray
 		case "INFO":
-			return "\033[36mINFO   \033[0m" // Cyan
+			return levelLabel("36", "INFO   ") // Cyan
 		case "WARN":
-			return "\033[33mWARN   \033[0m" // Yellow
+			return levelLabel("33", "WARN   ") // Yellow
 		case "ERROR":
-			return "\033[31mERROR  \033[0m" // Red
+			return levelLabel("31", "ERROR  ") // Red
 		case "CRITICAL":
-			return "\033[35mCRITICAL\033[0m" // Magenta
+			return levelLabel("35", "CRITICAL") // Magenta
 		default:
 			return ""
 		}
@@ -207,7 +245,8 @@ func NewNamedLogger(name string) Logger {
 	return logger
 }
 
-// InitializeFileLogging initializes file logging with rotation
+// InitializeFileLogging starts writing every message as a JSON line to
+// <logDir>/<name>.log, rotating the file once it exceeds DefaultMaxLogSize
 func (l *UnifiedLogger) InitializeFileLogging(logDir string) error {
 	l.mu.Lock()
 	defer l.mu.Unlock()
@@ -215,7 +254,7 @@ func (l *UnifiedLogger) InitializeFileLogging(logDir string) error {
 	if logDir == "" {
 		homeDir, _ := os.UserHomeDir()
 		// Use the new .crew/logs directory structure
-		logDir = filepath.Join(homeDir, ".claude", ".crew", "logs")
+		logDir = crewdirs.Path(filepath.Join(homeDir, ".claude"), "logs")
 	}
 	l.logDir = logDir
 
@@ -224,65 +263,18 @@ func (l *UnifiedLogger) InitializeFileLogging(logDir string) error {
 		return err
 	}
 
-	// Create timestamped log file
-	timestamp := l.sessionStart.Format("20060102_150405")
-	logFilePath := filepath.Join(l.logDir, fmt.Sprintf("%s_%s.log", l.name, timestamp))
-
-	var err error
-	l.logFile, err = os.OpenFile(logFilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	logFile, err := openRotatingFile(filepath.Join(l.logDir, l.name+".log"), DefaultMaxLogSize, DefaultMaxLogBackups)
 	if err != nil {
 		return err
 	}
-
-	// Clean up old log files (keep last 10)
-	l.cleanupOldLogs(10)
-
-	// Create multi-writer for both console and file
-	multi := io.MultiWriter(l.console, l.logFile)
-	l.logger = l.logger.Output(multi)
-
-	return nil
-}
-
-// cleanupOldLogs removes old log files keeping only the specified count
-func (l *UnifiedLogger) cleanupOldLogs(keepCount int) {
-	pattern := filepath.Join(l.logDir, l.name+"_*.log")
-	matches, err := filepath.Glob(pattern)
-	if err != nil {
-		return
-	}
-
-	if len(matches) <= keepCount {
-		return
-	}
-
-	// Sort by modification time
-	type fileInfo struct {
-		path    string
-		modTime time.Time
-	}
-
-	var files []fileInfo
-	for _, match := range matches {
-		info, err := os.Stat(match)
-		if err != nil {
-			continue
-		}
-		files = append(files, fileInfo{
-			path:    match,
-			modTime: info.ModTime(),
-		})
+	if l.logFile != nil {
+		l.logFile.Close()
 	}
+	logFile.setRedact(l.fileRedact)
+	l.logFile = logFile
+	l.fileLogger = l.newJSONLogger(logFile)
 
-	// Sort by modification time, newest first
-	sort.Slice(files, func(i, j int) bool {
-		return files[i].modTime.After(files[j].modTime)
-	})
-
-	// Remove old files
-	for i := keepCount; i < len(files); i++ {
-		os.Remove(files[i].path)
-	}
+	return nil
 }
 
 // SetLevel sets the logging level
@@ -339,147 +331,115 @@ func (l *UnifiedLogger) SetQuiet(quiet bool) {
 	if quiet {
 		// In quiet mode, disable console output
 		l.logger = l.logger.Output(io.Discard)
-		if l.logFile != nil {
-			l.logger = l.logger.Output(l.logFile)
-		}
 	} else {
 		// Restore console output
-		if l.logFile != nil {
-			multi := io.MultiWriter(l.console, l.logFile)
-			l.logger = l.logger.Output(multi)
-		} else {
-			l.logger = l.logger.Output(l.console)
-		}
+		l.logger = l.logger.Output(l.console)
 	}
 }
 
+// updateZerologLevel applies the console level to the console logger only,
+// so the log file keeps recording at its own level
 func (l *UnifiedLogger) updateZerologLevel() {
-	switch l.consoleLevel {
-	case DebugLevel:
-		zerolog.SetGlobalLevel(zerolog.DebugLevel)
-	case InfoLevel:
-		zerolog.SetGlobalLevel(zerolog.InfoLevel)
-	case WarnLevel:
-		zerolog.SetGlobalLevel(zerolog.WarnLevel)
-	case ErrorLevel:
-		zerolog.SetGlobalLevel(zerolog.ErrorLevel)
-	case CriticalLevel:
-		zerolog.SetGlobalLevel(zerolog.ErrorLevel)
-	}
+	l.logger = l.logger.Level(l.consoleLevel.ToZerologLevel())
 }
 
 // Debug logs a debug message
 func (l *UnifiedLogger) Debug(msg string) {
-	l.logCounts["debug"]++
-	l.logger.Debug().Msg(msg)
+	l.dispatch(entry{level: DebugLevel, msg: msg}, func() {
+		l.logger.Debug().Msg(l.prefix + msg)
+	})
 }
 
 // Debugf logs a formatted debug message
 func (l *UnifiedLogger) Debugf(format string, args ...interface{}) {
-	l.logCounts["debug"]++
-	l.logger.Debug().Msgf(format, args...)
+	l.Debug(fmt.Sprintf(format, args...))
 }
 
 // Info logs an info message
 func (l *UnifiedLogger) Info(msg string) {
-	l.logCounts["info"]++
-	if !l.verbose && !l.quiet {
-		fmt.Println(msg)
-	} else {
-		l.logger.Info().Msg(msg)
-	}
+	l.dispatch(entry{level: InfoLevel, msg: msg}, func() {
+		if !l.verbose && !l.quiet {
+			fmt.Println(l.prefix + msg)
+		} else {
+			l.logger.Info().Msg(l.prefix + msg)
+		}
+	})
 }
 
 // Infof logs a formatted info message
 func (l *UnifiedLogger) Infof(format string, args ...interface{}) {
-	l.logCounts["info"]++
-	if !l.verbose && !l.quiet {
-		fmt.Printf(format+"\n", args...)
-	} else {
-		l.logger.Info().Msgf(format, args...)
-	}
+	l.Info(fmt.Sprintf(format, args...))
 }
 
 // Warn logs a warning message
 func (l *UnifiedLogger) Warn(msg string) {
-	l.logCounts["warning"]++
-	if !l.verbose && !l.quiet {
-		fmt.Printf("\033[33m⚠️  %s\033[0m\n", msg)
-	} else {
-		l.logger.Warn().Msg(msg)
-	}
+	l.dispatch(entry{level: WarnLevel, msg: msg}, func() {
+		if !l.verbose && !l.quiet {
+			fmt.Println(styled("33", "⚠️ ", "[WARN]", l.prefix+msg))
+		} else {
+			l.logger.Warn().Msg(l.prefix + msg)
+		}
+	})
 }
 
 // Warnf logs a formatted warning message
 func (l *UnifiedLogger) Warnf(format string, args ...interface{}) {
-	l.logCounts["warning"]++
-	if !l.verbose && !l.quiet {
-		fmt.Printf("\033[33m⚠️  %s\033[0m\n", fmt.Sprintf(format, args...))
-	} else {
-		l.logger.Warn().Msgf(format, args...)
-	}
+	l.Warn(fmt.Sprintf(format, args...))
 }
 
 // Error logs an error message
 func (l *UnifiedLogger) Error(msg string) {
-	l.logCounts["error"]++
-	if !l.verbose {
-		fmt.Printf("\033[31m❌ %s\033[0m\n", msg)
-	} else {
-		l.logger.Error().Msg(msg)
-	}
+	l.dispatch(entry{level: ErrorLevel, msg: msg}, func() {
+		if !l.verbose {
+			fmt.Println(styled("31", "❌", "[ERROR]", l.prefix+msg))
+		} else {
+			l.logger.Error().Msg(l.prefix + msg)
+		}
+	})
 }
 
 // Errorf logs a formatted error message
 func (l *UnifiedLogger) Errorf(format string, args ...interface{}) {
-	l.logCounts["error"]++
-	if !l.verbose {
-		fmt.Printf("\033[31m❌ %s\033[0m\n", fmt.Sprintf(format, args...))
-	} else {
-		l.logger.Error().Msgf(format, args...)
-	}
+	l.Error(fmt.Sprintf(format, args...))
 }
 
 // Critical logs a critical message
 func (l *UnifiedLogger) Critical(msg string) {
-	l.logCounts["critical"]++
-	l.logger.Error().Str("level", "CRITICAL").Msg(msg)
+	l.dispatch(entry{level: CriticalLevel, msg: msg}, func() {
+		l.logger.Error().Str("level", "CRITICAL").Msg(l.prefix + msg)
+	})
 }
 
 // Criticalf logs a formatted critical message
 func (l *UnifiedLogger) Criticalf(format string, args ...interface{}) {
-	l.logCounts["critical"]++
-	l.logger.Error().Str("level", "CRITICAL").Msgf(format, args...)
+	l.Critical(fmt.Sprintf(format, args...))
 }
 
 // Success logs a success message
 func (l *UnifiedLogger) Success(msg string) {
-	l.logCounts["info"]++
-	if !l.verbose && !l.quiet {
-		fmt.Printf("\033[32m✅ %s\033[0m\n", msg)
-	} else if !l.quiet {
-		// Use info level with custom prefix for file logging
-		l.logger.Info().Str("type", "SUCCESS").Msg(msg)
-	}
+	l.dispatch(entry{level: InfoLevel, msg: msg, success: true}, func() {
+		if !l.verbose && !l.quiet {
+			fmt.Println(styled("32", "✅", "[OK]", l.prefix+msg))
+		} else if !l.quiet {
+			// Use info level with custom prefix for file logging
+			l.logger.Info().Str("type", "SUCCESS").Msg(l.prefix + msg)
+		}
+	})
 }
 
 // Successf logs a formatted success message
 func (l *UnifiedLogger) Successf(format string, args ...interface{}) {
-	l.logCounts["info"]++
-	if !l.verbose && !l.quiet {
-		fmt.Printf("\033[32m✅ %s\033[0m\n", fmt.Sprintf(format, args...))
-	} else if !l.quiet {
-		l.logger.Info().Str("type", "SUCCESS").Msgf(format, args...)
-	}
+	l.Success(fmt.Sprintf(format, args...))
 }
 
 // Exception logs an exception with error details
 func (l *UnifiedLogger) Exception(msg string, err error) {
-	l.logCounts["error"]++
-	l.logger.Error().Stack().Err(err).Msg(msg)
-	if l.verbose && err != nil {
-		l.logger.Debug().Str("error_detail", fmt.Sprintf("%+v", err)).Msg("Stack trace")
-	}
+	l.dispatch(entry{level: ErrorLevel, msg: msg, err: err}, func() {
+		l.logger.Error().Stack().Err(err).Msg(l.prefix + msg)
+		if l.verbose && err != nil {
+			l.logger.Debug().Str("error_detail", fmt.Sprintf("%+v", err)).Msg("Stack trace")
+		}
+	})
 }
 
 // Step logs step progress
@@ -531,20 +491,28 @@ func (l *UnifiedLogger) LogOperationEnd(operation string, success bool, duration
 
 // GetStatistics returns logging statistics
 func (l *UnifiedLogger) GetStatistics() map[string]interface{} {
+	l.mu.Lock()
+	defer l.mu.Unlock()
 	runtime := time.Since(l.sessionStart)
 
+	counts := make(map[string]int, len(l.logCounts))
+	for key, count := range l.logCounts {
+		counts[key] = count
+	}
 	return map[string]interface{}{
 		"session_start":   l.sessionStart.Format(time.RFC3339),
 		"runtime_seconds": runtime.Seconds(),
-		"log_counts":      l.logCounts,
+		"log_counts":      counts,
 		"total_messages":  l.getTotalMessages(),
 		"log_file":        l.getLogFilePath(),
-		"has_errors":      l.logCounts["error"]+l.logCounts["critical"] > 0,
+		"has_errors":      counts["error"]+counts["critical"] > 0,
 	}
 }
 
 // Flush flushes all log outputs
 func (l *UnifiedLogger) Flush() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
 	if l.logFile != nil {
 		l.logFile.Sync()
 	}
@@ -554,20 +522,24 @@ func (l *UnifiedLogger) Flush() {
 func (l *UnifiedLogger) Close() {
 	l.Section("Session Complete")
 	stats := l.GetStatistics()
+	counts := stats["log_counts"].(map[string]int)
 
 	l.Info(fmt.Sprintf("Total runtime: %.1f seconds", stats["runtime_seconds"].(float64)))
 	l.Info(fmt.Sprintf("Messages logged: %d", stats["total_messages"].(int)))
 
 	if stats["has_errors"].(bool) {
-		l.Warn(fmt.Sprintf("Errors/warnings: %d", l.logCounts["error"]+l.logCounts["warning"]))
+		l.Warn(fmt.Sprintf("Errors/warnings: %d", counts["error"]+counts["warning"]))
 	}
 
 	if logFile := stats["log_file"]; logFile != nil {
 		l.Info(fmt.Sprintf("Full log saved to: %s", logFile.(string)))
 	}
 
+	l.mu.Lock()
+	defer l.mu.Unlock()
 	if l.logFile != nil {
 		l.logFile.Close()
+		l.logFile = nil
 	}
 }
 
@@ -583,7 +555,7 @@ func (l *UnifiedLogger) getTotalMessages() int {
 
 func (l *UnifiedLogger) getLogFilePath() interface{} {
 	if l.logFile != nil {
-		return l.logFile.Name()
+		return l.logFile.path
 	}
 	return nil
 }
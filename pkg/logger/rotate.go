@@ -0,0 +1,150 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+const (
+	// DefaultMaxLogSize is the size in bytes at which the log file is rotated
+	DefaultMaxLogSize int64 = 5 * 1024 * 1024
+	// DefaultMaxLogBackups is the number of rotated log files kept
+	DefaultMaxLogBackups = 5
+)
+
+// rotatingFile is an append-only log file that rotates itself once it grows
+// past maxSize, keeping up to maxBackups older copies as path.1, path.2, ...
+type rotatingFile struct {
+	mu         sync.Mutex
+	path       string
+	maxSize    int64
+	maxBackups int
+	file       *os.File
+	size       int64
+	// redact, when set, rewrites every write before it reaches the file
+	redact func([]byte) []byte
+}
+
+// openRotatingFile opens (or creates) the log file at path for appending
+func openRotatingFile(path string, maxSize int64, maxBackups int) (*rotatingFile, error) {
+	r := &rotatingFile{path: path, maxSize: maxSize, maxBackups: maxBackups}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *rotatingFile) open() error {
+	file, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to stat log file: %w", err)
+	}
+	r.file = file
+	r.size = info.Size()
+	return nil
+}
+
+// Write appends p, rotating first if it would push the file past maxSize
+func (r *rotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return 0, os.ErrClosed
+	}
+	data := p
+	if r.redact != nil {
+		data = r.redact(p)
+	}
+	if r.maxSize > 0 && r.size > 0 && r.size+int64(len(data)) > r.maxSize {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := r.file.Write(data)
+	r.size += int64(n)
+	if err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// setRedact sets the function rewriting writes; nil writes them as they are
+func (r *rotatingFile) setRedact(redact func([]byte) []byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.redact = redact
+}
+
+// rotate shifts path.N-1 to path.N, moves the current file to path.1 and
+// reopens an empty file at path
+func (r *rotatingFile) rotate() error {
+	if err := r.file.Close(); err != nil {
+		return fmt.Errorf("failed to close log file: %w", err)
+	}
+	r.file = nil
+
+	if r.maxBackups > 0 {
+		os.Remove(r.backupPath(r.maxBackups))
+		for i := r.maxBackups - 1; i >= 1; i-- {
+			os.Rename(r.backupPath(i), r.backupPath(i+1))
+		}
+		if err := os.Rename(r.path, r.backupPath(1)); err != nil {
+			return fmt.Errorf("failed to rotate log file: %w", err)
+		}
+	} else if err := os.Remove(r.path); err != nil {
+		return fmt.Errorf("failed to rotate log file: %w", err)
+	}
+
+	return r.open()
+}
+
+func (r *rotatingFile) backupPath(n int) string {
+	return fmt.Sprintf("%s.%d", r.path, n)
+}
+
+// Sync flushes the file to disk
+func (r *rotatingFile) Sync() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.file == nil {
+		return nil
+	}
+	return r.file.Sync()
+}
+
+// Close closes the underlying file
+func (r *rotatingFile) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.file == nil {
+		return nil
+	}
+	err := r.file.Close()
+	r.file = nil
+	return err
+}
+
+// LogFiles returns the existing log files for the named logger in logDir,
+// oldest rotated backup first and the active file last
+func LogFiles(logDir, name string) []string {
+	active := filepath.Join(logDir, name+".log")
+	var files []string
+	for i := DefaultMaxLogBackups; i >= 1; i-- {
+		backup := fmt.Sprintf("%s.%d", active, i)
+		if _, err := os.Stat(backup); err == nil {
+			files = append(files, backup)
+		}
+	}
+	if _, err := os.Stat(active); err == nil {
+		files = append(files, active)
+	}
+	return files
+}
@@ -0,0 +1,234 @@
+package logger
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/rs/zerolog"
+)
+
+// Format selects how console log messages are rendered
+type Format string
+
+const (
+	// FormatText prints human-oriented messages
+	FormatText Format = "text"
+	// FormatJSON prints one JSON object per message on stderr
+	FormatJSON Format = "json"
+)
+
+// ParseFormat parses a --log-format value
+func ParseFormat(format string) (Format, error) {
+	switch Format(format) {
+	case FormatText, FormatJSON:
+		return Format(format), nil
+	default:
+		return "", fmt.Errorf("invalid log format %q: must be text or json", format)
+	}
+}
+
+// entry is a single log message on its way to the file and console
+type entry struct {
+	level   LogLevel
+	msg     string
+	success bool
+	err     error
+}
+
+// field is a key and value of a logger's scope
+type field struct {
+	key, value string
+}
+
+// With returns a child logger whose entries carry key=value besides the
+// scope of l, such as component=core or op=backup. The log file and JSON
+// console get the field; text console messages are prefixed with the
+// values of the scope, as in "[backup/core] Copying files". Setting
+// key again replaces its value. The child writes to the outputs of l but
+// starts with a copy of its levels, so setting the level of either one
+// leaves the other as it was.
+func (l *UnifiedLogger) With(key, value string) Logger {
+	l.mu.Lock()
+	settings := l.loggerSettings
+	l.mu.Unlock()
+
+	fields := make([]field, 0, len(l.fields)+1)
+	replaced := false
+	for _, f := range l.fields {
+		if f.key == key {
+			f.value, replaced = value, true
+		}
+		fields = append(fields, f)
+	}
+	if !replaced {
+		fields = append(fields, field{key, value})
+	}
+
+	values := make([]string, len(fields))
+	for i, f := range fields {
+		values[i] = f.value
+	}
+	return &UnifiedLogger{
+		loggerState:    l.loggerState,
+		loggerSettings: settings,
+		fields:         fields,
+		prefix:         "[" + strings.Join(values, "/") + "] ",
+	}
+}
+
+// With returns a child of the global logger scoped with key=value
+func With(key, value string) Logger {
+	return GetLogger().With(key, value)
+}
+
+// NewCorrelationID returns a short random identifier that ties together the
+// log lines written by one command invocation
+func NewCorrelationID() string {
+	b := make([]byte, 6)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("%x", os.Getpid())
+	}
+	return hex.EncodeToString(b)
+}
+
+// StartCommand tags every following message of the global logger with the
+// command name and a fresh correlation ID, which it returns
+func StartCommand(command string) string {
+	id := NewCorrelationID()
+	if l, ok := GetLogger().(*UnifiedLogger); ok {
+		l.SetContext(command, id)
+	}
+	return id
+}
+
+// CurrentCorrelationID returns the global logger's correlation ID
+func CurrentCorrelationID() string {
+	if l, ok := GetLogger().(*UnifiedLogger); ok {
+		return l.CorrelationID()
+	}
+	return ""
+}
+
+// SetFormat switches the global logger's console format
+func SetFormat(format Format) {
+	if l, ok := GetLogger().(*UnifiedLogger); ok {
+		l.SetFormat(format)
+	}
+}
+
+// SetFileRedactor sets a function applied to every entry before it is
+// written to the log file, such as one hiding personal information. The
+// console output is left as it is.
+func (l *UnifiedLogger) SetFileRedactor(redact func([]byte) []byte) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.fileRedact = redact
+	if l.logFile != nil {
+		l.logFile.setRedact(redact)
+	}
+}
+
+// SetFileRedactor sets the global logger's file redactor
+func SetFileRedactor(redact func([]byte) []byte) {
+	if l, ok := GetLogger().(*UnifiedLogger); ok {
+		l.SetFileRedactor(redact)
+	}
+}
+
+// SetContext sets the command name and correlation ID attached to entries
+func (l *UnifiedLogger) SetContext(command, correlationID string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.command = command
+	l.correlationID = correlationID
+}
+
+// CorrelationID returns the correlation ID of the current command, if any
+func (l *UnifiedLogger) CorrelationID() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.correlationID
+}
+
+// SetFormat switches console output between text and JSON
+func (l *UnifiedLogger) SetFormat(format Format) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.format = format
+}
+
+// newJSONLogger builds a zerolog logger writing JSON lines to w
+func (l *UnifiedLogger) newJSONLogger(w io.Writer) zerolog.Logger {
+	return zerolog.New(w).With().Timestamp().Str("logger", l.name).Logger()
+}
+
+// write renders e as a JSON line on lg
+func (l *UnifiedLogger) write(lg zerolog.Logger, e entry) {
+	level := e.level.ToZerologLevel()
+	if e.success {
+		level = zerolog.InfoLevel
+	}
+	event := lg.WithLevel(level)
+	if l.command != "" {
+		event = event.Str("command", l.command)
+	}
+	if l.correlationID != "" {
+		event = event.Str("cid", l.correlationID)
+	}
+	for _, f := range l.fields {
+		event = event.Str(f.key, f.value)
+	}
+	if e.level == CriticalLevel {
+		event = event.Bool("critical", true)
+	}
+	if e.success {
+		event = event.Str("type", "SUCCESS")
+	}
+	if e.err != nil {
+		event = event.Err(e.err)
+	}
+	event.Msg(e.msg)
+}
+
+// dispatch records e in the log file and, if the console level allows it,
+// prints it either as a JSON line or through the text printer. It holds the
+// lock throughout, so text must not call back into the logger.
+func (l *UnifiedLogger) dispatch(e entry, text func()) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.logCounts[e.level.countKey()]++
+
+	if l.logFile != nil && e.level >= l.fileLevel {
+		l.write(l.fileLogger, e)
+	}
+	if e.level < l.consoleLevel {
+		return
+	}
+	if l.format == FormatJSON {
+		if !l.quiet || e.level >= ErrorLevel {
+			l.write(l.jsonConsole, e)
+		}
+		return
+	}
+	text()
+}
+
+// countKey maps a level to its statistics counter
+func (l LogLevel) countKey() string {
+	switch l {
+	case DebugLevel:
+		return "debug"
+	case WarnLevel:
+		return "warning"
+	case ErrorLevel:
+		return "error"
+	case CriticalLevel:
+		return "critical"
+	default:
+		return "info"
+	}
+}
@@ -0,0 +1,200 @@
+package logger
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func readLogLines(t *testing.T, path string) []map[string]interface{} {
+	t.Helper()
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Failed to open log: %v", err)
+	}
+	defer file.Close()
+
+	var lines []map[string]interface{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var line map[string]interface{}
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			t.Fatalf("Invalid JSON log line %q: %v", scanner.Text(), err)
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func TestFileLoggingRecordsLevelsAndCorrelationID(t *testing.T) {
+	dir := t.TempDir()
+	l := NewNamedLogger("test").(*UnifiedLogger)
+	l.SetQuiet(true)
+	l.SetLevel(ErrorLevel)
+	if err := l.InitializeFileLogging(dir); err != nil {
+		t.Fatalf("InitializeFileLogging failed: %v", err)
+	}
+	defer l.logFile.Close()
+	l.SetContext("crew install", "abc123")
+
+	l.Debug("debug detail")
+	l.Warnf("warn %d", 1)
+	l.Success("done")
+
+	lines := readLogLines(t, filepath.Join(dir, "test.log"))
+	if len(lines) != 3 {
+		t.Fatalf("Expected 3 log lines below the console level, got %d", len(lines))
+	}
+	if lines[0]["level"] != "debug" || lines[1]["level"] != "warn" || lines[1]["message"] != "warn 1" {
+		t.Errorf("Unexpected entries: %v", lines)
+	}
+	if lines[2]["type"] != "SUCCESS" {
+		t.Errorf("Expected success marker, got %v", lines[2])
+	}
+	for _, line := range lines {
+		if line["cid"] != "abc123" || line["command"] != "crew install" {
+			t.Errorf("Expected correlation fields, got %v", line)
+		}
+	}
+}
+
+func TestFileRedactor(t *testing.T) {
+	dir := t.TempDir()
+	l := NewNamedLogger("test").(*UnifiedLogger)
+	l.SetQuiet(true)
+	l.SetFileRedactor(func(p []byte) []byte {
+		return []byte(strings.ReplaceAll(string(p), "/home/alice", "~"))
+	})
+	if err := l.InitializeFileLogging(dir); err != nil {
+		t.Fatalf("InitializeFileLogging failed: %v", err)
+	}
+	defer l.logFile.Close()
+
+	l.Info("installed to /home/alice/.claude")
+	l.SetFileRedactor(nil)
+	l.Info("installed to /home/alice/.claude")
+
+	lines := readLogLines(t, filepath.Join(dir, "test.log"))
+	if len(lines) != 2 || lines[0]["message"] != "installed to ~/.claude" || lines[1]["message"] != "installed to /home/alice/.claude" {
+		t.Errorf("Unexpected entries: %v", lines)
+	}
+}
+
+func TestWithScopesConcurrentEntries(t *testing.T) {
+	dir := t.TempDir()
+	l := NewNamedLogger("test").(*UnifiedLogger)
+	l.SetQuiet(true)
+	if err := l.InitializeFileLogging(dir); err != nil {
+		t.Fatalf("InitializeFileLogging failed: %v", err)
+	}
+	defer l.logFile.Close()
+
+	backup := l.With("op", "backup")
+	var wg sync.WaitGroup
+	for _, component := range []string{"core", "commands", "hooks"} {
+		scoped := backup.With("component", component)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				scoped.Infof("file %d", i)
+			}
+		}()
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 50; i++ {
+			l.SetVerbose(i%2 == 0)
+		}
+	}()
+	wg.Wait()
+
+	lines := readLogLines(t, filepath.Join(dir, "test.log"))
+	if len(lines) != 150 {
+		t.Fatalf("Expected 150 log lines, got %d", len(lines))
+	}
+	perComponent := map[interface{}]int{}
+	for _, line := range lines {
+		if line["op"] != "backup" {
+			t.Fatalf("Expected op=backup on every entry, got %v", line)
+		}
+		perComponent[line["component"]]++
+	}
+	if perComponent["core"] != 50 || perComponent["commands"] != 50 || perComponent["hooks"] != 50 {
+		t.Errorf("Unexpected entries per component: %v", perComponent)
+	}
+
+	if fields := backup.(*UnifiedLogger).fields; len(fields) != 1 {
+		t.Errorf("With changed the parent scope: %v", fields)
+	}
+	if got := backup.With("op", "restore").(*UnifiedLogger).prefix; got != "[restore] " {
+		t.Errorf("Expected With to replace op, got prefix %q", got)
+	}
+}
+
+func TestWithCopiesLevels(t *testing.T) {
+	dir := t.TempDir()
+	l := NewNamedLogger("test").(*UnifiedLogger)
+	l.SetQuiet(true)
+	l.SetFileLevel(WarnLevel)
+	if err := l.InitializeFileLogging(dir); err != nil {
+		t.Fatalf("InitializeFileLogging failed: %v", err)
+	}
+	defer l.logFile.Close()
+
+	child := l.With("component", "core")
+	child.SetFileLevel(DebugLevel)
+	l.SetFileLevel(ErrorLevel)
+
+	child.Info("child info")
+	l.Warn("parent warn")
+	l.Error("parent error")
+
+	lines := readLogLines(t, filepath.Join(dir, "test.log"))
+	if len(lines) != 2 || lines[0]["message"] != "child info" || lines[1]["message"] != "parent error" {
+		t.Errorf("Expected each logger to keep its own level, got %v", lines)
+	}
+	if grandchild := child.With("op", "copy").(*UnifiedLogger); grandchild.fileLevel != DebugLevel {
+		t.Errorf("Expected the child's level to be copied, got %v", grandchild.fileLevel)
+	}
+}
+
+func TestRotatingFileRotatesBySize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "crew.log")
+	r, err := openRotatingFile(path, 10, 2)
+	if err != nil {
+		t.Fatalf("openRotatingFile failed: %v", err)
+	}
+	defer r.Close()
+
+	for _, chunk := range []string{"aaaaaaaa\n", "bbbbbbbb\n", "cccccccc\n", "dddddddd\n"} {
+		if _, err := r.Write([]byte(chunk)); err != nil {
+			t.Fatalf("Write failed: %v", err)
+		}
+	}
+
+	expected := map[string]string{path: "dddddddd\n", path + ".1": "cccccccc\n", path + ".2": "bbbbbbbb\n"}
+	for file, content := range expected {
+		data, err := os.ReadFile(file)
+		if err != nil || string(data) != content {
+			t.Errorf("Expected %s to contain %q, got %q (%v)", file, content, data, err)
+		}
+	}
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Error("Expected only two backups to be kept")
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	if f, err := ParseFormat("json"); err != nil || f != FormatJSON {
+		t.Errorf("Expected json format, got %q (%v)", f, err)
+	}
+	if _, err := ParseFormat("xml"); err == nil || !strings.Contains(err.Error(), "xml") {
+		t.Errorf("Expected an error naming the invalid format, got %v", err)
+	}
+}
@@ -0,0 +1,62 @@
+package logger
+
+import (
+	"fmt"
+	"sync"
+)
+
+// consoleStyle controls how plain console messages are decorated
+var consoleStyle = struct {
+	sync.RWMutex
+	color bool
+	ascii bool
+}{color: true}
+
+// SetConsoleStyle configures whether console messages use ANSI colors and
+// whether emoji markers are replaced with plain ASCII tags
+func SetConsoleStyle(color, ascii bool) {
+	consoleStyle.Lock()
+	consoleStyle.color = color
+	consoleStyle.ascii = ascii
+	consoleStyle.Unlock()
+
+	if l, ok := GetLogger().(*UnifiedLogger); ok {
+		l.setNoColor(!color)
+	}
+}
+
+// styled wraps msg with an ANSI color code and a marker, honoring the
+// configured console style
+func styled(code, icon, asciiIcon, msg string) string {
+	consoleStyle.RLock()
+	defer consoleStyle.RUnlock()
+
+	if consoleStyle.ascii {
+		icon = asciiIcon
+	}
+	if !consoleStyle.color {
+		return fmt.Sprintf("%s %s", icon, msg)
+	}
+	return fmt.Sprintf("\033[%sm%s %s\033[0m", code, icon, msg)
+}
+
+// levelLabel returns the padded, optionally colored level column used by
+// the console writer
+func levelLabel(code, label string) string {
+	consoleStyle.RLock()
+	defer consoleStyle.RUnlock()
+
+	if !consoleStyle.color {
+		return label
+	}
+	return fmt.Sprintf("\033[%sm%s\033[0m", code, label)
+}
+
+// setNoColor toggles ANSI output on the console writer and reattaches it
+func (l *UnifiedLogger) setNoColor(noColor bool) {
+	l.mu.Lock()
+	l.console.NoColor = noColor
+	quiet := l.quiet
+	l.mu.Unlock()
+	l.SetQuiet(quiet)
+}